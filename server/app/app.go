@@ -38,7 +38,10 @@ import (
 	"server/internal/sourcing"
 	"server/internal/storage"
 	"server/internal/storage/scanner"
+	"server/internal/utils/hash"
+	"server/internal/utils/imageguard"
 	"server/internal/utils/imaging"
+	"server/internal/utils/urlfetch"
 	"server/internal/version"
 
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -128,6 +131,10 @@ func run(ctx context.Context, appConfig config.AppConfig, dbConfig config.Databa
 	// thread pool disabled; outer parallelism is governed by River worker counts.
 	imaging.StartVips()
 	defer imaging.ShutdownVips()
+	hash.SetReadOptions(hash.ReadOptions{
+		BufferSize: appConfig.StorageConfig.HashBufferBytes,
+		ReadAhead:  appConfig.StorageConfig.HashReadAhead,
+	})
 
 	// Ensure the default media root and explicitly separate private cloud/backup
 	// directories exist before any service reads them.
@@ -160,6 +167,19 @@ func run(ctx context.Context, appConfig config.AppConfig, dbConfig config.Databa
 	defer database.Close()
 	pgxPool := database.Pool
 	queries := database.Queries
+	startClockSkewCheck(ctx, pgxPool, dbConfig, appLogger.Named("clock"))
+
+	// Per-asset job locks hold a connection for the whole job, so they get a
+	// pool of their own rather than starving queries.
+	var assetLocks queue.AssetLocker
+	if dbConfig.AssetLockConnections > 0 {
+		lockPool, err := db.NewLockPool(dbConfig, dbConfig.AssetLockConnections)
+		if err != nil {
+			return fmt.Errorf("connect asset lock pool: %w", err)
+		}
+		defer lockPool.Close()
+		assetLocks = queue.NewAdvisoryAssetLocks(lockPool)
+	}
 
 	settingsService := service.NewSettingsService(queries, settings.Default(appConfig.Environment), appConfig.Auth.SecretKeyFile)
 	if err := settingsService.EnsureInitialized(ctx); err != nil {
@@ -212,6 +232,9 @@ func run(ctx context.Context, appConfig config.AppConfig, dbConfig config.Databa
 	if err := repoManager.ReconcileAll(ctx); err != nil {
 		appLogger.Warn("failed to reconcile repositories", zap.Error(err))
 	}
+	if err := gatePrimaryRepository(ctx, bootstrapService, queries, appConfig.StorageConfig.RequirePrimaryRepository, appLogger); err != nil {
+		return err
+	}
 	if controls.RepositoryManagerReady != nil {
 		controls.RepositoryManagerReady(newRepositoryControl(repoManager))
 		defer controls.RepositoryManagerReady(nil)
@@ -224,7 +247,7 @@ func run(ctx context.Context, appConfig config.AppConfig, dbConfig config.Databa
 	}
 	faceService := service.NewFaceService(queries, repoManager, pgxPool)
 
-	lumenService, embeddingService, classifierService, err := initMLServices(ctx, appConfig, pgxPool, queries, workers, appLogger, lumenLogger, settingsService, faceService)
+	lumenService, embeddingService, classifierService, err := initMLServices(ctx, appConfig, pgxPool, queries, workers, assetLocks, appLogger, lumenLogger, settingsService, faceService)
 	if err != nil {
 		return fmt.Errorf("initialize ML services: %w", err)
 	}
@@ -237,7 +260,12 @@ func run(ctx context.Context, appConfig config.AppConfig, dbConfig config.Databa
 		}
 	}()
 
-	assetService, err := service.NewAssetService(queries, pgxPool, lumenService, embeddingService, appLogger.Named("asset_service"))
+	assetService, err := service.NewAssetService(queries, pgxPool, lumenService, embeddingService, appConfig.ServerConfig.AlbumCoverOnAssetDelete == "reassign", appConfig.StorageConfig.DedupeThumbnails, service.SearchFusionWeights{
+		Semantic: appConfig.Search.SemanticWeight,
+		OCR:      appConfig.Search.OCRWeight,
+		Place:    appConfig.Search.PlaceWeight,
+		Filename: appConfig.Search.FilenameWeight,
+	}, appLogger.Named("asset_service"))
 	if err != nil {
 		return fmt.Errorf("initialize asset service: %w", err)
 	}
@@ -250,7 +278,7 @@ func run(ctx context.Context, appConfig config.AppConfig, dbConfig config.Databa
 	if err != nil {
 		return fmt.Errorf("initialize auth service: %w", err)
 	}
-	albumService := service.NewAlbumService(queries)
+	albumService := service.NewAlbumService(queries, pgxPool)
 	userService := service.NewUserService(queries, pgxPool)
 
 	// Break-glass recovery is an explicit single-run host control, separate from
@@ -280,17 +308,23 @@ func run(ctx context.Context, appConfig config.AppConfig, dbConfig config.Databa
 	// Initialize SourceMaterializer (unified ingest entry point for upload, scan, cloud sync)
 	sourceMaterializer := sourcing.NewSourceMaterializer(queries, stagingManager, queueClient, assetService, processorLogger, repoAuditProvider)
 
-	assetProcessor := processors.NewAssetProcessor(assetService, queries, repoManager, stagingManager, sourceMaterializer, queueClient, settingsService, embeddingService, lumenService, appConfig.Transcode, appConfig.Tools, processorLogger, repoAuditProvider)
-	repositoryScanner := scanner.NewScanner(queries, queueClient, appConfig.RepositoryScan, scannerLogger)
-	river.AddWorker[queue.IngestAssetArgs](workers, &queue.IngestAssetWorker{Processor: assetProcessor})
-	river.AddWorker[queue.DiscoverAssetArgs](workers, &queue.DiscoverAssetWorker{ProcessDiscover: assetProcessor.ProcessDiscoveredAsset})
-	river.AddWorker[queue.MetadataArgs](workers, &queue.MetadataWorker{Process: assetProcessor.ProcessMetadataTask})
-	river.AddWorker[queue.ThumbnailArgs](workers, &queue.ThumbnailWorker{Process: assetProcessor.ProcessThumbnailTask})
-	river.AddWorker[queue.TranscodeArgs](workers, &queue.TranscodeWorker{Process: assetProcessor.ProcessTranscodeTask})
-	river.AddWorker[queue.AssetRetryArgs](workers, &queue.AssetRetryWorker{ProcessRetry: assetProcessor.ProcessRetryTask})
+	assetProcessor := processors.NewAssetProcessor(assetService, queries, repoManager, stagingManager, sourceMaterializer, queueClient, settingsService, embeddingService, lumenService, appConfig.Transcode, appConfig.Tools, appConfig.RepositoryScan, processorLogger, repoAuditProvider, assetLocks)
+	repositoryScanner := scanner.NewScanner(queries, queueClient, appConfig.RepositoryScan, appConfig.StorageConfig.MinFileSizeBytes, scannerLogger)
+	processingPause := service.NewProcessingPauseService(queries)
+	river.AddWorker[queue.IngestAssetArgs](workers, &queue.IngestAssetWorker{Processor: assetProcessor, Gate: processingPause})
+	river.AddWorker[queue.DiscoverAssetArgs](workers, &queue.DiscoverAssetWorker{ProcessDiscover: assetProcessor.ProcessDiscoveredAsset, Gate: processingPause})
+	river.AddWorker[queue.MetadataArgs](workers, &queue.MetadataWorker{Process: assetProcessor.ProcessMetadataTask, Locks: assetLocks})
+	river.AddWorker[queue.ThumbnailArgs](workers, &queue.ThumbnailWorker{Process: assetProcessor.ProcessThumbnailTask, Locks: assetLocks})
+	river.AddWorker[queue.TranscodeArgs](workers, &queue.TranscodeWorker{Process: assetProcessor.ProcessTranscodeTask, Locks: assetLocks})
+	river.AddWorker[queue.AssetRetryArgs](workers, &queue.AssetRetryWorker{ProcessRetry: assetProcessor.ProcessRetryTask, Locks: assetLocks})
 	river.AddWorker[queue.ReindexAssetsArgs](workers, &queue.ReindexAssetsWorker{IndexingService: indexingService})
 	river.AddWorker[queue.RebuildLocationClustersArgs](workers, &queue.RebuildLocationClustersWorker{LocationService: locationService})
 	river.AddWorker[queue.ScanRepositoryArgs](workers, &queue.ScanRepositoryWorker{ProcessScan: repositoryScanner.ProcessScanRepository})
+	river.AddWorker[queue.CheckRepositorySizesArgs](workers, &queue.CheckRepositorySizesWorker{ProcessCheck: repositoryScanner.ProcessCheckRepositorySizes})
+	river.AddWorker[queue.ProcessPendingAssetsArgs](workers, &queue.ProcessPendingAssetsWorker{ProcessPending: assetProcessor.ProcessPendingAssets})
+	river.AddWorker[queue.ArchiveOriginalArgs](workers, &queue.ArchiveOriginalWorker{Archive: assetProcessor.ProcessArchiveOriginal, Locks: assetLocks})
+	river.AddWorker[queue.WriteRatingArgs](workers, &queue.WriteRatingWorker{Write: assetProcessor.ProcessWriteRating, Locks: assetLocks})
+	river.AddWorker[queue.EvictWebDerivativesArgs](workers, &queue.EvictWebDerivativesWorker{Evict: assetProcessor.ProcessEvictWebDerivatives})
 	river.AddWorker[queue.DetectStacksArgs](workers, &queue.DetectStacksWorker{StackService: stackService})
 	river.AddWorker[queue.LivePhotoMatchArgs](workers, &queue.LivePhotoMatchWorker{StackService: stackService})
 	river.AddWorker[queue.ProcessPHashArgs](workers, &queue.ProcessPHashWorker{
@@ -300,6 +334,19 @@ func run(ctx context.Context, appConfig config.AppConfig, dbConfig config.Databa
 	river.AddWorker[queue.ScheduleRepositoryScansArgs](workers, &queue.ScheduleRepositoryScansWorker{
 		EnqueueAll: repositoryScanner.EnqueueAllPeriodicScans,
 	})
+	river.AddWorker[queue.RetryTaggingArgs](workers, &queue.RetryTaggingWorker{
+		Store:          queries,
+		Queue:          queueClient,
+		LumenService:   lumenService,
+		ConfigProvider: settingsService,
+		MaxAttempts:    appConfig.Lumen.TagRetryMaxAttempts,
+		Grace:          appConfig.Lumen.TagRetryInterval,
+	})
+	river.AddWorker[queue.CleanupAITagsArgs](workers, &queue.CleanupAITagsWorker{
+		Cleanup:       assetService.CleanupAITags,
+		MinConfidence: appConfig.Lumen.TagCleanupMinConfidence,
+		RemoveOrphans: appConfig.Lumen.TagCleanupRemoveOrphans,
+	})
 
 	// Automatic database backups use their explicit private destination rather
 	// than following any removable repository root. Policy
@@ -382,6 +429,42 @@ func run(ctx context.Context, appConfig config.AppConfig, dbConfig config.Databa
 		))
 	}
 
+	// Re-queue tagging for photos that never got an embedding (usually because
+	// Lumen was down at ingest). The worker idles while Lumen is unavailable.
+	if appConfig.Lumen.TagRetryMaxAttempts > 0 {
+		queueClient.PeriodicJobs().Add(river.NewPeriodicJob(
+			river.PeriodicInterval(appConfig.Lumen.TagRetryInterval),
+			func() (river.JobArgs, *river.InsertOpts) {
+				return jobs.RetryTaggingArgs{}, nil
+			},
+			&river.PeriodicJobOpts{ID: "retry_tagging"},
+		))
+	}
+
+	// Drop AI tag links that fell below the configured confidence, the same
+	// cleanup POST /admin/tags/cleanup runs on demand.
+	if appConfig.Lumen.TagCleanupMinConfidence > 0 {
+		queueClient.PeriodicJobs().Add(river.NewPeriodicJob(
+			river.PeriodicInterval(appConfig.Lumen.TagCleanupInterval),
+			func() (river.JobArgs, *river.InsertOpts) {
+				return jobs.CleanupAITagsArgs{}, nil
+			},
+			&river.PeriodicJobOpts{ID: "cleanup_ai_tags"},
+		))
+	}
+
+	// Sweep web derivatives nobody played within the retention window; they
+	// are transcoded again when next requested.
+	if appConfig.Transcode.WebDerivativeRetention > 0 {
+		queueClient.PeriodicJobs().Add(river.NewPeriodicJob(
+			river.PeriodicInterval(6*time.Hour),
+			func() (river.JobArgs, *river.InsertOpts) {
+				return jobs.EvictWebDerivativesArgs{}, nil
+			},
+			&river.PeriodicJobOpts{ID: "evict_web_derivatives"},
+		))
+	}
+
 	// Hourly database-backup heartbeat. RunOnStart also gives a fresh install
 	// its first dump immediately; the worker's due-check (interval from
 	// runtime settings, storage reachability) turns superfluous ticks into
@@ -394,17 +477,35 @@ func run(ctx context.Context, appConfig config.AppConfig, dbConfig config.Databa
 		&river.PeriodicJobOpts{ID: "database_backup", RunOnStart: true},
 	))
 
+	var ratingWriter handler.AssetRatingWriter
+	if appConfig.StorageConfig.WriteRatingToExif {
+		ratingWriter = assetProcessor
+	}
+
 	// Initialize controllers with new storage system
-	assetController := handler.NewAssetHandler(assetService, authService, indexingService, stackService, queries, repoManager, stagingManager, queueClient, settingsService, lumenService)
+	assetController := handler.NewAssetHandler(assetService, authService, indexingService, stackService, queries, repoManager, stagingManager, queueClient, settingsService, lumenService, handler.AssetHandlerRuntime{
+		Processor:         assetProcessor,
+		RatingWriter:      ratingWriter,
+		MinFileSize:       appConfig.StorageConfig.MinFileSizeBytes,
+		MaxBatchFiles:     appConfig.StorageConfig.MaxBatchFiles,
+		MaxDownloadBytes:  appConfig.StorageConfig.MaxDownloadBytes,
+		MaxUploadsPerUser: appConfig.StorageConfig.MaxConcurrentUploadsPerUser,
+		ImageLimits: imageguard.Limits{
+			MaxDimension:  appConfig.ServerConfig.ImageOpMaxDimension,
+			MaxMegapixels: appConfig.ServerConfig.ImageOpMaxMegapixels,
+			Timeout:       appConfig.ServerConfig.ImageOpTimeout,
+		},
+		URLFetcher: urlfetch.New(appConfig.StorageConfig.URLImportMaxBytes, appConfig.StorageConfig.URLImportAllowedHosts),
+	})
 	assetController.StartCleanupTasks(ctx)
 	authController := handler.NewAuthHandler(authService)
 	setupController := handler.NewSetupHandler(service.NewSetupServiceWithPool(dbConfig, pgxPool, bootstrapService, repoManager, appConfig.StorageConfig.Path))
-	albumController := handler.NewAlbumHandler(&albumService, queries, queueClient, settingsService, lumenService)
+	albumController := handler.NewAlbumHandler(&albumService, assetService, shareLinkService, queries, queueClient, settingsService, lumenService, appConfig.ServerConfig.AlbumCoverMustBeMember)
 	peopleController := handler.NewPeopleHandler(assetService, faceService, authService, repoManager)
 	locationController := handler.NewLocationHandler(locationService, queueClient)
 	speciesController := handler.NewSpeciesHandler(speciesReferenceService)
 	userController := handler.NewUserHandler(userService, securityLogger)
-	queueController := handler.NewQueueHandler(pgxPool)
+	queueController := handler.NewQueueHandler(pgxPool, queueClient, queue.QueueConcurrency(), processingPause)
 	statsController := handler.NewStatsHandler(queries)
 	agentController := handler.NewAgentHandler(agentService, refStore, queries, agentPins, assetService)
 	capabilitiesController := handler.NewCapabilitiesHandler(settingsService, lumenService)
@@ -422,10 +523,13 @@ func run(ctx context.Context, appConfig config.AppConfig, dbConfig config.Databa
 	if err := repositoryScanner.ReclaimInterruptedRuns(ctx); err != nil {
 		appLogger.Warn("failed to reclaim interrupted repository scan runs", zap.Error(err))
 	}
+	startRepositoryMonitor(ctx, appConfig.RepositoryScan, appConfig.StorageConfig.MinFileSizeBytes, queueClient, queries, repoManager, scannerLogger)
 	cloudController := handler.NewCloudHandler(cloudSyncService)
 	repositoryScanController := handler.NewRepositoryScanHandler(repositoryScanner, repoManager, cloudSyncService)
 	duplicateController := handler.NewDuplicateHandler(duplicateService, queries)
-	shareLinkController := handler.NewShareLinkHandler(shareLinkService, assetService, queries)
+	shareLinkController := handler.NewShareLinkHandler(shareLinkService, assetService, queries, appConfig.StorageConfig.MaxDownloadBytes)
+	activityController := handler.NewActivityHandler(queries)
+	healthController := handler.NewHealthHandler(bootstrapService, queries, repositoryHealthCheck(repoManager, appConfig.RepositoryScan))
 
 	// Initialize Swagger docs
 	docs.SwaggerInfo.Title = "Lumilio-Photos API"
@@ -447,6 +551,7 @@ func run(ctx context.Context, appConfig config.AppConfig, dbConfig config.Databa
 		statsController,
 		agentController,
 		capabilitiesController,
+		healthController,
 		settingsController,
 		classifierController,
 		userController,
@@ -454,6 +559,7 @@ func run(ctx context.Context, appConfig config.AppConfig, dbConfig config.Databa
 		duplicateController,
 		cloudController,
 		shareLinkController,
+		activityController,
 		handler.RequireLLMAgentEnabled(settingsService),
 		handler.RequireAppInitialized(bootstrapService),
 		appConfig.ServerConfig.CORSAllowedOrigins,
@@ -463,14 +569,14 @@ func run(ctx context.Context, appConfig config.AppConfig, dbConfig config.Databa
 	// Add Swagger documentation endpoint
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
+	// Readiness sits beside /swagger at the root so probes need no API prefix.
+	router.GET("/readyz", healthController.Ready)
+
 	// Optionally serve the SPA bundle (desktop sets server.web_root; docker/web
 	// leave it empty and serve the bundle from a separate static server).
 	api.RegisterSPA(router, appConfig.ServerConfig.WebRoot)
 
-	srv := &http.Server{
-		Addr:    ":" + appConfig.ServerConfig.Port,
-		Handler: router,
-	}
+	srv := newHTTPServer(appConfig.ServerConfig, router)
 
 	appLogger.Info("server starting",
 		zap.String("operation", "server.listen"),
@@ -510,6 +616,8 @@ func run(ctx context.Context, appConfig config.AppConfig, dbConfig config.Databa
 	// lumen, database, libvips, logger) are released by the deferred cleanups.
 	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), shutdownTimeout)
 	defer shutdownCancel()
+	// End scan status streams first; the server waits on open connections.
+	repositoryScanner.CloseScanEvents()
 	if err := srv.Shutdown(shutdownCtx); err != nil {
 		appLogger.Warn("http server shutdown error", zap.String("operation", "server.shutdown"), zap.Error(err))
 	}
@@ -520,12 +628,26 @@ func run(ctx context.Context, appConfig config.AppConfig, dbConfig config.Databa
 	return nil
 }
 
+// newHTTPServer applies the manifest's connection timeouts. Routes that
+// stream large bodies lift the read/write deadlines per request.
+func newHTTPServer(cfg config.ServerConfig, handler http.Handler) *http.Server {
+	return &http.Server{
+		Addr:              ":" + cfg.Port,
+		Handler:           handler,
+		ReadHeaderTimeout: cfg.ReadHeaderTimeout,
+		ReadTimeout:       cfg.ReadTimeout,
+		WriteTimeout:      cfg.WriteTimeout,
+		IdleTimeout:       cfg.IdleTimeout,
+	}
+}
+
 func initMLServices(
 	ctx context.Context,
 	appConfig config.AppConfig,
 	pgxPool *pgxpool.Pool,
 	queries *repo.Queries,
 	workers *river.Workers,
+	assetLocks queue.AssetLocker,
 	appLogger *zap.Logger,
 	lumenLogger *zap.Logger,
 	settingsService service.SettingsService,
@@ -548,6 +670,7 @@ func initMLServices(
 	)
 
 	embeddingService := service.NewEmbeddingService(queries, pgxPool)
+	service.LogSemanticDimensionCheck(ctx, queries, appLogger.Named("embedding"))
 	speciesService := service.NewSpeciesService(queries)
 	ocrService := service.NewOCRService(queries, pgxPool)
 	imageLoader := queue.NewDBMLImageLoader(queries)
@@ -557,6 +680,7 @@ func initMLServices(
 		EmbeddingService: embeddingService,
 		ConfigProvider:   settingsService,
 		ImageLoader:      imageLoader,
+		Locks:            assetLocks,
 	})
 	appLogger.Info("semantic service and worker registered", zap.String("operation", "ml.init"))
 
@@ -565,6 +689,7 @@ func initMLServices(
 		SpeciesService: speciesService,
 		ConfigProvider: settingsService,
 		ImageLoader:    imageLoader,
+		Locks:          assetLocks,
 	})
 	appLogger.Info("BioCLIP service and worker registered", zap.String("operation", "ml.init"))
 
@@ -573,6 +698,7 @@ func initMLServices(
 		LumenService:   lumenService,
 		ConfigProvider: settingsService,
 		ImageLoader:    imageLoader,
+		Locks:          assetLocks,
 	})
 	appLogger.Info("OCR service and worker registered", zap.String("operation", "ml.init"))
 
@@ -581,6 +707,7 @@ func initMLServices(
 		LumenService:   lumenService,
 		ConfigProvider: settingsService,
 		ImageLoader:    imageLoader,
+		Locks:          assetLocks,
 	})
 	appLogger.Info("face service and worker registered", zap.String("operation", "ml.init"))
 
@@ -591,6 +718,10 @@ func initMLServices(
 		ClassifierService: classifierService,
 		AITagService:      aiTagService,
 		ConfigProvider:    settingsService,
+		TopN:              appConfig.Lumen.TagTopN,
+		MinConfidence:     appConfig.Lumen.TagMinConfidence,
+		Logger:            appLogger.Named("zeroshot"),
+		Locks:             assetLocks,
 	})
 	appLogger.Info("zero-shot classifier service and worker registered", zap.String("operation", "ml.init"))
 
@@ -0,0 +1,55 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"server/config"
+	"server/internal/db"
+
+	"go.uber.org/zap"
+)
+
+// checkClockSkew measures this process's clock against the database and logs
+// a warning when they are further apart than threshold. It reports whether
+// the skew was within threshold.
+func checkClockSkew(ctx context.Context, pool db.RowQuerier, threshold time.Duration, now func() time.Time, logger *zap.Logger) bool {
+	skew, err := db.MeasureClockSkew(ctx, pool, now)
+	if err != nil {
+		logger.Warn("failed to measure clock skew against the database", zap.Error(err))
+		return true
+	}
+	if skew.Abs() <= threshold {
+		logger.Debug("clock skew against the database within threshold",
+			zap.Duration("skew", skew),
+			zap.Duration("threshold", threshold),
+		)
+		return true
+	}
+	logger.Warn("process clock differs from the database clock; settle timers and recent-activity windows may misbehave",
+		zap.Duration("skew", skew),
+		zap.Duration("threshold", threshold),
+	)
+	return false
+}
+
+// startClockSkewCheck checks clock skew once now and then every
+// database.clock_skew_check_interval until ctx is done.
+func startClockSkewCheck(ctx context.Context, pool db.RowQuerier, cfg config.DatabaseConfig, logger *zap.Logger) {
+	checkClockSkew(ctx, pool, cfg.ClockSkewThreshold, time.Now, logger)
+	if cfg.ClockSkewCheckInterval <= 0 {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(cfg.ClockSkewCheckInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				checkClockSkew(ctx, pool, cfg.ClockSkewThreshold, time.Now, logger)
+			}
+		}
+	}()
+}
@@ -0,0 +1,50 @@
+package app
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+type fixedClockDB struct{ dbTime time.Time }
+
+func (db fixedClockDB) QueryRow(context.Context, string, ...any) pgx.Row { return db }
+
+func (db fixedClockDB) Scan(dest ...any) error {
+	*dest[0].(*time.Time) = db.dbTime
+	return nil
+}
+
+func TestCheckClockSkew_WarnsPastThreshold(t *testing.T) {
+	dbTime := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	for _, tc := range []struct {
+		name   string
+		offset time.Duration
+		ok     bool
+	}{
+		{"within threshold", 1500 * time.Millisecond, true},
+		{"process ahead", 3 * time.Second, false},
+		{"process behind", -time.Minute, false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			core, logs := observer.New(zapcore.WarnLevel)
+			now := func() time.Time { return dbTime.Add(tc.offset) }
+
+			ok := checkClockSkew(context.Background(), fixedClockDB{dbTime: dbTime}, 2*time.Second, now, zap.New(core))
+			require.Equal(t, tc.ok, ok)
+			if tc.ok {
+				require.Zero(t, logs.Len())
+				return
+			}
+			require.Equal(t, 1, logs.Len())
+			require.Equal(t, tc.offset, logs.All()[0].ContextMap()["skew"])
+		})
+	}
+}
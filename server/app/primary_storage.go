@@ -0,0 +1,52 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"server/config"
+	"server/internal/api/handler"
+	"server/internal/service"
+	"server/internal/storage"
+
+	"go.uber.org/zap"
+)
+
+// gatePrimaryRepository runs after ReconcileAll has refreshed repository
+// status. Before first-run setup there is no primary repository by design, so
+// the check only applies once the bootstrap phase is ready. With required
+// unset an unavailable primary is logged and left for /readyz to report.
+func gatePrimaryRepository(ctx context.Context, bootstrap service.BootstrapService, lookup storage.PrimaryRepositoryLookup, required bool, logger *zap.Logger) error {
+	ready, err := bootstrap.IsReady(ctx)
+	if err != nil || !ready {
+		return nil
+	}
+	if err := storage.CheckPrimaryRepository(ctx, lookup); err != nil {
+		if required {
+			return fmt.Errorf("storage.require_primary_repository is set: %w", err)
+		}
+		logger.Warn("primary repository unavailable; starting degraded",
+			zap.String("operation", "repository.primary_check"),
+			zap.Error(err))
+	}
+	return nil
+}
+
+// repositoryHealthStaleScans is how many missed periodic scan intervals make a
+// repository's sync count as stalled in a deep health check.
+const repositoryHealthStaleScans = 3
+
+// repositoryHealthCheck builds the deep /health repository check. Sync
+// staleness is only judged when periodic scans are enabled; otherwise nothing
+// is expected to refresh last_sync on its own.
+func repositoryHealthCheck(repositories storage.RepositoryLister, scans config.RepositoryScanConfig) handler.RepositoryHealthFunc {
+	var staleAfter time.Duration
+	if scans.Enabled && scans.IntervalSeconds > 0 {
+		staleAfter = repositoryHealthStaleScans * time.Duration(scans.IntervalSeconds) * time.Second
+	}
+	validator := storage.NewDirectoryManager()
+	return func(ctx context.Context) ([]storage.RepositoryHealth, error) {
+		return storage.CheckRepositoryHealth(ctx, repositories, validator, storage.RepositoryHealthOptions{SyncStaleAfter: staleAfter})
+	}
+}
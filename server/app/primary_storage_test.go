@@ -0,0 +1,54 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"server/internal/db/dbtypes"
+	"server/internal/db/repo"
+	"server/internal/service"
+	"server/internal/storage"
+
+	"go.uber.org/zap"
+)
+
+type stubBootstrap struct {
+	service.BootstrapService
+	ready bool
+}
+
+func (s stubBootstrap) IsReady(context.Context) (bool, error) { return s.ready, nil }
+
+type stubPrimaryLookup struct {
+	repository repo.Repository
+}
+
+func (s stubPrimaryLookup) GetPrimaryRepository(context.Context) (repo.Repository, error) {
+	return s.repository, nil
+}
+
+func TestGatePrimaryRepositoryFailsFastWhenRequired(t *testing.T) {
+	offline := stubPrimaryLookup{repository: repo.Repository{Path: "/mnt/photos", Status: dbtypes.RepoStatusOffline}}
+
+	err := gatePrimaryRepository(context.Background(), stubBootstrap{ready: true}, offline, true, zap.NewNop())
+	if !errors.Is(err, storage.ErrPrimaryRepositoryUnavailable) {
+		t.Fatalf("expected primary repository error, got %v", err)
+	}
+}
+
+func TestGatePrimaryRepositoryStartsDegradedWhenNotRequired(t *testing.T) {
+	offline := stubPrimaryLookup{repository: repo.Repository{Status: dbtypes.RepoStatusOffline}}
+
+	if err := gatePrimaryRepository(context.Background(), stubBootstrap{ready: true}, offline, false, zap.NewNop()); err != nil {
+		t.Fatalf("expected degraded start, got %v", err)
+	}
+}
+
+func TestGatePrimaryRepositorySkipsBeforeSetup(t *testing.T) {
+	offline := stubPrimaryLookup{repository: repo.Repository{Status: dbtypes.RepoStatusOffline}}
+
+	if err := gatePrimaryRepository(context.Background(), stubBootstrap{ready: false}, offline, true, zap.NewNop()); err != nil {
+		t.Fatalf("expected first-run setup to be allowed, got %v", err)
+	}
+}
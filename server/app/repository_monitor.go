@@ -0,0 +1,50 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"server/config"
+	"server/internal/db/dbtypes"
+	"server/internal/storage"
+	"server/internal/storage/monitor"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// startRepositoryMonitor watches the registered repositories with the backend
+// selected by repository_scan.monitor until ctx is done. Repositories added
+// later are picked up on the next restart; until then the periodic scan
+// covers them.
+func startRepositoryMonitor(ctx context.Context, scans config.RepositoryScanConfig, minFileSize int64, queue monitor.Enqueuer, assets monitor.AssetLookup, repositories storage.RepositoryLister, logger *zap.Logger) {
+	if scans.Monitor != monitor.BackendFsnotify {
+		return
+	}
+	m, err := monitor.NewFsnotifyMonitor(queue, assets, time.Duration(scans.SettleSeconds)*time.Second, minFileSize, logger)
+	if err != nil {
+		logger.Warn("repository monitor unavailable, relying on periodic scans", zap.Error(err))
+		return
+	}
+	repos, err := repositories.ListRepositories()
+	if err != nil {
+		logger.Warn("failed to list repositories for the monitor", zap.Error(err))
+	}
+	for _, r := range repos {
+		if r.Status == dbtypes.RepoStatusOffline {
+			continue
+		}
+		id := uuid.UUID(r.RepoID.Bytes).String()
+		if err := m.AddRepository(id, r.Path); err != nil {
+			logger.Warn("failed to watch repository",
+				zap.String("repository_id", id),
+				zap.String("path", r.Path),
+				zap.Error(err),
+			)
+		}
+	}
+	go func() {
+		_ = m.Run(ctx)
+	}()
+	logger.Info("repository monitor started", zap.String("backend", scans.Monitor))
+}
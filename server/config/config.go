@@ -5,6 +5,7 @@ import (
 	"crypto/sha256"
 	"errors"
 	"fmt"
+	"math"
 	"net"
 	"net/url"
 	"os"
@@ -33,6 +34,7 @@ type AppConfig struct {
 	Geocoding      GeocodingConfig
 	Auth           AuthConfig
 	Transcode      TranscodeConfig
+	Search         SearchConfig
 	Lumen          LumenConfig
 	Tools          ToolsConfig
 	loaded         bool
@@ -52,12 +54,42 @@ type DatabaseConfig struct {
 	RotatedPasswordFile   string
 	BootstrapPassword     string
 	ToolsBinDir           string
+	// ClockSkewThreshold is how far this process's clock may drift from the
+	// database clock before a warning is logged; the check runs at startup
+	// and every ClockSkewCheckInterval.
+	ClockSkewThreshold     time.Duration
+	ClockSkewCheckInterval time.Duration
+	// AssetLockConnections is the size of the connection pool holding
+	// per-asset job locks. Jobs on the same asset run one at a time, and at
+	// most this many asset jobs run at once. Zero disables the locks.
+	AssetLockConnections int
 }
 
 type ServerConfig struct {
 	Port               string
 	CORSAllowedOrigins []string
 	WebRoot            string
+	// AlbumCoverMustBeMember rejects album covers that are not in the album.
+	// When false, any asset the album owner can access may be the cover.
+	AlbumCoverMustBeMember bool
+	// AlbumCoverOnAssetDelete is what happens to albums whose cover asset is
+	// deleted: "reassign" picks the most recently added remaining member,
+	// "clear" unsets the cover.
+	AlbumCoverOnAssetDelete string
+	// ReadHeaderTimeout, ReadTimeout, WriteTimeout and IdleTimeout bound
+	// each HTTP connection. Uploads, media streams and SSE feeds lift the
+	// read and write deadlines for their own requests.
+	ReadHeaderTimeout time.Duration
+	ReadTimeout       time.Duration
+	WriteTimeout      time.Duration
+	IdleTimeout       time.Duration
+	// ImageOpMaxDimension and ImageOpMaxMegapixels cap the source images
+	// that request-time image operations (export, on-demand thumbnails)
+	// decode; larger sources are rejected. Zero disables either cap.
+	ImageOpMaxDimension  int
+	ImageOpMaxMegapixels int
+	// ImageOpTimeout bounds one request-time image operation.
+	ImageOpTimeout time.Duration
 }
 
 type LoggingConfig struct {
@@ -78,6 +110,38 @@ type StorageConfig struct {
 	// BackupsPath is an explicit database-backup destination. Desktop binds it
 	// to local app data; standalone operators may choose another private mount.
 	BackupsPath string
+	// MinFileSizeBytes skips uploaded and discovered media smaller than this
+	// size (resource forks, cache thumbnails). Zero disables the filter.
+	MinFileSizeBytes int64
+	// MaxBatchFiles caps how many files a single batch upload request may
+	// carry; larger batches are rejected before any file is processed.
+	MaxBatchFiles int
+	// MaxConcurrentUploadsPerUser caps how many upload requests one user may
+	// have in flight; extra requests get a 429. Zero disables the limit.
+	MaxConcurrentUploadsPerUser int
+	// MaxDownloadBytes caps the total size of originals one zip download may
+	// carry; larger selections are rejected. Zero disables the limit.
+	MaxDownloadBytes int64
+	// DedupeThumbnails names thumbnail files by the hash of their own bytes,
+	// so assets whose thumbnails come out identical share one file.
+	DedupeThumbnails bool
+	// WriteRatingToExif also writes a rating set through the API into the
+	// XMP Rating tag of JPEG and TIFF originals. A background job replaces
+	// the original in place.
+	WriteRatingToExif bool
+	// HashBufferBytes is the read size used when hashing whole files.
+	HashBufferBytes int
+	// HashReadAhead reads the next buffer while the current one is hashed.
+	HashReadAhead bool
+	// RequirePrimaryRepository fails startup when setup has completed but the
+	// primary repository is missing or offline, instead of serving degraded.
+	RequirePrimaryRepository bool
+	// URLImportMaxBytes caps the size of a file pulled through
+	// POST /assets/from-url. Zero disables URL imports.
+	URLImportMaxBytes int64
+	// URLImportAllowedHosts restricts URL imports to these hosts ("host" or
+	// "*.domain"). Empty allows any public host.
+	URLImportAllowedHosts []string
 }
 
 func (c StorageConfig) CloudDir() string   { return c.CloudStatePath }
@@ -89,6 +153,10 @@ type RepositoryScanConfig struct {
 	SettleSeconds      int
 	MaxConcurrentRepos int
 	BatchSize          int
+	AutoAlbumByFolder  bool
+	// Monitor selects how repository workspaces are watched between periodic
+	// scans: "off" or "fsnotify".
+	Monitor string
 }
 
 type GeocodingConfig struct {
@@ -109,7 +177,28 @@ type AuthConfig struct {
 	WebAuthnRPOrigins []string
 }
 
-type TranscodeConfig struct{ HardwareAccel string }
+type TranscodeConfig struct {
+	HardwareAccel string
+	// MaxBitrateKbps caps the video bitrate of web transcodes. Zero scales
+	// the bitrate with the output resolution instead.
+	MaxBitrateKbps int
+	// WebDerivativeRetention evicts web videos and audio not served for this
+	// long; they are transcoded again when next requested. Zero keeps them.
+	WebDerivativeRetention time.Duration
+	// HLSEnabled additionally segments long web videos into an HLS playlist
+	// so players fetch only what they seek to, at the cost of a second copy.
+	HLSEnabled bool
+}
+
+// SearchConfig weighs the channels of hybrid search in reciprocal-rank
+// fusion: an asset's score is the sum over channels of weight / (60 + rank).
+// Raising a weight lets that channel's matches outrank the others.
+type SearchConfig struct {
+	SemanticWeight float64
+	OCRWeight      float64
+	PlaceWeight    float64
+	FilenameWeight float64
+}
 
 type LumenConfig struct {
 	DiscoveryEnabled      bool
@@ -127,6 +216,25 @@ type LumenConfig struct {
 	ChunkAuto             bool
 	ChunkThresholdBytes   int
 	ChunkMaxBytes         int
+	// TagRetryInterval is how often photos left without AI tags are
+	// re-queued for semantic tagging; TagRetryMaxAttempts bounds how many
+	// times one photo is retried before it is marked clip_failed. Zero
+	// attempts disables the retry job.
+	TagRetryInterval    time.Duration
+	TagRetryMaxAttempts int
+	// TagTopN caps how many zero-shot tags one photo keeps, highest
+	// confidence first; zero keeps every match. Matches below
+	// TagMinConfidence are dropped. A match exactly at its classifier's
+	// threshold has confidence 0.5.
+	TagTopN          int
+	TagMinConfidence float64
+	// TagCleanupMinConfidence is the threshold of the periodic AI tag
+	// cleanup: every TagCleanupInterval, AI tag links below it are removed,
+	// and with TagCleanupRemoveOrphans so are AI tags left on no asset. Zero
+	// disables the job.
+	TagCleanupMinConfidence float64
+	TagCleanupInterval      time.Duration
+	TagCleanupRemoveOrphans bool
 }
 
 func (c LumenConfig) StaticNodes() []string {
@@ -148,24 +256,37 @@ type manifest struct {
 	Geocoding      *geocodingManifest      `toml:"geocoding"`
 	Auth           *authManifest           `toml:"auth"`
 	Transcode      *transcodeManifest      `toml:"transcode"`
+	Search         *searchManifest         `toml:"search"`
 	Lumen          *lumenManifest          `toml:"lumen"`
 	Tools          *toolsManifest          `toml:"tools"`
 }
 
 type databaseManifest struct {
-	Host                  *string `toml:"host"`
-	Port                  *string `toml:"port"`
-	User                  *string `toml:"user"`
-	Name                  *string `toml:"name"`
-	SSL                   *string `toml:"ssl"`
-	BootstrapPasswordFile *string `toml:"bootstrap_password_file"`
-	RotatedPasswordFile   *string `toml:"rotated_password_file"`
-	ToolsBinDir           *string `toml:"tools_bin_dir"`
+	Host                   *string `toml:"host"`
+	Port                   *string `toml:"port"`
+	User                   *string `toml:"user"`
+	Name                   *string `toml:"name"`
+	SSL                    *string `toml:"ssl"`
+	BootstrapPasswordFile  *string `toml:"bootstrap_password_file"`
+	RotatedPasswordFile    *string `toml:"rotated_password_file"`
+	ToolsBinDir            *string `toml:"tools_bin_dir"`
+	ClockSkewThreshold     *string `toml:"clock_skew_threshold"`
+	ClockSkewCheckInterval *string `toml:"clock_skew_check_interval"`
+	AssetLockConnections   *int    `toml:"asset_lock_connections"`
 }
 type serverManifest struct {
-	Port               *string   `toml:"port"`
-	CORSAllowedOrigins *[]string `toml:"cors_allowed_origins"`
-	WebRoot            *string   `toml:"web_root"`
+	Port                    *string   `toml:"port"`
+	CORSAllowedOrigins      *[]string `toml:"cors_allowed_origins"`
+	WebRoot                 *string   `toml:"web_root"`
+	AlbumCoverMustBeMember  *bool     `toml:"album_cover_must_be_member"`
+	AlbumCoverOnAssetDelete *string   `toml:"album_cover_on_asset_delete"`
+	ReadHeaderTimeout       *string   `toml:"read_header_timeout"`
+	ReadTimeout             *string   `toml:"read_timeout"`
+	WriteTimeout            *string   `toml:"write_timeout"`
+	IdleTimeout             *string   `toml:"idle_timeout"`
+	ImageOpMaxDimension     *int      `toml:"image_op_max_dimension"`
+	ImageOpMaxMegapixels    *int      `toml:"image_op_max_megapixels"`
+	ImageOpTimeout          *string   `toml:"image_op_timeout"`
 }
 type loggingManifest struct {
 	Level                  *string `toml:"level"`
@@ -175,16 +296,29 @@ type loggingManifest struct {
 	RepositoryAuditVerbose *bool   `toml:"repository_audit_verbose"`
 }
 type storageManifest struct {
-	Path           *string `toml:"path"`
-	CloudStatePath *string `toml:"cloud_state_path"`
-	BackupsPath    *string `toml:"backups_path"`
+	Path                        *string   `toml:"path"`
+	CloudStatePath              *string   `toml:"cloud_state_path"`
+	BackupsPath                 *string   `toml:"backups_path"`
+	MinFileSizeBytes            *int64    `toml:"min_file_size_bytes"`
+	MaxBatchFiles               *int      `toml:"max_batch_files"`
+	MaxConcurrentUploadsPerUser *int      `toml:"max_concurrent_uploads_per_user"`
+	MaxDownloadBytes            *int64    `toml:"max_download_bytes"`
+	DedupeThumbnails            *bool     `toml:"dedupe_thumbnails"`
+	WriteRatingToExif           *bool     `toml:"write_rating_to_exif"`
+	HashBufferBytes             *int      `toml:"hash_buffer_bytes"`
+	HashReadAhead               *bool     `toml:"hash_read_ahead"`
+	RequirePrimaryRepository    *bool     `toml:"require_primary_repository"`
+	URLImportMaxBytes           *int64    `toml:"url_import_max_bytes"`
+	URLImportAllowedHosts       *[]string `toml:"url_import_allowed_hosts"`
 }
 type repositoryScanManifest struct {
-	Enabled            *bool `toml:"enabled"`
-	IntervalSeconds    *int  `toml:"interval_seconds"`
-	SettleSeconds      *int  `toml:"settle_seconds"`
-	MaxConcurrentRepos *int  `toml:"max_concurrent_repos"`
-	BatchSize          *int  `toml:"batch_size"`
+	Enabled            *bool   `toml:"enabled"`
+	IntervalSeconds    *int    `toml:"interval_seconds"`
+	SettleSeconds      *int    `toml:"settle_seconds"`
+	MaxConcurrentRepos *int    `toml:"max_concurrent_repos"`
+	BatchSize          *int    `toml:"batch_size"`
+	AutoAlbumByFolder  *bool   `toml:"auto_album_by_folder"`
+	Monitor            *string `toml:"monitor"`
 }
 type geocodingManifest struct {
 	Provider          *string `toml:"provider"`
@@ -203,24 +337,40 @@ type authManifest struct {
 	WebAuthnRPOrigins *[]string `toml:"webauthn_rp_origins"`
 }
 type transcodeManifest struct {
-	HardwareAccel *string `toml:"hardware_accel"`
+	HardwareAccel          *string `toml:"hardware_accel"`
+	MaxBitrateKbps         *int    `toml:"max_bitrate_kbps"`
+	WebDerivativeRetention *string `toml:"web_derivative_retention"`
+	HLSEnabled             *bool   `toml:"hls_enabled"`
+}
+type searchManifest struct {
+	SemanticWeight *float64 `toml:"semantic_weight"`
+	OCRWeight      *float64 `toml:"ocr_weight"`
+	PlaceWeight    *float64 `toml:"place_weight"`
+	FilenameWeight *float64 `toml:"filename_weight"`
 }
 type lumenManifest struct {
-	DiscoveryEnabled      *bool     `toml:"discovery_enabled"`
-	DiscoveryMDNSEnabled  *bool     `toml:"discovery_mdns_enabled"`
-	DiscoveryHubURL       *string   `toml:"discovery_hub_url"`
-	DiscoveryStaticNodes  *[]string `toml:"discovery_static_nodes"`
-	DiscoveryServiceType  *string   `toml:"discovery_service_type"`
-	DiscoveryDomain       *string   `toml:"discovery_domain"`
-	DeploymentID          *string   `toml:"deployment_id"`
-	ResolveTimeout        *string   `toml:"resolve_timeout"`
-	ConnectTimeout        *string   `toml:"connect_timeout"`
-	RediscoveryBackoffMin *string   `toml:"rediscovery_backoff_min"`
-	RediscoveryBackoffMax *string   `toml:"rediscovery_backoff_max"`
-	ScanInterval          *string   `toml:"scan_interval"`
-	ChunkAuto             *bool     `toml:"chunk_auto"`
-	ChunkThresholdBytes   *int      `toml:"chunk_threshold_bytes"`
-	ChunkMaxBytes         *int      `toml:"chunk_max_bytes"`
+	DiscoveryEnabled        *bool     `toml:"discovery_enabled"`
+	DiscoveryMDNSEnabled    *bool     `toml:"discovery_mdns_enabled"`
+	DiscoveryHubURL         *string   `toml:"discovery_hub_url"`
+	DiscoveryStaticNodes    *[]string `toml:"discovery_static_nodes"`
+	DiscoveryServiceType    *string   `toml:"discovery_service_type"`
+	DiscoveryDomain         *string   `toml:"discovery_domain"`
+	DeploymentID            *string   `toml:"deployment_id"`
+	ResolveTimeout          *string   `toml:"resolve_timeout"`
+	ConnectTimeout          *string   `toml:"connect_timeout"`
+	RediscoveryBackoffMin   *string   `toml:"rediscovery_backoff_min"`
+	RediscoveryBackoffMax   *string   `toml:"rediscovery_backoff_max"`
+	ScanInterval            *string   `toml:"scan_interval"`
+	ChunkAuto               *bool     `toml:"chunk_auto"`
+	ChunkThresholdBytes     *int      `toml:"chunk_threshold_bytes"`
+	ChunkMaxBytes           *int      `toml:"chunk_max_bytes"`
+	TagRetryInterval        *string   `toml:"tag_retry_interval"`
+	TagRetryMaxAttempts     *int      `toml:"tag_retry_max_attempts"`
+	TagTopN                 *int      `toml:"tag_top_n"`
+	TagMinConfidence        *float64  `toml:"tag_min_confidence"`
+	TagCleanupMinConfidence *float64  `toml:"tag_cleanup_min_confidence"`
+	TagCleanupInterval      *string   `toml:"tag_cleanup_interval"`
+	TagCleanupRemoveOrphans *bool     `toml:"tag_cleanup_remove_orphans"`
 }
 type toolsManifest struct {
 	ExifToolPath *string `toml:"exiftool_path"`
@@ -278,6 +428,7 @@ func validateManifestPresence(m manifest) []string {
 	requiredSection(&p, "geocoding", m.Geocoding)
 	requiredSection(&p, "auth", m.Auth)
 	requiredSection(&p, "transcode", m.Transcode)
+	requiredSection(&p, "search", m.Search)
 	requiredSection(&p, "lumen", m.Lumen)
 	requiredSection(&p, "tools", m.Tools)
 	if m.Database != nil {
@@ -289,11 +440,23 @@ func validateManifestPresence(m manifest) []string {
 		required(&p, "database.bootstrap_password_file", m.Database.BootstrapPasswordFile)
 		required(&p, "database.rotated_password_file", m.Database.RotatedPasswordFile)
 		required(&p, "database.tools_bin_dir", m.Database.ToolsBinDir)
+		required(&p, "database.clock_skew_threshold", m.Database.ClockSkewThreshold)
+		required(&p, "database.clock_skew_check_interval", m.Database.ClockSkewCheckInterval)
+		required(&p, "database.asset_lock_connections", m.Database.AssetLockConnections)
 	}
 	if m.Server != nil {
 		required(&p, "server.port", m.Server.Port)
 		required(&p, "server.cors_allowed_origins", m.Server.CORSAllowedOrigins)
 		required(&p, "server.web_root", m.Server.WebRoot)
+		required(&p, "server.album_cover_must_be_member", m.Server.AlbumCoverMustBeMember)
+		required(&p, "server.album_cover_on_asset_delete", m.Server.AlbumCoverOnAssetDelete)
+		required(&p, "server.read_header_timeout", m.Server.ReadHeaderTimeout)
+		required(&p, "server.read_timeout", m.Server.ReadTimeout)
+		required(&p, "server.write_timeout", m.Server.WriteTimeout)
+		required(&p, "server.idle_timeout", m.Server.IdleTimeout)
+		required(&p, "server.image_op_max_dimension", m.Server.ImageOpMaxDimension)
+		required(&p, "server.image_op_max_megapixels", m.Server.ImageOpMaxMegapixels)
+		required(&p, "server.image_op_timeout", m.Server.ImageOpTimeout)
 	}
 	if m.Logging != nil {
 		required(&p, "logging.level", m.Logging.Level)
@@ -306,6 +469,17 @@ func validateManifestPresence(m manifest) []string {
 		required(&p, "storage.path", m.Storage.Path)
 		required(&p, "storage.cloud_state_path", m.Storage.CloudStatePath)
 		required(&p, "storage.backups_path", m.Storage.BackupsPath)
+		required(&p, "storage.min_file_size_bytes", m.Storage.MinFileSizeBytes)
+		required(&p, "storage.max_batch_files", m.Storage.MaxBatchFiles)
+		required(&p, "storage.max_concurrent_uploads_per_user", m.Storage.MaxConcurrentUploadsPerUser)
+		required(&p, "storage.max_download_bytes", m.Storage.MaxDownloadBytes)
+		required(&p, "storage.dedupe_thumbnails", m.Storage.DedupeThumbnails)
+		required(&p, "storage.write_rating_to_exif", m.Storage.WriteRatingToExif)
+		required(&p, "storage.hash_buffer_bytes", m.Storage.HashBufferBytes)
+		required(&p, "storage.hash_read_ahead", m.Storage.HashReadAhead)
+		required(&p, "storage.require_primary_repository", m.Storage.RequirePrimaryRepository)
+		required(&p, "storage.url_import_max_bytes", m.Storage.URLImportMaxBytes)
+		required(&p, "storage.url_import_allowed_hosts", m.Storage.URLImportAllowedHosts)
 	}
 	if m.RepositoryScan != nil {
 		required(&p, "repository_scan.enabled", m.RepositoryScan.Enabled)
@@ -313,6 +487,8 @@ func validateManifestPresence(m manifest) []string {
 		required(&p, "repository_scan.settle_seconds", m.RepositoryScan.SettleSeconds)
 		required(&p, "repository_scan.max_concurrent_repos", m.RepositoryScan.MaxConcurrentRepos)
 		required(&p, "repository_scan.batch_size", m.RepositoryScan.BatchSize)
+		required(&p, "repository_scan.auto_album_by_folder", m.RepositoryScan.AutoAlbumByFolder)
+		required(&p, "repository_scan.monitor", m.RepositoryScan.Monitor)
 	}
 	if m.Geocoding != nil {
 		required(&p, "geocoding.provider", m.Geocoding.Provider)
@@ -332,6 +508,15 @@ func validateManifestPresence(m manifest) []string {
 	}
 	if m.Transcode != nil {
 		required(&p, "transcode.hardware_accel", m.Transcode.HardwareAccel)
+		required(&p, "transcode.max_bitrate_kbps", m.Transcode.MaxBitrateKbps)
+		required(&p, "transcode.web_derivative_retention", m.Transcode.WebDerivativeRetention)
+		required(&p, "transcode.hls_enabled", m.Transcode.HLSEnabled)
+	}
+	if m.Search != nil {
+		required(&p, "search.semantic_weight", m.Search.SemanticWeight)
+		required(&p, "search.ocr_weight", m.Search.OCRWeight)
+		required(&p, "search.place_weight", m.Search.PlaceWeight)
+		required(&p, "search.filename_weight", m.Search.FilenameWeight)
 	}
 	if m.Lumen != nil {
 		required(&p, "lumen.discovery_enabled", m.Lumen.DiscoveryEnabled)
@@ -349,6 +534,13 @@ func validateManifestPresence(m manifest) []string {
 		required(&p, "lumen.chunk_auto", m.Lumen.ChunkAuto)
 		required(&p, "lumen.chunk_threshold_bytes", m.Lumen.ChunkThresholdBytes)
 		required(&p, "lumen.chunk_max_bytes", m.Lumen.ChunkMaxBytes)
+		required(&p, "lumen.tag_retry_interval", m.Lumen.TagRetryInterval)
+		required(&p, "lumen.tag_retry_max_attempts", m.Lumen.TagRetryMaxAttempts)
+		required(&p, "lumen.tag_top_n", m.Lumen.TagTopN)
+		required(&p, "lumen.tag_min_confidence", m.Lumen.TagMinConfidence)
+		required(&p, "lumen.tag_cleanup_min_confidence", m.Lumen.TagCleanupMinConfidence)
+		required(&p, "lumen.tag_cleanup_interval", m.Lumen.TagCleanupInterval)
+		required(&p, "lumen.tag_cleanup_remove_orphans", m.Lumen.TagCleanupRemoveOrphans)
 	}
 	if m.Tools != nil {
 		required(&p, "tools.exiftool_path", m.Tools.ExifToolPath)
@@ -392,6 +584,12 @@ func resolveManifest(m manifest, base string) (AppConfig, []string) {
 	requireOneOf(&p, "database.ssl", db.SSL, "disable", "require", "verify-ca", "verify-full")
 	requireNonEmpty(&p, "database.bootstrap_password_file", strings.TrimSpace(*m.Database.BootstrapPasswordFile))
 	requireNonEmpty(&p, "database.rotated_password_file", strings.TrimSpace(*m.Database.RotatedPasswordFile))
+	db.ClockSkewThreshold = parsePositiveDuration(&p, "database.clock_skew_threshold", *m.Database.ClockSkewThreshold)
+	db.ClockSkewCheckInterval = parsePositiveDuration(&p, "database.clock_skew_check_interval", *m.Database.ClockSkewCheckInterval)
+	db.AssetLockConnections = *m.Database.AssetLockConnections
+	if db.AssetLockConnections < 0 {
+		p = append(p, "database.asset_lock_connections must not be negative")
+	}
 	bootstrap, err := readRequiredSecret(db.BootstrapPasswordFile)
 	if err != nil {
 		p = append(p, fmt.Sprintf("database.bootstrap_password_file: %v", err))
@@ -405,8 +603,22 @@ func resolveManifest(m manifest, base string) (AppConfig, []string) {
 		db.Password = rotated
 	}
 
-	server := ServerConfig{Port: strings.TrimSpace(*m.Server.Port), CORSAllowedOrigins: cleanStrings(*m.Server.CORSAllowedOrigins), WebRoot: resolveOptionalPath(base, *m.Server.WebRoot)}
+	server := ServerConfig{Port: strings.TrimSpace(*m.Server.Port), CORSAllowedOrigins: cleanStrings(*m.Server.CORSAllowedOrigins), WebRoot: resolveOptionalPath(base, *m.Server.WebRoot), AlbumCoverMustBeMember: *m.Server.AlbumCoverMustBeMember, AlbumCoverOnAssetDelete: strings.ToLower(strings.TrimSpace(*m.Server.AlbumCoverOnAssetDelete))}
 	requirePort(&p, "server.port", server.Port)
+	requireOneOf(&p, "server.album_cover_on_asset_delete", server.AlbumCoverOnAssetDelete, "reassign", "clear")
+	server.ReadHeaderTimeout = parsePositiveDuration(&p, "server.read_header_timeout", *m.Server.ReadHeaderTimeout)
+	server.ReadTimeout = parsePositiveDuration(&p, "server.read_timeout", *m.Server.ReadTimeout)
+	server.WriteTimeout = parsePositiveDuration(&p, "server.write_timeout", *m.Server.WriteTimeout)
+	server.IdleTimeout = parsePositiveDuration(&p, "server.idle_timeout", *m.Server.IdleTimeout)
+	server.ImageOpMaxDimension = *m.Server.ImageOpMaxDimension
+	server.ImageOpMaxMegapixels = *m.Server.ImageOpMaxMegapixels
+	if server.ImageOpMaxDimension < 0 {
+		p = append(p, "server.image_op_max_dimension must not be negative")
+	}
+	if server.ImageOpMaxMegapixels < 0 {
+		p = append(p, "server.image_op_max_megapixels must not be negative")
+	}
+	server.ImageOpTimeout = parsePositiveDuration(&p, "server.image_op_timeout", *m.Server.ImageOpTimeout)
 	for i, origin := range server.CORSAllowedOrigins {
 		validateOrigin(&p, fmt.Sprintf("server.cors_allowed_origins[%d]", i), origin)
 	}
@@ -418,23 +630,49 @@ func resolveManifest(m manifest, base string) (AppConfig, []string) {
 	requireOneOf(&p, "logging.file_format", logging.FileFormat, "console", "json")
 
 	storage := StorageConfig{
-		Path:           resolvePath(base, *m.Storage.Path),
-		CloudStatePath: resolvePath(base, *m.Storage.CloudStatePath),
-		BackupsPath:    resolvePath(base, *m.Storage.BackupsPath),
+		Path:                        resolvePath(base, *m.Storage.Path),
+		CloudStatePath:              resolvePath(base, *m.Storage.CloudStatePath),
+		BackupsPath:                 resolvePath(base, *m.Storage.BackupsPath),
+		MinFileSizeBytes:            *m.Storage.MinFileSizeBytes,
+		MaxBatchFiles:               *m.Storage.MaxBatchFiles,
+		MaxConcurrentUploadsPerUser: *m.Storage.MaxConcurrentUploadsPerUser,
+		MaxDownloadBytes:            *m.Storage.MaxDownloadBytes,
+		DedupeThumbnails:            *m.Storage.DedupeThumbnails,
+		WriteRatingToExif:           *m.Storage.WriteRatingToExif,
+		HashBufferBytes:             *m.Storage.HashBufferBytes,
+		HashReadAhead:               *m.Storage.HashReadAhead,
+		RequirePrimaryRepository:    *m.Storage.RequirePrimaryRepository,
+		URLImportMaxBytes:           *m.Storage.URLImportMaxBytes,
+		URLImportAllowedHosts:       cleanStrings(*m.Storage.URLImportAllowedHosts),
 	}
 	requireNonEmpty(&p, "storage.path", strings.TrimSpace(*m.Storage.Path))
 	requireNonEmpty(&p, "storage.cloud_state_path", strings.TrimSpace(*m.Storage.CloudStatePath))
 	requireNonEmpty(&p, "storage.backups_path", strings.TrimSpace(*m.Storage.BackupsPath))
 	requireOutsidePath(&p, "storage.cloud_state_path", storage.CloudStatePath, storage.Path)
 	requireOutsidePath(&p, "storage.backups_path", storage.BackupsPath, storage.Path)
+	if storage.MinFileSizeBytes < 0 {
+		p = append(p, "storage.min_file_size_bytes must not be negative")
+	}
+	if storage.MaxConcurrentUploadsPerUser < 0 {
+		p = append(p, "storage.max_concurrent_uploads_per_user must not be negative")
+	}
+	if storage.MaxDownloadBytes < 0 {
+		p = append(p, "storage.max_download_bytes must not be negative")
+	}
+	if storage.URLImportMaxBytes < 0 {
+		p = append(p, "storage.url_import_max_bytes must not be negative")
+	}
+	requirePositive(&p, "storage.max_batch_files", storage.MaxBatchFiles)
+	requirePositive(&p, "storage.hash_buffer_bytes", storage.HashBufferBytes)
 	requireOutsidePath(&p, "logging.dir", logging.LogDir, storage.Path)
 	requireOutsidePath(&p, "database.bootstrap_password_file", db.BootstrapPasswordFile, storage.Path)
 	requireOutsidePath(&p, "database.rotated_password_file", db.RotatedPasswordFile, storage.Path)
-	scan := RepositoryScanConfig{Enabled: *m.RepositoryScan.Enabled, IntervalSeconds: *m.RepositoryScan.IntervalSeconds, SettleSeconds: *m.RepositoryScan.SettleSeconds, MaxConcurrentRepos: *m.RepositoryScan.MaxConcurrentRepos, BatchSize: *m.RepositoryScan.BatchSize}
+	scan := RepositoryScanConfig{Enabled: *m.RepositoryScan.Enabled, IntervalSeconds: *m.RepositoryScan.IntervalSeconds, SettleSeconds: *m.RepositoryScan.SettleSeconds, MaxConcurrentRepos: *m.RepositoryScan.MaxConcurrentRepos, BatchSize: *m.RepositoryScan.BatchSize, AutoAlbumByFolder: *m.RepositoryScan.AutoAlbumByFolder, Monitor: strings.ToLower(strings.TrimSpace(*m.RepositoryScan.Monitor))}
 	requirePositive(&p, "repository_scan.interval_seconds", scan.IntervalSeconds)
 	requirePositive(&p, "repository_scan.settle_seconds", scan.SettleSeconds)
 	requirePositive(&p, "repository_scan.max_concurrent_repos", scan.MaxConcurrentRepos)
 	requirePositive(&p, "repository_scan.batch_size", scan.BatchSize)
+	requireOneOf(&p, "repository_scan.monitor", scan.Monitor, "off", "fsnotify")
 
 	geocoding := GeocodingConfig{Provider: strings.ToLower(strings.TrimSpace(*m.Geocoding.Provider)), NominatimEndpoint: strings.TrimSpace(*m.Geocoding.NominatimEndpoint), Language: strings.TrimSpace(*m.Geocoding.Language), UserAgent: strings.TrimSpace(*m.Geocoding.UserAgent)}
 	requireOneOf(&p, "geocoding.provider", geocoding.Provider, "disabled", "nominatim")
@@ -467,10 +705,20 @@ func resolveManifest(m manifest, base string) (AppConfig, []string) {
 		validateOrigin(&p, fmt.Sprintf("auth.webauthn_rp_origins[%d]", i), origin)
 	}
 
-	transcode := TranscodeConfig{HardwareAccel: strings.ToLower(strings.TrimSpace(*m.Transcode.HardwareAccel))}
+	transcode := TranscodeConfig{HardwareAccel: strings.ToLower(strings.TrimSpace(*m.Transcode.HardwareAccel)), MaxBitrateKbps: *m.Transcode.MaxBitrateKbps, HLSEnabled: *m.Transcode.HLSEnabled}
 	requireOneOf(&p, "transcode.hardware_accel", transcode.HardwareAccel, "auto", "vaapi", "nvenc", "qsv", "videotoolbox", "none")
+	if transcode.MaxBitrateKbps < 0 {
+		p = append(p, "transcode.max_bitrate_kbps must not be negative")
+	}
+	transcode.WebDerivativeRetention = parseNonNegativeDuration(&p, "transcode.web_derivative_retention", *m.Transcode.WebDerivativeRetention)
+
+	search := SearchConfig{SemanticWeight: *m.Search.SemanticWeight, OCRWeight: *m.Search.OCRWeight, PlaceWeight: *m.Search.PlaceWeight, FilenameWeight: *m.Search.FilenameWeight}
+	requirePositiveFloat(&p, "search.semantic_weight", search.SemanticWeight)
+	requirePositiveFloat(&p, "search.ocr_weight", search.OCRWeight)
+	requirePositiveFloat(&p, "search.place_weight", search.PlaceWeight)
+	requirePositiveFloat(&p, "search.filename_weight", search.FilenameWeight)
 
-	lumen := LumenConfig{DiscoveryEnabled: *m.Lumen.DiscoveryEnabled, DiscoveryMDNSEnabled: *m.Lumen.DiscoveryMDNSEnabled, DiscoveryHubURL: strings.TrimSpace(*m.Lumen.DiscoveryHubURL), DiscoveryStaticNodes: cleanStrings(*m.Lumen.DiscoveryStaticNodes), DiscoveryServiceType: strings.TrimSpace(*m.Lumen.DiscoveryServiceType), DiscoveryDomain: strings.TrimSpace(*m.Lumen.DiscoveryDomain), DeploymentID: strings.TrimSpace(*m.Lumen.DeploymentID), ChunkAuto: *m.Lumen.ChunkAuto, ChunkThresholdBytes: *m.Lumen.ChunkThresholdBytes, ChunkMaxBytes: *m.Lumen.ChunkMaxBytes}
+	lumen := LumenConfig{DiscoveryEnabled: *m.Lumen.DiscoveryEnabled, DiscoveryMDNSEnabled: *m.Lumen.DiscoveryMDNSEnabled, DiscoveryHubURL: strings.TrimSpace(*m.Lumen.DiscoveryHubURL), DiscoveryStaticNodes: cleanStrings(*m.Lumen.DiscoveryStaticNodes), DiscoveryServiceType: strings.TrimSpace(*m.Lumen.DiscoveryServiceType), DiscoveryDomain: strings.TrimSpace(*m.Lumen.DiscoveryDomain), DeploymentID: strings.TrimSpace(*m.Lumen.DeploymentID), ChunkAuto: *m.Lumen.ChunkAuto, ChunkThresholdBytes: *m.Lumen.ChunkThresholdBytes, ChunkMaxBytes: *m.Lumen.ChunkMaxBytes, TagRetryMaxAttempts: *m.Lumen.TagRetryMaxAttempts, TagTopN: *m.Lumen.TagTopN, TagMinConfidence: *m.Lumen.TagMinConfidence, TagCleanupMinConfidence: *m.Lumen.TagCleanupMinConfidence, TagCleanupRemoveOrphans: *m.Lumen.TagCleanupRemoveOrphans}
 	requireNonEmpty(&p, "lumen.discovery_service_type", lumen.DiscoveryServiceType)
 	requireNonEmpty(&p, "lumen.discovery_domain", lumen.DiscoveryDomain)
 	requireNonEmpty(&p, "lumen.deployment_id", lumen.DeploymentID)
@@ -485,6 +733,8 @@ func resolveManifest(m manifest, base string) (AppConfig, []string) {
 	lumen.RediscoveryBackoffMin = parsePositiveDuration(&p, "lumen.rediscovery_backoff_min", *m.Lumen.RediscoveryBackoffMin)
 	lumen.RediscoveryBackoffMax = parsePositiveDuration(&p, "lumen.rediscovery_backoff_max", *m.Lumen.RediscoveryBackoffMax)
 	lumen.ScanInterval = parsePositiveDuration(&p, "lumen.scan_interval", *m.Lumen.ScanInterval)
+	lumen.TagRetryInterval = parsePositiveDuration(&p, "lumen.tag_retry_interval", *m.Lumen.TagRetryInterval)
+	lumen.TagCleanupInterval = parsePositiveDuration(&p, "lumen.tag_cleanup_interval", *m.Lumen.TagCleanupInterval)
 	if lumen.RediscoveryBackoffMax < lumen.RediscoveryBackoffMin {
 		p = append(p, "lumen.rediscovery_backoff_max must be greater than or equal to rediscovery_backoff_min")
 	}
@@ -504,13 +754,25 @@ func resolveManifest(m manifest, base string) (AppConfig, []string) {
 	if lumen.ChunkMaxBytes > lumen.ChunkThresholdBytes {
 		p = append(p, "lumen.chunk_max_bytes must be less than or equal to chunk_threshold_bytes")
 	}
+	if lumen.TagRetryMaxAttempts < 0 {
+		p = append(p, "lumen.tag_retry_max_attempts must be zero or positive")
+	}
+	if lumen.TagTopN < 0 {
+		p = append(p, "lumen.tag_top_n must be zero or positive")
+	}
+	if lumen.TagMinConfidence < 0 || lumen.TagMinConfidence > 1 {
+		p = append(p, "lumen.tag_min_confidence must be between 0 and 1")
+	}
+	if lumen.TagCleanupMinConfidence < 0 || lumen.TagCleanupMinConfidence > 1 {
+		p = append(p, "lumen.tag_cleanup_min_confidence must be between 0 and 1")
+	}
 
 	tools := ToolsConfig{ExifToolPath: resolveCommand(base, *m.Tools.ExifToolPath), FFmpegPath: resolveCommand(base, *m.Tools.FFmpegPath), FFprobePath: resolveCommand(base, *m.Tools.FFprobePath)}
 	requireNonEmpty(&p, "tools.exiftool_path", tools.ExifToolPath)
 	requireNonEmpty(&p, "tools.ffmpeg_path", tools.FFmpegPath)
 	requireNonEmpty(&p, "tools.ffprobe_path", tools.FFprobePath)
 
-	return AppConfig{Environment: environment, DatabaseConfig: db, ServerConfig: server, LoggingConfig: logging, StorageConfig: storage, RepositoryScan: scan, Geocoding: geocoding, Auth: auth, Transcode: transcode, Lumen: lumen, Tools: tools}, p
+	return AppConfig{Environment: environment, DatabaseConfig: db, ServerConfig: server, LoggingConfig: logging, StorageConfig: storage, RepositoryScan: scan, Geocoding: geocoding, Auth: auth, Transcode: transcode, Search: search, Lumen: lumen, Tools: tools}, p
 }
 
 func invalidConfig(p []string) error {
@@ -531,6 +793,11 @@ func requirePositive(p *[]string, name string, value int) {
 		*p = append(*p, name+" must be positive")
 	}
 }
+func requirePositiveFloat(p *[]string, name string, value float64) {
+	if !(value > 0) || math.IsInf(value, 0) {
+		*p = append(*p, name+" must be a positive number")
+	}
+}
 func requirePort(p *[]string, name, value string) {
 	n, err := strconv.Atoi(value)
 	if err != nil || n < 1 || n > 65535 {
@@ -577,6 +844,14 @@ func parsePositiveDuration(p *[]string, name, value string) time.Duration {
 	}
 	return d
 }
+func parseNonNegativeDuration(p *[]string, name, value string) time.Duration {
+	d, err := time.ParseDuration(strings.TrimSpace(value))
+	if err != nil || d < 0 {
+		*p = append(*p, name+" must be a duration of 0 or more")
+		return 0
+	}
+	return d
+}
 func cleanStrings(values []string) []string {
 	out := make([]string, 0, len(values))
 	for _, value := range values {
@@ -22,10 +22,22 @@ ssl = "disable"
 bootstrap_password_file = ".secrets/bootstrap"
 rotated_password_file = "data/rotated"
 tools_bin_dir = ""
+clock_skew_threshold = "2s"
+clock_skew_check_interval = "15m"
+asset_lock_connections = 8
 [server]
 port = "6680"
 cors_allowed_origins = []
 web_root = ""
+album_cover_must_be_member = true
+album_cover_on_asset_delete = "reassign"
+read_header_timeout = "10s"
+read_timeout = "10m"
+write_timeout = "10m"
+idle_timeout = "2m"
+image_op_max_dimension = 30000
+image_op_max_megapixels = 200
+image_op_timeout = "60s"
 [logging]
 level = "debug"
 dir = "logs"
@@ -36,12 +48,25 @@ repository_audit_verbose = false
 path = "data/storage"
 cloud_state_path = "data/app-state/cloud"
 backups_path = "data/app-state/backups"
+min_file_size_bytes = 0
+max_batch_files = 100
+max_concurrent_uploads_per_user = 16
+max_download_bytes = 10737418240
+dedupe_thumbnails = true
+write_rating_to_exif = false
+hash_buffer_bytes = 1048576
+hash_read_ahead = false
+require_primary_repository = false
+url_import_max_bytes = 0
+url_import_allowed_hosts = []
 [repository_scan]
 enabled = true
 interval_seconds = 300
 settle_seconds = 5
 max_concurrent_repos = 1
 batch_size = 500
+auto_album_by_folder = false
+monitor = "off"
 [geocoding]
 provider = "disabled"
 nominatim_endpoint = "https://nominatim.openstreetmap.org/reverse"
@@ -58,6 +83,14 @@ webauthn_rp_id = ""
 webauthn_rp_origins = []
 [transcode]
 hardware_accel = "auto"
+max_bitrate_kbps = 0
+web_derivative_retention = "0s"
+hls_enabled = false
+[search]
+semantic_weight = 1.0
+ocr_weight = 0.7
+place_weight = 0.8
+filename_weight = 0.6
 [lumen]
 discovery_enabled = true
 discovery_mdns_enabled = true
@@ -74,6 +107,13 @@ scan_interval = "30s"
 chunk_auto = true
 chunk_threshold_bytes = 1048576
 chunk_max_bytes = 262144
+tag_retry_interval = "1h"
+tag_retry_max_attempts = 3
+tag_top_n = 0
+tag_min_confidence = 0.5
+tag_cleanup_min_confidence = 0
+tag_cleanup_interval = "24h"
+tag_cleanup_remove_orphans = false
 [tools]
 exiftool_path = "exiftool"
 ffmpeg_path = "bin/ffmpeg"
@@ -133,6 +173,9 @@ func TestLoadAppConfigStrictCompleteManifest(t *testing.T) {
 	if cfg.Auth.AccessTokenTTL != 15*time.Minute {
 		t.Fatalf("access ttl = %v", cfg.Auth.AccessTokenTTL)
 	}
+	if cfg.Search.SemanticWeight != 1.0 || cfg.Search.FilenameWeight != 0.6 {
+		t.Fatalf("search weights = %+v", cfg.Search)
+	}
 }
 
 func TestLoadAppConfigUsesRotatedSecretWhenPresent(t *testing.T) {
@@ -203,11 +246,19 @@ func TestLoadAppConfigAggregatesInvalidValues(t *testing.T) {
 	contents := strings.ReplaceAll(completeManifest, "interval_seconds = 300", "interval_seconds = 0")
 	contents = strings.ReplaceAll(contents, "connect_timeout = \"3s\"", "connect_timeout = \"never\"")
 	contents = strings.ReplaceAll(contents, "chunk_max_bytes = 262144", "chunk_max_bytes = 2097152")
+	contents = strings.ReplaceAll(contents, "min_file_size_bytes = 0", "min_file_size_bytes = -1")
+	contents = strings.ReplaceAll(contents, "max_batch_files = 100", "max_batch_files = 0")
+	contents = strings.ReplaceAll(contents, "max_concurrent_uploads_per_user = 16", "max_concurrent_uploads_per_user = -1")
+	contents = strings.ReplaceAll(contents, `read_header_timeout = "10s"`, `read_header_timeout = "0s"`)
+	contents = strings.ReplaceAll(contents, `album_cover_on_asset_delete = "reassign"`, `album_cover_on_asset_delete = "keep"`)
+	contents = strings.ReplaceAll(contents, "filename_weight = 0.6", "filename_weight = 0")
+	contents = strings.ReplaceAll(contents, "tag_min_confidence = 0.5", "tag_min_confidence = 1.5")
+	contents = strings.ReplaceAll(contents, "tag_cleanup_min_confidence = 0", "tag_cleanup_min_confidence = -0.1")
 	_, err := LoadAppConfig(writeManifestFixture(t, contents))
 	if err == nil {
 		t.Fatal("expected invalid manifest")
 	}
-	for _, want := range []string{"repository_scan.interval_seconds", "lumen.connect_timeout", "lumen.chunk_max_bytes"} {
+	for _, want := range []string{"repository_scan.interval_seconds", "lumen.connect_timeout", "lumen.chunk_max_bytes", "storage.min_file_size_bytes", "storage.max_batch_files", "storage.max_concurrent_uploads_per_user", "server.read_header_timeout", "server.album_cover_on_asset_delete", "search.filename_weight", "lumen.tag_min_confidence", "lumen.tag_cleanup_min_confidence"} {
 		if !strings.Contains(err.Error(), want) {
 			t.Fatalf("error %q does not contain %q", err, want)
 		}
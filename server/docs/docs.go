@@ -73,10 +73,18 @@ const docTemplate = `{
                         "example": "Song Description",
                         "type": "string"
                     },
+                    "duration": {
+                        "description": "Duration is the running time in seconds.",
+                        "example": 215.4,
+                        "type": "number"
+                    },
                     "genre": {
                         "example": "Pop",
                         "type": "string"
                     },
+                    "hash_verified": {
+                        "type": "boolean"
+                    },
                     "sample_rate": {
                         "example": 44100,
                         "type": "integer"
@@ -85,6 +93,14 @@ const docTemplate = `{
                         "example": "Song Title",
                         "type": "string"
                     },
+                    "waveform_peaks": {
+                        "description": "WaveformPeaks is the loudest sample of each of up to 1000 equal\nstretches of the recording, as a percentage of full scale.",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "type": "array",
+                        "uniqueItems": false
+                    },
                     "year": {
                         "example": 2023,
                         "type": "integer"
@@ -94,12 +110,19 @@ const docTemplate = `{
             },
             "dbtypes.PhotoSpecificMetadata": {
                 "properties": {
+                    "blurhash": {
+                        "description": "BlurHash is a placeholder computed from the small thumbnail; the\nthumbnail task stores it and metadata rewrites keep it.",
+                        "type": "string"
+                    },
                     "camera_model": {
                         "type": "string"
                     },
                     "capture_offset_minutes": {
                         "type": "integer"
                     },
+                    "color_label": {
+                        "type": "string"
+                    },
                     "content_identifier": {
                         "type": "string"
                     },
@@ -109,6 +132,9 @@ const docTemplate = `{
                     "dimensions": {
                         "type": "string"
                     },
+                    "edited_with": {
+                        "type": "string"
+                    },
                     "exposure": {
                         "type": "number"
                     },
@@ -127,6 +153,14 @@ const docTemplate = `{
                     "gps_longitude": {
                         "type": "number"
                     },
+                    "has_edits": {
+                        "description": "HasEdits marks a RAW whose darktable/RawTherapee sidecar carries a\nprocessing history, so an edited version exists in that editor.",
+                        "type": "boolean"
+                    },
+                    "hash_verified": {
+                        "description": "HashVerified records, for uploads, whether the hash the ingest worker\ncomputed matched the one the client sent; absent when it sent none.",
+                        "type": "boolean"
+                    },
                     "is_raw": {
                         "type": "boolean"
                     },
@@ -194,6 +228,9 @@ const docTemplate = `{
                         "example": -122.4194,
                         "type": "number"
                     },
+                    "hash_verified": {
+                        "type": "boolean"
+                    },
                     "recorded_time": {
                         "example": "2023-01-01T00:00:00Z",
                         "type": "string"
@@ -201,8 +238,72 @@ const docTemplate = `{
                 },
                 "type": "object"
             },
+            "dto.ActivityEventDTO": {
+                "properties": {
+                    "album_id": {
+                        "example": 12,
+                        "type": "integer"
+                    },
+                    "asset_id": {
+                        "example": "550e8400-e29b-41d4-a716-446655440000",
+                        "type": "string"
+                    },
+                    "id": {
+                        "example": 1024,
+                        "type": "integer"
+                    },
+                    "occurred_at": {
+                        "type": "string"
+                    },
+                    "payload": {
+                        "type": "object"
+                    },
+                    "type": {
+                        "enum": [
+                            "asset.added",
+                            "asset.deleted",
+                            "asset.rating_updated",
+                            "album.created",
+                            "album.updated",
+                            "album.deleted",
+                            "album.asset_added",
+                            "album.asset_removed"
+                        ],
+                        "example": "asset.added",
+                        "type": "string"
+                    }
+                },
+                "type": "object"
+            },
+            "dto.ActivityFeedDTO": {
+                "properties": {
+                    "events": {
+                        "items": {
+                            "$ref": "#/components/schemas/dto.ActivityEventDTO"
+                        },
+                        "type": "array",
+                        "uniqueItems": false
+                    },
+                    "has_more": {
+                        "example": false,
+                        "type": "boolean"
+                    },
+                    "next_after_id": {
+                        "example": 1024,
+                        "type": "integer"
+                    },
+                    "next_since": {
+                        "type": "string"
+                    }
+                },
+                "type": "object"
+            },
             "dto.AddAssetTagRequestDTO": {
                 "properties": {
+                    "category": {
+                        "example": "travel",
+                        "type": "string"
+                    },
                     "tag_name": {
                         "example": "vacation",
                         "type": "string"
@@ -539,6 +640,11 @@ const docTemplate = `{
                     "asset_id": {
                         "type": "string"
                     },
+                    "blurhash": {
+                        "description": "BlurHash is a placeholder to paint until the thumbnail loads.",
+                        "example": "LEHV6nWB2yk8pyo0adR*.7kCMdnj",
+                        "type": "string"
+                    },
                     "capture_offset_minutes": {
                         "type": "integer"
                     },
@@ -569,12 +675,34 @@ const docTemplate = `{
                     "original_filename": {
                         "type": "string"
                     },
+                    "original_url": {
+                        "description": "Signed media URLs (populated only when the request sets include_urls)",
+                        "example": "/api/v1/assets/550e8400-e29b-41d4-a716-446655440000/original?mt=eyJhbGciOi...",
+                        "type": "string"
+                    },
                     "owner_id": {
                         "type": "integer"
                     },
                     "position": {
                         "type": "integer"
                     },
+                    "processing_error": {
+                        "description": "ProcessingError is why a failed or warning asset failed: its latest task error, else the status message.",
+                        "example": "thumbnail_asset: unsupported image format",
+                        "type": "string"
+                    },
+                    "processing_status": {
+                        "description": "ProcessingStatus is the state of Status.",
+                        "enum": [
+                            "processing",
+                            "complete",
+                            "warning",
+                            "failed",
+                            "deferred"
+                        ],
+                        "example": "failed",
+                        "type": "string"
+                    },
                     "rating": {
                         "type": "integer"
                     },
@@ -618,18 +746,47 @@ const docTemplate = `{
                     "taken_time": {
                         "type": "string"
                     },
+                    "thumbnail_urls": {
+                        "additionalProperties": {
+                            "type": "string"
+                        },
+                        "type": "object"
+                    },
                     "type": {
                         "type": "string"
                     },
                     "upload_time": {
                         "type": "string"
                     },
+                    "urls_expire_at": {
+                        "type": "string"
+                    },
+                    "web_url": {
+                        "example": "/api/v1/assets/550e8400-e29b-41d4-a716-446655440000/video/web?mt=eyJhbGciOi...",
+                        "type": "string"
+                    },
                     "width": {
                         "type": "integer"
                     }
                 },
                 "type": "object"
             },
+            "dto.AlbumAssetOrderResponseDTO": {
+                "properties": {
+                    "album_id": {
+                        "example": 1,
+                        "type": "integer"
+                    },
+                    "asset_ids": {
+                        "items": {
+                            "type": "string"
+                        },
+                        "type": "array",
+                        "uniqueItems": false
+                    }
+                },
+                "type": "object"
+            },
             "dto.AlbumAssetsResponseDTO": {
                 "properties": {
                     "album_id": {
@@ -648,6 +805,41 @@ const docTemplate = `{
                 },
                 "type": "object"
             },
+            "dto.AlbumDuplicateGroupDTO": {
+                "properties": {
+                    "album_name": {
+                        "example": "Japan 2024",
+                        "type": "string"
+                    },
+                    "album_type": {
+                        "example": "default",
+                        "type": "string"
+                    },
+                    "albums": {
+                        "items": {
+                            "$ref": "#/components/schemas/dto.GetAlbumResponseDTO"
+                        },
+                        "type": "array",
+                        "uniqueItems": false
+                    }
+                },
+                "type": "object"
+            },
+            "dto.AlbumDuplicatesResponseDTO": {
+                "properties": {
+                    "count": {
+                        "type": "integer"
+                    },
+                    "groups": {
+                        "items": {
+                            "$ref": "#/components/schemas/dto.AlbumDuplicateGroupDTO"
+                        },
+                        "type": "array",
+                        "uniqueItems": false
+                    }
+                },
+                "type": "object"
+            },
             "dto.AssetAlbumDTO": {
                 "properties": {
                     "added_time": {
@@ -677,9 +869,16 @@ const docTemplate = `{
                     "display_cover_asset_id": {
                         "type": "string"
                     },
+                    "is_smart": {
+                        "description": "IsSmart marks albums whose assets are whatever Rules matches when read, rather than a stored list.",
+                        "type": "boolean"
+                    },
                     "position": {
                         "type": "integer"
                     },
+                    "rules": {
+                        "$ref": "#/components/schemas/dto.AssetFilterDTO"
+                    },
                     "updated_at": {
                         "type": "string"
                     },
@@ -724,11 +923,28 @@ const docTemplate = `{
                 },
                 "type": "object"
             },
+            "dto.AssetCustomFieldsDTO": {
+                "properties": {
+                    "asset_id": {
+                        "example": "550e8400-e29b-41d4-a716-446655440000",
+                        "type": "string"
+                    },
+                    "fields": {
+                        "type": "object"
+                    }
+                },
+                "type": "object"
+            },
             "dto.AssetDTO": {
                 "properties": {
                     "asset_id": {
                         "type": "string"
                     },
+                    "blurhash": {
+                        "description": "BlurHash is a placeholder to paint until the thumbnail loads.",
+                        "example": "LEHV6nWB2yk8pyo0adR*.7kCMdnj",
+                        "type": "string"
+                    },
                     "capture_offset_minutes": {
                         "type": "integer"
                     },
@@ -759,9 +975,31 @@ const docTemplate = `{
                     "original_filename": {
                         "type": "string"
                     },
+                    "original_url": {
+                        "description": "Signed media URLs (populated only when the request sets include_urls)",
+                        "example": "/api/v1/assets/550e8400-e29b-41d4-a716-446655440000/original?mt=eyJhbGciOi...",
+                        "type": "string"
+                    },
                     "owner_id": {
                         "type": "integer"
                     },
+                    "processing_error": {
+                        "description": "ProcessingError is why a failed or warning asset failed: its latest task error, else the status message.",
+                        "example": "thumbnail_asset: unsupported image format",
+                        "type": "string"
+                    },
+                    "processing_status": {
+                        "description": "ProcessingStatus is the state of Status.",
+                        "enum": [
+                            "processing",
+                            "complete",
+                            "warning",
+                            "failed",
+                            "deferred"
+                        ],
+                        "example": "failed",
+                        "type": "string"
+                    },
                     "rating": {
                         "type": "integer"
                     },
@@ -805,12 +1043,25 @@ const docTemplate = `{
                     "taken_time": {
                         "type": "string"
                     },
+                    "thumbnail_urls": {
+                        "additionalProperties": {
+                            "type": "string"
+                        },
+                        "type": "object"
+                    },
                     "type": {
                         "type": "string"
                     },
                     "upload_time": {
                         "type": "string"
                     },
+                    "urls_expire_at": {
+                        "type": "string"
+                    },
+                    "web_url": {
+                        "example": "/api/v1/assets/550e8400-e29b-41d4-a716-446655440000/video/web?mt=eyJhbGciOi...",
+                        "type": "string"
+                    },
                     "width": {
                         "type": "integer"
                     }
@@ -829,6 +1080,11 @@ const docTemplate = `{
                     "asset_id": {
                         "type": "string"
                     },
+                    "blurhash": {
+                        "description": "BlurHash is a placeholder to paint until the thumbnail loads.",
+                        "example": "LEHV6nWB2yk8pyo0adR*.7kCMdnj",
+                        "type": "string"
+                    },
                     "capture_offset_minutes": {
                         "type": "integer"
                     },
@@ -865,9 +1121,31 @@ const docTemplate = `{
                     "original_filename": {
                         "type": "string"
                     },
+                    "original_url": {
+                        "description": "Signed media URLs (populated only when the request sets include_urls)",
+                        "example": "/api/v1/assets/550e8400-e29b-41d4-a716-446655440000/original?mt=eyJhbGciOi...",
+                        "type": "string"
+                    },
                     "owner_id": {
                         "type": "integer"
                     },
+                    "processing_error": {
+                        "description": "ProcessingError is why a failed or warning asset failed: its latest task error, else the status message.",
+                        "example": "thumbnail_asset: unsupported image format",
+                        "type": "string"
+                    },
+                    "processing_status": {
+                        "description": "ProcessingStatus is the state of Status.",
+                        "enum": [
+                            "processing",
+                            "complete",
+                            "warning",
+                            "failed",
+                            "deferred"
+                        ],
+                        "example": "failed",
+                        "type": "string"
+                    },
                     "rating": {
                         "type": "integer"
                     },
@@ -918,6 +1196,12 @@ const docTemplate = `{
                     "taken_time": {
                         "type": "string"
                     },
+                    "thumbnail_urls": {
+                        "additionalProperties": {
+                            "type": "string"
+                        },
+                        "type": "object"
+                    },
                     "thumbnails": {
                         "items": {
                             "$ref": "#/components/schemas/dto.AssetThumbnailDTO"
@@ -931,12 +1215,31 @@ const docTemplate = `{
                     "upload_time": {
                         "type": "string"
                     },
+                    "urls_expire_at": {
+                        "type": "string"
+                    },
+                    "web_url": {
+                        "example": "/api/v1/assets/550e8400-e29b-41d4-a716-446655440000/video/web?mt=eyJhbGciOi...",
+                        "type": "string"
+                    },
                     "width": {
                         "type": "integer"
                     }
                 },
                 "type": "object"
             },
+            "dto.AssetDownloadURLResponseDTO": {
+                "properties": {
+                    "expires_at": {
+                        "type": "string"
+                    },
+                    "url": {
+                        "example": "/api/v1/public/assets/550e8400-e29b-41d4-a716-446655440000?token=eyJhbGciOi...",
+                        "type": "string"
+                    }
+                },
+                "type": "object"
+            },
             "dto.AssetExifResponseDTO": {
                 "properties": {
                     "asset_id": {
@@ -1014,7 +1317,7 @@ const docTemplate = `{
                 "type": "object"
             },
             "dto.AssetFilterDTO": {
-                "description": "Unified filter options",
+                "description": "Rules is the filter the album's assets must match, as sent to POST /assets/list. An empty filter matches every asset of the owner.",
                 "properties": {
                     "album_id": {
                         "example": 123,
@@ -1024,6 +1327,10 @@ const docTemplate = `{
                         "example": "Canon EOS R5",
                         "type": "string"
                     },
+                    "custom_fields": {
+                        "description": "CustomFields matches assets whose custom fields contain every given pair.",
+                        "type": "object"
+                    },
                     "date": {
                         "$ref": "#/components/schemas/dto.DateRangeDTO"
                     },
@@ -1062,6 +1369,18 @@ const docTemplate = `{
                         "example": 42,
                         "type": "integer"
                     },
+                    "processing_status": {
+                        "description": "ProcessingStatus matches assets whose processing is in this state.",
+                        "enum": [
+                            "processing",
+                            "complete",
+                            "warning",
+                            "failed",
+                            "deferred"
+                        ],
+                        "example": "failed",
+                        "type": "string"
+                    },
                     "rating": {
                         "example": 5,
                         "maximum": 5,
@@ -1076,6 +1395,22 @@ const docTemplate = `{
                         "example": "550e8400-e29b-41d4-a716-446655440000",
                         "type": "string"
                     },
+                    "tag_match": {
+                        "description": "all (default) or any of TagNames; only search_type \"tag\" honours it",
+                        "enum": [
+                            "all",
+                            "any"
+                        ],
+                        "example": "any",
+                        "type": "string"
+                    },
+                    "tag_min_confidence": {
+                        "description": "only search_type \"tag\" honours it",
+                        "example": 0.5,
+                        "maximum": 1,
+                        "minimum": 0,
+                        "type": "number"
+                    },
                     "tag_name": {
                         "example": "document",
                         "type": "string"
@@ -1115,49 +1450,125 @@ const docTemplate = `{
                 },
                 "type": "object"
             },
-            "dto.AssetIndexingStatsResponseDTO": {
+            "dto.AssetFilterFieldErrorDTO": {
                 "properties": {
-                    "photo_total": {
-                        "example": 2400,
-                        "type": "integer"
-                    },
-                    "reindex_jobs": {
-                        "example": 1,
-                        "type": "integer"
+                    "field": {
+                        "example": "filter.type",
+                        "type": "string"
                     },
-                    "tasks": {
-                        "$ref": "#/components/schemas/dto.AssetIndexingTaskSetStatsDTO"
+                    "message": {
+                        "example": "must be one of PHOTO, VIDEO, AUDIO; got \"photo\"",
+                        "type": "string"
                     }
                 },
                 "type": "object"
             },
-            "dto.AssetIndexingTaskSetStatsDTO": {
+            "dto.AssetFilterPreviewRequestDTO": {
                 "properties": {
-                    "bioclip": {
-                        "$ref": "#/components/schemas/dto.AssetIndexingTaskStatsDTO"
+                    "filter": {
+                        "$ref": "#/components/schemas/dto.AssetFilterDTO"
                     },
-                    "face": {
-                        "$ref": "#/components/schemas/dto.AssetIndexingTaskStatsDTO"
+                    "sample_size": {
+                        "description": "Sample asset IDs to return, default 5",
+                        "example": 5,
+                        "maximum": 20,
+                        "minimum": 1,
+                        "type": "integer"
                     },
-                    "ocr": {
-                        "$ref": "#/components/schemas/dto.AssetIndexingTaskStatsDTO"
+                    "sort_by": {
+                        "enum": [
+                            "recently_added",
+                            "date_captured"
+                        ],
+                        "example": "date_captured",
+                        "type": "string"
                     },
-                    "semantic": {
-                        "$ref": "#/components/schemas/dto.AssetIndexingTaskStatsDTO"
+                    "viewer_timezone": {
+                        "example": "America/New_York",
+                        "type": "string"
                     }
                 },
                 "type": "object"
             },
-            "dto.AssetIndexingTaskStatsDTO": {
+            "dto.AssetFilterPreviewResponseDTO": {
                 "properties": {
-                    "indexed_count": {
-                        "example": 1200,
-                        "type": "integer"
+                    "sample_asset_ids": {
+                        "items": {
+                            "type": "string"
+                        },
+                        "type": "array",
+                        "uniqueItems": false
                     },
-                    "queued_jobs": {
-                        "example": 12,
+                    "total": {
+                        "example": 1250,
                         "type": "integer"
-                    },
+                    }
+                },
+                "type": "object"
+            },
+            "dto.AssetFilterValidationErrorDTO": {
+                "properties": {
+                    "code": {
+                        "example": 400,
+                        "type": "integer"
+                    },
+                    "errors": {
+                        "items": {
+                            "$ref": "#/components/schemas/dto.AssetFilterFieldErrorDTO"
+                        },
+                        "type": "array",
+                        "uniqueItems": false
+                    },
+                    "message": {
+                        "example": "Invalid asset filter",
+                        "type": "string"
+                    }
+                },
+                "type": "object"
+            },
+            "dto.AssetIndexingStatsResponseDTO": {
+                "properties": {
+                    "photo_total": {
+                        "example": 2400,
+                        "type": "integer"
+                    },
+                    "reindex_jobs": {
+                        "example": 1,
+                        "type": "integer"
+                    },
+                    "tasks": {
+                        "$ref": "#/components/schemas/dto.AssetIndexingTaskSetStatsDTO"
+                    }
+                },
+                "type": "object"
+            },
+            "dto.AssetIndexingTaskSetStatsDTO": {
+                "properties": {
+                    "bioclip": {
+                        "$ref": "#/components/schemas/dto.AssetIndexingTaskStatsDTO"
+                    },
+                    "face": {
+                        "$ref": "#/components/schemas/dto.AssetIndexingTaskStatsDTO"
+                    },
+                    "ocr": {
+                        "$ref": "#/components/schemas/dto.AssetIndexingTaskStatsDTO"
+                    },
+                    "semantic": {
+                        "$ref": "#/components/schemas/dto.AssetIndexingTaskStatsDTO"
+                    }
+                },
+                "type": "object"
+            },
+            "dto.AssetIndexingTaskStatsDTO": {
+                "properties": {
+                    "indexed_count": {
+                        "example": 1200,
+                        "type": "integer"
+                    },
+                    "queued_jobs": {
+                        "example": 12,
+                        "type": "integer"
+                    },
                     "total_count": {
                         "example": 2400,
                         "type": "integer"
@@ -1242,6 +1653,23 @@ const docTemplate = `{
                 },
                 "type": "object"
             },
+            "dto.AssetNeighborsResponseDTO": {
+                "properties": {
+                    "asset_id": {
+                        "example": "550e8400-e29b-41d4-a716-446655440000",
+                        "type": "string"
+                    },
+                    "next_id": {
+                        "example": "550e8400-e29b-41d4-a716-446655440002",
+                        "type": "string"
+                    },
+                    "previous_id": {
+                        "example": "550e8400-e29b-41d4-a716-446655440001",
+                        "type": "string"
+                    }
+                },
+                "type": "object"
+            },
             "dto.AssetOCRResultDTO": {
                 "properties": {
                     "created_at": {
@@ -1297,6 +1725,11 @@ const docTemplate = `{
                     "filter": {
                         "$ref": "#/components/schemas/dto.AssetFilterDTO"
                     },
+                    "include_urls": {
+                        "description": "Attach short-lived signed media URLs to each asset",
+                        "example": false,
+                        "type": "boolean"
+                    },
                     "pagination": {
                         "$ref": "#/components/schemas/dto.PaginationDTO"
                     },
@@ -1337,6 +1770,17 @@ const docTemplate = `{
                 },
                 "type": "object"
             },
+            "dto.AssetRawTagsResponseDTO": {
+                "properties": {
+                    "asset_id": {
+                        "type": "string"
+                    },
+                    "tags": {
+                        "type": "object"
+                    }
+                },
+                "type": "object"
+            },
             "dto.AssetSidecarResponseDTO": {
                 "properties": {
                     "asset_id": {
@@ -1353,6 +1797,77 @@ const docTemplate = `{
                 },
                 "type": "object"
             },
+            "dto.AssetSizeMismatchDTO": {
+                "properties": {
+                    "actual_size": {
+                        "example": 1048576,
+                        "type": "integer"
+                    },
+                    "asset_id": {
+                        "example": "550e8400-e29b-41d4-a716-446655440000",
+                        "type": "string"
+                    },
+                    "checked_at": {
+                        "type": "string"
+                    },
+                    "original_filename": {
+                        "example": "IMG_0001.jpg",
+                        "type": "string"
+                    },
+                    "recorded_size": {
+                        "example": 4821733,
+                        "type": "integer"
+                    },
+                    "storage_path": {
+                        "example": "2024/06/IMG_0001.jpg",
+                        "type": "string"
+                    }
+                },
+                "type": "object"
+            },
+            "dto.AssetSrcsetResponseDTO": {
+                "properties": {
+                    "asset_id": {
+                        "type": "string"
+                    },
+                    "sources": {
+                        "items": {
+                            "$ref": "#/components/schemas/dto.AssetSrcsetSourceDTO"
+                        },
+                        "type": "array",
+                        "uniqueItems": false
+                    },
+                    "srcset": {
+                        "example": "/api/v1/assets/550e8400-e29b-41d4-a716-446655440000/thumbnail?size=small 400w, /api/v1/assets/550e8400-e29b-41d4-a716-446655440000/thumbnail?size=medium 800w",
+                        "type": "string"
+                    },
+                    "urls_expire_at": {
+                        "type": "string"
+                    }
+                },
+                "type": "object"
+            },
+            "dto.AssetSrcsetSourceDTO": {
+                "properties": {
+                    "height": {
+                        "example": 534,
+                        "type": "integer"
+                    },
+                    "size": {
+                        "example": "medium",
+                        "type": "string"
+                    },
+                    "url": {
+                        "example": "/api/v1/assets/550e8400-e29b-41d4-a716-446655440000/thumbnail?size=medium\u0026mt=eyJhbGciOi...",
+                        "type": "string"
+                    },
+                    "width": {
+                        "example": 800,
+                        "type": "integer"
+                    }
+                },
+                "type": "object"
+            },
             "dto.AssetTagDTO": {
                 "properties": {
                     "confidence": {
@@ -1412,6 +1927,42 @@ const docTemplate = `{
                 },
                 "type": "object"
             },
+            "dto.AssignLegacyAssetsRequestDTO": {
+                "properties": {
+                    "repository_id": {
+                        "example": "550e8400-e29b-41d4-a716-446655440000",
+                        "type": "string"
+                    }
+                },
+                "required": [
+                    "repository_id"
+                ],
+                "type": "object"
+            },
+            "dto.AssignLegacyAssetsResponseDTO": {
+                "properties": {
+                    "repository_id": {
+                        "example": "550e8400-e29b-41d4-a716-446655440000",
+                        "type": "string"
+                    },
+                    "resolved": {
+                        "example": 120,
+                        "type": "integer"
+                    },
+                    "unresolved": {
+                        "example": 3,
+                        "type": "integer"
+                    },
+                    "unresolved_asset_ids": {
+                        "items": {
+                            "type": "string"
+                        },
+                        "type": "array",
+                        "uniqueItems": false
+                    }
+                },
+                "type": "object"
+            },
             "dto.AuthResponseDTO": {
                 "properties": {
                     "bootstrap_admin": {
@@ -1517,6 +2068,57 @@ const docTemplate = `{
                 },
                 "type": "object"
             },
+            "dto.BatchDeleteAssetsRequestDTO": {
+                "properties": {
+                    "asset_ids": {
+                        "example": [
+                            "550e8400-e29b-41d4-a716-446655440000",
+                            "550e8400-e29b-41d4-a716-446655440001"
+                        ],
+                        "items": {
+                            "type": "string"
+                        },
+                        "maxItems": 100,
+                        "type": "array",
+                        "uniqueItems": false
+                    },
+                    "hard": {
+                        "example": false,
+                        "type": "boolean"
+                    }
+                },
+                "required": [
+                    "asset_ids"
+                ],
+                "type": "object"
+            },
+            "dto.BatchDeleteResponseDTO": {
+                "properties": {
+                    "results": {
+                        "items": {
+                            "$ref": "#/components/schemas/dto.BatchDeleteResultDTO"
+                        },
+                        "type": "array",
+                        "uniqueItems": false
+                    }
+                },
+                "type": "object"
+            },
+            "dto.BatchDeleteResultDTO": {
+                "properties": {
+                    "asset_id": {
+                        "example": "550e8400-e29b-41d4-a716-446655440000",
+                        "type": "string"
+                    },
+                    "error": {
+                        "type": "string"
+                    },
+                    "success": {
+                        "type": "boolean"
+                    }
+                },
+                "type": "object"
+            },
             "dto.BatchUploadResponseDTO": {
                 "properties": {
                     "results": {
@@ -1534,6 +2136,11 @@ const docTemplate = `{
                     "content_hash": {
                         "type": "string"
                     },
+                    "duplicate_action": {
+                        "description": "DuplicateAction is as in UploadResponseDTO.",
+                        "example": "renamed",
+                        "type": "string"
+                    },
                     "error": {
                         "type": "string"
                     },
@@ -1543,6 +2150,9 @@ const docTemplate = `{
                     "message": {
                         "type": "string"
                     },
+                    "session_id": {
+                        "type": "string"
+                    },
                     "size": {
                         "type": "integer"
                     },
@@ -1623,6 +2233,27 @@ const docTemplate = `{
                 },
                 "type": "object"
             },
+            "dto.CancelUploadBatchResponseDTO": {
+                "properties": {
+                    "batch_id": {
+                        "example": "550e8400-e29b-41d4-a716-446655440000",
+                        "type": "string"
+                    },
+                    "cancelled_jobs": {
+                        "example": 120,
+                        "type": "integer"
+                    },
+                    "status": {
+                        "example": "cancelled",
+                        "type": "string"
+                    },
+                    "total_jobs": {
+                        "example": 150,
+                        "type": "integer"
+                    }
+                },
+                "type": "object"
+            },
             "dto.CapabilitiesResponseDTO": {
                 "properties": {
                     "llm": {
@@ -1650,15 +2281,72 @@ const docTemplate = `{
                 ],
                 "type": "object"
             },
-            "dto.ClassifierPreviewMatchDTO": {
+            "dto.CheckHashesRequestDTO": {
                 "properties": {
-                    "asset_id": {
-                        "type": "string"
+                    "hashes": {
+                        "items": {
+                            "type": "string"
+                        },
+                        "maxItems": 1000,
+                        "minItems": 1,
+                        "type": "array",
+                        "uniqueItems": false
                     },
-                    "score": {
-                        "type": "number"
-                    }
-                },
+                    "repository_id": {
+                        "example": "550e8400-e29b-41d4-a716-446655440000",
+                        "type": "string"
+                    }
+                },
+                "required": [
+                    "hashes"
+                ],
+                "type": "object"
+            },
+            "dto.CheckHashesResponseDTO": {
+                "properties": {
+                    "existing_count": {
+                        "example": 2,
+                        "type": "integer"
+                    },
+                    "results": {
+                        "items": {
+                            "$ref": "#/components/schemas/dto.CheckHashesResultDTO"
+                        },
+                        "type": "array",
+                        "uniqueItems": false
+                    }
+                },
+                "type": "object"
+            },
+            "dto.CheckHashesResultDTO": {
+                "properties": {
+                    "asset_ids": {
+                        "items": {
+                            "type": "string"
+                        },
+                        "type": "array",
+                        "uniqueItems": false
+                    },
+                    "exists": {
+                        "example": true,
+                        "type": "boolean"
+                    },
+                    "hash": {
+                        "example": "af1349b9f5f9a1a6a0404dea36dcc9499bcb25c9adc112b7cc9a93cae41f3262",
+                        "type": "string"
+                    }
+                },
+                "type": "object"
+            },
+            "dto.ClassifierPreviewMatchDTO": {
+                "properties": {
+                    "asset_id": {
+                        "type": "string"
+                    },
+                    "score": {
+                        "type": "number"
+                    }
+                },
                 "type": "object"
             },
             "dto.ClassifierPreviewRequestDTO": {
@@ -1936,6 +2624,67 @@ const docTemplate = `{
                 ],
                 "type": "object"
             },
+            "dto.ContactSheetRequestDTO": {
+                "properties": {
+                    "album_id": {
+                        "example": 123,
+                        "type": "integer"
+                    },
+                    "columns": {
+                        "example": 6,
+                        "maximum": 20,
+                        "minimum": 1,
+                        "type": "integer"
+                    },
+                    "filter": {
+                        "$ref": "#/components/schemas/dto.AssetFilterDTO"
+                    },
+                    "format": {
+                        "description": "Default jpeg",
+                        "enum": [
+                            "jpeg",
+                            "png"
+                        ],
+                        "example": "jpeg",
+                        "type": "string"
+                    },
+                    "labels": {
+                        "description": "Print the filename under each tile",
+                        "example": true,
+                        "type": "boolean"
+                    },
+                    "rows": {
+                        "example": 4,
+                        "maximum": 20,
+                        "minimum": 1,
+                        "type": "integer"
+                    },
+                    "sort_by": {
+                        "enum": [
+                            "recently_added",
+                            "date_captured"
+                        ],
+                        "example": "date_captured",
+                        "type": "string"
+                    },
+                    "tile_size": {
+                        "description": "Edge of each tile in pixels, default 256",
+                        "example": 256,
+                        "maximum": 800,
+                        "minimum": 64,
+                        "type": "integer"
+                    },
+                    "viewer_timezone": {
+                        "example": "America/New_York",
+                        "type": "string"
+                    }
+                },
+                "required": [
+                    "columns",
+                    "rows"
+                ],
+                "type": "object"
+            },
             "dto.CreateAgentPinRequest": {
                 "properties": {
                     "layout": {
@@ -2056,7 +2805,9 @@ const docTemplate = `{
                         "enum": [
                             "rename",
                             "uuid",
-                            "overwrite"
+                            "overwrite",
+                            "skip",
+                            "date_prefix"
                         ],
                         "example": "rename",
                         "type": "string"
@@ -2115,6 +2866,31 @@ const docTemplate = `{
                 },
                 "type": "object"
             },
+            "dto.CreateResumableUploadRequestDTO": {
+                "properties": {
+                    "content_type": {
+                        "example": "video/quicktime",
+                        "type": "string"
+                    },
+                    "filename": {
+                        "example": "holiday.mov",
+                        "type": "string"
+                    },
+                    "repository_id": {
+                        "type": "string"
+                    },
+                    "size": {
+                        "example": 4294967296,
+                        "minimum": 1,
+                        "type": "integer"
+                    }
+                },
+                "required": [
+                    "filename",
+                    "size"
+                ],
+                "type": "object"
+            },
             "dto.CreateShareLinkRequestDTO": {
                 "properties": {
                     "allow_download": {
@@ -2139,6 +2915,11 @@ const docTemplate = `{
                     "include_originals": {
                         "type": "boolean"
                     },
+                    "password": {
+                        "description": "Password, when set, must be presented before the share can be viewed.",
+                        "maxLength": 72,
+                        "type": "string"
+                    },
                     "source_kind": {
                         "enum": [
                             "asset_snapshot",
@@ -2185,6 +2966,9 @@ const docTemplate = `{
                     "last_viewed_at": {
                         "type": "string"
                     },
+                    "password_protected": {
+                        "type": "boolean"
+                    },
                     "revoked_at": {
                         "type": "string"
                     },
@@ -2228,6 +3012,23 @@ const docTemplate = `{
                 },
                 "type": "object"
             },
+            "dto.CreateSmartAlbumRequestDTO": {
+                "properties": {
+                    "album_name": {
+                        "type": "string"
+                    },
+                    "description": {
+                        "type": "string"
+                    },
+                    "rules": {
+                        "$ref": "#/components/schemas/dto.AssetFilterDTO"
+                    }
+                },
+                "required": [
+                    "album_name"
+                ],
+                "type": "object"
+            },
             "dto.CreateUploadSessionRequestDTO": {
                 "properties": {
                     "client_fingerprint": {
@@ -2262,6 +3063,7 @@ const docTemplate = `{
                 "type": "object"
             },
             "dto.DateRangeDTO": {
+                "description": "Date bounds the photos by capture time, falling back to upload time.",
                 "properties": {
                     "from": {
                         "type": "string"
@@ -2693,6 +3495,13 @@ const docTemplate = `{
                     "display_cover_asset_id": {
                         "type": "string"
                     },
+                    "is_smart": {
+                        "description": "IsSmart marks albums whose assets are whatever Rules matches when read, rather than a stored list.",
+                        "type": "boolean"
+                    },
+                    "rules": {
+                        "$ref": "#/components/schemas/dto.AssetFilterDTO"
+                    },
                     "updated_at": {
                         "type": "string"
                     },
@@ -2702,6 +3511,49 @@ const docTemplate = `{
                 },
                 "type": "object"
             },
+            "dto.ImportAssetFromURLRequestDTO": {
+                "properties": {
+                    "file_name": {
+                        "description": "FileName overrides the name taken from the response, for URLs that do\nnot end in a media file name.",
+                        "example": "holiday.jpg",
+                        "maxLength": 255,
+                        "type": "string"
+                    },
+                    "repository_id": {
+                        "example": "550e8400-e29b-41d4-a716-446655440000",
+                        "type": "string"
+                    },
+                    "url": {
+                        "example": "https://example.com/photos/holiday.jpg",
+                        "maxLength": 2048,
+                        "type": "string"
+                    }
+                },
+                "required": [
+                    "url"
+                ],
+                "type": "object"
+            },
+            "dto.ImportRepositoryConfigRequestDTO": {
+                "properties": {
+                    "bundle": {
+                        "$ref": "#/components/schemas/dto.RepositoryConfigBundleDTO"
+                    },
+                    "name": {
+                        "example": "Family Photos (copy)",
+                        "type": "string"
+                    },
+                    "root_id": {
+                        "description": "RootID identifies a registered Storage Location. Empty selects the\nconfigured default location.",
+                        "example": "550e8400-e29b-41d4-a716-446655440000",
+                        "type": "string"
+                    }
+                },
+                "required": [
+                    "bundle"
+                ],
+                "type": "object"
+            },
             "dto.IndexingRepositoryListResponseDTO": {
                 "properties": {
                     "repositories": {
@@ -3370,6 +4222,51 @@ const docTemplate = `{
                 },
                 "type": "object"
             },
+            "dto.MergeAlbumsRequestDTO": {
+                "properties": {
+                    "source_album_ids": {
+                        "example": [
+                            14,
+                            15
+                        ],
+                        "items": {
+                            "type": "integer"
+                        },
+                        "maxItems": 100,
+                        "minItems": 1,
+                        "type": "array",
+                        "uniqueItems": false
+                    },
+                    "target_album_id": {
+                        "example": 12,
+                        "type": "integer"
+                    }
+                },
+                "required": [
+                    "source_album_ids",
+                    "target_album_id"
+                ],
+                "type": "object"
+            },
+            "dto.MergeAlbumsResponseDTO": {
+                "properties": {
+                    "album": {
+                        "$ref": "#/components/schemas/dto.GetAlbumResponseDTO"
+                    },
+                    "assets_added": {
+                        "example": 37,
+                        "type": "integer"
+                    },
+                    "removed_album_ids": {
+                        "items": {
+                            "type": "integer"
+                        },
+                        "type": "array",
+                        "uniqueItems": false
+                    }
+                },
+                "type": "object"
+            },
             "dto.MergeDuplicateGroupRequestDTO": {
                 "properties": {
                     "duplicate_asset_ids": {
@@ -3467,6 +4364,18 @@ const docTemplate = `{
                 ],
                 "type": "object"
             },
+            "dto.MergeTagRequestDTO": {
+                "properties": {
+                    "target_tag_id": {
+                        "example": 12,
+                        "type": "integer"
+                    }
+                },
+                "required": [
+                    "target_tag_id"
+                ],
+                "type": "object"
+            },
             "dto.MessageResponseDTO": {
                 "properties": {
                     "message": {
@@ -3509,6 +4418,15 @@ const docTemplate = `{
                         "type": "array",
                         "uniqueItems": false
                     },
+                    "custom_fields": {
+                        "additionalProperties": {
+                            "items": {
+                                "type": "string"
+                            },
+                            "type": "array"
+                        },
+                        "type": "object"
+                    },
                     "lenses": {
                         "items": {
                             "type": "string"
@@ -3519,43 +4437,124 @@ const docTemplate = `{
                 },
                 "type": "object"
             },
-            "dto.PaginationDTO": {
-                "description": "limit, offset",
+            "dto.OrganizeMoveDTO": {
                 "properties": {
-                    "limit": {
-                        "example": 20,
-                        "maximum": 100,
-                        "minimum": 1,
-                        "type": "integer"
+                    "asset_id": {
+                        "example": "550e8400-e29b-41d4-a716-446655440000",
+                        "type": "string"
                     },
-                    "offset": {
-                        "example": 0,
-                        "minimum": 0,
-                        "type": "integer"
+                    "from": {
+                        "example": "inbox/2026/10/IMG_0001.jpg",
+                        "type": "string"
+                    },
+                    "to": {
+                        "example": "inbox/2021/05/IMG_0001.jpg",
+                        "type": "string"
                     }
                 },
                 "type": "object"
             },
-            "dto.PasskeyCredentialSummaryDTO": {
+            "dto.OrganizeRepositoryRequestDTO": {
                 "properties": {
-                    "created_at": {
-                        "type": "string"
-                    },
-                    "label": {
-                        "type": "string"
-                    },
-                    "last_used_at": {
-                        "type": "string"
-                    },
-                    "passkey_id": {
+                    "limit": {
+                        "example": 100,
+                        "maximum": 1000,
+                        "minimum": 1,
                         "type": "integer"
                     },
-                    "transports": {
-                        "items": {
-                            "type": "string"
-                        },
-                        "type": "array",
-                        "uniqueItems": false
+                    "strategy": {
+                        "enum": [
+                            "date",
+                            "cas",
+                            "flat"
+                        ],
+                        "example": "date",
+                        "type": "string"
+                    }
+                },
+                "type": "object"
+            },
+            "dto.OrganizeRepositoryResponseDTO": {
+                "properties": {
+                    "applied": {
+                        "example": 0,
+                        "type": "integer"
+                    },
+                    "dry_run": {
+                        "example": true,
+                        "type": "boolean"
+                    },
+                    "moves": {
+                        "items": {
+                            "$ref": "#/components/schemas/dto.OrganizeMoveDTO"
+                        },
+                        "type": "array",
+                        "uniqueItems": false
+                    },
+                    "repository_id": {
+                        "example": "550e8400-e29b-41d4-a716-446655440000",
+                        "type": "string"
+                    },
+                    "skipped": {
+                        "example": 2,
+                        "type": "integer"
+                    },
+                    "strategy": {
+                        "example": "date",
+                        "type": "string"
+                    },
+                    "total_moves": {
+                        "example": 240,
+                        "type": "integer"
+                    },
+                    "unchanged": {
+                        "example": 1180,
+                        "type": "integer"
+                    }
+                },
+                "type": "object"
+            },
+            "dto.PaginationDTO": {
+                "description": "limit, offset",
+                "properties": {
+                    "cursor": {
+                        "description": "next_cursor of the previous page; replaces offset. Only POST /assets/list honours it",
+                        "type": "string"
+                    },
+                    "limit": {
+                        "example": 20,
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer"
+                    },
+                    "offset": {
+                        "example": 0,
+                        "minimum": 0,
+                        "type": "integer"
+                    }
+                },
+                "type": "object"
+            },
+            "dto.PasskeyCredentialSummaryDTO": {
+                "properties": {
+                    "created_at": {
+                        "type": "string"
+                    },
+                    "label": {
+                        "type": "string"
+                    },
+                    "last_used_at": {
+                        "type": "string"
+                    },
+                    "passkey_id": {
+                        "type": "integer"
+                    },
+                    "transports": {
+                        "items": {
+                            "type": "string"
+                        },
+                        "type": "array",
+                        "uniqueItems": false
                     }
                 },
                 "type": "object"
@@ -3741,6 +4740,81 @@ const docTemplate = `{
                 },
                 "type": "object"
             },
+            "dto.PublicAlbumAssetDTO": {
+                "properties": {
+                    "asset_id": {
+                        "type": "string"
+                    },
+                    "duration": {
+                        "type": "number"
+                    },
+                    "height": {
+                        "type": "integer"
+                    },
+                    "taken_time": {
+                        "type": "string"
+                    },
+                    "thumbnail_url": {
+                        "example": "/api/v1/public/shares/7yQhF3z9k2mN8pXeR5tVwL1sJ4bC6dA0/assets/550e8400-e29b-41d4-a716-446655440000/thumbnail",
+                        "type": "string"
+                    },
+                    "type": {
+                        "enum": [
+                            "PHOTO",
+                            "VIDEO",
+                            "AUDIO"
+                        ],
+                        "type": "string"
+                    },
+                    "width": {
+                        "type": "integer"
+                    }
+                },
+                "type": "object"
+            },
+            "dto.PublicAlbumDTO": {
+                "properties": {
+                    "allow_download": {
+                        "type": "boolean"
+                    },
+                    "asset_count": {
+                        "type": "integer"
+                    },
+                    "assets": {
+                        "items": {
+                            "$ref": "#/components/schemas/dto.PublicAlbumAssetDTO"
+                        },
+                        "type": "array",
+                        "uniqueItems": false
+                    },
+                    "created_at": {
+                        "type": "string"
+                    },
+                    "description": {
+                        "type": "string"
+                    },
+                    "expires_at": {
+                        "type": "string"
+                    },
+                    "include_originals": {
+                        "description": "IncludeOriginals tells the viewer whether per-asset original downloads\nare available (GetPublicShareOriginal requires both this and\nAllowDownload); it is a policy flag, not sensitive.",
+                        "type": "boolean"
+                    },
+                    "limit": {
+                        "type": "integer"
+                    },
+                    "offset": {
+                        "type": "integer"
+                    },
+                    "title": {
+                        "type": "string"
+                    },
+                    "total": {
+                        "type": "integer"
+                    }
+                },
+                "type": "object"
+            },
             "dto.PublicAssetDTO": {
                 "properties": {
                     "asset_id": {
@@ -3858,6 +4932,11 @@ const docTemplate = `{
                         "example": 20,
                         "type": "integer"
                     },
+                    "next_cursor": {
+                        "description": "Pass as pagination.cursor to fetch the next page; omitted on the last page",
+                        "example": "MTcwNTMxMjAwMDAwMDAwMDo1NTBlODQwMC1lMjliLTQxZDQtYTcxNi00NDY2NTU0NDAwMDA",
+                        "type": "string"
+                    },
                     "offset": {
                         "example": 0,
                         "type": "integer"
@@ -3900,6 +4979,14 @@ const docTemplate = `{
             },
             "dto.RebuildAssetIndexesRequestDTO": {
                 "properties": {
+                    "date": {
+                        "$ref": "#/components/schemas/dto.DateRangeDTO"
+                    },
+                    "dry_run": {
+                        "description": "DryRun counts the matching photos without queueing anything.",
+                        "example": false,
+                        "type": "boolean"
+                    },
                     "limit": {
                         "example": 200,
                         "maximum": 500,
@@ -3929,6 +5016,10 @@ const docTemplate = `{
                         },
                         "type": "array",
                         "uniqueItems": false
+                    },
+                    "viewer_timezone": {
+                        "example": "America/New_York",
+                        "type": "string"
                     }
                 },
                 "type": "object"
@@ -3942,6 +5033,10 @@ const docTemplate = `{
                         "type": "array",
                         "uniqueItems": false
                     },
+                    "dry_run": {
+                        "example": false,
+                        "type": "boolean"
+                    },
                     "job_id": {
                         "example": 123,
                         "type": "integer"
@@ -3950,6 +5045,11 @@ const docTemplate = `{
                         "example": 200,
                         "type": "integer"
                     },
+                    "matching_assets": {
+                        "description": "MatchingAssets is how many photos the rebuild visits. Missing-only\nruns queue at most limit of them per request.",
+                        "example": 1200,
+                        "type": "integer"
+                    },
                     "message": {
                         "example": "Index rebuild job queued successfully",
                         "type": "string"
@@ -3976,6 +5076,62 @@ const docTemplate = `{
                 },
                 "type": "object"
             },
+            "dto.RebuildFileRecordsRequestDTO": {
+                "properties": {
+                    "dry_run": {
+                        "example": false,
+                        "type": "boolean"
+                    }
+                },
+                "type": "object"
+            },
+            "dto.RebuildFileRecordsResponseDTO": {
+                "properties": {
+                    "assigned": {
+                        "example": 40,
+                        "type": "integer"
+                    },
+                    "dry_run": {
+                        "example": false,
+                        "type": "boolean"
+                    },
+                    "matched": {
+                        "example": 1180,
+                        "type": "integer"
+                    },
+                    "missing_asset_ids": {
+                        "items": {
+                            "type": "string"
+                        },
+                        "type": "array",
+                        "uniqueItems": false
+                    },
+                    "partial": {
+                        "example": false,
+                        "type": "boolean"
+                    },
+                    "relinked": {
+                        "example": 12,
+                        "type": "integer"
+                    },
+                    "repository_id": {
+                        "example": "550e8400-e29b-41d4-a716-446655440000",
+                        "type": "string"
+                    },
+                    "unmatched": {
+                        "example": 3,
+                        "type": "integer"
+                    },
+                    "unmatched_files": {
+                        "items": {
+                            "type": "string"
+                        },
+                        "type": "array",
+                        "uniqueItems": false
+                    }
+                },
+                "type": "object"
+            },
             "dto.RebuildLocationClustersRequestDTO": {
                 "properties": {
                     "repository_id": {
@@ -4075,54 +5231,166 @@ const docTemplate = `{
                 ],
                 "type": "object"
             },
-            "dto.RepositoryCloudStatusDTO": {
+            "dto.RenameTagRequestDTO": {
                 "properties": {
-                    "credential": {
-                        "$ref": "#/components/schemas/dto.CloudCredentialDTO"
-                    },
-                    "enabled": {
-                        "example": true,
-                        "type": "boolean"
-                    },
-                    "last_import_run_id": {
-                        "type": "string"
-                    },
-                    "latest_run": {
-                        "$ref": "#/components/schemas/dto.CloudImportRunDTO"
-                    },
-                    "owner_id": {
-                        "example": 123,
-                        "type": "integer"
-                    },
-                    "provider": {
-                        "example": "icloud",
+                    "tag_name": {
+                        "example": "dog",
                         "type": "string"
                     }
                 },
+                "required": [
+                    "tag_name"
+                ],
                 "type": "object"
             },
-            "dto.RepositoryConflictDTO": {
+            "dto.ReorderAlbumAssetsRequestDTO": {
                 "properties": {
-                    "actions": {
-                        "example": [
-                            "relocate",
-                            "copy"
-                        ],
+                    "asset_ids": {
                         "items": {
                             "type": "string"
                         },
                         "type": "array",
                         "uniqueItems": false
+                    }
+                },
+                "required": [
+                    "asset_ids"
+                ],
+                "type": "object"
+            },
+            "dto.RepositoryAssetStatsDTO": {
+                "properties": {
+                    "audio_count": {
+                        "example": 10,
+                        "type": "integer"
                     },
-                    "code": {
-                        "example": 409,
+                    "average_rating": {
+                        "description": "AverageRating is over rated assets only and omitted when none is rated.",
+                        "example": 3.7,
+                        "type": "number"
+                    },
+                    "liked_count": {
+                        "example": 85,
                         "type": "integer"
                     },
-                    "conflict_type": {
-                        "example": "repository_identity",
+                    "newest_upload": {
                         "type": "string"
                     },
-                    "message": {
+                    "oldest_upload": {
+                        "type": "string"
+                    },
+                    "owner_id": {
+                        "example": 1,
+                        "type": "integer"
+                    },
+                    "photo_count": {
+                        "example": 1100,
+                        "type": "integer"
+                    },
+                    "rated_count": {
+                        "example": 300,
+                        "type": "integer"
+                    },
+                    "repository_id": {
+                        "example": "550e8400-e29b-41d4-a716-446655440000",
+                        "type": "string"
+                    },
+                    "total_assets": {
+                        "example": 1250,
+                        "type": "integer"
+                    },
+                    "total_size": {
+                        "description": "TotalSize is the sum of the original file sizes in bytes.",
+                        "example": 52428800000,
+                        "type": "integer"
+                    },
+                    "video_count": {
+                        "example": 140,
+                        "type": "integer"
+                    }
+                },
+                "type": "object"
+            },
+            "dto.RepositoryCloudStatusDTO": {
+                "properties": {
+                    "credential": {
+                        "$ref": "#/components/schemas/dto.CloudCredentialDTO"
+                    },
+                    "enabled": {
+                        "example": true,
+                        "type": "boolean"
+                    },
+                    "last_import_run_id": {
+                        "type": "string"
+                    },
+                    "latest_run": {
+                        "$ref": "#/components/schemas/dto.CloudImportRunDTO"
+                    },
+                    "owner_id": {
+                        "example": 123,
+                        "type": "integer"
+                    },
+                    "provider": {
+                        "example": "icloud",
+                        "type": "string"
+                    }
+                },
+                "type": "object"
+            },
+            "dto.RepositoryConfigBundleDTO": {
+                "properties": {
+                    "exported_at": {
+                        "example": "2026-01-01T00:00:00Z",
+                        "type": "string"
+                    },
+                    "kind": {
+                        "example": "lumilio.repository-config",
+                        "type": "string"
+                    },
+                    "local_settings": {
+                        "$ref": "#/components/schemas/dto.RepositoryLocalSettings"
+                    },
+                    "name": {
+                        "example": "Family Photos",
+                        "type": "string"
+                    },
+                    "storage_strategy": {
+                        "example": "date",
+                        "type": "string"
+                    },
+                    "version": {
+                        "example": 1,
+                        "type": "integer"
+                    }
+                },
+                "required": [
+                    "kind",
+                    "version"
+                ],
+                "type": "object"
+            },
+            "dto.RepositoryConflictDTO": {
+                "properties": {
+                    "actions": {
+                        "example": [
+                            "relocate",
+                            "copy"
+                        ],
+                        "items": {
+                            "type": "string"
+                        },
+                        "type": "array",
+                        "uniqueItems": false
+                    },
+                    "code": {
+                        "example": 409,
+                        "type": "integer"
+                    },
+                    "conflict_type": {
+                        "example": "repository_identity",
+                        "type": "string"
+                    },
+                    "message": {
                         "example": "Repository identity is already registered",
                         "type": "string"
                     },
@@ -4203,9 +5471,57 @@ const docTemplate = `{
             },
             "dto.RepositoryLocalSettings": {
                 "properties": {
+                    "archive_path": {
+                        "description": "ArchivePath is the absolute directory archived originals are moved to.\nOmitted in an update to keep it.",
+                        "example": "/mnt/cold/photos",
+                        "type": "string"
+                    },
                     "handle_duplicate_filenames": {
                         "example": "uuid",
                         "type": "string"
+                    },
+                    "ml_enabled": {
+                        "description": "MLEnabled false turns ML processing off for the repository; when unset\nthe global ML settings apply. Omitted in an update to keep it.",
+                        "example": false,
+                        "type": "boolean"
+                    },
+                    "original_policy": {
+                        "description": "OriginalPolicy is keep or archive; archive moves originals to\nArchivePath once their web derivatives exist. Omitted in an update to\nkeep it.",
+                        "enum": [
+                            "keep",
+                            "archive"
+                        ],
+                        "example": "keep",
+                        "type": "string"
+                    },
+                    "processing_mode": {
+                        "description": "ProcessingMode is on_upload or manual; manual uploads wait for\nPOST /repositories/{id}/process-pending. Omitted in an update to keep it.",
+                        "enum": [
+                            "on_upload",
+                            "manual"
+                        ],
+                        "example": "on_upload",
+                        "type": "string"
+                    },
+                    "semantic_skip": {
+                        "$ref": "#/components/schemas/dto.RepositorySemanticSkip"
+                    }
+                },
+                "type": "object"
+            },
+            "dto.RepositoryProcessPendingQueuedDTO": {
+                "properties": {
+                    "job_id": {
+                        "example": 12345,
+                        "type": "integer"
+                    },
+                    "repository_id": {
+                        "example": "550e8400-e29b-41d4-a716-446655440000",
+                        "type": "string"
+                    },
+                    "status": {
+                        "example": "queued",
+                        "type": "string"
                     }
                 },
                 "type": "object"
@@ -4327,6 +5643,62 @@ const docTemplate = `{
                 },
                 "type": "object"
             },
+            "dto.RepositorySemanticSkip": {
+                "description": "SemanticSkip leaves matching photos out of semantic (CLIP) indexing\nand so out of AI tagging. Omitted in an update to keep it; an empty\nobject indexes every photo again.",
+                "properties": {
+                    "extensions": {
+                        "description": "Extensions skips photos by file extension, ignoring case.",
+                        "example": [
+                            ".png",
+                            ".tiff"
+                        ],
+                        "items": {
+                            "type": "string"
+                        },
+                        "type": "array",
+                        "uniqueItems": false
+                    },
+                    "screenshots": {
+                        "description": "Screenshots skips photos sized like a common phone, tablet or desktop\nscreen, or named like a screenshot.",
+                        "example": true,
+                        "type": "boolean"
+                    }
+                },
+                "type": "object"
+            },
+            "dto.RepositorySizeCheckQueuedDTO": {
+                "properties": {
+                    "job_id": {
+                        "example": 12345,
+                        "type": "integer"
+                    },
+                    "repository_id": {
+                        "example": "550e8400-e29b-41d4-a716-446655440000",
+                        "type": "string"
+                    },
+                    "status": {
+                        "example": "queued",
+                        "type": "string"
+                    }
+                },
+                "type": "object"
+            },
+            "dto.RepositorySizeMismatchesDTO": {
+                "properties": {
+                    "mismatches": {
+                        "items": {
+                            "$ref": "#/components/schemas/dto.AssetSizeMismatchDTO"
+                        },
+                        "type": "array",
+                        "uniqueItems": false
+                    },
+                    "repository_id": {
+                        "example": "550e8400-e29b-41d4-a716-446655440000",
+                        "type": "string"
+                    }
+                },
+                "type": "object"
+            },
             "dto.ReprocessAssetRequestDTO": {
                 "properties": {
                     "force_full_retry": {
@@ -4401,6 +5773,30 @@ const docTemplate = `{
                 },
                 "type": "object"
             },
+            "dto.ResumableUploadDTO": {
+                "properties": {
+                    "filename": {
+                        "example": "holiday.mov",
+                        "type": "string"
+                    },
+                    "offset": {
+                        "example": 1073741824,
+                        "type": "integer"
+                    },
+                    "session_id": {
+                        "example": "123e4567-e89b-12d3-a456-426614174000",
+                        "type": "string"
+                    },
+                    "size": {
+                        "example": 4294967296,
+                        "type": "integer"
+                    },
+                    "task_id": {
+                        "type": "integer"
+                    }
+                },
+                "type": "object"
+            },
             "dto.RuntimeInfoDTO": {
                 "properties": {
                     "environment": {
@@ -4466,6 +5862,15 @@ const docTemplate = `{
                         "example": "red bird on branch",
                         "type": "string"
                     },
+                    "search_type": {
+                        "description": "\"tag\" matches filter.tag_names, or comma-separated tag names in query",
+                        "enum": [
+                            "text",
+                            "tag"
+                        ],
+                        "example": "text",
+                        "type": "string"
+                    },
                     "sort_by": {
                         "enum": [
                             "recently_added",
@@ -4738,6 +6143,27 @@ const docTemplate = `{
                 },
                 "type": "object"
             },
+            "dto.ShareAlbumRequestDTO": {
+                "properties": {
+                    "allow_download": {
+                        "type": "boolean"
+                    },
+                    "expires_in_days": {
+                        "example": 30,
+                        "maximum": 365,
+                        "minimum": 1,
+                        "type": "integer"
+                    },
+                    "include_originals": {
+                        "type": "boolean"
+                    },
+                    "password": {
+                        "maxLength": 72,
+                        "type": "string"
+                    }
+                },
+                "type": "object"
+            },
             "dto.ShareLinkDTO": {
                 "properties": {
                     "allow_download": {
@@ -4761,6 +6187,9 @@ const docTemplate = `{
                     "last_viewed_at": {
                         "type": "string"
                     },
+                    "password_protected": {
+                        "type": "boolean"
+                    },
                     "revoked_at": {
                         "type": "string"
                     },
@@ -5405,6 +6834,23 @@ const docTemplate = `{
                 },
                 "type": "object"
             },
+            "dto.TagCleanupResponseDTO": {
+                "properties": {
+                    "min_confidence": {
+                        "example": 0.3,
+                        "type": "number"
+                    },
+                    "removed_asset_tags": {
+                        "example": 1204,
+                        "type": "integer"
+                    },
+                    "removed_tags": {
+                        "example": 87,
+                        "type": "integer"
+                    }
+                },
+                "type": "object"
+            },
             "dto.TagDTO": {
                 "properties": {
                     "category": {
@@ -5431,12 +6877,32 @@ const docTemplate = `{
                 },
                 "type": "object"
             },
+            "dto.TagMergeResponseDTO": {
+                "properties": {
+                    "merged_asset_tags": {
+                        "example": 240,
+                        "type": "integer"
+                    },
+                    "removed_tag_id": {
+                        "example": 31,
+                        "type": "integer"
+                    },
+                    "tag": {
+                        "$ref": "#/components/schemas/dto.TagDTO"
+                    }
+                },
+                "type": "object"
+            },
             "dto.TagSummaryDTO": {
                 "properties": {
                     "asset_count": {
                         "example": 37,
                         "type": "integer"
                     },
+                    "category": {
+                        "example": "scene",
+                        "type": "string"
+                    },
                     "cover_asset_id": {
                         "example": "550e8400-e29b-41d4-a716-446655440000",
                         "type": "string"
@@ -5568,6 +7034,14 @@ const docTemplate = `{
                 },
                 "type": "object"
             },
+            "dto.UpdateCustomFieldsRequestDTO": {
+                "properties": {
+                    "fields": {
+                        "type": "object"
+                    }
+                },
+                "type": "object"
+            },
             "dto.UpdateDescriptionRequestDTO": {
                 "properties": {
                     "description": {
@@ -5897,9 +7371,19 @@ const docTemplate = `{
             },
             "dto.UploadResponseDTO": {
                 "properties": {
-                    "content_hash": {
-                        "example": "abcd1234567890",
-                        "type": "string"
+                    "asset_id": {
+                        "description": "AssetID is the existing asset a dedupe upload was linked to.",
+                        "example": "550e8400-e29b-41d4-a716-446655440000",
+                        "type": "string"
+                    },
+                    "content_hash": {
+                        "example": "abcd1234567890",
+                        "type": "string"
+                    },
+                    "duplicate_action": {
+                        "description": "DuplicateAction is what the repository's duplicate file name handling\ndoes because the file name is already in use: renamed, overwritten or\nskipped. Empty when the name is free.",
+                        "example": "renamed",
+                        "type": "string"
                     },
                     "file_name": {
                         "example": "photo.jpg",
@@ -6100,6 +7584,20 @@ const docTemplate = `{
                 ],
                 "type": "object"
             },
+            "handler.AssetTypeCount": {
+                "properties": {
+                    "count": {
+                        "type": "integer"
+                    },
+                    "size_bytes": {
+                        "type": "integer"
+                    },
+                    "type": {
+                        "type": "string"
+                    }
+                },
+                "type": "object"
+            },
             "handler.AvailableYearsResponse": {
                 "properties": {
                     "years": {
@@ -6167,11 +7665,70 @@ const docTemplate = `{
                 },
                 "type": "object"
             },
+            "handler.GearCount": {
+                "properties": {
+                    "count": {
+                        "type": "integer"
+                    },
+                    "name": {
+                        "type": "string"
+                    }
+                },
+                "type": "object"
+            },
+            "handler.GearStatsResponse": {
+                "properties": {
+                    "cameras": {
+                        "items": {
+                            "$ref": "#/components/schemas/handler.GearCount"
+                        },
+                        "type": "array",
+                        "uniqueItems": false
+                    },
+                    "focal_lengths": {
+                        "items": {
+                            "$ref": "#/components/schemas/handler.FocalLengthBucket"
+                        },
+                        "type": "array",
+                        "uniqueItems": false
+                    },
+                    "lenses": {
+                        "items": {
+                            "$ref": "#/components/schemas/handler.GearCount"
+                        },
+                        "type": "array",
+                        "uniqueItems": false
+                    }
+                },
+                "type": "object"
+            },
             "handler.HealthResponse": {
                 "properties": {
+                    "reasons": {
+                        "items": {
+                            "type": "string"
+                        },
+                        "type": "array",
+                        "uniqueItems": false
+                    },
+                    "repositories": {
+                        "items": {
+                            "$ref": "#/components/schemas/handler.RepositoryHealthDTO"
+                        },
+                        "type": "array",
+                        "uniqueItems": false
+                    },
                     "status": {
+                        "enum": [
+                            "ok",
+                            "degraded"
+                        ],
                         "example": "ok",
                         "type": "string"
+                    },
+                    "version": {
+                        "example": "1.0.0",
+                        "type": "string"
                     }
                 },
                 "type": "object"
@@ -6225,6 +7782,17 @@ const docTemplate = `{
                 },
                 "type": "object"
             },
+            "handler.ProcessingStateDTO": {
+                "properties": {
+                    "paused": {
+                        "type": "boolean"
+                    },
+                    "paused_at": {
+                        "type": "string"
+                    }
+                },
+                "type": "object"
+            },
             "handler.QueueErrorSampleDTO": {
                 "properties": {
                     "attempt": {
@@ -6260,6 +7828,41 @@ const docTemplate = `{
                 },
                 "type": "object"
             },
+            "handler.QueueListResponse": {
+                "properties": {
+                    "queues": {
+                        "items": {
+                            "$ref": "#/components/schemas/handler.QueueStateDTO"
+                        },
+                        "type": "array",
+                        "uniqueItems": false
+                    }
+                },
+                "type": "object"
+            },
+            "handler.QueueStateDTO": {
+                "properties": {
+                    "max_workers": {
+                        "type": "integer"
+                    },
+                    "name": {
+                        "type": "string"
+                    },
+                    "paused": {
+                        "type": "boolean"
+                    },
+                    "paused_at": {
+                        "type": "string"
+                    },
+                    "running_jobs": {
+                        "type": "integer"
+                    },
+                    "updated_at": {
+                        "type": "string"
+                    }
+                },
+                "type": "object"
+            },
             "handler.QueueSummaryDTO": {
                 "properties": {
                     "attention_jobs": {
@@ -6317,6 +7920,125 @@ const docTemplate = `{
                 },
                 "type": "object"
             },
+            "handler.ReadinessResponse": {
+                "properties": {
+                    "reasons": {
+                        "items": {
+                            "type": "string"
+                        },
+                        "type": "array",
+                        "uniqueItems": false
+                    },
+                    "status": {
+                        "enum": [
+                            "ready",
+                            "setup_required",
+                            "degraded"
+                        ],
+                        "example": "ready",
+                        "type": "string"
+                    }
+                },
+                "type": "object"
+            },
+            "handler.RepositoryHealthDTO": {
+                "properties": {
+                    "healthy": {
+                        "example": true,
+                        "type": "boolean"
+                    },
+                    "last_sync": {
+                        "type": "string"
+                    },
+                    "missing_directories": {
+                        "items": {
+                            "type": "string"
+                        },
+                        "type": "array",
+                        "uniqueItems": false
+                    },
+                    "name": {
+                        "example": "Primary",
+                        "type": "string"
+                    },
+                    "problems": {
+                        "items": {
+                            "type": "string"
+                        },
+                        "type": "array",
+                        "uniqueItems": false
+                    },
+                    "repository_id": {
+                        "example": "550e8400-e29b-41d4-a716-446655440000",
+                        "type": "string"
+                    },
+                    "status": {
+                        "example": "active",
+                        "type": "string"
+                    },
+                    "structure_valid": {
+                        "example": true,
+                        "type": "boolean"
+                    },
+                    "sync_stale": {
+                        "example": false,
+                        "type": "boolean"
+                    }
+                },
+                "type": "object"
+            },
+            "handler.RepositoryStorage": {
+                "properties": {
+                    "asset_count": {
+                        "type": "integer"
+                    },
+                    "name": {
+                        "type": "string"
+                    },
+                    "repository_id": {
+                        "type": "string"
+                    },
+                    "size_bytes": {
+                        "type": "integer"
+                    }
+                },
+                "type": "object"
+            },
+            "handler.StatsOverviewResponse": {
+                "properties": {
+                    "added_last_30_days": {
+                        "type": "integer"
+                    },
+                    "by_type": {
+                        "items": {
+                            "$ref": "#/components/schemas/handler.AssetTypeCount"
+                        },
+                        "type": "array",
+                        "uniqueItems": false
+                    },
+                    "repositories": {
+                        "items": {
+                            "$ref": "#/components/schemas/handler.RepositoryStorage"
+                        },
+                        "type": "array",
+                        "uniqueItems": false
+                    },
+                    "top_cameras": {
+                        "items": {
+                            "$ref": "#/components/schemas/handler.GearCount"
+                        },
+                        "type": "array",
+                        "uniqueItems": false
+                    },
+                    "total_assets": {
+                        "type": "integer"
+                    },
+                    "total_size_bytes": {
+                        "type": "integer"
+                    }
+                },
+                "type": "object"
+            },
             "handler.TimeBucket": {
                 "properties": {
                     "count": {
@@ -6421,79 +8143,92 @@ const docTemplate = `{
         "url": ""
     },
     "paths": {
-        "/api/v1/admin/river/queue-summary": {
+        "/api/v1/activity": {
             "get": {
-                "description": "Get aggregated processing activity per queue, including recent error samples",
+                "description": "Asset additions and deletions, rating edits and album changes after since (and after_id, for events at the same instant), oldest first. Non-admin users see changes to their own library. Without since the last 24 hours are listed.",
                 "parameters": [
                     {
-                        "description": "Recent error samples per queue (default: 5, max: 20)",
+                        "description": "RFC 3339 timestamp; events at or before it are skipped",
                         "in": "query",
-                        "name": "error_limit",
+                        "name": "since",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Event ID at since to continue after (next_after_id of the previous page)",
+                        "in": "query",
+                        "name": "after_id",
                         "schema": {
                             "type": "integer"
                         }
-                    }
-                ],
-                "requestBody": {
-                    "content": {
-                        "application/json": {
-                            "schema": {
-                                "type": "object"
-                            }
+                    },
+                    {
+                        "description": "Page size (max 500)",
+                        "in": "query",
+                        "name": "limit",
+                        "schema": {
+                            "default": 100,
+                            "type": "integer"
                         }
                     }
-                },
+                ],
                 "responses": {
                     "200": {
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/handler.QueueSummaryResponse"
+                                    "$ref": "#/components/schemas/dto.ActivityFeedDTO"
                                 }
                             }
                         },
                         "description": "OK"
-                    }
-                },
-                "summary": "Get queue summaries",
-                "tags": [
-                    "Queue"
-                ]
-            }
-        },
-        "/api/v1/admin/river/stats": {
-            "get": {
-                "description": "Get aggregated statistics about jobs by state",
-                "requestBody": {
-                    "content": {
-                        "application/json": {
-                            "schema": {
-                                "type": "object"
+                    },
+                    "400": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
                             }
-                        }
-                    }
-                },
-                "responses": {
-                    "200": {
+                        },
+                        "description": "Invalid since or after_id"
+                    },
+                    "401": {
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/handler.JobStatsResponse"
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
                                 }
                             }
                         },
-                        "description": "OK"
+                        "description": "Unauthorized"
+                    },
+                    "500": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Failed to list activity"
                     }
                 },
-                "summary": "Get job statistics",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "summary": "List recent library activity",
                 "tags": [
-                    "Queue"
+                    "activity"
                 ]
             }
         },
-        "/api/v1/agent/chat": {
+        "/api/v1/admin/assign-repository": {
             "post": {
-                "description": "Send a query to agent and receive streaming responses via SSE. Manages conversation threads.",
+                "description": "Attach every asset that has no repository to the given repository. Each asset's file is located under the repository root by its recorded path and the path is rewritten relative to the root. Assets whose file cannot be found are left unchanged and reported.",
                 "requestBody": {
                     "content": {
                         "application/json": {
@@ -6503,31 +8238,31 @@ const docTemplate = `{
                                         "type": "object"
                                     },
                                     {
-                                        "$ref": "#/components/schemas/handler.AgentChatRequest",
+                                        "$ref": "#/components/schemas/dto.AssignLegacyAssetsRequestDTO",
                                         "summary": "request",
-                                        "description": "Chat request"
+                                        "description": "Target repository"
                                     }
                                 ]
                             }
                         }
                     },
-                    "description": "Chat request",
+                    "description": "Target repository",
                     "required": true
                 },
                 "responses": {
                     "200": {
                         "content": {
-                            "text/event-stream": {
+                            "application/json": {
                                 "schema": {
-                                    "type": "string"
+                                    "$ref": "#/components/schemas/dto.AssignLegacyAssetsResponseDTO"
                                 }
                             }
                         },
-                        "description": "SSE stream"
+                        "description": "Assignment finished"
                     },
                     "400": {
                         "content": {
-                            "text/event-stream": {
+                            "application/json": {
                                 "schema": {
                                     "$ref": "#/components/schemas/api.ErrorResponse"
                                 }
@@ -6537,7 +8272,7 @@ const docTemplate = `{
                     },
                     "401": {
                         "content": {
-                            "text/event-stream": {
+                            "application/json": {
                                 "schema": {
                                     "$ref": "#/components/schemas/api.ErrorResponse"
                                 }
@@ -6545,80 +8280,39 @@ const docTemplate = `{
                         },
                         "description": "Unauthorized"
                     },
-                    "500": {
+                    "403": {
                         "content": {
-                            "text/event-stream": {
+                            "application/json": {
                                 "schema": {
                                     "$ref": "#/components/schemas/api.ErrorResponse"
                                 }
                             }
                         },
-                        "description": "Internal server error"
-                    }
-                },
-                "summary": "Chat with Agent",
-                "tags": [
-                    "agent"
-                ]
-            }
-        },
-        "/api/v1/agent/chat/resume": {
-            "post": {
-                "description": "Resume a conversation from an interrupt point (e.g., user confirmation for a tool call)",
-                "requestBody": {
-                    "content": {
-                        "application/json": {
-                            "schema": {
-                                "oneOf": [
-                                    {
-                                        "type": "object"
-                                    },
-                                    {
-                                        "$ref": "#/components/schemas/handler.AgentResumeRequest",
-                                        "summary": "request",
-                                        "description": "Resume request"
-                                    }
-                                ]
-                            }
-                        }
-                    },
-                    "description": "Resume request",
-                    "required": true
-                },
-                "responses": {
-                    "200": {
-                        "content": {
-                            "text/event-stream": {
-                                "schema": {
-                                    "type": "string"
-                                }
-                            }
-                        },
-                        "description": "SSE stream"
+                        "description": "Forbidden"
                     },
-                    "400": {
+                    "404": {
                         "content": {
-                            "text/event-stream": {
+                            "application/json": {
                                 "schema": {
                                     "$ref": "#/components/schemas/api.ErrorResponse"
                                 }
                             }
                         },
-                        "description": "Invalid request"
+                        "description": "Repository not found"
                     },
-                    "401": {
+                    "409": {
                         "content": {
-                            "text/event-stream": {
+                            "application/json": {
                                 "schema": {
                                     "$ref": "#/components/schemas/api.ErrorResponse"
                                 }
                             }
                         },
-                        "description": "Unauthorized"
+                        "description": "Repository is unavailable"
                     },
                     "500": {
                         "content": {
-                            "text/event-stream": {
+                            "application/json": {
                                 "schema": {
                                     "$ref": "#/components/schemas/api.ErrorResponse"
                                 }
@@ -6627,30 +8321,32 @@ const docTemplate = `{
                         "description": "Internal server error"
                     }
                 },
-                "summary": "Resume Agent Chat",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "summary": "Assign legacy assets to a repository",
                 "tags": [
-                    "agent"
+                    "admin"
                 ]
             }
         },
-        "/api/v1/agent/pins": {
+        "/api/v1/admin/processing": {
             "get": {
-                "description": "List all pinned widgets for the current user, in creation order.",
+                "description": "Report whether asset processing is globally paused for maintenance",
                 "responses": {
                     "200": {
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "items": {
-                                        "$ref": "#/components/schemas/dto.AgentPinDTO"
-                                    },
-                                    "type": "array"
+                                    "$ref": "#/components/schemas/handler.ProcessingStateDTO"
                                 }
                             }
                         },
                         "description": "OK"
                     },
-                    "401": {
+                    "500": {
                         "content": {
                             "application/json": {
                                 "schema": {
@@ -6658,48 +8354,30 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Unauthorized"
+                        "description": "Internal Server Error"
                     }
                 },
-                "summary": "List Agent Pins",
+                "summary": "Get the processing pause state",
                 "tags": [
-                    "agent"
+                    "Queue"
                 ]
-            },
+            }
+        },
+        "/api/v1/admin/processing/pause": {
             "post": {
-                "description": "Copy a session ref into a durable board widget. Live mode replays the producing plan on hydration when replayable; otherwise the pin freezes the snapshot.",
-                "requestBody": {
-                    "content": {
-                        "application/json": {
-                            "schema": {
-                                "oneOf": [
-                                    {
-                                        "type": "object"
-                                    },
-                                    {
-                                        "$ref": "#/components/schemas/dto.CreateAgentPinRequest",
-                                        "summary": "request",
-                                        "description": "Pin request"
-                                    }
-                                ]
-                            }
-                        }
-                    },
-                    "description": "Pin request",
-                    "required": true
-                },
+                "description": "Leave ingest and discovery jobs queued until processing is resumed. Uploads are still staged and enqueued, and jobs already running finish normally. The pause survives restarts.",
                 "responses": {
                     "200": {
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/dto.AgentPinDTO"
+                                    "$ref": "#/components/schemas/handler.ProcessingStateDTO"
                                 }
                             }
                         },
                         "description": "OK"
                     },
-                    "400": {
+                    "500": {
                         "content": {
                             "application/json": {
                                 "schema": {
@@ -6707,19 +8385,30 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Invalid request"
-                    },
-                    "401": {
+                        "description": "Internal Server Error"
+                    }
+                },
+                "summary": "Pause asset processing",
+                "tags": [
+                    "Queue"
+                ]
+            }
+        },
+        "/api/v1/admin/processing/resume": {
+            "post": {
+                "description": "Let ingest and discovery jobs run again after a processing pause",
+                "responses": {
+                    "200": {
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                    "$ref": "#/components/schemas/handler.ProcessingStateDTO"
                                 }
                             }
                         },
-                        "description": "Unauthorized"
+                        "description": "OK"
                     },
-                    "404": {
+                    "500": {
                         "content": {
                             "application/json": {
                                 "schema": {
@@ -6727,60 +8416,30 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Ref not found"
+                        "description": "Internal Server Error"
                     }
                 },
-                "summary": "Pin an Agent Ref",
+                "summary": "Resume asset processing",
                 "tags": [
-                    "agent"
+                    "Queue"
                 ]
             }
         },
-        "/api/v1/agent/pins/layout": {
-            "patch": {
-                "description": "Persist the board grid placement for one or more pins.",
-                "requestBody": {
-                    "content": {
-                        "application/json": {
-                            "schema": {
-                                "oneOf": [
-                                    {
-                                        "type": "object"
-                                    },
-                                    {
-                                        "$ref": "#/components/schemas/dto.UpdateAgentPinLayoutRequest",
-                                        "summary": "request",
-                                        "description": "Layout updates"
-                                    }
-                                ]
-                            }
-                        }
-                    },
-                    "description": "Layout updates",
-                    "required": true
-                },
+        "/api/v1/admin/queues": {
+            "get": {
+                "description": "List every job queue with its configured concurrency, paused state and in-flight job count",
                 "responses": {
                     "200": {
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/api.SuccessResponse"
+                                    "$ref": "#/components/schemas/handler.QueueListResponse"
                                 }
                             }
                         },
                         "description": "OK"
                     },
-                    "400": {
-                        "content": {
-                            "application/json": {
-                                "schema": {
-                                    "$ref": "#/components/schemas/api.ErrorResponse"
-                                }
-                            }
-                        },
-                        "description": "Invalid request"
-                    },
-                    "401": {
+                    "500": {
                         "content": {
                             "application/json": {
                                 "schema": {
@@ -6788,23 +8447,23 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Unauthorized"
+                        "description": "Internal Server Error"
                     }
                 },
-                "summary": "Update Agent Pin Layout",
+                "summary": "List job queues",
                 "tags": [
-                    "agent"
+                    "Queue"
                 ]
             }
         },
-        "/api/v1/agent/pins/{id}": {
-            "delete": {
-                "description": "Remove a pinned widget from the board.",
+        "/api/v1/admin/queues/{name}/pause": {
+            "post": {
+                "description": "Stop workers from fetching new jobs from a queue; jobs already running finish normally",
                 "parameters": [
                     {
-                        "description": "Pin ID",
+                        "description": "Queue name",
                         "in": "path",
-                        "name": "id",
+                        "name": "name",
                         "required": true,
                         "schema": {
                             "type": "string"
@@ -6816,13 +8475,13 @@ const docTemplate = `{
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/api.SuccessResponse"
+                                    "$ref": "#/components/schemas/handler.QueueStateDTO"
                                 }
                             }
                         },
                         "description": "OK"
                     },
-                    "401": {
+                    "404": {
                         "content": {
                             "application/json": {
                                 "schema": {
@@ -6830,9 +8489,9 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Unauthorized"
+                        "description": "Not Found"
                     },
-                    "404": {
+                    "500": {
                         "content": {
                             "application/json": {
                                 "schema": {
@@ -6840,21 +8499,23 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Pin not found"
+                        "description": "Internal Server Error"
                     }
                 },
-                "summary": "Delete Agent Pin",
+                "summary": "Pause a job queue",
                 "tags": [
-                    "agent"
+                    "Queue"
                 ]
-            },
-            "get": {
-                "description": "Get metadata and facet summary for a pinned widget. Frozen pins serve the stored snapshot; live pins replay their plan before facets are computed.",
+            }
+        },
+        "/api/v1/admin/queues/{name}/resume": {
+            "post": {
+                "description": "Let workers fetch jobs from a paused queue again",
                 "parameters": [
                     {
-                        "description": "Pin ID",
+                        "description": "Queue name",
                         "in": "path",
-                        "name": "id",
+                        "name": "name",
                         "required": true,
                         "schema": {
                             "type": "string"
@@ -6866,13 +8527,13 @@ const docTemplate = `{
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/dto.AgentPinDTO"
+                                    "$ref": "#/components/schemas/handler.QueueStateDTO"
                                 }
                             }
                         },
                         "description": "OK"
                     },
-                    "401": {
+                    "404": {
                         "content": {
                             "application/json": {
                                 "schema": {
@@ -6880,9 +8541,9 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Unauthorized"
+                        "description": "Not Found"
                     },
-                    "404": {
+                    "500": {
                         "content": {
                             "application/json": {
                                 "schema": {
@@ -6890,24 +8551,25 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Pin not found"
+                        "description": "Internal Server Error"
                     }
                 },
-                "summary": "Get Agent Pin Metadata",
+                "summary": "Resume a job queue",
                 "tags": [
-                    "agent"
+                    "Queue"
                 ]
-            },
-            "patch": {
-                "description": "Patch one pinned widget. Send title to rename it, widget to switch which view it renders through; both are optional.",
+            }
+        },
+        "/api/v1/admin/river/queue-summary": {
+            "get": {
+                "description": "Get aggregated processing activity per queue, including recent error samples",
                 "parameters": [
                     {
-                        "description": "Pin ID",
-                        "in": "path",
-                        "name": "id",
-                        "required": true,
+                        "description": "Recent error samples per queue (default: 5, max: 20)",
+                        "in": "query",
+                        "name": "error_limit",
                         "schema": {
-                            "type": "string"
+                            "type": "integer"
                         }
                     }
                 ],
@@ -6915,97 +8577,80 @@ const docTemplate = `{
                     "content": {
                         "application/json": {
                             "schema": {
-                                "oneOf": [
-                                    {
-                                        "type": "object"
-                                    },
-                                    {
-                                        "$ref": "#/components/schemas/dto.UpdateAgentPinRequest",
-                                        "summary": "request",
-                                        "description": "Pin update"
-                                    }
-                                ]
+                                "type": "object"
                             }
                         }
-                    },
-                    "description": "Pin update",
-                    "required": true
+                    }
                 },
                 "responses": {
                     "200": {
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/api.SuccessResponse"
+                                    "$ref": "#/components/schemas/handler.QueueSummaryResponse"
                                 }
                             }
                         },
                         "description": "OK"
-                    },
-                    "400": {
-                        "content": {
-                            "application/json": {
-                                "schema": {
-                                    "$ref": "#/components/schemas/api.ErrorResponse"
-                                }
-                            }
-                        },
-                        "description": "Invalid request"
-                    },
-                    "401": {
-                        "content": {
-                            "application/json": {
-                                "schema": {
-                                    "$ref": "#/components/schemas/api.ErrorResponse"
-                                }
+                    }
+                },
+                "summary": "Get queue summaries",
+                "tags": [
+                    "Queue"
+                ]
+            }
+        },
+        "/api/v1/admin/river/stats": {
+            "get": {
+                "description": "Get aggregated statistics about jobs by state",
+                "requestBody": {
+                    "content": {
+                        "application/json": {
+                            "schema": {
+                                "type": "object"
                             }
-                        },
-                        "description": "Unauthorized"
-                    },
-                    "404": {
+                        }
+                    }
+                },
+                "responses": {
+                    "200": {
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                    "$ref": "#/components/schemas/handler.JobStatsResponse"
                                 }
                             }
                         },
-                        "description": "Pin not found"
+                        "description": "OK"
                     }
                 },
-                "summary": "Update Agent Pin",
+                "summary": "Get job statistics",
                 "tags": [
-                    "agent"
+                    "Queue"
                 ]
             }
         },
-        "/api/v1/agent/pins/{id}/assets": {
-            "get": {
-                "description": "Get a page of assets for a pinned widget. Frozen pins serve the stored snapshot; live pins replay their plan.",
+        "/api/v1/admin/tags/cleanup": {
+            "post": {
+                "description": "Remove AI-sourced asset-tag links whose confidence is below min_confidence. User and system tags are never touched. Optionally also delete AI tag definitions no longer referenced by any asset. The same cleanup also runs periodically while lumen.tag_cleanup_min_confidence is set in the server config.",
                 "parameters": [
                     {
-                        "description": "Pin ID",
-                        "in": "path",
-                        "name": "id",
-                        "required": true,
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
-                    {
-                        "description": "Page size (default 50, max 200)",
+                        "description": "Confidence threshold in (0, 1]; AI tags strictly below it are removed",
+                        "example": 0.3,
                         "in": "query",
-                        "name": "limit",
+                        "name": "min_confidence",
+                        "required": true,
                         "schema": {
-                            "type": "integer"
+                            "type": "number"
                         }
                     },
                     {
-                        "description": "Page offset (default 0)",
+                        "description": "Also delete AI tags no longer attached to any asset",
                         "in": "query",
-                        "name": "offset",
+                        "name": "remove_orphans",
                         "schema": {
-                            "type": "integer"
+                            "default": false,
+                            "type": "boolean"
                         }
                     }
                 ],
@@ -7014,13 +8659,13 @@ const docTemplate = `{
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/dto.AgentRefAssetsDTO"
+                                    "$ref": "#/components/schemas/dto.TagCleanupResponseDTO"
                                 }
                             }
                         },
-                        "description": "OK"
+                        "description": "Cleanup completed"
                     },
-                    "401": {
+                    "400": {
                         "content": {
                             "application/json": {
                                 "schema": {
@@ -7028,9 +8673,9 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Unauthorized"
+                        "description": "Invalid min_confidence"
                     },
-                    "404": {
+                    "500": {
                         "content": {
                             "application/json": {
                                 "schema": {
@@ -7038,26 +8683,31 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Pin not found"
+                        "description": "Internal server error"
                     }
                 },
-                "summary": "Get Agent Pin Assets",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "summary": "Clean up low-confidence AI tags",
                 "tags": [
-                    "agent"
+                    "assets"
                 ]
             }
         },
-        "/api/v1/agent/pins/{id}/assets/list": {
-            "post": {
-                "description": "Query a pinned widget with the same list/filter/sort semantics as the assets gallery. Snapshot-order hydration remains available through GET /agent/pins/{id}/assets.",
+        "/api/v1/admin/tags/{id}": {
+            "put": {
+                "description": "Rename a tag for every asset that carries it. Tags are shared by all users. A name already used by another tag is rejected; merge the two tags instead.",
                 "parameters": [
                     {
-                        "description": "Pin ID",
+                        "description": "Tag ID",
                         "in": "path",
                         "name": "id",
                         "required": true,
                         "schema": {
-                            "type": "string"
+                            "type": "integer"
                         }
                     }
                 ],
@@ -7070,15 +8720,15 @@ const docTemplate = `{
                                         "type": "object"
                                     },
                                     {
-                                        "$ref": "#/components/schemas/dto.AssetQueryRequestDTO",
-                                        "summary": "data",
-                                        "description": "Query parameters"
+                                        "$ref": "#/components/schemas/dto.RenameTagRequestDTO",
+                                        "summary": "request",
+                                        "description": "New tag name"
                                     }
                                 ]
                             }
                         }
                     },
-                    "description": "Query parameters",
+                    "description": "New tag name",
                     "required": true
                 },
                 "responses": {
@@ -7086,11 +8736,11 @@ const docTemplate = `{
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/dto.QueryAssetsResponseDTO"
+                                    "$ref": "#/components/schemas/dto.TagDTO"
                                 }
                             }
                         },
-                        "description": "Pin assets queried successfully"
+                        "description": "Renamed tag"
                     },
                     "400": {
                         "content": {
@@ -7100,9 +8750,9 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Invalid request parameters"
+                        "description": "Invalid tag ID or name"
                     },
-                    "401": {
+                    "404": {
                         "content": {
                             "application/json": {
                                 "schema": {
@@ -7110,9 +8760,9 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Unauthorized"
+                        "description": "Tag not found"
                     },
-                    "404": {
+                    "409": {
                         "content": {
                             "application/json": {
                                 "schema": {
@@ -7120,7 +8770,7 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Pin not found"
+                        "description": "Another tag already has this name"
                     },
                     "500": {
                         "content": {
@@ -7131,35 +8781,30 @@ const docTemplate = `{
                             }
                         },
                         "description": "Internal server error"
-                    },
-                    "503": {
-                        "content": {
-                            "application/json": {
-                                "schema": {
-                                    "$ref": "#/components/schemas/api.ErrorResponse"
-                                }
-                            }
-                        },
-                        "description": "Semantic search unavailable"
                     }
                 },
-                "summary": "Query Agent Pin Assets",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "summary": "Rename a tag",
                 "tags": [
-                    "agent"
+                    "assets"
                 ]
             }
         },
-        "/api/v1/agent/pins/{id}/assets/search": {
+        "/api/v1/admin/tags/{id}/merge": {
             "post": {
-                "description": "Search a pinned widget with optional top results enhancement and filename fallback, constrained to the pin's asset set.",
+                "description": "Move every asset of the tag onto the target tag and delete the tag, e.g. to fold \"dogs\" into \"dog\". An asset carrying both keeps one link with the higher confidence, preferring user-added links. Runs in one transaction.",
                 "parameters": [
                     {
-                        "description": "Pin ID",
+                        "description": "Tag ID to merge away",
                         "in": "path",
                         "name": "id",
                         "required": true,
                         "schema": {
-                            "type": "string"
+                            "type": "integer"
                         }
                     }
                 ],
@@ -7172,15 +8817,15 @@ const docTemplate = `{
                                         "type": "object"
                                     },
                                     {
-                                        "$ref": "#/components/schemas/dto.SearchAssetsRequestDTO",
-                                        "summary": "data",
-                                        "description": "Search parameters"
+                                        "$ref": "#/components/schemas/dto.MergeTagRequestDTO",
+                                        "summary": "request",
+                                        "description": "Target tag"
                                     }
                                 ]
                             }
                         }
                     },
-                    "description": "Search parameters",
+                    "description": "Target tag",
                     "required": true
                 },
                 "responses": {
@@ -7188,11 +8833,11 @@ const docTemplate = `{
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/dto.SearchAssetsResponseDTO"
+                                    "$ref": "#/components/schemas/dto.TagMergeResponseDTO"
                                 }
                             }
                         },
-                        "description": "Pin assets searched successfully"
+                        "description": "Tags merged"
                     },
                     "400": {
                         "content": {
@@ -7202,17 +8847,7 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Invalid request parameters"
-                    },
-                    "401": {
-                        "content": {
-                            "application/json": {
-                                "schema": {
-                                    "$ref": "#/components/schemas/api.ErrorResponse"
-                                }
-                            }
-                        },
-                        "description": "Unauthorized"
+                        "description": "Invalid tag ID or a merge into itself"
                     },
                     "404": {
                         "content": {
@@ -7222,7 +8857,7 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Pin not found"
+                        "description": "Tag not found"
                     },
                     "500": {
                         "content": {
@@ -7235,126 +8870,135 @@ const docTemplate = `{
                         "description": "Internal server error"
                     }
                 },
-                "summary": "Search Agent Pin Assets",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "summary": "Merge tags",
                 "tags": [
-                    "agent"
+                    "assets"
                 ]
             }
         },
-        "/api/v1/agent/refs/{id}": {
-            "get": {
-                "description": "Get metadata and facet summary for an agent ref. Refs are scoped to the requesting user and thread.",
-                "parameters": [
-                    {
-                        "description": "Ref ID",
-                        "in": "path",
-                        "name": "id",
-                        "required": true,
-                        "schema": {
-                            "type": "string"
+        "/api/v1/agent/chat": {
+            "post": {
+                "description": "Send a query to agent and receive streaming responses via SSE. Manages conversation threads.",
+                "requestBody": {
+                    "content": {
+                        "application/json": {
+                            "schema": {
+                                "oneOf": [
+                                    {
+                                        "type": "object"
+                                    },
+                                    {
+                                        "$ref": "#/components/schemas/handler.AgentChatRequest",
+                                        "summary": "request",
+                                        "description": "Chat request"
+                                    }
+                                ]
+                            }
                         }
                     },
-                    {
-                        "description": "Thread (conversation) the ref belongs to",
-                        "in": "query",
-                        "name": "thread_id",
-                        "required": true,
-                        "schema": {
-                            "type": "string"
-                        }
-                    }
-                ],
+                    "description": "Chat request",
+                    "required": true
+                },
                 "responses": {
                     "200": {
                         "content": {
-                            "application/json": {
+                            "text/event-stream": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/dto.AgentRefDTO"
+                                    "type": "string"
                                 }
                             }
                         },
-                        "description": "OK"
+                        "description": "SSE stream"
                     },
-                    "401": {
+                    "400": {
                         "content": {
-                            "application/json": {
+                            "text/event-stream": {
                                 "schema": {
                                     "$ref": "#/components/schemas/api.ErrorResponse"
                                 }
                             }
                         },
-                        "description": "Unauthorized"
+                        "description": "Invalid request"
                     },
-                    "404": {
+                    "401": {
                         "content": {
-                            "application/json": {
+                            "text/event-stream": {
                                 "schema": {
                                     "$ref": "#/components/schemas/api.ErrorResponse"
                                 }
                             }
                         },
-                        "description": "Ref not found"
-                    }
-                },
-                "summary": "Get Agent Ref Metadata",
+                        "description": "Unauthorized"
+                    },
+                    "500": {
+                        "content": {
+                            "text/event-stream": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Internal server error"
+                    }
+                },
+                "summary": "Chat with Agent",
                 "tags": [
                     "agent"
                 ]
             }
         },
-        "/api/v1/agent/refs/{id}/assets": {
-            "get": {
-                "description": "Get a page of assets for an agent ref, in snapshot order.",
-                "parameters": [
-                    {
-                        "description": "Ref ID",
-                        "in": "path",
-                        "name": "id",
-                        "required": true,
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
-                    {
-                        "description": "Thread (conversation) the ref belongs to",
-                        "in": "query",
-                        "name": "thread_id",
-                        "required": true,
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
-                    {
-                        "description": "Page size (default 50, max 200)",
-                        "in": "query",
-                        "name": "limit",
-                        "schema": {
-                            "type": "integer"
+        "/api/v1/agent/chat/resume": {
+            "post": {
+                "description": "Resume a conversation from an interrupt point (e.g., user confirmation for a tool call)",
+                "requestBody": {
+                    "content": {
+                        "application/json": {
+                            "schema": {
+                                "oneOf": [
+                                    {
+                                        "type": "object"
+                                    },
+                                    {
+                                        "$ref": "#/components/schemas/handler.AgentResumeRequest",
+                                        "summary": "request",
+                                        "description": "Resume request"
+                                    }
+                                ]
+                            }
                         }
                     },
-                    {
-                        "description": "Page offset (default 0)",
-                        "in": "query",
-                        "name": "offset",
-                        "schema": {
-                            "type": "integer"
-                        }
-                    }
-                ],
+                    "description": "Resume request",
+                    "required": true
+                },
                 "responses": {
                     "200": {
                         "content": {
-                            "application/json": {
+                            "text/event-stream": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/dto.AgentRefAssetsDTO"
+                                    "type": "string"
                                 }
                             }
                         },
-                        "description": "OK"
+                        "description": "SSE stream"
+                    },
+                    "400": {
+                        "content": {
+                            "text/event-stream": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Invalid request"
                     },
                     "401": {
                         "content": {
-                            "application/json": {
+                            "text/event-stream": {
                                 "schema": {
                                     "$ref": "#/components/schemas/api.ErrorResponse"
                                 }
@@ -7362,113 +9006,88 @@ const docTemplate = `{
                         },
                         "description": "Unauthorized"
                     },
-                    "404": {
+                    "500": {
                         "content": {
-                            "application/json": {
+                            "text/event-stream": {
                                 "schema": {
                                     "$ref": "#/components/schemas/api.ErrorResponse"
                                 }
                             }
                         },
-                        "description": "Ref not found"
+                        "description": "Internal server error"
                     }
                 },
-                "summary": "Get Agent Ref Assets",
+                "summary": "Resume Agent Chat",
                 "tags": [
                     "agent"
                 ]
             }
         },
-        "/api/v1/agent/tools": {
+        "/api/v1/agent/pins": {
             "get": {
-                "description": "Get the agent tools visible in the given quick-action mode. An empty or unknown mode returns the full toolset.",
-                "parameters": [
-                    {
-                        "description": "Quick-action mode",
-                        "in": "query",
-                        "name": "mode",
-                        "schema": {
-                            "enum": [
-                                "review",
-                                "organize",
-                                "analyze",
-                                "curate"
-                            ],
-                            "type": "string"
-                        }
-                    }
-                ],
+                "description": "List all pinned widgets for the current user, in creation order.",
                 "responses": {
                     "200": {
                         "content": {
                             "application/json": {
                                 "schema": {
                                     "items": {
-                                        "$ref": "#/components/schemas/handler.ToolInfoResponse"
+                                        "$ref": "#/components/schemas/dto.AgentPinDTO"
                                     },
                                     "type": "array"
                                 }
                             }
                         },
                         "description": "OK"
+                    },
+                    "401": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Unauthorized"
                     }
                 },
-                "summary": "Get Available Tools",
+                "summary": "List Agent Pins",
                 "tags": [
                     "agent"
                 ]
-            }
-        },
-        "/api/v1/albums": {
-            "get": {
-                "description": "Retrieve a paginated list of albums for the authenticated user",
-                "parameters": [
-                    {
-                        "description": "Maximum number of results (max 100)",
-                        "in": "query",
-                        "name": "limit",
-                        "schema": {
-                            "default": 20,
-                            "type": "integer"
-                        }
-                    },
-                    {
-                        "description": "Number of results to skip for pagination",
-                        "in": "query",
-                        "name": "offset",
-                        "schema": {
-                            "default": 0,
-                            "type": "integer"
-                        }
-                    },
-                    {
-                        "description": "Optional repository UUID filter",
-                        "in": "query",
-                        "name": "repository_id",
-                        "schema": {
-                            "type": "string"
-                        }
-                    }
-                ],
+            },
+            "post": {
+                "description": "Copy a session ref into a durable board widget. Live mode replays the producing plan on hydration when replayable; otherwise the pin freezes the snapshot.",
                 "requestBody": {
                     "content": {
                         "application/json": {
                             "schema": {
-                                "type": "object"
+                                "oneOf": [
+                                    {
+                                        "type": "object"
+                                    },
+                                    {
+                                        "$ref": "#/components/schemas/dto.CreateAgentPinRequest",
+                                        "summary": "request",
+                                        "description": "Pin request"
+                                    }
+                                ]
                             }
                         }
-                    }
+                    },
+                    "description": "Pin request",
+                    "required": true
                 },
                 "responses": {
                     "200": {
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/dto.ListAlbumsResponseDTO"
+                                    "$ref": "#/components/schemas/dto.AgentPinDTO"
                                 }
                             }
                         },
-                        "description": "Albums retrieved successfully"
+                        "description": "OK"
                     },
                     "400": {
                         "content": {
@@ -7478,7 +9097,7 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Invalid parameters"
+                        "description": "Invalid request"
                     },
                     "401": {
                         "content": {
@@ -7490,7 +9109,7 @@ const docTemplate = `{
                         },
                         "description": "Unauthorized"
                     },
-                    "500": {
+                    "404": {
                         "content": {
                             "application/json": {
                                 "schema": {
@@ -7498,21 +9117,18 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Failed to retrieve albums"
+                        "description": "Ref not found"
                     }
                 },
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "summary": "List albums",
+                "summary": "Pin an Agent Ref",
                 "tags": [
-                    "albums"
+                    "agent"
                 ]
-            },
-            "post": {
-                "description": "Create a new album for the authenticated user",
+            }
+        },
+        "/api/v1/agent/pins/layout": {
+            "patch": {
+                "description": "Persist the board grid placement for one or more pins.",
                 "requestBody": {
                     "content": {
                         "application/json": {
@@ -7522,15 +9138,15 @@ const docTemplate = `{
                                         "type": "object"
                                     },
                                     {
-                                        "$ref": "#/components/schemas/dto.CreateAlbumRequestDTO",
+                                        "$ref": "#/components/schemas/dto.UpdateAgentPinLayoutRequest",
                                         "summary": "request",
-                                        "description": "Album creation data"
+                                        "description": "Layout updates"
                                     }
                                 ]
                             }
                         }
                     },
-                    "description": "Album creation data",
+                    "description": "Layout updates",
                     "required": true
                 },
                 "responses": {
@@ -7538,11 +9154,11 @@ const docTemplate = `{
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/dto.GetAlbumResponseDTO"
+                                    "$ref": "#/components/schemas/api.SuccessResponse"
                                 }
                             }
                         },
-                        "description": "Album created successfully"
+                        "description": "OK"
                     },
                     "400": {
                         "content": {
@@ -7552,7 +9168,7 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Invalid request data"
+                        "description": "Invalid request"
                     },
                     "401": {
                         "content": {
@@ -7563,52 +9179,28 @@ const docTemplate = `{
                             }
                         },
                         "description": "Unauthorized"
-                    },
-                    "500": {
-                        "content": {
-                            "application/json": {
-                                "schema": {
-                                    "$ref": "#/components/schemas/api.ErrorResponse"
-                                }
-                            }
-                        },
-                        "description": "Failed to create album"
                     }
                 },
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "summary": "Create a new album",
+                "summary": "Update Agent Pin Layout",
                 "tags": [
-                    "albums"
+                    "agent"
                 ]
             }
         },
-        "/api/v1/albums/{id}": {
+        "/api/v1/agent/pins/{id}": {
             "delete": {
-                "description": "Delete an album by its ID",
+                "description": "Remove a pinned widget from the board.",
                 "parameters": [
                     {
-                        "description": "Album ID",
+                        "description": "Pin ID",
                         "in": "path",
                         "name": "id",
                         "required": true,
                         "schema": {
-                            "type": "integer"
+                            "type": "string"
                         }
                     }
                 ],
-                "requestBody": {
-                    "content": {
-                        "application/json": {
-                            "schema": {
-                                "type": "object"
-                            }
-                        }
-                    }
-                },
                 "responses": {
                     "200": {
                         "content": {
@@ -7618,17 +9210,7 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Album deleted successfully"
-                    },
-                    "400": {
-                        "content": {
-                            "application/json": {
-                                "schema": {
-                                    "$ref": "#/components/schemas/api.ErrorResponse"
-                                }
-                            }
-                        },
-                        "description": "Invalid album ID"
+                        "description": "OK"
                     },
                     "401": {
                         "content": {
@@ -7640,16 +9222,6 @@ const docTemplate = `{
                         },
                         "description": "Unauthorized"
                     },
-                    "403": {
-                        "content": {
-                            "application/json": {
-                                "schema": {
-                                    "$ref": "#/components/schemas/api.ErrorResponse"
-                                }
-                            }
-                        },
-                        "description": "Forbidden"
-                    },
                     "404": {
                         "content": {
                             "application/json": {
@@ -7658,71 +9230,39 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Album not found"
-                    },
-                    "500": {
-                        "content": {
-                            "application/json": {
-                                "schema": {
-                                    "$ref": "#/components/schemas/api.ErrorResponse"
-                                }
-                            }
-                        },
-                        "description": "Failed to delete album"
+                        "description": "Pin not found"
                     }
                 },
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "summary": "Delete album",
+                "summary": "Delete Agent Pin",
                 "tags": [
-                    "albums"
+                    "agent"
                 ]
             },
             "get": {
-                "description": "Retrieve a specific album by its ID",
+                "description": "Get metadata and facet summary for a pinned widget. Frozen pins serve the stored snapshot; live pins replay their plan before facets are computed.",
                 "parameters": [
                     {
-                        "description": "Album ID",
+                        "description": "Pin ID",
                         "in": "path",
                         "name": "id",
                         "required": true,
-                        "schema": {
-                            "type": "integer"
-                        }
-                    },
-                    {
-                        "description": "Optional repository UUID filter",
-                        "in": "query",
-                        "name": "repository_id",
                         "schema": {
                             "type": "string"
                         }
                     }
                 ],
-                "requestBody": {
-                    "content": {
-                        "application/json": {
-                            "schema": {
-                                "type": "object"
-                            }
-                        }
-                    }
-                },
                 "responses": {
                     "200": {
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/dto.GetAlbumResponseDTO"
+                                    "$ref": "#/components/schemas/dto.AgentPinDTO"
                                 }
                             }
                         },
-                        "description": "Album retrieved successfully"
+                        "description": "OK"
                     },
-                    "400": {
+                    "401": {
                         "content": {
                             "application/json": {
                                 "schema": {
@@ -7730,7 +9270,7 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Invalid album ID"
+                        "description": "Unauthorized"
                     },
                     "404": {
                         "content": {
@@ -7740,29 +9280,24 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Album not found"
+                        "description": "Pin not found"
                     }
                 },
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "summary": "Get album by ID",
+                "summary": "Get Agent Pin Metadata",
                 "tags": [
-                    "albums"
+                    "agent"
                 ]
             },
-            "put": {
-                "description": "Update an existing album's information",
+            "patch": {
+                "description": "Patch one pinned widget. Send title to rename it, widget to switch which view it renders through; both are optional.",
                 "parameters": [
                     {
-                        "description": "Album ID",
+                        "description": "Pin ID",
                         "in": "path",
                         "name": "id",
                         "required": true,
                         "schema": {
-                            "type": "integer"
+                            "type": "string"
                         }
                     }
                 ],
@@ -7775,15 +9310,15 @@ const docTemplate = `{
                                         "type": "object"
                                     },
                                     {
-                                        "$ref": "#/components/schemas/dto.UpdateAlbumRequestDTO",
+                                        "$ref": "#/components/schemas/dto.UpdateAgentPinRequest",
                                         "summary": "request",
-                                        "description": "Album update data"
+                                        "description": "Pin update"
                                     }
                                 ]
                             }
                         }
                     },
-                    "description": "Album update data",
+                    "description": "Pin update",
                     "required": true
                 },
                 "responses": {
@@ -7791,11 +9326,11 @@ const docTemplate = `{
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/dto.GetAlbumResponseDTO"
+                                    "$ref": "#/components/schemas/api.SuccessResponse"
                                 }
                             }
                         },
-                        "description": "Album updated successfully"
+                        "description": "OK"
                     },
                     "400": {
                         "content": {
@@ -7805,7 +9340,7 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Invalid album ID or request data"
+                        "description": "Invalid request"
                     },
                     "401": {
                         "content": {
@@ -7817,16 +9352,6 @@ const docTemplate = `{
                         },
                         "description": "Unauthorized"
                     },
-                    "403": {
-                        "content": {
-                            "application/json": {
-                                "schema": {
-                                    "$ref": "#/components/schemas/api.ErrorResponse"
-                                }
-                            }
-                        },
-                        "description": "Forbidden"
-                    },
                     "404": {
                         "content": {
                             "application/json": {
@@ -7835,73 +9360,57 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Album not found"
-                    },
-                    "500": {
-                        "content": {
-                            "application/json": {
-                                "schema": {
-                                    "$ref": "#/components/schemas/api.ErrorResponse"
-                                }
-                            }
-                        },
-                        "description": "Failed to update album"
+                        "description": "Pin not found"
                     }
                 },
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "summary": "Update album",
+                "summary": "Update Agent Pin",
                 "tags": [
-                    "albums"
+                    "agent"
                 ]
             }
         },
-        "/api/v1/albums/{id}/assets": {
+        "/api/v1/agent/pins/{id}/assets": {
             "get": {
-                "description": "Retrieve all assets in a specific album",
+                "description": "Get a page of assets for a pinned widget. Frozen pins serve the stored snapshot; live pins replay their plan.",
                 "parameters": [
                     {
-                        "description": "Album ID",
+                        "description": "Pin ID",
                         "in": "path",
                         "name": "id",
                         "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Page size (default 50, max 200)",
+                        "in": "query",
+                        "name": "limit",
                         "schema": {
                             "type": "integer"
                         }
                     },
                     {
-                        "description": "Optional repository UUID filter",
+                        "description": "Page offset (default 0)",
                         "in": "query",
-                        "name": "repository_id",
+                        "name": "offset",
                         "schema": {
-                            "type": "string"
+                            "type": "integer"
                         }
                     }
                 ],
-                "requestBody": {
-                    "content": {
-                        "application/json": {
-                            "schema": {
-                                "type": "object"
-                            }
-                        }
-                    }
-                },
                 "responses": {
                     "200": {
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/dto.AlbumAssetsResponseDTO"
+                                    "$ref": "#/components/schemas/dto.AgentRefAssetsDTO"
                                 }
                             }
                         },
-                        "description": "Assets retrieved successfully"
+                        "description": "OK"
                     },
-                    "400": {
+                    "401": {
                         "content": {
                             "application/json": {
                                 "schema": {
@@ -7909,7 +9418,7 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Invalid album ID"
+                        "description": "Unauthorized"
                     },
                     "404": {
                         "content": {
@@ -7919,48 +9428,24 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Album not found"
-                    },
-                    "500": {
-                        "content": {
-                            "application/json": {
-                                "schema": {
-                                    "$ref": "#/components/schemas/api.ErrorResponse"
-                                }
-                            }
-                        },
-                        "description": "Failed to retrieve album assets"
+                        "description": "Pin not found"
                     }
                 },
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "summary": "Get assets in album",
+                "summary": "Get Agent Pin Assets",
                 "tags": [
-                    "albums"
+                    "agent"
                 ]
             }
         },
-        "/api/v1/albums/{id}/assets/{assetId}": {
-            "delete": {
-                "description": "Remove an asset from a specific album",
+        "/api/v1/agent/pins/{id}/assets/list": {
+            "post": {
+                "description": "Query a pinned widget with the same list/filter/sort semantics as the assets gallery. Snapshot-order hydration remains available through GET /agent/pins/{id}/assets.",
                 "parameters": [
                     {
-                        "description": "Album ID",
+                        "description": "Pin ID",
                         "in": "path",
                         "name": "id",
                         "required": true,
-                        "schema": {
-                            "type": "integer"
-                        }
-                    },
-                    {
-                        "description": "Asset ID (UUID format)",
-                        "in": "path",
-                        "name": "assetId",
-                        "required": true,
                         "schema": {
                             "type": "string"
                         }
@@ -7970,21 +9455,32 @@ const docTemplate = `{
                     "content": {
                         "application/json": {
                             "schema": {
-                                "type": "object"
+                                "oneOf": [
+                                    {
+                                        "type": "object"
+                                    },
+                                    {
+                                        "$ref": "#/components/schemas/dto.AssetQueryRequestDTO",
+                                        "summary": "data",
+                                        "description": "Query parameters"
+                                    }
+                                ]
                             }
                         }
-                    }
+                    },
+                    "description": "Query parameters",
+                    "required": true
                 },
                 "responses": {
                     "200": {
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/api.SuccessResponse"
+                                    "$ref": "#/components/schemas/dto.QueryAssetsResponseDTO"
                                 }
                             }
                         },
-                        "description": "Asset removed from album successfully"
+                        "description": "Pin assets queried successfully"
                     },
                     "400": {
                         "content": {
@@ -7994,9 +9490,9 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Invalid album ID or asset ID"
+                        "description": "Invalid request parameters"
                     },
-                    "500": {
+                    "401": {
                         "content": {
                             "application/json": {
                                 "schema": {
@@ -8004,35 +9500,53 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Failed to remove asset from album"
-                    }
-                },
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "summary": "Remove asset from album",
-                "tags": [
-                    "albums"
-                ]
-            },
-            "post": {
-                "description": "Add an asset to a specific album",
-                "parameters": [
-                    {
-                        "description": "Album ID",
-                        "in": "path",
-                        "name": "id",
-                        "required": true,
-                        "schema": {
-                            "type": "integer"
-                        }
+                        "description": "Unauthorized"
                     },
+                    "404": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Pin not found"
+                    },
+                    "500": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Internal server error"
+                    },
+                    "503": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Semantic search unavailable"
+                    }
+                },
+                "summary": "Query Agent Pin Assets",
+                "tags": [
+                    "agent"
+                ]
+            }
+        },
+        "/api/v1/agent/pins/{id}/assets/search": {
+            "post": {
+                "description": "Search a pinned widget with optional top results enhancement and filename fallback, constrained to the pin's asset set.",
+                "parameters": [
                     {
-                        "description": "Asset ID (UUID format)",
+                        "description": "Pin ID",
                         "in": "path",
-                        "name": "assetId",
+                        "name": "id",
                         "required": true,
                         "schema": {
                             "type": "string"
@@ -8048,26 +9562,27 @@ const docTemplate = `{
                                         "type": "object"
                                     },
                                     {
-                                        "$ref": "#/components/schemas/dto.AddAssetToAlbumRequestDTO",
-                                        "summary": "request",
-                                        "description": "Asset position in album"
+                                        "$ref": "#/components/schemas/dto.SearchAssetsRequestDTO",
+                                        "summary": "data",
+                                        "description": "Search parameters"
                                     }
                                 ]
                             }
                         }
                     },
-                    "description": "Asset position in album"
+                    "description": "Search parameters",
+                    "required": true
                 },
                 "responses": {
                     "200": {
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/api.SuccessResponse"
+                                    "$ref": "#/components/schemas/dto.SearchAssetsResponseDTO"
                                 }
                             }
                         },
-                        "description": "Asset added to album successfully"
+                        "description": "Pin assets searched successfully"
                     },
                     "400": {
                         "content": {
@@ -8077,7 +9592,17 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Invalid album ID or asset ID"
+                        "description": "Invalid request parameters"
+                    },
+                    "401": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Unauthorized"
                     },
                     "404": {
                         "content": {
@@ -8087,7 +9612,7 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Album not found"
+                        "description": "Pin not found"
                     },
                     "500": {
                         "content": {
@@ -8097,75 +9622,50 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Failed to add asset to album"
+                        "description": "Internal server error"
                     }
                 },
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "summary": "Add asset to album",
+                "summary": "Search Agent Pin Assets",
                 "tags": [
-                    "albums"
+                    "agent"
                 ]
             }
         },
-        "/api/v1/albums/{id}/assets/{assetId}/position": {
-            "put": {
-                "description": "Update the position of an asset within a specific album",
+        "/api/v1/agent/refs/{id}": {
+            "get": {
+                "description": "Get metadata and facet summary for an agent ref. Refs are scoped to the requesting user and thread.",
                 "parameters": [
                     {
-                        "description": "Album ID",
+                        "description": "Ref ID",
                         "in": "path",
                         "name": "id",
                         "required": true,
                         "schema": {
-                            "type": "integer"
+                            "type": "string"
                         }
                     },
                     {
-                        "description": "Asset ID (UUID format)",
-                        "in": "path",
-                        "name": "assetId",
+                        "description": "Thread (conversation) the ref belongs to",
+                        "in": "query",
+                        "name": "thread_id",
                         "required": true,
                         "schema": {
                             "type": "string"
                         }
                     }
                 ],
-                "requestBody": {
-                    "content": {
-                        "application/json": {
-                            "schema": {
-                                "oneOf": [
-                                    {
-                                        "type": "object"
-                                    },
-                                    {
-                                        "$ref": "#/components/schemas/dto.UpdateAssetPositionRequestDTO",
-                                        "summary": "request",
-                                        "description": "New position data"
-                                    }
-                                ]
-                            }
-                        }
-                    },
-                    "description": "New position data",
-                    "required": true
-                },
                 "responses": {
                     "200": {
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/api.SuccessResponse"
+                                    "$ref": "#/components/schemas/dto.AgentRefDTO"
                                 }
                             }
                         },
-                        "description": "Asset position updated successfully"
+                        "description": "OK"
                     },
-                    "400": {
+                    "401": {
                         "content": {
                             "application/json": {
                                 "schema": {
@@ -8173,9 +9673,9 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Invalid album ID or asset ID"
+                        "description": "Unauthorized"
                     },
-                    "500": {
+                    "404": {
                         "content": {
                             "application/json": {
                                 "schema": {
@@ -8183,63 +9683,64 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Failed to update asset position"
+                        "description": "Ref not found"
                     }
                 },
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "summary": "Update asset position in album",
+                "summary": "Get Agent Ref Metadata",
                 "tags": [
-                    "albums"
+                    "agent"
                 ]
             }
         },
-        "/api/v1/albums/{id}/bioclip/rebuild": {
-            "post": {
-                "description": "Queue BioCLIP processing for photo assets in a bio album that do not yet have species predictions.",
+        "/api/v1/agent/refs/{id}/assets": {
+            "get": {
+                "description": "Get a page of assets for an agent ref, in snapshot order.",
                 "parameters": [
                     {
-                        "description": "Album ID",
+                        "description": "Ref ID",
                         "in": "path",
                         "name": "id",
                         "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Thread (conversation) the ref belongs to",
+                        "in": "query",
+                        "name": "thread_id",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Page size (default 50, max 200)",
+                        "in": "query",
+                        "name": "limit",
                         "schema": {
                             "type": "integer"
                         }
-                    }
-                ],
-                "requestBody": {
-                    "content": {
-                        "application/json": {
-                            "schema": {
-                                "type": "object"
-                            }
+                    },
+                    {
+                        "description": "Page offset (default 0)",
+                        "in": "query",
+                        "name": "offset",
+                        "schema": {
+                            "type": "integer"
                         }
                     }
-                },
+                ],
                 "responses": {
                     "200": {
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/dto.RebuildAlbumBioClipResponseDTO"
-                                }
-                            }
-                        },
-                        "description": "BioCLIP jobs queued successfully"
-                    },
-                    "400": {
-                        "content": {
-                            "application/json": {
-                                "schema": {
-                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                    "$ref": "#/components/schemas/dto.AgentRefAssetsDTO"
                                 }
                             }
                         },
-                        "description": "Invalid album or album type"
+                        "description": "OK"
                     },
                     "401": {
                         "content": {
@@ -8251,16 +9752,6 @@ const docTemplate = `{
                         },
                         "description": "Unauthorized"
                     },
-                    "403": {
-                        "content": {
-                            "application/json": {
-                                "schema": {
-                                    "$ref": "#/components/schemas/api.ErrorResponse"
-                                }
-                            }
-                        },
-                        "description": "Forbidden"
-                    },
                     "404": {
                         "content": {
                             "application/json": {
@@ -8269,68 +9760,105 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Album not found"
-                    },
-                    "503": {
+                        "description": "Ref not found"
+                    }
+                },
+                "summary": "Get Agent Ref Assets",
+                "tags": [
+                    "agent"
+                ]
+            }
+        },
+        "/api/v1/agent/tools": {
+            "get": {
+                "description": "Get the agent tools visible in the given quick-action mode. An empty or unknown mode returns the full toolset.",
+                "parameters": [
+                    {
+                        "description": "Quick-action mode",
+                        "in": "query",
+                        "name": "mode",
+                        "schema": {
+                            "enum": [
+                                "review",
+                                "organize",
+                                "analyze",
+                                "curate"
+                            ],
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                    "items": {
+                                        "$ref": "#/components/schemas/handler.ToolInfoResponse"
+                                    },
+                                    "type": "array"
                                 }
                             }
                         },
-                        "description": "BioCLIP unavailable"
+                        "description": "OK"
                     }
                 },
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "summary": "Queue BioCLIP for a bio album",
+                "summary": "Get Available Tools",
                 "tags": [
-                    "albums"
+                    "agent"
                 ]
             }
         },
-        "/api/v1/assets": {
-            "post": {
-                "description": "Upload a single photo, video, audio file, or document to the system. The file is staged in a repository and queued for processing.",
-                "requestBody": {
+        "/api/v1/albums": {
+            "get": {
+                "description": "Retrieve a paginated list of albums for the authenticated user",
+                "parameters": [
+                    {
+                        "description": "Maximum number of results (max 100)",
+                        "in": "query",
+                        "name": "limit",
+                        "schema": {
+                            "default": 20,
+                            "type": "integer"
+                        }
+                    },
+                    {
+                        "description": "Number of results to skip for pagination",
+                        "in": "query",
+                        "name": "offset",
+                        "schema": {
+                            "default": 0,
+                            "type": "integer"
+                        }
+                    },
+                    {
+                        "description": "Optional repository UUID filter",
+                        "in": "query",
+                        "name": "repository_id",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "requestBody": {
                     "content": {
-                        "application/x-www-form-urlencoded": {
-                            "schema": {
-                                "oneOf": [
-                                    {
-                                        "title": "file",
-                                        "type": "file"
-                                    },
-                                    {
-                                        "example": "\"550e8400-e29b-41d4-a716-446655440000\"",
-                                        "title": "repository_id",
-                                        "type": "string"
-                                    }
-                                ]
-                            }
-                        },
-                        "multipart/form-data": {
+                        "application/json": {
                             "schema": {
                                 "type": "object"
                             }
                         }
-                    },
-                    "description": "Asset file to upload | Repository UUID (uses default repository if not provided)"
+                    }
                 },
                 "responses": {
                     "200": {
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/dto.UploadResponseDTO"
+                                    "$ref": "#/components/schemas/dto.ListAlbumsResponseDTO"
                                 }
                             }
                         },
-                        "description": "Upload successful"
+                        "description": "Albums retrieved successfully"
                     },
                     "400": {
                         "content": {
@@ -8340,7 +9868,17 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Bad request - no file provided or parse error"
+                        "description": "Invalid parameters"
+                    },
+                    "401": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Unauthorized"
                     },
                     "500": {
                         "content": {
@@ -8350,57 +9888,51 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Internal server error"
+                        "description": "Failed to retrieve albums"
                     }
                 },
-                "summary": "Upload a single asset",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "summary": "List albums",
                 "tags": [
-                    "assets"
+                    "albums"
                 ]
-            }
-        },
-        "/api/v1/assets/batch": {
+            },
             "post": {
-                "description": "Unified batch upload endpoint that supports both small files and chunked large files. Field names should follow format: single_{session_id} for single files or chunk_{session_id}_{index}_{total} for chunks.",
+                "description": "Create a new album for the authenticated user. cover_asset_id is optional; without it the server chooses the cover as assets are added, as after PUT /api/v1/albums/{id}/cover/auto.",
                 "requestBody": {
                     "content": {
-                        "application/x-www-form-urlencoded": {
+                        "application/json": {
                             "schema": {
                                 "oneOf": [
                                     {
-                                        "example": "\"550e8400-e29b-41d4-a716-446655440000\"",
-                                        "title": "repository_id",
-                                        "type": "string"
-                                    },
-                                    {
-                                        "title": "file",
-                                        "type": "file"
+                                        "type": "object"
                                     },
                                     {
-                                        "title": "file",
-                                        "type": "file"
+                                        "$ref": "#/components/schemas/dto.CreateAlbumRequestDTO",
+                                        "summary": "request",
+                                        "description": "Album creation data"
                                     }
                                 ]
                             }
-                        },
-                        "multipart/form-data": {
-                            "schema": {
-                                "type": "object"
-                            }
                         }
                     },
-                    "description": "Repository UUID (uses default repository if not provided) | Single file upload - use format: single_{session_id} | Chunked file upload - use format: chunk_{session_id}_{index}_{total}"
+                    "description": "Album creation data",
+                    "required": true
                 },
                 "responses": {
                     "200": {
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/dto.BatchUploadResponseDTO"
+                                    "$ref": "#/components/schemas/dto.GetAlbumResponseDTO"
                                 }
                             }
                         },
-                        "description": "Batch upload completed"
+                        "description": "Album created successfully"
                     },
                     "400": {
                         "content": {
@@ -8410,9 +9942,9 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Bad request - no files provided or parse error"
+                        "description": "Invalid request data"
                     },
-                    "500": {
+                    "401": {
                         "content": {
                             "application/json": {
                                 "schema": {
@@ -8420,71 +9952,45 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Internal server error"
-                    }
-                },
-                "summary": "Batch upload assets with chunk support",
-                "tags": [
-                    "assets"
-                ]
-            }
-        },
-        "/api/v1/assets/batch/config": {
-            "get": {
-                "description": "Get current upload configuration including chunk size and concurrency limits based on system memory",
-                "requestBody": {
-                    "content": {
-                        "application/json": {
-                            "schema": {
-                                "type": "object"
-                            }
-                        }
-                    }
-                },
-                "responses": {
-                    "200": {
+                        "description": "Unauthorized"
+                    },
+                    "500": {
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/dto.UploadConfigResponseDTO"
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
                                 }
                             }
                         },
-                        "description": "Upload configuration"
+                        "description": "Failed to create album"
                     }
                 },
-                "summary": "Get upload configuration",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "summary": "Create a new album",
                 "tags": [
-                    "assets"
+                    "albums"
                 ]
             }
         },
-        "/api/v1/assets/batch/jobs": {
+        "/api/v1/albums/duplicates": {
             "get": {
-                "description": "Get backend ingest lifecycle state for upload task IDs owned by the current caller",
-                "parameters": [
-                    {
-                        "description": "Comma-separated upload task IDs",
-                        "in": "query",
-                        "name": "task_ids",
-                        "required": true,
-                        "schema": {
-                            "type": "string"
-                        }
-                    }
-                ],
+                "description": "List the caller's albums whose exact name and type match another of their albums, grouped by name. Within a group the oldest album comes first, which makes it the natural merge target.",
                 "responses": {
                     "200": {
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/dto.UploadJobStatusResponseDTO"
+                                    "$ref": "#/components/schemas/dto.AlbumDuplicatesResponseDTO"
                                 }
                             }
                         },
-                        "description": "Upload materialization status"
+                        "description": "Duplicate album groups"
                     },
-                    "400": {
+                    "401": {
                         "content": {
                             "application/json": {
                                 "schema": {
@@ -8492,88 +9998,33 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Invalid task IDs"
-                    }
-                },
-                "summary": "Get upload materialization status",
-                "tags": [
-                    "assets"
-                ]
-            }
-        },
-        "/api/v1/assets/batch/jobs/stream": {
-            "get": {
-                "parameters": [
-                    {
-                        "description": "Comma-separated upload task IDs",
-                        "in": "query",
-                        "name": "task_ids",
-                        "required": true,
-                        "schema": {
-                            "type": "string"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
+                        "description": "Unauthorized"
+                    },
+                    "500": {
                         "content": {
-                            "text/event-stream": {
+                            "application/json": {
                                 "schema": {
-                                    "type": "string"
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
                                 }
                             }
                         },
-                        "description": "SSE stream"
+                        "description": "Failed to list duplicate albums"
                     }
                 },
-                "summary": "Stream upload materialization status",
-                "tags": [
-                    "assets"
-                ]
-            }
-        },
-        "/api/v1/assets/batch/progress": {
-            "get": {
-                "description": "Get detailed progress information for upload sessions",
-                "parameters": [
+                "security": [
                     {
-                        "description": "Comma-separated session IDs (optional)",
-                        "in": "query",
-                        "name": "session_ids",
-                        "schema": {
-                            "type": "string"
-                        }
+                        "BearerAuth": []
                     }
                 ],
-                "requestBody": {
-                    "content": {
-                        "application/json": {
-                            "schema": {
-                                "type": "object"
-                            }
-                        }
-                    }
-                },
-                "responses": {
-                    "200": {
-                        "content": {
-                            "application/json": {
-                                "schema": {
-                                    "$ref": "#/components/schemas/dto.UploadProgressResponseDTO"
-                                }
-                            }
-                        },
-                        "description": "Upload progress details"
-                    }
-                },
-                "summary": "Get upload progress",
+                "summary": "List duplicate albums",
                 "tags": [
-                    "assets"
+                    "albums"
                 ]
             }
         },
-        "/api/v1/assets/batch/sessions": {
+        "/api/v1/albums/merge": {
             "post": {
+                "description": "Move every asset of the source albums into the target and delete the sources. Assets already in the target keep their position; the others are appended after the target's last position, source by source in the order given, each keeping its order within its album. An asset in several sources is added once. A target without a cover adopts the first source cover. All albums must belong to the same user and share the target's type, and none may be a smart album. Runs in one transaction.",
                 "requestBody": {
                     "content": {
                         "application/json": {
@@ -8583,15 +10034,15 @@ const docTemplate = `{
                                         "type": "object"
                                     },
                                     {
-                                        "$ref": "#/components/schemas/dto.CreateUploadSessionRequestDTO",
+                                        "$ref": "#/components/schemas/dto.MergeAlbumsRequestDTO",
                                         "summary": "request",
-                                        "description": "Upload metadata"
+                                        "description": "Target and source albums"
                                     }
                                 ]
                             }
                         }
                     },
-                    "description": "Upload metadata",
+                    "description": "Target and source albums",
                     "required": true
                 },
                 "responses": {
@@ -8599,69 +10050,35 @@ const docTemplate = `{
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/dto.UploadSessionResponseDTO"
+                                    "$ref": "#/components/schemas/dto.MergeAlbumsResponseDTO"
                                 }
                             }
                         },
-                        "description": "OK"
-                    }
-                },
-                "summary": "Create or resume an upload session",
-                "tags": [
-                    "assets"
-                ]
-            }
-        },
-        "/api/v1/assets/download": {
-            "post": {
-                "description": "Serve original files for the requested asset IDs as a zip archive.",
-                "requestBody": {
-                    "content": {
-                        "application/json": {
-                            "schema": {
-                                "$ref": "#/components/schemas/dto.DownloadAssetsRequestDTO",
-                                "summary": "data",
-                                "description": "Asset IDs to download"
-                            }
-                        }
-                    },
-                    "description": "Asset IDs to download",
-                    "required": true
-                },
-                "responses": {
-                    "200": {
-                        "content": {
-                            "application/zip": {
-                                "schema": {
-                                    "type": "file"
-                                }
-                            }
-                        },
-                        "description": "Zip archive"
+                        "description": "Albums merged"
                     },
                     "400": {
                         "content": {
-                            "application/zip": {
+                            "application/json": {
                                 "schema": {
                                     "$ref": "#/components/schemas/api.ErrorResponse"
                                 }
                             }
                         },
-                        "description": "Invalid request"
+                        "description": "Invalid request, mismatched album types or smart album"
                     },
                     "401": {
                         "content": {
-                            "application/zip": {
+                            "application/json": {
                                 "schema": {
                                     "$ref": "#/components/schemas/api.ErrorResponse"
                                 }
                             }
                         },
-                        "description": "Authentication required"
+                        "description": "Unauthorized"
                     },
                     "403": {
                         "content": {
-                            "application/zip": {
+                            "application/json": {
                                 "schema": {
                                     "$ref": "#/components/schemas/api.ErrorResponse"
                                 }
@@ -8671,100 +10088,81 @@ const docTemplate = `{
                     },
                     "404": {
                         "content": {
-                            "application/zip": {
+                            "application/json": {
                                 "schema": {
                                     "$ref": "#/components/schemas/api.ErrorResponse"
                                 }
                             }
                         },
-                        "description": "Asset or original file not found"
+                        "description": "Album not found"
                     },
                     "500": {
                         "content": {
-                            "application/zip": {
+                            "application/json": {
                                 "schema": {
                                     "$ref": "#/components/schemas/api.ErrorResponse"
                                 }
                             }
                         },
-                        "description": "Internal server error"
+                        "description": "Failed to merge albums"
                     }
                 },
-                "summary": "Download assets",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "summary": "Merge albums",
                 "tags": [
-                    "assets"
+                    "albums"
                 ]
             }
         },
-        "/api/v1/assets/featured": {
-            "get": {
-                "description": "Select a small set of featured photos using deterministic weighted sampling (A-ES) with diversity constraints.",
-                "parameters": [
-                    {
-                        "description": "Number of featured photos to return",
-                        "in": "query",
-                        "name": "count",
-                        "schema": {
-                            "default": 8,
-                            "type": "integer"
-                        }
-                    },
-                    {
-                        "description": "Max candidate photos considered before selection",
-                        "in": "query",
-                        "name": "candidate_limit",
-                        "schema": {
-                            "default": 240,
-                            "type": "integer"
-                        }
-                    },
-                    {
-                        "description": "Only consider photos from the last N days (0 disables date cutoff)",
-                        "in": "query",
-                        "name": "days",
-                        "schema": {
-                            "default": 3650,
-                            "type": "integer"
-                        }
-                    },
-                    {
-                        "description": "Deterministic seed (default: current UTC date YYYY-MM-DD)",
-                        "in": "query",
-                        "name": "seed",
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
-                    {
-                        "description": "Optional repository UUID filter",
-                        "in": "query",
-                        "name": "repository_id",
-                        "schema": {
-                            "type": "string"
-                        }
-                    }
-                ],
+        "/api/v1/albums/smart": {
+            "post": {
+                "description": "Create an album that stores an asset filter instead of a list of assets. Reading the album evaluates the filter against the owner's assets at that moment, so it follows uploads, edits and deletions without being updated. Smart album assets cannot be added, removed or reordered, and smart albums cannot be merged.",
                 "requestBody": {
                     "content": {
                         "application/json": {
                             "schema": {
-                                "type": "object"
+                                "oneOf": [
+                                    {
+                                        "type": "object"
+                                    },
+                                    {
+                                        "$ref": "#/components/schemas/dto.CreateSmartAlbumRequestDTO",
+                                        "summary": "request",
+                                        "description": "Smart album name and rules"
+                                    }
+                                ]
                             }
                         }
-                    }
+                    },
+                    "description": "Smart album name and rules",
+                    "required": true
                 },
                 "responses": {
                     "200": {
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/dto.FeaturedAssetsResponseDTO"
+                                    "$ref": "#/components/schemas/dto.GetAlbumResponseDTO"
                                 }
                             }
                         },
-                        "description": "Featured photos selected successfully"
+                        "description": "Smart album created successfully"
                     },
                     "400": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/dto.AssetFilterValidationErrorDTO"
+                                }
+                            }
+                        },
+                        "description": "Invalid request data or rules"
+                    },
+                    "401": {
                         "content": {
                             "application/json": {
                                 "schema": {
@@ -8772,7 +10170,7 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Invalid request parameters"
+                        "description": "Unauthorized"
                     },
                     "500": {
                         "content": {
@@ -8782,18 +10180,34 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Internal server error"
+                        "description": "Failed to create album"
                     }
                 },
-                "summary": "Get featured photos",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "summary": "Create a smart album",
                 "tags": [
-                    "assets"
+                    "albums"
                 ]
             }
         },
-        "/api/v1/assets/filter-options": {
-            "get": {
-                "description": "Get available camera models and lenses for filter dropdowns",
+        "/api/v1/albums/{id}": {
+            "delete": {
+                "description": "Delete an album by its ID",
+                "parameters": [
+                    {
+                        "description": "Album ID",
+                        "in": "path",
+                        "name": "id",
+                        "required": true,
+                        "schema": {
+                            "type": "integer"
+                        }
+                    }
+                ],
                 "requestBody": {
                     "content": {
                         "application/json": {
@@ -8808,13 +10222,13 @@ const docTemplate = `{
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/dto.OptionsResponseDTO"
+                                    "$ref": "#/components/schemas/api.SuccessResponse"
                                 }
                             }
                         },
-                        "description": "Filter options retrieved successfully"
+                        "description": "Album deleted successfully"
                     },
-                    "500": {
+                    "400": {
                         "content": {
                             "application/json": {
                                 "schema": {
@@ -8822,48 +10236,19 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Internal server error"
-                    }
-                },
-                "summary": "Get filter options",
-                "tags": [
-                    "assets"
-                ]
-            }
-        },
-        "/api/v1/assets/folders": {
-            "get": {
-                "description": "List immediate child folders of a repository-relative path, with recursive asset counts and covers, for the Folders collection view",
-                "parameters": [
-                    {
-                        "description": "Optional repository UUID filter",
-                        "in": "query",
-                        "name": "repository_id",
-                        "schema": {
-                            "type": "string"
-                        }
+                        "description": "Invalid album ID"
                     },
-                    {
-                        "description": "Repository-relative parent folder path (empty for root)",
-                        "in": "query",
-                        "name": "path",
-                        "schema": {
-                            "type": "string"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
+                    "401": {
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/dto.FolderListResponseDTO"
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
                                 }
                             }
                         },
-                        "description": "Folder summaries retrieved successfully"
+                        "description": "Unauthorized"
                     },
-                    "400": {
+                    "403": {
                         "content": {
                             "application/json": {
                                 "schema": {
@@ -8871,7 +10256,17 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Invalid request parameters"
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Album not found"
                     },
                     "500": {
                         "content": {
@@ -8881,47 +10276,59 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Internal server error"
+                        "description": "Failed to delete album"
                     }
                 },
-                "summary": "List folder summaries",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "summary": "Delete album",
                 "tags": [
-                    "assets"
+                    "albums"
                 ]
-            }
-        },
-        "/api/v1/assets/folders/summary": {
+            },
             "get": {
-                "description": "Get recursive asset counts, date range, and cover for one repository-relative folder path, for the Folder detail header",
+                "description": "Retrieve a specific album by its ID",
                 "parameters": [
                     {
-                        "description": "Repository UUID",
-                        "in": "query",
-                        "name": "repository_id",
+                        "description": "Album ID",
+                        "in": "path",
+                        "name": "id",
                         "required": true,
                         "schema": {
-                            "type": "string"
+                            "type": "integer"
                         }
                     },
                     {
-                        "description": "Repository-relative folder path (empty for root)",
+                        "description": "Optional repository UUID filter",
                         "in": "query",
-                        "name": "path",
+                        "name": "repository_id",
                         "schema": {
                             "type": "string"
                         }
                     }
                 ],
+                "requestBody": {
+                    "content": {
+                        "application/json": {
+                            "schema": {
+                                "type": "object"
+                            }
+                        }
+                    }
+                },
                 "responses": {
                     "200": {
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/dto.FolderSummaryDTO"
+                                    "$ref": "#/components/schemas/dto.GetAlbumResponseDTO"
                                 }
                             }
                         },
-                        "description": "Folder summary retrieved successfully"
+                        "description": "Album retrieved successfully"
                     },
                     "400": {
                         "content": {
@@ -8931,9 +10338,9 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Invalid request parameters"
+                        "description": "Invalid album ID"
                     },
-                    "500": {
+                    "404": {
                         "content": {
                             "application/json": {
                                 "schema": {
@@ -8941,40 +10348,62 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Internal server error"
+                        "description": "Album not found"
                     }
                 },
-                "summary": "Get one folder summary",
-                "tags": [
-                    "assets"
-                ]
-            }
-        },
-        "/api/v1/assets/indexing/rebuild": {
-            "post": {
-                "description": "Queue a background batch that backfills AI indexing for existing photos.",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "summary": "Get album by ID",
+                "tags": [
+                    "albums"
+                ]
+            },
+            "put": {
+                "description": "Update an existing album's information",
+                "parameters": [
+                    {
+                        "description": "Album ID",
+                        "in": "path",
+                        "name": "id",
+                        "required": true,
+                        "schema": {
+                            "type": "integer"
+                        }
+                    }
+                ],
                 "requestBody": {
                     "content": {
                         "application/json": {
                             "schema": {
-                                "$ref": "#/components/schemas/dto.RebuildAssetIndexesRequestDTO",
-                                "summary": "data",
-                                "description": "Reindex request"
+                                "oneOf": [
+                                    {
+                                        "type": "object"
+                                    },
+                                    {
+                                        "$ref": "#/components/schemas/dto.UpdateAlbumRequestDTO",
+                                        "summary": "request",
+                                        "description": "Album update data"
+                                    }
+                                ]
                             }
                         }
                     },
-                    "description": "Reindex request"
+                    "description": "Album update data",
+                    "required": true
                 },
                 "responses": {
                     "200": {
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/dto.RebuildAssetIndexesResponseDTO"
+                                    "$ref": "#/components/schemas/dto.GetAlbumResponseDTO"
                                 }
                             }
                         },
-                        "description": "Reindex job queued successfully"
+                        "description": "Album updated successfully"
                     },
                     "400": {
                         "content": {
@@ -8984,9 +10413,9 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Invalid request parameters"
+                        "description": "Invalid album ID or request data"
                     },
-                    "500": {
+                    "401": {
                         "content": {
                             "application/json": {
                                 "schema": {
@@ -8994,37 +10423,27 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Internal server error"
-                    }
-                },
-                "summary": "Queue asset index rebuild",
-                "tags": [
-                    "assets"
-                ]
-            }
-        },
-        "/api/v1/assets/indexing/repositories": {
-            "get": {
-                "description": "Return the shared repository registry for browse-scope/upload selectors and indexing filters. Paths are only included for admins.",
-                "requestBody": {
-                    "content": {
-                        "application/json": {
-                            "schema": {
-                                "type": "object"
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
                             }
-                        }
-                    }
-                },
-                "responses": {
-                    "200": {
+                        },
+                        "description": "Forbidden"
+                    },
+                    "404": {
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/dto.IndexingRepositoryListResponseDTO"
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
                                 }
                             }
                         },
-                        "description": "Repository options retrieved successfully"
+                        "description": "Album not found"
                     },
                     "500": {
                         "content": {
@@ -9034,19 +10453,33 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Internal server error"
+                        "description": "Failed to update album"
                     }
                 },
-                "summary": "List repositories for scope selection",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "summary": "Update album",
                 "tags": [
-                    "assets"
+                    "albums"
                 ]
             }
         },
-        "/api/v1/assets/indexing/stats": {
+        "/api/v1/albums/{id}/assets": {
             "get": {
-                "description": "Return indexing coverage and queued job counts for photo AI tasks.",
+                "description": "Retrieve all assets in a specific album. A smart album's rules are evaluated at request time, newest capture first, and its assets carry no position or added time.",
                 "parameters": [
+                    {
+                        "description": "Album ID",
+                        "in": "path",
+                        "name": "id",
+                        "required": true,
+                        "schema": {
+                            "type": "integer"
+                        }
+                    },
                     {
                         "description": "Optional repository UUID filter",
                         "in": "query",
@@ -9070,11 +10503,11 @@ const docTemplate = `{
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/dto.AssetIndexingStatsResponseDTO"
+                                    "$ref": "#/components/schemas/dto.AlbumAssetsResponseDTO"
                                 }
                             }
                         },
-                        "description": "Indexing stats retrieved successfully"
+                        "description": "Assets retrieved successfully"
                     },
                     "400": {
                         "content": {
@@ -9084,7 +10517,17 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Invalid repository ID"
+                        "description": "Invalid album ID"
+                    },
+                    "404": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Album not found"
                     },
                     "500": {
                         "content": {
@@ -9094,34 +10537,30 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Internal server error"
+                        "description": "Failed to retrieve album assets"
                     }
                 },
-                "summary": "Get asset indexing stats",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "summary": "Get assets in album",
                 "tags": [
-                    "assets"
+                    "albums"
                 ]
             }
         },
-        "/api/v1/assets/liked": {
-            "get": {
-                "description": "Get all assets that have been liked/favorited",
+        "/api/v1/albums/{id}/assets/order": {
+            "put": {
+                "description": "Set the order of an album's assets in one request. The list must hold every asset of the album exactly once; the first gets position 1. All positions are updated in one transaction. Smart albums follow their rules and cannot be reordered.",
                 "parameters": [
                     {
-                        "description": "Number of assets to return",
-                        "in": "query",
-                        "name": "limit",
-                        "schema": {
-                            "default": 20,
-                            "type": "integer"
-                        }
-                    },
-                    {
-                        "description": "Number of assets to skip",
-                        "in": "query",
-                        "name": "offset",
+                        "description": "Album ID",
+                        "in": "path",
+                        "name": "id",
+                        "required": true,
                         "schema": {
-                            "default": 0,
                             "type": "integer"
                         }
                     }
@@ -9130,23 +10569,34 @@ const docTemplate = `{
                     "content": {
                         "application/json": {
                             "schema": {
-                                "type": "object"
+                                "oneOf": [
+                                    {
+                                        "type": "object"
+                                    },
+                                    {
+                                        "$ref": "#/components/schemas/dto.ReorderAlbumAssetsRequestDTO",
+                                        "summary": "request",
+                                        "description": "Every asset of the album, in the new order"
+                                    }
+                                ]
                             }
                         }
-                    }
+                    },
+                    "description": "Every asset of the album, in the new order",
+                    "required": true
                 },
                 "responses": {
                     "200": {
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/dto.AssetListResponseDTO"
+                                    "$ref": "#/components/schemas/dto.AlbumAssetOrderResponseDTO"
                                 }
                             }
                         },
-                        "description": "Liked assets retrieved successfully"
+                        "description": "Assets reordered successfully"
                     },
-                    "500": {
+                    "400": {
                         "content": {
                             "application/json": {
                                 "schema": {
@@ -9154,43 +10604,19 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Internal server error"
-                    }
-                },
-                "summary": "Get liked assets",
-                "tags": [
-                    "assets"
-                ]
-            }
-        },
-        "/api/v1/assets/list": {
-            "post": {
-                "description": "Unified endpoint for listing, filtering, and searching assets. Replaces separate /filter and /search endpoints.",
-                "requestBody": {
-                    "content": {
-                        "application/json": {
-                            "schema": {
-                                "$ref": "#/components/schemas/dto.AssetQueryRequestDTO",
-                                "summary": "data",
-                                "description": "Query parameters"
-                            }
-                        }
+                        "description": "Invalid request, smart album, or a list that is not exactly the album's assets"
                     },
-                    "description": "Query parameters",
-                    "required": true
-                },
-                "responses": {
-                    "200": {
+                    "401": {
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/dto.QueryAssetsResponseDTO"
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
                                 }
                             }
                         },
-                        "description": "Assets queried successfully"
+                        "description": "Unauthorized"
                     },
-                    "400": {
+                    "403": {
                         "content": {
                             "application/json": {
                                 "schema": {
@@ -9198,9 +10624,9 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Invalid request parameters"
+                        "description": "Forbidden"
                     },
-                    "500": {
+                    "404": {
                         "content": {
                             "application/json": {
                                 "schema": {
@@ -9208,9 +10634,9 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Internal server error"
+                        "description": "Album not found"
                     },
-                    "503": {
+                    "500": {
                         "content": {
                             "application/json": {
                                 "schema": {
@@ -9218,76 +10644,41 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Semantic search unavailable"
+                        "description": "Failed to reorder album assets"
                     }
                 },
-                "summary": "Query assets (unified endpoint)",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "summary": "Reorder album assets",
                 "tags": [
-                    "assets"
+                    "albums"
                 ]
             }
         },
-        "/api/v1/assets/map-points": {
-            "get": {
-                "description": "Return lightweight paginated photo records containing only map-related fields (asset ID, filename, times, GPS lat/lon).",
+        "/api/v1/albums/{id}/assets/{assetId}": {
+            "delete": {
+                "description": "Remove an asset from a specific album",
                 "parameters": [
                     {
-                        "description": "Page size (1-5000)",
-                        "in": "query",
-                        "name": "limit",
-                        "schema": {
-                            "default": 1000,
-                            "type": "integer"
-                        }
-                    },
-                    {
-                        "description": "Page offset",
-                        "in": "query",
-                        "name": "offset",
+                        "description": "Album ID",
+                        "in": "path",
+                        "name": "id",
+                        "required": true,
                         "schema": {
-                            "default": 0,
                             "type": "integer"
                         }
                     },
                     {
-                        "description": "Optional repository UUID filter",
-                        "in": "query",
-                        "name": "repository_id",
+                        "description": "Asset ID (UUID format)",
+                        "in": "path",
+                        "name": "assetId",
+                        "required": true,
                         "schema": {
                             "type": "string"
                         }
-                    },
-                    {
-                        "description": "Viewport south latitude (-90 to 90)",
-                        "in": "query",
-                        "name": "south",
-                        "schema": {
-                            "type": "number"
-                        }
-                    },
-                    {
-                        "description": "Viewport north latitude (-90 to 90)",
-                        "in": "query",
-                        "name": "north",
-                        "schema": {
-                            "type": "number"
-                        }
-                    },
-                    {
-                        "description": "Viewport west longitude (-180 to 180)",
-                        "in": "query",
-                        "name": "west",
-                        "schema": {
-                            "type": "number"
-                        }
-                    },
-                    {
-                        "description": "Viewport east longitude (-180 to 180)",
-                        "in": "query",
-                        "name": "east",
-                        "schema": {
-                            "type": "number"
-                        }
                     }
                 ],
                 "requestBody": {
@@ -9304,11 +10695,11 @@ const docTemplate = `{
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/dto.AssetMapPointListResponseDTO"
+                                    "$ref": "#/components/schemas/api.SuccessResponse"
                                 }
                             }
                         },
-                        "description": "Map points retrieved successfully"
+                        "description": "Asset removed from album successfully"
                     },
                     "400": {
                         "content": {
@@ -9318,7 +10709,7 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Invalid request parameters"
+                        "description": "Invalid album ID or asset ID, or smart album"
                     },
                     "500": {
                         "content": {
@@ -9328,18 +10719,41 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Internal server error"
+                        "description": "Failed to remove asset from album"
                     }
                 },
-                "summary": "Get photo map points",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "summary": "Remove asset from album",
                 "tags": [
-                    "assets"
+                    "albums"
                 ]
-            }
-        },
-        "/api/v1/assets/precheck": {
+            },
             "post": {
-                "description": "Given client-computed BLAKE3 fingerprints, reports advisory candidates. Candidates must still be uploaded for server-side full-file verification.",
+                "description": "Add an asset to a specific album",
+                "parameters": [
+                    {
+                        "description": "Album ID",
+                        "in": "path",
+                        "name": "id",
+                        "required": true,
+                        "schema": {
+                            "type": "integer"
+                        }
+                    },
+                    {
+                        "description": "Asset ID (UUID format)",
+                        "in": "path",
+                        "name": "assetId",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
                 "requestBody": {
                     "content": {
                         "application/json": {
@@ -9349,27 +10763,26 @@ const docTemplate = `{
                                         "type": "object"
                                     },
                                     {
-                                        "$ref": "#/components/schemas/dto.UploadPrecheckRequestDTO",
+                                        "$ref": "#/components/schemas/dto.AddAssetToAlbumRequestDTO",
                                         "summary": "request",
-                                        "description": "Candidate files"
+                                        "description": "Asset position in album"
                                     }
                                 ]
                             }
                         }
                     },
-                    "description": "Candidate files",
-                    "required": true
+                    "description": "Asset position in album"
                 },
                 "responses": {
                     "200": {
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/dto.UploadPrecheckResponseDTO"
+                                    "$ref": "#/components/schemas/api.SuccessResponse"
                                 }
                             }
                         },
-                        "description": "OK"
+                        "description": "Asset added to album successfully"
                     },
                     "400": {
                         "content": {
@@ -9379,7 +10792,7 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Bad Request"
+                        "description": "Invalid album ID or asset ID, or smart album"
                     },
                     "404": {
                         "content": {
@@ -9389,7 +10802,7 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Not Found"
+                        "description": "Album not found"
                     },
                     "500": {
                         "content": {
@@ -9399,44 +10812,40 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Internal Server Error"
+                        "description": "Failed to add asset to album"
                     }
                 },
-                "summary": "Precheck uploads against existing content fingerprints",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "summary": "Add asset to album",
                 "tags": [
-                    "assets"
+                    "albums"
                 ]
             }
         },
-        "/api/v1/assets/rating/{rating}": {
-            "get": {
-                "description": "Get assets with a specific rating (0-5)",
+        "/api/v1/albums/{id}/assets/{assetId}/position": {
+            "put": {
+                "description": "Update the position of an asset within a specific album",
                 "parameters": [
                     {
-                        "description": "Rating (0-5)",
+                        "description": "Album ID",
                         "in": "path",
-                        "name": "rating",
+                        "name": "id",
                         "required": true,
                         "schema": {
                             "type": "integer"
                         }
                     },
                     {
-                        "description": "Number of assets to return",
-                        "in": "query",
-                        "name": "limit",
-                        "schema": {
-                            "default": 20,
-                            "type": "integer"
-                        }
-                    },
-                    {
-                        "description": "Number of assets to skip",
-                        "in": "query",
-                        "name": "offset",
+                        "description": "Asset ID (UUID format)",
+                        "in": "path",
+                        "name": "assetId",
+                        "required": true,
                         "schema": {
-                            "default": 0,
-                            "type": "integer"
+                            "type": "string"
                         }
                     }
                 ],
@@ -9444,21 +10853,32 @@ const docTemplate = `{
                     "content": {
                         "application/json": {
                             "schema": {
-                                "type": "object"
+                                "oneOf": [
+                                    {
+                                        "type": "object"
+                                    },
+                                    {
+                                        "$ref": "#/components/schemas/dto.UpdateAssetPositionRequestDTO",
+                                        "summary": "request",
+                                        "description": "New position data"
+                                    }
+                                ]
                             }
                         }
-                    }
+                    },
+                    "description": "New position data",
+                    "required": true
                 },
                 "responses": {
                     "200": {
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/dto.AssetListResponseDTO"
+                                    "$ref": "#/components/schemas/api.SuccessResponse"
                                 }
                             }
                         },
-                        "description": "Assets retrieved successfully"
+                        "description": "Asset position updated successfully"
                     },
                     "400": {
                         "content": {
@@ -9468,7 +10888,7 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Bad request"
+                        "description": "Invalid album ID or asset ID, or smart album"
                     },
                     "500": {
                         "content": {
@@ -9478,41 +10898,53 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Internal server error"
+                        "description": "Failed to update asset position"
                     }
                 },
-                "summary": "Get assets by rating",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "summary": "Update asset position in album",
                 "tags": [
-                    "assets"
+                    "albums"
                 ]
             }
         },
-        "/api/v1/assets/search": {
+        "/api/v1/albums/{id}/bioclip/rebuild": {
             "post": {
-                "description": "Search assets with optional top results enhancement and filename fallback.",
+                "description": "Queue BioCLIP processing for photo assets in a bio album that do not yet have species predictions.",
+                "parameters": [
+                    {
+                        "description": "Album ID",
+                        "in": "path",
+                        "name": "id",
+                        "required": true,
+                        "schema": {
+                            "type": "integer"
+                        }
+                    }
+                ],
                 "requestBody": {
                     "content": {
                         "application/json": {
                             "schema": {
-                                "$ref": "#/components/schemas/dto.SearchAssetsRequestDTO",
-                                "summary": "data",
-                                "description": "Search parameters"
+                                "type": "object"
                             }
                         }
-                    },
-                    "description": "Search parameters",
-                    "required": true
+                    }
                 },
                 "responses": {
                     "200": {
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/dto.SearchAssetsResponseDTO"
+                                    "$ref": "#/components/schemas/dto.RebuildAlbumBioClipResponseDTO"
                                 }
                             }
                         },
-                        "description": "Assets searched successfully"
+                        "description": "BioCLIP jobs queued successfully"
                     },
                     "400": {
                         "content": {
@@ -9522,9 +10954,9 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Invalid request parameters"
+                        "description": "Invalid album or album type"
                     },
-                    "500": {
+                    "401": {
                         "content": {
                             "application/json": {
                                 "schema": {
@@ -9532,43 +10964,19 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Internal server error"
-                    }
-                },
-                "summary": "Search assets",
-                "tags": [
-                    "assets"
-                ]
-            }
-        },
-        "/api/v1/assets/stacks": {
-            "post": {
-                "description": "Manually groups the specified assets into a new stack",
-                "requestBody": {
-                    "content": {
-                        "application/json": {
-                            "schema": {
-                                "$ref": "#/components/schemas/dto.CreateManualStackRequestDTO",
-                                "summary": "data",
-                                "description": "Asset IDs to stack"
-                            }
-                        }
+                        "description": "Unauthorized"
                     },
-                    "description": "Asset IDs to stack",
-                    "required": true
-                },
-                "responses": {
-                    "201": {
+                    "403": {
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/dto.StackDTO"
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
                                 }
                             }
                         },
-                        "description": "Created"
+                        "description": "Forbidden"
                     },
-                    "400": {
+                    "404": {
                         "content": {
                             "application/json": {
                                 "schema": {
@@ -9576,9 +10984,9 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Bad Request"
+                        "description": "Album not found"
                     },
-                    "409": {
+                    "503": {
                         "content": {
                             "application/json": {
                                 "schema": {
@@ -9586,7 +10994,7 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Conflict"
+                        "description": "BioCLIP unavailable"
                     }
                 },
                 "security": [
@@ -9594,69 +11002,4650 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "summary": "Create manual stack",
+                "summary": "Queue BioCLIP for a bio album",
                 "tags": [
-                    "assets"
+                    "albums"
                 ]
             }
         },
-        "/api/v1/assets/tag-summaries": {
-            "get": {
-                "description": "List manual and AI/system tags with usage counts and covers, for the Tags collection view",
+        "/api/v1/albums/{id}/cover/auto": {
+            "put": {
+                "description": "Replace the cover of an album with its highest-rated asset, the most recently taken among equally rated ones, or leave an empty album without a cover. The server keeps making this choice as assets are added and removed until a cover is set by PUT /api/v1/albums/{id}/cover/{assetId}. Albums created without a cover start out this way, and an album whose cover is removed from it switches to it.",
                 "parameters": [
                     {
-                        "description": "Optional repository UUID filter",
-                        "in": "query",
-                        "name": "repository_id",
+                        "description": "Album ID",
+                        "in": "path",
+                        "name": "id",
+                        "required": true,
                         "schema": {
-                            "type": "string"
+                            "type": "integer"
                         }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/dto.GetAlbumResponseDTO"
+                                }
+                            }
+                        },
+                        "description": "Album cover updated successfully"
                     },
-                    {
-                        "description": "Optional tag source filter (e.g. manual, zeroshot)",
-                        "in": "query",
+                    "400": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Invalid album ID, or a smart album"
+                    },
+                    "401": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Album not found"
+                    },
+                    "500": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Failed to update album cover"
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "summary": "Choose album cover automatically",
+                "tags": [
+                    "albums"
+                ]
+            }
+        },
+        "/api/v1/albums/{id}/cover/{assetId}": {
+            "put": {
+                "description": "Set the cover asset of an album without resending the full album payload. The asset must be accessible to the album owner; when the server requires it, the asset must also be in the album.",
+                "parameters": [
+                    {
+                        "description": "Album ID",
+                        "in": "path",
+                        "name": "id",
+                        "required": true,
+                        "schema": {
+                            "type": "integer"
+                        }
+                    },
+                    {
+                        "description": "Asset ID (UUID format)",
+                        "in": "path",
+                        "name": "assetId",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "requestBody": {
+                    "content": {
+                        "application/json": {
+                            "schema": {
+                                "type": "object"
+                            }
+                        }
+                    }
+                },
+                "responses": {
+                    "200": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/dto.GetAlbumResponseDTO"
+                                }
+                            }
+                        },
+                        "description": "Album cover updated successfully"
+                    },
+                    "400": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Invalid album ID or asset ID, or asset not in album"
+                    },
+                    "401": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Album or asset not found"
+                    },
+                    "500": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Failed to update album cover"
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "summary": "Set album cover",
+                "tags": [
+                    "albums"
+                ]
+            }
+        },
+        "/api/v1/albums/{id}/share": {
+            "post": {
+                "description": "Create a revocable public link to an album, viewable at GET /api/v1/public/albums/{token} without signing in. The link holds the album's assets at this moment, including what a smart album's rules match now. It can expire and can require a password. The raw token is returned only in this response.",
+                "parameters": [
+                    {
+                        "description": "Album ID",
+                        "in": "path",
+                        "name": "id",
+                        "required": true,
+                        "schema": {
+                            "type": "integer"
+                        }
+                    }
+                ],
+                "requestBody": {
+                    "content": {
+                        "application/json": {
+                            "schema": {
+                                "oneOf": [
+                                    {
+                                        "type": "object"
+                                    },
+                                    {
+                                        "$ref": "#/components/schemas/dto.ShareAlbumRequestDTO",
+                                        "summary": "request",
+                                        "description": "Share settings"
+                                    }
+                                ]
+                            }
+                        }
+                    },
+                    "description": "Share settings"
+                },
+                "responses": {
+                    "200": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/dto.CreateShareLinkResponseDTO"
+                                }
+                            }
+                        },
+                        "description": "Album shared successfully"
+                    },
+                    "400": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Invalid album ID, settings, or an empty album"
+                    },
+                    "401": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Album not found"
+                    },
+                    "500": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Failed to create share link"
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "summary": "Share an album",
+                "tags": [
+                    "albums"
+                ]
+            }
+        },
+        "/api/v1/albums/{id}/share/{token}": {
+            "delete": {
+                "description": "Revoke a public link created by POST /api/v1/albums/{id}/share, identified by its token. The link stops working immediately and stays listed under /api/v1/share-links as revoked.",
+                "parameters": [
+                    {
+                        "description": "Album ID",
+                        "in": "path",
+                        "name": "id",
+                        "required": true,
+                        "schema": {
+                            "type": "integer"
+                        }
+                    },
+                    {
+                        "description": "Share token",
+                        "in": "path",
+                        "name": "token",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/dto.ShareLinkDTO"
+                                }
+                            }
+                        },
+                        "description": "Share revoked successfully"
+                    },
+                    "400": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Invalid album ID"
+                    },
+                    "401": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Share link not found"
+                    },
+                    "500": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Failed to access share link"
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "summary": "Revoke an album share",
+                "tags": [
+                    "albums"
+                ]
+            }
+        },
+        "/api/v1/assets": {
+            "post": {
+                "description": "Upload a single photo, video, audio file, or document to the system. The file is staged in a repository and queued for processing. When its file name is already in use, duplicate_action reports what the repository's duplicate file name handling does: renamed, overwritten, or skipped, in which case the status is skipped and nothing is stored.",
+                "parameters": [
+                    {
+                        "description": "BLAKE3 hash of the file. Used to link an existing asset with dedupe; otherwise the ingest worker checks it and records hash_verified in specific_metadata, leaving a mismatched asset with a warning",
+                        "example": "\"af1349b9f5f9a1a6a0404dea36dcc9499bcb25c9adc112b7cc9a93cae41f3262\"",
+                        "in": "header",
+                        "name": "X-Content-Hash",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "requestBody": {
+                    "content": {
+                        "application/x-www-form-urlencoded": {
+                            "schema": {
+                                "oneOf": [
+                                    {
+                                        "title": "file",
+                                        "type": "file"
+                                    },
+                                    {
+                                        "example": "\"550e8400-e29b-41d4-a716-446655440000\"",
+                                        "title": "repository_id",
+                                        "type": "string"
+                                    },
+                                    {
+                                        "example": "\"2025-07-14T09:30:00Z\"",
+                                        "title": "taken_time",
+                                        "type": "string"
+                                    },
+                                    {
+                                        "example": 48.8584,
+                                        "title": "latitude",
+                                        "type": "number"
+                                    },
+                                    {
+                                        "example": 2.2945,
+                                        "title": "longitude",
+                                        "type": "number"
+                                    },
+                                    {
+                                        "example": "\"iPhone 15 Pro\"",
+                                        "title": "device",
+                                        "type": "string"
+                                    },
+                                    {
+                                        "example": true,
+                                        "title": "dedupe",
+                                        "type": "boolean"
+                                    }
+                                ]
+                            }
+                        },
+                        "multipart/form-data": {
+                            "schema": {
+                                "type": "object"
+                            }
+                        }
+                    },
+                    "description": "Asset file to upload | Repository UUID (uses default repository if not provided) | Client-known capture time (RFC 3339), used only when EXIF has none | Client-known capture latitude, used only when EXIF has no GPS | Client-known capture longitude, used only when EXIF has no GPS | Client-known capture device, used only when EXIF has no camera model | Return an existing asset with the hash in X-Content-Hash and the same size, with status duplicate and its asset_id, instead of storing the file again. Only assets the caller can read match. An unowned asset is given to the caller only when the server's own hash of the upload matches it, never on the header alone. Without the header, a duplicate found after hashing the upload also reports asset_id."
+                },
+                "responses": {
+                    "200": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/dto.UploadResponseDTO"
+                                }
+                            }
+                        },
+                        "description": "Upload successful, or a duplicate of an existing asset"
+                    },
+                    "400": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Bad request - no file provided, parse error, or malformed X-Content-Hash"
+                    },
+                    "429": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Too many concurrent uploads for this user; see Retry-After"
+                    },
+                    "500": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Internal server error"
+                    }
+                },
+                "summary": "Upload a single asset",
+                "tags": [
+                    "assets"
+                ]
+            }
+        },
+        "/api/v1/assets/around-time": {
+            "get": {
+                "description": "List assets whose capture time lies within window before or after at, closest first, to match photos to an external event. Assets without a capture time are never returned. Non-admins only see their own assets; admins may pass owner_id to look at one user.",
+                "parameters": [
+                    {
+                        "description": "Instant to search around (RFC 3339)",
+                        "example": "2024-06-15T15:00:00Z",
+                        "in": "query",
+                        "name": "at",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Maximum distance from at, as a Go duration of at most 720h",
+                        "in": "query",
+                        "name": "window",
+                        "schema": {
+                            "default": "1h",
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Owner user ID (admins only; others may only pass their own ID)",
+                        "in": "query",
+                        "name": "owner_id",
+                        "schema": {
+                            "type": "integer"
+                        }
+                    },
+                    {
+                        "description": "Number of assets to return",
+                        "in": "query",
+                        "name": "limit",
+                        "schema": {
+                            "default": 50,
+                            "type": "integer"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/dto.AssetListResponseDTO"
+                                }
+                            }
+                        },
+                        "description": "Assets closest to at first"
+                    },
+                    "400": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Invalid request parameters"
+                    },
+                    "401": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Forbidden"
+                    },
+                    "500": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Internal server error"
+                    }
+                },
+                "summary": "List assets taken around a time",
+                "tags": [
+                    "assets"
+                ]
+            }
+        },
+        "/api/v1/assets/batch": {
+            "post": {
+                "description": "Unified batch upload endpoint that supports both small files and chunked large files. Field names should follow format: single_{session_id} for single files or chunk_{session_id}_{index}_{total} for chunks. Results hold one entry per session, in the order each session's first part appeared in the body, and carry the session ID.",
+                "requestBody": {
+                    "content": {
+                        "application/x-www-form-urlencoded": {
+                            "schema": {
+                                "oneOf": [
+                                    {
+                                        "example": "\"550e8400-e29b-41d4-a716-446655440000\"",
+                                        "title": "repository_id",
+                                        "type": "string"
+                                    },
+                                    {
+                                        "example": "\"6ba7b810-9dad-11d1-80b4-00c04fd430c8\"",
+                                        "title": "batch_id",
+                                        "type": "string"
+                                    },
+                                    {
+                                        "title": "file",
+                                        "type": "file"
+                                    },
+                                    {
+                                        "title": "file",
+                                        "type": "file"
+                                    }
+                                ]
+                            }
+                        },
+                        "multipart/form-data": {
+                            "schema": {
+                                "type": "object"
+                            }
+                        }
+                    },
+                    "description": "Repository UUID (uses default repository if not provided) | Client-chosen UUID grouping this request's files with other requests of the same import, so POST /api/v1/uploads/batch/{batch_id}/cancel can stop them all. Send it before the files. | Single file upload - use format: single_{session_id} | Chunked file upload - use format: chunk_{session_id}_{index}_{total}"
+                },
+                "responses": {
+                    "200": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/dto.BatchUploadResponseDTO"
+                                }
+                            }
+                        },
+                        "description": "Batch upload completed"
+                    },
+                    "400": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Bad request - no files provided, too many files, or parse error"
+                    },
+                    "403": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Upload batch belongs to another user"
+                    },
+                    "408": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Request cancelled before all files were processed"
+                    },
+                    "409": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Upload batch was cancelled"
+                    },
+                    "429": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Too many concurrent uploads for this user; see Retry-After"
+                    },
+                    "500": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Internal server error"
+                    }
+                },
+                "summary": "Batch upload assets with chunk support",
+                "tags": [
+                    "assets"
+                ]
+            }
+        },
+        "/api/v1/assets/batch-delete": {
+            "post": {
+                "description": "Delete each listed asset the way DELETE /assets/{id} would, soft by default or permanently with hard=true. Every asset is handled on its own: an invalid ID, a missing asset, a permission failure or a failed deletion is reported in that asset's result and the remaining assets are still processed. At most 100 asset IDs per request.",
+                "requestBody": {
+                    "content": {
+                        "application/json": {
+                            "schema": {
+                                "oneOf": [
+                                    {
+                                        "type": "object"
+                                    },
+                                    {
+                                        "$ref": "#/components/schemas/dto.BatchDeleteAssetsRequestDTO",
+                                        "summary": "request",
+                                        "description": "Asset IDs to delete"
+                                    }
+                                ]
+                            }
+                        }
+                    },
+                    "description": "Asset IDs to delete",
+                    "required": true
+                },
+                "responses": {
+                    "200": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/dto.BatchDeleteResponseDTO"
+                                }
+                            }
+                        },
+                        "description": "Per-asset results"
+                    },
+                    "400": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Invalid request body, no asset IDs or more than 100"
+                    }
+                },
+                "summary": "Batch delete assets",
+                "tags": [
+                    "assets"
+                ]
+            }
+        },
+        "/api/v1/assets/batch/config": {
+            "get": {
+                "description": "Get current upload configuration including chunk size and concurrency limits based on system memory",
+                "requestBody": {
+                    "content": {
+                        "application/json": {
+                            "schema": {
+                                "type": "object"
+                            }
+                        }
+                    }
+                },
+                "responses": {
+                    "200": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/dto.UploadConfigResponseDTO"
+                                }
+                            }
+                        },
+                        "description": "Upload configuration"
+                    }
+                },
+                "summary": "Get upload configuration",
+                "tags": [
+                    "assets"
+                ]
+            }
+        },
+        "/api/v1/assets/batch/jobs": {
+            "get": {
+                "description": "Get backend ingest lifecycle state for upload task IDs owned by the current caller",
+                "parameters": [
+                    {
+                        "description": "Comma-separated upload task IDs",
+                        "in": "query",
+                        "name": "task_ids",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/dto.UploadJobStatusResponseDTO"
+                                }
+                            }
+                        },
+                        "description": "Upload materialization status"
+                    },
+                    "400": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Invalid task IDs"
+                    }
+                },
+                "summary": "Get upload materialization status",
+                "tags": [
+                    "assets"
+                ]
+            }
+        },
+        "/api/v1/assets/batch/jobs/stream": {
+            "get": {
+                "parameters": [
+                    {
+                        "description": "Comma-separated upload task IDs",
+                        "in": "query",
+                        "name": "task_ids",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "content": {
+                            "text/event-stream": {
+                                "schema": {
+                                    "type": "string"
+                                }
+                            }
+                        },
+                        "description": "SSE stream"
+                    }
+                },
+                "summary": "Stream upload materialization status",
+                "tags": [
+                    "assets"
+                ]
+            }
+        },
+        "/api/v1/assets/batch/progress": {
+            "get": {
+                "description": "Get detailed progress information for upload sessions",
+                "parameters": [
+                    {
+                        "description": "Comma-separated session IDs (optional)",
+                        "in": "query",
+                        "name": "session_ids",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "requestBody": {
+                    "content": {
+                        "application/json": {
+                            "schema": {
+                                "type": "object"
+                            }
+                        }
+                    }
+                },
+                "responses": {
+                    "200": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/dto.UploadProgressResponseDTO"
+                                }
+                            }
+                        },
+                        "description": "Upload progress details"
+                    }
+                },
+                "summary": "Get upload progress",
+                "tags": [
+                    "assets"
+                ]
+            }
+        },
+        "/api/v1/assets/batch/sessions": {
+            "post": {
+                "requestBody": {
+                    "content": {
+                        "application/json": {
+                            "schema": {
+                                "oneOf": [
+                                    {
+                                        "type": "object"
+                                    },
+                                    {
+                                        "$ref": "#/components/schemas/dto.CreateUploadSessionRequestDTO",
+                                        "summary": "request",
+                                        "description": "Upload metadata"
+                                    }
+                                ]
+                            }
+                        }
+                    },
+                    "description": "Upload metadata",
+                    "required": true
+                },
+                "responses": {
+                    "200": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/dto.UploadSessionResponseDTO"
+                                }
+                            }
+                        },
+                        "description": "OK"
+                    }
+                },
+                "summary": "Create or resume an upload session",
+                "tags": [
+                    "assets"
+                ]
+            }
+        },
+        "/api/v1/assets/check-hashes": {
+            "post": {
+                "description": "Given full-content BLAKE3 hashes, reports which already exist among the caller's assets so the client can skip uploading them. Unlike /assets/precheck, quick fingerprints are not accepted.",
+                "requestBody": {
+                    "content": {
+                        "application/json": {
+                            "schema": {
+                                "oneOf": [
+                                    {
+                                        "type": "object"
+                                    },
+                                    {
+                                        "$ref": "#/components/schemas/dto.CheckHashesRequestDTO",
+                                        "summary": "request",
+                                        "description": "Hashes to check"
+                                    }
+                                ]
+                            }
+                        }
+                    },
+                    "description": "Hashes to check",
+                    "required": true
+                },
+                "responses": {
+                    "200": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/dto.CheckHashesResponseDTO"
+                                }
+                            }
+                        },
+                        "description": "OK"
+                    },
+                    "400": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Unauthorized"
+                    },
+                    "500": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Internal Server Error"
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "summary": "Check content hashes before upload",
+                "tags": [
+                    "assets"
+                ]
+            }
+        },
+        "/api/v1/assets/contact-sheet": {
+            "post": {
+                "description": "Tile the first columns×rows assets of an album or of POST /assets/list's filter into one image, optionally with filenames under each tile. Tiles are drawn from the stored thumbnails; assets without one get a grey placeholder. Grids are capped at 20×20 tiles and 8000 px per edge.",
+                "requestBody": {
+                    "content": {
+                        "application/json": {
+                            "schema": {
+                                "oneOf": [
+                                    {
+                                        "type": "object"
+                                    },
+                                    {
+                                        "$ref": "#/components/schemas/dto.ContactSheetRequestDTO",
+                                        "summary": "data",
+                                        "description": "Album or filter and grid layout"
+                                    }
+                                ]
+                            }
+                        }
+                    },
+                    "description": "Album or filter and grid layout",
+                    "required": true
+                },
+                "responses": {
+                    "200": {
+                        "content": {
+                            "image/png": {
+                                "schema": {
+                                    "type": "file"
+                                }
+                            }
+                        },
+                        "description": "Contact sheet image"
+                    },
+                    "400": {
+                        "content": {
+                            "image/png": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Invalid request"
+                    },
+                    "404": {
+                        "content": {
+                            "image/png": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "No assets match"
+                    },
+                    "422": {
+                        "content": {
+                            "image/png": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Sheet exceeds the image processing limits"
+                    },
+                    "500": {
+                        "content": {
+                            "image/png": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Internal server error"
+                    },
+                    "504": {
+                        "content": {
+                            "image/png": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Image processing timed out"
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "summary": "Generate a contact sheet",
+                "tags": [
+                    "assets"
+                ]
+            }
+        },
+        "/api/v1/assets/download": {
+            "post": {
+                "description": "Stream original files for the requested asset IDs as a zip archive. Assets whose original is missing or whose repository is offline or removed are skipped and listed in a trailing MISSING-FILES.txt entry. Selections larger than the configured download limit are rejected.",
+                "requestBody": {
+                    "content": {
+                        "application/json": {
+                            "schema": {
+                                "$ref": "#/components/schemas/dto.DownloadAssetsRequestDTO",
+                                "summary": "data",
+                                "description": "Asset IDs to download"
+                            }
+                        }
+                    },
+                    "description": "Asset IDs to download",
+                    "required": true
+                },
+                "responses": {
+                    "200": {
+                        "content": {
+                            "application/zip": {
+                                "schema": {
+                                    "type": "file"
+                                }
+                            }
+                        },
+                        "description": "Zip archive"
+                    },
+                    "400": {
+                        "content": {
+                            "application/zip": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Invalid request"
+                    },
+                    "401": {
+                        "content": {
+                            "application/zip": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Authentication required"
+                    },
+                    "403": {
+                        "content": {
+                            "application/zip": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "content": {
+                            "application/zip": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Asset not found, or none of the originals exist"
+                    },
+                    "413": {
+                        "content": {
+                            "application/zip": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Selected files exceed the download limit"
+                    },
+                    "500": {
+                        "content": {
+                            "application/zip": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Internal server error"
+                    }
+                },
+                "summary": "Download assets",
+                "tags": [
+                    "assets"
+                ]
+            }
+        },
+        "/api/v1/assets/featured": {
+            "get": {
+                "description": "Select a small set of featured photos using deterministic weighted sampling (A-ES) with diversity constraints.",
+                "parameters": [
+                    {
+                        "description": "Number of featured photos to return",
+                        "in": "query",
+                        "name": "count",
+                        "schema": {
+                            "default": 8,
+                            "type": "integer"
+                        }
+                    },
+                    {
+                        "description": "Max candidate photos considered before selection",
+                        "in": "query",
+                        "name": "candidate_limit",
+                        "schema": {
+                            "default": 240,
+                            "type": "integer"
+                        }
+                    },
+                    {
+                        "description": "Only consider photos from the last N days (0 disables date cutoff)",
+                        "in": "query",
+                        "name": "days",
+                        "schema": {
+                            "default": 3650,
+                            "type": "integer"
+                        }
+                    },
+                    {
+                        "description": "Deterministic seed (default: current UTC date YYYY-MM-DD)",
+                        "in": "query",
+                        "name": "seed",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Optional repository UUID filter",
+                        "in": "query",
+                        "name": "repository_id",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "requestBody": {
+                    "content": {
+                        "application/json": {
+                            "schema": {
+                                "type": "object"
+                            }
+                        }
+                    }
+                },
+                "responses": {
+                    "200": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/dto.FeaturedAssetsResponseDTO"
+                                }
+                            }
+                        },
+                        "description": "Featured photos selected successfully"
+                    },
+                    "400": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Invalid request parameters"
+                    },
+                    "500": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Internal server error"
+                    }
+                },
+                "summary": "Get featured photos",
+                "tags": [
+                    "assets"
+                ]
+            }
+        },
+        "/api/v1/assets/filter-options": {
+            "get": {
+                "description": "Get available camera models, lenses and custom field values for filter dropdowns",
+                "requestBody": {
+                    "content": {
+                        "application/json": {
+                            "schema": {
+                                "type": "object"
+                            }
+                        }
+                    }
+                },
+                "responses": {
+                    "200": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/dto.OptionsResponseDTO"
+                                }
+                            }
+                        },
+                        "description": "Filter options retrieved successfully"
+                    },
+                    "500": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Internal server error"
+                    }
+                },
+                "summary": "Get filter options",
+                "tags": [
+                    "assets"
+                ]
+            }
+        },
+        "/api/v1/assets/filter/preview": {
+            "post": {
+                "description": "Validate an asset filter as sent to POST /assets/list and return how many assets it matches with the first few asset IDs, without fetching pages. Unlike the listing, which quietly ignores or defaults values it does not understand, every invalid field is reported. The count is of assets, with stacks expanded.",
+                "requestBody": {
+                    "content": {
+                        "application/json": {
+                            "schema": {
+                                "oneOf": [
+                                    {
+                                        "type": "object"
+                                    },
+                                    {
+                                        "$ref": "#/components/schemas/dto.AssetFilterPreviewRequestDTO",
+                                        "summary": "data",
+                                        "description": "Filter to preview"
+                                    }
+                                ]
+                            }
+                        }
+                    },
+                    "description": "Filter to preview",
+                    "required": true
+                },
+                "responses": {
+                    "200": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/dto.AssetFilterPreviewResponseDTO"
+                                }
+                            }
+                        },
+                        "description": "Match count and sample asset IDs"
+                    },
+                    "400": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/dto.AssetFilterValidationErrorDTO"
+                                }
+                            }
+                        },
+                        "description": "Invalid filter, with every invalid field"
+                    },
+                    "500": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Internal server error"
+                    }
+                },
+                "summary": "Preview an asset filter",
+                "tags": [
+                    "assets"
+                ]
+            }
+        },
+        "/api/v1/assets/folders": {
+            "get": {
+                "description": "List immediate child folders of a repository-relative path, with recursive asset counts and covers, for the Folders collection view",
+                "parameters": [
+                    {
+                        "description": "Optional repository UUID filter",
+                        "in": "query",
+                        "name": "repository_id",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Repository-relative parent folder path (empty for root)",
+                        "in": "query",
+                        "name": "path",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/dto.FolderListResponseDTO"
+                                }
+                            }
+                        },
+                        "description": "Folder summaries retrieved successfully"
+                    },
+                    "400": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Invalid request parameters"
+                    },
+                    "500": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Internal server error"
+                    }
+                },
+                "summary": "List folder summaries",
+                "tags": [
+                    "assets"
+                ]
+            }
+        },
+        "/api/v1/assets/folders/summary": {
+            "get": {
+                "description": "Get recursive asset counts, date range, and cover for one repository-relative folder path, for the Folder detail header",
+                "parameters": [
+                    {
+                        "description": "Repository UUID",
+                        "in": "query",
+                        "name": "repository_id",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Repository-relative folder path (empty for root)",
+                        "in": "query",
+                        "name": "path",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/dto.FolderSummaryDTO"
+                                }
+                            }
+                        },
+                        "description": "Folder summary retrieved successfully"
+                    },
+                    "400": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Invalid request parameters"
+                    },
+                    "500": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Internal server error"
+                    }
+                },
+                "summary": "Get one folder summary",
+                "tags": [
+                    "assets"
+                ]
+            }
+        },
+        "/api/v1/assets/from-url": {
+            "post": {
+                "description": "Download a photo, video or audio file from a public http(s) URL into a repository's staging area and queue it for processing like an upload. Loopback, private, link-local and other internal addresses are refused, including through redirects and DNS; storage.url_import_allowed_hosts can narrow the hosts further. The file type comes from file_name, else the Content-Disposition or URL path, and payloads that are text or markup (an HTML error page, say) are refused.",
+                "requestBody": {
+                    "content": {
+                        "application/json": {
+                            "schema": {
+                                "oneOf": [
+                                    {
+                                        "type": "object"
+                                    },
+                                    {
+                                        "$ref": "#/components/schemas/dto.ImportAssetFromURLRequestDTO",
+                                        "summary": "request",
+                                        "description": "URL to import"
+                                    }
+                                ]
+                            }
+                        }
+                    },
+                    "description": "URL to import",
+                    "required": true
+                },
+                "responses": {
+                    "200": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/dto.UploadResponseDTO"
+                                }
+                            }
+                        },
+                        "description": "Import queued, or skipped as a duplicate"
+                    },
+                    "400": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Invalid or forbidden URL, or unsupported file type"
+                    },
+                    "401": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Authentication required"
+                    },
+                    "403": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "URL imports are disabled"
+                    },
+                    "404": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Repository not found"
+                    },
+                    "413": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Remote file exceeds storage.url_import_max_bytes"
+                    },
+                    "429": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Too many concurrent uploads for this user; see Retry-After"
+                    },
+                    "500": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Internal server error"
+                    },
+                    "502": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Remote server could not be fetched"
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "summary": "Import an asset from a URL",
+                "tags": [
+                    "assets"
+                ]
+            }
+        },
+        "/api/v1/assets/gear-stats": {
+            "get": {
+                "description": "Get shot counts grouped by camera body and by lens, plus the most-used focal lengths (rounded to whole millimetres)",
+                "parameters": [
+                    {
+                        "description": "Maximum entries per group (1-100)",
+                        "in": "query",
+                        "name": "limit",
+                        "schema": {
+                            "default": 20,
+                            "type": "integer"
+                        }
+                    },
+                    {
+                        "description": "Optional repository UUID filter",
+                        "in": "query",
+                        "name": "repository_id",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Optional owner user ID filter; users are always limited to their own assets, admins may pick any owner",
+                        "in": "query",
+                        "name": "owner_id",
+                        "schema": {
+                            "type": "integer"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/handler.GearStatsResponse"
+                                }
+                            }
+                        },
+                        "description": "OK"
+                    },
+                    "400": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "owner_id sent without authentication"
+                    },
+                    "403": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "owner_id names another user"
+                    },
+                    "500": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Internal Server Error"
+                    }
+                },
+                "summary": "Get gear usage stats",
+                "tags": [
+                    "stats"
+                ]
+            }
+        },
+        "/api/v1/assets/indexing/rebuild": {
+            "post": {
+                "description": "Queue a background batch that backfills AI indexing for existing photos. Use the semantic task to recompute embeddings, with reset_semantic after a model swap. A repository and a capture date range narrow the photos; date-only bounds are whole days in viewer_timezone (UTC by default), and a lone date-only from means that single day. Only photos are indexed. With dry_run nothing is queued and matching_assets reports how many photos the rebuild would visit.",
+                "requestBody": {
+                    "content": {
+                        "application/json": {
+                            "schema": {
+                                "$ref": "#/components/schemas/dto.RebuildAssetIndexesRequestDTO",
+                                "summary": "data",
+                                "description": "Reindex request"
+                            }
+                        }
+                    },
+                    "description": "Reindex request"
+                },
+                "responses": {
+                    "200": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/dto.RebuildAssetIndexesResponseDTO"
+                                }
+                            }
+                        },
+                        "description": "Reindex job queued, or the dry-run count"
+                    },
+                    "400": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Invalid request parameters"
+                    },
+                    "500": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Internal server error"
+                    }
+                },
+                "summary": "Queue asset index rebuild",
+                "tags": [
+                    "assets"
+                ]
+            }
+        },
+        "/api/v1/assets/indexing/repositories": {
+            "get": {
+                "description": "Return the shared repository registry for browse-scope/upload selectors and indexing filters. Paths are only included for admins.",
+                "requestBody": {
+                    "content": {
+                        "application/json": {
+                            "schema": {
+                                "type": "object"
+                            }
+                        }
+                    }
+                },
+                "responses": {
+                    "200": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/dto.IndexingRepositoryListResponseDTO"
+                                }
+                            }
+                        },
+                        "description": "Repository options retrieved successfully"
+                    },
+                    "500": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Internal server error"
+                    }
+                },
+                "summary": "List repositories for scope selection",
+                "tags": [
+                    "assets"
+                ]
+            }
+        },
+        "/api/v1/assets/indexing/stats": {
+            "get": {
+                "description": "Return indexing coverage and queued job counts for photo AI tasks.",
+                "parameters": [
+                    {
+                        "description": "Optional repository UUID filter",
+                        "in": "query",
+                        "name": "repository_id",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "requestBody": {
+                    "content": {
+                        "application/json": {
+                            "schema": {
+                                "type": "object"
+                            }
+                        }
+                    }
+                },
+                "responses": {
+                    "200": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/dto.AssetIndexingStatsResponseDTO"
+                                }
+                            }
+                        },
+                        "description": "Indexing stats retrieved successfully"
+                    },
+                    "400": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Invalid repository ID"
+                    },
+                    "500": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Internal server error"
+                    }
+                },
+                "summary": "Get asset indexing stats",
+                "tags": [
+                    "assets"
+                ]
+            }
+        },
+        "/api/v1/assets/liked": {
+            "get": {
+                "description": "Get all assets that have been liked/favorited",
+                "parameters": [
+                    {
+                        "description": "Number of assets to return",
+                        "in": "query",
+                        "name": "limit",
+                        "schema": {
+                            "default": 20,
+                            "type": "integer"
+                        }
+                    },
+                    {
+                        "description": "Number of assets to skip",
+                        "in": "query",
+                        "name": "offset",
+                        "schema": {
+                            "default": 0,
+                            "type": "integer"
+                        }
+                    }
+                ],
+                "requestBody": {
+                    "content": {
+                        "application/json": {
+                            "schema": {
+                                "type": "object"
+                            }
+                        }
+                    }
+                },
+                "responses": {
+                    "200": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/dto.AssetListResponseDTO"
+                                }
+                            }
+                        },
+                        "description": "Liked assets retrieved successfully"
+                    },
+                    "500": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Internal server error"
+                    }
+                },
+                "summary": "Get liked assets",
+                "tags": [
+                    "assets"
+                ]
+            }
+        },
+        "/api/v1/assets/list": {
+            "post": {
+                "description": "Unified endpoint for listing, filtering, and searching assets. Replaces separate /filter and /search endpoints. Pages by offset, or by pagination.cursor set to the previous page's next_cursor, which stays stable while assets are added or removed. Cursors are not available for semantic search.",
+                "requestBody": {
+                    "content": {
+                        "application/json": {
+                            "schema": {
+                                "$ref": "#/components/schemas/dto.AssetQueryRequestDTO",
+                                "summary": "data",
+                                "description": "Query parameters"
+                            }
+                        }
+                    },
+                    "description": "Query parameters",
+                    "required": true
+                },
+                "responses": {
+                    "200": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/dto.QueryAssetsResponseDTO"
+                                }
+                            }
+                        },
+                        "description": "Assets queried successfully"
+                    },
+                    "400": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Invalid request parameters"
+                    },
+                    "500": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Internal server error"
+                    },
+                    "503": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Semantic search unavailable"
+                    }
+                },
+                "summary": "Query assets (unified endpoint)",
+                "tags": [
+                    "assets"
+                ]
+            }
+        },
+        "/api/v1/assets/map-points": {
+            "get": {
+                "description": "Return lightweight paginated photo records containing only map-related fields (asset ID, filename, times, GPS lat/lon).",
+                "parameters": [
+                    {
+                        "description": "Page size (1-5000)",
+                        "in": "query",
+                        "name": "limit",
+                        "schema": {
+                            "default": 1000,
+                            "type": "integer"
+                        }
+                    },
+                    {
+                        "description": "Page offset",
+                        "in": "query",
+                        "name": "offset",
+                        "schema": {
+                            "default": 0,
+                            "type": "integer"
+                        }
+                    },
+                    {
+                        "description": "Optional repository UUID filter",
+                        "in": "query",
+                        "name": "repository_id",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Viewport south latitude (-90 to 90)",
+                        "in": "query",
+                        "name": "south",
+                        "schema": {
+                            "type": "number"
+                        }
+                    },
+                    {
+                        "description": "Viewport north latitude (-90 to 90)",
+                        "in": "query",
+                        "name": "north",
+                        "schema": {
+                            "type": "number"
+                        }
+                    },
+                    {
+                        "description": "Viewport west longitude (-180 to 180)",
+                        "in": "query",
+                        "name": "west",
+                        "schema": {
+                            "type": "number"
+                        }
+                    },
+                    {
+                        "description": "Viewport east longitude (-180 to 180)",
+                        "in": "query",
+                        "name": "east",
+                        "schema": {
+                            "type": "number"
+                        }
+                    }
+                ],
+                "requestBody": {
+                    "content": {
+                        "application/json": {
+                            "schema": {
+                                "type": "object"
+                            }
+                        }
+                    }
+                },
+                "responses": {
+                    "200": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/dto.AssetMapPointListResponseDTO"
+                                }
+                            }
+                        },
+                        "description": "Map points retrieved successfully"
+                    },
+                    "400": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Invalid request parameters"
+                    },
+                    "500": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Internal server error"
+                    }
+                },
+                "summary": "Get photo map points",
+                "tags": [
+                    "assets"
+                ]
+            }
+        },
+        "/api/v1/assets/precheck": {
+            "post": {
+                "description": "Given client-computed BLAKE3 fingerprints, reports advisory candidates. Candidates must still be uploaded for server-side full-file verification.",
+                "requestBody": {
+                    "content": {
+                        "application/json": {
+                            "schema": {
+                                "oneOf": [
+                                    {
+                                        "type": "object"
+                                    },
+                                    {
+                                        "$ref": "#/components/schemas/dto.UploadPrecheckRequestDTO",
+                                        "summary": "request",
+                                        "description": "Candidate files"
+                                    }
+                                ]
+                            }
+                        }
+                    },
+                    "description": "Candidate files",
+                    "required": true
+                },
+                "responses": {
+                    "200": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/dto.UploadPrecheckResponseDTO"
+                                }
+                            }
+                        },
+                        "description": "OK"
+                    },
+                    "400": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Bad Request"
+                    },
+                    "404": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Internal Server Error"
+                    }
+                },
+                "summary": "Precheck uploads against existing content fingerprints",
+                "tags": [
+                    "assets"
+                ]
+            }
+        },
+        "/api/v1/assets/rating/{rating}": {
+            "get": {
+                "description": "Get assets with a specific rating (0-5)",
+                "parameters": [
+                    {
+                        "description": "Rating (0-5)",
+                        "in": "path",
+                        "name": "rating",
+                        "required": true,
+                        "schema": {
+                            "type": "integer"
+                        }
+                    },
+                    {
+                        "description": "Number of assets to return",
+                        "in": "query",
+                        "name": "limit",
+                        "schema": {
+                            "default": 20,
+                            "type": "integer"
+                        }
+                    },
+                    {
+                        "description": "Number of assets to skip",
+                        "in": "query",
+                        "name": "offset",
+                        "schema": {
+                            "default": 0,
+                            "type": "integer"
+                        }
+                    }
+                ],
+                "requestBody": {
+                    "content": {
+                        "application/json": {
+                            "schema": {
+                                "type": "object"
+                            }
+                        }
+                    }
+                },
+                "responses": {
+                    "200": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/dto.AssetListResponseDTO"
+                                }
+                            }
+                        },
+                        "description": "Assets retrieved successfully"
+                    },
+                    "400": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Bad request"
+                    },
+                    "500": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Internal server error"
+                    }
+                },
+                "summary": "Get assets by rating",
+                "tags": [
+                    "assets"
+                ]
+            }
+        },
+        "/api/v1/assets/search": {
+            "post": {
+                "description": "Search assets with optional top results enhancement and filename fallback. With search_type \"tag\", return the assets carrying all (tag_match \"all\") or any (tag_match \"any\") of filter.tag_names, or of the comma-separated tag names in query, newest capture first; filter.tag_source (\"ai\", \"manual\" or a raw source) and filter.tag_min_confidence narrow the matching tags.",
+                "requestBody": {
+                    "content": {
+                        "application/json": {
+                            "schema": {
+                                "$ref": "#/components/schemas/dto.SearchAssetsRequestDTO",
+                                "summary": "data",
+                                "description": "Search parameters"
+                            }
+                        }
+                    },
+                    "description": "Search parameters",
+                    "required": true
+                },
+                "responses": {
+                    "200": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/dto.SearchAssetsResponseDTO"
+                                }
+                            }
+                        },
+                        "description": "Assets searched successfully"
+                    },
+                    "400": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Invalid request parameters"
+                    },
+                    "500": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Internal server error"
+                    }
+                },
+                "summary": "Search assets",
+                "tags": [
+                    "assets"
+                ]
+            }
+        },
+        "/api/v1/assets/stacks": {
+            "post": {
+                "description": "Manually groups the specified assets into a new stack",
+                "requestBody": {
+                    "content": {
+                        "application/json": {
+                            "schema": {
+                                "$ref": "#/components/schemas/dto.CreateManualStackRequestDTO",
+                                "summary": "data",
+                                "description": "Asset IDs to stack"
+                            }
+                        }
+                    },
+                    "description": "Asset IDs to stack",
+                    "required": true
+                },
+                "responses": {
+                    "201": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/dto.StackDTO"
+                                }
+                            }
+                        },
+                        "description": "Created"
+                    },
+                    "400": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Bad Request"
+                    },
+                    "409": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Conflict"
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "summary": "Create manual stack",
+                "tags": [
+                    "assets"
+                ]
+            }
+        },
+        "/api/v1/assets/tag-summaries": {
+            "get": {
+                "description": "List manual and AI/system tags with usage counts, categories and covers, most used first, for the Tags collection view",
+                "parameters": [
+                    {
+                        "description": "Optional repository UUID filter",
+                        "in": "query",
+                        "name": "repository_id",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Optional tag source filter (e.g. manual, zeroshot)",
+                        "in": "query",
                         "name": "source",
                         "schema": {
                             "type": "string"
                         }
                     },
                     {
-                        "description": "Search query (substring match on tag name)",
+                        "description": "Optional tag category filter (e.g. scene)",
+                        "in": "query",
+                        "name": "category",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Search query (substring match on tag name)",
+                        "in": "query",
+                        "name": "q",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Max results",
+                        "in": "query",
+                        "name": "limit",
+                        "schema": {
+                            "default": 50,
+                            "type": "integer"
+                        }
+                    },
+                    {
+                        "description": "Result offset",
+                        "in": "query",
+                        "name": "offset",
+                        "schema": {
+                            "default": 0,
+                            "type": "integer"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/dto.TagSummaryListResponseDTO"
+                                }
+                            }
+                        },
+                        "description": "Tag summaries retrieved successfully"
+                    },
+                    "400": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Invalid request parameters"
+                    },
+                    "500": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Internal server error"
+                    }
+                },
+                "summary": "List tag summaries",
+                "tags": [
+                    "assets"
+                ]
+            }
+        },
+        "/api/v1/assets/tags": {
+            "get": {
+                "description": "List all tags or search by name for autocomplete suggestions",
+                "parameters": [
+                    {
+                        "description": "Search query (substring match)",
+                        "in": "query",
+                        "name": "q",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Max results",
+                        "in": "query",
+                        "name": "limit",
+                        "schema": {
+                            "default": 20,
+                            "type": "integer"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/dto.TagListResponseDTO"
+                                }
+                            }
+                        },
+                        "description": "Tags retrieved successfully"
+                    },
+                    "500": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Internal server error"
+                    }
+                },
+                "summary": "List/search tags",
+                "tags": [
+                    "assets"
+                ]
+            }
+        },
+        "/api/v1/assets/types": {
+            "get": {
+                "description": "Retrieve a list of all supported asset types in the system.",
+                "requestBody": {
+                    "content": {
+                        "application/json": {
+                            "schema": {
+                                "type": "object"
+                            }
+                        }
+                    }
+                },
+                "responses": {
+                    "200": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/dto.AssetTypesResponseDTO"
+                                }
+                            }
+                        },
+                        "description": "Asset types retrieved successfully"
+                    }
+                },
+                "summary": "Get supported asset types",
+                "tags": [
+                    "assets"
+                ]
+            }
+        },
+        "/api/v1/assets/unalbumed": {
+            "get": {
+                "description": "List assets that belong to no album, newest upload first, to help organize a library. Non-admins only see their own assets; admins may pass owner_id to look at one user.",
+                "parameters": [
+                    {
+                        "description": "Owner user ID (admins only; others may only pass their own ID)",
+                        "in": "query",
+                        "name": "owner_id",
+                        "schema": {
+                            "type": "integer"
+                        }
+                    },
+                    {
+                        "description": "Optional repository UUID filter",
+                        "in": "query",
+                        "name": "repository_id",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Number of assets to return",
+                        "in": "query",
+                        "name": "limit",
+                        "schema": {
+                            "default": 50,
+                            "type": "integer"
+                        }
+                    },
+                    {
+                        "description": "Number of assets to skip",
+                        "in": "query",
+                        "name": "offset",
+                        "schema": {
+                            "default": 0,
+                            "type": "integer"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/dto.AssetListResponseDTO"
+                                }
+                            }
+                        },
+                        "description": "Assets without an album"
+                    },
+                    "400": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Invalid request parameters"
+                    },
+                    "401": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Forbidden"
+                    },
+                    "500": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Internal server error"
+                    }
+                },
+                "summary": "List assets without an album",
+                "tags": [
+                    "assets"
+                ]
+            }
+        },
+        "/api/v1/assets/upload/session": {
+            "post": {
+                "description": "Create an empty staging file for a file of the given size. Send its bytes with PATCH /assets/upload/session/{id} in any number of chunks, then call /complete. The session survives interruptions and server restarts; query it to find where to resume.",
+                "requestBody": {
+                    "content": {
+                        "application/json": {
+                            "schema": {
+                                "oneOf": [
+                                    {
+                                        "type": "object"
+                                    },
+                                    {
+                                        "$ref": "#/components/schemas/dto.CreateResumableUploadRequestDTO",
+                                        "summary": "request",
+                                        "description": "File to upload"
+                                    }
+                                ]
+                            }
+                        }
+                    },
+                    "description": "File to upload",
+                    "required": true
+                },
+                "responses": {
+                    "200": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/dto.ResumableUploadDTO"
+                                }
+                            }
+                        },
+                        "description": "Upload session created"
+                    },
+                    "400": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Invalid request or unsupported file type"
+                    },
+                    "500": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Internal server error"
+                    }
+                },
+                "summary": "Start a resumable upload",
+                "tags": [
+                    "assets"
+                ]
+            }
+        },
+        "/api/v1/assets/upload/session/{id}": {
+            "get": {
+                "description": "Returns the number of bytes received so far; the next PATCH must start there. The offset is also sent in the Upload-Offset header.",
+                "parameters": [
+                    {
+                        "description": "Upload session ID",
+                        "in": "path",
+                        "name": "id",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/dto.ResumableUploadDTO"
+                                }
+                            }
+                        },
+                        "description": "Upload session state"
+                    },
+                    "404": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Upload session not found"
+                    }
+                },
+                "summary": "Get resumable upload offset",
+                "tags": [
+                    "assets"
+                ]
+            },
+            "patch": {
+                "description": "The request body is the raw chunk. Upload-Offset must equal the bytes received so far; a mismatch returns 409 and the current offset in the Upload-Offset header. If the connection drops mid-chunk, the bytes that arrived are kept.",
+                "parameters": [
+                    {
+                        "description": "Upload session ID",
+                        "in": "path",
+                        "name": "id",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Offset the chunk starts at",
+                        "in": "header",
+                        "name": "Upload-Offset",
+                        "required": true,
+                        "schema": {
+                            "type": "integer"
+                        }
+                    }
+                ],
+                "requestBody": {
+                    "content": {
+                        "application/offset+octet-stream": {
+                            "schema": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "responses": {
+                    "200": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/dto.ResumableUploadDTO"
+                                }
+                            }
+                        },
+                        "description": "Chunk stored; offset is the next expected offset"
+                    },
+                    "400": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Missing or invalid Upload-Offset"
+                    },
+                    "404": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Upload session not found"
+                    },
+                    "409": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Offset mismatch, upload completed, or another chunk in progress"
+                    },
+                    "413": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Chunk exceeds the declared size"
+                    },
+                    "429": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Too many concurrent uploads for this user; see Retry-After"
+                    },
+                    "500": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Internal server error"
+                    }
+                },
+                "summary": "Append a chunk to a resumable upload",
+                "tags": [
+                    "assets"
+                ]
+            }
+        },
+        "/api/v1/assets/upload/session/{id}/complete": {
+            "post": {
+                "description": "Hashes the received file and queues it for processing. Calling it again returns the same task; a call made while another is still completing the upload gets 409.",
+                "parameters": [
+                    {
+                        "description": "Upload session ID",
+                        "in": "path",
+                        "name": "id",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/dto.UploadResponseDTO"
+                                }
+                            }
+                        },
+                        "description": "Upload queued for processing, or skipped as a duplicate"
+                    },
+                    "404": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Upload session not found"
+                    },
+                    "409": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Upload has not received all bytes, or is already being completed"
+                    },
+                    "500": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Internal server error"
+                    }
+                },
+                "summary": "Complete a resumable upload",
+                "tags": [
+                    "assets"
+                ]
+            }
+        },
+        "/api/v1/assets/{id}": {
+            "delete": {
+                "description": "Soft delete an asset by marking it as deleted. The physical file is not removed. With hard=true the original, its thumbnails and web video/audio versions are moved into the repository trash (.lumilio/trash) and the asset's database rows are removed; this also works on an asset already in Trash, and files that are already gone are skipped. A hard delete needs a signed-in owner of the asset, or an admin for assets without an owner.",
+                "parameters": [
+                    {
+                        "description": "Asset ID (UUID format)",
+                        "example": "\"550e8400-e29b-41d4-a716-446655440000\"",
+                        "in": "path",
+                        "name": "id",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Remove files and database rows instead of soft deleting",
+                        "in": "query",
+                        "name": "hard",
+                        "schema": {
+                            "default": false,
+                            "type": "boolean"
+                        }
+                    }
+                ],
+                "requestBody": {
+                    "content": {
+                        "application/json": {
+                            "schema": {
+                                "type": "object"
+                            }
+                        }
+                    }
+                },
+                "responses": {
+                    "200": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/dto.MessageResponseDTO"
+                                }
+                            }
+                        },
+                        "description": "Asset deleted successfully"
+                    },
+                    "400": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Invalid asset ID format"
+                    },
+                    "401": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Authentication required"
+                    },
+                    "403": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Not allowed to delete this asset"
+                    },
+                    "409": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Repository is unavailable"
+                    },
+                    "500": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Internal server error"
+                    }
+                },
+                "summary": "Delete asset",
+                "tags": [
+                    "assets"
+                ]
+            },
+            "get": {
+                "description": "Retrieve detailed information about a specific asset. Optionally include thumbnails, tags, albums, species predictions, OCR results, face recognition, and captions.",
+                "parameters": [
+                    {
+                        "description": "Asset ID (UUID format)",
+                        "example": "\"550e8400-e29b-41d4-a716-446655440000\"",
+                        "in": "path",
+                        "name": "id",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Include thumbnails",
+                        "in": "query",
+                        "name": "include_thumbnails",
+                        "schema": {
+                            "default": true,
+                            "type": "boolean"
+                        }
+                    },
+                    {
+                        "description": "Include tags",
+                        "in": "query",
+                        "name": "include_tags",
+                        "schema": {
+                            "default": true,
+                            "type": "boolean"
+                        }
+                    },
+                    {
+                        "description": "Include albums",
+                        "in": "query",
+                        "name": "include_albums",
+                        "schema": {
+                            "default": true,
+                            "type": "boolean"
+                        }
+                    },
+                    {
+                        "description": "Include species predictions",
+                        "in": "query",
+                        "name": "include_species",
+                        "schema": {
+                            "default": true,
+                            "type": "boolean"
+                        }
+                    },
+                    {
+                        "description": "Include OCR results",
+                        "in": "query",
+                        "name": "include_ocr",
+                        "schema": {
+                            "default": false,
+                            "type": "boolean"
+                        }
+                    },
+                    {
+                        "description": "Include face recognition",
+                        "in": "query",
+                        "name": "include_faces",
+                        "schema": {
+                            "default": false,
+                            "type": "boolean"
+                        }
+                    },
+                    {
+                        "description": "Include short-lived signed media URLs",
+                        "in": "query",
+                        "name": "include_urls",
+                        "schema": {
+                            "default": false,
+                            "type": "boolean"
+                        }
+                    }
+                ],
+                "requestBody": {
+                    "content": {
+                        "application/json": {
+                            "schema": {
+                                "type": "object"
+                            }
+                        }
+                    }
+                },
+                "responses": {
+                    "200": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/dto.AssetDetailDTO"
+                                }
+                            }
+                        },
+                        "description": "Asset details with optional relationships"
+                    },
+                    "400": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Invalid asset ID"
+                    },
+                    "404": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Asset not found"
+                    }
+                },
+                "summary": "Get asset by ID",
+                "tags": [
+                    "assets"
+                ]
+            },
+            "put": {
+                "description": "Update the specific metadata of an asset (e.g., photo EXIF data, video metadata). The metadata must be a JSON object whose known fields match the asset type's schema, e.g. taken_time as an RFC 3339 timestamp or f_number as a number; otherwise the request is rejected with every offending field listed. Fields the schema does not define are stored as sent.",
+                "parameters": [
+                    {
+                        "description": "Asset ID (UUID format)",
+                        "example": "\"550e8400-e29b-41d4-a716-446655440000\"",
+                        "in": "path",
+                        "name": "id",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "requestBody": {
+                    "content": {
+                        "application/json": {
+                            "schema": {
+                                "$ref": "#/components/schemas/dto.UpdateAssetRequestDTO",
+                                "summary": "data",
+                                "description": "Asset metadata"
+                            }
+                        }
+                    },
+                    "description": "Asset metadata",
+                    "required": true
+                },
+                "responses": {
+                    "200": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/dto.MessageResponseDTO"
+                                }
+                            }
+                        },
+                        "description": "Asset updated successfully"
+                    },
+                    "400": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Invalid asset ID, request body or metadata"
+                    },
+                    "500": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Internal server error"
+                    }
+                },
+                "summary": "Update asset metadata",
+                "tags": [
+                    "assets"
+                ]
+            }
+        },
+        "/api/v1/assets/{id}/albums": {
+            "get": {
+                "description": "Retrieve all albums that contain a specific asset",
+                "parameters": [
+                    {
+                        "description": "Asset ID (UUID format)",
+                        "in": "path",
+                        "name": "id",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "requestBody": {
+                    "content": {
+                        "application/json": {
+                            "schema": {
+                                "type": "object"
+                            }
+                        }
+                    }
+                },
+                "responses": {
+                    "200": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/dto.AssetAlbumsResponseDTO"
+                                }
+                            }
+                        },
+                        "description": "Albums retrieved successfully"
+                    },
+                    "400": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Invalid asset ID"
+                    },
+                    "500": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Failed to retrieve asset albums"
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "summary": "Get albums containing asset",
+                "tags": [
+                    "albums"
+                ]
+            }
+        },
+        "/api/v1/assets/{id}/albums/{albumId}": {
+            "post": {
+                "description": "Associate an asset with a specific album by asset ID and album ID.",
+                "parameters": [
+                    {
+                        "description": "Asset ID (UUID format)",
+                        "example": "\"550e8400-e29b-41d4-a716-446655440000\"",
+                        "in": "path",
+                        "name": "id",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Album ID",
+                        "example": 123,
+                        "in": "path",
+                        "name": "albumId",
+                        "required": true,
+                        "schema": {
+                            "type": "integer"
+                        }
+                    }
+                ],
+                "requestBody": {
+                    "content": {
+                        "application/json": {
+                            "schema": {
+                                "type": "object"
+                            }
+                        }
+                    }
+                },
+                "responses": {
+                    "200": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/dto.MessageResponseDTO"
+                                }
+                            }
+                        },
+                        "description": "Asset added to album successfully"
+                    },
+                    "400": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Invalid asset ID or album ID, or smart album"
+                    },
+                    "500": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Internal server error"
+                    }
+                },
+                "summary": "Add asset to album",
+                "tags": [
+                    "assets"
+                ]
+            }
+        },
+        "/api/v1/assets/{id}/audio/web": {
+            "get": {
+                "description": "Serve the web-optimized MP3 audio version for an asset by asset ID.",
+                "parameters": [
+                    {
+                        "description": "Asset ID (UUID format)",
+                        "example": "\"550e8400-e29b-41d4-a716-446655440000\"",
+                        "in": "path",
+                        "name": "id",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "content": {
+                            "audio/mpeg": {
+                                "schema": {
+                                    "type": "file"
+                                }
+                            }
+                        },
+                        "description": "Web-optimized audio file"
+                    },
+                    "400": {
+                        "content": {
+                            "audio/mpeg": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Invalid asset ID"
+                    },
+                    "404": {
+                        "content": {
+                            "audio/mpeg": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Asset not found or not audio"
+                    },
+                    "410": {
+                        "content": {
+                            "audio/mpeg": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Asset's repository was removed"
+                    },
+                    "500": {
+                        "content": {
+                            "audio/mpeg": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Internal server error"
+                    }
+                },
+                "summary": "Get web-optimized audio",
+                "tags": [
+                    "assets"
+                ]
+            }
+        },
+        "/api/v1/assets/{id}/custom-fields": {
+            "get": {
+                "description": "Get the user-defined key/value fields of an asset",
+                "parameters": [
+                    {
+                        "description": "Asset ID",
+                        "in": "path",
+                        "name": "id",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/dto.AssetCustomFieldsDTO"
+                                }
+                            }
+                        },
+                        "description": "Custom fields retrieved successfully"
+                    },
+                    "400": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Bad request"
+                    },
+                    "404": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Asset not found"
+                    },
+                    "500": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Internal server error"
+                    }
+                },
+                "summary": "Get asset custom fields",
+                "tags": [
+                    "assets"
+                ]
+            },
+            "put": {
+                "description": "Replace the user-defined key/value fields of an asset. Keys must be lower snake case; values must be strings, numbers or booleans. Custom fields are stored apart from extracted metadata and survive a metadata refresh.",
+                "parameters": [
+                    {
+                        "description": "Asset ID",
+                        "in": "path",
+                        "name": "id",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "requestBody": {
+                    "content": {
+                        "application/json": {
+                            "schema": {
+                                "oneOf": [
+                                    {
+                                        "type": "object"
+                                    },
+                                    {
+                                        "$ref": "#/components/schemas/dto.UpdateCustomFieldsRequestDTO",
+                                        "summary": "fields",
+                                        "description": "Custom fields"
+                                    }
+                                ]
+                            }
+                        }
+                    },
+                    "description": "Custom fields",
+                    "required": true
+                },
+                "responses": {
+                    "200": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/dto.AssetCustomFieldsDTO"
+                                }
+                            }
+                        },
+                        "description": "Custom fields updated successfully"
+                    },
+                    "400": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Bad request"
+                    },
+                    "404": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Asset not found"
+                    },
+                    "500": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Internal server error"
+                    }
+                },
+                "summary": "Update asset custom fields",
+                "tags": [
+                    "assets"
+                ]
+            }
+        },
+        "/api/v1/assets/{id}/description": {
+            "put": {
+                "description": "Update the description metadata of an asset",
+                "parameters": [
+                    {
+                        "description": "Asset ID",
+                        "in": "path",
+                        "name": "id",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "requestBody": {
+                    "content": {
+                        "application/json": {
+                            "schema": {
+                                "$ref": "#/components/schemas/dto.UpdateDescriptionRequestDTO",
+                                "summary": "description",
+                                "description": "Description data"
+                            }
+                        }
+                    },
+                    "description": "Description data",
+                    "required": true
+                },
+                "responses": {
+                    "200": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/dto.MessageResponseDTO"
+                                }
+                            }
+                        },
+                        "description": "Description updated successfully"
+                    },
+                    "400": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Bad request"
+                    },
+                    "404": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Asset not found"
+                    },
+                    "500": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Internal server error"
+                    }
+                },
+                "summary": "Update asset description",
+                "tags": [
+                    "assets"
+                ]
+            }
+        },
+        "/api/v1/assets/{id}/download-url": {
+            "get": {
+                "description": "Sign a short-lived URL that serves the asset's original without authentication, for embedding or handing to a CDN. expires is in seconds (default 3600) and is clamped to between 60 seconds and 24 hours.",
+                "parameters": [
+                    {
+                        "description": "Asset ID (UUID format)",
+                        "example": "\"550e8400-e29b-41d4-a716-446655440000\"",
+                        "in": "path",
+                        "name": "id",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "URL lifetime in seconds",
+                        "in": "query",
+                        "name": "expires",
+                        "schema": {
+                            "default": 3600,
+                            "maximum": 86400,
+                            "minimum": 60,
+                            "type": "integer"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/dto.AssetDownloadURLResponseDTO"
+                                }
+                            }
+                        },
+                        "description": "Signed URL"
+                    },
+                    "400": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Invalid asset ID"
+                    },
+                    "401": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Authentication required"
+                    },
+                    "403": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Access denied"
+                    },
+                    "404": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Asset not found"
+                    },
+                    "500": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Internal server error"
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "summary": "Get signed download URL",
+                "tags": [
+                    "assets"
+                ]
+            }
+        },
+        "/api/v1/assets/{id}/exif": {
+            "get": {
+                "description": "Retrieve the full exiftool JSON object stored for an asset during metadata processing.",
+                "parameters": [
+                    {
+                        "description": "Asset ID (UUID format)",
+                        "example": "\"550e8400-e29b-41d4-a716-446655440000\"",
+                        "in": "path",
+                        "name": "id",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "requestBody": {
+                    "content": {
+                        "application/json": {
+                            "schema": {
+                                "type": "object"
+                            }
+                        }
+                    }
+                },
+                "responses": {
+                    "200": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/dto.AssetExifResponseDTO"
+                                }
+                            }
+                        },
+                        "description": "Raw EXIF JSON"
+                    },
+                    "400": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Invalid asset ID"
+                    },
+                    "404": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Asset or EXIF not found"
+                    }
+                },
+                "summary": "Get raw asset EXIF",
+                "tags": [
+                    "assets"
+                ]
+            }
+        },
+        "/api/v1/assets/{id}/exif-raw": {
+            "get": {
+                "description": "Read the stored original with exiftool and return every EXIF, MakerNotes, XMP and IPTC tag it carries, keyed by \"Group:Tag\". Unlike /exif this does not use what was extracted into the database, so it reflects the file as it is now. Intended for debugging metadata extraction; only photos are supported.",
+                "parameters": [
+                    {
+                        "description": "Asset ID (UUID format)",
+                        "example": "\"550e8400-e29b-41d4-a716-446655440000\"",
+                        "in": "path",
+                        "name": "id",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/dto.AssetRawTagsResponseDTO"
+                                }
+                            }
+                        },
+                        "description": "All tags in the original"
+                    },
+                    "400": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Invalid asset ID"
+                    },
+                    "404": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Asset or its original file not found"
+                    },
+                    "415": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Asset is not a photo"
+                    },
+                    "500": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Internal server error"
+                    }
+                },
+                "summary": "Get raw metadata tags from the original",
+                "tags": [
+                    "assets"
+                ]
+            }
+        },
+        "/api/v1/assets/{id}/export": {
+            "get": {
+                "description": "Re-encode an asset's original file to JPEG, PNG, WebP, or AVIF with optional max dimensions and quality, and stream it back as a download.",
+                "parameters": [
+                    {
+                        "description": "Asset ID",
+                        "in": "path",
+                        "name": "id",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Output format (jpeg, png, webp, avif)",
+                        "in": "query",
+                        "name": "format",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Quality 1-100 for lossy formats",
+                        "in": "query",
+                        "name": "quality",
+                        "schema": {
+                            "type": "integer"
+                        }
+                    },
+                    {
+                        "description": "Maximum output width in pixels",
+                        "in": "query",
+                        "name": "max_width",
+                        "schema": {
+                            "type": "integer"
+                        }
+                    },
+                    {
+                        "description": "Maximum output height in pixels",
+                        "in": "query",
+                        "name": "max_height",
+                        "schema": {
+                            "type": "integer"
+                        }
+                    },
+                    {
+                        "description": "Base download filename (without extension)",
+                        "in": "query",
+                        "name": "filename",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "content": {
+                            "image/avif": {
+                                "schema": {
+                                    "type": "file"
+                                }
+                            },
+                            "image/jpeg": {
+                                "schema": {
+                                    "type": "file"
+                                }
+                            },
+                            "image/png": {
+                                "schema": {
+                                    "type": "file"
+                                }
+                            },
+                            "image/webp": {
+                                "schema": {
+                                    "type": "file"
+                                }
+                            }
+                        },
+                        "description": "Encoded image"
+                    },
+                    "400": {
+                        "content": {
+                            "image/avif": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            },
+                            "image/jpeg": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            },
+                            "image/png": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            },
+                            "image/webp": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Invalid request"
+                    },
+                    "401": {
+                        "content": {
+                            "image/avif": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            },
+                            "image/jpeg": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            },
+                            "image/png": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            },
+                            "image/webp": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Authentication required"
+                    },
+                    "403": {
+                        "content": {
+                            "image/avif": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            },
+                            "image/jpeg": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            },
+                            "image/png": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            },
+                            "image/webp": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "content": {
+                            "image/avif": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            },
+                            "image/jpeg": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            },
+                            "image/png": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            },
+                            "image/webp": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Asset or original file not found"
+                    },
+                    "422": {
+                        "content": {
+                            "image/avif": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            },
+                            "image/jpeg": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            },
+                            "image/png": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            },
+                            "image/webp": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Source image could not be encoded or is above the size cap"
+                    },
+                    "500": {
+                        "content": {
+                            "image/avif": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            },
+                            "image/jpeg": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            },
+                            "image/png": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            },
+                            "image/webp": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Internal server error"
+                    },
+                    "504": {
+                        "content": {
+                            "image/avif": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            },
+                            "image/jpeg": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            },
+                            "image/png": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            },
+                            "image/webp": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Export ran past the image operation timeout"
+                    }
+                },
+                "summary": "Export asset",
+                "tags": [
+                    "assets"
+                ]
+            }
+        },
+        "/api/v1/assets/{id}/flip": {
+            "post": {
+                "description": "Mirror a photo's original horizontally or vertically. JPEG and TIFF originals are flipped losslessly through their EXIF orientation; PNG, WebP and AVIF are re-encoded. The previous original is kept in the repository trash and thumbnails are regenerated in the background.",
+                "parameters": [
+                    {
+                        "description": "Asset ID",
+                        "in": "path",
+                        "name": "id",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Flip direction",
+                        "in": "query",
+                        "name": "direction",
+                        "required": true,
+                        "schema": {
+                            "enum": [
+                                "horizontal",
+                                "vertical"
+                            ],
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/dto.AssetDTO"
+                                }
+                            }
+                        },
+                        "description": "Flipped asset"
+                    },
+                    "400": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Invalid asset ID, direction, or unsupported format"
+                    },
+                    "404": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Asset or its original file not found"
+                    },
+                    "500": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Internal server error"
+                    }
+                },
+                "summary": "Flip asset",
+                "tags": [
+                    "assets"
+                ]
+            }
+        },
+        "/api/v1/assets/{id}/like": {
+            "put": {
+                "description": "Update the like/favorite status of a specific asset",
+                "parameters": [
+                    {
+                        "description": "Asset ID",
+                        "in": "path",
+                        "name": "id",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "requestBody": {
+                    "content": {
+                        "application/json": {
+                            "schema": {
+                                "$ref": "#/components/schemas/dto.UpdateLikeRequestDTO",
+                                "summary": "like",
+                                "description": "Like data"
+                            }
+                        }
+                    },
+                    "description": "Like data",
+                    "required": true
+                },
+                "responses": {
+                    "200": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/dto.MessageResponseDTO"
+                                }
+                            }
+                        },
+                        "description": "Like status updated successfully"
+                    },
+                    "400": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Bad request"
+                    },
+                    "404": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Asset not found"
+                    },
+                    "500": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Internal server error"
+                    }
+                },
+                "summary": "Update asset like status",
+                "tags": [
+                    "assets"
+                ]
+            }
+        },
+        "/api/v1/assets/{id}/media-item": {
+            "get": {
+                "description": "Returns the logical media item and its RAW/JPEG, Live Photo, or edited components",
+                "parameters": [
+                    {
+                        "description": "Asset ID",
+                        "in": "path",
+                        "name": "id",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/dto.MediaItemByAssetResponseDTO"
+                                }
+                            }
+                        },
+                        "description": "OK"
+                    },
+                    "404": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Not Found"
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "summary": "Get logical media item",
+                "tags": [
+                    "assets"
+                ]
+            }
+        },
+        "/api/v1/assets/{id}/neighbors": {
+            "get": {
+                "description": "Resolve the previous and next asset around an asset under the same filter and sort as POST /assets/list, so a fullscreen viewer can preload and swipe without refetching the page. Stacks are expanded; semantic search is not supported.",
+                "parameters": [
+                    {
+                        "description": "Asset ID (UUID format)",
+                        "example": "\"550e8400-e29b-41d4-a716-446655440000\"",
+                        "in": "path",
+                        "name": "id",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Sort order",
                         "in": "query",
-                        "name": "q",
+                        "name": "sort_by",
                         "schema": {
+                            "default": "date_captured",
+                            "enum": [
+                                "recently_added",
+                                "date_captured"
+                            ],
                             "type": "string"
                         }
                     },
                     {
-                        "description": "Max results",
+                        "description": "Filename search keyword",
                         "in": "query",
-                        "name": "limit",
+                        "name": "query",
                         "schema": {
-                            "default": 50,
-                            "type": "integer"
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "IANA time zone for date-only filter bounds",
+                        "example": "America/New_York",
+                        "in": "query",
+                        "name": "viewer_timezone",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "JSON-encoded dto.AssetFilterDTO, as sent to POST /assets/list",
+                        "in": "query",
+                        "name": "filter",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/dto.AssetNeighborsResponseDTO"
+                                }
+                            }
+                        },
+                        "description": "Adjacent assets resolved"
+                    },
+                    "400": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Invalid request parameters"
+                    },
+                    "403": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Asset not found"
+                    },
+                    "500": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Internal server error"
+                    }
+                },
+                "summary": "Get adjacent assets",
+                "tags": [
+                    "assets"
+                ]
+            }
+        },
+        "/api/v1/assets/{id}/original": {
+            "get": {
+                "description": "Serve the original file content for an asset by asset ID. Returns the file as an octet-stream. HEIC/HEIF originals are converted to JPEG unless the Accept header names image/heic or image/heif.",
+                "parameters": [
+                    {
+                        "description": "Asset ID (UUID format)",
+                        "example": "\"550e8400-e29b-41d4-a716-446655440000\"",
+                        "in": "path",
+                        "name": "id",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "content": {
+                            "application/octet-stream": {
+                                "schema": {
+                                    "type": "file"
+                                }
+                            }
+                        },
+                        "description": "Original file content"
+                    },
+                    "400": {
+                        "content": {
+                            "application/octet-stream": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Invalid asset ID"
+                    },
+                    "404": {
+                        "content": {
+                            "application/octet-stream": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Asset not found"
+                    },
+                    "410": {
+                        "content": {
+                            "application/octet-stream": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Asset's repository was removed"
+                    },
+                    "500": {
+                        "content": {
+                            "application/octet-stream": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Internal server error"
+                    }
+                },
+                "summary": "Get original file",
+                "tags": [
+                    "assets"
+                ]
+            }
+        },
+        "/api/v1/assets/{id}/rating": {
+            "put": {
+                "description": "Update the rating (0-5) of a specific asset. With storage.write_rating_to_exif enabled, a background job also writes the rating into the XMP Rating tag of JPEG and TIFF originals; other formats are skipped and a failed write does not affect the stored rating.",
+                "parameters": [
+                    {
+                        "description": "Asset ID",
+                        "in": "path",
+                        "name": "id",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "requestBody": {
+                    "content": {
+                        "application/json": {
+                            "schema": {
+                                "$ref": "#/components/schemas/dto.UpdateRatingRequestDTO",
+                                "summary": "rating",
+                                "description": "Rating data"
+                            }
                         }
                     },
+                    "description": "Rating data",
+                    "required": true
+                },
+                "responses": {
+                    "200": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/dto.MessageResponseDTO"
+                                }
+                            }
+                        },
+                        "description": "Rating updated successfully"
+                    },
+                    "400": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Bad request"
+                    },
+                    "404": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Asset not found"
+                    },
+                    "500": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Internal server error"
+                    }
+                },
+                "summary": "Update asset rating",
+                "tags": [
+                    "assets"
+                ]
+            }
+        },
+        "/api/v1/assets/{id}/rating-and-like": {
+            "put": {
+                "description": "Update both the rating (0-5) and like/favorite status of a specific asset. The rating is written back to the original in the background like PUT /assets/{id}/rating does.",
+                "parameters": [
                     {
-                        "description": "Result offset",
-                        "in": "query",
-                        "name": "offset",
+                        "description": "Asset ID",
+                        "in": "path",
+                        "name": "id",
+                        "required": true,
                         "schema": {
-                            "default": 0,
-                            "type": "integer"
+                            "type": "string"
                         }
                     }
                 ],
+                "requestBody": {
+                    "content": {
+                        "application/json": {
+                            "schema": {
+                                "$ref": "#/components/schemas/dto.UpdateRatingAndLikeRequestDTO",
+                                "summary": "data",
+                                "description": "Rating and like data"
+                            }
+                        }
+                    },
+                    "description": "Rating and like data",
+                    "required": true
+                },
                 "responses": {
                     "200": {
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/dto.TagSummaryListResponseDTO"
+                                    "$ref": "#/components/schemas/dto.MessageResponseDTO"
                                 }
                             }
                         },
-                        "description": "Tag summaries retrieved successfully"
+                        "description": "Rating and like status updated successfully"
                     },
                     "400": {
                         "content": {
@@ -9666,7 +15655,17 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Invalid request parameters"
+                        "description": "Bad request"
+                    },
+                    "404": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Asset not found"
                     },
                     "500": {
                         "content": {
@@ -9679,32 +15678,24 @@ const docTemplate = `{
                         "description": "Internal server error"
                     }
                 },
-                "summary": "List tag summaries",
+                "summary": "Update asset rating and like status",
                 "tags": [
                     "assets"
                 ]
             }
         },
-        "/api/v1/assets/tags": {
-            "get": {
-                "description": "List all tags or search by name for autocomplete suggestions",
+        "/api/v1/assets/{id}/refresh-metadata": {
+            "post": {
+                "description": "Re-run EXIF/ffprobe extraction against the stored original and update specific_metadata and taken_time in place. Rating, like state and description are preserved.",
                 "parameters": [
                     {
-                        "description": "Search query (substring match)",
-                        "in": "query",
-                        "name": "q",
+                        "description": "Asset ID",
+                        "in": "path",
+                        "name": "id",
+                        "required": true,
                         "schema": {
                             "type": "string"
                         }
-                    },
-                    {
-                        "description": "Max results",
-                        "in": "query",
-                        "name": "limit",
-                        "schema": {
-                            "default": 20,
-                            "type": "integer"
-                        }
                     }
                 ],
                 "responses": {
@@ -9712,11 +15703,31 @@ const docTemplate = `{
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/dto.TagListResponseDTO"
+                                    "$ref": "#/components/schemas/dto.AssetDTO"
                                 }
                             }
                         },
-                        "description": "Tags retrieved successfully"
+                        "description": "Asset with refreshed metadata"
+                    },
+                    "400": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Invalid asset ID"
+                    },
+                    "404": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Asset or its original file not found"
                     },
                     "500": {
                         "content": {
@@ -9729,45 +15740,89 @@ const docTemplate = `{
                         "description": "Internal server error"
                     }
                 },
-                "summary": "List/search tags",
+                "summary": "Refresh asset metadata",
                 "tags": [
                     "assets"
                 ]
             }
         },
-        "/api/v1/assets/types": {
-            "get": {
-                "description": "Retrieve a list of all supported asset types in the system.",
+        "/api/v1/assets/{id}/reprocess": {
+            "post": {
+                "description": "Reprocess a failed or warning asset by resetting its status and re-enqueuing for processing",
+                "parameters": [
+                    {
+                        "description": "Asset ID",
+                        "in": "path",
+                        "name": "id",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
                 "requestBody": {
                     "content": {
                         "application/json": {
                             "schema": {
-                                "type": "object"
+                                "$ref": "#/components/schemas/dto.ReprocessAssetRequestDTO",
+                                "summary": "data",
+                                "description": "Reprocessing tasks (optional)"
                             }
                         }
-                    }
+                    },
+                    "description": "Reprocessing tasks (optional)"
                 },
                 "responses": {
                     "200": {
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/dto.AssetTypesResponseDTO"
+                                    "$ref": "#/components/schemas/dto.ReprocessAssetResponseDTO"
                                 }
                             }
                         },
-                        "description": "Asset types retrieved successfully"
+                        "description": "OK"
+                    },
+                    "400": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Bad Request"
+                    },
+                    "404": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Internal Server Error"
                     }
                 },
-                "summary": "Get supported asset types",
+                "summary": "Reprocess asset",
                 "tags": [
                     "assets"
                 ]
             }
         },
-        "/api/v1/assets/{id}": {
-            "delete": {
-                "description": "Soft delete an asset by marking it as deleted. The physical file is not removed.",
+        "/api/v1/assets/{id}/restore": {
+            "post": {
+                "description": "Restore a soft-deleted asset from Trash. If its original is no longer on disk because a hard delete moved it into the repository trash and then failed, the files are recovered from there first.",
                 "parameters": [
                     {
                         "description": "Asset ID (UUID format)",
@@ -9798,7 +15853,7 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Asset deleted successfully"
+                        "description": "Asset restored successfully"
                     },
                     "400": {
                         "content": {
@@ -9810,6 +15865,102 @@ const docTemplate = `{
                         },
                         "description": "Invalid asset ID format"
                     },
+                    "404": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Asset not found or not in Trash"
+                    },
+                    "409": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Original file can no longer be recovered, or repository is unavailable"
+                    },
+                    "500": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Internal server error"
+                    }
+                },
+                "summary": "Restore asset",
+                "tags": [
+                    "assets"
+                ]
+            }
+        },
+        "/api/v1/assets/{id}/rotate": {
+            "post": {
+                "description": "Rotate a photo's original clockwise by 90, 180 or 270 degrees. JPEG and TIFF originals are rotated losslessly through their EXIF orientation; PNG, WebP and AVIF are re-encoded. The previous original is kept in the repository trash and thumbnails are regenerated in the background.",
+                "parameters": [
+                    {
+                        "description": "Asset ID",
+                        "in": "path",
+                        "name": "id",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Clockwise rotation",
+                        "in": "query",
+                        "name": "degrees",
+                        "required": true,
+                        "schema": {
+                            "enum": [
+                                90,
+                                180,
+                                270
+                            ],
+                            "type": "integer"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/dto.AssetDTO"
+                                }
+                            }
+                        },
+                        "description": "Asset with updated dimensions"
+                    },
+                    "400": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Invalid asset ID, rotation, or unsupported format"
+                    },
+                    "404": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Asset or its original file not found"
+                    },
                     "500": {
                         "content": {
                             "application/json": {
@@ -9821,13 +15972,15 @@ const docTemplate = `{
                         "description": "Internal server error"
                     }
                 },
-                "summary": "Delete asset",
+                "summary": "Rotate asset",
                 "tags": [
                     "assets"
                 ]
-            },
+            }
+        },
+        "/api/v1/assets/{id}/sidecar": {
             "get": {
-                "description": "Retrieve detailed information about a specific asset. Optionally include thumbnails, tags, albums, species predictions, OCR results, face recognition, and captions.",
+                "description": "Retrieve the non-destructive Studio edit sidecar stored under the asset repository .lumilio directory.",
                 "parameters": [
                     {
                         "description": "Asset ID (UUID format)",
@@ -9838,60 +15991,6 @@ const docTemplate = `{
                         "schema": {
                             "type": "string"
                         }
-                    },
-                    {
-                        "description": "Include thumbnails",
-                        "in": "query",
-                        "name": "include_thumbnails",
-                        "schema": {
-                            "default": true,
-                            "type": "boolean"
-                        }
-                    },
-                    {
-                        "description": "Include tags",
-                        "in": "query",
-                        "name": "include_tags",
-                        "schema": {
-                            "default": true,
-                            "type": "boolean"
-                        }
-                    },
-                    {
-                        "description": "Include albums",
-                        "in": "query",
-                        "name": "include_albums",
-                        "schema": {
-                            "default": true,
-                            "type": "boolean"
-                        }
-                    },
-                    {
-                        "description": "Include species predictions",
-                        "in": "query",
-                        "name": "include_species",
-                        "schema": {
-                            "default": true,
-                            "type": "boolean"
-                        }
-                    },
-                    {
-                        "description": "Include OCR results",
-                        "in": "query",
-                        "name": "include_ocr",
-                        "schema": {
-                            "default": false,
-                            "type": "boolean"
-                        }
-                    },
-                    {
-                        "description": "Include face recognition",
-                        "in": "query",
-                        "name": "include_faces",
-                        "schema": {
-                            "default": false,
-                            "type": "boolean"
-                        }
                     }
                 ],
                 "requestBody": {
@@ -9908,11 +16007,11 @@ const docTemplate = `{
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/dto.AssetDetailDTO"
+                                    "$ref": "#/components/schemas/dto.AssetSidecarResponseDTO"
                                 }
                             }
                         },
-                        "description": "Asset details with optional relationships"
+                        "description": "Asset sidecar"
                     },
                     "400": {
                         "content": {
@@ -9933,15 +16032,25 @@ const docTemplate = `{
                             }
                         },
                         "description": "Asset not found"
+                    },
+                    "500": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Internal server error"
                     }
                 },
-                "summary": "Get asset by ID",
+                "summary": "Get asset edit sidecar",
                 "tags": [
                     "assets"
                 ]
             },
             "put": {
-                "description": "Update the specific metadata of an asset (e.g., photo EXIF data, video metadata).",
+                "description": "Store non-destructive Studio edit data under the asset repository .lumilio directory.",
                 "parameters": [
                     {
                         "description": "Asset ID (UUID format)",
@@ -9958,13 +16067,13 @@ const docTemplate = `{
                     "content": {
                         "application/json": {
                             "schema": {
-                                "$ref": "#/components/schemas/dto.UpdateAssetRequestDTO",
+                                "$ref": "#/components/schemas/dto.LumilioSidecarV1DTO",
                                 "summary": "data",
-                                "description": "Asset metadata"
+                                "description": "Sidecar payload"
                             }
                         }
                     },
-                    "description": "Asset metadata",
+                    "description": "Sidecar payload",
                     "required": true
                 },
                 "responses": {
@@ -9972,11 +16081,11 @@ const docTemplate = `{
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/dto.MessageResponseDTO"
+                                    "$ref": "#/components/schemas/dto.AssetSidecarResponseDTO"
                                 }
                             }
                         },
-                        "description": "Asset updated successfully"
+                        "description": "Asset sidecar saved"
                     },
                     "400": {
                         "content": {
@@ -9988,6 +16097,16 @@ const docTemplate = `{
                         },
                         "description": "Invalid asset ID or request body"
                     },
+                    "404": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Asset not found"
+                    },
                     "500": {
                         "content": {
                             "application/json": {
@@ -9999,18 +16118,19 @@ const docTemplate = `{
                         "description": "Internal server error"
                     }
                 },
-                "summary": "Update asset metadata",
+                "summary": "Update asset edit sidecar",
                 "tags": [
                     "assets"
                 ]
             }
         },
-        "/api/v1/assets/{id}/albums": {
+        "/api/v1/assets/{id}/srcset": {
             "get": {
-                "description": "Retrieve all albums that contain a specific asset",
+                "description": "List the thumbnails generated for an asset with their actual width and height and signed URLs, narrowest first, plus a ready-made value for an \u003cimg srcset\u003e attribute. Only sizes that were generated are listed, so each URL serves exactly the stated dimensions. Thumbnails generated before dimensions were recorded are omitted until they are regenerated.",
                 "parameters": [
                     {
                         "description": "Asset ID (UUID format)",
+                        "example": "\"550e8400-e29b-41d4-a716-446655440000\"",
                         "in": "path",
                         "name": "id",
                         "required": true,
@@ -10019,25 +16139,16 @@ const docTemplate = `{
                         }
                     }
                 ],
-                "requestBody": {
-                    "content": {
-                        "application/json": {
-                            "schema": {
-                                "type": "object"
-                            }
-                        }
-                    }
-                },
                 "responses": {
                     "200": {
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/dto.AssetAlbumsResponseDTO"
+                                    "$ref": "#/components/schemas/dto.AssetSrcsetResponseDTO"
                                 }
                             }
                         },
-                        "description": "Albums retrieved successfully"
+                        "description": "Thumbnail sources"
                     },
                     "400": {
                         "content": {
@@ -10049,6 +16160,16 @@ const docTemplate = `{
                         },
                         "description": "Invalid asset ID"
                     },
+                    "404": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Asset not found"
+                    },
                     "500": {
                         "content": {
                             "application/json": {
@@ -10057,76 +16178,76 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Failed to retrieve asset albums"
+                        "description": "Internal server error"
                     }
                 },
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "summary": "Get albums containing asset",
+                "summary": "Get responsive thumbnail srcset",
                 "tags": [
-                    "albums"
+                    "assets"
                 ]
             }
         },
-        "/api/v1/assets/{id}/albums/{albumId}": {
-            "post": {
-                "description": "Associate an asset with a specific album by asset ID and album ID.",
+        "/api/v1/assets/{id}/stack": {
+            "delete": {
+                "description": "Removes an asset from its stack, making it standalone",
                 "parameters": [
                     {
-                        "description": "Asset ID (UUID format)",
-                        "example": "\"550e8400-e29b-41d4-a716-446655440000\"",
+                        "description": "Asset ID",
                         "in": "path",
                         "name": "id",
                         "required": true,
                         "schema": {
                             "type": "string"
                         }
-                    },
-                    {
-                        "description": "Album ID",
-                        "example": 123,
-                        "in": "path",
-                        "name": "albumId",
-                        "required": true,
-                        "schema": {
-                            "type": "integer"
-                        }
                     }
                 ],
-                "requestBody": {
-                    "content": {
-                        "application/json": {
-                            "schema": {
-                                "type": "object"
-                            }
-                        }
-                    }
-                },
                 "responses": {
                     "200": {
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/dto.MessageResponseDTO"
+                                    "$ref": "#/components/schemas/api.SuccessResponse"
                                 }
                             }
                         },
-                        "description": "Asset added to album successfully"
-                    },
-                    "400": {
+                        "description": "OK"
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "summary": "Remove asset from stack",
+                "tags": [
+                    "assets"
+                ]
+            },
+            "get": {
+                "description": "Returns the stack (group) that contains the specified asset",
+                "parameters": [
+                    {
+                        "description": "Asset ID",
+                        "in": "path",
+                        "name": "id",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                    "$ref": "#/components/schemas/dto.StackByAssetResponseDTO"
                                 }
                             }
                         },
-                        "description": "Invalid asset ID or album ID"
+                        "description": "OK"
                     },
-                    "500": {
+                    "404": {
                         "content": {
                             "application/json": {
                                 "schema": {
@@ -10134,22 +16255,26 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Internal server error"
+                        "description": "Not Found"
                     }
                 },
-                "summary": "Add asset to album",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "summary": "Get asset stack",
                 "tags": [
                     "assets"
                 ]
             }
         },
-        "/api/v1/assets/{id}/audio/web": {
+        "/api/v1/assets/{id}/tags": {
             "get": {
-                "description": "Serve the web-optimized MP3 audio version for an asset by asset ID.",
+                "description": "Get all tags (manual and AI-generated) attached to an asset",
                 "parameters": [
                     {
-                        "description": "Asset ID (UUID format)",
-                        "example": "\"550e8400-e29b-41d4-a716-446655440000\"",
+                        "description": "Asset ID",
                         "in": "path",
                         "name": "id",
                         "required": true,
@@ -10161,37 +16286,27 @@ const docTemplate = `{
                 "responses": {
                     "200": {
                         "content": {
-                            "audio/mpeg": {
+                            "application/json": {
                                 "schema": {
-                                    "type": "file"
+                                    "$ref": "#/components/schemas/dto.AssetTagsResponseDTO"
                                 }
                             }
                         },
-                        "description": "Web-optimized audio file"
+                        "description": "Tags retrieved successfully"
                     },
                     "400": {
                         "content": {
-                            "audio/mpeg": {
-                                "schema": {
-                                    "$ref": "#/components/schemas/api.ErrorResponse"
-                                }
-                            }
-                        },
-                        "description": "Invalid asset ID"
-                    },
-                    "404": {
-                        "content": {
-                            "audio/mpeg": {
+                            "application/json": {
                                 "schema": {
                                     "$ref": "#/components/schemas/api.ErrorResponse"
                                 }
                             }
                         },
-                        "description": "Asset not found or not audio"
+                        "description": "Bad request"
                     },
                     "500": {
                         "content": {
-                            "audio/mpeg": {
+                            "application/json": {
                                 "schema": {
                                     "$ref": "#/components/schemas/api.ErrorResponse"
                                 }
@@ -10200,15 +16315,13 @@ const docTemplate = `{
                         "description": "Internal server error"
                     }
                 },
-                "summary": "Get web-optimized audio",
+                "summary": "Get asset tags",
                 "tags": [
                     "assets"
                 ]
-            }
-        },
-        "/api/v1/assets/{id}/description": {
-            "put": {
-                "description": "Update the description metadata of an asset",
+            },
+            "post": {
+                "description": "Resolve (creating if needed, with the optional category) a tag by name and link it to the asset with the manual source and full confidence. Returns the asset's updated tag list.",
                 "parameters": [
                     {
                         "description": "Asset ID",
@@ -10224,13 +16337,20 @@ const docTemplate = `{
                     "content": {
                         "application/json": {
                             "schema": {
-                                "$ref": "#/components/schemas/dto.UpdateDescriptionRequestDTO",
-                                "summary": "description",
-                                "description": "Description data"
+                                "oneOf": [
+                                    {
+                                        "type": "object"
+                                    },
+                                    {
+                                        "$ref": "#/components/schemas/dto.AddAssetTagRequestDTO",
+                                        "summary": "request",
+                                        "description": "Tag to add"
+                                    }
+                                ]
                             }
                         }
                     },
-                    "description": "Description data",
+                    "description": "Tag to add",
                     "required": true
                 },
                 "responses": {
@@ -10238,11 +16358,11 @@ const docTemplate = `{
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/dto.MessageResponseDTO"
+                                    "$ref": "#/components/schemas/dto.AssetTagsResponseDTO"
                                 }
                             }
                         },
-                        "description": "Description updated successfully"
+                        "description": "Updated asset tags"
                     },
                     "400": {
                         "content": {
@@ -10254,16 +16374,6 @@ const docTemplate = `{
                         },
                         "description": "Bad request"
                     },
-                    "404": {
-                        "content": {
-                            "application/json": {
-                                "schema": {
-                                    "$ref": "#/components/schemas/api.ErrorResponse"
-                                }
-                            }
-                        },
-                        "description": "Asset not found"
-                    },
                     "500": {
                         "content": {
                             "application/json": {
@@ -10275,46 +16385,45 @@ const docTemplate = `{
                         "description": "Internal server error"
                     }
                 },
-                "summary": "Update asset description",
+                "summary": "Add a manual tag to an asset",
                 "tags": [
                     "assets"
                 ]
             }
         },
-        "/api/v1/assets/{id}/exif": {
-            "get": {
-                "description": "Retrieve the full exiftool JSON object stored for an asset during metadata processing.",
+        "/api/v1/assets/{id}/tags/{tagId}": {
+            "delete": {
+                "description": "Unlink a tag from an asset by tag ID. Returns the asset's updated tag list.",
                 "parameters": [
                     {
-                        "description": "Asset ID (UUID format)",
-                        "example": "\"550e8400-e29b-41d4-a716-446655440000\"",
+                        "description": "Asset ID",
                         "in": "path",
                         "name": "id",
                         "required": true,
                         "schema": {
                             "type": "string"
                         }
-                    }
-                ],
-                "requestBody": {
-                    "content": {
-                        "application/json": {
-                            "schema": {
-                                "type": "object"
-                            }
+                    },
+                    {
+                        "description": "Tag ID",
+                        "in": "path",
+                        "name": "tagId",
+                        "required": true,
+                        "schema": {
+                            "type": "integer"
                         }
                     }
-                },
+                ],
                 "responses": {
                     "200": {
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/dto.AssetExifResponseDTO"
+                                    "$ref": "#/components/schemas/dto.AssetTagsResponseDTO"
                                 }
                             }
                         },
-                        "description": "Raw EXIF JSON"
+                        "description": "Updated asset tags"
                     },
                     "400": {
                         "content": {
@@ -10324,9 +16433,9 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Invalid asset ID"
+                        "description": "Bad request"
                     },
-                    "404": {
+                    "500": {
                         "content": {
                             "application/json": {
                                 "schema": {
@@ -10334,21 +16443,22 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Asset or EXIF not found"
+                        "description": "Internal server error"
                     }
                 },
-                "summary": "Get raw asset EXIF",
+                "summary": "Remove a tag from an asset",
                 "tags": [
                     "assets"
                 ]
             }
         },
-        "/api/v1/assets/{id}/export": {
+        "/api/v1/assets/{id}/thumbnail": {
             "get": {
-                "description": "Re-encode an asset's original file to JPEG, PNG, WebP, or AVIF with optional max dimensions and quality, and stream it back as a download.",
+                "description": "Retrieve a specific thumbnail image for an asset by asset ID and size parameter. Returns the image file directly. Thumbnails are never upscaled, so when the source is too small for the requested size the largest smaller size is served. A photo whose thumbnails were not generated yet is rendered from its original on the first request.",
                 "parameters": [
                     {
-                        "description": "Asset ID",
+                        "description": "Asset ID (UUID format)",
+                        "example": "\"550e8400-e29b-41d4-a716-446655440000\"",
                         "in": "path",
                         "name": "id",
                         "required": true,
@@ -10357,236 +16467,186 @@ const docTemplate = `{
                         }
                     },
                     {
-                        "description": "Output format (jpeg, png, webp, avif)",
+                        "description": "Thumbnail size",
                         "in": "query",
-                        "name": "format",
-                        "required": true,
+                        "name": "size",
                         "schema": {
+                            "default": "medium",
+                            "enum": [
+                                "small",
+                                "medium",
+                                "large"
+                            ],
                             "type": "string"
                         }
                     },
                     {
-                        "description": "Quality 1-100 for lossy formats",
-                        "in": "query",
-                        "name": "quality",
-                        "schema": {
-                            "type": "integer"
-                        }
-                    },
-                    {
-                        "description": "Maximum output width in pixels",
-                        "in": "query",
-                        "name": "max_width",
-                        "schema": {
-                            "type": "integer"
-                        }
-                    },
-                    {
-                        "description": "Maximum output height in pixels",
-                        "in": "query",
-                        "name": "max_height",
-                        "schema": {
-                            "type": "integer"
-                        }
-                    },
-                    {
-                        "description": "Base download filename (without extension)",
+                        "description": "Serve as an attachment named \u003coriginal-basename\u003e_\u003csize\u003e.webp instead of inline",
                         "in": "query",
-                        "name": "filename",
+                        "name": "download",
                         "schema": {
-                            "type": "string"
+                            "default": false,
+                            "type": "boolean"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
                         "content": {
-                            "image/avif": {
-                                "schema": {
-                                    "type": "file"
-                                }
-                            },
                             "image/jpeg": {
                                 "schema": {
                                     "type": "file"
                                 }
-                            },
-                            "image/png": {
-                                "schema": {
-                                    "type": "file"
-                                }
-                            },
-                            "image/webp": {
-                                "schema": {
-                                    "type": "file"
-                                }
                             }
                         },
-                        "description": "Encoded image"
+                        "description": "Thumbnail image file"
                     },
                     "400": {
                         "content": {
-                            "image/avif": {
-                                "schema": {
-                                    "$ref": "#/components/schemas/api.ErrorResponse"
-                                }
-                            },
                             "image/jpeg": {
                                 "schema": {
                                     "$ref": "#/components/schemas/api.ErrorResponse"
                                 }
-                            },
-                            "image/png": {
-                                "schema": {
-                                    "$ref": "#/components/schemas/api.ErrorResponse"
-                                }
-                            },
-                            "image/webp": {
-                                "schema": {
-                                    "$ref": "#/components/schemas/api.ErrorResponse"
-                                }
                             }
                         },
-                        "description": "Invalid request"
+                        "description": "Invalid asset ID or size parameter"
                     },
-                    "401": {
+                    "404": {
                         "content": {
-                            "image/avif": {
-                                "schema": {
-                                    "$ref": "#/components/schemas/api.ErrorResponse"
-                                }
-                            },
                             "image/jpeg": {
                                 "schema": {
                                     "$ref": "#/components/schemas/api.ErrorResponse"
                                 }
-                            },
-                            "image/png": {
-                                "schema": {
-                                    "$ref": "#/components/schemas/api.ErrorResponse"
-                                }
-                            },
-                            "image/webp": {
-                                "schema": {
-                                    "$ref": "#/components/schemas/api.ErrorResponse"
-                                }
                             }
                         },
-                        "description": "Authentication required"
+                        "description": "Asset or thumbnail not found"
                     },
-                    "403": {
+                    "410": {
                         "content": {
-                            "image/avif": {
-                                "schema": {
-                                    "$ref": "#/components/schemas/api.ErrorResponse"
-                                }
-                            },
                             "image/jpeg": {
                                 "schema": {
                                     "$ref": "#/components/schemas/api.ErrorResponse"
                                 }
-                            },
-                            "image/png": {
-                                "schema": {
-                                    "$ref": "#/components/schemas/api.ErrorResponse"
-                                }
-                            },
-                            "image/webp": {
-                                "schema": {
-                                    "$ref": "#/components/schemas/api.ErrorResponse"
-                                }
                             }
                         },
-                        "description": "Forbidden"
+                        "description": "Asset's repository was removed"
                     },
-                    "404": {
+                    "422": {
                         "content": {
-                            "image/avif": {
-                                "schema": {
-                                    "$ref": "#/components/schemas/api.ErrorResponse"
-                                }
-                            },
                             "image/jpeg": {
                                 "schema": {
                                     "$ref": "#/components/schemas/api.ErrorResponse"
                                 }
-                            },
-                            "image/png": {
-                                "schema": {
-                                    "$ref": "#/components/schemas/api.ErrorResponse"
-                                }
-                            },
-                            "image/webp": {
-                                "schema": {
-                                    "$ref": "#/components/schemas/api.ErrorResponse"
-                                }
                             }
                         },
-                        "description": "Asset or original file not found"
+                        "description": "Original is too large to render a thumbnail on the fly"
                     },
-                    "422": {
+                    "500": {
                         "content": {
-                            "image/avif": {
-                                "schema": {
-                                    "$ref": "#/components/schemas/api.ErrorResponse"
-                                }
-                            },
                             "image/jpeg": {
                                 "schema": {
                                     "$ref": "#/components/schemas/api.ErrorResponse"
                                 }
-                            },
-                            "image/png": {
-                                "schema": {
-                                    "$ref": "#/components/schemas/api.ErrorResponse"
-                                }
-                            },
-                            "image/webp": {
+                            }
+                        },
+                        "description": "Internal server error"
+                    },
+                    "504": {
+                        "content": {
+                            "image/jpeg": {
                                 "schema": {
                                     "$ref": "#/components/schemas/api.ErrorResponse"
                                 }
                             }
                         },
-                        "description": "Source image could not be encoded"
+                        "description": "Thumbnail rendering ran past the image operation timeout"
+                    }
+                },
+                "summary": "Get asset thumbnail",
+                "tags": [
+                    "assets"
+                ]
+            }
+        },
+        "/api/v1/assets/{id}/video/hls/{file}": {
+            "get": {
+                "description": "Serve the HLS playlist (index.m3u8) or one of its MPEG-TS segments for a video. Only long videos transcoded with transcode.hls_enabled have a stream; players should fall back to /video/web on 404. Segment references in the playlist are relative to it.",
+                "parameters": [
+                    {
+                        "description": "Asset ID (UUID format)",
+                        "example": "\"550e8400-e29b-41d4-a716-446655440000\"",
+                        "in": "path",
+                        "name": "id",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
                     },
-                    "500": {
+                    {
+                        "description": "index.m3u8 or a segment file name",
+                        "in": "path",
+                        "name": "file",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
                         "content": {
-                            "image/avif": {
+                            "video/mp2t": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                    "type": "file"
                                 }
-                            },
-                            "image/jpeg": {
+                            }
+                        },
+                        "description": "Playlist or segment"
+                    },
+                    "400": {
+                        "content": {
+                            "video/mp2t": {
                                 "schema": {
                                     "$ref": "#/components/schemas/api.ErrorResponse"
                                 }
-                            },
-                            "image/png": {
+                            }
+                        },
+                        "description": "Invalid asset ID or not a video"
+                    },
+                    "404": {
+                        "content": {
+                            "video/mp2t": {
                                 "schema": {
                                     "$ref": "#/components/schemas/api.ErrorResponse"
                                 }
-                            },
-                            "image/webp": {
+                            }
+                        },
+                        "description": "Asset or HLS stream not found"
+                    },
+                    "410": {
+                        "content": {
+                            "video/mp2t": {
                                 "schema": {
                                     "$ref": "#/components/schemas/api.ErrorResponse"
                                 }
                             }
                         },
-                        "description": "Internal server error"
+                        "description": "Asset's repository was removed"
                     }
                 },
-                "summary": "Export asset",
+                "summary": "Get HLS video stream",
                 "tags": [
                     "assets"
                 ]
             }
         },
-        "/api/v1/assets/{id}/like": {
-            "put": {
-                "description": "Update the like/favorite status of a specific asset",
+        "/api/v1/assets/{id}/video/web": {
+            "get": {
+                "description": "Serve the web-optimized MP4 video version for an asset by asset ID.",
                 "parameters": [
                     {
-                        "description": "Asset ID",
+                        "description": "Asset ID (UUID format)",
+                        "example": "\"550e8400-e29b-41d4-a716-446655440000\"",
                         "in": "path",
                         "name": "id",
                         "required": true,
@@ -10595,53 +16655,50 @@ const docTemplate = `{
                         }
                     }
                 ],
-                "requestBody": {
-                    "content": {
-                        "application/json": {
-                            "schema": {
-                                "$ref": "#/components/schemas/dto.UpdateLikeRequestDTO",
-                                "summary": "like",
-                                "description": "Like data"
-                            }
-                        }
-                    },
-                    "description": "Like data",
-                    "required": true
-                },
                 "responses": {
                     "200": {
                         "content": {
-                            "application/json": {
+                            "video/mp4": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/dto.MessageResponseDTO"
+                                    "type": "file"
                                 }
                             }
                         },
-                        "description": "Like status updated successfully"
+                        "description": "Web-optimized video file"
                     },
                     "400": {
                         "content": {
-                            "application/json": {
+                            "video/mp4": {
                                 "schema": {
                                     "$ref": "#/components/schemas/api.ErrorResponse"
                                 }
                             }
                         },
-                        "description": "Bad request"
+                        "description": "Invalid asset ID"
                     },
                     "404": {
                         "content": {
-                            "application/json": {
+                            "video/mp4": {
                                 "schema": {
                                     "$ref": "#/components/schemas/api.ErrorResponse"
                                 }
                             }
                         },
-                        "description": "Asset not found"
+                        "description": "Asset not found or not a video"
+                    },
+                    "410": {
+                        "content": {
+                            "video/mp4": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Asset's repository was removed"
                     },
                     "500": {
                         "content": {
-                            "application/json": {
+                            "video/mp4": {
                                 "schema": {
                                     "$ref": "#/components/schemas/api.ErrorResponse"
                                 }
@@ -10650,38 +16707,47 @@ const docTemplate = `{
                         "description": "Internal server error"
                     }
                 },
-                "summary": "Update asset like status",
+                "summary": "Get web-optimized video",
                 "tags": [
                     "assets"
                 ]
             }
         },
-        "/api/v1/assets/{id}/media-item": {
-            "get": {
-                "description": "Returns the logical media item and its RAW/JPEG, Live Photo, or edited components",
-                "parameters": [
-                    {
-                        "description": "Asset ID",
-                        "in": "path",
-                        "name": "id",
-                        "required": true,
-                        "schema": {
-                            "type": "string"
+        "/api/v1/auth/login": {
+            "post": {
+                "description": "Authenticate user with username and password. Returns an MFA challenge instead of session tokens when TOTP is enabled.",
+                "requestBody": {
+                    "content": {
+                        "application/json": {
+                            "schema": {
+                                "oneOf": [
+                                    {
+                                        "type": "object"
+                                    },
+                                    {
+                                        "$ref": "#/components/schemas/dto.LoginRequestDTO",
+                                        "summary": "request",
+                                        "description": "Login credentials"
+                                    }
+                                ]
+                            }
                         }
-                    }
-                ],
+                    },
+                    "description": "Login credentials",
+                    "required": true
+                },
                 "responses": {
                     "200": {
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/dto.MediaItemByAssetResponseDTO"
+                                    "$ref": "#/components/schemas/dto.AuthResponseDTO"
                                 }
                             }
                         },
-                        "description": "OK"
+                        "description": "Login successful"
                     },
-                    "404": {
+                    "400": {
                         "content": {
                             "application/json": {
                                 "schema": {
@@ -10689,69 +16755,82 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Not Found"
+                        "description": "Invalid request data"
+                    },
+                    "401": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Invalid credentials"
+                    },
+                    "500": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Internal server error"
                     }
                 },
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "summary": "Get logical media item",
+                "summary": "Login user",
                 "tags": [
-                    "assets"
+                    "auth"
                 ]
             }
         },
-        "/api/v1/assets/{id}/original": {
-            "get": {
-                "description": "Serve the original file content for an asset by asset ID. Returns the file as an octet-stream.",
-                "parameters": [
-                    {
-                        "description": "Asset ID (UUID format)",
-                        "example": "\"550e8400-e29b-41d4-a716-446655440000\"",
-                        "in": "path",
-                        "name": "id",
-                        "required": true,
-                        "schema": {
-                            "type": "string"
+        "/api/v1/auth/login/options": {
+            "post": {
+                "description": "Identifier-first capability probe. Always returns password:true for valid usernames; passkey:true only when the active account has at least one passkey. Does not reveal TOTP enrollment. Unknown and inactive usernames match the password-only shape.",
+                "requestBody": {
+                    "content": {
+                        "application/json": {
+                            "schema": {
+                                "oneOf": [
+                                    {
+                                        "type": "object"
+                                    },
+                                    {
+                                        "$ref": "#/components/schemas/dto.LoginOptionsRequestDTO",
+                                        "summary": "request",
+                                        "description": "Username to probe"
+                                    }
+                                ]
+                            }
                         }
-                    }
-                ],
+                    },
+                    "description": "Username to probe",
+                    "required": true
+                },
                 "responses": {
                     "200": {
                         "content": {
-                            "application/octet-stream": {
+                            "application/json": {
                                 "schema": {
-                                    "type": "file"
+                                    "$ref": "#/components/schemas/dto.LoginOptionsResponseDTO"
                                 }
                             }
                         },
-                        "description": "Original file content"
+                        "description": "Login options for the username"
                     },
                     "400": {
                         "content": {
-                            "application/octet-stream": {
-                                "schema": {
-                                    "$ref": "#/components/schemas/api.ErrorResponse"
-                                }
-                            }
-                        },
-                        "description": "Invalid asset ID"
-                    },
-                    "404": {
-                        "content": {
-                            "application/octet-stream": {
+                            "application/json": {
                                 "schema": {
                                     "$ref": "#/components/schemas/api.ErrorResponse"
                                 }
                             }
                         },
-                        "description": "Asset not found"
+                        "description": "Invalid request data"
                     },
                     "500": {
                         "content": {
-                            "application/octet-stream": {
+                            "application/json": {
                                 "schema": {
                                     "$ref": "#/components/schemas/api.ErrorResponse"
                                 }
@@ -10760,37 +16839,33 @@ const docTemplate = `{
                         "description": "Internal server error"
                     }
                 },
-                "summary": "Get original file",
+                "summary": "Probe login options",
                 "tags": [
-                    "assets"
+                    "auth"
                 ]
             }
         },
-        "/api/v1/assets/{id}/rating": {
-            "put": {
-                "description": "Update the rating (0-5) of a specific asset",
-                "parameters": [
-                    {
-                        "description": "Asset ID",
-                        "in": "path",
-                        "name": "id",
-                        "required": true,
-                        "schema": {
-                            "type": "string"
-                        }
-                    }
-                ],
+        "/api/v1/auth/logout": {
+            "post": {
+                "description": "Revoke the user's refresh token",
                 "requestBody": {
                     "content": {
                         "application/json": {
                             "schema": {
-                                "$ref": "#/components/schemas/dto.UpdateRatingRequestDTO",
-                                "summary": "rating",
-                                "description": "Rating data"
+                                "oneOf": [
+                                    {
+                                        "type": "object"
+                                    },
+                                    {
+                                        "$ref": "#/components/schemas/dto.RefreshTokenRequestDTO",
+                                        "summary": "request",
+                                        "description": "Refresh token to revoke"
+                                    }
+                                ]
                             }
                         }
                     },
-                    "description": "Rating data",
+                    "description": "Refresh token to revoke",
                     "required": true
                 },
                 "responses": {
@@ -10798,11 +16873,11 @@ const docTemplate = `{
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/dto.MessageResponseDTO"
+                                    "$ref": "#/components/schemas/api.SuccessResponse"
                                 }
                             }
                         },
-                        "description": "Rating updated successfully"
+                        "description": "Logout successful"
                     },
                     "400": {
                         "content": {
@@ -10812,9 +16887,9 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Bad request"
+                        "description": "Invalid request data"
                     },
-                    "404": {
+                    "401": {
                         "content": {
                             "application/json": {
                                 "schema": {
@@ -10822,7 +16897,7 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Asset not found"
+                        "description": "Invalid refresh token"
                     },
                     "500": {
                         "content": {
@@ -10835,61 +16910,36 @@ const docTemplate = `{
                         "description": "Internal server error"
                     }
                 },
-                "summary": "Update asset rating",
+                "summary": "Logout user",
                 "tags": [
-                    "assets"
+                    "auth"
                 ]
             }
         },
-        "/api/v1/assets/{id}/rating-and-like": {
-            "put": {
-                "description": "Update both the rating (0-5) and like/favorite status of a specific asset",
-                "parameters": [
-                    {
-                        "description": "Asset ID",
-                        "in": "path",
-                        "name": "id",
-                        "required": true,
-                        "schema": {
-                            "type": "string"
-                        }
-                    }
-                ],
+        "/api/v1/auth/me": {
+            "get": {
+                "description": "Get information about the currently authenticated user",
                 "requestBody": {
                     "content": {
                         "application/json": {
                             "schema": {
-                                "$ref": "#/components/schemas/dto.UpdateRatingAndLikeRequestDTO",
-                                "summary": "data",
-                                "description": "Rating and like data"
+                                "type": "object"
                             }
                         }
-                    },
-                    "description": "Rating and like data",
-                    "required": true
+                    }
                 },
                 "responses": {
                     "200": {
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/dto.MessageResponseDTO"
-                                }
-                            }
-                        },
-                        "description": "Rating and like status updated successfully"
-                    },
-                    "400": {
-                        "content": {
-                            "application/json": {
-                                "schema": {
-                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                    "$ref": "#/components/schemas/dto.UserDTO"
                                 }
                             }
                         },
-                        "description": "Bad request"
+                        "description": "User information retrieved successfully"
                     },
-                    "404": {
+                    "401": {
                         "content": {
                             "application/json": {
                                 "schema": {
@@ -10897,7 +16947,7 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Asset not found"
+                        "description": "Unauthorized"
                     },
                     "500": {
                         "content": {
@@ -10910,60 +16960,41 @@ const docTemplate = `{
                         "description": "Internal server error"
                     }
                 },
-                "summary": "Update asset rating and like status",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "summary": "Get current user",
                 "tags": [
-                    "assets"
+                    "auth"
                 ]
             }
         },
-        "/api/v1/assets/{id}/reprocess": {
-            "post": {
-                "description": "Reprocess a failed or warning asset by resetting its status and re-enqueuing for processing",
-                "parameters": [
-                    {
-                        "description": "Asset ID",
-                        "in": "path",
-                        "name": "id",
-                        "required": true,
-                        "schema": {
-                            "type": "string"
-                        }
-                    }
-                ],
+        "/api/v1/auth/media-token": {
+            "get": {
+                "description": "Generate a short-lived media token for image/video/audio URL authorization in browser media elements.",
                 "requestBody": {
                     "content": {
                         "application/json": {
                             "schema": {
-                                "$ref": "#/components/schemas/dto.ReprocessAssetRequestDTO",
-                                "summary": "data",
-                                "description": "Reprocessing tasks (optional)"
+                                "type": "object"
                             }
                         }
-                    },
-                    "description": "Reprocessing tasks (optional)"
+                    }
                 },
                 "responses": {
                     "200": {
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/dto.ReprocessAssetResponseDTO"
-                                }
-                            }
-                        },
-                        "description": "OK"
-                    },
-                    "400": {
-                        "content": {
-                            "application/json": {
-                                "schema": {
-                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                    "$ref": "#/components/schemas/dto.MediaTokenDTO"
                                 }
                             }
                         },
-                        "description": "Bad Request"
+                        "description": "Media token issued successfully"
                     },
-                    "404": {
+                    "401": {
                         "content": {
                             "application/json": {
                                 "schema": {
@@ -10971,7 +17002,7 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Not Found"
+                        "description": "Unauthorized"
                     },
                     "500": {
                         "content": {
@@ -10981,30 +17012,23 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Internal Server Error"
+                        "description": "Internal server error"
                     }
                 },
-                "summary": "Reprocess asset",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "summary": "Get media access token",
                 "tags": [
-                    "assets"
+                    "auth"
                 ]
             }
         },
-        "/api/v1/assets/{id}/restore": {
-            "post": {
-                "description": "Restore a soft-deleted asset from Trash. The original file is not moved.",
-                "parameters": [
-                    {
-                        "description": "Asset ID (UUID format)",
-                        "example": "\"550e8400-e29b-41d4-a716-446655440000\"",
-                        "in": "path",
-                        "name": "id",
-                        "required": true,
-                        "schema": {
-                            "type": "string"
-                        }
-                    }
-                ],
+        "/api/v1/auth/mfa": {
+            "get": {
+                "description": "Get the authenticated user's MFA status, including TOTP enablement and remaining recovery codes.",
                 "requestBody": {
                     "content": {
                         "application/json": {
@@ -11019,13 +17043,13 @@ const docTemplate = `{
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/dto.MessageResponseDTO"
+                                    "$ref": "#/components/schemas/dto.MFAStatusDTO"
                                 }
                             }
                         },
-                        "description": "Asset restored successfully"
+                        "description": "MFA status retrieved successfully"
                     },
-                    "400": {
+                    "401": {
                         "content": {
                             "application/json": {
                                 "schema": {
@@ -11033,7 +17057,7 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Invalid asset ID format"
+                        "description": "Unauthorized"
                     },
                     "500": {
                         "content": {
@@ -11046,27 +17070,20 @@ const docTemplate = `{
                         "description": "Internal server error"
                     }
                 },
-                "summary": "Restore asset",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "summary": "Get MFA status",
                 "tags": [
-                    "assets"
+                    "auth"
                 ]
             }
         },
-        "/api/v1/assets/{id}/sidecar": {
+        "/api/v1/auth/mfa/passkeys": {
             "get": {
-                "description": "Retrieve the non-destructive Studio edit sidecar stored under the asset repository .lumilio directory.",
-                "parameters": [
-                    {
-                        "description": "Asset ID (UUID format)",
-                        "example": "\"550e8400-e29b-41d4-a716-446655440000\"",
-                        "in": "path",
-                        "name": "id",
-                        "required": true,
-                        "schema": {
-                            "type": "string"
-                        }
-                    }
-                ],
+                "description": "List the authenticated user's enrolled passkeys.",
                 "requestBody": {
                     "content": {
                         "application/json": {
@@ -11081,23 +17098,13 @@ const docTemplate = `{
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/dto.AssetSidecarResponseDTO"
-                                }
-                            }
-                        },
-                        "description": "Asset sidecar"
-                    },
-                    "400": {
-                        "content": {
-                            "application/json": {
-                                "schema": {
-                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                    "$ref": "#/components/schemas/dto.PasskeyListResponseDTO"
                                 }
                             }
                         },
-                        "description": "Invalid asset ID"
+                        "description": "Passkeys retrieved successfully"
                     },
-                    "404": {
+                    "401": {
                         "content": {
                             "application/json": {
                                 "schema": {
@@ -11105,7 +17112,7 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Asset not found"
+                        "description": "Unauthorized"
                     },
                     "500": {
                         "content": {
@@ -11118,60 +17125,41 @@ const docTemplate = `{
                         "description": "Internal server error"
                     }
                 },
-                "summary": "Get asset edit sidecar",
-                "tags": [
-                    "assets"
-                ]
-            },
-            "put": {
-                "description": "Store non-destructive Studio edit data under the asset repository .lumilio directory.",
-                "parameters": [
+                "security": [
                     {
-                        "description": "Asset ID (UUID format)",
-                        "example": "\"550e8400-e29b-41d4-a716-446655440000\"",
-                        "in": "path",
-                        "name": "id",
-                        "required": true,
-                        "schema": {
-                            "type": "string"
-                        }
+                        "BearerAuth": []
                     }
                 ],
+                "summary": "List passkeys",
+                "tags": [
+                    "auth"
+                ]
+            }
+        },
+        "/api/v1/auth/mfa/passkeys/options": {
+            "post": {
+                "description": "Create WebAuthn registration options to add a new passkey to the authenticated account.",
                 "requestBody": {
                     "content": {
                         "application/json": {
                             "schema": {
-                                "$ref": "#/components/schemas/dto.LumilioSidecarV1DTO",
-                                "summary": "data",
-                                "description": "Sidecar payload"
+                                "type": "object"
                             }
                         }
-                    },
-                    "description": "Sidecar payload",
-                    "required": true
+                    }
                 },
                 "responses": {
                     "200": {
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/dto.AssetSidecarResponseDTO"
-                                }
-                            }
-                        },
-                        "description": "Asset sidecar saved"
-                    },
-                    "400": {
-                        "content": {
-                            "application/json": {
-                                "schema": {
-                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                    "$ref": "#/components/schemas/dto.PasskeyOptionsResponseDTO"
                                 }
                             }
                         },
-                        "description": "Invalid asset ID or request body"
+                        "description": "Passkey enrollment options created successfully"
                     },
-                    "404": {
+                    "401": {
                         "content": {
                             "application/json": {
                                 "schema": {
@@ -11179,7 +17167,7 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Asset not found"
+                        "description": "Unauthorized"
                     },
                     "500": {
                         "content": {
@@ -11192,73 +17180,72 @@ const docTemplate = `{
                         "description": "Internal server error"
                     }
                 },
-                "summary": "Update asset edit sidecar",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "summary": "Begin passkey enrollment",
                 "tags": [
-                    "assets"
+                    "auth"
                 ]
             }
         },
-        "/api/v1/assets/{id}/stack": {
-            "delete": {
-                "description": "Removes an asset from its stack, making it standalone",
-                "parameters": [
-                    {
-                        "description": "Asset ID",
-                        "in": "path",
-                        "name": "id",
-                        "required": true,
-                        "schema": {
-                            "type": "string"
+        "/api/v1/auth/mfa/passkeys/verify": {
+            "post": {
+                "description": "Verify a passkey enrollment response and attach the new passkey to the authenticated account.",
+                "requestBody": {
+                    "content": {
+                        "application/json": {
+                            "schema": {
+                                "oneOf": [
+                                    {
+                                        "type": "object"
+                                    },
+                                    {
+                                        "$ref": "#/components/schemas/dto.PasskeyVerifyRequestDTO",
+                                        "summary": "request",
+                                        "description": "Passkey enrollment verification payload"
+                                    }
+                                ]
+                            }
                         }
-                    }
-                ],
+                    },
+                    "description": "Passkey enrollment verification payload",
+                    "required": true
+                },
                 "responses": {
                     "200": {
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/api.SuccessResponse"
+                                    "$ref": "#/components/schemas/dto.PasskeyCredentialSummaryDTO"
+                                }
+                            }
+                        },
+                        "description": "Passkey enrolled successfully"
+                    },
+                    "400": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
                                 }
                             }
                         },
-                        "description": "OK"
-                    }
-                },
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "summary": "Remove asset from stack",
-                "tags": [
-                    "assets"
-                ]
-            },
-            "get": {
-                "description": "Returns the stack (group) that contains the specified asset",
-                "parameters": [
-                    {
-                        "description": "Asset ID",
-                        "in": "path",
-                        "name": "id",
-                        "required": true,
-                        "schema": {
-                            "type": "string"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
+                        "description": "Invalid or expired challenge"
+                    },
+                    "401": {
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/dto.StackByAssetResponseDTO"
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
                                 }
                             }
                         },
-                        "description": "OK"
+                        "description": "Unauthorized"
                     },
-                    "404": {
+                    "500": {
                         "content": {
                             "application/json": {
                                 "schema": {
@@ -11266,7 +17253,7 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Not Found"
+                        "description": "Internal server error"
                     }
                 },
                 "security": [
@@ -11274,38 +17261,47 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "summary": "Get asset stack",
+                "summary": "Verify passkey enrollment",
                 "tags": [
-                    "assets"
+                    "auth"
                 ]
             }
         },
-        "/api/v1/assets/{id}/tags": {
-            "get": {
-                "description": "Get all tags (manual and AI-generated) attached to an asset",
+        "/api/v1/auth/mfa/passkeys/{id}": {
+            "delete": {
+                "description": "Delete one enrolled passkey for the authenticated user.",
                 "parameters": [
                     {
-                        "description": "Asset ID",
+                        "description": "Passkey ID",
                         "in": "path",
                         "name": "id",
                         "required": true,
                         "schema": {
-                            "type": "string"
+                            "type": "integer"
                         }
                     }
                 ],
+                "requestBody": {
+                    "content": {
+                        "application/json": {
+                            "schema": {
+                                "type": "object"
+                            }
+                        }
+                    }
+                },
                 "responses": {
                     "200": {
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/dto.AssetTagsResponseDTO"
+                                    "$ref": "#/components/schemas/api.SuccessResponse"
                                 }
                             }
                         },
-                        "description": "Tags retrieved successfully"
+                        "description": "Passkey deleted successfully"
                     },
-                    "400": {
+                    "401": {
                         "content": {
                             "application/json": {
                                 "schema": {
@@ -11313,7 +17309,17 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Bad request"
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Passkey not found"
                     },
                     "500": {
                         "content": {
@@ -11326,24 +17332,20 @@ const docTemplate = `{
                         "description": "Internal server error"
                     }
                 },
-                "summary": "Get asset tags",
-                "tags": [
-                    "assets"
-                ]
-            },
-            "post": {
-                "description": "Resolve (creating if needed) a tag by name and link it to the asset with the manual source",
-                "parameters": [
+                "security": [
                     {
-                        "description": "Asset ID",
-                        "in": "path",
-                        "name": "id",
-                        "required": true,
-                        "schema": {
-                            "type": "string"
-                        }
+                        "BearerAuth": []
                     }
                 ],
+                "summary": "Delete passkey",
+                "tags": [
+                    "auth"
+                ]
+            }
+        },
+        "/api/v1/auth/mfa/recovery-codes/regenerate": {
+            "post": {
+                "description": "Generate a fresh set of recovery codes for the authenticated user.",
                 "requestBody": {
                     "content": {
                         "application/json": {
@@ -11353,15 +17355,15 @@ const docTemplate = `{
                                         "type": "object"
                                     },
                                     {
-                                        "$ref": "#/components/schemas/dto.AddAssetTagRequestDTO",
+                                        "$ref": "#/components/schemas/dto.RegenerateRecoveryCodesRequestDTO",
                                         "summary": "request",
-                                        "description": "Tag to add"
+                                        "description": "Recovery code regeneration payload"
                                     }
                                 ]
                             }
                         }
                     },
-                    "description": "Tag to add",
+                    "description": "Recovery code regeneration payload",
                     "required": true
                 },
                 "responses": {
@@ -11369,11 +17371,11 @@ const docTemplate = `{
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/dto.AssetTagDTO"
+                                    "$ref": "#/components/schemas/dto.RecoveryCodesResponseDTO"
                                 }
                             }
                         },
-                        "description": "Tag added successfully"
+                        "description": "Recovery codes regenerated successfully"
                     },
                     "400": {
                         "content": {
@@ -11383,7 +17385,17 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Bad request"
+                        "description": "MFA is not enabled"
+                    },
+                    "401": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Unauthorized or incorrect password"
                     },
                     "500": {
                         "content": {
@@ -11396,45 +17408,50 @@ const docTemplate = `{
                         "description": "Internal server error"
                     }
                 },
-                "summary": "Add a manual tag to an asset",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "summary": "Regenerate recovery codes",
                 "tags": [
-                    "assets"
+                    "auth"
                 ]
             }
         },
-        "/api/v1/assets/{id}/tags/{tagId}": {
-            "delete": {
-                "description": "Unlink a tag from an asset by tag ID",
-                "parameters": [
-                    {
-                        "description": "Asset ID",
-                        "in": "path",
-                        "name": "id",
-                        "required": true,
-                        "schema": {
-                            "type": "string"
+        "/api/v1/auth/mfa/totp/disable": {
+            "post": {
+                "description": "Disable TOTP MFA and invalidate recovery codes for the authenticated user.",
+                "requestBody": {
+                    "content": {
+                        "application/json": {
+                            "schema": {
+                                "oneOf": [
+                                    {
+                                        "type": "object"
+                                    },
+                                    {
+                                        "$ref": "#/components/schemas/dto.DisableTOTPRequestDTO",
+                                        "summary": "request",
+                                        "description": "Disable TOTP payload"
+                                    }
+                                ]
+                            }
                         }
                     },
-                    {
-                        "description": "Tag ID",
-                        "in": "path",
-                        "name": "tagId",
-                        "required": true,
-                        "schema": {
-                            "type": "integer"
-                        }
-                    }
-                ],
+                    "description": "Disable TOTP payload",
+                    "required": true
+                },
                 "responses": {
                     "200": {
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/dto.MessageResponseDTO"
+                                    "$ref": "#/components/schemas/dto.MFAStatusDTO"
                                 }
                             }
                         },
-                        "description": "Tag removed successfully"
+                        "description": "TOTP disabled successfully"
                     },
                     "400": {
                         "content": {
@@ -11444,7 +17461,17 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Bad request"
+                        "description": "MFA is not enabled"
+                    },
+                    "401": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Unauthorized or incorrect password"
                     },
                     "500": {
                         "content": {
@@ -11457,75 +17484,74 @@ const docTemplate = `{
                         "description": "Internal server error"
                     }
                 },
-                "summary": "Remove a tag from an asset",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "summary": "Disable TOTP",
                 "tags": [
-                    "assets"
+                    "auth"
                 ]
             }
         },
-        "/api/v1/assets/{id}/thumbnail": {
-            "get": {
-                "description": "Retrieve a specific thumbnail image for an asset by asset ID and size parameter. Returns the image file directly.",
-                "parameters": [
-                    {
-                        "description": "Asset ID (UUID format)",
-                        "example": "\"550e8400-e29b-41d4-a716-446655440000\"",
-                        "in": "path",
-                        "name": "id",
-                        "required": true,
-                        "schema": {
-                            "type": "string"
+        "/api/v1/auth/mfa/totp/enable": {
+            "post": {
+                "description": "Verify a TOTP setup code and enable TOTP MFA for the authenticated user.",
+                "requestBody": {
+                    "content": {
+                        "application/json": {
+                            "schema": {
+                                "oneOf": [
+                                    {
+                                        "type": "object"
+                                    },
+                                    {
+                                        "$ref": "#/components/schemas/dto.EnableTOTPRequestDTO",
+                                        "summary": "request",
+                                        "description": "TOTP enable payload"
+                                    }
+                                ]
+                            }
                         }
                     },
-                    {
-                        "description": "Thumbnail size",
-                        "in": "query",
-                        "name": "size",
-                        "schema": {
-                            "default": "medium",
-                            "enum": [
-                                "small",
-                                "medium",
-                                "large"
-                            ],
-                            "type": "string"
-                        }
-                    }
-                ],
+                    "description": "TOTP enable payload",
+                    "required": true
+                },
                 "responses": {
                     "200": {
                         "content": {
-                            "image/jpeg": {
+                            "application/json": {
                                 "schema": {
-                                    "type": "file"
+                                    "$ref": "#/components/schemas/dto.RecoveryCodesResponseDTO"
                                 }
                             }
                         },
-                        "description": "Thumbnail image file"
+                        "description": "TOTP enabled successfully"
                     },
                     "400": {
                         "content": {
-                            "image/jpeg": {
+                            "application/json": {
                                 "schema": {
                                     "$ref": "#/components/schemas/api.ErrorResponse"
                                 }
                             }
                         },
-                        "description": "Invalid asset ID or size parameter"
+                        "description": "Invalid setup token or verification code"
                     },
-                    "404": {
+                    "401": {
                         "content": {
-                            "image/jpeg": {
+                            "application/json": {
                                 "schema": {
                                     "$ref": "#/components/schemas/api.ErrorResponse"
                                 }
                             }
                         },
-                        "description": "Asset or thumbnail not found"
+                        "description": "Unauthorized"
                     },
                     "500": {
                         "content": {
-                            "image/jpeg": {
+                            "application/json": {
                                 "schema": {
                                     "$ref": "#/components/schemas/api.ErrorResponse"
                                 }
@@ -11534,61 +17560,129 @@ const docTemplate = `{
                         "description": "Internal server error"
                     }
                 },
-                "summary": "Get asset thumbnail",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "summary": "Enable TOTP",
                 "tags": [
-                    "assets"
+                    "auth"
                 ]
             }
         },
-        "/api/v1/assets/{id}/video/web": {
-            "get": {
-                "description": "Serve the web-optimized MP4 video version for an asset by asset ID.",
-                "parameters": [
-                    {
-                        "description": "Asset ID (UUID format)",
-                        "example": "\"550e8400-e29b-41d4-a716-446655440000\"",
-                        "in": "path",
-                        "name": "id",
-                        "required": true,
-                        "schema": {
-                            "type": "string"
+        "/api/v1/auth/mfa/totp/setup": {
+            "post": {
+                "description": "Generate a new TOTP secret and setup token for the authenticated user.",
+                "requestBody": {
+                    "content": {
+                        "application/json": {
+                            "schema": {
+                                "type": "object"
+                            }
                         }
                     }
+                },
+                "responses": {
+                    "200": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/dto.TOTPSetupResponseDTO"
+                                }
+                            }
+                        },
+                        "description": "TOTP setup created successfully"
+                    },
+                    "401": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Unauthorized"
+                    },
+                    "500": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Internal server error"
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "summary": "Begin TOTP setup",
+                "tags": [
+                    "auth"
+                ]
+            }
+        },
+        "/api/v1/auth/mfa/verify": {
+            "post": {
+                "description": "Verify a pending MFA login challenge with a TOTP code or recovery code.",
+                "requestBody": {
+                    "content": {
+                        "application/json": {
+                            "schema": {
+                                "oneOf": [
+                                    {
+                                        "type": "object"
+                                    },
+                                    {
+                                        "$ref": "#/components/schemas/dto.VerifyMFARequestDTO",
+                                        "summary": "request",
+                                        "description": "MFA verification payload"
+                                    }
+                                ]
+                            }
+                        }
+                    },
+                    "description": "MFA verification payload",
+                    "required": true
+                },
                 "responses": {
                     "200": {
                         "content": {
-                            "video/mp4": {
+                            "application/json": {
                                 "schema": {
-                                    "type": "file"
+                                    "$ref": "#/components/schemas/dto.AuthResponseDTO"
                                 }
                             }
                         },
-                        "description": "Web-optimized video file"
+                        "description": "MFA verification successful"
                     },
                     "400": {
                         "content": {
-                            "video/mp4": {
+                            "application/json": {
                                 "schema": {
                                     "$ref": "#/components/schemas/api.ErrorResponse"
                                 }
                             }
                         },
-                        "description": "Invalid asset ID"
+                        "description": "Invalid request data"
                     },
-                    "404": {
+                    "401": {
                         "content": {
-                            "video/mp4": {
+                            "application/json": {
                                 "schema": {
                                     "$ref": "#/components/schemas/api.ErrorResponse"
                                 }
                             }
                         },
-                        "description": "Asset not found or not a video"
+                        "description": "Invalid or expired MFA challenge"
                     },
                     "500": {
                         "content": {
-                            "video/mp4": {
+                            "application/json": {
                                 "schema": {
                                     "$ref": "#/components/schemas/api.ErrorResponse"
                                 }
@@ -11597,15 +17691,15 @@ const docTemplate = `{
                         "description": "Internal server error"
                     }
                 },
-                "summary": "Get web-optimized video",
+                "summary": "Verify MFA challenge",
                 "tags": [
-                    "assets"
+                    "auth"
                 ]
             }
         },
-        "/api/v1/auth/login": {
+        "/api/v1/auth/passkeys/login/options": {
             "post": {
-                "description": "Authenticate user with username and password. Returns an MFA challenge instead of session tokens when TOTP is enabled.",
+                "description": "Create WebAuthn login options for a username-first passkey login flow.",
                 "requestBody": {
                     "content": {
                         "application/json": {
@@ -11615,15 +17709,15 @@ const docTemplate = `{
                                         "type": "object"
                                     },
                                     {
-                                        "$ref": "#/components/schemas/dto.LoginRequestDTO",
+                                        "$ref": "#/components/schemas/dto.PasskeyOptionsRequestDTO",
                                         "summary": "request",
-                                        "description": "Login credentials"
+                                        "description": "Username for passkey login"
                                     }
                                 ]
                             }
                         }
                     },
-                    "description": "Login credentials",
+                    "description": "Username for passkey login",
                     "required": true
                 },
                 "responses": {
@@ -11631,11 +17725,11 @@ const docTemplate = `{
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/dto.AuthResponseDTO"
+                                    "$ref": "#/components/schemas/dto.PasskeyOptionsResponseDTO"
                                 }
                             }
                         },
-                        "description": "Login successful"
+                        "description": "Passkey login options created successfully"
                     },
                     "400": {
                         "content": {
@@ -11668,15 +17762,15 @@ const docTemplate = `{
                         "description": "Internal server error"
                     }
                 },
-                "summary": "Login user",
+                "summary": "Begin passkey login",
                 "tags": [
                     "auth"
                 ]
             }
         },
-        "/api/v1/auth/login/options": {
+        "/api/v1/auth/passkeys/login/verify": {
             "post": {
-                "description": "Identifier-first capability probe. Always returns password:true for valid usernames; passkey:true only when the active account has at least one passkey. Does not reveal TOTP enrollment. Unknown and inactive usernames match the password-only shape.",
+                "description": "Verify a passkey login assertion and issue session tokens.",
                 "requestBody": {
                     "content": {
                         "application/json": {
@@ -11686,15 +17780,15 @@ const docTemplate = `{
                                         "type": "object"
                                     },
                                     {
-                                        "$ref": "#/components/schemas/dto.LoginOptionsRequestDTO",
+                                        "$ref": "#/components/schemas/dto.PasskeyVerifyRequestDTO",
                                         "summary": "request",
-                                        "description": "Username to probe"
+                                        "description": "Passkey login verification payload"
                                     }
                                 ]
                             }
                         }
                     },
-                    "description": "Username to probe",
+                    "description": "Passkey login verification payload",
                     "required": true
                 },
                 "responses": {
@@ -11702,11 +17796,11 @@ const docTemplate = `{
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/dto.LoginOptionsResponseDTO"
+                                    "$ref": "#/components/schemas/dto.AuthResponseDTO"
                                 }
                             }
                         },
-                        "description": "Login options for the username"
+                        "description": "Passkey login verified successfully"
                     },
                     "400": {
                         "content": {
@@ -11718,6 +17812,16 @@ const docTemplate = `{
                         },
                         "description": "Invalid request data"
                     },
+                    "401": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Invalid credentials"
+                    },
                     "500": {
                         "content": {
                             "application/json": {
@@ -11729,15 +17833,15 @@ const docTemplate = `{
                         "description": "Internal server error"
                     }
                 },
-                "summary": "Probe login options",
+                "summary": "Verify passkey login",
                 "tags": [
                     "auth"
                 ]
             }
         },
-        "/api/v1/auth/logout": {
+        "/api/v1/auth/password-change/complete": {
             "post": {
-                "description": "Revoke the user's refresh token",
+                "description": "Replace a temporary reset password using the short-lived token returned by login.",
                 "requestBody": {
                     "content": {
                         "application/json": {
@@ -11747,15 +17851,15 @@ const docTemplate = `{
                                         "type": "object"
                                     },
                                     {
-                                        "$ref": "#/components/schemas/dto.RefreshTokenRequestDTO",
+                                        "$ref": "#/components/schemas/dto.CompleteRequiredPasswordChangeRequestDTO",
                                         "summary": "request",
-                                        "description": "Refresh token to revoke"
+                                        "description": "Password change payload"
                                     }
                                 ]
                             }
                         }
                     },
-                    "description": "Refresh token to revoke",
+                    "description": "Password change payload",
                     "required": true
                 },
                 "responses": {
@@ -11763,11 +17867,11 @@ const docTemplate = `{
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/api.SuccessResponse"
+                                    "$ref": "#/components/schemas/dto.AuthResponseDTO"
                                 }
                             }
                         },
-                        "description": "Logout successful"
+                        "description": "Password changed and session issued"
                     },
                     "400": {
                         "content": {
@@ -11777,7 +17881,7 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Invalid request data"
+                        "description": "Invalid password"
                     },
                     "401": {
                         "content": {
@@ -11787,7 +17891,7 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Invalid refresh token"
+                        "description": "Invalid or expired password change token"
                     },
                     "500": {
                         "content": {
@@ -11800,34 +17904,55 @@ const docTemplate = `{
                         "description": "Internal server error"
                     }
                 },
-                "summary": "Logout user",
+                "summary": "Complete required password change",
                 "tags": [
                     "auth"
                 ]
             }
         },
-        "/api/v1/auth/me": {
-            "get": {
-                "description": "Get information about the currently authenticated user",
+        "/api/v1/auth/refresh": {
+            "post": {
+                "description": "Generate a new access token using a valid refresh token",
                 "requestBody": {
                     "content": {
                         "application/json": {
                             "schema": {
-                                "type": "object"
+                                "oneOf": [
+                                    {
+                                        "type": "object"
+                                    },
+                                    {
+                                        "$ref": "#/components/schemas/dto.RefreshTokenRequestDTO",
+                                        "summary": "request",
+                                        "description": "Refresh token"
+                                    }
+                                ]
                             }
                         }
-                    }
+                    },
+                    "description": "Refresh token",
+                    "required": true
                 },
                 "responses": {
                     "200": {
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/dto.UserDTO"
+                                    "$ref": "#/components/schemas/dto.AuthResponseDTO"
                                 }
                             }
                         },
-                        "description": "User information retrieved successfully"
+                        "description": "Token refreshed successfully"
+                    },
+                    "400": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Invalid request data"
                     },
                     "401": {
                         "content": {
@@ -11837,7 +17962,7 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Unauthorized"
+                        "description": "Invalid or expired refresh token"
                     },
                     "500": {
                         "content": {
@@ -11850,41 +17975,47 @@ const docTemplate = `{
                         "description": "Internal server error"
                     }
                 },
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "summary": "Get current user",
+                "summary": "Refresh access token",
                 "tags": [
                     "auth"
                 ]
             }
         },
-        "/api/v1/auth/media-token": {
-            "get": {
-                "description": "Generate a short-lived media token for image/video/audio URL authorization in browser media elements.",
+        "/api/v1/auth/register/start": {
+            "post": {
+                "description": "Create a new account from a username and password and issue session tokens. MFA (TOTP/passkey) is optional and added afterwards.",
                 "requestBody": {
                     "content": {
                         "application/json": {
                             "schema": {
-                                "type": "object"
+                                "oneOf": [
+                                    {
+                                        "type": "object"
+                                    },
+                                    {
+                                        "$ref": "#/components/schemas/dto.RegistrationStartRequestDTO",
+                                        "summary": "request",
+                                        "description": "Registration data"
+                                    }
+                                ]
                             }
                         }
-                    }
+                    },
+                    "description": "Registration data",
+                    "required": true
                 },
                 "responses": {
                     "200": {
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/dto.MediaTokenDTO"
+                                    "$ref": "#/components/schemas/dto.AuthResponseDTO"
                                 }
                             }
                         },
-                        "description": "Media token issued successfully"
+                        "description": "Account created successfully"
                     },
-                    "401": {
+                    "400": {
                         "content": {
                             "application/json": {
                                 "schema": {
@@ -11892,7 +18023,17 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Unauthorized"
+                        "description": "Invalid request data"
+                    },
+                    "409": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "User already exists"
                     },
                     "500": {
                         "content": {
@@ -11905,20 +18046,15 @@ const docTemplate = `{
                         "description": "Internal server error"
                     }
                 },
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "summary": "Get media access token",
+                "summary": "Register a new account",
                 "tags": [
                     "auth"
                 ]
             }
         },
-        "/api/v1/auth/mfa": {
+        "/api/v1/capabilities": {
             "get": {
-                "description": "Get the authenticated user's MFA status, including TOTP enablement and remaining recovery codes.",
+                "description": "Return a de-sensitized view of backend ML and LLM runtime capabilities without exposing secrets.",
                 "requestBody": {
                     "content": {
                         "application/json": {
@@ -11933,66 +18069,62 @@ const docTemplate = `{
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/dto.MFAStatusDTO"
-                                }
-                            }
-                        },
-                        "description": "MFA status retrieved successfully"
-                    },
-                    "401": {
-                        "content": {
-                            "application/json": {
-                                "schema": {
-                                    "$ref": "#/components/schemas/api.ErrorResponse"
-                                }
-                            }
-                        },
-                        "description": "Unauthorized"
-                    },
-                    "500": {
-                        "content": {
-                            "application/json": {
-                                "schema": {
-                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                    "$ref": "#/components/schemas/dto.CapabilitiesResponseDTO"
                                 }
                             }
                         },
-                        "description": "Internal server error"
+                        "description": "Capabilities retrieved successfully"
                     }
                 },
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "summary": "Get MFA status",
+                "summary": "Get public runtime capabilities",
                 "tags": [
-                    "auth"
+                    "capabilities"
                 ]
             }
         },
-        "/api/v1/auth/mfa/passkeys": {
-            "get": {
-                "description": "List the authenticated user's enrolled passkeys.",
+        "/api/v1/classifiers/preview": {
+            "post": {
+                "description": "Embed positive/negative prompts with semantic and return library assets whose contrastive score exceeds the threshold. Used to tune prompts and thresholds before persisting a smart album. Requires the semantic embedding pipeline and a reachable semantic text-embed task.",
                 "requestBody": {
                     "content": {
                         "application/json": {
                             "schema": {
-                                "type": "object"
+                                "oneOf": [
+                                    {
+                                        "type": "object"
+                                    },
+                                    {
+                                        "$ref": "#/components/schemas/dto.ClassifierPreviewRequestDTO",
+                                        "summary": "request",
+                                        "description": "Prompts and threshold"
+                                    }
+                                ]
                             }
                         }
-                    }
+                    },
+                    "description": "Prompts and threshold",
+                    "required": true
                 },
                 "responses": {
                     "200": {
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/dto.PasskeyListResponseDTO"
+                                    "$ref": "#/components/schemas/dto.ClassifierPreviewResponseDTO"
                                 }
                             }
                         },
-                        "description": "Passkeys retrieved successfully"
+                        "description": "Preview matches retrieved successfully"
+                    },
+                    "400": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Invalid request data"
                     },
                     "401": {
                         "content": {
@@ -12013,6 +18145,16 @@ const docTemplate = `{
                             }
                         },
                         "description": "Internal server error"
+                    },
+                    "503": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Classification unavailable"
                     }
                 },
                 "security": [
@@ -12020,34 +18162,25 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "summary": "List passkeys",
+                "summary": "Preview a zero-shot classifier",
                 "tags": [
-                    "auth"
+                    "classifiers"
                 ]
             }
         },
-        "/api/v1/auth/mfa/passkeys/options": {
-            "post": {
-                "description": "Create WebAuthn registration options to add a new passkey to the authenticated account.",
-                "requestBody": {
-                    "content": {
-                        "application/json": {
-                            "schema": {
-                                "type": "object"
-                            }
-                        }
-                    }
-                },
+        "/api/v1/cloud/credentials": {
+            "get": {
+                "description": "List the current user's cloud credentials without exposing secrets. Administrators receive all credentials.",
                 "responses": {
                     "200": {
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/dto.PasskeyOptionsResponseDTO"
+                                    "$ref": "#/components/schemas/dto.ListCloudCredentialsResponse"
                                 }
                             }
                         },
-                        "description": "Passkey enrollment options created successfully"
+                        "description": "Credential list"
                     },
                     "401": {
                         "content": {
@@ -12075,15 +18208,13 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "summary": "Begin passkey enrollment",
+                "summary": "List cloud credentials",
                 "tags": [
-                    "auth"
+                    "cloud"
                 ]
-            }
-        },
-        "/api/v1/auth/mfa/passkeys/verify": {
+            },
             "post": {
-                "description": "Verify a passkey enrollment response and attach the new passkey to the authenticated account.",
+                "description": "Authenticate with a cloud provider and save a user-owned, repo-reusable credential. Provider-specific challenges return auth_status=challenge_required.",
                 "requestBody": {
                     "content": {
                         "application/json": {
@@ -12093,15 +18224,15 @@ const docTemplate = `{
                                         "type": "object"
                                     },
                                     {
-                                        "$ref": "#/components/schemas/dto.PasskeyVerifyRequestDTO",
+                                        "$ref": "#/components/schemas/dto.CreateCloudCredentialRequest",
                                         "summary": "request",
-                                        "description": "Passkey enrollment verification payload"
+                                        "description": "Cloud credential"
                                     }
                                 ]
                             }
                         }
                     },
-                    "description": "Passkey enrollment verification payload",
+                    "description": "Cloud credential",
                     "required": true
                 },
                 "responses": {
@@ -12109,11 +18240,11 @@ const docTemplate = `{
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/dto.PasskeyCredentialSummaryDTO"
+                                    "$ref": "#/components/schemas/dto.CreateCloudCredentialResponse"
                                 }
                             }
                         },
-                        "description": "Passkey enrolled successfully"
+                        "description": "Credential creation result"
                     },
                     "400": {
                         "content": {
@@ -12123,7 +18254,7 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Invalid or expired challenge"
+                        "description": "Invalid request"
                     },
                     "401": {
                         "content": {
@@ -12135,6 +18266,16 @@ const docTemplate = `{
                         },
                         "description": "Unauthorized"
                     },
+                    "403": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Credential belongs to another user"
+                    },
                     "500": {
                         "content": {
                             "application/json": {
@@ -12151,35 +18292,26 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "summary": "Verify passkey enrollment",
+                "summary": "Create cloud credential",
                 "tags": [
-                    "auth"
+                    "cloud"
                 ]
             }
         },
-        "/api/v1/auth/mfa/passkeys/{id}": {
+        "/api/v1/cloud/credentials/{id}": {
             "delete": {
-                "description": "Delete one enrolled passkey for the authenticated user.",
+                "description": "Permanently delete a cloud credential, its session data, and unbind associated repositories.",
                 "parameters": [
                     {
-                        "description": "Passkey ID",
+                        "description": "Credential UUID",
                         "in": "path",
                         "name": "id",
                         "required": true,
                         "schema": {
-                            "type": "integer"
+                            "type": "string"
                         }
                     }
                 ],
-                "requestBody": {
-                    "content": {
-                        "application/json": {
-                            "schema": {
-                                "type": "object"
-                            }
-                        }
-                    }
-                },
                 "responses": {
                     "200": {
                         "content": {
@@ -12189,85 +18321,19 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Passkey deleted successfully"
-                    },
-                    "401": {
-                        "content": {
-                            "application/json": {
-                                "schema": {
-                                    "$ref": "#/components/schemas/api.ErrorResponse"
-                                }
-                            }
-                        },
-                        "description": "Unauthorized"
-                    },
-                    "404": {
-                        "content": {
-                            "application/json": {
-                                "schema": {
-                                    "$ref": "#/components/schemas/api.ErrorResponse"
-                                }
-                            }
-                        },
-                        "description": "Passkey not found"
-                    },
-                    "500": {
-                        "content": {
-                            "application/json": {
-                                "schema": {
-                                    "$ref": "#/components/schemas/api.ErrorResponse"
-                                }
-                            }
-                        },
-                        "description": "Internal server error"
-                    }
-                },
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "summary": "Delete passkey",
-                "tags": [
-                    "auth"
-                ]
-            }
-        },
-        "/api/v1/auth/mfa/recovery-codes/regenerate": {
-            "post": {
-                "description": "Generate a fresh set of recovery codes for the authenticated user.",
-                "requestBody": {
-                    "content": {
-                        "application/json": {
-                            "schema": {
-                                "oneOf": [
-                                    {
-                                        "type": "object"
-                                    },
-                                    {
-                                        "$ref": "#/components/schemas/dto.RegenerateRecoveryCodesRequestDTO",
-                                        "summary": "request",
-                                        "description": "Recovery code regeneration payload"
-                                    }
-                                ]
-                            }
-                        }
-                    },
-                    "description": "Recovery code regeneration payload",
-                    "required": true
-                },
-                "responses": {
-                    "200": {
+                        "description": "Credential removed"
+                    },
+                    "400": {
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/dto.RecoveryCodesResponseDTO"
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
                                 }
                             }
                         },
-                        "description": "Recovery codes regenerated successfully"
+                        "description": "Invalid request"
                     },
-                    "400": {
+                    "401": {
                         "content": {
                             "application/json": {
                                 "schema": {
@@ -12275,9 +18341,9 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "MFA is not enabled"
+                        "description": "Unauthorized"
                     },
-                    "401": {
+                    "403": {
                         "content": {
                             "application/json": {
                                 "schema": {
@@ -12285,7 +18351,7 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Unauthorized or incorrect password"
+                        "description": "Credential belongs to another user"
                     },
                     "500": {
                         "content": {
@@ -12303,15 +18369,26 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "summary": "Regenerate recovery codes",
+                "summary": "Remove cloud credential",
                 "tags": [
-                    "auth"
+                    "cloud"
                 ]
             }
         },
-        "/api/v1/auth/mfa/totp/disable": {
+        "/api/v1/cloud/credentials/{id}/auth-challenge": {
             "post": {
-                "description": "Disable TOTP MFA and invalidate recovery codes for the authenticated user.",
+                "description": "Submit challenge inputs to complete cloud credential creation.",
+                "parameters": [
+                    {
+                        "description": "Credential UUID",
+                        "in": "path",
+                        "name": "id",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
                 "requestBody": {
                     "content": {
                         "application/json": {
@@ -12321,15 +18398,15 @@ const docTemplate = `{
                                         "type": "object"
                                     },
                                     {
-                                        "$ref": "#/components/schemas/dto.DisableTOTPRequestDTO",
+                                        "$ref": "#/components/schemas/dto.VerifyCloudAuthChallengeRequest",
                                         "summary": "request",
-                                        "description": "Disable TOTP payload"
+                                        "description": "Challenge inputs"
                                     }
                                 ]
                             }
                         }
                     },
-                    "description": "Disable TOTP payload",
+                    "description": "Challenge inputs",
                     "required": true
                 },
                 "responses": {
@@ -12337,11 +18414,11 @@ const docTemplate = `{
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/dto.MFAStatusDTO"
+                                    "$ref": "#/components/schemas/dto.VerifyCloudAuthChallengeResponse"
                                 }
                             }
                         },
-                        "description": "TOTP disabled successfully"
+                        "description": "Challenge verified successfully"
                     },
                     "400": {
                         "content": {
@@ -12351,7 +18428,7 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "MFA is not enabled"
+                        "description": "Invalid request"
                     },
                     "401": {
                         "content": {
@@ -12361,7 +18438,17 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Unauthorized or incorrect password"
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Credential belongs to another user"
                     },
                     "500": {
                         "content": {
@@ -12379,45 +18466,36 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "summary": "Disable TOTP",
+                "summary": "Verify cloud credential challenge",
                 "tags": [
-                    "auth"
+                    "cloud"
                 ]
             }
         },
-        "/api/v1/auth/mfa/totp/enable": {
+        "/api/v1/cloud/credentials/{id}/disconnect": {
             "post": {
-                "description": "Verify a TOTP setup code and enable TOTP MFA for the authenticated user.",
-                "requestBody": {
-                    "content": {
-                        "application/json": {
-                            "schema": {
-                                "oneOf": [
-                                    {
-                                        "type": "object"
-                                    },
-                                    {
-                                        "$ref": "#/components/schemas/dto.EnableTOTPRequestDTO",
-                                        "summary": "request",
-                                        "description": "TOTP enable payload"
-                                    }
-                                ]
-                            }
+                "description": "Pause a cloud credential so it cannot start new imports. Can be reconnected later.",
+                "parameters": [
+                    {
+                        "description": "Credential UUID",
+                        "in": "path",
+                        "name": "id",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
                         }
-                    },
-                    "description": "TOTP enable payload",
-                    "required": true
-                },
+                    }
+                ],
                 "responses": {
                     "200": {
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/dto.RecoveryCodesResponseDTO"
+                                    "$ref": "#/components/schemas/api.SuccessResponse"
                                 }
                             }
                         },
-                        "description": "TOTP enabled successfully"
+                        "description": "Credential disconnected"
                     },
                     "400": {
                         "content": {
@@ -12427,7 +18505,7 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Invalid setup token or verification code"
+                        "description": "Invalid request"
                     },
                     "401": {
                         "content": {
@@ -12439,6 +18517,16 @@ const docTemplate = `{
                         },
                         "description": "Unauthorized"
                     },
+                    "403": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Credential belongs to another user"
+                    },
                     "500": {
                         "content": {
                             "application/json": {
@@ -12455,34 +18543,66 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "summary": "Enable TOTP",
+                "summary": "Disconnect cloud credential",
                 "tags": [
-                    "auth"
+                    "cloud"
                 ]
             }
         },
-        "/api/v1/auth/mfa/totp/setup": {
+        "/api/v1/cloud/credentials/{id}/reconnect": {
             "post": {
-                "description": "Generate a new TOTP secret and setup token for the authenticated user.",
+                "description": "Re-authenticate a disconnected or errored credential. If no password is provided, attempts to reuse the existing session.",
+                "parameters": [
+                    {
+                        "description": "Credential UUID",
+                        "in": "path",
+                        "name": "id",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
                 "requestBody": {
                     "content": {
                         "application/json": {
                             "schema": {
-                                "type": "object"
+                                "oneOf": [
+                                    {
+                                        "type": "object"
+                                    },
+                                    {
+                                        "$ref": "#/components/schemas/dto.ReconnectCloudCredentialRequest",
+                                        "summary": "request",
+                                        "description": "Reconnect inputs"
+                                    }
+                                ]
                             }
                         }
-                    }
+                    },
+                    "description": "Reconnect inputs",
+                    "required": true
                 },
                 "responses": {
                     "200": {
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/dto.TOTPSetupResponseDTO"
+                                    "$ref": "#/components/schemas/dto.CreateCloudCredentialResponse"
                                 }
                             }
                         },
-                        "description": "TOTP setup created successfully"
+                        "description": "Reconnect result"
+                    },
+                    "400": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Invalid request"
                     },
                     "401": {
                         "content": {
@@ -12494,6 +18614,16 @@ const docTemplate = `{
                         },
                         "description": "Unauthorized"
                     },
+                    "403": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Credential belongs to another user"
+                    },
                     "500": {
                         "content": {
                             "application/json": {
@@ -12510,45 +18640,36 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "summary": "Begin TOTP setup",
+                "summary": "Reconnect cloud credential",
                 "tags": [
-                    "auth"
+                    "cloud"
                 ]
             }
         },
-        "/api/v1/auth/mfa/verify": {
-            "post": {
-                "description": "Verify a pending MFA login challenge with a TOTP code or recovery code.",
-                "requestBody": {
-                    "content": {
-                        "application/json": {
-                            "schema": {
-                                "oneOf": [
-                                    {
-                                        "type": "object"
-                                    },
-                                    {
-                                        "$ref": "#/components/schemas/dto.VerifyMFARequestDTO",
-                                        "summary": "request",
-                                        "description": "MFA verification payload"
-                                    }
-                                ]
-                            }
+        "/api/v1/cloud/import-runs/{id}": {
+            "get": {
+                "description": "Return a cloud import run by ID.",
+                "parameters": [
+                    {
+                        "description": "Import run UUID",
+                        "in": "path",
+                        "name": "id",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
                         }
-                    },
-                    "description": "MFA verification payload",
-                    "required": true
-                },
+                    }
+                ],
                 "responses": {
                     "200": {
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/dto.AuthResponseDTO"
+                                    "$ref": "#/components/schemas/dto.CloudImportRunDTO"
                                 }
                             }
                         },
-                        "description": "MFA verification successful"
+                        "description": "Import run"
                     },
                     "400": {
                         "content": {
@@ -12558,7 +18679,7 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Invalid request data"
+                        "description": "Invalid request"
                     },
                     "401": {
                         "content": {
@@ -12568,7 +18689,17 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Invalid or expired MFA challenge"
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Import run belongs to another user"
                     },
                     "500": {
                         "content": {
@@ -12581,47 +18712,32 @@ const docTemplate = `{
                         "description": "Internal server error"
                     }
                 },
-                "summary": "Verify MFA challenge",
-                "tags": [
-                    "auth"
-                ]
-            }
-        },
-        "/api/v1/auth/passkeys/login/options": {
-            "post": {
-                "description": "Create WebAuthn login options for a username-first passkey login flow.",
-                "requestBody": {
-                    "content": {
-                        "application/json": {
-                            "schema": {
-                                "oneOf": [
-                                    {
-                                        "type": "object"
-                                    },
-                                    {
-                                        "$ref": "#/components/schemas/dto.PasskeyOptionsRequestDTO",
-                                        "summary": "request",
-                                        "description": "Username for passkey login"
-                                    }
-                                ]
-                            }
-                        }
-                    },
-                    "description": "Username for passkey login",
-                    "required": true
-                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "summary": "Get cloud import run",
+                "tags": [
+                    "cloud"
+                ]
+            }
+        },
+        "/api/v1/cloud/providers": {
+            "get": {
+                "description": "List cloud provider descriptors for credential creation.",
                 "responses": {
                     "200": {
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/dto.PasskeyOptionsResponseDTO"
+                                    "$ref": "#/components/schemas/dto.ListCloudProvidersResponse"
                                 }
                             }
                         },
-                        "description": "Passkey login options created successfully"
+                        "description": "Provider list"
                     },
-                    "400": {
+                    "401": {
                         "content": {
                             "application/json": {
                                 "schema": {
@@ -12629,9 +18745,9 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Invalid request data"
+                        "description": "Unauthorized"
                     },
-                    "401": {
+                    "500": {
                         "content": {
                             "application/json": {
                                 "schema": {
@@ -12639,9 +18755,25 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Invalid credentials"
-                    },
-                    "500": {
+                        "description": "Internal server error"
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "summary": "List cloud providers",
+                "tags": [
+                    "cloud"
+                ]
+            }
+        },
+        "/api/v1/cloud/sync": {
+            "post": {
+                "description": "Deprecated. Use repo-scoped cloud import endpoints instead.",
+                "responses": {
+                    "400": {
                         "content": {
                             "application/json": {
                                 "schema": {
@@ -12649,18 +18781,23 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Internal server error"
+                        "description": "Deprecated endpoint"
                     }
                 },
-                "summary": "Begin passkey login",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "summary": "Deprecated cloud sync endpoint",
                 "tags": [
-                    "auth"
+                    "cloud"
                 ]
             }
         },
-        "/api/v1/auth/passkeys/login/verify": {
+        "/api/v1/duplicates/detect": {
             "post": {
-                "description": "Verify a passkey login assertion and issue session tokens.",
+                "description": "Rebuilds the pending duplicate graph for a repository by combining exact-hash and pHash edges.",
                 "requestBody": {
                     "content": {
                         "application/json": {
@@ -12670,15 +18807,15 @@ const docTemplate = `{
                                         "type": "object"
                                     },
                                     {
-                                        "$ref": "#/components/schemas/dto.PasskeyVerifyRequestDTO",
+                                        "$ref": "#/components/schemas/dto.DetectDuplicatesRequestDTO",
                                         "summary": "request",
-                                        "description": "Passkey login verification payload"
+                                        "description": "Repository to scan"
                                     }
                                 ]
                             }
                         }
                     },
-                    "description": "Passkey login verification payload",
+                    "description": "Repository to scan",
                     "required": true
                 },
                 "responses": {
@@ -12686,11 +18823,11 @@ const docTemplate = `{
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/dto.AuthResponseDTO"
+                                    "$ref": "#/components/schemas/dto.DetectDuplicatesResponseDTO"
                                 }
                             }
                         },
-                        "description": "Passkey login verified successfully"
+                        "description": "OK"
                     },
                     "400": {
                         "content": {
@@ -12700,17 +18837,7 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Invalid request data"
-                    },
-                    "401": {
-                        "content": {
-                            "application/json": {
-                                "schema": {
-                                    "$ref": "#/components/schemas/api.ErrorResponse"
-                                }
-                            }
-                        },
-                        "description": "Invalid credentials"
+                        "description": "Bad Request"
                     },
                     "500": {
                         "content": {
@@ -12720,48 +18847,73 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Internal server error"
+                        "description": "Internal Server Error"
                     }
                 },
-                "summary": "Verify passkey login",
+                "summary": "Detect duplicates for a repository",
                 "tags": [
-                    "auth"
+                    "duplicates"
                 ]
             }
         },
-        "/api/v1/auth/password-change/complete": {
-            "post": {
-                "description": "Replace a temporary reset password using the short-lived token returned by login.",
+        "/api/v1/duplicates/groups": {
+            "get": {
+                "description": "Paginated list of duplicate groups, scoped by repository and status (default pending).",
+                "parameters": [
+                    {
+                        "description": "Repository UUID",
+                        "in": "query",
+                        "name": "repository_id",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "pending | merged | dismissed (defaults to pending)",
+                        "in": "query",
+                        "name": "status",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Page size",
+                        "in": "query",
+                        "name": "limit",
+                        "schema": {
+                            "default": 20,
+                            "type": "integer"
+                        }
+                    },
+                    {
+                        "description": "Page offset",
+                        "in": "query",
+                        "name": "offset",
+                        "schema": {
+                            "default": 0,
+                            "type": "integer"
+                        }
+                    }
+                ],
                 "requestBody": {
                     "content": {
                         "application/json": {
                             "schema": {
-                                "oneOf": [
-                                    {
-                                        "type": "object"
-                                    },
-                                    {
-                                        "$ref": "#/components/schemas/dto.CompleteRequiredPasswordChangeRequestDTO",
-                                        "summary": "request",
-                                        "description": "Password change payload"
-                                    }
-                                ]
+                                "type": "object"
                             }
                         }
-                    },
-                    "description": "Password change payload",
-                    "required": true
+                    }
                 },
                 "responses": {
                     "200": {
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/dto.AuthResponseDTO"
+                                    "$ref": "#/components/schemas/dto.ListDuplicateGroupsResponseDTO"
                                 }
                             }
                         },
-                        "description": "Password changed and session issued"
+                        "description": "OK"
                     },
                     "400": {
                         "content": {
@@ -12771,17 +18923,7 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Invalid password"
-                    },
-                    "401": {
-                        "content": {
-                            "application/json": {
-                                "schema": {
-                                    "$ref": "#/components/schemas/api.ErrorResponse"
-                                }
-                            }
-                        },
-                        "description": "Invalid or expired password change token"
+                        "description": "Bad Request"
                     },
                     "500": {
                         "content": {
@@ -12791,50 +18933,50 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Internal server error"
+                        "description": "Internal Server Error"
                     }
                 },
-                "summary": "Complete required password change",
+                "summary": "List duplicate groups",
                 "tags": [
-                    "auth"
+                    "duplicates"
                 ]
             }
         },
-        "/api/v1/auth/refresh": {
-            "post": {
-                "description": "Generate a new access token using a valid refresh token",
+        "/api/v1/duplicates/groups/{id}": {
+            "get": {
+                "description": "Returns one duplicate group with all assets and evidence edges.",
+                "parameters": [
+                    {
+                        "description": "Duplicate group UUID",
+                        "in": "path",
+                        "name": "id",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
                 "requestBody": {
                     "content": {
                         "application/json": {
                             "schema": {
-                                "oneOf": [
-                                    {
-                                        "type": "object"
-                                    },
-                                    {
-                                        "$ref": "#/components/schemas/dto.RefreshTokenRequestDTO",
-                                        "summary": "request",
-                                        "description": "Refresh token"
-                                    }
-                                ]
+                                "type": "object"
                             }
                         }
-                    },
-                    "description": "Refresh token",
-                    "required": true
+                    }
                 },
                 "responses": {
                     "200": {
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/dto.AuthResponseDTO"
+                                    "$ref": "#/components/schemas/dto.DuplicateGroupDTO"
                                 }
                             }
                         },
-                        "description": "Token refreshed successfully"
+                        "description": "OK"
                     },
-                    "400": {
+                    "404": {
                         "content": {
                             "application/json": {
                                 "schema": {
@@ -12842,9 +18984,50 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Invalid request data"
+                        "description": "Not Found"
+                    }
+                },
+                "summary": "Get a duplicate group",
+                "tags": [
+                    "duplicates"
+                ]
+            }
+        },
+        "/api/v1/duplicates/groups/{id}/dismiss": {
+            "post": {
+                "description": "Marks a duplicate group as dismissed without merging any assets.",
+                "parameters": [
+                    {
+                        "description": "Duplicate group UUID",
+                        "in": "path",
+                        "name": "id",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "requestBody": {
+                    "content": {
+                        "application/json": {
+                            "schema": {
+                                "type": "object"
+                            }
+                        }
+                    }
+                },
+                "responses": {
+                    "200": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/dto.MessageResponseDTO"
+                                }
+                            }
+                        },
+                        "description": "OK"
                     },
-                    "401": {
+                    "404": {
                         "content": {
                             "application/json": {
                                 "schema": {
@@ -12852,9 +19035,9 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Invalid or expired refresh token"
+                        "description": "Not Found"
                     },
-                    "500": {
+                    "409": {
                         "content": {
                             "application/json": {
                                 "schema": {
@@ -12862,18 +19045,29 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Internal server error"
+                        "description": "Conflict"
                     }
                 },
-                "summary": "Refresh access token",
+                "summary": "Dismiss a duplicate group",
                 "tags": [
-                    "auth"
+                    "duplicates"
                 ]
             }
         },
-        "/api/v1/auth/register/start": {
+        "/api/v1/duplicates/groups/{id}/merge": {
             "post": {
-                "description": "Create a new account from a username and password and issue session tokens. MFA (TOTP/passkey) is optional and added afterwards.",
+                "description": "Keeps the chosen asset, unions metadata from duplicates, and soft-deletes the remaining members.",
+                "parameters": [
+                    {
+                        "description": "Duplicate group UUID",
+                        "in": "path",
+                        "name": "id",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
                 "requestBody": {
                     "content": {
                         "application/json": {
@@ -12883,15 +19077,15 @@ const docTemplate = `{
                                         "type": "object"
                                     },
                                     {
-                                        "$ref": "#/components/schemas/dto.RegistrationStartRequestDTO",
+                                        "$ref": "#/components/schemas/dto.MergeDuplicateGroupRequestDTO",
                                         "summary": "request",
-                                        "description": "Registration data"
+                                        "description": "Merge configuration"
                                     }
                                 ]
                             }
                         }
                     },
-                    "description": "Registration data",
+                    "description": "Merge configuration",
                     "required": true
                 },
                 "responses": {
@@ -12899,11 +19093,11 @@ const docTemplate = `{
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/dto.AuthResponseDTO"
+                                    "$ref": "#/components/schemas/dto.MergeDuplicateGroupResponseDTO"
                                 }
                             }
                         },
-                        "description": "Account created successfully"
+                        "description": "OK"
                     },
                     "400": {
                         "content": {
@@ -12913,7 +19107,17 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Invalid request data"
+                        "description": "Bad Request"
+                    },
+                    "404": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Not Found"
                     },
                     "409": {
                         "content": {
@@ -12923,7 +19127,7 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "User already exists"
+                        "description": "Conflict"
                     },
                     "500": {
                         "content": {
@@ -12933,18 +19137,28 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Internal server error"
+                        "description": "Internal Server Error"
                     }
                 },
-                "summary": "Register a new account",
+                "summary": "Merge a duplicate group",
                 "tags": [
-                    "auth"
+                    "duplicates"
                 ]
             }
         },
-        "/api/v1/capabilities": {
+        "/api/v1/duplicates/summary": {
             "get": {
-                "description": "Return a de-sensitized view of backend ML and LLM runtime capabilities without exposing secrets.",
+                "description": "Returns counts and recoverable space for pending duplicate groups, scoped by optional repository_id.",
+                "parameters": [
+                    {
+                        "description": "Repository UUID to scope the summary",
+                        "in": "query",
+                        "name": "repository_id",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
                 "requestBody": {
                     "content": {
                         "application/json": {
@@ -12959,52 +19173,71 @@ const docTemplate = `{
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/dto.CapabilitiesResponseDTO"
+                                    "$ref": "#/components/schemas/dto.DuplicateSummaryDTO"
                                 }
                             }
                         },
-                        "description": "Capabilities retrieved successfully"
+                        "description": "OK"
+                    },
+                    "400": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Bad Request"
+                    },
+                    "500": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Internal Server Error"
                     }
                 },
-                "summary": "Get public runtime capabilities",
+                "summary": "Get duplicate detection summary",
                 "tags": [
-                    "capabilities"
+                    "duplicates"
                 ]
             }
         },
-        "/api/v1/classifiers/preview": {
-            "post": {
-                "description": "Embed positive/negative prompts with semantic and return library assets whose contrastive score exceeds the threshold. Used to tune prompts and thresholds before persisting a smart album. Requires the semantic embedding pipeline and a reachable semantic text-embed task.",
+        "/api/v1/health": {
+            "get": {
+                "description": "Check if the server is healthy. With deep=true, also validate every repository's directory structure and sync recency; any unhealthy repository makes the response degraded.",
+                "parameters": [
+                    {
+                        "description": "Include per-repository structure and sync checks",
+                        "in": "query",
+                        "name": "deep",
+                        "schema": {
+                            "type": "boolean"
+                        }
+                    }
+                ],
                 "requestBody": {
                     "content": {
                         "application/json": {
                             "schema": {
-                                "oneOf": [
-                                    {
-                                        "type": "object"
-                                    },
-                                    {
-                                        "$ref": "#/components/schemas/dto.ClassifierPreviewRequestDTO",
-                                        "summary": "request",
-                                        "description": "Prompts and threshold"
-                                    }
-                                ]
+                                "type": "object"
                             }
                         }
-                    },
-                    "description": "Prompts and threshold",
-                    "required": true
+                    }
                 },
                 "responses": {
                     "200": {
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/dto.ClassifierPreviewResponseDTO"
+                                    "$ref": "#/components/schemas/handler.HealthResponse"
                                 }
                             }
                         },
-                        "description": "Preview matches retrieved successfully"
+                        "description": "Server is healthy"
                     },
                     "400": {
                         "content": {
@@ -13014,65 +19247,85 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Invalid request data"
-                    },
-                    "401": {
-                        "content": {
-                            "application/json": {
-                                "schema": {
-                                    "$ref": "#/components/schemas/api.ErrorResponse"
-                                }
-                            }
-                        },
-                        "description": "Unauthorized"
-                    },
-                    "500": {
-                        "content": {
-                            "application/json": {
-                                "schema": {
-                                    "$ref": "#/components/schemas/api.ErrorResponse"
-                                }
-                            }
-                        },
-                        "description": "Internal server error"
+                        "description": "Invalid deep flag"
                     },
                     "503": {
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                    "$ref": "#/components/schemas/handler.HealthResponse"
                                 }
                             }
                         },
-                        "description": "Classification unavailable"
+                        "description": "A deep check found an unhealthy repository"
                     }
                 },
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "summary": "Preview a zero-shot classifier",
+                "summary": "Health check",
                 "tags": [
-                    "classifiers"
+                    "Health"
                 ]
             }
         },
-        "/api/v1/cloud/credentials": {
+        "/api/v1/locations/clusters": {
             "get": {
-                "description": "List the current user's cloud credentials without exposing secrets. Administrators receive all credentials.",
+                "description": "Return paginated persisted photo location clusters with cached labels when available.",
+                "parameters": [
+                    {
+                        "description": "Page size (1-1000)",
+                        "in": "query",
+                        "name": "limit",
+                        "schema": {
+                            "default": 100,
+                            "type": "integer"
+                        }
+                    },
+                    {
+                        "description": "Page offset",
+                        "in": "query",
+                        "name": "offset",
+                        "schema": {
+                            "default": 0,
+                            "type": "integer"
+                        }
+                    },
+                    {
+                        "description": "Optional repository UUID filter",
+                        "in": "query",
+                        "name": "repository_id",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Optional geohash filter",
+                        "in": "query",
+                        "name": "geohash",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "requestBody": {
+                    "content": {
+                        "application/json": {
+                            "schema": {
+                                "type": "object"
+                            }
+                        }
+                    }
+                },
                 "responses": {
                     "200": {
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/dto.ListCloudCredentialsResponse"
+                                    "$ref": "#/components/schemas/dto.LocationClusterListResponseDTO"
                                 }
                             }
                         },
-                        "description": "Credential list"
+                        "description": "Location clusters retrieved successfully"
                     },
-                    "401": {
+                    "400": {
                         "content": {
                             "application/json": {
                                 "schema": {
@@ -13080,7 +19333,7 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Unauthorized"
+                        "description": "Invalid request parameters"
                     },
                     "500": {
                         "content": {
@@ -13093,18 +19346,15 @@ const docTemplate = `{
                         "description": "Internal server error"
                     }
                 },
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "summary": "List cloud credentials",
+                "summary": "Get location clusters",
                 "tags": [
-                    "cloud"
+                    "locations"
                 ]
-            },
+            }
+        },
+        "/api/v1/locations/rebuild": {
             "post": {
-                "description": "Authenticate with a cloud provider and save a user-owned, repo-reusable credential. Provider-specific challenges return auth_status=challenge_required.",
+                "description": "Queue a location cluster rebuild for all photos or one repository.",
                 "requestBody": {
                     "content": {
                         "application/json": {
@@ -13114,49 +19364,28 @@ const docTemplate = `{
                                         "type": "object"
                                     },
                                     {
-                                        "$ref": "#/components/schemas/dto.CreateCloudCredentialRequest",
+                                        "$ref": "#/components/schemas/dto.RebuildLocationClustersRequestDTO",
                                         "summary": "request",
-                                        "description": "Cloud credential"
+                                        "description": "Rebuild request"
                                     }
                                 ]
                             }
-                        }
-                    },
-                    "description": "Cloud credential",
-                    "required": true
-                },
-                "responses": {
-                    "200": {
-                        "content": {
-                            "application/json": {
-                                "schema": {
-                                    "$ref": "#/components/schemas/dto.CreateCloudCredentialResponse"
-                                }
-                            }
-                        },
-                        "description": "Credential creation result"
-                    },
-                    "400": {
-                        "content": {
-                            "application/json": {
-                                "schema": {
-                                    "$ref": "#/components/schemas/api.ErrorResponse"
-                                }
-                            }
-                        },
-                        "description": "Invalid request"
+                        }
                     },
-                    "401": {
+                    "description": "Rebuild request"
+                },
+                "responses": {
+                    "200": {
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                    "$ref": "#/components/schemas/dto.RebuildLocationClustersResponseDTO"
                                 }
                             }
                         },
-                        "description": "Unauthorized"
+                        "description": "Location cluster rebuild queued successfully"
                     },
-                    "403": {
+                    "400": {
                         "content": {
                             "application/json": {
                                 "schema": {
@@ -13164,7 +19393,7 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Credential belongs to another user"
+                        "description": "Invalid request body"
                     },
                     "500": {
                         "content": {
@@ -13177,29 +19406,50 @@ const docTemplate = `{
                         "description": "Internal server error"
                     }
                 },
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "summary": "Create cloud credential",
+                "summary": "Queue location cluster rebuild",
                 "tags": [
-                    "cloud"
+                    "locations"
                 ]
             }
         },
-        "/api/v1/cloud/credentials/{id}": {
-            "delete": {
-                "description": "Permanently delete a cloud credential, its session data, and unbind associated repositories.",
+        "/api/v1/people": {
+            "get": {
+                "description": "List recognized people for the current repository scope.",
                 "parameters": [
                     {
-                        "description": "Credential UUID",
-                        "in": "path",
-                        "name": "id",
-                        "required": true,
+                        "description": "Optional repository UUID filter",
+                        "in": "query",
+                        "name": "repository_id",
                         "schema": {
                             "type": "string"
                         }
+                    },
+                    {
+                        "description": "Include people hidden from the default grid",
+                        "in": "query",
+                        "name": "include_hidden",
+                        "schema": {
+                            "default": false,
+                            "type": "boolean"
+                        }
+                    },
+                    {
+                        "description": "Maximum number of results (max 100)",
+                        "in": "query",
+                        "name": "limit",
+                        "schema": {
+                            "default": 24,
+                            "type": "integer"
+                        }
+                    },
+                    {
+                        "description": "Number of results to skip",
+                        "in": "query",
+                        "name": "offset",
+                        "schema": {
+                            "default": 0,
+                            "type": "integer"
+                        }
                     }
                 ],
                 "responses": {
@@ -13207,11 +19457,11 @@ const docTemplate = `{
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/api.SuccessResponse"
+                                    "$ref": "#/components/schemas/dto.ListPeopleResponseDTO"
                                 }
                             }
                         },
-                        "description": "Credential removed"
+                        "description": "People listed successfully"
                     },
                     "400": {
                         "content": {
@@ -13221,27 +19471,7 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Invalid request"
-                    },
-                    "401": {
-                        "content": {
-                            "application/json": {
-                                "schema": {
-                                    "$ref": "#/components/schemas/api.ErrorResponse"
-                                }
-                            }
-                        },
-                        "description": "Unauthorized"
-                    },
-                    "403": {
-                        "content": {
-                            "application/json": {
-                                "schema": {
-                                    "$ref": "#/components/schemas/api.ErrorResponse"
-                                }
-                            }
-                        },
-                        "description": "Credential belongs to another user"
+                        "description": "Invalid request parameters"
                     },
                     "500": {
                         "content": {
@@ -13254,61 +19484,35 @@ const docTemplate = `{
                         "description": "Internal server error"
                     }
                 },
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "summary": "Remove cloud credential",
+                "summary": "List people",
                 "tags": [
-                    "cloud"
+                    "people"
                 ]
             }
         },
-        "/api/v1/cloud/credentials/{id}/auth-challenge": {
+        "/api/v1/people/rebuild": {
             "post": {
-                "description": "Submit challenge inputs to complete cloud credential creation.",
+                "description": "Rebuild recognized people for the selected repository scope using HDBSCAN over face embeddings.",
                 "parameters": [
                     {
-                        "description": "Credential UUID",
-                        "in": "path",
-                        "name": "id",
-                        "required": true,
+                        "description": "Optional repository UUID filter",
+                        "in": "query",
+                        "name": "repository_id",
                         "schema": {
                             "type": "string"
                         }
                     }
                 ],
-                "requestBody": {
-                    "content": {
-                        "application/json": {
-                            "schema": {
-                                "oneOf": [
-                                    {
-                                        "type": "object"
-                                    },
-                                    {
-                                        "$ref": "#/components/schemas/dto.VerifyCloudAuthChallengeRequest",
-                                        "summary": "request",
-                                        "description": "Challenge inputs"
-                                    }
-                                ]
-                            }
-                        }
-                    },
-                    "description": "Challenge inputs",
-                    "required": true
-                },
                 "responses": {
                     "200": {
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/dto.VerifyCloudAuthChallengeResponse"
+                                    "$ref": "#/components/schemas/dto.FaceClusterRebuildResponseDTO"
                                 }
                             }
                         },
-                        "description": "Challenge verified successfully"
+                        "description": "People clusters rebuilt successfully"
                     },
                     "400": {
                         "content": {
@@ -13318,7 +19522,7 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Invalid request"
+                        "description": "Invalid request parameters"
                     },
                     "401": {
                         "content": {
@@ -13330,16 +19534,6 @@ const docTemplate = `{
                         },
                         "description": "Unauthorized"
                     },
-                    "403": {
-                        "content": {
-                            "application/json": {
-                                "schema": {
-                                    "$ref": "#/components/schemas/api.ErrorResponse"
-                                }
-                            }
-                        },
-                        "description": "Credential belongs to another user"
-                    },
                     "500": {
                         "content": {
                             "application/json": {
@@ -13356,21 +19550,29 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "summary": "Verify cloud credential challenge",
+                "summary": "Rebuild people clusters",
                 "tags": [
-                    "cloud"
+                    "people"
                 ]
             }
         },
-        "/api/v1/cloud/credentials/{id}/disconnect": {
-            "post": {
-                "description": "Pause a cloud credential so it cannot start new imports. Can be reconnected later.",
+        "/api/v1/people/{id}": {
+            "get": {
+                "description": "Get a single recognized person by cluster ID.",
                 "parameters": [
                     {
-                        "description": "Credential UUID",
+                        "description": "Person ID",
                         "in": "path",
                         "name": "id",
                         "required": true,
+                        "schema": {
+                            "type": "integer"
+                        }
+                    },
+                    {
+                        "description": "Optional repository UUID filter",
+                        "in": "query",
+                        "name": "repository_id",
                         "schema": {
                             "type": "string"
                         }
@@ -13381,11 +19583,11 @@ const docTemplate = `{
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/api.SuccessResponse"
+                                    "$ref": "#/components/schemas/dto.PersonDetailDTO"
                                 }
                             }
                         },
-                        "description": "Credential disconnected"
+                        "description": "Person fetched successfully"
                     },
                     "400": {
                         "content": {
@@ -13395,19 +19597,9 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Invalid request"
-                    },
-                    "401": {
-                        "content": {
-                            "application/json": {
-                                "schema": {
-                                    "$ref": "#/components/schemas/api.ErrorResponse"
-                                }
-                            }
-                        },
-                        "description": "Unauthorized"
+                        "description": "Invalid request parameters"
                     },
-                    "403": {
+                    "404": {
                         "content": {
                             "application/json": {
                                 "schema": {
@@ -13415,7 +19607,7 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Credential belongs to another user"
+                        "description": "Person not found"
                     },
                     "500": {
                         "content": {
@@ -13428,28 +19620,21 @@ const docTemplate = `{
                         "description": "Internal server error"
                     }
                 },
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "summary": "Disconnect cloud credential",
+                "summary": "Get person",
                 "tags": [
-                    "cloud"
+                    "people"
                 ]
-            }
-        },
-        "/api/v1/cloud/credentials/{id}/reconnect": {
-            "post": {
-                "description": "Re-authenticate a disconnected or errored credential. If no password is provided, attempts to reuse the existing session.",
+            },
+            "patch": {
+                "description": "Rename a recognized person. Successful updates mark the person as confirmed.",
                 "parameters": [
                     {
-                        "description": "Credential UUID",
+                        "description": "Person ID",
                         "in": "path",
                         "name": "id",
                         "required": true,
                         "schema": {
-                            "type": "string"
+                            "type": "integer"
                         }
                     }
                 ],
@@ -13462,15 +19647,15 @@ const docTemplate = `{
                                         "type": "object"
                                     },
                                     {
-                                        "$ref": "#/components/schemas/dto.ReconnectCloudCredentialRequest",
+                                        "$ref": "#/components/schemas/dto.UpdatePersonRequestDTO",
                                         "summary": "request",
-                                        "description": "Reconnect inputs"
+                                        "description": "Person update payload"
                                     }
                                 ]
                             }
                         }
                     },
-                    "description": "Reconnect inputs",
+                    "description": "Person update payload",
                     "required": true
                 },
                 "responses": {
@@ -13478,11 +19663,11 @@ const docTemplate = `{
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/dto.CreateCloudCredentialResponse"
+                                    "$ref": "#/components/schemas/dto.PersonDetailDTO"
                                 }
                             }
                         },
-                        "description": "Reconnect result"
+                        "description": "Person updated successfully"
                     },
                     "400": {
                         "content": {
@@ -13492,19 +19677,9 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Invalid request"
-                    },
-                    "401": {
-                        "content": {
-                            "application/json": {
-                                "schema": {
-                                    "$ref": "#/components/schemas/api.ErrorResponse"
-                                }
-                            }
-                        },
-                        "description": "Unauthorized"
+                        "description": "Invalid request parameters"
                     },
-                    "403": {
+                    "404": {
                         "content": {
                             "application/json": {
                                 "schema": {
@@ -13512,7 +19687,7 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Credential belongs to another user"
+                        "description": "Person not found"
                     },
                     "500": {
                         "content": {
@@ -13530,36 +19705,56 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "summary": "Reconnect cloud credential",
+                "summary": "Update person",
                 "tags": [
-                    "cloud"
+                    "people"
                 ]
             }
         },
-        "/api/v1/cloud/import-runs/{id}": {
-            "get": {
-                "description": "Return a cloud import run by ID.",
+        "/api/v1/people/{id}/assets/list": {
+            "post": {
+                "description": "List assets scoped to a specific person while reusing the unified asset query filters.",
                 "parameters": [
                     {
-                        "description": "Import run UUID",
+                        "description": "Person ID",
                         "in": "path",
                         "name": "id",
                         "required": true,
                         "schema": {
-                            "type": "string"
+                            "type": "integer"
+                        }
+                    }
+                ],
+                "requestBody": {
+                    "content": {
+                        "application/json": {
+                            "schema": {
+                                "oneOf": [
+                                    {
+                                        "type": "object"
+                                    },
+                                    {
+                                        "$ref": "#/components/schemas/dto.AssetQueryRequestDTO",
+                                        "summary": "request",
+                                        "description": "Asset query parameters"
+                                    }
+                                ]
+                            }
                         }
-                    }
-                ],
+                    },
+                    "description": "Asset query parameters",
+                    "required": true
+                },
                 "responses": {
                     "200": {
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/dto.CloudImportRunDTO"
+                                    "$ref": "#/components/schemas/dto.QueryAssetsResponseDTO"
                                 }
                             }
                         },
-                        "description": "Import run"
+                        "description": "Assets listed successfully"
                     },
                     "400": {
                         "content": {
@@ -13569,9 +19764,9 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Invalid request"
+                        "description": "Invalid request parameters"
                     },
-                    "401": {
+                    "404": {
                         "content": {
                             "application/json": {
                                 "schema": {
@@ -13579,9 +19774,9 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Unauthorized"
+                        "description": "Person not found"
                     },
-                    "403": {
+                    "500": {
                         "content": {
                             "application/json": {
                                 "schema": {
@@ -13589,9 +19784,9 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Import run belongs to another user"
+                        "description": "Internal server error"
                     },
-                    "500": {
+                    "503": {
                         "content": {
                             "application/json": {
                                 "schema": {
@@ -13599,37 +19794,61 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Internal server error"
+                        "description": "Semantic search unavailable"
                     }
                 },
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "summary": "Get cloud import run",
+                "summary": "List person assets",
                 "tags": [
-                    "cloud"
+                    "people"
                 ]
             }
         },
-        "/api/v1/cloud/providers": {
+        "/api/v1/people/{id}/cover": {
             "get": {
-                "description": "List cloud provider descriptors for credential creation.",
+                "description": "Serve the representative face crop image for a recognized person.",
+                "parameters": [
+                    {
+                        "description": "Person ID",
+                        "in": "path",
+                        "name": "id",
+                        "required": true,
+                        "schema": {
+                            "type": "integer"
+                        }
+                    },
+                    {
+                        "description": "Optional repository UUID filter",
+                        "in": "query",
+                        "name": "repository_id",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
                 "responses": {
                     "200": {
                         "content": {
-                            "application/json": {
+                            "image/webp": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/dto.ListCloudProvidersResponse"
+                                    "type": "file"
                                 }
                             }
                         },
-                        "description": "Provider list"
+                        "description": "Face crop"
+                    },
+                    "400": {
+                        "content": {
+                            "image/webp": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Invalid request parameters"
                     },
                     "401": {
                         "content": {
-                            "application/json": {
+                            "image/webp": {
                                 "schema": {
                                     "$ref": "#/components/schemas/api.ErrorResponse"
                                 }
@@ -13637,57 +19856,45 @@ const docTemplate = `{
                         },
                         "description": "Unauthorized"
                     },
-                    "500": {
+                    "404": {
                         "content": {
-                            "application/json": {
+                            "image/webp": {
                                 "schema": {
                                     "$ref": "#/components/schemas/api.ErrorResponse"
                                 }
                             }
                         },
-                        "description": "Internal server error"
-                    }
-                },
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "summary": "List cloud providers",
-                "tags": [
-                    "cloud"
-                ]
-            }
-        },
-        "/api/v1/cloud/sync": {
-            "post": {
-                "description": "Deprecated. Use repo-scoped cloud import endpoints instead.",
-                "responses": {
-                    "400": {
+                        "description": "Person cover not found"
+                    },
+                    "500": {
                         "content": {
-                            "application/json": {
+                            "image/webp": {
                                 "schema": {
                                     "$ref": "#/components/schemas/api.ErrorResponse"
                                 }
                             }
                         },
-                        "description": "Deprecated endpoint"
+                        "description": "Internal server error"
                     }
                 },
-                "security": [
+                "summary": "Get person cover",
+                "tags": [
+                    "people"
+                ]
+            },
+            "put": {
+                "description": "Set the representative cover face for a person. The face must belong to the person.",
+                "parameters": [
                     {
-                        "BearerAuth": []
+                        "description": "Person ID",
+                        "in": "path",
+                        "name": "id",
+                        "required": true,
+                        "schema": {
+                            "type": "integer"
+                        }
                     }
                 ],
-                "summary": "Deprecated cloud sync endpoint",
-                "tags": [
-                    "cloud"
-                ]
-            }
-        },
-        "/api/v1/duplicates/detect": {
-            "post": {
-                "description": "Rebuilds the pending duplicate graph for a repository by combining exact-hash and pHash edges.",
                 "requestBody": {
                     "content": {
                         "application/json": {
@@ -13697,15 +19904,15 @@ const docTemplate = `{
                                         "type": "object"
                                     },
                                     {
-                                        "$ref": "#/components/schemas/dto.DetectDuplicatesRequestDTO",
+                                        "$ref": "#/components/schemas/dto.SetPersonCoverRequestDTO",
                                         "summary": "request",
-                                        "description": "Repository to scan"
+                                        "description": "Cover payload"
                                     }
                                 ]
                             }
                         }
                     },
-                    "description": "Repository to scan",
+                    "description": "Cover payload",
                     "required": true
                 },
                 "responses": {
@@ -13713,11 +19920,11 @@ const docTemplate = `{
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/dto.DetectDuplicatesResponseDTO"
+                                    "$ref": "#/components/schemas/dto.PersonCorrectionResponseDTO"
                                 }
                             }
                         },
-                        "description": "OK"
+                        "description": "Cover updated successfully"
                     },
                     "400": {
                         "content": {
@@ -13727,7 +19934,17 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Bad Request"
+                        "description": "Invalid request parameters"
+                    },
+                    "404": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Person or face not found"
                     },
                     "500": {
                         "content": {
@@ -13737,46 +19954,52 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Internal Server Error"
+                        "description": "Internal server error"
                     }
                 },
-                "summary": "Detect duplicates for a repository",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "summary": "Set person cover",
                 "tags": [
-                    "duplicates"
+                    "people"
                 ]
             }
         },
-        "/api/v1/duplicates/groups": {
+        "/api/v1/people/{id}/faces": {
             "get": {
-                "description": "Paginated list of duplicate groups, scoped by repository and status (default pending).",
+                "description": "List the individual face crops assigned to a person for correction workflows.",
                 "parameters": [
                     {
-                        "description": "Repository UUID",
-                        "in": "query",
-                        "name": "repository_id",
+                        "description": "Person ID",
+                        "in": "path",
+                        "name": "id",
+                        "required": true,
                         "schema": {
-                            "type": "string"
+                            "type": "integer"
                         }
                     },
                     {
-                        "description": "pending | merged | dismissed (defaults to pending)",
+                        "description": "Optional repository UUID filter",
                         "in": "query",
-                        "name": "status",
+                        "name": "repository_id",
                         "schema": {
                             "type": "string"
                         }
                     },
                     {
-                        "description": "Page size",
+                        "description": "Maximum number of results (max 200)",
                         "in": "query",
                         "name": "limit",
                         "schema": {
-                            "default": 20,
+                            "default": 60,
                             "type": "integer"
                         }
                     },
                     {
-                        "description": "Page offset",
+                        "description": "Number of results to skip",
                         "in": "query",
                         "name": "offset",
                         "schema": {
@@ -13785,25 +20008,16 @@ const docTemplate = `{
                         }
                     }
                 ],
-                "requestBody": {
-                    "content": {
-                        "application/json": {
-                            "schema": {
-                                "type": "object"
-                            }
-                        }
-                    }
-                },
                 "responses": {
                     "200": {
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/dto.ListDuplicateGroupsResponseDTO"
+                                    "$ref": "#/components/schemas/dto.ListPersonFacesResponseDTO"
                                 }
                             }
                         },
-                        "description": "OK"
+                        "description": "Faces listed successfully"
                     },
                     "400": {
                         "content": {
@@ -13813,9 +20027,9 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Bad Request"
+                        "description": "Invalid request parameters"
                     },
-                    "500": {
+                    "404": {
                         "content": {
                             "application/json": {
                                 "schema": {
@@ -13823,50 +20037,9 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Internal Server Error"
-                    }
-                },
-                "summary": "List duplicate groups",
-                "tags": [
-                    "duplicates"
-                ]
-            }
-        },
-        "/api/v1/duplicates/groups/{id}": {
-            "get": {
-                "description": "Returns one duplicate group with all assets and evidence edges.",
-                "parameters": [
-                    {
-                        "description": "Duplicate group UUID",
-                        "in": "path",
-                        "name": "id",
-                        "required": true,
-                        "schema": {
-                            "type": "string"
-                        }
-                    }
-                ],
-                "requestBody": {
-                    "content": {
-                        "application/json": {
-                            "schema": {
-                                "type": "object"
-                            }
-                        }
-                    }
-                },
-                "responses": {
-                    "200": {
-                        "content": {
-                            "application/json": {
-                                "schema": {
-                                    "$ref": "#/components/schemas/dto.DuplicateGroupDTO"
-                                }
-                            }
-                        },
-                        "description": "OK"
+                        "description": "Person not found"
                     },
-                    "404": {
+                    "500": {
                         "content": {
                             "application/json": {
                                 "schema": {
@@ -13874,87 +20047,124 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Not Found"
+                        "description": "Internal server error"
                     }
                 },
-                "summary": "Get a duplicate group",
+                "summary": "List person faces",
                 "tags": [
-                    "duplicates"
+                    "people"
                 ]
             }
         },
-        "/api/v1/duplicates/groups/{id}/dismiss": {
-            "post": {
-                "description": "Marks a duplicate group as dismissed without merging any assets.",
+        "/api/v1/people/{id}/faces/{faceId}/crop": {
+            "get": {
+                "description": "Serve the face crop image for a single face belonging to a person.",
                 "parameters": [
                     {
-                        "description": "Duplicate group UUID",
+                        "description": "Person ID",
                         "in": "path",
                         "name": "id",
                         "required": true,
+                        "schema": {
+                            "type": "integer"
+                        }
+                    },
+                    {
+                        "description": "Face ID",
+                        "in": "path",
+                        "name": "faceId",
+                        "required": true,
+                        "schema": {
+                            "type": "integer"
+                        }
+                    },
+                    {
+                        "description": "Optional repository UUID filter",
+                        "in": "query",
+                        "name": "repository_id",
                         "schema": {
                             "type": "string"
                         }
                     }
                 ],
-                "requestBody": {
-                    "content": {
-                        "application/json": {
-                            "schema": {
-                                "type": "object"
-                            }
-                        }
-                    }
-                },
                 "responses": {
                     "200": {
                         "content": {
-                            "application/json": {
+                            "image/webp": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/dto.MessageResponseDTO"
+                                    "type": "file"
                                 }
                             }
                         },
-                        "description": "OK"
+                        "description": "Face crop"
+                    },
+                    "400": {
+                        "content": {
+                            "image/webp": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Invalid request parameters"
+                    },
+                    "401": {
+                        "content": {
+                            "image/webp": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Unauthorized"
                     },
                     "404": {
                         "content": {
-                            "application/json": {
+                            "image/webp": {
                                 "schema": {
                                     "$ref": "#/components/schemas/api.ErrorResponse"
                                 }
                             }
                         },
-                        "description": "Not Found"
+                        "description": "Face crop not found"
                     },
-                    "409": {
+                    "500": {
                         "content": {
-                            "application/json": {
+                            "image/webp": {
                                 "schema": {
                                     "$ref": "#/components/schemas/api.ErrorResponse"
                                 }
                             }
                         },
-                        "description": "Conflict"
+                        "description": "Internal server error"
                     }
                 },
-                "summary": "Dismiss a duplicate group",
+                "summary": "Get person face crop",
                 "tags": [
-                    "duplicates"
+                    "people"
                 ]
             }
         },
-        "/api/v1/duplicates/groups/{id}/merge": {
+        "/api/v1/people/{id}/faces/{faceId}/move": {
             "post": {
-                "description": "Keeps the chosen asset, unions metadata from duplicates, and soft-deletes the remaining members.",
+                "description": "Reassign a single face from this person to another person as a manual correction.",
                 "parameters": [
                     {
-                        "description": "Duplicate group UUID",
+                        "description": "Source person ID",
                         "in": "path",
                         "name": "id",
                         "required": true,
                         "schema": {
-                            "type": "string"
+                            "type": "integer"
+                        }
+                    },
+                    {
+                        "description": "Face ID",
+                        "in": "path",
+                        "name": "faceId",
+                        "required": true,
+                        "schema": {
+                            "type": "integer"
                         }
                     }
                 ],
@@ -13967,15 +20177,15 @@ const docTemplate = `{
                                         "type": "object"
                                     },
                                     {
-                                        "$ref": "#/components/schemas/dto.MergeDuplicateGroupRequestDTO",
+                                        "$ref": "#/components/schemas/dto.MoveFaceRequestDTO",
                                         "summary": "request",
-                                        "description": "Merge configuration"
+                                        "description": "Move payload"
                                     }
                                 ]
                             }
                         }
                     },
-                    "description": "Merge configuration",
+                    "description": "Move payload",
                     "required": true
                 },
                 "responses": {
@@ -13983,11 +20193,11 @@ const docTemplate = `{
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/dto.MergeDuplicateGroupResponseDTO"
+                                    "$ref": "#/components/schemas/dto.PersonCorrectionResponseDTO"
                                 }
                             }
                         },
-                        "description": "OK"
+                        "description": "Face moved successfully"
                     },
                     "400": {
                         "content": {
@@ -13997,7 +20207,7 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Bad Request"
+                        "description": "Invalid request parameters"
                     },
                     "404": {
                         "content": {
@@ -14007,17 +20217,7 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Not Found"
-                    },
-                    "409": {
-                        "content": {
-                            "application/json": {
-                                "schema": {
-                                    "$ref": "#/components/schemas/api.ErrorResponse"
-                                }
-                            }
-                        },
-                        "description": "Conflict"
+                        "description": "Person or face not found"
                     },
                     "500": {
                         "content": {
@@ -14027,47 +20227,53 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Internal Server Error"
+                        "description": "Internal server error"
                     }
                 },
-                "summary": "Merge a duplicate group",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "summary": "Move face to another person",
                 "tags": [
-                    "duplicates"
+                    "people"
                 ]
             }
         },
-        "/api/v1/duplicates/summary": {
-            "get": {
-                "description": "Returns counts and recoverable space for pending duplicate groups, scoped by optional repository_id.",
+        "/api/v1/people/{id}/faces/{faceId}/remove": {
+            "post": {
+                "description": "Detach a face from this person, leaving the original asset unchanged.",
                 "parameters": [
                     {
-                        "description": "Repository UUID to scope the summary",
-                        "in": "query",
-                        "name": "repository_id",
+                        "description": "Person ID",
+                        "in": "path",
+                        "name": "id",
+                        "required": true,
                         "schema": {
-                            "type": "string"
+                            "type": "integer"
                         }
-                    }
-                ],
-                "requestBody": {
-                    "content": {
-                        "application/json": {
-                            "schema": {
-                                "type": "object"
-                            }
+                    },
+                    {
+                        "description": "Face ID",
+                        "in": "path",
+                        "name": "faceId",
+                        "required": true,
+                        "schema": {
+                            "type": "integer"
                         }
                     }
-                },
+                ],
                 "responses": {
                     "200": {
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/dto.DuplicateSummaryDTO"
+                                    "$ref": "#/components/schemas/dto.PersonCorrectionResponseDTO"
                                 }
                             }
                         },
-                        "description": "OK"
+                        "description": "Face removed successfully"
                     },
                     "400": {
                         "content": {
@@ -14077,9 +20283,9 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Bad Request"
+                        "description": "Invalid request parameters"
                     },
-                    "500": {
+                    "404": {
                         "content": {
                             "application/json": {
                                 "schema": {
@@ -14087,103 +20293,74 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Internal Server Error"
-                    }
-                },
-                "summary": "Get duplicate detection summary",
-                "tags": [
-                    "duplicates"
-                ]
-            }
-        },
-        "/api/v1/health": {
-            "get": {
-                "description": "Check if the server is healthy",
-                "requestBody": {
-                    "content": {
-                        "application/json": {
-                            "schema": {
-                                "type": "object"
-                            }
-                        }
-                    }
-                },
-                "responses": {
-                    "200": {
+                        "description": "Person or face not found"
+                    },
+                    "500": {
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/handler.HealthResponse"
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
                                 }
                             }
                         },
-                        "description": "Server is healthy"
+                        "description": "Internal server error"
                     }
                 },
-                "summary": "Health check",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "summary": "Remove face from person",
                 "tags": [
-                    "Health"
+                    "people"
                 ]
             }
         },
-        "/api/v1/locations/clusters": {
-            "get": {
-                "description": "Return paginated persisted photo location clusters with cached labels when available.",
+        "/api/v1/people/{id}/hidden": {
+            "put": {
+                "description": "Hide or unhide a person from the default people grid. Faces, assets and names are preserved.",
                 "parameters": [
                     {
-                        "description": "Page size (1-1000)",
-                        "in": "query",
-                        "name": "limit",
-                        "schema": {
-                            "default": 100,
-                            "type": "integer"
-                        }
-                    },
-                    {
-                        "description": "Page offset",
-                        "in": "query",
-                        "name": "offset",
+                        "description": "Person ID",
+                        "in": "path",
+                        "name": "id",
+                        "required": true,
                         "schema": {
-                            "default": 0,
                             "type": "integer"
                         }
-                    },
-                    {
-                        "description": "Optional repository UUID filter",
-                        "in": "query",
-                        "name": "repository_id",
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
-                    {
-                        "description": "Optional geohash filter",
-                        "in": "query",
-                        "name": "geohash",
-                        "schema": {
-                            "type": "string"
-                        }
                     }
                 ],
                 "requestBody": {
                     "content": {
                         "application/json": {
                             "schema": {
-                                "type": "object"
+                                "oneOf": [
+                                    {
+                                        "type": "object"
+                                    },
+                                    {
+                                        "$ref": "#/components/schemas/dto.SetPersonHiddenRequestDTO",
+                                        "summary": "request",
+                                        "description": "Hidden payload"
+                                    }
+                                ]
                             }
                         }
-                    }
+                    },
+                    "description": "Hidden payload",
+                    "required": true
                 },
                 "responses": {
                     "200": {
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/dto.LocationClusterListResponseDTO"
+                                    "$ref": "#/components/schemas/dto.PersonCorrectionResponseDTO"
                                 }
                             }
                         },
-                        "description": "Location clusters retrieved successfully"
+                        "description": "Hidden state updated successfully"
                     },
                     "400": {
                         "content": {
@@ -14195,6 +20372,16 @@ const docTemplate = `{
                         },
                         "description": "Invalid request parameters"
                     },
+                    "404": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Person not found"
+                    },
                     "500": {
                         "content": {
                             "application/json": {
@@ -14206,15 +20393,31 @@ const docTemplate = `{
                         "description": "Internal server error"
                     }
                 },
-                "summary": "Get location clusters",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "summary": "Set person hidden",
                 "tags": [
-                    "locations"
+                    "people"
                 ]
             }
         },
-        "/api/v1/locations/rebuild": {
+        "/api/v1/people/{id}/merge": {
             "post": {
-                "description": "Queue a location cluster rebuild for all photos or one repository.",
+                "description": "Merge one or more source people into the target person. Assets remain in the library and corrections become manual.",
+                "parameters": [
+                    {
+                        "description": "Target person ID",
+                        "in": "path",
+                        "name": "id",
+                        "required": true,
+                        "schema": {
+                            "type": "integer"
+                        }
+                    }
+                ],
                 "requestBody": {
                     "content": {
                         "application/json": {
@@ -14224,26 +20427,27 @@ const docTemplate = `{
                                         "type": "object"
                                     },
                                     {
-                                        "$ref": "#/components/schemas/dto.RebuildLocationClustersRequestDTO",
+                                        "$ref": "#/components/schemas/dto.MergePeopleRequestDTO",
                                         "summary": "request",
-                                        "description": "Rebuild request"
+                                        "description": "Merge payload"
                                     }
                                 ]
                             }
                         }
                     },
-                    "description": "Rebuild request"
+                    "description": "Merge payload",
+                    "required": true
                 },
                 "responses": {
                     "200": {
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/dto.RebuildLocationClustersResponseDTO"
+                                    "$ref": "#/components/schemas/dto.PersonCorrectionResponseDTO"
                                 }
                             }
                         },
-                        "description": "Location cluster rebuild queued successfully"
+                        "description": "People merged successfully"
                     },
                     "400": {
                         "content": {
@@ -14253,7 +20457,17 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Invalid request body"
+                        "description": "Invalid request parameters"
+                    },
+                    "404": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Person not found"
                     },
                     "500": {
                         "content": {
@@ -14266,48 +20480,56 @@ const docTemplate = `{
                         "description": "Internal server error"
                     }
                 },
-                "summary": "Queue location cluster rebuild",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "summary": "Merge people",
                 "tags": [
-                    "locations"
+                    "people"
                 ]
             }
         },
-        "/api/v1/people": {
+        "/api/v1/public/albums/{token}": {
             "get": {
-                "description": "List recognized people for the current repository scope.",
+                "description": "Get an album shared through POST /api/v1/albums/{id}/share: de-sensitized metadata plus one page of assets with their public thumbnail URLs. Records one view. Password-protected shares need the X-Share-Password header once; the response then sets a cookie that unlocks the share's other public routes.",
                 "parameters": [
                     {
-                        "description": "Optional repository UUID filter",
-                        "in": "query",
-                        "name": "repository_id",
+                        "description": "Share token",
+                        "in": "path",
+                        "name": "token",
+                        "required": true,
                         "schema": {
                             "type": "string"
                         }
                     },
                     {
-                        "description": "Include people hidden from the default grid",
-                        "in": "query",
-                        "name": "include_hidden",
+                        "description": "Share password",
+                        "in": "header",
+                        "name": "X-Share-Password",
                         "schema": {
-                            "default": false,
-                            "type": "boolean"
+                            "type": "string"
                         }
                     },
                     {
-                        "description": "Maximum number of results (max 100)",
+                        "description": "Maximum number of assets to return",
                         "in": "query",
                         "name": "limit",
                         "schema": {
-                            "default": 24,
+                            "default": 50,
+                            "maximum": 200,
+                            "minimum": 1,
                             "type": "integer"
                         }
                     },
                     {
-                        "description": "Number of results to skip",
+                        "description": "Number of assets to skip",
                         "in": "query",
                         "name": "offset",
                         "schema": {
                             "default": 0,
+                            "minimum": 0,
                             "type": "integer"
                         }
                     }
@@ -14317,64 +20539,13 @@ const docTemplate = `{
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/dto.ListPeopleResponseDTO"
-                                }
-                            }
-                        },
-                        "description": "People listed successfully"
-                    },
-                    "400": {
-                        "content": {
-                            "application/json": {
-                                "schema": {
-                                    "$ref": "#/components/schemas/api.ErrorResponse"
-                                }
-                            }
-                        },
-                        "description": "Invalid request parameters"
-                    },
-                    "500": {
-                        "content": {
-                            "application/json": {
-                                "schema": {
-                                    "$ref": "#/components/schemas/api.ErrorResponse"
-                                }
-                            }
-                        },
-                        "description": "Internal server error"
-                    }
-                },
-                "summary": "List people",
-                "tags": [
-                    "people"
-                ]
-            }
-        },
-        "/api/v1/people/rebuild": {
-            "post": {
-                "description": "Rebuild recognized people for the selected repository scope using HDBSCAN over face embeddings.",
-                "parameters": [
-                    {
-                        "description": "Optional repository UUID filter",
-                        "in": "query",
-                        "name": "repository_id",
-                        "schema": {
-                            "type": "string"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "content": {
-                            "application/json": {
-                                "schema": {
-                                    "$ref": "#/components/schemas/dto.FaceClusterRebuildResponseDTO"
+                                    "$ref": "#/components/schemas/dto.PublicAlbumDTO"
                                 }
                             }
                         },
-                        "description": "People clusters rebuilt successfully"
+                        "description": "OK"
                     },
-                    "400": {
+                    "401": {
                         "content": {
                             "application/json": {
                                 "schema": {
@@ -14382,9 +20553,9 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Invalid request parameters"
+                        "description": "This share is password protected"
                     },
-                    "401": {
+                    "404": {
                         "content": {
                             "application/json": {
                                 "schema": {
@@ -14392,7 +20563,7 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Unauthorized"
+                        "description": "Share not found or no longer available"
                     },
                     "500": {
                         "content": {
@@ -14405,34 +20576,31 @@ const docTemplate = `{
                         "description": "Internal server error"
                     }
                 },
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "summary": "Rebuild people clusters",
+                "summary": "Get a public album",
                 "tags": [
-                    "people"
+                    "public-shares"
                 ]
             }
         },
-        "/api/v1/people/{id}": {
+        "/api/v1/public/assets/{id}": {
             "get": {
-                "description": "Get a single recognized person by cluster ID.",
+                "description": "Serve an asset's original for a token issued by GET /api/v1/assets/{id}/download-url. No other authentication is needed.",
                 "parameters": [
                     {
-                        "description": "Person ID",
+                        "description": "Asset ID (UUID format)",
+                        "example": "\"550e8400-e29b-41d4-a716-446655440000\"",
                         "in": "path",
                         "name": "id",
                         "required": true,
                         "schema": {
-                            "type": "integer"
+                            "type": "string"
                         }
                     },
                     {
-                        "description": "Optional repository UUID filter",
+                        "description": "Signed download token",
                         "in": "query",
-                        "name": "repository_id",
+                        "name": "token",
+                        "required": true,
                         "schema": {
                             "type": "string"
                         }
@@ -14441,37 +20609,47 @@ const docTemplate = `{
                 "responses": {
                     "200": {
                         "content": {
-                            "application/json": {
+                            "application/octet-stream": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/dto.PersonDetailDTO"
+                                    "type": "file"
                                 }
                             }
                         },
-                        "description": "Person fetched successfully"
+                        "description": "Original file content"
                     },
-                    "400": {
+                    "403": {
                         "content": {
-                            "application/json": {
+                            "application/octet-stream": {
                                 "schema": {
                                     "$ref": "#/components/schemas/api.ErrorResponse"
                                 }
                             }
                         },
-                        "description": "Invalid request parameters"
+                        "description": "Token missing, tampered, expired or for another asset"
                     },
                     "404": {
                         "content": {
-                            "application/json": {
+                            "application/octet-stream": {
                                 "schema": {
                                     "$ref": "#/components/schemas/api.ErrorResponse"
                                 }
                             }
                         },
-                        "description": "Person not found"
+                        "description": "Asset not found"
+                    },
+                    "410": {
+                        "content": {
+                            "application/octet-stream": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Asset's repository was removed"
                     },
                     "500": {
                         "content": {
-                            "application/json": {
+                            "application/octet-stream": {
                                 "schema": {
                                     "$ref": "#/components/schemas/api.ErrorResponse"
                                 }
@@ -14480,66 +20658,38 @@ const docTemplate = `{
                         "description": "Internal server error"
                     }
                 },
-                "summary": "Get person",
+                "summary": "Get original file by signed URL",
                 "tags": [
-                    "people"
+                    "assets"
                 ]
-            },
-            "patch": {
-                "description": "Rename a recognized person. Successful updates mark the person as confirmed.",
+            }
+        },
+        "/api/v1/public/shares/{token}": {
+            "get": {
+                "description": "Get a public share's de-sensitized metadata (title, asset count, expiry, download policy). Records one view.",
                 "parameters": [
                     {
-                        "description": "Person ID",
+                        "description": "Share token",
                         "in": "path",
-                        "name": "id",
+                        "name": "token",
                         "required": true,
-                        "schema": {
-                            "type": "integer"
-                        }
-                    }
-                ],
-                "requestBody": {
-                    "content": {
-                        "application/json": {
-                            "schema": {
-                                "oneOf": [
-                                    {
-                                        "type": "object"
-                                    },
-                                    {
-                                        "$ref": "#/components/schemas/dto.UpdatePersonRequestDTO",
-                                        "summary": "request",
-                                        "description": "Person update payload"
-                                    }
-                                ]
-                            }
-                        }
-                    },
-                    "description": "Person update payload",
-                    "required": true
-                },
-                "responses": {
-                    "200": {
-                        "content": {
-                            "application/json": {
-                                "schema": {
-                                    "$ref": "#/components/schemas/dto.PersonDetailDTO"
-                                }
-                            }
-                        },
-                        "description": "Person updated successfully"
-                    },
-                    "400": {
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                    "$ref": "#/components/schemas/dto.PublicShareMetadataDTO"
                                 }
                             }
                         },
-                        "description": "Invalid request parameters"
+                        "description": "OK"
                     },
-                    "404": {
+                    "401": {
                         "content": {
                             "application/json": {
                                 "schema": {
@@ -14547,9 +20697,9 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Person not found"
+                        "description": "This share is password protected"
                     },
-                    "500": {
+                    "404": {
                         "content": {
                             "application/json": {
                                 "schema": {
@@ -14557,31 +20707,26 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Internal server error"
+                        "description": "Share not found or no longer available"
                     }
                 },
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "summary": "Update person",
+                "summary": "Get public share metadata",
                 "tags": [
-                    "people"
+                    "public-shares"
                 ]
             }
         },
-        "/api/v1/people/{id}/assets/list": {
+        "/api/v1/public/shares/{token}/assets/list": {
             "post": {
-                "description": "List assets scoped to a specific person while reusing the unified asset query filters.",
+                "description": "Browse a public share's assets in date order. v1 is browse-only: no filter/search/sort.",
                 "parameters": [
                     {
-                        "description": "Person ID",
+                        "description": "Share token",
                         "in": "path",
-                        "name": "id",
+                        "name": "token",
                         "required": true,
                         "schema": {
-                            "type": "integer"
+                            "type": "string"
                         }
                     }
                 ],
@@ -14594,15 +20739,15 @@ const docTemplate = `{
                                         "type": "object"
                                     },
                                     {
-                                        "$ref": "#/components/schemas/dto.AssetQueryRequestDTO",
+                                        "$ref": "#/components/schemas/dto.PublicShareAssetListRequestDTO",
                                         "summary": "request",
-                                        "description": "Asset query parameters"
+                                        "description": "Pagination"
                                     }
                                 ]
                             }
                         }
                     },
-                    "description": "Asset query parameters",
+                    "description": "Pagination",
                     "required": true
                 },
                 "responses": {
@@ -14610,13 +20755,13 @@ const docTemplate = `{
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/dto.QueryAssetsResponseDTO"
+                                    "$ref": "#/components/schemas/dto.PublicShareAssetListResponseDTO"
                                 }
                             }
                         },
-                        "description": "Assets listed successfully"
+                        "description": "OK"
                     },
-                    "400": {
+                    "401": {
                         "content": {
                             "application/json": {
                                 "schema": {
@@ -14624,7 +20769,7 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Invalid request parameters"
+                        "description": "This share is password protected"
                     },
                     "404": {
                         "content": {
@@ -14634,7 +20779,7 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Person not found"
+                        "description": "Share not found or no longer available"
                     },
                     "500": {
                         "content": {
@@ -14645,41 +20790,32 @@ const docTemplate = `{
                             }
                         },
                         "description": "Internal server error"
-                    },
-                    "503": {
-                        "content": {
-                            "application/json": {
-                                "schema": {
-                                    "$ref": "#/components/schemas/api.ErrorResponse"
-                                }
-                            }
-                        },
-                        "description": "Semantic search unavailable"
                     }
                 },
-                "summary": "List person assets",
+                "summary": "List public share assets",
                 "tags": [
-                    "people"
+                    "public-shares"
                 ]
             }
         },
-        "/api/v1/people/{id}/cover": {
+        "/api/v1/public/shares/{token}/assets/{assetId}/original": {
             "get": {
-                "description": "Serve the representative face crop image for a recognized person.",
+                "description": "Serve the original file for an asset that belongs to this share. Requires allow_download and include_originals to both be enabled.",
                 "parameters": [
                     {
-                        "description": "Person ID",
+                        "description": "Share token",
                         "in": "path",
-                        "name": "id",
+                        "name": "token",
                         "required": true,
                         "schema": {
-                            "type": "integer"
+                            "type": "string"
                         }
                     },
                     {
-                        "description": "Optional repository UUID filter",
-                        "in": "query",
-                        "name": "repository_id",
+                        "description": "Asset ID",
+                        "in": "path",
+                        "name": "assetId",
+                        "required": true,
                         "schema": {
                             "type": "string"
                         }
@@ -14688,260 +20824,205 @@ const docTemplate = `{
                 "responses": {
                     "200": {
                         "content": {
-                            "image/webp": {
+                            "application/octet-stream": {
                                 "schema": {
                                     "type": "file"
                                 }
                             }
                         },
-                        "description": "Face crop"
-                    },
-                    "400": {
-                        "content": {
-                            "image/webp": {
-                                "schema": {
-                                    "$ref": "#/components/schemas/api.ErrorResponse"
-                                }
-                            }
-                        },
-                        "description": "Invalid request parameters"
+                        "description": "Original file content"
                     },
                     "401": {
                         "content": {
-                            "image/webp": {
+                            "application/octet-stream": {
                                 "schema": {
                                     "$ref": "#/components/schemas/api.ErrorResponse"
                                 }
                             }
                         },
-                        "description": "Unauthorized"
+                        "description": "This share is password protected"
                     },
-                    "404": {
+                    "403": {
                         "content": {
-                            "image/webp": {
+                            "application/octet-stream": {
                                 "schema": {
                                     "$ref": "#/components/schemas/api.ErrorResponse"
                                 }
                             }
                         },
-                        "description": "Person cover not found"
+                        "description": "Original downloads are not enabled for this share"
                     },
-                    "500": {
+                    "404": {
                         "content": {
-                            "image/webp": {
+                            "application/octet-stream": {
                                 "schema": {
                                     "$ref": "#/components/schemas/api.ErrorResponse"
                                 }
                             }
                         },
-                        "description": "Internal server error"
+                        "description": "Not found"
                     }
                 },
-                "summary": "Get person cover",
+                "summary": "Get public share original file",
                 "tags": [
-                    "people"
+                    "public-shares"
                 ]
-            },
-            "put": {
-                "description": "Set the representative cover face for a person. The face must belong to the person.",
+            }
+        },
+        "/api/v1/public/shares/{token}/assets/{assetId}/thumbnail": {
+            "get": {
+                "description": "Serve a thumbnail for an asset that belongs to this share.",
                 "parameters": [
                     {
-                        "description": "Person ID",
+                        "description": "Share token",
                         "in": "path",
-                        "name": "id",
+                        "name": "token",
                         "required": true,
                         "schema": {
-                            "type": "integer"
+                            "type": "string"
                         }
-                    }
-                ],
-                "requestBody": {
-                    "content": {
-                        "application/json": {
-                            "schema": {
-                                "oneOf": [
-                                    {
-                                        "type": "object"
-                                    },
-                                    {
-                                        "$ref": "#/components/schemas/dto.SetPersonCoverRequestDTO",
-                                        "summary": "request",
-                                        "description": "Cover payload"
-                                    }
-                                ]
-                            }
+                    },
+                    {
+                        "description": "Asset ID",
+                        "in": "path",
+                        "name": "assetId",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
                         }
                     },
-                    "description": "Cover payload",
-                    "required": true
-                },
+                    {
+                        "description": "Thumbnail size",
+                        "in": "query",
+                        "name": "size",
+                        "schema": {
+                            "default": "medium",
+                            "enum": [
+                                "small",
+                                "medium",
+                                "large"
+                            ],
+                            "type": "string"
+                        }
+                    }
+                ],
                 "responses": {
                     "200": {
                         "content": {
-                            "application/json": {
+                            "image/jpeg": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/dto.PersonCorrectionResponseDTO"
+                                    "type": "file"
                                 }
                             }
                         },
-                        "description": "Cover updated successfully"
+                        "description": "Thumbnail image file"
                     },
-                    "400": {
+                    "401": {
                         "content": {
-                            "application/json": {
+                            "image/jpeg": {
                                 "schema": {
                                     "$ref": "#/components/schemas/api.ErrorResponse"
                                 }
                             }
                         },
-                        "description": "Invalid request parameters"
+                        "description": "This share is password protected"
                     },
                     "404": {
                         "content": {
-                            "application/json": {
-                                "schema": {
-                                    "$ref": "#/components/schemas/api.ErrorResponse"
-                                }
-                            }
-                        },
-                        "description": "Person or face not found"
-                    },
-                    "500": {
-                        "content": {
-                            "application/json": {
+                            "image/jpeg": {
                                 "schema": {
                                     "$ref": "#/components/schemas/api.ErrorResponse"
                                 }
                             }
                         },
-                        "description": "Internal server error"
+                        "description": "Not found"
                     }
                 },
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "summary": "Set person cover",
+                "summary": "Get public share asset thumbnail",
                 "tags": [
-                    "people"
+                    "public-shares"
                 ]
             }
         },
-        "/api/v1/people/{id}/faces": {
+        "/api/v1/public/shares/{token}/assets/{assetId}/web-audio": {
             "get": {
-                "description": "List the individual face crops assigned to a person for correction workflows.",
+                "description": "Serve the web-optimized audio for an asset that belongs to this share.",
                 "parameters": [
                     {
-                        "description": "Person ID",
+                        "description": "Share token",
                         "in": "path",
-                        "name": "id",
+                        "name": "token",
                         "required": true,
-                        "schema": {
-                            "type": "integer"
-                        }
-                    },
-                    {
-                        "description": "Optional repository UUID filter",
-                        "in": "query",
-                        "name": "repository_id",
                         "schema": {
                             "type": "string"
                         }
                     },
                     {
-                        "description": "Maximum number of results (max 200)",
-                        "in": "query",
-                        "name": "limit",
-                        "schema": {
-                            "default": 60,
-                            "type": "integer"
-                        }
-                    },
-                    {
-                        "description": "Number of results to skip",
-                        "in": "query",
-                        "name": "offset",
+                        "description": "Asset ID",
+                        "in": "path",
+                        "name": "assetId",
+                        "required": true,
                         "schema": {
-                            "default": 0,
-                            "type": "integer"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "content": {
-                            "application/json": {
-                                "schema": {
-                                    "$ref": "#/components/schemas/dto.ListPersonFacesResponseDTO"
-                                }
-                            }
-                        },
-                        "description": "Faces listed successfully"
-                    },
-                    "400": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
                         "content": {
-                            "application/json": {
+                            "audio/mpeg": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                    "type": "file"
                                 }
                             }
                         },
-                        "description": "Invalid request parameters"
+                        "description": "Web-optimized audio file"
                     },
-                    "404": {
+                    "401": {
                         "content": {
-                            "application/json": {
+                            "audio/mpeg": {
                                 "schema": {
                                     "$ref": "#/components/schemas/api.ErrorResponse"
                                 }
                             }
                         },
-                        "description": "Person not found"
+                        "description": "This share is password protected"
                     },
-                    "500": {
+                    "404": {
                         "content": {
-                            "application/json": {
+                            "audio/mpeg": {
                                 "schema": {
                                     "$ref": "#/components/schemas/api.ErrorResponse"
                                 }
                             }
                         },
-                        "description": "Internal server error"
+                        "description": "Not found"
                     }
                 },
-                "summary": "List person faces",
+                "summary": "Get public share asset web audio",
                 "tags": [
-                    "people"
+                    "public-shares"
                 ]
             }
         },
-        "/api/v1/people/{id}/faces/{faceId}/crop": {
+        "/api/v1/public/shares/{token}/assets/{assetId}/web-video": {
             "get": {
-                "description": "Serve the face crop image for a single face belonging to a person.",
+                "description": "Serve the web-optimized video for an asset that belongs to this share.",
                 "parameters": [
                     {
-                        "description": "Person ID",
+                        "description": "Share token",
                         "in": "path",
-                        "name": "id",
+                        "name": "token",
                         "required": true,
                         "schema": {
-                            "type": "integer"
+                            "type": "string"
                         }
                     },
                     {
-                        "description": "Face ID",
+                        "description": "Asset ID",
                         "in": "path",
-                        "name": "faceId",
+                        "name": "assetId",
                         "required": true,
-                        "schema": {
-                            "type": "integer"
-                        }
-                    },
-                    {
-                        "description": "Optional repository UUID filter",
-                        "in": "query",
-                        "name": "repository_id",
                         "schema": {
                             "type": "string"
                         }
@@ -14950,81 +21031,52 @@ const docTemplate = `{
                 "responses": {
                     "200": {
                         "content": {
-                            "image/webp": {
+                            "video/mp4": {
                                 "schema": {
                                     "type": "file"
                                 }
                             }
                         },
-                        "description": "Face crop"
-                    },
-                    "400": {
-                        "content": {
-                            "image/webp": {
-                                "schema": {
-                                    "$ref": "#/components/schemas/api.ErrorResponse"
-                                }
-                            }
-                        },
-                        "description": "Invalid request parameters"
+                        "description": "Web-optimized video file"
                     },
                     "401": {
                         "content": {
-                            "image/webp": {
+                            "video/mp4": {
                                 "schema": {
                                     "$ref": "#/components/schemas/api.ErrorResponse"
                                 }
                             }
                         },
-                        "description": "Unauthorized"
+                        "description": "This share is password protected"
                     },
                     "404": {
                         "content": {
-                            "image/webp": {
-                                "schema": {
-                                    "$ref": "#/components/schemas/api.ErrorResponse"
-                                }
-                            }
-                        },
-                        "description": "Face crop not found"
-                    },
-                    "500": {
-                        "content": {
-                            "image/webp": {
+                            "video/mp4": {
                                 "schema": {
                                     "$ref": "#/components/schemas/api.ErrorResponse"
                                 }
                             }
                         },
-                        "description": "Internal server error"
+                        "description": "Not found"
                     }
                 },
-                "summary": "Get person face crop",
+                "summary": "Get public share asset web video",
                 "tags": [
-                    "people"
+                    "public-shares"
                 ]
             }
         },
-        "/api/v1/people/{id}/faces/{faceId}/move": {
+        "/api/v1/public/shares/{token}/download": {
             "post": {
-                "description": "Reassign a single face from this person to another person as a manual correction.",
+                "description": "Serve the share's original files (optionally scoped to a subset via asset_ids) as a zip archive. Requires allow_download to be enabled.",
                 "parameters": [
                     {
-                        "description": "Source person ID",
-                        "in": "path",
-                        "name": "id",
-                        "required": true,
-                        "schema": {
-                            "type": "integer"
-                        }
-                    },
-                    {
-                        "description": "Face ID",
+                        "description": "Share token",
                         "in": "path",
-                        "name": "faceId",
+                        "name": "token",
                         "required": true,
                         "schema": {
-                            "type": "integer"
+                            "type": "string"
                         }
                     }
                 ],
@@ -15037,57 +21089,87 @@ const docTemplate = `{
                                         "type": "object"
                                     },
                                     {
-                                        "$ref": "#/components/schemas/dto.MoveFaceRequestDTO",
+                                        "$ref": "#/components/schemas/dto.PublicShareDownloadRequestDTO",
                                         "summary": "request",
-                                        "description": "Move payload"
+                                        "description": "Optional asset ID subset"
                                     }
                                 ]
                             }
                         }
                     },
-                    "description": "Move payload",
-                    "required": true
+                    "description": "Optional asset ID subset"
                 },
                 "responses": {
                     "200": {
                         "content": {
-                            "application/json": {
+                            "application/zip": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/dto.PersonCorrectionResponseDTO"
+                                    "type": "file"
                                 }
                             }
                         },
-                        "description": "Face moved successfully"
+                        "description": "Zip archive"
                     },
-                    "400": {
+                    "401": {
                         "content": {
-                            "application/json": {
+                            "application/zip": {
                                 "schema": {
                                     "$ref": "#/components/schemas/api.ErrorResponse"
                                 }
                             }
                         },
-                        "description": "Invalid request parameters"
+                        "description": "This share is password protected"
+                    },
+                    "403": {
+                        "content": {
+                            "application/zip": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Downloads are not enabled for this share"
                     },
                     "404": {
                         "content": {
-                            "application/json": {
+                            "application/zip": {
                                 "schema": {
                                     "$ref": "#/components/schemas/api.ErrorResponse"
                                 }
                             }
                         },
-                        "description": "Person or face not found"
+                        "description": "Not found"
                     },
-                    "500": {
+                    "413": {
                         "content": {
-                            "application/json": {
+                            "application/zip": {
                                 "schema": {
                                     "$ref": "#/components/schemas/api.ErrorResponse"
                                 }
                             }
                         },
-                        "description": "Internal server error"
+                        "description": "Selected files exceed the download limit"
+                    }
+                },
+                "summary": "Download public share",
+                "tags": [
+                    "public-shares"
+                ]
+            }
+        },
+        "/api/v1/repositories": {
+            "get": {
+                "description": "Return all registered repositories.",
+                "responses": {
+                    "200": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/dto.ListRepositoriesResponseDTO"
+                                }
+                            }
+                        },
+                        "description": "Repositories retrieved successfully"
                     }
                 },
                 "security": [
@@ -15095,45 +21177,43 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "summary": "Move face to another person",
+                "summary": "List repositories",
                 "tags": [
-                    "people"
+                    "repositories"
                 ]
-            }
-        },
-        "/api/v1/people/{id}/faces/{faceId}/remove": {
+            },
             "post": {
-                "description": "Detach a face from this person, leaving the original asset unchanged.",
-                "parameters": [
-                    {
-                        "description": "Person ID",
-                        "in": "path",
-                        "name": "id",
-                        "required": true,
-                        "schema": {
-                            "type": "integer"
+                "description": "Create a repository folder under a registered Storage Location. Empty root_id selects the configured default. If the target already contains a .lumiliorepo file, it is registered instead.",
+                "requestBody": {
+                    "content": {
+                        "application/json": {
+                            "schema": {
+                                "oneOf": [
+                                    {
+                                        "type": "object"
+                                    },
+                                    {
+                                        "$ref": "#/components/schemas/dto.CreateRepositoryRequestDTO",
+                                        "summary": "request",
+                                        "description": "Repository name"
+                                    }
+                                ]
+                            }
                         }
                     },
-                    {
-                        "description": "Face ID",
-                        "in": "path",
-                        "name": "faceId",
-                        "required": true,
-                        "schema": {
-                            "type": "integer"
-                        }
-                    }
-                ],
+                    "description": "Repository name",
+                    "required": true
+                },
                 "responses": {
                     "200": {
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/dto.PersonCorrectionResponseDTO"
+                                    "$ref": "#/components/schemas/dto.CreateRepositoryResponseDTO"
                                 }
                             }
                         },
-                        "description": "Face removed successfully"
+                        "description": "Repository created successfully"
                     },
                     "400": {
                         "content": {
@@ -15143,9 +21223,9 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Invalid request parameters"
+                        "description": "Invalid request"
                     },
-                    "404": {
+                    "401": {
                         "content": {
                             "application/json": {
                                 "schema": {
@@ -15153,7 +21233,27 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Person or face not found"
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Forbidden"
+                    },
+                    "409": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/dto.RepositoryConflictDTO"
+                                }
+                            }
+                        },
+                        "description": "Repository identity conflict"
                     },
                     "500": {
                         "content": {
@@ -15171,26 +21271,15 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "summary": "Remove face from person",
-                "tags": [
-                    "people"
-                ]
-            }
-        },
-        "/api/v1/people/{id}/hidden": {
-            "put": {
-                "description": "Hide or unhide a person from the default people grid. Faces, assets and names are preserved.",
-                "parameters": [
-                    {
-                        "description": "Person ID",
-                        "in": "path",
-                        "name": "id",
-                        "required": true,
-                        "schema": {
-                            "type": "integer"
-                        }
-                    }
-                ],
+                "summary": "Create repository",
+                "tags": [
+                    "repositories"
+                ]
+            }
+        },
+        "/api/v1/repositories/import-config": {
+            "post": {
+                "description": "Create a regular repository below a registered Storage Location with the settings of an exported bundle. The bundle kind and version are checked. A folder that already holds a repository is refused rather than registered.",
                 "requestBody": {
                     "content": {
                         "application/json": {
@@ -15200,15 +21289,15 @@ const docTemplate = `{
                                         "type": "object"
                                     },
                                     {
-                                        "$ref": "#/components/schemas/dto.SetPersonHiddenRequestDTO",
+                                        "$ref": "#/components/schemas/dto.ImportRepositoryConfigRequestDTO",
                                         "summary": "request",
-                                        "description": "Hidden payload"
+                                        "description": "Bundle and target"
                                     }
                                 ]
                             }
                         }
                     },
-                    "description": "Hidden payload",
+                    "description": "Bundle and target",
                     "required": true
                 },
                 "responses": {
@@ -15216,11 +21305,11 @@ const docTemplate = `{
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/dto.PersonCorrectionResponseDTO"
+                                    "$ref": "#/components/schemas/dto.CreateRepositoryResponseDTO"
                                 }
                             }
                         },
-                        "description": "Hidden state updated successfully"
+                        "description": "Repository created"
                     },
                     "400": {
                         "content": {
@@ -15230,9 +21319,9 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Invalid request parameters"
+                        "description": "Invalid request or unsupported bundle"
                     },
-                    "404": {
+                    "401": {
                         "content": {
                             "application/json": {
                                 "schema": {
@@ -15240,7 +21329,27 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Person not found"
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Forbidden"
+                    },
+                    "409": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/dto.RepositoryConflictDTO"
+                                }
+                            }
+                        },
+                        "description": "Storage Location unavailable or primary repository missing"
                     },
                     "500": {
                         "content": {
@@ -15258,56 +21367,48 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "summary": "Set person hidden",
+                "summary": "Import repository configuration",
                 "tags": [
-                    "people"
+                    "repositories"
                 ]
             }
         },
-        "/api/v1/people/{id}/merge": {
-            "post": {
-                "description": "Merge one or more source people into the target person. Assets remain in the library and corrections become manual.",
+        "/api/v1/repositories/{id}": {
+            "delete": {
+                "description": "Remove a repository from the registry. Does not delete files on disk. A repository that still has assets is refused unless ` + "`" + `assets` + "`" + ` says what to do with them: ` + "`" + `detach` + "`" + ` keeps them in the library without a repository, ` + "`" + `soft_delete` + "`" + ` also moves them to Trash. Either way their media then answers 410 Gone.",
                 "parameters": [
                     {
-                        "description": "Target person ID",
+                        "description": "Repository UUID",
                         "in": "path",
                         "name": "id",
                         "required": true,
                         "schema": {
-                            "type": "integer"
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "What to do with the repository's assets",
+                        "in": "query",
+                        "name": "assets",
+                        "schema": {
+                            "enum": [
+                                "detach",
+                                "soft_delete"
+                            ],
+                            "type": "string"
                         }
                     }
                 ],
-                "requestBody": {
-                    "content": {
-                        "application/json": {
-                            "schema": {
-                                "oneOf": [
-                                    {
-                                        "type": "object"
-                                    },
-                                    {
-                                        "$ref": "#/components/schemas/dto.MergePeopleRequestDTO",
-                                        "summary": "request",
-                                        "description": "Merge payload"
-                                    }
-                                ]
-                            }
-                        }
-                    },
-                    "description": "Merge payload",
-                    "required": true
-                },
                 "responses": {
                     "200": {
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/dto.PersonCorrectionResponseDTO"
+                                    "$ref": "#/components/schemas/api.SuccessResponse"
                                 }
                             }
                         },
-                        "description": "People merged successfully"
+                        "description": "Repository deleted successfully"
                     },
                     "400": {
                         "content": {
@@ -15317,7 +21418,7 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Invalid request parameters"
+                        "description": "Invalid assets policy"
                     },
                     "404": {
                         "content": {
@@ -15327,9 +21428,9 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Person not found"
+                        "description": "Repository not found"
                     },
-                    "500": {
+                    "409": {
                         "content": {
                             "application/json": {
                                 "schema": {
@@ -15337,7 +21438,7 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Internal server error"
+                        "description": "Primary repository, or repository still has assets"
                     }
                 },
                 "security": [
@@ -15345,20 +21446,18 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "summary": "Merge people",
+                "summary": "Delete repository",
                 "tags": [
-                    "people"
+                    "repositories"
                 ]
-            }
-        },
-        "/api/v1/public/shares/{token}": {
+            },
             "get": {
-                "description": "Get a public share's de-sensitized metadata (title, asset count, expiry, download policy). Records one view.",
+                "description": "Return a single repository.",
                 "parameters": [
                     {
-                        "description": "Share token",
+                        "description": "Repository UUID",
                         "in": "path",
-                        "name": "token",
+                        "name": "id",
                         "required": true,
                         "schema": {
                             "type": "string"
@@ -15370,11 +21469,11 @@ const docTemplate = `{
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/dto.PublicShareMetadataDTO"
+                                    "$ref": "#/components/schemas/dto.RepositoryDTO"
                                 }
                             }
                         },
-                        "description": "OK"
+                        "description": "Repository retrieved successfully"
                     },
                     "404": {
                         "content": {
@@ -15384,23 +21483,26 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Share not found or no longer available"
+                        "description": "Repository not found"
                     }
                 },
-                "summary": "Get public share metadata",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "summary": "Get repository",
                 "tags": [
-                    "public-shares"
+                    "repositories"
                 ]
-            }
-        },
-        "/api/v1/public/shares/{token}/assets/list": {
-            "post": {
-                "description": "Browse a public share's assets in date order. v1 is browse-only: no filter/search/sort.",
+            },
+            "patch": {
+                "description": "Update mutable repository fields (name, storage_strategy, local_settings). Repository ownership is fixed to the Host Owner.",
                 "parameters": [
                     {
-                        "description": "Share token",
+                        "description": "Repository UUID",
                         "in": "path",
-                        "name": "token",
+                        "name": "id",
                         "required": true,
                         "schema": {
                             "type": "string"
@@ -15416,15 +21518,15 @@ const docTemplate = `{
                                         "type": "object"
                                     },
                                     {
-                                        "$ref": "#/components/schemas/dto.PublicShareAssetListRequestDTO",
+                                        "$ref": "#/components/schemas/dto.UpdateRepositoryRequestDTO",
                                         "summary": "request",
-                                        "description": "Pagination"
+                                        "description": "Fields to update"
                                     }
                                 ]
                             }
                         }
                     },
-                    "description": "Pagination",
+                    "description": "Fields to update",
                     "required": true
                 },
                 "responses": {
@@ -15432,11 +21534,21 @@ const docTemplate = `{
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/dto.PublicShareAssetListResponseDTO"
+                                    "$ref": "#/components/schemas/dto.RepositoryDTO"
                                 }
                             }
                         },
-                        "description": "OK"
+                        "description": "Repository updated successfully"
+                    },
+                    "400": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Invalid request or repository configuration"
                     },
                     "404": {
                         "content": {
@@ -15446,7 +21558,17 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Share not found or no longer available"
+                        "description": "Repository not found"
+                    },
+                    "409": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Repository is offline"
                     },
                     "500": {
                         "content": {
@@ -15459,29 +21581,25 @@ const docTemplate = `{
                         "description": "Internal server error"
                     }
                 },
-                "summary": "List public share assets",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "summary": "Update repository",
                 "tags": [
-                    "public-shares"
+                    "repositories"
                 ]
             }
         },
-        "/api/v1/public/shares/{token}/assets/{assetId}/original": {
+        "/api/v1/repositories/{id}/cloud": {
             "get": {
-                "description": "Serve the original file for an asset that belongs to this share. Requires allow_download and include_originals to both be enabled.",
+                "description": "Return cloud credential binding and latest import run for a repository.",
                 "parameters": [
                     {
-                        "description": "Share token",
-                        "in": "path",
-                        "name": "token",
-                        "required": true,
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
-                    {
-                        "description": "Asset ID",
+                        "description": "Repository UUID",
                         "in": "path",
-                        "name": "assetId",
+                        "name": "id",
                         "required": true,
                         "schema": {
                             "type": "string"
@@ -15491,74 +21609,66 @@ const docTemplate = `{
                 "responses": {
                     "200": {
                         "content": {
-                            "application/octet-stream": {
+                            "application/json": {
                                 "schema": {
-                                    "type": "file"
+                                    "$ref": "#/components/schemas/dto.RepositoryCloudStatusDTO"
                                 }
                             }
                         },
-                        "description": "Original file content"
+                        "description": "Repository cloud status"
                     },
-                    "403": {
+                    "400": {
                         "content": {
-                            "application/octet-stream": {
+                            "application/json": {
                                 "schema": {
                                     "$ref": "#/components/schemas/api.ErrorResponse"
                                 }
                             }
                         },
-                        "description": "Original downloads are not enabled for this share"
+                        "description": "Invalid request"
                     },
-                    "404": {
+                    "401": {
                         "content": {
-                            "application/octet-stream": {
+                            "application/json": {
                                 "schema": {
                                     "$ref": "#/components/schemas/api.ErrorResponse"
                                 }
                             }
                         },
-                        "description": "Not found"
+                        "description": "Unauthorized"
+                    },
+                    "500": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Internal server error"
                     }
                 },
-                "summary": "Get public share original file",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "summary": "Get repository cloud status",
                 "tags": [
-                    "public-shares"
+                    "cloud"
                 ]
             }
         },
-        "/api/v1/public/shares/{token}/assets/{assetId}/thumbnail": {
-            "get": {
-                "description": "Serve a thumbnail for an asset that belongs to this share.",
+        "/api/v1/repositories/{id}/cloud/import": {
+            "post": {
+                "description": "Start an import run for the repository's configured cloud credential.",
                 "parameters": [
                     {
-                        "description": "Share token",
-                        "in": "path",
-                        "name": "token",
-                        "required": true,
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
-                    {
-                        "description": "Asset ID",
-                        "in": "path",
-                        "name": "assetId",
-                        "required": true,
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
-                    {
-                        "description": "Thumbnail size",
-                        "in": "query",
-                        "name": "size",
-                        "schema": {
-                            "default": "medium",
-                            "enum": [
-                                "small",
-                                "medium",
-                                "large"
-                            ],
+                        "description": "Repository UUID",
+                        "in": "path",
+                        "name": "id",
+                        "required": true,
+                        "schema": {
                             "type": "string"
                         }
                     }
@@ -15566,48 +21676,64 @@ const docTemplate = `{
                 "responses": {
                     "200": {
                         "content": {
-                            "image/jpeg": {
+                            "application/json": {
                                 "schema": {
-                                    "type": "file"
+                                    "$ref": "#/components/schemas/dto.StartCloudImportResponse"
                                 }
                             }
                         },
-                        "description": "Thumbnail image file"
+                        "description": "Import started"
                     },
-                    "404": {
+                    "400": {
                         "content": {
-                            "image/jpeg": {
+                            "application/json": {
                                 "schema": {
                                     "$ref": "#/components/schemas/api.ErrorResponse"
                                 }
                             }
                         },
-                        "description": "Not found"
+                        "description": "Invalid request"
+                    },
+                    "401": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Unauthorized"
+                    },
+                    "500": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Internal server error"
                     }
                 },
-                "summary": "Get public share asset thumbnail",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "summary": "Start repository cloud import",
                 "tags": [
-                    "public-shares"
+                    "cloud"
                 ]
             }
         },
-        "/api/v1/public/shares/{token}/assets/{assetId}/web-audio": {
+        "/api/v1/repositories/{id}/config/export": {
             "get": {
-                "description": "Serve the web-optimized audio for an asset that belongs to this share.",
+                "description": "Return the repository's storage strategy and local settings (duplicate handling, processing mode, ML and original policy) as a bundle another instance can import. The repository's identity and path are not included.",
                 "parameters": [
                     {
-                        "description": "Share token",
-                        "in": "path",
-                        "name": "token",
-                        "required": true,
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
-                    {
-                        "description": "Asset ID",
+                        "description": "Repository UUID",
                         "in": "path",
-                        "name": "assetId",
+                        "name": "id",
                         "required": true,
                         "schema": {
                             "type": "string"
@@ -15617,90 +21743,160 @@ const docTemplate = `{
                 "responses": {
                     "200": {
                         "content": {
-                            "audio/mpeg": {
+                            "application/json": {
                                 "schema": {
-                                    "type": "file"
+                                    "$ref": "#/components/schemas/dto.RepositoryConfigBundleDTO"
                                 }
                             }
                         },
-                        "description": "Web-optimized audio file"
+                        "description": "Configuration bundle"
+                    },
+                    "401": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Forbidden"
                     },
                     "404": {
                         "content": {
-                            "audio/mpeg": {
+                            "application/json": {
                                 "schema": {
                                     "$ref": "#/components/schemas/api.ErrorResponse"
                                 }
                             }
                         },
-                        "description": "Not found"
+                        "description": "Repository not found"
                     }
                 },
-                "summary": "Get public share asset web audio",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "summary": "Export repository configuration",
                 "tags": [
-                    "public-shares"
+                    "repositories"
                 ]
             }
         },
-        "/api/v1/public/shares/{token}/assets/{assetId}/web-video": {
-            "get": {
-                "description": "Serve the web-optimized video for an asset that belongs to this share.",
+        "/api/v1/repositories/{id}/organize/apply": {
+            "post": {
+                "description": "Move each file of the repository's inbox to the path the preview reports and update its asset's storage path. Files are never overwritten: clashing names are resolved by the repository's duplicate handling, with overwrite treated as rename. The repository's configured strategy is not changed; update it separately so new uploads follow. Runs synchronously and stops at the first failed move, leaving earlier moves in place.",
                 "parameters": [
                     {
-                        "description": "Share token",
-                        "in": "path",
-                        "name": "token",
-                        "required": true,
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
-                    {
-                        "description": "Asset ID",
+                        "description": "Repository UUID",
                         "in": "path",
-                        "name": "assetId",
+                        "name": "id",
                         "required": true,
                         "schema": {
                             "type": "string"
                         }
                     }
                 ],
+                "requestBody": {
+                    "content": {
+                        "application/json": {
+                            "schema": {
+                                "oneOf": [
+                                    {
+                                        "type": "object"
+                                    },
+                                    {
+                                        "$ref": "#/components/schemas/dto.OrganizeRepositoryRequestDTO",
+                                        "summary": "request",
+                                        "description": "Strategy and sample size"
+                                    }
+                                ]
+                            }
+                        }
+                    },
+                    "description": "Strategy and sample size"
+                },
                 "responses": {
                     "200": {
                         "content": {
-                            "video/mp4": {
+                            "application/json": {
                                 "schema": {
-                                    "type": "file"
+                                    "$ref": "#/components/schemas/dto.OrganizeRepositoryResponseDTO"
                                 }
                             }
                         },
-                        "description": "Web-optimized video file"
+                        "description": "Moves made"
+                    },
+                    "400": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Invalid request or repository ID"
                     },
                     "404": {
                         "content": {
-                            "video/mp4": {
+                            "application/json": {
                                 "schema": {
                                     "$ref": "#/components/schemas/api.ErrorResponse"
                                 }
                             }
                         },
-                        "description": "Not found"
+                        "description": "Repository not found"
+                    },
+                    "409": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Repository is offline"
+                    },
+                    "500": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Internal server error"
                     }
                 },
-                "summary": "Get public share asset web video",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "summary": "Apply inbox organization",
                 "tags": [
-                    "public-shares"
+                    "repositories"
                 ]
             }
         },
-        "/api/v1/public/shares/{token}/download": {
+        "/api/v1/repositories/{id}/organize/preview": {
             "post": {
-                "description": "Serve the share's original files (optionally scoped to a subset via asset_ids) as a zip archive. Requires allow_download to be enabled.",
+                "description": "Compute where the date, cas or flat storage strategy puts each file of the repository's inbox, without moving anything. Date folders follow each asset's capture time from EXIF, falling back to the upload time. Only files under inbox/ are considered; the rest of the repository is the user's own layout. An empty strategy uses the repository's configured one.",
                 "parameters": [
                     {
-                        "description": "Share token",
+                        "description": "Repository UUID",
                         "in": "path",
-                        "name": "token",
+                        "name": "id",
                         "required": true,
                         "schema": {
                             "type": "string"
@@ -15716,67 +21912,133 @@ const docTemplate = `{
                                         "type": "object"
                                     },
                                     {
-                                        "$ref": "#/components/schemas/dto.PublicShareDownloadRequestDTO",
+                                        "$ref": "#/components/schemas/dto.OrganizeRepositoryRequestDTO",
                                         "summary": "request",
-                                        "description": "Optional asset ID subset"
+                                        "description": "Strategy and sample size"
                                     }
                                 ]
                             }
                         }
                     },
-                    "description": "Optional asset ID subset"
+                    "description": "Strategy and sample size"
                 },
                 "responses": {
                     "200": {
                         "content": {
-                            "application/zip": {
+                            "application/json": {
                                 "schema": {
-                                    "type": "file"
+                                    "$ref": "#/components/schemas/dto.OrganizeRepositoryResponseDTO"
                                 }
                             }
                         },
-                        "description": "Zip archive"
+                        "description": "Proposed moves"
                     },
-                    "403": {
+                    "400": {
                         "content": {
-                            "application/zip": {
+                            "application/json": {
                                 "schema": {
                                     "$ref": "#/components/schemas/api.ErrorResponse"
                                 }
                             }
                         },
-                        "description": "Downloads are not enabled for this share"
+                        "description": "Invalid request or repository ID"
                     },
                     "404": {
                         "content": {
-                            "application/zip": {
+                            "application/json": {
                                 "schema": {
                                     "$ref": "#/components/schemas/api.ErrorResponse"
                                 }
                             }
                         },
-                        "description": "Not found"
+                        "description": "Repository not found"
+                    },
+                    "409": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Repository is offline"
+                    },
+                    "500": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Internal server error"
                     }
                 },
-                "summary": "Download public share",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "summary": "Preview inbox organization",
                 "tags": [
-                    "public-shares"
+                    "repositories"
                 ]
             }
         },
-        "/api/v1/repositories": {
-            "get": {
-                "description": "Return all registered repositories.",
+        "/api/v1/repositories/{id}/process-pending": {
+            "post": {
+                "description": "Queue the processing pipeline (metadata, thumbnails, transcoding) for every asset uploaded to the repository while its processing_mode was manual. Such uploads are stored and listed with status \"deferred\" but not processed until this is called. Assets are picked up when the job runs, so uploads made before then are included.",
+                "parameters": [
+                    {
+                        "description": "Repository UUID",
+                        "in": "path",
+                        "name": "id",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
                 "responses": {
                     "200": {
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/dto.ListRepositoriesResponseDTO"
+                                    "$ref": "#/components/schemas/dto.RepositoryProcessPendingQueuedDTO"
+                                }
+                            }
+                        },
+                        "description": "Pending processing queued"
+                    },
+                    "400": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Invalid repository ID"
+                    },
+                    "404": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Repository not found"
+                    },
+                    "500": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
                                 }
                             }
                         },
-                        "description": "Repositories retrieved successfully"
+                        "description": "Internal server error"
                     }
                 },
                 "security": [
@@ -15784,13 +22046,26 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "summary": "List repositories",
+                "summary": "Process pending uploads",
                 "tags": [
                     "repositories"
                 ]
-            },
+            }
+        },
+        "/api/v1/repositories/{id}/rebuild-file-records": {
             "post": {
-                "description": "Create a repository folder under a registered Storage Location. Empty root_id selects the configured default. If the target already contains a .lumiliorepo file, it is registered instead.",
+                "description": "Walk the repository and match its files to assets: by stored path, then by content hash for assets whose file moved, and by former path or content hash for assets without a repository, which are attached to this one. Reports matched and unmatched files and assets whose file is missing. New files are not imported; queue a scan for that. Runs synchronously.",
+                "parameters": [
+                    {
+                        "description": "Repository UUID",
+                        "in": "path",
+                        "name": "id",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
                 "requestBody": {
                     "content": {
                         "application/json": {
@@ -15800,27 +22075,26 @@ const docTemplate = `{
                                         "type": "object"
                                     },
                                     {
-                                        "$ref": "#/components/schemas/dto.CreateRepositoryRequestDTO",
+                                        "$ref": "#/components/schemas/dto.RebuildFileRecordsRequestDTO",
                                         "summary": "request",
-                                        "description": "Repository name"
+                                        "description": "Rebuild options"
                                     }
                                 ]
                             }
                         }
                     },
-                    "description": "Repository name",
-                    "required": true
+                    "description": "Rebuild options"
                 },
                 "responses": {
                     "200": {
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/dto.CreateRepositoryResponseDTO"
+                                    "$ref": "#/components/schemas/dto.RebuildFileRecordsResponseDTO"
                                 }
                             }
                         },
-                        "description": "Repository created successfully"
+                        "description": "Rebuild finished"
                     },
                     "400": {
                         "content": {
@@ -15830,19 +22104,9 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Invalid request"
-                    },
-                    "401": {
-                        "content": {
-                            "application/json": {
-                                "schema": {
-                                    "$ref": "#/components/schemas/api.ErrorResponse"
-                                }
-                            }
-                        },
-                        "description": "Unauthorized"
+                        "description": "Invalid request or repository ID"
                     },
-                    "403": {
+                    "404": {
                         "content": {
                             "application/json": {
                                 "schema": {
@@ -15850,17 +22114,17 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Forbidden"
+                        "description": "Repository not found"
                     },
                     "409": {
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/dto.RepositoryConflictDTO"
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
                                 }
                             }
                         },
-                        "description": "Repository identity conflict"
+                        "description": "Repository is offline"
                     },
                     "500": {
                         "content": {
@@ -15878,15 +22142,15 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "summary": "Create repository",
+                "summary": "Rebuild repository file records",
                 "tags": [
                     "repositories"
                 ]
             }
         },
-        "/api/v1/repositories/{id}": {
-            "delete": {
-                "description": "Remove a repository from the registry. Does not delete files on disk.",
+        "/api/v1/repositories/{id}/scan": {
+            "post": {
+                "description": "Queue a manual scan for a repository free workspace.",
                 "parameters": [
                     {
                         "description": "Repository UUID",
@@ -15898,18 +22162,37 @@ const docTemplate = `{
                         }
                     }
                 ],
+                "requestBody": {
+                    "content": {
+                        "application/json": {
+                            "schema": {
+                                "oneOf": [
+                                    {
+                                        "type": "object"
+                                    },
+                                    {
+                                        "$ref": "#/components/schemas/dto.RepositoryScanRequestDTO",
+                                        "summary": "request",
+                                        "description": "Scan request"
+                                    }
+                                ]
+                            }
+                        }
+                    },
+                    "description": "Scan request"
+                },
                 "responses": {
                     "200": {
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/api.SuccessResponse"
+                                    "$ref": "#/components/schemas/dto.RepositoryScanQueuedDTO"
                                 }
                             }
                         },
-                        "description": "Repository deleted successfully"
+                        "description": "Repository scan queued successfully"
                     },
-                    "404": {
+                    "400": {
                         "content": {
                             "application/json": {
                                 "schema": {
@@ -15917,7 +22200,27 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Repository not found"
+                        "description": "Invalid request"
+                    },
+                    "401": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Forbidden"
                     }
                 },
                 "security": [
@@ -15925,13 +22228,15 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "summary": "Delete repository",
+                "summary": "Queue repository scan",
                 "tags": [
                     "repositories"
                 ]
-            },
+            }
+        },
+        "/api/v1/repositories/{id}/scans": {
             "get": {
-                "description": "Return a single repository.",
+                "description": "List recent scan runs for a repository.",
                 "parameters": [
                     {
                         "description": "Repository UUID",
@@ -15941,6 +22246,24 @@ const docTemplate = `{
                         "schema": {
                             "type": "string"
                         }
+                    },
+                    {
+                        "description": "Limit",
+                        "in": "query",
+                        "name": "limit",
+                        "schema": {
+                            "default": 20,
+                            "type": "integer"
+                        }
+                    },
+                    {
+                        "description": "Offset",
+                        "in": "query",
+                        "name": "offset",
+                        "schema": {
+                            "default": 0,
+                            "type": "integer"
+                        }
                     }
                 ],
                 "responses": {
@@ -15948,21 +22271,11 @@ const docTemplate = `{
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/dto.RepositoryDTO"
-                                }
-                            }
-                        },
-                        "description": "Repository retrieved successfully"
-                    },
-                    "404": {
-                        "content": {
-                            "application/json": {
-                                "schema": {
-                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                    "$ref": "#/components/schemas/dto.RepositoryScanRunListDTO"
                                 }
                             }
                         },
-                        "description": "Repository not found"
+                        "description": "Repository scan runs retrieved successfully"
                     }
                 },
                 "security": [
@@ -15970,13 +22283,15 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "summary": "Get repository",
+                "summary": "List repository scans",
                 "tags": [
                     "repositories"
                 ]
-            },
-            "patch": {
-                "description": "Update mutable repository fields (name, storage_strategy, local_settings). Repository ownership is fixed to the Host Owner.",
+            }
+        },
+        "/api/v1/repositories/{id}/scans/latest": {
+            "get": {
+                "description": "Return the latest scan run for a repository.",
                 "parameters": [
                     {
                         "description": "Repository UUID",
@@ -15988,46 +22303,16 @@ const docTemplate = `{
                         }
                     }
                 ],
-                "requestBody": {
-                    "content": {
-                        "application/json": {
-                            "schema": {
-                                "oneOf": [
-                                    {
-                                        "type": "object"
-                                    },
-                                    {
-                                        "$ref": "#/components/schemas/dto.UpdateRepositoryRequestDTO",
-                                        "summary": "request",
-                                        "description": "Fields to update"
-                                    }
-                                ]
-                            }
-                        }
-                    },
-                    "description": "Fields to update",
-                    "required": true
-                },
                 "responses": {
                     "200": {
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/dto.RepositoryDTO"
-                                }
-                            }
-                        },
-                        "description": "Repository updated successfully"
-                    },
-                    "400": {
-                        "content": {
-                            "application/json": {
-                                "schema": {
-                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                    "$ref": "#/components/schemas/dto.RepositoryScanRunDTO"
                                 }
                             }
                         },
-                        "description": "Invalid request"
+                        "description": "Latest repository scan retrieved successfully"
                     },
                     "404": {
                         "content": {
@@ -16037,7 +22322,7 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Repository not found"
+                        "description": "No scan run found"
                     }
                 },
                 "security": [
@@ -16045,15 +22330,15 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "summary": "Update repository",
+                "summary": "Get latest repository scan",
                 "tags": [
                     "repositories"
                 ]
             }
         },
-        "/api/v1/repositories/{id}/cloud": {
+        "/api/v1/repositories/{id}/scans/stream": {
             "get": {
-                "description": "Return cloud credential binding and latest import run for a repository.",
+                "description": "Server-Sent Events stream of a repository's scan runs. A \"scan\" event carries the latest run on connect, then every run as it starts, fails or completes, without polling. \"heartbeat\" events keep the connection open; a \"closed\" event ends the stream when the repository is removed or the server shuts down.",
                 "parameters": [
                     {
                         "description": "Repository UUID",
@@ -16068,43 +22353,43 @@ const docTemplate = `{
                 "responses": {
                     "200": {
                         "content": {
-                            "application/json": {
+                            "text/event-stream": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/dto.RepositoryCloudStatusDTO"
+                                    "$ref": "#/components/schemas/dto.RepositoryScanRunDTO"
                                 }
                             }
                         },
-                        "description": "Repository cloud status"
+                        "description": "Stream of scan run events"
                     },
                     "400": {
                         "content": {
-                            "application/json": {
+                            "text/event-stream": {
                                 "schema": {
                                     "$ref": "#/components/schemas/api.ErrorResponse"
                                 }
                             }
                         },
-                        "description": "Invalid request"
+                        "description": "Invalid repository ID"
                     },
-                    "401": {
+                    "404": {
                         "content": {
-                            "application/json": {
+                            "text/event-stream": {
                                 "schema": {
                                     "$ref": "#/components/schemas/api.ErrorResponse"
                                 }
                             }
                         },
-                        "description": "Unauthorized"
+                        "description": "Repository not found"
                     },
-                    "500": {
+                    "503": {
                         "content": {
-                            "application/json": {
+                            "text/event-stream": {
                                 "schema": {
                                     "$ref": "#/components/schemas/api.ErrorResponse"
                                 }
                             }
                         },
-                        "description": "Internal server error"
+                        "description": "Server shutting down"
                     }
                 },
                 "security": [
@@ -16112,15 +22397,15 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "summary": "Get repository cloud status",
+                "summary": "Stream repository scan status",
                 "tags": [
-                    "cloud"
+                    "repositories"
                 ]
             }
         },
-        "/api/v1/repositories/{id}/cloud/import": {
+        "/api/v1/repositories/{id}/size-check": {
             "post": {
-                "description": "Start an import run for the repository's configured cloud credential.",
+                "description": "Queue a quick integrity check that compares every asset's recorded file size with its original on disk, without re-hashing. Assets whose size differs, a sign of truncation or outside modification, are listed by GET /repositories/{id}/size-mismatches once the check has run.",
                 "parameters": [
                     {
                         "description": "Repository UUID",
@@ -16137,11 +22422,11 @@ const docTemplate = `{
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/dto.StartCloudImportResponse"
+                                    "$ref": "#/components/schemas/dto.RepositorySizeCheckQueuedDTO"
                                 }
                             }
                         },
-                        "description": "Import started"
+                        "description": "Size check queued"
                     },
                     "400": {
                         "content": {
@@ -16151,9 +22436,9 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Invalid request"
+                        "description": "Invalid repository ID"
                     },
-                    "401": {
+                    "404": {
                         "content": {
                             "application/json": {
                                 "schema": {
@@ -16161,7 +22446,7 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Unauthorized"
+                        "description": "Repository not found"
                     },
                     "500": {
                         "content": {
@@ -16179,55 +22464,36 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "summary": "Start repository cloud import",
+                "summary": "Queue repository size check",
                 "tags": [
-                    "cloud"
+                    "repositories"
                 ]
             }
         },
-        "/api/v1/repositories/{id}/scan": {
-            "post": {
-                "description": "Queue a manual scan for a repository free workspace.",
+        "/api/v1/repositories/{id}/size-mismatches": {
+            "get": {
+                "description": "List the assets whose original on disk had a different size than recorded at import when the repository was last size-checked. Assets re-imported, moved or deleted since are left out. Empty until a size check has run.",
                 "parameters": [
                     {
                         "description": "Repository UUID",
                         "in": "path",
                         "name": "id",
                         "required": true,
-                        "schema": {
-                            "type": "string"
-                        }
-                    }
-                ],
-                "requestBody": {
-                    "content": {
-                        "application/json": {
-                            "schema": {
-                                "oneOf": [
-                                    {
-                                        "type": "object"
-                                    },
-                                    {
-                                        "$ref": "#/components/schemas/dto.RepositoryScanRequestDTO",
-                                        "summary": "request",
-                                        "description": "Scan request"
-                                    }
-                                ]
-                            }
+                        "schema": {
+                            "type": "string"
                         }
-                    },
-                    "description": "Scan request"
-                },
+                    }
+                ],
                 "responses": {
                     "200": {
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/dto.RepositoryScanQueuedDTO"
+                                    "$ref": "#/components/schemas/dto.RepositorySizeMismatchesDTO"
                                 }
                             }
                         },
-                        "description": "Repository scan queued successfully"
+                        "description": "Size mismatches"
                     },
                     "400": {
                         "content": {
@@ -16237,9 +22503,9 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Invalid request"
+                        "description": "Invalid repository ID"
                     },
-                    "401": {
+                    "404": {
                         "content": {
                             "application/json": {
                                 "schema": {
@@ -16247,9 +22513,9 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Unauthorized"
+                        "description": "Repository not found"
                     },
-                    "403": {
+                    "500": {
                         "content": {
                             "application/json": {
                                 "schema": {
@@ -16257,7 +22523,7 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "Forbidden"
+                        "description": "Internal server error"
                     }
                 },
                 "security": [
@@ -16265,42 +22531,24 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "summary": "Queue repository scan",
+                "summary": "List repository size mismatches",
                 "tags": [
                     "repositories"
                 ]
             }
         },
-        "/api/v1/repositories/{id}/scans": {
-            "get": {
-                "description": "List recent scan runs for a repository.",
+        "/api/v1/repositories/{id}/stacks/detect": {
+            "post": {
+                "description": "Merges RAW/JPEG and Live Photo components into logical media items, then detects burst presentation stacks",
                 "parameters": [
                     {
-                        "description": "Repository UUID",
+                        "description": "Repository ID",
                         "in": "path",
                         "name": "id",
                         "required": true,
                         "schema": {
                             "type": "string"
                         }
-                    },
-                    {
-                        "description": "Limit",
-                        "in": "query",
-                        "name": "limit",
-                        "schema": {
-                            "default": 20,
-                            "type": "integer"
-                        }
-                    },
-                    {
-                        "description": "Offset",
-                        "in": "query",
-                        "name": "offset",
-                        "schema": {
-                            "default": 0,
-                            "type": "integer"
-                        }
                     }
                 ],
                 "responses": {
@@ -16308,11 +22556,11 @@ const docTemplate = `{
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/dto.RepositoryScanRunListDTO"
+                                    "$ref": "#/components/schemas/dto.AutoDetectStacksResponseDTO"
                                 }
                             }
                         },
-                        "description": "Repository scan runs retrieved successfully"
+                        "description": "OK"
                     }
                 },
                 "security": [
@@ -16320,15 +22568,15 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "summary": "List repository scans",
+                "summary": "Auto-detect stacks",
                 "tags": [
                     "repositories"
                 ]
             }
         },
-        "/api/v1/repositories/{id}/scans/latest": {
+        "/api/v1/repositories/{id}/stats": {
             "get": {
-                "description": "Return the latest scan run for a repository.",
+                "description": "Count the live (not trashed) assets of a repository by type, liked and rated, with their average rating, total original size and upload time range. owner_id restricts the counts to one owner's assets.",
                 "parameters": [
                     {
                         "description": "Repository UUID",
@@ -16338,6 +22586,14 @@ const docTemplate = `{
                         "schema": {
                             "type": "string"
                         }
+                    },
+                    {
+                        "description": "Only count assets of this owner",
+                        "in": "query",
+                        "name": "owner_id",
+                        "schema": {
+                            "type": "integer"
+                        }
                     }
                 ],
                 "responses": {
@@ -16345,11 +22601,21 @@ const docTemplate = `{
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/dto.RepositoryScanRunDTO"
+                                    "$ref": "#/components/schemas/dto.RepositoryAssetStatsDTO"
                                 }
                             }
                         },
-                        "description": "Latest repository scan retrieved successfully"
+                        "description": "Repository asset statistics"
+                    },
+                    "400": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Invalid repository ID or owner_id"
                     },
                     "404": {
                         "content": {
@@ -16359,44 +22625,17 @@ const docTemplate = `{
                                 }
                             }
                         },
-                        "description": "No scan run found"
-                    }
-                },
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "summary": "Get latest repository scan",
-                "tags": [
-                    "repositories"
-                ]
-            }
-        },
-        "/api/v1/repositories/{id}/stacks/detect": {
-            "post": {
-                "description": "Merges RAW/JPEG and Live Photo components into logical media items, then detects burst presentation stacks",
-                "parameters": [
-                    {
-                        "description": "Repository ID",
-                        "in": "path",
-                        "name": "id",
-                        "required": true,
-                        "schema": {
-                            "type": "string"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
+                        "description": "Repository not found"
+                    },
+                    "500": {
                         "content": {
                             "application/json": {
                                 "schema": {
-                                    "$ref": "#/components/schemas/dto.AutoDetectStacksResponseDTO"
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
                                 }
                             }
                         },
-                        "description": "OK"
+                        "description": "Internal server error"
                     }
                 },
                 "security": [
@@ -16404,7 +22643,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "summary": "Auto-detect stacks",
+                "summary": "Get repository asset statistics",
                 "tags": [
                     "repositories"
                 ]
@@ -17776,6 +24015,85 @@ const docTemplate = `{
                 ]
             }
         },
+        "/api/v1/stats/overview": {
+            "get": {
+                "description": "Get aggregate library statistics for a home dashboard: total assets and bytes, counts by asset type, assets added in the last 30 days, the most-used cameras, and storage per repository. Results are cached for a short time.",
+                "parameters": [
+                    {
+                        "description": "Optional repository UUID filter",
+                        "in": "query",
+                        "name": "repository_id",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Optional owner user ID filter; users are always limited to their own assets, admins may pick any owner",
+                        "in": "query",
+                        "name": "owner_id",
+                        "schema": {
+                            "type": "integer"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/handler.StatsOverviewResponse"
+                                }
+                            }
+                        },
+                        "description": "OK"
+                    },
+                    "400": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "owner_id sent without authentication"
+                    },
+                    "403": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "owner_id names another user"
+                    },
+                    "500": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Internal Server Error"
+                    }
+                },
+                "summary": "Get library overview",
+                "tags": [
+                    "stats"
+                ]
+            }
+        },
         "/api/v1/stats/time-distribution": {
             "get": {
                 "description": "Get shooting time distribution by hour or month",
@@ -17840,6 +24158,68 @@ const docTemplate = `{
                 ]
             }
         },
+        "/api/v1/uploads/batch/{batch_id}/cancel": {
+            "post": {
+                "description": "Cancel the ingest jobs of a batch upload that have not started yet and remove their staged files, then mark the batch cancelled so further uploads into it are refused. Files already ingested, or being ingested, are left alone. Batches are named by the batch_id sent with POST /api/v1/assets/batch. Cancelling a cancelled batch again is harmless.",
+                "parameters": [
+                    {
+                        "description": "Upload batch UUID",
+                        "in": "path",
+                        "name": "batch_id",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/dto.CancelUploadBatchResponseDTO"
+                                }
+                            }
+                        },
+                        "description": "Batch cancelled"
+                    },
+                    "400": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Invalid batch ID"
+                    },
+                    "404": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Upload batch not found"
+                    },
+                    "500": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/api.ErrorResponse"
+                                }
+                            }
+                        },
+                        "description": "Failed to cancel upload batch"
+                    }
+                },
+                "summary": "Cancel an upload batch",
+                "tags": [
+                    "assets"
+                ]
+            }
+        },
         "/api/v1/users": {
             "get": {
                 "description": "List users with ownership statistics for administrator management views.",
@@ -18289,6 +24669,37 @@ const docTemplate = `{
                     "users"
                 ]
             }
+        },
+        "/readyz": {
+            "get": {
+                "description": "Report whether the server can take uploads. A fresh install awaiting first-run setup is ready to serve setup; an initialized server whose primary repository is missing or offline is degraded.",
+                "responses": {
+                    "200": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/handler.ReadinessResponse"
+                                }
+                            }
+                        },
+                        "description": "Server is ready or awaiting setup"
+                    },
+                    "503": {
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "$ref": "#/components/schemas/handler.ReadinessResponse"
+                                }
+                            }
+                        },
+                        "description": "Server is degraded"
+                    }
+                },
+                "summary": "Readiness check",
+                "tags": [
+                    "Health"
+                ]
+            }
         }
     },
     "openapi": "3.1.0",
@@ -14,6 +14,7 @@ require (
 
 require (
 	github.com/chyroc/gorequests v0.33.0
+	github.com/fsnotify/fsnotify v1.8.0
 	github.com/gin-gonic/gin v1.10.0
 	github.com/go-webauthn/webauthn v0.16.0
 	github.com/golang-jwt/jwt/v5 v5.3.1
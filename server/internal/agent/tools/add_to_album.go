@@ -145,6 +145,8 @@ func resumeAddToAlbum(ctx context.Context, deps *core.ToolDependencies, toolName
 			return &AddToAlbumOutput{Error: refErr, AlbumID: int(state.AlbumID)}, nil
 		}
 	}
+	// Best effort: an album without a cover still shows its first asset.
+	_, _ = deps.Queries.RefreshAlbumCover(ctx, repo.RefreshAlbumCoverParams{AlbumID: state.AlbumID})
 
 	message := fmt.Sprintf("Added %d photos to album", r.Count())
 	sendSuccess(deps, toolName, execID, start, message, &core.DataPayload{RefID: r.ID, Count: r.Count()})
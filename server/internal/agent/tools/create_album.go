@@ -164,6 +164,8 @@ func resumeCreateAlbum(ctx context.Context, deps *core.ToolDependencies, toolNam
 			return &CreateAlbumOutput{Error: refErr, AlbumID: int(album.AlbumID)}, nil
 		}
 	}
+	// Best effort: an album without a cover still shows its first asset.
+	_, _ = deps.Queries.RefreshAlbumCover(ctx, repo.RefreshAlbumCoverParams{AlbumID: album.AlbumID})
 
 	message := fmt.Sprintf("Created album %q with %d photos", state.Title, r.Count())
 	sendSuccess(deps, toolName, execID, start, message, &core.DataPayload{RefID: r.ID, Count: r.Count()})
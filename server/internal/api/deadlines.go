@@ -0,0 +1,22 @@
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// longLived lifts the server-wide read and write timeouts for one request.
+// Large uploads, media streams, archive downloads and SSE feeds legitimately
+// outlive them; the read-header and idle timeouts still apply.
+func longLived() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		rc := http.NewResponseController(c.Writer)
+		// Errors mean the connection has no deadlines to lift (e.g. tests
+		// using a recorder), which is the state we want anyway.
+		_ = rc.SetReadDeadline(time.Time{})
+		_ = rc.SetWriteDeadline(time.Time{})
+		c.Next()
+	}
+}
@@ -0,0 +1,51 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func newDeadlineTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	slow := func(c *gin.Context) {
+		time.Sleep(300 * time.Millisecond)
+		c.String(http.StatusOK, "done")
+	}
+	r.GET("/bounded", slow)
+	r.GET("/long-lived", longLived(), slow)
+
+	srv := httptest.NewUnstartedServer(r)
+	srv.Config.WriteTimeout = 100 * time.Millisecond
+	srv.Start()
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestLongLivedLiftsWriteTimeout(t *testing.T) {
+	srv := newDeadlineTestServer(t)
+
+	resp, err := srv.Client().Get(srv.URL + "/long-lived")
+	if err != nil {
+		t.Fatalf("long-lived request failed: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+}
+
+func TestBoundedRouteKeepsWriteTimeout(t *testing.T) {
+	srv := newDeadlineTestServer(t)
+
+	resp, err := srv.Client().Get(srv.URL + "/bounded")
+	if err == nil {
+		resp.Body.Close()
+		t.Fatal("expected the write timeout to cut off a slow response")
+	}
+}
@@ -0,0 +1,22 @@
+package dto
+
+import "time"
+
+// ActivityEventDTO is one library change in the activity feed.
+type ActivityEventDTO struct {
+	ID         int64          `json:"id" example:"1024"`
+	Type       string         `json:"type" example:"asset.added" enums:"asset.added,asset.deleted,asset.rating_updated,album.created,album.updated,album.deleted,album.asset_added,album.asset_removed"`
+	AssetID    *string        `json:"asset_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440000"`
+	AlbumID    *int32         `json:"album_id,omitempty" example:"12"`
+	Payload    map[string]any `json:"payload" swaggertype:"object"`
+	OccurredAt time.Time      `json:"occurred_at"`
+}
+
+// ActivityFeedDTO lists changes oldest first. Pass next_since and
+// next_after_id back as since and after_id to continue after the last event.
+type ActivityFeedDTO struct {
+	Events      []ActivityEventDTO `json:"events"`
+	NextSince   time.Time          `json:"next_since"`
+	NextAfterID int64              `json:"next_after_id" example:"1024"`
+	HasMore     bool               `json:"has_more" example:"false"`
+}
@@ -1,6 +1,7 @@
 package dto
 
 import (
+	"encoding/json"
 	"time"
 
 	"server/internal/db/repo"
@@ -16,6 +17,14 @@ type CreateAlbumRequestDTO struct {
 	AlbumType    *string `json:"album_type,omitempty" binding:"omitempty,oneof=default bio"`
 }
 
+// CreateSmartAlbumRequestDTO represents the request structure for creating a smart album
+type CreateSmartAlbumRequestDTO struct {
+	AlbumName   string  `json:"album_name" binding:"required"`
+	Description *string `json:"description"`
+	// Rules is the filter the album's assets must match, as sent to POST /assets/list. An empty filter matches every asset of the owner.
+	Rules AssetFilterDTO `json:"rules"`
+}
+
 // UpdateAlbumRequestDTO represents the request structure for updating an album
 type UpdateAlbumRequestDTO struct {
 	AlbumName    *string `json:"album_name"`
@@ -34,6 +43,9 @@ type AlbumDTO struct {
 	Description  *string   `json:"description"`
 	CoverAssetID *string   `json:"cover_asset_id"`
 	AlbumType    string    `json:"album_type"`
+	// IsSmart marks albums whose assets are whatever Rules matches when read, rather than a stored list.
+	IsSmart bool            `json:"is_smart"`
+	Rules   *AssetFilterDTO `json:"rules,omitempty"`
 }
 
 // ToAlbumDTO converts a repo.Album to AlbumDTO
@@ -51,6 +63,13 @@ func ToAlbumDTO(a repo.Album) AlbumDTO {
 		s := uuid.UUID(a.CoverAssetID.Bytes).String()
 		coverID = &s
 	}
+	var rules *AssetFilterDTO
+	if a.Rules != nil {
+		rules = &AssetFilterDTO{}
+		if err := json.Unmarshal(a.Rules, rules); err != nil {
+			rules = nil
+		}
+	}
 
 	return AlbumDTO{
 		AlbumID:      a.AlbumID,
@@ -61,6 +80,8 @@ func ToAlbumDTO(a repo.Album) AlbumDTO {
 		Description:  a.Description,
 		CoverAssetID: coverID,
 		AlbumType:    string(a.AlbumType),
+		IsSmart:      a.Rules != nil,
+		Rules:        rules,
 	}
 }
 
@@ -113,8 +134,44 @@ type UpdateAssetPositionRequestDTO struct {
 	Position *int32 `json:"position" binding:"required"`
 }
 
+// ReorderAlbumAssetsRequestDTO lists every asset of an album in its new order.
+type ReorderAlbumAssetsRequestDTO struct {
+	AssetIDs []string `json:"asset_ids" binding:"required"`
+}
+
+// AlbumAssetOrderResponseDTO lists an album's assets in their new order; an
+// asset's position is its index in asset_ids plus one.
+type AlbumAssetOrderResponseDTO struct {
+	AlbumID  int64    `json:"album_id" example:"1"`
+	AssetIDs []string `json:"asset_ids"`
+}
+
 type RebuildAlbumBioClipResponseDTO struct {
 	Status       string `json:"status" example:"queued"`
 	Message      string `json:"message" example:"BioCLIP processing queued successfully"`
 	QueuedAssets int    `json:"queued_assets" example:"12"`
 }
+
+// AlbumDuplicateGroupDTO is a set of albums sharing one name and type, oldest first
+type AlbumDuplicateGroupDTO struct {
+	AlbumName string                `json:"album_name" example:"Japan 2024"`
+	AlbumType string                `json:"album_type" example:"default"`
+	Albums    []GetAlbumResponseDTO `json:"albums"`
+}
+
+type AlbumDuplicatesResponseDTO struct {
+	Groups []AlbumDuplicateGroupDTO `json:"groups"`
+	Count  int                      `json:"count"`
+}
+
+// MergeAlbumsRequestDTO represents the request structure for merging albums into a target
+type MergeAlbumsRequestDTO struct {
+	TargetAlbumID  int32   `json:"target_album_id" binding:"required,gt=0" example:"12"`
+	SourceAlbumIDs []int32 `json:"source_album_ids" binding:"required,min=1,max=100,dive,gt=0" example:"14,15"`
+}
+
+type MergeAlbumsResponseDTO struct {
+	Album           GetAlbumResponseDTO `json:"album"`
+	AssetsAdded     int64               `json:"assets_added" example:"37"`
+	RemovedAlbumIDs []int32             `json:"removed_album_ids"`
+}
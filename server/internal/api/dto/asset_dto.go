@@ -6,14 +6,23 @@ import (
 	"time"
 
 	"server/internal/db/dbtypes"
+	"server/internal/db/dbtypes/status"
 	"server/internal/db/repo"
 
 	"github.com/google/uuid"
 )
 
-// UploadAssetRequestDTO represents the request structure for asset upload
+// UploadAssetRequestDTO represents the request structure for asset upload.
+// The capture fields are optional client hints that only fill metadata the
+// file's EXIF does not provide.
 type UploadAssetRequestDTO struct {
-	RepositoryID string `form:"repository_id" binding:"omitempty,uuid" example:"550e8400-e29b-41d4-a716-446655440000"`
+	RepositoryID string   `form:"repository_id" binding:"omitempty,uuid" example:"550e8400-e29b-41d4-a716-446655440000"`
+	TakenTime    string   `form:"taken_time" example:"2025-07-14T09:30:00Z"`
+	Latitude     *float64 `form:"latitude" binding:"omitempty,min=-90,max=90" example:"48.8584"`
+	Longitude    *float64 `form:"longitude" binding:"omitempty,min=-180,max=180" example:"2.2945"`
+	Device       string   `form:"device" binding:"omitempty,max=128" example:"iPhone 15 Pro"`
+	// Dedupe links to an existing asset with the X-Content-Hash header's hash instead of storing the file again.
+	Dedupe bool `form:"dedupe" example:"true"`
 }
 
 // BatchUploadRequestDTO represents the request structure for batch upload
@@ -45,6 +54,11 @@ type RebuildAssetIndexesRequestDTO struct {
 	// after switching the embedding model (drop+refill) so no two models' vectors
 	// are mixed. Honored only when the semantic task is included.
 	ResetSemantic *bool `json:"reset_semantic,omitempty" example:"false"`
+	// Date bounds the photos by capture time, falling back to upload time.
+	Date           *DateRangeDTO `json:"date,omitempty"`
+	ViewerTimezone string        `json:"viewer_timezone,omitempty" example:"America/New_York"`
+	// DryRun counts the matching photos without queueing anything.
+	DryRun bool `json:"dry_run,omitempty" example:"false"`
 }
 
 type RebuildAssetIndexesResponseDTO struct {
@@ -56,6 +70,10 @@ type RebuildAssetIndexesResponseDTO struct {
 	Limit          int      `json:"limit" example:"200"`
 	MissingOnly    bool     `json:"missing_only" example:"true"`
 	RepositoryID   *string  `json:"repository_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440000"`
+	// MatchingAssets is how many photos the rebuild visits. Missing-only
+	// runs queue at most limit of them per request.
+	MatchingAssets int64 `json:"matching_assets" example:"1200"`
+	DryRun         bool  `json:"dry_run" example:"false"`
 }
 
 type IndexingRepositoryOptionDTO struct {
@@ -102,6 +120,12 @@ type UploadResponseDTO struct {
 	Size        int64  `json:"size" example:"1048576"`
 	ContentHash string `json:"content_hash" example:"abcd1234567890"`
 	Message     string `json:"message" example:"File received and queued for processing"`
+	// AssetID is the existing asset a dedupe upload was linked to.
+	AssetID string `json:"asset_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440000"`
+	// DuplicateAction is what the repository's duplicate file name handling
+	// does because the file name is already in use: renamed, overwritten or
+	// skipped. Empty when the name is free.
+	DuplicateAction string `json:"duplicate_action,omitempty" example:"renamed"`
 }
 
 // BatchUploadResponseDTO represents the response structure for batch upload
@@ -112,6 +136,7 @@ type BatchUploadResponseDTO struct {
 // BatchUploadResultDTO represents a single result in a batch upload
 type BatchUploadResultDTO struct {
 	Success     bool    `json:"success"`
+	SessionID   string  `json:"session_id,omitempty"`
 	FileName    string  `json:"file_name,omitempty"`
 	ContentHash string  `json:"content_hash"`
 	TaskID      *int64  `json:"task_id,omitempty"`
@@ -119,6 +144,27 @@ type BatchUploadResultDTO struct {
 	Size        *int64  `json:"size,omitempty"`
 	Message     *string `json:"message,omitempty"`
 	Error       *string `json:"error,omitempty"`
+	// DuplicateAction is as in UploadResponseDTO.
+	DuplicateAction *string `json:"duplicate_action,omitempty" example:"renamed"`
+}
+
+// BatchDeleteAssetsRequestDTO lists up to 100 assets to delete in one request.
+// Hard has the same meaning as the hard query flag on DELETE /assets/{id}.
+type BatchDeleteAssetsRequestDTO struct {
+	AssetIDs []string `json:"asset_ids" binding:"required,max=100" example:"550e8400-e29b-41d4-a716-446655440000,550e8400-e29b-41d4-a716-446655440001"`
+	Hard     bool     `json:"hard" example:"false"`
+}
+
+// BatchDeleteResponseDTO holds one result per requested asset ID, in request order.
+type BatchDeleteResponseDTO struct {
+	Results []BatchDeleteResultDTO `json:"results"`
+}
+
+// BatchDeleteResultDTO reports the outcome of deleting a single asset.
+type BatchDeleteResultDTO struct {
+	AssetID string  `json:"asset_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Success bool    `json:"success"`
+	Error   *string `json:"error,omitempty"`
 }
 
 // UploadPrecheckFileDTO is one candidate file in an upload precheck request.
@@ -158,6 +204,28 @@ type UploadPrecheckResponseDTO struct {
 	DuplicateCount int                       `json:"duplicate_count" example:"3"`
 }
 
+// CheckHashesRequestDTO is the body for POST /assets/check-hashes. Hashes are
+// full-content BLAKE3 digests in hex, as reported by upload responses.
+type CheckHashesRequestDTO struct {
+	Hashes       []string `json:"hashes" binding:"required,min=1,max=1000"`
+	RepositoryID string   `json:"repository_id,omitempty" binding:"omitempty,uuid" example:"550e8400-e29b-41d4-a716-446655440000"`
+}
+
+// CheckHashesResultDTO reports one requested hash. AssetIDs lists every
+// visible asset with that content, oldest first.
+type CheckHashesResultDTO struct {
+	Hash     string   `json:"hash" example:"af1349b9f5f9a1a6a0404dea36dcc9499bcb25c9adc112b7cc9a93cae41f3262"`
+	Exists   bool     `json:"exists" example:"true"`
+	AssetIDs []string `json:"asset_ids,omitempty"`
+}
+
+// CheckHashesResponseDTO is the response for POST /assets/check-hashes.
+// Results follow the (deduplicated) request order.
+type CheckHashesResponseDTO struct {
+	Results       []CheckHashesResultDTO `json:"results"`
+	ExistingCount int                    `json:"existing_count" example:"2"`
+}
+
 // UploadConfigResponseDTO represents the response structure for upload configuration
 type UploadConfigResponseDTO struct {
 	ChunkSize           int64 `json:"chunk_size"`
@@ -186,10 +254,68 @@ type UploadSessionResponseDTO struct {
 	TaskID         *int64 `json:"task_id,omitempty"`
 }
 
+// CreateResumableUploadRequestDTO starts an offset-based upload of one file,
+// sent in any number of PATCH chunks.
+type CreateResumableUploadRequestDTO struct {
+	Filename     string `json:"filename" binding:"required" example:"holiday.mov"`
+	Size         int64  `json:"size" binding:"required,gte=1" example:"4294967296"`
+	ContentType  string `json:"content_type,omitempty" example:"video/quicktime"`
+	RepositoryID string `json:"repository_id,omitempty" binding:"omitempty,uuid"`
+}
+
+// ResumableUploadDTO is the state of a resumable upload. Offset is the number
+// of bytes received, which is where the next chunk must start.
+type ResumableUploadDTO struct {
+	SessionID string `json:"session_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Filename  string `json:"filename" example:"holiday.mov"`
+	Size      int64  `json:"size" example:"4294967296"`
+	Offset    int64  `json:"offset" example:"1073741824"`
+	TaskID    *int64 `json:"task_id,omitempty"`
+}
+
 func stringPtr(value string) *string {
 	return &value
 }
 
+// blurHashFromMetadata reads the blurhash the thumbnail task stores in
+// specific_metadata, or nil before thumbnails exist.
+func blurHashFromMetadata(metadata dbtypes.SpecificMetadata) *string {
+	if len(metadata) == 0 {
+		return nil
+	}
+	var fields struct {
+		BlurHash string `json:"blurhash"`
+	}
+	if err := json.Unmarshal(metadata, &fields); err != nil || fields.BlurHash == "" {
+		return nil
+	}
+	return &fields.BlurHash
+}
+
+// processingFromStatus reads the state of an asset status and, for failed and
+// warning assets, the reason to show for it.
+func processingFromStatus(raw []byte) (string, *string) {
+	if len(raw) == 0 {
+		return "", nil
+	}
+	current, err := status.FromJSONB(raw)
+	if err != nil {
+		return "", nil
+	}
+	if current.State != status.StateFailed && current.State != status.StateWarning {
+		return string(current.State), nil
+	}
+	reason := current.Message
+	if n := len(current.Errors); n > 0 {
+		latest := current.Errors[n-1]
+		reason = latest.Task + ": " + latest.Error
+	}
+	if reason == "" {
+		return string(current.State), nil
+	}
+	return string(current.State), &reason
+}
+
 // SessionProgressDTO represents progress information for an upload session
 type SessionProgressDTO struct {
 	SessionID       string    `json:"session_id"`
@@ -234,6 +360,16 @@ type UploadJobStatusResponseDTO struct {
 	Jobs []UploadJobStatusDTO `json:"jobs"`
 }
 
+// CancelUploadBatchResponseDTO reports the outcome of cancelling an upload
+// batch. Jobs that had already started or finished are left alone and are
+// counted in TotalJobs only.
+type CancelUploadBatchResponseDTO struct {
+	BatchID       string `json:"batch_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Status        string `json:"status" example:"cancelled"`
+	CancelledJobs int    `json:"cancelled_jobs" example:"120"`
+	TotalJobs     int    `json:"total_jobs" example:"150"`
+}
+
 // AssetDTO represents an asset
 type AssetDTO struct {
 	AssetID              string                          `json:"asset_id"`
@@ -258,8 +394,19 @@ type AssetDTO struct {
 	Metadata             dbtypes.SpecificMetadata        `json:"specific_metadata" swaggertype:"object" oneOf:"dbtypes.PhotoSpecificMetadata,dbtypes.VideoSpecificMetadata,dbtypes.AudioSpecificMetadata"`
 	Status               []byte                          `json:"status"`
 	SpeciesPredictions   []dbtypes.SpeciesPredictionMeta `json:"species_predictions,omitempty"`
+	// ProcessingStatus is the state of Status.
+	ProcessingStatus string `json:"processing_status,omitempty" example:"failed" enums:"processing,complete,warning,failed,deferred"`
+	// ProcessingError is why a failed or warning asset failed: its latest task error, else the status message.
+	ProcessingError *string `json:"processing_error,omitempty" example:"thumbnail_asset: unsupported image format"`
+	// BlurHash is a placeholder to paint until the thumbnail loads.
+	BlurHash *string `json:"blurhash,omitempty" example:"LEHV6nWB2yk8pyo0adR*.7kCMdnj"`
 	// Stack fields (populated when stack mode is enabled)
 	Stack *StackPreviewDTO `json:"stack,omitempty"`
+	// Signed media URLs (populated only when the request sets include_urls)
+	OriginalURL   *string           `json:"original_url,omitempty" example:"/api/v1/assets/550e8400-e29b-41d4-a716-446655440000/original?mt=eyJhbGciOi..."`
+	ThumbnailURLs map[string]string `json:"thumbnail_urls,omitempty"`
+	WebURL        *string           `json:"web_url,omitempty" example:"/api/v1/assets/550e8400-e29b-41d4-a716-446655440000/video/web?mt=eyJhbGciOi..."`
+	URLsExpireAt  *time.Time        `json:"urls_expire_at,omitempty"`
 }
 
 type AssetExifResponseDTO struct {
@@ -267,6 +414,37 @@ type AssetExifResponseDTO struct {
 	ExifRaw map[string]any `json:"exif_raw" swaggertype:"object"`
 }
 
+// AssetSrcsetSourceDTO is one generated thumbnail with its actual pixel size.
+type AssetSrcsetSourceDTO struct {
+	Size   string `json:"size" example:"medium"`
+	Width  int32  `json:"width" example:"800"`
+	Height int32  `json:"height" example:"534"`
+	URL    string `json:"url" example:"/api/v1/assets/550e8400-e29b-41d4-a716-446655440000/thumbnail?size=medium&mt=eyJhbGciOi..."`
+}
+
+// AssetSrcsetResponseDTO lists an asset's thumbnails, narrowest first, and the
+// matching value for an <img srcset> attribute.
+type AssetSrcsetResponseDTO struct {
+	AssetID      string                 `json:"asset_id"`
+	Sources      []AssetSrcsetSourceDTO `json:"sources"`
+	Srcset       string                 `json:"srcset" example:"/api/v1/assets/550e8400-e29b-41d4-a716-446655440000/thumbnail?size=small 400w, /api/v1/assets/550e8400-e29b-41d4-a716-446655440000/thumbnail?size=medium 800w"`
+	URLsExpireAt *time.Time             `json:"urls_expire_at,omitempty"`
+}
+
+// AssetDownloadURLResponseDTO is a signed URL serving an asset's original
+// without authentication until ExpiresAt.
+type AssetDownloadURLResponseDTO struct {
+	URL       string    `json:"url" example:"/api/v1/public/assets/550e8400-e29b-41d4-a716-446655440000?token=eyJhbGciOi..."`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// AssetRawTagsResponseDTO is every tag read from an asset's original, keyed
+// by "Group:Tag" (e.g. "IFD0:Make", "XMP-dc:Creator", "IPTC:Keywords").
+type AssetRawTagsResponseDTO struct {
+	AssetID string         `json:"asset_id"`
+	Tags    map[string]any `json:"tags" swaggertype:"object"`
+}
+
 type LumilioSidecarSourceDTO struct {
 	OriginalFilename string  `json:"original_filename" example:"IMG_0001.jpg"`
 	StoragePath      string  `json:"storage_path" example:"inbox/2026/05/IMG_0001.jpg"`
@@ -498,6 +676,8 @@ func ToAssetDTO(a repo.Asset) AssetDTO {
 		t := a.TakenTime.Time
 		takenTime = &t
 	}
+	processingStatus, processingError := processingFromStatus(a.Status)
+
 	return AssetDTO{
 		AssetID:              id,
 		OwnerID:              a.OwnerID,
@@ -520,6 +700,9 @@ func ToAssetDTO(a repo.Asset) AssetDTO {
 		DeletedAt:            deletedAt,
 		Metadata:             a.SpecificMetadata,
 		Status:               a.Status,
+		ProcessingStatus:     processingStatus,
+		ProcessingError:      processingError,
+		BlurHash:             blurHashFromMetadata(a.SpecificMetadata),
 	}
 }
 
@@ -545,8 +728,10 @@ type AssetTagDTO struct {
 }
 
 // AddAssetTagRequestDTO is the body for adding a manual tag to an asset.
+// Category is only used when a tag with that name does not exist yet.
 type AddAssetTagRequestDTO struct {
-	TagName string `json:"tag_name" binding:"required" example:"vacation"`
+	TagName  string `json:"tag_name" binding:"required" example:"vacation"`
+	Category string `json:"category,omitempty" example:"travel"`
 }
 
 // AssetTagsResponseDTO is the list of tags attached to an asset.
@@ -572,6 +757,7 @@ type TagListResponseDTO struct {
 type TagSummaryDTO struct {
 	TagID        int32      `json:"tag_id" example:"42"`
 	TagName      string     `json:"tag_name" example:"document"`
+	Category     *string    `json:"category,omitempty" example:"scene"`
 	Source       string     `json:"source,omitempty" example:"manual"`
 	AssetCount   int64      `json:"asset_count" example:"37"`
 	CoverAssetID *string    `json:"cover_asset_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440000"`
@@ -584,6 +770,30 @@ type TagSummaryListResponseDTO struct {
 	Tags []TagSummaryDTO `json:"tags"`
 }
 
+// TagCleanupResponseDTO reports the outcome of an AI tag cleanup run.
+type TagCleanupResponseDTO struct {
+	MinConfidence    float64 `json:"min_confidence" example:"0.3"`
+	RemovedAssetTags int64   `json:"removed_asset_tags" example:"1204"`
+	RemovedTags      int64   `json:"removed_tags" example:"87"`
+}
+
+// RenameTagRequestDTO renames a tag definition.
+type RenameTagRequestDTO struct {
+	TagName string `json:"tag_name" binding:"required" example:"dog"`
+}
+
+// MergeTagRequestDTO names the tag a source tag is merged into.
+type MergeTagRequestDTO struct {
+	TargetTagID int32 `json:"target_tag_id" binding:"required" example:"12"`
+}
+
+// TagMergeResponseDTO reports the outcome of a tag merge.
+type TagMergeResponseDTO struct {
+	Tag             TagDTO `json:"tag"`
+	RemovedTagID    int32  `json:"removed_tag_id" example:"31"`
+	MergedAssetTags int64  `json:"merged_asset_tags" example:"240"`
+}
+
 // FolderSummaryDTO summarizes assets grouped under one immediate child
 // folder of the requested parent path. FolderPath and DisplayName are
 // repository-relative; absolute host paths are never exposed here.
@@ -744,6 +954,7 @@ func ToAssetDetailDTO(r repo.GetAssetWithRelationsRow, inc AssetDetailIncludes)
 		Metadata:             r.SpecificMetadata,
 		Status:               r.Status,
 	}
+	base.ProcessingStatus, base.ProcessingError = processingFromStatus(r.Status)
 
 	if inc.Species && len(r.SpeciesPredictions) > 0 {
 		var preds []dbtypes.SpeciesPredictionMeta
@@ -803,6 +1014,7 @@ type QueryAssetsResponseDTO struct {
 	StackMode    string          `json:"stack_mode,omitempty" example:"collapsed" enums:"collapsed,expanded"`
 	Limit        int             `json:"limit" example:"20"`
 	Offset       int             `json:"offset" example:"0"`
+	NextCursor   string          `json:"next_cursor,omitempty" example:"MTcwNTMxMjAwMDAwMDAwMDo1NTBlODQwMC1lMjliLTQxZDQtYTcxNi00NDY2NTU0NDAwMDA"` // Pass as pagination.cursor to fetch the next page; omitted on the last page
 }
 
 // SearchAssetsResponseDTO represents the response structure for searching assets
@@ -822,6 +1034,48 @@ type DownloadAssetsRequestDTO struct {
 	AssetIDs []string `json:"asset_ids" binding:"required" example:"550e8400-e29b-41d4-a716-446655440000,550e8400-e29b-41d4-a716-446655440001"`
 }
 
+// AssetFilterPreviewRequestDTO asks how many assets a filter matches.
+type AssetFilterPreviewRequestDTO struct {
+	Filter         AssetFilterDTO `json:"filter"`
+	SortBy         string         `json:"sort_by,omitempty" example:"date_captured" enums:"recently_added,date_captured"`
+	ViewerTimezone string         `json:"viewer_timezone,omitempty" example:"America/New_York"`
+	SampleSize     int            `json:"sample_size,omitempty" example:"5" minimum:"1" maximum:"20"` // Sample asset IDs to return, default 5
+}
+
+// AssetFilterPreviewResponseDTO is the match count of a filter and the first
+// few matching asset IDs in sort order.
+type AssetFilterPreviewResponseDTO struct {
+	Total          int64    `json:"total" example:"1250"`
+	SampleAssetIDs []string `json:"sample_asset_ids"`
+}
+
+// AssetFilterFieldErrorDTO is one invalid field of an AssetFilterDTO.
+type AssetFilterFieldErrorDTO struct {
+	Field   string `json:"field" example:"filter.type"`
+	Message string `json:"message" example:"must be one of PHOTO, VIDEO, AUDIO; got \"photo\""`
+}
+
+// AssetFilterValidationErrorDTO lists every invalid field of a filter.
+type AssetFilterValidationErrorDTO struct {
+	Code    int                        `json:"code" example:"400"`
+	Message string                     `json:"message" example:"Invalid asset filter"`
+	Errors  []AssetFilterFieldErrorDTO `json:"errors"`
+}
+
+// ContactSheetRequestDTO asks for a contact sheet of an album or of the assets
+// matching a filter. AlbumID is shorthand for filter.album_id and wins over it.
+type ContactSheetRequestDTO struct {
+	AlbumID        *int           `json:"album_id,omitempty" example:"123"`
+	Filter         AssetFilterDTO `json:"filter"`
+	SortBy         string         `json:"sort_by,omitempty" example:"date_captured" enums:"recently_added,date_captured"`
+	ViewerTimezone string         `json:"viewer_timezone,omitempty" example:"America/New_York"`
+	Columns        int            `json:"columns" binding:"required" example:"6" minimum:"1" maximum:"20"`
+	Rows           int            `json:"rows" binding:"required" example:"4" minimum:"1" maximum:"20"`
+	TileSize       int            `json:"tile_size,omitempty" example:"256" minimum:"64" maximum:"800"` // Edge of each tile in pixels, default 256
+	Labels         bool           `json:"labels,omitempty" example:"true"`                              // Print the filename under each tile
+	Format         string         `json:"format,omitempty" example:"jpeg" enums:"jpeg,png"`             // Default jpeg
+}
+
 // FeaturedAssetsResponseDTO represents curated featured photos for home/gallery use.
 type FeaturedAssetsResponseDTO struct {
 	Assets          []AssetDTO `json:"assets"`
@@ -876,6 +1130,18 @@ type UpdateDescriptionRequestDTO struct {
 	Description string `json:"description" example:"A beautiful sunset photo"`
 }
 
+// UpdateCustomFieldsRequestDTO replaces an asset's custom fields. Keys are
+// lower snake case; values are strings, numbers or booleans.
+type UpdateCustomFieldsRequestDTO struct {
+	Fields map[string]any `json:"fields" swaggertype:"object"`
+}
+
+// AssetCustomFieldsDTO is an asset's user-defined key/value fields.
+type AssetCustomFieldsDTO struct {
+	AssetID string         `json:"asset_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Fields  map[string]any `json:"fields" swaggertype:"object"`
+}
+
 // MessageResponseDTO represents a simple message response
 type MessageResponseDTO struct {
 	Message string `json:"message" example:"Operation completed successfully"`
@@ -960,7 +1226,28 @@ func (d *DateRangeDTO) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
-// LocationBBoxDTO represents a GPS bounding-box filter.
+// MarshalJSON writes date-only bounds back as YYYY-MM-DD, so a stored filter
+// still matches whole days when it is decoded again.
+func (d DateRangeDTO) MarshalJSON() ([]byte, error) {
+	format := func(t *time.Time, dateOnly bool) *string {
+		if t == nil {
+			return nil
+		}
+		layout := time.RFC3339Nano
+		if dateOnly {
+			layout = "2006-01-02"
+		}
+		s := t.Format(layout)
+		return &s
+	}
+	return json.Marshal(struct {
+		From *string `json:"from,omitempty"`
+		To   *string `json:"to,omitempty"`
+	}{From: format(d.From, d.FromDateOnly), To: format(d.To, d.ToDateOnly)})
+}
+
+// LocationBBoxDTO represents a GPS bounding-box filter. West may exceed East for a
+// box that crosses the antimeridian; assets without GPS never match.
 type LocationBBoxDTO struct {
 	North float64 `json:"north" example:"37.9"`
 	South float64 `json:"south" example:"37.7"`
@@ -970,27 +1257,33 @@ type LocationBBoxDTO struct {
 
 // AssetFilterDTO represents comprehensive filtering options
 type AssetFilterDTO struct {
-	RepositoryID *string            `json:"repository_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440000"`
-	AlbumID      *int               `json:"album_id,omitempty" example:"123"`
-	Type         *string            `json:"type,omitempty" example:"PHOTO" enums:"PHOTO,VIDEO,AUDIO"`
-	Types        []string           `json:"types,omitempty" example:"PHOTO,VIDEO"` // Multiple asset types
-	OwnerID      *int32             `json:"owner_id,omitempty" example:"123"`
-	RAW          *bool              `json:"raw,omitempty" example:"true"`
-	Rating       *int               `json:"rating,omitempty" example:"5" minimum:"0" maximum:"5"`
-	Liked        *bool              `json:"liked,omitempty" example:"true"`
-	Filename     *FilenameFilterDTO `json:"filename,omitempty"`
-	Date         *DateRangeDTO      `json:"date,omitempty"`
-	IsDeleted    *bool              `json:"is_deleted,omitempty" example:"false"`
-	CameraModel  *string            `json:"camera_model,omitempty" example:"Canon EOS R5"`
-	Lens         *string            `json:"lens,omitempty" example:"EF 50mm f/1.8"`
-	Location     *LocationBBoxDTO   `json:"location,omitempty"`
-	TagName      *string            `json:"tag_name,omitempty" example:"document"`
-	TagSource    *string            `json:"tag_source,omitempty" example:"zeroshot"`
-	TagNames     []string           `json:"tag_names,omitempty"`
-	PersonID     *int32             `json:"person_id,omitempty" example:"42"`
-	FolderPath   *string            `json:"folder_path,omitempty" example:"inbox/2026/05"`
+	RepositoryID     *string            `json:"repository_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440000"`
+	AlbumID          *int               `json:"album_id,omitempty" example:"123"`
+	Type             *string            `json:"type,omitempty" example:"PHOTO" enums:"PHOTO,VIDEO,AUDIO"`
+	Types            []string           `json:"types,omitempty" example:"PHOTO,VIDEO"` // Multiple asset types
+	OwnerID          *int32             `json:"owner_id,omitempty" example:"123"`
+	RAW              *bool              `json:"raw,omitempty" example:"true"`
+	Rating           *int               `json:"rating,omitempty" example:"5" minimum:"0" maximum:"5"`
+	Liked            *bool              `json:"liked,omitempty" example:"true"`
+	Filename         *FilenameFilterDTO `json:"filename,omitempty"`
+	Date             *DateRangeDTO      `json:"date,omitempty"`
+	IsDeleted        *bool              `json:"is_deleted,omitempty" example:"false"`
+	CameraModel      *string            `json:"camera_model,omitempty" example:"Canon EOS R5"`
+	Lens             *string            `json:"lens,omitempty" example:"EF 50mm f/1.8"`
+	Location         *LocationBBoxDTO   `json:"location,omitempty"`
+	TagName          *string            `json:"tag_name,omitempty" example:"document"`
+	TagSource        *string            `json:"tag_source,omitempty" example:"zeroshot"`
+	TagNames         []string           `json:"tag_names,omitempty"`
+	TagMatch         *string            `json:"tag_match,omitempty" example:"any" enums:"all,any"`                  // all (default) or any of TagNames; only search_type "tag" honours it
+	TagMinConfidence *float64           `json:"tag_min_confidence,omitempty" example:"0.5" minimum:"0" maximum:"1"` // only search_type "tag" honours it
+	PersonID         *int32             `json:"person_id,omitempty" example:"42"`
+	FolderPath       *string            `json:"folder_path,omitempty" example:"inbox/2026/05"`
 	// FolderRecursive controls whether FolderPath matches descendants (default true) or direct contents only.
 	FolderRecursive *bool `json:"folder_recursive,omitempty" example:"true"`
+	// CustomFields matches assets whose custom fields contain every given pair.
+	CustomFields map[string]any `json:"custom_fields,omitempty" swaggertype:"object"`
+	// ProcessingStatus matches assets whose processing is in this state.
+	ProcessingStatus *string `json:"processing_status,omitempty" example:"failed" enums:"processing,complete,warning,failed,deferred"`
 }
 
 // FilterAssetsRequestDTO represents the request structure for filtering assets
@@ -1003,6 +1296,7 @@ type FilterAssetsRequestDTO struct {
 // SearchAssetsRequestDTO represents the request structure for searching assets
 type SearchAssetsRequestDTO struct {
 	Query           string         `json:"query,omitempty" example:"red bird on branch"`
+	SearchType      string         `json:"search_type,omitempty" example:"text" enums:"text,tag"` // "tag" matches filter.tag_names, or comma-separated tag names in query
 	Filter          AssetFilterDTO `json:"filter,omitempty"`
 	SortBy          string         `json:"sort_by,omitempty" example:"date_captured" enums:"recently_added,date_captured"`
 	ViewerTimezone  string         `json:"viewer_timezone,omitempty" example:"America/New_York"`
@@ -1047,8 +1341,9 @@ type SearchDebugItemDTO struct {
 
 // OptionsResponseDTO represents the response for filter options
 type OptionsResponseDTO struct {
-	CameraModels []string `json:"camera_models"`
-	Lenses       []string `json:"lenses"`
+	CameraModels []string            `json:"camera_models"`
+	Lenses       []string            `json:"lenses"`
+	CustomFields map[string][]string `json:"custom_fields"`
 }
 
 // BulkLikeUpdateDTO represents the result of a bulk like/unlike operation
@@ -1085,8 +1380,9 @@ type BulkLikeUpdateDTO struct {
 
 // PaginationDTO represents pagination options for list queries
 type PaginationDTO struct {
-	Limit  int `json:"limit" example:"20" minimum:"1" maximum:"100"`
-	Offset int `json:"offset" example:"0" minimum:"0"`
+	Limit  int    `json:"limit" example:"20" minimum:"1" maximum:"100"`
+	Offset int    `json:"offset" example:"0" minimum:"0"`
+	Cursor string `json:"cursor,omitempty"` // next_cursor of the previous page; replaces offset. Only POST /assets/list honours it
 }
 
 // AssetQueryRequestDTO is the unified request for listing/searching/filtering assets
@@ -1098,7 +1394,16 @@ type AssetQueryRequestDTO struct {
 	SortBy         string         `json:"sort_by,omitempty" example:"date_captured" enums:"recently_added,date_captured"`
 	ViewerTimezone string         `json:"viewer_timezone,omitempty" example:"America/New_York"`
 	StackMode      string         `json:"stack_mode,omitempty" example:"collapsed" enums:"collapsed,expanded"`
-	Pagination     PaginationDTO  `json:"pagination"` // limit, offset
+	IncludeURLs    bool           `json:"include_urls,omitempty" example:"false"` // Attach short-lived signed media URLs to each asset
+	Pagination     PaginationDTO  `json:"pagination"`                             // limit, offset
+}
+
+// AssetNeighborsResponseDTO is the response for GET /assets/{id}/neighbors.
+// PreviousID and NextID are omitted at either end of the result set.
+type AssetNeighborsResponseDTO struct {
+	AssetID    string  `json:"asset_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	PreviousID *string `json:"previous_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440001"`
+	NextID     *string `json:"next_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440002"`
 }
 
 type BrowseStackDTO struct {
@@ -1117,3 +1422,13 @@ type BrowseItemDTO struct {
 	Asset *AssetDTO       `json:"asset,omitempty"`
 	Stack *BrowseStackDTO `json:"stack,omitempty"`
 }
+
+// ImportAssetFromURLRequestDTO asks the server to pull a remote file into a
+// repository.
+type ImportAssetFromURLRequestDTO struct {
+	URL          string `json:"url" binding:"required,max=2048" example:"https://example.com/photos/holiday.jpg"`
+	RepositoryID string `json:"repository_id,omitempty" binding:"omitempty,uuid" example:"550e8400-e29b-41d4-a716-446655440000"`
+	// FileName overrides the name taken from the response, for URLs that do
+	// not end in a media file name.
+	FileName string `json:"file_name,omitempty" binding:"omitempty,max=255" example:"holiday.jpg"`
+}
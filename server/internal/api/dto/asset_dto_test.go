@@ -1,6 +1,7 @@
 package dto
 
 import (
+	"encoding/json"
 	"testing"
 
 	"server/internal/db/repo"
@@ -26,3 +27,44 @@ func TestToAssetDTONilStoragePath(t *testing.T) {
 	require.Equal(t, "", got.StoragePath)
 	require.Equal(t, "missing-path.jpg", got.OriginalFilename)
 }
+
+func TestToAssetDTOExposesBlurHash(t *testing.T) {
+	got := ToAssetDTO(repo.Asset{Type: "PHOTO", SpecificMetadata: []byte(`{"camera_model":"X100V","blurhash":"LEHV6nWB2yk8pyo0adR*.7kCMdnj"}`)})
+	require.NotNil(t, got.BlurHash)
+	require.Equal(t, "LEHV6nWB2yk8pyo0adR*.7kCMdnj", *got.BlurHash)
+
+	require.Nil(t, ToAssetDTO(repo.Asset{Type: "PHOTO", SpecificMetadata: []byte(`{"camera_model":"X100V"}`)}).BlurHash)
+	require.Nil(t, ToAssetDTO(repo.Asset{Type: "PHOTO"}).BlurHash)
+}
+
+func TestToAssetDTOExposesProcessingError(t *testing.T) {
+	failed := ToAssetDTO(repo.Asset{Type: "PHOTO", Status: []byte(`{"state":"failed","message":"Asset ingestion failed","errors":[{"task":"file_read","error":"permission denied"},{"task":"thumbnail_asset","error":"unsupported image format"}]}`)})
+	require.Equal(t, "failed", failed.ProcessingStatus)
+	require.NotNil(t, failed.ProcessingError)
+	require.Equal(t, "thumbnail_asset: unsupported image format", *failed.ProcessingError)
+
+	warning := ToAssetDTO(repo.Asset{Type: "PHOTO", Status: []byte(`{"state":"warning","message":"Processed with errors"}`)})
+	require.Equal(t, "warning", warning.ProcessingStatus)
+	require.Equal(t, "Processed with errors", *warning.ProcessingError)
+
+	complete := ToAssetDTO(repo.Asset{Type: "PHOTO", Status: []byte(`{"state":"complete","message":"Asset processed successfully"}`)})
+	require.Equal(t, "complete", complete.ProcessingStatus)
+	require.Nil(t, complete.ProcessingError)
+
+	require.Empty(t, ToAssetDTO(repo.Asset{Type: "PHOTO"}).ProcessingStatus)
+}
+
+func TestAlbumRulesKeepDateOnlyBounds(t *testing.T) {
+	var rules AssetFilterDTO
+	require.NoError(t, json.Unmarshal([]byte(`{"date":{"from":"2024-06-01","to":"2024-06-30T18:00:00+02:00"}}`), &rules))
+	stored, err := json.Marshal(rules)
+	require.NoError(t, err)
+
+	album := ToAlbumDTO(repo.Album{AlbumID: 3, Rules: stored})
+	require.True(t, album.IsSmart)
+	require.True(t, album.Rules.Date.FromDateOnly, "stored rules still match the whole first day")
+	require.False(t, album.Rules.Date.ToDateOnly)
+	require.True(t, album.Rules.Date.To.Equal(*rules.Date.To))
+
+	require.False(t, ToAlbumDTO(repo.Album{AlbumID: 4}).IsSmart)
+}
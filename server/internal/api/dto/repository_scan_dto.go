@@ -9,7 +9,7 @@ type CreateRepositoryRequestDTO struct {
 	RootID            string `json:"root_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440000"`
 	Role              string `json:"role,omitempty" binding:"omitempty,oneof=primary regular" example:"regular"`
 	StorageStrategy   string `json:"storage_strategy,omitempty" binding:"omitempty,oneof=date flat cas" example:"date"`
-	DuplicateHandling string `json:"duplicate_handling,omitempty" binding:"omitempty,oneof=rename uuid overwrite" example:"rename"`
+	DuplicateHandling string `json:"duplicate_handling,omitempty" binding:"omitempty,oneof=rename uuid overwrite skip date_prefix" example:"rename"`
 	CloudCredentialID string `json:"cloud_credential_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440000"`
 }
 
@@ -28,6 +28,32 @@ type RepositoryDTO struct {
 
 type RepositoryLocalSettings struct {
 	HandleDuplicateFilenames string `json:"handle_duplicate_filenames" example:"uuid"`
+	// ProcessingMode is on_upload or manual; manual uploads wait for
+	// POST /repositories/{id}/process-pending. Omitted in an update to keep it.
+	ProcessingMode string `json:"processing_mode,omitempty" example:"on_upload" enums:"on_upload,manual"`
+	// MLEnabled false turns ML processing off for the repository; when unset
+	// the global ML settings apply. Omitted in an update to keep it.
+	MLEnabled *bool `json:"ml_enabled,omitempty" example:"false"`
+	// OriginalPolicy is keep or archive; archive moves originals to
+	// ArchivePath once their web derivatives exist. Omitted in an update to
+	// keep it.
+	OriginalPolicy string `json:"original_policy,omitempty" example:"keep" enums:"keep,archive"`
+	// ArchivePath is the absolute directory archived originals are moved to.
+	// Omitted in an update to keep it.
+	ArchivePath string `json:"archive_path,omitempty" example:"/mnt/cold/photos"`
+	// SemanticSkip leaves matching photos out of semantic (CLIP) indexing
+	// and so out of AI tagging. Omitted in an update to keep it; an empty
+	// object indexes every photo again.
+	SemanticSkip *RepositorySemanticSkip `json:"semantic_skip,omitempty"`
+}
+
+// RepositorySemanticSkip selects photos that are not semantically indexed.
+type RepositorySemanticSkip struct {
+	// Screenshots skips photos sized like a common phone, tablet or desktop
+	// screen, or named like a screenshot.
+	Screenshots bool `json:"screenshots" example:"true"`
+	// Extensions skips photos by file extension, ignoring case.
+	Extensions []string `json:"extensions,omitempty" example:".png,.tiff"`
 }
 
 type UpdateRepositoryRequestDTO struct {
@@ -36,6 +62,29 @@ type UpdateRepositoryRequestDTO struct {
 	LocalSettings   *RepositoryLocalSettings `json:"local_settings,omitempty"`
 }
 
+// RepositoryConfigBundleDTO is a repository's portable configuration: its
+// layout and processing settings without its identity or location. Kind and
+// Version identify the bundle format and are checked on import.
+type RepositoryConfigBundleDTO struct {
+	Kind            string                  `json:"kind" binding:"required" example:"lumilio.repository-config"`
+	Version         int                     `json:"version" binding:"required" example:"1"`
+	Name            string                  `json:"name" example:"Family Photos"`
+	ExportedAt      time.Time               `json:"exported_at" example:"2026-01-01T00:00:00Z"`
+	StorageStrategy string                  `json:"storage_strategy" example:"date"`
+	LocalSettings   RepositoryLocalSettings `json:"local_settings"`
+}
+
+// ImportRepositoryConfigRequestDTO creates a repository from an exported
+// bundle. Name defaults to the bundle's name; the repository folder is
+// derived from it below the Storage Location, as on create.
+type ImportRepositoryConfigRequestDTO struct {
+	Bundle RepositoryConfigBundleDTO `json:"bundle" binding:"required"`
+	Name   string                    `json:"name,omitempty" example:"Family Photos (copy)"`
+	// RootID identifies a registered Storage Location. Empty selects the
+	// configured default location.
+	RootID string `json:"root_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440000"`
+}
+
 type ListRepositoriesResponseDTO struct {
 	Repositories []RepositoryDTO `json:"repositories"`
 }
@@ -102,3 +151,118 @@ type RepositoryScanRunDTO struct {
 type RepositoryScanRunListDTO struct {
 	Scans []RepositoryScanRunDTO `json:"scans"`
 }
+
+type AssignLegacyAssetsRequestDTO struct {
+	RepositoryID string `json:"repository_id" binding:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+}
+
+// AssignLegacyAssetsResponseDTO reports the outcome of attaching
+// repository-less assets to a repository. Unresolved assets were left as they
+// were and can be retried against another repository.
+type AssignLegacyAssetsResponseDTO struct {
+	RepositoryID       string   `json:"repository_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Resolved           int      `json:"resolved" example:"120"`
+	Unresolved         int      `json:"unresolved" example:"3"`
+	UnresolvedAssetIDs []string `json:"unresolved_asset_ids"`
+}
+
+// RebuildFileRecordsRequestDTO configures a file record rebuild. With dry_run
+// the result is reported but no asset is changed.
+type RebuildFileRecordsRequestDTO struct {
+	DryRun bool `json:"dry_run" example:"false"`
+}
+
+// RebuildFileRecordsResponseDTO reports how a repository's files line up with
+// its assets after a rebuild. matched, relinked and assigned files all have an
+// asset now; unmatched files have none.
+type RebuildFileRecordsResponseDTO struct {
+	RepositoryID    string   `json:"repository_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	DryRun          bool     `json:"dry_run" example:"false"`
+	Matched         int64    `json:"matched" example:"1180"`
+	Relinked        int64    `json:"relinked" example:"12"`
+	Assigned        int64    `json:"assigned" example:"40"`
+	Unmatched       int      `json:"unmatched" example:"3"`
+	UnmatchedFiles  []string `json:"unmatched_files"`
+	MissingAssetIDs []string `json:"missing_asset_ids"`
+	Partial         bool     `json:"partial" example:"false"`
+}
+
+// OrganizeRepositoryRequestDTO picks the storage strategy to lay a
+// repository's inbox out by; empty uses the repository's own. limit caps how
+// many moves the response lists.
+type OrganizeRepositoryRequestDTO struct {
+	Strategy string `json:"strategy" binding:"omitempty,oneof=date cas flat" example:"date"`
+	Limit    int    `json:"limit" binding:"omitempty,min=1,max=1000" example:"100"`
+}
+
+// OrganizeMoveDTO is one inbox file the organizer relocates. Paths are
+// repository-relative.
+type OrganizeMoveDTO struct {
+	AssetID string `json:"asset_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	From    string `json:"from" example:"inbox/2026/10/IMG_0001.jpg"`
+	To      string `json:"to" example:"inbox/2021/05/IMG_0001.jpg"`
+}
+
+// OrganizeRepositoryResponseDTO reports where a storage strategy puts a
+// repository's inbox files. moves is a sample of at most the requested limit;
+// total_moves counts them all.
+type OrganizeRepositoryResponseDTO struct {
+	RepositoryID string            `json:"repository_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Strategy     string            `json:"strategy" example:"date"`
+	DryRun       bool              `json:"dry_run" example:"true"`
+	TotalMoves   int               `json:"total_moves" example:"240"`
+	Moves        []OrganizeMoveDTO `json:"moves"`
+	Unchanged    int               `json:"unchanged" example:"1180"`
+	Skipped      int               `json:"skipped" example:"2"`
+	Applied      int               `json:"applied" example:"0"`
+}
+
+// RepositorySizeCheckQueuedDTO reports a queued repository size check.
+type RepositorySizeCheckQueuedDTO struct {
+	JobID        int64  `json:"job_id" example:"12345"`
+	RepositoryID string `json:"repository_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Status       string `json:"status" example:"queued"`
+}
+
+// RepositoryProcessPendingQueuedDTO reports queued processing of deferred uploads.
+type RepositoryProcessPendingQueuedDTO struct {
+	JobID        int64  `json:"job_id" example:"12345"`
+	RepositoryID string `json:"repository_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Status       string `json:"status" example:"queued"`
+}
+
+// AssetSizeMismatchDTO is an asset whose original no longer has the size
+// recorded at import.
+type AssetSizeMismatchDTO struct {
+	AssetID          string    `json:"asset_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	StoragePath      string    `json:"storage_path" example:"2024/06/IMG_0001.jpg"`
+	OriginalFilename string    `json:"original_filename" example:"IMG_0001.jpg"`
+	RecordedSize     int64     `json:"recorded_size" example:"4821733"`
+	ActualSize       int64     `json:"actual_size" example:"1048576"`
+	CheckedAt        time.Time `json:"checked_at"`
+}
+
+// RepositorySizeMismatchesDTO lists what the last size check of a repository
+// found. It is empty until a check has run.
+type RepositorySizeMismatchesDTO struct {
+	RepositoryID string                 `json:"repository_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Mismatches   []AssetSizeMismatchDTO `json:"mismatches"`
+}
+
+// RepositoryAssetStatsDTO summarises the live assets of a repository.
+type RepositoryAssetStatsDTO struct {
+	RepositoryID string `json:"repository_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	OwnerID      *int32 `json:"owner_id,omitempty" example:"1"`
+	TotalAssets  int64  `json:"total_assets" example:"1250"`
+	PhotoCount   int64  `json:"photo_count" example:"1100"`
+	VideoCount   int64  `json:"video_count" example:"140"`
+	AudioCount   int64  `json:"audio_count" example:"10"`
+	LikedCount   int64  `json:"liked_count" example:"85"`
+	RatedCount   int64  `json:"rated_count" example:"300"`
+	// AverageRating is over rated assets only and omitted when none is rated.
+	AverageRating *float64 `json:"average_rating,omitempty" example:"3.7"`
+	// TotalSize is the sum of the original file sizes in bytes.
+	TotalSize    int64      `json:"total_size" example:"52428800000"`
+	OldestUpload *time.Time `json:"oldest_upload,omitempty"`
+	NewestUpload *time.Time `json:"newest_upload,omitempty"`
+}
@@ -21,6 +21,17 @@ type CreateShareLinkRequestDTO struct {
 	ExpiresInDays    int      `json:"expires_in_days,omitempty" example:"30" minimum:"1" maximum:"365"`
 	AllowDownload    bool     `json:"allow_download,omitempty"`
 	IncludeOriginals bool     `json:"include_originals,omitempty"`
+	// Password, when set, must be presented before the share can be viewed.
+	Password string `json:"password,omitempty" maxLength:"72"`
+}
+
+// ShareAlbumRequestDTO represents the request to share an album publicly. The
+// share takes the album's name and description and its assets at this moment.
+type ShareAlbumRequestDTO struct {
+	ExpiresInDays    int    `json:"expires_in_days,omitempty" example:"30" minimum:"1" maximum:"365"`
+	Password         string `json:"password,omitempty" maxLength:"72"`
+	AllowDownload    bool   `json:"allow_download,omitempty"`
+	IncludeOriginals bool   `json:"include_originals,omitempty"`
 }
 
 // UpdateShareLinkRequestDTO represents a patch to an existing share link's
@@ -38,36 +49,38 @@ type UpdateShareLinkRequestDTO struct {
 // includes the token or token hash; the raw token is only ever returned once,
 // embedded in CreateShareLinkResponseDTO.
 type ShareLinkDTO struct {
-	ShareID          string     `json:"share_id" example:"550e8400-e29b-41d4-a716-446655440000"`
-	Title            string     `json:"title"`
-	Description      *string    `json:"description,omitempty"`
-	SourceKind       string     `json:"source_kind" enums:"asset_snapshot,album,person,utility_query,pin"`
-	SourceRef        *string    `json:"source_ref,omitempty"`
-	AssetCount       int        `json:"asset_count"`
-	AllowDownload    bool       `json:"allow_download"`
-	IncludeOriginals bool       `json:"include_originals"`
-	Status           string     `json:"status" enums:"active,revoked"`
-	ExpiresAt        time.Time  `json:"expires_at"`
-	CreatedAt        time.Time  `json:"created_at"`
-	UpdatedAt        time.Time  `json:"updated_at"`
-	RevokedAt        *time.Time `json:"revoked_at,omitempty"`
-	LastViewedAt     *time.Time `json:"last_viewed_at,omitempty"`
-	ViewCount        int64      `json:"view_count"`
+	ShareID           string     `json:"share_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Title             string     `json:"title"`
+	Description       *string    `json:"description,omitempty"`
+	SourceKind        string     `json:"source_kind" enums:"asset_snapshot,album,person,utility_query,pin"`
+	SourceRef         *string    `json:"source_ref,omitempty"`
+	AssetCount        int        `json:"asset_count"`
+	AllowDownload     bool       `json:"allow_download"`
+	IncludeOriginals  bool       `json:"include_originals"`
+	PasswordProtected bool       `json:"password_protected"`
+	Status            string     `json:"status" enums:"active,revoked"`
+	ExpiresAt         time.Time  `json:"expires_at"`
+	CreatedAt         time.Time  `json:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at"`
+	RevokedAt         *time.Time `json:"revoked_at,omitempty"`
+	LastViewedAt      *time.Time `json:"last_viewed_at,omitempty"`
+	ViewCount         int64      `json:"view_count"`
 }
 
 // ToShareLinkDTO converts a repo.ShareLink row into its owner-facing DTO.
 func ToShareLinkDTO(l repo.ShareLink) ShareLinkDTO {
 	out := ShareLinkDTO{
-		ShareID:          uuid.UUID(l.ShareID.Bytes).String(),
-		Title:            l.Title,
-		Description:      l.Description,
-		SourceKind:       l.SourceKind,
-		SourceRef:        l.SourceRef,
-		AssetCount:       int(l.AssetCount),
-		AllowDownload:    l.AllowDownload,
-		IncludeOriginals: l.IncludeOriginals,
-		Status:           l.Status,
-		ViewCount:        l.ViewCount,
+		ShareID:           uuid.UUID(l.ShareID.Bytes).String(),
+		Title:             l.Title,
+		Description:       l.Description,
+		SourceKind:        l.SourceKind,
+		SourceRef:         l.SourceRef,
+		AssetCount:        int(l.AssetCount),
+		AllowDownload:     l.AllowDownload,
+		IncludeOriginals:  l.IncludeOriginals,
+		PasswordProtected: l.PasswordHash != nil,
+		Status:            l.Status,
+		ViewCount:         l.ViewCount,
 	}
 	if l.ExpiresAt.Valid {
 		out.ExpiresAt = l.ExpiresAt.Time
@@ -177,6 +190,22 @@ type PublicShareAssetListResponseDTO struct {
 	Offset int              `json:"offset"`
 }
 
+// PublicAlbumAssetDTO is a public album asset with the URL of its thumbnail.
+type PublicAlbumAssetDTO struct {
+	PublicAssetDTO
+	ThumbnailURL string `json:"thumbnail_url" example:"/api/v1/public/shares/7yQhF3z9k2mN8pXeR5tVwL1sJ4bC6dA0/assets/550e8400-e29b-41d4-a716-446655440000/thumbnail"`
+}
+
+// PublicAlbumDTO is a shared album as served to public viewers: the share's
+// de-sensitized metadata plus one page of its assets.
+type PublicAlbumDTO struct {
+	PublicShareMetadataDTO
+	Assets []PublicAlbumAssetDTO `json:"assets"`
+	Total  int                   `json:"total"`
+	Limit  int                   `json:"limit"`
+	Offset int                   `json:"offset"`
+}
+
 // PublicShareDownloadRequestDTO optionally scopes a zip download to a subset
 // of the share's assets; an empty/omitted asset_ids downloads the whole share.
 type PublicShareDownloadRequestDTO struct {
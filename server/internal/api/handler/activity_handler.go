@@ -0,0 +1,121 @@
+package handler
+
+import (
+	"encoding/json"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"server/internal/api"
+	"server/internal/api/dto"
+	"server/internal/db/repo"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	defaultActivityLimit = 100
+	maxActivityLimit     = 500
+	// defaultActivityWindow bounds the feed when a client has no cursor yet.
+	defaultActivityWindow = 24 * time.Hour
+)
+
+// ActivityHandler serves the activity feed: recent asset and album changes
+// clients replay to keep their view of the library in sync.
+type ActivityHandler struct {
+	queries repo.Querier
+}
+
+// NewActivityHandler builds the handler with its required collaborators.
+func NewActivityHandler(queries repo.Querier) *ActivityHandler {
+	return &ActivityHandler{queries: queries}
+}
+
+// ListActivity returns library changes after a cursor, oldest first.
+// @Summary List recent library activity
+// @Description Asset additions and deletions, rating edits and album changes after since (and after_id, for events at the same instant), oldest first. Non-admin users see changes to their own library. Without since the last 24 hours are listed.
+// @Tags activity
+// @Produce json
+// @Param since query string false "RFC 3339 timestamp; events at or before it are skipped"
+// @Param after_id query int false "Event ID at since to continue after (next_after_id of the previous page)"
+// @Param limit query int false "Page size (max 500)" default(100)
+// @Success 200 {object} dto.ActivityFeedDTO
+// @Failure 400 {object} api.ErrorResponse "Invalid since or after_id"
+// @Failure 401 {object} api.ErrorResponse "Unauthorized"
+// @Failure 500 {object} api.ErrorResponse "Failed to list activity"
+// @Router /api/v1/activity [get]
+// @Security BearerAuth
+func (h *ActivityHandler) ListActivity(c *gin.Context) {
+	since := time.Now().Add(-defaultActivityWindow)
+	if raw := strings.TrimSpace(c.Query("since")); raw != "" {
+		parsed, err := time.Parse(time.RFC3339Nano, raw)
+		if err != nil {
+			api.GinBadRequest(c, err, "Invalid since; use an RFC 3339 timestamp")
+			return
+		}
+		since = parsed
+	}
+	var afterID int64
+	if raw := strings.TrimSpace(c.Query("after_id")); raw != "" {
+		parsed, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil || parsed < 0 {
+			api.GinBadRequest(c, err, "Invalid after_id")
+			return
+		}
+		afterID = parsed
+	}
+	limit := defaultActivityLimit
+	if raw := strings.TrimSpace(c.Query("limit")); raw != "" {
+		if v, err := strconv.Atoi(raw); err == nil && v > 0 {
+			limit = min(v, maxActivityLimit)
+		}
+	}
+
+	// One extra row tells whether another page follows.
+	events, err := h.queries.ListActivityEvents(c.Request.Context(), repo.ListActivityEventsParams{
+		Since:   pgtype.Timestamptz{Time: since, Valid: true},
+		AfterID: afterID,
+		OwnerID: ownerScopeID(c),
+		Limit:   int32(limit + 1),
+	})
+	if err != nil {
+		log.Printf("list activity events failed: %v", err)
+		api.GinInternalError(c, err, "Failed to list activity")
+		return
+	}
+
+	feed := dto.ActivityFeedDTO{
+		Events:      make([]dto.ActivityEventDTO, 0, min(len(events), limit)),
+		NextSince:   since,
+		NextAfterID: afterID,
+		HasMore:     len(events) > limit,
+	}
+	for i, event := range events {
+		if i == limit {
+			break
+		}
+		feed.Events = append(feed.Events, toActivityEventDTO(event))
+		feed.NextSince = event.OccurredAt.Time
+		feed.NextAfterID = event.EventID
+	}
+	api.JSONOK(c, feed)
+}
+
+func toActivityEventDTO(event repo.ActivityEvent) dto.ActivityEventDTO {
+	payload := map[string]any{}
+	if len(event.Payload) > 0 {
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			log.Printf("decode payload of activity event %d: %v", event.EventID, err)
+		}
+	}
+	return dto.ActivityEventDTO{
+		ID:         event.EventID,
+		Type:       event.EventType,
+		AssetID:    optionalUUIDToString(event.AssetID),
+		AlbumID:    event.AlbumID,
+		Payload:    payload,
+		OccurredAt: event.OccurredAt.Time,
+	}
+}
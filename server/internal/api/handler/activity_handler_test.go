@@ -0,0 +1,111 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"server/internal/api/dto"
+	"server/internal/db/repo"
+	"server/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+type stubActivityQueries struct {
+	repo.Querier
+	events []repo.ActivityEvent
+	params repo.ListActivityEventsParams
+}
+
+func (s *stubActivityQueries) ListActivityEvents(_ context.Context, arg repo.ListActivityEventsParams) ([]repo.ActivityEvent, error) {
+	s.params = arg
+	return s.events, nil
+}
+
+func listActivityForTest(t *testing.T, queries *stubActivityQueries, user *service.UserResponse, query string) *httptest.ResponseRecorder {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Request = httptest.NewRequest(http.MethodGet, "/api/v1/activity?"+query, nil)
+	if user != nil {
+		ctx.Set("current_user", user)
+	}
+	NewActivityHandler(queries).ListActivity(ctx)
+	return recorder
+}
+
+func TestListActivityReturnsEventsAfterCursor(t *testing.T) {
+	assetID := uuid.New()
+	albumID := int32(9)
+	first := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	queries := &stubActivityQueries{events: []repo.ActivityEvent{
+		{
+			EventID:    41,
+			EventType:  service.ActivityAssetRatingUpdated,
+			AssetID:    pgtype.UUID{Bytes: assetID, Valid: true},
+			Payload:    []byte(`{"rating":5}`),
+			OccurredAt: pgtype.Timestamptz{Time: first, Valid: true},
+		},
+		{
+			EventID:    42,
+			EventType:  service.ActivityAlbumAssetAdded,
+			AssetID:    pgtype.UUID{Bytes: assetID, Valid: true},
+			AlbumID:    &albumID,
+			Payload:    []byte(`{"album_name":"Trip"}`),
+			OccurredAt: pgtype.Timestamptz{Time: first.Add(time.Second), Valid: true},
+		},
+		{
+			EventID:    43,
+			EventType:  service.ActivityAssetDeleted,
+			Payload:    []byte(`{}`),
+			OccurredAt: pgtype.Timestamptz{Time: first.Add(2 * time.Second), Valid: true},
+		},
+	}}
+
+	recorder := listActivityForTest(t, queries, &service.UserResponse{UserID: 7, Role: "user"}, "since=2026-03-01T11:00:00Z&after_id=40&limit=2")
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Equal(t, time.Date(2026, 3, 1, 11, 0, 0, 0, time.UTC), queries.params.Since.Time)
+	require.Equal(t, int64(40), queries.params.AfterID)
+	require.Equal(t, int32(3), queries.params.Limit, "one extra row detects the next page")
+	require.NotNil(t, queries.params.OwnerID)
+	require.Equal(t, int32(7), *queries.params.OwnerID, "non-admins only see their own library")
+
+	var feed dto.ActivityFeedDTO
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &feed))
+	require.Len(t, feed.Events, 2)
+	require.True(t, feed.HasMore)
+	require.Equal(t, service.ActivityAssetRatingUpdated, feed.Events[0].Type)
+	require.Equal(t, assetID.String(), *feed.Events[0].AssetID)
+	require.Equal(t, float64(5), feed.Events[0].Payload["rating"])
+	require.Equal(t, &albumID, feed.Events[1].AlbumID)
+	require.Equal(t, int64(42), feed.NextAfterID)
+	require.True(t, feed.NextSince.Equal(first.Add(time.Second)))
+}
+
+func TestListActivityAdminSeesEveryLibrary(t *testing.T) {
+	queries := &stubActivityQueries{}
+
+	recorder := listActivityForTest(t, queries, &service.UserResponse{UserID: 1, Role: "admin"}, "")
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Nil(t, queries.params.OwnerID)
+	require.Equal(t, int32(defaultActivityLimit+1), queries.params.Limit)
+	require.WithinDuration(t, time.Now().Add(-defaultActivityWindow), queries.params.Since.Time, time.Minute)
+}
+
+func TestListActivityRejectsInvalidCursor(t *testing.T) {
+	for _, query := range []string{"since=yesterday", "after_id=-1", "after_id=abc"} {
+		recorder := listActivityForTest(t, &stubActivityQueries{}, nil, query)
+		require.Equal(t, http.StatusBadRequest, recorder.Code, query)
+	}
+}
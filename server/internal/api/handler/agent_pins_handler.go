@@ -269,6 +269,10 @@ func (h *AgentHandler) QueryPinAssets(c *gin.Context) {
 		api.GinBadRequest(c, err, "stack_mode must be 'collapsed' or 'expanded'")
 		return
 	}
+	if err := validateAssetFilterLocation(req.Filter); err != nil {
+		api.GinBadRequest(c, err, err.Error())
+		return
+	}
 	if req.SearchType == "" {
 		req.SearchType = "filename"
 	}
@@ -331,6 +335,10 @@ func (h *AgentHandler) SearchPinAssets(c *gin.Context) {
 		api.GinBadRequest(c, err, "stack_mode must be 'collapsed' or 'expanded'")
 		return
 	}
+	if err := validateAssetFilterLocation(req.Filter); err != nil {
+		api.GinBadRequest(c, err, err.Error())
+		return
+	}
 	if err := validateSearchEnhancementMode(req.EnhancementMode); err != nil {
 		api.GinBadRequest(c, err, "Enhancement mode must be 'auto', 'off', or 'only'")
 		return
@@ -0,0 +1,188 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"server/internal/api/dto"
+	"server/internal/db/repo"
+	"server/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+type stubAlbumCoverQueries struct {
+	repo.Querier
+	album    repo.Album
+	asset    repo.Asset
+	isMember bool
+	setCover *pgtype.UUID
+	activity []repo.RecordActivityEventParams
+}
+
+func (s *stubAlbumCoverQueries) GetAlbumByID(_ context.Context, albumID int32) (repo.Album, error) {
+	return s.album, nil
+}
+
+func (s *stubAlbumCoverQueries) GetAssetByID(_ context.Context, assetID pgtype.UUID) (repo.Asset, error) {
+	return s.asset, nil
+}
+
+func (s *stubAlbumCoverQueries) IsAssetInAlbum(_ context.Context, arg repo.IsAssetInAlbumParams) (bool, error) {
+	return s.isMember, nil
+}
+
+func (s *stubAlbumCoverQueries) SetAlbumCoverAsset(_ context.Context, arg repo.SetAlbumCoverAssetParams) (repo.Album, error) {
+	s.setCover = &arg.CoverAssetID
+	album := s.album
+	album.CoverAssetID = arg.CoverAssetID
+	return album, nil
+}
+
+func (s *stubAlbumCoverQueries) RecordActivityEvent(_ context.Context, arg repo.RecordActivityEventParams) error {
+	s.activity = append(s.activity, arg)
+	return nil
+}
+
+func (s *stubAlbumCoverQueries) GetAlbumAssetCount(_ context.Context, albumID int32) (int64, error) {
+	return 4, nil
+}
+
+func setAlbumCoverForTest(t *testing.T, queries *stubAlbumCoverQueries, mustBeMember bool) *httptest.ResponseRecorder {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	handler := &AlbumHandler{queries: queries, coverMustBeMember: mustBeMember}
+	assetID := uuid.UUID(queries.asset.AssetID.Bytes).String()
+
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Request = httptest.NewRequest(http.MethodPut, "/api/v1/albums/9/cover/"+assetID, nil)
+	ctx.Params = gin.Params{{Key: "id", Value: "9"}, {Key: "assetId", Value: assetID}}
+	ctx.Set("current_user", &service.UserResponse{UserID: 7, Role: "user"})
+
+	handler.SetAlbumCover(ctx)
+	return recorder
+}
+
+func newAlbumCoverQueries(t *testing.T) *stubAlbumCoverQueries {
+	ownerID := int32(7)
+	asset := testHandlerAsset(t, "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa", "cover.jpg")
+	asset.OwnerID = &ownerID
+	return &stubAlbumCoverQueries{
+		album: repo.Album{AlbumID: 9, UserID: ownerID, AlbumName: "Trip", AlbumType: repo.AlbumTypeDefault},
+		asset: asset,
+	}
+}
+
+func TestAlbumHandlerSetAlbumCover_SetsCoverForMember(t *testing.T) {
+	queries := newAlbumCoverQueries(t)
+	queries.isMember = true
+
+	recorder := setAlbumCoverForTest(t, queries, true)
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.NotNil(t, queries.setCover)
+	require.Equal(t, queries.asset.AssetID, *queries.setCover)
+
+	var response dto.GetAlbumResponseDTO
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	require.NotNil(t, response.CoverAssetID)
+	require.Equal(t, "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa", *response.CoverAssetID)
+	require.Equal(t, int64(4), response.AssetCount)
+
+	require.Len(t, queries.activity, 1)
+	require.Equal(t, service.ActivityAlbumUpdated, queries.activity[0].EventType)
+	require.Equal(t, int32(9), *queries.activity[0].AlbumID)
+	require.Equal(t, int32(7), *queries.activity[0].OwnerID)
+}
+
+func TestAlbumHandlerSetAlbumCover_RejectsNonMemberWhenRequired(t *testing.T) {
+	queries := newAlbumCoverQueries(t)
+
+	recorder := setAlbumCoverForTest(t, queries, true)
+
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+	require.Nil(t, queries.setCover)
+}
+
+func TestAlbumHandlerSetAlbumCover_AllowsOwnedNonMemberWhenNotRequired(t *testing.T) {
+	queries := newAlbumCoverQueries(t)
+
+	recorder := setAlbumCoverForTest(t, queries, false)
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.NotNil(t, queries.setCover)
+}
+
+func TestAlbumHandlerSetAlbumCover_RejectsOtherUsersAsset(t *testing.T) {
+	queries := newAlbumCoverQueries(t)
+	otherOwner := int32(8)
+	queries.asset.OwnerID = &otherOwner
+
+	recorder := setAlbumCoverForTest(t, queries, false)
+
+	require.Equal(t, http.StatusForbidden, recorder.Code)
+	require.Nil(t, queries.setCover)
+}
+
+type stubAlbumCoverService struct {
+	service.AlbumService
+	cover pgtype.UUID
+	calls int
+}
+
+func (s *stubAlbumCoverService) AutoSelectAlbumCover(_ context.Context, albumID int32) (repo.Album, error) {
+	s.calls++
+	return repo.Album{AlbumID: albumID, UserID: 7, AlbumName: "Trip", AlbumType: repo.AlbumTypeDefault, CoverAssetID: s.cover}, nil
+}
+
+func autoSelectAlbumCoverForTest(t *testing.T, queries *stubAlbumCoverQueries, svc *stubAlbumCoverService) *httptest.ResponseRecorder {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	var albumService service.AlbumService = svc
+	handler := &AlbumHandler{albumService: &albumService, queries: queries}
+
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Request = httptest.NewRequest(http.MethodPut, "/api/v1/albums/9/cover/auto", nil)
+	ctx.Params = gin.Params{{Key: "id", Value: "9"}}
+	ctx.Set("current_user", &service.UserResponse{UserID: 7, Role: "user"})
+
+	handler.AutoSelectAlbumCover(ctx)
+	return recorder
+}
+
+func TestAlbumHandlerAutoSelectAlbumCover_ReturnsChosenCover(t *testing.T) {
+	queries := newAlbumCoverQueries(t)
+	svc := &stubAlbumCoverService{cover: queries.asset.AssetID}
+
+	recorder := autoSelectAlbumCoverForTest(t, queries, svc)
+
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+	require.Equal(t, 1, svc.calls)
+	var response dto.GetAlbumResponseDTO
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	require.NotNil(t, response.CoverAssetID)
+	require.Equal(t, "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa", *response.CoverAssetID)
+	require.Len(t, queries.activity, 1)
+	require.Equal(t, service.ActivityAlbumUpdated, queries.activity[0].EventType)
+}
+
+func TestAlbumHandlerAutoSelectAlbumCover_RejectsSmartAlbum(t *testing.T) {
+	queries := newAlbumCoverQueries(t)
+	queries.album.Rules = []byte(`{"liked":true}`)
+	svc := &stubAlbumCoverService{}
+
+	recorder := autoSelectAlbumCoverForTest(t, queries, svc)
+
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+	require.Zero(t, svc.calls)
+}
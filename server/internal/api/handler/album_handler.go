@@ -93,6 +93,7 @@ func toAssetAlbumDTO(row repo.GetAssetAlbumsRow) dto.AssetAlbumDTO {
 				Description:  row.Description,
 				CoverAssetID: row.CoverAssetID,
 				AlbumType:    row.AlbumType,
+				Rules:        row.Rules,
 			}),
 		},
 		Position:  row.Position,
@@ -111,6 +112,7 @@ func toScopedAlbumResponseDTO(row repo.GetAlbumByIDScopedRow) dto.GetAlbumRespon
 			Description:  row.Description,
 			CoverAssetID: row.CoverAssetID,
 			AlbumType:    row.AlbumType,
+			Rules:        row.Rules,
 		}),
 		row.AssetCount,
 		optionalUUIDToString(row.DisplayCoverAssetID),
@@ -128,6 +130,7 @@ func toScopedAlbumListItemDTO(row repo.GetAlbumsByUserScopedRow) dto.GetAlbumRes
 			Description:  row.Description,
 			CoverAssetID: row.CoverAssetID,
 			AlbumType:    row.AlbumType,
+			Rules:        row.Rules,
 		}),
 		row.AssetCount,
 		optionalUUIDToString(row.DisplayCoverAssetID),
@@ -135,33 +138,42 @@ func toScopedAlbumListItemDTO(row repo.GetAlbumsByUserScopedRow) dto.GetAlbumRes
 }
 
 type AlbumHandler struct {
-	albumService    *service.AlbumService
-	queries         *repo.Queries
-	queueClient     *river.Client[pgx.Tx]
-	settingsService service.SettingsService
-	runtimeChecker  service.LumenService
+	albumService      *service.AlbumService
+	assetService      service.AssetService
+	shareService      service.ShareLinkService
+	queries           repo.Querier
+	queueClient       *river.Client[pgx.Tx]
+	settingsService   service.SettingsService
+	runtimeChecker    service.LumenService
+	coverMustBeMember bool
 }
 
 // NewAlbumHandler creates a new album handler
 func NewAlbumHandler(
 	albumService *service.AlbumService,
-	queries *repo.Queries,
+	assetService service.AssetService,
+	shareService service.ShareLinkService,
+	queries repo.Querier,
 	queueClient *river.Client[pgx.Tx],
 	settingsService service.SettingsService,
 	runtimeChecker service.LumenService,
+	coverMustBeMember bool,
 ) *AlbumHandler {
 	return &AlbumHandler{
-		albumService:    albumService,
-		queries:         queries,
-		queueClient:     queueClient,
-		settingsService: settingsService,
-		runtimeChecker:  runtimeChecker,
+		albumService:      albumService,
+		assetService:      assetService,
+		shareService:      shareService,
+		queries:           queries,
+		queueClient:       queueClient,
+		settingsService:   settingsService,
+		runtimeChecker:    runtimeChecker,
+		coverMustBeMember: coverMustBeMember,
 	}
 }
 
 // NewAlbum creates a new album
 // @Summary Create a new album
-// @Description Create a new album for the authenticated user
+// @Description Create a new album for the authenticated user. cover_asset_id is optional; without it the server chooses the cover as assets are added, as after PUT /api/v1/albums/{id}/cover/auto.
 // @Tags albums
 // @Accept json
 // @Produce json
@@ -213,6 +225,7 @@ func (h *AlbumHandler) NewAlbum(c *gin.Context) {
 		api.GinInternalError(c, err, "Failed to create album")
 		return
 	}
+	h.recordAlbumActivity(c.Request.Context(), service.ActivityAlbumCreated, album, pgtype.UUID{})
 
 	// Get asset count for the new album (should be 0 for new album)
 	count, _ := h.queries.GetAlbumAssetCount(c.Request.Context(), album.AlbumID)
@@ -226,6 +239,22 @@ func (h *AlbumHandler) NewAlbum(c *gin.Context) {
 	api.JSONOK(c, response)
 }
 
+// recordAlbumActivity adds an album change to the activity feed. The change
+// itself has already succeeded, so a failure is only logged.
+func (h *AlbumHandler) recordAlbumActivity(ctx context.Context, eventType string, album repo.Album, assetID pgtype.UUID) {
+	albumID := album.AlbumID
+	ownerID := album.UserID
+	if err := service.RecordActivity(ctx, h.queries, service.ActivityEvent{
+		Type:    eventType,
+		AssetID: assetID,
+		AlbumID: &albumID,
+		OwnerID: &ownerID,
+		Payload: map[string]any{"album_name": album.AlbumName},
+	}); err != nil {
+		log.Printf("Failed to record activity for album %d: %v", album.AlbumID, err)
+	}
+}
+
 // GetAlbum retrieves a specific album by ID
 // @Summary Get album by ID
 // @Description Retrieve a specific album by its ID
@@ -247,7 +276,8 @@ func (h *AlbumHandler) GetAlbum(c *gin.Context) {
 		return
 	}
 
-	if _, ok := h.getAuthorizedAlbum(c, int32(albumID), "Authentication required to access this album", "You don't have permission to access this album"); !ok {
+	authorized, ok := h.getAuthorizedAlbum(c, int32(albumID), "Authentication required to access this album", "You don't have permission to access this album")
+	if !ok {
 		return
 	}
 
@@ -265,7 +295,16 @@ func (h *AlbumHandler) GetAlbum(c *gin.Context) {
 		return
 	}
 
-	api.JSONOK(c, toScopedAlbumResponseDTO(album))
+	response := toScopedAlbumResponseDTO(album)
+	if isSmartAlbum(*authorized) {
+		if err := h.fillSmartAlbumSummary(c.Request.Context(), *authorized, repositoryID, &response); err != nil {
+			log.Printf("Failed to evaluate smart album %d: %v", albumID, err)
+			api.GinInternalError(c, err, "Failed to retrieve album")
+			return
+		}
+	}
+
+	api.JSONOK(c, response)
 }
 
 // ListAlbums retrieves albums for the authenticated user
@@ -338,6 +377,12 @@ func (h *AlbumHandler) ListAlbums(c *gin.Context) {
 	albumResponses := make([]dto.GetAlbumResponseDTO, len(albums))
 	for i, album := range albums {
 		albumResponses[i] = toScopedAlbumListItemDTO(album)
+		if album.Rules != nil {
+			smart := repo.Album{AlbumID: album.AlbumID, UserID: album.UserID, Rules: album.Rules}
+			if err := h.fillSmartAlbumSummary(c.Request.Context(), smart, repositoryID, &albumResponses[i]); err != nil {
+				log.Printf("Failed to evaluate smart album %d: %v", album.AlbumID, err)
+			}
+		}
 	}
 
 	response := dto.ListAlbumsResponseDTO{
@@ -419,6 +464,7 @@ func (h *AlbumHandler) UpdateAlbum(c *gin.Context) {
 		api.GinInternalError(c, err, "Failed to update album")
 		return
 	}
+	h.recordAlbumActivity(c.Request.Context(), service.ActivityAlbumUpdated, updatedAlbum, pgtype.UUID{})
 
 	// Get asset count for the updated album
 	count, err := h.queries.GetAlbumAssetCount(c.Request.Context(), updatedAlbum.AlbumID)
@@ -432,10 +478,152 @@ func (h *AlbumHandler) UpdateAlbum(c *gin.Context) {
 		count,
 		optionalUUIDToString(updatedAlbum.CoverAssetID),
 	)
+	if isSmartAlbum(updatedAlbum) {
+		if err := h.fillSmartAlbumSummary(c.Request.Context(), updatedAlbum, pgtype.UUID{}, &response); err != nil {
+			log.Printf("Failed to evaluate smart album %d: %v", updatedAlbum.AlbumID, err)
+		}
+	}
 
 	api.JSONOK(c, response)
 }
 
+// SetAlbumCover sets an album's cover asset
+// @Summary Set album cover
+// @Description Set the cover asset of an album without resending the full album payload. The asset must be accessible to the album owner; when the server requires it, the asset must also be in the album.
+// @Tags albums
+// @Accept json
+// @Produce json
+// @Param id path int true "Album ID"
+// @Param assetId path string true "Asset ID (UUID format)"
+// @Success 200 {object} dto.GetAlbumResponseDTO "Album cover updated successfully"
+// @Failure 400 {object} api.ErrorResponse "Invalid album ID or asset ID, or asset not in album"
+// @Failure 401 {object} api.ErrorResponse "Unauthorized"
+// @Failure 403 {object} api.ErrorResponse "Forbidden"
+// @Failure 404 {object} api.ErrorResponse "Album or asset not found"
+// @Failure 500 {object} api.ErrorResponse "Failed to update album cover"
+// @Router /api/v1/albums/{id}/cover/{assetId} [put]
+// @Security BearerAuth
+func (h *AlbumHandler) SetAlbumCover(c *gin.Context) {
+	albumID, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		api.GinBadRequest(c, err, "Invalid album ID")
+		return
+	}
+
+	assetID, err := uuid.Parse(c.Param("assetId"))
+	if err != nil {
+		api.GinBadRequest(c, err, "Invalid asset ID")
+		return
+	}
+
+	album, ok := h.getAuthorizedAlbum(c, int32(albumID), "Authentication required to update this album", "You don't have permission to update this album")
+	if !ok {
+		return
+	}
+
+	assetPGUUID := pgtype.UUID{Bytes: assetID, Valid: true}
+	asset, err := h.queries.GetAssetByID(c.Request.Context(), assetPGUUID)
+	if err == nil && asset.IsDeleted != nil && *asset.IsDeleted {
+		err = errors.New("asset is deleted")
+	}
+	if err != nil {
+		api.GinNotFound(c, err, "Asset not found")
+		return
+	}
+	if !ensureOwnerAccess(c, asset.OwnerID, "Authentication required to access this asset", "You don't have permission to access this asset") {
+		return
+	}
+	if asset.OwnerID != nil && *asset.OwnerID != album.UserID && !currentUserIsAdmin(c) {
+		api.GinForbidden(c, errors.New("cross-user album access denied"), "Asset and album must belong to the same user")
+		return
+	}
+
+	if h.coverMustBeMember {
+		isMember, err := h.queries.IsAssetInAlbum(c.Request.Context(), repo.IsAssetInAlbumParams{
+			AlbumID: album.AlbumID,
+			AssetID: assetPGUUID,
+		})
+		if err != nil {
+			api.GinInternalError(c, err, "Failed to check album membership")
+			return
+		}
+		if !isMember {
+			api.GinBadRequest(c, errors.New("asset is not in album"), "Asset must be added to the album before it can be the cover")
+			return
+		}
+	}
+
+	updatedAlbum, err := h.queries.SetAlbumCoverAsset(c.Request.Context(), repo.SetAlbumCoverAssetParams{
+		AlbumID:      album.AlbumID,
+		CoverAssetID: assetPGUUID,
+	})
+	if err != nil {
+		log.Printf("Failed to set cover of album %d: %v", albumID, err)
+		api.GinInternalError(c, err, "Failed to update album cover")
+		return
+	}
+	h.recordAlbumActivity(c.Request.Context(), service.ActivityAlbumUpdated, updatedAlbum, pgtype.UUID{})
+
+	count, err := h.queries.GetAlbumAssetCount(c.Request.Context(), updatedAlbum.AlbumID)
+	if err != nil {
+		log.Printf("Failed to get asset count for album %d: %v", updatedAlbum.AlbumID, err)
+		count = 0
+	}
+
+	api.JSONOK(c, toAlbumResponseDTO(
+		dto.ToAlbumDTO(updatedAlbum),
+		count,
+		optionalUUIDToString(updatedAlbum.CoverAssetID),
+	))
+}
+
+// AutoSelectAlbumCover lets the server choose an album's cover
+// @Summary Choose album cover automatically
+// @Description Replace the cover of an album with its highest-rated asset, the most recently taken among equally rated ones, or leave an empty album without a cover. The server keeps making this choice as assets are added and removed until a cover is set by PUT /api/v1/albums/{id}/cover/{assetId}. Albums created without a cover start out this way, and an album whose cover is removed from it switches to it.
+// @Tags albums
+// @Produce json
+// @Param id path int true "Album ID"
+// @Success 200 {object} dto.GetAlbumResponseDTO "Album cover updated successfully"
+// @Failure 400 {object} api.ErrorResponse "Invalid album ID, or a smart album"
+// @Failure 401 {object} api.ErrorResponse "Unauthorized"
+// @Failure 403 {object} api.ErrorResponse "Forbidden"
+// @Failure 404 {object} api.ErrorResponse "Album not found"
+// @Failure 500 {object} api.ErrorResponse "Failed to update album cover"
+// @Router /api/v1/albums/{id}/cover/auto [put]
+// @Security BearerAuth
+func (h *AlbumHandler) AutoSelectAlbumCover(c *gin.Context) {
+	albumID, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		api.GinBadRequest(c, err, "Invalid album ID")
+		return
+	}
+
+	album, ok := h.getAuthorizedAlbum(c, int32(albumID), "Authentication required to update this album", "You don't have permission to update this album")
+	if !ok || !ensureManualAlbum(c, *album) {
+		return
+	}
+
+	updatedAlbum, err := (*h.albumService).AutoSelectAlbumCover(c.Request.Context(), album.AlbumID)
+	if err != nil {
+		log.Printf("Failed to choose cover of album %d: %v", albumID, err)
+		api.GinInternalError(c, err, "Failed to update album cover")
+		return
+	}
+	h.recordAlbumActivity(c.Request.Context(), service.ActivityAlbumUpdated, updatedAlbum, pgtype.UUID{})
+
+	count, err := h.queries.GetAlbumAssetCount(c.Request.Context(), updatedAlbum.AlbumID)
+	if err != nil {
+		log.Printf("Failed to get asset count for album %d: %v", updatedAlbum.AlbumID, err)
+		count = 0
+	}
+
+	api.JSONOK(c, toAlbumResponseDTO(
+		dto.ToAlbumDTO(updatedAlbum),
+		count,
+		optionalUUIDToString(updatedAlbum.CoverAssetID),
+	))
+}
+
 // DeleteAlbum deletes an album
 // @Summary Delete album
 // @Description Delete an album by its ID
@@ -459,7 +647,8 @@ func (h *AlbumHandler) DeleteAlbum(c *gin.Context) {
 		return
 	}
 
-	if _, ok := h.getAuthorizedAlbum(c, int32(albumID), "Authentication required to delete this album", "You don't have permission to delete this album"); !ok {
+	album, ok := h.getAuthorizedAlbum(c, int32(albumID), "Authentication required to delete this album", "You don't have permission to delete this album")
+	if !ok {
 		return
 	}
 
@@ -469,13 +658,14 @@ func (h *AlbumHandler) DeleteAlbum(c *gin.Context) {
 		api.GinInternalError(c, err, "Failed to delete album")
 		return
 	}
+	h.recordAlbumActivity(c.Request.Context(), service.ActivityAlbumDeleted, *album, pgtype.UUID{})
 
 	api.JSONOK(c, api.SuccessResponse{Message: "Album deleted successfully"})
 }
 
 // GetAlbumAssets retrieves all assets in an album
 // @Summary Get assets in album
-// @Description Retrieve all assets in a specific album
+// @Description Retrieve all assets in a specific album. A smart album's rules are evaluated at request time, newest capture first, and its assets carry no position or added time.
 // @Tags albums
 // @Accept json
 // @Produce json
@@ -500,7 +690,12 @@ func (h *AlbumHandler) GetAlbumAssets(c *gin.Context) {
 		return
 	}
 
-	if _, ok := h.getAuthorizedAlbum(c, int32(albumID), "Authentication required to access this album", "You don't have permission to access this album"); !ok {
+	album, ok := h.getAuthorizedAlbum(c, int32(albumID), "Authentication required to access this album", "You don't have permission to access this album")
+	if !ok {
+		return
+	}
+	if isSmartAlbum(*album) {
+		h.getSmartAlbumAssets(c, *album, repositoryID)
 		return
 	}
 
@@ -536,7 +731,7 @@ func (h *AlbumHandler) GetAlbumAssets(c *gin.Context) {
 // @Param assetId path string true "Asset ID (UUID format)"
 // @Param request body dto.AddAssetToAlbumRequestDTO false "Asset position in album"
 // @Success 200 {object} api.SuccessResponse "Asset added to album successfully"
-// @Failure 400 {object} api.ErrorResponse "Invalid album ID or asset ID"
+// @Failure 400 {object} api.ErrorResponse "Invalid album ID or asset ID, or smart album"
 // @Failure 404 {object} api.ErrorResponse "Album not found"
 // @Failure 500 {object} api.ErrorResponse "Failed to add asset to album"
 // @Router /api/v1/albums/{id}/assets/{assetId} [post]
@@ -563,7 +758,7 @@ func (h *AlbumHandler) AddAssetToAlbum(c *gin.Context) {
 	}
 
 	album, ok := h.getAuthorizedAlbum(c, int32(albumID), "Authentication required to modify this album", "You don't have permission to modify this album")
-	if !ok {
+	if !ok || !ensureManualAlbum(c, *album) {
 		return
 	}
 
@@ -587,12 +782,13 @@ func (h *AlbumHandler) AddAssetToAlbum(c *gin.Context) {
 		Position: req.Position,
 	}
 
-	err = h.queries.AddAssetToAlbum(c.Request.Context(), params)
+	err = (*h.albumService).AddAssetToAlbum(c.Request.Context(), params)
 	if err != nil {
 		log.Printf("Failed to add asset %s to album %d: %v", assetID, albumID, err)
 		api.GinInternalError(c, err, "Failed to add asset to album")
 		return
 	}
+	h.recordAlbumActivity(c.Request.Context(), service.ActivityAlbumAssetAdded, *album, assetPGUUID)
 	h.enqueueBioClipForAddedAsset(c.Request.Context(), *album, asset)
 
 	api.JSONOK(c, api.SuccessResponse{Message: "Asset added to album successfully"})
@@ -691,7 +887,7 @@ func (h *AlbumHandler) enqueueBioClipForAddedAsset(ctx context.Context, album re
 // @Param id path int true "Album ID"
 // @Param assetId path string true "Asset ID (UUID format)"
 // @Success 200 {object} api.SuccessResponse "Asset removed from album successfully"
-// @Failure 400 {object} api.ErrorResponse "Invalid album ID or asset ID"
+// @Failure 400 {object} api.ErrorResponse "Invalid album ID or asset ID, or smart album"
 // @Failure 500 {object} api.ErrorResponse "Failed to remove asset from album"
 // @Router /api/v1/albums/{id}/assets/{assetId} [delete]
 // @Security BearerAuth
@@ -715,16 +911,18 @@ func (h *AlbumHandler) RemoveAssetFromAlbum(c *gin.Context) {
 		AlbumID: int32(albumID),
 	}
 
-	if _, ok := h.getAuthorizedAlbum(c, int32(albumID), "Authentication required to modify this album", "You don't have permission to modify this album"); !ok {
+	album, ok := h.getAuthorizedAlbum(c, int32(albumID), "Authentication required to modify this album", "You don't have permission to modify this album")
+	if !ok || !ensureManualAlbum(c, *album) {
 		return
 	}
 
-	err = h.queries.RemoveAssetFromAlbum(c.Request.Context(), params)
+	err = (*h.albumService).RemoveAssetFromAlbum(c.Request.Context(), params)
 	if err != nil {
 		log.Printf("Failed to remove asset %s from album %d: %v", assetID, albumID, err)
 		api.GinInternalError(c, err, "Failed to remove asset from album")
 		return
 	}
+	h.recordAlbumActivity(c.Request.Context(), service.ActivityAlbumAssetRemoved, *album, params.AssetID)
 
 	api.JSONOK(c, api.SuccessResponse{Message: "Asset removed from album successfully"})
 }
@@ -739,7 +937,7 @@ func (h *AlbumHandler) RemoveAssetFromAlbum(c *gin.Context) {
 // @Param assetId path string true "Asset ID (UUID format)"
 // @Param request body dto.UpdateAssetPositionRequestDTO true "New position data"
 // @Success 200 {object} api.SuccessResponse "Asset position updated successfully"
-// @Failure 400 {object} api.ErrorResponse "Invalid album ID or asset ID"
+// @Failure 400 {object} api.ErrorResponse "Invalid album ID or asset ID, or smart album"
 // @Failure 500 {object} api.ErrorResponse "Failed to update asset position"
 // @Router /api/v1/albums/{id}/assets/{assetId}/position [put]
 // @Security BearerAuth
@@ -770,7 +968,8 @@ func (h *AlbumHandler) UpdateAssetPositionInAlbum(c *gin.Context) {
 		Position: req.Position,
 	}
 
-	if _, ok := h.getAuthorizedAlbum(c, int32(albumID), "Authentication required to modify this album", "You don't have permission to modify this album"); !ok {
+	album, ok := h.getAuthorizedAlbum(c, int32(albumID), "Authentication required to modify this album", "You don't have permission to modify this album")
+	if !ok || !ensureManualAlbum(c, *album) {
 		return
 	}
 
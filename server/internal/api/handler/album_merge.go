@@ -0,0 +1,144 @@
+package handler
+
+import (
+	"errors"
+	"log"
+
+	"server/internal/api"
+	"server/internal/api/dto"
+	"server/internal/db/repo"
+	"server/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// ListDuplicateAlbums lists the caller's albums that share a name
+// @Summary List duplicate albums
+// @Description List the caller's albums whose exact name and type match another of their albums, grouped by name. Within a group the oldest album comes first, which makes it the natural merge target.
+// @Tags albums
+// @Produce json
+// @Success 200 {object} dto.AlbumDuplicatesResponseDTO "Duplicate album groups"
+// @Failure 401 {object} api.ErrorResponse "Unauthorized"
+// @Failure 500 {object} api.ErrorResponse "Failed to list duplicate albums"
+// @Router /api/v1/albums/duplicates [get]
+// @Security BearerAuth
+func (h *AlbumHandler) ListDuplicateAlbums(c *gin.Context) {
+	userID, err := currentUserIDFromContext(c)
+	if err != nil {
+		api.GinUnauthorized(c, err, "Unauthorized")
+		return
+	}
+
+	rows, err := (*h.albumService).ListDuplicateAlbums(c.Request.Context(), *userID)
+	if err != nil {
+		log.Printf("Failed to list duplicate albums for user %d: %v", *userID, err)
+		api.GinInternalError(c, err, "Failed to list duplicate albums")
+		return
+	}
+
+	api.JSONOK(c, toAlbumDuplicatesResponseDTO(rows))
+}
+
+// toAlbumDuplicatesResponseDTO groups rows that arrive ordered by name and type.
+func toAlbumDuplicatesResponseDTO(rows []repo.ListDuplicateAlbumsByUserRow) dto.AlbumDuplicatesResponseDTO {
+	groups := make([]dto.AlbumDuplicateGroupDTO, 0)
+	for _, row := range rows {
+		albumType := string(row.AlbumType)
+		if n := len(groups); n == 0 || groups[n-1].AlbumName != row.AlbumName || groups[n-1].AlbumType != albumType {
+			groups = append(groups, dto.AlbumDuplicateGroupDTO{AlbumName: row.AlbumName, AlbumType: albumType})
+		}
+		album := repo.Album{
+			AlbumID:      row.AlbumID,
+			UserID:       row.UserID,
+			AlbumName:    row.AlbumName,
+			CreatedAt:    row.CreatedAt,
+			UpdatedAt:    row.UpdatedAt,
+			Description:  row.Description,
+			CoverAssetID: row.CoverAssetID,
+			AlbumType:    row.AlbumType,
+		}
+		last := &groups[len(groups)-1]
+		last.Albums = append(last.Albums, toAlbumResponseDTO(dto.ToAlbumDTO(album), row.AssetCount, optionalUUIDToString(row.CoverAssetID)))
+	}
+	return dto.AlbumDuplicatesResponseDTO{Groups: groups, Count: len(groups)}
+}
+
+// MergeAlbums moves the assets of source albums into a target album
+// @Summary Merge albums
+// @Description Move every asset of the source albums into the target and delete the sources. Assets already in the target keep their position; the others are appended after the target's last position, source by source in the order given, each keeping its order within its album. An asset in several sources is added once. A target without a cover adopts the first source cover. All albums must belong to the same user and share the target's type, and none may be a smart album. Runs in one transaction.
+// @Tags albums
+// @Accept json
+// @Produce json
+// @Param request body dto.MergeAlbumsRequestDTO true "Target and source albums"
+// @Success 200 {object} dto.MergeAlbumsResponseDTO "Albums merged"
+// @Failure 400 {object} api.ErrorResponse "Invalid request, mismatched album types or smart album"
+// @Failure 401 {object} api.ErrorResponse "Unauthorized"
+// @Failure 403 {object} api.ErrorResponse "Forbidden"
+// @Failure 404 {object} api.ErrorResponse "Album not found"
+// @Failure 500 {object} api.ErrorResponse "Failed to merge albums"
+// @Router /api/v1/albums/merge [post]
+// @Security BearerAuth
+func (h *AlbumHandler) MergeAlbums(c *gin.Context) {
+	var req dto.MergeAlbumsRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		api.GinBadRequest(c, err, "Invalid request data")
+		return
+	}
+
+	sourceIDs := make([]int32, 0, len(req.SourceAlbumIDs))
+	seen := make(map[int32]bool, len(req.SourceAlbumIDs))
+	for _, id := range req.SourceAlbumIDs {
+		if id == req.TargetAlbumID {
+			api.GinBadRequest(c, errors.New("target album is also a source"), "An album cannot be merged into itself")
+			return
+		}
+		if !seen[id] {
+			seen[id] = true
+			sourceIDs = append(sourceIDs, id)
+		}
+	}
+
+	target, ok := h.getAuthorizedAlbum(c, req.TargetAlbumID, "Authentication required to merge albums", "You don't have permission to modify this album")
+	if !ok || !ensureManualAlbum(c, *target) {
+		return
+	}
+	sources := make([]repo.Album, 0, len(sourceIDs))
+	for _, id := range sourceIDs {
+		source, ok := h.getAuthorizedAlbum(c, id, "Authentication required to merge albums", "You don't have permission to delete this album")
+		if !ok || !ensureManualAlbum(c, *source) {
+			return
+		}
+		if source.UserID != target.UserID {
+			api.GinForbidden(c, errors.New("cross-user album merge denied"), "Albums must belong to the same user")
+			return
+		}
+		if source.AlbumType != target.AlbumType {
+			api.GinBadRequest(c, errors.New("album types differ"), "Albums must share the target's type")
+			return
+		}
+		sources = append(sources, *source)
+	}
+
+	result, err := (*h.albumService).MergeAlbums(c.Request.Context(), target.AlbumID, sourceIDs)
+	if err != nil {
+		log.Printf("Failed to merge albums %v into %d: %v", sourceIDs, target.AlbumID, err)
+		api.GinInternalError(c, err, "Failed to merge albums")
+		return
+	}
+	for _, source := range sources {
+		h.recordAlbumActivity(c.Request.Context(), service.ActivityAlbumDeleted, source, pgtype.UUID{})
+	}
+	h.recordAlbumActivity(c.Request.Context(), service.ActivityAlbumUpdated, result.Album, pgtype.UUID{})
+
+	count, err := h.queries.GetAlbumAssetCount(c.Request.Context(), target.AlbumID)
+	if err != nil {
+		log.Printf("Failed to count assets of merged album %d: %v", target.AlbumID, err)
+	}
+
+	api.JSONOK(c, dto.MergeAlbumsResponseDTO{
+		Album:           toAlbumResponseDTO(dto.ToAlbumDTO(result.Album), count, optionalUUIDToString(result.Album.CoverAssetID)),
+		AssetsAdded:     result.AssetsAdded,
+		RemovedAlbumIDs: sourceIDs,
+	})
+}
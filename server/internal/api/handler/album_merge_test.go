@@ -0,0 +1,140 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"server/internal/api/dto"
+	"server/internal/db/repo"
+	"server/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/require"
+)
+
+type stubAlbumMergeQueries struct {
+	repo.Querier
+	albums map[int32]repo.Album
+}
+
+func (s *stubAlbumMergeQueries) GetAlbumByID(_ context.Context, albumID int32) (repo.Album, error) {
+	album, ok := s.albums[albumID]
+	if !ok {
+		return repo.Album{}, pgx.ErrNoRows
+	}
+	return album, nil
+}
+
+func (s *stubAlbumMergeQueries) RecordActivityEvent(context.Context, repo.RecordActivityEventParams) error {
+	return nil
+}
+
+func (s *stubAlbumMergeQueries) GetAlbumAssetCount(context.Context, int32) (int64, error) {
+	return 6, nil
+}
+
+type stubAlbumMergeService struct {
+	service.AlbumService
+	targetID  int32
+	sourceIDs []int32
+	calls     int
+}
+
+func (s *stubAlbumMergeService) MergeAlbums(_ context.Context, targetID int32, sourceIDs []int32) (service.MergeAlbumsResult, error) {
+	s.calls++
+	s.targetID = targetID
+	s.sourceIDs = sourceIDs
+	return service.MergeAlbumsResult{
+		Album:         repo.Album{AlbumID: targetID, UserID: 7, AlbumName: "Trip", AlbumType: repo.AlbumTypeDefault},
+		AssetsAdded:   4,
+		AlbumsRemoved: int64(len(sourceIDs)),
+	}, nil
+}
+
+func mergeAlbumsForTest(t *testing.T, svc *stubAlbumMergeService, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	queries := &stubAlbumMergeQueries{albums: map[int32]repo.Album{
+		1: {AlbumID: 1, UserID: 7, AlbumName: "Trip", AlbumType: repo.AlbumTypeDefault},
+		2: {AlbumID: 2, UserID: 7, AlbumName: "Trip", AlbumType: repo.AlbumTypeDefault},
+		3: {AlbumID: 3, UserID: 7, AlbumName: "Trip", AlbumType: repo.AlbumTypeDefault},
+		4: {AlbumID: 4, UserID: 7, AlbumName: "Trip", AlbumType: repo.AlbumTypeBio},
+		5: {AlbumID: 5, UserID: 8, AlbumName: "Trip", AlbumType: repo.AlbumTypeDefault},
+	}}
+	var albumService service.AlbumService = svc
+	handler := &AlbumHandler{albumService: &albumService, queries: queries}
+
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Request = httptest.NewRequest(http.MethodPost, "/api/v1/albums/merge", strings.NewReader(body))
+	ctx.Request.Header.Set("Content-Type", "application/json")
+	ctx.Set("current_user", &service.UserResponse{UserID: 7, Role: "user"})
+
+	handler.MergeAlbums(ctx)
+	return recorder
+}
+
+func TestMergeAlbumsDedupesSourcesAndReportsTarget(t *testing.T) {
+	svc := &stubAlbumMergeService{}
+	recorder := mergeAlbumsForTest(t, svc, `{"target_album_id":1,"source_album_ids":[3,2,3]}`)
+
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+	require.Equal(t, int32(1), svc.targetID)
+	require.Equal(t, []int32{3, 2}, svc.sourceIDs)
+
+	var response dto.MergeAlbumsResponseDTO
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	require.Equal(t, int32(1), response.Album.AlbumID)
+	require.Equal(t, int64(6), response.Album.AssetCount)
+	require.Equal(t, int64(4), response.AssetsAdded)
+	require.Equal(t, []int32{3, 2}, response.RemovedAlbumIDs)
+}
+
+func TestMergeAlbumsRejectsInvalidMerges(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want int
+	}{
+		{name: "no sources", body: `{"target_album_id":1,"source_album_ids":[]}`, want: http.StatusBadRequest},
+		{name: "into itself", body: `{"target_album_id":1,"source_album_ids":[2,1]}`, want: http.StatusBadRequest},
+		{name: "different type", body: `{"target_album_id":1,"source_album_ids":[4]}`, want: http.StatusBadRequest},
+		{name: "another user's album", body: `{"target_album_id":1,"source_album_ids":[5]}`, want: http.StatusForbidden},
+		{name: "missing album", body: `{"target_album_id":1,"source_album_ids":[9]}`, want: http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &stubAlbumMergeService{}
+			recorder := mergeAlbumsForTest(t, svc, tt.body)
+			require.Equal(t, tt.want, recorder.Code, recorder.Body.String())
+			require.Zero(t, svc.calls)
+		})
+	}
+}
+
+func TestToAlbumDuplicatesResponseDTOGroupsByNameAndType(t *testing.T) {
+	rows := []repo.ListDuplicateAlbumsByUserRow{
+		{AlbumID: 4, AlbumName: "Birds", AlbumType: repo.AlbumTypeBio, AssetCount: 3},
+		{AlbumID: 9, AlbumName: "Birds", AlbumType: repo.AlbumTypeBio},
+		{AlbumID: 2, AlbumName: "Birds", AlbumType: repo.AlbumTypeDefault},
+		{AlbumID: 6, AlbumName: "Birds", AlbumType: repo.AlbumTypeDefault},
+		{AlbumID: 1, AlbumName: "Trip", AlbumType: repo.AlbumTypeDefault},
+		{AlbumID: 3, AlbumName: "Trip", AlbumType: repo.AlbumTypeDefault},
+	}
+
+	response := toAlbumDuplicatesResponseDTO(rows)
+
+	require.Equal(t, 3, response.Count)
+	require.Equal(t, "bio", response.Groups[0].AlbumType)
+	require.Len(t, response.Groups[0].Albums, 2)
+	require.Equal(t, int64(3), response.Groups[0].Albums[0].AssetCount)
+	require.Equal(t, "default", response.Groups[1].AlbumType)
+	require.Equal(t, "Trip", response.Groups[2].AlbumName)
+	require.Equal(t, int32(3), response.Groups[2].Albums[1].AlbumID)
+}
@@ -0,0 +1,76 @@
+package handler
+
+import (
+	"errors"
+	"log"
+	"strconv"
+
+	"server/internal/api"
+	"server/internal/api/dto"
+	"server/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// ReorderAlbumAssets sets the order of all assets in an album
+// @Summary Reorder album assets
+// @Description Set the order of an album's assets in one request. The list must hold every asset of the album exactly once; the first gets position 1. All positions are updated in one transaction. Smart albums follow their rules and cannot be reordered.
+// @Tags albums
+// @Accept json
+// @Produce json
+// @Param id path int true "Album ID"
+// @Param request body dto.ReorderAlbumAssetsRequestDTO true "Every asset of the album, in the new order"
+// @Success 200 {object} dto.AlbumAssetOrderResponseDTO "Assets reordered successfully"
+// @Failure 400 {object} api.ErrorResponse "Invalid request, smart album, or a list that is not exactly the album's assets"
+// @Failure 401 {object} api.ErrorResponse "Unauthorized"
+// @Failure 403 {object} api.ErrorResponse "Forbidden"
+// @Failure 404 {object} api.ErrorResponse "Album not found"
+// @Failure 500 {object} api.ErrorResponse "Failed to reorder album assets"
+// @Router /api/v1/albums/{id}/assets/order [put]
+// @Security BearerAuth
+func (h *AlbumHandler) ReorderAlbumAssets(c *gin.Context) {
+	albumID, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		api.GinBadRequest(c, err, "Invalid album ID")
+		return
+	}
+
+	var req dto.ReorderAlbumAssetsRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		api.GinBadRequest(c, err, "Invalid request data")
+		return
+	}
+	order := make([]uuid.UUID, 0, len(req.AssetIDs))
+	for _, raw := range req.AssetIDs {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			api.GinBadRequest(c, err, "Invalid asset ID in asset_ids")
+			return
+		}
+		order = append(order, id)
+	}
+
+	album, ok := h.getAuthorizedAlbum(c, int32(albumID), "Authentication required to modify this album", "You don't have permission to modify this album")
+	if !ok || !ensureManualAlbum(c, *album) {
+		return
+	}
+
+	ordered, err := (*h.albumService).ReorderAlbumAssets(c.Request.Context(), album.AlbumID, order)
+	if err != nil {
+		var orderErr *service.AlbumOrderError
+		if errors.As(err, &orderErr) {
+			api.GinBadRequest(c, err, orderErr.Error())
+			return
+		}
+		log.Printf("Failed to reorder assets of album %d: %v", album.AlbumID, err)
+		api.GinInternalError(c, err, "Failed to reorder album assets")
+		return
+	}
+
+	assetIDs := make([]string, len(ordered))
+	for i, id := range ordered {
+		assetIDs[i] = id.String()
+	}
+	api.JSONOK(c, dto.AlbumAssetOrderResponseDTO{AlbumID: int64(album.AlbumID), AssetIDs: assetIDs})
+}
@@ -0,0 +1,105 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"server/internal/api/dto"
+	"server/internal/db/repo"
+	"server/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	orderTestAssetA = "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa"
+	orderTestAssetB = "bbbbbbbb-bbbb-bbbb-bbbb-bbbbbbbbbbbb"
+)
+
+type stubAlbumOrderService struct {
+	service.AlbumService
+	err   error
+	order []uuid.UUID
+	calls int
+}
+
+func (s *stubAlbumOrderService) ReorderAlbumAssets(_ context.Context, _ int32, order []uuid.UUID) ([]uuid.UUID, error) {
+	s.calls++
+	s.order = order
+	if s.err != nil {
+		return nil, s.err
+	}
+	return order, nil
+}
+
+func reorderAlbumAssetsForTest(t *testing.T, svc *stubAlbumOrderService, album repo.Album, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	queries := &stubAlbumMergeQueries{albums: map[int32]repo.Album{album.AlbumID: album}}
+	var albumService service.AlbumService = svc
+	handler := &AlbumHandler{albumService: &albumService, queries: queries}
+
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Request = httptest.NewRequest(http.MethodPut, "/api/v1/albums/1/assets/order", strings.NewReader(body))
+	ctx.Request.Header.Set("Content-Type", "application/json")
+	ctx.Params = gin.Params{{Key: "id", Value: "1"}}
+	ctx.Set("current_user", &service.UserResponse{UserID: 7, Role: "user"})
+
+	handler.ReorderAlbumAssets(ctx)
+	return recorder
+}
+
+func TestReorderAlbumAssetsReturnsNewOrder(t *testing.T) {
+	svc := &stubAlbumOrderService{}
+	recorder := reorderAlbumAssetsForTest(t, svc, repo.Album{AlbumID: 1, UserID: 7}, `{"asset_ids":["`+orderTestAssetB+`","`+orderTestAssetA+`"]}`)
+
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+	require.Equal(t, []uuid.UUID{uuid.MustParse(orderTestAssetB), uuid.MustParse(orderTestAssetA)}, svc.order)
+
+	var response dto.AlbumAssetOrderResponseDTO
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	require.Equal(t, int64(1), response.AlbumID)
+	require.Equal(t, []string{orderTestAssetB, orderTestAssetA}, response.AssetIDs)
+}
+
+func TestReorderAlbumAssetsRejectsInvalidOrders(t *testing.T) {
+	manual := repo.Album{AlbumID: 1, UserID: 7}
+	smart := repo.Album{AlbumID: 1, UserID: 7, Rules: []byte(`{"liked":true}`)}
+	tests := []struct {
+		name   string
+		album  repo.Album
+		body   string
+		err    error
+		want   int
+		called bool
+	}{
+		{name: "malformed asset ID", album: manual, body: `{"asset_ids":["nope"]}`, want: http.StatusBadRequest},
+		{name: "missing list", album: manual, body: `{}`, want: http.StatusBadRequest},
+		{name: "smart album", album: smart, body: `{"asset_ids":["` + orderTestAssetA + `"]}`, want: http.StatusBadRequest},
+		{name: "another user's album", album: repo.Album{AlbumID: 1, UserID: 8}, body: `{"asset_ids":["` + orderTestAssetA + `"]}`, want: http.StatusForbidden},
+		{
+			name:   "asset outside the album",
+			album:  manual,
+			body:   `{"asset_ids":["` + orderTestAssetA + `"]}`,
+			err:    &service.AlbumOrderError{NotInAlbum: []uuid.UUID{uuid.MustParse(orderTestAssetA)}},
+			want:   http.StatusBadRequest,
+			called: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &stubAlbumOrderService{err: tt.err}
+			recorder := reorderAlbumAssetsForTest(t, svc, tt.album, tt.body)
+			require.Equal(t, tt.want, recorder.Code, recorder.Body.String())
+			require.Equal(t, tt.called, svc.calls > 0)
+		})
+	}
+}
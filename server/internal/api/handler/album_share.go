@@ -0,0 +1,140 @@
+package handler
+
+import (
+	"log"
+	"strconv"
+	"strings"
+
+	"server/internal/api"
+	"server/internal/api/dto"
+	"server/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// ShareAlbum creates a public share link for an album
+// @Summary Share an album
+// @Description Create a revocable public link to an album, viewable at GET /api/v1/public/albums/{token} without signing in. The link holds the album's assets at this moment, including what a smart album's rules match now. It can expire and can require a password. The raw token is returned only in this response.
+// @Tags albums
+// @Accept json
+// @Produce json
+// @Param id path int true "Album ID"
+// @Param request body dto.ShareAlbumRequestDTO false "Share settings"
+// @Success 200 {object} dto.CreateShareLinkResponseDTO "Album shared successfully"
+// @Failure 400 {object} api.ErrorResponse "Invalid album ID, settings, or an empty album"
+// @Failure 401 {object} api.ErrorResponse "Unauthorized"
+// @Failure 403 {object} api.ErrorResponse "Forbidden"
+// @Failure 404 {object} api.ErrorResponse "Album not found"
+// @Failure 500 {object} api.ErrorResponse "Failed to create share link"
+// @Router /api/v1/albums/{id}/share [post]
+// @Security BearerAuth
+func (h *AlbumHandler) ShareAlbum(c *gin.Context) {
+	albumID, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		api.GinBadRequest(c, err, "Invalid album ID")
+		return
+	}
+
+	var req dto.ShareAlbumRequestDTO
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			api.GinBadRequest(c, err, "Invalid request data")
+			return
+		}
+	}
+
+	album, ok := h.getAuthorizedAlbum(c, int32(albumID), "Authentication required to share this album", "You don't have permission to share this album")
+	if !ok {
+		return
+	}
+	user, ok := requireCurrentUser(c)
+	if !ok {
+		return
+	}
+
+	var explicitIDs []uuid.UUID
+	if isSmartAlbum(*album) {
+		assets, err := h.smartAlbumAssets(c.Request.Context(), *album, pgtype.UUID{})
+		if err != nil {
+			log.Printf("Failed to evaluate smart album %d: %v", albumID, err)
+			api.GinInternalError(c, err, "Failed to create share link")
+			return
+		}
+		if len(assets) == 0 {
+			api.GinBadRequest(c, service.ErrShareLinkSourceEmpty, service.ErrShareLinkSourceEmpty.Error())
+			return
+		}
+		explicitIDs = make([]uuid.UUID, 0, len(assets))
+		for _, asset := range assets {
+			explicitIDs = append(explicitIDs, uuid.UUID(asset.AssetID.Bytes))
+		}
+	}
+
+	ref := strconv.FormatInt(albumID, 10)
+	link, rawToken, err := h.shareService.Create(c.Request.Context(), service.ShareLinkCreateParams{
+		OwnerID:          int32(user.UserID),
+		OwnerScope:       ownerScopeID(c),
+		Title:            album.AlbumName,
+		Description:      album.Description,
+		SourceKind:       "album",
+		SourceRef:        &ref,
+		ExplicitAssetIDs: explicitIDs,
+		ExpiresInDays:    req.ExpiresInDays,
+		AllowDownload:    req.AllowDownload,
+		IncludeOriginals: req.IncludeOriginals,
+		Password:         req.Password,
+	})
+	if err != nil {
+		writeShareLinkCreateError(c, err)
+		return
+	}
+
+	api.JSONOK(c, dto.CreateShareLinkResponseDTO{
+		ShareLinkDTO: dto.ToShareLinkDTO(link),
+		Token:        rawToken,
+	})
+}
+
+// RevokeAlbumShare revokes a public share link of an album
+// @Summary Revoke an album share
+// @Description Revoke a public link created by POST /api/v1/albums/{id}/share, identified by its token. The link stops working immediately and stays listed under /api/v1/share-links as revoked.
+// @Tags albums
+// @Produce json
+// @Param id path int true "Album ID"
+// @Param token path string true "Share token"
+// @Success 200 {object} dto.ShareLinkDTO "Share revoked successfully"
+// @Failure 400 {object} api.ErrorResponse "Invalid album ID"
+// @Failure 401 {object} api.ErrorResponse "Unauthorized"
+// @Failure 404 {object} api.ErrorResponse "Share link not found"
+// @Failure 500 {object} api.ErrorResponse "Failed to access share link"
+// @Router /api/v1/albums/{id}/share/{token} [delete]
+// @Security BearerAuth
+func (h *AlbumHandler) RevokeAlbumShare(c *gin.Context) {
+	albumID, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		api.GinBadRequest(c, err, "Invalid album ID")
+		return
+	}
+	user, ok := requireCurrentUser(c)
+	if !ok {
+		return
+	}
+
+	link, err := h.shareService.GetByToken(c.Request.Context(), int32(user.UserID), strings.TrimSpace(c.Param("token")))
+	if err == nil && (link.SourceKind != "album" || link.SourceRef == nil || *link.SourceRef != strconv.FormatInt(albumID, 10)) {
+		err = service.ErrShareLinkNotFound
+	}
+	if err != nil {
+		writeShareLinkLookupError(c, err)
+		return
+	}
+
+	revoked, err := h.shareService.Revoke(c.Request.Context(), int32(user.UserID), uuid.UUID(link.ShareID.Bytes))
+	if err != nil {
+		writeShareLinkLookupError(c, err)
+		return
+	}
+	api.JSONOK(c, dto.ToShareLinkDTO(revoked))
+}
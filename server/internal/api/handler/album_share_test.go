@@ -0,0 +1,134 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"server/internal/api/dto"
+	"server/internal/db/repo"
+	"server/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+// stubShareLinkService resolves one share and unlocks it with "secret",
+// handing out "proof" as the unlock cookie.
+type stubShareLinkService struct {
+	service.ShareLinkService
+	link repo.ShareLink
+}
+
+func (s stubShareLinkService) ResolvePublic(context.Context, string) (repo.ShareLink, error) {
+	return s.link, nil
+}
+
+func (s stubShareLinkService) UnlockPublic(link repo.ShareLink, _, password, proof string) (string, error) {
+	if link.PasswordHash == nil {
+		return "", nil
+	}
+	if proof == "proof" || password == "secret" {
+		return "proof", nil
+	}
+	return "", service.ErrShareLinkLocked
+}
+
+func (s stubShareLinkService) RecordView(context.Context, uuid.UUID) error { return nil }
+
+func (s stubShareLinkService) PublicAssetSource(repo.ShareLink) *service.AssetSetSource {
+	return &service.AssetSetSource{}
+}
+
+func newPublicAlbumTestHandler(t *testing.T, link repo.ShareLink) *ShareLinkHandler {
+	t.Helper()
+	var assetID pgtype.UUID
+	require.NoError(t, assetID.Scan("11111111-1111-1111-1111-111111111111"))
+	ownerID := int32(7)
+	path := "/photos/private/beach.jpg"
+	assets := stubAssetService{
+		queryBrowseFn: func(context.Context, service.QueryAssetsParams) (service.BrowseQueryResult, error) {
+			return service.BrowseQueryResult{
+				Items:        []service.BrowseItem{{Asset: repo.Asset{AssetID: assetID, Type: "PHOTO", OwnerID: &ownerID, StoragePath: &path, OriginalFilename: "beach.jpg"}}},
+				TotalVisible: 1,
+			}, nil
+		},
+	}
+	return &ShareLinkHandler{service: stubShareLinkService{link: link}, assetService: assets}
+}
+
+func getPublicAlbumForTest(h *ShareLinkHandler, header http.Header) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Request = httptest.NewRequest(http.MethodGet, "/api/v1/public/albums/tok", nil)
+	for name, values := range header {
+		ctx.Request.Header[name] = values
+	}
+	ctx.Params = gin.Params{{Key: "token", Value: "tok"}}
+	h.GetPublicAlbum(ctx)
+	return recorder
+}
+
+func publicAlbumLink(password bool) repo.ShareLink {
+	ref := "9"
+	link := repo.ShareLink{
+		OwnerID:    7,
+		Title:      "Beach",
+		SourceKind: "album",
+		SourceRef:  &ref,
+		AssetCount: 1,
+		ExpiresAt:  pgtype.Timestamptz{Time: time.Now().Add(time.Hour), Valid: true},
+	}
+	if password {
+		hash := "$2a$10$hash"
+		link.PasswordHash = &hash
+	}
+	return link
+}
+
+func TestShareLinkHandlerGetPublicAlbum_HidesOwnerDetails(t *testing.T) {
+	recorder := getPublicAlbumForTest(newPublicAlbumTestHandler(t, publicAlbumLink(false)), nil)
+
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+	body := recorder.Body.String()
+	for _, private := range []string{"owner_id", "storage_path", "beach.jpg", "source_ref", "/photos/private"} {
+		require.NotContains(t, body, private)
+	}
+
+	var response dto.PublicAlbumDTO
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	require.Equal(t, "Beach", response.Title)
+	require.Equal(t, 1, response.Total)
+	require.Equal(t, "/api/v1/public/shares/tok/assets/11111111-1111-1111-1111-111111111111/thumbnail", response.Assets[0].ThumbnailURL)
+}
+
+func TestShareLinkHandlerGetPublicAlbum_RequiresPassword(t *testing.T) {
+	h := newPublicAlbumTestHandler(t, publicAlbumLink(true))
+
+	require.Equal(t, http.StatusUnauthorized, getPublicAlbumForTest(h, nil).Code)
+	require.Equal(t, http.StatusUnauthorized, getPublicAlbumForTest(h, http.Header{SharePasswordHeader: {"wrong"}}).Code)
+
+	unlocked := getPublicAlbumForTest(h, http.Header{SharePasswordHeader: {"secret"}})
+	require.Equal(t, http.StatusOK, unlocked.Code, unlocked.Body.String())
+	cookies := unlocked.Result().Cookies()
+	require.Len(t, cookies, 1)
+	require.Equal(t, shareUnlockCookieName("tok"), cookies[0].Name)
+	require.True(t, cookies[0].HttpOnly)
+
+	withCookie := getPublicAlbumForTest(h, http.Header{"Cookie": {cookies[0].Name + "=" + cookies[0].Value}})
+	require.Equal(t, http.StatusOK, withCookie.Code, withCookie.Body.String())
+	require.Empty(t, withCookie.Result().Cookies(), "a valid cookie is not set again")
+}
+
+func TestShareLinkHandlerGetPublicAlbum_NotFoundForOtherShares(t *testing.T) {
+	link := publicAlbumLink(false)
+	link.SourceKind = "person"
+
+	require.Equal(t, http.StatusNotFound, getPublicAlbumForTest(newPublicAlbumTestHandler(t, link), nil).Code)
+}
@@ -0,0 +1,199 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+
+	"server/internal/api"
+	"server/internal/api/dto"
+	"server/internal/db/repo"
+	"server/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// smartAlbumPageSize bounds each query while a smart album is read in full.
+const smartAlbumPageSize = 500
+
+// CreateSmartAlbum creates an album whose assets are matched by rules
+// @Summary Create a smart album
+// @Description Create an album that stores an asset filter instead of a list of assets. Reading the album evaluates the filter against the owner's assets at that moment, so it follows uploads, edits and deletions without being updated. Smart album assets cannot be added, removed or reordered, and smart albums cannot be merged.
+// @Tags albums
+// @Accept json
+// @Produce json
+// @Param request body dto.CreateSmartAlbumRequestDTO true "Smart album name and rules"
+// @Success 200 {object} dto.GetAlbumResponseDTO "Smart album created successfully"
+// @Failure 400 {object} dto.AssetFilterValidationErrorDTO "Invalid request data or rules"
+// @Failure 401 {object} api.ErrorResponse "Unauthorized"
+// @Failure 500 {object} api.ErrorResponse "Failed to create album"
+// @Router /api/v1/albums/smart [post]
+// @Security BearerAuth
+func (h *AlbumHandler) CreateSmartAlbum(c *gin.Context) {
+	userID, err := currentUserIDFromContext(c)
+	if err != nil {
+		api.GinUnauthorized(c, err, "Unauthorized")
+		return
+	}
+
+	var req dto.CreateSmartAlbumRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		api.GinBadRequest(c, err, "Invalid request data")
+		return
+	}
+	if fieldErrors := validateSmartAlbumRules(req.Rules); len(fieldErrors) > 0 {
+		c.JSON(http.StatusBadRequest, dto.AssetFilterValidationErrorDTO{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid smart album rules",
+			Errors:  fieldErrors,
+		})
+		return
+	}
+
+	rules, err := json.Marshal(req.Rules)
+	if err != nil {
+		api.GinInternalError(c, err, "Failed to create album")
+		return
+	}
+
+	album, err := (*h.albumService).CreateNewAlbum(c.Request.Context(), repo.CreateAlbumParams{
+		UserID:      *userID,
+		AlbumName:   req.AlbumName,
+		Description: req.Description,
+		AlbumType:   repo.AlbumTypeDefault,
+		Rules:       rules,
+	})
+	if err != nil {
+		log.Printf("Failed to create smart album: %v", err)
+		api.GinInternalError(c, err, "Failed to create album")
+		return
+	}
+	h.recordAlbumActivity(c.Request.Context(), service.ActivityAlbumCreated, album, pgtype.UUID{})
+
+	response := toAlbumResponseDTO(dto.ToAlbumDTO(album), 0, optionalUUIDToString(album.CoverAssetID))
+	if err := h.fillSmartAlbumSummary(c.Request.Context(), album, pgtype.UUID{}, &response); err != nil {
+		log.Printf("Failed to evaluate smart album %d: %v", album.AlbumID, err)
+	}
+
+	api.JSONOK(c, response)
+}
+
+func isSmartAlbum(album repo.Album) bool {
+	return album.Rules != nil
+}
+
+// ensureManualAlbum refuses membership edits on a smart album, whose assets
+// follow its rules.
+func ensureManualAlbum(c *gin.Context, album repo.Album) bool {
+	if isSmartAlbum(album) {
+		api.GinBadRequest(c, errors.New("smart album assets are rule-based"), "Assets of a smart album follow its rules and cannot be edited")
+		return false
+	}
+	return true
+}
+
+// validateSmartAlbumRules reports invalid filter fields, plus the ones a
+// smart album decides for itself: it only ever holds its owner's live assets.
+func validateSmartAlbumRules(rules dto.AssetFilterDTO) []dto.AssetFilterFieldErrorDTO {
+	errs := validateAssetFilter(rules)
+	if rules.OwnerID != nil {
+		errs = append(errs, dto.AssetFilterFieldErrorDTO{Field: "owner_id", Message: "must be unset; a smart album matches its owner's assets"})
+	}
+	if rules.IsDeleted != nil {
+		errs = append(errs, dto.AssetFilterFieldErrorDTO{Field: "is_deleted", Message: "must be unset; a smart album never holds deleted assets"})
+	}
+	for i := range errs {
+		errs[i].Field = "rules." + errs[i].Field
+	}
+	return errs
+}
+
+// smartAlbumQueryParams turns album's rules into a query over its owner's
+// assets, newest capture first. A repository scope narrows the rules; ok is
+// false when the rules name another repository, so nothing can match.
+func smartAlbumQueryParams(album repo.Album, repositoryID pgtype.UUID, limit, offset int) (params service.QueryAssetsParams, ok bool, err error) {
+	var filter dto.AssetFilterDTO
+	if err := json.Unmarshal(album.Rules, &filter); err != nil {
+		return service.QueryAssetsParams{}, false, fmt.Errorf("decode rules of album %d: %w", album.AlbumID, err)
+	}
+
+	ownerID := album.UserID
+	filter.OwnerID = &ownerID
+	filter.IsDeleted = nil
+	if repositoryID.Valid {
+		scope := uuid.UUID(repositoryID.Bytes)
+		if filter.RepositoryID != nil {
+			if ruled, err := uuid.Parse(*filter.RepositoryID); err != nil || ruled != scope {
+				return service.QueryAssetsParams{}, false, nil
+			}
+		}
+		scoped := scope.String()
+		filter.RepositoryID = &scoped
+	}
+
+	return buildQueryAssetsParams("", "filename", "date_captured", "", service.StackModeExpanded, filter, dto.PaginationDTO{Limit: limit, Offset: offset}), true, nil
+}
+
+// smartAlbumAssets evaluates album's rules now and returns every match.
+func (h *AlbumHandler) smartAlbumAssets(ctx context.Context, album repo.Album, repositoryID pgtype.UUID) ([]repo.Asset, error) {
+	var assets []repo.Asset
+	for offset := 0; ; offset += smartAlbumPageSize {
+		params, ok, err := smartAlbumQueryParams(album, repositoryID, smartAlbumPageSize, offset)
+		if err != nil || !ok {
+			return assets, err
+		}
+		page, _, err := h.assetService.QueryAssets(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+		assets = append(assets, page...)
+		if len(page) < smartAlbumPageSize {
+			return assets, nil
+		}
+	}
+}
+
+// fillSmartAlbumSummary sets the asset count of a smart album's response to
+// what its rules match now. Without a usable cover, the newest match is shown.
+func (h *AlbumHandler) fillSmartAlbumSummary(ctx context.Context, album repo.Album, repositoryID pgtype.UUID, response *dto.GetAlbumResponseDTO) error {
+	params, ok, err := smartAlbumQueryParams(album, repositoryID, 1, 0)
+	if err != nil || !ok {
+		response.AssetCount = 0
+		return err
+	}
+	assets, total, err := h.assetService.QueryAssets(ctx, params)
+	if err != nil {
+		return err
+	}
+	response.AssetCount = total
+	if response.DisplayCoverAssetID == nil && len(assets) > 0 {
+		response.DisplayCoverAssetID = optionalUUIDToString(assets[0].AssetID)
+	}
+	return nil
+}
+
+// getSmartAlbumAssets answers GetAlbumAssets for a smart album.
+func (h *AlbumHandler) getSmartAlbumAssets(c *gin.Context, album repo.Album, repositoryID pgtype.UUID) {
+	assets, err := h.smartAlbumAssets(c.Request.Context(), album, repositoryID)
+	if err != nil {
+		log.Printf("Failed to evaluate smart album %d: %v", album.AlbumID, err)
+		api.GinInternalError(c, err, "Failed to retrieve album assets")
+		return
+	}
+
+	items := make([]dto.AlbumAssetDTO, 0, len(assets))
+	for _, asset := range assets {
+		items = append(items, dto.AlbumAssetDTO{AssetDTO: dto.ToAssetDTO(asset)})
+	}
+
+	api.JSONOK(c, dto.AlbumAssetsResponseDTO{
+		AlbumID: int64(album.AlbumID),
+		Assets:  items,
+		Count:   len(items),
+	})
+}
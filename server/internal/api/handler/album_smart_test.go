@@ -0,0 +1,122 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"server/internal/api/dto"
+	"server/internal/db/repo"
+	"server/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+const smartAlbumTestRepository = "550e8400-e29b-41d4-a716-446655440000"
+
+func newSmartAlbumQueries(t *testing.T) *stubAlbumCoverQueries {
+	queries := newAlbumCoverQueries(t)
+	queries.album.Rules = []byte(`{"liked":true,"repository_id":"` + smartAlbumTestRepository + `"}`)
+	return queries
+}
+
+func getSmartAlbumAssetsForTest(t *testing.T, queries *stubAlbumCoverQueries, assets stubAssetService, rawQuery string) *httptest.ResponseRecorder {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	handler := &AlbumHandler{queries: queries, assetService: assets}
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Request = httptest.NewRequest(http.MethodGet, "/api/v1/albums/9/assets?"+rawQuery, nil)
+	ctx.Params = gin.Params{{Key: "id", Value: "9"}}
+	ctx.Set("current_user", &service.UserResponse{UserID: 7, Role: "user"})
+
+	handler.GetAlbumAssets(ctx)
+	return recorder
+}
+
+func TestAlbumHandlerGetAlbumAssets_EvaluatesSmartAlbumRules(t *testing.T) {
+	queries := newSmartAlbumQueries(t)
+	var got service.QueryAssetsParams
+	assets := stubAssetService{
+		queryFn: func(_ context.Context, params service.QueryAssetsParams) ([]repo.Asset, int64, error) {
+			got = params
+			return []repo.Asset{queries.asset}, 1, nil
+		},
+	}
+
+	recorder := getSmartAlbumAssetsForTest(t, queries, assets, "")
+
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+	require.Equal(t, int32(7), *got.OwnerID, "rules only match the album owner's assets")
+	require.True(t, *got.Liked)
+	require.Equal(t, smartAlbumTestRepository, *got.RepositoryID)
+	require.Equal(t, service.StackModeExpanded, got.StackMode)
+
+	var response dto.AlbumAssetsResponseDTO
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	require.Equal(t, 1, response.Count)
+	require.Equal(t, "cover.jpg", response.Assets[0].OriginalFilename)
+	require.Nil(t, response.Assets[0].Position)
+}
+
+func TestAlbumHandlerGetAlbumAssets_SmartAlbumOutsideRepositoryScopeIsEmpty(t *testing.T) {
+	assets := stubAssetService{
+		queryFn: func(context.Context, service.QueryAssetsParams) ([]repo.Asset, int64, error) {
+			t.Fatal("rules naming another repository must not be queried")
+			return nil, 0, nil
+		},
+	}
+
+	recorder := getSmartAlbumAssetsForTest(t, newSmartAlbumQueries(t), assets, "repository_id=6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+	var response dto.AlbumAssetsResponseDTO
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	require.Zero(t, response.Count)
+	require.Empty(t, response.Assets)
+}
+
+func TestAlbumHandlerAddAssetToAlbum_RejectsSmartAlbum(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	queries := newSmartAlbumQueries(t)
+	handler := &AlbumHandler{queries: queries}
+
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Request = httptest.NewRequest(http.MethodPost, "/api/v1/albums/9/assets/aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa", strings.NewReader(`{}`))
+	ctx.Request.Header.Set("Content-Type", "application/json")
+	ctx.Params = gin.Params{{Key: "id", Value: "9"}, {Key: "assetId", Value: "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa"}}
+	ctx.Set("current_user", &service.UserResponse{UserID: 7, Role: "user"})
+
+	handler.AddAssetToAlbum(ctx)
+
+	require.Equal(t, http.StatusBadRequest, recorder.Code, recorder.Body.String())
+	require.Empty(t, queries.activity)
+}
+
+func TestAlbumHandlerCreateSmartAlbum_ReportsEveryInvalidRule(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	handler := &AlbumHandler{}
+
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Request = httptest.NewRequest(http.MethodPost, "/api/v1/albums/smart", strings.NewReader(`{"album_name":"Best","rules":{"rating":9,"owner_id":3,"is_deleted":true}}`))
+	ctx.Request.Header.Set("Content-Type", "application/json")
+	ctx.Set("current_user", &service.UserResponse{UserID: 7, Role: "user"})
+
+	handler.CreateSmartAlbum(ctx)
+
+	require.Equal(t, http.StatusBadRequest, recorder.Code, recorder.Body.String())
+	var response dto.AssetFilterValidationErrorDTO
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	fields := make([]string, 0, len(response.Errors))
+	for _, fieldErr := range response.Errors {
+		fields = append(fields, fieldErr.Field)
+	}
+	require.ElementsMatch(t, []string{"rules.rating", "rules.owner_id", "rules.is_deleted"}, fields)
+}
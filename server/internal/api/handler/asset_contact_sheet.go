@@ -0,0 +1,179 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+
+	"server/internal/api"
+	"server/internal/api/dto"
+	"server/internal/db/repo"
+	"server/internal/service"
+	"server/internal/utils/contactsheet"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	defaultContactSheetTileSize = 256
+	contactSheetJPEGQuality     = 90
+)
+
+// GenerateContactSheet renders an album or filter as a grid of thumbnails.
+// @Summary Generate a contact sheet
+// @Description Tile the first columns×rows assets of an album or of POST /assets/list's filter into one image, optionally with filenames under each tile. Tiles are drawn from the stored thumbnails; assets without one get a grey placeholder. Grids are capped at 20×20 tiles and 8000 px per edge.
+// @Tags assets
+// @Accept json
+// @Produce image/jpeg
+// @Produce image/png
+// @Param data body dto.ContactSheetRequestDTO true "Album or filter and grid layout"
+// @Success 200 {file} file "Contact sheet image"
+// @Failure 400 {object} api.ErrorResponse "Invalid request"
+// @Failure 404 {object} api.ErrorResponse "No assets match"
+// @Failure 422 {object} api.ErrorResponse "Sheet exceeds the image processing limits"
+// @Failure 500 {object} api.ErrorResponse "Internal server error"
+// @Failure 504 {object} api.ErrorResponse "Image processing timed out"
+// @Router /api/v1/assets/contact-sheet [post]
+// @Security BearerAuth
+func (h *AssetHandler) GenerateContactSheet(c *gin.Context) {
+	var req dto.ContactSheetRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		api.GinBadRequest(c, err, "Invalid request data")
+		return
+	}
+	if err := validateAssetQuerySortBy(req.SortBy); err != nil {
+		api.GinBadRequest(c, err, "sort_by must be 'recently_added' or 'date_captured'")
+		return
+	}
+	if err := validateAssetFilterLocation(req.Filter); err != nil {
+		api.GinBadRequest(c, err, err.Error())
+		return
+	}
+	format := strings.ToLower(strings.TrimSpace(req.Format))
+	if format == "" {
+		format = "jpeg"
+	}
+	if format != "jpeg" && format != "png" {
+		api.GinBadRequest(c, fmt.Errorf("unsupported format %q", req.Format), "format must be 'jpeg' or 'png'")
+		return
+	}
+
+	layout := contactsheet.Layout{Columns: req.Columns, Rows: req.Rows, TileSize: req.TileSize, Labels: req.Labels}
+	if layout.TileSize == 0 {
+		layout.TileSize = defaultContactSheetTileSize
+	}
+	if err := layout.Validate(); err != nil {
+		api.GinBadRequest(c, err, err.Error())
+		return
+	}
+
+	if req.AlbumID != nil {
+		req.Filter.AlbumID = req.AlbumID
+	}
+	params := buildQueryAssetsParams("", "filename", req.SortBy, req.ViewerTimezone, service.StackModeExpanded, req.Filter, dto.PaginationDTO{Limit: layout.Tiles()})
+	params = applyAssetOwnershipScope(c, params)
+
+	ctx := c.Request.Context()
+	assets, _, err := h.assetService.QueryAssets(ctx, params)
+	if err != nil {
+		log.Printf("Failed to query assets for contact sheet: %v", err)
+		api.GinInternalError(c, err, "Failed to query assets")
+		return
+	}
+	if len(assets) == 0 {
+		api.GinNotFound(c, errors.New("no assets match"), "No assets match the album or filter")
+		return
+	}
+
+	var out []byte
+	width, height := layout.Size()
+	err = h.imageLimits.Run(ctx, width, height, func(ctx context.Context) error {
+		sheet, err := contactsheet.Compose(layout, h.contactSheetTiles(ctx, assets, layout.TileSize))
+		if err != nil {
+			return err
+		}
+		out, err = encodeContactSheet(sheet, format)
+		return err
+	})
+	if respondImageOpError(c, err) {
+		return
+	}
+	if err != nil {
+		log.Printf("Failed to render contact sheet: %v", err)
+		api.GinInternalError(c, err, "Failed to render contact sheet")
+		return
+	}
+
+	c.Header("Cache-Control", "private, max-age=0")
+	c.Header("Content-Disposition", fmt.Sprintf("inline; filename=%q", "contact-sheet."+format))
+	c.Data(http.StatusOK, "image/"+format, out)
+}
+
+// contactSheetTiles loads the thumbnail of each asset, the smallest size that
+// covers the tile. Assets whose thumbnail or repository can't be read get a
+// placeholder tile instead of failing the sheet.
+func (h *AssetHandler) contactSheetTiles(ctx context.Context, assets []repo.Asset, tileSize int) []contactsheet.Tile {
+	size := "medium"
+	if tileSize <= 400 {
+		size = "small"
+	}
+
+	repositoryPaths := make(map[pgtype.UUID]string)
+	tiles := make([]contactsheet.Tile, len(assets))
+	for i := range assets {
+		asset := &assets[i]
+		tiles[i].Label = asset.OriginalFilename
+
+		repoPath, ok := repositoryPaths[asset.RepositoryID]
+		if !ok {
+			repository, err := h.getRepositoryForAsset(ctx, asset)
+			if err != nil {
+				log.Printf("Contact sheet: skipping repository of asset %s: %v", uuid.UUID(asset.AssetID.Bytes), err)
+			} else {
+				repoPath = repository.Path
+			}
+			repositoryPaths[asset.RepositoryID] = repoPath
+		}
+		if repoPath == "" {
+			continue
+		}
+
+		_, fullPath, _, err := h.findThumbnailFile(ctx, asset.AssetID.Bytes, size, repoPath)
+		if err != nil {
+			continue
+		}
+		data, err := os.ReadFile(fullPath)
+		if err != nil {
+			log.Printf("Contact sheet: failed to read thumbnail %s: %v", fullPath, err)
+			continue
+		}
+		if tiles[i].Image, err = contactsheet.DecodeTile(data); err != nil {
+			log.Printf("Contact sheet: failed to decode thumbnail %s: %v", fullPath, err)
+		}
+	}
+	return tiles
+}
+
+func encodeContactSheet(sheet image.Image, format string) ([]byte, error) {
+	var buf bytes.Buffer
+	var err error
+	if format == "png" {
+		err = png.Encode(&buf, sheet)
+	} else {
+		err = jpeg.Encode(&buf, sheet, &jpeg.Options{Quality: contactSheetJPEGQuality})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("encode contact sheet: %w", err)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,98 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"image"
+	"image/color"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"server/internal/api/dto"
+	"server/internal/db/repo"
+	"server/internal/service"
+	"server/internal/utils/contactsheet"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+// contactSheetAssetService answers QueryAssets with the thumbnail test asset
+// repeated count times.
+type contactSheetAssetService struct {
+	*thumbnailAssetService
+	count int
+	got   service.QueryAssetsParams
+}
+
+func (s *contactSheetAssetService) QueryAssets(_ context.Context, params service.QueryAssetsParams) ([]repo.Asset, int64, error) {
+	s.got = params
+	assets := make([]repo.Asset, s.count)
+	for i := range assets {
+		assets[i] = s.asset
+	}
+	return assets, int64(s.count), nil
+}
+
+func postContactSheet(t *testing.T, h *AssetHandler, req dto.ContactSheetRequestDTO) *httptest.ResponseRecorder {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	body, err := json.Marshal(req)
+	require.NoError(t, err)
+
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Request = httptest.NewRequest(http.MethodPost, "/api/v1/assets/contact-sheet", bytes.NewReader(body))
+	ctx.Request.Header.Set("Content-Type", "application/json")
+	h.GenerateContactSheet(ctx)
+	return recorder
+}
+
+func TestGenerateContactSheet_RendersRequestedGrid(t *testing.T) {
+	h, thumbnails, root := thumbnailTestHandler(t, "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa")
+	rel := ".lumilio/assets/thumbnails/small/photo.png"
+	var thumbnail bytes.Buffer
+	img := image.NewRGBA(image.Rect(0, 0, 40, 30))
+	img.Set(0, 0, color.Black)
+	require.NoError(t, png.Encode(&thumbnail, img))
+	require.NoError(t, os.MkdirAll(filepath.Join(root, filepath.Dir(rel)), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, rel), thumbnail.Bytes(), 0o600))
+	thumbnails.thumbnails["small"] = repo.Thumbnail{Size: "small", StoragePath: rel}
+
+	assets := &contactSheetAssetService{thumbnailAssetService: thumbnails, count: 5}
+	h.assetService = assets
+	albumID := 7
+
+	recorder := postContactSheet(t, h, dto.ContactSheetRequestDTO{AlbumID: &albumID, Columns: 3, Rows: 2, TileSize: 96, Labels: true, Format: "png"})
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+	require.Equal(t, "image/png", recorder.Header().Get("Content-Type"))
+	require.Equal(t, 6, assets.got.Limit)
+	require.Equal(t, int32(7), *assets.got.AlbumID)
+
+	sheet, err := png.Decode(recorder.Body)
+	require.NoError(t, err)
+	width, height := contactsheet.Layout{Columns: 3, Rows: 2, TileSize: 96, Labels: true}.Size()
+	require.Equal(t, image.Rect(0, 0, width, height), sheet.Bounds())
+}
+
+func TestGenerateContactSheet_RejectsBadRequests(t *testing.T) {
+	h, thumbnails, _ := thumbnailTestHandler(t, "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa")
+	h.assetService = &contactSheetAssetService{thumbnailAssetService: thumbnails}
+
+	for name, req := range map[string]dto.ContactSheetRequestDTO{
+		"too many columns": {Columns: contactsheet.MaxColumns + 1, Rows: 1},
+		"oversize tiles":   {Columns: 2, Rows: 2, TileSize: contactsheet.MaxTileSize + 1},
+		"unknown format":   {Columns: 2, Rows: 2, Format: "gif"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, http.StatusBadRequest, postContactSheet(t, h, req).Code)
+		})
+	}
+
+	require.Equal(t, http.StatusNotFound, postContactSheet(t, h, dto.ContactSheetRequestDTO{Columns: 2, Rows: 2}).Code)
+}
@@ -0,0 +1,97 @@
+package handler
+
+import (
+	"errors"
+	"net/url"
+	"time"
+
+	"server/internal/api"
+	"server/internal/api/dto"
+	"server/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// Context keys under which AssetDownloadTokenMiddleware stores the asset a
+// valid download token grants and when the token expires.
+const (
+	downloadAssetIDKey      = "download_asset_id"
+	downloadAssetExpiresKey = "download_asset_expires_at"
+)
+
+// GetAssetDownloadURL issues a signed URL for an asset's original
+// @Summary Get signed download URL
+// @Description Sign a short-lived URL that serves the asset's original without authentication, for embedding or handing to a CDN. expires is in seconds (default 3600) and is clamped to between 60 seconds and 24 hours.
+// @Tags assets
+// @Produce json
+// @Param id path string true "Asset ID (UUID format)" example("550e8400-e29b-41d4-a716-446655440000")
+// @Param expires query int false "URL lifetime in seconds" default(3600) minimum(60) maximum(86400)
+// @Success 200 {object} dto.AssetDownloadURLResponseDTO "Signed URL"
+// @Failure 400 {object} api.ErrorResponse "Invalid asset ID"
+// @Failure 401 {object} api.ErrorResponse "Authentication required"
+// @Failure 403 {object} api.ErrorResponse "Access denied"
+// @Failure 404 {object} api.ErrorResponse "Asset not found"
+// @Failure 500 {object} api.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /api/v1/assets/{id}/download-url [get]
+func (h *AssetHandler) GetAssetDownloadURL(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		api.GinBadRequest(c, err, "Invalid asset ID")
+		return
+	}
+
+	if _, ok := h.getAuthorizedAssetForRead(c, id, "Authentication required to download this asset", "You don't have permission to download this asset"); !ok {
+		return
+	}
+	if h.authService == nil {
+		api.GinInternalError(c, errors.New("auth service unavailable"), "Failed to sign download URL")
+		return
+	}
+
+	ttl := time.Duration(clampedIntQuery(c, "expires",
+		int(service.DefaultAssetDownloadURLTTL.Seconds()),
+		int(service.MinAssetDownloadURLTTL.Seconds()),
+		int(service.MaxAssetDownloadURLTTL.Seconds()))) * time.Second
+	token, expiresAt, err := h.authService.GenerateAssetDownloadToken(id, ttl)
+	if err != nil {
+		api.GinInternalError(c, err, "Failed to sign download URL")
+		return
+	}
+
+	api.JSONOK(c, dto.AssetDownloadURLResponseDTO{
+		URL:       "/api/v1/public/assets/" + id.String() + "?" + url.Values{"token": {token}}.Encode(),
+		ExpiresAt: expiresAt,
+	})
+}
+
+// GetPublicAssetOriginal serves an asset's original to the holder of a signed download URL
+// @Summary Get original file by signed URL
+// @Description Serve an asset's original for a token issued by GET /api/v1/assets/{id}/download-url. No other authentication is needed.
+// @Tags assets
+// @Produce application/octet-stream
+// @Param id path string true "Asset ID (UUID format)" example("550e8400-e29b-41d4-a716-446655440000")
+// @Param token query string true "Signed download token"
+// @Success 200 {file} file "Original file content"
+// @Failure 403 {object} api.ErrorResponse "Token missing, tampered, expired or for another asset"
+// @Failure 404 {object} api.ErrorResponse "Asset not found"
+// @Failure 410 {object} api.ErrorResponse "Asset's repository was removed"
+// @Failure 500 {object} api.ErrorResponse "Internal server error"
+// @Router /api/v1/public/assets/{id} [get]
+func (h *AssetHandler) GetPublicAssetOriginal(c *gin.Context) {
+	assetID, ok := c.Get(downloadAssetIDKey)
+	if !ok {
+		api.GinForbidden(c, errors.New("no download token"), "Invalid or expired download link")
+		return
+	}
+	expiresAt := c.GetTime(downloadAssetExpiresKey)
+
+	asset, ok := h.loadAssetAny(c, assetID.(uuid.UUID))
+	if !ok {
+		return
+	}
+	// Caches must not keep serving the file after the link expired. Download
+	// links always hand out the original bytes.
+	h.serveOriginalFile(c, asset, max(time.Until(expiresAt), 0), false)
+}
@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"server/config"
+	"server/internal/api/dto"
+	"server/internal/db/repo"
+	"server/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAssetDownloadURL_ServesOriginalOnlyForValidToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	assetID := "cccccccc-cccc-cccc-cccc-cccccccccccc"
+	repositoryID := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+	root := t.TempDir()
+
+	storagePath := "2024/photo.jpg"
+	asset := testHandlerAsset(t, assetID, "photo.jpg")
+	asset.StoragePath = &storagePath
+	asset.RepositoryID = repositoryID
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "2024"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "2024", "photo.jpg"), []byte("original"), 0o600))
+
+	authService, err := service.NewAuthService(nil, nil, config.AuthConfig{SecretKeyFile: filepath.Join(t.TempDir(), "secret")})
+	require.NoError(t, err)
+	assets := &AssetHandler{
+		assetService: stubMediaAssetService{asset: asset},
+		queries:      repo.New(repositoriesDB{roots: map[pgtype.UUID]string{repositoryID: root}}),
+		authService:  authService,
+	}
+	auth := &AuthHandler{authService: authService}
+
+	router := gin.New()
+	router.GET("/api/v1/assets/:id/download-url", assets.GetAssetDownloadURL)
+	router.GET("/api/v1/public/assets/:id", auth.AssetDownloadTokenMiddleware(), assets.GetPublicAssetOriginal)
+	get := func(target string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, target, nil))
+		return recorder
+	}
+
+	recorder := get("/api/v1/assets/" + assetID + "/download-url?expires=600")
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+	var response dto.AssetDownloadURLResponseDTO
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	require.WithinDuration(t, time.Now().Add(10*time.Minute), response.ExpiresAt, 2*time.Second)
+
+	recorder = get(response.URL)
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+	require.Equal(t, "original", recorder.Body.String())
+
+	signed, err := url.Parse(response.URL)
+	require.NoError(t, err)
+	token := signed.Query().Get("token")
+	otherToken, _, err := authService.GenerateAssetDownloadToken(uuid.New(), time.Hour)
+	require.NoError(t, err)
+	expiredToken, _, err := authService.GenerateAssetDownloadToken(uuid.MustParse(assetID), -time.Minute)
+	require.NoError(t, err)
+
+	base := "/api/v1/public/assets/" + assetID
+	for name, target := range map[string]string{
+		"missing":           base,
+		"tampered":          base + "?token=" + token[:len(token)-2] + "xx",
+		"expired":           base + "?token=" + expiredToken,
+		"other asset":       base + "?token=" + otherToken,
+		"used on other URL": "/api/v1/public/assets/" + uuid.NewString() + "?token=" + token,
+	} {
+		require.Equal(t, http.StatusForbidden, get(target).Code, name)
+	}
+}
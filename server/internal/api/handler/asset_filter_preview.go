@@ -0,0 +1,178 @@
+package handler
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"server/internal/api"
+	"server/internal/api/dto"
+	"server/internal/db/dbtypes"
+	"server/internal/db/dbtypes/status"
+	"server/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultFilterPreviewSampleSize = 5
+	maxFilterPreviewSampleSize     = 20
+)
+
+// assetTagSources are the asset_tags.source values a filter can match.
+var assetTagSources = []string{"system", service.AssetTagSourceUser, "ai", "bioclip_classify", service.AssetTagSourceZeroshot}
+
+// assetProcessingStates are the status states a filter can match.
+var assetProcessingStates = []string{
+	string(status.StateProcessing), string(status.StateComplete), string(status.StateWarning),
+	string(status.StateFailed), string(status.StateDeferred),
+}
+
+// PreviewAssetFilter reports how many assets a filter matches
+// @Summary Preview an asset filter
+// @Description Validate an asset filter as sent to POST /assets/list and return how many assets it matches with the first few asset IDs, without fetching pages. Unlike the listing, which quietly ignores or defaults values it does not understand, every invalid field is reported. The count is of assets, with stacks expanded.
+// @Tags assets
+// @Accept json
+// @Produce json
+// @Param data body dto.AssetFilterPreviewRequestDTO true "Filter to preview"
+// @Success 200 {object} dto.AssetFilterPreviewResponseDTO "Match count and sample asset IDs"
+// @Failure 400 {object} dto.AssetFilterValidationErrorDTO "Invalid filter, with every invalid field"
+// @Failure 500 {object} api.ErrorResponse "Internal server error"
+// @Router /api/v1/assets/filter/preview [post]
+func (h *AssetHandler) PreviewAssetFilter(c *gin.Context) {
+	var req dto.AssetFilterPreviewRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		api.GinBadRequest(c, err, "Invalid request data")
+		return
+	}
+
+	fieldErrors := validateAssetFilterPreview(req)
+	if len(fieldErrors) > 0 {
+		c.JSON(http.StatusBadRequest, dto.AssetFilterValidationErrorDTO{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid asset filter",
+			Errors:  fieldErrors,
+		})
+		return
+	}
+
+	sampleSize := req.SampleSize
+	if sampleSize == 0 {
+		sampleSize = defaultFilterPreviewSampleSize
+	}
+	params := buildQueryAssetsParams("", "filename", req.SortBy, req.ViewerTimezone, service.StackModeExpanded, req.Filter, dto.PaginationDTO{Limit: sampleSize})
+	params = applyAssetOwnershipScope(c, params)
+
+	assets, total, err := h.assetService.QueryAssets(c.Request.Context(), params)
+	if err != nil {
+		log.Printf("Failed to preview asset filter: %v", err)
+		api.GinInternalError(c, err, "Failed to count matching assets")
+		return
+	}
+
+	sample := make([]string, 0, len(assets))
+	for _, asset := range assets {
+		sample = append(sample, uuid.UUID(asset.AssetID.Bytes).String())
+	}
+	api.JSONOK(c, dto.AssetFilterPreviewResponseDTO{Total: total, SampleAssetIDs: sample})
+}
+
+// validateAssetFilterPreview checks a preview request, filter included.
+func validateAssetFilterPreview(req dto.AssetFilterPreviewRequestDTO) []dto.AssetFilterFieldErrorDTO {
+	var errs []dto.AssetFilterFieldErrorDTO
+	add := func(field, format string, args ...any) {
+		errs = append(errs, dto.AssetFilterFieldErrorDTO{Field: field, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if err := validateAssetQuerySortBy(req.SortBy); err != nil {
+		add("sort_by", "must be recently_added or date_captured; got %q", req.SortBy)
+	}
+	if tz := strings.TrimSpace(req.ViewerTimezone); tz != "" {
+		if _, err := time.LoadLocation(tz); err != nil {
+			add("viewer_timezone", "unknown IANA time zone %q", tz)
+		}
+	}
+	if req.SampleSize < 0 || req.SampleSize > maxFilterPreviewSampleSize {
+		add("sample_size", "must be between 1 and %d; got %d", maxFilterPreviewSampleSize, req.SampleSize)
+	}
+
+	for _, fieldErr := range validateAssetFilter(req.Filter) {
+		fieldErr.Field = "filter." + fieldErr.Field
+		errs = append(errs, fieldErr)
+	}
+	return errs
+}
+
+// validateAssetFilter reports every field of filter that POST /assets/list
+// would silently ignore, default or match nothing with.
+func validateAssetFilter(filter dto.AssetFilterDTO) []dto.AssetFilterFieldErrorDTO {
+	var errs []dto.AssetFilterFieldErrorDTO
+	add := func(field, format string, args ...any) {
+		errs = append(errs, dto.AssetFilterFieldErrorDTO{Field: field, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if filter.RepositoryID != nil {
+		if _, err := uuid.Parse(*filter.RepositoryID); err != nil {
+			add("repository_id", "must be a UUID; got %q", *filter.RepositoryID)
+		}
+	}
+	if filter.AlbumID != nil && *filter.AlbumID <= 0 {
+		add("album_id", "must be positive; got %d", *filter.AlbumID)
+	}
+	if filter.Type != nil && !dbtypes.AssetType(*filter.Type).Valid() {
+		add("type", "must be one of PHOTO, VIDEO, AUDIO; got %q", *filter.Type)
+	}
+	for i, assetType := range filter.Types {
+		if !dbtypes.AssetType(assetType).Valid() {
+			add(fmt.Sprintf("types[%d]", i), "must be one of PHOTO, VIDEO, AUDIO; got %q", assetType)
+		}
+	}
+	if filter.OwnerID != nil && *filter.OwnerID <= 0 {
+		add("owner_id", "must be positive; got %d", *filter.OwnerID)
+	}
+	if filter.Rating != nil && (*filter.Rating < 0 || *filter.Rating > 5) {
+		add("rating", "must be between 0 and 5; got %d", *filter.Rating)
+	}
+	if filter.Filename != nil {
+		switch strings.ToLower(strings.TrimSpace(filter.Filename.Operator)) {
+		case "", "contains", "matches", "starts_with", "startswith", "ends_with", "endswith":
+		default:
+			add("filename.operator", "must be one of contains, matches, starts_with, ends_with; got %q", filter.Filename.Operator)
+		}
+	}
+	if filter.Date != nil && filter.Date.From != nil && filter.Date.To != nil && filter.Date.From.After(*filter.Date.To) {
+		add("date", "from must not be after to")
+	}
+	if err := validateAssetFilterLocation(filter); err != nil {
+		add("location", "%s", err.Error())
+	}
+	if filter.TagSource != nil && !slices.Contains(assetTagSources, *filter.TagSource) {
+		add("tag_source", "must be one of %s; got %q", strings.Join(assetTagSources, ", "), *filter.TagSource)
+	}
+	if filter.TagMatch != nil {
+		switch strings.ToLower(strings.TrimSpace(*filter.TagMatch)) {
+		case "all", "any":
+		default:
+			add("tag_match", "must be all or any; got %q", *filter.TagMatch)
+		}
+	}
+	if filter.TagMinConfidence != nil && (*filter.TagMinConfidence < 0 || *filter.TagMinConfidence > 1) {
+		add("tag_min_confidence", "must be between 0 and 1; got %g", *filter.TagMinConfidence)
+	}
+	if filter.ProcessingStatus != nil && !slices.Contains(assetProcessingStates, *filter.ProcessingStatus) {
+		add("processing_status", "must be one of %s; got %q", strings.Join(assetProcessingStates, ", "), *filter.ProcessingStatus)
+	}
+	if filter.PersonID != nil && *filter.PersonID <= 0 {
+		add("person_id", "must be positive; got %d", *filter.PersonID)
+	}
+	if filter.FolderPath != nil {
+		if folder := normalizeFolderPath(*filter.FolderPath); folder == ".." || strings.HasPrefix(folder, "../") {
+			add("folder_path", "must stay inside the repository; got %q", *filter.FolderPath)
+		}
+	}
+	return errs
+}
@@ -0,0 +1,125 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"server/internal/api/dto"
+	"server/internal/db/repo"
+	"server/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func postFilterPreview(t *testing.T, h *AssetHandler, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Request = httptest.NewRequest(http.MethodPost, "/api/v1/assets/filter/preview", bytes.NewReader([]byte(body)))
+	ctx.Request.Header.Set("Content-Type", "application/json")
+	h.PreviewAssetFilter(ctx)
+	return recorder
+}
+
+func TestPreviewAssetFilter_CountsAndSamples(t *testing.T) {
+	first := testHandlerAsset(t, "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa", "a.jpg")
+	second := testHandlerAsset(t, "bbbbbbbb-bbbb-bbbb-bbbb-bbbbbbbbbbbb", "b.jpg")
+	var got service.QueryAssetsParams
+	h := &AssetHandler{assetService: stubAssetService{
+		queryFn: func(_ context.Context, params service.QueryAssetsParams) ([]repo.Asset, int64, error) {
+			got = params
+			return []repo.Asset{first, second}, 42, nil
+		},
+	}}
+
+	recorder := postFilterPreview(t, h, `{"filter":{"type":"PHOTO","tag_source":"zeroshot"},"sample_size":2}`)
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+	require.Equal(t, 2, got.Limit)
+	require.Equal(t, "PHOTO", *got.AssetType)
+
+	var response dto.AssetFilterPreviewResponseDTO
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	require.EqualValues(t, 42, response.Total)
+	require.Equal(t, []string{"aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa", "bbbbbbbb-bbbb-bbbb-bbbb-bbbbbbbbbbbb"}, response.SampleAssetIDs)
+}
+
+func TestPreviewAssetFilter_ReportsInvalidEnums(t *testing.T) {
+	h := &AssetHandler{assetService: stubAssetService{
+		queryFn: func(context.Context, service.QueryAssetsParams) ([]repo.Asset, int64, error) {
+			t.Fatal("an invalid filter must not be queried")
+			return nil, 0, nil
+		},
+	}}
+
+	recorder := postFilterPreview(t, h, `{
+		"sort_by": "oldest",
+		"filter": {
+			"type": "photo",
+			"types": ["VIDEO", "GIF"],
+			"rating": 7,
+			"filename": {"value": "IMG_", "operator": "regex"},
+			"tag_source": "manual",
+			"tag_match": "some",
+			"processing_status": "error"
+		}
+	}`)
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+
+	var response dto.AssetFilterValidationErrorDTO
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	fields := make(map[string]string, len(response.Errors))
+	for _, fieldErr := range response.Errors {
+		fields[fieldErr.Field] = fieldErr.Message
+	}
+	require.Equal(t, map[string]string{
+		"sort_by":                  `must be recently_added or date_captured; got "oldest"`,
+		"filter.type":              `must be one of PHOTO, VIDEO, AUDIO; got "photo"`,
+		"filter.types[1]":          `must be one of PHOTO, VIDEO, AUDIO; got "GIF"`,
+		"filter.rating":            `must be between 0 and 5; got 7`,
+		"filter.filename.operator": `must be one of contains, matches, starts_with, ends_with; got "regex"`,
+		"filter.tag_source":        `must be one of system, user, ai, bioclip_classify, zeroshot; got "manual"`,
+		"filter.tag_match":         `must be all or any; got "some"`,
+		"filter.processing_status": `must be one of processing, complete, warning, failed, deferred; got "error"`,
+	}, fields)
+}
+
+func TestQueryAssets_FiltersByProcessingStatus(t *testing.T) {
+	failed := testHandlerAsset(t, "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa", "broken.heic")
+	failed.Status = []byte(`{"state":"failed","message":"Asset ingestion failed","errors":[{"task":"thumbnail_asset","error":"unsupported image format"}]}`)
+	var got service.QueryAssetsParams
+	h := &AssetHandler{assetService: stubAssetService{
+		queryBrowseFn: func(_ context.Context, params service.QueryAssetsParams) (service.BrowseQueryResult, error) {
+			got = params
+			return service.BrowseQueryResult{
+				Items:        []service.BrowseItem{{Type: "asset", ID: "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa", Asset: failed}},
+				TotalVisible: 1,
+				TotalAssets:  1,
+			}, nil
+		},
+	}}
+
+	processingStatus := "failed"
+	recorder := postAssetListForTest(t, h, dto.AssetQueryRequestDTO{
+		Filter: dto.AssetFilterDTO{ProcessingStatus: &processingStatus},
+	})
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+	require.Equal(t, "failed", *got.ProcessingStatus)
+
+	var response dto.QueryAssetsResponseDTO
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	require.Len(t, response.Items, 1)
+	require.Equal(t, "failed", response.Items[0].Asset.ProcessingStatus)
+	require.Equal(t, "thumbnail_asset: unsupported image format", *response.Items[0].Asset.ProcessingError)
+
+	unknown := "broken"
+	recorder = postAssetListForTest(t, h, dto.AssetQueryRequestDTO{
+		Filter: dto.AssetFilterDTO{ProcessingStatus: &unknown},
+	})
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+}
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"io"
 	"log"
+	"math"
 	"mime/multipart"
 	"net/http"
 	"os"
@@ -22,12 +23,16 @@ import (
 	"server/internal/queue/jobs"
 	"server/internal/service"
 	"server/internal/storage"
+	"server/internal/utils/exif"
 	filevalidator "server/internal/utils/file"
 	"server/internal/utils/hash"
+	"server/internal/utils/imageguard"
 	"server/internal/utils/imagesource"
 	"server/internal/utils/imaging"
 	"server/internal/utils/memory"
 	"server/internal/utils/upload"
+	"server/internal/utils/urlfetch"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -44,22 +49,92 @@ import (
 // content already exists in the target repository.
 const uploadStatusDuplicate = "duplicate"
 
+// uploadStatusSkipped marks an upload the server dropped because it is smaller
+// than storage.min_file_size_bytes (resource forks, cache thumbnails), or
+// because its file name is taken in a repository that skips duplicate names.
+const uploadStatusSkipped = "skipped"
+
 // AssetHandler handles HTTP requests for asset management
 type AssetHandler struct {
-	assetService    service.AssetService
-	authService     *service.AuthService
-	indexingService service.AssetIndexingService
-	stackService    service.StackService
-	queries         *repo.Queries
-	repoManager     storage.RepositoryManager
-	stagingManager  storage.StagingManager
-	queueClient     *river.Client[pgx.Tx]
-	settingsService service.SettingsService
-	runtimeChecker  service.LumenService
-	memoryMonitor   *memory.MemoryMonitor
-	sessionManager  *upload.SessionManager
-	chunkMerger     *upload.ChunkMerger
-	uploadLimiter   chan struct{}
+	assetService     service.AssetService
+	authService      *service.AuthService
+	indexingService  service.AssetIndexingService
+	stackService     service.StackService
+	queries          *repo.Queries
+	repoManager      storage.RepositoryManager
+	stagingManager   storage.StagingManager
+	queueClient      *river.Client[pgx.Tx]
+	settingsService  service.SettingsService
+	runtimeChecker   service.LumenService
+	memoryMonitor    *memory.MemoryMonitor
+	sessionManager   *upload.SessionManager
+	chunkMerger      *upload.ChunkMerger
+	resumable        *upload.ResumableManager
+	uploadLimiter    chan struct{}
+	uploadSlots      *userUploadSlots
+	minFileSize      int64
+	maxBatchFiles    int
+	maxDownloadBytes int64
+	imageLimits      imageguard.Limits
+	urlFetcher       *urlfetch.Fetcher
+
+	metadataRefresher     AssetMetadataRefresher
+	assetTransformer      AssetTransformer
+	rawTagReader          AssetRawTagReader
+	thumbnailGenerator    AssetThumbnailGenerator
+	derivativeRegenerator AssetWebDerivativeRegenerator
+	ratingWriter          AssetRatingWriter
+}
+
+// AssetMetadataRefresher re-extracts metadata for one asset from its original.
+type AssetMetadataRefresher interface {
+	RefreshAssetMetadata(ctx context.Context, assetID pgtype.UUID) (*repo.Asset, error)
+}
+
+// AssetTransformer rotates or flips one asset's stored original.
+type AssetTransformer interface {
+	TransformAsset(ctx context.Context, assetID pgtype.UUID, transform exif.Transform) (*repo.Asset, error)
+}
+
+// AssetRawTagReader reads the full tag set from one asset's original.
+type AssetRawTagReader interface {
+	ReadAssetRawTags(ctx context.Context, assetID pgtype.UUID) (map[string]any, error)
+}
+
+// AssetRatingWriter queues writing an asset's stored rating into its
+// original. It is nil unless storage.write_rating_to_exif is enabled.
+type AssetRatingWriter interface {
+	QueueRatingWrite(ctx context.Context, assetID pgtype.UUID) error
+}
+
+// AssetThumbnailGenerator renders a missing thumbnail size from one asset's
+// original.
+type AssetThumbnailGenerator interface {
+	GenerateMissingThumbnail(ctx context.Context, assetID pgtype.UUID, size string) error
+}
+
+// AssetOriginalProcessor is everything the handler runs against an asset's
+// original: the asset processor in production.
+type AssetOriginalProcessor interface {
+	AssetMetadataRefresher
+	AssetTransformer
+	AssetRawTagReader
+	AssetThumbnailGenerator
+	AssetWebDerivativeRegenerator
+}
+
+// AssetHandlerRuntime carries the handler's processor and its upload, download
+// and image limits; app.go fills it from the boot config.
+type AssetHandlerRuntime struct {
+	Processor AssetOriginalProcessor
+	// RatingWriter is nil unless storage.write_rating_to_exif is enabled.
+	RatingWriter      AssetRatingWriter
+	MinFileSize       int64
+	MaxBatchFiles     int
+	MaxDownloadBytes  int64
+	MaxUploadsPerUser int
+	ImageLimits       imageguard.Limits
+	URLFetcher        *urlfetch.Fetcher
 }
 
 // NewAssetHandler creates a new AssetHandler instance
@@ -74,6 +149,7 @@ func NewAssetHandler(
 	queueClient *river.Client[pgx.Tx],
 	settingsService service.SettingsService,
 	runtimeChecker service.LumenService,
+	rt AssetHandlerRuntime,
 ) *AssetHandler {
 	memoryMonitor := memory.NewMemoryMonitor()
 	sessionManager := upload.NewSessionManager(30 * time.Minute) // 30 minute timeout
@@ -82,25 +158,45 @@ func NewAssetHandler(
 	uploadLimiter := make(chan struct{}, 32)
 
 	handler := &AssetHandler{
-		assetService:    assetService,
-		authService:     authService,
-		indexingService: indexingService,
-		stackService:    stackService,
-		queries:         queries,
-		repoManager:     repoManager,
-		stagingManager:  stagingManager,
-		queueClient:     queueClient,
-		settingsService: settingsService,
-		runtimeChecker:  runtimeChecker,
-		memoryMonitor:   memoryMonitor,
-		sessionManager:  sessionManager,
-		chunkMerger:     chunkMerger,
-		uploadLimiter:   uploadLimiter,
+		assetService:     assetService,
+		authService:      authService,
+		indexingService:  indexingService,
+		stackService:     stackService,
+		queries:          queries,
+		repoManager:      repoManager,
+		stagingManager:   stagingManager,
+		queueClient:      queueClient,
+		settingsService:  settingsService,
+		runtimeChecker:   runtimeChecker,
+		memoryMonitor:    memoryMonitor,
+		sessionManager:   sessionManager,
+		chunkMerger:      chunkMerger,
+		resumable:        upload.NewResumableManager(),
+		uploadLimiter:    uploadLimiter,
+		uploadSlots:      newUserUploadSlots(rt.MaxUploadsPerUser),
+		minFileSize:      rt.MinFileSize,
+		maxBatchFiles:    rt.MaxBatchFiles,
+		maxDownloadBytes: rt.MaxDownloadBytes,
+		imageLimits:      rt.ImageLimits,
+		urlFetcher:       rt.URLFetcher,
+
+		metadataRefresher:     rt.Processor,
+		assetTransformer:      rt.Processor,
+		rawTagReader:          rt.Processor,
+		thumbnailGenerator:    rt.Processor,
+		derivativeRegenerator: rt.Processor,
+		ratingWriter:          rt.RatingWriter,
 	}
 
 	return handler
 }
 
+// belowMinFileSize reports whether an upload is smaller than the configured
+// minimum and should be skipped instead of ingested.
+func (h *AssetHandler) belowMinFileSize(size int64) bool {
+	return h.minFileSize > 0 && size < h.minFileSize
+}
+
 var (
 	errInvalidRepositoryID = errors.New("invalid repository ID")
 	errRepositoryNotFound  = errors.New("repository not found")
@@ -161,17 +257,29 @@ func (h *AssetHandler) respondRepositoryError(c *gin.Context, err error) {
 
 // UploadAsset handles asset upload requests
 // @Summary Upload a single asset
-// @Description Upload a single photo, video, audio file, or document to the system. The file is staged in a repository and queued for processing.
+// @Description Upload a single photo, video, audio file, or document to the system. The file is staged in a repository and queued for processing. When its file name is already in use, duplicate_action reports what the repository's duplicate file name handling does: renamed, overwritten, or skipped, in which case the status is skipped and nothing is stored.
 // @Tags assets
 // @Accept multipart/form-data
 // @Produce json
 // @Param file formData file true "Asset file to upload"
 // @Param repository_id formData string false "Repository UUID (uses default repository if not provided)" example("550e8400-e29b-41d4-a716-446655440000")
-// @Success 200 {object} dto.UploadResponseDTO "Upload successful"
-// @Failure 400 {object} api.ErrorResponse "Bad request - no file provided or parse error"
+// @Param taken_time formData string false "Client-known capture time (RFC 3339), used only when EXIF has none" example("2025-07-14T09:30:00Z")
+// @Param latitude formData number false "Client-known capture latitude, used only when EXIF has no GPS" example(48.8584)
+// @Param longitude formData number false "Client-known capture longitude, used only when EXIF has no GPS" example(2.2945)
+// @Param device formData string false "Client-known capture device, used only when EXIF has no camera model" example("iPhone 15 Pro")
+// @Param dedupe formData bool false "Return an existing asset with the hash in X-Content-Hash and the same size, with status duplicate and its asset_id, instead of storing the file again. Only assets the caller can read match. An unowned asset is given to the caller only when the server's own hash of the upload matches it, never on the header alone. Without the header, a duplicate found after hashing the upload also reports asset_id." example(true)
+// @Param X-Content-Hash header string false "BLAKE3 hash of the file. Used to link an existing asset with dedupe; otherwise the ingest worker checks it and records hash_verified in specific_metadata, leaving a mismatched asset with a warning" example("af1349b9f5f9a1a6a0404dea36dcc9499bcb25c9adc112b7cc9a93cae41f3262")
+// @Success 200 {object} dto.UploadResponseDTO "Upload successful, or a duplicate of an existing asset"
+// @Failure 400 {object} api.ErrorResponse "Bad request - no file provided, parse error, or malformed X-Content-Hash"
+// @Failure 429 {object} api.ErrorResponse "Too many concurrent uploads for this user; see Retry-After"
 // @Failure 500 {object} api.ErrorResponse "Internal server error"
 // @Router /api/v1/assets [post]
 func (h *AssetHandler) UploadAsset(c *gin.Context) {
+	release, ok := h.acquireUploadSlot(c)
+	if !ok {
+		return
+	}
+	defer release()
 	h.uploadLimiter <- struct{}{}
 	defer func() { <-h.uploadLimiter }()
 
@@ -182,8 +290,18 @@ func (h *AssetHandler) UploadAsset(c *gin.Context) {
 		api.GinBadRequest(c, err, "Invalid request")
 		return
 	}
+	captureHints, err := captureHintsFromUploadRequest(req)
+	if err != nil {
+		api.GinBadRequest(c, err, "Invalid capture metadata")
+		return
+	}
+	claimedHash, err := parseClaimedContentHash(c.GetHeader(contentHashHeader))
+	if err != nil {
+		api.GinBadRequest(c, err, "X-Content-Hash must be a 64-character hex BLAKE3 digest")
+		return
+	}
 
-	err := c.Request.ParseMultipartForm(32 << 20)
+	err = c.Request.ParseMultipartForm(32 << 20)
 	if err != nil {
 		api.GinBadRequest(c, err, "Failed to parse form")
 		return
@@ -203,6 +321,11 @@ func (h *AssetHandler) UploadAsset(c *gin.Context) {
 	}
 	log.Printf("Validated file %s as %s with canonical MIME %s (RAW: %v)",
 		header.Filename, validationResult.AssetType, validationResult.MimeType, validationResult.IsRAW)
+	if h.belowMinFileSize(header.Size) {
+		log.Printf("Upload skipped: %s is %d bytes, below minimum of %d", header.Filename, header.Size, h.minFileSize)
+		api.JSONOK(c, dto.UploadResponseDTO{Status: uploadStatusSkipped, FileName: header.Filename, Size: header.Size, Message: "File is smaller than the configured minimum size"})
+		return
+	}
 
 	repository, err := h.resolveUploadRepository(ctx, req.RepositoryID)
 	if err != nil {
@@ -210,6 +333,19 @@ func (h *AssetHandler) UploadAsset(c *gin.Context) {
 		return
 	}
 
+	if req.Dedupe && claimedHash != "" {
+		user, _ := currentUserFromContext(c)
+		linked, err := linkUploadDuplicate(ctx, h.queries, claimedHash, header.Size, repository.RepoID, user, false)
+		if err != nil {
+			api.GinInternalError(c, err, "Failed to check for duplicate content")
+			return
+		}
+		if linked != nil {
+			api.JSONOK(c, dto.UploadResponseDTO{Status: uploadStatusDuplicate, FileName: header.Filename, Size: header.Size, ContentHash: claimedHash, AssetID: linked.assetID, Message: "File already exists in repository; linked to the existing asset"})
+			return
+		}
+	}
+
 	// Create staging file in repository
 	stagingFile, err := h.stagingManager.CreateStagingFile(repository.Path, header.Filename)
 	if err != nil {
@@ -226,7 +362,10 @@ func (h *AssetHandler) UploadAsset(c *gin.Context) {
 		return
 	}
 
-	_, err = io.Copy(osFile, file)
+	// Hash while staging so the authoritative hash is known without reading
+	// the staged file back.
+	hasher := hash.NewLayeredWriter()
+	_, err = io.Copy(io.MultiWriter(osFile, hasher), file)
 	osFile.Close()
 	if err != nil {
 		log.Printf("Failed to copy file to staging: %v", err)
@@ -234,14 +373,7 @@ func (h *AssetHandler) UploadAsset(c *gin.Context) {
 		api.GinInternalError(c, err, "Upload failed")
 		return
 	}
-
-	hashResult, err := hash.CalculateLayeredBLAKE3(stagingFile.Path)
-	if err != nil {
-		log.Printf("Failed to calculate authoritative hash: %v", err)
-		h.handleUploadFailureFile(repository.Path, stagingFile.Path, header.Filename, "calculate upload hash")
-		api.GinInternalError(c, err, "Failed to calculate file hash")
-		return
-	}
+	hashResult := hasher.Result()
 	duplicate, err := h.findDuplicateByHash(ctx, hashResult.ContentHash, hashResult.FileSize, repository.RepoID)
 	if err != nil {
 		h.handleUploadFailureFile(repository.Path, stagingFile.Path, header.Filename, "check duplicate content")
@@ -250,7 +382,35 @@ func (h *AssetHandler) UploadAsset(c *gin.Context) {
 	}
 	if duplicate != nil {
 		h.removeUploadTempFile(stagingFile.Path)
-		api.JSONOK(c, dto.UploadResponseDTO{Status: uploadStatusDuplicate, FileName: header.Filename, Size: header.Size, ContentHash: hashResult.ContentHash, Message: "File already exists in repository"})
+		response := dto.UploadResponseDTO{Status: uploadStatusDuplicate, FileName: header.Filename, Size: header.Size, ContentHash: hashResult.ContentHash, Message: "File already exists in repository"}
+		if req.Dedupe {
+			user, _ := currentUserFromContext(c)
+			linked, err := linkUploadDuplicate(ctx, h.queries, hashResult.ContentHash, hashResult.FileSize, repository.RepoID, user, true)
+			if err != nil {
+				api.GinInternalError(c, err, "Failed to check for duplicate content")
+				return
+			}
+			if linked != nil {
+				response.AssetID = linked.assetID
+			}
+		}
+		api.JSONOK(c, response)
+		return
+	}
+
+	var takenTime *time.Time
+	if captureHints != nil {
+		takenTime = captureHints.TakenTime
+	}
+	duplicateAction, err := h.planUploadDuplicateAction(repository.Path, stagingFile.Path, header.Filename, hashResult.ContentHash, takenTime)
+	if err != nil {
+		h.handleUploadFailureFile(repository.Path, stagingFile.Path, header.Filename, "plan inbox commit")
+		api.GinInternalError(c, err, "Upload failed")
+		return
+	}
+	if duplicateAction == storage.DuplicateActionSkipped {
+		h.removeUploadTempFile(stagingFile.Path)
+		api.JSONOK(c, dto.UploadResponseDTO{Status: uploadStatusSkipped, FileName: header.Filename, Size: header.Size, ContentHash: hashResult.ContentHash, DuplicateAction: string(duplicateAction), Message: duplicateNameSkippedMessage})
 		return
 	}
 
@@ -264,25 +424,29 @@ func (h *AssetHandler) UploadAsset(c *gin.Context) {
 	}
 
 	payload := processors.AssetPayload{
-		ContentHash:      hashResult.ContentHash,
-		QuickFingerprint: valueOrEmpty(hashResult.QuickFingerprint),
-		StagedPath:       stagingFile.Path,
-		UserID:           userID,
-		Timestamp:        time.Now(),
-		ContentType:      validationResult.MimeType,
-		FileName:         header.Filename,
-		RepositoryID:     uuid.UUID(repository.RepoID.Bytes).String(),
+		ContentHash:       hashResult.ContentHash,
+		QuickFingerprint:  valueOrEmpty(hashResult.QuickFingerprint),
+		StagedPath:        stagingFile.Path,
+		UserID:            userID,
+		Timestamp:         time.Now(),
+		ContentType:       validationResult.MimeType,
+		FileName:          header.Filename,
+		RepositoryID:      uuid.UUID(repository.RepoID.Bytes).String(),
+		CaptureHints:      captureHints,
+		ClientContentHash: claimedHash,
 	}
 
 	jobInsetResult, err := h.queueClient.Insert(ctx, jobs.IngestAssetArgs{
-		ContentHash:      payload.ContentHash,
-		QuickFingerprint: payload.QuickFingerprint,
-		StagedPath:       payload.StagedPath,
-		UserID:           payload.UserID,
-		Timestamp:        payload.Timestamp,
-		ContentType:      payload.ContentType,
-		FileName:         payload.FileName,
-		RepositoryID:     payload.RepositoryID,
+		ContentHash:       payload.ContentHash,
+		QuickFingerprint:  payload.QuickFingerprint,
+		StagedPath:        payload.StagedPath,
+		UserID:            payload.UserID,
+		Timestamp:         payload.Timestamp,
+		ContentType:       payload.ContentType,
+		FileName:          payload.FileName,
+		RepositoryID:      payload.RepositoryID,
+		CaptureHints:      payload.CaptureHints,
+		ClientContentHash: payload.ClientContentHash,
 	}, &river.InsertOpts{Queue: "ingest_asset"})
 
 	if err != nil {
@@ -301,12 +465,13 @@ func (h *AssetHandler) UploadAsset(c *gin.Context) {
 	log.Printf("Task %d enqueued for processing file %s in repository %s", jobId, header.Filename, repository.Name)
 
 	response := dto.UploadResponseDTO{
-		TaskID:      jobId,
-		Status:      "processing",
-		FileName:    header.Filename,
-		Size:        header.Size,
-		ContentHash: hashResult.ContentHash,
-		Message:     fmt.Sprintf("File received and queued for processing in repository '%s'", repository.Name),
+		TaskID:          jobId,
+		Status:          "processing",
+		FileName:        header.Filename,
+		Size:            header.Size,
+		ContentHash:     hashResult.ContentHash,
+		Message:         fmt.Sprintf("File received and queued for processing in repository '%s'", repository.Name),
+		DuplicateAction: string(duplicateAction),
 	}
 
 	// Merge structural media components and detect bursts asynchronously after upload.
@@ -323,26 +488,69 @@ func (h *AssetHandler) UploadAsset(c *gin.Context) {
 	api.JSONOK(c, response)
 }
 
+// captureHintsFromUploadRequest validates the optional client capture fields.
+// It returns nil when the client sent none of them.
+func captureHintsFromUploadRequest(req dto.UploadAssetRequestDTO) (*jobs.CaptureHints, error) {
+	hints := &jobs.CaptureHints{Device: strings.TrimSpace(req.Device)}
+	if raw := strings.TrimSpace(req.TakenTime); raw != "" {
+		takenTime, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			return nil, fmt.Errorf("taken_time must be an RFC 3339 timestamp: %w", err)
+		}
+		// A small allowance covers client clock skew; anything further ahead is bogus.
+		if takenTime.After(time.Now().Add(24 * time.Hour)) {
+			return nil, errors.New("taken_time must not be in the future")
+		}
+		hints.TakenTime = &takenTime
+	}
+	if (req.Latitude == nil) != (req.Longitude == nil) {
+		return nil, errors.New("latitude and longitude must be provided together")
+	}
+	if req.Latitude != nil {
+		lat, lng := *req.Latitude, *req.Longitude
+		if math.IsNaN(lat) || math.IsNaN(lng) || lat < -90 || lat > 90 || lng < -180 || lng > 180 {
+			return nil, errors.New("latitude must be within [-90, 90] and longitude within [-180, 180]")
+		}
+		hints.Latitude = req.Latitude
+		hints.Longitude = req.Longitude
+	}
+	if hints.TakenTime == nil && hints.Latitude == nil && hints.Device == "" {
+		return nil, nil
+	}
+	return hints, nil
+}
+
 // BatchUploadAssets handles multiple asset uploads with unified chunk support
 // @Summary Batch upload assets with chunk support
-// @Description Unified batch upload endpoint that supports both small files and chunked large files. Field names should follow format: single_{session_id} for single files or chunk_{session_id}_{index}_{total} for chunks.
+// @Description Unified batch upload endpoint that supports both small files and chunked large files. Field names should follow format: single_{session_id} for single files or chunk_{session_id}_{index}_{total} for chunks. Results hold one entry per session, in the order each session's first part appeared in the body, and carry the session ID.
 // @Tags assets
 // @Accept multipart/form-data
 // @Produce json
 // @Param repository_id formData string false "Repository UUID (uses default repository if not provided)" example("550e8400-e29b-41d4-a716-446655440000")
+// @Param batch_id formData string false "Client-chosen UUID grouping this request's files with other requests of the same import, so POST /api/v1/uploads/batch/{batch_id}/cancel can stop them all. Send it before the files." example("6ba7b810-9dad-11d1-80b4-00c04fd430c8")
 // @Param file formData file false "Single file upload - use format: single_{session_id}" example("single_123e4567-e89b-12d3-a456-426614174000")
 // @Param file formData file false "Chunked file upload - use format: chunk_{session_id}_{index}_{total}" example("chunk_123e4567-e89b-12d3-a456-426614174000_1_10")
 // @Success 200 {object} dto.BatchUploadResponseDTO "Batch upload completed"
-// @Failure 400 {object} api.ErrorResponse "Bad request - no files provided or parse error"
+// @Failure 400 {object} api.ErrorResponse "Bad request - no files provided, too many files, or parse error"
+// @Failure 403 {object} api.ErrorResponse "Upload batch belongs to another user"
+// @Failure 408 {object} api.ErrorResponse "Request cancelled before all files were processed"
+// @Failure 409 {object} api.ErrorResponse "Upload batch was cancelled"
+// @Failure 429 {object} api.ErrorResponse "Too many concurrent uploads for this user; see Retry-After"
 // @Failure 500 {object} api.ErrorResponse "Internal server error"
 // @Router /api/v1/assets/batch [post]
 func (h *AssetHandler) BatchUploadAssets(c *gin.Context) {
+	release, ok := h.acquireUploadSlot(c)
+	if !ok {
+		return
+	}
+	defer release()
 	h.uploadLimiter <- struct{}{}
 	defer func() { <-h.uploadLimiter }()
 
 	ctx := c.Request.Context()
 
 	repositoryID := strings.TrimSpace(c.Query("repository_id"))
+	batchID := strings.TrimSpace(c.Query("batch_id"))
 	var repository repo.Repository
 	repositoryResolved := false
 	resolveRepository := func() bool {
@@ -381,11 +589,29 @@ func (h *AssetHandler) BatchUploadAssets(c *gin.Context) {
 		filename    string
 		contentType string
 		chunkInfos  []upload.ChunkInfo
+		// hashes is computed while a single-file part streams to staging.
+		hashes *hash.LayeredHashResult
 	}
 
 	sessions := make(map[string]*sessionState)
+	var sessionOrder []string
 	buf := make([]byte, 1<<20) // 1MiB shared buffer for streaming copy
 
+	// discardStaged drops single-file uploads this request staged but will not
+	// process. Chunk sessions are kept so the client can resume them.
+	discardStaged := func(sessionIDs []string) {
+		for _, sessionID := range sessionIDs {
+			state := sessions[sessionID]
+			if state == nil || state.info.Type != "single" {
+				continue
+			}
+			for _, chunk := range state.chunkInfos {
+				h.removeUploadTempFile(chunk.FilePath)
+			}
+			h.sessionManager.DeleteSession(sessionID)
+		}
+	}
+
 	for {
 		part, perr := mr.NextPart()
 		if perr == io.EOF {
@@ -396,10 +622,14 @@ func (h *AssetHandler) BatchUploadAssets(c *gin.Context) {
 			return
 		}
 		if part.FileName() == "" {
-			if part.FormName() == "repository_id" {
+			switch part.FormName() {
+			case "repository_id":
 				data, _ := io.ReadAll(part)
 				repositoryID = strings.TrimSpace(string(data))
 				repositoryResolved = false
+			case "batch_id":
+				data, _ := io.ReadAll(part)
+				batchID = strings.TrimSpace(string(data))
 			}
 			part.Close()
 			continue
@@ -418,12 +648,19 @@ func (h *AssetHandler) BatchUploadAssets(c *gin.Context) {
 
 		state := sessions[fileInfo.SessionID]
 		if state == nil {
+			if h.maxBatchFiles > 0 && len(sessions) >= h.maxBatchFiles {
+				part.Close()
+				discardStaged(sessionOrder)
+				api.GinBadRequest(c, fmt.Errorf("batch exceeds %d files", h.maxBatchFiles), fmt.Sprintf("Too many files in batch; the limit is %d", h.maxBatchFiles))
+				return
+			}
 			state = &sessionState{
 				info:        fileInfo,
 				filename:    filename,
 				contentType: contentType,
 			}
 			sessions[fileInfo.SessionID] = state
+			sessionOrder = append(sessionOrder, fileInfo.SessionID)
 		}
 
 		if !repositoryResolved {
@@ -486,7 +723,13 @@ func (h *AssetHandler) BatchUploadAssets(c *gin.Context) {
 			return
 		}
 
-		written, err := io.CopyBuffer(dst, part, buf)
+		var hasher *hash.LayeredWriter
+		var sink io.Writer = dst
+		if fileInfo.Type == "single" {
+			hasher = hash.NewLayeredWriter()
+			sink = io.MultiWriter(dst, hasher)
+		}
+		written, err := io.CopyBuffer(sink, part, buf)
 		dst.Close()
 		part.Close()
 		if err != nil {
@@ -500,6 +743,9 @@ func (h *AssetHandler) BatchUploadAssets(c *gin.Context) {
 			return
 		}
 
+		if hasher != nil {
+			state.hashes = hasher.Result()
+		}
 		state.chunkInfos = append(state.chunkInfos, upload.ChunkInfo{
 			SessionID:  fileInfo.SessionID,
 			ChunkIndex: fileInfo.ChunkIndex,
@@ -512,10 +758,22 @@ func (h *AssetHandler) BatchUploadAssets(c *gin.Context) {
 		api.GinBadRequest(c, errors.New("no files provided"), "No files provided")
 		return
 	}
+	batch, ok := h.joinUploadBatch(c, batchID, userID)
+	if !ok {
+		discardStaged(sessionOrder)
+		return
+	}
 
 	var results []dto.BatchUploadResultDTO
 
-	for sessionID, state := range sessions {
+	for i, sessionID := range sessionOrder {
+		if err := ctx.Err(); err != nil {
+			discardStaged(sessionOrder[i:])
+			api.HandleError(c, http.StatusRequestTimeout, "Batch upload cancelled", err)
+			return
+		}
+
+		state := sessions[sessionID]
 		if state.info.Type == "single" {
 			session, _ := h.sessionManager.GetSession(sessionID)
 			header := &multipart.FileHeader{
@@ -525,7 +783,7 @@ func (h *AssetHandler) BatchUploadAssets(c *gin.Context) {
 			}
 			header.Header.Set("Content-Type", state.contentType)
 
-			result, err := h.processCompletedUpload(ctx, header, session, repository, state.chunkInfos[0].FilePath)
+			result, err := h.processCompletedUpload(ctx, header, session, repository, state.chunkInfos[0].FilePath, state.hashes)
 			if err != nil {
 				errMsg := err.Error()
 				results = append(results, dto.BatchUploadResultDTO{
@@ -578,7 +836,7 @@ func (h *AssetHandler) BatchUploadAssets(c *gin.Context) {
 		header.Header.Set("Content-Type", state.contentType)
 
 		session, _ := h.sessionManager.GetSession(sessionID)
-		result, err := h.processCompletedUpload(ctx, header, session, repository, mergeResult.MergedFilePath)
+		result, err := h.processCompletedUpload(ctx, header, session, repository, mergeResult.MergedFilePath, nil)
 
 		h.chunkMerger.CleanupChunks(sessionID)
 
@@ -602,6 +860,13 @@ func (h *AssetHandler) BatchUploadAssets(c *gin.Context) {
 		}
 		results = append(results, *result)
 	}
+	// Every session above appended exactly one result, in part order.
+	for i := range results {
+		results[i].SessionID = sessionOrder[i]
+	}
+	if batch.Valid {
+		h.recordUploadBatchJobs(ctx, batch, results)
+	}
 
 	if len(sessions) > 0 {
 		go h.cleanupExpiredSessions()
@@ -739,6 +1004,100 @@ func (h *AssetHandler) PrecheckUpload(c *gin.Context) {
 	})
 }
 
+// CheckHashes reports which full-content hashes already exist.
+// @Summary Check content hashes before upload
+// @Description Given full-content BLAKE3 hashes, reports which already exist among the caller's assets so the client can skip uploading them. Unlike /assets/precheck, quick fingerprints are not accepted.
+// @Tags assets
+// @Accept json
+// @Produce json
+// @Param request body dto.CheckHashesRequestDTO true "Hashes to check"
+// @Success 200 {object} dto.CheckHashesResponseDTO
+// @Failure 400 {object} api.ErrorResponse
+// @Failure 401 {object} api.ErrorResponse
+// @Failure 500 {object} api.ErrorResponse
+// @Security BearerAuth
+// @Router /api/v1/assets/check-hashes [post]
+func (h *AssetHandler) CheckHashes(c *gin.Context) {
+	var req dto.CheckHashesRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		api.GinBadRequest(c, err, "Invalid request body")
+		return
+	}
+
+	hashes, err := normalizeContentHashes(req.Hashes)
+	if err != nil {
+		api.GinBadRequest(c, err, "Hashes must be 64-character hex BLAKE3 digests")
+		return
+	}
+
+	var repositoryID pgtype.UUID
+	if req.RepositoryID != "" {
+		if err := repositoryID.Scan(req.RepositoryID); err != nil {
+			api.GinBadRequest(c, err, "Invalid repository_id")
+			return
+		}
+	}
+
+	response, err := checkContentHashes(c.Request.Context(), h.queries, hashes, ownerScopeID(c), repositoryID)
+	if err != nil {
+		api.GinInternalError(c, err, "Failed to check hashes")
+		return
+	}
+	api.JSONOK(c, response)
+}
+
+// contentHashLookup is the query CheckHashes depends on.
+type contentHashLookup interface {
+	GetAssetIDsByContentHashes(ctx context.Context, arg repo.GetAssetIDsByContentHashesParams) ([]repo.GetAssetIDsByContentHashesRow, error)
+}
+
+// normalizeContentHashes lowercases and dedupes hashes, keeping request order.
+func normalizeContentHashes(raw []string) ([]string, error) {
+	hashes := make([]string, 0, len(raw))
+	seen := make(map[string]struct{}, len(raw))
+	for _, value := range raw {
+		value = strings.ToLower(strings.TrimSpace(value))
+		if !hash.ValidateHash(value, hash.AlgorithmBLAKE3) {
+			return nil, fmt.Errorf("invalid content hash %q", value)
+		}
+		if _, ok := seen[value]; ok {
+			continue
+		}
+		seen[value] = struct{}{}
+		hashes = append(hashes, value)
+	}
+	return hashes, nil
+}
+
+func checkContentHashes(ctx context.Context, q contentHashLookup, hashes []string, ownerID *int32, repositoryID pgtype.UUID) (dto.CheckHashesResponseDTO, error) {
+	rows, err := q.GetAssetIDsByContentHashes(ctx, repo.GetAssetIDsByContentHashesParams{
+		ContentHashes: hashes,
+		OwnerID:       ownerID,
+		RepositoryID:  repositoryID,
+	})
+	if err != nil {
+		return dto.CheckHashesResponseDTO{}, err
+	}
+
+	assetIDs := make(map[string][]string, len(rows))
+	for _, row := range rows {
+		key := strings.ToLower(row.ContentHash)
+		assetIDs[key] = append(assetIDs[key], row.AssetID.String())
+	}
+
+	response := dto.CheckHashesResponseDTO{Results: make([]dto.CheckHashesResultDTO, 0, len(hashes))}
+	for _, contentHash := range hashes {
+		result := dto.CheckHashesResultDTO{Hash: contentHash}
+		if ids, ok := assetIDs[contentHash]; ok {
+			result.Exists = true
+			result.AssetIDs = ids
+			response.ExistingCount++
+		}
+		response.Results = append(response.Results, result)
+	}
+	return response, nil
+}
+
 // GetUploadConfig returns current upload configuration
 // @Summary Get upload configuration
 // @Description Get current upload configuration including chunk size and concurrency limits based on system memory
@@ -878,12 +1237,295 @@ func (h *AssetHandler) GetUploadProgress(c *gin.Context) {
 		OverallProgress: overallProgress,
 	}
 
-	response := dto.UploadProgressResponseDTO{
-		Sessions: sessionsProgress,
-		Summary:  summary,
+	response := dto.UploadProgressResponseDTO{
+		Sessions: sessionsProgress,
+		Summary:  summary,
+	}
+
+	api.JSONOK(c, response)
+}
+
+// uploadCallerID returns the caller's user ID as upload sessions record it.
+func uploadCallerID(c *gin.Context) string {
+	if id, ok := c.Get("user_id"); ok {
+		return fmt.Sprintf("%d", id)
+	}
+	return "anonymous"
+}
+
+// CreateResumableUpload starts an offset-based upload for a large file.
+// @Summary Start a resumable upload
+// @Description Create an empty staging file for a file of the given size. Send its bytes with PATCH /assets/upload/session/{id} in any number of chunks, then call /complete. The session survives interruptions and server restarts; query it to find where to resume.
+// @Tags assets
+// @Accept json
+// @Produce json
+// @Param request body dto.CreateResumableUploadRequestDTO true "File to upload"
+// @Success 200 {object} dto.ResumableUploadDTO "Upload session created"
+// @Failure 400 {object} api.ErrorResponse "Invalid request or unsupported file type"
+// @Failure 500 {object} api.ErrorResponse "Internal server error"
+// @Router /api/v1/assets/upload/session [post]
+func (h *AssetHandler) CreateResumableUpload(c *gin.Context) {
+	var req dto.CreateResumableUploadRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		api.GinBadRequest(c, err, "Invalid upload session")
+		return
+	}
+	validationResult := filevalidator.ValidateFile(req.Filename, req.ContentType)
+	if !validationResult.Valid {
+		api.GinBadRequest(c, fmt.Errorf("unsupported file type: %s", validationResult.ErrorReason))
+		return
+	}
+	if h.belowMinFileSize(req.Size) {
+		api.GinBadRequest(c, fmt.Errorf("file is %d bytes, below the minimum of %d", req.Size, h.minFileSize), "File is smaller than the configured minimum size")
+		return
+	}
+
+	repository, err := h.resolveUploadRepository(c.Request.Context(), req.RepositoryID)
+	if err != nil {
+		h.respondRepositoryError(c, err)
+		return
+	}
+	stagingFile, err := h.stagingManager.CreateStagingFile(repository.Path, req.Filename)
+	if err != nil {
+		api.GinInternalError(c, err, "Failed to create staging file")
+		return
+	}
+	session, err := h.resumable.Create(repository.Path, stagingFile.Path, req.Filename, validationResult.MimeType, req.Size, uploadCallerID(c))
+	if err != nil {
+		h.removeUploadTempFile(stagingFile.Path)
+		api.GinInternalError(c, err, "Failed to create upload session")
+		return
+	}
+	api.JSONOK(c, resumableUploadDTO(session, 0))
+}
+
+// GetResumableUpload reports how much of a resumable upload has arrived.
+// @Summary Get resumable upload offset
+// @Description Returns the number of bytes received so far; the next PATCH must start there. The offset is also sent in the Upload-Offset header.
+// @Tags assets
+// @Produce json
+// @Param id path string true "Upload session ID"
+// @Success 200 {object} dto.ResumableUploadDTO "Upload session state"
+// @Failure 404 {object} api.ErrorResponse "Upload session not found"
+// @Router /api/v1/assets/upload/session/{id} [get]
+func (h *AssetHandler) GetResumableUpload(c *gin.Context) {
+	session, ok := h.loadResumableUpload(c)
+	if !ok {
+		return
+	}
+	offset, err := session.Offset()
+	if err != nil {
+		h.respondResumableError(c, err)
+		return
+	}
+	c.Header("Upload-Offset", strconv.FormatInt(offset, 10))
+	api.JSONOK(c, resumableUploadDTO(session, offset))
+}
+
+// AppendResumableUpload appends one chunk to a resumable upload.
+// @Summary Append a chunk to a resumable upload
+// @Description The request body is the raw chunk. Upload-Offset must equal the bytes received so far; a mismatch returns 409 and the current offset in the Upload-Offset header. If the connection drops mid-chunk, the bytes that arrived are kept.
+// @Tags assets
+// @Accept application/offset+octet-stream
+// @Produce json
+// @Param id path string true "Upload session ID"
+// @Param Upload-Offset header int true "Offset the chunk starts at"
+// @Success 200 {object} dto.ResumableUploadDTO "Chunk stored; offset is the next expected offset"
+// @Failure 400 {object} api.ErrorResponse "Missing or invalid Upload-Offset"
+// @Failure 404 {object} api.ErrorResponse "Upload session not found"
+// @Failure 409 {object} api.ErrorResponse "Offset mismatch, upload completed, or another chunk in progress"
+// @Failure 413 {object} api.ErrorResponse "Chunk exceeds the declared size"
+// @Failure 429 {object} api.ErrorResponse "Too many concurrent uploads for this user; see Retry-After"
+// @Failure 500 {object} api.ErrorResponse "Internal server error"
+// @Router /api/v1/assets/upload/session/{id} [patch]
+func (h *AssetHandler) AppendResumableUpload(c *gin.Context) {
+	release, ok := h.acquireUploadSlot(c)
+	if !ok {
+		return
+	}
+	defer release()
+	h.uploadLimiter <- struct{}{}
+	defer func() { <-h.uploadLimiter }()
+
+	offset, err := strconv.ParseInt(c.GetHeader("Upload-Offset"), 10, 64)
+	if err != nil || offset < 0 {
+		api.GinBadRequest(c, errors.New("invalid Upload-Offset header"), "Upload-Offset must be a non-negative integer")
+		return
+	}
+	session, ok := h.loadResumableUpload(c)
+	if !ok {
+		return
+	}
+
+	next, err := h.resumable.Append(session, offset, c.Request.Body)
+	c.Header("Upload-Offset", strconv.FormatInt(next, 10))
+	if err != nil {
+		h.respondResumableError(c, err)
+		return
+	}
+	api.JSONOK(c, resumableUploadDTO(session, next))
+}
+
+// CompleteResumableUpload queues a fully received resumable upload for
+// ingest, the same way a direct upload is.
+// @Summary Complete a resumable upload
+// @Description Hashes the received file and queues it for processing. Calling it again returns the same task; a call made while another is still completing the upload gets 409.
+// @Tags assets
+// @Produce json
+// @Param id path string true "Upload session ID"
+// @Success 200 {object} dto.UploadResponseDTO "Upload queued for processing, or skipped as a duplicate"
+// @Failure 404 {object} api.ErrorResponse "Upload session not found"
+// @Failure 409 {object} api.ErrorResponse "Upload has not received all bytes, or is already being completed"
+// @Failure 500 {object} api.ErrorResponse "Internal server error"
+// @Router /api/v1/assets/upload/session/{id}/complete [post]
+func (h *AssetHandler) CompleteResumableUpload(c *gin.Context) {
+	session, ok := h.loadResumableUpload(c)
+	if !ok {
+		return
+	}
+	if session.TaskID != nil {
+		api.JSONOK(c, dto.UploadResponseDTO{TaskID: *session.TaskID, Status: "processing", FileName: session.Filename, Size: session.Size, Message: "Upload already queued for processing"})
+		return
+	}
+	offset, err := session.Offset()
+	if err != nil {
+		h.respondResumableError(c, err)
+		return
+	}
+	if offset != session.Size {
+		h.respondResumableError(c, fmt.Errorf("%w: %d of %d bytes", upload.ErrUploadIncomplete, offset, session.Size))
+		return
+	}
+
+	release, err := h.resumable.Claim(session)
+	if err != nil {
+		h.respondResumableError(c, err)
+		return
+	}
+	queued := false
+	defer func() {
+		if !queued {
+			release()
+		}
+	}()
+
+	ctx := c.Request.Context()
+	repository, err := h.repoManager.GetRepositoryByPath(session.RepositoryPath)
+	if err != nil {
+		api.GinNotFound(c, err, "Repository not found")
+		return
+	}
+	if err := rejectOfflineRepository(*repository); err != nil {
+		h.respondRepositoryError(c, err)
+		return
+	}
+	hashResult, err := hash.CalculateLayeredBLAKE3(session.StagingPath)
+	if err != nil {
+		api.GinInternalError(c, err, "Failed to hash upload")
+		return
+	}
+	duplicate, err := h.findDuplicateByHash(ctx, hashResult.ContentHash, hashResult.FileSize, repository.RepoID)
+	if err != nil {
+		api.GinInternalError(c, err, "Failed to check for duplicate content")
+		return
+	}
+	if duplicate != nil {
+		h.removeUploadTempFile(session.StagingPath)
+		h.resumable.Delete(session.ID)
+		api.JSONOK(c, dto.UploadResponseDTO{Status: uploadStatusDuplicate, FileName: session.Filename, Size: session.Size, ContentHash: hashResult.ContentHash, Message: "File already exists in repository"})
+		return
+	}
+	duplicateAction, err := h.planUploadDuplicateAction(repository.Path, session.StagingPath, session.Filename, hashResult.ContentHash, nil)
+	if err != nil {
+		api.GinInternalError(c, err, "Failed to queue upload")
+		return
+	}
+	if duplicateAction == storage.DuplicateActionSkipped {
+		h.removeUploadTempFile(session.StagingPath)
+		h.resumable.Delete(session.ID)
+		api.JSONOK(c, dto.UploadResponseDTO{Status: uploadStatusSkipped, FileName: session.Filename, Size: session.Size, ContentHash: hashResult.ContentHash, DuplicateAction: string(duplicateAction), Message: duplicateNameSkippedMessage})
+		return
+	}
+
+	result, err := h.queueClient.Insert(ctx, jobs.IngestAssetArgs{
+		ContentHash:      hashResult.ContentHash,
+		QuickFingerprint: valueOrEmpty(hashResult.QuickFingerprint),
+		StagedPath:       session.StagingPath,
+		UserID:           session.UserID,
+		Timestamp:        time.Now(),
+		ContentType:      session.ContentType,
+		FileName:         session.Filename,
+		RepositoryID:     uuid.UUID(repository.RepoID.Bytes).String(),
+	}, &river.InsertOpts{Queue: "ingest_asset"})
+	if err != nil {
+		api.GinInternalError(c, err, "Failed to queue upload")
+		return
+	}
+	if result == nil || result.Job == nil {
+		api.GinInternalError(c, errors.New("enqueue failed"), "Failed to queue upload")
+		return
+	}
+	// Keep the claim: a call that loaded the session before MarkCompleted
+	// must not queue it again.
+	queued = true
+	if err := h.resumable.MarkCompleted(session.ID, result.Job.ID); err != nil {
+		log.Printf("Failed to record completion of resumable upload %s: %v", session.ID, err)
+	}
+
+	api.JSONOK(c, dto.UploadResponseDTO{
+		TaskID:          result.Job.ID,
+		Status:          "processing",
+		FileName:        session.Filename,
+		Size:            session.Size,
+		ContentHash:     hashResult.ContentHash,
+		Message:         fmt.Sprintf("File received and queued for processing in repository '%s'", repository.Name),
+		DuplicateAction: string(duplicateAction),
+	})
+}
+
+// loadResumableUpload finds the :id upload session owned by the caller,
+// writing a 404 when there is none.
+func (h *AssetHandler) loadResumableUpload(c *gin.Context) (*upload.ResumableUpload, bool) {
+	var repoPaths []string
+	if repositories, err := h.repoManager.ListRepositories(); err == nil {
+		for _, repository := range repositories {
+			repoPaths = append(repoPaths, repository.Path)
+		}
+	}
+	session, err := h.resumable.Get(c.Param("id"), repoPaths)
+	if err == nil && session.UserID != uploadCallerID(c) {
+		err = upload.ErrResumableNotFound
+	}
+	if err != nil {
+		h.respondResumableError(c, err)
+		return nil, false
+	}
+	return session, true
+}
+
+func (h *AssetHandler) respondResumableError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, upload.ErrResumableNotFound):
+		api.GinNotFound(c, err, "Upload session not found")
+	case errors.Is(err, upload.ErrOffsetMismatch), errors.Is(err, upload.ErrUploadBusy),
+		errors.Is(err, upload.ErrUploadCompleted), errors.Is(err, upload.ErrUploadIncomplete),
+		errors.Is(err, upload.ErrUploadCompleting):
+		api.GinError(c, http.StatusConflict, err, http.StatusConflict, "Upload session conflict")
+	case errors.Is(err, upload.ErrUploadTooLarge):
+		api.GinError(c, http.StatusRequestEntityTooLarge, err, http.StatusRequestEntityTooLarge, "Chunk exceeds the declared upload size")
+	default:
+		api.GinInternalError(c, err, "Upload failed")
+	}
+}
+
+func resumableUploadDTO(session *upload.ResumableUpload, offset int64) dto.ResumableUploadDTO {
+	return dto.ResumableUploadDTO{
+		SessionID: session.ID,
+		Filename:  session.Filename,
+		Size:      session.Size,
+		Offset:    offset,
+		TaskID:    session.TaskID,
 	}
-
-	api.JSONOK(c, response)
 }
 
 // GetUploadJobStatus returns lifecycle state for accepted ingest jobs.
@@ -1047,6 +1689,7 @@ func uploadJobStatusForCaller(row *rivertype.JobRow, callerID string) (dto.Uploa
 // @Param include_species query bool false "Include species predictions" default(true)
 // @Param include_ocr query bool false "Include OCR results" default(false)
 // @Param include_faces query bool false "Include face recognition" default(false)
+// @Param include_urls query bool false "Include short-lived signed media URLs" default(false)
 // @Success 200 {object} dto.AssetDetailDTO "Asset details with optional relationships"
 // @Failure 400 {object} api.ErrorResponse "Invalid asset ID"
 // @Failure 404 {object} api.ErrorResponse "Asset not found"
@@ -1080,7 +1723,17 @@ func (h *AssetHandler) GetAsset(c *gin.Context) {
 		return
 	}
 
-	api.JSONOK(c, dto.ToAssetDetailDTO(row, includes))
+	detail := dto.ToAssetDetailDTO(row, includes)
+	if c.DefaultQuery("include_urls", "false") == "true" {
+		signer, err := h.mediaURLSigner(c)
+		if err != nil {
+			api.GinInternalError(c, err, "Failed to sign media URLs")
+			return
+		}
+		signer.sign(&detail.AssetDTO)
+	}
+
+	api.JSONOK(c, detail)
 }
 
 // GetAssetExif retrieves the raw EXIF JSON captured during metadata processing.
@@ -1128,6 +1781,53 @@ func (h *AssetHandler) GetAssetExif(c *gin.Context) {
 	})
 }
 
+// GetAssetRawExif dumps every metadata tag read from the asset's original.
+// @Summary Get raw metadata tags from the original
+// @Description Read the stored original with exiftool and return every EXIF, MakerNotes, XMP and IPTC tag it carries, keyed by "Group:Tag". Unlike /exif this does not use what was extracted into the database, so it reflects the file as it is now. Intended for debugging metadata extraction; only photos are supported.
+// @Tags assets
+// @Produce json
+// @Param id path string true "Asset ID (UUID format)" example("550e8400-e29b-41d4-a716-446655440000")
+// @Success 200 {object} dto.AssetRawTagsResponseDTO "All tags in the original"
+// @Failure 400 {object} api.ErrorResponse "Invalid asset ID"
+// @Failure 404 {object} api.ErrorResponse "Asset or its original file not found"
+// @Failure 415 {object} api.ErrorResponse "Asset is not a photo"
+// @Failure 500 {object} api.ErrorResponse "Internal server error"
+// @Router /api/v1/assets/{id}/exif-raw [get]
+func (h *AssetHandler) GetAssetRawExif(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		api.GinBadRequest(c, err, "Invalid asset ID")
+		return
+	}
+
+	asset, ok := h.getAuthorizedAssetForRead(c, id, "Authentication required to access this asset", "You don't have permission to access this asset")
+	if !ok {
+		return
+	}
+	if h.rawTagReader == nil {
+		api.GinInternalError(c, errors.New("raw tag reader not configured"), "Raw metadata dump is unavailable")
+		return
+	}
+
+	tags, err := h.rawTagReader.ReadAssetRawTags(c.Request.Context(), asset.AssetID)
+	if err != nil {
+		switch {
+		case errors.Is(err, processors.ErrRawTagsUnsupported):
+			api.GinError(c, http.StatusUnsupportedMediaType, err, http.StatusUnsupportedMediaType, "Raw metadata dump is only available for photos")
+		case errors.Is(err, processors.ErrOriginalMissing):
+			api.GinNotFound(c, err, "Asset original file not found")
+		default:
+			api.GinInternalError(c, err, "Failed to read metadata from the original")
+		}
+		return
+	}
+
+	api.JSONOK(c, dto.AssetRawTagsResponseDTO{
+		AssetID: id.String(),
+		Tags:    tags,
+	})
+}
+
 // GetAssetSidecar retrieves the Lumilio edit sidecar for an asset.
 // @Summary Get asset edit sidecar
 // @Description Retrieve the non-destructive Studio edit sidecar stored under the asset repository .lumilio directory.
@@ -1249,17 +1949,69 @@ func (h *AssetHandler) UpdateAssetSidecar(c *gin.Context) {
 	})
 }
 
+// thumbnailFallbackSizes lists the sizes to try for a request, largest first.
+var thumbnailFallbackSizes = map[string][]string{
+	"small":  {"small"},
+	"medium": {"medium", "small"},
+	"large":  {"large", "medium", "small"},
+}
+
+func (h *AssetHandler) getThumbnailWithFallback(ctx context.Context, assetID uuid.UUID, size string) (*repo.Thumbnail, error) {
+	var lastErr error
+	for _, candidate := range thumbnailFallbackSizes[size] {
+		thumbnail, err := h.assetService.GetThumbnailByAssetIDAndSize(ctx, assetID, candidate)
+		if err == nil {
+			return thumbnail, nil
+		}
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return nil, err
+		}
+		lastErr = err
+	}
+	return nil, lastErr
+}
+
+// errThumbnailMissing is returned by findThumbnailFile when no thumbnail
+// record or file exists for the requested size or a smaller one.
+var errThumbnailMissing = errors.New("thumbnail not found")
+
+// findThumbnailFile returns the thumbnail to serve for size and its file on
+// disk. Sources are never upscaled, so a small original may lack the larger
+// sizes; the largest one that exists at or below the request is used.
+func (h *AssetHandler) findThumbnailFile(ctx context.Context, assetID uuid.UUID, size, repoPath string) (*repo.Thumbnail, string, os.FileInfo, error) {
+	thumbnail, err := h.getThumbnailWithFallback(ctx, assetID, size)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, "", nil, errThumbnailMissing
+		}
+		return nil, "", nil, fmt.Errorf("get thumbnail metadata: %w", err)
+	}
+	fullPath := h.resolveRepositoryPath(repoPath, thumbnail.StoragePath)
+	fileInfo, err := os.Stat(fullPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, "", nil, errThumbnailMissing
+		}
+		return nil, "", nil, fmt.Errorf("stat thumbnail %s: %w", fullPath, err)
+	}
+	return thumbnail, fullPath, fileInfo, nil
+}
+
 // GetAssetThumbnail retrieves a thumbnail for a specific asset by asset ID and size
 // @Summary Get asset thumbnail
-// @Description Retrieve a specific thumbnail image for an asset by asset ID and size parameter. Returns the image file directly.
+// @Description Retrieve a specific thumbnail image for an asset by asset ID and size parameter. Returns the image file directly. Thumbnails are never upscaled, so when the source is too small for the requested size the largest smaller size is served. A photo whose thumbnails were not generated yet is rendered from its original on the first request.
 // @Tags assets
 // @Produce image/jpeg
 // @Param id path string true "Asset ID (UUID format)" example("550e8400-e29b-41d4-a716-446655440000")
 // @Param size query string false "Thumbnail size" default(medium) Enums(small,medium,large)
+// @Param download query bool false "Serve as an attachment named <original-basename>_<size>.webp instead of inline" default(false)
 // @Success 200 {file} string "Thumbnail image file"
 // @Failure 400 {object} api.ErrorResponse "Invalid asset ID or size parameter"
 // @Failure 404 {object} api.ErrorResponse "Asset or thumbnail not found"
+// @Failure 410 {object} api.ErrorResponse "Asset's repository was removed"
+// @Failure 422 {object} api.ErrorResponse "Original is too large to render a thumbnail on the fly"
 // @Failure 500 {object} api.ErrorResponse "Internal server error"
+// @Failure 504 {object} api.ErrorResponse "Thumbnail rendering ran past the image operation timeout"
 // @Router /api/v1/assets/{id}/thumbnail [get]
 func (h *AssetHandler) GetAssetThumbnail(c *gin.Context) {
 	// Parse asset ID from URL parameter
@@ -1284,35 +2036,37 @@ func (h *AssetHandler) GetAssetThumbnail(c *gin.Context) {
 		return
 	}
 
-	// Get thumbnail from service
-	thumbnail, err := h.assetService.GetThumbnailByAssetIDAndSize(c.Request.Context(), assetID, size)
-	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			api.GinNotFound(c, err, "Thumbnail not found")
-			return
-		}
-		log.Printf("Failed to retrieve thumbnail metadata: %v", err)
-		api.GinInternalError(c, err, "Failed to retrieve thumbnail")
-		return
-	}
-
 	repository, err := h.getRepositoryForAsset(c.Request.Context(), asset)
 	if err != nil {
 		log.Printf("Failed to resolve repository for thumbnail request: %v", err)
 		respondRepositoryResolveError(c, err, "Failed to resolve repository")
 		return
 	}
-	fullPath := h.resolveRepositoryPath(repository.Path, thumbnail.StoragePath)
 
-	// Get file info for proper cache control
-	fileInfo, err := os.Stat(fullPath)
+	thumbnail, fullPath, fileInfo, err := h.findThumbnailFile(c.Request.Context(), assetID, size, repository.Path)
+	if errors.Is(err, errThumbnailMissing) && h.thumbnailGenerator != nil {
+		// Assets the watcher just imported may not have been through the
+		// thumbnail worker yet; render from the original instead of failing.
+		width, height := imageguard.Dimensions(nil, asset.Width, asset.Height)
+		genErr := h.imageLimits.Run(c.Request.Context(), width, height, func(ctx context.Context) error {
+			return h.thumbnailGenerator.GenerateMissingThumbnail(ctx, asset.AssetID, size)
+		})
+		switch {
+		case genErr == nil:
+			thumbnail, fullPath, fileInfo, err = h.findThumbnailFile(c.Request.Context(), assetID, size, repository.Path)
+		case respondImageOpError(c, genErr):
+			return
+		case !errors.Is(genErr, processors.ErrOriginalMissing) && !errors.Is(genErr, processors.ErrThumbnailUnsupported):
+			log.Printf("Failed to generate missing thumbnail for asset %s (%s): %v", assetID.String(), size, genErr)
+		}
+	}
 	if err != nil {
-		if os.IsNotExist(err) {
-			api.GinNotFound(c, err, "Thumbnail file not found")
+		if errors.Is(err, errThumbnailMissing) {
+			api.GinNotFound(c, err, "Thumbnail not found")
 			return
 		}
-		log.Printf("Failed to get file info for %s: %v", fullPath, err)
-		api.GinInternalError(c, err, "Failed to access thumbnail file")
+		log.Printf("Failed to retrieve thumbnail: %v", err)
+		api.GinInternalError(c, err, "Failed to retrieve thumbnail")
 		return
 	}
 
@@ -1336,23 +2090,76 @@ func (h *AssetHandler) GetAssetThumbnail(c *gin.Context) {
 
 	log.Printf("Request for asset %s thumbnail (%s), serving file: %s (ETag: %s)", assetID.String(), size, fullPath, etag)
 
+	if c.DefaultQuery("download", "false") == "true" {
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", thumbnailDownloadFilename(asset.OriginalFilename, thumbnail.Size, fullPath)))
+	}
 	c.File(fullPath)
 }
 
+// thumbnailDownloadFilename names a downloaded thumbnail after the original
+// and the size actually served, e.g. "IMG_0001_medium.webp".
+func thumbnailDownloadFilename(originalFilename, size, thumbnailPath string) string {
+	base := strings.TrimSuffix(filepath.Base(originalFilename), filepath.Ext(originalFilename))
+	if strings.TrimSpace(base) == "" || base == "." {
+		base = "thumbnail"
+	}
+	ext := filepath.Ext(thumbnailPath)
+	if ext == "" {
+		ext = ".webp"
+	}
+	return base + "_" + size + ext
+}
+
+// GetAssetSrcset lists the asset's thumbnails with their pixel sizes
+// @Summary Get responsive thumbnail srcset
+// @Description List the thumbnails generated for an asset with their actual width and height and signed URLs, narrowest first, plus a ready-made value for an <img srcset> attribute. Only sizes that were generated are listed, so each URL serves exactly the stated dimensions. Thumbnails generated before dimensions were recorded are omitted until they are regenerated.
+// @Tags assets
+// @Produce json
+// @Param id path string true "Asset ID (UUID format)" example("550e8400-e29b-41d4-a716-446655440000")
+// @Success 200 {object} dto.AssetSrcsetResponseDTO "Thumbnail sources"
+// @Failure 400 {object} api.ErrorResponse "Invalid asset ID"
+// @Failure 404 {object} api.ErrorResponse "Asset not found"
+// @Failure 500 {object} api.ErrorResponse "Internal server error"
+// @Router /api/v1/assets/{id}/srcset [get]
+func (h *AssetHandler) GetAssetSrcset(c *gin.Context) {
+	assetID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		api.GinBadRequest(c, err, "Invalid asset ID")
+		return
+	}
+
+	if _, ok := h.getAuthorizedAssetForRead(c, assetID, "Authentication required to access this asset", "You don't have permission to access this asset"); !ok {
+		return
+	}
+
+	thumbnails, err := h.assetService.GetThumbnailsByAssetID(c.Request.Context(), assetID)
+	if err != nil {
+		api.GinInternalError(c, err, "Failed to retrieve thumbnails")
+		return
+	}
+
+	signer, err := h.mediaURLSigner(c)
+	if err != nil {
+		api.GinInternalError(c, err, "Failed to sign media URLs")
+		return
+	}
+
+	api.JSONOK(c, signer.srcset(assetID.String(), thumbnails))
+}
+
 // GetOriginalFile serves the original file content by asset ID
 // @Summary Get original file
-// @Description Serve the original file content for an asset by asset ID. Returns the file as an octet-stream.
+// @Description Serve the original file content for an asset by asset ID. Returns the file as an octet-stream. HEIC/HEIF originals are converted to JPEG unless the Accept header names image/heic or image/heif.
 // @Tags assets
 // @Produce application/octet-stream
 // @Param id path string true "Asset ID (UUID format)" example("550e8400-e29b-41d4-a716-446655440000")
 // @Success 200 {file} file "Original file content"
 // @Failure 400 {object} api.ErrorResponse "Invalid asset ID"
 // @Failure 404 {object} api.ErrorResponse "Asset not found"
+// @Failure 410 {object} api.ErrorResponse "Asset's repository was removed"
 // @Failure 500 {object} api.ErrorResponse "Internal server error"
 // @Router /api/v1/assets/{id}/original [get]
 func (h *AssetHandler) GetOriginalFile(c *gin.Context) {
-	ctx := c.Request.Context()
-
 	// Parse asset ID from URL parameter
 	idStr := c.Param("id")
 	id, err := uuid.Parse(idStr)
@@ -1366,6 +2173,15 @@ func (h *AssetHandler) GetOriginalFile(c *gin.Context) {
 		return
 	}
 
+	h.serveOriginalFile(c, asset, 24*time.Hour, true)
+}
+
+// serveOriginalFile streams an asset's original, letting caches keep it for
+// cacheFor. With negotiateHEIF, a HEIC/HEIF original is sent as JPEG unless
+// the Accept header names HEIF.
+func (h *AssetHandler) serveOriginalFile(c *gin.Context, asset *repo.Asset, cacheFor time.Duration, negotiateHEIF bool) {
+	ctx := c.Request.Context()
+
 	if asset.StoragePath == nil || strings.TrimSpace(*asset.StoragePath) == "" {
 		api.GinNotFound(c, fmt.Errorf("asset storage path is empty"), "Original file not found")
 		return
@@ -1377,19 +2193,36 @@ func (h *AssetHandler) GetOriginalFile(c *gin.Context) {
 		respondRepositoryResolveError(c, err, "Failed to access repository")
 		return
 	}
-	fullPath := h.resolveRepositoryPath(repository.Path, *asset.StoragePath)
+	fullPath, archived := resolveOriginalPath(ctx, h.queries, repository.Path, asset)
 
-	// Check if file exists
-	if _, err := os.Stat(fullPath); os.IsNotExist(err) {
-		log.Printf("Original file not found at path: %s", fullPath)
-		api.GinNotFound(c, err, "Original file not found")
+	// Only a file that is genuinely absent from the owning repository is a 404;
+	// anything else (permissions, I/O) is a server-side failure.
+	if _, err := os.Stat(fullPath); err != nil {
+		if os.IsNotExist(err) {
+			log.Printf("Original file not found at path: %s", fullPath)
+			api.GinNotFound(c, err, "Original file not found")
+			return
+		}
+		log.Printf("Failed to get file info for %s: %v", fullPath, err)
+		api.GinInternalError(c, err, "Failed to access original file")
 		return
 	}
 
+	if negotiateHEIF && isHEIFMime(asset.MimeType) {
+		c.Header("Vary", "Accept")
+		if !acceptsHEIF(c.GetHeader("Accept")) {
+			h.serveHEIFAsJPEG(c, asset, fullPath, cacheFor)
+			return
+		}
+	}
+
 	// Set appropriate headers
-	c.Header("Cache-Control", "public, max-age=86400") // Cache for 1 day
+	c.Header("Cache-Control", fmt.Sprintf("public, max-age=%d", int(cacheFor.Seconds())))
 	c.Header("Content-Type", asset.MimeType)
 	c.Header("Content-Disposition", fmt.Sprintf("inline; filename=\"%s\"", asset.OriginalFilename))
+	if archived {
+		c.Header("X-Lumilio-Original-Location", "archive")
+	}
 
 	// Serve the file
 	c.File(fullPath)
@@ -1415,6 +2248,23 @@ func clampedIntQuery(c *gin.Context, key string, def, min, max int) int {
 	return v
 }
 
+// respondImageOpError answers a request-time image operation the guard
+// stopped: 422 for a source above the size cap, 504 for one that ran past the
+// timeout. It reports whether it wrote a response.
+func respondImageOpError(c *gin.Context, err error) bool {
+	switch {
+	case errors.Is(err, imageguard.ErrSourceTooLarge):
+		api.GinError(c, http.StatusUnprocessableEntity, err, http.StatusUnprocessableEntity,
+			"Source image is too large to process on the fly")
+		return true
+	case errors.Is(err, imageguard.ErrTimeout):
+		api.GinError(c, http.StatusGatewayTimeout, err, http.StatusGatewayTimeout,
+			"Image processing timed out")
+		return true
+	}
+	return false
+}
+
 // ExportAsset re-encodes an asset's original file to a requested format and size.
 // @Summary Export asset
 // @Description Re-encode an asset's original file to JPEG, PNG, WebP, or AVIF with optional max dimensions and quality, and stream it back as a download.
@@ -1431,8 +2281,9 @@ func clampedIntQuery(c *gin.Context, key string, def, min, max int) int {
 // @Failure 401 {object} api.ErrorResponse "Authentication required"
 // @Failure 403 {object} api.ErrorResponse "Forbidden"
 // @Failure 404 {object} api.ErrorResponse "Asset or original file not found"
-// @Failure 422 {object} api.ErrorResponse "Source image could not be encoded"
+// @Failure 422 {object} api.ErrorResponse "Source image could not be encoded or is above the size cap"
 // @Failure 500 {object} api.ErrorResponse "Internal server error"
+// @Failure 504 {object} api.ErrorResponse "Export ran past the image operation timeout"
 // @Router /api/v1/assets/{id}/export [get]
 func (h *AssetHandler) ExportAsset(c *gin.Context) {
 	ctx := c.Request.Context()
@@ -1466,7 +2317,7 @@ func (h *AssetHandler) ExportAsset(c *gin.Context) {
 		respondRepositoryResolveError(c, err, "Failed to access repository")
 		return
 	}
-	fullPath := h.resolveRepositoryPath(repository.Path, *asset.StoragePath)
+	fullPath, _ := resolveOriginalPath(ctx, h.queries, repository.Path, asset)
 
 	if _, statErr := os.Stat(fullPath); os.IsNotExist(statErr) {
 		api.GinNotFound(c, statErr, "Original file not found")
@@ -1492,12 +2343,22 @@ func (h *AssetHandler) ExportAsset(c *gin.Context) {
 		return
 	}
 
-	out, mime, ext, err := imaging.ExportImageBytes(buf, imaging.ExportParams{
-		Format:    format,
-		Quality:   clampedIntQuery(c, "quality", 0, 1, 100),
-		MaxWidth:  clampedIntQuery(c, "max_width", 0, 0, 60000),
-		MaxHeight: clampedIntQuery(c, "max_height", 0, 0, 60000),
+	var out []byte
+	var mime, ext string
+	width, height := imageguard.Dimensions(buf, asset.Width, asset.Height)
+	err = h.imageLimits.Run(ctx, width, height, func(context.Context) error {
+		var exportErr error
+		out, mime, ext, exportErr = imaging.ExportImageBytes(buf, imaging.ExportParams{
+			Format:    format,
+			Quality:   clampedIntQuery(c, "quality", 0, 1, 100),
+			MaxWidth:  clampedIntQuery(c, "max_width", 0, 0, 60000),
+			MaxHeight: clampedIntQuery(c, "max_height", 0, 0, 60000),
+		})
+		return exportErr
 	})
+	if respondImageOpError(c, err) {
+		return
+	}
 	if err != nil {
 		log.Printf("Failed to export asset %s as %s: %v", id, format, err)
 		api.GinError(c, http.StatusUnprocessableEntity, err, http.StatusUnprocessableEntity,
@@ -1518,9 +2379,9 @@ func (h *AssetHandler) ExportAsset(c *gin.Context) {
 	c.Data(http.StatusOK, mime, out)
 }
 
-// DownloadAssets serves multiple original files as a zip archive.
+// DownloadAssets streams multiple original files as a zip archive.
 // @Summary Download assets
-// @Description Serve original files for the requested asset IDs as a zip archive.
+// @Description Stream original files for the requested asset IDs as a zip archive. Assets whose original is missing or whose repository is offline or removed are skipped and listed in a trailing MISSING-FILES.txt entry. Selections larger than the configured download limit are rejected.
 // @Tags assets
 // @Produce application/zip
 // @Param data body dto.DownloadAssetsRequestDTO true "Asset IDs to download"
@@ -1528,7 +2389,8 @@ func (h *AssetHandler) ExportAsset(c *gin.Context) {
 // @Failure 400 {object} api.ErrorResponse "Invalid request"
 // @Failure 401 {object} api.ErrorResponse "Authentication required"
 // @Failure 403 {object} api.ErrorResponse "Forbidden"
-// @Failure 404 {object} api.ErrorResponse "Asset or original file not found"
+// @Failure 404 {object} api.ErrorResponse "Asset not found, or none of the originals exist"
+// @Failure 413 {object} api.ErrorResponse "Selected files exceed the download limit"
 // @Failure 500 {object} api.ErrorResponse "Internal server error"
 // @Router /api/v1/assets/download [post]
 func (h *AssetHandler) DownloadAssets(c *gin.Context) {
@@ -1546,6 +2408,7 @@ func (h *AssetHandler) DownloadAssets(c *gin.Context) {
 	}
 
 	files := make([]assetDownloadFile, 0, len(req.AssetIDs))
+	var skipped []skippedDownloadFile
 	for _, rawAssetID := range req.AssetIDs {
 		assetIDText := strings.TrimSpace(rawAssetID)
 		assetID, err := uuid.Parse(assetIDText)
@@ -1558,49 +2421,71 @@ func (h *AssetHandler) DownloadAssets(c *gin.Context) {
 		if !ok {
 			return
 		}
+		skip := func(reason string) {
+			skipped = append(skipped, skippedDownloadFile{assetID: assetID.String(), filename: asset.OriginalFilename, reason: reason})
+		}
 
 		if asset.StoragePath == nil || strings.TrimSpace(*asset.StoragePath) == "" {
-			api.GinNotFound(c, fmt.Errorf("asset storage path is empty"), "Original file not found")
-			return
+			skip("no original file")
+			continue
 		}
 
 		repository, err := h.getRepositoryForAsset(ctx, asset)
-		if err != nil {
+		switch {
+		case errors.Is(err, storage.ErrRepositoryRemoved):
+			skip("repository was removed")
+			continue
+		case errors.Is(err, storage.ErrRepositoryOffline):
+			skip("repository is offline")
+			continue
+		case err != nil:
 			log.Printf("Failed to resolve repository for bulk download: %v", err)
-			respondRepositoryResolveError(c, err, "Failed to access repository")
+			api.GinInternalError(c, err, "Failed to access repository")
 			return
 		}
 
-		fullPath := h.resolveRepositoryPath(repository.Path, *asset.StoragePath)
+		fullPath, _ := resolveOriginalPath(ctx, h.queries, repository.Path, asset)
 		fileInfo, err := os.Stat(fullPath)
 		if err != nil {
 			if os.IsNotExist(err) {
 				log.Printf("Original file not found at path: %s", fullPath)
-				api.GinNotFound(c, err, "Original file not found")
-				return
+				skip("original file not found")
+				continue
 			}
 			api.GinInternalError(c, err, "Failed to access original file")
 			return
 		}
 		if fileInfo.IsDir() {
-			api.GinNotFound(c, fmt.Errorf("original file path is a directory"), "Original file not found")
-			return
+			skip("original file not found")
+			continue
 		}
 
 		files = append(files, assetDownloadFile{
 			asset: *asset,
 			path:  fullPath,
+			size:  fileInfo.Size(),
 		})
 	}
 
+	if len(files) == 0 {
+		api.GinNotFound(c, errors.New("none of the requested originals exist"), "Original file not found")
+		return
+	}
+	if downloadExceedsLimit(files, h.maxDownloadBytes) {
+		respondDownloadTooLarge(c, h.maxDownloadBytes)
+		return
+	}
+
 	filename := fmt.Sprintf("lumilio-assets-%s.zip", time.Now().Format("20060102-150405"))
 	c.Header("Cache-Control", "no-store")
 	c.Header("Content-Type", "application/zip")
 	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", filename))
 	c.Status(http.StatusOK)
 
+	// Entries are written straight to the response, one file at a time, so
+	// memory stays flat however large the selection is.
 	zipWriter := zip.NewWriter(c.Writer)
-	archiveNames := make(map[string]int, len(files))
+	archiveNames := make(map[string]int, len(files)+1)
 	for _, file := range files {
 		if err := writeAssetToZip(zipWriter, archiveNames, file); err != nil {
 			log.Printf("Failed to write asset to zip: %v", err)
@@ -1608,6 +2493,11 @@ func (h *AssetHandler) DownloadAssets(c *gin.Context) {
 			return
 		}
 	}
+	if len(skipped) > 0 {
+		if err := writeMissingFilesManifest(zipWriter, archiveNames, skipped); err != nil {
+			log.Printf("Failed to write missing files manifest: %v", err)
+		}
+	}
 
 	if err := zipWriter.Close(); err != nil {
 		log.Printf("Failed to finalize asset download zip: %v", err)
@@ -1623,6 +2513,7 @@ func (h *AssetHandler) DownloadAssets(c *gin.Context) {
 // @Success 200 {file} file "Web-optimized video file"
 // @Failure 400 {object} api.ErrorResponse "Invalid asset ID"
 // @Failure 404 {object} api.ErrorResponse "Asset not found or not a video"
+// @Failure 410 {object} api.ErrorResponse "Asset's repository was removed"
 // @Failure 500 {object} api.ErrorResponse "Internal server error"
 // @Router /api/v1/assets/{id}/video/web [get]
 func (h *AssetHandler) GetWebVideo(c *gin.Context) {
@@ -1655,7 +2546,7 @@ func (h *AssetHandler) GetWebVideo(c *gin.Context) {
 
 	repository, err := h.getRepositoryForAsset(ctx, asset)
 	if err != nil {
-		api.GinInternalError(c, err, "Failed to access repository")
+		respondRepositoryResolveError(c, err, "Failed to access repository")
 		return
 	}
 	repoPath := repository.Path
@@ -1665,13 +2556,8 @@ func (h *AssetHandler) GetWebVideo(c *gin.Context) {
 	webVersionExists := false
 
 	if asset.ContentHash != "" {
-		webVideoFilename := fmt.Sprintf("%s_web.mp4", asset.ContentHash)
-		webVideoPath := filepath.Join(storage.DefaultStructure.VideosDir, "web", webVideoFilename)
-		fullPath = filepath.Join(repoPath, webVideoPath)
-
-		if _, err := os.Stat(fullPath); err == nil {
-			webVersionExists = true
-		}
+		fullPath = storage.WebVideoPath(repoPath, asset.ContentHash)
+		webVersionExists = h.webDerivativeAvailable(ctx, asset, fullPath)
 	}
 
 	// Check if web version exists, fallback to original
@@ -1703,6 +2589,7 @@ func (h *AssetHandler) GetWebVideo(c *gin.Context) {
 // @Success 200 {file} file "Web-optimized audio file"
 // @Failure 400 {object} api.ErrorResponse "Invalid asset ID"
 // @Failure 404 {object} api.ErrorResponse "Asset not found or not audio"
+// @Failure 410 {object} api.ErrorResponse "Asset's repository was removed"
 // @Failure 500 {object} api.ErrorResponse "Internal server error"
 // @Router /api/v1/assets/{id}/audio/web [get]
 func (h *AssetHandler) GetWebAudio(c *gin.Context) {
@@ -1735,7 +2622,7 @@ func (h *AssetHandler) GetWebAudio(c *gin.Context) {
 
 	repository, err := h.getRepositoryForAsset(ctx, asset)
 	if err != nil {
-		api.GinInternalError(c, err, "Failed to access repository")
+		respondRepositoryResolveError(c, err, "Failed to access repository")
 		return
 	}
 	repoPath := repository.Path
@@ -1745,13 +2632,8 @@ func (h *AssetHandler) GetWebAudio(c *gin.Context) {
 	webVersionExists := false
 
 	if asset.ContentHash != "" {
-		webAudioFilename := fmt.Sprintf("%s_web.mp3", asset.ContentHash)
-		webAudioPath := filepath.Join(storage.DefaultStructure.AudiosDir, "web", webAudioFilename)
-		fullPath = filepath.Join(repoPath, webAudioPath)
-
-		if _, err := os.Stat(fullPath); err == nil {
-			webVersionExists = true
-		}
+		fullPath = storage.WebAudioPath(repoPath, asset.ContentHash)
+		webVersionExists = h.webDerivativeAvailable(ctx, asset, fullPath)
 	}
 
 	// Check if web version exists, fallback to original
@@ -1777,13 +2659,13 @@ func (h *AssetHandler) GetWebAudio(c *gin.Context) {
 
 // UpdateAsset updates asset metadata
 // @Summary Update asset metadata
-// @Description Update the specific metadata of an asset (e.g., photo EXIF data, video metadata).
+// @Description Update the specific metadata of an asset (e.g., photo EXIF data, video metadata). The metadata must be a JSON object whose known fields match the asset type's schema, e.g. taken_time as an RFC 3339 timestamp or f_number as a number; otherwise the request is rejected with every offending field listed. Fields the schema does not define are stored as sent.
 // @Tags assets
 // @Produce json
 // @Param id path string true "Asset ID (UUID format)" example("550e8400-e29b-41d4-a716-446655440000")
 // @Param data body dto.UpdateAssetRequestDTO true "Asset metadata"
 // @Success 200 {object} dto.MessageResponseDTO "Asset updated successfully"
-// @Failure 400 {object} api.ErrorResponse "Invalid asset ID or request body"
+// @Failure 400 {object} api.ErrorResponse "Invalid asset ID, request body or metadata"
 // @Failure 500 {object} api.ErrorResponse "Internal server error"
 // @Router /api/v1/assets/{id} [put]
 func (h *AssetHandler) UpdateAsset(c *gin.Context) {
@@ -1804,58 +2686,206 @@ func (h *AssetHandler) UpdateAsset(c *gin.Context) {
 		return
 	}
 
-	err = h.assetService.UpdateAssetMetadata(c.Request.Context(), id, updateData.Metadata)
+	err = h.assetService.UpdateAssetMetadata(c.Request.Context(), id, updateData.Metadata)
+	if errors.Is(err, service.ErrInvalidMetadata) {
+		api.GinBadRequest(c, err, err.Error())
+		return
+	}
+	if err != nil {
+		log.Printf("Failed to update asset metadata: %v", err)
+		api.GinInternalError(c, err, "Failed to update asset")
+		return
+	}
+
+	api.JSONOK(c, dto.MessageResponseDTO{Message: "Asset updated successfully"})
+}
+
+// DeleteAsset deletes an asset
+// @Summary Delete asset
+// @Description Soft delete an asset by marking it as deleted. The physical file is not removed. With hard=true the original, its thumbnails and web video/audio versions are moved into the repository trash (.lumilio/trash) and the asset's database rows are removed; this also works on an asset already in Trash, and files that are already gone are skipped. A hard delete needs a signed-in owner of the asset, or an admin for assets without an owner.
+// @Tags assets
+// @Accept json
+// @Produce json
+// @Param id path string true "Asset ID (UUID format)" example("550e8400-e29b-41d4-a716-446655440000")
+// @Param hard query bool false "Remove files and database rows instead of soft deleting" default(false)
+// @Success 200 {object} dto.MessageResponseDTO "Asset deleted successfully"
+// @Failure 400 {object} api.ErrorResponse "Invalid asset ID format"
+// @Failure 401 {object} api.ErrorResponse "Authentication required"
+// @Failure 403 {object} api.ErrorResponse "Not allowed to delete this asset"
+// @Failure 409 {object} api.ErrorResponse "Repository is unavailable"
+// @Failure 500 {object} api.ErrorResponse "Internal server error"
+// @Router /api/v1/assets/{id} [delete]
+func (h *AssetHandler) DeleteAsset(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		api.GinBadRequest(c, err, "Invalid asset ID")
+		return
+	}
+
+	if c.DefaultQuery("hard", "false") == "true" {
+		h.hardDeleteAsset(c, id)
+		return
+	}
+
+	if _, ok := h.getAuthorizedAsset(c, id, "Authentication required to delete this asset", "You don't have permission to delete this asset"); !ok {
+		return
+	}
+
+	err = h.assetService.DeleteAsset(c.Request.Context(), id)
+	if err != nil {
+		log.Printf("Failed to delete asset: %v", err)
+		api.GinInternalError(c, err, "Failed to delete asset")
+		return
+	}
+
+	api.JSONOK(c, dto.MessageResponseDTO{Message: "Asset deleted successfully"})
+}
+
+// hardDeleteAsset handles DELETE /assets/:id?hard=true. The asset may already
+// be in Trash, so it is looked up regardless of its deleted flag. Unlike a
+// soft delete it needs a signed-in owner, or an admin for unowned assets.
+func (h *AssetHandler) hardDeleteAsset(c *gin.Context, id uuid.UUID) {
+	if _, ok := requireCurrentUser(c); !ok {
+		return
+	}
+	asset, ok := h.loadAssetAny(c, id)
+	if !ok {
+		return
+	}
+	if !canPermanentlyDelete(c, asset.OwnerID) {
+		api.GinForbidden(c, errors.New("access denied"), "You don't have permission to permanently delete this asset")
+		return
+	}
+
+	repository, err := h.getRepositoryForAsset(c.Request.Context(), asset)
+	if err != nil {
+		respondRepositoryResolveError(c, err, "Failed to resolve repository")
+		return
+	}
+
+	var deletedBy *string
+	if user, ok := currentUserFromContext(c); ok {
+		userID := strconv.Itoa(user.UserID)
+		deletedBy = &userID
+	}
+
+	if err := h.assetService.HardDeleteAsset(c.Request.Context(), id, repository.Path, deletedBy); err != nil {
+		log.Printf("Failed to hard delete asset: %v", err)
+		api.GinInternalError(c, err, "Failed to delete asset")
+		return
+	}
+
+	api.JSONOK(c, dto.MessageResponseDTO{Message: "Asset permanently deleted"})
+}
+
+// BatchDeleteAssets deletes several assets in one request
+// @Summary Batch delete assets
+// @Description Delete each listed asset the way DELETE /assets/{id} would, soft by default or permanently with hard=true. Every asset is handled on its own: an invalid ID, a missing asset, a permission failure or a failed deletion is reported in that asset's result and the remaining assets are still processed. At most 100 asset IDs per request.
+// @Tags assets
+// @Accept json
+// @Produce json
+// @Param request body dto.BatchDeleteAssetsRequestDTO true "Asset IDs to delete"
+// @Success 200 {object} dto.BatchDeleteResponseDTO "Per-asset results"
+// @Failure 400 {object} api.ErrorResponse "Invalid request body, no asset IDs or more than 100"
+// @Router /api/v1/assets/batch-delete [post]
+func (h *AssetHandler) BatchDeleteAssets(c *gin.Context) {
+	var req dto.BatchDeleteAssetsRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		api.GinBadRequest(c, err, "Invalid request body")
+		return
+	}
+	if len(req.AssetIDs) == 0 {
+		api.GinBadRequest(c, errors.New("asset_ids is required"), "asset_ids is required")
+		return
+	}
+
+	results := make([]dto.BatchDeleteResultDTO, 0, len(req.AssetIDs))
+	for _, rawAssetID := range req.AssetIDs {
+		result := dto.BatchDeleteResultDTO{AssetID: strings.TrimSpace(rawAssetID), Success: true}
+		if err := h.deleteAssetInBatch(c, result.AssetID, req.Hard); err != nil {
+			log.Printf("Batch delete of asset %q failed: %v", result.AssetID, err)
+			message := batchDeleteMessage(err)
+			result.Success = false
+			result.Error = &message
+		}
+		results = append(results, result)
+	}
+
+	api.JSONOK(c, dto.BatchDeleteResponseDTO{Results: results})
+}
+
+// Batch delete failures a caller is told about; anything else is reported as
+// errBatchDeleteFailed and only logged in full.
+var (
+	errBatchDeleteInvalidID = errors.New("invalid asset ID")
+	errBatchDeleteNotFound  = errors.New("asset not found")
+	errBatchDeleteForbidden = errors.New("you don't have permission to delete this asset")
+	errBatchDeleteFailed    = errors.New("failed to delete asset")
+)
+
+// batchDeleteMessage is the result message for a failed batch entry.
+func batchDeleteMessage(err error) string {
+	for _, known := range []error{errBatchDeleteInvalidID, errBatchDeleteNotFound, errBatchDeleteForbidden} {
+		if errors.Is(err, known) {
+			return known.Error()
+		}
+	}
+	return errBatchDeleteFailed.Error()
+}
+
+// deleteAssetInBatch is DeleteAsset for one entry of a batch: failures come
+// back as errors for the entry's result instead of being written to c.
+func (h *AssetHandler) deleteAssetInBatch(c *gin.Context, rawAssetID string, hard bool) error {
+	ctx := c.Request.Context()
+	id, err := uuid.Parse(rawAssetID)
 	if err != nil {
-		log.Printf("Failed to update asset metadata: %v", err)
-		api.GinInternalError(c, err, "Failed to update asset")
-		return
+		return errBatchDeleteInvalidID
 	}
 
-	api.JSONOK(c, dto.MessageResponseDTO{Message: "Asset updated successfully"})
-}
-
-// DeleteAsset deletes an asset
-// @Summary Delete asset
-// @Description Soft delete an asset by marking it as deleted. The physical file is not removed.
-// @Tags assets
-// @Accept json
-// @Produce json
-// @Param id path string true "Asset ID (UUID format)" example("550e8400-e29b-41d4-a716-446655440000")
-// @Success 200 {object} dto.MessageResponseDTO "Asset deleted successfully"
-// @Failure 400 {object} api.ErrorResponse "Invalid asset ID format"
-// @Failure 500 {object} api.ErrorResponse "Internal server error"
-// @Router /api/v1/assets/{id} [delete]
-func (h *AssetHandler) DeleteAsset(c *gin.Context) {
-	idStr := c.Param("id")
-	id, err := uuid.Parse(idStr)
+	var asset *repo.Asset
+	if hard {
+		asset, err = h.assetService.GetAssetAny(ctx, id)
+	} else {
+		asset, err = h.assetService.GetAsset(ctx, id)
+	}
 	if err != nil {
-		api.GinBadRequest(c, err, "Invalid asset ID")
-		return
+		if errors.Is(err, pgx.ErrNoRows) {
+			return errBatchDeleteNotFound
+		}
+		return fmt.Errorf("failed to access asset: %w", err)
+	}
+	if !canAccessOwner(c, asset.OwnerID) || (hard && !canPermanentlyDelete(c, asset.OwnerID)) {
+		return errBatchDeleteForbidden
 	}
 
-	if _, ok := h.getAuthorizedAsset(c, id, "Authentication required to delete this asset", "You don't have permission to delete this asset"); !ok {
-		return
+	if !hard {
+		return h.assetService.DeleteAsset(ctx, id)
 	}
 
-	err = h.assetService.DeleteAsset(c.Request.Context(), id)
+	repository, err := h.getRepositoryForAsset(ctx, asset)
 	if err != nil {
-		log.Printf("Failed to delete asset: %v", err)
-		api.GinInternalError(c, err, "Failed to delete asset")
-		return
+		return err
 	}
-
-	api.JSONOK(c, dto.MessageResponseDTO{Message: "Asset deleted successfully"})
+	var deletedBy *string
+	if user, ok := currentUserFromContext(c); ok {
+		userID := strconv.Itoa(user.UserID)
+		deletedBy = &userID
+	}
+	return h.assetService.HardDeleteAsset(ctx, id, repository.Path, deletedBy)
 }
 
 // RestoreAsset restores an asset from Trash
 // @Summary Restore asset
-// @Description Restore a soft-deleted asset from Trash. The original file is not moved.
+// @Description Restore a soft-deleted asset from Trash. If its original is no longer on disk because a hard delete moved it into the repository trash and then failed, the files are recovered from there first.
 // @Tags assets
 // @Accept json
 // @Produce json
 // @Param id path string true "Asset ID (UUID format)" example("550e8400-e29b-41d4-a716-446655440000")
 // @Success 200 {object} dto.MessageResponseDTO "Asset restored successfully"
 // @Failure 400 {object} api.ErrorResponse "Invalid asset ID format"
+// @Failure 404 {object} api.ErrorResponse "Asset not found or not in Trash"
+// @Failure 409 {object} api.ErrorResponse "Original file can no longer be recovered, or repository is unavailable"
 // @Failure 500 {object} api.ErrorResponse "Internal server error"
 // @Router /api/v1/assets/{id}/restore [post]
 func (h *AssetHandler) RestoreAsset(c *gin.Context) {
@@ -1866,12 +2896,36 @@ func (h *AssetHandler) RestoreAsset(c *gin.Context) {
 		return
 	}
 
-	if _, ok := h.getAuthorizedAssetAny(c, id, "Authentication required to restore this asset", "You don't have permission to restore this asset"); !ok {
+	asset, ok := h.getAuthorizedAssetAny(c, id, "Authentication required to restore this asset", "You don't have permission to restore this asset")
+	if !ok {
+		return
+	}
+	if asset.IsDeleted == nil || !*asset.IsDeleted {
+		api.GinNotFound(c, service.ErrAssetNotDeleted, "Asset is not in Trash")
 		return
 	}
 
-	err = h.assetService.RestoreAsset(c.Request.Context(), id)
-	if err != nil {
+	// Assets without a repository have nowhere to recover files from; the
+	// row is still restored.
+	var repoPath string
+	if asset.RepositoryID.Valid {
+		repository, err := h.getRepositoryForAsset(c.Request.Context(), asset)
+		if err != nil {
+			respondRepositoryResolveError(c, err, "Failed to resolve repository")
+			return
+		}
+		repoPath = repository.Path
+	}
+
+	err = h.assetService.RestoreAsset(c.Request.Context(), id, repoPath)
+	switch {
+	case errors.Is(err, service.ErrAssetNotDeleted):
+		api.GinNotFound(c, err, "Asset is not in Trash")
+		return
+	case errors.Is(err, service.ErrOriginalUnrecoverable):
+		api.GinError(c, http.StatusConflict, err, http.StatusConflict, "Original file can no longer be recovered")
+		return
+	case err != nil:
 		log.Printf("Failed to restore asset: %v", err)
 		api.GinInternalError(c, err, "Failed to restore asset")
 		return
@@ -1889,7 +2943,7 @@ func (h *AssetHandler) RestoreAsset(c *gin.Context) {
 // @Param id path string true "Asset ID (UUID format)" example("550e8400-e29b-41d4-a716-446655440000")
 // @Param albumId path int true "Album ID" example(123)
 // @Success 200 {object} dto.MessageResponseDTO "Asset added to album successfully"
-// @Failure 400 {object} api.ErrorResponse "Invalid asset ID or album ID"
+// @Failure 400 {object} api.ErrorResponse "Invalid asset ID or album ID, or smart album"
 // @Failure 500 {object} api.ErrorResponse "Internal server error"
 // @Router /api/v1/assets/{id}/albums/{albumId} [post]
 func (h *AssetHandler) AddAssetToAlbum(c *gin.Context) {
@@ -1918,6 +2972,9 @@ func (h *AssetHandler) AddAssetToAlbum(c *gin.Context) {
 	if !ensureOwnerAccess(c, &album.UserID, "Authentication required to modify this album", "You don't have permission to modify this album") {
 		return
 	}
+	if !ensureManualAlbum(c, album) {
+		return
+	}
 	if asset.OwnerID != nil && *asset.OwnerID != album.UserID && !currentUserIsAdmin(c) {
 		api.GinForbidden(c, errors.New("cross-user album access denied"), "Asset and album must belong to the same user")
 		return
@@ -2147,27 +3204,33 @@ func assetQueryDateLocation(viewerTimeZone string) *time.Location {
 	return location
 }
 
-func buildQueryAssetsParams(query, searchType, sortBy, viewerTimeZone, stackMode string, filter dto.AssetFilterDTO, pagination dto.PaginationDTO) service.QueryAssetsParams {
-	var dateFrom, dateTo *time.Time
-	if filter.Date != nil {
-		dateFrom = filter.Date.From
-		dateTo = filter.Date.To
+// assetQueryDateBounds resolves a date filter to inclusive bounds. Date-only
+// inputs are normalized in the viewer's timezone, a lone date-only From
+// meaning that single day. Exact timestamps remain exact.
+func assetQueryDateBounds(date *dto.DateRangeDTO, viewerTimeZone string) (dateFrom, dateTo *time.Time) {
+	if date == nil {
+		return nil, nil
+	}
+	dateFrom = date.From
+	dateTo = date.To
 
-		// Normalize date-only inputs in the viewer's timezone. Exact timestamps
-		// remain exact.
-		location := assetQueryDateLocation(viewerTimeZone)
-		if dateFrom != nil && filter.Date.FromDateOnly {
-			start := time.Date(dateFrom.Year(), dateFrom.Month(), dateFrom.Day(), 0, 0, 0, 0, location)
-			dateFrom = &start
-		}
-		if dateFrom != nil && dateTo == nil && filter.Date.FromDateOnly {
-			end := time.Date(dateFrom.Year(), dateFrom.Month(), dateFrom.Day(), 23, 59, 59, int(time.Second-time.Nanosecond), location)
-			dateTo = &end
-		} else if dateTo != nil && filter.Date.ToDateOnly {
-			end := time.Date(dateTo.Year(), dateTo.Month(), dateTo.Day(), 23, 59, 59, int(time.Second-time.Nanosecond), location)
-			dateTo = &end
-		}
+	location := assetQueryDateLocation(viewerTimeZone)
+	if dateFrom != nil && date.FromDateOnly {
+		start := time.Date(dateFrom.Year(), dateFrom.Month(), dateFrom.Day(), 0, 0, 0, 0, location)
+		dateFrom = &start
 	}
+	if dateFrom != nil && dateTo == nil && date.FromDateOnly {
+		end := time.Date(dateFrom.Year(), dateFrom.Month(), dateFrom.Day(), 23, 59, 59, int(time.Second-time.Nanosecond), location)
+		dateTo = &end
+	} else if dateTo != nil && date.ToDateOnly {
+		end := time.Date(dateTo.Year(), dateTo.Month(), dateTo.Day(), 23, 59, 59, int(time.Second-time.Nanosecond), location)
+		dateTo = &end
+	}
+	return dateFrom, dateTo
+}
+
+func buildQueryAssetsParams(query, searchType, sortBy, viewerTimeZone, stackMode string, filter dto.AssetFilterDTO, pagination dto.PaginationDTO) service.QueryAssetsParams {
+	dateFrom, dateTo := assetQueryDateBounds(filter.Date, viewerTimeZone)
 
 	var albumIDPtr *int32
 	if filter.AlbumID != nil {
@@ -2197,6 +3260,12 @@ func buildQueryAssetsParams(query, searchType, sortBy, viewerTimeZone, stackMode
 		folderPath = &normalized
 	}
 
+	var customFields json.RawMessage
+	if len(filter.CustomFields) > 0 {
+		// Decoded from JSON, so re-encoding cannot fail.
+		customFields, _ = json.Marshal(filter.CustomFields)
+	}
+
 	return service.QueryAssetsParams{
 		Query:            query,
 		SearchType:       searchType,
@@ -2216,6 +3285,8 @@ func buildQueryAssetsParams(query, searchType, sortBy, viewerTimeZone, stackMode
 		Liked:            filter.Liked,
 		CameraModel:      filter.CameraModel,
 		LensModel:        filter.Lens,
+		CustomFields:     customFields,
+		ProcessingStatus: filter.ProcessingStatus,
 		TagName:          filter.TagName,
 		TagSource:        filter.TagSource,
 		TagNames:         filter.TagNames,
@@ -2370,7 +3441,7 @@ func toSearchDebugItemDTOs(debug []service.SearchDebugItem) []dto.SearchDebugIte
 
 // QueryAssets handles unified asset listing, filtering, and searching
 // @Summary Query assets (unified endpoint)
-// @Description Unified endpoint for listing, filtering, and searching assets. Replaces separate /filter and /search endpoints.
+// @Description Unified endpoint for listing, filtering, and searching assets. Replaces separate /filter and /search endpoints. Pages by offset, or by pagination.cursor set to the previous page's next_cursor, which stays stable while assets are added or removed. Cursors are not available for semantic search.
 // @Tags assets
 // @Produce json
 // @Param data body dto.AssetQueryRequestDTO true "Query parameters"
@@ -2400,6 +3471,14 @@ func (h *AssetHandler) QueryAssets(c *gin.Context) {
 		api.GinBadRequest(c, err, "stack_mode must be 'collapsed' or 'expanded'")
 		return
 	}
+	if err := validateAssetFilterLocation(req.Filter); err != nil {
+		api.GinBadRequest(c, err, err.Error())
+		return
+	}
+	if err := validateAssetFilterProcessingStatus(req.Filter); err != nil {
+		api.GinBadRequest(c, err, err.Error())
+		return
+	}
 
 	// Default to filename search if not specified
 	if req.SearchType == "" {
@@ -2408,6 +3487,15 @@ func (h *AssetHandler) QueryAssets(c *gin.Context) {
 
 	params := buildQueryAssetsParams(req.Query, req.SearchType, req.SortBy, req.ViewerTimezone, req.StackMode, req.Filter, req.Pagination)
 	params = applyAssetOwnershipScope(c, params)
+	if req.Pagination.Cursor != "" {
+		cursor, err := service.DecodeAssetCursor(req.Pagination.Cursor)
+		if err != nil {
+			api.GinBadRequest(c, err, "Invalid pagination cursor")
+			return
+		}
+		params.Cursor = &cursor
+		req.Pagination.Offset = 0
+	}
 
 	browseResult, err := h.assetService.QueryBrowseItems(c.Request.Context(), params)
 	if err != nil {
@@ -2416,6 +3504,10 @@ func (h *AssetHandler) QueryAssets(c *gin.Context) {
 			api.GinError(c, 503, err, 503, "Semantic search is currently unavailable")
 			return
 		}
+		if errors.Is(err, service.ErrAssetCursorUnsupported) {
+			api.GinBadRequest(c, err, "Cursor pagination is not available for semantic search")
+			return
+		}
 		log.Printf("Failed to query assets: %v", err)
 		api.GinInternalError(c, err, "Failed to query assets")
 		return
@@ -2426,12 +3518,90 @@ func (h *AssetHandler) QueryAssets(c *gin.Context) {
 		req.Pagination.Limit,
 		req.Pagination.Offset,
 	)
+	if browseResult.NextCursor != nil {
+		response.NextCursor = service.EncodeAssetCursor(*browseResult.NextCursor)
+	}
+	if req.IncludeURLs {
+		signer, err := h.mediaURLSigner(c)
+		if err != nil {
+			api.GinInternalError(c, err, "Failed to sign media URLs")
+			return
+		}
+		signer.signBrowseItems(response.Items)
+	}
+	api.JSONOK(c, response)
+}
+
+// GetAssetNeighbors returns the assets before and after an asset in a list
+// @Summary Get adjacent assets
+// @Description Resolve the previous and next asset around an asset under the same filter and sort as POST /assets/list, so a fullscreen viewer can preload and swipe without refetching the page. Stacks are expanded; semantic search is not supported.
+// @Tags assets
+// @Produce json
+// @Param id path string true "Asset ID (UUID format)" example("550e8400-e29b-41d4-a716-446655440000")
+// @Param sort_by query string false "Sort order" Enums(recently_added, date_captured) default(date_captured)
+// @Param query query string false "Filename search keyword"
+// @Param viewer_timezone query string false "IANA time zone for date-only filter bounds" example(America/New_York)
+// @Param filter query string false "JSON-encoded dto.AssetFilterDTO, as sent to POST /assets/list"
+// @Success 200 {object} dto.AssetNeighborsResponseDTO "Adjacent assets resolved"
+// @Failure 400 {object} api.ErrorResponse "Invalid request parameters"
+// @Failure 403 {object} api.ErrorResponse "Forbidden"
+// @Failure 404 {object} api.ErrorResponse "Asset not found"
+// @Failure 500 {object} api.ErrorResponse "Internal server error"
+// @Router /api/v1/assets/{id}/neighbors [get]
+func (h *AssetHandler) GetAssetNeighbors(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		api.GinBadRequest(c, err, "Invalid asset ID")
+		return
+	}
+
+	sortBy := c.Query("sort_by")
+	if err := validateAssetQuerySortBy(sortBy); err != nil {
+		api.GinBadRequest(c, err, "sort_by must be 'recently_added' or 'date_captured'")
+		return
+	}
+
+	var filter dto.AssetFilterDTO
+	if raw := strings.TrimSpace(c.Query("filter")); raw != "" {
+		if err := json.Unmarshal([]byte(raw), &filter); err != nil {
+			api.GinBadRequest(c, err, "filter must be a JSON-encoded asset filter")
+			return
+		}
+	}
+
+	if _, ok := h.getAuthorizedAssetForRead(c, id, "Authentication required to access this asset", "You don't have permission to access this asset"); !ok {
+		return
+	}
+
+	params := buildQueryAssetsParams(c.Query("query"), "filename", sortBy, c.Query("viewer_timezone"), "", filter, dto.PaginationDTO{})
+	params = applyAssetOwnershipScope(c, params)
+
+	neighbors, err := h.assetService.GetAssetNeighbors(c.Request.Context(), id, params)
+	if err != nil {
+		if errors.Is(err, service.ErrAssetNotFound) {
+			api.GinNotFound(c, err, "Asset not found")
+			return
+		}
+		log.Printf("Failed to get asset neighbors: %v", err)
+		api.GinInternalError(c, err, "Failed to get asset neighbors")
+		return
+	}
+
+	response := dto.AssetNeighborsResponseDTO{AssetID: id.String()}
+	if neighbors.PreviousID != nil {
+		previousID := neighbors.PreviousID.String()
+		response.PreviousID = &previousID
+	}
+	if neighbors.NextID != nil {
+		nextID := neighbors.NextID.String()
+		response.NextID = &nextID
+	}
 	api.JSONOK(c, response)
 }
 
 // SearchAssets handles sectioned asset search with best-effort top results.
 // @Summary Search assets
-// @Description Search assets with optional top results enhancement and filename fallback.
+// @Description Search assets with optional top results enhancement and filename fallback. With search_type "tag", return the assets carrying all (tag_match "all") or any (tag_match "any") of filter.tag_names, or of the comma-separated tag names in query, newest capture first; filter.tag_source ("ai", "manual" or a raw source) and filter.tag_min_confidence narrow the matching tags.
 // @Tags assets
 // @Produce json
 // @Param data body dto.SearchAssetsRequestDTO true "Search parameters"
@@ -2447,6 +3617,15 @@ func (h *AssetHandler) SearchAssets(c *gin.Context) {
 	}
 
 	normalizeAssetQueryPagination(&req.Pagination)
+	switch strings.TrimSpace(req.SearchType) {
+	case "", "text":
+	case "tag":
+		h.searchAssetsByTag(c, req)
+		return
+	default:
+		api.GinBadRequest(c, errors.New("invalid search type"), "search_type must be 'text' or 'tag'")
+		return
+	}
 	if err := validateAssetQuerySortBy(req.SortBy); err != nil {
 		api.GinBadRequest(c, err, "sort_by must be 'recently_added' or 'date_captured'")
 		return
@@ -2455,6 +3634,10 @@ func (h *AssetHandler) SearchAssets(c *gin.Context) {
 		api.GinBadRequest(c, err, "stack_mode must be 'collapsed' or 'expanded'")
 		return
 	}
+	if err := validateAssetFilterLocation(req.Filter); err != nil {
+		api.GinBadRequest(c, err, err.Error())
+		return
+	}
 
 	if err := validateSearchEnhancementMode(req.EnhancementMode); err != nil {
 		api.GinBadRequest(c, err, "Enhancement mode must be 'auto', 'off', or 'only'")
@@ -2484,6 +3667,79 @@ func (h *AssetHandler) SearchAssets(c *gin.Context) {
 	api.JSONOK(c, searchResponse)
 }
 
+// searchAssetsByTag answers SearchAssets for search_type "tag".
+func (h *AssetHandler) searchAssetsByTag(c *gin.Context, req dto.SearchAssetsRequestDTO) {
+	params, err := buildTagSearchParams(req)
+	if err != nil {
+		api.GinBadRequest(c, err, err.Error())
+		return
+	}
+	params.OwnerID = ownerScopeID(c)
+
+	result, err := h.assetService.SearchAssetsByTag(c.Request.Context(), params)
+	if err != nil {
+		if errors.Is(err, service.ErrNoSearchTags) {
+			api.GinBadRequest(c, err, "search_type 'tag' needs filter.tag_names or tag names in query")
+			return
+		}
+		log.Printf("Failed to search assets by tag: %v", err)
+		api.GinInternalError(c, err, "Failed to search assets")
+		return
+	}
+
+	total := int(result.Total)
+	api.JSONOK(c, dto.SearchAssetsResponseDTO{
+		TopResultsMeta:      dto.SearchTopResultsMetaDTO{SourceTypes: []string{}},
+		ResultItems:         toBrowseItemDTOs(result.Items),
+		ResultsTotalVisible: &total,
+		ResultsTotalAssets:  &total,
+		StackMode:           service.StackModeExpanded,
+		Limit:               req.Pagination.Limit,
+		Offset:              req.Pagination.Offset,
+	})
+}
+
+// buildTagSearchParams reads a tag search from the request. Tag names come
+// from filter.tag_names and filter.tag_name, or else from query split on
+// commas.
+func buildTagSearchParams(req dto.SearchAssetsRequestDTO) (service.TagSearchParams, error) {
+	filter := req.Filter
+	tagNames := append([]string{}, filter.TagNames...)
+	if filter.TagName != nil {
+		tagNames = append(tagNames, *filter.TagName)
+	}
+	if len(tagNames) == 0 {
+		tagNames = strings.Split(req.Query, ",")
+	}
+
+	params := service.TagSearchParams{
+		TagNames:      tagNames,
+		Source:        filter.TagSource,
+		MinConfidence: filter.TagMinConfidence,
+		RepositoryID:  filter.RepositoryID,
+		Limit:         req.Pagination.Limit,
+		Offset:        req.Pagination.Offset,
+	}
+	if filter.TagMatch != nil {
+		switch strings.ToLower(strings.TrimSpace(*filter.TagMatch)) {
+		case "", "all":
+		case "any":
+			params.MatchAny = true
+		default:
+			return service.TagSearchParams{}, errors.New("tag_match must be 'all' or 'any'")
+		}
+	}
+	if confidence := filter.TagMinConfidence; confidence != nil && (*confidence < 0 || *confidence > 1) {
+		return service.TagSearchParams{}, errors.New("tag_min_confidence must be between 0 and 1")
+	}
+	if filter.RepositoryID != nil && *filter.RepositoryID != "" {
+		if _, err := uuid.Parse(*filter.RepositoryID); err != nil {
+			return service.TagSearchParams{}, errors.New("invalid repository_id")
+		}
+	}
+	return params, nil
+}
+
 // ListIndexingRepositories returns repository options for scope selectors
 // (browse scope, upload target) and indexing filters. All authenticated users
 // may read the shared registry; filesystem paths are admin-only.
@@ -2546,11 +3802,11 @@ func (h *AssetHandler) GetIndexingStats(c *gin.Context) {
 
 // RebuildAssetIndexes queues a background indexing backfill batch for existing photos.
 // @Summary Queue asset index rebuild
-// @Description Queue a background batch that backfills AI indexing for existing photos.
+// @Description Queue a background batch that backfills AI indexing for existing photos. Use the semantic task to recompute embeddings, with reset_semantic after a model swap. A repository and a capture date range narrow the photos; date-only bounds are whole days in viewer_timezone (UTC by default), and a lone date-only from means that single day. Only photos are indexed. With dry_run nothing is queued and matching_assets reports how many photos the rebuild would visit.
 // @Tags assets
 // @Produce json
 // @Param data body dto.RebuildAssetIndexesRequestDTO false "Reindex request"
-// @Success 200 {object} dto.RebuildAssetIndexesResponseDTO "Reindex job queued successfully"
+// @Success 200 {object} dto.RebuildAssetIndexesResponseDTO "Reindex job queued, or the dry-run count"
 // @Failure 400 {object} api.ErrorResponse "Invalid request parameters"
 // @Failure 500 {object} api.ErrorResponse "Internal server error"
 // @Router /api/v1/assets/indexing/rebuild [post]
@@ -2582,11 +3838,20 @@ func (h *AssetHandler) RebuildAssetIndexes(c *gin.Context) {
 		resetSemantic = *req.ResetSemantic
 	}
 
+	dateFrom, dateTo := assetQueryDateBounds(req.Date, req.ViewerTimezone)
+	if dateFrom != nil && dateTo != nil && dateFrom.After(*dateTo) {
+		api.GinBadRequest(c, errors.New("date.from is after date.to"), "Invalid date range")
+		return
+	}
+
 	result, err := h.indexingService.EnqueueReindexAssets(c.Request.Context(), service.ReindexAssetsInput{
 		RepositoryID:  repositoryIDPtr,
 		Tasks:         tasks,
 		Limit:         normalizeRebuildIndexLimit(req.Limit),
 		MissingOnly:   missingOnly,
+		DateFrom:      dateFrom,
+		DateTo:        dateTo,
+		DryRun:        req.DryRun,
 		ResetSemantic: resetSemantic,
 	})
 	if err != nil {
@@ -2610,6 +3875,9 @@ func (h *AssetHandler) RebuildAssetIndexes(c *gin.Context) {
 	if result.JobID == 0 && len(result.Requested) == 0 {
 		status = "skipped"
 		message = "All requested indexing tasks are disabled in ML settings"
+	} else if result.DryRun {
+		status = "dry_run"
+		message = "Dry run: no jobs were queued"
 	}
 
 	api.JSONOK(c, dto.RebuildAssetIndexesResponseDTO{
@@ -2621,6 +3889,8 @@ func (h *AssetHandler) RebuildAssetIndexes(c *gin.Context) {
 		Limit:          result.Limit,
 		MissingOnly:    result.MissingOnly,
 		RepositoryID:   result.RepositoryID,
+		MatchingAssets: result.Matching,
+		DryRun:         result.DryRun,
 	})
 }
 
@@ -2825,14 +4095,39 @@ func parseOptionalMapViewport(c *gin.Context) (*float64, *float64, *float64, *fl
 	if present != len(names) {
 		return nil, nil, nil, nil, errors.New("south, north, west, and east must be provided together")
 	}
-	south, north, west, east := *values[0], *values[1], *values[2], *values[3]
+	if err := validateLocationBounds(*values[0], *values[1], *values[2], *values[3]); err != nil {
+		return nil, nil, nil, nil, err
+	}
+	return values[0], values[1], values[2], values[3], nil
+}
+
+// validateLocationBounds checks a GPS bounding box. West may exceed east for a
+// box that crosses the antimeridian.
+func validateLocationBounds(south, north, west, east float64) error {
 	if south < -90 || south > 90 || north < -90 || north > 90 || south > north {
-		return nil, nil, nil, nil, errors.New("latitude bounds must satisfy -90 <= south <= north <= 90")
+		return errors.New("latitude bounds must satisfy -90 <= south <= north <= 90")
 	}
 	if west < -180 || west > 180 || east < -180 || east > 180 {
-		return nil, nil, nil, nil, errors.New("longitude bounds must be between -180 and 180")
+		return errors.New("longitude bounds must be between -180 and 180")
 	}
-	return values[0], values[1], values[2], values[3], nil
+	return nil
+}
+
+// validateAssetFilterLocation checks filter.location, the bounding box a map
+// view sends as it pans.
+func validateAssetFilterLocation(filter dto.AssetFilterDTO) error {
+	if filter.Location == nil {
+		return nil
+	}
+	location := filter.Location
+	return validateLocationBounds(location.South, location.North, location.West, location.East)
+}
+
+func validateAssetFilterProcessingStatus(filter dto.AssetFilterDTO) error {
+	if filter.ProcessingStatus == nil || slices.Contains(assetProcessingStates, *filter.ProcessingStatus) {
+		return nil
+	}
+	return fmt.Errorf("processing_status must be one of %s; got %q", strings.Join(assetProcessingStates, ", "), *filter.ProcessingStatus)
 }
 
 func parseIntQueryWithRange(
@@ -2874,7 +4169,7 @@ func countUniqueAssets(assets []repo.Asset) int {
 
 // GetFilterOptions returns available options for filters
 // @Summary Get filter options
-// @Description Get available camera models and lenses for filter dropdowns
+// @Description Get available camera models, lenses and custom field values for filter dropdowns
 // @Tags assets
 // @Accept json
 // @Produce json
@@ -2898,9 +4193,17 @@ func (h *AssetHandler) GetFilterOptions(c *gin.Context) {
 		return
 	}
 
+	customFields, err := h.assetService.GetCustomFieldValues(ctx)
+	if err != nil {
+		log.Printf("Failed to get custom field values: %v", err)
+		api.GinInternalError(c, err, "Failed to get filter options")
+		return
+	}
+
 	response := dto.OptionsResponseDTO{
 		CameraModels: cameraModels,
 		Lenses:       lenses,
+		CustomFields: customFields,
 	}
 	api.JSONOK(c, response)
 }
@@ -2909,7 +4212,7 @@ func (h *AssetHandler) GetFilterOptions(c *gin.Context) {
 
 // UpdateAssetRating updates the rating of an asset
 // @Summary Update asset rating
-// @Description Update the rating (0-5) of a specific asset
+// @Description Update the rating (0-5) of a specific asset. With storage.write_rating_to_exif enabled, a background job also writes the rating into the XMP Rating tag of JPEG and TIFF originals; other formats are skipped and a failed write does not affect the stored rating.
 // @Tags assets
 // @Produce json
 // @Param id path string true "Asset ID"
@@ -2948,10 +4251,23 @@ func (h *AssetHandler) UpdateAssetRating(c *gin.Context) {
 		api.GinInternalError(c, err, "Failed to update rating")
 		return
 	}
+	h.queueRatingWrite(c.Request.Context(), id)
 
 	api.JSONOK(c, dto.MessageResponseDTO{Message: "Rating updated successfully"})
 }
 
+// queueRatingWrite queues mirroring a stored rating into the asset's original
+// when write-back is enabled. The database stays the source of truth, so a
+// failure to queue is only logged.
+func (h *AssetHandler) queueRatingWrite(ctx context.Context, id uuid.UUID) {
+	if h.ratingWriter == nil {
+		return
+	}
+	if err := h.ratingWriter.QueueRatingWrite(ctx, pgtype.UUID{Bytes: id, Valid: true}); err != nil {
+		log.Printf("Failed to queue rating write for asset %s: %v", id, err)
+	}
+}
+
 // UpdateAssetLike updates the like status of an asset
 // @Summary Update asset like status
 // @Description Update the like/favorite status of a specific asset
@@ -2963,8 +4279,94 @@ func (h *AssetHandler) UpdateAssetRating(c *gin.Context) {
 // @Failure 400 {object} api.ErrorResponse "Bad request"
 // @Failure 404 {object} api.ErrorResponse "Asset not found"
 // @Failure 500 {object} api.ErrorResponse "Internal server error"
-// @Router /api/v1/assets/{id}/like [put]
-func (h *AssetHandler) UpdateAssetLike(c *gin.Context) {
+// @Router /api/v1/assets/{id}/like [put]
+func (h *AssetHandler) UpdateAssetLike(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		api.GinBadRequest(c, err, "Invalid asset ID")
+		return
+	}
+
+	var req dto.UpdateLikeRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		api.GinBadRequest(c, err, "Invalid request body")
+		return
+	}
+
+	if _, ok := h.getAuthorizedAsset(c, id, "Authentication required to update this asset", "You don't have permission to update this asset"); !ok {
+		return
+	}
+
+	err = h.assetService.UpdateAssetLike(c.Request.Context(), id, req.Liked)
+	if err != nil {
+		log.Printf("Failed to update asset like status: %v", err)
+		api.GinInternalError(c, err, "Failed to update like status")
+		return
+	}
+
+	api.JSONOK(c, dto.MessageResponseDTO{Message: "Like status updated successfully"})
+}
+
+// UpdateAssetRatingAndLike updates both rating and like status of an asset
+// @Summary Update asset rating and like status
+// @Description Update both the rating (0-5) and like/favorite status of a specific asset. The rating is written back to the original in the background like PUT /assets/{id}/rating does.
+// @Tags assets
+// @Produce json
+// @Param id path string true "Asset ID"
+// @Param data body dto.UpdateRatingAndLikeRequestDTO true "Rating and like data"
+// @Success 200 {object} dto.MessageResponseDTO "Rating and like status updated successfully"
+// @Failure 400 {object} api.ErrorResponse "Bad request"
+// @Failure 404 {object} api.ErrorResponse "Asset not found"
+// @Failure 500 {object} api.ErrorResponse "Internal server error"
+// @Router /api/v1/assets/{id}/rating-and-like [put]
+func (h *AssetHandler) UpdateAssetRatingAndLike(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		api.GinBadRequest(c, err, "Invalid asset ID")
+		return
+	}
+
+	var req dto.UpdateRatingAndLikeRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		api.GinBadRequest(c, err, "Invalid request body")
+		return
+	}
+
+	if req.Rating < 0 || req.Rating > 5 {
+		api.GinBadRequest(c, nil, "Rating must be between 0 and 5")
+		return
+	}
+
+	if _, ok := h.getAuthorizedAsset(c, id, "Authentication required to update this asset", "You don't have permission to update this asset"); !ok {
+		return
+	}
+
+	err = h.assetService.UpdateAssetRatingAndLike(c.Request.Context(), id, req.Rating, req.Liked)
+	if err != nil {
+		log.Printf("Failed to update asset rating and like status: %v", err)
+		api.GinInternalError(c, err, "Failed to update rating and like status")
+		return
+	}
+	h.queueRatingWrite(c.Request.Context(), id)
+
+	api.JSONOK(c, dto.MessageResponseDTO{Message: "Rating and like status updated successfully"})
+}
+
+// UpdateAssetDescription updates the description of an asset
+// @Summary Update asset description
+// @Description Update the description metadata of an asset
+// @Tags assets
+// @Produce json
+// @Param id path string true "Asset ID"
+// @Param description body dto.UpdateDescriptionRequestDTO true "Description data"
+// @Success 200 {object} dto.MessageResponseDTO "Description updated successfully"
+// @Failure 400 {object} api.ErrorResponse "Bad request"
+// @Failure 404 {object} api.ErrorResponse "Asset not found"
+// @Failure 500 {object} api.ErrorResponse "Internal server error"
+// @Router /api/v1/assets/{id}/description [put]
+func (h *AssetHandler) UpdateAssetDescription(c *gin.Context) {
 	idStr := c.Param("id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
@@ -2972,7 +4374,7 @@ func (h *AssetHandler) UpdateAssetLike(c *gin.Context) {
 		return
 	}
 
-	var req dto.UpdateLikeRequestDTO
+	var req dto.UpdateDescriptionRequestDTO
 	if err := c.ShouldBindJSON(&req); err != nil {
 		api.GinBadRequest(c, err, "Invalid request body")
 		return
@@ -2982,29 +4384,28 @@ func (h *AssetHandler) UpdateAssetLike(c *gin.Context) {
 		return
 	}
 
-	err = h.assetService.UpdateAssetLike(c.Request.Context(), id, req.Liked)
+	err = h.assetService.UpdateAssetDescription(c.Request.Context(), id, req.Description)
 	if err != nil {
-		log.Printf("Failed to update asset like status: %v", err)
-		api.GinInternalError(c, err, "Failed to update like status")
+		log.Printf("Failed to update asset description: %v", err)
+		api.GinInternalError(c, err, "Failed to update description")
 		return
 	}
 
-	api.JSONOK(c, dto.MessageResponseDTO{Message: "Like status updated successfully"})
+	api.JSONOK(c, dto.MessageResponseDTO{Message: "Description updated successfully"})
 }
 
-// UpdateAssetRatingAndLike updates both rating and like status of an asset
-// @Summary Update asset rating and like status
-// @Description Update both the rating (0-5) and like/favorite status of a specific asset
+// GetAssetCustomFields returns an asset's custom fields
+// @Summary Get asset custom fields
+// @Description Get the user-defined key/value fields of an asset
 // @Tags assets
 // @Produce json
 // @Param id path string true "Asset ID"
-// @Param data body dto.UpdateRatingAndLikeRequestDTO true "Rating and like data"
-// @Success 200 {object} dto.MessageResponseDTO "Rating and like status updated successfully"
+// @Success 200 {object} dto.AssetCustomFieldsDTO "Custom fields retrieved successfully"
 // @Failure 400 {object} api.ErrorResponse "Bad request"
 // @Failure 404 {object} api.ErrorResponse "Asset not found"
 // @Failure 500 {object} api.ErrorResponse "Internal server error"
-// @Router /api/v1/assets/{id}/rating-and-like [put]
-func (h *AssetHandler) UpdateAssetRatingAndLike(c *gin.Context) {
+// @Router /api/v1/assets/{id}/custom-fields [get]
+func (h *AssetHandler) GetAssetCustomFields(c *gin.Context) {
 	idStr := c.Param("id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
@@ -3012,44 +4413,34 @@ func (h *AssetHandler) UpdateAssetRatingAndLike(c *gin.Context) {
 		return
 	}
 
-	var req dto.UpdateRatingAndLikeRequestDTO
-	if err := c.ShouldBindJSON(&req); err != nil {
-		api.GinBadRequest(c, err, "Invalid request body")
-		return
-	}
-
-	if req.Rating < 0 || req.Rating > 5 {
-		api.GinBadRequest(c, nil, "Rating must be between 0 and 5")
-		return
-	}
-
-	if _, ok := h.getAuthorizedAsset(c, id, "Authentication required to update this asset", "You don't have permission to update this asset"); !ok {
+	if _, ok := h.getAuthorizedAssetForRead(c, id, "Authentication required to view this asset", "You don't have permission to view this asset"); !ok {
 		return
 	}
 
-	err = h.assetService.UpdateAssetRatingAndLike(c.Request.Context(), id, req.Rating, req.Liked)
+	fields, err := h.assetService.GetAssetCustomFields(c.Request.Context(), id)
 	if err != nil {
-		log.Printf("Failed to update asset rating and like status: %v", err)
-		api.GinInternalError(c, err, "Failed to update rating and like status")
+		log.Printf("Failed to get asset custom fields: %v", err)
+		api.GinInternalError(c, err, "Failed to get custom fields")
 		return
 	}
 
-	api.JSONOK(c, dto.MessageResponseDTO{Message: "Rating and like status updated successfully"})
+	api.JSONOK(c, dto.AssetCustomFieldsDTO{AssetID: id.String(), Fields: fields})
 }
 
-// UpdateAssetDescription updates the description of an asset
-// @Summary Update asset description
-// @Description Update the description metadata of an asset
+// UpdateAssetCustomFields replaces an asset's custom fields
+// @Summary Update asset custom fields
+// @Description Replace the user-defined key/value fields of an asset. Keys must be lower snake case; values must be strings, numbers or booleans. Custom fields are stored apart from extracted metadata and survive a metadata refresh.
 // @Tags assets
+// @Accept json
 // @Produce json
 // @Param id path string true "Asset ID"
-// @Param description body dto.UpdateDescriptionRequestDTO true "Description data"
-// @Success 200 {object} dto.MessageResponseDTO "Description updated successfully"
+// @Param fields body dto.UpdateCustomFieldsRequestDTO true "Custom fields"
+// @Success 200 {object} dto.AssetCustomFieldsDTO "Custom fields updated successfully"
 // @Failure 400 {object} api.ErrorResponse "Bad request"
 // @Failure 404 {object} api.ErrorResponse "Asset not found"
 // @Failure 500 {object} api.ErrorResponse "Internal server error"
-// @Router /api/v1/assets/{id}/description [put]
-func (h *AssetHandler) UpdateAssetDescription(c *gin.Context) {
+// @Router /api/v1/assets/{id}/custom-fields [put]
+func (h *AssetHandler) UpdateAssetCustomFields(c *gin.Context) {
 	idStr := c.Param("id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
@@ -3057,24 +4448,28 @@ func (h *AssetHandler) UpdateAssetDescription(c *gin.Context) {
 		return
 	}
 
-	var req dto.UpdateDescriptionRequestDTO
+	var req dto.UpdateCustomFieldsRequestDTO
 	if err := c.ShouldBindJSON(&req); err != nil {
 		api.GinBadRequest(c, err, "Invalid request body")
 		return
 	}
+	if err := service.ValidateCustomFields(req.Fields); err != nil {
+		api.GinBadRequest(c, err, err.Error())
+		return
+	}
 
 	if _, ok := h.getAuthorizedAsset(c, id, "Authentication required to update this asset", "You don't have permission to update this asset"); !ok {
 		return
 	}
 
-	err = h.assetService.UpdateAssetDescription(c.Request.Context(), id, req.Description)
+	fields, err := h.assetService.SetAssetCustomFields(c.Request.Context(), id, req.Fields)
 	if err != nil {
-		log.Printf("Failed to update asset description: %v", err)
-		api.GinInternalError(c, err, "Failed to update description")
+		log.Printf("Failed to update asset custom fields: %v", err)
+		api.GinInternalError(c, err, "Failed to update custom fields")
 		return
 	}
 
-	api.JSONOK(c, dto.MessageResponseDTO{Message: "Description updated successfully"})
+	api.JSONOK(c, dto.AssetCustomFieldsDTO{AssetID: id.String(), Fields: fields})
 }
 
 // GetAssetTags lists the tags attached to an asset
@@ -3099,6 +4494,12 @@ func (h *AssetHandler) GetAssetTags(c *gin.Context) {
 		return
 	}
 
+	h.respondAssetTags(c, id)
+}
+
+// respondAssetTags writes the asset's current tag list, so tag mutations can
+// hand the UI the refreshed list without another round trip.
+func (h *AssetHandler) respondAssetTags(c *gin.Context, id uuid.UUID) {
 	raw, err := h.assetService.GetAssetTags(c.Request.Context(), id)
 	if err != nil {
 		log.Printf("Failed to get asset tags: %v", err)
@@ -3120,13 +4521,13 @@ func (h *AssetHandler) GetAssetTags(c *gin.Context) {
 
 // AddAssetTag adds a manual tag to an asset
 // @Summary Add a manual tag to an asset
-// @Description Resolve (creating if needed) a tag by name and link it to the asset with the manual source
+// @Description Resolve (creating if needed, with the optional category) a tag by name and link it to the asset with the manual source and full confidence. Returns the asset's updated tag list.
 // @Tags assets
 // @Accept json
 // @Produce json
 // @Param id path string true "Asset ID"
 // @Param request body dto.AddAssetTagRequestDTO true "Tag to add"
-// @Success 200 {object} dto.AssetTagDTO "Tag added successfully"
+// @Success 200 {object} dto.AssetTagsResponseDTO "Updated asset tags"
 // @Failure 400 {object} api.ErrorResponse "Bad request"
 // @Failure 500 {object} api.ErrorResponse "Internal server error"
 // @Router /api/v1/assets/{id}/tags [post]
@@ -3148,30 +4549,23 @@ func (h *AssetHandler) AddAssetTag(c *gin.Context) {
 		return
 	}
 
-	tag, err := h.assetService.AddManualTagToAsset(c.Request.Context(), id, req.TagName)
-	if err != nil {
+	if _, err := h.assetService.AddManualTagToAsset(c.Request.Context(), id, req.TagName, req.Category); err != nil {
 		log.Printf("Failed to add tag to asset: %v", err)
 		api.GinInternalError(c, err, "Failed to add tag")
 		return
 	}
 
-	source := service.AssetTagSourceUser
-	resp := dto.AssetTagDTO{
-		TagID:   tag.TagID,
-		TagName: tag.TagName,
-		Source:  &source,
-	}
-	api.JSONOK(c, resp)
+	h.respondAssetTags(c, id)
 }
 
 // RemoveAssetTag removes a tag from an asset
 // @Summary Remove a tag from an asset
-// @Description Unlink a tag from an asset by tag ID
+// @Description Unlink a tag from an asset by tag ID. Returns the asset's updated tag list.
 // @Tags assets
 // @Produce json
 // @Param id path string true "Asset ID"
 // @Param tagId path int true "Tag ID"
-// @Success 200 {object} dto.MessageResponseDTO "Tag removed successfully"
+// @Success 200 {object} dto.AssetTagsResponseDTO "Updated asset tags"
 // @Failure 400 {object} api.ErrorResponse "Bad request"
 // @Failure 500 {object} api.ErrorResponse "Internal server error"
 // @Router /api/v1/assets/{id}/tags/{tagId} [delete]
@@ -3199,7 +4593,7 @@ func (h *AssetHandler) RemoveAssetTag(c *gin.Context) {
 		return
 	}
 
-	api.JSONOK(c, dto.MessageResponseDTO{Message: "Tag removed successfully"})
+	h.respondAssetTags(c, id)
 }
 
 // ListTags returns tag definitions for autocomplete
@@ -3230,23 +4624,59 @@ func (h *AssetHandler) ListTags(c *gin.Context) {
 
 	items := make([]dto.TagDTO, 0, len(tags))
 	for _, tag := range tags {
-		item := dto.TagDTO{TagID: tag.TagID, TagName: tag.TagName}
-		if tag.Category != nil {
-			item.Category = *tag.Category
-		}
-		items = append(items, item)
+		items = append(items, toTagDTO(tag))
 	}
 
 	api.JSONOK(c, dto.TagListResponseDTO{Tags: items})
 }
 
+// CleanupAITags removes low-confidence AI tag assignments
+// @Summary Clean up low-confidence AI tags
+// @Description Remove AI-sourced asset-tag links whose confidence is below min_confidence. User and system tags are never touched. Optionally also delete AI tag definitions no longer referenced by any asset. The same cleanup also runs periodically while lumen.tag_cleanup_min_confidence is set in the server config.
+// @Tags assets
+// @Produce json
+// @Param min_confidence query number true "Confidence threshold in (0, 1]; AI tags strictly below it are removed" example(0.3)
+// @Param remove_orphans query bool false "Also delete AI tags no longer attached to any asset" default(false)
+// @Success 200 {object} dto.TagCleanupResponseDTO "Cleanup completed"
+// @Failure 400 {object} api.ErrorResponse "Invalid min_confidence"
+// @Failure 500 {object} api.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /api/v1/admin/tags/cleanup [post]
+func (h *AssetHandler) CleanupAITags(c *gin.Context) {
+	rawConfidence := strings.TrimSpace(c.Query("min_confidence"))
+	if rawConfidence == "" {
+		api.GinBadRequest(c, errors.New("min_confidence is required"), "min_confidence is required")
+		return
+	}
+	minConfidence, err := strconv.ParseFloat(rawConfidence, 64)
+	if err != nil || math.IsNaN(minConfidence) || minConfidence <= 0 || minConfidence > 1 {
+		api.GinBadRequest(c, fmt.Errorf("invalid min_confidence %q", rawConfidence), "min_confidence must be a number in (0, 1]")
+		return
+	}
+	removeOrphans := c.DefaultQuery("remove_orphans", "false") == "true"
+
+	result, err := h.assetService.CleanupAITags(c.Request.Context(), minConfidence, removeOrphans)
+	if err != nil {
+		log.Printf("Failed to clean up AI tags: %v", err)
+		api.GinInternalError(c, err, "Failed to clean up AI tags")
+		return
+	}
+
+	api.JSONOK(c, dto.TagCleanupResponseDTO{
+		MinConfidence:    minConfidence,
+		RemovedAssetTags: result.RemovedAssetTags,
+		RemovedTags:      result.RemovedTags,
+	})
+}
+
 // GetTagSummaries returns a browsable, count/cover-enriched tag vocabulary
 // @Summary List tag summaries
-// @Description List manual and AI/system tags with usage counts and covers, for the Tags collection view
+// @Description List manual and AI/system tags with usage counts, categories and covers, most used first, for the Tags collection view
 // @Tags assets
 // @Produce json
 // @Param repository_id query string false "Optional repository UUID filter"
 // @Param source query string false "Optional tag source filter (e.g. manual, zeroshot)"
+// @Param category query string false "Optional tag category filter (e.g. scene)"
 // @Param q query string false "Search query (substring match on tag name)"
 // @Param limit query int false "Max results" default(50)
 // @Param offset query int false "Result offset" default(0)
@@ -3269,6 +4699,11 @@ func (h *AssetHandler) GetTagSummaries(c *gin.Context) {
 		source = &rawSource
 	}
 
+	var category *string
+	if rawCategory := strings.TrimSpace(c.Query("category")); rawCategory != "" {
+		category = &rawCategory
+	}
+
 	var query *string
 	if rawQuery := strings.TrimSpace(c.Query("q")); rawQuery != "" {
 		query = &rawQuery
@@ -3285,7 +4720,7 @@ func (h *AssetHandler) GetTagSummaries(c *gin.Context) {
 		return
 	}
 
-	summaries, err := h.assetService.ListTagSummaries(c.Request.Context(), ownerScopeID(c), repositoryID, source, query, limit, offset)
+	summaries, err := h.assetService.ListTagSummaries(c.Request.Context(), ownerScopeID(c), repositoryID, source, category, query, limit, offset)
 	if err != nil {
 		log.Printf("Failed to list tag summaries: %v", err)
 		api.GinInternalError(c, err, "Failed to list tag summaries")
@@ -3297,6 +4732,7 @@ func (h *AssetHandler) GetTagSummaries(c *gin.Context) {
 		items[i] = dto.TagSummaryDTO{
 			TagID:        summary.TagID,
 			TagName:      summary.TagName,
+			Category:     summary.Category,
 			Source:       summary.Source,
 			AssetCount:   summary.AssetCount,
 			CoverAssetID: summary.CoverAssetID,
@@ -3521,6 +4957,156 @@ func (h *AssetHandler) GetLikedAssets(c *gin.Context) {
 	api.JSONOK(c, response)
 }
 
+// ListUnalbumedAssets lists assets that are in no album
+// @Summary List assets without an album
+// @Description List assets that belong to no album, newest upload first, to help organize a library. Non-admins only see their own assets; admins may pass owner_id to look at one user.
+// @Tags assets
+// @Produce json
+// @Param owner_id query int false "Owner user ID (admins only; others may only pass their own ID)"
+// @Param repository_id query string false "Optional repository UUID filter"
+// @Param limit query int false "Number of assets to return" default(50)
+// @Param offset query int false "Number of assets to skip" default(0)
+// @Success 200 {object} dto.AssetListResponseDTO "Assets without an album"
+// @Failure 400 {object} api.ErrorResponse "Invalid request parameters"
+// @Failure 401 {object} api.ErrorResponse "Unauthorized"
+// @Failure 403 {object} api.ErrorResponse "Forbidden"
+// @Failure 500 {object} api.ErrorResponse "Internal server error"
+// @Router /api/v1/assets/unalbumed [get]
+func (h *AssetHandler) ListUnalbumedAssets(c *gin.Context) {
+	user, ok := requireCurrentUser(c)
+	if !ok {
+		return
+	}
+	ownerID := ownerScopeID(c)
+	if rawOwnerID := strings.TrimSpace(c.Query("owner_id")); rawOwnerID != "" {
+		parsed, err := strconv.ParseInt(rawOwnerID, 10, 32)
+		if err != nil || parsed <= 0 {
+			api.GinBadRequest(c, err, "Invalid owner_id parameter")
+			return
+		}
+		requested := int32(parsed)
+		if ownerID != nil && *ownerID != requested {
+			api.GinForbidden(c, fmt.Errorf("user %d requested assets of user %d", user.UserID, requested), "You can only list your own assets")
+			return
+		}
+		ownerID = &requested
+	}
+
+	var repositoryID *string
+	if rawRepoID := strings.TrimSpace(c.Query("repository_id")); rawRepoID != "" {
+		if _, err := uuid.Parse(rawRepoID); err != nil {
+			api.GinBadRequest(c, err, "Invalid repository_id parameter")
+			return
+		}
+		repositoryID = &rawRepoID
+	}
+
+	limit, err := parseIntQueryWithRange(c, "limit", 50, 1, 500)
+	if err != nil {
+		api.GinBadRequest(c, err, "Invalid limit parameter")
+		return
+	}
+	offset, err := parseIntQueryWithRange(c, "offset", 0, 0, 10000000)
+	if err != nil {
+		api.GinBadRequest(c, err, "Invalid offset parameter")
+		return
+	}
+
+	assets, err := h.assetService.ListUnalbumedAssets(c.Request.Context(), ownerID, repositoryID, limit, offset)
+	if err != nil {
+		log.Printf("Failed to list unalbumed assets: %v", err)
+		api.GinInternalError(c, err, "Failed to list assets without an album")
+		return
+	}
+
+	assetDTOs := make([]dto.AssetDTO, len(assets))
+	for i, asset := range assets {
+		assetDTOs[i] = dto.ToAssetDTO(asset)
+	}
+
+	api.JSONOK(c, dto.AssetListResponseDTO{
+		Assets: assetDTOs,
+		Limit:  limit,
+		Offset: offset,
+	})
+}
+
+// maxAroundTimeWindow bounds the window of ListAssetsAroundTime.
+const maxAroundTimeWindow = 30 * 24 * time.Hour
+
+// ListAssetsAroundTime lists assets taken near a given instant
+// @Summary List assets taken around a time
+// @Description List assets whose capture time lies within window before or after at, closest first, to match photos to an external event. Assets without a capture time are never returned. Non-admins only see their own assets; admins may pass owner_id to look at one user.
+// @Tags assets
+// @Produce json
+// @Param at query string true "Instant to search around (RFC 3339)" example(2024-06-15T15:00:00Z)
+// @Param window query string false "Maximum distance from at, as a Go duration of at most 720h" default(1h)
+// @Param owner_id query int false "Owner user ID (admins only; others may only pass their own ID)"
+// @Param limit query int false "Number of assets to return" default(50)
+// @Success 200 {object} dto.AssetListResponseDTO "Assets closest to at first"
+// @Failure 400 {object} api.ErrorResponse "Invalid request parameters"
+// @Failure 401 {object} api.ErrorResponse "Unauthorized"
+// @Failure 403 {object} api.ErrorResponse "Forbidden"
+// @Failure 500 {object} api.ErrorResponse "Internal server error"
+// @Router /api/v1/assets/around-time [get]
+func (h *AssetHandler) ListAssetsAroundTime(c *gin.Context) {
+	user, ok := requireCurrentUser(c)
+	if !ok {
+		return
+	}
+	ownerID := ownerScopeID(c)
+	if rawOwnerID := strings.TrimSpace(c.Query("owner_id")); rawOwnerID != "" {
+		parsed, err := strconv.ParseInt(rawOwnerID, 10, 32)
+		if err != nil || parsed <= 0 {
+			api.GinBadRequest(c, err, "Invalid owner_id parameter")
+			return
+		}
+		requested := int32(parsed)
+		if ownerID != nil && *ownerID != requested {
+			api.GinForbidden(c, fmt.Errorf("user %d requested assets of user %d", user.UserID, requested), "You can only list your own assets")
+			return
+		}
+		ownerID = &requested
+	}
+
+	at, err := time.Parse(time.RFC3339, strings.TrimSpace(c.Query("at")))
+	if err != nil {
+		api.GinBadRequest(c, err, "at must be an RFC 3339 timestamp")
+		return
+	}
+	window := time.Hour
+	if rawWindow := strings.TrimSpace(c.Query("window")); rawWindow != "" {
+		window, err = time.ParseDuration(rawWindow)
+		if err != nil || window <= 0 || window > maxAroundTimeWindow {
+			api.GinBadRequest(c, err, "window must be a positive duration of at most 720h")
+			return
+		}
+	}
+
+	limit, err := parseIntQueryWithRange(c, "limit", 50, 1, 500)
+	if err != nil {
+		api.GinBadRequest(c, err, "Invalid limit parameter")
+		return
+	}
+
+	assets, err := h.assetService.ListAssetsAroundTime(c.Request.Context(), at, window, ownerID, limit)
+	if err != nil {
+		log.Printf("Failed to list assets around %s: %v", at.Format(time.RFC3339), err)
+		api.GinInternalError(c, err, "Failed to list assets around the given time")
+		return
+	}
+
+	assetDTOs := make([]dto.AssetDTO, len(assets))
+	for i, asset := range assets {
+		assetDTOs[i] = dto.ToAssetDTO(asset)
+	}
+
+	api.JSONOK(c, dto.AssetListResponseDTO{
+		Assets: assetDTOs,
+		Limit:  limit,
+	})
+}
+
 // Helper methods for unified chunk upload
 
 // cleanupExpiredSessions periodically cleans up expired upload sessions
@@ -3836,7 +5422,7 @@ func (h *AssetHandler) processSingleFileSession(ctx context.Context, header *mul
 	h.sessionManager.UpdateSessionStatus(session.SessionID, "uploading")
 
 	// Process the single file
-	return h.processCompletedUpload(ctx, header, session, repository, "")
+	return h.processCompletedUpload(ctx, header, session, repository, "", nil)
 }
 
 // processChunkedFileSession processes a chunked file upload session
@@ -3970,7 +5556,7 @@ func (h *AssetHandler) processChunkedFileSession(ctx context.Context, sessionID
 
 	// Process the merged file
 	log.Printf("Starting processCompletedUpload for merged file: %s", mergeResult.MergedFilePath)
-	result, processErr := h.processCompletedUpload(ctx, mergedHeader, session, repository, mergeResult.MergedFilePath)
+	result, processErr := h.processCompletedUpload(ctx, mergedHeader, session, repository, mergeResult.MergedFilePath, nil)
 
 	// Cleanup chunk files regardless of processing result
 	h.chunkMerger.CleanupChunks(sessionID)
@@ -3989,7 +5575,7 @@ func (h *AssetHandler) processChunkedFileSession(ctx context.Context, sessionID
 }
 
 // processCompletedUpload processes a completed upload (single file or merged chunks)
-func (h *AssetHandler) processCompletedUpload(ctx context.Context, header *multipart.FileHeader, session *upload.UploadSession, repository repo.Repository, mergedFilePath string) (*dto.BatchUploadResultDTO, error) {
+func (h *AssetHandler) processCompletedUpload(ctx context.Context, header *multipart.FileHeader, session *upload.UploadSession, repository repo.Repository, mergedFilePath string, stagedHash *hash.LayeredHashResult) (*dto.BatchUploadResultDTO, error) {
 	var stagingFilePath string
 	log.Printf("processCompletedUpload: mergedFilePath=%s, filename=%s", mergedFilePath, header.Filename)
 
@@ -4019,23 +5605,44 @@ func (h *AssetHandler) processCompletedUpload(ctx context.Context, header *multi
 		}
 		defer osFile.Close()
 
-		_, err = io.Copy(osFile, file)
+		hasher := hash.NewLayeredWriter()
+		_, err = io.Copy(io.MultiWriter(osFile, hasher), file)
 		if err != nil {
 			h.handleUploadFailureFile(repository.Path, stagingFilePath, header.Filename, "copy completed upload to staging")
 			return nil, fmt.Errorf("failed to copy file to staging: %w", err)
 		}
+		stagedHash = hasher.Result()
 	}
 
-	log.Printf("Calculating authoritative hash for file: %s", stagingFilePath)
-	hashResult, err := hash.CalculateLayeredBLAKE3(stagingFilePath)
-	if err != nil {
-		log.Printf("Failed to calculate hash for %s: %v", stagingFilePath, err)
-		h.handleUploadFailureFile(repository.Path, stagingFilePath, header.Filename, "calculate completed upload hash")
-		return nil, fmt.Errorf("failed to calculate file hash: %w", err)
+	hashResult := stagedHash
+	if hashResult == nil {
+		log.Printf("Calculating authoritative hash for file: %s", stagingFilePath)
+		calculated, err := hash.CalculateLayeredBLAKE3(stagingFilePath)
+		if err != nil {
+			log.Printf("Failed to calculate hash for %s: %v", stagingFilePath, err)
+			h.handleUploadFailureFile(repository.Path, stagingFilePath, header.Filename, "calculate completed upload hash")
+			return nil, fmt.Errorf("failed to calculate file hash: %w", err)
+		}
+		hashResult = calculated
 	}
 	finalHash := hashResult.ContentHash
 	log.Printf("Calculated full content hash for %s: %s", header.Filename, finalHash)
 
+	if h.belowMinFileSize(hashResult.FileSize) {
+		log.Printf("Upload skipped: %s is %d bytes, below minimum of %d", header.Filename, hashResult.FileSize, h.minFileSize)
+		h.removeUploadTempFile(stagingFilePath)
+		size := hashResult.FileSize
+		status := uploadStatusSkipped
+		message := "File is smaller than the configured minimum size"
+		return &dto.BatchUploadResultDTO{
+			Success:  true,
+			FileName: header.Filename,
+			Status:   &status,
+			Size:     &size,
+			Message:  &message,
+		}, nil
+	}
+
 	validationResult := filevalidator.ValidateFile(header.Filename, session.ContentType)
 	if !validationResult.Valid {
 		h.handleUploadFailureFile(repository.Path, stagingFilePath, header.Filename, "validate completed upload")
@@ -4068,6 +5675,32 @@ func (h *AssetHandler) processCompletedUpload(ctx context.Context, header *multi
 		}, nil
 	}
 
+	duplicateAction, err := h.planUploadDuplicateAction(repository.Path, stagingFilePath, session.Filename, finalHash, nil)
+	if err != nil {
+		h.handleUploadFailureFile(repository.Path, stagingFilePath, header.Filename, "plan inbox commit")
+		return nil, err
+	}
+	var duplicateActionValue *string
+	if duplicateAction != storage.DuplicateActionNone {
+		value := string(duplicateAction)
+		duplicateActionValue = &value
+	}
+	if duplicateAction == storage.DuplicateActionSkipped {
+		h.removeUploadTempFile(stagingFilePath)
+		size := header.Size
+		status := uploadStatusSkipped
+		message := duplicateNameSkippedMessage
+		return &dto.BatchUploadResultDTO{
+			Success:         true,
+			FileName:        header.Filename,
+			ContentHash:     finalHash,
+			Status:          &status,
+			Size:            &size,
+			Message:         &message,
+			DuplicateAction: duplicateActionValue,
+		}, nil
+	}
+
 	// Enqueue for processing
 	log.Printf("Enqueuing processing job for file: %s (hash: %s)", stagingFilePath, finalHash)
 	jobResult, err := h.queueClient.Insert(ctx, jobs.IngestAssetArgs{
@@ -4100,13 +5733,14 @@ func (h *AssetHandler) processCompletedUpload(ctx context.Context, header *multi
 	log.Printf("Task %d enqueued for processing file %s in repository %s (staged path: %s)", taskID, header.Filename, repository.Name, stagingFilePath)
 
 	return &dto.BatchUploadResultDTO{
-		Success:     true,
-		FileName:    header.Filename,
-		ContentHash: finalHash,
-		TaskID:      &taskID,
-		Status:      &status,
-		Size:        &size,
-		Message:     &message,
+		Success:         true,
+		FileName:        header.Filename,
+		ContentHash:     finalHash,
+		TaskID:          &taskID,
+		Status:          &status,
+		Size:            &size,
+		Message:         &message,
+		DuplicateAction: duplicateActionValue,
 	}, nil
 }
 
@@ -4143,6 +5777,132 @@ func (h *AssetHandler) findDuplicateByHash(ctx context.Context, contentHash stri
 	return nil, nil
 }
 
+// RefreshAssetMetadata re-extracts metadata for a single asset
+// @Summary Refresh asset metadata
+// @Description Re-run EXIF/ffprobe extraction against the stored original and update specific_metadata and taken_time in place. Rating, like state and description are preserved.
+// @Tags assets
+// @Produce json
+// @Param id path string true "Asset ID"
+// @Success 200 {object} dto.AssetDTO "Asset with refreshed metadata"
+// @Failure 400 {object} api.ErrorResponse "Invalid asset ID"
+// @Failure 404 {object} api.ErrorResponse "Asset or its original file not found"
+// @Failure 500 {object} api.ErrorResponse "Internal server error"
+// @Router /api/v1/assets/{id}/refresh-metadata [post]
+func (h *AssetHandler) RefreshAssetMetadata(c *gin.Context) {
+	assetID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		api.GinBadRequest(c, err, "Invalid asset ID")
+		return
+	}
+
+	asset, ok := h.getAuthorizedAsset(c, assetID, "Authentication required to refresh this asset", "You don't have permission to refresh this asset")
+	if !ok {
+		return
+	}
+	if h.metadataRefresher == nil {
+		api.GinInternalError(c, errors.New("metadata refresher not configured"), "Metadata refresh is unavailable")
+		return
+	}
+
+	refreshed, err := h.metadataRefresher.RefreshAssetMetadata(c.Request.Context(), asset.AssetID)
+	if err != nil {
+		if errors.Is(err, processors.ErrOriginalMissing) {
+			api.GinNotFound(c, err, "Asset original file not found")
+			return
+		}
+		api.GinInternalError(c, err, "Failed to refresh asset metadata")
+		return
+	}
+
+	api.JSONOK(c, dto.ToAssetDTO(*refreshed))
+}
+
+// RotateAsset rotates a photo's stored original clockwise
+// @Summary Rotate asset
+// @Description Rotate a photo's original clockwise by 90, 180 or 270 degrees. JPEG and TIFF originals are rotated losslessly through their EXIF orientation; PNG, WebP and AVIF are re-encoded. The previous original is kept in the repository trash and thumbnails are regenerated in the background.
+// @Tags assets
+// @Produce json
+// @Param id path string true "Asset ID"
+// @Param degrees query int true "Clockwise rotation" Enums(90, 180, 270)
+// @Success 200 {object} dto.AssetDTO "Asset with updated dimensions"
+// @Failure 400 {object} api.ErrorResponse "Invalid asset ID, rotation, or unsupported format"
+// @Failure 404 {object} api.ErrorResponse "Asset or its original file not found"
+// @Failure 500 {object} api.ErrorResponse "Internal server error"
+// @Router /api/v1/assets/{id}/rotate [post]
+func (h *AssetHandler) RotateAsset(c *gin.Context) {
+	var transform exif.Transform
+	switch c.Query("degrees") {
+	case "90":
+		transform = exif.TransformRotate90
+	case "180":
+		transform = exif.TransformRotate180
+	case "270":
+		transform = exif.TransformRotate270
+	default:
+		api.GinBadRequest(c, errors.New("degrees must be 90, 180 or 270"), "Invalid rotation")
+		return
+	}
+	h.transformAsset(c, transform)
+}
+
+// FlipAsset mirrors a photo's stored original
+// @Summary Flip asset
+// @Description Mirror a photo's original horizontally or vertically. JPEG and TIFF originals are flipped losslessly through their EXIF orientation; PNG, WebP and AVIF are re-encoded. The previous original is kept in the repository trash and thumbnails are regenerated in the background.
+// @Tags assets
+// @Produce json
+// @Param id path string true "Asset ID"
+// @Param direction query string true "Flip direction" Enums(horizontal, vertical)
+// @Success 200 {object} dto.AssetDTO "Flipped asset"
+// @Failure 400 {object} api.ErrorResponse "Invalid asset ID, direction, or unsupported format"
+// @Failure 404 {object} api.ErrorResponse "Asset or its original file not found"
+// @Failure 500 {object} api.ErrorResponse "Internal server error"
+// @Router /api/v1/assets/{id}/flip [post]
+func (h *AssetHandler) FlipAsset(c *gin.Context) {
+	var transform exif.Transform
+	switch c.Query("direction") {
+	case "horizontal":
+		transform = exif.TransformFlipHorizontal
+	case "vertical":
+		transform = exif.TransformFlipVertical
+	default:
+		api.GinBadRequest(c, errors.New("direction must be horizontal or vertical"), "Invalid flip direction")
+		return
+	}
+	h.transformAsset(c, transform)
+}
+
+func (h *AssetHandler) transformAsset(c *gin.Context, transform exif.Transform) {
+	assetID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		api.GinBadRequest(c, err, "Invalid asset ID")
+		return
+	}
+
+	asset, ok := h.getAuthorizedAsset(c, assetID, "Authentication required to edit this asset", "You don't have permission to edit this asset")
+	if !ok {
+		return
+	}
+	if h.assetTransformer == nil {
+		api.GinInternalError(c, errors.New("asset transformer not configured"), "Rotate and flip are unavailable")
+		return
+	}
+
+	transformed, err := h.assetTransformer.TransformAsset(c.Request.Context(), asset.AssetID, transform)
+	if err != nil {
+		switch {
+		case errors.Is(err, processors.ErrOriginalMissing):
+			api.GinNotFound(c, err, "Asset original file not found")
+		case errors.Is(err, processors.ErrTransformUnsupported):
+			api.GinBadRequest(c, err, "This asset cannot be rotated or flipped")
+		default:
+			api.GinInternalError(c, err, "Failed to edit asset")
+		}
+		return
+	}
+
+	api.JSONOK(c, dto.ToAssetDTO(*transformed))
+}
+
 // ReprocessAsset reprocesses a failed or warning asset
 // @Summary Reprocess asset
 // @Description Reprocess a failed or warning asset by resetting its status and re-enqueuing for processing
@@ -4273,6 +6033,7 @@ func (h *AssetHandler) ReprocessAsset(c *gin.Context) {
 			OriginalFilename: updatedAsset.OriginalFilename,
 			FileSize:         updatedAsset.FileSize,
 			MimeType:         updatedAsset.MimeType,
+			Force:            true,
 		}
 		if _, err := h.queueClient.Insert(ctx, metaArgs, &river.InsertOpts{Queue: "metadata_asset"}); err != nil {
 			api.GinInternalError(c, err, "Failed to enqueue metadata job")
@@ -4286,6 +6047,7 @@ func (h *AssetHandler) ReprocessAsset(c *gin.Context) {
 				RepoPath:    repository.Path,
 				StoragePath: storagePath,
 				AssetType:   assetType,
+				Force:       true,
 			}, &river.InsertOpts{Queue: "thumbnail_asset"}); err != nil {
 				api.GinInternalError(c, err, "Failed to enqueue thumbnail job")
 				return
@@ -4296,6 +6058,7 @@ func (h *AssetHandler) ReprocessAsset(c *gin.Context) {
 				RepoPath:    repository.Path,
 				StoragePath: storagePath,
 				AssetType:   assetType,
+				Force:       true,
 			}, &river.InsertOpts{Queue: "thumbnail_asset"}); err != nil {
 				api.GinInternalError(c, err, "Failed to enqueue thumbnail job")
 				return
@@ -4305,6 +6068,7 @@ func (h *AssetHandler) ReprocessAsset(c *gin.Context) {
 				RepoPath:    repository.Path,
 				StoragePath: storagePath,
 				AssetType:   assetType,
+				Force:       true,
 			}, &river.InsertOpts{Queue: "transcode_asset"}); err != nil {
 				api.GinInternalError(c, err, "Failed to enqueue transcode job")
 				return
@@ -4315,6 +6079,7 @@ func (h *AssetHandler) ReprocessAsset(c *gin.Context) {
 				RepoPath:    repository.Path,
 				StoragePath: storagePath,
 				AssetType:   assetType,
+				Force:       true,
 			}, &river.InsertOpts{Queue: "transcode_asset"}); err != nil {
 				api.GinInternalError(c, err, "Failed to enqueue transcode job")
 				return
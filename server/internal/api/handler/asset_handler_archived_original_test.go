@@ -0,0 +1,110 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"server/internal/db/repo"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+// archivedOriginalsDB answers GetArchivedOriginal from archived (asset ID to
+// archive path) and everything else like repositoriesDB.
+type archivedOriginalsDB struct {
+	repositoriesDB
+	archived map[pgtype.UUID]string
+}
+
+func (db archivedOriginalsDB) QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+	if strings.Contains(sql, "FROM archived_originals") {
+		id := args[0].(pgtype.UUID)
+		path, ok := db.archived[id]
+		return archivedOriginalRow{id: id, path: path, found: ok}
+	}
+	return db.repositoriesDB.QueryRow(ctx, sql, args...)
+}
+
+type archivedOriginalRow struct {
+	id    pgtype.UUID
+	path  string
+	found bool
+}
+
+func (r archivedOriginalRow) Scan(dest ...any) error {
+	if !r.found {
+		return pgx.ErrNoRows
+	}
+	*dest[0].(*pgtype.UUID) = r.id
+	*dest[1].(*string) = r.path
+	return nil
+}
+
+type thumbnailMediaAssetService struct {
+	stubMediaAssetService
+	thumbnails map[string]string // size -> storage path
+}
+
+func (s thumbnailMediaAssetService) GetThumbnailByAssetIDAndSize(_ context.Context, _ uuid.UUID, size string) (*repo.Thumbnail, error) {
+	path, ok := s.thumbnails[size]
+	if !ok {
+		return nil, pgx.ErrNoRows
+	}
+	return &repo.Thumbnail{AssetID: s.asset.AssetID, Size: size, StoragePath: path}, nil
+}
+
+func TestArchivedOriginalIsServedFromArchiveAndDerivativesFromRepository(t *testing.T) {
+	assetID := "bbbbbbbb-bbbb-bbbb-bbbb-bbbbbbbbbbbb"
+	repositoryID := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+	root, archiveRoot := t.TempDir(), t.TempDir()
+
+	storagePath := "2024/photo.jpg"
+	asset := testHandlerAsset(t, assetID, "photo.jpg")
+	asset.StoragePath = &storagePath
+	asset.RepositoryID = repositoryID
+
+	write := func(path, content string) {
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	}
+	thumbnailPath := ".lumilio/assets/thumbnails/medium/photo.webp"
+	write(filepath.Join(root, filepath.FromSlash(thumbnailPath)), "medium thumbnail")
+	archivePath := filepath.Join(archiveRoot, "2024", "photo.jpg")
+	write(archivePath, "archived original")
+
+	db := archivedOriginalsDB{
+		repositoriesDB: repositoriesDB{roots: map[pgtype.UUID]string{repositoryID: root}},
+		archived:       map[pgtype.UUID]string{asset.AssetID: archivePath},
+	}
+	h := &AssetHandler{
+		assetService: thumbnailMediaAssetService{
+			stubMediaAssetService: stubMediaAssetService{asset: asset},
+			thumbnails:            map[string]string{"medium": thumbnailPath},
+		},
+		queries: repo.New(db),
+	}
+
+	// The original is no longer in the repository; it comes from the archive.
+	recorder := serveMediaForTest(t, h, assetID, (*AssetHandler).GetOriginalFile)
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+	require.Equal(t, "archived original", recorder.Body.String())
+	require.Equal(t, "archive", recorder.Header().Get("X-Lumilio-Original-Location"))
+
+	recorder = serveMediaForTest(t, h, assetID, (*AssetHandler).GetAssetThumbnail)
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+	require.Equal(t, "medium thumbnail", recorder.Body.String())
+
+	// While the original is still in the repository it is served from there.
+	write(filepath.Join(root, filepath.FromSlash(storagePath)), "local original")
+	recorder = serveMediaForTest(t, h, assetID, (*AssetHandler).GetOriginalFile)
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+	require.Equal(t, "local original", recorder.Body.String())
+	require.Empty(t, recorder.Header().Get("X-Lumilio-Original-Location"))
+}
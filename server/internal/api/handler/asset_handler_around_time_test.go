@@ -0,0 +1,110 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"server/internal/api/dto"
+	"server/internal/db/repo"
+	"server/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+type stubAroundTimeAssetService struct {
+	service.AssetService
+	assets  []repo.Asset
+	calls   int
+	at      time.Time
+	window  time.Duration
+	ownerID *int32
+	limit   int
+}
+
+func (s *stubAroundTimeAssetService) ListAssetsAroundTime(_ context.Context, at time.Time, window time.Duration, ownerID *int32, limit int) ([]repo.Asset, error) {
+	s.calls++
+	s.at = at
+	s.window = window
+	s.ownerID = ownerID
+	s.limit = limit
+	return s.assets, nil
+}
+
+func listAroundTimeForTest(t *testing.T, svc *stubAroundTimeAssetService, user *service.UserResponse, query string) *httptest.ResponseRecorder {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Request = httptest.NewRequest(http.MethodGet, "/api/v1/assets/around-time?"+query, nil)
+	if user != nil {
+		ctx.Set("current_user", user)
+	}
+
+	(&AssetHandler{assetService: svc}).ListAssetsAroundTime(ctx)
+	return recorder
+}
+
+func TestListAssetsAroundTime_ParsesInstantAndWindow(t *testing.T) {
+	svc := &stubAroundTimeAssetService{assets: []repo.Asset{testHandlerAsset(t, "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa", "party.jpg")}}
+	user := &service.UserResponse{UserID: 7, Role: "user"}
+
+	recorder := listAroundTimeForTest(t, svc, user, "at=2024-06-15T15:00:00%2B02:00&window=30m&limit=5")
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+	require.True(t, svc.at.Equal(time.Date(2024, 6, 15, 13, 0, 0, 0, time.UTC)))
+	require.Equal(t, 30*time.Minute, svc.window)
+	require.Equal(t, 5, svc.limit)
+	require.NotNil(t, svc.ownerID)
+	require.Equal(t, int32(7), *svc.ownerID)
+
+	var response dto.AssetListResponseDTO
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	require.Len(t, response.Assets, 1)
+	require.Equal(t, "party.jpg", response.Assets[0].OriginalFilename)
+
+	recorder = listAroundTimeForTest(t, svc, user, "at=2024-06-15T15:00:00Z")
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+	require.Equal(t, time.Hour, svc.window)
+	require.Equal(t, 50, svc.limit)
+}
+
+func TestListAssetsAroundTime_ScopesOwner(t *testing.T) {
+	svc := &stubAroundTimeAssetService{}
+	recorder := listAroundTimeForTest(t, svc, &service.UserResponse{UserID: 7, Role: "user"}, "at=2024-06-15T15:00:00Z&owner_id=8")
+	require.Equal(t, http.StatusForbidden, recorder.Code)
+	require.Zero(t, svc.calls)
+
+	recorder = listAroundTimeForTest(t, svc, &service.UserResponse{UserID: 1, Role: "admin"}, "at=2024-06-15T15:00:00Z")
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+	require.Nil(t, svc.ownerID, "admins see every owner by default")
+
+	recorder = listAroundTimeForTest(t, svc, &service.UserResponse{UserID: 1, Role: "admin"}, "at=2024-06-15T15:00:00Z&owner_id=8")
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+	require.Equal(t, int32(8), *svc.ownerID)
+}
+
+func TestListAssetsAroundTime_RejectsInvalidParameters(t *testing.T) {
+	user := &service.UserResponse{UserID: 7, Role: "user"}
+	for _, query := range []string{
+		"",
+		"at=yesterday",
+		"at=2024-06-15T15:00:00Z&window=1d",
+		"at=2024-06-15T15:00:00Z&window=-1h",
+		"at=2024-06-15T15:00:00Z&window=721h",
+		"at=2024-06-15T15:00:00Z&owner_id=abc",
+		"at=2024-06-15T15:00:00Z&limit=0",
+	} {
+		svc := &stubAroundTimeAssetService{}
+		recorder := listAroundTimeForTest(t, svc, user, query)
+		require.Equal(t, http.StatusBadRequest, recorder.Code, query)
+		require.Zero(t, svc.calls, query)
+	}
+
+	recorder := listAroundTimeForTest(t, &stubAroundTimeAssetService{}, nil, "at=2024-06-15T15:00:00Z")
+	require.Equal(t, http.StatusUnauthorized, recorder.Code)
+}
@@ -0,0 +1,187 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"server/internal/api/dto"
+	"server/internal/db/repo"
+	"server/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+type stubBatchDeleteAssetService struct {
+	service.AssetService
+	assets      map[uuid.UUID]repo.Asset
+	failDelete  map[uuid.UUID]bool
+	deleted     []uuid.UUID
+	hardDeleted map[uuid.UUID]string
+}
+
+func (s *stubBatchDeleteAssetService) GetAsset(_ context.Context, id uuid.UUID) (*repo.Asset, error) {
+	asset, ok := s.assets[id]
+	if !ok {
+		return nil, pgx.ErrNoRows
+	}
+	return &asset, nil
+}
+
+func (s *stubBatchDeleteAssetService) GetAssetAny(ctx context.Context, id uuid.UUID) (*repo.Asset, error) {
+	return s.GetAsset(ctx, id)
+}
+
+func (s *stubBatchDeleteAssetService) DeleteAsset(_ context.Context, id uuid.UUID) error {
+	if s.failDelete[id] {
+		return errors.New("database unavailable")
+	}
+	s.deleted = append(s.deleted, id)
+	return nil
+}
+
+func (s *stubBatchDeleteAssetService) HardDeleteAsset(_ context.Context, id uuid.UUID, repoPath string, _ *string) error {
+	s.hardDeleted[id] = repoPath
+	return nil
+}
+
+func batchDeleteForTest(t *testing.T, h *AssetHandler, req dto.BatchDeleteAssetsRequestDTO) (*httptest.ResponseRecorder, dto.BatchDeleteResponseDTO) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	body, err := json.Marshal(req)
+	require.NoError(t, err)
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Request = httptest.NewRequest(http.MethodPost, "/api/v1/assets/batch-delete", bytes.NewReader(body))
+	ctx.Request.Header.Set("Content-Type", "application/json")
+	ctx.Set("current_user", &service.UserResponse{UserID: 7, Role: "user"})
+
+	h.BatchDeleteAssets(ctx)
+
+	var response dto.BatchDeleteResponseDTO
+	if recorder.Code == http.StatusOK {
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	}
+	return recorder, response
+}
+
+func TestBatchDeleteAssets_ReportsEachAsset(t *testing.T) {
+	owned := testHandlerAsset(t, "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa", "a.jpg")
+	failing := testHandlerAsset(t, "bbbbbbbb-bbbb-bbbb-bbbb-bbbbbbbbbbbb", "b.jpg")
+	otherUsers := testHandlerAsset(t, "cccccccc-cccc-cccc-cccc-cccccccccccc", "c.jpg")
+	otherOwner := int32(8)
+	otherUsers.OwnerID = &otherOwner
+	last := testHandlerAsset(t, "dddddddd-dddd-dddd-dddd-dddddddddddd", "d.jpg")
+
+	svc := &stubBatchDeleteAssetService{
+		assets: map[uuid.UUID]repo.Asset{
+			owned.AssetID.Bytes:      owned,
+			failing.AssetID.Bytes:    failing,
+			otherUsers.AssetID.Bytes: otherUsers,
+			last.AssetID.Bytes:       last,
+		},
+		failDelete: map[uuid.UUID]bool{failing.AssetID.Bytes: true},
+	}
+
+	recorder, response := batchDeleteForTest(t, &AssetHandler{assetService: svc}, dto.BatchDeleteAssetsRequestDTO{
+		AssetIDs: []string{
+			owned.AssetID.String(),
+			"not-a-uuid",
+			failing.AssetID.String(),
+			"eeeeeeee-eeee-eeee-eeee-eeeeeeeeeeee",
+			otherUsers.AssetID.String(),
+			last.AssetID.String(),
+		},
+	})
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+	require.Len(t, response.Results, 6)
+
+	succeeded := map[string]bool{}
+	for _, result := range response.Results {
+		succeeded[result.AssetID] = result.Success
+		if result.Success {
+			require.Nil(t, result.Error)
+		} else {
+			require.NotNil(t, result.Error, result.AssetID)
+		}
+	}
+	require.Equal(t, map[string]bool{
+		owned.AssetID.String():                 true,
+		"not-a-uuid":                           false,
+		failing.AssetID.String():               false,
+		"eeeeeeee-eeee-eeee-eeee-eeeeeeeeeeee": false,
+		otherUsers.AssetID.String():            false,
+		last.AssetID.String():                  true,
+	}, succeeded)
+	require.Equal(t, "invalid asset ID", *response.Results[1].Error)
+	require.Equal(t, "asset not found", *response.Results[3].Error)
+	// The service's own error is logged, not returned.
+	require.Equal(t, "failed to delete asset", *response.Results[2].Error)
+	require.Equal(t, "you don't have permission to delete this asset", *response.Results[4].Error)
+	require.Equal(t, []uuid.UUID{owned.AssetID.Bytes, last.AssetID.Bytes}, svc.deleted)
+}
+
+func TestBatchDeleteAssets_HardDeleteUsesRepositoryPath(t *testing.T) {
+	asset := testHandlerAsset(t, "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa", "a.jpg")
+	asset.RepositoryID = pgtype.UUID{Bytes: uuid.New(), Valid: true}
+	ownerID := int32(7)
+	asset.OwnerID = &ownerID
+	svc := &stubBatchDeleteAssetService{
+		assets:      map[uuid.UUID]repo.Asset{asset.AssetID.Bytes: asset},
+		hardDeleted: map[uuid.UUID]string{},
+	}
+	h := &AssetHandler{
+		assetService: svc,
+		queries:      repo.New(primaryRepositoryDB{path: "/photos/library"}),
+	}
+
+	recorder, response := batchDeleteForTest(t, h, dto.BatchDeleteAssetsRequestDTO{
+		AssetIDs: []string{asset.AssetID.String()},
+		Hard:     true,
+	})
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+	require.Len(t, response.Results, 1)
+	require.True(t, response.Results[0].Success)
+	require.Equal(t, "/photos/library", svc.hardDeleted[asset.AssetID.Bytes])
+	require.Empty(t, svc.deleted)
+}
+
+func TestBatchDeleteAssets_HardDeleteOfUnownedAssetNeedsAdmin(t *testing.T) {
+	asset := testHandlerAsset(t, "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa", "a.jpg")
+	svc := &stubBatchDeleteAssetService{
+		assets:      map[uuid.UUID]repo.Asset{asset.AssetID.Bytes: asset},
+		hardDeleted: map[uuid.UUID]string{},
+	}
+
+	recorder, response := batchDeleteForTest(t, &AssetHandler{assetService: svc}, dto.BatchDeleteAssetsRequestDTO{
+		AssetIDs: []string{asset.AssetID.String()},
+		Hard:     true,
+	})
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+	require.Len(t, response.Results, 1)
+	require.False(t, response.Results[0].Success)
+	require.Empty(t, svc.hardDeleted)
+}
+
+func TestBatchDeleteAssets_RejectsEmptyList(t *testing.T) {
+	recorder, _ := batchDeleteForTest(t, &AssetHandler{}, dto.BatchDeleteAssetsRequestDTO{AssetIDs: []string{}})
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+}
+
+func TestBatchDeleteAssets_RejectsOversizedList(t *testing.T) {
+	ids := make([]string, 101)
+	for i := range ids {
+		ids[i] = uuid.NewString()
+	}
+	recorder, _ := batchDeleteForTest(t, &AssetHandler{}, dto.BatchDeleteAssetsRequestDTO{AssetIDs: ids})
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+}
@@ -0,0 +1,153 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"server/internal/api/dto"
+	"server/internal/db/repo"
+	"server/internal/storage"
+	"server/internal/utils/upload"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/require"
+)
+
+// primaryRepositoryDB answers GetPrimaryRepository with a repository rooted at
+// path; any other query panics through the nil embedded DBTX.
+type primaryRepositoryDB struct {
+	repo.DBTX
+	path string
+}
+
+func (db primaryRepositoryDB) QueryRow(context.Context, string, ...interface{}) pgx.Row {
+	return primaryRepositoryRow{path: db.path}
+}
+
+type primaryRepositoryRow struct{ path string }
+
+func (r primaryRepositoryRow) Scan(dest ...any) error {
+	// Column order follows repo.Repository: repo_id, name, path, ...
+	*dest[2].(*string) = r.path
+	return nil
+}
+
+func newBatchLimitTestHandler(t *testing.T, maxBatchFiles int) (*AssetHandler, string) {
+	t.Helper()
+	repoPath := t.TempDir()
+	return &AssetHandler{
+		queries:        repo.New(primaryRepositoryDB{path: repoPath}),
+		stagingManager: storage.NewStagingManager(),
+		sessionManager: upload.NewSessionManager(time.Minute),
+		uploadLimiter:  make(chan struct{}, 1),
+		maxBatchFiles:  maxBatchFiles,
+	}, repoPath
+}
+
+func batchUploadRequest(t *testing.T, sessionIDs []string) *http.Request {
+	t.Helper()
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	for _, sessionID := range sessionIDs {
+		part, err := writer.CreateFormFile("single_"+sessionID, sessionID+".jpg")
+		require.NoError(t, err)
+		_, err = part.Write([]byte("jpeg bytes"))
+		require.NoError(t, err)
+	}
+	require.NoError(t, writer.Close())
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/assets/batch", &body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	return req
+}
+
+func TestBatchUploadAssets_RejectsBatchOverLimit(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	h, repoPath := newBatchLimitTestHandler(t, 2)
+	sessionIDs := []string{uuid.NewString(), uuid.NewString(), uuid.NewString()}
+
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Request = batchUploadRequest(t, sessionIDs)
+
+	h.BatchUploadAssets(ctx)
+
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+	require.Contains(t, recorder.Body.String(), "limit is 2")
+	for _, sessionID := range sessionIDs {
+		_, exists := h.sessionManager.GetSession(sessionID)
+		require.False(t, exists, "rejected batch must not leave upload sessions behind")
+	}
+	staged, err := os.ReadDir(filepath.Join(repoPath, storage.DefaultStructure.IncomingDir))
+	require.NoError(t, err)
+	require.Empty(t, staged, "rejected batch must not leave staged files behind")
+}
+
+func TestBatchUploadAssets_CancelledBeforeProcessing(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	h, repoPath := newBatchLimitTestHandler(t, 2)
+	sessionIDs := []string{uuid.NewString(), uuid.NewString()}
+
+	requestCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Request = batchUploadRequest(t, sessionIDs).WithContext(requestCtx)
+
+	h.BatchUploadAssets(ctx)
+
+	require.Equal(t, http.StatusRequestTimeout, recorder.Code)
+	staged, err := os.ReadDir(filepath.Join(repoPath, storage.DefaultStructure.IncomingDir))
+	require.NoError(t, err)
+	require.Empty(t, staged)
+}
+
+func TestBatchUploadAssets_ResultsFollowPartOrder(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	h, repoPath := newBatchLimitTestHandler(t, 0)
+	h.chunkMerger = upload.NewChunkMerger(storage.NewDirectoryManager())
+
+	// Incomplete chunk sessions answer without touching the database. Enough
+	// sessions are sent that map iteration would almost surely reorder them.
+	sessionIDs := make([]string, 8)
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	for i := range sessionIDs {
+		sessionIDs[i] = uuid.NewString()
+		filename := sessionIDs[i] + ".mov"
+		h.sessionManager.CreateSession(sessionIDs[i], filename, 0, 2, "video/quicktime", repoPath, "anonymous")
+		part, err := writer.CreateFormFile("chunk_"+sessionIDs[i]+"_0_2", filename)
+		require.NoError(t, err)
+		_, err = part.Write([]byte("first half"))
+		require.NoError(t, err)
+	}
+	require.NoError(t, writer.Close())
+
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Request = httptest.NewRequest(http.MethodPost, "/api/v1/assets/batch", &body)
+	ctx.Request.Header.Set("Content-Type", writer.FormDataContentType())
+
+	h.BatchUploadAssets(ctx)
+
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+	var response dto.BatchUploadResponseDTO
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	got := make([]string, 0, len(response.Results))
+	for _, result := range response.Results {
+		require.Equal(t, result.SessionID+".mov", result.FileName)
+		got = append(got, result.SessionID)
+	}
+	require.Equal(t, sessionIDs, got)
+}
@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"server/internal/api/dto"
+)
+
+func TestCaptureHintsFromUploadRequestReturnsNilWithoutFields(t *testing.T) {
+	hints, err := captureHintsFromUploadRequest(dto.UploadAssetRequestDTO{})
+	require.NoError(t, err)
+	require.Nil(t, hints)
+}
+
+func TestCaptureHintsFromUploadRequestParsesFields(t *testing.T) {
+	latitude, longitude := 48.8584, 2.2945
+	hints, err := captureHintsFromUploadRequest(dto.UploadAssetRequestDTO{
+		TakenTime: "2025-07-14T09:30:00+02:00",
+		Latitude:  &latitude,
+		Longitude: &longitude,
+		Device:    "  iPhone 15 Pro ",
+	})
+	require.NoError(t, err)
+	require.NotNil(t, hints)
+	require.NotNil(t, hints.TakenTime)
+	require.True(t, hints.TakenTime.Equal(time.Date(2025, 7, 14, 7, 30, 0, 0, time.UTC)))
+	require.Equal(t, latitude, *hints.Latitude)
+	require.Equal(t, longitude, *hints.Longitude)
+	require.Equal(t, "iPhone 15 Pro", hints.Device)
+}
+
+func TestCaptureHintsFromUploadRequestRejectsInvalidInput(t *testing.T) {
+	latitude, longitude, outOfRange := 48.8584, 2.2945, 91.0
+	future := time.Now().Add(72 * time.Hour).Format(time.RFC3339)
+
+	cases := map[string]dto.UploadAssetRequestDTO{
+		"malformed time":   {TakenTime: "14/07/2025"},
+		"future time":      {TakenTime: future},
+		"latitude only":    {Latitude: &latitude},
+		"longitude only":   {Longitude: &longitude},
+		"latitude too big": {Latitude: &outOfRange, Longitude: &longitude},
+	}
+	for name, req := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := captureHintsFromUploadRequest(req)
+			require.Error(t, err)
+		})
+	}
+}
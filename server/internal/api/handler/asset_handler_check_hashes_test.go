@@ -0,0 +1,88 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"server/internal/db/repo"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+type stubContentHashLookup struct {
+	rows []repo.GetAssetIDsByContentHashesRow
+	got  repo.GetAssetIDsByContentHashesParams
+}
+
+func (s *stubContentHashLookup) GetAssetIDsByContentHashes(_ context.Context, arg repo.GetAssetIDsByContentHashesParams) ([]repo.GetAssetIDsByContentHashesRow, error) {
+	s.got = arg
+	return s.rows, nil
+}
+
+func TestCheckContentHashesReportsKnownAndUnknownHashes(t *testing.T) {
+	known := strings.Repeat("a", 64)
+	unknown := strings.Repeat("b", 64)
+
+	var firstID, secondID pgtype.UUID
+	require.NoError(t, firstID.Scan("11111111-1111-1111-1111-111111111111"))
+	require.NoError(t, secondID.Scan("22222222-2222-2222-2222-222222222222"))
+	lookup := &stubContentHashLookup{rows: []repo.GetAssetIDsByContentHashesRow{
+		{AssetID: firstID, ContentHash: known},
+		{AssetID: secondID, ContentHash: known},
+	}}
+	ownerID := int32(7)
+
+	response, err := checkContentHashes(context.Background(), lookup, []string{known, unknown}, &ownerID, pgtype.UUID{})
+	require.NoError(t, err)
+
+	require.Equal(t, []string{known, unknown}, lookup.got.ContentHashes)
+	require.Equal(t, &ownerID, lookup.got.OwnerID)
+	require.Equal(t, 1, response.ExistingCount)
+	require.Len(t, response.Results, 2)
+
+	require.Equal(t, known, response.Results[0].Hash)
+	require.True(t, response.Results[0].Exists)
+	require.Equal(t, []string{
+		"11111111-1111-1111-1111-111111111111",
+		"22222222-2222-2222-2222-222222222222",
+	}, response.Results[0].AssetIDs)
+
+	require.Equal(t, unknown, response.Results[1].Hash)
+	require.False(t, response.Results[1].Exists)
+	require.Empty(t, response.Results[1].AssetIDs)
+}
+
+func TestNormalizeContentHashesLowercasesAndDedupes(t *testing.T) {
+	upper := strings.Repeat("AB", 32)
+	lower := strings.Repeat("ab", 32)
+	other := strings.Repeat("c", 64)
+
+	hashes, err := normalizeContentHashes([]string{upper, " " + other + " ", lower})
+	require.NoError(t, err)
+	require.Equal(t, []string{lower, other}, hashes)
+}
+
+func TestNormalizeContentHashesRejectsMalformedHashes(t *testing.T) {
+	for _, value := range []string{"", "abc", strings.Repeat("z", 64), strings.Repeat("a", 65)} {
+		_, err := normalizeContentHashes([]string{value})
+		require.Error(t, err, value)
+	}
+}
+
+func TestAssetHandlerCheckHashesRejectsEmptyList(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Request = httptest.NewRequest(http.MethodPost, "/api/v1/assets/check-hashes", strings.NewReader(`{"hashes":[]}`))
+	ctx.Request.Header.Set("Content-Type", "application/json")
+
+	(&AssetHandler{}).CheckHashes(ctx)
+
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+}
@@ -0,0 +1,123 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"server/internal/api/dto"
+	"server/internal/db/dbtypes"
+	"server/internal/db/repo"
+	"server/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+// stubCustomFieldsAssetService keeps custom fields in memory, apart from the
+// asset row, the way the asset_custom_fields table does.
+type stubCustomFieldsAssetService struct {
+	service.AssetService
+	asset  *repo.Asset
+	fields map[uuid.UUID]map[string]any
+}
+
+func (s *stubCustomFieldsAssetService) GetAsset(context.Context, uuid.UUID) (*repo.Asset, error) {
+	asset := *s.asset
+	return &asset, nil
+}
+
+func (s *stubCustomFieldsAssetService) GetAssetCustomFields(_ context.Context, id uuid.UUID) (map[string]any, error) {
+	if fields, ok := s.fields[id]; ok {
+		return fields, nil
+	}
+	return map[string]any{}, nil
+}
+
+func (s *stubCustomFieldsAssetService) SetAssetCustomFields(_ context.Context, id uuid.UUID, fields map[string]any) (map[string]any, error) {
+	s.fields[id] = fields
+	return fields, nil
+}
+
+func serveCustomFieldsRequest(t *testing.T, h *AssetHandler, method, assetID, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Params = gin.Params{{Key: "id", Value: assetID}}
+	ctx.Request = httptest.NewRequest(method, "/api/v1/assets/"+assetID+"/custom-fields", strings.NewReader(body))
+	ctx.Request.Header.Set("Content-Type", "application/json")
+
+	switch method {
+	case http.MethodPut:
+		h.UpdateAssetCustomFields(ctx)
+	default:
+		h.GetAssetCustomFields(ctx)
+	}
+	return recorder
+}
+
+func TestUpdateAssetCustomFields_PersistThroughMetadataRefresh(t *testing.T) {
+	assetID := "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa"
+	asset := testHandlerAsset(t, assetID, "photo.jpg")
+	asset.SpecificMetadata = dbtypes.SpecificMetadata(`{"camera_model":"unknown"}`)
+	svc := &stubCustomFieldsAssetService{asset: &asset, fields: map[uuid.UUID]map[string]any{}}
+	refresher := stubMetadataRefresher{refreshFn: func(context.Context, pgtype.UUID) (*repo.Asset, error) {
+		asset.SpecificMetadata = dbtypes.SpecificMetadata(`{"camera_model":"X100V"}`)
+		refreshed := asset
+		return &refreshed, nil
+	}}
+	h := &AssetHandler{assetService: svc, metadataRefresher: refresher}
+
+	recorder := serveCustomFieldsRequest(t, h, http.MethodPut, assetID, `{"fields":{"model_release":"signed","client":"Acme","shoot_day":2}}`)
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+
+	require.Equal(t, http.StatusOK, refreshAssetMetadataForTest(t, h, assetID).Code)
+
+	recorder = serveCustomFieldsRequest(t, h, http.MethodGet, assetID, "")
+	require.Equal(t, http.StatusOK, recorder.Code)
+	var response dto.AssetCustomFieldsDTO
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	require.Equal(t, assetID, response.AssetID)
+	require.Equal(t, map[string]any{"model_release": "signed", "client": "Acme", "shoot_day": float64(2)}, response.Fields)
+	require.JSONEq(t, `{"camera_model":"X100V"}`, string(asset.SpecificMetadata))
+}
+
+func TestUpdateAssetCustomFields_RejectsInvalidFields(t *testing.T) {
+	assetID := "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa"
+	asset := testHandlerAsset(t, assetID, "photo.jpg")
+
+	for name, body := range map[string]string{
+		"uppercase key":    `{"fields":{"Client":"Acme"}}`,
+		"key with dash":    `{"fields":{"model-release":"signed"}}`,
+		"leading digit":    `{"fields":{"1st":"yes"}}`,
+		"nested object":    `{"fields":{"client":{"name":"Acme"}}}`,
+		"array value":      `{"fields":{"clients":["Acme"]}}`,
+		"null value":       `{"fields":{"client":null}}`,
+		"malformed JSON":   `{"fields":`,
+		"fields not a map": `{"fields":"client=Acme"}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			svc := &stubCustomFieldsAssetService{asset: &asset, fields: map[uuid.UUID]map[string]any{}}
+			recorder := serveCustomFieldsRequest(t, &AssetHandler{assetService: svc}, http.MethodPut, assetID, body)
+			require.Equal(t, http.StatusBadRequest, recorder.Code)
+			require.Empty(t, svc.fields)
+		})
+	}
+}
+
+func TestBuildQueryAssetsParams_EncodesCustomFieldsFilter(t *testing.T) {
+	params := buildQueryAssetsParams("", "", "", "", "", dto.AssetFilterDTO{
+		CustomFields: map[string]any{"client": "Acme"},
+	}, dto.PaginationDTO{Limit: 10})
+	require.JSONEq(t, `{"client":"Acme"}`, string(params.CustomFields))
+
+	params = buildQueryAssetsParams("", "", "", "", "", dto.AssetFilterDTO{}, dto.PaginationDTO{Limit: 10})
+	require.Nil(t, params.CustomFields)
+}
@@ -3,6 +3,7 @@ package handler
 import (
 	"archive/zip"
 	"bytes"
+	"context"
 	"encoding/json"
 	"io"
 	"net/http"
@@ -13,8 +14,12 @@ import (
 
 	"server/internal/api/dto"
 	"server/internal/db/repo"
+	"server/internal/service"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/stretchr/testify/require"
 )
 
@@ -100,3 +105,119 @@ func TestAssetHandlerWriteAssetToZip_UsesOriginalFilenames(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, "first", string(firstBytes))
 }
+
+type stubDownloadAssetService struct {
+	service.AssetService
+	assets map[uuid.UUID]repo.Asset
+}
+
+func (s stubDownloadAssetService) GetAssetAny(_ context.Context, id uuid.UUID) (*repo.Asset, error) {
+	asset, ok := s.assets[id]
+	if !ok {
+		return nil, pgx.ErrNoRows
+	}
+	return &asset, nil
+}
+
+// newDownloadTestHandler serves assets from one repository rooted in a temp
+// directory. Each entry of files maps an asset filename to its content; an
+// empty content leaves the original missing on disk.
+func newDownloadTestHandler(t *testing.T, maxDownloadBytes int64, files map[string]string) (*AssetHandler, map[string]uuid.UUID) {
+	t.Helper()
+	root := t.TempDir()
+	repositoryID := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+	assets := map[uuid.UUID]repo.Asset{}
+	ids := map[string]uuid.UUID{}
+	for name, content := range files {
+		id := uuid.New()
+		asset := testHandlerAsset(t, id.String(), name)
+		storagePath := "2024/" + name
+		asset.StoragePath = &storagePath
+		asset.RepositoryID = repositoryID
+		if content != "" {
+			path := filepath.Join(root, filepath.FromSlash(storagePath))
+			require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+			require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		}
+		assets[id] = asset
+		ids[name] = id
+	}
+	return &AssetHandler{
+		assetService:     stubDownloadAssetService{assets: assets},
+		queries:          repo.New(repositoriesDB{roots: map[pgtype.UUID]string{repositoryID: root}}),
+		maxDownloadBytes: maxDownloadBytes,
+	}, ids
+}
+
+func downloadAssetsForTest(t *testing.T, h *AssetHandler, ids ...uuid.UUID) *httptest.ResponseRecorder {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	req := dto.DownloadAssetsRequestDTO{}
+	for _, id := range ids {
+		req.AssetIDs = append(req.AssetIDs, id.String())
+	}
+	body, err := json.Marshal(req)
+	require.NoError(t, err)
+
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Request = httptest.NewRequest(http.MethodPost, "/api/v1/assets/download", bytes.NewReader(body))
+	ctx.Request.Header.Set("Content-Type", "application/json")
+
+	h.DownloadAssets(ctx)
+	return recorder
+}
+
+func TestDownloadAssets_SkipsMissingOriginalsIntoManifest(t *testing.T) {
+	h, ids := newDownloadTestHandler(t, 0, map[string]string{"kept.jpg": "kept bytes", "gone.jpg": ""})
+
+	recorder := downloadAssetsForTest(t, h, ids["kept.jpg"], ids["gone.jpg"])
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+	require.Contains(t, recorder.Header().Get("Content-Disposition"), "attachment;")
+
+	archive, err := zip.NewReader(bytes.NewReader(recorder.Body.Bytes()), int64(recorder.Body.Len()))
+	require.NoError(t, err)
+	require.Len(t, archive.File, 2)
+	require.Equal(t, "kept.jpg", archive.File[0].Name)
+	require.Equal(t, missingFilesManifestName, archive.File[1].Name, "the manifest must be the trailing entry")
+
+	manifest, err := archive.File[1].Open()
+	require.NoError(t, err)
+	defer manifest.Close()
+	content, err := io.ReadAll(manifest)
+	require.NoError(t, err)
+	require.Contains(t, string(content), ids["gone.jpg"].String()+"\tgone.jpg\toriginal file not found")
+	require.NotContains(t, string(content), ids["kept.jpg"].String())
+}
+
+func TestDownloadAssets_OmitsManifestWhenNothingIsMissing(t *testing.T) {
+	h, ids := newDownloadTestHandler(t, 0, map[string]string{"a.jpg": "a", "b.jpg": "b"})
+
+	recorder := downloadAssetsForTest(t, h, ids["a.jpg"], ids["b.jpg"])
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+
+	archive, err := zip.NewReader(bytes.NewReader(recorder.Body.Bytes()), int64(recorder.Body.Len()))
+	require.NoError(t, err)
+	require.Len(t, archive.File, 2)
+	for _, file := range archive.File {
+		require.NotEqual(t, missingFilesManifestName, file.Name)
+	}
+}
+
+func TestDownloadAssets_RejectsSelectionOverLimit(t *testing.T) {
+	h, ids := newDownloadTestHandler(t, 15, map[string]string{"a.jpg": "0123456789", "b.jpg": "0123456789"})
+
+	recorder := downloadAssetsForTest(t, h, ids["a.jpg"], ids["b.jpg"])
+	require.Equal(t, http.StatusRequestEntityTooLarge, recorder.Code, recorder.Body.String())
+
+	recorder = downloadAssetsForTest(t, h, ids["a.jpg"])
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+}
+
+func TestDownloadAssets_AllOriginalsMissingIsNotFound(t *testing.T) {
+	h, ids := newDownloadTestHandler(t, 0, map[string]string{"gone.jpg": ""})
+
+	recorder := downloadAssetsForTest(t, h, ids["gone.jpg"])
+	require.Equal(t, http.StatusNotFound, recorder.Code, recorder.Body.String())
+}
@@ -0,0 +1,80 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"server/internal/db/repo"
+	"server/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func hardDeleteForTest(t *testing.T, h *AssetHandler, user *service.UserResponse, id string) *httptest.ResponseRecorder {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Request = httptest.NewRequest(http.MethodDelete, "/api/v1/assets/"+id+"?hard=true", nil)
+	ctx.Params = gin.Params{{Key: "id", Value: id}}
+	if user != nil {
+		ctx.Set("current_user", user)
+	}
+
+	h.DeleteAsset(ctx)
+	return recorder
+}
+
+func TestDeleteAsset_AnonymousHardDeleteIsRejected(t *testing.T) {
+	unowned := testHandlerAsset(t, "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa", "a.jpg")
+	svc := &stubBatchDeleteAssetService{
+		assets:      map[uuid.UUID]repo.Asset{unowned.AssetID.Bytes: unowned},
+		hardDeleted: map[uuid.UUID]string{},
+	}
+
+	recorder := hardDeleteForTest(t, &AssetHandler{assetService: svc}, nil, unowned.AssetID.String())
+	require.Equal(t, http.StatusUnauthorized, recorder.Code)
+	require.Empty(t, svc.hardDeleted)
+}
+
+func TestDeleteAsset_HardDeleteNeedsOwnerOrAdmin(t *testing.T) {
+	unowned := testHandlerAsset(t, "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa", "a.jpg")
+	unowned.RepositoryID = pgtype.UUID{Bytes: uuid.New(), Valid: true}
+	owned := testHandlerAsset(t, "bbbbbbbb-bbbb-bbbb-bbbb-bbbbbbbbbbbb", "b.jpg")
+	owned.RepositoryID = unowned.RepositoryID
+	ownerID := int32(7)
+	owned.OwnerID = &ownerID
+	svc := &stubBatchDeleteAssetService{
+		assets: map[uuid.UUID]repo.Asset{
+			unowned.AssetID.Bytes: unowned,
+			owned.AssetID.Bytes:   owned,
+		},
+		hardDeleted: map[uuid.UUID]string{},
+	}
+	h := &AssetHandler{
+		assetService: svc,
+		queries:      repo.New(primaryRepositoryDB{path: "/photos/library"}),
+	}
+	user := &service.UserResponse{UserID: 7, Role: "user"}
+
+	recorder := hardDeleteForTest(t, h, user, unowned.AssetID.String())
+	require.Equal(t, http.StatusForbidden, recorder.Code, "only admins may permanently delete unowned assets")
+	require.Empty(t, svc.hardDeleted)
+
+	recorder = hardDeleteForTest(t, h, &service.UserResponse{UserID: 8, Role: "user"}, owned.AssetID.String())
+	require.Equal(t, http.StatusForbidden, recorder.Code)
+	require.Empty(t, svc.hardDeleted)
+
+	recorder = hardDeleteForTest(t, h, user, owned.AssetID.String())
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+	require.Equal(t, "/photos/library", svc.hardDeleted[owned.AssetID.Bytes])
+
+	recorder = hardDeleteForTest(t, h, &service.UserResponse{UserID: 1, Role: "admin"}, unowned.AssetID.String())
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+	require.Equal(t, "/photos/library", svc.hardDeleted[unowned.AssetID.Bytes])
+}
@@ -0,0 +1,79 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/png"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"server/internal/utils/imageguard"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportAsset_RejectsOversizedSource(t *testing.T) {
+	assetID := "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa"
+	h, assets, root := thumbnailTestHandler(t, assetID)
+	h.imageLimits = imageguard.Limits{MaxDimension: 1000, Timeout: time.Minute}
+
+	// A wide strip compresses to a few bytes but decodes to 1200 px; the
+	// recorded size claims a small image, the header decides.
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, image.NewGray(image.Rect(0, 0, 1200, 2))))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "photo.png"), buf.Bytes(), 0o600))
+	storagePath := "photo.png"
+	small := int32(100)
+	assets.asset.StoragePath = &storagePath
+	assets.asset.OriginalFilename = "photo.png"
+	assets.asset.Width, assets.asset.Height = &small, &small
+
+	recorder := serveMediaForTest(t, h, assetID, func(h *AssetHandler, c *gin.Context) {
+		c.Request.URL.RawQuery = "format=jpeg"
+		h.ExportAsset(c)
+	})
+	require.Equal(t, http.StatusUnprocessableEntity, recorder.Code, recorder.Body.String())
+	require.Contains(t, recorder.Body.String(), "too large to process on the fly")
+}
+
+func TestGetAssetThumbnail_RejectsOversizedSourceBeforeRendering(t *testing.T) {
+	assetID := "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa"
+	h, assets, root := thumbnailTestHandler(t, assetID)
+	generator := &fakeThumbnailGenerator{root: root, service: assets}
+	h.thumbnailGenerator = generator
+	h.imageLimits = imageguard.Limits{MaxMegapixels: 100}
+
+	width, height := int32(20000), int32(20000)
+	assets.asset.Width, assets.asset.Height = &width, &height
+
+	code, body := getThumbnailForTest(t, h, assetID)
+	require.Equal(t, http.StatusUnprocessableEntity, code, body)
+	require.Zero(t, generator.calls, "the original is never decoded")
+}
+
+// stalledThumbnailGenerator blocks until released, like a decoder stuck on a
+// hostile file.
+type stalledThumbnailGenerator struct{ release chan struct{} }
+
+func (g stalledThumbnailGenerator) GenerateMissingThumbnail(context.Context, pgtype.UUID, string) error {
+	<-g.release
+	return nil
+}
+
+func TestGetAssetThumbnail_TimesOut(t *testing.T) {
+	assetID := "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa"
+	h, _, _ := thumbnailTestHandler(t, assetID)
+	generator := stalledThumbnailGenerator{release: make(chan struct{})}
+	defer close(generator.release)
+	h.thumbnailGenerator = generator
+	h.imageLimits = imageguard.Limits{Timeout: 20 * time.Millisecond}
+
+	code, body := getThumbnailForTest(t, h, assetID)
+	require.Equal(t, http.StatusGatewayTimeout, code, body)
+}
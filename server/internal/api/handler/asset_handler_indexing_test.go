@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"server/internal/api/dto"
 	"server/internal/db/dbtypes"
@@ -265,6 +266,70 @@ func TestAssetHandlerRebuildAssetIndexes_NormalizesTasksAndLimit(t *testing.T) {
 	require.Equal(t, []string{"semantic", "ocr"}, response.RequestedTasks)
 }
 
+func TestAssetHandlerRebuildAssetIndexes_DryRunWithDateRange(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	handler := &AssetHandler{
+		indexingService: stubAssetIndexingService{
+			enqueueReindexAssets: func(ctx context.Context, input service.ReindexAssetsInput) (service.ReindexAssetsJobResult, error) {
+				require.True(t, input.DryRun)
+				require.NotNil(t, input.DateFrom)
+				require.NotNil(t, input.DateTo)
+				require.Equal(t, "2024-03-01T00:00:00+01:00", input.DateFrom.Format(time.RFC3339))
+				require.Equal(t, "2024-03-31T23:59:59.999999999+02:00", input.DateTo.Format(time.RFC3339Nano))
+
+				return service.ReindexAssetsJobResult{
+					Requested:   input.Tasks,
+					Limit:       input.Limit,
+					MissingOnly: input.MissingOnly,
+					Matching:    314,
+					DryRun:      true,
+				}, nil
+			},
+		},
+	}
+
+	body := `{"tasks":["semantic"],"missing_only":false,"dry_run":true,"viewer_timezone":"Europe/Berlin","date":{"from":"2024-03-01","to":"2024-03-31"}}`
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Request = httptest.NewRequest(http.MethodPost, "/api/v1/assets/indexing/rebuild", bytes.NewReader([]byte(body)))
+	ctx.Request.Header.Set("Content-Type", "application/json")
+
+	handler.RebuildAssetIndexes(ctx)
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var response dto.RebuildAssetIndexesResponseDTO
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	require.Equal(t, "dry_run", response.Status)
+	require.True(t, response.DryRun)
+	require.Zero(t, response.JobID)
+	require.Equal(t, int64(314), response.MatchingAssets)
+}
+
+func TestAssetHandlerRebuildAssetIndexes_RejectsInvertedDateRange(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	handler := &AssetHandler{
+		indexingService: stubAssetIndexingService{
+			enqueueReindexAssets: func(ctx context.Context, input service.ReindexAssetsInput) (service.ReindexAssetsJobResult, error) {
+				t.Fatal("service should not be called for an inverted date range")
+				return service.ReindexAssetsJobResult{}, nil
+			},
+		},
+	}
+
+	body := `{"date":{"from":"2024-04-01","to":"2024-03-01"}}`
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Request = httptest.NewRequest(http.MethodPost, "/api/v1/assets/indexing/rebuild", bytes.NewReader([]byte(body)))
+	ctx.Request.Header.Set("Content-Type", "application/json")
+
+	handler.RebuildAssetIndexes(ctx)
+
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+}
+
 func TestAssetHandlerRebuildAssetIndexes_RejectsInvalidTask(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
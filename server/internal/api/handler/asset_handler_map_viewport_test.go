@@ -1,10 +1,18 @@
 package handler
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
 	"net/http/httptest"
 	"testing"
 
+	"server/internal/api/dto"
+	"server/internal/service"
+
 	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
 )
 
 func mapViewportContext(rawQuery string) *gin.Context {
@@ -45,3 +53,48 @@ func TestParseOptionalMapViewport(t *testing.T) {
 		}
 	})
 }
+
+func postAssetListForTest(t *testing.T, h *AssetHandler, req dto.AssetQueryRequestDTO) *httptest.ResponseRecorder {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	body, err := json.Marshal(req)
+	require.NoError(t, err)
+
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Request = httptest.NewRequest(http.MethodPost, "/api/v1/assets/list", bytes.NewReader(body))
+	ctx.Request.Header.Set("Content-Type", "application/json")
+	h.QueryAssets(ctx)
+	return recorder
+}
+
+func TestQueryAssetsLocationFilter(t *testing.T) {
+	var got service.QueryAssetsParams
+	h := &AssetHandler{assetService: stubAssetService{
+		queryBrowseFn: func(_ context.Context, params service.QueryAssetsParams) (service.BrowseQueryResult, error) {
+			got = params
+			return service.BrowseQueryResult{}, nil
+		},
+	}}
+
+	// A map panned across the antimeridian sends west > east.
+	recorder := postAssetListForTest(t, h, dto.AssetQueryRequestDTO{
+		Filter: dto.AssetFilterDTO{Location: &dto.LocationBBoxDTO{North: 20, South: -20, West: 170, East: -170}},
+	})
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+	require.Equal(t, 20.0, *got.LocationNorth)
+	require.Equal(t, -20.0, *got.LocationSouth)
+	require.Equal(t, 170.0, *got.LocationWest)
+	require.Equal(t, -170.0, *got.LocationEast)
+
+	for name, box := range map[string]dto.LocationBBoxDTO{
+		"inverted latitude":      {North: -20, South: 20, West: -30, East: 30},
+		"latitude out of range":  {North: 95, South: 0, West: -30, East: 30},
+		"longitude out of range": {North: 20, South: -20, West: -190, East: 30},
+	} {
+		t.Run(name, func(t *testing.T) {
+			recorder := postAssetListForTest(t, h, dto.AssetQueryRequestDTO{Filter: dto.AssetFilterDTO{Location: &box}})
+			require.Equal(t, http.StatusBadRequest, recorder.Code)
+		})
+	}
+}
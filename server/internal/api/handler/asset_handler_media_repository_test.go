@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"server/internal/db/dbtypes"
+	"server/internal/db/repo"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+// repositoriesDB answers GetRepository with the root registered for the
+// requested repository ID; any other query panics through the nil embedded
+// DBTX.
+type repositoriesDB struct {
+	repo.DBTX
+	roots map[pgtype.UUID]string
+}
+
+func (db repositoriesDB) QueryRow(_ context.Context, _ string, args ...interface{}) pgx.Row {
+	id := args[0].(pgtype.UUID)
+	root, ok := db.roots[id]
+	return repositoriesRow{id: id, root: root, found: ok}
+}
+
+type repositoriesRow struct {
+	id    pgtype.UUID
+	root  string
+	found bool
+}
+
+func (r repositoriesRow) Scan(dest ...any) error {
+	if !r.found {
+		return pgx.ErrNoRows
+	}
+	// Column order follows repo.Repository: repo_id, name, path, config, status, ...
+	*dest[0].(*pgtype.UUID) = r.id
+	*dest[2].(*string) = r.root
+	*dest[4].(*dbtypes.RepoStatus) = dbtypes.RepoStatusActive
+	return nil
+}
+
+func TestGetOriginalFile_ServesFromOwningRepository(t *testing.T) {
+	assetID := "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa"
+	primary := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+	secondary := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+	primaryRoot, secondaryRoot := t.TempDir(), t.TempDir()
+	db := repositoriesDB{roots: map[pgtype.UUID]string{primary: primaryRoot, secondary: secondaryRoot}}
+
+	storagePath := "2024/photo.jpg"
+	asset := testHandlerAsset(t, assetID, "photo.jpg")
+	asset.StoragePath = &storagePath
+	asset.RepositoryID = secondary
+	h := &AssetHandler{assetService: stubMediaAssetService{asset: asset}, queries: repo.New(db)}
+
+	write := func(root, content string) {
+		path := filepath.Join(root, filepath.FromSlash(storagePath))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	}
+
+	// The same relative path in another repository must not be served.
+	write(primaryRoot, "primary")
+	recorder := serveMediaForTest(t, h, assetID, (*AssetHandler).GetOriginalFile)
+	require.Equal(t, http.StatusNotFound, recorder.Code, recorder.Body.String())
+
+	write(secondaryRoot, "secondary")
+	recorder = serveMediaForTest(t, h, assetID, (*AssetHandler).GetOriginalFile)
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+	require.Equal(t, "secondary", recorder.Body.String())
+}
@@ -0,0 +1,123 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"server/internal/api/dto"
+	"server/internal/db/repo"
+	"server/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+type stubNeighborAssetService struct {
+	service.AssetService
+	asset       repo.Asset
+	neighborsFn func(ctx context.Context, assetID uuid.UUID, params service.QueryAssetsParams) (service.AssetNeighbors, error)
+}
+
+func (s stubNeighborAssetService) GetAssetAny(context.Context, uuid.UUID) (*repo.Asset, error) {
+	asset := s.asset
+	return &asset, nil
+}
+
+func (s stubNeighborAssetService) GetAssetNeighbors(ctx context.Context, assetID uuid.UUID, params service.QueryAssetsParams) (service.AssetNeighbors, error) {
+	return s.neighborsFn(ctx, assetID, params)
+}
+
+func getAssetNeighborsForTest(t *testing.T, svc stubNeighborAssetService, assetID string, query url.Values) *httptest.ResponseRecorder {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Params = gin.Params{{Key: "id", Value: assetID}}
+	ctx.Request = httptest.NewRequest(http.MethodGet, "/api/v1/assets/"+assetID+"/neighbors?"+query.Encode(), nil)
+
+	(&AssetHandler{assetService: svc}).GetAssetNeighbors(ctx)
+	return recorder
+}
+
+func TestGetAssetNeighbors_PassesDateSortedFilter(t *testing.T) {
+	anchorID := "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa"
+	previousID := uuid.MustParse("bbbbbbbb-bbbb-bbbb-bbbb-bbbbbbbbbbbb")
+	nextID := uuid.MustParse("cccccccc-cccc-cccc-cccc-cccccccccccc")
+
+	var captured service.QueryAssetsParams
+	svc := stubNeighborAssetService{
+		asset: testHandlerAsset(t, anchorID, "anchor.jpg"),
+		neighborsFn: func(_ context.Context, assetID uuid.UUID, params service.QueryAssetsParams) (service.AssetNeighbors, error) {
+			require.Equal(t, anchorID, assetID.String())
+			captured = params
+			return service.AssetNeighbors{PreviousID: &previousID, NextID: &nextID}, nil
+		},
+	}
+
+	recorder := getAssetNeighborsForTest(t, svc, anchorID, url.Values{
+		"sort_by": {"date_captured"},
+		"filter":  {`{"type":"PHOTO","liked":true,"date":{"from":"2024-01-01","to":"2024-12-31"}}`},
+	})
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var response dto.AssetNeighborsResponseDTO
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	require.Equal(t, anchorID, response.AssetID)
+	require.NotNil(t, response.PreviousID)
+	require.Equal(t, previousID.String(), *response.PreviousID)
+	require.NotNil(t, response.NextID)
+	require.Equal(t, nextID.String(), *response.NextID)
+
+	require.Equal(t, "date_captured", captured.SortBy)
+	require.NotNil(t, captured.AssetType)
+	require.Equal(t, "PHOTO", *captured.AssetType)
+	require.NotNil(t, captured.Liked)
+	require.True(t, *captured.Liked)
+	require.NotNil(t, captured.DateFrom)
+	require.NotNil(t, captured.DateTo)
+	require.True(t, captured.DateTo.After(*captured.DateFrom))
+}
+
+func TestGetAssetNeighbors_OmitsMissingEnds(t *testing.T) {
+	anchorID := "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa"
+	svc := stubNeighborAssetService{
+		asset: testHandlerAsset(t, anchorID, "anchor.jpg"),
+		neighborsFn: func(context.Context, uuid.UUID, service.QueryAssetsParams) (service.AssetNeighbors, error) {
+			return service.AssetNeighbors{}, nil
+		},
+	}
+
+	recorder := getAssetNeighborsForTest(t, svc, anchorID, nil)
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.JSONEq(t, `{"asset_id":"`+anchorID+`"}`, recorder.Body.String())
+}
+
+func TestGetAssetNeighbors_RejectsInvalidContext(t *testing.T) {
+	anchorID := "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa"
+	svc := stubNeighborAssetService{
+		asset: testHandlerAsset(t, anchorID, "anchor.jpg"),
+		neighborsFn: func(context.Context, uuid.UUID, service.QueryAssetsParams) (service.AssetNeighbors, error) {
+			t.Fatal("service must not be called for invalid input")
+			return service.AssetNeighbors{}, nil
+		},
+	}
+
+	for name, query := range map[string]url.Values{
+		"sort_by": {"sort_by": {"random"}},
+		"filter":  {"filter": {"{not json"}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			recorder := getAssetNeighborsForTest(t, svc, anchorID, query)
+			require.Equal(t, http.StatusBadRequest, recorder.Code)
+		})
+	}
+
+	recorder := getAssetNeighborsForTest(t, svc, "not-a-uuid", nil)
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+}
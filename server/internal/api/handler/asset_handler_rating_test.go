@@ -0,0 +1,84 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+type stubRatingAssetService struct {
+	stubRefreshAssetService
+	rating *int
+}
+
+func (s *stubRatingAssetService) UpdateAssetRating(_ context.Context, _ uuid.UUID, rating int) error {
+	s.rating = &rating
+	return nil
+}
+
+type stubAssetRatingWriter struct {
+	err   error
+	calls int
+	id    pgtype.UUID
+}
+
+func (s *stubAssetRatingWriter) QueueRatingWrite(_ context.Context, assetID pgtype.UUID) error {
+	s.calls++
+	s.id = assetID
+	return s.err
+}
+
+func updateRatingForTest(t *testing.T, h *AssetHandler, assetID, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Params = gin.Params{{Key: "id", Value: assetID}}
+	ctx.Request = httptest.NewRequest(http.MethodPut, "/api/v1/assets/"+assetID+"/rating", strings.NewReader(body))
+	ctx.Request.Header.Set("Content-Type", "application/json")
+
+	h.UpdateAssetRating(ctx)
+	return recorder
+}
+
+func TestUpdateAssetRating_QueuesRatingWrite(t *testing.T) {
+	assetID := "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa"
+	svc := &stubRatingAssetService{stubRefreshAssetService: stubRefreshAssetService{asset: testHandlerAsset(t, assetID, "photo.jpg")}}
+	writer := &stubAssetRatingWriter{}
+
+	recorder := updateRatingForTest(t, &AssetHandler{assetService: svc, ratingWriter: writer}, assetID, `{"rating":4}`)
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+	require.NotNil(t, svc.rating)
+	require.Equal(t, 4, *svc.rating)
+	require.Equal(t, 1, writer.calls)
+	require.Equal(t, svc.asset.AssetID, writer.id)
+}
+
+func TestUpdateAssetRating_QueueFailureKeepsStoredRating(t *testing.T) {
+	assetID := "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa"
+	svc := &stubRatingAssetService{stubRefreshAssetService: stubRefreshAssetService{asset: testHandlerAsset(t, assetID, "photo.jpg")}}
+	writer := &stubAssetRatingWriter{err: fmt.Errorf("enqueue write_rating: connection refused")}
+
+	recorder := updateRatingForTest(t, &AssetHandler{assetService: svc, ratingWriter: writer}, assetID, `{"rating":2}`)
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+	require.NotNil(t, svc.rating)
+	require.Equal(t, 1, writer.calls)
+}
+
+func TestUpdateAssetRating_WithoutWriterOnlyStoresRating(t *testing.T) {
+	assetID := "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa"
+	svc := &stubRatingAssetService{stubRefreshAssetService: stubRefreshAssetService{asset: testHandlerAsset(t, assetID, "photo.jpg")}}
+
+	recorder := updateRatingForTest(t, &AssetHandler{assetService: svc}, assetID, `{"rating":5}`)
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+	require.NotNil(t, svc.rating)
+}
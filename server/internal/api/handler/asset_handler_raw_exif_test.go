@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"server/internal/api/dto"
+	"server/internal/processors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+type stubRawTagReader struct {
+	readFn func(ctx context.Context, assetID pgtype.UUID) (map[string]any, error)
+}
+
+func (s stubRawTagReader) ReadAssetRawTags(ctx context.Context, assetID pgtype.UUID) (map[string]any, error) {
+	return s.readFn(ctx, assetID)
+}
+
+func getAssetRawExifForTest(t *testing.T, h *AssetHandler, assetID string) *httptest.ResponseRecorder {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Params = gin.Params{{Key: "id", Value: assetID}}
+	ctx.Request = httptest.NewRequest(http.MethodGet, "/api/v1/assets/"+assetID+"/exif-raw", nil)
+
+	h.GetAssetRawExif(ctx)
+	return recorder
+}
+
+func TestGetAssetRawExif_ReturnsAllGroupedTags(t *testing.T) {
+	assetID := "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa"
+	asset := testHandlerAsset(t, assetID, "photo.jpg")
+	reader := stubRawTagReader{readFn: func(_ context.Context, id pgtype.UUID) (map[string]any, error) {
+		require.Equal(t, asset.AssetID, id)
+		return map[string]any{
+			"IFD0:Make":           "FUJIFILM",
+			"XMP-dc:Creator":      "Edwin",
+			"IPTC:Keywords":       []any{"street", "night"},
+			"Fujifilm:FilmMode":   "F0/Standard (Provia)",
+			"ExifIFD:ExifVersion": "0232",
+		}, nil
+	}}
+
+	recorder := getAssetRawExifForTest(t, &AssetHandler{
+		assetService: stubRefreshAssetService{asset: asset},
+		rawTagReader: reader,
+	}, assetID)
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+
+	var response dto.AssetRawTagsResponseDTO
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	require.Equal(t, assetID, response.AssetID)
+	require.Equal(t, "FUJIFILM", response.Tags["IFD0:Make"])
+	require.Equal(t, "Edwin", response.Tags["XMP-dc:Creator"])
+	require.Equal(t, []any{"street", "night"}, response.Tags["IPTC:Keywords"])
+	require.Contains(t, response.Tags, "Fujifilm:FilmMode")
+}
+
+func TestGetAssetRawExif_ErrorMapping(t *testing.T) {
+	assetID := "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa"
+	cases := []struct {
+		err  error
+		code int
+	}{
+		{processors.ErrRawTagsUnsupported, http.StatusUnsupportedMediaType},
+		{processors.ErrOriginalMissing, http.StatusNotFound},
+	}
+	for _, tc := range cases {
+		reader := stubRawTagReader{readFn: func(context.Context, pgtype.UUID) (map[string]any, error) {
+			return nil, tc.err
+		}}
+		recorder := getAssetRawExifForTest(t, &AssetHandler{
+			assetService: stubRefreshAssetService{asset: testHandlerAsset(t, assetID, "clip.mp4")},
+			rawTagReader: reader,
+		}, assetID)
+		require.Equal(t, tc.code, recorder.Code, tc.err.Error())
+	}
+}
+
+func TestGetAssetRawExif_RejectsInvalidID(t *testing.T) {
+	recorder := getAssetRawExifForTest(t, &AssetHandler{}, "not-a-uuid")
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+}
@@ -0,0 +1,102 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"server/internal/api/dto"
+	"server/internal/db/dbtypes"
+	"server/internal/db/repo"
+	"server/internal/processors"
+	"server/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+type stubRefreshAssetService struct {
+	service.AssetService
+	asset repo.Asset
+}
+
+func (s stubRefreshAssetService) GetAsset(context.Context, uuid.UUID) (*repo.Asset, error) {
+	asset := s.asset
+	return &asset, nil
+}
+
+type stubMetadataRefresher struct {
+	refreshFn func(ctx context.Context, assetID pgtype.UUID) (*repo.Asset, error)
+}
+
+func (s stubMetadataRefresher) RefreshAssetMetadata(ctx context.Context, assetID pgtype.UUID) (*repo.Asset, error) {
+	return s.refreshFn(ctx, assetID)
+}
+
+func refreshAssetMetadataForTest(t *testing.T, h *AssetHandler, assetID string) *httptest.ResponseRecorder {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Params = gin.Params{{Key: "id", Value: assetID}}
+	ctx.Request = httptest.NewRequest(http.MethodPost, "/api/v1/assets/"+assetID+"/refresh-metadata", nil)
+
+	h.RefreshAssetMetadata(ctx)
+	return recorder
+}
+
+func TestRefreshAssetMetadata_ReturnsRefreshedAssetKeepingRating(t *testing.T) {
+	assetID := "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa"
+	rating := int32(5)
+	stale := testHandlerAsset(t, assetID, "photo.jpg")
+	stale.Rating = &rating
+	stale.SpecificMetadata = dbtypes.SpecificMetadata(`{"camera_model":"unknown"}`)
+
+	takenTime := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	refresher := stubMetadataRefresher{refreshFn: func(_ context.Context, id pgtype.UUID) (*repo.Asset, error) {
+		require.Equal(t, stale.AssetID, id)
+		refreshed := stale
+		refreshed.SpecificMetadata = dbtypes.SpecificMetadata(`{"camera_model":"X100V"}`)
+		refreshed.TakenTime = pgtype.Timestamptz{Time: takenTime, Valid: true}
+		return &refreshed, nil
+	}}
+
+	recorder := refreshAssetMetadataForTest(t, &AssetHandler{
+		assetService:      stubRefreshAssetService{asset: stale},
+		metadataRefresher: refresher,
+	}, assetID)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var response dto.AssetDTO
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	require.JSONEq(t, `{"camera_model":"X100V"}`, string(response.Metadata))
+	require.NotNil(t, response.TakenTime)
+	require.True(t, response.TakenTime.Equal(takenTime))
+	require.NotNil(t, response.Rating)
+	require.Equal(t, rating, *response.Rating)
+}
+
+func TestRefreshAssetMetadata_MissingOriginalIsNotFound(t *testing.T) {
+	assetID := "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa"
+	refresher := stubMetadataRefresher{refreshFn: func(context.Context, pgtype.UUID) (*repo.Asset, error) {
+		return nil, fmt.Errorf("%w: photo.jpg", processors.ErrOriginalMissing)
+	}}
+
+	recorder := refreshAssetMetadataForTest(t, &AssetHandler{
+		assetService:      stubRefreshAssetService{asset: testHandlerAsset(t, assetID, "photo.jpg")},
+		metadataRefresher: refresher,
+	}, assetID)
+	require.Equal(t, http.StatusNotFound, recorder.Code)
+}
+
+func TestRefreshAssetMetadata_RejectsInvalidID(t *testing.T) {
+	recorder := refreshAssetMetadataForTest(t, &AssetHandler{}, "not-a-uuid")
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+}
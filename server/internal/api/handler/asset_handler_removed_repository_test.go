@@ -0,0 +1,100 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"server/internal/db/repo"
+	"server/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/require"
+)
+
+type stubMediaAssetService struct {
+	service.AssetService
+	asset repo.Asset
+}
+
+func (s stubMediaAssetService) GetAssetAny(context.Context, uuid.UUID) (*repo.Asset, error) {
+	asset := s.asset
+	return &asset, nil
+}
+
+// repositoryRemovalDB answers GetAssetRepositoryRemoval with a marker for
+// repositoryName, or no row when it is empty; any other query panics through
+// the nil embedded DBTX.
+type repositoryRemovalDB struct {
+	repo.DBTX
+	repositoryName string
+}
+
+func (db repositoryRemovalDB) QueryRow(context.Context, string, ...interface{}) pgx.Row {
+	return repositoryRemovalRow{repositoryName: db.repositoryName}
+}
+
+type repositoryRemovalRow struct{ repositoryName string }
+
+func (r repositoryRemovalRow) Scan(dest ...any) error {
+	if r.repositoryName == "" {
+		return pgx.ErrNoRows
+	}
+	// Column order follows repo.AssetRepositoryRemoval: asset_id, repository_id, repository_name, removed_at.
+	*dest[2].(*string) = r.repositoryName
+	return nil
+}
+
+func serveMediaForTest(t *testing.T, h *AssetHandler, assetID string, serve func(*AssetHandler, *gin.Context)) *httptest.ResponseRecorder {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Params = gin.Params{{Key: "id", Value: assetID}}
+	ctx.Request = httptest.NewRequest(http.MethodGet, "/api/v1/assets/"+assetID, nil)
+
+	serve(h, ctx)
+	return recorder
+}
+
+func TestMediaEndpoints_RemovedRepositoryAssetIsGone(t *testing.T) {
+	assetID := "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa"
+	photo := testHandlerAsset(t, assetID, "photo.jpg")
+	video := testHandlerAsset(t, assetID, "clip.mov")
+	video.Type = "VIDEO"
+
+	cases := map[string]struct {
+		asset repo.Asset
+		serve func(*AssetHandler, *gin.Context)
+	}{
+		"original":  {photo, (*AssetHandler).GetOriginalFile},
+		"web video": {video, (*AssetHandler).GetWebVideo},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			require.False(t, tc.asset.RepositoryID.Valid)
+			h := &AssetHandler{
+				assetService: stubMediaAssetService{asset: tc.asset},
+				queries:      repo.New(repositoryRemovalDB{repositoryName: "Old Drive"}),
+			}
+
+			recorder := serveMediaForTest(t, h, assetID, tc.serve)
+			require.Equal(t, http.StatusGone, recorder.Code, recorder.Body.String())
+		})
+	}
+}
+
+func TestMediaEndpoints_UnassignedAssetIsNotGone(t *testing.T) {
+	assetID := "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa"
+	h := &AssetHandler{
+		assetService: stubMediaAssetService{asset: testHandlerAsset(t, assetID, "photo.jpg")},
+		queries:      repo.New(repositoryRemovalDB{}),
+	}
+
+	recorder := serveMediaForTest(t, h, assetID, (*AssetHandler).GetOriginalFile)
+	require.Equal(t, http.StatusInternalServerError, recorder.Code, recorder.Body.String())
+}
@@ -0,0 +1,75 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"server/internal/db/repo"
+	"server/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+type stubRestoreAssetService struct {
+	service.AssetService
+	asset      repo.Asset
+	restoreErr error
+	restored   bool
+}
+
+func (s *stubRestoreAssetService) GetAssetAny(context.Context, uuid.UUID) (*repo.Asset, error) {
+	asset := s.asset
+	return &asset, nil
+}
+
+func (s *stubRestoreAssetService) RestoreAsset(context.Context, uuid.UUID, string) error {
+	if s.restoreErr != nil {
+		return s.restoreErr
+	}
+	s.restored = true
+	return nil
+}
+
+func restoreAssetForTest(t *testing.T, svc service.AssetService, assetID string) *httptest.ResponseRecorder {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Params = gin.Params{{Key: "id", Value: assetID}}
+	ctx.Request = httptest.NewRequest(http.MethodPost, "/api/v1/assets/"+assetID+"/restore", nil)
+
+	(&AssetHandler{assetService: svc}).RestoreAsset(ctx)
+	return recorder
+}
+
+func TestRestoreAsset(t *testing.T) {
+	assetID := "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa"
+	deleted := true
+	trashed := testHandlerAsset(t, assetID, "photo.jpg")
+	trashed.IsDeleted = &deleted
+
+	t.Run("restores a trashed asset", func(t *testing.T) {
+		svc := &stubRestoreAssetService{asset: trashed}
+		recorder := restoreAssetForTest(t, svc, assetID)
+		require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+		require.True(t, svc.restored)
+	})
+
+	t.Run("404 for an asset that was never deleted", func(t *testing.T) {
+		svc := &stubRestoreAssetService{asset: testHandlerAsset(t, assetID, "photo.jpg")}
+		recorder := restoreAssetForTest(t, svc, assetID)
+		require.Equal(t, http.StatusNotFound, recorder.Code, recorder.Body.String())
+		require.False(t, svc.restored)
+	})
+
+	t.Run("409 when the original cannot be recovered", func(t *testing.T) {
+		svc := &stubRestoreAssetService{asset: trashed, restoreErr: service.ErrOriginalUnrecoverable}
+		recorder := restoreAssetForTest(t, svc, assetID)
+		require.Equal(t, http.StatusConflict, recorder.Code, recorder.Body.String())
+	})
+}
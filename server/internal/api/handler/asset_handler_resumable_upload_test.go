@@ -0,0 +1,136 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"server/internal/api/dto"
+	"server/internal/db/repo"
+	"server/internal/utils/upload"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func serveResumableRequest(t *testing.T, h *AssetHandler, method, sessionID, offset, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Params = gin.Params{{Key: "id", Value: sessionID}}
+	ctx.Request = httptest.NewRequest(method, "/api/v1/assets/upload/session/"+sessionID, strings.NewReader(body))
+	if offset != "" {
+		ctx.Request.Header.Set("Upload-Offset", offset)
+	}
+	ctx.Set("user_id", int32(7))
+
+	switch method {
+	case http.MethodPatch:
+		h.AppendResumableUpload(ctx)
+	case http.MethodPost:
+		h.CompleteResumableUpload(ctx)
+	default:
+		h.GetResumableUpload(ctx)
+	}
+	return recorder
+}
+
+func TestResumableUpload_ResumesFromReportedOffset(t *testing.T) {
+	repoPath := t.TempDir()
+	stagingPath := filepath.Join(repoPath, ".lumilio", "staging", "incoming", "staged_movie.mp4")
+	require.NoError(t, os.MkdirAll(filepath.Dir(stagingPath), 0o700))
+	require.NoError(t, os.WriteFile(stagingPath, nil, 0o600))
+
+	session, err := upload.NewResumableManager().Create(repoPath, stagingPath, "movie.mp4", "video/mp4", 8, "7")
+	require.NoError(t, err)
+
+	// A fresh manager stands in for a server restart between chunks.
+	h := &AssetHandler{
+		resumable:     upload.NewResumableManager(),
+		uploadLimiter: make(chan struct{}, 1),
+		repoManager: stubRepositoryManager{listRepositoriesFn: func() ([]*repo.Repository, error) {
+			return []*repo.Repository{testRepository(t, "550e8400-e29b-41d4-a716-446655440000", "primary", repoPath)}, nil
+		}},
+	}
+
+	recorder := serveResumableRequest(t, h, http.MethodPatch, session.ID, "0", "abcd")
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+	require.Equal(t, "4", recorder.Header().Get("Upload-Offset"))
+
+	recorder = serveResumableRequest(t, h, http.MethodPatch, session.ID, "0", "abcd")
+	require.Equal(t, http.StatusConflict, recorder.Code)
+	require.Equal(t, "4", recorder.Header().Get("Upload-Offset"))
+
+	recorder = serveResumableRequest(t, h, http.MethodGet, session.ID, "", "")
+	require.Equal(t, http.StatusOK, recorder.Code)
+	var state dto.ResumableUploadDTO
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &state))
+	require.Equal(t, int64(4), state.Offset)
+	require.Equal(t, int64(8), state.Size)
+
+	recorder = serveResumableRequest(t, h, http.MethodPatch, session.ID, "4", "efghij")
+	require.Equal(t, http.StatusRequestEntityTooLarge, recorder.Code)
+
+	recorder = serveResumableRequest(t, h, http.MethodPatch, session.ID, "4", "efgh")
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Equal(t, "8", recorder.Header().Get("Upload-Offset"))
+
+	data, err := os.ReadFile(stagingPath)
+	require.NoError(t, err)
+	require.Equal(t, "abcdefgh", string(data))
+}
+
+func TestResumableUpload_HidesOtherUsersSessions(t *testing.T) {
+	repoPath := t.TempDir()
+	stagingPath := filepath.Join(repoPath, ".lumilio", "staging", "incoming", "staged_movie.mp4")
+	require.NoError(t, os.MkdirAll(filepath.Dir(stagingPath), 0o700))
+	require.NoError(t, os.WriteFile(stagingPath, nil, 0o600))
+
+	manager := upload.NewResumableManager()
+	session, err := manager.Create(repoPath, stagingPath, "movie.mp4", "video/mp4", 8, "99")
+	require.NoError(t, err)
+
+	h := &AssetHandler{
+		resumable:     manager,
+		uploadLimiter: make(chan struct{}, 1),
+		repoManager: stubRepositoryManager{listRepositoriesFn: func() ([]*repo.Repository, error) {
+			return nil, nil
+		}},
+	}
+	recorder := serveResumableRequest(t, h, http.MethodPatch, session.ID, "0", "abcd")
+	require.Equal(t, http.StatusNotFound, recorder.Code)
+}
+
+func TestResumableUpload_CompleteRefusesUploadBeingCompleted(t *testing.T) {
+	repoPath := t.TempDir()
+	stagingPath := filepath.Join(repoPath, ".lumilio", "staging", "incoming", "staged_movie.mp4")
+	require.NoError(t, os.MkdirAll(filepath.Dir(stagingPath), 0o700))
+	require.NoError(t, os.WriteFile(stagingPath, []byte("abcd"), 0o600))
+
+	manager := upload.NewResumableManager()
+	session, err := manager.Create(repoPath, stagingPath, "movie.mp4", "video/mp4", 4, "7")
+	require.NoError(t, err)
+	// Another request, here from a second server process, is completing it.
+	_, err = upload.NewResumableManager().Claim(session)
+	require.NoError(t, err)
+
+	// The stub has no GetRepositoryByPath, so going on past the claim panics.
+	h := &AssetHandler{
+		resumable:     manager,
+		uploadLimiter: make(chan struct{}, 1),
+		repoManager: stubRepositoryManager{listRepositoriesFn: func() ([]*repo.Repository, error) {
+			return nil, nil
+		}},
+	}
+	recorder := serveResumableRequest(t, h, http.MethodPost, session.ID, "", "")
+	require.Equal(t, http.StatusConflict, recorder.Code, recorder.Body.String())
+
+	_, err = os.Stat(stagingPath)
+	require.NoError(t, err, "the staged file stays for the request that holds the claim")
+}
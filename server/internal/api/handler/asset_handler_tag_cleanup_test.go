@@ -0,0 +1,74 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"server/internal/api/dto"
+	"server/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+type stubTagCleanupService struct {
+	service.AssetService
+	cleanupFn func(ctx context.Context, minConfidence float64, removeOrphans bool) (service.TagCleanupResult, error)
+}
+
+func (s stubTagCleanupService) CleanupAITags(ctx context.Context, minConfidence float64, removeOrphans bool) (service.TagCleanupResult, error) {
+	return s.cleanupFn(ctx, minConfidence, removeOrphans)
+}
+
+func TestAssetHandlerCleanupAITags_PassesThresholdAndReportsCounts(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	handler := &AssetHandler{
+		assetService: stubTagCleanupService{
+			cleanupFn: func(_ context.Context, minConfidence float64, removeOrphans bool) (service.TagCleanupResult, error) {
+				require.Equal(t, 0.35, minConfidence)
+				require.True(t, removeOrphans)
+				return service.TagCleanupResult{RemovedAssetTags: 12, RemovedTags: 3}, nil
+			},
+		},
+	}
+
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Request = httptest.NewRequest(http.MethodPost, "/api/v1/admin/tags/cleanup?min_confidence=0.35&remove_orphans=true", nil)
+
+	handler.CleanupAITags(ctx)
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+	var response dto.TagCleanupResponseDTO
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	require.Equal(t, dto.TagCleanupResponseDTO{MinConfidence: 0.35, RemovedAssetTags: 12, RemovedTags: 3}, response)
+}
+
+func TestAssetHandlerCleanupAITags_RejectsInvalidThreshold(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	for _, query := range []string{"", "?min_confidence=abc", "?min_confidence=0", "?min_confidence=1.5", "?min_confidence=NaN"} {
+		t.Run(query, func(t *testing.T) {
+			handler := &AssetHandler{
+				assetService: stubTagCleanupService{
+					cleanupFn: func(context.Context, float64, bool) (service.TagCleanupResult, error) {
+						t.Fatal("cleanup must not run for an invalid threshold")
+						return service.TagCleanupResult{}, nil
+					},
+				},
+			}
+
+			recorder := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(recorder)
+			ctx.Request = httptest.NewRequest(http.MethodPost, "/api/v1/admin/tags/cleanup"+query, nil)
+
+			handler.CleanupAITags(ctx)
+
+			require.Equal(t, http.StatusBadRequest, recorder.Code)
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"server/internal/api/dto"
+	"server/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+type stubTagSearchAssetService struct {
+	service.AssetService
+	searchByTagFn func(ctx context.Context, params service.TagSearchParams) (service.TagSearchResult, error)
+}
+
+func (s stubTagSearchAssetService) SearchAssetsByTag(ctx context.Context, params service.TagSearchParams) (service.TagSearchResult, error) {
+	return s.searchByTagFn(ctx, params)
+}
+
+func postTagSearch(t *testing.T, h *AssetHandler, req dto.SearchAssetsRequestDTO) *httptest.ResponseRecorder {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	body, err := json.Marshal(req)
+	require.NoError(t, err)
+
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Request = httptest.NewRequest(http.MethodPost, "/api/v1/assets/search", bytes.NewReader(body))
+	ctx.Request.Header.Set("Content-Type", "application/json")
+	h.SearchAssets(ctx)
+	return recorder
+}
+
+func TestAssetHandlerSearchAssets_TagSearch(t *testing.T) {
+	bird := testHandlerAsset(t, "dddddddd-dddd-dddd-dddd-dddddddddddd", "bird.jpg")
+	source := "ai"
+	match := "any"
+	minConfidence := 0.4
+
+	var got service.TagSearchParams
+	h := &AssetHandler{assetService: stubTagSearchAssetService{
+		searchByTagFn: func(_ context.Context, params service.TagSearchParams) (service.TagSearchResult, error) {
+			got = params
+			return service.TagSearchResult{
+				Items: []service.BrowseItem{{Type: "asset", ID: "asset:" + uuid.UUID(bird.AssetID.Bytes).String(), Asset: bird}},
+				Total: 1,
+			}, nil
+		},
+	}}
+
+	recorder := postTagSearch(t, h, dto.SearchAssetsRequestDTO{
+		SearchType: "tag",
+		Filter: dto.AssetFilterDTO{
+			TagNames:         []string{"bird", "owl"},
+			TagSource:        &source,
+			TagMatch:         &match,
+			TagMinConfidence: &minConfidence,
+		},
+		Pagination: dto.PaginationDTO{Limit: 10},
+	})
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Equal(t, []string{"bird", "owl"}, got.TagNames)
+	require.True(t, got.MatchAny)
+	require.Equal(t, "ai", *got.Source)
+	require.Equal(t, 0.4, *got.MinConfidence)
+	require.Equal(t, 10, got.Limit)
+
+	var response dto.SearchAssetsResponseDTO
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	require.Len(t, response.ResultItems, 1)
+	require.Equal(t, 1, *response.ResultsTotalAssets)
+	require.False(t, response.TopResultsMeta.Enabled)
+}
+
+func TestAssetHandlerSearchAssets_TagSearchReadsTagsFromQuery(t *testing.T) {
+	var got service.TagSearchParams
+	h := &AssetHandler{assetService: stubTagSearchAssetService{
+		searchByTagFn: func(_ context.Context, params service.TagSearchParams) (service.TagSearchResult, error) {
+			got = params
+			return service.TagSearchResult{}, nil
+		},
+	}}
+
+	recorder := postTagSearch(t, h, dto.SearchAssetsRequestDTO{SearchType: "tag", Query: "bird, branch"})
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Equal(t, []string{"bird", " branch"}, got.TagNames, "the service trims and lower-cases")
+	require.False(t, got.MatchAny)
+}
+
+func TestAssetHandlerSearchAssets_TagSearchRejectsBadInput(t *testing.T) {
+	h := &AssetHandler{assetService: stubTagSearchAssetService{
+		searchByTagFn: func(context.Context, service.TagSearchParams) (service.TagSearchResult, error) {
+			return service.TagSearchResult{}, service.ErrNoSearchTags
+		},
+	}}
+
+	badMatch := "some"
+	tooConfident := 1.5
+	for name, req := range map[string]dto.SearchAssetsRequestDTO{
+		"unknown search type": {SearchType: "fuzzy", Query: "bird"},
+		"unknown tag match":   {SearchType: "tag", Filter: dto.AssetFilterDTO{TagNames: []string{"bird"}, TagMatch: &badMatch}},
+		"confidence above 1":  {SearchType: "tag", Filter: dto.AssetFilterDTO{TagNames: []string{"bird"}, TagMinConfidence: &tooConfident}},
+		"no tags":             {SearchType: "tag"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, http.StatusBadRequest, postTagSearch(t, h, req).Code)
+		})
+	}
+}
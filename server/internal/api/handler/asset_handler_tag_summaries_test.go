@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"server/internal/api/dto"
+	"server/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+type stubTagSummaryAssetService struct {
+	service.AssetService
+	source   *string
+	category *string
+	query    *string
+	limit    int
+	offset   int
+}
+
+func (s *stubTagSummaryAssetService) ListTagSummaries(_ context.Context, _ *int32, _ *string, source, category, query *string, limit, offset int) ([]service.TagSummary, error) {
+	s.source, s.category, s.query = source, category, query
+	s.limit, s.offset = limit, offset
+	scene := "scene"
+	return []service.TagSummary{
+		{TagID: 1, TagName: "beach", Category: &scene, Source: "zeroshot", AssetCount: 12},
+		{TagID: 2, TagName: "family", Source: "user", AssetCount: 3},
+	}, nil
+}
+
+func TestGetTagSummaries_FiltersByCategoryAndSource(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	svc := &stubTagSummaryAssetService{}
+
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Request = httptest.NewRequest(http.MethodGet, "/api/v1/assets/tag-summaries?category=scene&source=zeroshot&limit=20&offset=40", nil)
+	ctx.Set("current_user", &service.UserResponse{UserID: 7, Role: "user"})
+
+	(&AssetHandler{assetService: svc}).GetTagSummaries(ctx)
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+	require.NotNil(t, svc.category)
+	require.Equal(t, "scene", *svc.category)
+	require.NotNil(t, svc.source)
+	require.Equal(t, "zeroshot", *svc.source)
+	require.Nil(t, svc.query)
+	require.Equal(t, 20, svc.limit)
+	require.Equal(t, 40, svc.offset)
+
+	var response dto.TagSummaryListResponseDTO
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	require.Len(t, response.Tags, 2)
+	require.NotNil(t, response.Tags[0].Category)
+	require.Equal(t, "scene", *response.Tags[0].Category)
+	require.Equal(t, int64(12), response.Tags[0].AssetCount)
+	require.Nil(t, response.Tags[1].Category)
+}
@@ -0,0 +1,88 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"server/internal/api/dto"
+	"server/internal/db/repo"
+	"server/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+type stubTagAssetService struct {
+	service.AssetService
+	asset repo.Asset
+	tags  map[int32]string
+	added []string
+}
+
+func (s *stubTagAssetService) GetAsset(context.Context, uuid.UUID) (*repo.Asset, error) {
+	asset := s.asset
+	return &asset, nil
+}
+
+func (s *stubTagAssetService) AddManualTagToAsset(_ context.Context, _ uuid.UUID, tagName, category string) (*repo.Tag, error) {
+	s.added = append(s.added, tagName+"/"+category)
+	id := int32(len(s.tags) + 1)
+	s.tags[id] = tagName
+	return &repo.Tag{TagID: id, TagName: tagName}, nil
+}
+
+func (s *stubTagAssetService) RemoveTagFromAsset(_ context.Context, _ uuid.UUID, tagID int) error {
+	delete(s.tags, int32(tagID))
+	return nil
+}
+
+func (s *stubTagAssetService) GetAssetTags(context.Context, uuid.UUID) (json.RawMessage, error) {
+	tags := []dto.AssetTagDTO{}
+	for id, name := range s.tags {
+		source := service.AssetTagSourceUser
+		tags = append(tags, dto.AssetTagDTO{TagID: id, TagName: name, Source: &source})
+	}
+	return json.Marshal(tags)
+}
+
+func serveAssetTagRequest(t *testing.T, h *AssetHandler, method, assetID, tagID, body string) dto.AssetTagsResponseDTO {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Params = gin.Params{{Key: "id", Value: assetID}, {Key: "tagId", Value: tagID}}
+	ctx.Request = httptest.NewRequest(method, "/api/v1/assets/"+assetID+"/tags", strings.NewReader(body))
+	ctx.Request.Header.Set("Content-Type", "application/json")
+
+	if method == http.MethodDelete {
+		h.RemoveAssetTag(ctx)
+	} else {
+		h.AddAssetTag(ctx)
+	}
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+
+	var response dto.AssetTagsResponseDTO
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	return response
+}
+
+func TestAssetTagMutationsReturnUpdatedTagList(t *testing.T) {
+	assetID := "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa"
+	svc := &stubTagAssetService{asset: testHandlerAsset(t, assetID, "photo.jpg"), tags: map[int32]string{}}
+	h := &AssetHandler{assetService: svc}
+
+	response := serveAssetTagRequest(t, h, http.MethodPost, assetID, "", `{"tag_name":"vacation","category":"travel"}`)
+	require.Equal(t, []string{"vacation/travel"}, svc.added)
+	require.Len(t, response.Tags, 1)
+	require.Equal(t, "vacation", response.Tags[0].TagName)
+	require.Equal(t, service.AssetTagSourceUser, *response.Tags[0].Source)
+
+	response = serveAssetTagRequest(t, h, http.MethodDelete, assetID, "1", "")
+	require.Empty(t, response.Tags)
+}
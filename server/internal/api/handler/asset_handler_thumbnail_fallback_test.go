@@ -0,0 +1,70 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"server/internal/db/repo"
+	"server/internal/service"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/require"
+)
+
+type stubThumbnailAssetService struct {
+	service.AssetService
+	available map[string]bool
+	err       error
+	requested []string
+}
+
+func (s *stubThumbnailAssetService) GetThumbnailByAssetIDAndSize(_ context.Context, _ uuid.UUID, size string) (*repo.Thumbnail, error) {
+	s.requested = append(s.requested, size)
+	if s.err != nil {
+		return nil, s.err
+	}
+	if !s.available[size] {
+		return nil, pgx.ErrNoRows
+	}
+	return &repo.Thumbnail{Size: size}, nil
+}
+
+func TestGetThumbnailWithFallback_ServesLargestAvailable(t *testing.T) {
+	svc := &stubThumbnailAssetService{available: map[string]bool{"small": true}}
+	h := &AssetHandler{assetService: svc}
+
+	thumbnail, err := h.getThumbnailWithFallback(context.Background(), uuid.New(), "large")
+	require.NoError(t, err)
+	require.Equal(t, "small", thumbnail.Size)
+	require.Equal(t, []string{"large", "medium", "small"}, svc.requested)
+}
+
+func TestGetThumbnailWithFallback_PrefersRequestedSize(t *testing.T) {
+	svc := &stubThumbnailAssetService{available: map[string]bool{"small": true, "medium": true, "large": true}}
+	h := &AssetHandler{assetService: svc}
+
+	thumbnail, err := h.getThumbnailWithFallback(context.Background(), uuid.New(), "medium")
+	require.NoError(t, err)
+	require.Equal(t, "medium", thumbnail.Size)
+	require.Equal(t, []string{"medium"}, svc.requested)
+}
+
+func TestGetThumbnailWithFallback_NeverServesLarger(t *testing.T) {
+	svc := &stubThumbnailAssetService{available: map[string]bool{"large": true}}
+	h := &AssetHandler{assetService: svc}
+
+	_, err := h.getThumbnailWithFallback(context.Background(), uuid.New(), "small")
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+}
+
+func TestGetThumbnailWithFallback_StopsOnLookupError(t *testing.T) {
+	lookupErr := errors.New("database unavailable")
+	svc := &stubThumbnailAssetService{err: lookupErr}
+	h := &AssetHandler{assetService: svc}
+
+	_, err := h.getThumbnailWithFallback(context.Background(), uuid.New(), "large")
+	require.ErrorIs(t, err, lookupErr)
+	require.Equal(t, []string{"large"}, svc.requested)
+}
@@ -0,0 +1,155 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"server/internal/db/repo"
+	"server/internal/processors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+// thumbnailAssetService serves one asset and the thumbnail records stored in
+// it, keyed by size.
+type thumbnailAssetService struct {
+	stubMediaAssetService
+	mu         sync.Mutex
+	thumbnails map[string]repo.Thumbnail
+}
+
+func (s *thumbnailAssetService) GetThumbnailByAssetIDAndSize(_ context.Context, _ uuid.UUID, size string) (*repo.Thumbnail, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	thumbnail, ok := s.thumbnails[size]
+	if !ok {
+		return nil, pgx.ErrNoRows
+	}
+	return &thumbnail, nil
+}
+
+// fakeThumbnailGenerator writes the requested size under root and records it
+// in service, or fails with err.
+type fakeThumbnailGenerator struct {
+	root    string
+	service *thumbnailAssetService
+	err     error
+	calls   int
+}
+
+func (g *fakeThumbnailGenerator) GenerateMissingThumbnail(_ context.Context, assetID pgtype.UUID, size string) error {
+	g.calls++
+	if g.err != nil {
+		return g.err
+	}
+	rel := filepath.Join(".lumilio/assets/thumbnails", size, "generated.webp")
+	path := filepath.Join(g.root, rel)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, []byte("generated "+size), 0o600); err != nil {
+		return err
+	}
+	g.service.mu.Lock()
+	g.service.thumbnails[size] = repo.Thumbnail{AssetID: assetID, Size: size, StoragePath: rel}
+	g.service.mu.Unlock()
+	return nil
+}
+
+func thumbnailTestHandler(t *testing.T, assetID string) (*AssetHandler, *thumbnailAssetService, string) {
+	t.Helper()
+	repositoryID := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+	root := t.TempDir()
+
+	asset := testHandlerAsset(t, assetID, "photo.jpg")
+	asset.RepositoryID = repositoryID
+	assets := &thumbnailAssetService{
+		stubMediaAssetService: stubMediaAssetService{asset: asset},
+		thumbnails:            map[string]repo.Thumbnail{},
+	}
+	h := &AssetHandler{
+		assetService: assets,
+		queries:      repo.New(repositoriesDB{roots: map[pgtype.UUID]string{repositoryID: root}}),
+	}
+	return h, assets, root
+}
+
+func getThumbnailForTest(t *testing.T, h *AssetHandler, assetID string) (int, string) {
+	t.Helper()
+	recorder := serveMediaForTest(t, h, assetID, func(h *AssetHandler, c *gin.Context) {
+		c.Request.URL.RawQuery = "size=medium"
+		h.GetAssetThumbnail(c)
+	})
+	return recorder.Code, recorder.Body.String()
+}
+
+func TestGetAssetThumbnail_GeneratesMissingThumbnail(t *testing.T) {
+	assetID := "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa"
+	h, assets, root := thumbnailTestHandler(t, assetID)
+	generator := &fakeThumbnailGenerator{root: root, service: assets}
+	h.thumbnailGenerator = generator
+
+	code, body := getThumbnailForTest(t, h, assetID)
+	require.Equal(t, http.StatusOK, code, body)
+	require.Equal(t, "generated medium", body)
+
+	// The second request finds the saved thumbnail.
+	code, body = getThumbnailForTest(t, h, assetID)
+	require.Equal(t, http.StatusOK, code, body)
+	require.Equal(t, 1, generator.calls)
+}
+
+func TestGetAssetThumbnail_RegeneratesDeletedFile(t *testing.T) {
+	assetID := "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa"
+	h, assets, root := thumbnailTestHandler(t, assetID)
+	assets.thumbnails["medium"] = repo.Thumbnail{Size: "medium", StoragePath: ".lumilio/assets/thumbnails/medium/gone.webp"}
+	h.thumbnailGenerator = &fakeThumbnailGenerator{root: root, service: assets}
+
+	code, body := getThumbnailForTest(t, h, assetID)
+	require.Equal(t, http.StatusOK, code, body)
+	require.Equal(t, "generated medium", body)
+}
+
+func TestGetAssetThumbnail_NotFoundWhenGenerationImpossible(t *testing.T) {
+	assetID := "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa"
+	for _, generator := range []AssetThumbnailGenerator{
+		nil,
+		&fakeThumbnailGenerator{err: processors.ErrOriginalMissing},
+		&fakeThumbnailGenerator{err: processors.ErrThumbnailUnsupported},
+	} {
+		h, _, _ := thumbnailTestHandler(t, assetID)
+		h.thumbnailGenerator = generator
+
+		code, body := getThumbnailForTest(t, h, assetID)
+		require.Equal(t, http.StatusNotFound, code, body)
+	}
+}
+
+func TestGetAssetThumbnail_DownloadSetsAttachment(t *testing.T) {
+	assetID := "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa"
+	h, assets, root := thumbnailTestHandler(t, assetID)
+	h.thumbnailGenerator = &fakeThumbnailGenerator{root: root, service: assets}
+
+	recorder := serveMediaForTest(t, h, assetID, func(h *AssetHandler, c *gin.Context) {
+		c.Request.URL.RawQuery = "size=medium&download=true"
+		h.GetAssetThumbnail(c)
+	})
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+	require.Equal(t, `attachment; filename="photo_medium.webp"`, recorder.Header().Get("Content-Disposition"))
+
+	// Inline stays the default.
+	recorder = serveMediaForTest(t, h, assetID, func(h *AssetHandler, c *gin.Context) {
+		c.Request.URL.RawQuery = "size=medium"
+		h.GetAssetThumbnail(c)
+	})
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+	require.Empty(t, recorder.Header().Get("Content-Disposition"))
+}
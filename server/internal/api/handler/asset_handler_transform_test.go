@@ -0,0 +1,116 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"server/internal/api/dto"
+	"server/internal/db/repo"
+	"server/internal/processors"
+	"server/internal/utils/exif"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+type stubAssetTransformer struct {
+	transformFn func(ctx context.Context, assetID pgtype.UUID, transform exif.Transform) (*repo.Asset, error)
+}
+
+func (s stubAssetTransformer) TransformAsset(ctx context.Context, assetID pgtype.UUID, transform exif.Transform) (*repo.Asset, error) {
+	return s.transformFn(ctx, assetID, transform)
+}
+
+func transformAssetForTest(t *testing.T, h *AssetHandler, assetID, path, query string, handle func(*AssetHandler, *gin.Context)) *httptest.ResponseRecorder {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Params = gin.Params{{Key: "id", Value: assetID}}
+	ctx.Request = httptest.NewRequest(http.MethodPost, "/api/v1/assets/"+assetID+"/"+path+"?"+query, nil)
+
+	handle(h, ctx)
+	return recorder
+}
+
+func TestRotateAsset_Rotate90ReturnsSwappedDimensions(t *testing.T) {
+	assetID := "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa"
+	width, height := int32(4000), int32(3000)
+	asset := testHandlerAsset(t, assetID, "photo.jpg")
+	asset.Width = &width
+	asset.Height = &height
+
+	transformer := stubAssetTransformer{transformFn: func(_ context.Context, id pgtype.UUID, transform exif.Transform) (*repo.Asset, error) {
+		require.Equal(t, asset.AssetID, id)
+		require.Equal(t, exif.TransformRotate90, transform)
+		rotated := asset
+		rotated.Width = &height
+		rotated.Height = &width
+		return &rotated, nil
+	}}
+
+	recorder := transformAssetForTest(t, &AssetHandler{
+		assetService:     stubRefreshAssetService{asset: asset},
+		assetTransformer: transformer,
+	}, assetID, "rotate", "degrees=90", (*AssetHandler).RotateAsset)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var response dto.AssetDTO
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	require.NotNil(t, response.Width)
+	require.NotNil(t, response.Height)
+	require.Equal(t, int32(3000), *response.Width)
+	require.Equal(t, int32(4000), *response.Height)
+}
+
+func TestFlipAsset_PassesDirection(t *testing.T) {
+	assetID := "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa"
+	asset := testHandlerAsset(t, assetID, "photo.png")
+
+	var got exif.Transform
+	transformer := stubAssetTransformer{transformFn: func(_ context.Context, _ pgtype.UUID, transform exif.Transform) (*repo.Asset, error) {
+		got = transform
+		return &asset, nil
+	}}
+
+	recorder := transformAssetForTest(t, &AssetHandler{
+		assetService:     stubRefreshAssetService{asset: asset},
+		assetTransformer: transformer,
+	}, assetID, "flip", "direction=vertical", (*AssetHandler).FlipAsset)
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Equal(t, exif.TransformFlipVertical, got)
+}
+
+func TestRotateAsset_RejectsInvalidDegrees(t *testing.T) {
+	assetID := "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa"
+	for _, query := range []string{"", "degrees=45", "degrees=-90"} {
+		recorder := transformAssetForTest(t, &AssetHandler{}, assetID, "rotate", query, (*AssetHandler).RotateAsset)
+		require.Equal(t, http.StatusBadRequest, recorder.Code, query)
+	}
+	recorder := transformAssetForTest(t, &AssetHandler{}, assetID, "flip", "direction=diagonal", (*AssetHandler).FlipAsset)
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+}
+
+func TestRotateAsset_MapsProcessorErrors(t *testing.T) {
+	assetID := "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa"
+	cases := map[error]int{
+		fmt.Errorf("%w: photo.jpg", processors.ErrOriginalMissing):       http.StatusNotFound,
+		fmt.Errorf("%w: image/heic", processors.ErrTransformUnsupported): http.StatusBadRequest,
+	}
+	for transformErr, status := range cases {
+		transformer := stubAssetTransformer{transformFn: func(context.Context, pgtype.UUID, exif.Transform) (*repo.Asset, error) {
+			return nil, transformErr
+		}}
+		recorder := transformAssetForTest(t, &AssetHandler{
+			assetService:     stubRefreshAssetService{asset: testHandlerAsset(t, assetID, "photo.jpg")},
+			assetTransformer: transformer,
+		}, assetID, "rotate", "degrees=180", (*AssetHandler).RotateAsset)
+		require.Equal(t, status, recorder.Code, transformErr.Error())
+	}
+}
@@ -0,0 +1,105 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"server/internal/api/dto"
+	"server/internal/db/repo"
+	"server/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+type stubUnalbumedAssetService struct {
+	service.AssetService
+	assets       []repo.Asset
+	calls        int
+	ownerID      *int32
+	repositoryID *string
+	limit        int
+	offset       int
+}
+
+func (s *stubUnalbumedAssetService) ListUnalbumedAssets(_ context.Context, ownerID *int32, repositoryID *string, limit, offset int) ([]repo.Asset, error) {
+	s.calls++
+	s.ownerID = ownerID
+	s.repositoryID = repositoryID
+	s.limit = limit
+	s.offset = offset
+	return s.assets, nil
+}
+
+func listUnalbumedForTest(t *testing.T, svc *stubUnalbumedAssetService, user *service.UserResponse, query string) *httptest.ResponseRecorder {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Request = httptest.NewRequest(http.MethodGet, "/api/v1/assets/unalbumed?"+query, nil)
+	if user != nil {
+		ctx.Set("current_user", user)
+	}
+
+	(&AssetHandler{assetService: svc}).ListUnalbumedAssets(ctx)
+	return recorder
+}
+
+func TestListUnalbumedAssets_ScopesUsersToTheirOwnAssets(t *testing.T) {
+	svc := &stubUnalbumedAssetService{assets: []repo.Asset{testHandlerAsset(t, "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa", "orphan.jpg")}}
+	user := &service.UserResponse{UserID: 7, Role: "user"}
+
+	recorder := listUnalbumedForTest(t, svc, user, "repository_id=bbbbbbbb-bbbb-bbbb-bbbb-bbbbbbbbbbbb&limit=10&offset=20")
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+	require.NotNil(t, svc.ownerID)
+	require.Equal(t, int32(7), *svc.ownerID)
+	require.NotNil(t, svc.repositoryID)
+	require.Equal(t, "bbbbbbbb-bbbb-bbbb-bbbb-bbbbbbbbbbbb", *svc.repositoryID)
+	require.Equal(t, 10, svc.limit)
+	require.Equal(t, 20, svc.offset)
+
+	var response dto.AssetListResponseDTO
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	require.Len(t, response.Assets, 1)
+	require.Equal(t, "orphan.jpg", response.Assets[0].OriginalFilename)
+
+	recorder = listUnalbumedForTest(t, svc, user, "owner_id=7")
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+
+	svc.calls = 0
+	recorder = listUnalbumedForTest(t, svc, user, "owner_id=8")
+	require.Equal(t, http.StatusForbidden, recorder.Code)
+	require.Zero(t, svc.calls)
+}
+
+func TestListUnalbumedAssets_AdminMayFilterByOwner(t *testing.T) {
+	svc := &stubUnalbumedAssetService{}
+	admin := &service.UserResponse{UserID: 1, Role: "admin"}
+
+	recorder := listUnalbumedForTest(t, svc, admin, "")
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+	require.Nil(t, svc.ownerID, "admins see every owner by default")
+	require.Equal(t, 50, svc.limit)
+
+	recorder = listUnalbumedForTest(t, svc, admin, "owner_id=8")
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+	require.NotNil(t, svc.ownerID)
+	require.Equal(t, int32(8), *svc.ownerID)
+}
+
+func TestListUnalbumedAssets_RejectsInvalidParameters(t *testing.T) {
+	user := &service.UserResponse{UserID: 7, Role: "user"}
+	for _, query := range []string{"owner_id=abc", "owner_id=0", "repository_id=nope", "limit=0", "offset=-1"} {
+		svc := &stubUnalbumedAssetService{}
+		recorder := listUnalbumedForTest(t, svc, user, query)
+		require.Equal(t, http.StatusBadRequest, recorder.Code, query)
+		require.Zero(t, svc.calls, query)
+	}
+
+	recorder := listUnalbumedForTest(t, &stubUnalbumedAssetService{}, nil, "")
+	require.Equal(t, http.StatusUnauthorized, recorder.Code)
+}
@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"server/internal/db/dbtypes"
+	"server/internal/db/repo"
+	"server/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+// stubMetadataAssetService validates like the real service but records
+// instead of writing.
+type stubMetadataAssetService struct {
+	service.AssetService
+	asset   repo.Asset
+	updated dbtypes.SpecificMetadata
+}
+
+func (s *stubMetadataAssetService) GetAsset(_ context.Context, _ uuid.UUID) (*repo.Asset, error) {
+	return &s.asset, nil
+}
+
+func (s *stubMetadataAssetService) UpdateAssetMetadata(_ context.Context, _ uuid.UUID, metadata dbtypes.SpecificMetadata) error {
+	if err := service.ValidateSpecificMetadata(dbtypes.AssetType(s.asset.Type), metadata); err != nil {
+		return err
+	}
+	s.updated = metadata
+	return nil
+}
+
+func updateAssetForTest(t *testing.T, svc *stubMetadataAssetService, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	id := "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa"
+	ctx.Request = httptest.NewRequest(http.MethodPut, "/api/v1/assets/"+id, strings.NewReader(body))
+	ctx.Request.Header.Set("Content-Type", "application/json")
+	ctx.Params = gin.Params{{Key: "id", Value: id}}
+
+	(&AssetHandler{assetService: svc}).UpdateAsset(ctx)
+	return recorder
+}
+
+func TestUpdateAsset_RejectsMalformedPhotoMetadata(t *testing.T) {
+	for body, want := range map[string]string{
+		`{"specific_metadata":{"taken_time":"yesterday"}}`: "taken_time: must be an RFC 3339 timestamp",
+		`{"specific_metadata":{"f_number":"wide"}}`:        "f_number: must be a number",
+		`{"specific_metadata":{"gps_latitude":123.4}}`:     "gps_latitude: must be between -90 and 90",
+		`{"specific_metadata":"2024-06-15T10:30:00Z"}`:     "must be a JSON object",
+		`{"specific_metadata":[{"camera_model":"X100V"}]}`: "must be a JSON object",
+	} {
+		svc := &stubMetadataAssetService{asset: testHandlerAsset(t, "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa", "photo.jpg")}
+		recorder := updateAssetForTest(t, svc, body)
+		require.Equal(t, http.StatusBadRequest, recorder.Code, body)
+		require.Contains(t, recorder.Body.String(), want, body)
+		require.Nil(t, svc.updated, body)
+	}
+}
+
+func TestUpdateAsset_KeepsCustomMetadataFields(t *testing.T) {
+	svc := &stubMetadataAssetService{asset: testHandlerAsset(t, "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa", "photo.jpg")}
+	body := `{"specific_metadata":{"taken_time":"2024-06-15T10:30:00Z","film_simulation":"Classic Chrome"}}`
+
+	recorder := updateAssetForTest(t, svc, body)
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+	require.JSONEq(t, `{"taken_time":"2024-06-15T10:30:00Z","film_simulation":"Classic Chrome"}`, string(svc.updated))
+}
@@ -39,3 +39,12 @@ func TestUploadJobStatusForCallerReportsRunningAsNonTerminal(t *testing.T) {
 	require.False(t, status.Terminal)
 	require.False(t, status.Success)
 }
+
+func TestBelowMinFileSizeSkipsTinyUploads(t *testing.T) {
+	handler := &AssetHandler{minFileSize: 10 * 1024}
+	require.True(t, handler.belowMinFileSize(200))
+	require.False(t, handler.belowMinFileSize(10*1024))
+
+	disabled := &AssetHandler{}
+	require.False(t, disabled.belowMinFileSize(200))
+}
@@ -0,0 +1,91 @@
+package handler
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"server/internal/api"
+	"server/internal/storage"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// GetVideoHLS serves a video's HLS playlist and segments by asset ID
+// @Summary Get HLS video stream
+// @Description Serve the HLS playlist (index.m3u8) or one of its MPEG-TS segments for a video. Only long videos transcoded with transcode.hls_enabled have a stream; players should fall back to /video/web on 404. Segment references in the playlist are relative to it.
+// @Tags assets
+// @Produce application/vnd.apple.mpegurl
+// @Produce video/mp2t
+// @Param id path string true "Asset ID (UUID format)" example("550e8400-e29b-41d4-a716-446655440000")
+// @Param file path string true "index.m3u8 or a segment file name"
+// @Success 200 {file} file "Playlist or segment"
+// @Failure 400 {object} api.ErrorResponse "Invalid asset ID or not a video"
+// @Failure 404 {object} api.ErrorResponse "Asset or HLS stream not found"
+// @Failure 410 {object} api.ErrorResponse "Asset's repository was removed"
+// @Router /api/v1/assets/{id}/video/hls/{file} [get]
+func (h *AssetHandler) GetVideoHLS(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		api.GinBadRequest(c, err, "Invalid asset ID")
+		return
+	}
+	name := strings.TrimPrefix(c.Param("file"), "/")
+	contentType, ok := hlsContentType(name)
+	if !ok {
+		api.GinNotFound(c, errors.New("unknown hls file"), "HLS stream not found")
+		return
+	}
+
+	asset, ok := h.getAuthorizedAssetForMedia(c, id, "Authentication required to access this video", "You don't have permission to access this video")
+	if !ok {
+		return
+	}
+	if asset.Type != "VIDEO" {
+		api.GinBadRequest(c, errors.New("asset is not a video"), "Asset is not a video")
+		return
+	}
+
+	repository, err := h.getRepositoryForAsset(ctx, asset)
+	if err != nil {
+		respondRepositoryResolveError(c, err, "Failed to access repository")
+		return
+	}
+	fullPath := filepath.Join(storage.HLSDir(repository.Path, asset.AssetID.String()), name)
+	if _, err := os.Stat(fullPath); err != nil {
+		api.GinNotFound(c, err, "HLS stream not found")
+		return
+	}
+
+	// Segments never change once written; the playlist is replaced when the
+	// video is transcoded again.
+	if contentType == hlsPlaylistContentType {
+		c.Header("Cache-Control", "no-cache")
+	} else {
+		c.Header("Cache-Control", "public, max-age=86400")
+	}
+	c.Header("Content-Type", contentType)
+	c.File(fullPath)
+}
+
+const hlsPlaylistContentType = "application/vnd.apple.mpegurl"
+
+// hlsContentType returns the MIME type of a file in an HLS directory, or
+// false for anything but a plain playlist or segment name.
+func hlsContentType(name string) (string, bool) {
+	if name == "" || strings.HasPrefix(name, ".") || strings.ContainsAny(name, `/\`) {
+		return "", false
+	}
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".m3u8":
+		return hlsPlaylistContentType, true
+	case ".ts":
+		return "video/mp2t", true
+	default:
+		return "", false
+	}
+}
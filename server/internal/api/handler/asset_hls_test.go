@@ -0,0 +1,61 @@
+package handler
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"server/internal/db/repo"
+	"server/internal/storage"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetVideoHLS_ServesPlaylistAndSegments(t *testing.T) {
+	assetID := "eeeeeeee-eeee-eeee-eeee-eeeeeeeeeeee"
+	repositoryID := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+	root := t.TempDir()
+
+	storagePath := "clip.mov"
+	video := testHandlerAsset(t, assetID, "clip.mov")
+	video.Type = "VIDEO"
+	video.StoragePath = &storagePath
+	video.RepositoryID = repositoryID
+
+	dir := storage.HLSDir(root, assetID)
+	require.NoError(t, os.MkdirAll(dir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, storage.HLSPlaylistName), []byte("#EXTM3U\nsegment_00000.ts\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "segment_00000.ts"), []byte("segment"), 0o644))
+	// A file outside the HLS directory must stay unreachable.
+	require.NoError(t, os.WriteFile(filepath.Join(root, "secret.ts"), []byte("secret"), 0o644))
+
+	h := &AssetHandler{
+		assetService: stubMediaAssetService{asset: video},
+		queries:      repo.New(repositoriesDB{roots: map[pgtype.UUID]string{repositoryID: root}}),
+	}
+	serveFile := func(file string) func(*AssetHandler, *gin.Context) {
+		return func(h *AssetHandler, c *gin.Context) {
+			c.Params = append(c.Params, gin.Param{Key: "file", Value: file})
+			h.GetVideoHLS(c)
+		}
+	}
+
+	recorder := serveMediaForTest(t, h, assetID, serveFile("/index.m3u8"))
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+	require.Equal(t, "application/vnd.apple.mpegurl", recorder.Header().Get("Content-Type"))
+	require.Equal(t, "#EXTM3U\nsegment_00000.ts\n", recorder.Body.String())
+
+	recorder = serveMediaForTest(t, h, assetID, serveFile("/segment_00000.ts"))
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+	require.Equal(t, "video/mp2t", recorder.Header().Get("Content-Type"))
+	require.Equal(t, "segment", recorder.Body.String())
+
+	for _, file := range []string{"/segment_00001.ts", "/../../../../../secret.ts", "/sub/segment_00000.ts", "/clip.mov", "/"} {
+		recorder = serveMediaForTest(t, h, assetID, serveFile(file))
+		require.Equal(t, http.StatusNotFound, recorder.Code, file)
+	}
+}
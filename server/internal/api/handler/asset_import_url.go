@@ -0,0 +1,228 @@
+package handler
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"server/internal/api"
+	"server/internal/api/dto"
+	"server/internal/queue/jobs"
+	filevalidator "server/internal/utils/file"
+	"server/internal/utils/hash"
+	"server/internal/utils/urlfetch"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/riverqueue/river"
+)
+
+// urlImportTimeout bounds one whole URL import, download included.
+const urlImportTimeout = 10 * time.Minute
+
+// ImportAssetFromURL pulls a remote file into a repository and queues it
+// @Summary Import an asset from a URL
+// @Description Download a photo, video or audio file from a public http(s) URL into a repository's staging area and queue it for processing like an upload. Loopback, private, link-local and other internal addresses are refused, including through redirects and DNS; storage.url_import_allowed_hosts can narrow the hosts further. The file type comes from file_name, else the Content-Disposition or URL path, and payloads that are text or markup (an HTML error page, say) are refused.
+// @Tags assets
+// @Accept json
+// @Produce json
+// @Param request body dto.ImportAssetFromURLRequestDTO true "URL to import"
+// @Success 200 {object} dto.UploadResponseDTO "Import queued, or skipped as a duplicate"
+// @Failure 400 {object} api.ErrorResponse "Invalid or forbidden URL, or unsupported file type"
+// @Failure 401 {object} api.ErrorResponse "Authentication required"
+// @Failure 403 {object} api.ErrorResponse "URL imports are disabled"
+// @Failure 404 {object} api.ErrorResponse "Repository not found"
+// @Failure 413 {object} api.ErrorResponse "Remote file exceeds storage.url_import_max_bytes"
+// @Failure 502 {object} api.ErrorResponse "Remote server could not be fetched"
+// @Failure 429 {object} api.ErrorResponse "Too many concurrent uploads for this user; see Retry-After"
+// @Failure 500 {object} api.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /api/v1/assets/from-url [post]
+func (h *AssetHandler) ImportAssetFromURL(c *gin.Context) {
+	if !h.urlFetcher.Enabled() {
+		api.GinForbidden(c, errors.New("url imports are disabled"), "URL imports are disabled on this server")
+		return
+	}
+
+	var req dto.ImportAssetFromURLRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		api.GinBadRequest(c, err, "Invalid request")
+		return
+	}
+	remoteURL, err := h.urlFetcher.ParseURL(req.URL)
+	if err != nil {
+		api.GinBadRequest(c, err, "URL cannot be imported")
+		return
+	}
+	if name := strings.TrimSpace(req.FileName); name != "" {
+		if result := filevalidator.ValidateFile(name, ""); !result.Valid {
+			api.GinBadRequest(c, fmt.Errorf("unsupported file type: %s", result.ErrorReason))
+			return
+		}
+	}
+	repository, err := h.resolveUploadRepository(c.Request.Context(), req.RepositoryID)
+	if err != nil {
+		h.respondRepositoryError(c, err)
+		return
+	}
+
+	release, ok := h.acquireUploadSlot(c)
+	if !ok {
+		return
+	}
+	defer release()
+	h.uploadLimiter <- struct{}{}
+	defer func() { <-h.uploadLimiter }()
+
+	ctx := c.Request.Context()
+	fetchCtx, cancel := context.WithTimeout(ctx, urlImportTimeout)
+	defer cancel()
+
+	download, err := h.urlFetcher.Open(fetchCtx, remoteURL)
+	if err != nil {
+		respondURLImportError(c, err)
+		return
+	}
+	defer download.Body.Close()
+
+	filename := strings.TrimSpace(req.FileName)
+	if filename == "" {
+		filename = download.Filename
+	}
+	validationResult := filevalidator.ValidateFile(filename, download.ContentType)
+	if !validationResult.Valid {
+		api.GinBadRequest(c, fmt.Errorf("unsupported file type: %s", validationResult.ErrorReason), "Unsupported file type; pass file_name when the URL does not end in a media file name")
+		return
+	}
+
+	// The declared type is only the server's claim; sniff the leading bytes
+	// too so an HTML page or JSON error served under a .jpg URL is refused
+	// before anything is staged.
+	body := bufio.NewReaderSize(download.Body, 512)
+	head, err := body.Peek(512)
+	if err != nil && !errors.Is(err, io.EOF) {
+		respondURLImportError(c, err)
+		return
+	}
+	if !importableContentType(download.ContentType) || !importableContentType(http.DetectContentType(head)) {
+		api.GinBadRequest(c, fmt.Errorf("remote content type %q is not media", download.ContentType), "The URL does not serve a media file")
+		return
+	}
+
+	stagingFile, err := h.stagingManager.CreateStagingFile(repository.Path, filename)
+	if err != nil {
+		log.Printf("Failed to create staging file: %v", err)
+		api.GinInternalError(c, err, "Import failed")
+		return
+	}
+	osFile, err := os.Create(stagingFile.Path)
+	if err != nil {
+		log.Printf("Failed to open staging file: %v", err)
+		api.GinInternalError(c, err, "Import failed")
+		return
+	}
+
+	hasher := hash.NewLayeredWriter()
+	size, err := io.Copy(io.MultiWriter(osFile, hasher), body)
+	osFile.Close()
+	if err != nil {
+		h.removeUploadTempFile(stagingFile.Path)
+		respondURLImportError(c, err)
+		return
+	}
+	if h.belowMinFileSize(size) {
+		h.removeUploadTempFile(stagingFile.Path)
+		api.JSONOK(c, dto.UploadResponseDTO{Status: uploadStatusSkipped, FileName: filename, Size: size, Message: "File is smaller than the configured minimum size"})
+		return
+	}
+
+	hashResult := hasher.Result()
+	duplicate, err := h.findDuplicateByHash(ctx, hashResult.ContentHash, hashResult.FileSize, repository.RepoID)
+	if err != nil {
+		h.handleUploadFailureFile(repository.Path, stagingFile.Path, filename, "check duplicate content")
+		api.GinInternalError(c, err, "Failed to check for duplicate content")
+		return
+	}
+	if duplicate != nil {
+		h.removeUploadTempFile(stagingFile.Path)
+		api.JSONOK(c, dto.UploadResponseDTO{Status: uploadStatusDuplicate, FileName: filename, Size: size, ContentHash: hashResult.ContentHash, Message: "File already exists in repository"})
+		return
+	}
+
+	userID := "anonymous"
+	if id, exists := c.Get("user_id"); exists {
+		userID = fmt.Sprintf("%d", id)
+	}
+
+	jobInsertResult, err := h.queueClient.Insert(ctx, jobs.IngestAssetArgs{
+		ContentHash:      hashResult.ContentHash,
+		QuickFingerprint: valueOrEmpty(hashResult.QuickFingerprint),
+		StagedPath:       stagingFile.Path,
+		UserID:           userID,
+		Timestamp:        time.Now(),
+		ContentType:      validationResult.MimeType,
+		FileName:         filename,
+		RepositoryID:     uuid.UUID(repository.RepoID.Bytes).String(),
+	}, &river.InsertOpts{Queue: "ingest_asset"})
+	if err != nil || jobInsertResult == nil || jobInsertResult.Job == nil {
+		if err == nil {
+			err = errors.New("enqueue returned empty result")
+		}
+		log.Printf("Failed to enqueue URL import: %v", err)
+		h.handleUploadFailureFile(repository.Path, stagingFile.Path, filename, "enqueue ingest task")
+		api.GinInternalError(c, err, "Import failed")
+		return
+	}
+	log.Printf("Task %d enqueued for URL import of %s into repository %s", jobInsertResult.Job.ID, filename, repository.Name)
+
+	api.JSONOK(c, dto.UploadResponseDTO{
+		TaskID:      jobInsertResult.Job.ID,
+		Status:      "processing",
+		FileName:    filename,
+		Size:        size,
+		ContentHash: hashResult.ContentHash,
+		Message:     fmt.Sprintf("File downloaded and queued for processing in repository '%s'", repository.Name),
+	})
+}
+
+// respondURLImportError maps a fetch failure to a response: the client's
+// fault for refused destinations, 413 for oversize files, 502 otherwise.
+func respondURLImportError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, urlfetch.ErrForbiddenAddress),
+		errors.Is(err, urlfetch.ErrHostNotAllowed),
+		errors.Is(err, urlfetch.ErrInvalidURL):
+		api.GinBadRequest(c, err, "URL cannot be imported")
+	case errors.Is(err, urlfetch.ErrTooLarge):
+		api.GinError(c, http.StatusRequestEntityTooLarge, err, http.StatusRequestEntityTooLarge, "Remote file exceeds the import size limit")
+	default:
+		api.GinError(c, http.StatusBadGateway, err, http.StatusBadGateway, "Failed to download the remote file")
+	}
+}
+
+// importableContentType reports whether a declared or sniffed content type
+// may be a media file. Servers label media inconsistently (RAW files are
+// usually application/octet-stream), so only types that are certainly not
+// media are refused.
+func importableContentType(contentType string) bool {
+	contentType = strings.ToLower(strings.TrimSpace(contentType))
+	if i := strings.IndexByte(contentType, ';'); i >= 0 {
+		contentType = strings.TrimSpace(contentType[:i])
+	}
+	switch {
+	case strings.HasPrefix(contentType, "text/"):
+		return false
+	case contentType == "application/json", contentType == "application/xml",
+		contentType == "application/xhtml+xml", contentType == "application/javascript",
+		contentType == "application/pdf":
+		return false
+	}
+	return true
+}
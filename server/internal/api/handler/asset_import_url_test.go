@@ -0,0 +1,74 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"server/internal/utils/urlfetch"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func importFromURLForTest(t *testing.T, h *AssetHandler, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/api/v1/assets/from-url", h.ImportAssetFromURL)
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodPost, "/api/v1/assets/from-url", strings.NewReader(body))
+	request.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(recorder, request)
+	return recorder
+}
+
+// Internal destinations are refused before any repository lookup or
+// request, so the handler needs nothing but the fetcher here.
+func TestImportAssetFromURL_RejectsInternalURLs(t *testing.T) {
+	h := &AssetHandler{urlFetcher: urlfetch.New(1<<20, nil), uploadLimiter: make(chan struct{}, 1)}
+	for _, target := range []string{
+		"http://127.0.0.1/photo.jpg",
+		"http://127.0.0.1:5432/",
+		"http://[::1]/photo.jpg",
+		"http://10.0.0.8/photo.jpg",
+		"http://192.168.1.20/photo.jpg",
+		"http://169.254.169.254/latest/meta-data/iam/",
+		"http://[::ffff:127.0.0.1]/photo.jpg",
+		"http://0.0.0.0:8080/photo.jpg",
+		"file:///etc/passwd",
+		"gopher://example.com/",
+	} {
+		recorder := importFromURLForTest(t, h, `{"url":"`+target+`"}`)
+		require.Equal(t, http.StatusBadRequest, recorder.Code, "%s: %s", target, recorder.Body.String())
+	}
+}
+
+func TestImportAssetFromURL_RejectsHostsOutsideAllowlist(t *testing.T) {
+	h := &AssetHandler{urlFetcher: urlfetch.New(1<<20, []string{"*.example.com"}), uploadLimiter: make(chan struct{}, 1)}
+	recorder := importFromURLForTest(t, h, `{"url":"https://example.org/photo.jpg"}`)
+	require.Equal(t, http.StatusBadRequest, recorder.Code, recorder.Body.String())
+}
+
+func TestImportAssetFromURL_DisabledWithoutSizeLimit(t *testing.T) {
+	for _, fetcher := range []*urlfetch.Fetcher{nil, urlfetch.New(0, nil)} {
+		recorder := importFromURLForTest(t, &AssetHandler{urlFetcher: fetcher}, `{"url":"https://example.com/photo.jpg"}`)
+		require.Equal(t, http.StatusForbidden, recorder.Code, recorder.Body.String())
+	}
+}
+
+func TestImportableContentType(t *testing.T) {
+	for contentType, want := range map[string]bool{
+		"image/jpeg":                true,
+		"video/mp4":                 true,
+		"application/octet-stream":  true,
+		"":                          true,
+		"text/html; charset=utf-8":  false,
+		"text/plain; charset=utf-8": false,
+		"application/json":          false,
+		"Application/XML":           false,
+	} {
+		require.Equal(t, want, importableContentType(contentType), contentType)
+	}
+}
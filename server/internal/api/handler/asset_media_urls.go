@@ -0,0 +1,129 @@
+package handler
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"server/internal/api/dto"
+	"server/internal/db/dbtypes"
+	"server/internal/db/repo"
+
+	"github.com/gin-gonic/gin"
+)
+
+// assetThumbnailSizes lists the sizes served by GET /assets/{id}/thumbnail.
+var assetThumbnailSizes = []string{"small", "medium", "large"}
+
+// assetMediaURLSigner attaches media URLs to asset DTOs. The media token is
+// scoped to the user rather than the asset, so one token signs a whole
+// response. An empty token yields unsigned URLs, which still resolve for
+// assets without an owner.
+type assetMediaURLSigner struct {
+	token     string
+	expiresAt *time.Time
+}
+
+// mediaURLSigner mints a media token for the current user. Anonymous callers
+// get an unsigned signer.
+func (h *AssetHandler) mediaURLSigner(c *gin.Context) (assetMediaURLSigner, error) {
+	user, ok := currentUserFromContext(c)
+	if !ok || h.authService == nil {
+		return assetMediaURLSigner{}, nil
+	}
+	token, expiresAt, err := h.authService.GenerateMediaToken(c.Request.Context(), user.UserID)
+	if err != nil {
+		return assetMediaURLSigner{}, err
+	}
+	return assetMediaURLSigner{token: token, expiresAt: &expiresAt}, nil
+}
+
+func (s assetMediaURLSigner) url(path string, query url.Values) string {
+	if s.token != "" {
+		if query == nil {
+			query = url.Values{}
+		}
+		query.Set("mt", s.token)
+	}
+	if len(query) == 0 {
+		return path
+	}
+	return path + "?" + query.Encode()
+}
+
+// sign fills the URL fields of asset. Web URLs are only set for video and
+// audio, and thumbnail URLs are skipped for audio which has none.
+func (s assetMediaURLSigner) sign(asset *dto.AssetDTO) {
+	if asset == nil || asset.AssetID == "" {
+		return
+	}
+	base := "/api/v1/assets/" + asset.AssetID
+
+	original := s.url(base+"/original", nil)
+	asset.OriginalURL = &original
+
+	switch dbtypes.AssetType(asset.Type) {
+	case dbtypes.AssetTypeVideo:
+		web := s.url(base+"/video/web", nil)
+		asset.WebURL = &web
+	case dbtypes.AssetTypeAudio:
+		web := s.url(base+"/audio/web", nil)
+		asset.WebURL = &web
+	}
+
+	if dbtypes.AssetType(asset.Type) != dbtypes.AssetTypeAudio {
+		asset.ThumbnailURLs = make(map[string]string, len(assetThumbnailSizes))
+		for _, size := range assetThumbnailSizes {
+			asset.ThumbnailURLs[size] = s.url(base+"/thumbnail", url.Values{"size": {size}})
+		}
+	}
+
+	asset.URLsExpireAt = s.expiresAt
+}
+
+// signBrowseItems signs every asset in a browse page, including stack covers.
+func (s assetMediaURLSigner) signBrowseItems(items []dto.BrowseItemDTO) {
+	for i := range items {
+		s.sign(items[i].Asset)
+		if items[i].Stack != nil {
+			s.sign(&items[i].Stack.CoverAsset)
+		}
+	}
+}
+
+// srcset builds the responsive image candidates for an asset from its stored
+// thumbnails. Thumbnails without recorded dimensions (generated before they
+// were stored) are left out, as is a size no wider than the one before it,
+// since srcset width descriptors must be distinct.
+func (s assetMediaURLSigner) srcset(assetID string, thumbnails []repo.Thumbnail) dto.AssetSrcsetResponseDTO {
+	base := "/api/v1/assets/" + assetID
+	sources := make([]dto.AssetSrcsetSourceDTO, 0, len(thumbnails))
+	for _, thumbnail := range thumbnails {
+		if !slices.Contains(assetThumbnailSizes, thumbnail.Size) || thumbnail.Width == nil || thumbnail.Height == nil {
+			continue
+		}
+		sources = append(sources, dto.AssetSrcsetSourceDTO{
+			Size:   thumbnail.Size,
+			Width:  *thumbnail.Width,
+			Height: *thumbnail.Height,
+			URL:    s.url(base+"/thumbnail", url.Values{"size": {thumbnail.Size}}),
+		})
+	}
+	slices.SortStableFunc(sources, func(a, b dto.AssetSrcsetSourceDTO) int {
+		return int(a.Width - b.Width)
+	})
+
+	response := dto.AssetSrcsetResponseDTO{AssetID: assetID, Sources: sources[:0], URLsExpireAt: s.expiresAt}
+	candidates := make([]string, 0, len(sources))
+	for _, source := range sources {
+		if n := len(response.Sources); n > 0 && response.Sources[n-1].Width >= source.Width {
+			continue
+		}
+		response.Sources = append(response.Sources, source)
+		candidates = append(candidates, fmt.Sprintf("%s %dw", source.URL, source.Width))
+	}
+	response.Srcset = strings.Join(candidates, ", ")
+	return response
+}
@@ -0,0 +1,153 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"server/internal/api/dto"
+	"server/internal/db/repo"
+	"server/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func queryAssetsForURLTest(t *testing.T, includeURLs bool) dto.QueryAssetsResponseDTO {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	asset := testHandlerAsset(t, "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa", "a.jpg")
+	handler := &AssetHandler{
+		assetService: stubAssetService{
+			queryBrowseFn: func(context.Context, service.QueryAssetsParams) (service.BrowseQueryResult, error) {
+				return service.BrowseQueryResult{
+					Items:        []service.BrowseItem{{Type: "asset", ID: "asset:a", Asset: asset}},
+					TotalVisible: 1,
+					TotalAssets:  1,
+				}, nil
+			},
+		},
+	}
+
+	body, err := json.Marshal(dto.AssetQueryRequestDTO{
+		IncludeURLs: includeURLs,
+		Pagination:  dto.PaginationDTO{Limit: 20},
+	})
+	require.NoError(t, err)
+
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Request = httptest.NewRequest(http.MethodPost, "/api/v1/assets/list", bytes.NewReader(body))
+	ctx.Request.Header.Set("Content-Type", "application/json")
+
+	handler.QueryAssets(ctx)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var response dto.QueryAssetsResponseDTO
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	require.Len(t, response.Items, 1)
+	require.NotNil(t, response.Items[0].Asset)
+	return response
+}
+
+func TestAssetHandlerQueryAssets_OmitsURLsByDefault(t *testing.T) {
+	asset := queryAssetsForURLTest(t, false).Items[0].Asset
+	require.Nil(t, asset.OriginalURL)
+	require.Nil(t, asset.ThumbnailURLs)
+	require.Nil(t, asset.WebURL)
+	require.Nil(t, asset.URLsExpireAt)
+}
+
+func TestAssetHandlerQueryAssets_IncludesURLsWhenRequested(t *testing.T) {
+	asset := queryAssetsForURLTest(t, true).Items[0].Asset
+	require.NotNil(t, asset.OriginalURL)
+	require.Equal(t, "/api/v1/assets/aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa/original", *asset.OriginalURL)
+	require.Len(t, asset.ThumbnailURLs, 3)
+	require.Nil(t, asset.WebURL, "photos have no web rendition")
+}
+
+func TestAssetMediaURLSignerSignsEveryURL(t *testing.T) {
+	expiresAt := time.Now().Add(10 * time.Minute)
+	signer := assetMediaURLSigner{token: "media-token", expiresAt: &expiresAt}
+	asset := dto.AssetDTO{AssetID: "bbbbbbbb-bbbb-bbbb-bbbb-bbbbbbbbbbbb", Type: "VIDEO"}
+
+	signer.sign(&asset)
+
+	urls := []string{*asset.OriginalURL, *asset.WebURL}
+	for _, size := range assetThumbnailSizes {
+		thumbnailURL := asset.ThumbnailURLs[size]
+		parsed, err := url.Parse(thumbnailURL)
+		require.NoError(t, err)
+		require.Equal(t, size, parsed.Query().Get("size"))
+		urls = append(urls, thumbnailURL)
+	}
+	for _, raw := range urls {
+		parsed, err := url.Parse(raw)
+		require.NoError(t, err)
+		require.Equal(t, "media-token", parsed.Query().Get("mt"), raw)
+	}
+	webURL, err := url.Parse(*asset.WebURL)
+	require.NoError(t, err)
+	require.Equal(t, "/api/v1/assets/bbbbbbbb-bbbb-bbbb-bbbb-bbbbbbbbbbbb/video/web", webURL.Path)
+	require.Equal(t, &expiresAt, asset.URLsExpireAt)
+}
+
+func TestAssetMediaURLSignerSkipsThumbnailsForAudio(t *testing.T) {
+	asset := dto.AssetDTO{AssetID: "cccccccc-cccc-cccc-cccc-cccccccccccc", Type: "AUDIO"}
+
+	assetMediaURLSigner{}.sign(&asset)
+
+	require.Nil(t, asset.ThumbnailURLs)
+	require.NotNil(t, asset.WebURL)
+	require.Equal(t, "/api/v1/assets/cccccccc-cccc-cccc-cccc-cccccccccccc/audio/web", *asset.WebURL)
+}
+
+func TestSrcsetListsGeneratedThumbnailDimensions(t *testing.T) {
+	dims := func(w, h int32) (*int32, *int32) { return &w, &h }
+	thumbnail := func(size string, w, h int32) repo.Thumbnail {
+		width, height := dims(w, h)
+		return repo.Thumbnail{Size: size, Width: width, Height: height}
+	}
+
+	expiresAt := time.Now().Add(time.Hour)
+	signer := assetMediaURLSigner{token: "tok", expiresAt: &expiresAt}
+	response := signer.srcset("aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa", []repo.Thumbnail{
+		thumbnail("large", 1920, 1280),
+		thumbnail("small", 400, 267),
+		thumbnail("medium", 800, 534),
+		{Size: "waveform"},
+	})
+
+	require.Len(t, response.Sources, 3)
+	require.Equal(t, "small", response.Sources[0].Size)
+	require.Equal(t, int32(400), response.Sources[0].Width)
+	require.Equal(t, int32(267), response.Sources[0].Height)
+	require.Equal(t, "large", response.Sources[2].Size)
+	require.Equal(t, int32(1920), response.Sources[2].Width)
+	require.Equal(t, "/api/v1/assets/aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa/thumbnail?mt=tok&size=medium", response.Sources[1].URL)
+	require.Equal(t,
+		"/api/v1/assets/aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa/thumbnail?mt=tok&size=small 400w, "+
+			"/api/v1/assets/aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa/thumbnail?mt=tok&size=medium 800w, "+
+			"/api/v1/assets/aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa/thumbnail?mt=tok&size=large 1920w",
+		response.Srcset)
+	require.Equal(t, &expiresAt, response.URLsExpireAt)
+}
+
+func TestSrcsetSkipsUnsizedAndDuplicateWidths(t *testing.T) {
+	width, height := int32(320), int32(240)
+	response := assetMediaURLSigner{}.srcset("aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa", []repo.Thumbnail{
+		{Size: "small", Width: &width, Height: &height},
+		{Size: "medium", Width: &width, Height: &height},
+		{Size: "large"},
+	})
+
+	require.Len(t, response.Sources, 1)
+	require.Equal(t, "small", response.Sources[0].Size)
+	require.Equal(t, "/api/v1/assets/aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa/thumbnail?size=small 320w", response.Srcset)
+}
@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"server/internal/api"
+	"server/internal/db/repo"
+	"server/internal/utils/imageguard"
+	"server/internal/utils/imaging"
+
+	"github.com/gin-gonic/gin"
+)
+
+// isHEIFMime reports whether mimeType is a HEIC/HEIF still image, which most
+// browsers cannot render.
+func isHEIFMime(mimeType string) bool {
+	switch strings.ToLower(strings.TrimSpace(mimeType)) {
+	case "image/heic", "image/heif":
+		return true
+	}
+	return false
+}
+
+// acceptsHEIF reports whether an Accept header names HEIC or HEIF explicitly.
+// Wildcards do not count: browsers send */* for images they cannot decode.
+func acceptsHEIF(accept string) bool {
+	for _, part := range strings.Split(accept, ",") {
+		fields := strings.Split(part, ";")
+		if !isHEIFMime(fields[0]) {
+			continue
+		}
+		accepted := true
+		for _, param := range fields[1:] {
+			name, value, ok := strings.Cut(strings.TrimSpace(param), "=")
+			if ok && strings.EqualFold(name, "q") {
+				if q, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err == nil && q <= 0 {
+					accepted = false
+				}
+			}
+		}
+		if accepted {
+			return true
+		}
+	}
+	return false
+}
+
+// serveHEIFAsJPEG transcodes a HEIC/HEIF original at fullPath to JPEG for a
+// client that did not ask for HEIF.
+func (h *AssetHandler) serveHEIFAsJPEG(c *gin.Context, asset *repo.Asset, fullPath string, cacheFor time.Duration) {
+	buf, err := os.ReadFile(fullPath)
+	if err != nil {
+		log.Printf("Failed to read HEIF original %s: %v", fullPath, err)
+		api.GinInternalError(c, err, "Failed to access original file")
+		return
+	}
+
+	var out []byte
+	width, height := imageguard.Dimensions(buf, asset.Width, asset.Height)
+	err = h.imageLimits.Run(c.Request.Context(), width, height, func(context.Context) error {
+		var transcodeErr error
+		out, _, _, transcodeErr = imaging.ExportImageBytes(buf, imaging.ExportParams{Format: "jpeg", Quality: 90})
+		return transcodeErr
+	})
+	if respondImageOpError(c, err) {
+		return
+	}
+	if err != nil {
+		log.Printf("Failed to transcode HEIF original of asset %s: %v", asset.AssetID.String(), err)
+		api.GinError(c, http.StatusUnprocessableEntity, err, http.StatusUnprocessableEntity,
+			"Failed to convert the HEIF original to JPEG")
+		return
+	}
+
+	base := strings.TrimSuffix(asset.OriginalFilename, filepath.Ext(asset.OriginalFilename))
+	c.Header("Cache-Control", fmt.Sprintf("public, max-age=%d", int(cacheFor.Seconds())))
+	c.Header("Content-Disposition", fmt.Sprintf("inline; filename=\"%s.jpg\"", base))
+	c.Data(http.StatusOK, "image/jpeg", out)
+}
@@ -0,0 +1,61 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"server/internal/db/repo"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAcceptsHEIF(t *testing.T) {
+	cases := map[string]bool{
+		"":    false,
+		"*/*": false,
+		"image/avif,image/webp,image/*,*/*;q=0.8": false,
+		"image/heic":                   true,
+		"image/webp, IMAGE/HEIF;q=0.9": true,
+		"image/heic;q=0":               false,
+		"image/heic;q=0, image/heif":   true,
+	}
+	for accept, want := range cases {
+		require.Equal(t, want, acceptsHEIF(accept), accept)
+	}
+}
+
+func TestGetOriginalFile_ServesHEIFToClientsThatAskForIt(t *testing.T) {
+	assetID := "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa"
+	repositoryID := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+	root := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(root, "IMG_0001.HEIC"), []byte("heic bytes"), 0o600))
+
+	storagePath := "IMG_0001.HEIC"
+	asset := testHandlerAsset(t, assetID, "IMG_0001.HEIC")
+	asset.StoragePath = &storagePath
+	asset.RepositoryID = repositoryID
+	asset.MimeType = "image/heic"
+	h := &AssetHandler{
+		assetService: stubMediaAssetService{asset: asset},
+		queries:      repo.New(repositoriesDB{roots: map[pgtype.UUID]string{repositoryID: root}}),
+	}
+
+	gin.SetMode(gin.TestMode)
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Params = gin.Params{{Key: "id", Value: assetID}}
+	ctx.Request = httptest.NewRequest(http.MethodGet, "/api/v1/assets/"+assetID+"/original", nil)
+	ctx.Request.Header.Set("Accept", "image/heic, image/jpeg;q=0.5")
+
+	h.GetOriginalFile(ctx)
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+	require.Equal(t, "heic bytes", recorder.Body.String())
+	require.Equal(t, "image/heic", recorder.Header().Get("Content-Type"))
+	require.Equal(t, "Accept", recorder.Header().Get("Vary"))
+}
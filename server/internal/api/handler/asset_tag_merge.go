@@ -0,0 +1,117 @@
+package handler
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"server/internal/api"
+	"server/internal/api/dto"
+	"server/internal/db/repo"
+	"server/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RenameTag renames a tag definition
+// @Summary Rename a tag
+// @Description Rename a tag for every asset that carries it. Tags are shared by all users. A name already used by another tag is rejected; merge the two tags instead.
+// @Tags assets
+// @Accept json
+// @Produce json
+// @Param id path int true "Tag ID"
+// @Param request body dto.RenameTagRequestDTO true "New tag name"
+// @Success 200 {object} dto.TagDTO "Renamed tag"
+// @Failure 400 {object} api.ErrorResponse "Invalid tag ID or name"
+// @Failure 404 {object} api.ErrorResponse "Tag not found"
+// @Failure 409 {object} api.ErrorResponse "Another tag already has this name"
+// @Failure 500 {object} api.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /api/v1/admin/tags/{id} [put]
+func (h *AssetHandler) RenameTag(c *gin.Context) {
+	tagID, ok := parseTagIDParam(c)
+	if !ok {
+		return
+	}
+	var req dto.RenameTagRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		api.GinBadRequest(c, err, "Invalid request data")
+		return
+	}
+
+	tag, err := h.assetService.RenameTag(c.Request.Context(), tagID, req.TagName)
+	if err != nil {
+		h.respondTagEditError(c, err, "Failed to rename tag")
+		return
+	}
+	api.JSONOK(c, toTagDTO(*tag))
+}
+
+// MergeTag folds a tag into another one
+// @Summary Merge tags
+// @Description Move every asset of the tag onto the target tag and delete the tag, e.g. to fold "dogs" into "dog". An asset carrying both keeps one link with the higher confidence, preferring user-added links. Runs in one transaction.
+// @Tags assets
+// @Accept json
+// @Produce json
+// @Param id path int true "Tag ID to merge away"
+// @Param request body dto.MergeTagRequestDTO true "Target tag"
+// @Success 200 {object} dto.TagMergeResponseDTO "Tags merged"
+// @Failure 400 {object} api.ErrorResponse "Invalid tag ID or a merge into itself"
+// @Failure 404 {object} api.ErrorResponse "Tag not found"
+// @Failure 500 {object} api.ErrorResponse "Internal server error"
+// @Security BearerAuth
+// @Router /api/v1/admin/tags/{id}/merge [post]
+func (h *AssetHandler) MergeTag(c *gin.Context) {
+	sourceID, ok := parseTagIDParam(c)
+	if !ok {
+		return
+	}
+	var req dto.MergeTagRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		api.GinBadRequest(c, err, "Invalid request data")
+		return
+	}
+
+	result, err := h.assetService.MergeTags(c.Request.Context(), sourceID, req.TargetTagID)
+	if err != nil {
+		h.respondTagEditError(c, err, "Failed to merge tags")
+		return
+	}
+	api.JSONOK(c, dto.TagMergeResponseDTO{
+		Tag:             toTagDTO(result.Target),
+		RemovedTagID:    sourceID,
+		MergedAssetTags: result.MergedAssetTags,
+	})
+}
+
+func parseTagIDParam(c *gin.Context) (int32, bool) {
+	tagID, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil || tagID <= 0 {
+		api.GinBadRequest(c, errors.New("invalid tag id"), "Invalid tag ID")
+		return 0, false
+	}
+	return int32(tagID), true
+}
+
+func (h *AssetHandler) respondTagEditError(c *gin.Context, err error, msg string) {
+	switch {
+	case errors.Is(err, service.ErrTagNotFound):
+		api.GinNotFound(c, err, "Tag not found")
+	case errors.Is(err, service.ErrTagNameTaken):
+		api.GinError(c, http.StatusConflict, err, http.StatusConflict, "Another tag already has this name")
+	case errors.Is(err, service.ErrInvalidTagName), errors.Is(err, service.ErrTagMergeIntoItself):
+		api.GinBadRequest(c, err, err.Error())
+	default:
+		log.Printf("%s: %v", msg, err)
+		api.GinInternalError(c, err, msg)
+	}
+}
+
+func toTagDTO(tag repo.Tag) dto.TagDTO {
+	item := dto.TagDTO{TagID: tag.TagID, TagName: tag.TagName}
+	if tag.Category != nil {
+		item.Category = *tag.Category
+	}
+	return item
+}
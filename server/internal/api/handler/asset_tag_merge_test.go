@@ -0,0 +1,101 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"server/internal/api/dto"
+	"server/internal/db/repo"
+	"server/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+type stubTagEditService struct {
+	service.AssetService
+	renameFn func(ctx context.Context, tagID int32, name string) (*repo.Tag, error)
+	mergeFn  func(ctx context.Context, sourceTagID, targetTagID int32) (service.TagMergeResult, error)
+}
+
+func (s stubTagEditService) RenameTag(ctx context.Context, tagID int32, name string) (*repo.Tag, error) {
+	return s.renameFn(ctx, tagID, name)
+}
+
+func (s stubTagEditService) MergeTags(ctx context.Context, sourceTagID, targetTagID int32) (service.TagMergeResult, error) {
+	return s.mergeFn(ctx, sourceTagID, targetTagID)
+}
+
+func serveTagEditRequest(t *testing.T, svc stubTagEditService, method, tagID, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	handler := &AssetHandler{assetService: svc}
+
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Params = gin.Params{{Key: "id", Value: tagID}}
+	ctx.Request = httptest.NewRequest(method, "/api/v1/admin/tags/"+tagID, strings.NewReader(body))
+	ctx.Request.Header.Set("Content-Type", "application/json")
+
+	if method == http.MethodPut {
+		handler.RenameTag(ctx)
+	} else {
+		handler.MergeTag(ctx)
+	}
+	return recorder
+}
+
+func TestAssetHandlerMergeTag_ReportsTargetAndMergedLinks(t *testing.T) {
+	svc := stubTagEditService{
+		mergeFn: func(_ context.Context, sourceTagID, targetTagID int32) (service.TagMergeResult, error) {
+			require.Equal(t, int32(31), sourceTagID)
+			require.Equal(t, int32(12), targetTagID)
+			return service.TagMergeResult{Target: repo.Tag{TagID: 12, TagName: "dog"}, MergedAssetTags: 240}, nil
+		},
+	}
+
+	recorder := serveTagEditRequest(t, svc, http.MethodPost, "31", `{"target_tag_id":12}`)
+
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+	var response dto.TagMergeResponseDTO
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	require.Equal(t, dto.TagMergeResponseDTO{
+		Tag:             dto.TagDTO{TagID: 12, TagName: "dog"},
+		RemovedTagID:    31,
+		MergedAssetTags: 240,
+	}, response)
+}
+
+func TestAssetHandlerRenameTag_MapsServiceErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		tagID  string
+		err    error
+		status int
+	}{
+		{name: "invalid id", tagID: "dog", status: http.StatusBadRequest},
+		{name: "invalid name", tagID: "12", err: service.ErrInvalidTagName, status: http.StatusBadRequest},
+		{name: "missing", tagID: "12", err: service.ErrTagNotFound, status: http.StatusNotFound},
+		{name: "name taken", tagID: "12", err: service.ErrTagNameTaken, status: http.StatusConflict},
+		{name: "renamed", tagID: "12", status: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := stubTagEditService{
+				renameFn: func(_ context.Context, tagID int32, name string) (*repo.Tag, error) {
+					require.Equal(t, "canine", name)
+					if tt.err != nil {
+						return nil, tt.err
+					}
+					return &repo.Tag{TagID: tagID, TagName: name}, nil
+				},
+			}
+			recorder := serveTagEditRequest(t, svc, http.MethodPut, tt.tagID, `{"tag_name":"canine"}`)
+			require.Equal(t, tt.status, recorder.Code, recorder.Body.String())
+		})
+	}
+}
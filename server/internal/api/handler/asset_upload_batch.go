@@ -0,0 +1,205 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+
+	"server/internal/api"
+	"server/internal/api/dto"
+	"server/internal/db/repo"
+	"server/internal/queue/jobs"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/riverqueue/river"
+	"github.com/riverqueue/river/rivertype"
+)
+
+const (
+	uploadBatchStatusCancelled = "cancelled"
+
+	// uploadBatchJobPageSize bounds each job lookup while a batch is cancelled.
+	uploadBatchJobPageSize = 1000
+)
+
+// pendingUploadJobStates are the states of ingest jobs that have not started
+// yet; only these are cancelled with their batch.
+var pendingUploadJobStates = []rivertype.JobState{
+	rivertype.JobStateAvailable,
+	rivertype.JobStatePending,
+	rivertype.JobStateRetryable,
+	rivertype.JobStateScheduled,
+}
+
+// uploadBatchQueue is the part of the River client batch cancellation uses.
+type uploadBatchQueue interface {
+	JobList(ctx context.Context, params *river.JobListParams) (*river.JobListResult, error)
+	JobCancel(ctx context.Context, jobID int64) (*rivertype.JobRow, error)
+}
+
+// CancelUploadBatch stops an upload batch that is still being ingested
+// @Summary Cancel an upload batch
+// @Description Cancel the ingest jobs of a batch upload that have not started yet and remove their staged files, then mark the batch cancelled so further uploads into it are refused. Files already ingested, or being ingested, are left alone. Batches are named by the batch_id sent with POST /api/v1/assets/batch. Cancelling a cancelled batch again is harmless.
+// @Tags assets
+// @Produce json
+// @Param batch_id path string true "Upload batch UUID"
+// @Success 200 {object} dto.CancelUploadBatchResponseDTO "Batch cancelled"
+// @Failure 400 {object} api.ErrorResponse "Invalid batch ID"
+// @Failure 404 {object} api.ErrorResponse "Upload batch not found"
+// @Failure 500 {object} api.ErrorResponse "Failed to cancel upload batch"
+// @Router /api/v1/uploads/batch/{batch_id}/cancel [post]
+func (h *AssetHandler) CancelUploadBatch(c *gin.Context) {
+	ctx := c.Request.Context()
+	parsed, err := uuid.Parse(c.Param("batch_id"))
+	if err != nil {
+		api.GinBadRequest(c, err, "Invalid batch ID")
+		return
+	}
+	batchID := pgtype.UUID{Bytes: parsed, Valid: true}
+
+	callerID := "anonymous"
+	if id, exists := c.Get("user_id"); exists {
+		callerID = fmt.Sprintf("%d", id)
+	}
+	batch, err := h.queries.GetUploadBatch(ctx, batchID)
+	if err == nil && batch.UserID != callerID {
+		err = pgx.ErrNoRows
+	}
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			api.GinNotFound(c, err, "Upload batch not found")
+			return
+		}
+		api.GinInternalError(c, err, "Failed to cancel upload batch")
+		return
+	}
+
+	// Mark the batch first so later uploads into it are refused; requests
+	// already past that check cancel their own jobs once recorded.
+	batch, err = h.queries.CancelUploadBatch(ctx, batchID)
+	if err != nil {
+		api.GinInternalError(c, err, "Failed to cancel upload batch")
+		return
+	}
+	jobIDs, err := h.queries.ListUploadBatchJobIDs(ctx, batchID)
+	if err != nil {
+		api.GinInternalError(c, err, "Failed to cancel upload batch")
+		return
+	}
+	cancelled, err := h.cancelPendingUploadJobs(ctx, h.queueClient, jobIDs)
+	if err != nil {
+		log.Printf("Failed to cancel jobs of upload batch %s: %v", parsed, err)
+		api.GinInternalError(c, err, "Failed to cancel upload batch")
+		return
+	}
+
+	api.JSONOK(c, dto.CancelUploadBatchResponseDTO{
+		BatchID:       parsed.String(),
+		Status:        batch.Status,
+		CancelledJobs: cancelled,
+		TotalJobs:     len(jobIDs),
+	})
+}
+
+// cancelPendingUploadJobs cancels the ingest jobs among jobIDs that have not
+// started and removes their staged files. A job that starts between the
+// lookup and the cancel keeps its file. It returns how many were cancelled.
+func (h *AssetHandler) cancelPendingUploadJobs(ctx context.Context, queue uploadBatchQueue, jobIDs []int64) (int, error) {
+	cancelled := 0
+	for start := 0; start < len(jobIDs); start += uploadBatchJobPageSize {
+		page := jobIDs[start:min(start+uploadBatchJobPageSize, len(jobIDs))]
+		pending, err := queue.JobList(ctx, river.NewJobListParams().
+			IDs(page...).
+			Kinds(jobs.IngestAssetArgs{}.Kind()).
+			States(pendingUploadJobStates...).
+			First(len(page)))
+		if err != nil {
+			return cancelled, err
+		}
+
+		for _, row := range pending.Jobs {
+			updated, err := queue.JobCancel(ctx, row.ID)
+			if err != nil {
+				if errors.Is(err, river.ErrNotFound) {
+					continue
+				}
+				return cancelled, err
+			}
+			if updated.State != rivertype.JobStateCancelled {
+				continue
+			}
+			var args jobs.IngestAssetArgs
+			if err := json.Unmarshal(row.EncodedArgs, &args); err == nil {
+				h.removeUploadTempFile(args.StagedPath)
+			}
+			cancelled++
+		}
+	}
+	return cancelled, nil
+}
+
+// joinUploadBatch resolves the batch_id sent with a batch upload, creating
+// the batch on first use. The returned ID is invalid when none was sent. It
+// writes the error response itself when the upload must be refused.
+func (h *AssetHandler) joinUploadBatch(c *gin.Context, raw, callerID string) (pgtype.UUID, bool) {
+	if raw == "" {
+		return pgtype.UUID{}, true
+	}
+	parsed, err := uuid.Parse(raw)
+	if err != nil {
+		api.GinBadRequest(c, err, "Invalid batch ID")
+		return pgtype.UUID{}, false
+	}
+
+	batch, err := h.queries.EnsureUploadBatch(c.Request.Context(), repo.EnsureUploadBatchParams{
+		BatchID: pgtype.UUID{Bytes: parsed, Valid: true},
+		UserID:  callerID,
+	})
+	if err != nil {
+		api.GinInternalError(c, err, "Failed to register upload batch")
+		return pgtype.UUID{}, false
+	}
+	if batch.UserID != callerID {
+		api.GinForbidden(c, errors.New("upload batch belongs to another user"), "Upload batch belongs to another user")
+		return pgtype.UUID{}, false
+	}
+	if batch.Status == uploadBatchStatusCancelled {
+		api.GinError(c, http.StatusConflict, errors.New("upload batch cancelled"), http.StatusConflict, "Upload batch was cancelled")
+		return pgtype.UUID{}, false
+	}
+	return batch.BatchID, true
+}
+
+// recordUploadBatchJobs ties the ingest jobs queued for results to batchID so
+// cancelling the batch can find them. A batch cancelled while this request
+// was queueing them would miss them, so their cancellation is done here.
+func (h *AssetHandler) recordUploadBatchJobs(ctx context.Context, batchID pgtype.UUID, results []dto.BatchUploadResultDTO) {
+	var jobIDs []int64
+	for _, result := range results {
+		if result.TaskID == nil {
+			continue
+		}
+		if err := h.queries.AddUploadBatchJob(ctx, repo.AddUploadBatchJobParams{BatchID: batchID, JobID: *result.TaskID}); err != nil {
+			log.Printf("Failed to record job %d in upload batch %s: %v", *result.TaskID, uuid.UUID(batchID.Bytes), err)
+			continue
+		}
+		jobIDs = append(jobIDs, *result.TaskID)
+	}
+	if len(jobIDs) == 0 {
+		return
+	}
+
+	batch, err := h.queries.GetUploadBatch(ctx, batchID)
+	if err != nil || batch.Status != uploadBatchStatusCancelled {
+		return
+	}
+	if _, err := h.cancelPendingUploadJobs(ctx, h.queueClient, jobIDs); err != nil {
+		log.Printf("Failed to cancel late jobs of upload batch %s: %v", uuid.UUID(batchID.Bytes), err)
+	}
+}
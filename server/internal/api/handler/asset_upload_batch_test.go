@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"server/internal/queue/jobs"
+
+	"github.com/riverqueue/river"
+	"github.com/riverqueue/river/rivertype"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeUploadBatchQueue lists the jobs it holds in a pending state, as River
+// does for the filter cancelPendingUploadJobs sends. Jobs in startsRunning
+// are picked up by a worker between the listing and the cancel.
+type fakeUploadBatchQueue struct {
+	jobs          map[int64]*rivertype.JobRow
+	startsRunning map[int64]bool
+	cancelCalls   []int64
+}
+
+func (q *fakeUploadBatchQueue) JobList(context.Context, *river.JobListParams) (*river.JobListResult, error) {
+	result := &river.JobListResult{}
+	for _, row := range q.jobs {
+		if slices.Contains(pendingUploadJobStates, row.State) {
+			result.Jobs = append(result.Jobs, row)
+		}
+	}
+	return result, nil
+}
+
+func (q *fakeUploadBatchQueue) JobCancel(_ context.Context, jobID int64) (*rivertype.JobRow, error) {
+	q.cancelCalls = append(q.cancelCalls, jobID)
+	row := *q.jobs[jobID]
+	if q.startsRunning[jobID] {
+		row.State = rivertype.JobStateRunning
+	} else {
+		row.State = rivertype.JobStateCancelled
+	}
+	q.jobs[jobID] = &row
+	return &row, nil
+}
+
+func stagedIngestJob(t *testing.T, dir string, id int64, state rivertype.JobState) (*rivertype.JobRow, string) {
+	t.Helper()
+	path := filepath.Join(dir, fmt.Sprintf("staged-%d.jpg", id))
+	require.NoError(t, os.WriteFile(path, []byte("staged"), 0o644))
+	args, err := json.Marshal(jobs.IngestAssetArgs{StagedPath: path})
+	require.NoError(t, err)
+	return &rivertype.JobRow{ID: id, Kind: jobs.IngestAssetArgs{}.Kind(), State: state, EncodedArgs: args}, path
+}
+
+func TestCancelPendingUploadJobs_CancelsOnlyJobsNotStarted(t *testing.T) {
+	dir := t.TempDir()
+	queue := &fakeUploadBatchQueue{jobs: map[int64]*rivertype.JobRow{}, startsRunning: map[int64]bool{4: true}}
+	paths := map[int64]string{}
+	for id, state := range map[int64]rivertype.JobState{
+		1: rivertype.JobStateAvailable,
+		2: rivertype.JobStateScheduled,
+		3: rivertype.JobStateCompleted,
+		4: rivertype.JobStateAvailable,
+		5: rivertype.JobStateRetryable,
+	} {
+		queue.jobs[id], paths[id] = stagedIngestJob(t, dir, id, state)
+	}
+
+	cancelled, err := (&AssetHandler{}).cancelPendingUploadJobs(context.Background(), queue, []int64{1, 2, 3, 4, 5})
+
+	require.NoError(t, err)
+	require.Equal(t, 3, cancelled)
+	for _, id := range []int64{1, 2, 5} {
+		require.Equal(t, rivertype.JobStateCancelled, queue.jobs[id].State)
+		require.NoFileExists(t, paths[id], "a cancelled job's staged file is removed")
+	}
+	require.NotContains(t, queue.cancelCalls, int64(3), "completed jobs are left alone")
+	require.Equal(t, rivertype.JobStateCompleted, queue.jobs[3].State)
+	require.FileExists(t, paths[3])
+	require.FileExists(t, paths[4], "a job that started before the cancel keeps its file")
+}
+
+func TestCancelPendingUploadJobs_NoJobs(t *testing.T) {
+	queue := &fakeUploadBatchQueue{jobs: map[int64]*rivertype.JobRow{}}
+
+	cancelled, err := (&AssetHandler{}).cancelPendingUploadJobs(context.Background(), queue, nil)
+
+	require.NoError(t, err)
+	require.Zero(t, cancelled)
+	require.Empty(t, queue.cancelCalls)
+}
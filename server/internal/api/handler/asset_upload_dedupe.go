@@ -0,0 +1,86 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"server/internal/db/repo"
+	"server/internal/service"
+	"server/internal/utils/hash"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// contentHashHeader carries the client's BLAKE3 hash of an upload. With
+// dedupe=true it selects an existing asset; otherwise the ingest worker
+// verifies it.
+const contentHashHeader = "X-Content-Hash"
+
+// uploadDedupeStore is what a dedupe upload needs from the database.
+type uploadDedupeStore interface {
+	GetAssetsByContentHashesAndRepository(ctx context.Context, arg repo.GetAssetsByContentHashesAndRepositoryParams) ([]repo.GetAssetsByContentHashesAndRepositoryRow, error)
+	ClaimUnownedAsset(ctx context.Context, arg repo.ClaimUnownedAssetParams) (int64, error)
+}
+
+// parseClaimedContentHash reads the X-Content-Hash header, returning "" when
+// the client sent none.
+func parseClaimedContentHash(raw string) (string, error) {
+	value := strings.ToLower(strings.TrimSpace(raw))
+	if value == "" {
+		return "", nil
+	}
+	if !hash.ValidateHash(value, hash.AlgorithmBLAKE3) {
+		return "", fmt.Errorf("invalid content hash %q", raw)
+	}
+	return value, nil
+}
+
+// linkUploadDuplicate finds an asset of the repository with the given hash and
+// size among the assets user may read: its own, unowned ones, or any for an
+// admin. An unowned match is given to user.
+//
+// verified says the server hashed the uploaded bytes itself. A hash the client
+// only claims is taken on trust, so it links assets the caller already has and
+// never hands over an unowned one; that waits for the server's hash.
+func linkUploadDuplicate(ctx context.Context, q uploadDedupeStore, contentHash string, size int64, repositoryID pgtype.UUID, user *service.UserResponse, verified bool) (*duplicateAsset, error) {
+	rows, err := q.GetAssetsByContentHashesAndRepository(ctx, repo.GetAssetsByContentHashesAndRepositoryParams{
+		ContentHashes: []string{contentHash},
+		RepositoryID:  repositoryID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up existing content hash: %w", err)
+	}
+
+	var userID *int32
+	admin := false
+	if user != nil {
+		id := int32(user.UserID)
+		userID = &id
+		admin = service.IsAdminRole(user.Role)
+	}
+	for _, row := range rows {
+		if row.FileSize != size {
+			continue
+		}
+		switch {
+		case row.OwnerID == nil:
+			if !verified {
+				continue
+			}
+			if userID != nil {
+				if _, err := q.ClaimUnownedAsset(ctx, repo.ClaimUnownedAssetParams{OwnerID: userID, AssetID: row.AssetID}); err != nil {
+					return nil, fmt.Errorf("failed to claim existing asset: %w", err)
+				}
+			}
+		case admin || (userID != nil && *row.OwnerID == *userID):
+		default:
+			continue
+		}
+		return &duplicateAsset{
+			assetID:  row.AssetID.String(),
+			filename: row.OriginalFilename,
+		}, nil
+	}
+	return nil, nil
+}
@@ -0,0 +1,101 @@
+package handler
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"server/internal/db/repo"
+	"server/internal/service"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+type stubUploadDedupeStore struct {
+	rows    []repo.GetAssetsByContentHashesAndRepositoryRow
+	claimed []repo.ClaimUnownedAssetParams
+}
+
+func (s *stubUploadDedupeStore) GetAssetsByContentHashesAndRepository(_ context.Context, arg repo.GetAssetsByContentHashesAndRepositoryParams) ([]repo.GetAssetsByContentHashesAndRepositoryRow, error) {
+	var rows []repo.GetAssetsByContentHashesAndRepositoryRow
+	for _, row := range s.rows {
+		if row.ContentHash == arg.ContentHashes[0] {
+			rows = append(rows, row)
+		}
+	}
+	return rows, nil
+}
+
+func (s *stubUploadDedupeStore) ClaimUnownedAsset(_ context.Context, arg repo.ClaimUnownedAssetParams) (int64, error) {
+	s.claimed = append(s.claimed, arg)
+	return 1, nil
+}
+
+func TestLinkUploadDuplicate(t *testing.T) {
+	contentHash := strings.Repeat("c", 64)
+	var assetID pgtype.UUID
+	require.NoError(t, assetID.Scan("11111111-1111-1111-1111-111111111111"))
+	owner := int32(7)
+	other := int32(8)
+	caller := &service.UserResponse{UserID: 7, Role: "user"}
+
+	t.Run("links the caller's own asset", func(t *testing.T) {
+		store := &stubUploadDedupeStore{rows: []repo.GetAssetsByContentHashesAndRepositoryRow{
+			{AssetID: assetID, ContentHash: contentHash, FileSize: 2048, OriginalFilename: "IMG_0001.jpg", OwnerID: &owner},
+		}}
+		linked, err := linkUploadDuplicate(context.Background(), store, contentHash, 2048, pgtype.UUID{}, caller, true)
+		require.NoError(t, err)
+		require.NotNil(t, linked)
+		require.Equal(t, "11111111-1111-1111-1111-111111111111", linked.assetID)
+		require.Empty(t, store.claimed)
+	})
+
+	t.Run("claims an unowned asset", func(t *testing.T) {
+		store := &stubUploadDedupeStore{rows: []repo.GetAssetsByContentHashesAndRepositoryRow{
+			{AssetID: assetID, ContentHash: contentHash, FileSize: 2048, OriginalFilename: "scan.jpg"},
+		}}
+		linked, err := linkUploadDuplicate(context.Background(), store, contentHash, 2048, pgtype.UUID{}, caller, true)
+		require.NoError(t, err)
+		require.NotNil(t, linked)
+		require.Equal(t, []repo.ClaimUnownedAssetParams{{OwnerID: &owner, AssetID: assetID}}, store.claimed)
+	})
+
+	t.Run("leaves an unowned asset alone on the client's hash", func(t *testing.T) {
+		store := &stubUploadDedupeStore{rows: []repo.GetAssetsByContentHashesAndRepositoryRow{
+			{AssetID: assetID, ContentHash: contentHash, FileSize: 2048, OriginalFilename: "scan.jpg"},
+		}}
+		linked, err := linkUploadDuplicate(context.Background(), store, contentHash, 2048, pgtype.UUID{}, caller, false)
+		require.NoError(t, err)
+		require.Nil(t, linked)
+		require.Empty(t, store.claimed)
+	})
+
+	t.Run("ignores another user's asset and a different size", func(t *testing.T) {
+		store := &stubUploadDedupeStore{rows: []repo.GetAssetsByContentHashesAndRepositoryRow{
+			{AssetID: assetID, ContentHash: contentHash, FileSize: 2048, OriginalFilename: "theirs.jpg", OwnerID: &other},
+			{AssetID: assetID, ContentHash: contentHash, FileSize: 4096, OriginalFilename: "mine.jpg", OwnerID: &owner},
+		}}
+		linked, err := linkUploadDuplicate(context.Background(), store, contentHash, 2048, pgtype.UUID{}, caller, true)
+		require.NoError(t, err)
+		require.Nil(t, linked)
+
+		admin := &service.UserResponse{UserID: 1, Role: "admin"}
+		linked, err = linkUploadDuplicate(context.Background(), store, contentHash, 2048, pgtype.UUID{}, admin, true)
+		require.NoError(t, err)
+		require.NotNil(t, linked, "admins can read every asset")
+	})
+}
+
+func TestParseClaimedContentHash(t *testing.T) {
+	got, err := parseClaimedContentHash("  " + strings.Repeat("AB", 32) + " ")
+	require.NoError(t, err)
+	require.Equal(t, strings.Repeat("ab", 32), got)
+
+	got, err = parseClaimedContentHash("")
+	require.NoError(t, err)
+	require.Empty(t, got)
+
+	_, err = parseClaimedContentHash("sha256:abc")
+	require.Error(t, err)
+}
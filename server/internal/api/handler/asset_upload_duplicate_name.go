@@ -0,0 +1,30 @@
+package handler
+
+import (
+	"fmt"
+	"time"
+
+	"server/internal/storage"
+)
+
+// duplicateNameSkippedMessage explains an upload dropped because its file name
+// is taken in a repository whose duplicate file name handling is skip.
+const duplicateNameSkippedMessage = "A file with this name already exists in the repository and duplicate file names are skipped"
+
+// planUploadDuplicateAction previews what the repository's duplicate file name
+// handling does with a staged upload when the ingest worker files it into the
+// inbox. The worker decides again, so a concurrent upload of the same name can
+// still change the outcome.
+func (h *AssetHandler) planUploadDuplicateAction(repoPath, stagedPath, filename, contentHash string, takenTime *time.Time) (storage.DuplicateAction, error) {
+	plan, err := h.stagingManager.PlanInboxCommit(&storage.StagingFile{
+		RepoPath:  repoPath,
+		Path:      stagedPath,
+		Filename:  filename,
+		CreatedAt: time.Now(),
+		TakenTime: takenTime,
+	}, contentHash)
+	if err != nil {
+		return storage.DuplicateActionNone, fmt.Errorf("failed to plan inbox commit: %w", err)
+	}
+	return plan.Action, nil
+}
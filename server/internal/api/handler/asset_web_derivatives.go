@@ -0,0 +1,54 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"log"
+	"os"
+	"time"
+
+	"server/internal/db/dbtypes/status"
+	"server/internal/db/repo"
+	"server/internal/storage"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// transcodeTask is the status task that produces an asset's web derivative.
+const transcodeTask = "transcode_asset"
+
+// AssetWebDerivativeRegenerator queues an asset's web video or audio to be
+// transcoded again after eviction.
+type AssetWebDerivativeRegenerator interface {
+	RegenerateWebDerivative(ctx context.Context, assetID pgtype.UUID) error
+}
+
+// webDerivativeAvailable reports whether the web derivative at path can be
+// served, recording the access so eviction leaves it alone. A derivative
+// missing although the transcode completed was evicted: it is queued for
+// regeneration and the caller serves the original meanwhile.
+func (h *AssetHandler) webDerivativeAvailable(ctx context.Context, asset *repo.Asset, path string) bool {
+	info, err := os.Stat(path)
+	if err == nil {
+		storage.TouchWebDerivative(path, info.ModTime(), time.Now())
+		return true
+	}
+	if !errors.Is(err, os.ErrNotExist) || h.derivativeRegenerator == nil || !transcodeCompleted(asset.Status) {
+		return false
+	}
+	if err := h.derivativeRegenerator.RegenerateWebDerivative(ctx, asset.AssetID); err != nil {
+		log.Printf("Failed to queue regeneration of evicted web derivative for %s: %v", asset.AssetID.String(), err)
+	}
+	return false
+}
+
+func transcodeCompleted(rawStatus []byte) bool {
+	if len(rawStatus) == 0 {
+		return false
+	}
+	current, err := status.FromJSONB(rawStatus)
+	if err != nil {
+		return false
+	}
+	return current.Tasks[transcodeTask].State == status.TaskComplete
+}
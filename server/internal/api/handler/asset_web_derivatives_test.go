@@ -0,0 +1,118 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"server/internal/db/dbtypes/status"
+	"server/internal/db/repo"
+	"server/internal/storage"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingDerivativeRegenerator struct {
+	assetIDs []pgtype.UUID
+}
+
+func (r *recordingDerivativeRegenerator) RegenerateWebDerivative(_ context.Context, assetID pgtype.UUID) error {
+	r.assetIDs = append(r.assetIDs, assetID)
+	return nil
+}
+
+func transcodedStatus(t *testing.T, transcoded bool) []byte {
+	t.Helper()
+	current := status.NewTrackedProcessingStatus("Processing", []string{"metadata_asset", "thumbnail_asset", transcodeTask})
+	if transcoded {
+		current.MarkTaskComplete(transcodeTask, "Transcoding completed")
+	}
+	raw, err := current.ToJSONB()
+	require.NoError(t, err)
+	return raw
+}
+
+func TestGetWebVideo_EvictedDerivativeIsRegeneratedOnAccess(t *testing.T) {
+	assetID := "dddddddd-dddd-dddd-dddd-dddddddddddd"
+	repositoryID := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+	root := t.TempDir()
+
+	storagePath := "2024/clip.mov"
+	video := testHandlerAsset(t, assetID, "clip.mov")
+	video.Type = "VIDEO"
+	video.ContentHash = "cliphash"
+	video.StoragePath = &storagePath
+	video.RepositoryID = repositoryID
+	video.Status = transcodedStatus(t, true)
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "2024"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "2024", "clip.mov"), []byte("original"), 0o600))
+
+	// A derivative nobody played within the retention window is evicted.
+	derivative := storage.WebVideoPath(root, video.ContentHash)
+	require.NoError(t, os.MkdirAll(filepath.Dir(derivative), 0o755))
+	require.NoError(t, os.WriteFile(derivative, []byte("web"), 0o600))
+	idle := time.Now().Add(-60 * 24 * time.Hour)
+	require.NoError(t, os.Chtimes(derivative, idle, idle))
+	result, err := storage.EvictIdleWebDerivatives(root, time.Now().Add(-30*24*time.Hour), func(string) bool { return false })
+	require.NoError(t, err)
+	require.Equal(t, 1, result.Files)
+
+	regenerator := &recordingDerivativeRegenerator{}
+	h := &AssetHandler{
+		assetService:          stubMediaAssetService{asset: video},
+		queries:               repo.New(repositoriesDB{roots: map[pgtype.UUID]string{repositoryID: root}}),
+		derivativeRegenerator: regenerator,
+	}
+
+	// The next request falls back to the original and queues the transcode.
+	recorder := serveMediaForTest(t, h, assetID, (*AssetHandler).GetWebVideo)
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+	require.Equal(t, "original", recorder.Body.String())
+	require.Equal(t, []pgtype.UUID{video.AssetID}, regenerator.assetIDs)
+
+	// Once regenerated, the derivative is served again and its access
+	// recorded, so the next sweep keeps it.
+	require.NoError(t, os.WriteFile(derivative, []byte("web"), 0o600))
+	require.NoError(t, os.Chtimes(derivative, idle, idle))
+	recorder = serveMediaForTest(t, h, assetID, (*AssetHandler).GetWebVideo)
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+	require.Equal(t, "web", recorder.Body.String())
+	require.Len(t, regenerator.assetIDs, 1)
+	info, err := os.Stat(derivative)
+	require.NoError(t, err)
+	require.WithinDuration(t, time.Now(), info.ModTime(), time.Minute)
+}
+
+// A video still waiting for its first transcode has no derivative yet; that
+// is not an eviction.
+func TestGetWebVideo_PendingTranscodeIsNotRegenerated(t *testing.T) {
+	assetID := "dddddddd-dddd-dddd-dddd-dddddddddddd"
+	repositoryID := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+	root := t.TempDir()
+
+	storagePath := "clip.mov"
+	video := testHandlerAsset(t, assetID, "clip.mov")
+	video.Type = "VIDEO"
+	video.ContentHash = "cliphash"
+	video.StoragePath = &storagePath
+	video.RepositoryID = repositoryID
+	video.Status = transcodedStatus(t, false)
+	require.NoError(t, os.WriteFile(filepath.Join(root, "clip.mov"), []byte("original"), 0o600))
+
+	regenerator := &recordingDerivativeRegenerator{}
+	h := &AssetHandler{
+		assetService:          stubMediaAssetService{asset: video},
+		queries:               repo.New(repositoriesDB{roots: map[pgtype.UUID]string{repositoryID: root}}),
+		derivativeRegenerator: regenerator,
+	}
+
+	recorder := serveMediaForTest(t, h, assetID, (*AssetHandler).GetWebVideo)
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+	require.Equal(t, "original", recorder.Body.String())
+	require.Empty(t, regenerator.assetIDs)
+}
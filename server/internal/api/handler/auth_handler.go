@@ -316,6 +316,28 @@ func (h *AuthHandler) OptionalAuthMiddleware() gin.HandlerFunc {
 	}
 }
 
+// AssetDownloadTokenMiddleware admits requests carrying a signed download
+// token (?token=) for the asset in the :id path parameter, in place of user
+// authentication. Missing, tampered, expired and other assets' tokens are
+// rejected with 403.
+func (h *AuthHandler) AssetDownloadTokenMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		assetID, expiresAt, err := h.authService.ValidateAssetDownloadToken(strings.TrimSpace(c.Query("token")))
+		if err != nil || assetID.String() != c.Param("id") {
+			if err == nil {
+				err = errors.New("download token is for another asset")
+			}
+			api.GinForbidden(c, err, "Invalid or expired download link")
+			c.Abort()
+			return
+		}
+
+		c.Set(downloadAssetIDKey, assetID)
+		c.Set(downloadAssetExpiresKey, expiresAt)
+		c.Next()
+	}
+}
+
 func (h *AuthHandler) RequireAdmin() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		if _, ok := requireAdminUser(c); !ok {
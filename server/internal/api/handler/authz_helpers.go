@@ -89,6 +89,31 @@ func requireAdminUser(c *gin.Context) (*service.UserResponse, bool) {
 	return user, true
 }
 
+// canAccessOwner reports whether the current user may act on a resource owned
+// by ownerID, without writing a response. Batch endpoints use it to fail one
+// item instead of the whole request.
+func canAccessOwner(c *gin.Context, ownerID *int32) bool {
+	if ownerID == nil {
+		return true
+	}
+	user, ok := currentUserFromContext(c)
+	return ok && (service.IsAdminRole(user.Role) || int32(user.UserID) == *ownerID)
+}
+
+// canPermanentlyDelete is canAccessOwner for hard deletes, which cannot be
+// undone: they always need a signed-in user, and only admins may remove
+// assets that have no owner.
+func canPermanentlyDelete(c *gin.Context, ownerID *int32) bool {
+	user, ok := currentUserFromContext(c)
+	if !ok {
+		return false
+	}
+	if service.IsAdminRole(user.Role) {
+		return true
+	}
+	return ownerID != nil && int32(user.UserID) == *ownerID
+}
+
 func ensureOwnerAccess(c *gin.Context, ownerID *int32, unauthorizedMessage, forbiddenMessage string) bool {
 	if ownerID == nil {
 		return true
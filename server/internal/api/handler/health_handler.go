@@ -1,32 +1,173 @@
 package handler
 
 import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
 	"server/internal/api"
+	"server/internal/service"
+	"server/internal/storage"
+	"server/internal/version"
 
 	"github.com/gin-gonic/gin"
 )
 
+// RepositoryHealthFunc reports the structure and sync health of every
+// repository. It walks each repository on disk, so it only runs for deep
+// health checks.
+type RepositoryHealthFunc func(ctx context.Context) ([]storage.RepositoryHealth, error)
+
 // HealthHandler handles health check HTTP requests
-type HealthHandler struct{}
+type HealthHandler struct {
+	bootstrap        service.BootstrapService
+	repositories     storage.PrimaryRepositoryLookup
+	repositoryHealth RepositoryHealthFunc
+}
 
-// NewHealthHandler creates a new health handler
-func NewHealthHandler() *HealthHandler {
-	return &HealthHandler{}
+// NewHealthHandler creates a new health handler. repositoryHealth may be nil,
+// in which case deep checks report no repositories.
+func NewHealthHandler(bootstrap service.BootstrapService, repositories storage.PrimaryRepositoryLookup, repositoryHealth RepositoryHealthFunc) *HealthHandler {
+	return &HealthHandler{
+		bootstrap:        bootstrap,
+		repositories:     repositories,
+		repositoryHealth: repositoryHealth,
+	}
 }
 
+// Health states reported by /health.
+const (
+	HealthOK       = "ok"
+	HealthDegraded = "degraded"
+)
+
 // HealthResponse represents the health check response
 type HealthResponse struct {
-	Status string `json:"status" example:"ok"`
+	Status       string                `json:"status" example:"ok" enums:"ok,degraded"`
+	Version      string                `json:"version" example:"1.0.0"`
+	Repositories []RepositoryHealthDTO `json:"repositories,omitempty"`
+	Reasons      []string              `json:"reasons,omitempty"`
+}
+
+// RepositoryHealthDTO is one repository's entry in a deep health check.
+type RepositoryHealthDTO struct {
+	RepositoryID       string     `json:"repository_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Name               string     `json:"name" example:"Primary"`
+	Status             string     `json:"status" example:"active"`
+	Healthy            bool       `json:"healthy" example:"true"`
+	StructureValid     *bool      `json:"structure_valid,omitempty" example:"true"`
+	MissingDirectories []string   `json:"missing_directories,omitempty"`
+	LastSync           *time.Time `json:"last_sync,omitempty"`
+	SyncStale          bool       `json:"sync_stale" example:"false"`
+	Problems           []string   `json:"problems,omitempty"`
+}
+
+// Readiness states reported by /readyz.
+const (
+	ReadinessReady         = "ready"
+	ReadinessSetupRequired = "setup_required"
+	ReadinessDegraded      = "degraded"
+)
+
+// ReadinessResponse represents the readiness check response
+type ReadinessResponse struct {
+	Status  string   `json:"status" example:"ready" enums:"ready,setup_required,degraded"`
+	Reasons []string `json:"reasons,omitempty"`
 }
 
 // Check handles health check requests
 // @Summary Health check
-// @Description Check if the server is healthy
+// @Description Check if the server is healthy. With deep=true, also validate every repository's directory structure and sync recency; any unhealthy repository makes the response degraded.
 // @Tags Health
 // @Accept json
 // @Produce json
+// @Param deep query bool false "Include per-repository structure and sync checks"
 // @Success 200 {object} HealthResponse "Server is healthy"
+// @Failure 400 {object} api.ErrorResponse "Invalid deep flag"
+// @Failure 503 {object} HealthResponse "A deep check found an unhealthy repository"
 // @Router /api/v1/health [get]
 func (h *HealthHandler) Check(c *gin.Context) {
-	api.JSONOK(c, HealthResponse{Status: "ok"})
+	response := HealthResponse{Status: HealthOK, Version: version.Version}
+
+	deep := false
+	if raw := c.Query("deep"); raw != "" {
+		parsed, err := strconv.ParseBool(raw)
+		if err != nil {
+			api.GinBadRequest(c, err, "deep must be a boolean")
+			return
+		}
+		deep = parsed
+	}
+	if !deep || h.repositoryHealth == nil {
+		api.JSONOK(c, response)
+		return
+	}
+
+	results, err := h.repositoryHealth(c.Request.Context())
+	if err != nil {
+		response.Status = HealthDegraded
+		response.Reasons = []string{err.Error()}
+		c.JSON(http.StatusServiceUnavailable, response)
+		return
+	}
+	response.Repositories = make([]RepositoryHealthDTO, 0, len(results))
+	for _, result := range results {
+		if !result.Healthy {
+			response.Status = HealthDegraded
+		}
+		response.Repositories = append(response.Repositories, toRepositoryHealthDTO(result))
+	}
+	if response.Status != HealthOK {
+		c.JSON(http.StatusServiceUnavailable, response)
+		return
+	}
+	api.JSONOK(c, response)
+}
+
+func toRepositoryHealthDTO(result storage.RepositoryHealth) RepositoryHealthDTO {
+	dto := RepositoryHealthDTO{
+		RepositoryID: result.RepositoryID,
+		Name:         result.Name,
+		Status:       string(result.Status),
+		Healthy:      result.Healthy,
+		LastSync:     result.LastSync,
+		SyncStale:    result.SyncStale,
+		Problems:     result.Problems,
+	}
+	if result.Structure != nil {
+		valid := result.Structure.Valid
+		dto.StructureValid = &valid
+		dto.MissingDirectories = result.Structure.MissingDirectories
+	}
+	return dto
+}
+
+// Ready handles readiness requests
+// @Summary Readiness check
+// @Description Report whether the server can take uploads. A fresh install awaiting first-run setup is ready to serve setup; an initialized server whose primary repository is missing or offline is degraded.
+// @Tags Health
+// @Produce json
+// @Success 200 {object} ReadinessResponse "Server is ready or awaiting setup"
+// @Failure 503 {object} ReadinessResponse "Server is degraded"
+// @Router /readyz [get]
+func (h *HealthHandler) Ready(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	ready, err := h.bootstrap.IsReady(ctx)
+	if err != nil {
+		c.JSON(http.StatusServiceUnavailable, ReadinessResponse{Status: ReadinessDegraded, Reasons: []string{err.Error()}})
+		return
+	}
+	if !ready {
+		api.JSONOK(c, ReadinessResponse{Status: ReadinessSetupRequired})
+		return
+	}
+
+	if err := storage.CheckPrimaryRepository(ctx, h.repositories); err != nil {
+		c.JSON(http.StatusServiceUnavailable, ReadinessResponse{Status: ReadinessDegraded, Reasons: []string{err.Error()}})
+		return
+	}
+
+	api.JSONOK(c, ReadinessResponse{Status: ReadinessReady})
 }
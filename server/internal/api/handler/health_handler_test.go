@@ -0,0 +1,157 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"server/internal/db/dbtypes"
+	"server/internal/db/repo"
+	"server/internal/service"
+	"server/internal/storage"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+type stubReadinessBootstrap struct {
+	service.BootstrapService
+	ready bool
+}
+
+func (s stubReadinessBootstrap) IsReady(context.Context) (bool, error) { return s.ready, nil }
+
+type stubPrimaryRepository struct {
+	status dbtypes.RepoStatus
+}
+
+func (s stubPrimaryRepository) GetPrimaryRepository(context.Context) (repo.Repository, error) {
+	return repo.Repository{Path: "/mnt/photos", Status: s.status}, nil
+}
+
+func readinessForTest(t *testing.T, handler *HealthHandler) (int, ReadinessResponse) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Request = httptest.NewRequest(http.MethodGet, "/readyz", nil)
+
+	handler.Ready(ctx)
+
+	var response ReadinessResponse
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	return recorder.Code, response
+}
+
+func TestHealthHandlerReadyReportsDegradedPrimaryRepository(t *testing.T) {
+	code, response := readinessForTest(t, NewHealthHandler(
+		stubReadinessBootstrap{ready: true},
+		stubPrimaryRepository{status: dbtypes.RepoStatusOffline},
+		nil,
+	))
+
+	require.Equal(t, http.StatusServiceUnavailable, code)
+	require.Equal(t, ReadinessDegraded, response.Status)
+	require.Len(t, response.Reasons, 1)
+	require.Contains(t, response.Reasons[0], "primary repository unavailable")
+}
+
+func TestHealthHandlerReadyReportsReady(t *testing.T) {
+	code, response := readinessForTest(t, NewHealthHandler(
+		stubReadinessBootstrap{ready: true},
+		stubPrimaryRepository{status: dbtypes.RepoStatusActive},
+		nil,
+	))
+
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, ReadinessReady, response.Status)
+}
+
+func TestHealthHandlerReadyBeforeSetup(t *testing.T) {
+	code, response := readinessForTest(t, NewHealthHandler(
+		stubReadinessBootstrap{ready: false},
+		stubPrimaryRepository{status: dbtypes.RepoStatusOffline},
+		nil,
+	))
+
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, ReadinessSetupRequired, response.Status)
+}
+
+func healthForTest(t *testing.T, handler *HealthHandler, target string) (int, HealthResponse) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Request = httptest.NewRequest(http.MethodGet, target, nil)
+
+	handler.Check(ctx)
+
+	var response HealthResponse
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	return recorder.Code, response
+}
+
+func TestHealthHandlerDeepReportsRepositoryWithMissingDirectory(t *testing.T) {
+	calls := 0
+	repositoryHealth := func(context.Context) ([]storage.RepositoryHealth, error) {
+		calls++
+		return []storage.RepositoryHealth{
+			{RepositoryID: "a", Name: "Primary", Status: dbtypes.RepoStatusActive, Healthy: true, Structure: &storage.StructureValidation{Valid: true}},
+			{
+				RepositoryID: "b",
+				Name:         "Archive",
+				Status:       dbtypes.RepoStatusActive,
+				Structure:    &storage.StructureValidation{Valid: true, MissingDirectories: []string{".lumilio/assets/faces"}},
+				Problems:     []string{"missing directory: .lumilio/assets/faces"},
+			},
+		}, nil
+	}
+	handler := NewHealthHandler(stubReadinessBootstrap{ready: true}, stubPrimaryRepository{status: dbtypes.RepoStatusActive}, repositoryHealth)
+
+	// The shallow check never walks repositories.
+	code, response := healthForTest(t, handler, "/api/v1/health")
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, HealthOK, response.Status)
+	require.Empty(t, response.Repositories)
+	require.Zero(t, calls)
+
+	code, response = healthForTest(t, handler, "/api/v1/health?deep=true")
+	require.Equal(t, http.StatusServiceUnavailable, code)
+	require.Equal(t, HealthDegraded, response.Status)
+	require.Len(t, response.Repositories, 2)
+	require.True(t, response.Repositories[0].Healthy)
+	archive := response.Repositories[1]
+	require.False(t, archive.Healthy)
+	require.Equal(t, []string{".lumilio/assets/faces"}, archive.MissingDirectories)
+	require.Equal(t, 1, calls)
+}
+
+func TestHealthHandlerDeepAllHealthy(t *testing.T) {
+	handler := NewHealthHandler(stubReadinessBootstrap{ready: true}, stubPrimaryRepository{status: dbtypes.RepoStatusActive},
+		func(context.Context) ([]storage.RepositoryHealth, error) {
+			return []storage.RepositoryHealth{{RepositoryID: "a", Name: "Primary", Healthy: true}}, nil
+		})
+
+	code, response := healthForTest(t, handler, "/api/v1/health?deep=1")
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, HealthOK, response.Status)
+	require.Len(t, response.Repositories, 1)
+}
+
+func TestHealthHandlerDeepCheckFailure(t *testing.T) {
+	handler := NewHealthHandler(stubReadinessBootstrap{ready: true}, stubPrimaryRepository{status: dbtypes.RepoStatusActive},
+		func(context.Context) ([]storage.RepositoryHealth, error) {
+			return nil, errors.New("list repositories: connection refused")
+		})
+
+	code, response := healthForTest(t, handler, "/api/v1/health?deep=true")
+	require.Equal(t, http.StatusServiceUnavailable, code)
+	require.Equal(t, HealthDegraded, response.Status)
+	require.Contains(t, response.Reasons[0], "connection refused")
+}
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"log"
 	"net/http"
 	"os"
 	"path/filepath"
@@ -17,6 +18,7 @@ import (
 	"server/internal/storage"
 
 	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
 )
 
 // assetDownloadFile pairs a resolved asset with its on-disk original path,
@@ -26,8 +28,21 @@ import (
 type assetDownloadFile struct {
 	asset repo.Asset
 	path  string
+	size  int64
 }
 
+// skippedDownloadFile is an asset left out of a zip download because its
+// original could not be found. Skipped assets are listed in a trailing
+// manifest entry instead of failing the whole archive.
+type skippedDownloadFile struct {
+	assetID  string
+	filename string
+	reason   string
+}
+
+// missingFilesManifestName is the zip entry listing skipped assets.
+const missingFilesManifestName = "MISSING-FILES.txt"
+
 // getRepositoryForAsset resolves the repository row an asset belongs to.
 // Shared by AssetHandler (authenticated media) and ShareLinkHandler (public
 // share media) so the two never drift on how a storage path is resolved.
@@ -36,6 +51,12 @@ func getRepositoryForAsset(ctx context.Context, queries *repo.Queries, asset *re
 		return nil, fmt.Errorf("asset is nil")
 	}
 	if !asset.RepositoryID.Valid {
+		// Removing a repository detaches its assets rather than deleting them.
+		// Those must answer "gone", not fall through to some other repository
+		// or a generic failure.
+		if removal, err := queries.GetAssetRepositoryRemoval(ctx, asset.AssetID); err == nil {
+			return nil, fmt.Errorf("%w: %s", storage.ErrRepositoryRemoved, removal.RepositoryName)
+		}
 		return nil, fmt.Errorf("asset repository id is invalid")
 	}
 
@@ -59,8 +80,13 @@ func getRepositoryForAsset(ctx context.Context, queries *repo.Queries, asset *re
 // a recoverable condition the user can act on, while a 500 reads as a server
 // fault and tells the UI nothing it can show. 409 is the same status the ingest
 // path already returns for an offline repository, so a client has one code to
-// recognize regardless of which endpoint it hit.
+// recognize regardless of which endpoint it hit. An asset whose repository was
+// removed is 410: unlike an offline drive, retrying will not bring it back.
 func respondRepositoryResolveError(c *gin.Context, err error, message string) {
+	if errors.Is(err, storage.ErrRepositoryRemoved) {
+		api.GinError(c, http.StatusGone, err, http.StatusGone, "Asset repository was removed")
+		return
+	}
 	if errors.Is(err, storage.ErrRepositoryOffline) {
 		api.GinError(c, http.StatusConflict, err, http.StatusConflict, "Repository is unavailable")
 		return
@@ -78,6 +104,25 @@ func resolveRepositoryPath(repositoryPath string, storagePath string) string {
 	return filepath.Join(repositoryPath, trimmed)
 }
 
+// resolveOriginalPath returns the file holding an asset's original: the one in
+// its repository or, once that was moved to the repository's archive, the
+// archived copy. archived reports the second, typically slower, location. A
+// missing original resolves to its repository path for the caller to report.
+func resolveOriginalPath(ctx context.Context, queries *repo.Queries, repositoryPath string, asset *repo.Asset) (path string, archived bool) {
+	local := resolveRepositoryPath(repositoryPath, *asset.StoragePath)
+	if _, err := os.Stat(local); !os.IsNotExist(err) {
+		return local, false
+	}
+	record, err := queries.GetArchivedOriginal(ctx, asset.AssetID)
+	if err != nil {
+		if !errors.Is(err, pgx.ErrNoRows) {
+			log.Printf("Failed to look up archived original of asset %s: %v", asset.AssetID.String(), err)
+		}
+		return local, false
+	}
+	return record.ArchivePath, true
+}
+
 // writeAssetToZip streams one asset's original file into an open zip writer,
 // deduping archive entry names via uniqueZipArchiveName.
 func writeAssetToZip(zipWriter *zip.Writer, archiveNames map[string]int, file assetDownloadFile) error {
@@ -97,6 +142,44 @@ func writeAssetToZip(zipWriter *zip.Writer, archiveNames map[string]int, file as
 	return err
 }
 
+// writeMissingFilesManifest appends the manifest of skipped assets as the
+// last entry of the archive, one "asset id, filename, reason" line each.
+func writeMissingFilesManifest(zipWriter *zip.Writer, archiveNames map[string]int, skipped []skippedDownloadFile) error {
+	entry, err := zipWriter.Create(uniqueZipArchiveName(archiveNames, missingFilesManifestName))
+	if err != nil {
+		return err
+	}
+	var manifest strings.Builder
+	manifest.WriteString("These assets were requested but their original files could not be included.\n\n")
+	for _, file := range skipped {
+		fmt.Fprintf(&manifest, "%s\t%s\t%s\n", file.assetID, file.filename, file.reason)
+	}
+	_, err = io.WriteString(entry, manifest.String())
+	return err
+}
+
+// downloadExceedsLimit reports whether files add up to more than limit bytes.
+// A limit of zero disables the check.
+func downloadExceedsLimit(files []assetDownloadFile, limit int64) bool {
+	if limit <= 0 {
+		return false
+	}
+	var total int64
+	for _, file := range files {
+		total += file.size
+		if total > limit {
+			return true
+		}
+	}
+	return false
+}
+
+// respondDownloadTooLarge rejects a zip download over the configured limit.
+func respondDownloadTooLarge(c *gin.Context, limit int64) {
+	api.GinError(c, http.StatusRequestEntityTooLarge, fmt.Errorf("download exceeds %d bytes", limit),
+		http.StatusRequestEntityTooLarge, fmt.Sprintf("Selected files exceed the download limit of %d bytes", limit))
+}
+
 // uniqueZipArchiveName returns a filesystem-safe, collision-free archive
 // entry name for filename, tracking names already used in seen.
 func uniqueZipArchiveName(seen map[string]int, filename string) string {
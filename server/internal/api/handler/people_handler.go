@@ -248,6 +248,10 @@ func (h *PeopleHandler) ListPersonAssets(c *gin.Context) {
 		api.GinBadRequest(c, err, "stack_mode must be 'collapsed' or 'expanded'")
 		return
 	}
+	if err := validateAssetFilterLocation(req.Filter); err != nil {
+		api.GinBadRequest(c, err, err.Error())
+		return
+	}
 	if req.SearchType == "" {
 		req.SearchType = "filename"
 	}
@@ -3,26 +3,58 @@ package handler
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"net/http"
+	"sort"
 	"strconv"
 	"time"
 
 	"server/internal/api"
+	"server/internal/service"
 
 	"github.com/gin-gonic/gin"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/riverqueue/river"
+	"github.com/riverqueue/river/rivertype"
 )
 
-// QueueHandler handles River queue monitoring endpoints (read-only)
+// QueueHandler handles River queue monitoring and pause/resume endpoints
 type QueueHandler struct {
-	dbpool *pgxpool.Pool
+	dbpool      *pgxpool.Pool
+	controls    QueueControls
+	concurrency map[string]int
+	processing  ProcessingPauseControls
+	// runningJobs counts in-flight jobs per queue; it reads river_job through
+	// dbpool unless a test replaces it.
+	runningJobs func(ctx context.Context) (map[string]int64, error)
 }
 
-// NewQueueHandler creates a new queue handler
-func NewQueueHandler(dbpool *pgxpool.Pool) *QueueHandler {
-	return &QueueHandler{
-		dbpool: dbpool,
+// QueueControls is the part of the River client used to inspect, pause and
+// resume queues.
+type QueueControls interface {
+	QueueGet(ctx context.Context, name string) (*rivertype.Queue, error)
+	QueueList(ctx context.Context, params *river.QueueListParams) (*river.QueueListResult, error)
+	QueuePause(ctx context.Context, name string, opts *river.QueuePauseOpts) error
+	QueueResume(ctx context.Context, name string, opts *river.QueuePauseOpts) error
+}
+
+// ProcessingPauseControls reads and toggles the global processing pause.
+type ProcessingPauseControls interface {
+	ProcessingPauseState(ctx context.Context) (service.ProcessingPause, error)
+	SetProcessingPaused(ctx context.Context, paused bool) (service.ProcessingPause, error)
+}
+
+// NewQueueHandler creates a new queue handler. concurrency maps each
+// configured queue to its MaxWorkers.
+func NewQueueHandler(dbpool *pgxpool.Pool, controls QueueControls, concurrency map[string]int, processing ProcessingPauseControls) *QueueHandler {
+	h := &QueueHandler{
+		dbpool:      dbpool,
+		controls:    controls,
+		concurrency: concurrency,
+		processing:  processing,
 	}
+	h.runningJobs = h.countRunningJobs
+	return h
 }
 
 // JobStatsResponse represents overall job statistics
@@ -142,6 +174,230 @@ func (h *QueueHandler) GetJobStats(c *gin.Context) {
 	api.JSONOK(c, stats)
 }
 
+// QueueListResponse lists the job queues and their runtime state.
+type QueueListResponse struct {
+	Queues []QueueStateDTO `json:"queues"`
+}
+
+// QueueStateDTO describes one queue's configuration and runtime state.
+type QueueStateDTO struct {
+	Name        string     `json:"name"`
+	MaxWorkers  int        `json:"max_workers"`
+	Paused      bool       `json:"paused"`
+	PausedAt    *time.Time `json:"paused_at,omitempty"`
+	RunningJobs int64      `json:"running_jobs"`
+	UpdatedAt   *time.Time `json:"updated_at,omitempty"`
+}
+
+// ListQueues godoc
+// @Summary List job queues
+// @Description List every job queue with its configured concurrency, paused state and in-flight job count
+// @Tags Queue
+// @Produce json
+// @Success 200 {object} QueueListResponse
+// @Failure 500 {object} api.ErrorResponse
+// @Router /api/v1/admin/queues [get]
+func (h *QueueHandler) ListQueues(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	listed, err := h.controls.QueueList(ctx, river.NewQueueListParams().First(1000))
+	if err != nil {
+		api.GinInternalError(c, err, "Failed to list queues")
+		return
+	}
+	running, err := h.runningJobs(ctx)
+	if err != nil {
+		api.GinInternalError(c, err, "Failed to count running jobs")
+		return
+	}
+
+	byName := make(map[string]*rivertype.Queue, len(listed.Queues))
+	for _, queue := range listed.Queues {
+		byName[queue.Name] = queue
+	}
+	// Configured queues a client has not registered yet still belong in the
+	// list; so do queues River knows about that this server no longer works.
+	names := make([]string, 0, len(h.concurrency)+len(byName))
+	for name := range h.concurrency {
+		names = append(names, name)
+	}
+	for name := range byName {
+		if _, configured := h.concurrency[name]; !configured {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	queues := make([]QueueStateDTO, 0, len(names))
+	for _, name := range names {
+		queues = append(queues, h.queueState(name, byName[name], running[name]))
+	}
+	api.JSONOK(c, QueueListResponse{Queues: queues})
+}
+
+// PauseQueue godoc
+// @Summary Pause a job queue
+// @Description Stop workers from fetching new jobs from a queue; jobs already running finish normally
+// @Tags Queue
+// @Produce json
+// @Param name path string true "Queue name"
+// @Success 200 {object} QueueStateDTO
+// @Failure 404 {object} api.ErrorResponse
+// @Failure 500 {object} api.ErrorResponse
+// @Router /api/v1/admin/queues/{name}/pause [post]
+func (h *QueueHandler) PauseQueue(c *gin.Context) {
+	h.setQueuePaused(c, true)
+}
+
+// ResumeQueue godoc
+// @Summary Resume a job queue
+// @Description Let workers fetch jobs from a paused queue again
+// @Tags Queue
+// @Produce json
+// @Param name path string true "Queue name"
+// @Success 200 {object} QueueStateDTO
+// @Failure 404 {object} api.ErrorResponse
+// @Failure 500 {object} api.ErrorResponse
+// @Router /api/v1/admin/queues/{name}/resume [post]
+func (h *QueueHandler) ResumeQueue(c *gin.Context) {
+	h.setQueuePaused(c, false)
+}
+
+func (h *QueueHandler) setQueuePaused(c *gin.Context, paused bool) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	name := c.Param("name")
+	// Only queues this server works can be toggled; this also keeps River's
+	// "*" wildcard from pausing everything at once.
+	if _, configured := h.concurrency[name]; !configured {
+		api.GinNotFound(c, errors.New("unknown queue "+name), "Queue not found")
+		return
+	}
+
+	var err error
+	if paused {
+		err = h.controls.QueuePause(ctx, name, nil)
+	} else {
+		err = h.controls.QueueResume(ctx, name, nil)
+	}
+	if err != nil {
+		if errors.Is(err, rivertype.ErrNotFound) {
+			api.GinNotFound(c, err, "Queue not found")
+			return
+		}
+		api.GinInternalError(c, err, "Failed to update queue")
+		return
+	}
+
+	queue, err := h.controls.QueueGet(ctx, name)
+	if err != nil {
+		api.GinInternalError(c, err, "Failed to load queue")
+		return
+	}
+	running, err := h.runningJobs(ctx)
+	if err != nil {
+		api.GinInternalError(c, err, "Failed to count running jobs")
+		return
+	}
+	api.JSONOK(c, h.queueState(name, queue, running[name]))
+}
+
+// ProcessingStateDTO reports the global processing pause.
+type ProcessingStateDTO struct {
+	Paused   bool       `json:"paused"`
+	PausedAt *time.Time `json:"paused_at,omitempty"`
+}
+
+// GetProcessingState godoc
+// @Summary Get the processing pause state
+// @Description Report whether asset processing is globally paused for maintenance
+// @Tags Queue
+// @Produce json
+// @Success 200 {object} ProcessingStateDTO
+// @Failure 500 {object} api.ErrorResponse
+// @Router /api/v1/admin/processing [get]
+func (h *QueueHandler) GetProcessingState(c *gin.Context) {
+	state, err := h.processing.ProcessingPauseState(c.Request.Context())
+	if err != nil {
+		api.GinInternalError(c, err, "Failed to load processing state")
+		return
+	}
+	api.JSONOK(c, processingStateDTO(state))
+}
+
+// PauseProcessing godoc
+// @Summary Pause asset processing
+// @Description Leave ingest and discovery jobs queued until processing is resumed. Uploads are still staged and enqueued, and jobs already running finish normally. The pause survives restarts.
+// @Tags Queue
+// @Produce json
+// @Success 200 {object} ProcessingStateDTO
+// @Failure 500 {object} api.ErrorResponse
+// @Router /api/v1/admin/processing/pause [post]
+func (h *QueueHandler) PauseProcessing(c *gin.Context) {
+	h.setProcessingPaused(c, true)
+}
+
+// ResumeProcessing godoc
+// @Summary Resume asset processing
+// @Description Let ingest and discovery jobs run again after a processing pause
+// @Tags Queue
+// @Produce json
+// @Success 200 {object} ProcessingStateDTO
+// @Failure 500 {object} api.ErrorResponse
+// @Router /api/v1/admin/processing/resume [post]
+func (h *QueueHandler) ResumeProcessing(c *gin.Context) {
+	h.setProcessingPaused(c, false)
+}
+
+func (h *QueueHandler) setProcessingPaused(c *gin.Context, paused bool) {
+	state, err := h.processing.SetProcessingPaused(c.Request.Context(), paused)
+	if err != nil {
+		api.GinInternalError(c, err, "Failed to update processing state")
+		return
+	}
+	api.JSONOK(c, processingStateDTO(state))
+}
+
+func processingStateDTO(state service.ProcessingPause) ProcessingStateDTO {
+	return ProcessingStateDTO{Paused: state.Paused, PausedAt: state.PausedAt}
+}
+
+func (h *QueueHandler) queueState(name string, queue *rivertype.Queue, running int64) QueueStateDTO {
+	state := QueueStateDTO{
+		Name:        name,
+		MaxWorkers:  h.concurrency[name],
+		RunningJobs: running,
+	}
+	if queue != nil {
+		state.Paused = queue.PausedAt != nil
+		state.PausedAt = queue.PausedAt
+		updatedAt := queue.UpdatedAt
+		state.UpdatedAt = &updatedAt
+	}
+	return state
+}
+
+func (h *QueueHandler) countRunningJobs(ctx context.Context) (map[string]int64, error) {
+	rows, err := h.dbpool.Query(ctx, `SELECT queue, COUNT(*) FROM river_job WHERE state = 'running' GROUP BY queue`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int64)
+	for rows.Next() {
+		var queue string
+		var count int64
+		if err := rows.Scan(&queue, &count); err != nil {
+			return nil, err
+		}
+		counts[queue] = count
+	}
+	return counts, rows.Err()
+}
+
 func parseErrorLimit(raw string) int {
 	limit, err := strconv.Atoi(raw)
 	if err != nil || limit < 0 {
@@ -0,0 +1,203 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"server/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/riverqueue/river"
+	"github.com/riverqueue/river/rivertype"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeQueueControls keeps queue pause state in memory the way River's
+// river_queue table would.
+type fakeQueueControls struct {
+	queues map[string]*rivertype.Queue
+}
+
+func newFakeQueueControls(names ...string) *fakeQueueControls {
+	controls := &fakeQueueControls{queues: map[string]*rivertype.Queue{}}
+	for _, name := range names {
+		controls.queues[name] = &rivertype.Queue{Name: name, UpdatedAt: time.Now()}
+	}
+	return controls
+}
+
+func (f *fakeQueueControls) QueueGet(_ context.Context, name string) (*rivertype.Queue, error) {
+	queue, ok := f.queues[name]
+	if !ok {
+		return nil, rivertype.ErrNotFound
+	}
+	return queue, nil
+}
+
+func (f *fakeQueueControls) QueueList(context.Context, *river.QueueListParams) (*river.QueueListResult, error) {
+	result := &river.QueueListResult{}
+	for _, queue := range f.queues {
+		result.Queues = append(result.Queues, queue)
+	}
+	return result, nil
+}
+
+func (f *fakeQueueControls) QueuePause(_ context.Context, name string, _ *river.QueuePauseOpts) error {
+	queue, ok := f.queues[name]
+	if !ok {
+		return rivertype.ErrNotFound
+	}
+	now := time.Now()
+	queue.PausedAt = &now
+	return nil
+}
+
+func (f *fakeQueueControls) QueueResume(_ context.Context, name string, _ *river.QueuePauseOpts) error {
+	queue, ok := f.queues[name]
+	if !ok {
+		return rivertype.ErrNotFound
+	}
+	queue.PausedAt = nil
+	return nil
+}
+
+// fakeProcessingPause keeps the processing pause in memory the way the
+// system_state row would.
+type fakeProcessingPause struct {
+	state service.ProcessingPause
+}
+
+func (f *fakeProcessingPause) ProcessingPauseState(context.Context) (service.ProcessingPause, error) {
+	return f.state, nil
+}
+
+func (f *fakeProcessingPause) SetProcessingPaused(_ context.Context, paused bool) (service.ProcessingPause, error) {
+	switch {
+	case !paused:
+		f.state = service.ProcessingPause{}
+	case f.state.PausedAt == nil:
+		now := time.Now()
+		f.state = service.ProcessingPause{Paused: true, PausedAt: &now}
+	}
+	return f.state, nil
+}
+
+func newQueueTestHandler(controls QueueControls) *QueueHandler {
+	h := NewQueueHandler(nil, controls, map[string]int{"thumbnail_asset": 8, "transcode_asset": 1, "process_face": 1}, &fakeProcessingPause{})
+	h.runningJobs = func(context.Context) (map[string]int64, error) {
+		return map[string]int64{"thumbnail_asset": 3}, nil
+	}
+	return h
+}
+
+func serveQueueRequest(t *testing.T, h *QueueHandler, method, path string) *httptest.ResponseRecorder {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/admin/queues", h.ListQueues)
+	router.POST("/admin/queues/:name/pause", h.PauseQueue)
+	router.POST("/admin/queues/:name/resume", h.ResumeQueue)
+	router.GET("/admin/processing", h.GetProcessingState)
+	router.POST("/admin/processing/pause", h.PauseProcessing)
+	router.POST("/admin/processing/resume", h.ResumeProcessing)
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(method, path, nil))
+	return recorder
+}
+
+func listQueuesForTest(t *testing.T, h *QueueHandler) map[string]QueueStateDTO {
+	t.Helper()
+	recorder := serveQueueRequest(t, h, http.MethodGet, "/admin/queues")
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var response QueueListResponse
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	states := map[string]QueueStateDTO{}
+	for _, queue := range response.Queues {
+		states[queue.Name] = queue
+	}
+	return states
+}
+
+func TestListQueues_ReportsConcurrencyAndRunningJobs(t *testing.T) {
+	// process_face is configured but has not registered with River yet.
+	h := newQueueTestHandler(newFakeQueueControls("thumbnail_asset", "transcode_asset"))
+
+	states := listQueuesForTest(t, h)
+	require.Len(t, states, 3)
+	require.Equal(t, 8, states["thumbnail_asset"].MaxWorkers)
+	require.Equal(t, int64(3), states["thumbnail_asset"].RunningJobs)
+	require.False(t, states["thumbnail_asset"].Paused)
+	require.Equal(t, 1, states["process_face"].MaxWorkers)
+	require.Nil(t, states["process_face"].UpdatedAt)
+}
+
+func TestPauseAndResumeQueue(t *testing.T) {
+	h := newQueueTestHandler(newFakeQueueControls("thumbnail_asset", "transcode_asset"))
+
+	recorder := serveQueueRequest(t, h, http.MethodPost, "/admin/queues/thumbnail_asset/pause")
+	require.Equal(t, http.StatusOK, recorder.Code)
+	var paused QueueStateDTO
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &paused))
+	require.True(t, paused.Paused)
+	require.NotNil(t, paused.PausedAt)
+
+	states := listQueuesForTest(t, h)
+	require.True(t, states["thumbnail_asset"].Paused)
+	require.False(t, states["transcode_asset"].Paused)
+
+	recorder = serveQueueRequest(t, h, http.MethodPost, "/admin/queues/thumbnail_asset/resume")
+	require.Equal(t, http.StatusOK, recorder.Code)
+	var resumed QueueStateDTO
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &resumed))
+	require.False(t, resumed.Paused)
+	require.Nil(t, resumed.PausedAt)
+	require.False(t, listQueuesForTest(t, h)["thumbnail_asset"].Paused)
+}
+
+func TestPauseQueue_UnknownQueueIsNotFound(t *testing.T) {
+	controls := newFakeQueueControls("thumbnail_asset")
+	h := newQueueTestHandler(controls)
+
+	for _, name := range []string{"missing", "*"} {
+		recorder := serveQueueRequest(t, h, http.MethodPost, "/admin/queues/"+name+"/pause")
+		require.Equal(t, http.StatusNotFound, recorder.Code, name)
+	}
+	// Configured but not yet known to River.
+	recorder := serveQueueRequest(t, h, http.MethodPost, "/admin/queues/process_face/pause")
+	require.Equal(t, http.StatusNotFound, recorder.Code)
+	require.Nil(t, controls.queues["thumbnail_asset"].PausedAt)
+}
+
+func processingStateForTest(t *testing.T, h *QueueHandler, method, path string) ProcessingStateDTO {
+	t.Helper()
+	recorder := serveQueueRequest(t, h, method, path)
+	require.Equal(t, http.StatusOK, recorder.Code)
+	var state ProcessingStateDTO
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &state))
+	return state
+}
+
+func TestPauseAndResumeProcessing(t *testing.T) {
+	h := newQueueTestHandler(newFakeQueueControls("thumbnail_asset"))
+
+	require.False(t, processingStateForTest(t, h, http.MethodGet, "/admin/processing").Paused)
+
+	paused := processingStateForTest(t, h, http.MethodPost, "/admin/processing/pause")
+	require.True(t, paused.Paused)
+	require.NotNil(t, paused.PausedAt)
+
+	// Pausing again keeps the original pause time.
+	again := processingStateForTest(t, h, http.MethodPost, "/admin/processing/pause")
+	require.True(t, again.PausedAt.Equal(*paused.PausedAt))
+	require.True(t, processingStateForTest(t, h, http.MethodGet, "/admin/processing").Paused)
+
+	resumed := processingStateForTest(t, h, http.MethodPost, "/admin/processing/resume")
+	require.False(t, resumed.Paused)
+	require.Nil(t, resumed.PausedAt)
+}
@@ -0,0 +1,135 @@
+package handler
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"server/internal/api"
+	"server/internal/api/dto"
+	"server/internal/db/dbtypes"
+	"server/internal/storage"
+	"server/internal/storage/repocfg"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ExportRepositoryConfig returns a repository's configuration as a portable bundle.
+// @Summary Export repository configuration
+// @Description Return the repository's storage strategy and local settings (duplicate handling, processing mode, ML and original policy) as a bundle another instance can import. The repository's identity and path are not included.
+// @Tags repositories
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Repository UUID"
+// @Success 200 {object} dto.RepositoryConfigBundleDTO "Configuration bundle"
+// @Failure 401 {object} api.ErrorResponse "Unauthorized"
+// @Failure 403 {object} api.ErrorResponse "Forbidden"
+// @Failure 404 {object} api.ErrorResponse "Repository not found"
+// @Router /api/v1/repositories/{id}/config/export [get]
+func (h *RepositoryScanHandler) ExportRepositoryConfig(c *gin.Context) {
+	repository, err := h.repoManager.GetRepository(strings.TrimSpace(c.Param("id")))
+	if err != nil {
+		api.GinNotFound(c, err, "Repository not found")
+		return
+	}
+	api.JSONOK(c, toRepositoryConfigBundleDTO(repository.Config.Portable(time.Now())))
+}
+
+// ImportRepositoryConfig creates a repository from an exported configuration bundle.
+// @Summary Import repository configuration
+// @Description Create a regular repository below a registered Storage Location with the settings of an exported bundle. The bundle kind and version are checked. A folder that already holds a repository is refused rather than registered.
+// @Tags repositories
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.ImportRepositoryConfigRequestDTO true "Bundle and target"
+// @Success 200 {object} dto.CreateRepositoryResponseDTO "Repository created"
+// @Failure 400 {object} api.ErrorResponse "Invalid request or unsupported bundle"
+// @Failure 401 {object} api.ErrorResponse "Unauthorized"
+// @Failure 403 {object} api.ErrorResponse "Forbidden"
+// @Failure 409 {object} dto.RepositoryConflictDTO "Storage Location unavailable or primary repository missing"
+// @Failure 500 {object} api.ErrorResponse "Internal server error"
+// @Router /api/v1/repositories/import-config [post]
+func (h *RepositoryScanHandler) ImportRepositoryConfig(c *gin.Context) {
+	if h == nil || h.repoManager == nil {
+		api.GinInternalError(c, errors.New("repository manager unavailable"), "Repository manager unavailable")
+		return
+	}
+
+	var req dto.ImportRepositoryConfigRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		api.GinBadRequest(c, err, "Invalid import request")
+		return
+	}
+	bundle := fromRepositoryConfigBundleDTO(req.Bundle)
+	if err := bundle.Validate(); err != nil {
+		api.GinBadRequest(c, err, "Invalid configuration bundle")
+		return
+	}
+	name := firstNonEmptyString(strings.TrimSpace(req.Name), strings.TrimSpace(bundle.Name))
+	if name == "" {
+		api.GinBadRequest(c, errors.New("repository name is required"), "Repository name is required")
+		return
+	}
+
+	hostOwnerID, err := h.repoManager.HostOwnerID(c.Request.Context())
+	if err != nil {
+		api.GinInternalError(c, err, "Failed to resolve host owner")
+		return
+	}
+	if hostOwnerID == nil {
+		hostOwnerID = adminIDFromContext(c)
+	}
+	result, err := h.repoManager.CreateRepository(c.Request.Context(), storage.CreateRepositorySpec{
+		Name:     name,
+		Role:     dbtypes.RepoRoleRegular,
+		RootID:   strings.TrimSpace(req.RootID),
+		OwnerID:  hostOwnerID,
+		Imported: &bundle,
+	})
+	if err != nil {
+		respondCreateRepositoryError(c, err)
+		return
+	}
+
+	api.JSONOK(c, dto.CreateRepositoryResponseDTO{
+		Repository: toRepositoryDTO(result.Repository),
+		Warnings:   result.Warnings,
+	})
+}
+
+func toRepositoryConfigBundleDTO(bundle repocfg.PortableConfig) dto.RepositoryConfigBundleDTO {
+	return dto.RepositoryConfigBundleDTO{
+		Kind:            bundle.Kind,
+		Version:         bundle.Version,
+		Name:            bundle.Name,
+		ExportedAt:      bundle.ExportedAt,
+		StorageStrategy: bundle.StorageStrategy,
+		LocalSettings: dto.RepositoryLocalSettings{
+			HandleDuplicateFilenames: bundle.LocalSettings.HandleDuplicateFilenames,
+			ProcessingMode:           bundle.LocalSettings.ProcessingMode,
+			MLEnabled:                bundle.LocalSettings.MLEnabled,
+			OriginalPolicy:           bundle.LocalSettings.OriginalPolicy,
+			ArchivePath:              bundle.LocalSettings.ArchivePath,
+			SemanticSkip:             toSemanticSkipDTO(bundle.LocalSettings.SemanticSkip),
+		},
+	}
+}
+
+func fromRepositoryConfigBundleDTO(bundle dto.RepositoryConfigBundleDTO) repocfg.PortableConfig {
+	return repocfg.PortableConfig{
+		Kind:            bundle.Kind,
+		Version:         bundle.Version,
+		Name:            bundle.Name,
+		ExportedAt:      bundle.ExportedAt,
+		StorageStrategy: bundle.StorageStrategy,
+		LocalSettings: repocfg.LocalSettings{
+			HandleDuplicateFilenames: bundle.LocalSettings.HandleDuplicateFilenames,
+			ProcessingMode:           bundle.LocalSettings.ProcessingMode,
+			MLEnabled:                bundle.LocalSettings.MLEnabled,
+			OriginalPolicy:           bundle.LocalSettings.OriginalPolicy,
+			ArchivePath:              bundle.LocalSettings.ArchivePath,
+			SemanticSkip:             fromSemanticSkipDTO(bundle.LocalSettings.SemanticSkip),
+		},
+	}
+}
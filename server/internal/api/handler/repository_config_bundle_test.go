@@ -0,0 +1,130 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"server/internal/api/dto"
+	"server/internal/db/dbtypes"
+	"server/internal/db/repo"
+	"server/internal/storage"
+	"server/internal/storage/repocfg"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+type configBundleRepositoryManagerStub struct {
+	storage.RepositoryManager
+	source      *repo.Repository
+	createdSpec *storage.CreateRepositorySpec
+}
+
+func (s *configBundleRepositoryManagerStub) GetRepository(id string) (*repo.Repository, error) {
+	if s.source == nil || id != uuid.UUID(s.source.RepoID.Bytes).String() {
+		return nil, errors.New("repository not found")
+	}
+	return s.source, nil
+}
+
+func (s *configBundleRepositoryManagerStub) HostOwnerID(context.Context) (*int32, error) {
+	owner := int32(1)
+	return &owner, nil
+}
+
+func (s *configBundleRepositoryManagerStub) CreateRepository(_ context.Context, spec storage.CreateRepositorySpec) (*storage.CreateRepositoryResult, error) {
+	s.createdSpec = &spec
+	cfg := spec.Imported.NewRepositoryConfig(spec.Name)
+	return &storage.CreateRepositoryResult{
+		Repository: &repo.Repository{
+			RepoID: pgtype.UUID{Bytes: uuid.MustParse(cfg.ID), Valid: true},
+			Name:   cfg.Name,
+			Path:   "/data/storage/" + cfg.Name,
+			Config: *cfg,
+			Role:   spec.Role,
+			Status: dbtypes.RepoStatusActive,
+		},
+	}, nil
+}
+
+func serveRepositoryConfigBundle(t *testing.T, method, target, body string, handle func(*gin.Context), params gin.Params) *httptest.ResponseRecorder {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Request = httptest.NewRequest(method, target, strings.NewReader(body))
+	ctx.Request.Header.Set("Content-Type", "application/json")
+	ctx.Params = params
+	handle(ctx)
+	return recorder
+}
+
+func TestRepositoryConfigExportImportRoundTrip(t *testing.T) {
+	disabled := false
+	sourceConfig := repocfg.NewRepositoryConfig("Scans", repocfg.WithStorageStrategy("cas"), repocfg.WithLocalSettings("overwrite"))
+	sourceConfig.LocalSettings.ProcessingMode = repocfg.ProcessingModeManual
+	sourceConfig.LocalSettings.MLEnabled = &disabled
+	sourceConfig.LocalSettings.OriginalPolicy = repocfg.OriginalPolicyArchive
+	sourceConfig.LocalSettings.ArchivePath = "/mnt/cold/scans"
+	sourceID := uuid.New()
+	manager := &configBundleRepositoryManagerStub{source: &repo.Repository{
+		RepoID: pgtype.UUID{Bytes: sourceID, Valid: true},
+		Name:   "Scans",
+		Path:   "/data/storage/scans",
+		Config: *sourceConfig,
+	}}
+	handler := NewRepositoryScanHandler(nil, manager, nil)
+
+	exported := serveRepositoryConfigBundle(t, http.MethodGet, "/api/v1/repositories/"+sourceID.String()+"/config/export", "",
+		handler.ExportRepositoryConfig, gin.Params{{Key: "id", Value: sourceID.String()}})
+	require.Equal(t, http.StatusOK, exported.Code, exported.Body.String())
+	require.NotContains(t, exported.Body.String(), sourceConfig.ID, "the bundle carries no identity")
+	require.NotContains(t, exported.Body.String(), "/data/storage/scans", "the bundle carries no path")
+
+	var bundle dto.RepositoryConfigBundleDTO
+	require.NoError(t, json.Unmarshal(exported.Body.Bytes(), &bundle))
+	require.Equal(t, repocfg.PortableConfigKind, bundle.Kind)
+
+	request, err := json.Marshal(dto.ImportRepositoryConfigRequestDTO{Bundle: bundle, Name: "Scans mirror"})
+	require.NoError(t, err)
+	imported := serveRepositoryConfigBundle(t, http.MethodPost, "/api/v1/repositories/import-config", string(request),
+		handler.ImportRepositoryConfig, nil)
+	require.Equal(t, http.StatusOK, imported.Code, imported.Body.String())
+
+	require.NotNil(t, manager.createdSpec)
+	require.Equal(t, "Scans mirror", manager.createdSpec.Name)
+	require.Equal(t, dbtypes.RepoRoleRegular, manager.createdSpec.Role)
+	created := manager.createdSpec.Imported.NewRepositoryConfig(manager.createdSpec.Name)
+	require.Equal(t, sourceConfig.StorageStrategy, created.StorageStrategy)
+	require.Equal(t, sourceConfig.LocalSettings, created.LocalSettings)
+	require.NotEqual(t, sourceConfig.ID, created.ID)
+
+	var response dto.CreateRepositoryResponseDTO
+	require.NoError(t, json.Unmarshal(imported.Body.Bytes(), &response))
+	require.Equal(t, "cas", response.Repository.StorageStrategy)
+	require.Equal(t, repocfg.ProcessingModeManual, response.Repository.LocalSettings.ProcessingMode)
+}
+
+func TestImportRepositoryConfigRejectsUnsupportedBundles(t *testing.T) {
+	for name, body := range map[string]string{
+		"unknown kind":   `{"bundle":{"kind":"other","version":1,"name":"A","storage_strategy":"date","local_settings":{"handle_duplicate_filenames":"rename"}}}`,
+		"newer version":  `{"bundle":{"kind":"lumilio.repository-config","version":2,"name":"A","storage_strategy":"date","local_settings":{"handle_duplicate_filenames":"rename"}}}`,
+		"bad strategy":   `{"bundle":{"kind":"lumilio.repository-config","version":1,"name":"A","storage_strategy":"by-camera","local_settings":{"handle_duplicate_filenames":"rename"}}}`,
+		"missing bundle": `{"name":"A"}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			manager := &configBundleRepositoryManagerStub{}
+			recorder := serveRepositoryConfigBundle(t, http.MethodPost, "/api/v1/repositories/import-config", body,
+				NewRepositoryScanHandler(nil, manager, nil).ImportRepositoryConfig, nil)
+			require.Equal(t, http.StatusBadRequest, recorder.Code, recorder.Body.String())
+			require.Nil(t, manager.createdSpec)
+		})
+	}
+}
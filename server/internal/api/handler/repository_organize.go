@@ -0,0 +1,123 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"server/internal/api"
+	"server/internal/api/dto"
+	"server/internal/storage"
+	"server/internal/storage/repocfg"
+	"server/internal/storage/scanner"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// defaultOrganizeMoveSample is how many moves a response lists when the
+// request sets no limit.
+const defaultOrganizeMoveSample = 100
+
+// PreviewRepositoryOrganization shows where a storage strategy would put a repository's inbox files.
+// @Summary Preview inbox organization
+// @Description Compute where the date, cas or flat storage strategy puts each file of the repository's inbox, without moving anything. Date folders follow each asset's capture time from EXIF, falling back to the upload time. Only files under inbox/ are considered; the rest of the repository is the user's own layout. An empty strategy uses the repository's configured one.
+// @Tags repositories
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Repository UUID"
+// @Param request body dto.OrganizeRepositoryRequestDTO false "Strategy and sample size"
+// @Success 200 {object} dto.OrganizeRepositoryResponseDTO "Proposed moves"
+// @Failure 400 {object} api.ErrorResponse "Invalid request or repository ID"
+// @Failure 404 {object} api.ErrorResponse "Repository not found"
+// @Failure 409 {object} api.ErrorResponse "Repository is offline"
+// @Failure 500 {object} api.ErrorResponse "Internal server error"
+// @Router /api/v1/repositories/{id}/organize/preview [post]
+func (h *RepositoryScanHandler) PreviewRepositoryOrganization(c *gin.Context) {
+	h.organizeRepository(c, true)
+}
+
+// ApplyRepositoryOrganization moves a repository's inbox files to where a storage strategy puts them.
+// @Summary Apply inbox organization
+// @Description Move each file of the repository's inbox to the path the preview reports and update its asset's storage path. Files are never overwritten: clashing names are resolved by the repository's duplicate handling, with overwrite treated as rename. The repository's configured strategy is not changed; update it separately so new uploads follow. Runs synchronously and stops at the first failed move, leaving earlier moves in place.
+// @Tags repositories
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Repository UUID"
+// @Param request body dto.OrganizeRepositoryRequestDTO false "Strategy and sample size"
+// @Success 200 {object} dto.OrganizeRepositoryResponseDTO "Moves made"
+// @Failure 400 {object} api.ErrorResponse "Invalid request or repository ID"
+// @Failure 404 {object} api.ErrorResponse "Repository not found"
+// @Failure 409 {object} api.ErrorResponse "Repository is offline"
+// @Failure 500 {object} api.ErrorResponse "Internal server error"
+// @Router /api/v1/repositories/{id}/organize/apply [post]
+func (h *RepositoryScanHandler) ApplyRepositoryOrganization(c *gin.Context) {
+	h.organizeRepository(c, false)
+}
+
+func (h *RepositoryScanHandler) organizeRepository(c *gin.Context, dryRun bool) {
+	if h == nil || h.scanService == nil {
+		api.GinInternalError(c, errors.New("repository scan service unavailable"), "Repository scan service unavailable")
+		return
+	}
+
+	var req dto.OrganizeRepositoryRequestDTO
+	if c.Request.Body != nil && c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			api.GinBadRequest(c, err, "Invalid organize request")
+			return
+		}
+	}
+
+	id := strings.TrimSpace(c.Param("id"))
+	if _, err := uuid.Parse(id); err != nil {
+		api.GinBadRequest(c, err, "Invalid repository ID")
+		return
+	}
+
+	result, err := h.scanService.OrganizeInbox(c.Request.Context(), id, req.Strategy, dryRun)
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		api.GinNotFound(c, err, "Repository not found")
+		return
+	case errors.Is(err, storage.ErrRepositoryOffline):
+		api.GinError(c, http.StatusConflict, err, http.StatusConflict, "Repository is unavailable")
+		return
+	case errors.Is(err, repocfg.ErrInvalidConfig):
+		api.GinBadRequest(c, err, "Invalid storage strategy")
+		return
+	case err != nil:
+		api.GinInternalError(c, err, "Failed to organize repository")
+		return
+	}
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultOrganizeMoveSample
+	}
+	api.JSONOK(c, toOrganizeRepositoryResponseDTO(id, dryRun, result, limit))
+}
+
+func toOrganizeRepositoryResponseDTO(repositoryID string, dryRun bool, result scanner.OrganizeResult, limit int) dto.OrganizeRepositoryResponseDTO {
+	sample := result.Moves
+	if len(sample) > limit {
+		sample = sample[:limit]
+	}
+	moves := make([]dto.OrganizeMoveDTO, 0, len(sample))
+	for _, move := range sample {
+		moves = append(moves, dto.OrganizeMoveDTO{AssetID: move.AssetID, From: move.From, To: move.To})
+	}
+	return dto.OrganizeRepositoryResponseDTO{
+		RepositoryID: repositoryID,
+		Strategy:     result.Strategy,
+		DryRun:       dryRun,
+		TotalMoves:   len(result.Moves),
+		Moves:        moves,
+		Unchanged:    result.Unchanged,
+		Skipped:      result.Skipped,
+		Applied:      result.Applied,
+	}
+}
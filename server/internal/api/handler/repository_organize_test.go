@@ -0,0 +1,113 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"server/internal/api/dto"
+	"server/internal/storage/repocfg"
+	"server/internal/storage/scanner"
+
+	"github.com/gin-gonic/gin"
+)
+
+type organizeScanServiceStub struct {
+	RepositoryScanService
+	err      error
+	result   scanner.OrganizeResult
+	strategy string
+	dryRun   bool
+	calls    int
+}
+
+func (s *organizeScanServiceStub) OrganizeInbox(_ context.Context, _ string, strategy string, dryRun bool) (scanner.OrganizeResult, error) {
+	s.calls++
+	s.strategy = strategy
+	s.dryRun = dryRun
+	return s.result, s.err
+}
+
+func performOrganize(t *testing.T, scanService RepositoryScanService, action, repositoryID, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Params = gin.Params{{Key: "id", Value: repositoryID}}
+	ctx.Request = httptest.NewRequest(http.MethodPost, "/api/v1/repositories/"+repositoryID+"/organize/"+action, strings.NewReader(body))
+	ctx.Request.Header.Set("Content-Type", "application/json")
+	h := NewRepositoryScanHandler(scanService, nil, nil)
+	if action == "apply" {
+		h.ApplyRepositoryOrganization(ctx)
+	} else {
+		h.PreviewRepositoryOrganization(ctx)
+	}
+	return recorder
+}
+
+func TestOrganizeRepositoryPreviewIsDryRunAndSamplesMoves(t *testing.T) {
+	repositoryID := "7e32cc57-bfe0-42b2-943b-d43e0510e0bd"
+	scanService := &organizeScanServiceStub{result: scanner.OrganizeResult{
+		Strategy: "date",
+		Moves: []scanner.OrganizeMove{
+			{AssetID: "a", From: "inbox/2026/10/a.jpg", To: "inbox/2021/05/a.jpg"},
+			{AssetID: "b", From: "inbox/2026/10/b.jpg", To: "inbox/2021/06/b.jpg"},
+			{AssetID: "c", From: "inbox/2026/10/c.jpg", To: "inbox/2021/07/c.jpg"},
+		},
+		Unchanged: 5,
+	}}
+
+	recorder := performOrganize(t, scanService, "preview", repositoryID, `{"strategy":"date","limit":2}`)
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("status = %d, body = %s", recorder.Code, recorder.Body.String())
+	}
+	if !scanService.dryRun || scanService.strategy != "date" {
+		t.Fatalf("preview called OrganizeInbox with strategy %q dryRun %v", scanService.strategy, scanService.dryRun)
+	}
+	var got dto.OrganizeRepositoryResponseDTO
+	if err := json.Unmarshal(recorder.Body.Bytes(), &got); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if !got.DryRun || got.TotalMoves != 3 || len(got.Moves) != 2 || got.Moves[1].To != "inbox/2021/06/b.jpg" || got.Unchanged != 5 {
+		t.Fatalf("response = %+v", got)
+	}
+
+	recorder = performOrganize(t, scanService, "apply", repositoryID, "")
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("apply status = %d, body = %s", recorder.Code, recorder.Body.String())
+	}
+	if scanService.dryRun || scanService.strategy != "" {
+		t.Fatalf("apply called OrganizeInbox with strategy %q dryRun %v", scanService.strategy, scanService.dryRun)
+	}
+}
+
+func TestOrganizeRepositoryMapsErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		id    string
+		body  string
+		err   error
+		want  int
+		calls int
+	}{
+		{name: "malformed repository", id: "nope", want: http.StatusBadRequest},
+		{name: "unknown strategy", id: "9e71fa01-7881-462c-970b-d750af832314", body: `{"strategy":"by-camera"}`, want: http.StatusBadRequest},
+		{name: "invalid repository strategy", id: "9e71fa01-7881-462c-970b-d750af832314", err: fmt.Errorf("%w: invalid storage strategy", repocfg.ErrInvalidConfig), want: http.StatusBadRequest, calls: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scanService := &organizeScanServiceStub{err: tt.err}
+			recorder := performOrganize(t, scanService, "apply", tt.id, tt.body)
+			if recorder.Code != tt.want {
+				t.Fatalf("status = %d, want %d, body = %s", recorder.Code, tt.want, recorder.Body.String())
+			}
+			if scanService.calls != tt.calls {
+				t.Fatalf("OrganizeInbox ran %d times, want %d", scanService.calls, tt.calls)
+			}
+		})
+	}
+}
@@ -2,7 +2,9 @@ package handler
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
 	"strings"
@@ -14,6 +16,7 @@ import (
 	"server/internal/db/dbtypes"
 	"server/internal/db/repo"
 	"server/internal/storage"
+	"server/internal/storage/repocfg"
 	"server/internal/storage/scanner"
 
 	"github.com/gin-gonic/gin"
@@ -25,6 +28,13 @@ type RepositoryScanService interface {
 	EnqueueManualScan(ctx context.Context, repositoryID string, requestedBy string, force bool) (scanner.EnqueueResult, error)
 	GetLatestScanRun(ctx context.Context, repositoryID string) (repo.RepositoryScanRun, error)
 	ListScanRuns(ctx context.Context, repositoryID string, limit, offset int32) ([]repo.RepositoryScanRun, error)
+	RebuildFileRecords(ctx context.Context, repositoryID string, dryRun bool) (scanner.FileRecordRebuildResult, error)
+	OrganizeInbox(ctx context.Context, repositoryID, strategy string, dryRun bool) (scanner.OrganizeResult, error)
+	EnqueueSizeCheck(ctx context.Context, repositoryID string) (scanner.EnqueueResult, error)
+	EnqueueProcessPending(ctx context.Context, repositoryID string) (scanner.EnqueueResult, error)
+	ListSizeMismatches(ctx context.Context, repositoryID string) ([]repo.ListRepositorySizeMismatchesRow, error)
+	SubscribeScanRuns(repositoryID string) (<-chan repo.RepositoryScanRun, func(), error)
+	CloseRepositoryScanEvents(repositoryID string)
 }
 
 type RepositoryScanHandler struct {
@@ -101,35 +111,7 @@ func (h *RepositoryScanHandler) CreateRepository(c *gin.Context) {
 		DuplicateHandling: req.DuplicateHandling,
 	})
 	if err != nil {
-		var conflict *storage.RepositoryConflictError
-		switch {
-		case errors.Is(err, storage.ErrPrimaryRepositoryExists):
-			writeRepositoryConflict(c, "primary_exists", "Primary repository already exists")
-		case errors.Is(err, storage.ErrPrimaryRepositoryRequired):
-			writeRepositoryConflict(c, "primary_required", "Primary repository must be created first")
-		case errors.Is(err, storage.ErrRepositoryRootOffline):
-			writeRepositoryConflict(c, "storage_location_offline", "Storage Location is offline")
-		case errors.Is(err, storage.ErrRepositoryRootInvalid):
-			writeRepositoryConflict(c, "storage_location_invalid", "Storage Location needs attention")
-		case errors.Is(err, storage.ErrRepositoryExistsAtPath):
-			api.GinBadRequest(c, err, "Repository already exists")
-		case errors.Is(err, storage.ErrPathNotAllowed):
-			api.GinBadRequest(c, err, "Repository path is not allowed")
-		case errors.As(err, &conflict):
-			// Only the user can say whether this is the same library that moved
-			// or an independent copy. Hand back both paths so the client can ask.
-			c.JSON(http.StatusConflict, dto.RepositoryConflictDTO{
-				Code:           http.StatusConflict,
-				Message:        "Repository identity is already registered",
-				ConflictType:   "repository_identity",
-				RepositoryID:   conflict.RepositoryID,
-				RegisteredPath: conflict.RegisteredPath,
-				RequestedPath:  conflict.RequestedPath,
-				Actions:        []string{"relocate", "copy"},
-			})
-		default:
-			api.GinBadRequest(c, err, "Failed to create repository")
-		}
+		respondCreateRepositoryError(c, err)
 		return
 	}
 	dbRepo := result.Repository
@@ -175,6 +157,42 @@ func (h *RepositoryScanHandler) CreateRepository(c *gin.Context) {
 	})
 }
 
+// respondCreateRepositoryError maps a storage.CreateRepository failure to
+// its response.
+func respondCreateRepositoryError(c *gin.Context, err error) {
+	var conflict *storage.RepositoryConflictError
+	switch {
+	case errors.Is(err, storage.ErrPrimaryRepositoryExists):
+		writeRepositoryConflict(c, "primary_exists", "Primary repository already exists")
+	case errors.Is(err, storage.ErrPrimaryRepositoryRequired):
+		writeRepositoryConflict(c, "primary_required", "Primary repository must be created first")
+	case errors.Is(err, storage.ErrRepositoryRootOffline):
+		writeRepositoryConflict(c, "storage_location_offline", "Storage Location is offline")
+	case errors.Is(err, storage.ErrRepositoryRootInvalid):
+		writeRepositoryConflict(c, "storage_location_invalid", "Storage Location needs attention")
+	case errors.Is(err, storage.ErrRepositoryExistsAtPath):
+		api.GinBadRequest(c, err, "Repository already exists")
+	case errors.Is(err, storage.ErrPathNotAllowed):
+		api.GinBadRequest(c, err, "Repository path is not allowed")
+	case errors.Is(err, repocfg.ErrInvalidConfig):
+		api.GinBadRequest(c, err, "Invalid repository configuration")
+	case errors.As(err, &conflict):
+		// Only the user can say whether this is the same library that moved
+		// or an independent copy. Hand back both paths so the client can ask.
+		c.JSON(http.StatusConflict, dto.RepositoryConflictDTO{
+			Code:           http.StatusConflict,
+			Message:        "Repository identity is already registered",
+			ConflictType:   "repository_identity",
+			RepositoryID:   conflict.RepositoryID,
+			RegisteredPath: conflict.RegisteredPath,
+			RequestedPath:  conflict.RequestedPath,
+			Actions:        []string{"relocate", "copy"},
+		})
+	default:
+		api.GinBadRequest(c, err, "Failed to create repository")
+	}
+}
+
 func writeRepositoryConflict(c *gin.Context, conflictType, message string) {
 	c.JSON(http.StatusConflict, dto.RepositoryConflictDTO{
 		Code: http.StatusConflict, Message: message, ConflictType: conflictType,
@@ -232,6 +250,247 @@ func (h *RepositoryScanHandler) QueueRepositoryScan(c *gin.Context) {
 	})
 }
 
+// RebuildFileRecords repairs which file each asset of a repository points at.
+// @Summary Rebuild repository file records
+// @Description Walk the repository and match its files to assets: by stored path, then by content hash for assets whose file moved, and by former path or content hash for assets without a repository, which are attached to this one. Reports matched and unmatched files and assets whose file is missing. New files are not imported; queue a scan for that. Runs synchronously.
+// @Tags repositories
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Repository UUID"
+// @Param request body dto.RebuildFileRecordsRequestDTO false "Rebuild options"
+// @Success 200 {object} dto.RebuildFileRecordsResponseDTO "Rebuild finished"
+// @Failure 400 {object} api.ErrorResponse "Invalid request or repository ID"
+// @Failure 404 {object} api.ErrorResponse "Repository not found"
+// @Failure 409 {object} api.ErrorResponse "Repository is offline"
+// @Failure 500 {object} api.ErrorResponse "Internal server error"
+// @Router /api/v1/repositories/{id}/rebuild-file-records [post]
+func (h *RepositoryScanHandler) RebuildFileRecords(c *gin.Context) {
+	if h == nil || h.scanService == nil {
+		api.GinInternalError(c, errors.New("repository scan service unavailable"), "Repository scan service unavailable")
+		return
+	}
+
+	var req dto.RebuildFileRecordsRequestDTO
+	if c.Request.Body != nil && c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			api.GinBadRequest(c, err, "Invalid rebuild request")
+			return
+		}
+	}
+
+	id := strings.TrimSpace(c.Param("id"))
+	if _, err := uuid.Parse(id); err != nil {
+		api.GinBadRequest(c, err, "Invalid repository ID")
+		return
+	}
+
+	result, err := h.scanService.RebuildFileRecords(c.Request.Context(), id, req.DryRun)
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		api.GinNotFound(c, err, "Repository not found")
+		return
+	case errors.Is(err, storage.ErrRepositoryOffline):
+		api.GinError(c, http.StatusConflict, err, http.StatusConflict, "Repository is unavailable")
+		return
+	case err != nil:
+		api.GinInternalError(c, err, "Failed to rebuild file records")
+		return
+	}
+
+	api.JSONOK(c, dto.RebuildFileRecordsResponseDTO{
+		RepositoryID:    id,
+		DryRun:          req.DryRun,
+		Matched:         result.Matched,
+		Relinked:        result.Relinked,
+		Assigned:        result.Assigned,
+		Unmatched:       len(result.UnmatchedFiles),
+		UnmatchedFiles:  result.UnmatchedFiles,
+		MissingAssetIDs: result.MissingAssetIDs,
+		Partial:         result.Partial,
+	})
+}
+
+// QueueRepositorySizeCheck queues a size quick-check of a repository.
+// @Summary Queue repository size check
+// @Description Queue a quick integrity check that compares every asset's recorded file size with its original on disk, without re-hashing. Assets whose size differs, a sign of truncation or outside modification, are listed by GET /repositories/{id}/size-mismatches once the check has run.
+// @Tags repositories
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Repository UUID"
+// @Success 200 {object} dto.RepositorySizeCheckQueuedDTO "Size check queued"
+// @Failure 400 {object} api.ErrorResponse "Invalid repository ID"
+// @Failure 404 {object} api.ErrorResponse "Repository not found"
+// @Failure 500 {object} api.ErrorResponse "Internal server error"
+// @Router /api/v1/repositories/{id}/size-check [post]
+func (h *RepositoryScanHandler) QueueRepositorySizeCheck(c *gin.Context) {
+	if h == nil || h.scanService == nil {
+		api.GinInternalError(c, errors.New("repository scan service unavailable"), "Repository scan service unavailable")
+		return
+	}
+	id := strings.TrimSpace(c.Param("id"))
+	if _, err := uuid.Parse(id); err != nil {
+		api.GinBadRequest(c, err, "Invalid repository ID")
+		return
+	}
+
+	result, err := h.scanService.EnqueueSizeCheck(c.Request.Context(), id)
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		api.GinNotFound(c, err, "Repository not found")
+		return
+	case err != nil:
+		api.GinInternalError(c, err, "Failed to queue repository size check")
+		return
+	}
+
+	api.JSONOK(c, dto.RepositorySizeCheckQueuedDTO{
+		JobID:        result.JobID,
+		RepositoryID: result.RepositoryID,
+		Status:       result.Status,
+	})
+}
+
+// QueueProcessPendingAssets queues processing of a repository's deferred uploads.
+// @Summary Process pending uploads
+// @Description Queue the processing pipeline (metadata, thumbnails, transcoding) for every asset uploaded to the repository while its processing_mode was manual. Such uploads are stored and listed with status "deferred" but not processed until this is called. Assets are picked up when the job runs, so uploads made before then are included.
+// @Tags repositories
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Repository UUID"
+// @Success 200 {object} dto.RepositoryProcessPendingQueuedDTO "Pending processing queued"
+// @Failure 400 {object} api.ErrorResponse "Invalid repository ID"
+// @Failure 404 {object} api.ErrorResponse "Repository not found"
+// @Failure 500 {object} api.ErrorResponse "Internal server error"
+// @Router /api/v1/repositories/{id}/process-pending [post]
+func (h *RepositoryScanHandler) QueueProcessPendingAssets(c *gin.Context) {
+	if h == nil || h.scanService == nil {
+		api.GinInternalError(c, errors.New("repository scan service unavailable"), "Repository scan service unavailable")
+		return
+	}
+	id := strings.TrimSpace(c.Param("id"))
+	if _, err := uuid.Parse(id); err != nil {
+		api.GinBadRequest(c, err, "Invalid repository ID")
+		return
+	}
+
+	result, err := h.scanService.EnqueueProcessPending(c.Request.Context(), id)
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		api.GinNotFound(c, err, "Repository not found")
+		return
+	case err != nil:
+		api.GinInternalError(c, err, "Failed to queue pending asset processing")
+		return
+	}
+
+	api.JSONOK(c, dto.RepositoryProcessPendingQueuedDTO{
+		JobID:        result.JobID,
+		RepositoryID: result.RepositoryID,
+		Status:       result.Status,
+	})
+}
+
+// GetRepositorySizeMismatches lists the assets the last size check flagged.
+// @Summary List repository size mismatches
+// @Description List the assets whose original on disk had a different size than recorded at import when the repository was last size-checked. Assets re-imported, moved or deleted since are left out. Empty until a size check has run.
+// @Tags repositories
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Repository UUID"
+// @Success 200 {object} dto.RepositorySizeMismatchesDTO "Size mismatches"
+// @Failure 400 {object} api.ErrorResponse "Invalid repository ID"
+// @Failure 404 {object} api.ErrorResponse "Repository not found"
+// @Failure 500 {object} api.ErrorResponse "Internal server error"
+// @Router /api/v1/repositories/{id}/size-mismatches [get]
+func (h *RepositoryScanHandler) GetRepositorySizeMismatches(c *gin.Context) {
+	if h == nil || h.scanService == nil {
+		api.GinInternalError(c, errors.New("repository scan service unavailable"), "Repository scan service unavailable")
+		return
+	}
+	id := strings.TrimSpace(c.Param("id"))
+	if _, err := uuid.Parse(id); err != nil {
+		api.GinBadRequest(c, err, "Invalid repository ID")
+		return
+	}
+
+	rows, err := h.scanService.ListSizeMismatches(c.Request.Context(), id)
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		api.GinNotFound(c, err, "Repository not found")
+		return
+	case err != nil:
+		api.GinInternalError(c, err, "Failed to list size mismatches")
+		return
+	}
+
+	mismatches := make([]dto.AssetSizeMismatchDTO, 0, len(rows))
+	for _, row := range rows {
+		mismatch := dto.AssetSizeMismatchDTO{
+			AssetID:          row.AssetID.String(),
+			OriginalFilename: row.OriginalFilename,
+			RecordedSize:     row.RecordedSize,
+			ActualSize:       row.ActualSize,
+			CheckedAt:        row.CheckedAt.Time,
+		}
+		if row.StoragePath != nil {
+			mismatch.StoragePath = *row.StoragePath
+		}
+		mismatches = append(mismatches, mismatch)
+	}
+	api.JSONOK(c, dto.RepositorySizeMismatchesDTO{RepositoryID: id, Mismatches: mismatches})
+}
+
+// GetRepositoryAssetStats summarises the assets of a repository.
+// @Summary Get repository asset statistics
+// @Description Count the live (not trashed) assets of a repository by type, liked and rated, with their average rating, total original size and upload time range. owner_id restricts the counts to one owner's assets.
+// @Tags repositories
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Repository UUID"
+// @Param owner_id query int false "Only count assets of this owner"
+// @Success 200 {object} dto.RepositoryAssetStatsDTO "Repository asset statistics"
+// @Failure 400 {object} api.ErrorResponse "Invalid repository ID or owner_id"
+// @Failure 404 {object} api.ErrorResponse "Repository not found"
+// @Failure 500 {object} api.ErrorResponse "Internal server error"
+// @Router /api/v1/repositories/{id}/stats [get]
+func (h *RepositoryScanHandler) GetRepositoryAssetStats(c *gin.Context) {
+	id := strings.TrimSpace(c.Param("id"))
+	if _, err := uuid.Parse(id); err != nil {
+		api.GinBadRequest(c, err, "Invalid repository ID")
+		return
+	}
+	ownerID, ok := parseOptionalOwnerID(c)
+	if !ok {
+		return
+	}
+
+	stats, err := h.repoManager.GetRepositoryAssetStats(c.Request.Context(), id, ownerID)
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		api.GinNotFound(c, err, "Repository not found")
+		return
+	case err != nil:
+		api.GinInternalError(c, err, "Failed to get repository statistics")
+		return
+	}
+
+	api.JSONOK(c, dto.RepositoryAssetStatsDTO{
+		RepositoryID:  id,
+		OwnerID:       ownerID,
+		TotalAssets:   stats.TotalAssets,
+		PhotoCount:    stats.PhotoCount,
+		VideoCount:    stats.VideoCount,
+		AudioCount:    stats.AudioCount,
+		LikedCount:    stats.LikedCount,
+		RatedCount:    stats.RatedCount,
+		AverageRating: stats.AverageRating,
+		TotalSize:     stats.TotalSize,
+		OldestUpload:  stats.OldestUpload,
+		NewestUpload:  stats.NewestUpload,
+	})
+}
+
 // GetLatestRepositoryScan returns the latest scan run for a repository.
 // @Summary Get latest repository scan
 // @Description Return the latest scan run for a repository.
@@ -281,6 +540,98 @@ func (h *RepositoryScanHandler) ListRepositoryScans(c *gin.Context) {
 	api.JSONOK(c, dto.RepositoryScanRunListDTO{Scans: items})
 }
 
+// StreamRepositoryScans streams scan run updates for a repository.
+// @Summary Stream repository scan status
+// @Description Server-Sent Events stream of a repository's scan runs. A "scan" event carries the latest run on connect, then every run as it starts, fails or completes, without polling. "heartbeat" events keep the connection open; a "closed" event ends the stream when the repository is removed or the server shuts down.
+// @Tags repositories
+// @Produce text/event-stream
+// @Security BearerAuth
+// @Param id path string true "Repository UUID"
+// @Success 200 {object} dto.RepositoryScanRunDTO "Stream of scan run events"
+// @Failure 400 {object} api.ErrorResponse "Invalid repository ID"
+// @Failure 404 {object} api.ErrorResponse "Repository not found"
+// @Failure 503 {object} api.ErrorResponse "Server shutting down"
+// @Router /api/v1/repositories/{id}/scans/stream [get]
+func (h *RepositoryScanHandler) StreamRepositoryScans(c *gin.Context) {
+	id := strings.TrimSpace(c.Param("id"))
+	if _, err := uuid.Parse(id); err != nil {
+		api.GinBadRequest(c, err, "Invalid repository ID")
+		return
+	}
+	if _, err := h.repoManager.GetRepository(id); err != nil {
+		api.GinNotFound(c, err, "Repository not found")
+		return
+	}
+	flusher, ok := c.Writer.(http.Flusher)
+	if !ok {
+		api.GinInternalError(c, errors.New("streaming unsupported"), "Streaming unsupported")
+		return
+	}
+
+	// Subscribe before reading the latest run so a run that starts in
+	// between is not missed.
+	runs, unsubscribe, err := h.scanService.SubscribeScanRuns(id)
+	if err != nil {
+		if errors.Is(err, scanner.ErrScanEventsClosed) {
+			api.GinError(c, http.StatusServiceUnavailable, err, http.StatusServiceUnavailable, "Server is shutting down")
+			return
+		}
+		api.GinBadRequest(c, err, "Failed to follow repository scans")
+		return
+	}
+	defer unsubscribe()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	heartbeat := time.NewTicker(15 * time.Second)
+	defer heartbeat.Stop()
+	send := func(event string, value any) bool {
+		data, err := json.Marshal(value)
+		if err != nil {
+			return false
+		}
+		_, err = fmt.Fprintf(c.Writer, "event: %s\ndata: %s\n\n", event, data)
+		if err == nil {
+			flusher.Flush()
+		}
+		return err == nil
+	}
+
+	ctx := c.Request.Context()
+	latest, err := h.scanService.GetLatestScanRun(ctx, id)
+	switch {
+	case err == nil:
+		if !send("scan", toRepositoryScanRunDTO(latest)) {
+			return
+		}
+	case errors.Is(err, pgx.ErrNoRows):
+		flusher.Flush()
+	default:
+		send("error", map[string]string{"error": err.Error()})
+		return
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case run, ok := <-runs:
+			if !ok {
+				send("closed", map[string]string{"repository_id": id})
+				return
+			}
+			if !send("scan", toRepositoryScanRunDTO(run)) {
+				return
+			}
+		case <-heartbeat.C:
+			if !send("heartbeat", map[string]int64{"timestamp": time.Now().Unix()}) {
+				return
+			}
+		}
+	}
+}
+
 // ListRepositories returns all registered repositories.
 // @Summary List repositories
 // @Description Return all registered repositories.
@@ -364,8 +715,10 @@ func (h *RepositoryScanHandler) GetRepository(c *gin.Context) {
 // @Param id path string true "Repository UUID"
 // @Param request body dto.UpdateRepositoryRequestDTO true "Fields to update"
 // @Success 200 {object} dto.RepositoryDTO "Repository updated successfully"
-// @Failure 400 {object} api.ErrorResponse "Invalid request"
+// @Failure 400 {object} api.ErrorResponse "Invalid request or repository configuration"
 // @Failure 404 {object} api.ErrorResponse "Repository not found"
+// @Failure 409 {object} api.ErrorResponse "Repository is offline"
+// @Failure 500 {object} api.ErrorResponse "Internal server error"
 // @Router /api/v1/repositories/{id} [patch]
 func (h *RepositoryScanHandler) UpdateRepository(c *gin.Context) {
 	id := strings.TrimSpace(c.Param("id"))
@@ -392,29 +745,118 @@ func (h *RepositoryScanHandler) UpdateRepository(c *gin.Context) {
 	}
 	if req.LocalSettings != nil {
 		cfg.LocalSettings.HandleDuplicateFilenames = req.LocalSettings.HandleDuplicateFilenames
+		if req.LocalSettings.ProcessingMode != "" {
+			cfg.LocalSettings.ProcessingMode = req.LocalSettings.ProcessingMode
+		}
+		if req.LocalSettings.MLEnabled != nil {
+			cfg.LocalSettings.MLEnabled = req.LocalSettings.MLEnabled
+		}
+		if req.LocalSettings.OriginalPolicy != "" {
+			cfg.LocalSettings.OriginalPolicy = req.LocalSettings.OriginalPolicy
+		}
+		if req.LocalSettings.ArchivePath != "" {
+			cfg.LocalSettings.ArchivePath = req.LocalSettings.ArchivePath
+		}
+		if req.LocalSettings.SemanticSkip != nil {
+			cfg.LocalSettings.SemanticSkip = fromSemanticSkipDTO(req.LocalSettings.SemanticSkip)
+		}
 	}
 
 	updated, err := h.repoManager.UpdateRepository(id, cfg, existing.DefaultOwnerID)
-	if err != nil {
-		api.GinBadRequest(c, err, "Failed to update repository")
+	switch {
+	case errors.Is(err, repocfg.ErrInvalidConfig):
+		api.GinBadRequest(c, err, "Invalid repository configuration")
+		return
+	case errors.Is(err, storage.ErrRepositoryOffline):
+		api.GinError(c, http.StatusConflict, err, http.StatusConflict, "Repository is offline; reconnect it before editing")
+		return
+	case err != nil:
+		api.GinInternalError(c, err, "Failed to update repository")
 		return
 	}
 
 	api.JSONOK(c, toRepositoryDTO(updated))
 }
 
+// AssignLegacyAssets attaches assets without a repository to a repository.
+// @Summary Assign legacy assets to a repository
+// @Description Attach every asset that has no repository to the given repository. Each asset's file is located under the repository root by its recorded path and the path is rewritten relative to the root. Assets whose file cannot be found are left unchanged and reported.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.AssignLegacyAssetsRequestDTO true "Target repository"
+// @Success 200 {object} dto.AssignLegacyAssetsResponseDTO "Assignment finished"
+// @Failure 400 {object} api.ErrorResponse "Invalid request"
+// @Failure 401 {object} api.ErrorResponse "Unauthorized"
+// @Failure 403 {object} api.ErrorResponse "Forbidden"
+// @Failure 404 {object} api.ErrorResponse "Repository not found"
+// @Failure 409 {object} api.ErrorResponse "Repository is unavailable"
+// @Failure 500 {object} api.ErrorResponse "Internal server error"
+// @Router /api/v1/admin/assign-repository [post]
+func (h *RepositoryScanHandler) AssignLegacyAssets(c *gin.Context) {
+	if h == nil || h.repoManager == nil {
+		api.GinInternalError(c, errors.New("repository manager unavailable"), "Repository manager unavailable")
+		return
+	}
+
+	var req dto.AssignLegacyAssetsRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		api.GinBadRequest(c, err, "Invalid assign request")
+		return
+	}
+	id := strings.TrimSpace(req.RepositoryID)
+	if _, err := uuid.Parse(id); err != nil {
+		api.GinBadRequest(c, err, "Invalid repository ID")
+		return
+	}
+	if _, err := h.repoManager.GetRepository(id); err != nil {
+		api.GinNotFound(c, err, "Repository not found")
+		return
+	}
+
+	result, err := h.repoManager.AssignLegacyAssets(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, storage.ErrRepositoryOffline) {
+			api.GinError(c, http.StatusConflict, err, http.StatusConflict, "Repository is unavailable")
+			return
+		}
+		api.GinInternalError(c, err, "Failed to assign legacy assets")
+		return
+	}
+
+	unresolved := result.UnresolvedAssetIDs
+	if unresolved == nil {
+		unresolved = []string{}
+	}
+	api.JSONOK(c, dto.AssignLegacyAssetsResponseDTO{
+		RepositoryID:       id,
+		Resolved:           result.Resolved,
+		Unresolved:         len(unresolved),
+		UnresolvedAssetIDs: unresolved,
+	})
+}
+
 // DeleteRepository removes a repository registration.
 // @Summary Delete repository
-// @Description Remove a repository from the registry. Does not delete files on disk.
+// @Description Remove a repository from the registry. Does not delete files on disk. A repository that still has assets is refused unless `assets` says what to do with them: `detach` keeps them in the library without a repository, `soft_delete` also moves them to Trash. Either way their media then answers 410 Gone.
 // @Tags repositories
 // @Produce json
 // @Security BearerAuth
 // @Param id path string true "Repository UUID"
+// @Param assets query string false "What to do with the repository's assets" Enums(detach, soft_delete)
 // @Success 200 {object} api.SuccessResponse "Repository deleted successfully"
+// @Failure 400 {object} api.ErrorResponse "Invalid assets policy"
 // @Failure 404 {object} api.ErrorResponse "Repository not found"
+// @Failure 409 {object} api.ErrorResponse "Primary repository, or repository still has assets"
 // @Router /api/v1/repositories/{id} [delete]
 func (h *RepositoryScanHandler) DeleteRepository(c *gin.Context) {
 	id := strings.TrimSpace(c.Param("id"))
+	policy, err := storage.ParseRemovedAssetPolicy(c.Query("assets"))
+	if err != nil {
+		api.GinBadRequest(c, err, "assets must be detach or soft_delete")
+		return
+	}
 
 	existing, err := h.repoManager.GetRepository(id)
 	if err != nil {
@@ -426,10 +868,17 @@ func (h *RepositoryScanHandler) DeleteRepository(c *gin.Context) {
 		return
 	}
 
-	if err := h.repoManager.RemoveRepository(id); err != nil {
+	if err := h.repoManager.RemoveRepository(id, policy); err != nil {
+		if errors.Is(err, storage.ErrRepositoryHasAssets) {
+			api.GinError(c, http.StatusConflict, err, http.StatusConflict, "Repository still has assets; pass assets=detach or assets=soft_delete")
+			return
+		}
 		api.GinInternalError(c, err, "Failed to delete repository")
 		return
 	}
+	if h.scanService != nil {
+		h.scanService.CloseRepositoryScanEvents(id)
+	}
 
 	api.JSONOK(c, api.SuccessResponse{Message: "Repository deleted successfully"})
 }
@@ -479,10 +928,31 @@ func toRepositoryDTO(repository *repo.Repository) dto.RepositoryDTO {
 		StorageStrategy: repository.Config.StorageStrategy,
 		LocalSettings: dto.RepositoryLocalSettings{
 			HandleDuplicateFilenames: repository.Config.LocalSettings.HandleDuplicateFilenames,
+			ProcessingMode:           firstNonEmptyString(repository.Config.LocalSettings.ProcessingMode, repocfg.ProcessingModeOnUpload),
+			MLEnabled:                repository.Config.LocalSettings.MLEnabled,
+			OriginalPolicy:           firstNonEmptyString(repository.Config.LocalSettings.OriginalPolicy, repocfg.OriginalPolicyKeep),
+			ArchivePath:              repository.Config.LocalSettings.ArchivePath,
+			SemanticSkip:             toSemanticSkipDTO(repository.Config.LocalSettings.SemanticSkip),
 		},
 	}
 }
 
+func toSemanticSkipDTO(skip *repocfg.SemanticSkip) *dto.RepositorySemanticSkip {
+	if skip == nil {
+		return nil
+	}
+	return &dto.RepositorySemanticSkip{Screenshots: skip.Screenshots, Extensions: skip.Extensions}
+}
+
+// fromSemanticSkipDTO maps a rule that skips nothing to nil, so clearing it
+// leaves no empty semantic_skip block in .lumiliorepo.
+func fromSemanticSkipDTO(skip *dto.RepositorySemanticSkip) *repocfg.SemanticSkip {
+	if skip == nil || (!skip.Screenshots && len(skip.Extensions) == 0) {
+		return nil
+	}
+	return &repocfg.SemanticSkip{Screenshots: skip.Screenshots, Extensions: skip.Extensions}
+}
+
 func parseInt32Query(c *gin.Context, key string, fallback int32) int32 {
 	raw := strings.TrimSpace(c.Query(key))
 	if raw == "" {
@@ -2,19 +2,28 @@ package handler
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
+	"server/internal/api"
+	"server/internal/api/dto"
 	"server/internal/cloud"
 	"server/internal/db/dbtypes"
 	"server/internal/db/repo"
 	"server/internal/service"
 	"server/internal/storage"
+	"server/internal/storage/repocfg"
+	"server/internal/storage/scanner"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
@@ -98,3 +107,518 @@ func TestCreateCloudRepositoryUsesActingAdminCredentialAccess(t *testing.T) {
 		t.Fatalf("cloud credential access = %+v, want admin %d", cloudService.bindInput.Access, actorOwnerID)
 	}
 }
+
+type assignLegacyRepositoryManagerStub struct {
+	storage.RepositoryManager
+	repositoryID string
+	offline      bool
+	result       storage.LegacyAssignResult
+	assignedTo   string
+}
+
+func (s *assignLegacyRepositoryManagerStub) GetRepository(id string) (*repo.Repository, error) {
+	if id != s.repositoryID {
+		return nil, errors.New("repository not found")
+	}
+	return &repo.Repository{Name: "Archive"}, nil
+}
+
+func (s *assignLegacyRepositoryManagerStub) AssignLegacyAssets(_ context.Context, id string) (storage.LegacyAssignResult, error) {
+	if s.offline {
+		return storage.LegacyAssignResult{}, fmt.Errorf("%w: Archive", storage.ErrRepositoryOffline)
+	}
+	s.assignedTo = id
+	return s.result, nil
+}
+
+func performAssignLegacyAssets(t *testing.T, manager storage.RepositoryManager, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Request = httptest.NewRequest(http.MethodPost, "/api/v1/admin/assign-repository", strings.NewReader(body))
+	ctx.Request.Header.Set("Content-Type", "application/json")
+	NewRepositoryScanHandler(nil, manager, nil).AssignLegacyAssets(ctx)
+	return recorder
+}
+
+func TestAssignLegacyAssetsReportsCounts(t *testing.T) {
+	repositoryID := "7e32cc57-bfe0-42b2-943b-d43e0510e0bd"
+	manager := &assignLegacyRepositoryManagerStub{
+		repositoryID: repositoryID,
+		result: storage.LegacyAssignResult{
+			Resolved:           4,
+			UnresolvedAssetIDs: []string{"aa8df1d6-92d5-4921-a253-0d66b60f945b"},
+		},
+	}
+
+	recorder := performAssignLegacyAssets(t, manager, `{"repository_id":"`+repositoryID+`"}`)
+
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("status = %d, body = %s", recorder.Code, recorder.Body.String())
+	}
+	if manager.assignedTo != repositoryID {
+		t.Fatalf("assigned to %q, want %q", manager.assignedTo, repositoryID)
+	}
+	var got dto.AssignLegacyAssetsResponseDTO
+	if err := json.Unmarshal(recorder.Body.Bytes(), &got); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if got.Resolved != 4 || got.Unresolved != 1 || len(got.UnresolvedAssetIDs) != 1 {
+		t.Fatalf("response = %+v, want 4 resolved and 1 unresolved", got)
+	}
+}
+
+func TestAssignLegacyAssetsRejectsBadRequests(t *testing.T) {
+	repositoryID := "7e32cc57-bfe0-42b2-943b-d43e0510e0bd"
+	tests := []struct {
+		name    string
+		body    string
+		offline bool
+		want    int
+	}{
+		{name: "missing repository", body: `{}`, want: http.StatusBadRequest},
+		{name: "malformed repository", body: `{"repository_id":"nope"}`, want: http.StatusBadRequest},
+		{name: "unknown repository", body: `{"repository_id":"9e71fa01-7881-462c-970b-d750af832314"}`, want: http.StatusNotFound},
+		{name: "offline repository", body: `{"repository_id":"` + repositoryID + `"}`, offline: true, want: http.StatusConflict},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manager := &assignLegacyRepositoryManagerStub{repositoryID: repositoryID, offline: tt.offline}
+			recorder := performAssignLegacyAssets(t, manager, tt.body)
+			if recorder.Code != tt.want {
+				t.Fatalf("status = %d, want %d, body = %s", recorder.Code, tt.want, recorder.Body.String())
+			}
+			if manager.assignedTo != "" {
+				t.Fatalf("assignment ran for a rejected request")
+			}
+		})
+	}
+}
+
+type rebuildFileRecordsScanServiceStub struct {
+	RepositoryScanService
+	err    error
+	result scanner.FileRecordRebuildResult
+	dryRun bool
+}
+
+func (s *rebuildFileRecordsScanServiceStub) RebuildFileRecords(_ context.Context, _ string, dryRun bool) (scanner.FileRecordRebuildResult, error) {
+	s.dryRun = dryRun
+	return s.result, s.err
+}
+
+func performRebuildFileRecords(t *testing.T, scanService RepositoryScanService, repositoryID, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Params = gin.Params{{Key: "id", Value: repositoryID}}
+	ctx.Request = httptest.NewRequest(http.MethodPost, "/api/v1/repositories/"+repositoryID+"/rebuild-file-records", strings.NewReader(body))
+	ctx.Request.Header.Set("Content-Type", "application/json")
+	NewRepositoryScanHandler(scanService, nil, nil).RebuildFileRecords(ctx)
+	return recorder
+}
+
+func TestRebuildFileRecordsReportsMatchedAndUnmatched(t *testing.T) {
+	repositoryID := "7e32cc57-bfe0-42b2-943b-d43e0510e0bd"
+	scanService := &rebuildFileRecordsScanServiceStub{result: scanner.FileRecordRebuildResult{
+		Matched:        10,
+		Relinked:       2,
+		Assigned:       3,
+		UnmatchedFiles: []string{"new/a.jpg", "new/b.jpg"},
+	}}
+
+	recorder := performRebuildFileRecords(t, scanService, repositoryID, `{"dry_run":true}`)
+
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("status = %d, body = %s", recorder.Code, recorder.Body.String())
+	}
+	if !scanService.dryRun {
+		t.Fatal("dry_run was not passed through")
+	}
+	var got dto.RebuildFileRecordsResponseDTO
+	if err := json.Unmarshal(recorder.Body.Bytes(), &got); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if got.Matched != 10 || got.Relinked != 2 || got.Assigned != 3 || got.Unmatched != 2 || !got.DryRun {
+		t.Fatalf("response = %+v", got)
+	}
+}
+
+func TestRebuildFileRecordsMapsErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		id   string
+		err  error
+		want int
+	}{
+		{name: "malformed repository", id: "nope", want: http.StatusBadRequest},
+		{name: "unknown repository", id: "9e71fa01-7881-462c-970b-d750af832314", err: fmt.Errorf("get repository: %w", pgx.ErrNoRows), want: http.StatusNotFound},
+		{name: "offline repository", id: "9e71fa01-7881-462c-970b-d750af832314", err: fmt.Errorf("%w: Archive", storage.ErrRepositoryOffline), want: http.StatusConflict},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := performRebuildFileRecords(t, &rebuildFileRecordsScanServiceStub{err: tt.err}, tt.id, "")
+			if recorder.Code != tt.want {
+				t.Fatalf("status = %d, want %d, body = %s", recorder.Code, tt.want, recorder.Body.String())
+			}
+		})
+	}
+}
+
+type sizeMismatchScanServiceStub struct {
+	RepositoryScanService
+	err  error
+	rows []repo.ListRepositorySizeMismatchesRow
+}
+
+func (s *sizeMismatchScanServiceStub) ListSizeMismatches(context.Context, string) ([]repo.ListRepositorySizeMismatchesRow, error) {
+	return s.rows, s.err
+}
+
+func performGetSizeMismatches(t *testing.T, scanService RepositoryScanService, repositoryID string) *httptest.ResponseRecorder {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Params = gin.Params{{Key: "id", Value: repositoryID}}
+	ctx.Request = httptest.NewRequest(http.MethodGet, "/api/v1/repositories/"+repositoryID+"/size-mismatches", nil)
+	NewRepositoryScanHandler(scanService, nil, nil).GetRepositorySizeMismatches(ctx)
+	return recorder
+}
+
+func TestGetRepositorySizeMismatchesListsTruncatedAsset(t *testing.T) {
+	repositoryID := "7e32cc57-bfe0-42b2-943b-d43e0510e0bd"
+	assetID := pgtype.UUID{Bytes: uuid.MustParse("aa8df1d6-92d5-4921-a253-0d66b60f945b"), Valid: true}
+	storagePath := "2024/IMG_0001.jpg"
+	checkedAt := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	scanService := &sizeMismatchScanServiceStub{rows: []repo.ListRepositorySizeMismatchesRow{{
+		AssetID:          assetID,
+		StoragePath:      &storagePath,
+		OriginalFilename: "IMG_0001.jpg",
+		RecordedSize:     4821733,
+		ActualSize:       1048576,
+		CheckedAt:        pgtype.Timestamptz{Time: checkedAt, Valid: true},
+	}}}
+
+	recorder := performGetSizeMismatches(t, scanService, repositoryID)
+
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("status = %d, body = %s", recorder.Code, recorder.Body.String())
+	}
+	var got dto.RepositorySizeMismatchesDTO
+	if err := json.Unmarshal(recorder.Body.Bytes(), &got); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	want := dto.AssetSizeMismatchDTO{
+		AssetID:          assetID.String(),
+		StoragePath:      storagePath,
+		OriginalFilename: "IMG_0001.jpg",
+		RecordedSize:     4821733,
+		ActualSize:       1048576,
+		CheckedAt:        checkedAt,
+	}
+	if got.RepositoryID != repositoryID || len(got.Mismatches) != 1 || got.Mismatches[0] != want {
+		t.Fatalf("response = %+v", got)
+	}
+}
+
+func TestGetRepositorySizeMismatchesMapsErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		id   string
+		err  error
+		want int
+	}{
+		{name: "malformed repository", id: "nope", want: http.StatusBadRequest},
+		{name: "unknown repository", id: "9e71fa01-7881-462c-970b-d750af832314", err: fmt.Errorf("get repository: %w", pgx.ErrNoRows), want: http.StatusNotFound},
+		{name: "never checked", id: "9e71fa01-7881-462c-970b-d750af832314", want: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := performGetSizeMismatches(t, &sizeMismatchScanServiceStub{err: tt.err}, tt.id)
+			if recorder.Code != tt.want {
+				t.Fatalf("status = %d, want %d, body = %s", recorder.Code, tt.want, recorder.Body.String())
+			}
+		})
+	}
+}
+
+type repositoryStatsManagerStub struct {
+	storage.RepositoryManager
+	repositoryID string
+	ownerID      *int32
+}
+
+func (s *repositoryStatsManagerStub) GetRepositoryAssetStats(_ context.Context, id string, ownerID *int32) (*storage.RepositoryAssetStats, error) {
+	if id != s.repositoryID {
+		return nil, fmt.Errorf("repository not found: %w", pgx.ErrNoRows)
+	}
+	s.ownerID = ownerID
+	average := 4.5
+	return &storage.RepositoryAssetStats{TotalAssets: 3, PhotoCount: 3, RatedCount: 2, AverageRating: &average, TotalSize: 2048}, nil
+}
+
+func getRepositoryStats(t *testing.T, manager storage.RepositoryManager, id, query string) *httptest.ResponseRecorder {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Request = httptest.NewRequest(http.MethodGet, "/api/v1/repositories/"+id+"/stats?"+query, nil)
+	ctx.Params = gin.Params{{Key: "id", Value: id}}
+	NewRepositoryScanHandler(nil, manager, nil).GetRepositoryAssetStats(ctx)
+	return recorder
+}
+
+func TestGetRepositoryAssetStats(t *testing.T) {
+	repositoryID := "7e32cc57-bfe0-42b2-943b-d43e0510e0bd"
+	manager := &repositoryStatsManagerStub{repositoryID: repositoryID}
+
+	recorder := getRepositoryStats(t, manager, repositoryID, "owner_id=5")
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("status = %d, body = %s", recorder.Code, recorder.Body.String())
+	}
+	if manager.ownerID == nil || *manager.ownerID != 5 {
+		t.Fatalf("owner filter = %v, want 5", manager.ownerID)
+	}
+	var stats dto.RepositoryAssetStatsDTO
+	if err := json.Unmarshal(recorder.Body.Bytes(), &stats); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if stats.RepositoryID != repositoryID || stats.TotalAssets != 3 || stats.TotalSize != 2048 || stats.AverageRating == nil || *stats.AverageRating != 4.5 {
+		t.Fatalf("stats = %+v", stats)
+	}
+
+	for name, tc := range map[string]struct {
+		id, query string
+		want      int
+	}{
+		"malformed id": {"not-a-uuid", "", http.StatusBadRequest},
+		"bad owner":    {repositoryID, "owner_id=abc", http.StatusBadRequest},
+		"unregistered": {"aa8df1d6-92d5-4921-a253-0d66b60f945b", "", http.StatusNotFound},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if code := getRepositoryStats(t, manager, tc.id, tc.query).Code; code != tc.want {
+				t.Fatalf("status = %d, want %d", code, tc.want)
+			}
+		})
+	}
+}
+
+type updateRepositoryManagerStub struct {
+	storage.RepositoryManager
+	offline bool
+}
+
+func (s *updateRepositoryManagerStub) GetRepository(string) (*repo.Repository, error) {
+	return &repo.Repository{Config: *repocfg.NewRepositoryConfig("Archive")}, nil
+}
+
+func (s *updateRepositoryManagerStub) UpdateRepository(_ string, config repocfg.RepositoryConfig, _ *int32) (*repo.Repository, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	if s.offline {
+		return nil, fmt.Errorf("%w: /mnt/archive", storage.ErrRepositoryOffline)
+	}
+	return &repo.Repository{Name: config.Name, Config: config}, nil
+}
+
+func patchRepository(t *testing.T, manager storage.RepositoryManager, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Request = httptest.NewRequest(http.MethodPatch, "/api/v1/repositories/7e32cc57-bfe0-42b2-943b-d43e0510e0bd", strings.NewReader(body))
+	ctx.Request.Header.Set("Content-Type", "application/json")
+	ctx.Params = gin.Params{{Key: "id", Value: "7e32cc57-bfe0-42b2-943b-d43e0510e0bd"}}
+	NewRepositoryScanHandler(nil, manager, nil).UpdateRepository(ctx)
+	return recorder
+}
+
+func TestUpdateRepositoryReportsConfigErrors(t *testing.T) {
+	recorder := patchRepository(t, &updateRepositoryManagerStub{}, `{"storage_strategy":"by-camera"}`)
+	if recorder.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want 400", recorder.Code)
+	}
+	var got api.ErrorResponse
+	if err := json.Unmarshal(recorder.Body.Bytes(), &got); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if got.Message != "Invalid repository configuration" || !strings.Contains(got.Error, "invalid storage strategy 'by-camera'") {
+		t.Fatalf("response = %+v", got)
+	}
+
+	if code := patchRepository(t, &updateRepositoryManagerStub{offline: true}, `{"name":"Renamed"}`).Code; code != http.StatusConflict {
+		t.Fatalf("offline status = %d, want 409", code)
+	}
+	if code := patchRepository(t, &updateRepositoryManagerStub{}, `{"name":"Renamed"}`).Code; code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", code)
+	}
+}
+
+type streamScanServiceStub struct {
+	RepositoryScanService
+	latest *repo.RepositoryScanRun
+	runs   chan repo.RepositoryScanRun
+}
+
+func (s *streamScanServiceStub) SubscribeScanRuns(_ string) (<-chan repo.RepositoryScanRun, func(), error) {
+	return s.runs, func() {}, nil
+}
+
+func (s *streamScanServiceStub) GetLatestScanRun(_ context.Context, _ string) (repo.RepositoryScanRun, error) {
+	if s.latest == nil {
+		return repo.RepositoryScanRun{}, pgx.ErrNoRows
+	}
+	return *s.latest, nil
+}
+
+func TestStreamRepositoryScansSendsLatestAndUpdatesUntilClosed(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repositoryID := "4b0f58b8-8b55-4c58-b0a5-1e3d3d0cb3a1"
+	repoID := pgtype.UUID{Bytes: uuid.MustParse(repositoryID), Valid: true}
+	latest := repo.RepositoryScanRun{ScanID: pgtype.UUID{Bytes: uuid.New(), Valid: true}, RepositoryID: repoID, Status: scanner.ScanStatusCompleted}
+	running := repo.RepositoryScanRun{ScanID: pgtype.UUID{Bytes: uuid.New(), Valid: true}, RepositoryID: repoID, Status: scanner.ScanStatusRunning}
+
+	scanService := &streamScanServiceStub{latest: &latest, runs: make(chan repo.RepositoryScanRun, 1)}
+	scanService.runs <- running
+	close(scanService.runs)
+	manager := &assignLegacyRepositoryManagerStub{repositoryID: repositoryID}
+
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Request = httptest.NewRequest(http.MethodGet, "/api/v1/repositories/"+repositoryID+"/scans/stream", nil)
+	ctx.Params = gin.Params{{Key: "id", Value: repositoryID}}
+	NewRepositoryScanHandler(scanService, manager, nil).StreamRepositoryScans(ctx)
+
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("status = %d, body = %s", recorder.Code, recorder.Body.String())
+	}
+	events := strings.Split(strings.TrimSpace(recorder.Body.String()), "\n\n")
+	if len(events) != 3 {
+		t.Fatalf("events = %q, want latest scan, running scan and closed", events)
+	}
+	for i, want := range []string{latest.ScanID.String(), running.ScanID.String()} {
+		if !strings.HasPrefix(events[i], "event: scan\n") || !strings.Contains(events[i], want) {
+			t.Fatalf("event %d = %q, want scan %s", i, events[i], want)
+		}
+	}
+	if !strings.HasPrefix(events[2], "event: closed\n") {
+		t.Fatalf("last event = %q, want closed", events[2])
+	}
+}
+
+func TestStreamRepositoryScansUnknownRepository(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	manager := &assignLegacyRepositoryManagerStub{repositoryID: "4b0f58b8-8b55-4c58-b0a5-1e3d3d0cb3a1"}
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	missing := "0f0f58b8-8b55-4c58-b0a5-1e3d3d0cb3a1"
+	ctx.Request = httptest.NewRequest(http.MethodGet, "/api/v1/repositories/"+missing+"/scans/stream", nil)
+	ctx.Params = gin.Params{{Key: "id", Value: missing}}
+	NewRepositoryScanHandler(&streamScanServiceStub{}, manager, nil).StreamRepositoryScans(ctx)
+
+	if recorder.Code != http.StatusNotFound {
+		t.Fatalf("status = %d, want 404", recorder.Code)
+	}
+}
+
+func TestUpdateRepositorySetsProcessingMode(t *testing.T) {
+	recorder := patchRepository(t, &updateRepositoryManagerStub{}, `{"local_settings":{"handle_duplicate_filenames":"uuid","processing_mode":"manual"}}`)
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("status = %d, body = %s", recorder.Code, recorder.Body.String())
+	}
+	var got dto.RepositoryDTO
+	if err := json.Unmarshal(recorder.Body.Bytes(), &got); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if got.LocalSettings.ProcessingMode != repocfg.ProcessingModeManual {
+		t.Fatalf("processing_mode = %q, want manual", got.LocalSettings.ProcessingMode)
+	}
+
+	recorder = patchRepository(t, &updateRepositoryManagerStub{}, `{"local_settings":{"handle_duplicate_filenames":"uuid"}}`)
+	if err := json.Unmarshal(recorder.Body.Bytes(), &got); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if got.LocalSettings.ProcessingMode != repocfg.ProcessingModeOnUpload {
+		t.Fatalf("omitted processing_mode = %q, want the existing on_upload", got.LocalSettings.ProcessingMode)
+	}
+
+	if code := patchRepository(t, &updateRepositoryManagerStub{}, `{"local_settings":{"handle_duplicate_filenames":"uuid","processing_mode":"later"}}`).Code; code != http.StatusBadRequest {
+		t.Fatalf("invalid processing_mode status = %d, want 400", code)
+	}
+}
+
+func TestUpdateRepositorySetsMLEnabled(t *testing.T) {
+	recorder := patchRepository(t, &updateRepositoryManagerStub{}, `{"local_settings":{"handle_duplicate_filenames":"uuid","ml_enabled":false}}`)
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("status = %d, body = %s", recorder.Code, recorder.Body.String())
+	}
+	var got dto.RepositoryDTO
+	if err := json.Unmarshal(recorder.Body.Bytes(), &got); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if got.LocalSettings.MLEnabled == nil || *got.LocalSettings.MLEnabled {
+		t.Fatalf("ml_enabled = %v, want false", got.LocalSettings.MLEnabled)
+	}
+
+	recorder = patchRepository(t, &updateRepositoryManagerStub{}, `{"local_settings":{"handle_duplicate_filenames":"uuid"}}`)
+	got = dto.RepositoryDTO{}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &got); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if got.LocalSettings.MLEnabled != nil {
+		t.Fatalf("omitted ml_enabled = %v, want unset to follow the global settings", *got.LocalSettings.MLEnabled)
+	}
+}
+
+type processPendingScanServiceStub struct {
+	RepositoryScanService
+	err    error
+	queued string
+}
+
+func (s *processPendingScanServiceStub) EnqueueProcessPending(_ context.Context, repositoryID string) (scanner.EnqueueResult, error) {
+	if s.err != nil {
+		return scanner.EnqueueResult{}, s.err
+	}
+	s.queued = repositoryID
+	return scanner.EnqueueResult{JobID: 42, RepositoryID: repositoryID, Status: scanner.ScanStatusQueued}, nil
+}
+
+func postProcessPending(t *testing.T, scanService RepositoryScanService, repositoryID string) *httptest.ResponseRecorder {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Request = httptest.NewRequest(http.MethodPost, "/api/v1/repositories/"+repositoryID+"/process-pending", nil)
+	ctx.Params = gin.Params{{Key: "id", Value: repositoryID}}
+	NewRepositoryScanHandler(scanService, nil, nil).QueueProcessPendingAssets(ctx)
+	return recorder
+}
+
+func TestQueueProcessPendingAssets(t *testing.T) {
+	repositoryID := "7e32cc57-bfe0-42b2-943b-d43e0510e0bd"
+	scanService := &processPendingScanServiceStub{}
+	recorder := postProcessPending(t, scanService, repositoryID)
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("status = %d, body = %s", recorder.Code, recorder.Body.String())
+	}
+	var got dto.RepositoryProcessPendingQueuedDTO
+	if err := json.Unmarshal(recorder.Body.Bytes(), &got); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if got.JobID != 42 || got.RepositoryID != repositoryID || got.Status != scanner.ScanStatusQueued || scanService.queued != repositoryID {
+		t.Fatalf("response = %+v, queued = %q", got, scanService.queued)
+	}
+
+	if code := postProcessPending(t, &processPendingScanServiceStub{}, "not-a-uuid").Code; code != http.StatusBadRequest {
+		t.Fatalf("invalid id status = %d, want 400", code)
+	}
+	missing := &processPendingScanServiceStub{err: fmt.Errorf("get repository: %w", pgx.ErrNoRows)}
+	if code := postProcessPending(t, missing, repositoryID).Code; code != http.StatusNotFound {
+		t.Fatalf("unknown repository status = %d, want 404", code)
+	}
+}
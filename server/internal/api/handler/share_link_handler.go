@@ -2,12 +2,16 @@ package handler
 
 import (
 	"archive/zip"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"log"
 	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
@@ -27,14 +31,15 @@ import (
 // require authController middleware; the share token itself is the
 // capability, validated via service.ResolvePublic on every request.
 type ShareLinkHandler struct {
-	service      service.ShareLinkService
-	assetService service.AssetService
-	queries      *repo.Queries
+	service          service.ShareLinkService
+	assetService     service.AssetService
+	queries          *repo.Queries
+	maxDownloadBytes int64
 }
 
 // NewShareLinkHandler constructs the share link handler.
-func NewShareLinkHandler(shareService service.ShareLinkService, assetService service.AssetService, queries *repo.Queries) *ShareLinkHandler {
-	return &ShareLinkHandler{service: shareService, assetService: assetService, queries: queries}
+func NewShareLinkHandler(shareService service.ShareLinkService, assetService service.AssetService, queries *repo.Queries, maxDownloadBytes int64) *ShareLinkHandler {
+	return &ShareLinkHandler{service: shareService, assetService: assetService, queries: queries, maxDownloadBytes: maxDownloadBytes}
 }
 
 // --- Authenticated (owner) endpoints -------------------------------------
@@ -85,6 +90,7 @@ func (h *ShareLinkHandler) NewShareLink(c *gin.Context) {
 		ExpiresInDays:    req.ExpiresInDays,
 		AllowDownload:    req.AllowDownload,
 		IncludeOriginals: req.IncludeOriginals,
+		Password:         req.Password,
 	})
 	if err != nil {
 		writeShareLinkCreateError(c, err)
@@ -265,7 +271,8 @@ func writeShareLinkCreateError(c *gin.Context, err error) {
 	switch {
 	case errors.Is(err, service.ErrShareLinkTooLarge),
 		errors.Is(err, service.ErrShareLinkSourceEmpty),
-		errors.Is(err, service.ErrShareLinkInvalidSource):
+		errors.Is(err, service.ErrShareLinkInvalidSource),
+		errors.Is(err, service.ErrShareLinkInvalidPassword):
 		api.GinBadRequest(c, err, err.Error())
 	default:
 		api.GinInternalError(c, err, "Failed to create share link")
@@ -282,9 +289,22 @@ func writeShareLinkLookupError(c *gin.Context, err error) {
 
 // --- Public (token-authorized) endpoints ---------------------------------
 
+// SharePasswordHeader carries a protected share's password on public requests.
+const SharePasswordHeader = "X-Share-Password"
+
+// shareUnlockCookieName names the cookie holding a share's unlock proof. It
+// is derived from the token so unlocking one share doesn't lock another.
+func shareUnlockCookieName(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return "lumilio_share_" + hex.EncodeToString(sum[:8])
+}
+
 // resolvePublicShare authorizes the :token path param. Every public handler
 // must call this first; expired, revoked, and unknown tokens are
 // deliberately indistinguishable to avoid token-probing feedback.
+// Password-protected shares also need the password header or the unlock
+// cookie set by an earlier request that carried it, so <img> thumbnail loads
+// keep working after the viewer has entered the password once.
 func (h *ShareLinkHandler) resolvePublicShare(c *gin.Context) (repo.ShareLink, bool) {
 	token := strings.TrimSpace(c.Param("token"))
 	if token == "" {
@@ -296,6 +316,19 @@ func (h *ShareLinkHandler) resolvePublicShare(c *gin.Context) (repo.ShareLink, b
 		api.GinNotFound(c, err, "Share not found or no longer available")
 		return repo.ShareLink{}, false
 	}
+
+	cookieName := shareUnlockCookieName(token)
+	presented, _ := c.Cookie(cookieName)
+	proof, err := h.service.UnlockPublic(link, token, c.GetHeader(SharePasswordHeader), presented)
+	if err != nil {
+		api.GinUnauthorized(c, err, "This share is password protected")
+		return repo.ShareLink{}, false
+	}
+	if proof != "" && proof != presented {
+		maxAge := int(time.Until(link.ExpiresAt.Time).Seconds())
+		c.SetSameSite(http.SameSiteLaxMode)
+		c.SetCookie(cookieName, proof, maxAge, "/api/v1/public", "", c.Request.TLS != nil, true)
+	}
 	return link, true
 }
 
@@ -326,6 +359,7 @@ func (h *ShareLinkHandler) resolvePublicShareAsset(c *gin.Context, link repo.Sha
 // @Produce json
 // @Param token path string true "Share token"
 // @Success 200 {object} dto.PublicShareMetadataDTO
+// @Failure 401 {object} api.ErrorResponse "This share is password protected"
 // @Failure 404 {object} api.ErrorResponse "Share not found or no longer available"
 // @Router /api/v1/public/shares/{token} [get]
 func (h *ShareLinkHandler) GetPublicShare(c *gin.Context) {
@@ -349,6 +383,7 @@ func (h *ShareLinkHandler) GetPublicShare(c *gin.Context) {
 // @Param token path string true "Share token"
 // @Param request body dto.PublicShareAssetListRequestDTO true "Pagination"
 // @Success 200 {object} dto.PublicShareAssetListResponseDTO
+// @Failure 401 {object} api.ErrorResponse "This share is password protected"
 // @Failure 404 {object} api.ErrorResponse "Share not found or no longer available"
 // @Failure 500 {object} api.ErrorResponse "Internal server error"
 // @Router /api/v1/public/shares/{token}/assets/list [post]
@@ -361,15 +396,35 @@ func (h *ShareLinkHandler) ListPublicShareAssets(c *gin.Context) {
 	var req dto.PublicShareAssetListRequestDTO
 	_ = c.ShouldBindJSON(&req)
 
-	limit := req.Limit
+	limit, offset := clampPublicSharePage(req.Limit, req.Offset)
+	items, total, err := h.publicShareAssetPage(c, link, limit, offset)
+	if err != nil {
+		log.Printf("Failed to list public share assets: %v", err)
+		api.GinInternalError(c, err, "Failed to list share assets")
+		return
+	}
+
+	c.Header("Cache-Control", "private, max-age=0, no-store")
+	api.JSONOK(c, dto.PublicShareAssetListResponseDTO{
+		Items:  items,
+		Total:  total,
+		Limit:  limit,
+		Offset: offset,
+	})
+}
+
+func clampPublicSharePage(limit, offset int) (int, int) {
 	if limit <= 0 || limit > 200 {
 		limit = 50
 	}
-	offset := req.Offset
 	if offset < 0 {
 		offset = 0
 	}
+	return limit, offset
+}
 
+// publicShareAssetPage returns one date-ordered page of a share's snapshot.
+func (h *ShareLinkHandler) publicShareAssetPage(c *gin.Context, link repo.ShareLink, limit, offset int) ([]dto.PublicAssetDTO, int, error) {
 	result, err := h.assetService.QueryBrowseItems(c.Request.Context(), service.QueryAssetsParams{
 		Source:    h.service.PublicAssetSource(link),
 		StackMode: service.StackModeExpanded,
@@ -378,22 +433,66 @@ func (h *ShareLinkHandler) ListPublicShareAssets(c *gin.Context) {
 		Offset:    offset,
 	})
 	if err != nil {
-		log.Printf("Failed to list public share assets: %v", err)
-		api.GinInternalError(c, err, "Failed to list share assets")
-		return
+		return nil, 0, err
 	}
 
 	items := make([]dto.PublicAssetDTO, 0, len(result.Items))
 	for _, item := range result.Items {
 		items = append(items, dto.ToPublicAssetDTO(item.Asset))
 	}
+	return items, int(result.TotalVisible), nil
+}
+
+// GetPublicAlbum returns a shared album with one page of its assets.
+// @Summary Get a public album
+// @Description Get an album shared through POST /api/v1/albums/{id}/share: de-sensitized metadata plus one page of assets with their public thumbnail URLs. Records one view. Password-protected shares need the X-Share-Password header once; the response then sets a cookie that unlocks the share's other public routes.
+// @Tags public-shares
+// @Produce json
+// @Param token path string true "Share token"
+// @Param X-Share-Password header string false "Share password"
+// @Param limit query int false "Maximum number of assets to return" default(50) minimum(1) maximum(200)
+// @Param offset query int false "Number of assets to skip" default(0) minimum(0)
+// @Success 200 {object} dto.PublicAlbumDTO
+// @Failure 401 {object} api.ErrorResponse "This share is password protected"
+// @Failure 404 {object} api.ErrorResponse "Share not found or no longer available"
+// @Failure 500 {object} api.ErrorResponse "Internal server error"
+// @Router /api/v1/public/albums/{token} [get]
+func (h *ShareLinkHandler) GetPublicAlbum(c *gin.Context) {
+	link, ok := h.resolvePublicShare(c)
+	if !ok {
+		return
+	}
+	if link.SourceKind != "album" {
+		api.GinNotFound(c, errors.New("share is not an album"), "Share not found or no longer available")
+		return
+	}
+
+	limit, _ := strconv.Atoi(c.Query("limit"))
+	offset, _ := strconv.Atoi(c.Query("offset"))
+	limit, offset = clampPublicSharePage(limit, offset)
+	items, total, err := h.publicShareAssetPage(c, link, limit, offset)
+	if err != nil {
+		log.Printf("Failed to list public album assets: %v", err)
+		api.GinInternalError(c, err, "Failed to list share assets")
+		return
+	}
+	if err := h.service.RecordView(c.Request.Context(), uuid.UUID(link.ShareID.Bytes)); err != nil {
+		log.Printf("Failed to record share view: %v", err)
+	}
+
+	base := "/api/v1/public/shares/" + url.PathEscape(strings.TrimSpace(c.Param("token"))) + "/assets/"
+	assets := make([]dto.PublicAlbumAssetDTO, 0, len(items))
+	for _, item := range items {
+		assets = append(assets, dto.PublicAlbumAssetDTO{PublicAssetDTO: item, ThumbnailURL: base + item.AssetID + "/thumbnail"})
+	}
 
 	c.Header("Cache-Control", "private, max-age=0, no-store")
-	api.JSONOK(c, dto.PublicShareAssetListResponseDTO{
-		Items:  items,
-		Total:  int(result.TotalVisible),
-		Limit:  limit,
-		Offset: offset,
+	api.JSONOK(c, dto.PublicAlbumDTO{
+		PublicShareMetadataDTO: dto.ToPublicShareMetadataDTO(link),
+		Assets:                 assets,
+		Total:                  total,
+		Limit:                  limit,
+		Offset:                 offset,
 	})
 }
 
@@ -406,6 +505,7 @@ func (h *ShareLinkHandler) ListPublicShareAssets(c *gin.Context) {
 // @Param assetId path string true "Asset ID"
 // @Param size query string false "Thumbnail size" default(medium) Enums(small,medium,large)
 // @Success 200 {file} string "Thumbnail image file"
+// @Failure 401 {object} api.ErrorResponse "This share is password protected"
 // @Failure 404 {object} api.ErrorResponse "Not found"
 // @Router /api/v1/public/shares/{token}/assets/{assetId}/thumbnail [get]
 func (h *ShareLinkHandler) GetPublicShareThumbnail(c *gin.Context) {
@@ -457,6 +557,7 @@ func (h *ShareLinkHandler) GetPublicShareThumbnail(c *gin.Context) {
 // @Param token path string true "Share token"
 // @Param assetId path string true "Asset ID"
 // @Success 200 {file} file "Web-optimized video file"
+// @Failure 401 {object} api.ErrorResponse "This share is password protected"
 // @Failure 404 {object} api.ErrorResponse "Not found"
 // @Router /api/v1/public/shares/{token}/assets/{assetId}/web-video [get]
 func (h *ShareLinkHandler) GetPublicShareWebVideo(c *gin.Context) {
@@ -472,6 +573,7 @@ func (h *ShareLinkHandler) GetPublicShareWebVideo(c *gin.Context) {
 // @Param token path string true "Share token"
 // @Param assetId path string true "Asset ID"
 // @Success 200 {file} file "Web-optimized audio file"
+// @Failure 401 {object} api.ErrorResponse "This share is password protected"
 // @Failure 404 {object} api.ErrorResponse "Not found"
 // @Router /api/v1/public/shares/{token}/assets/{assetId}/web-audio [get]
 func (h *ShareLinkHandler) GetPublicShareWebAudio(c *gin.Context) {
@@ -500,7 +602,7 @@ func (h *ShareLinkHandler) servePublicShareWebMedia(c *gin.Context, assetType, w
 
 	repository, err := getRepositoryForAsset(c.Request.Context(), h.queries, asset)
 	if err != nil {
-		api.GinInternalError(c, err, "Failed to access repository")
+		respondRepositoryResolveError(c, err, "Failed to access repository")
 		return
 	}
 
@@ -508,7 +610,8 @@ func (h *ShareLinkHandler) servePublicShareWebMedia(c *gin.Context, assetType, w
 	if asset.ContentHash != "" {
 		webFilename := asset.ContentHash + webSuffix
 		candidate := filepath.Join(repository.Path, webVersionDir, "web", webFilename)
-		if _, statErr := os.Stat(candidate); statErr == nil {
+		if info, statErr := os.Stat(candidate); statErr == nil {
+			storage.TouchWebDerivative(candidate, info.ModTime(), time.Now())
 			fullPath = candidate
 		}
 	}
@@ -536,6 +639,7 @@ func (h *ShareLinkHandler) servePublicShareWebMedia(c *gin.Context, assetType, w
 // @Param assetId path string true "Asset ID"
 // @Success 200 {file} file "Original file content"
 // @Failure 403 {object} api.ErrorResponse "Original downloads are not enabled for this share"
+// @Failure 401 {object} api.ErrorResponse "This share is password protected"
 // @Failure 404 {object} api.ErrorResponse "Not found"
 // @Router /api/v1/public/shares/{token}/assets/{assetId}/original [get]
 func (h *ShareLinkHandler) GetPublicShareOriginal(c *gin.Context) {
@@ -558,10 +662,10 @@ func (h *ShareLinkHandler) GetPublicShareOriginal(c *gin.Context) {
 
 	repository, err := getRepositoryForAsset(c.Request.Context(), h.queries, asset)
 	if err != nil {
-		api.GinInternalError(c, err, "Failed to access repository")
+		respondRepositoryResolveError(c, err, "Failed to access repository")
 		return
 	}
-	fullPath := resolveRepositoryPath(repository.Path, *asset.StoragePath)
+	fullPath, _ := resolveOriginalPath(c.Request.Context(), h.queries, repository.Path, asset)
 	if _, err := os.Stat(fullPath); os.IsNotExist(err) {
 		api.GinNotFound(c, err, "Original file not found")
 		return
@@ -584,7 +688,9 @@ func (h *ShareLinkHandler) GetPublicShareOriginal(c *gin.Context) {
 // @Param request body dto.PublicShareDownloadRequestDTO false "Optional asset ID subset"
 // @Success 200 {file} file "Zip archive"
 // @Failure 403 {object} api.ErrorResponse "Downloads are not enabled for this share"
+// @Failure 401 {object} api.ErrorResponse "This share is password protected"
 // @Failure 404 {object} api.ErrorResponse "Not found"
+// @Failure 413 {object} api.ErrorResponse "Selected files exceed the download limit"
 // @Router /api/v1/public/shares/{token}/download [post]
 func (h *ShareLinkHandler) DownloadPublicShare(c *gin.Context) {
 	link, ok := h.resolvePublicShare(c)
@@ -628,17 +734,22 @@ func (h *ShareLinkHandler) DownloadPublicShare(c *gin.Context) {
 		if err != nil {
 			continue
 		}
-		fullPath := resolveRepositoryPath(repository.Path, *asset.StoragePath)
-		if info, statErr := os.Stat(fullPath); statErr != nil || info.IsDir() {
+		fullPath, _ := resolveOriginalPath(c.Request.Context(), h.queries, repository.Path, asset)
+		info, statErr := os.Stat(fullPath)
+		if statErr != nil || info.IsDir() {
 			continue
 		}
-		files = append(files, assetDownloadFile{asset: *asset, path: fullPath})
+		files = append(files, assetDownloadFile{asset: *asset, path: fullPath, size: info.Size()})
 	}
 
 	if len(files) == 0 {
 		api.GinNotFound(c, errors.New("no downloadable files in this share"), "No downloadable files in this share")
 		return
 	}
+	if downloadExceedsLimit(files, h.maxDownloadBytes) {
+		respondDownloadTooLarge(c, h.maxDownloadBytes)
+		return
+	}
 
 	filename := fmt.Sprintf("lumilio-share-%s.zip", time.Now().Format("20060102-150405"))
 	c.Header("Cache-Control", "no-store")
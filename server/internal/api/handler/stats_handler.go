@@ -3,10 +3,12 @@ package handler
 import (
 	"context"
 	"errors"
+	"fmt"
 	"server/internal/api"
 	"server/internal/db/repo"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -21,11 +23,18 @@ type statsQuerier interface {
 	GetTimeDistributionMonthly(ctx context.Context, repositoryID pgtype.UUID) ([]repo.GetTimeDistributionMonthlyRow, error)
 	GetDailyActivityHeatmap(ctx context.Context, arg repo.GetDailyActivityHeatmapParams) ([]repo.GetDailyActivityHeatmapRow, error)
 	GetAvailableYears(ctx context.Context, repositoryID pgtype.UUID) ([]int32, error)
+	GetGearCameraCounts(ctx context.Context, arg repo.GetGearCameraCountsParams) ([]repo.GetGearCameraCountsRow, error)
+	GetGearLensCounts(ctx context.Context, arg repo.GetGearLensCountsParams) ([]repo.GetGearLensCountsRow, error)
+	GetGearFocalLengthCounts(ctx context.Context, arg repo.GetGearFocalLengthCountsParams) ([]repo.GetGearFocalLengthCountsRow, error)
+	GetOverviewTypeCounts(ctx context.Context, arg repo.GetOverviewTypeCountsParams) ([]repo.GetOverviewTypeCountsRow, error)
+	GetOverviewAddedSince(ctx context.Context, arg repo.GetOverviewAddedSinceParams) (int64, error)
+	GetOverviewRepositoryStorage(ctx context.Context, arg repo.GetOverviewRepositoryStorageParams) ([]repo.GetOverviewRepositoryStorageRow, error)
 }
 
 // StatsHandler handles HTTP requests for photo statistics
 type StatsHandler struct {
-	queries statsQuerier
+	queries  statsQuerier
+	overview statsOverviewCache
 }
 
 // NewStatsHandler creates a new StatsHandler instance
@@ -89,10 +98,91 @@ type AvailableYearsResponse struct {
 	Years []int `json:"years"`
 }
 
+// GearCount represents shots taken with a single camera body or lens
+type GearCount struct {
+	Name  string `json:"name"`
+	Count int64  `json:"count"`
+}
+
+// GearStatsResponse represents per-camera, per-lens, and focal length usage
+type GearStatsResponse struct {
+	Cameras      []GearCount         `json:"cameras"`
+	Lenses       []GearCount         `json:"lenses"`
+	FocalLengths []FocalLengthBucket `json:"focal_lengths"`
+}
+
+// AssetTypeCount represents live assets and their bytes for one asset type
+type AssetTypeCount struct {
+	Type      string `json:"type"`
+	Count     int64  `json:"count"`
+	SizeBytes int64  `json:"size_bytes"`
+}
+
+// RepositoryStorage represents live assets and their bytes in one repository
+type RepositoryStorage struct {
+	RepositoryID string `json:"repository_id"`
+	Name         string `json:"name"`
+	AssetCount   int64  `json:"asset_count"`
+	SizeBytes    int64  `json:"size_bytes"`
+}
+
+// StatsOverviewResponse aggregates the library for a home dashboard
+type StatsOverviewResponse struct {
+	TotalAssets     int64               `json:"total_assets"`
+	TotalSizeBytes  int64               `json:"total_size_bytes"`
+	ByType          []AssetTypeCount    `json:"by_type"`
+	AddedLast30Days int64               `json:"added_last_30_days"`
+	TopCameras      []GearCount         `json:"top_cameras"`
+	Repositories    []RepositoryStorage `json:"repositories"`
+}
+
 const (
 	heatmapDateLayout = "2006-01-02"
+
+	// overviewTopCameras is how many cameras the overview lists.
+	overviewTopCameras = 5
+	// overviewCacheTTL bounds how stale the overview may be. Every aggregate
+	// scans all live assets, and a dashboard is reloaded far more often than
+	// the library changes.
+	overviewCacheTTL = 30 * time.Second
 )
 
+// statsOverviewCache keeps recent overview responses per scope. The zero
+// value is ready to use.
+type statsOverviewCache struct {
+	mu      sync.Mutex
+	entries map[string]statsOverviewEntry
+}
+
+type statsOverviewEntry struct {
+	response  StatsOverviewResponse
+	expiresAt time.Time
+}
+
+func (c *statsOverviewCache) get(key string, now time.Time) (StatsOverviewResponse, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[key]
+	if !ok || !now.Before(entry.expiresAt) {
+		return StatsOverviewResponse{}, false
+	}
+	return entry.response, true
+}
+
+func (c *statsOverviewCache) put(key string, response StatsOverviewResponse, now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.entries == nil {
+		c.entries = make(map[string]statsOverviewEntry)
+	}
+	for k, entry := range c.entries {
+		if !now.Before(entry.expiresAt) {
+			delete(c.entries, k)
+		}
+	}
+	c.entries[key] = statsOverviewEntry{response: response, expiresAt: now.Add(overviewCacheTTL)}
+}
+
 // GetFocalLengthDistribution godoc
 // @Summary Get focal length distribution
 // @Description Get distribution of commonly used focal lengths
@@ -207,6 +297,180 @@ func (h *StatsHandler) GetCameraLensStats(c *gin.Context) {
 	api.JSONOK(c, response)
 }
 
+// GetGearStats godoc
+// @Summary Get gear usage stats
+// @Description Get shot counts grouped by camera body and by lens, plus the most-used focal lengths (rounded to whole millimetres)
+// @Tags stats
+// @Produce json
+// @Param limit query int false "Maximum entries per group (1-100)" default(20)
+// @Param repository_id query string false "Optional repository UUID filter"
+// @Param owner_id query int false "Optional owner user ID filter; users are always limited to their own assets, admins may pick any owner"
+// @Success 200 {object} GearStatsResponse
+// @Failure 400 {object} api.ErrorResponse
+// @Failure 401 {object} api.ErrorResponse "owner_id sent without authentication"
+// @Failure 403 {object} api.ErrorResponse "owner_id names another user"
+// @Failure 500 {object} api.ErrorResponse
+// @Router /api/v1/assets/gear-stats [get]
+func (h *StatsHandler) GetGearStats(c *gin.Context) {
+	limit, err := strconv.ParseInt(c.DefaultQuery("limit", "20"), 10, 32)
+	if err != nil || limit <= 0 || limit > 100 {
+		api.GinBadRequest(c, err, "Invalid limit parameter")
+		return
+	}
+
+	repositoryID, ok := parseOptionalRepositoryUUID(c)
+	if !ok {
+		return
+	}
+
+	ownerID, ok := statsOwnerScope(c)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+	cameraRows, err := h.queries.GetGearCameraCounts(ctx, repo.GetGearCameraCountsParams{
+		RepositoryID: repositoryID,
+		OwnerID:      ownerID,
+		Limit:        int32(limit),
+	})
+	if err != nil {
+		api.GinInternalError(c, err, "Failed to fetch camera stats")
+		return
+	}
+	lensRows, err := h.queries.GetGearLensCounts(ctx, repo.GetGearLensCountsParams{
+		RepositoryID: repositoryID,
+		OwnerID:      ownerID,
+		Limit:        int32(limit),
+	})
+	if err != nil {
+		api.GinInternalError(c, err, "Failed to fetch lens stats")
+		return
+	}
+	focalRows, err := h.queries.GetGearFocalLengthCounts(ctx, repo.GetGearFocalLengthCountsParams{
+		RepositoryID: repositoryID,
+		OwnerID:      ownerID,
+		Limit:        int32(limit),
+	})
+	if err != nil {
+		api.GinInternalError(c, err, "Failed to fetch focal length stats")
+		return
+	}
+
+	response := GearStatsResponse{
+		Cameras:      make([]GearCount, 0, len(cameraRows)),
+		Lenses:       make([]GearCount, 0, len(lensRows)),
+		FocalLengths: make([]FocalLengthBucket, 0, len(focalRows)),
+	}
+	for _, row := range cameraRows {
+		response.Cameras = append(response.Cameras, GearCount{Name: row.CameraModel, Count: row.Count})
+	}
+	for _, row := range lensRows {
+		response.Lenses = append(response.Lenses, GearCount{Name: row.LensModel, Count: row.Count})
+	}
+	for _, row := range focalRows {
+		response.FocalLengths = append(response.FocalLengths, FocalLengthBucket{FocalLength: float64(row.FocalLength), Count: row.Count})
+	}
+
+	api.JSONOK(c, response)
+}
+
+// GetOverview godoc
+// @Summary Get library overview
+// @Description Get aggregate library statistics for a home dashboard: total assets and bytes, counts by asset type, assets added in the last 30 days, the most-used cameras, and storage per repository. Results are cached for a short time.
+// @Tags stats
+// @Produce json
+// @Param repository_id query string false "Optional repository UUID filter"
+// @Param owner_id query int false "Optional owner user ID filter; users are always limited to their own assets, admins may pick any owner"
+// @Success 200 {object} StatsOverviewResponse
+// @Failure 400 {object} api.ErrorResponse
+// @Failure 401 {object} api.ErrorResponse "owner_id sent without authentication"
+// @Failure 403 {object} api.ErrorResponse "owner_id names another user"
+// @Failure 500 {object} api.ErrorResponse
+// @Router /api/v1/stats/overview [get]
+func (h *StatsHandler) GetOverview(c *gin.Context) {
+	repositoryID, ok := parseOptionalRepositoryUUID(c)
+	if !ok {
+		return
+	}
+	ownerID, ok := statsOwnerScope(c)
+	if !ok {
+		return
+	}
+
+	now := time.Now()
+	cacheKey := repositoryID.String()
+	if ownerID != nil {
+		cacheKey += "/" + strconv.Itoa(int(*ownerID))
+	}
+	if response, ok := h.overview.get(cacheKey, now); ok {
+		api.JSONOK(c, response)
+		return
+	}
+
+	ctx := c.Request.Context()
+	typeRows, err := h.queries.GetOverviewTypeCounts(ctx, repo.GetOverviewTypeCountsParams{
+		RepositoryID: repositoryID,
+		OwnerID:      ownerID,
+	})
+	if err != nil {
+		api.GinInternalError(c, err, "Failed to fetch asset counts")
+		return
+	}
+	added, err := h.queries.GetOverviewAddedSince(ctx, repo.GetOverviewAddedSinceParams{
+		RepositoryID: repositoryID,
+		OwnerID:      ownerID,
+		Since:        pgtype.Timestamptz{Time: now.AddDate(0, 0, -30), Valid: true},
+	})
+	if err != nil {
+		api.GinInternalError(c, err, "Failed to fetch recently added assets")
+		return
+	}
+	cameraRows, err := h.queries.GetGearCameraCounts(ctx, repo.GetGearCameraCountsParams{
+		RepositoryID: repositoryID,
+		OwnerID:      ownerID,
+		Limit:        overviewTopCameras,
+	})
+	if err != nil {
+		api.GinInternalError(c, err, "Failed to fetch camera stats")
+		return
+	}
+	repositoryRows, err := h.queries.GetOverviewRepositoryStorage(ctx, repo.GetOverviewRepositoryStorageParams{
+		OwnerID:      ownerID,
+		RepositoryID: repositoryID,
+	})
+	if err != nil {
+		api.GinInternalError(c, err, "Failed to fetch repository storage")
+		return
+	}
+
+	response := StatsOverviewResponse{
+		ByType:          make([]AssetTypeCount, 0, len(typeRows)),
+		AddedLast30Days: added,
+		TopCameras:      make([]GearCount, 0, len(cameraRows)),
+		Repositories:    make([]RepositoryStorage, 0, len(repositoryRows)),
+	}
+	for _, row := range typeRows {
+		response.ByType = append(response.ByType, AssetTypeCount{Type: row.Type, Count: row.Count, SizeBytes: row.TotalSize})
+		response.TotalAssets += row.Count
+		response.TotalSizeBytes += row.TotalSize
+	}
+	for _, row := range cameraRows {
+		response.TopCameras = append(response.TopCameras, GearCount{Name: row.CameraModel, Count: row.Count})
+	}
+	for _, row := range repositoryRows {
+		response.Repositories = append(response.Repositories, RepositoryStorage{
+			RepositoryID: row.RepoID.String(),
+			Name:         row.Name,
+			AssetCount:   row.AssetCount,
+			SizeBytes:    row.TotalSize,
+		})
+	}
+
+	h.overview.put(cacheKey, response, now)
+	api.JSONOK(c, response)
+}
+
 // GetTimeDistribution godoc
 // @Summary Get time distribution
 // @Description Get shooting time distribution by hour or month
@@ -464,3 +728,48 @@ func parseOptionalRepositoryUUID(c *gin.Context) (pgtype.UUID, bool) {
 
 	return pgtype.UUID{Bytes: repositoryID, Valid: true}, true
 }
+
+// statsOwnerScope resolves the owner filter of a stats request the way asset
+// listings are scoped: users only see their own assets and may only name
+// themselves in owner_id, admins see every owner unless they pick one.
+// Anonymous callers see library-wide numbers and may not filter by owner.
+func statsOwnerScope(c *gin.Context) (*int32, bool) {
+	requested, ok := parseOptionalOwnerID(c)
+	if !ok {
+		return nil, false
+	}
+
+	user, authenticated := currentUserFromContext(c)
+	if !authenticated {
+		if requested != nil {
+			api.GinUnauthorized(c, errors.New("authentication required"), "Authentication required to filter by owner")
+			return nil, false
+		}
+		return nil, true
+	}
+
+	ownerID := ownerScopeID(c)
+	if requested == nil {
+		return ownerID, true
+	}
+	if ownerID != nil && *ownerID != *requested {
+		api.GinForbidden(c, fmt.Errorf("user %d requested stats of user %d", user.UserID, *requested), "You can only view your own stats")
+		return nil, false
+	}
+	return requested, true
+}
+
+func parseOptionalOwnerID(c *gin.Context) (*int32, bool) {
+	rawOwnerID := strings.TrimSpace(c.Query("owner_id"))
+	if rawOwnerID == "" {
+		return nil, true
+	}
+
+	parsed, err := strconv.ParseInt(rawOwnerID, 10, 32)
+	if err != nil || parsed <= 0 {
+		api.GinBadRequest(c, err, "Invalid owner_id parameter")
+		return nil, false
+	}
+	ownerID := int32(parsed)
+	return &ownerID, true
+}
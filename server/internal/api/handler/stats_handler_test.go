@@ -5,10 +5,12 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"sort"
 	"testing"
 	"time"
 
 	"server/internal/db/repo"
+	"server/internal/service"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -23,6 +25,12 @@ type stubStatsQuerier struct {
 	getTimeDistributionMonthlyFn func(repositoryID pgtype.UUID) ([]repo.GetTimeDistributionMonthlyRow, error)
 	getDailyActivityHeatmapFn    func(arg repo.GetDailyActivityHeatmapParams) ([]repo.GetDailyActivityHeatmapRow, error)
 	getAvailableYearsFn          func(repositoryID pgtype.UUID) ([]int32, error)
+	getGearCameraCountsFn        func(arg repo.GetGearCameraCountsParams) ([]repo.GetGearCameraCountsRow, error)
+	getGearLensCountsFn          func(arg repo.GetGearLensCountsParams) ([]repo.GetGearLensCountsRow, error)
+	getGearFocalLengthCountsFn   func(arg repo.GetGearFocalLengthCountsParams) ([]repo.GetGearFocalLengthCountsRow, error)
+	getOverviewTypeCountsFn      func(arg repo.GetOverviewTypeCountsParams) ([]repo.GetOverviewTypeCountsRow, error)
+	getOverviewAddedSinceFn      func(arg repo.GetOverviewAddedSinceParams) (int64, error)
+	getOverviewRepositoryFn      func(arg repo.GetOverviewRepositoryStorageParams) ([]repo.GetOverviewRepositoryStorageRow, error)
 }
 
 func (s stubStatsQuerier) GetFocalLengthDistribution(_ context.Context, repositoryID pgtype.UUID) ([]repo.GetFocalLengthDistributionRow, error) {
@@ -67,6 +75,48 @@ func (s stubStatsQuerier) GetAvailableYears(_ context.Context, repositoryID pgty
 	return nil, nil
 }
 
+func (s stubStatsQuerier) GetGearCameraCounts(_ context.Context, arg repo.GetGearCameraCountsParams) ([]repo.GetGearCameraCountsRow, error) {
+	if s.getGearCameraCountsFn != nil {
+		return s.getGearCameraCountsFn(arg)
+	}
+	return nil, nil
+}
+
+func (s stubStatsQuerier) GetGearLensCounts(_ context.Context, arg repo.GetGearLensCountsParams) ([]repo.GetGearLensCountsRow, error) {
+	if s.getGearLensCountsFn != nil {
+		return s.getGearLensCountsFn(arg)
+	}
+	return nil, nil
+}
+
+func (s stubStatsQuerier) GetGearFocalLengthCounts(_ context.Context, arg repo.GetGearFocalLengthCountsParams) ([]repo.GetGearFocalLengthCountsRow, error) {
+	if s.getGearFocalLengthCountsFn != nil {
+		return s.getGearFocalLengthCountsFn(arg)
+	}
+	return nil, nil
+}
+
+func (s stubStatsQuerier) GetOverviewTypeCounts(_ context.Context, arg repo.GetOverviewTypeCountsParams) ([]repo.GetOverviewTypeCountsRow, error) {
+	if s.getOverviewTypeCountsFn != nil {
+		return s.getOverviewTypeCountsFn(arg)
+	}
+	return nil, nil
+}
+
+func (s stubStatsQuerier) GetOverviewAddedSince(_ context.Context, arg repo.GetOverviewAddedSinceParams) (int64, error) {
+	if s.getOverviewAddedSinceFn != nil {
+		return s.getOverviewAddedSinceFn(arg)
+	}
+	return 0, nil
+}
+
+func (s stubStatsQuerier) GetOverviewRepositoryStorage(_ context.Context, arg repo.GetOverviewRepositoryStorageParams) ([]repo.GetOverviewRepositoryStorageRow, error) {
+	if s.getOverviewRepositoryFn != nil {
+		return s.getOverviewRepositoryFn(arg)
+	}
+	return nil, nil
+}
+
 func TestStatsHandlerGetFocalLengthDistribution_UsesRepositoryScope(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
@@ -174,3 +224,328 @@ func TestStatsHandlerGetAvailableYears_AllRepositories(t *testing.T) {
 
 	require.Equal(t, http.StatusOK, recorder.Code)
 }
+
+func getGearStats(t *testing.T, handler *StatsHandler, user *service.UserResponse, query string) *httptest.ResponseRecorder {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Request = httptest.NewRequest(http.MethodGet, "/api/v1/assets/gear-stats"+query, nil)
+	if user != nil {
+		ctx.Set("current_user", user)
+	}
+
+	handler.GetGearStats(ctx)
+	return recorder
+}
+
+// gearScopeQuerier records the owner every gear query was scoped to.
+func gearScopeQuerier(owners *[]*int32) stubStatsQuerier {
+	return stubStatsQuerier{
+		getGearCameraCountsFn: func(arg repo.GetGearCameraCountsParams) ([]repo.GetGearCameraCountsRow, error) {
+			*owners = append(*owners, arg.OwnerID)
+			return nil, nil
+		},
+		getGearLensCountsFn: func(arg repo.GetGearLensCountsParams) ([]repo.GetGearLensCountsRow, error) {
+			*owners = append(*owners, arg.OwnerID)
+			return nil, nil
+		},
+		getGearFocalLengthCountsFn: func(arg repo.GetGearFocalLengthCountsParams) ([]repo.GetGearFocalLengthCountsRow, error) {
+			*owners = append(*owners, arg.OwnerID)
+			return nil, nil
+		},
+	}
+}
+
+func TestStatsHandlerGetGearStats_GroupsByCameraAndLens(t *testing.T) {
+	rawRepositoryID := "550e8400-e29b-41d4-a716-446655440000"
+	expectedRepositoryID := uuid.MustParse(rawRepositoryID)
+	assertScope := func(repositoryID pgtype.UUID, ownerID *int32, limit int32) {
+		require.True(t, repositoryID.Valid)
+		require.Equal(t, [16]byte(expectedRepositoryID), repositoryID.Bytes)
+		require.NotNil(t, ownerID, "users only see their own gear")
+		require.Equal(t, int32(7), *ownerID)
+		require.Equal(t, int32(5), limit)
+	}
+	handler := &StatsHandler{
+		queries: stubStatsQuerier{
+			getGearCameraCountsFn: func(arg repo.GetGearCameraCountsParams) ([]repo.GetGearCameraCountsRow, error) {
+				assertScope(arg.RepositoryID, arg.OwnerID, arg.Limit)
+				return []repo.GetGearCameraCountsRow{
+					{CameraModel: "Sony ILCE-7M4", Count: 12},
+					{CameraModel: "iPhone 15 Pro", Count: 3},
+				}, nil
+			},
+			getGearLensCountsFn: func(arg repo.GetGearLensCountsParams) ([]repo.GetGearLensCountsRow, error) {
+				assertScope(arg.RepositoryID, arg.OwnerID, arg.Limit)
+				return []repo.GetGearLensCountsRow{
+					{LensModel: "FE 24-70mm F2.8 GM II", Count: 9},
+					{LensModel: "FE 85mm F1.8", Count: 3},
+				}, nil
+			},
+			getGearFocalLengthCountsFn: func(arg repo.GetGearFocalLengthCountsParams) ([]repo.GetGearFocalLengthCountsRow, error) {
+				assertScope(arg.RepositoryID, arg.OwnerID, arg.Limit)
+				return []repo.GetGearFocalLengthCountsRow{
+					{FocalLength: 35, Count: 6},
+					{FocalLength: 85, Count: 3},
+				}, nil
+			},
+		},
+	}
+
+	user := &service.UserResponse{UserID: 7, Role: "user"}
+	recorder := getGearStats(t, handler, user, "?limit=5&repository_id="+rawRepositoryID)
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+
+	var response GearStatsResponse
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	require.Equal(t, []GearCount{
+		{Name: "Sony ILCE-7M4", Count: 12},
+		{Name: "iPhone 15 Pro", Count: 3},
+	}, response.Cameras)
+	require.Equal(t, []GearCount{
+		{Name: "FE 24-70mm F2.8 GM II", Count: 9},
+		{Name: "FE 85mm F1.8", Count: 3},
+	}, response.Lenses)
+	require.Equal(t, []FocalLengthBucket{
+		{FocalLength: 35, Count: 6},
+		{FocalLength: 85, Count: 3},
+	}, response.FocalLengths)
+
+	recorder = getGearStats(t, handler, user, "?limit=5&owner_id=7&repository_id="+rawRepositoryID)
+	require.Equal(t, http.StatusOK, recorder.Code, "naming yourself is allowed")
+}
+
+func TestStatsHandlerGetGearStats_ForbidsOtherUsersOwnerID(t *testing.T) {
+	var owners []*int32
+	handler := &StatsHandler{queries: gearScopeQuerier(&owners)}
+
+	recorder := getGearStats(t, handler, &service.UserResponse{UserID: 7, Role: "user"}, "?owner_id=8")
+	require.Equal(t, http.StatusForbidden, recorder.Code)
+	require.Empty(t, owners, "no stats may be read for another user")
+
+	recorder = getGearStats(t, handler, &service.UserResponse{UserID: 1, Role: "admin"}, "?owner_id=8")
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+	require.Len(t, owners, 3)
+	for _, ownerID := range owners {
+		require.NotNil(t, ownerID)
+		require.Equal(t, int32(8), *ownerID)
+	}
+}
+
+func TestStatsHandlerGetGearStats_AnonymousCallersCannotFilterByOwner(t *testing.T) {
+	var owners []*int32
+	handler := &StatsHandler{queries: gearScopeQuerier(&owners)}
+
+	recorder := getGearStats(t, handler, nil, "?owner_id=8")
+	require.Equal(t, http.StatusUnauthorized, recorder.Code)
+	require.Empty(t, owners)
+
+	recorder = getGearStats(t, handler, nil, "")
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+	require.Equal(t, []*int32{nil, nil, nil}, owners, "anonymous callers get library-wide numbers")
+}
+
+func TestStatsHandlerGetGearStats_RejectsInvalidOwnerID(t *testing.T) {
+	recorder := getGearStats(t, &StatsHandler{queries: stubStatsQuerier{}}, nil, "?owner_id=abc")
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+}
+
+// overviewSeedAsset is one live asset of the seeded overview dataset.
+type overviewSeedAsset struct {
+	repository pgtype.UUID
+	owner      int32
+	kind       string
+	size       int64
+	camera     string
+	uploaded   time.Time
+}
+
+// seededOverviewQuerier answers the overview queries by aggregating assets the
+// way the SQL does, and counts how often the database was hit.
+func seededOverviewQuerier(assets []overviewSeedAsset, repositories map[pgtype.UUID]string, queries *int) stubStatsQuerier {
+	inScope := func(asset overviewSeedAsset, repositoryID pgtype.UUID, ownerID *int32) bool {
+		return (!repositoryID.Valid || asset.repository == repositoryID) && (ownerID == nil || asset.owner == *ownerID)
+	}
+	return stubStatsQuerier{
+		getOverviewTypeCountsFn: func(arg repo.GetOverviewTypeCountsParams) ([]repo.GetOverviewTypeCountsRow, error) {
+			*queries++
+			var rows []repo.GetOverviewTypeCountsRow
+			for _, kind := range []string{"AUDIO", "PHOTO", "VIDEO"} {
+				row := repo.GetOverviewTypeCountsRow{Type: kind}
+				for _, asset := range assets {
+					if asset.kind == kind && inScope(asset, arg.RepositoryID, arg.OwnerID) {
+						row.Count++
+						row.TotalSize += asset.size
+					}
+				}
+				if row.Count > 0 {
+					rows = append(rows, row)
+				}
+			}
+			return rows, nil
+		},
+		getOverviewAddedSinceFn: func(arg repo.GetOverviewAddedSinceParams) (int64, error) {
+			var count int64
+			for _, asset := range assets {
+				if inScope(asset, arg.RepositoryID, arg.OwnerID) && !asset.uploaded.Before(arg.Since.Time) {
+					count++
+				}
+			}
+			return count, nil
+		},
+		getGearCameraCountsFn: func(arg repo.GetGearCameraCountsParams) ([]repo.GetGearCameraCountsRow, error) {
+			counts := map[string]int64{}
+			for _, asset := range assets {
+				if asset.camera != "" && inScope(asset, arg.RepositoryID, arg.OwnerID) {
+					counts[asset.camera]++
+				}
+			}
+			var rows []repo.GetGearCameraCountsRow
+			for camera, count := range counts {
+				rows = append(rows, repo.GetGearCameraCountsRow{CameraModel: camera, Count: count})
+			}
+			sort.Slice(rows, func(i, j int) bool { return rows[i].Count > rows[j].Count })
+			return rows, nil
+		},
+		getOverviewRepositoryFn: func(arg repo.GetOverviewRepositoryStorageParams) ([]repo.GetOverviewRepositoryStorageRow, error) {
+			var rows []repo.GetOverviewRepositoryStorageRow
+			for id, name := range repositories {
+				if arg.RepositoryID.Valid && id != arg.RepositoryID {
+					continue
+				}
+				row := repo.GetOverviewRepositoryStorageRow{RepoID: id, Name: name}
+				for _, asset := range assets {
+					if asset.repository == id && inScope(asset, pgtype.UUID{}, arg.OwnerID) {
+						row.AssetCount++
+						row.TotalSize += asset.size
+					}
+				}
+				rows = append(rows, row)
+			}
+			sort.Slice(rows, func(i, j int) bool { return rows[i].TotalSize > rows[j].TotalSize })
+			return rows, nil
+		},
+	}
+}
+
+var overviewAdmin = &service.UserResponse{UserID: 1, Role: "admin"}
+
+func requestOverview(handler *StatsHandler, user *service.UserResponse, query string) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Request = httptest.NewRequest(http.MethodGet, "/api/v1/stats/overview"+query, nil)
+	if user != nil {
+		ctx.Set("current_user", user)
+	}
+
+	handler.GetOverview(ctx)
+	return recorder
+}
+
+func getOverview(t *testing.T, handler *StatsHandler, user *service.UserResponse, query string) StatsOverviewResponse {
+	t.Helper()
+	recorder := requestOverview(handler, user, query)
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+	var response StatsOverviewResponse
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	return response
+}
+
+func TestStatsHandlerGetOverview_MatchesSeededDataset(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	photos := pgtype.UUID{Bytes: uuid.MustParse("11111111-1111-1111-1111-111111111111"), Valid: true}
+	archive := pgtype.UUID{Bytes: uuid.MustParse("22222222-2222-2222-2222-222222222222"), Valid: true}
+	empty := pgtype.UUID{Bytes: uuid.MustParse("33333333-3333-3333-3333-333333333333"), Valid: true}
+	recent := time.Now().Add(-48 * time.Hour)
+	old := time.Now().AddDate(-1, 0, 0)
+	assets := []overviewSeedAsset{
+		{repository: photos, owner: 1, kind: "PHOTO", size: 4_000, camera: "X100V", uploaded: recent},
+		{repository: photos, owner: 1, kind: "PHOTO", size: 6_000, camera: "X100V", uploaded: old},
+		{repository: photos, owner: 2, kind: "PHOTO", size: 5_000, camera: "iPhone 15 Pro", uploaded: recent},
+		{repository: photos, owner: 1, kind: "VIDEO", size: 90_000, camera: "iPhone 15 Pro", uploaded: old},
+		{repository: archive, owner: 1, kind: "PHOTO", size: 3_000, camera: "X100V", uploaded: old},
+		{repository: archive, owner: 2, kind: "AUDIO", size: 700, uploaded: recent},
+	}
+	repositories := map[pgtype.UUID]string{photos: "Photos", archive: "Archive", empty: "Empty"}
+	var queries int
+	handler := &StatsHandler{queries: seededOverviewQuerier(assets, repositories, &queries)}
+
+	response := getOverview(t, handler, overviewAdmin, "")
+	require.Equal(t, int64(6), response.TotalAssets)
+	require.Equal(t, int64(108_700), response.TotalSizeBytes)
+	require.Equal(t, []AssetTypeCount{
+		{Type: "AUDIO", Count: 1, SizeBytes: 700},
+		{Type: "PHOTO", Count: 4, SizeBytes: 18_000},
+		{Type: "VIDEO", Count: 1, SizeBytes: 90_000},
+	}, response.ByType)
+	require.Equal(t, int64(3), response.AddedLast30Days)
+	require.Equal(t, []GearCount{{Name: "X100V", Count: 3}, {Name: "iPhone 15 Pro", Count: 2}}, response.TopCameras)
+	require.Equal(t, []RepositoryStorage{
+		{RepositoryID: photos.String(), Name: "Photos", AssetCount: 4, SizeBytes: 105_000},
+		{RepositoryID: archive.String(), Name: "Archive", AssetCount: 2, SizeBytes: 3_700},
+		{RepositoryID: empty.String(), Name: "Empty"},
+	}, response.Repositories)
+
+	owned := getOverview(t, handler, overviewAdmin, "?owner_id=2")
+	require.Equal(t, int64(2), owned.TotalAssets)
+	require.Equal(t, int64(5_700), owned.TotalSizeBytes)
+	require.Equal(t, int64(2), owned.AddedLast30Days)
+
+	scoped := getOverview(t, handler, overviewAdmin, "?repository_id="+archive.String())
+	require.Equal(t, int64(2), scoped.TotalAssets)
+	require.Equal(t, []RepositoryStorage{{RepositoryID: archive.String(), Name: "Archive", AssetCount: 2, SizeBytes: 3_700}}, scoped.Repositories)
+	require.Equal(t, 3, queries)
+}
+
+func TestStatsHandlerGetOverview_CachesPerScope(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	var queries int
+	handler := &StatsHandler{queries: seededOverviewQuerier(nil, nil, &queries)}
+
+	getOverview(t, handler, overviewAdmin, "?owner_id=7")
+	getOverview(t, handler, overviewAdmin, "?owner_id=7")
+	require.Equal(t, 1, queries, "a repeated overview within the TTL must be served from cache")
+
+	getOverview(t, handler, overviewAdmin, "?owner_id=8")
+	require.Equal(t, 2, queries, "another scope must not share the cached overview")
+}
+
+func TestStatsHandlerGetOverview_RejectsInvalidOwnerID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	recorder := requestOverview(&StatsHandler{queries: stubStatsQuerier{}}, overviewAdmin, "?owner_id=0")
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+}
+
+func TestStatsHandlerGetOverview_ScopesUsersToTheirOwnAssets(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	photos := pgtype.UUID{Bytes: uuid.MustParse("11111111-1111-1111-1111-111111111111"), Valid: true}
+	assets := []overviewSeedAsset{
+		{repository: photos, owner: 1, kind: "PHOTO", size: 4_000, uploaded: time.Now()},
+		{repository: photos, owner: 2, kind: "PHOTO", size: 5_000, uploaded: time.Now()},
+	}
+	var queries int
+	handler := &StatsHandler{queries: seededOverviewQuerier(assets, map[pgtype.UUID]string{photos: "Photos"}, &queries)}
+
+	// Warm the library-wide cache entry first; users must not be served it.
+	require.Equal(t, int64(2), getOverview(t, handler, overviewAdmin, "").TotalAssets)
+
+	user := &service.UserResponse{UserID: 2, Role: "user"}
+	own := getOverview(t, handler, user, "")
+	require.Equal(t, int64(1), own.TotalAssets)
+	require.Equal(t, int64(5_000), own.TotalSizeBytes)
+
+	before := queries
+	recorder := requestOverview(handler, user, "?owner_id=1")
+	require.Equal(t, http.StatusForbidden, recorder.Code)
+	require.Equal(t, before, queries, "a forbidden overview must not reach the database or the cache")
+
+	recorder = requestOverview(handler, nil, "?owner_id=1")
+	require.Equal(t, http.StatusUnauthorized, recorder.Code)
+	require.Equal(t, before, queries)
+}
@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"server/internal/api"
+
+	"github.com/gin-gonic/gin"
+)
+
+// uploadRetryAfterSeconds is the Retry-After hint sent with a 429. Uploads
+// usually finish within seconds, so a short wait is enough for a slot.
+const uploadRetryAfterSeconds = 5
+
+// userUploadSlots counts the upload requests each user has in flight. Unlike
+// the global uploadLimiter it never blocks: a request over the limit is
+// rejected so one user's backlog cannot hold every shared slot.
+type userUploadSlots struct {
+	mu       sync.Mutex
+	limit    int
+	inFlight map[int32]int
+}
+
+func newUserUploadSlots(limit int) *userUploadSlots {
+	return &userUploadSlots{limit: limit, inFlight: make(map[int32]int)}
+}
+
+func (s *userUploadSlots) acquire(userID int32) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.inFlight[userID] >= s.limit {
+		return false
+	}
+	s.inFlight[userID]++
+	return true
+}
+
+func (s *userUploadSlots) release(userID int32) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.inFlight[userID] <= 1 {
+		delete(s.inFlight, userID)
+		return
+	}
+	s.inFlight[userID]--
+}
+
+// acquireUploadSlot reserves one of the caller's upload slots. It responds
+// with 429 and Retry-After when they are all taken. Anonymous callers and a
+// disabled limit are not counted; the returned release is always safe to defer.
+func (h *AssetHandler) acquireUploadSlot(c *gin.Context) (func(), bool) {
+	if h.uploadSlots == nil || h.uploadSlots.limit <= 0 {
+		return func() {}, true
+	}
+	userID, err := currentUserIDFromContext(c)
+	if err != nil {
+		return func() {}, true
+	}
+	if !h.uploadSlots.acquire(*userID) {
+		c.Header("Retry-After", strconv.Itoa(uploadRetryAfterSeconds))
+		api.GinError(c, http.StatusTooManyRequests, errors.New("too many concurrent uploads"), http.StatusTooManyRequests, "Too many concurrent uploads; retry later")
+		return nil, false
+	}
+	return func() { h.uploadSlots.release(*userID) }, true
+}
@@ -0,0 +1,63 @@
+package handler
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"server/internal/db/repo"
+	"server/internal/utils/upload"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserUploadSlotsLimitsEachUserSeparately(t *testing.T) {
+	slots := newUserUploadSlots(2)
+
+	require.True(t, slots.acquire(7))
+	require.True(t, slots.acquire(7))
+	require.False(t, slots.acquire(7), "third concurrent upload is over the limit")
+	require.True(t, slots.acquire(8), "another user has their own slots")
+
+	slots.release(7)
+	require.True(t, slots.acquire(7), "a finished upload frees its slot")
+
+	slots.release(7)
+	slots.release(7)
+	slots.release(8)
+	require.Empty(t, slots.inFlight)
+}
+
+func TestAppendResumableUploadRejectsUploadOverPerUserLimit(t *testing.T) {
+	repoPath := t.TempDir()
+	stagingPath := filepath.Join(repoPath, ".lumilio", "staging", "incoming", "staged_movie.mp4")
+	require.NoError(t, os.MkdirAll(filepath.Dir(stagingPath), 0o700))
+	require.NoError(t, os.WriteFile(stagingPath, nil, 0o600))
+
+	resumable := upload.NewResumableManager()
+	session, err := resumable.Create(repoPath, stagingPath, "movie.mp4", "video/mp4", 8, "7")
+	require.NoError(t, err)
+
+	h := &AssetHandler{
+		resumable:     resumable,
+		uploadLimiter: make(chan struct{}, 4),
+		uploadSlots:   newUserUploadSlots(2),
+		repoManager: stubRepositoryManager{listRepositoriesFn: func() ([]*repo.Repository, error) {
+			return []*repo.Repository{testRepository(t, "550e8400-e29b-41d4-a716-446655440000", "primary", repoPath)}, nil
+		}},
+	}
+
+	// Two uploads of user 7 are still in flight.
+	require.True(t, h.uploadSlots.acquire(7))
+	require.True(t, h.uploadSlots.acquire(7))
+
+	recorder := serveResumableRequest(t, h, http.MethodPatch, session.ID, "0", "abcd")
+	require.Equal(t, http.StatusTooManyRequests, recorder.Code, recorder.Body.String())
+	require.Equal(t, "5", recorder.Header().Get("Retry-After"))
+
+	h.uploadSlots.release(7)
+	recorder = serveResumableRequest(t, h, http.MethodPatch, session.ID, "0", "abcd")
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+	require.Equal(t, 1, h.uploadSlots.inFlight[7], "the accepted upload released its slot")
+}
@@ -5,8 +5,6 @@ import (
 	"strings"
 	"time"
 
-	"server/internal/version"
-
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 )
@@ -15,32 +13,48 @@ import (
 type AssetControllerInterface interface {
 	// Legacy asset operations
 	UploadAsset(c *gin.Context)
+	ImportAssetFromURL(c *gin.Context) // POST /assets/from-url - Pull a remote file into a repository
 	GetAsset(c *gin.Context)
 	GetAssetExif(c *gin.Context)
+	GetAssetRawExif(c *gin.Context) // GET /assets/:id/exif-raw - Dump every tag read from the original
 	GetAssetSidecar(c *gin.Context)
 	UpdateAssetSidecar(c *gin.Context)
 	GetOriginalFile(c *gin.Context)
 	ExportAsset(c *gin.Context) // GET /assets/:id/export - Re-encode original to jpeg/png/webp/avif
 	DownloadAssets(c *gin.Context)
+	GenerateContactSheet(c *gin.Context) // POST /assets/contact-sheet - Tile an album or filter into one image
 	GetWebVideo(c *gin.Context)
+	GetVideoHLS(c *gin.Context) // GET /assets/:id/video/hls/*file - HLS playlist and segments
 	GetWebAudio(c *gin.Context)
 	UpdateAsset(c *gin.Context)
 	DeleteAsset(c *gin.Context)
+	BatchDeleteAssets(c *gin.Context)
 	RestoreAsset(c *gin.Context)
 	PrecheckUpload(c *gin.Context)
+	CheckHashes(c *gin.Context)
 	BatchUploadAssets(c *gin.Context)
 	CreateUploadSession(c *gin.Context)
 	GetUploadConfig(c *gin.Context)
 	GetUploadProgress(c *gin.Context)
+	CreateResumableUpload(c *gin.Context)
+	GetResumableUpload(c *gin.Context)
+	AppendResumableUpload(c *gin.Context)
+	CompleteResumableUpload(c *gin.Context)
 	GetUploadJobStatus(c *gin.Context)
 	StreamUploadJobStatus(c *gin.Context)
+	CancelUploadBatch(c *gin.Context)
 	AddAssetToAlbum(c *gin.Context)
 	GetAssetTypes(c *gin.Context)
 	GetAssetThumbnail(c *gin.Context)
+	GetAssetSrcset(c *gin.Context)         // GET /assets/:id/srcset - Thumbnail sizes with dimensions for responsive images
+	GetAssetDownloadURL(c *gin.Context)    // GET /assets/:id/download-url - Sign a short-lived URL for the original
+	GetPublicAssetOriginal(c *gin.Context) // GET /public/assets/:id - Serve the original for a signed download URL
 
 	// New filtering and search operations
 	QueryAssets(c *gin.Context)              // POST /assets/list - Unified asset listing, filtering, and search
+	PreviewAssetFilter(c *gin.Context)       // POST /assets/filter/preview - Validate a filter and count its matches
 	SearchAssets(c *gin.Context)             // POST /assets/search - Sectioned search with top results and fallback results
+	GetAssetNeighbors(c *gin.Context)        // GET /assets/:id/neighbors - Previous/next asset under a filter and sort
 	ListIndexingRepositories(c *gin.Context) // GET /assets/indexing/repositories - List repositories for indexing filters
 	GetIndexingStats(c *gin.Context)         // GET /assets/indexing/stats - Index coverage and queue status
 	RebuildAssetIndexes(c *gin.Context)      // POST /assets/indexing/rebuild - Queue reindex backfill for existing assets
@@ -53,8 +67,12 @@ type AssetControllerInterface interface {
 	UpdateAssetLike(c *gin.Context)          // PUT /assets/:id/like - Update asset like status
 	UpdateAssetRatingAndLike(c *gin.Context) // PUT /assets/:id/rating-and-like - Update both rating and like
 	UpdateAssetDescription(c *gin.Context)   // PUT /assets/:id/description - Update asset description
+	GetAssetCustomFields(c *gin.Context)     // GET /assets/:id/custom-fields - Get user-defined key/value fields
+	UpdateAssetCustomFields(c *gin.Context)  // PUT /assets/:id/custom-fields - Replace user-defined key/value fields
 	GetAssetsByRating(c *gin.Context)        // GET /assets/rating/:rating - Get assets by rating
 	GetLikedAssets(c *gin.Context)           // GET /assets/liked - Get liked assets
+	ListUnalbumedAssets(c *gin.Context)      // GET /assets/unalbumed - Assets in no album
+	ListAssetsAroundTime(c *gin.Context)     // GET /assets/around-time - Assets taken near an instant
 
 	// Tag management operations
 	GetAssetTags(c *gin.Context)    // GET    /assets/:id/tags - List tags on an asset
@@ -62,13 +80,19 @@ type AssetControllerInterface interface {
 	RemoveAssetTag(c *gin.Context)  // DELETE /assets/:id/tags/:tagId - Remove a tag from an asset
 	ListTags(c *gin.Context)        // GET    /assets/tags - List/search tag definitions
 	GetTagSummaries(c *gin.Context) // GET   /assets/tag-summaries - Browsable tag vocabulary with counts/covers
+	CleanupAITags(c *gin.Context)   // POST  /admin/tags/cleanup - Remove low-confidence AI tag assignments
+	RenameTag(c *gin.Context)       // PUT   /admin/tags/:id - Rename a tag definition
+	MergeTag(c *gin.Context)        // POST  /admin/tags/:id/merge - Fold a tag into another one
 
 	// Folder collection view (derived from storage_path, no folders table)
 	GetFolders(c *gin.Context)       // GET /assets/folders - List immediate child folders under a parent path
 	GetFolderSummary(c *gin.Context) // GET /assets/folders/summary - Aggregate stats for one folder path
 
 	// Reprocessing operations
-	ReprocessAsset(c *gin.Context) // POST /assets/:id/reprocess - Reprocess failed or warning assets
+	ReprocessAsset(c *gin.Context)       // POST /assets/:id/reprocess - Reprocess failed or warning assets
+	RefreshAssetMetadata(c *gin.Context) // POST /assets/:id/refresh-metadata - Re-extract metadata from the original
+	RotateAsset(c *gin.Context)          // POST /assets/:id/rotate - Rotate the original clockwise
+	FlipAsset(c *gin.Context)            // POST /assets/:id/flip - Mirror the original
 
 	// Stack operations
 	GetAssetStack(c *gin.Context)     // GET /assets/:id/stack - Get stack containing this asset
@@ -103,21 +127,30 @@ type AuthControllerInterface interface {
 	AuthMiddleware() gin.HandlerFunc
 	OptionalAuthMiddleware() gin.HandlerFunc
 	RequireAdmin() gin.HandlerFunc
+	AssetDownloadTokenMiddleware() gin.HandlerFunc
 }
 
 // AlbumControllerInterface defines the interface for album controllers
 type AlbumControllerInterface interface {
 	NewAlbum(c *gin.Context)
+	CreateSmartAlbum(c *gin.Context)
 	GetAlbum(c *gin.Context)
 	ListAlbums(c *gin.Context)
 	UpdateAlbum(c *gin.Context)
+	SetAlbumCover(c *gin.Context)
+	AutoSelectAlbumCover(c *gin.Context)
 	DeleteAlbum(c *gin.Context)
 	GetAlbumAssets(c *gin.Context)
 	AddAssetToAlbum(c *gin.Context)
 	RemoveAssetFromAlbum(c *gin.Context)
 	UpdateAssetPositionInAlbum(c *gin.Context)
+	ReorderAlbumAssets(c *gin.Context)
 	RebuildAlbumBioClip(c *gin.Context)
 	GetAssetAlbums(c *gin.Context)
+	ListDuplicateAlbums(c *gin.Context)
+	MergeAlbums(c *gin.Context)
+	ShareAlbum(c *gin.Context)
+	RevokeAlbumShare(c *gin.Context)
 }
 
 type PeopleControllerInterface interface {
@@ -149,6 +182,12 @@ type SpeciesControllerInterface interface {
 type QueueControllerInterface interface {
 	GetQueueSummary(c *gin.Context)
 	GetJobStats(c *gin.Context)
+	ListQueues(c *gin.Context)         // GET  /admin/queues - Queues with concurrency, paused state and running jobs
+	PauseQueue(c *gin.Context)         // POST /admin/queues/:name/pause
+	ResumeQueue(c *gin.Context)        // POST /admin/queues/:name/resume
+	GetProcessingState(c *gin.Context) // GET  /admin/processing - Global processing pause state
+	PauseProcessing(c *gin.Context)    // POST /admin/processing/pause
+	ResumeProcessing(c *gin.Context)   // POST /admin/processing/resume
 }
 
 // StatsControllerInterface defines the interface for statistics controllers
@@ -158,6 +197,8 @@ type StatsControllerInterface interface {
 	GetTimeDistribution(c *gin.Context)        // GET /stats/time-distribution - Get time distribution
 	GetDailyActivityHeatmap(c *gin.Context)    // GET /stats/daily-activity - Get daily activity heatmap
 	GetAvailableYears(c *gin.Context)          // GET /stats/available-years - Get available years
+	GetOverview(c *gin.Context)                // GET /stats/overview - Get aggregate library statistics
+	GetGearStats(c *gin.Context)               // GET /assets/gear-stats - Get shots per camera/lens and top focal lengths
 }
 
 // AgentControllerInterface defines the interface for agent controllers
@@ -178,6 +219,11 @@ type AgentControllerInterface interface {
 	DeletePin(c *gin.Context)       // DELETE /agent/pins/:id - Remove a board widget
 }
 
+// HealthControllerInterface defines the public health check.
+type HealthControllerInterface interface {
+	Check(c *gin.Context) // GET /health - Liveness, or per-repository health with ?deep=true
+}
+
 // CapabilitiesControllerInterface defines the interface for public system capability controllers.
 type CapabilitiesControllerInterface interface {
 	GetCapabilities(c *gin.Context) // GET /capabilities - Get de-sensitized runtime capabilities
@@ -217,6 +263,17 @@ type RepositoryScanControllerInterface interface {
 	QueueRepositoryScan(c *gin.Context)
 	GetLatestRepositoryScan(c *gin.Context)
 	ListRepositoryScans(c *gin.Context)
+	StreamRepositoryScans(c *gin.Context)
+	AssignLegacyAssets(c *gin.Context)
+	RebuildFileRecords(c *gin.Context)
+	PreviewRepositoryOrganization(c *gin.Context)
+	ApplyRepositoryOrganization(c *gin.Context)
+	QueueRepositorySizeCheck(c *gin.Context)
+	QueueProcessPendingAssets(c *gin.Context)
+	GetRepositorySizeMismatches(c *gin.Context)
+	GetRepositoryAssetStats(c *gin.Context)
+	ExportRepositoryConfig(c *gin.Context)
+	ImportRepositoryConfig(c *gin.Context)
 }
 
 // DuplicateControllerInterface defines the Utilities Rail "Duplicates" endpoints.
@@ -229,6 +286,11 @@ type DuplicateControllerInterface interface {
 	DismissDuplicateGroup(c *gin.Context) // POST   /duplicates/groups/:id/dismiss
 }
 
+// ActivityControllerInterface defines the activity feed endpoint.
+type ActivityControllerInterface interface {
+	ListActivity(c *gin.Context) // GET /activity
+}
+
 // CloudControllerInterface defines the cloud sync endpoints.
 type CloudControllerInterface interface {
 	ListProviders(c *gin.Context)                 // GET    /cloud/providers
@@ -267,6 +329,7 @@ type ShareLinkControllerInterface interface {
 	GetPublicShareWebAudio(c *gin.Context)  // GET  /public/shares/:token/assets/:assetId/web-audio
 	GetPublicShareOriginal(c *gin.Context)  // GET  /public/shares/:token/assets/:assetId/original
 	DownloadPublicShare(c *gin.Context)     // POST /public/shares/:token/download
+	GetPublicAlbum(c *gin.Context)          // GET  /public/albums/:token
 }
 
 func NewRouter(
@@ -281,6 +344,7 @@ func NewRouter(
 	statsController StatsControllerInterface,
 	agentController AgentControllerInterface,
 	capabilitiesController CapabilitiesControllerInterface,
+	healthController HealthControllerInterface,
 	settingsController SettingsControllerInterface,
 	classifierController ClassifierControllerInterface,
 	userController UserControllerInterface,
@@ -288,6 +352,7 @@ func NewRouter(
 	duplicateController DuplicateControllerInterface,
 	cloudController CloudControllerInterface,
 	shareLinkController ShareLinkControllerInterface,
+	activityController ActivityControllerInterface,
 	agentAvailabilityMiddleware gin.HandlerFunc,
 	appInitializedMiddleware gin.HandlerFunc,
 	corsAllowedOrigins []string,
@@ -312,12 +377,7 @@ func NewRouter(
 	v1 := api.Group("/v1")
 	{
 		// Health check
-		v1.GET("/health", func(c *gin.Context) {
-			JSONOK(c, struct {
-				Status  string `json:"status"`
-				Version string `json:"version"`
-			}{Status: "ok", Version: version.Version})
-		})
+		v1.GET("/health", healthController.Check)
 		v1.GET("/capabilities", authController.OptionalAuthMiddleware(), capabilitiesController.GetCapabilities)
 
 		// Zero-config first-run setup. Public: the system has no users/secrets yet.
@@ -336,7 +396,7 @@ func NewRouter(
 			settings.GET("/runtime-info", settingsController.GetRuntimeInfo)
 			settings.GET("/backups", settingsController.ListBackups)
 			settings.POST("/backups", settingsController.CreateBackup)
-			settings.GET("/backups/:name/download", settingsController.DownloadBackup)
+			settings.GET("/backups/:name/download", longLived(), settingsController.DownloadBackup)
 			settings.DELETE("/backups/:name", settingsController.DeleteBackup)
 			settings.POST("/backups/:name/restore", settingsController.RestoreBackup)
 		}
@@ -387,6 +447,7 @@ func NewRouter(
 		{
 			repositories.GET("", appInitializedMiddleware, repositoryScanController.ListRepositories)
 			repositories.POST("", repositoryScanController.CreateRepository)
+			repositories.POST("/import-config", repositoryScanController.ImportRepositoryConfig)
 			repositories.GET("/:id", appInitializedMiddleware, repositoryScanController.GetRepository)
 			repositories.PATCH("/:id", appInitializedMiddleware, repositoryScanController.UpdateRepository)
 			repositories.DELETE("/:id", appInitializedMiddleware, repositoryScanController.DeleteRepository)
@@ -395,6 +456,15 @@ func NewRouter(
 			repositories.POST("/:id/scan", appInitializedMiddleware, repositoryScanController.QueueRepositoryScan)
 			repositories.GET("/:id/scans/latest", appInitializedMiddleware, repositoryScanController.GetLatestRepositoryScan)
 			repositories.GET("/:id/scans", appInitializedMiddleware, repositoryScanController.ListRepositoryScans)
+			repositories.GET("/:id/scans/stream", appInitializedMiddleware, longLived(), repositoryScanController.StreamRepositoryScans)
+			repositories.POST("/:id/rebuild-file-records", appInitializedMiddleware, repositoryScanController.RebuildFileRecords)
+			repositories.POST("/:id/organize/preview", appInitializedMiddleware, repositoryScanController.PreviewRepositoryOrganization)
+			repositories.POST("/:id/organize/apply", appInitializedMiddleware, repositoryScanController.ApplyRepositoryOrganization)
+			repositories.POST("/:id/size-check", appInitializedMiddleware, repositoryScanController.QueueRepositorySizeCheck)
+			repositories.POST("/:id/process-pending", appInitializedMiddleware, repositoryScanController.QueueProcessPendingAssets)
+			repositories.GET("/:id/size-mismatches", appInitializedMiddleware, repositoryScanController.GetRepositorySizeMismatches)
+			repositories.GET("/:id/stats", appInitializedMiddleware, repositoryScanController.GetRepositoryAssetStats)
+			repositories.GET("/:id/config/export", appInitializedMiddleware, repositoryScanController.ExportRepositoryConfig)
 			repositories.POST("/:id/stacks/detect", appInitializedMiddleware, assetController.AutoDetectStacks)
 		}
 
@@ -421,11 +491,13 @@ func NewRouter(
 		assets := v1.Group("/assets")
 		assets.Use(appInitializedMiddleware, authController.OptionalAuthMiddleware())
 		{
-			assets.POST("", assetController.UploadAsset)
+			assets.POST("", longLived(), assetController.UploadAsset)
+			assets.POST("/from-url", authController.AuthMiddleware(), longLived(), assetController.ImportAssetFromURL)
 			assets.GET("/types", assetController.GetAssetTypes)
 			assets.GET("/filter-options", assetController.GetFilterOptions)
 			assets.GET("/featured", assetController.GetFeaturedAssets)
 			assets.GET("/map-points", assetController.GetPhotoMapPoints)
+			assets.GET("/gear-stats", statsController.GetGearStats)
 			// Repository registry read: open to all authenticated users so
 			// browse-scope and upload selectors work for non-admins; the
 			// handler strips filesystem paths for them.
@@ -433,28 +505,42 @@ func NewRouter(
 			assets.GET("/indexing/stats", authController.AuthMiddleware(), authController.RequireAdmin(), assetController.GetIndexingStats)
 			assets.POST("/indexing/rebuild", authController.AuthMiddleware(), authController.RequireAdmin(), assetController.RebuildAssetIndexes)
 			assets.POST("/list", assetController.QueryAssets)
+			assets.POST("/filter/preview", assetController.PreviewAssetFilter)
 			assets.POST("/search", assetController.SearchAssets)
 			assets.POST("/precheck", assetController.PrecheckUpload)
-			assets.POST("/batch", assetController.BatchUploadAssets)
+			assets.POST("/check-hashes", authController.AuthMiddleware(), assetController.CheckHashes)
+			assets.POST("/batch", longLived(), assetController.BatchUploadAssets)
 			assets.POST("/batch/sessions", assetController.CreateUploadSession)
+			assets.POST("/batch-delete", assetController.BatchDeleteAssets)
 			assets.GET("/batch/config", assetController.GetUploadConfig)
 			assets.GET("/batch/progress", assetController.GetUploadProgress)
 			assets.GET("/batch/jobs", assetController.GetUploadJobStatus)
-			assets.GET("/batch/jobs/stream", assetController.StreamUploadJobStatus)
-			assets.POST("/download", assetController.DownloadAssets)
+			assets.GET("/batch/jobs/stream", longLived(), assetController.StreamUploadJobStatus)
+			assets.POST("/upload/session", assetController.CreateResumableUpload)
+			assets.GET("/upload/session/:id", assetController.GetResumableUpload)
+			assets.HEAD("/upload/session/:id", assetController.GetResumableUpload)
+			assets.PATCH("/upload/session/:id", longLived(), assetController.AppendResumableUpload)
+			assets.POST("/upload/session/:id/complete", longLived(), assetController.CompleteResumableUpload)
+			assets.POST("/download", longLived(), assetController.DownloadAssets)
+			assets.POST("/contact-sheet", assetController.GenerateContactSheet)
 			assets.GET("/:id", assetController.GetAsset)
 			assets.GET("/:id/exif", assetController.GetAssetExif)
+			assets.GET("/:id/exif-raw", assetController.GetAssetRawExif)
+			assets.GET("/:id/neighbors", assetController.GetAssetNeighbors)
 			assets.GET("/:id/sidecar", assetController.GetAssetSidecar)
 			assets.PUT("/:id/sidecar", assetController.UpdateAssetSidecar)
-			assets.GET("/:id/original", assetController.GetOriginalFile)
-			assets.HEAD("/:id/original", assetController.GetOriginalFile)
+			assets.GET("/:id/original", longLived(), assetController.GetOriginalFile)
+			assets.HEAD("/:id/original", longLived(), assetController.GetOriginalFile)
 			assets.GET("/:id/export", assetController.ExportAsset)
-			assets.GET("/:id/video/web", assetController.GetWebVideo)
-			assets.HEAD("/:id/video/web", assetController.GetWebVideo)
-			assets.GET("/:id/audio/web", assetController.GetWebAudio)
-			assets.HEAD("/:id/audio/web", assetController.GetWebAudio)
+			assets.GET("/:id/video/web", longLived(), assetController.GetWebVideo)
+			assets.HEAD("/:id/video/web", longLived(), assetController.GetWebVideo)
+			assets.GET("/:id/video/hls/*file", longLived(), assetController.GetVideoHLS)
+			assets.GET("/:id/audio/web", longLived(), assetController.GetWebAudio)
+			assets.HEAD("/:id/audio/web", longLived(), assetController.GetWebAudio)
 			assets.GET("/:id/thumbnail", assetController.GetAssetThumbnail)
 			assets.HEAD("/:id/thumbnail", assetController.GetAssetThumbnail)
+			assets.GET("/:id/srcset", assetController.GetAssetSrcset)
+			assets.GET("/:id/download-url", assetController.GetAssetDownloadURL)
 			assets.PUT("/:id", assetController.UpdateAsset)
 			assets.DELETE("/:id", assetController.DeleteAsset)
 			assets.POST("/:id/restore", assetController.RestoreAsset)
@@ -466,9 +552,16 @@ func NewRouter(
 			assets.PUT("/:id/like", assetController.UpdateAssetLike)
 			assets.PUT("/:id/rating-and-like", assetController.UpdateAssetRatingAndLike)
 			assets.PUT("/:id/description", assetController.UpdateAssetDescription)
+			assets.GET("/:id/custom-fields", assetController.GetAssetCustomFields)
+			assets.PUT("/:id/custom-fields", assetController.UpdateAssetCustomFields)
 			assets.GET("/rating/:rating", assetController.GetAssetsByRating)
 			assets.GET("/liked", assetController.GetLikedAssets)
+			assets.GET("/unalbumed", assetController.ListUnalbumedAssets)
+			assets.GET("/around-time", assetController.ListAssetsAroundTime)
 			assets.POST("/:id/reprocess", assetController.ReprocessAsset)
+			assets.POST("/:id/refresh-metadata", assetController.RefreshAssetMetadata)
+			assets.POST("/:id/rotate", assetController.RotateAsset)
+			assets.POST("/:id/flip", assetController.FlipAsset)
 
 			// Tag management routes
 			assets.GET("/tags", assetController.ListTags)
@@ -489,20 +582,36 @@ func NewRouter(
 			assets.POST("/stacks", authController.AuthMiddleware(), assetController.CreateManualStack)
 		}
 
+		// Upload batches span many /assets/batch requests; they are named by
+		// the batch_id those requests carry.
+		uploads := v1.Group("/uploads")
+		uploads.Use(appInitializedMiddleware, authController.OptionalAuthMiddleware())
+		{
+			uploads.POST("/batch/:batch_id/cancel", assetController.CancelUploadBatch)
+		}
+
 		// Album routes - with authentication required
 		albums := v1.Group("/albums")
 		albums.Use(authController.AuthMiddleware(), appInitializedMiddleware)
 		{
 			albums.POST("", albumController.NewAlbum)
+			albums.POST("/smart", albumController.CreateSmartAlbum)
 			albums.GET("", albumController.ListAlbums)
+			albums.GET("/duplicates", albumController.ListDuplicateAlbums)
+			albums.POST("/merge", albumController.MergeAlbums)
 			albums.GET("/:id", albumController.GetAlbum)
 			albums.PUT("/:id", albumController.UpdateAlbum)
+			albums.PUT("/:id/cover/auto", albumController.AutoSelectAlbumCover)
+			albums.PUT("/:id/cover/:assetId", albumController.SetAlbumCover)
 			albums.DELETE("/:id", albumController.DeleteAlbum)
 			albums.GET("/:id/assets", albumController.GetAlbumAssets)
 			albums.POST("/:id/bioclip/rebuild", albumController.RebuildAlbumBioClip)
 			albums.POST("/:id/assets/:assetId", albumController.AddAssetToAlbum)
 			albums.DELETE("/:id/assets/:assetId", albumController.RemoveAssetFromAlbum)
+			albums.PUT("/:id/assets/order", albumController.ReorderAlbumAssets)
 			albums.PUT("/:id/assets/:assetId/position", albumController.UpdateAssetPositionInAlbum)
+			albums.POST("/:id/share", albumController.ShareAlbum)
+			albums.DELETE("/:id/share/:token", albumController.RevokeAlbumShare)
 		}
 
 		people := v1.Group("/people")
@@ -554,7 +663,7 @@ func NewRouter(
 			cloud.POST("/sync", cloudController.TriggerSync)
 		}
 
-		// Admin routes for queue monitoring (read-only)
+		// Admin routes for queue monitoring and maintenance
 		admin := v1.Group("/admin")
 		admin.Use(authController.AuthMiddleware(), authController.RequireAdmin(), appInitializedMiddleware)
 		{
@@ -563,6 +672,22 @@ func NewRouter(
 				river.GET("/queue-summary", queueController.GetQueueSummary)
 				river.GET("/stats", queueController.GetJobStats)
 			}
+			queues := admin.Group("/queues")
+			{
+				queues.GET("", queueController.ListQueues)
+				queues.POST("/:name/pause", queueController.PauseQueue)
+				queues.POST("/:name/resume", queueController.ResumeQueue)
+			}
+			processing := admin.Group("/processing")
+			{
+				processing.GET("", queueController.GetProcessingState)
+				processing.POST("/pause", queueController.PauseProcessing)
+				processing.POST("/resume", queueController.ResumeProcessing)
+			}
+			admin.POST("/tags/cleanup", assetController.CleanupAITags)
+			admin.PUT("/tags/:id", assetController.RenameTag)
+			admin.POST("/tags/:id/merge", assetController.MergeTag)
+			admin.POST("/assign-repository", repositoryScanController.AssignLegacyAssets)
 		}
 
 		// Stats routes - with optional authentication
@@ -574,6 +699,7 @@ func NewRouter(
 			stats.GET("/time-distribution", statsController.GetTimeDistribution)
 			stats.GET("/daily-activity", statsController.GetDailyActivityHeatmap)
 			stats.GET("/available-years", statsController.GetAvailableYears)
+			stats.GET("/overview", statsController.GetOverview)
 		}
 
 		// Agent routes - authentication required: refs are scoped to the
@@ -581,8 +707,8 @@ func NewRouter(
 		agent := v1.Group("/agent")
 		agent.Use(appInitializedMiddleware, agentAvailabilityMiddleware, authController.AuthMiddleware())
 		{
-			agent.POST("/chat", agentController.Chat)
-			agent.POST("/chat/resume", agentController.ResumeChat)
+			agent.POST("/chat", longLived(), agentController.Chat)
+			agent.POST("/chat/resume", longLived(), agentController.ResumeChat)
 			agent.GET("/tools", agentController.GetTools)
 			agent.GET("/refs/:id", agentController.GetRef)
 			agent.GET("/refs/:id/assets", agentController.GetRefAssets)
@@ -597,6 +723,9 @@ func NewRouter(
 			agent.DELETE("/pins/:id", agentController.DeletePin)
 		}
 
+		// Activity feed: owner-scoped in the handler; admins see every user.
+		v1.GET("/activity", authController.AuthMiddleware(), appInitializedMiddleware, activityController.ListActivity)
+
 		// Share link routes: owner-scoped management, authenticated.
 		shareLinks := v1.Group("/share-links")
 		shareLinks.Use(authController.AuthMiddleware(), appInitializedMiddleware)
@@ -619,10 +748,27 @@ func NewRouter(
 			publicShares.GET("/:token", shareLinkController.GetPublicShare)
 			publicShares.POST("/:token/assets/list", shareLinkController.ListPublicShareAssets)
 			publicShares.GET("/:token/assets/:assetId/thumbnail", shareLinkController.GetPublicShareThumbnail)
-			publicShares.GET("/:token/assets/:assetId/web-video", shareLinkController.GetPublicShareWebVideo)
-			publicShares.GET("/:token/assets/:assetId/web-audio", shareLinkController.GetPublicShareWebAudio)
-			publicShares.GET("/:token/assets/:assetId/original", shareLinkController.GetPublicShareOriginal)
-			publicShares.POST("/:token/download", shareLinkController.DownloadPublicShare)
+			publicShares.GET("/:token/assets/:assetId/web-video", longLived(), shareLinkController.GetPublicShareWebVideo)
+			publicShares.GET("/:token/assets/:assetId/web-audio", longLived(), shareLinkController.GetPublicShareWebAudio)
+			publicShares.GET("/:token/assets/:assetId/original", longLived(), shareLinkController.GetPublicShareOriginal)
+			publicShares.POST("/:token/download", longLived(), shareLinkController.DownloadPublicShare)
+		}
+
+		// Shared albums reuse the share token; their media is served by the
+		// /public/shares routes above.
+		publicAlbums := v1.Group("/public/albums")
+		publicAlbums.Use(appInitializedMiddleware)
+		{
+			publicAlbums.GET("/:token", shareLinkController.GetPublicAlbum)
+		}
+
+		// Signed asset download URLs: like share links, the token is the
+		// capability, checked by its own middleware instead of user auth.
+		publicAssets := v1.Group("/public/assets")
+		publicAssets.Use(appInitializedMiddleware, authController.AssetDownloadTokenMiddleware())
+		{
+			publicAssets.GET("/:id", longLived(), assetController.GetPublicAssetOriginal)
+			publicAssets.HEAD("/:id", longLived(), assetController.GetPublicAssetOriginal)
 		}
 	}
 
@@ -0,0 +1,33 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// RowQuerier is the slice of pgxpool.Pool the clock skew probe needs.
+type RowQuerier interface {
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// MeasureClockSkew reports how far the process clock, read through now, is
+// ahead of the database clock; a negative skew means it is behind. The
+// database is the time source every process shares, so settle timers and
+// "recent" windows only agree when each process stays close to it.
+//
+// The process clock is read halfway through the round trip, so query latency
+// does not show up as skew. clock_timestamp() is used rather than now(), which
+// would report the start of the transaction.
+func MeasureClockSkew(ctx context.Context, db RowQuerier, now func() time.Time) (time.Duration, error) {
+	before := now()
+	var dbTime time.Time
+	if err := db.QueryRow(ctx, "SELECT clock_timestamp()").Scan(&dbTime); err != nil {
+		return 0, fmt.Errorf("read database clock: %w", err)
+	}
+	after := now()
+	local := before.Add(after.Sub(before) / 2)
+	return local.Sub(dbTime), nil
+}
@@ -0,0 +1,72 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// clockDB answers the clock query with a fixed database time.
+type clockDB struct {
+	dbTime time.Time
+	err    error
+}
+
+func (db clockDB) QueryRow(context.Context, string, ...any) pgx.Row { return clockRow(db) }
+
+type clockRow clockDB
+
+func (r clockRow) Scan(dest ...any) error {
+	if r.err != nil {
+		return r.err
+	}
+	*dest[0].(*time.Time) = r.dbTime
+	return nil
+}
+
+// offsetClock reads a process clock running offset ahead of base, advancing by
+// step on every read to stand in for query latency.
+func offsetClock(base time.Time, offset, step time.Duration) func() time.Time {
+	reads := 0
+	return func() time.Time {
+		t := base.Add(offset + time.Duration(reads)*step)
+		reads++
+		return t
+	}
+}
+
+func TestMeasureClockSkew(t *testing.T) {
+	dbTime := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	for _, tc := range []struct {
+		name   string
+		offset time.Duration
+	}{
+		{"in sync", 0},
+		{"process ahead", 90 * time.Second},
+		{"process behind", -5 * time.Second},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// The database answers 100ms into a 200ms round trip.
+			now := offsetClock(dbTime.Add(-100*time.Millisecond), tc.offset, 200*time.Millisecond)
+			skew, err := MeasureClockSkew(context.Background(), clockDB{dbTime: dbTime}, now)
+			if err != nil {
+				t.Fatalf("MeasureClockSkew: %v", err)
+			}
+			if skew != tc.offset {
+				t.Fatalf("skew = %v, want %v", skew, tc.offset)
+			}
+		})
+	}
+}
+
+func TestMeasureClockSkew_QueryError(t *testing.T) {
+	queryErr := errors.New("connection refused")
+	_, err := MeasureClockSkew(context.Background(), clockDB{err: queryErr}, time.Now)
+	if !errors.Is(err, queryErr) {
+		t.Fatalf("err = %v, want %v", err, queryErr)
+	}
+}
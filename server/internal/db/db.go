@@ -24,6 +24,34 @@ type DB struct {
 
 // New creates a new database connection with the given configuration
 func New(cfg config.DatabaseConfig) (*DB, error) {
+	pool, err := newPool(cfg, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	queries := repo.New(pool)
+
+	log.Printf("📊 Database connection successful: %s:%s/%s", cfg.Host, cfg.Port, cfg.DBName)
+
+	return &DB{
+		Pool:    pool,
+		Queries: queries,
+	}, nil
+}
+
+// NewLockPool opens a separate pool of at most maxConns connections for
+// sessions that hold locks for a long time, so they cannot starve the
+// queries of the main pool.
+func NewLockPool(cfg config.DatabaseConfig, maxConns int) (*pgxpool.Pool, error) {
+	if maxConns < 1 {
+		return nil, fmt.Errorf("lock pool needs at least one connection, got %d", maxConns)
+	}
+	return newPool(cfg, int32(maxConns))
+}
+
+// newPool connects a pool to the configured database. maxConns of zero keeps
+// pgx's default size.
+func newPool(cfg config.DatabaseConfig, maxConns int32) (*pgxpool.Pool, error) {
 	// A Unix-socket directory host (desktop runtime) cannot be expressed in a
 	// URL-form DSN, so use the keyword/value form there. TCP hosts keep the
 	// existing URL form unchanged.
@@ -56,6 +84,9 @@ func New(cfg config.DatabaseConfig) (*DB, error) {
 		}
 		return nil
 	}
+	if maxConns > 0 {
+		poolCfg.MaxConns = maxConns
+	}
 
 	pool, err := pgxpool.NewWithConfig(context.Background(), poolCfg)
 	if err != nil {
@@ -67,15 +98,7 @@ func New(cfg config.DatabaseConfig) (*DB, error) {
 		pool.Close()
 		return nil, fmt.Errorf("failed to ping database: %w", err)
 	}
-
-	queries := repo.New(pool)
-
-	log.Printf("📊 Database connection successful: %s:%s/%s", cfg.Host, cfg.Port, cfg.DBName)
-
-	return &DB{
-		Pool:    pool,
-		Queries: queries,
-	}, nil
+	return pool, nil
 }
 
 // readRotatedPassword returns the rotated database password persisted on disk,
@@ -0,0 +1,37 @@
+// Package dbtest connects integration tests to a real PostgreSQL database.
+package dbtest
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// DatabaseURLEnv names the database integration tests run against. The tests
+// commit rows, so point it at an isolated, already-migrated database.
+const DatabaseURLEnv = "LUMILIO_TEST_DATABASE_URL"
+
+// Pool connects to databaseURL, read by the test from DatabaseURLEnv, and
+// closes the pool when t finishes. An empty URL skips the test, which keeps
+// integration tests opt-in. The variable is not read here because only test
+// files may read the environment.
+func Pool(t testing.TB, databaseURL string) *pgxpool.Pool {
+	t.Helper()
+	databaseURL = strings.TrimSpace(databaseURL)
+	if databaseURL == "" {
+		t.Skip("set " + DatabaseURLEnv + " to an isolated migrated PostgreSQL database")
+	}
+
+	ctx := context.Background()
+	pool, err := pgxpool.New(ctx, databaseURL)
+	if err != nil {
+		t.Fatalf("connect to test database: %v", err)
+	}
+	t.Cleanup(pool.Close)
+	if err := pool.Ping(ctx); err != nil {
+		t.Fatalf("ping test database: %v", err)
+	}
+	return pool
+}
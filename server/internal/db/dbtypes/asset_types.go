@@ -63,6 +63,17 @@ type PhotoSpecificMetadata struct {
 	Description          string     `json:"description,omitempty"`
 	IsRAW                bool       `json:"is_raw,omitempty"`
 	ContentIdentifier    string     `json:"content_identifier,omitempty"`
+	// HasEdits marks a RAW whose darktable/RawTherapee sidecar carries a
+	// processing history, so an edited version exists in that editor.
+	HasEdits   bool   `json:"has_edits,omitempty"`
+	EditedWith string `json:"edited_with,omitempty"`
+	ColorLabel string `json:"color_label,omitempty"`
+	// BlurHash is a placeholder computed from the small thumbnail; the
+	// thumbnail task stores it and metadata rewrites keep it.
+	BlurHash string `json:"blurhash,omitempty"`
+	// HashVerified records, for uploads, whether the hash the ingest worker
+	// computed matched the one the client sent; absent when it sent none.
+	HashVerified *bool `json:"hash_verified,omitempty"`
 }
 
 type VideoSpecificMetadata struct {
@@ -76,6 +87,7 @@ type VideoSpecificMetadata struct {
 	GPSLongitude         *float64   `json:"gps_longitude,omitempty" example:"-122.4194"`
 	Description          string     `json:"description,omitempty" example:"A beautiful sunset over the ocean"`
 	ContentIdentifier    string     `json:"content_identifier,omitempty"`
+	HashVerified         *bool      `json:"hash_verified,omitempty"`
 }
 
 type AudioSpecificMetadata struct {
@@ -89,6 +101,12 @@ type AudioSpecificMetadata struct {
 	Genre       string `json:"genre,omitempty" example:"Pop"`
 	Year        int    `json:"year,omitempty" example:"2023"`
 	Description string `json:"description,omitempty" example:"Song Description"`
+	// Duration is the running time in seconds.
+	Duration float64 `json:"duration,omitempty" example:"215.4"`
+	// WaveformPeaks is the loudest sample of each of up to 1000 equal
+	// stretches of the recording, as a percentage of full scale.
+	WaveformPeaks []int `json:"waveform_peaks,omitempty"`
+	HashVerified  *bool `json:"hash_verified,omitempty"`
 }
 
 // ----- 便捷（反）序列化函数 -----
@@ -18,6 +18,9 @@ const (
 	StateWarning AssetState = "warning"
 	// StateFailed indicates the asset processing failed completely
 	StateFailed AssetState = "failed"
+	// StateDeferred indicates the asset is stored but waits for processing to
+	// be requested, as uploads to a repository in manual processing mode do
+	StateDeferred AssetState = "deferred"
 )
 
 // TaskState represents the processing state of an individual pipeline task.
@@ -95,6 +98,15 @@ func NewFailedStatus(message string, errors []ErrorDetail) AssetStatus {
 	}
 }
 
+// NewDeferredStatus creates a status for an asset whose processing is deferred
+func NewDeferredStatus() AssetStatus {
+	return AssetStatus{
+		State:     StateDeferred,
+		Message:   "Processing deferred until requested",
+		UpdatedAt: nowRFC3339(),
+	}
+}
+
 // NewTrackedProcessingStatus initializes a processing status with known pipeline tasks.
 func NewTrackedProcessingStatus(message string, tasks []string) AssetStatus {
 	status := NewProcessingStatus(message)
@@ -54,3 +54,13 @@ func TestTrackedStatusFailedTaskCanRecoverAfterRetry(t *testing.T) {
 	require.Equal(t, "Asset processed successfully", current.Message)
 	require.Empty(t, current.Errors)
 }
+
+func TestDeferredStatusStaysDeferredUntilProcessed(t *testing.T) {
+	current := NewDeferredStatus()
+	require.Equal(t, StateDeferred, current.State)
+	require.Empty(t, current.Tasks)
+	require.False(t, current.IsRetryable())
+
+	current.RefreshSummary()
+	require.Equal(t, StateDeferred, current.State)
+}
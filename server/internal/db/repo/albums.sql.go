@@ -13,12 +13,51 @@ import (
 	"server/internal/db/dbtypes"
 )
 
+const autoSelectAlbumCover = `-- name: AutoSelectAlbumCover :one
+UPDATE albums al
+SET cover_auto = true,
+  cover_asset_id = (
+    SELECT aa.asset_id
+    FROM album_assets aa
+    JOIN assets a ON a.asset_id = aa.asset_id
+    WHERE aa.album_id = al.album_id
+      AND a.is_deleted = false
+    ORDER BY a.rating DESC NULLS LAST, COALESCE(a.taken_time, a.upload_time) DESC, a.asset_id DESC
+    LIMIT 1
+  ),
+  updated_at = CURRENT_TIMESTAMP
+WHERE al.album_id = $1
+RETURNING album_id, user_id, album_name, created_at, updated_at, description, cover_asset_id, album_type, rules, cover_auto
+`
+
+// Points the cover at the album's highest-rated live member, the most
+// recently taken among equals, or clears it when the album is empty, and
+// keeps choosing it automatically from then on.
+func (q *Queries) AutoSelectAlbumCover(ctx context.Context, albumID int32) (Album, error) {
+	row := q.db.QueryRow(ctx, autoSelectAlbumCover, albumID)
+	var i Album
+	err := row.Scan(
+		&i.AlbumID,
+		&i.UserID,
+		&i.AlbumName,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Description,
+		&i.CoverAssetID,
+		&i.AlbumType,
+		&i.Rules,
+		&i.CoverAuto,
+	)
+	return i, err
+}
+
 const countAlbumsByUserScoped = `-- name: CountAlbumsByUserScoped :one
 SELECT COUNT(*)
 FROM albums al
 WHERE al.user_id = $1
   AND (
     $2::uuid IS NULL
+    OR al.rules IS NOT NULL
     OR EXISTS (
       SELECT 1
       FROM album_assets aa
@@ -50,9 +89,9 @@ func (q *Queries) CountAlbumsByUserScoped(ctx context.Context, arg CountAlbumsBy
 }
 
 const createAlbum = `-- name: CreateAlbum :one
-INSERT INTO albums (user_id, album_name, description, cover_asset_id, album_type)
-VALUES ($1, $2, $3, $4, $5)
-RETURNING album_id, user_id, album_name, created_at, updated_at, description, cover_asset_id, album_type
+INSERT INTO albums (user_id, album_name, description, cover_asset_id, album_type, rules, cover_auto)
+VALUES ($1, $2, $3, $4, $5, $6, $4::uuid IS NULL)
+RETURNING album_id, user_id, album_name, created_at, updated_at, description, cover_asset_id, album_type, rules, cover_auto
 `
 
 type CreateAlbumParams struct {
@@ -61,8 +100,10 @@ type CreateAlbumParams struct {
 	Description  *string     `db:"description" json:"description"`
 	CoverAssetID pgtype.UUID `db:"cover_asset_id" json:"cover_asset_id"`
 	AlbumType    AlbumType   `db:"album_type" json:"album_type"`
+	Rules        []byte      `db:"rules" json:"rules"`
 }
 
+// An album created without a cover has it chosen automatically.
 func (q *Queries) CreateAlbum(ctx context.Context, arg CreateAlbumParams) (Album, error) {
 	row := q.db.QueryRow(ctx, createAlbum,
 		arg.UserID,
@@ -70,6 +111,7 @@ func (q *Queries) CreateAlbum(ctx context.Context, arg CreateAlbumParams) (Album
 		arg.Description,
 		arg.CoverAssetID,
 		arg.AlbumType,
+		arg.Rules,
 	)
 	var i Album
 	err := row.Scan(
@@ -81,6 +123,8 @@ func (q *Queries) CreateAlbum(ctx context.Context, arg CreateAlbumParams) (Album
 		&i.Description,
 		&i.CoverAssetID,
 		&i.AlbumType,
+		&i.Rules,
+		&i.CoverAuto,
 	)
 	return i, err
 }
@@ -94,6 +138,18 @@ func (q *Queries) DeleteAlbum(ctx context.Context, albumID int32) error {
 	return err
 }
 
+const deleteAlbumsByIDs = `-- name: DeleteAlbumsByIDs :execrows
+DELETE FROM albums WHERE album_id = ANY($1::int[])
+`
+
+func (q *Queries) DeleteAlbumsByIDs(ctx context.Context, albumIds []int32) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteAlbumsByIDs, albumIds)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const getAlbumAssetCount = `-- name: GetAlbumAssetCount :one
 SELECT COUNT(*) as count
 FROM album_assets aa
@@ -331,7 +387,7 @@ func (q *Queries) GetAlbumAssetsScoped(ctx context.Context, arg GetAlbumAssetsSc
 }
 
 const getAlbumByID = `-- name: GetAlbumByID :one
-SELECT album_id, user_id, album_name, created_at, updated_at, description, cover_asset_id, album_type FROM albums WHERE album_id = $1
+SELECT album_id, user_id, album_name, created_at, updated_at, description, cover_asset_id, album_type, rules, cover_auto FROM albums WHERE album_id = $1
 `
 
 func (q *Queries) GetAlbumByID(ctx context.Context, albumID int32) (Album, error) {
@@ -346,6 +402,8 @@ func (q *Queries) GetAlbumByID(ctx context.Context, albumID int32) (Album, error
 		&i.Description,
 		&i.CoverAssetID,
 		&i.AlbumType,
+		&i.Rules,
+		&i.CoverAuto,
 	)
 	return i, err
 }
@@ -360,6 +418,7 @@ SELECT
   al.description,
   al.cover_asset_id,
   al.album_type,
+  al.rules,
   COALESCE(asset_counts.asset_count, 0) AS asset_count,
   COALESCE(cover_asset.cover_asset_id, first_asset.asset_id)::uuid AS display_cover_asset_id
 FROM albums al
@@ -415,6 +474,7 @@ type GetAlbumByIDScopedRow struct {
 	Description         *string            `db:"description" json:"description"`
 	CoverAssetID        pgtype.UUID        `db:"cover_asset_id" json:"cover_asset_id"`
 	AlbumType           AlbumType          `db:"album_type" json:"album_type"`
+	Rules               []byte             `db:"rules" json:"rules"`
 	AssetCount          int64              `db:"asset_count" json:"asset_count"`
 	DisplayCoverAssetID pgtype.UUID        `db:"display_cover_asset_id" json:"display_cover_asset_id"`
 }
@@ -431,14 +491,45 @@ func (q *Queries) GetAlbumByIDScoped(ctx context.Context, arg GetAlbumByIDScoped
 		&i.Description,
 		&i.CoverAssetID,
 		&i.AlbumType,
+		&i.Rules,
 		&i.AssetCount,
 		&i.DisplayCoverAssetID,
 	)
 	return i, err
 }
 
+const getAlbumByUserAndName = `-- name: GetAlbumByUserAndName :one
+SELECT album_id, user_id, album_name, created_at, updated_at, description, cover_asset_id, album_type, rules, cover_auto FROM albums
+WHERE user_id = $1 AND album_name = $2 AND album_type = 'default'
+ORDER BY album_id
+LIMIT 1
+`
+
+type GetAlbumByUserAndNameParams struct {
+	UserID    int32  `db:"user_id" json:"user_id"`
+	AlbumName string `db:"album_name" json:"album_name"`
+}
+
+func (q *Queries) GetAlbumByUserAndName(ctx context.Context, arg GetAlbumByUserAndNameParams) (Album, error) {
+	row := q.db.QueryRow(ctx, getAlbumByUserAndName, arg.UserID, arg.AlbumName)
+	var i Album
+	err := row.Scan(
+		&i.AlbumID,
+		&i.UserID,
+		&i.AlbumName,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Description,
+		&i.CoverAssetID,
+		&i.AlbumType,
+		&i.Rules,
+		&i.CoverAuto,
+	)
+	return i, err
+}
+
 const getAlbumsByUser = `-- name: GetAlbumsByUser :many
-SELECT album_id, user_id, album_name, created_at, updated_at, description, cover_asset_id, album_type FROM albums
+SELECT album_id, user_id, album_name, created_at, updated_at, description, cover_asset_id, album_type, rules, cover_auto FROM albums
 WHERE user_id = $1
 ORDER BY created_at DESC
 LIMIT $2 OFFSET $3
@@ -468,6 +559,8 @@ func (q *Queries) GetAlbumsByUser(ctx context.Context, arg GetAlbumsByUserParams
 			&i.Description,
 			&i.CoverAssetID,
 			&i.AlbumType,
+			&i.Rules,
+			&i.CoverAuto,
 		); err != nil {
 			return nil, err
 		}
@@ -488,6 +581,7 @@ WITH page_albums AS MATERIALIZED (
   WHERE al.user_id = $2
     AND (
       $1::uuid IS NULL
+      OR al.rules IS NOT NULL
       OR EXISTS (
         SELECT 1
         FROM album_assets aa_exists
@@ -516,6 +610,7 @@ SELECT
   al.description,
   al.cover_asset_id,
   al.album_type,
+  al.rules,
   COALESCE(asset_counts.asset_count, 0) AS asset_count,
   COALESCE(cover_asset.cover_asset_id, first_asset.asset_id)::uuid AS display_cover_asset_id
 FROM page_albums p
@@ -574,6 +669,7 @@ type GetAlbumsByUserScopedRow struct {
 	Description         *string            `db:"description" json:"description"`
 	CoverAssetID        pgtype.UUID        `db:"cover_asset_id" json:"cover_asset_id"`
 	AlbumType           AlbumType          `db:"album_type" json:"album_type"`
+	Rules               []byte             `db:"rules" json:"rules"`
 	AssetCount          int64              `db:"asset_count" json:"asset_count"`
 	DisplayCoverAssetID pgtype.UUID        `db:"display_cover_asset_id" json:"display_cover_asset_id"`
 }
@@ -601,6 +697,7 @@ func (q *Queries) GetAlbumsByUserScoped(ctx context.Context, arg GetAlbumsByUser
 			&i.Description,
 			&i.CoverAssetID,
 			&i.AlbumType,
+			&i.Rules,
 			&i.AssetCount,
 			&i.DisplayCoverAssetID,
 		); err != nil {
@@ -615,7 +712,7 @@ func (q *Queries) GetAlbumsByUserScoped(ctx context.Context, arg GetAlbumsByUser
 }
 
 const getAssetAlbums = `-- name: GetAssetAlbums :many
-SELECT al.album_id, al.user_id, al.album_name, al.created_at, al.updated_at, al.description, al.cover_asset_id, al.album_type, aa.position, aa.added_time
+SELECT al.album_id, al.user_id, al.album_name, al.created_at, al.updated_at, al.description, al.cover_asset_id, al.album_type, al.rules, al.cover_auto, aa.position, aa.added_time
 FROM albums al
 JOIN album_assets aa ON al.album_id = aa.album_id
 WHERE aa.asset_id = $1
@@ -631,6 +728,8 @@ type GetAssetAlbumsRow struct {
 	Description  *string            `db:"description" json:"description"`
 	CoverAssetID pgtype.UUID        `db:"cover_asset_id" json:"cover_asset_id"`
 	AlbumType    AlbumType          `db:"album_type" json:"album_type"`
+	Rules        []byte             `db:"rules" json:"rules"`
+	CoverAuto    bool               `db:"cover_auto" json:"cover_auto"`
 	Position     *int32             `db:"position" json:"position"`
 	AddedTime    pgtype.Timestamptz `db:"added_time" json:"added_time"`
 }
@@ -653,6 +752,8 @@ func (q *Queries) GetAssetAlbums(ctx context.Context, assetID pgtype.UUID) ([]Ge
 			&i.Description,
 			&i.CoverAssetID,
 			&i.AlbumType,
+			&i.Rules,
+			&i.CoverAuto,
 			&i.Position,
 			&i.AddedTime,
 		); err != nil {
@@ -666,6 +767,25 @@ func (q *Queries) GetAssetAlbums(ctx context.Context, assetID pgtype.UUID) ([]Ge
 	return items, nil
 }
 
+const isAssetInAlbum = `-- name: IsAssetInAlbum :one
+SELECT EXISTS (
+  SELECT 1 FROM album_assets
+  WHERE album_id = $1 AND asset_id = $2
+) AS is_member
+`
+
+type IsAssetInAlbumParams struct {
+	AlbumID int32       `db:"album_id" json:"album_id"`
+	AssetID pgtype.UUID `db:"asset_id" json:"asset_id"`
+}
+
+func (q *Queries) IsAssetInAlbum(ctx context.Context, arg IsAssetInAlbumParams) (bool, error) {
+	row := q.db.QueryRow(ctx, isAssetInAlbum, arg.AlbumID, arg.AssetID)
+	var is_member bool
+	err := row.Scan(&is_member)
+	return is_member, err
+}
+
 const listBioAlbumAssetsMissingSpeciesPredictions = `-- name: ListBioAlbumAssetsMissingSpeciesPredictions :many
 SELECT a.asset_id, a.owner_id, a.type, a.original_filename, a.storage_path, a.mime_type, a.file_size, a.content_hash, a.quick_fingerprint, a.quick_fingerprint_version, a.width, a.height, a.duration, a.upload_time, a.taken_time, a.capture_offset_minutes, a.is_deleted, a.deleted_at, a.specific_metadata, a.rating, a.liked, a.repository_id, a.status, a.updated_at, a.gps_latitude, a.gps_longitude, a.gps_geohash_5, a.gps_geohash_7, a.exif_raw
 FROM album_assets aa
@@ -733,11 +853,352 @@ func (q *Queries) ListBioAlbumAssetsMissingSpeciesPredictions(ctx context.Contex
 	return items, nil
 }
 
+const listDuplicateAlbumsByUser = `-- name: ListDuplicateAlbumsByUser :many
+SELECT
+  al.album_id,
+  al.user_id,
+  al.album_name,
+  al.created_at,
+  al.updated_at,
+  al.description,
+  al.cover_asset_id,
+  al.album_type,
+  (
+    SELECT COUNT(*)
+    FROM album_assets aa
+    JOIN assets a ON a.asset_id = aa.asset_id
+    WHERE aa.album_id = al.album_id
+      AND a.is_deleted = false
+  ) AS asset_count
+FROM albums al
+WHERE al.user_id = $1
+  AND EXISTS (
+    SELECT 1
+    FROM albums other
+    WHERE other.user_id = al.user_id
+      AND other.album_name = al.album_name
+      AND other.album_type = al.album_type
+      AND other.album_id <> al.album_id
+  )
+ORDER BY al.album_name ASC, al.album_type ASC, al.created_at ASC, al.album_id ASC
+`
+
+type ListDuplicateAlbumsByUserRow struct {
+	AlbumID      int32              `db:"album_id" json:"album_id"`
+	UserID       int32              `db:"user_id" json:"user_id"`
+	AlbumName    string             `db:"album_name" json:"album_name"`
+	CreatedAt    pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	UpdatedAt    pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+	Description  *string            `db:"description" json:"description"`
+	CoverAssetID pgtype.UUID        `db:"cover_asset_id" json:"cover_asset_id"`
+	AlbumType    AlbumType          `db:"album_type" json:"album_type"`
+	AssetCount   int64              `db:"asset_count" json:"asset_count"`
+}
+
+// Albums sharing their exact name and type with another album of the same
+// user, grouped by name with the oldest album first.
+func (q *Queries) ListDuplicateAlbumsByUser(ctx context.Context, userID int32) ([]ListDuplicateAlbumsByUserRow, error) {
+	rows, err := q.db.Query(ctx, listDuplicateAlbumsByUser, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListDuplicateAlbumsByUserRow{}
+	for rows.Next() {
+		var i ListDuplicateAlbumsByUserRow
+		if err := rows.Scan(
+			&i.AlbumID,
+			&i.UserID,
+			&i.AlbumName,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Description,
+			&i.CoverAssetID,
+			&i.AlbumType,
+			&i.AssetCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const lockAlbumAssetIDs = `-- name: LockAlbumAssetIDs :many
+SELECT aa.asset_id
+FROM album_assets aa
+JOIN assets a ON a.asset_id = aa.asset_id
+WHERE aa.album_id = $1 AND a.is_deleted = false
+ORDER BY aa.asset_id
+FOR UPDATE OF aa
+`
+
+// Live members of an album, locked until the transaction ends so a reorder
+// is checked against the same membership it writes.
+func (q *Queries) LockAlbumAssetIDs(ctx context.Context, albumID int32) ([]pgtype.UUID, error) {
+	rows, err := q.db.Query(ctx, lockAlbumAssetIDs, albumID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []pgtype.UUID
+	for rows.Next() {
+		var asset_id pgtype.UUID
+		if err := rows.Scan(&asset_id); err != nil {
+			return nil, err
+		}
+		items = append(items, asset_id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const mergeAlbumAssets = `-- name: MergeAlbumAssets :execrows
+INSERT INTO album_assets (album_id, asset_id, position, added_time)
+SELECT
+  $1::int,
+  src.asset_id,
+  COALESCE((
+    SELECT MAX(t.position)
+    FROM album_assets t
+    WHERE t.album_id = $1::int
+  ), 0) + ROW_NUMBER() OVER (
+    ORDER BY src.source_rank, src.position ASC NULLS LAST, src.added_time ASC, src.asset_id ASC
+  ),
+  src.added_time
+FROM (
+  SELECT DISTINCT ON (aa.asset_id)
+    aa.asset_id,
+    aa.position,
+    aa.added_time,
+    array_position($2::int[], aa.album_id) AS source_rank
+  FROM album_assets aa
+  WHERE aa.album_id = ANY($2::int[])
+    AND NOT EXISTS (
+      SELECT 1
+      FROM album_assets t
+      WHERE t.album_id = $1::int
+        AND t.asset_id = aa.asset_id
+    )
+  ORDER BY aa.asset_id, array_position($2::int[], aa.album_id)
+) src
+ON CONFLICT (album_id, asset_id) DO NOTHING
+`
+
+type MergeAlbumAssetsParams struct {
+	TargetAlbumID  int32   `db:"target_album_id" json:"target_album_id"`
+	SourceAlbumIds []int32 `db:"source_album_ids" json:"source_album_ids"`
+}
+
+// Appends the source albums' members the target lacks after the target's last
+// position. Each asset keeps its first membership in source order, and
+// members stay in their album order, so the target reads like the sources
+// laid end to end.
+func (q *Queries) MergeAlbumAssets(ctx context.Context, arg MergeAlbumAssetsParams) (int64, error) {
+	result, err := q.db.Exec(ctx, mergeAlbumAssets, arg.TargetAlbumID, arg.SourceAlbumIds)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const refreshAlbumCover = `-- name: RefreshAlbumCover :execrows
+UPDATE albums al
+SET cover_auto = true,
+  cover_asset_id = (
+    SELECT aa.asset_id
+    FROM album_assets aa
+    JOIN assets a ON a.asset_id = aa.asset_id
+    WHERE aa.album_id = al.album_id
+      AND a.is_deleted = false
+    ORDER BY a.rating DESC NULLS LAST, COALESCE(a.taken_time, a.upload_time) DESC, a.asset_id DESC
+    LIMIT 1
+  ),
+  updated_at = CURRENT_TIMESTAMP
+WHERE al.album_id = $1
+  AND (al.cover_auto OR al.cover_asset_id IS NOT DISTINCT FROM $2::uuid)
+`
+
+type RefreshAlbumCoverParams struct {
+	AlbumID   int32       `db:"album_id" json:"album_id"`
+	Replacing pgtype.UUID `db:"replacing" json:"replacing"`
+}
+
+// Reruns AutoSelectAlbumCover's choice after a membership change for an
+// album whose cover is automatic, or is replacing: the removed asset, or NULL
+// when the album had no cover.
+func (q *Queries) RefreshAlbumCover(ctx context.Context, arg RefreshAlbumCoverParams) (int64, error) {
+	result, err := q.db.Exec(ctx, refreshAlbumCover, arg.AlbumID, arg.Replacing)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const refreshAlbumCoversForAsset = `-- name: RefreshAlbumCoversForAsset :execrows
+UPDATE albums al
+SET cover_asset_id = CASE
+    WHEN al.cover_auto THEN (
+      SELECT aa.asset_id
+      FROM album_assets aa
+      JOIN assets a ON a.asset_id = aa.asset_id
+      WHERE aa.album_id = al.album_id
+        AND aa.asset_id <> $1::uuid
+        AND a.is_deleted = false
+      ORDER BY a.rating DESC NULLS LAST, COALESCE(a.taken_time, a.upload_time) DESC, a.asset_id DESC
+      LIMIT 1
+    )
+    WHEN $2::boolean THEN (
+      SELECT aa.asset_id
+      FROM album_assets aa
+      JOIN assets a ON a.asset_id = aa.asset_id
+      WHERE aa.album_id = al.album_id
+        AND aa.asset_id <> $1::uuid
+        AND a.is_deleted = false
+      ORDER BY aa.added_time DESC, aa.asset_id DESC
+      LIMIT 1
+    )
+  END,
+  updated_at = CURRENT_TIMESTAMP
+WHERE al.cover_asset_id = $1::uuid
+`
+
+type RefreshAlbumCoversForAssetParams struct {
+	AssetID  pgtype.UUID `db:"asset_id" json:"asset_id"`
+	Reassign bool        `db:"reassign" json:"reassign"`
+}
+
+// Repoints albums whose cover is the given asset. Automatic covers get the
+// next automatic choice. Otherwise, with reassign the cover moves to the most
+// recently added remaining live member, else it is cleared.
+func (q *Queries) RefreshAlbumCoversForAsset(ctx context.Context, arg RefreshAlbumCoversForAssetParams) (int64, error) {
+	result, err := q.db.Exec(ctx, refreshAlbumCoversForAsset, arg.AssetID, arg.Reassign)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const reorderAlbumAssets = `-- name: ReorderAlbumAssets :many
+UPDATE album_assets aa
+SET position = o.ord::integer
+FROM unnest($1::uuid[]) WITH ORDINALITY AS o(asset_id, ord)
+WHERE aa.album_id = $2 AND aa.asset_id = o.asset_id
+RETURNING aa.asset_id, aa.position
+`
+
+type ReorderAlbumAssetsParams struct {
+	AssetIds []pgtype.UUID `db:"asset_ids" json:"asset_ids"`
+	AlbumID  int32         `db:"album_id" json:"album_id"`
+}
+
+type ReorderAlbumAssetsRow struct {
+	AssetID  pgtype.UUID `db:"asset_id" json:"asset_id"`
+	Position *int32      `db:"position" json:"position"`
+}
+
+// Sets each listed member's position to its 1-based index in asset_ids.
+func (q *Queries) ReorderAlbumAssets(ctx context.Context, arg ReorderAlbumAssetsParams) ([]ReorderAlbumAssetsRow, error) {
+	rows, err := q.db.Query(ctx, reorderAlbumAssets, arg.AssetIds, arg.AlbumID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ReorderAlbumAssetsRow
+	for rows.Next() {
+		var i ReorderAlbumAssetsRow
+		if err := rows.Scan(&i.AssetID, &i.Position); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const setAlbumCoverAsset = `-- name: SetAlbumCoverAsset :one
+UPDATE albums
+SET cover_asset_id = $2, cover_auto = false, updated_at = CURRENT_TIMESTAMP
+WHERE album_id = $1
+RETURNING album_id, user_id, album_name, created_at, updated_at, description, cover_asset_id, album_type, rules, cover_auto
+`
+
+type SetAlbumCoverAssetParams struct {
+	AlbumID      int32       `db:"album_id" json:"album_id"`
+	CoverAssetID pgtype.UUID `db:"cover_asset_id" json:"cover_asset_id"`
+}
+
+func (q *Queries) SetAlbumCoverAsset(ctx context.Context, arg SetAlbumCoverAssetParams) (Album, error) {
+	row := q.db.QueryRow(ctx, setAlbumCoverAsset, arg.AlbumID, arg.CoverAssetID)
+	var i Album
+	err := row.Scan(
+		&i.AlbumID,
+		&i.UserID,
+		&i.AlbumName,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Description,
+		&i.CoverAssetID,
+		&i.AlbumType,
+		&i.Rules,
+		&i.CoverAuto,
+	)
+	return i, err
+}
+
+const touchMergedAlbum = `-- name: TouchMergedAlbum :one
+UPDATE albums al
+SET cover_asset_id = COALESCE(al.cover_asset_id, (
+    SELECT s.cover_asset_id
+    FROM albums s
+    WHERE s.album_id = ANY($1::int[])
+      AND s.cover_asset_id IS NOT NULL
+    ORDER BY array_position($1::int[], s.album_id)
+    LIMIT 1
+  )),
+  updated_at = CURRENT_TIMESTAMP
+WHERE al.album_id = $2::int
+RETURNING album_id, user_id, album_name, created_at, updated_at, description, cover_asset_id, album_type, rules, cover_auto
+`
+
+type TouchMergedAlbumParams struct {
+	SourceAlbumIds []int32 `db:"source_album_ids" json:"source_album_ids"`
+	TargetAlbumID  int32   `db:"target_album_id" json:"target_album_id"`
+}
+
+// A target without a cover adopts the first source cover.
+func (q *Queries) TouchMergedAlbum(ctx context.Context, arg TouchMergedAlbumParams) (Album, error) {
+	row := q.db.QueryRow(ctx, touchMergedAlbum, arg.SourceAlbumIds, arg.TargetAlbumID)
+	var i Album
+	err := row.Scan(
+		&i.AlbumID,
+		&i.UserID,
+		&i.AlbumName,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Description,
+		&i.CoverAssetID,
+		&i.AlbumType,
+		&i.Rules,
+		&i.CoverAuto,
+	)
+	return i, err
+}
+
 const updateAlbum = `-- name: UpdateAlbum :one
 UPDATE albums
-SET album_name = $2, description = $3, cover_asset_id = $4, album_type = $5, updated_at = CURRENT_TIMESTAMP
+SET album_name = $2, description = $3, cover_asset_id = $4, album_type = $5,
+  cover_auto = cover_auto AND cover_asset_id IS NOT DISTINCT FROM $4,
+  updated_at = CURRENT_TIMESTAMP
 WHERE album_id = $1
-RETURNING album_id, user_id, album_name, created_at, updated_at, description, cover_asset_id, album_type
+RETURNING album_id, user_id, album_name, created_at, updated_at, description, cover_asset_id, album_type, rules, cover_auto
 `
 
 type UpdateAlbumParams struct {
@@ -748,6 +1209,7 @@ type UpdateAlbumParams struct {
 	AlbumType    AlbumType   `db:"album_type" json:"album_type"`
 }
 
+// A changed cover counts as picked by hand.
 func (q *Queries) UpdateAlbum(ctx context.Context, arg UpdateAlbumParams) (Album, error) {
 	row := q.db.QueryRow(ctx, updateAlbum,
 		arg.AlbumID,
@@ -766,6 +1228,8 @@ func (q *Queries) UpdateAlbum(ctx context.Context, arg UpdateAlbumParams) (Album
 		&i.Description,
 		&i.CoverAssetID,
 		&i.AlbumType,
+		&i.Rules,
+		&i.CoverAuto,
 	)
 	return i, err
 }
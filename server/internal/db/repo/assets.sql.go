@@ -54,6 +54,29 @@ func (q *Queries) AddTagToAsset(ctx context.Context, arg AddTagToAssetParams) er
 	return err
 }
 
+const assignAssetRepository = `-- name: AssignAssetRepository :execrows
+UPDATE assets
+SET repository_id = $2,
+    storage_path = $3,
+    updated_at = CURRENT_TIMESTAMP
+WHERE asset_id = $1
+  AND repository_id IS NULL
+`
+
+type AssignAssetRepositoryParams struct {
+	AssetID      pgtype.UUID `db:"asset_id" json:"asset_id"`
+	RepositoryID pgtype.UUID `db:"repository_id" json:"repository_id"`
+	StoragePath  *string     `db:"storage_path" json:"storage_path"`
+}
+
+func (q *Queries) AssignAssetRepository(ctx context.Context, arg AssignAssetRepositoryParams) (int64, error) {
+	result, err := q.db.Exec(ctx, assignAssetRepository, arg.AssetID, arg.RepositoryID, arg.StoragePath)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const bulkToggleAssetLiked = `-- name: BulkToggleAssetLiked :exec
 UPDATE assets
 SET liked = NOT liked
@@ -117,6 +140,28 @@ func (q *Queries) BulkUpdateAssetStatus(ctx context.Context, arg BulkUpdateAsset
 	return err
 }
 
+const claimUnownedAsset = `-- name: ClaimUnownedAsset :execrows
+UPDATE assets
+SET owner_id = $1, updated_at = CURRENT_TIMESTAMP
+WHERE asset_id = $2
+  AND owner_id IS NULL
+`
+
+type ClaimUnownedAssetParams struct {
+	OwnerID *int32      `db:"owner_id" json:"owner_id"`
+	AssetID pgtype.UUID `db:"asset_id" json:"asset_id"`
+}
+
+// Gives an asset discovered without an owner to the user who uploaded the
+// same content again.
+func (q *Queries) ClaimUnownedAsset(ctx context.Context, arg ClaimUnownedAssetParams) (int64, error) {
+	result, err := q.db.Exec(ctx, claimUnownedAsset, arg.OwnerID, arg.AssetID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const countAssetsByRating = `-- name: CountAssetsByRating :many
 SELECT rating, COUNT(*) as count
 FROM assets
@@ -183,6 +228,100 @@ func (q *Queries) CountAssetsByStatusAndOwner(ctx context.Context, arg CountAsse
 	return count, err
 }
 
+const detachAssetStoragePath = `-- name: DetachAssetStoragePath :execrows
+UPDATE assets
+SET
+    storage_path = NULL,
+    is_deleted = true,
+    deleted_at = COALESCE(deleted_at, CURRENT_TIMESTAMP)
+WHERE repository_id = $1
+  AND storage_path = $2
+  AND asset_id <> $3
+`
+
+type DetachAssetStoragePathParams struct {
+	RepositoryID pgtype.UUID `db:"repository_id" json:"repository_id"`
+	StoragePath  *string     `db:"storage_path" json:"storage_path"`
+	KeepAssetID  pgtype.UUID `db:"keep_asset_id" json:"keep_asset_id"`
+}
+
+// Frees a path for an asset moving onto it: whatever other asset is recorded
+// there lost its file and goes to the Trash without a path, from where a
+// later move (two files swapping names) brings it back.
+func (q *Queries) DetachAssetStoragePath(ctx context.Context, arg DetachAssetStoragePathParams) (int64, error) {
+	result, err := q.db.Exec(ctx, detachAssetStoragePath, arg.RepositoryID, arg.StoragePath, arg.KeepAssetID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const listDeferredRepositoryAssets = `-- name: ListDeferredRepositoryAssets :many
+SELECT asset_id, owner_id, type, original_filename, storage_path, mime_type, file_size, content_hash, quick_fingerprint, quick_fingerprint_version, width, height, duration, upload_time, taken_time, capture_offset_minutes, is_deleted, deleted_at, specific_metadata, rating, liked, repository_id, status, updated_at, gps_latitude, gps_longitude, gps_geohash_5, gps_geohash_7, exif_raw FROM assets
+WHERE repository_id = $1
+  AND status->>'state' = 'deferred'
+  AND is_deleted = false
+  AND asset_id > $2
+ORDER BY asset_id
+LIMIT $3
+`
+
+type ListDeferredRepositoryAssetsParams struct {
+	RepositoryID pgtype.UUID `db:"repository_id" json:"repository_id"`
+	AfterAssetID pgtype.UUID `db:"after_asset_id" json:"after_asset_id"`
+	Limit        int32       `db:"limit" json:"limit"`
+}
+
+func (q *Queries) ListDeferredRepositoryAssets(ctx context.Context, arg ListDeferredRepositoryAssetsParams) ([]Asset, error) {
+	rows, err := q.db.Query(ctx, listDeferredRepositoryAssets, arg.RepositoryID, arg.AfterAssetID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Asset
+	for rows.Next() {
+		var i Asset
+		if err := rows.Scan(
+			&i.AssetID,
+			&i.OwnerID,
+			&i.Type,
+			&i.OriginalFilename,
+			&i.StoragePath,
+			&i.MimeType,
+			&i.FileSize,
+			&i.ContentHash,
+			&i.QuickFingerprint,
+			&i.QuickFingerprintVersion,
+			&i.Width,
+			&i.Height,
+			&i.Duration,
+			&i.UploadTime,
+			&i.TakenTime,
+			&i.CaptureOffsetMinutes,
+			&i.IsDeleted,
+			&i.DeletedAt,
+			&i.SpecificMetadata,
+			&i.Rating,
+			&i.Liked,
+			&i.RepositoryID,
+			&i.Status,
+			&i.UpdatedAt,
+			&i.GpsLatitude,
+			&i.GpsLongitude,
+			&i.GpsGeohash5,
+			&i.GpsGeohash7,
+			&i.ExifRaw,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const countAssetsByStatusAndRepository = `-- name: CountAssetsByStatusAndRepository :one
 SELECT COUNT(*) as count
 FROM assets
@@ -201,6 +340,45 @@ func (q *Queries) CountAssetsByStatusAndRepository(ctx context.Context, arg Coun
 	return count, err
 }
 
+const countAssetsByTags = `-- name: CountAssetsByTags :one
+SELECT COUNT(*) FROM assets a
+WHERE a.is_deleted = false
+  AND ($1::integer IS NULL OR a.owner_id = $1)
+  AND ($2::uuid IS NULL OR a.repository_id = $2)
+  AND (
+    SELECT COUNT(DISTINCT lower(t.tag_name))
+    FROM asset_tags at
+    JOIN tags t ON t.tag_id = at.tag_id
+    WHERE at.asset_id = a.asset_id
+      AND lower(t.tag_name) = ANY($3::text[])
+      AND (cardinality($4::text[]) = 0 OR at.source = ANY($4::text[]))
+      AND at.confidence >= $5::float8
+  ) >= CASE WHEN $6::boolean THEN cardinality($3::text[]) ELSE 1 END
+`
+
+type CountAssetsByTagsParams struct {
+	OwnerID       *int32      `db:"owner_id" json:"owner_id"`
+	RepositoryID  pgtype.UUID `db:"repository_id" json:"repository_id"`
+	TagNames      []string    `db:"tag_names" json:"tag_names"`
+	Sources       []string    `db:"sources" json:"sources"`
+	MinConfidence float64     `db:"min_confidence" json:"min_confidence"`
+	MatchAll      bool        `db:"match_all" json:"match_all"`
+}
+
+func (q *Queries) CountAssetsByTags(ctx context.Context, arg CountAssetsByTagsParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countAssetsByTags,
+		arg.OwnerID,
+		arg.RepositoryID,
+		arg.TagNames,
+		arg.Sources,
+		arg.MinConfidence,
+		arg.MatchAll,
+	)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const countAssetsUnified = `-- name: CountAssetsUnified :one
 SELECT COUNT(*) as count
 FROM assets a
@@ -300,23 +478,33 @@ WHERE a.is_deleted = COALESCE($1::boolean, false)
   AND ($22::text IS NULL OR a.specific_metadata->>'camera_model' = $22)
   AND ($23::text IS NULL OR a.specific_metadata->>'lens_model' = $23)
   AND (
-    $24::float8 IS NULL
-    OR $25::float8 IS NULL
-    OR $26::float8 IS NULL
+    $24::jsonb IS NULL
+    OR EXISTS (
+      SELECT 1
+      FROM asset_custom_fields acf
+      WHERE acf.asset_id = a.asset_id
+        AND acf.fields @> $24::jsonb
+    )
+  )
+  AND ($25::text IS NULL OR a.status->>'state' = $25)
+  AND (
+    $26::float8 IS NULL
     OR $27::float8 IS NULL
+    OR $28::float8 IS NULL
+    OR $29::float8 IS NULL
     OR (
     a.gps_latitude IS NOT NULL
     AND a.gps_longitude IS NOT NULL
     AND a.gps_latitude
-      BETWEEN LEAST($25::float8, $24::float8)
-      AND GREATEST($25::float8, $24::float8)
+      BETWEEN LEAST($27::float8, $26::float8)
+      AND GREATEST($27::float8, $26::float8)
     AND (
       CASE
-        WHEN $27::float8 <= $26::float8 THEN
-          a.gps_longitude BETWEEN $27::float8 AND $26::float8
+        WHEN $29::float8 <= $28::float8 THEN
+          a.gps_longitude BETWEEN $29::float8 AND $28::float8
         ELSE
-          a.gps_longitude >= $27::float8
-          OR a.gps_longitude <= $26::float8
+          a.gps_longitude >= $29::float8
+          OR a.gps_longitude <= $28::float8
       END
     )
     )
@@ -347,6 +535,8 @@ type CountAssetsUnifiedParams struct {
 	Liked            *bool              `db:"liked" json:"liked"`
 	CameraModel      *string            `db:"camera_model" json:"camera_model"`
 	LensModel        *string            `db:"lens_model" json:"lens_model"`
+	CustomFields     []byte             `db:"custom_fields" json:"custom_fields"`
+	ProcessingStatus *string            `db:"processing_status" json:"processing_status"`
 	LocationNorth    *float64           `db:"location_north" json:"location_north"`
 	LocationSouth    *float64           `db:"location_south" json:"location_south"`
 	LocationEast     *float64           `db:"location_east" json:"location_east"`
@@ -380,6 +570,8 @@ func (q *Queries) CountAssetsUnified(ctx context.Context, arg CountAssetsUnified
 		arg.Liked,
 		arg.CameraModel,
 		arg.LensModel,
+		arg.CustomFields,
+		arg.ProcessingStatus,
 		arg.LocationNorth,
 		arg.LocationSouth,
 		arg.LocationEast,
@@ -494,23 +686,33 @@ WITH filtered AS MATERIALIZED (
     AND ($22::text IS NULL OR a.specific_metadata->>'camera_model' = $22)
     AND ($23::text IS NULL OR a.specific_metadata->>'lens_model' = $23)
     AND (
-      $24::float8 IS NULL
-      OR $25::float8 IS NULL
-      OR $26::float8 IS NULL
+      $24::jsonb IS NULL
+      OR EXISTS (
+        SELECT 1
+        FROM asset_custom_fields acf
+        WHERE acf.asset_id = a.asset_id
+          AND acf.fields @> $24::jsonb
+      )
+    )
+    AND ($25::text IS NULL OR a.status->>'state' = $25)
+    AND (
+      $26::float8 IS NULL
       OR $27::float8 IS NULL
+      OR $28::float8 IS NULL
+      OR $29::float8 IS NULL
       OR (
         a.gps_latitude IS NOT NULL
         AND a.gps_longitude IS NOT NULL
         AND a.gps_latitude
-          BETWEEN LEAST($25::float8, $24::float8)
-          AND GREATEST($25::float8, $24::float8)
+          BETWEEN LEAST($27::float8, $26::float8)
+          AND GREATEST($27::float8, $26::float8)
         AND (
           CASE
-            WHEN $27::float8 <= $26::float8 THEN
-              a.gps_longitude BETWEEN $27::float8 AND $26::float8
+            WHEN $29::float8 <= $28::float8 THEN
+              a.gps_longitude BETWEEN $29::float8 AND $28::float8
             ELSE
-              a.gps_longitude >= $27::float8
-              OR a.gps_longitude <= $26::float8
+              a.gps_longitude >= $29::float8
+              OR a.gps_longitude <= $28::float8
           END
         )
       )
@@ -548,6 +750,8 @@ type CountCollapsedBrowseItemsUnifiedParams struct {
 	Liked            *bool              `db:"liked" json:"liked"`
 	CameraModel      *string            `db:"camera_model" json:"camera_model"`
 	LensModel        *string            `db:"lens_model" json:"lens_model"`
+	CustomFields     []byte             `db:"custom_fields" json:"custom_fields"`
+	ProcessingStatus *string            `db:"processing_status" json:"processing_status"`
 	LocationNorth    *float64           `db:"location_north" json:"location_north"`
 	LocationSouth    *float64           `db:"location_south" json:"location_south"`
 	LocationEast     *float64           `db:"location_east" json:"location_east"`
@@ -579,6 +783,8 @@ func (q *Queries) CountCollapsedBrowseItemsUnified(ctx context.Context, arg Coun
 		arg.Liked,
 		arg.CameraModel,
 		arg.LensModel,
+		arg.CustomFields,
+		arg.ProcessingStatus,
 		arg.LocationNorth,
 		arg.LocationSouth,
 		arg.LocationEast,
@@ -653,6 +859,18 @@ func (q *Queries) CountPhotoMapPoints(ctx context.Context, arg CountPhotoMapPoin
 	return count, err
 }
 
+const countThumbnailReferences = `-- name: CountThumbnailReferences :one
+SELECT COUNT(*) FROM thumbnails
+WHERE storage_path = $1
+`
+
+func (q *Queries) CountThumbnailReferences(ctx context.Context, storagePath string) (int64, error) {
+	row := q.db.QueryRow(ctx, countThumbnailReferences, storagePath)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createAsset = `-- name: CreateAsset :one
 INSERT INTO assets (
     owner_id, type, original_filename, storage_path, mime_type,
@@ -740,13 +958,15 @@ func (q *Queries) CreateAsset(ctx context.Context, arg CreateAssetParams) (Asset
 }
 
 const createThumbnail = `-- name: CreateThumbnail :one
-INSERT INTO thumbnails (asset_id, size, storage_path, mime_type)
-VALUES ($1, $2, $3, $4)
+INSERT INTO thumbnails (asset_id, size, storage_path, mime_type, width, height)
+VALUES ($1, $2, $3, $4, $5, $6)
 ON CONFLICT (asset_id, size) DO UPDATE
 SET storage_path = EXCLUDED.storage_path,
     mime_type = EXCLUDED.mime_type,
+    width = EXCLUDED.width,
+    height = EXCLUDED.height,
     created_at = CURRENT_TIMESTAMP
-RETURNING thumbnail_id, asset_id, size, storage_path, mime_type, created_at
+RETURNING thumbnail_id, asset_id, size, storage_path, mime_type, created_at, width, height
 `
 
 type CreateThumbnailParams struct {
@@ -754,6 +974,8 @@ type CreateThumbnailParams struct {
 	Size        string      `db:"size" json:"size"`
 	StoragePath string      `db:"storage_path" json:"storage_path"`
 	MimeType    string      `db:"mime_type" json:"mime_type"`
+	Width       *int32      `db:"width" json:"width"`
+	Height      *int32      `db:"height" json:"height"`
 }
 
 func (q *Queries) CreateThumbnail(ctx context.Context, arg CreateThumbnailParams) (Thumbnail, error) {
@@ -762,6 +984,8 @@ func (q *Queries) CreateThumbnail(ctx context.Context, arg CreateThumbnailParams
 		arg.Size,
 		arg.StoragePath,
 		arg.MimeType,
+		arg.Width,
+		arg.Height,
 	)
 	var i Thumbnail
 	err := row.Scan(
@@ -771,6 +995,8 @@ func (q *Queries) CreateThumbnail(ctx context.Context, arg CreateThumbnailParams
 		&i.StoragePath,
 		&i.MimeType,
 		&i.CreatedAt,
+		&i.Width,
+		&i.Height,
 	)
 	return i, err
 }
@@ -786,6 +1012,26 @@ func (q *Queries) DeleteAsset(ctx context.Context, assetID pgtype.UUID) error {
 	return err
 }
 
+const deleteAssetTagsByAsset = `-- name: DeleteAssetTagsByAsset :exec
+DELETE FROM asset_tags
+WHERE asset_id = $1
+`
+
+func (q *Queries) DeleteAssetTagsByAsset(ctx context.Context, assetID pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, deleteAssetTagsByAsset, assetID)
+	return err
+}
+
+const deleteThumbnailsByAsset = `-- name: DeleteThumbnailsByAsset :exec
+DELETE FROM thumbnails
+WHERE asset_id = $1
+`
+
+func (q *Queries) DeleteThumbnailsByAsset(ctx context.Context, assetID pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, deleteThumbnailsByAsset, assetID)
+	return err
+}
+
 const getAssetByContentHashAndRepository = `-- name: GetAssetByContentHashAndRepository :one
 SELECT asset_id, owner_id, type, original_filename, storage_path, mime_type, file_size, content_hash, quick_fingerprint, quick_fingerprint_version, width, height, duration, upload_time, taken_time, capture_offset_minutes, is_deleted, deleted_at, specific_metadata, rating, liked, repository_id, status, updated_at, gps_latitude, gps_longitude, gps_geohash_5, gps_geohash_7, exif_raw FROM assets
 WHERE content_hash = $1 AND repository_id = $2 AND is_deleted = false
@@ -977,6 +1223,46 @@ func (q *Queries) GetAssetExifRaw(ctx context.Context, assetID pgtype.UUID) (jso
 	return exif_raw, err
 }
 
+const getAssetIDsByContentHashes = `-- name: GetAssetIDsByContentHashes :many
+SELECT asset_id, content_hash FROM assets
+WHERE content_hash = ANY($1::text[])
+  AND is_deleted = false
+  AND ($2::integer IS NULL OR owner_id = $2)
+  AND ($3::uuid IS NULL OR repository_id = $3)
+ORDER BY upload_time ASC, asset_id ASC
+`
+
+type GetAssetIDsByContentHashesParams struct {
+	ContentHashes []string    `db:"content_hashes" json:"content_hashes"`
+	OwnerID       *int32      `db:"owner_id" json:"owner_id"`
+	RepositoryID  pgtype.UUID `db:"repository_id" json:"repository_id"`
+}
+
+type GetAssetIDsByContentHashesRow struct {
+	AssetID     pgtype.UUID `db:"asset_id" json:"asset_id"`
+	ContentHash string      `db:"content_hash" json:"content_hash"`
+}
+
+func (q *Queries) GetAssetIDsByContentHashes(ctx context.Context, arg GetAssetIDsByContentHashesParams) ([]GetAssetIDsByContentHashesRow, error) {
+	rows, err := q.db.Query(ctx, getAssetIDsByContentHashes, arg.ContentHashes, arg.OwnerID, arg.RepositoryID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetAssetIDsByContentHashesRow
+	for rows.Next() {
+		var i GetAssetIDsByContentHashesRow
+		if err := rows.Scan(&i.AssetID, &i.ContentHash); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getAssetIDsUnified = `-- name: GetAssetIDsUnified :many
 
 SELECT a.asset_id
@@ -1077,17 +1363,27 @@ WHERE a.is_deleted = COALESCE($1::boolean, false)
   AND ($22::text IS NULL OR a.specific_metadata->>'camera_model' = $22)
   AND ($23::text IS NULL OR a.specific_metadata->>'lens_model' = $23)
   AND (
-    $24::text IS NULL
+    $24::jsonb IS NULL
+    OR EXISTS (
+      SELECT 1
+      FROM asset_custom_fields acf
+      WHERE acf.asset_id = a.asset_id
+        AND acf.fields @> $24::jsonb
+    )
+  )
+  AND ($25::text IS NULL OR a.status->>'state' = $25)
+  AND (
+    $26::text IS NULL
     OR EXISTS (
       SELECT 1
       FROM location_cluster_assets lca
       JOIN location_clusters lc ON lc.cluster_id = lca.cluster_id
       WHERE lca.asset_id = a.asset_id
-        AND lc.search_vector @@ plainto_tsquery('simple', $24)
+        AND lc.search_vector @@ plainto_tsquery('simple', $26)
     )
   )
 ORDER BY COALESCE(a.taken_time, a.upload_time) DESC, a.asset_id DESC
-LIMIT $25
+LIMIT $27
 `
 
 type GetAssetIDsUnifiedParams struct {
@@ -1114,6 +1410,8 @@ type GetAssetIDsUnifiedParams struct {
 	Liked            *bool              `db:"liked" json:"liked"`
 	CameraModel      *string            `db:"camera_model" json:"camera_model"`
 	LensModel        *string            `db:"lens_model" json:"lens_model"`
+	CustomFields     []byte             `db:"custom_fields" json:"custom_fields"`
+	ProcessingStatus *string            `db:"processing_status" json:"processing_status"`
 	Place            *string            `db:"place" json:"place"`
 	Limit            int32              `db:"limit" json:"limit"`
 }
@@ -1150,6 +1448,8 @@ func (q *Queries) GetAssetIDsUnified(ctx context.Context, arg GetAssetIDsUnified
 		arg.Liked,
 		arg.CameraModel,
 		arg.LensModel,
+		arg.CustomFields,
+		arg.ProcessingStatus,
 		arg.Place,
 		arg.Limit,
 	)
@@ -1171,77 +1471,322 @@ func (q *Queries) GetAssetIDsUnified(ctx context.Context, arg GetAssetIDsUnified
 	return items, nil
 }
 
-const getAssetStatsForOwner = `-- name: GetAssetStatsForOwner :one
-SELECT
-  COUNT(*) as total_assets,
-  COUNT(CASE WHEN liked = true THEN 1 END) as liked_count,
-  COUNT(CASE WHEN rating IS NOT NULL THEN 1 END) as rated_count,
-  AVG(rating) as avg_rating,
-  MAX(rating) as max_rating,
-  MIN(rating) as min_rating
-FROM assets
-WHERE is_deleted = false
-  AND owner_id = $1::integer
-`
-
-type GetAssetStatsForOwnerRow struct {
-	TotalAssets int64       `db:"total_assets" json:"total_assets"`
-	LikedCount  int64       `db:"liked_count" json:"liked_count"`
-	RatedCount  int64       `db:"rated_count" json:"rated_count"`
-	AvgRating   float64     `db:"avg_rating" json:"avg_rating"`
-	MaxRating   interface{} `db:"max_rating" json:"max_rating"`
-	MinRating   interface{} `db:"min_rating" json:"min_rating"`
-}
-
-func (q *Queries) GetAssetStatsForOwner(ctx context.Context, ownerID int32) (GetAssetStatsForOwnerRow, error) {
-	row := q.db.QueryRow(ctx, getAssetStatsForOwner, ownerID)
-	var i GetAssetStatsForOwnerRow
-	err := row.Scan(
-		&i.TotalAssets,
-		&i.LikedCount,
-		&i.RatedCount,
-		&i.AvgRating,
-		&i.MaxRating,
-		&i.MinRating,
-	)
-	return i, err
-}
-
-const getAssetsByContentHash = `-- name: GetAssetsByContentHash :many
-SELECT asset_id, owner_id, type, original_filename, storage_path, mime_type, file_size, content_hash, quick_fingerprint, quick_fingerprint_version, width, height, duration, upload_time, taken_time, capture_offset_minutes, is_deleted, deleted_at, specific_metadata, rating, liked, repository_id, status, updated_at, gps_latitude, gps_longitude, gps_geohash_5, gps_geohash_7, exif_raw FROM assets
-WHERE content_hash = $1 AND is_deleted = false
-`
-
-func (q *Queries) GetAssetsByContentHash(ctx context.Context, contentHash string) ([]Asset, error) {
-	rows, err := q.db.Query(ctx, getAssetsByContentHash, contentHash)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-	var items []Asset
-	for rows.Next() {
-		var i Asset
-		if err := rows.Scan(
-			&i.AssetID,
-			&i.OwnerID,
-			&i.Type,
-			&i.OriginalFilename,
-			&i.StoragePath,
-			&i.MimeType,
-			&i.FileSize,
-			&i.ContentHash,
-			&i.QuickFingerprint,
-			&i.QuickFingerprintVersion,
-			&i.Width,
-			&i.Height,
-			&i.Duration,
-			&i.UploadTime,
-			&i.TakenTime,
-			&i.CaptureOffsetMinutes,
-			&i.IsDeleted,
-			&i.DeletedAt,
-			&i.SpecificMetadata,
-			&i.Rating,
+const getAssetNeighborsUnified = `-- name: GetAssetNeighborsUnified :one
+WITH anchor AS (
+  SELECT
+    a.asset_id,
+    CASE
+      WHEN $1::text = 'recently_added' THEN a.upload_time
+      ELSE COALESCE(a.taken_time, a.upload_time)
+    END AS sort_time
+  FROM assets a
+  WHERE a.asset_id = $2::uuid
+),
+filtered AS NOT MATERIALIZED (
+  SELECT
+    a.asset_id,
+    CASE
+      WHEN $1::text = 'recently_added' THEN a.upload_time
+      ELSE COALESCE(a.taken_time, a.upload_time)
+    END AS sort_time
+  FROM assets a
+  WHERE a.is_deleted = COALESCE($3::boolean, false)
+    AND ($4::uuid[] IS NULL OR a.asset_id = ANY($4::uuid[]))
+    AND ($5::text IS NULL OR a.original_filename ILIKE '%' || $5 || '%')
+    AND ($6::text IS NULL OR a.type = $6)
+    AND ($7::text[] IS NULL OR a.type = ANY($7::text[]))
+    AND ($8::integer IS NULL OR a.owner_id = $8)
+    AND ($9::uuid IS NULL OR a.repository_id = $9)
+    AND (
+      $10::text IS NULL
+      OR (
+        CASE
+          WHEN $10::text = '' THEN
+            CASE WHEN COALESCE($11::boolean, true) THEN true
+              ELSE position('/' in a.storage_path) = 0
+            END
+          ELSE
+            CASE WHEN COALESCE($11::boolean, true) THEN
+              a.storage_path LIKE $10 || '/%'
+            ELSE
+              a.storage_path LIKE $10 || '/%'
+              AND a.storage_path NOT LIKE $10 || '/%/%'
+            END
+        END
+      )
+    )
+    AND (
+      $12::integer IS NULL
+      OR EXISTS (
+        SELECT 1
+        FROM face_cluster_members fcm
+        JOIN face_items fi_person ON fi_person.id = fcm.face_id
+        WHERE fcm.cluster_id = $12
+          AND fi_person.asset_id = a.asset_id
+      )
+    )
+    AND (
+      $13::integer IS NULL
+      OR EXISTS (
+        SELECT 1
+        FROM album_assets aa
+        WHERE aa.asset_id = a.asset_id
+          AND aa.album_id = $13
+      )
+    )
+    AND (
+      $14::text IS NULL
+      OR EXISTS (
+        SELECT 1
+        FROM asset_tags at
+        JOIN tags t ON t.tag_id = at.tag_id
+        WHERE at.asset_id = a.asset_id
+          AND t.tag_name = $14
+          AND ($15::text IS NULL OR at.source = $15)
+      )
+    )
+    AND (
+      $16::text[] IS NULL
+      OR (
+        SELECT COUNT(DISTINCT t2.tag_name)
+        FROM asset_tags at2
+        JOIN tags t2 ON t2.tag_id = at2.tag_id
+        WHERE at2.asset_id = a.asset_id
+          AND t2.tag_name = ANY($16::text[])
+      ) = cardinality($16::text[])
+    )
+    AND ($17::text IS NULL OR
+      CASE COALESCE($18::text, 'contains')
+        WHEN 'matches' THEN a.original_filename ILIKE $17
+        WHEN 'starts_with' THEN a.original_filename ILIKE $17 || '%'
+        WHEN 'ends_with' THEN a.original_filename ILIKE '%' || $17
+        ELSE a.original_filename ILIKE '%' || $17 || '%'
+      END
+    )
+    AND ($19::timestamptz IS NULL OR COALESCE(a.taken_time, a.upload_time) >= $19)
+    AND ($20::timestamptz IS NULL OR COALESCE(a.taken_time, a.upload_time) <= $20)
+    AND ($21::boolean IS NULL OR
+      CASE
+        WHEN $21 = true THEN a.specific_metadata->>'is_raw' = 'true'
+        ELSE a.specific_metadata->>'is_raw' = 'false' OR a.specific_metadata->>'is_raw' IS NULL
+      END
+    )
+    AND ($22::integer IS NULL OR
+      CASE
+        WHEN $22 = 0 THEN a.rating IS NULL OR a.rating = 0
+        ELSE a.rating = $22
+      END
+    )
+    AND ($23::boolean IS NULL OR
+      CASE
+        WHEN $23 = false THEN a.liked IS NULL OR a.liked = false
+        ELSE a.liked = true
+      END
+    )
+    AND ($24::text IS NULL OR a.specific_metadata->>'camera_model' = $24)
+    AND ($25::text IS NULL OR a.specific_metadata->>'lens_model' = $25)
+    AND (
+      $26::jsonb IS NULL
+      OR EXISTS (
+        SELECT 1
+        FROM asset_custom_fields acf
+        WHERE acf.asset_id = a.asset_id
+          AND acf.fields @> $26::jsonb
+      )
+    )
+    AND ($27::text IS NULL OR a.status->>'state' = $27)
+    AND (
+      $28::float8 IS NULL
+      OR $29::float8 IS NULL
+      OR $30::float8 IS NULL
+      OR $31::float8 IS NULL
+      OR (
+        a.gps_latitude IS NOT NULL
+        AND a.gps_longitude IS NOT NULL
+        AND a.gps_latitude
+          BETWEEN LEAST($29::float8, $28::float8)
+          AND GREATEST($29::float8, $28::float8)
+        AND (
+          CASE
+            WHEN $31::float8 <= $30::float8 THEN
+              a.gps_longitude BETWEEN $31::float8 AND $30::float8
+            ELSE
+              a.gps_longitude >= $31::float8
+              OR a.gps_longitude <= $30::float8
+          END
+        )
+      )
+    )
+)
+SELECT
+  (
+    SELECT f.asset_id
+    FROM filtered f
+    WHERE (f.sort_time, f.asset_id) > (anchor.sort_time, anchor.asset_id)
+    ORDER BY f.sort_time ASC, f.asset_id ASC
+    LIMIT 1
+  )::uuid AS previous_id,
+  (
+    SELECT f.asset_id
+    FROM filtered f
+    WHERE (f.sort_time, f.asset_id) < (anchor.sort_time, anchor.asset_id)
+    ORDER BY f.sort_time DESC, f.asset_id DESC
+    LIMIT 1
+  )::uuid AS next_id
+FROM anchor
+`
+
+type GetAssetNeighborsUnifiedParams struct {
+	SortBy           *string            `db:"sort_by" json:"sort_by"`
+	AnchorID         pgtype.UUID        `db:"anchor_id" json:"anchor_id"`
+	IsDeleted        *bool              `db:"is_deleted" json:"is_deleted"`
+	AssetIds         []pgtype.UUID      `db:"asset_ids" json:"asset_ids"`
+	Query            *string            `db:"query" json:"query"`
+	AssetType        *string            `db:"asset_type" json:"asset_type"`
+	AssetTypes       []string           `db:"asset_types" json:"asset_types"`
+	OwnerID          *int32             `db:"owner_id" json:"owner_id"`
+	RepositoryID     pgtype.UUID        `db:"repository_id" json:"repository_id"`
+	FolderPath       *string            `db:"folder_path" json:"folder_path"`
+	FolderRecursive  *bool              `db:"folder_recursive" json:"folder_recursive"`
+	PersonID         *int32             `db:"person_id" json:"person_id"`
+	AlbumID          *int32             `db:"album_id" json:"album_id"`
+	TagName          *string            `db:"tag_name" json:"tag_name"`
+	TagSource        *string            `db:"tag_source" json:"tag_source"`
+	TagNames         []string           `db:"tag_names" json:"tag_names"`
+	FilenameVal      *string            `db:"filename_val" json:"filename_val"`
+	FilenameOperator *string            `db:"filename_operator" json:"filename_operator"`
+	DateFrom         pgtype.Timestamptz `db:"date_from" json:"date_from"`
+	DateTo           pgtype.Timestamptz `db:"date_to" json:"date_to"`
+	IsRaw            *bool              `db:"is_raw" json:"is_raw"`
+	Rating           *int32             `db:"rating" json:"rating"`
+	Liked            *bool              `db:"liked" json:"liked"`
+	CameraModel      *string            `db:"camera_model" json:"camera_model"`
+	LensModel        *string            `db:"lens_model" json:"lens_model"`
+	CustomFields     []byte             `db:"custom_fields" json:"custom_fields"`
+	ProcessingStatus *string            `db:"processing_status" json:"processing_status"`
+	LocationNorth    *float64           `db:"location_north" json:"location_north"`
+	LocationSouth    *float64           `db:"location_south" json:"location_south"`
+	LocationEast     *float64           `db:"location_east" json:"location_east"`
+	LocationWest     *float64           `db:"location_west" json:"location_west"`
+}
+
+type GetAssetNeighborsUnifiedRow struct {
+	PreviousID pgtype.UUID `db:"previous_id" json:"previous_id"`
+	NextID     pgtype.UUID `db:"next_id" json:"next_id"`
+}
+
+// Returns the assets either side of an anchor asset in GetAssetsUnified order
+// Keyset lookups on (sort_time, asset_id), so the anchor need not be paged in
+func (q *Queries) GetAssetNeighborsUnified(ctx context.Context, arg GetAssetNeighborsUnifiedParams) (GetAssetNeighborsUnifiedRow, error) {
+	row := q.db.QueryRow(ctx, getAssetNeighborsUnified,
+		arg.SortBy,
+		arg.AnchorID,
+		arg.IsDeleted,
+		arg.AssetIds,
+		arg.Query,
+		arg.AssetType,
+		arg.AssetTypes,
+		arg.OwnerID,
+		arg.RepositoryID,
+		arg.FolderPath,
+		arg.FolderRecursive,
+		arg.PersonID,
+		arg.AlbumID,
+		arg.TagName,
+		arg.TagSource,
+		arg.TagNames,
+		arg.FilenameVal,
+		arg.FilenameOperator,
+		arg.DateFrom,
+		arg.DateTo,
+		arg.IsRaw,
+		arg.Rating,
+		arg.Liked,
+		arg.CameraModel,
+		arg.LensModel,
+		arg.CustomFields,
+		arg.ProcessingStatus,
+		arg.LocationNorth,
+		arg.LocationSouth,
+		arg.LocationEast,
+		arg.LocationWest,
+	)
+	var i GetAssetNeighborsUnifiedRow
+	err := row.Scan(&i.PreviousID, &i.NextID)
+	return i, err
+}
+
+const getAssetStatsForOwner = `-- name: GetAssetStatsForOwner :one
+SELECT
+  COUNT(*) as total_assets,
+  COUNT(CASE WHEN liked = true THEN 1 END) as liked_count,
+  COUNT(CASE WHEN rating IS NOT NULL THEN 1 END) as rated_count,
+  AVG(rating) as avg_rating,
+  MAX(rating) as max_rating,
+  MIN(rating) as min_rating
+FROM assets
+WHERE is_deleted = false
+  AND owner_id = $1::integer
+`
+
+type GetAssetStatsForOwnerRow struct {
+	TotalAssets int64       `db:"total_assets" json:"total_assets"`
+	LikedCount  int64       `db:"liked_count" json:"liked_count"`
+	RatedCount  int64       `db:"rated_count" json:"rated_count"`
+	AvgRating   float64     `db:"avg_rating" json:"avg_rating"`
+	MaxRating   interface{} `db:"max_rating" json:"max_rating"`
+	MinRating   interface{} `db:"min_rating" json:"min_rating"`
+}
+
+func (q *Queries) GetAssetStatsForOwner(ctx context.Context, ownerID int32) (GetAssetStatsForOwnerRow, error) {
+	row := q.db.QueryRow(ctx, getAssetStatsForOwner, ownerID)
+	var i GetAssetStatsForOwnerRow
+	err := row.Scan(
+		&i.TotalAssets,
+		&i.LikedCount,
+		&i.RatedCount,
+		&i.AvgRating,
+		&i.MaxRating,
+		&i.MinRating,
+	)
+	return i, err
+}
+
+const getAssetsByContentHash = `-- name: GetAssetsByContentHash :many
+SELECT asset_id, owner_id, type, original_filename, storage_path, mime_type, file_size, content_hash, quick_fingerprint, quick_fingerprint_version, width, height, duration, upload_time, taken_time, capture_offset_minutes, is_deleted, deleted_at, specific_metadata, rating, liked, repository_id, status, updated_at, gps_latitude, gps_longitude, gps_geohash_5, gps_geohash_7, exif_raw FROM assets
+WHERE content_hash = $1 AND is_deleted = false
+`
+
+func (q *Queries) GetAssetsByContentHash(ctx context.Context, contentHash string) ([]Asset, error) {
+	rows, err := q.db.Query(ctx, getAssetsByContentHash, contentHash)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Asset
+	for rows.Next() {
+		var i Asset
+		if err := rows.Scan(
+			&i.AssetID,
+			&i.OwnerID,
+			&i.Type,
+			&i.OriginalFilename,
+			&i.StoragePath,
+			&i.MimeType,
+			&i.FileSize,
+			&i.ContentHash,
+			&i.QuickFingerprint,
+			&i.QuickFingerprintVersion,
+			&i.Width,
+			&i.Height,
+			&i.Duration,
+			&i.UploadTime,
+			&i.TakenTime,
+			&i.CaptureOffsetMinutes,
+			&i.IsDeleted,
+			&i.DeletedAt,
+			&i.SpecificMetadata,
+			&i.Rating,
 			&i.Liked,
 			&i.RepositoryID,
 			&i.Status,
@@ -1263,7 +1808,7 @@ func (q *Queries) GetAssetsByContentHash(ctx context.Context, contentHash string
 }
 
 const getAssetsByContentHashesAndRepository = `-- name: GetAssetsByContentHashesAndRepository :many
-SELECT asset_id, content_hash, file_size, original_filename FROM assets
+SELECT asset_id, content_hash, file_size, original_filename, owner_id FROM assets
 WHERE content_hash = ANY($1::text[])
   AND repository_id = $2
   AND is_deleted = false
@@ -1279,6 +1824,7 @@ type GetAssetsByContentHashesAndRepositoryRow struct {
 	ContentHash      string      `db:"content_hash" json:"content_hash"`
 	FileSize         int64       `db:"file_size" json:"file_size"`
 	OriginalFilename string      `db:"original_filename" json:"original_filename"`
+	OwnerID          *int32      `db:"owner_id" json:"owner_id"`
 }
 
 func (q *Queries) GetAssetsByContentHashesAndRepository(ctx context.Context, arg GetAssetsByContentHashesAndRepositoryParams) ([]GetAssetsByContentHashesAndRepositoryRow, error) {
@@ -1295,6 +1841,7 @@ func (q *Queries) GetAssetsByContentHashesAndRepository(ctx context.Context, arg
 			&i.ContentHash,
 			&i.FileSize,
 			&i.OriginalFilename,
+			&i.OwnerID,
 		); err != nil {
 			return nil, err
 		}
@@ -2411,31 +2958,52 @@ WITH page_ids AS MATERIALIZED (
     AND ($23::text IS NULL OR a.specific_metadata->>'camera_model' = $23)
     AND ($24::text IS NULL OR a.specific_metadata->>'lens_model' = $24)
     AND (
-      $25::float8 IS NULL
-      OR $26::float8 IS NULL
-      OR $27::float8 IS NULL
+      $25::jsonb IS NULL
+      OR EXISTS (
+        SELECT 1
+        FROM asset_custom_fields acf
+        WHERE acf.asset_id = a.asset_id
+          AND acf.fields @> $25::jsonb
+      )
+    )
+    AND ($26::text IS NULL OR a.status->>'state' = $26)
+    AND (
+      $27::float8 IS NULL
       OR $28::float8 IS NULL
+      OR $29::float8 IS NULL
+      OR $30::float8 IS NULL
       OR (
         a.gps_latitude IS NOT NULL
         AND a.gps_longitude IS NOT NULL
         AND a.gps_latitude
-          BETWEEN LEAST($26::float8, $25::float8)
-          AND GREATEST($26::float8, $25::float8)
+          BETWEEN LEAST($28::float8, $27::float8)
+          AND GREATEST($28::float8, $27::float8)
         AND (
           CASE
-            WHEN $28::float8 <= $27::float8 THEN
-              a.gps_longitude BETWEEN $28::float8 AND $27::float8
+            WHEN $30::float8 <= $29::float8 THEN
+              a.gps_longitude BETWEEN $30::float8 AND $29::float8
             ELSE
-              a.gps_longitude >= $28::float8
-              OR a.gps_longitude <= $27::float8
+              a.gps_longitude >= $30::float8
+              OR a.gps_longitude <= $29::float8
           END
         )
       )
     )
+    -- Keyset continuation after the last row of a previous page
+    AND (
+      $31::timestamptz IS NULL
+      OR (
+        CASE
+          WHEN $1::text = 'recently_added' THEN a.upload_time
+          ELSE COALESCE(a.taken_time, a.upload_time)
+        END,
+        a.asset_id
+      ) < ($31::timestamptz, $32::uuid)
+    )
   ORDER BY
     sort_time DESC,
     a.asset_id DESC
-  LIMIT $30 OFFSET $29
+  LIMIT $34 OFFSET $33
 )
 SELECT a.asset_id, a.owner_id, a.type, a.original_filename, a.storage_path, a.mime_type, a.file_size, a.content_hash, a.quick_fingerprint, a.quick_fingerprint_version, a.width, a.height, a.duration, a.upload_time, a.taken_time, a.capture_offset_minutes, a.is_deleted, a.deleted_at, a.specific_metadata, a.rating, a.liked, a.repository_id, a.status, a.updated_at, a.gps_latitude, a.gps_longitude, a.gps_geohash_5, a.gps_geohash_7, a.exif_raw
 FROM page_ids p
@@ -2468,10 +3036,14 @@ type GetAssetsUnifiedParams struct {
 	Liked            *bool              `db:"liked" json:"liked"`
 	CameraModel      *string            `db:"camera_model" json:"camera_model"`
 	LensModel        *string            `db:"lens_model" json:"lens_model"`
+	CustomFields     []byte             `db:"custom_fields" json:"custom_fields"`
+	ProcessingStatus *string            `db:"processing_status" json:"processing_status"`
 	LocationNorth    *float64           `db:"location_north" json:"location_north"`
 	LocationSouth    *float64           `db:"location_south" json:"location_south"`
 	LocationEast     *float64           `db:"location_east" json:"location_east"`
 	LocationWest     *float64           `db:"location_west" json:"location_west"`
+	CursorTime       pgtype.Timestamptz `db:"cursor_time" json:"cursor_time"`
+	CursorID         pgtype.UUID        `db:"cursor_id" json:"cursor_id"`
 	Offset           int32              `db:"offset" json:"offset"`
 	Limit            int32              `db:"limit" json:"limit"`
 }
@@ -2504,10 +3076,14 @@ func (q *Queries) GetAssetsUnified(ctx context.Context, arg GetAssetsUnifiedPara
 		arg.Liked,
 		arg.CameraModel,
 		arg.LensModel,
+		arg.CustomFields,
+		arg.ProcessingStatus,
 		arg.LocationNorth,
 		arg.LocationSouth,
 		arg.LocationEast,
 		arg.LocationWest,
+		arg.CursorTime,
+		arg.CursorID,
 		arg.Offset,
 		arg.Limit,
 	)
@@ -2790,23 +3366,33 @@ WITH filtered AS MATERIALIZED (
     AND ($22::text IS NULL OR a.specific_metadata->>'camera_model' = $22)
     AND ($23::text IS NULL OR a.specific_metadata->>'lens_model' = $23)
     AND (
-      $24::float8 IS NULL
-      OR $25::float8 IS NULL
-      OR $26::float8 IS NULL
+      $24::jsonb IS NULL
+      OR EXISTS (
+        SELECT 1
+        FROM asset_custom_fields acf
+        WHERE acf.asset_id = a.asset_id
+          AND acf.fields @> $24::jsonb
+      )
+    )
+    AND ($25::text IS NULL OR a.status->>'state' = $25)
+    AND (
+      $26::float8 IS NULL
       OR $27::float8 IS NULL
+      OR $28::float8 IS NULL
+      OR $29::float8 IS NULL
       OR (
         a.gps_latitude IS NOT NULL
         AND a.gps_longitude IS NOT NULL
         AND a.gps_latitude
-          BETWEEN LEAST($25::float8, $24::float8)
-          AND GREATEST($25::float8, $24::float8)
+          BETWEEN LEAST($27::float8, $26::float8)
+          AND GREATEST($27::float8, $26::float8)
         AND (
           CASE
-            WHEN $27::float8 <= $26::float8 THEN
-              a.gps_longitude BETWEEN $27::float8 AND $26::float8
+            WHEN $29::float8 <= $28::float8 THEN
+              a.gps_longitude BETWEEN $29::float8 AND $28::float8
             ELSE
-              a.gps_longitude >= $27::float8
-              OR a.gps_longitude <= $26::float8
+              a.gps_longitude >= $29::float8
+              OR a.gps_longitude <= $28::float8
           END
         )
       )
@@ -2860,13 +3446,21 @@ paged AS (
     bi.member_asset_ids,
     bi.matched_asset_ids,
     CASE
-      WHEN $28::text = 'recently_added' THEN cover.upload_time
+      WHEN $30::text = 'recently_added' THEN cover.upload_time
       ELSE COALESCE(cover.taken_time, cover.upload_time)
     END AS sort_time
   FROM browse_items bi
   JOIN assets cover ON cover.asset_id = bi.cover_asset_id
+  WHERE $31::timestamptz IS NULL
+    OR (
+      CASE
+        WHEN $30::text = 'recently_added' THEN cover.upload_time
+        ELSE COALESCE(cover.taken_time, cover.upload_time)
+      END,
+      cover.asset_id
+    ) < ($31::timestamptz, $32::uuid)
   ORDER BY sort_time DESC, cover.asset_id DESC
-  LIMIT $30 OFFSET $29
+  LIMIT $34 OFFSET $33
 )
 SELECT
   p.item_type,
@@ -2904,11 +3498,15 @@ type GetCollapsedBrowseItemsUnifiedParams struct {
 	Liked            *bool              `db:"liked" json:"liked"`
 	CameraModel      *string            `db:"camera_model" json:"camera_model"`
 	LensModel        *string            `db:"lens_model" json:"lens_model"`
+	CustomFields     []byte             `db:"custom_fields" json:"custom_fields"`
+	ProcessingStatus *string            `db:"processing_status" json:"processing_status"`
 	LocationNorth    *float64           `db:"location_north" json:"location_north"`
 	LocationSouth    *float64           `db:"location_south" json:"location_south"`
 	LocationEast     *float64           `db:"location_east" json:"location_east"`
 	LocationWest     *float64           `db:"location_west" json:"location_west"`
 	SortBy           *string            `db:"sort_by" json:"sort_by"`
+	CursorTime       pgtype.Timestamptz `db:"cursor_time" json:"cursor_time"`
+	CursorID         pgtype.UUID        `db:"cursor_id" json:"cursor_id"`
 	Offset           int32              `db:"offset" json:"offset"`
 	Limit            int32              `db:"limit" json:"limit"`
 }
@@ -2947,11 +3545,15 @@ func (q *Queries) GetCollapsedBrowseItemsUnified(ctx context.Context, arg GetCol
 		arg.Liked,
 		arg.CameraModel,
 		arg.LensModel,
+		arg.CustomFields,
+		arg.ProcessingStatus,
 		arg.LocationNorth,
 		arg.LocationSouth,
 		arg.LocationEast,
 		arg.LocationWest,
 		arg.SortBy,
+		arg.CursorTime,
+		arg.CursorID,
 		arg.Offset,
 		arg.Limit,
 	)
@@ -3367,10 +3969,10 @@ SELECT
   COUNT(CASE WHEN type = 'AUDIO' THEN 1 END) as audio_count,
   COUNT(CASE WHEN liked = true THEN 1 END) as liked_count,
   COUNT(CASE WHEN rating IS NOT NULL THEN 1 END) as rated_count,
-  AVG(rating) as avg_rating,
-  SUM(file_size) as total_size,
-  MIN(upload_time) as oldest_upload,
-  MAX(upload_time) as newest_upload
+  COALESCE(AVG(rating), 0)::float8 as avg_rating,
+  COALESCE(SUM(file_size), 0)::bigint as total_size,
+  MIN(upload_time)::timestamptz as oldest_upload,
+  MAX(upload_time)::timestamptz as newest_upload
 FROM assets
 WHERE is_deleted = false
   AND repository_id = $1::uuid
@@ -3383,16 +3985,16 @@ type GetRepositoryAssetStatsParams struct {
 }
 
 type GetRepositoryAssetStatsRow struct {
-	TotalAssets  int64       `db:"total_assets" json:"total_assets"`
-	PhotoCount   int64       `db:"photo_count" json:"photo_count"`
-	VideoCount   int64       `db:"video_count" json:"video_count"`
-	AudioCount   int64       `db:"audio_count" json:"audio_count"`
-	LikedCount   int64       `db:"liked_count" json:"liked_count"`
-	RatedCount   int64       `db:"rated_count" json:"rated_count"`
-	AvgRating    float64     `db:"avg_rating" json:"avg_rating"`
-	TotalSize    int64       `db:"total_size" json:"total_size"`
-	OldestUpload interface{} `db:"oldest_upload" json:"oldest_upload"`
-	NewestUpload interface{} `db:"newest_upload" json:"newest_upload"`
+	TotalAssets  int64              `db:"total_assets" json:"total_assets"`
+	PhotoCount   int64              `db:"photo_count" json:"photo_count"`
+	VideoCount   int64              `db:"video_count" json:"video_count"`
+	AudioCount   int64              `db:"audio_count" json:"audio_count"`
+	LikedCount   int64              `db:"liked_count" json:"liked_count"`
+	RatedCount   int64              `db:"rated_count" json:"rated_count"`
+	AvgRating    float64            `db:"avg_rating" json:"avg_rating"`
+	TotalSize    int64              `db:"total_size" json:"total_size"`
+	OldestUpload pgtype.Timestamptz `db:"oldest_upload" json:"oldest_upload"`
+	NewestUpload pgtype.Timestamptz `db:"newest_upload" json:"newest_upload"`
 }
 
 // Repository Asset Statistics (kept for repository management)
@@ -3415,7 +4017,7 @@ func (q *Queries) GetRepositoryAssetStats(ctx context.Context, arg GetRepository
 }
 
 const getThumbnailByAssetAndSize = `-- name: GetThumbnailByAssetAndSize :one
-SELECT thumbnail_id, asset_id, size, storage_path, mime_type, created_at FROM thumbnails
+SELECT thumbnail_id, asset_id, size, storage_path, mime_type, created_at, width, height FROM thumbnails
 WHERE asset_id = $1 AND size = $2
 `
 
@@ -3434,12 +4036,14 @@ func (q *Queries) GetThumbnailByAssetAndSize(ctx context.Context, arg GetThumbna
 		&i.StoragePath,
 		&i.MimeType,
 		&i.CreatedAt,
+		&i.Width,
+		&i.Height,
 	)
 	return i, err
 }
 
 const getThumbnailByID = `-- name: GetThumbnailByID :one
-SELECT thumbnail_id, asset_id, size, storage_path, mime_type, created_at FROM thumbnails WHERE thumbnail_id = $1
+SELECT thumbnail_id, asset_id, size, storage_path, mime_type, created_at, width, height FROM thumbnails WHERE thumbnail_id = $1
 `
 
 func (q *Queries) GetThumbnailByID(ctx context.Context, thumbnailID int32) (Thumbnail, error) {
@@ -3452,12 +4056,14 @@ func (q *Queries) GetThumbnailByID(ctx context.Context, thumbnailID int32) (Thum
 		&i.StoragePath,
 		&i.MimeType,
 		&i.CreatedAt,
+		&i.Width,
+		&i.Height,
 	)
 	return i, err
 }
 
 const getThumbnailsByAsset = `-- name: GetThumbnailsByAsset :many
-SELECT thumbnail_id, asset_id, size, storage_path, mime_type, created_at FROM thumbnails
+SELECT thumbnail_id, asset_id, size, storage_path, mime_type, created_at, width, height FROM thumbnails
 WHERE asset_id = $1
 ORDER BY CASE size
     WHEN 'small' THEN 1
@@ -3482,6 +4088,8 @@ func (q *Queries) GetThumbnailsByAsset(ctx context.Context, assetID pgtype.UUID)
 			&i.StoragePath,
 			&i.MimeType,
 			&i.CreatedAt,
+			&i.Width,
+			&i.Height,
 		); err != nil {
 			return nil, err
 		}
@@ -3565,9 +4173,83 @@ func (q *Queries) GetTopRatedAssets(ctx context.Context, arg GetTopRatedAssetsPa
 	return items, nil
 }
 
-const listAssetsByRepositoryAny = `-- name: ListAssetsByRepositoryAny :many
-SELECT asset_id, owner_id, type, original_filename, storage_path, mime_type, file_size, content_hash, quick_fingerprint, quick_fingerprint_version, width, height, duration, upload_time, taken_time, capture_offset_minutes, is_deleted, deleted_at, specific_metadata, rating, liked, repository_id, status, updated_at, gps_latitude, gps_longitude, gps_geohash_5, gps_geohash_7, exif_raw FROM assets
-WHERE repository_id = $1
+const listAssetsAroundTime = `-- name: ListAssetsAroundTime :many
+SELECT a.asset_id, a.owner_id, a.type, a.original_filename, a.storage_path, a.mime_type, a.file_size, a.content_hash, a.quick_fingerprint, a.quick_fingerprint_version, a.width, a.height, a.duration, a.upload_time, a.taken_time, a.capture_offset_minutes, a.is_deleted, a.deleted_at, a.specific_metadata, a.rating, a.liked, a.repository_id, a.status, a.updated_at, a.gps_latitude, a.gps_longitude, a.gps_geohash_5, a.gps_geohash_7, a.exif_raw FROM assets a
+WHERE a.is_deleted = false
+  AND a.taken_time BETWEEN $1::timestamptz AND $2::timestamptz
+  AND ($3::integer IS NULL OR a.owner_id = $3)
+ORDER BY abs(extract(epoch FROM a.taken_time - $4::timestamptz)), a.taken_time, a.asset_id
+LIMIT $5
+`
+
+type ListAssetsAroundTimeParams struct {
+	FromTime pgtype.Timestamptz `db:"from_time" json:"from_time"`
+	ToTime   pgtype.Timestamptz `db:"to_time" json:"to_time"`
+	OwnerID  *int32             `db:"owner_id" json:"owner_id"`
+	At       pgtype.Timestamptz `db:"at" json:"at"`
+	Limit    int32              `db:"limit" json:"limit"`
+}
+
+// Assets taken between from_time and to_time, closest to at first.
+func (q *Queries) ListAssetsAroundTime(ctx context.Context, arg ListAssetsAroundTimeParams) ([]Asset, error) {
+	rows, err := q.db.Query(ctx, listAssetsAroundTime,
+		arg.FromTime,
+		arg.ToTime,
+		arg.OwnerID,
+		arg.At,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Asset
+	for rows.Next() {
+		var i Asset
+		if err := rows.Scan(
+			&i.AssetID,
+			&i.OwnerID,
+			&i.Type,
+			&i.OriginalFilename,
+			&i.StoragePath,
+			&i.MimeType,
+			&i.FileSize,
+			&i.ContentHash,
+			&i.QuickFingerprint,
+			&i.QuickFingerprintVersion,
+			&i.Width,
+			&i.Height,
+			&i.Duration,
+			&i.UploadTime,
+			&i.TakenTime,
+			&i.CaptureOffsetMinutes,
+			&i.IsDeleted,
+			&i.DeletedAt,
+			&i.SpecificMetadata,
+			&i.Rating,
+			&i.Liked,
+			&i.RepositoryID,
+			&i.Status,
+			&i.UpdatedAt,
+			&i.GpsLatitude,
+			&i.GpsLongitude,
+			&i.GpsGeohash5,
+			&i.GpsGeohash7,
+			&i.ExifRaw,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAssetsByRepositoryAny = `-- name: ListAssetsByRepositoryAny :many
+SELECT asset_id, owner_id, type, original_filename, storage_path, mime_type, file_size, content_hash, quick_fingerprint, quick_fingerprint_version, width, height, duration, upload_time, taken_time, capture_offset_minutes, is_deleted, deleted_at, specific_metadata, rating, liked, repository_id, status, updated_at, gps_latitude, gps_longitude, gps_geohash_5, gps_geohash_7, exif_raw FROM assets
+WHERE repository_id = $1
   AND storage_path IS NOT NULL
 ORDER BY storage_path ASC
 `
@@ -3622,6 +4304,119 @@ func (q *Queries) ListAssetsByRepositoryAny(ctx context.Context, repositoryID pg
 	return items, nil
 }
 
+const listAssetsWithoutRepository = `-- name: ListAssetsWithoutRepository :many
+SELECT asset_id, storage_path, content_hash, file_size FROM assets
+WHERE repository_id IS NULL
+ORDER BY asset_id
+`
+
+type ListAssetsWithoutRepositoryRow struct {
+	AssetID     pgtype.UUID `db:"asset_id" json:"asset_id"`
+	StoragePath *string     `db:"storage_path" json:"storage_path"`
+	ContentHash string      `db:"content_hash" json:"content_hash"`
+	FileSize    int64       `db:"file_size" json:"file_size"`
+}
+
+func (q *Queries) ListAssetsWithoutRepository(ctx context.Context) ([]ListAssetsWithoutRepositoryRow, error) {
+	rows, err := q.db.Query(ctx, listAssetsWithoutRepository)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListAssetsWithoutRepositoryRow
+	for rows.Next() {
+		var i ListAssetsWithoutRepositoryRow
+		if err := rows.Scan(
+			&i.AssetID,
+			&i.StoragePath,
+			&i.ContentHash,
+			&i.FileSize,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUnalbumedAssets = `-- name: ListUnalbumedAssets :many
+SELECT a.asset_id, a.owner_id, a.type, a.original_filename, a.storage_path, a.mime_type, a.file_size, a.content_hash, a.quick_fingerprint, a.quick_fingerprint_version, a.width, a.height, a.duration, a.upload_time, a.taken_time, a.capture_offset_minutes, a.is_deleted, a.deleted_at, a.specific_metadata, a.rating, a.liked, a.repository_id, a.status, a.updated_at, a.gps_latitude, a.gps_longitude, a.gps_geohash_5, a.gps_geohash_7, a.exif_raw FROM assets a
+WHERE a.is_deleted = false
+  AND ($1::integer IS NULL OR a.owner_id = $1)
+  AND ($2::uuid IS NULL OR a.repository_id = $2)
+  AND NOT EXISTS (
+    SELECT 1 FROM album_assets aa WHERE aa.asset_id = a.asset_id
+  )
+ORDER BY a.upload_time DESC, a.asset_id DESC
+LIMIT $4 OFFSET $3
+`
+
+type ListUnalbumedAssetsParams struct {
+	OwnerID      *int32      `db:"owner_id" json:"owner_id"`
+	RepositoryID pgtype.UUID `db:"repository_id" json:"repository_id"`
+	Offset       int32       `db:"offset" json:"offset"`
+	Limit        int32       `db:"limit" json:"limit"`
+}
+
+// Assets that belong to no album, newest upload first.
+func (q *Queries) ListUnalbumedAssets(ctx context.Context, arg ListUnalbumedAssetsParams) ([]Asset, error) {
+	rows, err := q.db.Query(ctx, listUnalbumedAssets,
+		arg.OwnerID,
+		arg.RepositoryID,
+		arg.Offset,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Asset
+	for rows.Next() {
+		var i Asset
+		if err := rows.Scan(
+			&i.AssetID,
+			&i.OwnerID,
+			&i.Type,
+			&i.OriginalFilename,
+			&i.StoragePath,
+			&i.MimeType,
+			&i.FileSize,
+			&i.ContentHash,
+			&i.QuickFingerprint,
+			&i.QuickFingerprintVersion,
+			&i.Width,
+			&i.Height,
+			&i.Duration,
+			&i.UploadTime,
+			&i.TakenTime,
+			&i.CaptureOffsetMinutes,
+			&i.IsDeleted,
+			&i.DeletedAt,
+			&i.SpecificMetadata,
+			&i.Rating,
+			&i.Liked,
+			&i.RepositoryID,
+			&i.Status,
+			&i.UpdatedAt,
+			&i.GpsLatitude,
+			&i.GpsLongitude,
+			&i.GpsGeohash5,
+			&i.GpsGeohash7,
+			&i.ExifRaw,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const moveAssetWithinRepository = `-- name: MoveAssetWithinRepository :one
 UPDATE assets
 SET
@@ -3683,6 +4478,22 @@ func (q *Queries) MoveAssetWithinRepository(ctx context.Context, arg MoveAssetWi
 	return i, err
 }
 
+const purgeAsset = `-- name: PurgeAsset :execrows
+DELETE FROM assets
+WHERE asset_id = $1
+`
+
+// Removes the asset row itself. Thumbnails, tag links and album links do not
+// cascade and must be deleted first; every other reference cascades or is
+// set to NULL.
+func (q *Queries) PurgeAsset(ctx context.Context, assetID pgtype.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, purgeAsset, assetID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const removeAssetFromAlbum = `-- name: RemoveAssetFromAlbum :exec
 DELETE FROM album_assets
 WHERE asset_id = $1 AND album_id = $2
@@ -3698,6 +4509,16 @@ func (q *Queries) RemoveAssetFromAlbum(ctx context.Context, arg RemoveAssetFromA
 	return err
 }
 
+const removeAssetFromAllAlbums = `-- name: RemoveAssetFromAllAlbums :exec
+DELETE FROM album_assets
+WHERE asset_id = $1
+`
+
+func (q *Queries) RemoveAssetFromAllAlbums(ctx context.Context, assetID pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, removeAssetFromAllAlbums, assetID)
+	return err
+}
+
 const removeAssetTagsBySources = `-- name: RemoveAssetTagsBySources :exec
 DELETE FROM asset_tags
 WHERE asset_id = $1
@@ -3777,15 +4598,18 @@ func (q *Queries) ResetAssetStatusForRetry(ctx context.Context, assetID pgtype.U
 	return i, err
 }
 
-const restoreAsset = `-- name: RestoreAsset :exec
+const restoreAsset = `-- name: RestoreAsset :execrows
 UPDATE assets
 SET is_deleted = false, deleted_at = NULL
-WHERE asset_id = $1
+WHERE asset_id = $1 AND is_deleted = true
 `
 
-func (q *Queries) RestoreAsset(ctx context.Context, assetID pgtype.UUID) error {
-	_, err := q.db.Exec(ctx, restoreAsset, assetID)
-	return err
+func (q *Queries) RestoreAsset(ctx context.Context, assetID pgtype.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, restoreAsset, assetID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
 }
 
 const searchAssets = `-- name: SearchAssets :many
@@ -3859,12 +4683,104 @@ func (q *Queries) SearchAssets(ctx context.Context, arg SearchAssetsParams) ([]A
 	return items, nil
 }
 
+const searchAssetsByTags = `-- name: SearchAssetsByTags :many
+SELECT a.asset_id, a.owner_id, a.type, a.original_filename, a.storage_path, a.mime_type, a.file_size, a.content_hash, a.quick_fingerprint, a.quick_fingerprint_version, a.width, a.height, a.duration, a.upload_time, a.taken_time, a.capture_offset_minutes, a.is_deleted, a.deleted_at, a.specific_metadata, a.rating, a.liked, a.repository_id, a.status, a.updated_at, a.gps_latitude, a.gps_longitude, a.gps_geohash_5, a.gps_geohash_7, a.exif_raw FROM assets a
+WHERE a.is_deleted = false
+  AND ($1::integer IS NULL OR a.owner_id = $1)
+  AND ($2::uuid IS NULL OR a.repository_id = $2)
+  AND (
+    SELECT COUNT(DISTINCT lower(t.tag_name))
+    FROM asset_tags at
+    JOIN tags t ON t.tag_id = at.tag_id
+    WHERE at.asset_id = a.asset_id
+      AND lower(t.tag_name) = ANY($3::text[])
+      AND (cardinality($4::text[]) = 0 OR at.source = ANY($4::text[]))
+      AND at.confidence >= $5::float8
+  ) >= CASE WHEN $6::boolean THEN cardinality($3::text[]) ELSE 1 END
+ORDER BY COALESCE(a.taken_time, a.upload_time) DESC, a.asset_id DESC
+LIMIT $8 OFFSET $7
+`
+
+type SearchAssetsByTagsParams struct {
+	OwnerID       *int32      `db:"owner_id" json:"owner_id"`
+	RepositoryID  pgtype.UUID `db:"repository_id" json:"repository_id"`
+	TagNames      []string    `db:"tag_names" json:"tag_names"`
+	Sources       []string    `db:"sources" json:"sources"`
+	MinConfidence float64     `db:"min_confidence" json:"min_confidence"`
+	MatchAll      bool        `db:"match_all" json:"match_all"`
+	Offset        int32       `db:"offset" json:"offset"`
+	Limit         int32       `db:"limit" json:"limit"`
+}
+
+// Assets carrying all (match_all) or any of tag_names, lower-cased, newest capture first.
+func (q *Queries) SearchAssetsByTags(ctx context.Context, arg SearchAssetsByTagsParams) ([]Asset, error) {
+	rows, err := q.db.Query(ctx, searchAssetsByTags,
+		arg.OwnerID,
+		arg.RepositoryID,
+		arg.TagNames,
+		arg.Sources,
+		arg.MinConfidence,
+		arg.MatchAll,
+		arg.Offset,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Asset
+	for rows.Next() {
+		var i Asset
+		if err := rows.Scan(
+			&i.AssetID,
+			&i.OwnerID,
+			&i.Type,
+			&i.OriginalFilename,
+			&i.StoragePath,
+			&i.MimeType,
+			&i.FileSize,
+			&i.ContentHash,
+			&i.QuickFingerprint,
+			&i.QuickFingerprintVersion,
+			&i.Width,
+			&i.Height,
+			&i.Duration,
+			&i.UploadTime,
+			&i.TakenTime,
+			&i.CaptureOffsetMinutes,
+			&i.IsDeleted,
+			&i.DeletedAt,
+			&i.SpecificMetadata,
+			&i.Rating,
+			&i.Liked,
+			&i.RepositoryID,
+			&i.Status,
+			&i.UpdatedAt,
+			&i.GpsLatitude,
+			&i.GpsLongitude,
+			&i.GpsGeohash5,
+			&i.GpsGeohash7,
+			&i.ExifRaw,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const softDeleteAssetByRepositoryAndStoragePath = `-- name: SoftDeleteAssetByRepositoryAndStoragePath :execrows
 UPDATE assets
 SET is_deleted = true, deleted_at = CURRENT_TIMESTAMP
 WHERE repository_id = $1
   AND storage_path = $2
   AND is_deleted = false
+  AND NOT EXISTS (
+      SELECT 1 FROM archived_originals ao WHERE ao.asset_id = assets.asset_id
+  )
 `
 
 type SoftDeleteAssetByRepositoryAndStoragePathParams struct {
@@ -3872,6 +4788,8 @@ type SoftDeleteAssetByRepositoryAndStoragePathParams struct {
 	StoragePath  *string     `db:"storage_path" json:"storage_path"`
 }
 
+// Assets whose original was archived are kept: their file left the
+// repository on purpose.
 func (q *Queries) SoftDeleteAssetByRepositoryAndStoragePath(ctx context.Context, arg SoftDeleteAssetByRepositoryAndStoragePathParams) (int64, error) {
 	result, err := q.db.Exec(ctx, softDeleteAssetByRepositoryAndStoragePath, arg.RepositoryID, arg.StoragePath)
 	if err != nil {
@@ -3930,6 +4848,60 @@ func (q *Queries) UpdateAsset(ctx context.Context, arg UpdateAssetParams) (Asset
 	return i, err
 }
 
+const updateAssetBlurHash = `-- name: UpdateAssetBlurHash :exec
+UPDATE assets
+SET specific_metadata = jsonb_set(
+    COALESCE(specific_metadata, '{}'::jsonb),
+    '{blurhash}',
+    to_jsonb($1::text)
+)
+WHERE asset_id = $2
+`
+
+type UpdateAssetBlurHashParams struct {
+	Blurhash string      `db:"blurhash" json:"blurhash"`
+	AssetID  pgtype.UUID `db:"asset_id" json:"asset_id"`
+}
+
+func (q *Queries) UpdateAssetBlurHash(ctx context.Context, arg UpdateAssetBlurHashParams) error {
+	_, err := q.db.Exec(ctx, updateAssetBlurHash, arg.Blurhash, arg.AssetID)
+	return err
+}
+
+const updateAssetContent = `-- name: UpdateAssetContent :exec
+UPDATE assets
+SET file_size = $2,
+    content_hash = $3,
+    quick_fingerprint = $4,
+    quick_fingerprint_version = $5,
+    storage_path = $6,
+    updated_at = NOW()
+WHERE asset_id = $1
+`
+
+type UpdateAssetContentParams struct {
+	AssetID                 pgtype.UUID `db:"asset_id" json:"asset_id"`
+	FileSize                int64       `db:"file_size" json:"file_size"`
+	ContentHash             string      `db:"content_hash" json:"content_hash"`
+	QuickFingerprint        *string     `db:"quick_fingerprint" json:"quick_fingerprint"`
+	QuickFingerprintVersion *string     `db:"quick_fingerprint_version" json:"quick_fingerprint_version"`
+	StoragePath             *string     `db:"storage_path" json:"storage_path"`
+}
+
+// Records an original rewritten in place. updated_at is set here although the
+// trigger would: the scanner treats files modified after it as changed.
+func (q *Queries) UpdateAssetContent(ctx context.Context, arg UpdateAssetContentParams) error {
+	_, err := q.db.Exec(ctx, updateAssetContent,
+		arg.AssetID,
+		arg.FileSize,
+		arg.ContentHash,
+		arg.QuickFingerprint,
+		arg.QuickFingerprintVersion,
+		arg.StoragePath,
+	)
+	return err
+}
+
 const updateAssetDescription = `-- name: UpdateAssetDescription :exec
 UPDATE assets
 SET specific_metadata = jsonb_set(
@@ -3950,6 +4922,30 @@ func (q *Queries) UpdateAssetDescription(ctx context.Context, arg UpdateAssetDes
 	return err
 }
 
+const restoreAssetDescription = `-- name: RestoreAssetDescription :exec
+UPDATE assets
+SET specific_metadata = jsonb_set(
+    COALESCE(specific_metadata, '{}'::jsonb),
+    '{description}',
+    to_jsonb($1::text)
+)
+WHERE asset_id = $2
+  AND COALESCE(specific_metadata->>'description', '') = $3::text
+`
+
+type RestoreAssetDescriptionParams struct {
+	Description string      `db:"description" json:"description"`
+	AssetID     pgtype.UUID `db:"asset_id" json:"asset_id"`
+	Expected    string      `db:"expected" json:"expected"`
+}
+
+// Puts back a description that re-extracting metadata replaced, but only while
+// the description is still the extracted one, so a newer edit is kept.
+func (q *Queries) RestoreAssetDescription(ctx context.Context, arg RestoreAssetDescriptionParams) error {
+	_, err := q.db.Exec(ctx, restoreAssetDescription, arg.Description, arg.AssetID, arg.Expected)
+	return err
+}
+
 const updateAssetDimensions = `-- name: UpdateAssetDimensions :exec
 UPDATE assets
 SET width = $2, height = $3
@@ -4017,7 +5013,14 @@ func (q *Queries) UpdateAssetMetadata(ctx context.Context, arg UpdateAssetMetada
 
 const updateAssetMetadataWithTakenTime = `-- name: UpdateAssetMetadataWithTakenTime :exec
 UPDATE assets
-SET specific_metadata = $1,
+SET specific_metadata = CASE
+        WHEN jsonb_typeof($1) = 'object'
+        THEN $1 || jsonb_strip_nulls(jsonb_build_object(
+            'blurhash', specific_metadata -> 'blurhash',
+            'hash_verified', specific_metadata -> 'hash_verified'
+        ))
+        ELSE $1
+    END,
     exif_raw = COALESCE($2::jsonb, exif_raw),
     taken_time = CASE
         WHEN $3::timestamptz IS NOT NULL THEN $3::timestamptz
@@ -4066,6 +5069,9 @@ type UpdateAssetMetadataWithTakenTimeParams struct {
 	AssetID              pgtype.UUID              `db:"asset_id" json:"asset_id"`
 }
 
+// Replaces the metadata but keeps a blurhash the thumbnail task stored,
+// which runs concurrently with metadata extraction, and the hash_verified
+// flag recorded at ingest.
 func (q *Queries) UpdateAssetMetadataWithTakenTime(ctx context.Context, arg UpdateAssetMetadataWithTakenTimeParams) error {
 	_, err := q.db.Exec(ctx, updateAssetMetadataWithTakenTime,
 		arg.SpecificMetadata,
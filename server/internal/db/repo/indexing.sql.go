@@ -128,6 +128,62 @@ func (q *Queries) CountPhotoAssetsWithSemanticEmbedding(ctx context.Context, rep
 	return count, err
 }
 
+const countPhotoAssetsForReindex = `-- name: CountPhotoAssetsForReindex :one
+SELECT COUNT(*) AS count
+FROM assets a
+WHERE a.type = 'PHOTO'
+  AND a.is_deleted = false
+  AND ($1::uuid IS NULL OR a.repository_id = $1)
+  AND ($2::timestamptz IS NULL OR COALESCE(a.taken_time, a.upload_time) >= $2)
+  AND ($3::timestamptz IS NULL OR COALESCE(a.taken_time, a.upload_time) <= $3)
+  AND (
+    NOT $4::boolean
+    OR ($5::boolean AND NOT EXISTS (
+      SELECT 1
+      FROM search_embeddings se
+      WHERE se.asset_id = a.asset_id
+        AND se.frame_ts_ms IS NULL
+    ))
+    OR ($6::boolean AND NOT EXISTS (
+      SELECT 1
+      FROM ocr_results o
+      WHERE o.asset_id = a.asset_id
+    ))
+    OR ($7::boolean AND NOT EXISTS (
+      SELECT 1
+      FROM face_results f
+      WHERE f.asset_id = a.asset_id
+    ))
+  )
+`
+
+type CountPhotoAssetsForReindexParams struct {
+	RepositoryID pgtype.UUID        `db:"repository_id" json:"repository_id"`
+	DateFrom     pgtype.Timestamptz `db:"date_from" json:"date_from"`
+	DateTo       pgtype.Timestamptz `db:"date_to" json:"date_to"`
+	MissingOnly  bool               `db:"missing_only" json:"missing_only"`
+	Semantic     bool               `db:"semantic" json:"semantic"`
+	Ocr          bool               `db:"ocr" json:"ocr"`
+	Face         bool               `db:"face" json:"face"`
+}
+
+// Photos a reindex would visit. With missing_only, only those lacking the
+// result of at least one of the flagged tasks count.
+func (q *Queries) CountPhotoAssetsForReindex(ctx context.Context, arg CountPhotoAssetsForReindexParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countPhotoAssetsForReindex,
+		arg.RepositoryID,
+		arg.DateFrom,
+		arg.DateTo,
+		arg.MissingOnly,
+		arg.Semantic,
+		arg.Ocr,
+		arg.Face,
+	)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const listPhotoAssetsForIndexingBatch = `-- name: ListPhotoAssetsForIndexingBatch :many
 WITH page_ids AS MATERIALIZED (
   SELECT
@@ -137,6 +193,8 @@ WITH page_ids AS MATERIALIZED (
   WHERE a.type = 'PHOTO'
     AND a.is_deleted = false
     AND ($1::uuid IS NULL OR a.repository_id = $1)
+    AND ($4::timestamptz IS NULL OR COALESCE(a.taken_time, a.upload_time) >= $4)
+    AND ($5::timestamptz IS NULL OR COALESCE(a.taken_time, a.upload_time) <= $5)
   ORDER BY COALESCE(a.taken_time, a.upload_time) DESC, a.asset_id DESC
   LIMIT $3
   OFFSET $2
@@ -148,13 +206,21 @@ ORDER BY p.sort_time DESC, p.asset_id DESC
 `
 
 type ListPhotoAssetsForIndexingBatchParams struct {
-	RepositoryID pgtype.UUID `db:"repository_id" json:"repository_id"`
-	Offset       int32       `db:"offset" json:"offset"`
-	Limit        int32       `db:"limit" json:"limit"`
+	RepositoryID pgtype.UUID        `db:"repository_id" json:"repository_id"`
+	Offset       int32              `db:"offset" json:"offset"`
+	Limit        int32              `db:"limit" json:"limit"`
+	DateFrom     pgtype.Timestamptz `db:"date_from" json:"date_from"`
+	DateTo       pgtype.Timestamptz `db:"date_to" json:"date_to"`
 }
 
 func (q *Queries) ListPhotoAssetsForIndexingBatch(ctx context.Context, arg ListPhotoAssetsForIndexingBatchParams) ([]Asset, error) {
-	rows, err := q.db.Query(ctx, listPhotoAssetsForIndexingBatch, arg.RepositoryID, arg.Offset, arg.Limit)
+	rows, err := q.db.Query(ctx, listPhotoAssetsForIndexingBatch,
+		arg.RepositoryID,
+		arg.Offset,
+		arg.Limit,
+		arg.DateFrom,
+		arg.DateTo,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -217,6 +283,8 @@ WITH page_ids AS MATERIALIZED (
       WHERE f.asset_id = a.asset_id
     )
     AND ($1::uuid IS NULL OR a.repository_id = $1)
+    AND ($4::timestamptz IS NULL OR COALESCE(a.taken_time, a.upload_time) >= $4)
+    AND ($5::timestamptz IS NULL OR COALESCE(a.taken_time, a.upload_time) <= $5)
   ORDER BY COALESCE(a.taken_time, a.upload_time) DESC, a.asset_id DESC
   LIMIT $3
   OFFSET $2
@@ -228,13 +296,21 @@ ORDER BY p.sort_time DESC, p.asset_id DESC
 `
 
 type ListPhotoAssetsMissingFaceResultsParams struct {
-	RepositoryID pgtype.UUID `db:"repository_id" json:"repository_id"`
-	Offset       int32       `db:"offset" json:"offset"`
-	Limit        int32       `db:"limit" json:"limit"`
+	RepositoryID pgtype.UUID        `db:"repository_id" json:"repository_id"`
+	Offset       int32              `db:"offset" json:"offset"`
+	Limit        int32              `db:"limit" json:"limit"`
+	DateFrom     pgtype.Timestamptz `db:"date_from" json:"date_from"`
+	DateTo       pgtype.Timestamptz `db:"date_to" json:"date_to"`
 }
 
 func (q *Queries) ListPhotoAssetsMissingFaceResults(ctx context.Context, arg ListPhotoAssetsMissingFaceResultsParams) ([]Asset, error) {
-	rows, err := q.db.Query(ctx, listPhotoAssetsMissingFaceResults, arg.RepositoryID, arg.Offset, arg.Limit)
+	rows, err := q.db.Query(ctx, listPhotoAssetsMissingFaceResults,
+		arg.RepositoryID,
+		arg.Offset,
+		arg.Limit,
+		arg.DateFrom,
+		arg.DateTo,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -297,6 +373,8 @@ WITH page_ids AS MATERIALIZED (
       WHERE o.asset_id = a.asset_id
     )
     AND ($1::uuid IS NULL OR a.repository_id = $1)
+    AND ($4::timestamptz IS NULL OR COALESCE(a.taken_time, a.upload_time) >= $4)
+    AND ($5::timestamptz IS NULL OR COALESCE(a.taken_time, a.upload_time) <= $5)
   ORDER BY COALESCE(a.taken_time, a.upload_time) DESC, a.asset_id DESC
   LIMIT $3
   OFFSET $2
@@ -308,13 +386,21 @@ ORDER BY p.sort_time DESC, p.asset_id DESC
 `
 
 type ListPhotoAssetsMissingOCRResultsParams struct {
-	RepositoryID pgtype.UUID `db:"repository_id" json:"repository_id"`
-	Offset       int32       `db:"offset" json:"offset"`
-	Limit        int32       `db:"limit" json:"limit"`
+	RepositoryID pgtype.UUID        `db:"repository_id" json:"repository_id"`
+	Offset       int32              `db:"offset" json:"offset"`
+	Limit        int32              `db:"limit" json:"limit"`
+	DateFrom     pgtype.Timestamptz `db:"date_from" json:"date_from"`
+	DateTo       pgtype.Timestamptz `db:"date_to" json:"date_to"`
 }
 
 func (q *Queries) ListPhotoAssetsMissingOCRResults(ctx context.Context, arg ListPhotoAssetsMissingOCRResultsParams) ([]Asset, error) {
-	rows, err := q.db.Query(ctx, listPhotoAssetsMissingOCRResults, arg.RepositoryID, arg.Offset, arg.Limit)
+	rows, err := q.db.Query(ctx, listPhotoAssetsMissingOCRResults,
+		arg.RepositoryID,
+		arg.Offset,
+		arg.Limit,
+		arg.DateFrom,
+		arg.DateTo,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -378,6 +464,8 @@ WITH page_ids AS MATERIALIZED (
         AND se.frame_ts_ms IS NULL
     )
     AND ($1::uuid IS NULL OR a.repository_id = $1)
+    AND ($4::timestamptz IS NULL OR COALESCE(a.taken_time, a.upload_time) >= $4)
+    AND ($5::timestamptz IS NULL OR COALESCE(a.taken_time, a.upload_time) <= $5)
   ORDER BY COALESCE(a.taken_time, a.upload_time) DESC, a.asset_id DESC
   LIMIT $3
   OFFSET $2
@@ -389,13 +477,21 @@ ORDER BY p.sort_time DESC, p.asset_id DESC
 `
 
 type ListPhotoAssetsMissingSemanticEmbeddingParams struct {
-	RepositoryID pgtype.UUID `db:"repository_id" json:"repository_id"`
-	Offset       int32       `db:"offset" json:"offset"`
-	Limit        int32       `db:"limit" json:"limit"`
+	RepositoryID pgtype.UUID        `db:"repository_id" json:"repository_id"`
+	Offset       int32              `db:"offset" json:"offset"`
+	Limit        int32              `db:"limit" json:"limit"`
+	DateFrom     pgtype.Timestamptz `db:"date_from" json:"date_from"`
+	DateTo       pgtype.Timestamptz `db:"date_to" json:"date_to"`
 }
 
 func (q *Queries) ListPhotoAssetsMissingSemanticEmbedding(ctx context.Context, arg ListPhotoAssetsMissingSemanticEmbeddingParams) ([]Asset, error) {
-	rows, err := q.db.Query(ctx, listPhotoAssetsMissingSemanticEmbedding, arg.RepositoryID, arg.Offset, arg.Limit)
+	rows, err := q.db.Query(ctx, listPhotoAssetsMissingSemanticEmbedding,
+		arg.RepositoryID,
+		arg.Offset,
+		arg.Limit,
+		arg.DateFrom,
+		arg.DateTo,
+	)
 	if err != nil {
 		return nil, err
 	}
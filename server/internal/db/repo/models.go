@@ -103,6 +103,16 @@ func (ns NullStackRelation) Value() (driver.Value, error) {
 	return string(ns.StackRelation), nil
 }
 
+type ActivityEvent struct {
+	EventID    int64              `db:"event_id" json:"event_id"`
+	EventType  string             `db:"event_type" json:"event_type"`
+	AssetID    pgtype.UUID        `db:"asset_id" json:"asset_id"`
+	AlbumID    *int32             `db:"album_id" json:"album_id"`
+	OwnerID    *int32             `db:"owner_id" json:"owner_id"`
+	Payload    []byte             `db:"payload" json:"payload"`
+	OccurredAt pgtype.Timestamptz `db:"occurred_at" json:"occurred_at"`
+}
+
 type AgentCheckpoint struct {
 	ID        string             `db:"id" json:"id"`
 	Data      []byte             `db:"data" json:"data"`
@@ -136,6 +146,8 @@ type Album struct {
 	Description  *string            `db:"description" json:"description"`
 	CoverAssetID pgtype.UUID        `db:"cover_asset_id" json:"cover_asset_id"`
 	AlbumType    AlbumType          `db:"album_type" json:"album_type"`
+	Rules        []byte             `db:"rules" json:"rules"`
+	CoverAuto    bool               `db:"cover_auto" json:"cover_auto"`
 }
 
 type AlbumAsset struct {
@@ -145,6 +157,12 @@ type AlbumAsset struct {
 	AddedTime pgtype.Timestamptz `db:"added_time" json:"added_time"`
 }
 
+type ArchivedOriginal struct {
+	AssetID     pgtype.UUID        `db:"asset_id" json:"asset_id"`
+	ArchivePath string             `db:"archive_path" json:"archive_path"`
+	ArchivedAt  pgtype.Timestamptz `db:"archived_at" json:"archived_at"`
+}
+
 type Asset struct {
 	AssetID                 pgtype.UUID              `db:"asset_id" json:"asset_id"`
 	OwnerID                 *int32                   `db:"owner_id" json:"owner_id"`
@@ -177,6 +195,12 @@ type Asset struct {
 	ExifRaw                 json.RawMessage          `db:"exif_raw" json:"exif_raw"`
 }
 
+type AssetCustomField struct {
+	AssetID   pgtype.UUID        `db:"asset_id" json:"asset_id"`
+	Fields    []byte             `db:"fields" json:"fields"`
+	UpdatedAt pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+}
+
 type AssetQualityScore struct {
 	AssetID      pgtype.UUID        `db:"asset_id" json:"asset_id"`
 	Score        float32            `db:"score" json:"score"`
@@ -185,6 +209,21 @@ type AssetQualityScore struct {
 	UpdatedAt    pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
 }
 
+type AssetRepositoryRemoval struct {
+	AssetID        pgtype.UUID        `db:"asset_id" json:"asset_id"`
+	RepositoryID   pgtype.UUID        `db:"repository_id" json:"repository_id"`
+	RepositoryName string             `db:"repository_name" json:"repository_name"`
+	RemovedAt      pgtype.Timestamptz `db:"removed_at" json:"removed_at"`
+}
+
+type AssetSizeMismatch struct {
+	AssetID      pgtype.UUID        `db:"asset_id" json:"asset_id"`
+	RepositoryID pgtype.UUID        `db:"repository_id" json:"repository_id"`
+	RecordedSize int64              `db:"recorded_size" json:"recorded_size"`
+	ActualSize   int64              `db:"actual_size" json:"actual_size"`
+	CheckedAt    pgtype.Timestamptz `db:"checked_at" json:"checked_at"`
+}
+
 type AssetStack struct {
 	StackID          pgtype.UUID        `db:"stack_id" json:"stack_id"`
 	OwnerID          *int32             `db:"owner_id" json:"owner_id"`
@@ -210,6 +249,13 @@ type AssetTag struct {
 	Source     string         `db:"source" json:"source"`
 }
 
+type AssetTaggingRetry struct {
+	AssetID       pgtype.UUID        `db:"asset_id" json:"asset_id"`
+	Attempts      int32              `db:"attempts" json:"attempts"`
+	LastAttemptAt pgtype.Timestamptz `db:"last_attempt_at" json:"last_attempt_at"`
+	ClipFailed    bool               `db:"clip_failed" json:"clip_failed"`
+}
+
 type ClassifierDefinition struct {
 	ID                  int32              `db:"id" json:"id"`
 	Slug                string             `db:"slug" json:"slug"`
@@ -604,6 +650,7 @@ type ShareLink struct {
 	RevokedAt        pgtype.Timestamptz `db:"revoked_at" json:"revoked_at"`
 	LastViewedAt     pgtype.Timestamptz `db:"last_viewed_at" json:"last_viewed_at"`
 	ViewCount        int64              `db:"view_count" json:"view_count"`
+	PasswordHash     *string            `db:"password_hash" json:"password_hash"`
 }
 
 type SpeciesPrediction struct {
@@ -615,9 +662,11 @@ type SpeciesPrediction struct {
 }
 
 type SystemState struct {
-	ID             int32              `db:"id" json:"id"`
-	BootstrapPhase string             `db:"bootstrap_phase" json:"bootstrap_phase"`
-	UpdatedAt      pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+	ID                 int32              `db:"id" json:"id"`
+	BootstrapPhase     string             `db:"bootstrap_phase" json:"bootstrap_phase"`
+	UpdatedAt          pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+	ProcessingPaused   bool               `db:"processing_paused" json:"processing_paused"`
+	ProcessingPausedAt pgtype.Timestamptz `db:"processing_paused_at" json:"processing_paused_at"`
 }
 
 type Tag struct {
@@ -634,6 +683,21 @@ type Thumbnail struct {
 	StoragePath string             `db:"storage_path" json:"storage_path"`
 	MimeType    string             `db:"mime_type" json:"mime_type"`
 	CreatedAt   pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	Width       *int32             `db:"width" json:"width"`
+	Height      *int32             `db:"height" json:"height"`
+}
+
+type UploadBatch struct {
+	BatchID     pgtype.UUID        `db:"batch_id" json:"batch_id"`
+	UserID      string             `db:"user_id" json:"user_id"`
+	Status      string             `db:"status" json:"status"`
+	CreatedAt   pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	CancelledAt pgtype.Timestamptz `db:"cancelled_at" json:"cancelled_at"`
+}
+
+type UploadBatchJob struct {
+	BatchID pgtype.UUID `db:"batch_id" json:"batch_id"`
+	JobID   int64       `db:"job_id" json:"job_id"`
 }
 
 type User struct {
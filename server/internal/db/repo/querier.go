@@ -16,6 +16,7 @@ type Querier interface {
 	AddAssetToAlbum(ctx context.Context, arg AddAssetToAlbumParams) error
 	AddStackMember(ctx context.Context, arg AddStackMemberParams) error
 	AddTagToAsset(ctx context.Context, arg AddTagToAssetParams) error
+	AddUploadBatchJob(ctx context.Context, arg AddUploadBatchJobParams) error
 	AdminUpdateUser(ctx context.Context, arg AdminUpdateUserParams) (User, error)
 	// Per-asset SigLIP aesthetic scores for a ref snapshot. Unscored assets are
 	// omitted; callers that filter by quality percentile drop them.
@@ -58,12 +59,22 @@ type Querier interface {
 	// Rating uses MAX, liked is OR'd, description is set only when keeper currently
 	// has no description (or the field is empty).
 	ApplyMergedKeeperPreferences(ctx context.Context, arg ApplyMergedKeeperPreferencesParams) error
+	AssignAssetRepository(ctx context.Context, arg AssignAssetRepositoryParams) (int64, error)
 	AssignFaceClusterMemberExclusive(ctx context.Context, arg AssignFaceClusterMemberExclusiveParams) (FaceClusterMember, error)
+	// Points the cover at the album's highest-rated live member, the most
+	// recently taken among equals, or clears it when the album is empty, and
+	// keeps choosing it automatically from then on.
+	AutoSelectAlbumCover(ctx context.Context, albumID int32) (Album, error)
 	BulkToggleAssetLiked(ctx context.Context, assetIds []pgtype.UUID) error
 	BulkUpdateAssetLiked(ctx context.Context, arg BulkUpdateAssetLikedParams) error
 	BulkUpdateAssetRating(ctx context.Context, arg BulkUpdateAssetRatingParams) error
 	BulkUpdateAssetStatus(ctx context.Context, arg BulkUpdateAssetStatusParams) error
 	CancelRepositoryScanRun(ctx context.Context, arg CancelRepositoryScanRunParams) (RepositoryScanRun, error)
+	// Marks a batch cancelled; cancelling again keeps the first cancelled_at.
+	CancelUploadBatch(ctx context.Context, batchID pgtype.UUID) (UploadBatch, error)
+	// Gives an asset discovered without an owner to the user who uploaded the
+	// same content again.
+	ClaimUnownedAsset(ctx context.Context, arg ClaimUnownedAssetParams) (int64, error)
 	ClearDefaultSearchSpaceByType(ctx context.Context, embeddingType string) error
 	CompleteRepositoryScanRun(ctx context.Context, arg CompleteRepositoryScanRunParams) (RepositoryScanRun, error)
 	CompleteRequiredPasswordChange(ctx context.Context, arg CompleteRequiredPasswordChangeParams) (User, error)
@@ -74,6 +85,7 @@ type Querier interface {
 	CountAssetsByStatus(ctx context.Context, status []byte) (int64, error)
 	CountAssetsByStatusAndOwner(ctx context.Context, arg CountAssetsByStatusAndOwnerParams) (int64, error)
 	CountAssetsByStatusAndRepository(ctx context.Context, arg CountAssetsByStatusAndRepositoryParams) (int64, error)
+	CountAssetsByTags(ctx context.Context, arg CountAssetsByTagsParams) (int64, error)
 	// Count query matching GetAssetsUnified WHERE clause
 	// Returns total count of assets matching the filters (for pagination)
 	CountAssetsUnified(ctx context.Context, arg CountAssetsUnifiedParams) (int64, error)
@@ -94,6 +106,9 @@ type Querier interface {
 	CountPeopleScoped(ctx context.Context, arg CountPeopleScopedParams) (int64, error)
 	CountPersonFacesScoped(ctx context.Context, arg CountPersonFacesScopedParams) (int64, error)
 	CountPhotoAssetsForIndexing(ctx context.Context, repositoryID pgtype.UUID) (int64, error)
+	// Photos a reindex would visit. With missing_only, only those lacking the
+	// result of at least one of the flagged tasks count.
+	CountPhotoAssetsForReindex(ctx context.Context, arg CountPhotoAssetsForReindexParams) (int64, error)
 	CountPhotoAssetsWithFaceResults(ctx context.Context, repositoryID pgtype.UUID) (int64, error)
 	CountPhotoAssetsWithOCRResults(ctx context.Context, repositoryID pgtype.UUID) (int64, error)
 	CountPhotoAssetsWithSemanticEmbedding(ctx context.Context, repositoryID pgtype.UUID) (int64, error)
@@ -102,9 +117,13 @@ type Querier interface {
 	CountPrimaryRepositories(ctx context.Context) (int64, error)
 	CountRepositories(ctx context.Context) (int64, error)
 	CountRepositoriesByStatus(ctx context.Context, status dbtypes.RepoStatus) (int64, error)
+	// Every asset row still pointing at the repository, Trash included.
+	CountRepositoryAssets(ctx context.Context, repositoryID pgtype.UUID) (int64, error)
 	CountRepositoryCloudBindingsByCredential(ctx context.Context, credentialID pgtype.UUID) (int64, error)
+	CountThumbnailReferences(ctx context.Context, storagePath string) (int64, error)
 	CountUsers(ctx context.Context) (int64, error)
 	CreateAgentPin(ctx context.Context, arg CreateAgentPinParams) (AgentPin, error)
+	// An album created without a cover has it chosen automatically.
 	CreateAlbum(ctx context.Context, arg CreateAlbumParams) (Album, error)
 	CreateAsset(ctx context.Context, arg CreateAssetParams) (Asset, error)
 	CreateCloudCredential(ctx context.Context, arg CreateCloudCredentialParams) (CloudCredential, error)
@@ -131,9 +150,15 @@ type Querier interface {
 	CreateUserWebAuthnCredential(ctx context.Context, arg CreateUserWebAuthnCredentialParams) (UserWebauthnCredential, error)
 	DeleteAgentPin(ctx context.Context, arg DeleteAgentPinParams) error
 	DeleteAlbum(ctx context.Context, albumID int32) error
+	DeleteAlbumsByIDs(ctx context.Context, albumIds []int32) (int64, error)
 	DeleteAllEmbeddingsForAsset(ctx context.Context, assetID pgtype.UUID) error
 	DeleteAllSearchEmbeddings(ctx context.Context) error
 	DeleteAsset(ctx context.Context, assetID pgtype.UUID) error
+	// Maintenance sweep for AI tag noise: drops tag links from the given sources
+	// whose confidence falls below the threshold. Manual tags are never passed in.
+	DeleteAssetTagsBelowConfidence(ctx context.Context, arg DeleteAssetTagsBelowConfidenceParams) (int64, error)
+	DeleteAssetTagsByAsset(ctx context.Context, assetID pgtype.UUID) error
+	DeleteAssetTagsByTag(ctx context.Context, tagID int32) error
 	DeleteCloudCredential(ctx context.Context, credentialID pgtype.UUID) error
 	DeleteEmbedding(ctx context.Context, arg DeleteEmbeddingParams) error
 	DeleteEmptyFaceClusters(ctx context.Context) error
@@ -150,6 +175,8 @@ type Querier interface {
 	DeleteMediaItem(ctx context.Context, mediaItemID pgtype.UUID) error
 	DeleteOCRResultByAsset(ctx context.Context, assetID pgtype.UUID) error
 	DeleteOCRTextItemsByAsset(ctx context.Context, assetID pgtype.UUID) error
+	// Removes AI-generated tag definitions no asset references any more.
+	DeleteOrphanedAITags(ctx context.Context) (int64, error)
 	// ============================================================================
 	// Duplicate group lifecycle
 	// ============================================================================
@@ -165,13 +192,27 @@ type Querier interface {
 	DeleteSpeciesPredictionsByAsset(ctx context.Context, assetID pgtype.UUID) error
 	// Presentation stacks ------------------------------------------------------
 	DeleteStack(ctx context.Context, stackID pgtype.UUID) error
+	// Drops mismatches an earlier check of the repository recorded and the check
+	// that started at checked_before no longer found.
+	DeleteStaleAssetSizeMismatches(ctx context.Context, arg DeleteStaleAssetSizeMismatchesParams) (int64, error)
 	DeleteTag(ctx context.Context, tagID int32) error
+	DeleteThumbnailsByAsset(ctx context.Context, assetID pgtype.UUID) error
 	DeleteUser(ctx context.Context, userID int32) error
 	DeleteUserRecoveryCodes(ctx context.Context, userID int32) error
 	DeleteUserTOTPCredential(ctx context.Context, userID int32) error
 	DeleteUserWebAuthnCredential(ctx context.Context, arg DeleteUserWebAuthnCredentialParams) (int64, error)
 	DeleteUserWebAuthnCredentials(ctx context.Context, userID int32) error
+	// Frees a path for an asset moving onto it: whatever other asset is recorded
+	// there lost its file and goes to the Trash without a path, from where a
+	// later move (two files swapping names) brings it back.
+	DetachAssetStoragePath(ctx context.Context, arg DetachAssetStoragePathParams) (int64, error)
+	// Unlinks every asset from a repository that is about to be removed, records
+	// the repository it came from, and with soft_delete also moves it to Trash.
+	DetachRepositoryAssets(ctx context.Context, arg DetachRepositoryAssetsParams) (int64, error)
 	DisableRepositoryCloudBindingsByCredential(ctx context.Context, credentialID pgtype.UUID) error
+	// Creates the batch on first use and returns it either way, so callers can
+	// check its owner and status.
+	EnsureUploadBatch(ctx context.Context, arg EnsureUploadBatchParams) (UploadBatch, error)
 	ExtendShareLinkExpiry(ctx context.Context, arg ExtendShareLinkExpiryParams) (ShareLink, error)
 	FailRepositoryScanRun(ctx context.Context, arg FailRepositoryScanRunParams) (RepositoryScanRun, error)
 	// Structural and burst detection ------------------------------------------
@@ -187,16 +228,20 @@ type Querier interface {
 	GetAlbumAssetsScoped(ctx context.Context, arg GetAlbumAssetsScopedParams) ([]GetAlbumAssetsScopedRow, error)
 	GetAlbumByID(ctx context.Context, albumID int32) (Album, error)
 	GetAlbumByIDScoped(ctx context.Context, arg GetAlbumByIDScopedParams) (GetAlbumByIDScopedRow, error)
+	GetAlbumByUserAndName(ctx context.Context, arg GetAlbumByUserAndNameParams) (Album, error)
 	GetAlbumsByUser(ctx context.Context, arg GetAlbumsByUserParams) ([]Album, error)
 	GetAlbumsByUserScoped(ctx context.Context, arg GetAlbumsByUserScopedParams) ([]GetAlbumsByUserScopedRow, error)
 	GetAllEmbeddingsForAsset(ctx context.Context, assetID pgtype.UUID) ([]GetAllEmbeddingsForAssetRow, error)
 	GetAllFaceClusters(ctx context.Context) ([]FaceCluster, error)
+	GetArchivedOriginal(ctx context.Context, assetID pgtype.UUID) (ArchivedOriginal, error)
 	GetAssetAlbums(ctx context.Context, assetID pgtype.UUID) ([]GetAssetAlbumsRow, error)
 	GetAssetByContentHashAndRepository(ctx context.Context, arg GetAssetByContentHashAndRepositoryParams) (Asset, error)
 	GetAssetByID(ctx context.Context, assetID pgtype.UUID) (Asset, error)
 	GetAssetByIDAny(ctx context.Context, assetID pgtype.UUID) (Asset, error)
 	GetAssetByRepositoryAndStoragePathAny(ctx context.Context, arg GetAssetByRepositoryAndStoragePathAnyParams) (Asset, error)
+	GetAssetCustomFields(ctx context.Context, assetID pgtype.UUID) ([]byte, error)
 	GetAssetExifRaw(ctx context.Context, assetID pgtype.UUID) (json.RawMessage, error)
+	GetAssetIDsByContentHashes(ctx context.Context, arg GetAssetIDsByContentHashesParams) ([]GetAssetIDsByContentHashesRow, error)
 	// Queries backing the Phase 2 agent tools (producers, transformers,
 	// observers). All ANY(asset_ids) queries operate on ref snapshots.
 	// search_people producer: assets containing at least one of the given people
@@ -210,7 +255,11 @@ type Querier interface {
 	// returns ordered asset ids only (capture time desc). The limit is the ref
 	// snapshot cap; callers detect truncation by requesting cap+1.
 	GetAssetIDsUnified(ctx context.Context, arg GetAssetIDsUnifiedParams) ([]pgtype.UUID, error)
+	// Returns the assets either side of an anchor asset in GetAssetsUnified order
+	// Keyset lookups on (sort_time, asset_id), so the anchor need not be paged in
+	GetAssetNeighborsUnified(ctx context.Context, arg GetAssetNeighborsUnifiedParams) (GetAssetNeighborsUnifiedRow, error)
 	GetAssetQualityScore(ctx context.Context, assetID pgtype.UUID) (AssetQualityScore, error)
+	GetAssetRepositoryRemoval(ctx context.Context, assetID pgtype.UUID) (AssetRepositoryRemoval, error)
 	GetAssetStatsForOwner(ctx context.Context, ownerID int32) (GetAssetStatsForOwnerRow, error)
 	GetAssetWithRelations(ctx context.Context, assetID pgtype.UUID) (GetAssetWithRelationsRow, error)
 	GetAssetWithTags(ctx context.Context, assetID pgtype.UUID) (GetAssetWithTagsRow, error)
@@ -305,6 +354,12 @@ type Querier interface {
 	// Aggregate stats for one folder path (recursive descendants), used for the
 	// folder detail header/hero.
 	GetFolderSummary(ctx context.Context, arg GetFolderSummaryParams) (GetFolderSummaryRow, error)
+	// Shots per camera body for gear analytics, optionally scoped to a repository and owner.
+	GetGearCameraCounts(ctx context.Context, arg GetGearCameraCountsParams) ([]GetGearCameraCountsRow, error)
+	// Most-used focal lengths rounded to whole millimetres; the regex guards the numeric cast.
+	GetGearFocalLengthCounts(ctx context.Context, arg GetGearFocalLengthCountsParams) ([]GetGearFocalLengthCountsRow, error)
+	// Shots per lens for gear analytics, optionally scoped to a repository and owner.
+	GetGearLensCounts(ctx context.Context, arg GetGearLensCountsParams) ([]GetGearLensCountsRow, error)
 	GetHighConfidenceTextItems(ctx context.Context, arg GetHighConfidenceTextItemsParams) ([]OcrTextItem, error)
 	// The primary repository pins the Host Owner after bootstrap. Before the
 	// primary exists, the first account is the initial administrator and therefore
@@ -328,6 +383,12 @@ type Querier interface {
 	GetOCRTextItemsByAsset(ctx context.Context, assetID pgtype.UUID) ([]OcrTextItem, error)
 	GetOCRTextItemsByAssetWithLimit(ctx context.Context, arg GetOCRTextItemsByAssetWithLimitParams) ([]OcrTextItem, error)
 	GetOldestActiveAdmin(ctx context.Context) (User, error)
+	// Live assets uploaded at or after since, for the dashboard overview.
+	GetOverviewAddedSince(ctx context.Context, arg GetOverviewAddedSinceParams) (int64, error)
+	// Live assets and bytes per repository; repositories without assets report zero.
+	GetOverviewRepositoryStorage(ctx context.Context, arg GetOverviewRepositoryStorageParams) ([]GetOverviewRepositoryStorageRow, error)
+	// Live assets and their bytes per asset type for the dashboard overview.
+	GetOverviewTypeCounts(ctx context.Context, arg GetOverviewTypeCountsParams) ([]GetOverviewTypeCountsRow, error)
 	// Ref-scoped variant of ListPHashEmbeddingsForRepository: pHash embeddings for
 	// a specific asset set, for the agent dedupe tool's in-memory similarity graph.
 	GetPHashEmbeddingsByAssetIDs(ctx context.Context, assetIds []pgtype.UUID) ([]GetPHashEmbeddingsByAssetIDsRow, error)
@@ -354,8 +415,12 @@ type Querier interface {
 	GetRepositoryRootByPath(ctx context.Context, path string) (RepositoryRoot, error)
 	GetRepositoryScanRun(ctx context.Context, scanID pgtype.UUID) (RepositoryScanRun, error)
 	GetReverseGeocodeCache(ctx context.Context, arg GetReverseGeocodeCacheParams) (ReverseGeocodeCache, error)
+	// pgvector stores a vector column's dimension as its type modifier.
+	GetSearchEmbeddingColumnDimension(ctx context.Context) (int32, error)
 	GetSettings(ctx context.Context) (Setting, error)
 	GetShareLinkByID(ctx context.Context, arg GetShareLinkByIDParams) (ShareLink, error)
+	// Finds a link by token whatever its status, for owner-side revocation.
+	GetShareLinkByTokenHash(ctx context.Context, arg GetShareLinkByTokenHashParams) (ShareLink, error)
 	GetSimilarFaces(ctx context.Context, arg GetSimilarFacesParams) ([]GetSimilarFacesRow, error)
 	GetSpeciesPredictionsByAsset(ctx context.Context, assetID pgtype.UUID) ([]SpeciesPrediction, error)
 	GetSpeciesPredictionsByLabel(ctx context.Context, arg GetSpeciesPredictionsByLabelParams) ([]SpeciesPrediction, error)
@@ -397,6 +462,7 @@ type Querier interface {
 	// repository_id is a face *selection* filter (which faces get processed),
 	// not part of cluster identity — clusters span repositories, never owners.
 	GetUnclusteredFacesInScope(ctx context.Context, arg GetUnclusteredFacesInScopeParams) ([]FaceItem, error)
+	GetUploadBatch(ctx context.Context, batchID pgtype.UUID) (UploadBatch, error)
 	GetUserByID(ctx context.Context, userID int32) (User, error)
 	GetUserByUsername(ctx context.Context, username string) (User, error)
 	GetUserMFAStatus(ctx context.Context, userID int32) (GetUserMFAStatusRow, error)
@@ -411,14 +477,30 @@ type Querier interface {
 	// Dedicated fixed-dimension semantic search vectors (see migration 000012).
 	// Photos have one row (frame_ts_ms IS NULL); videos have one row per frame.
 	InsertSearchEmbedding(ctx context.Context, arg InsertSearchEmbeddingParams) error
+	IsAssetInAlbum(ctx context.Context, arg IsAssetInAlbumParams) (bool, error)
 	ListActiveRepositories(ctx context.Context) ([]Repository, error)
+	// Events after the (since, after_id) cursor, oldest first. A nil owner_id
+	// lists every user's events.
+	ListActivityEvents(ctx context.Context, arg ListActivityEventsParams) ([]ActivityEvent, error)
 	ListAgentPins(ctx context.Context, userID int32) ([]AgentPin, error)
+	ListArchivedOriginalAssetIDsByRepository(ctx context.Context, repositoryID pgtype.UUID) ([]pgtype.UUID, error)
+	ListArchivedOriginalContentHashesByRepository(ctx context.Context, repositoryID pgtype.UUID) ([]string, error)
 	ListAssetEmbeddings(ctx context.Context, dollar_1 []pgtype.UUID) ([]ListAssetEmbeddingsRow, error)
+	// Assets taken between from_time and to_time, closest to at first.
+	ListAssetsAroundTime(ctx context.Context, arg ListAssetsAroundTimeParams) ([]Asset, error)
 	ListAssetsByRepositoryAny(ctx context.Context, repositoryID pgtype.UUID) ([]Asset, error)
+	ListAssetsWithoutRepository(ctx context.Context) ([]ListAssetsWithoutRepositoryRow, error)
 	ListBioAlbumAssetsMissingSpeciesPredictions(ctx context.Context, albumID int32) ([]Asset, error)
 	ListCloudCredentials(ctx context.Context) ([]CloudCredential, error)
 	ListCloudCredentialsForOwner(ctx context.Context, ownerID int32) ([]CloudCredential, error)
 	ListCloudImportRunsForRepository(ctx context.Context, arg ListCloudImportRunsForRepositoryParams) ([]CloudImportRun, error)
+	// Every key/value pair in use on a live asset, for filter dropdowns. Values
+	// are rendered as text, so 3 and "3" share an entry.
+	ListCustomFieldValues(ctx context.Context) ([]ListCustomFieldValuesRow, error)
+	ListDeferredRepositoryAssets(ctx context.Context, arg ListDeferredRepositoryAssetsParams) ([]Asset, error)
+	// Albums sharing their exact name and type with another album of the same
+	// user, grouped by name with the oldest album first.
+	ListDuplicateAlbumsByUser(ctx context.Context, userID int32) ([]ListDuplicateAlbumsByUserRow, error)
 	// Paginated list of duplicate groups for the given repository, owner, and
 	// status. owner_id NULL means no owner scope (admin); non-admin callers pass
 	// their own ID and never see NULL-owner or foreign groups.
@@ -437,21 +519,42 @@ type Querier interface {
 	ListPhotoAssetsMissingOCRResults(ctx context.Context, arg ListPhotoAssetsMissingOCRResultsParams) ([]Asset, error)
 	ListPhotoAssetsMissingSemanticEmbedding(ctx context.Context, arg ListPhotoAssetsMissingSemanticEmbeddingParams) ([]Asset, error)
 	ListRepositories(ctx context.Context) ([]Repository, error)
+	// The recorded size of every live asset of a repository with a stored path;
+	// all the size check needs, without loading full asset rows.
+	ListRepositoryAssetSizes(ctx context.Context, repositoryID pgtype.UUID) ([]ListRepositoryAssetSizesRow, error)
 	ListRepositoryCloudBindings(ctx context.Context, repositoryID pgtype.UUID) ([]RepositoryCloudBinding, error)
 	ListRepositoryRoots(ctx context.Context) ([]RepositoryRoot, error)
 	ListRepositoryScanRuns(ctx context.Context, arg ListRepositoryScanRunsParams) ([]RepositoryScanRun, error)
+	// Mismatches from the last size check of a repository. Assets re-imported
+	// since (their recorded size changed), moved elsewhere or deleted are left
+	// out.
+	ListRepositorySizeMismatches(ctx context.Context, repositoryID pgtype.UUID) ([]ListRepositorySizeMismatchesRow, error)
 	ListShareLinksByOwner(ctx context.Context, ownerID int32) ([]ShareLink, error)
 	ListTags(ctx context.Context, arg ListTagsParams) ([]Tag, error)
+	// Assets that belong to no album, newest upload first.
+	ListUnalbumedAssets(ctx context.Context, arg ListUnalbumedAssetsParams) ([]Asset, error)
+	ListUploadBatchJobIDs(ctx context.Context, batchID pgtype.UUID) ([]int64, error)
 	ListUserWebAuthnCredentialSummaries(ctx context.Context, userID int32) ([]ListUserWebAuthnCredentialSummariesRow, error)
 	ListUserWebAuthnCredentials(ctx context.Context, userID int32) ([]UserWebauthnCredential, error)
 	ListUsers(ctx context.Context, arg ListUsersParams) ([]User, error)
 	ListUsersWithStats(ctx context.Context, arg ListUsersWithStatsParams) ([]ListUsersWithStatsRow, error)
+	// Live members of an album, locked until the transaction ends so a reorder
+	// is checked against the same membership it writes.
+	LockAlbumAssetIDs(ctx context.Context, albumID int32) ([]pgtype.UUID, error)
 	MarkCloudImportRunStarted(ctx context.Context, runID pgtype.UUID) (CloudImportRun, error)
 	MarkCloudSyncFile(ctx context.Context, arg MarkCloudSyncFileParams) error
 	MarkDuplicateGroupDismissed(ctx context.Context, groupID pgtype.UUID) error
 	MarkDuplicateGroupMerged(ctx context.Context, arg MarkDuplicateGroupMergedParams) error
 	MarkLocationClustersGeocodeDisabled(ctx context.Context, arg MarkLocationClustersGeocodeDisabledParams) error
 	MarkStaleCloudImportRunsInterrupted(ctx context.Context) error
+	// Marks a photo left out of semantic indexing on purpose (see the
+	// repository's semantic_skip rule) as clip_failed, so it is not retried.
+	MarkTagRetrySkipped(ctx context.Context, assetID pgtype.UUID) error
+	// Appends the source albums' members the target lacks after the target's last
+	// position. Each asset keeps its first membership in source order, and
+	// members stay in their album order, so the target reads like the sources
+	// laid end to end.
+	MergeAlbumAssets(ctx context.Context, arg MergeAlbumAssetsParams) (int64, error)
 	// ============================================================================
 	// Metadata merge helpers (used inside merge transactions)
 	// ============================================================================
@@ -461,6 +564,10 @@ type Querier interface {
 	// Copies duplicate tags onto the keeper, choosing the higher confidence and
 	// preferring user-provided tags over AI-generated ones on conflict.
 	MergeAssetTagsForDuplicate(ctx context.Context, arg MergeAssetTagsForDuplicateParams) error
+	// Links every asset of the source tag to the target. An asset that already
+	// carries the target keeps one link with the higher confidence, preferring
+	// user-provided tags like MergeAssetTagsForDuplicate.
+	MergeAssetTagsIntoTag(ctx context.Context, arg MergeAssetTagsIntoTagParams) (int64, error)
 	MergeFaceClusters(ctx context.Context, arg MergeFaceClustersParams) error
 	// Re-parents the duplicate asset's face_items onto the keeper so cluster
 	// memberships (and thus person assignments) follow the keeper after merge.
@@ -470,6 +577,10 @@ type Querier interface {
 	MoveClusterMembersToClusterManual(ctx context.Context, arg MoveClusterMembersToClusterManualParams) error
 	MoveMediaItemComponent(ctx context.Context, arg MoveMediaItemComponentParams) error
 	PromoteEmbeddingSpaceAsDefaultIfNone(ctx context.Context, arg PromoteEmbeddingSpaceAsDefaultIfNoneParams) (EmbeddingSpace, error)
+	// Removes the asset row itself. Thumbnails, tag links and album links do not
+	// cascade and must be deleted first; every other reference cascades or is
+	// set to NULL.
+	PurgeAsset(ctx context.Context, assetID pgtype.UUID) (int64, error)
 	// rank(by=quality) ascending, using the aesthetic score from the SigLIP MLP
 	// head when available, falling back to the legacy heuristic (rating, liked,
 	// resolution) for unscored assets. Callers reverse for descending order.
@@ -479,15 +590,34 @@ type Querier interface {
 	// "recently added" presentation order, ascending; callers reverse for newest first.
 	RankAssetIDsByUploadTime(ctx context.Context, assetIds []pgtype.UUID) ([]pgtype.UUID, error)
 	ReclaimInterruptedRepositoryScanRuns(ctx context.Context) (int64, error)
+	// Appends an event. Without an explicit owner_id the owner is read from the
+	// asset, then the album, so callers only pass it once the row is gone.
+	RecordActivityEvent(ctx context.Context, arg RecordActivityEventParams) error
+	RecordArchivedOriginal(ctx context.Context, arg RecordArchivedOriginalParams) error
+	// Reruns AutoSelectAlbumCover's choice after a membership change for an
+	// album whose cover is automatic, or is replacing: the removed asset, or NULL
+	// when the album had no cover.
+	RefreshAlbumCover(ctx context.Context, arg RefreshAlbumCoverParams) (int64, error)
+	// Repoints albums whose cover is the given asset. Automatic covers get the
+	// next automatic choice. Otherwise, with reassign the cover moves to the most
+	// recently added remaining live member, else it is cleared.
+	RefreshAlbumCoversForAsset(ctx context.Context, arg RefreshAlbumCoversForAssetParams) (int64, error)
 	RemoveAssetFromAlbum(ctx context.Context, arg RemoveAssetFromAlbumParams) error
+	RemoveAssetFromAllAlbums(ctx context.Context, assetID pgtype.UUID) error
 	RemoveAssetTagsBySources(ctx context.Context, arg RemoveAssetTagsBySourcesParams) error
 	RemoveStackMemberByAssetID(ctx context.Context, assetID pgtype.UUID) error
 	RemoveTagFromAsset(ctx context.Context, arg RemoveTagFromAssetParams) error
 	RenameFaceCluster(ctx context.Context, arg RenameFaceClusterParams) (FaceCluster, error)
+	RenameTag(ctx context.Context, arg RenameTagParams) (Tag, error)
+	// Sets each listed member's position to its 1-based index in asset_ids.
+	ReorderAlbumAssets(ctx context.Context, arg ReorderAlbumAssetsParams) ([]ReorderAlbumAssetsRow, error)
 	RepositoryExists(ctx context.Context, path string) (bool, error)
 	ResetAssetStatusForRetry(ctx context.Context, assetID pgtype.UUID) (Asset, error)
 	ResetUserAccessPassword(ctx context.Context, arg ResetUserAccessPasswordParams) (User, error)
-	RestoreAsset(ctx context.Context, assetID pgtype.UUID) error
+	RestoreAsset(ctx context.Context, assetID pgtype.UUID) (int64, error)
+	// Puts back a description that re-extracting metadata replaced, but only while
+	// the description is still the extracted one, so a newer edit is kept.
+	RestoreAssetDescription(ctx context.Context, arg RestoreAssetDescriptionParams) error
 	RevokeRefreshToken(ctx context.Context, tokenID int32) error
 	RevokeShareLink(ctx context.Context, arg RevokeShareLinkParams) (ShareLink, error)
 	RevokeUserRefreshTokens(ctx context.Context, userID int32) error
@@ -495,24 +625,40 @@ type Querier interface {
 	SearchAssetsByFaceCluster(ctx context.Context, arg SearchAssetsByFaceClusterParams) ([]Asset, error)
 	SearchAssetsByFaceID(ctx context.Context, arg SearchAssetsByFaceIDParams) ([]Asset, error)
 	SearchAssetsBySpecies(ctx context.Context, arg SearchAssetsBySpeciesParams) ([]Asset, error)
+	// Assets carrying all (match_all) or any of tag_names, lower-cased, newest capture first.
+	SearchAssetsByTags(ctx context.Context, arg SearchAssetsByTagsParams) ([]Asset, error)
 	SearchTagsByName(ctx context.Context, arg SearchTagsByNameParams) ([]Tag, error)
+	SetAlbumCoverAsset(ctx context.Context, arg SetAlbumCoverAssetParams) (Album, error)
 	SetBootstrapPhase(ctx context.Context, bootstrapPhase string) (SystemState, error)
 	SetFaceClusterHidden(ctx context.Context, arg SetFaceClusterHiddenParams) (FaceCluster, error)
 	SetPrimaryEmbedding(ctx context.Context, arg SetPrimaryEmbeddingParams) error
 	SetPrimaryEmbeddingForAsset(ctx context.Context, arg SetPrimaryEmbeddingForAssetParams) error
+	SetProcessingPaused(ctx context.Context, processingPaused bool) (SystemState, error)
 	SetRepositoryRoot(ctx context.Context, arg SetRepositoryRootParams) (Repository, error)
 	SetUnownedRepositoryHostOwner(ctx context.Context, defaultOwnerID *int32) error
+	// Assets whose original was archived are kept: their file left the
+	// repository on purpose.
 	SoftDeleteAssetByRepositoryAndStoragePath(ctx context.Context, arg SoftDeleteAssetByRepositoryAndStoragePathParams) (int64, error)
+	// A target without a cover adopts the first source cover.
+	TouchMergedAlbum(ctx context.Context, arg TouchMergedAlbumParams) (Album, error)
 	UpdateAgentPinLayout(ctx context.Context, arg UpdateAgentPinLayoutParams) error
 	UpdateAgentPinTitle(ctx context.Context, arg UpdateAgentPinTitleParams) error
 	UpdateAgentPinWidget(ctx context.Context, arg UpdateAgentPinWidgetParams) error
+	// A changed cover counts as picked by hand.
 	UpdateAlbum(ctx context.Context, arg UpdateAlbumParams) (Album, error)
 	UpdateAsset(ctx context.Context, arg UpdateAssetParams) (Asset, error)
+	UpdateAssetBlurHash(ctx context.Context, arg UpdateAssetBlurHashParams) error
+	// Records an original rewritten in place. updated_at is set here although the
+	// trigger would: the scanner treats files modified after it as changed.
+	UpdateAssetContent(ctx context.Context, arg UpdateAssetContentParams) error
 	UpdateAssetDescription(ctx context.Context, arg UpdateAssetDescriptionParams) error
 	UpdateAssetDimensions(ctx context.Context, arg UpdateAssetDimensionsParams) error
 	UpdateAssetDuration(ctx context.Context, arg UpdateAssetDurationParams) error
 	UpdateAssetLike(ctx context.Context, arg UpdateAssetLikeParams) error
 	UpdateAssetMetadata(ctx context.Context, arg UpdateAssetMetadataParams) error
+	// Replaces the metadata but keeps a blurhash the thumbnail task stored,
+	// which runs concurrently with metadata extraction, and the hash_verified
+	// flag recorded at ingest.
 	UpdateAssetMetadataWithTakenTime(ctx context.Context, arg UpdateAssetMetadataWithTakenTimeParams) error
 	UpdateAssetPositionInAlbum(ctx context.Context, arg UpdateAssetPositionInAlbumParams) error
 	UpdateAssetRating(ctx context.Context, arg UpdateAssetRatingParams) error
@@ -550,8 +696,10 @@ type Querier interface {
 	UpdateUserProfile(ctx context.Context, arg UpdateUserProfileParams) (User, error)
 	UpdateUserTOTPLastUsed(ctx context.Context, userID int32) error
 	UpdateUserWebAuthnCredentialUsage(ctx context.Context, arg UpdateUserWebAuthnCredentialUsageParams) (UserWebauthnCredential, error)
+	UpsertAssetCustomFields(ctx context.Context, arg UpsertAssetCustomFieldsParams) ([]byte, error)
 	// Asset quality scores: per-asset aesthetic score from MLP head on SigLIP.
 	UpsertAssetQualityScore(ctx context.Context, arg UpsertAssetQualityScoreParams) (AssetQualityScore, error)
+	UpsertAssetSizeMismatch(ctx context.Context, arg UpsertAssetSizeMismatchParams) error
 	UpsertCheckpoint(ctx context.Context, arg UpsertCheckpointParams) error
 	UpsertCloudSyncCursor(ctx context.Context, arg UpsertCloudSyncCursorParams) error
 	// Unified embeddings table queries
@@ -0,0 +1,27 @@
+-- Activity events: the chronological feed of library changes.
+
+-- name: RecordActivityEvent :exec
+-- Appends an event. Without an explicit owner_id the owner is read from the
+-- asset, then the album, so callers only pass it once the row is gone.
+INSERT INTO activity_events (event_type, asset_id, album_id, owner_id, payload)
+VALUES (
+    sqlc.arg('event_type'),
+    sqlc.narg('asset_id'),
+    sqlc.narg('album_id'),
+    COALESCE(
+        sqlc.narg('owner_id')::integer,
+        (SELECT a.owner_id FROM assets a WHERE a.asset_id = sqlc.narg('asset_id')),
+        (SELECT al.user_id FROM albums al WHERE al.album_id = sqlc.narg('album_id'))
+    ),
+    sqlc.arg('payload')
+);
+
+-- name: ListActivityEvents :many
+-- Events after the (since, after_id) cursor, oldest first. A nil owner_id
+-- lists every user's events.
+SELECT event_id, event_type, asset_id, album_id, owner_id, payload, occurred_at
+FROM activity_events
+WHERE (occurred_at, event_id) > (sqlc.arg('since')::timestamptz, sqlc.arg('after_id')::bigint)
+  AND (sqlc.narg('owner_id')::integer IS NULL OR owner_id = sqlc.narg('owner_id'))
+ORDER BY occurred_at, event_id
+LIMIT sqlc.arg('limit');
@@ -1,11 +1,18 @@
 -- name: CreateAlbum :one
-INSERT INTO albums (user_id, album_name, description, cover_asset_id, album_type)
-VALUES ($1, $2, $3, $4, $5)
+-- An album created without a cover has it chosen automatically.
+INSERT INTO albums (user_id, album_name, description, cover_asset_id, album_type, rules, cover_auto)
+VALUES ($1, $2, $3, $4, $5, $6, $4::uuid IS NULL)
 RETURNING *;
 
 -- name: GetAlbumByID :one
 SELECT * FROM albums WHERE album_id = $1;
 
+-- name: GetAlbumByUserAndName :one
+SELECT * FROM albums
+WHERE user_id = $1 AND album_name = $2 AND album_type = 'default'
+ORDER BY album_id
+LIMIT 1;
+
 -- name: GetAlbumsByUser :many
 SELECT * FROM albums
 WHERE user_id = $1
@@ -18,6 +25,7 @@ FROM albums al
 WHERE al.user_id = sqlc.arg('user_id')
   AND (
     sqlc.narg('repository_id')::uuid IS NULL
+    OR al.rules IS NOT NULL
     OR EXISTS (
       SELECT 1
       FROM album_assets aa
@@ -44,6 +52,7 @@ WITH page_albums AS MATERIALIZED (
   WHERE al.user_id = sqlc.arg('user_id')
     AND (
       sqlc.narg('repository_id')::uuid IS NULL
+      OR al.rules IS NOT NULL
       OR EXISTS (
         SELECT 1
         FROM album_assets aa_exists
@@ -72,6 +81,7 @@ SELECT
   al.description,
   al.cover_asset_id,
   al.album_type,
+  al.rules,
   COALESCE(asset_counts.asset_count, 0) AS asset_count,
   COALESCE(cover_asset.cover_asset_id, first_asset.asset_id)::uuid AS display_cover_asset_id
 FROM page_albums p
@@ -123,6 +133,7 @@ SELECT
   al.description,
   al.cover_asset_id,
   al.album_type,
+  al.rules,
   COALESCE(asset_counts.asset_count, 0) AS asset_count,
   COALESCE(cover_asset.cover_asset_id, first_asset.asset_id)::uuid AS display_cover_asset_id
 FROM albums al
@@ -164,11 +175,88 @@ LEFT JOIN LATERAL (
 WHERE al.album_id = sqlc.arg('album_id');
 
 -- name: UpdateAlbum :one
+-- A changed cover counts as picked by hand.
+UPDATE albums
+SET album_name = $2, description = $3, cover_asset_id = $4, album_type = $5,
+  cover_auto = cover_auto AND cover_asset_id IS NOT DISTINCT FROM $4,
+  updated_at = CURRENT_TIMESTAMP
+WHERE album_id = $1
+RETURNING *;
+
+-- name: RefreshAlbumCoversForAsset :execrows
+-- Repoints albums whose cover is the given asset. Automatic covers get the
+-- next automatic choice. Otherwise, with reassign the cover moves to the most
+-- recently added remaining live member, else it is cleared.
+UPDATE albums al
+SET cover_asset_id = CASE
+    WHEN al.cover_auto THEN (
+      SELECT aa.asset_id
+      FROM album_assets aa
+      JOIN assets a ON a.asset_id = aa.asset_id
+      WHERE aa.album_id = al.album_id
+        AND aa.asset_id <> sqlc.arg('asset_id')::uuid
+        AND a.is_deleted = false
+      ORDER BY a.rating DESC NULLS LAST, COALESCE(a.taken_time, a.upload_time) DESC, a.asset_id DESC
+      LIMIT 1
+    )
+    WHEN sqlc.arg('reassign')::boolean THEN (
+      SELECT aa.asset_id
+      FROM album_assets aa
+      JOIN assets a ON a.asset_id = aa.asset_id
+      WHERE aa.album_id = al.album_id
+        AND aa.asset_id <> sqlc.arg('asset_id')::uuid
+        AND a.is_deleted = false
+      ORDER BY aa.added_time DESC, aa.asset_id DESC
+      LIMIT 1
+    )
+  END,
+  updated_at = CURRENT_TIMESTAMP
+WHERE al.cover_asset_id = sqlc.arg('asset_id')::uuid;
+
+-- name: SetAlbumCoverAsset :one
 UPDATE albums
-SET album_name = $2, description = $3, cover_asset_id = $4, album_type = $5, updated_at = CURRENT_TIMESTAMP
+SET cover_asset_id = $2, cover_auto = false, updated_at = CURRENT_TIMESTAMP
 WHERE album_id = $1
 RETURNING *;
 
+-- name: AutoSelectAlbumCover :one
+-- Points the cover at the album's highest-rated live member, the most
+-- recently taken among equals, or clears it when the album is empty, and
+-- keeps choosing it automatically from then on.
+UPDATE albums al
+SET cover_auto = true,
+  cover_asset_id = (
+    SELECT aa.asset_id
+    FROM album_assets aa
+    JOIN assets a ON a.asset_id = aa.asset_id
+    WHERE aa.album_id = al.album_id
+      AND a.is_deleted = false
+    ORDER BY a.rating DESC NULLS LAST, COALESCE(a.taken_time, a.upload_time) DESC, a.asset_id DESC
+    LIMIT 1
+  ),
+  updated_at = CURRENT_TIMESTAMP
+WHERE al.album_id = $1
+RETURNING *;
+
+-- name: RefreshAlbumCover :execrows
+-- Reruns AutoSelectAlbumCover's choice after a membership change for an
+-- album whose cover is automatic, or is replacing: the removed asset, or NULL
+-- when the album had no cover.
+UPDATE albums al
+SET cover_auto = true,
+  cover_asset_id = (
+    SELECT aa.asset_id
+    FROM album_assets aa
+    JOIN assets a ON a.asset_id = aa.asset_id
+    WHERE aa.album_id = al.album_id
+      AND a.is_deleted = false
+    ORDER BY a.rating DESC NULLS LAST, COALESCE(a.taken_time, a.upload_time) DESC, a.asset_id DESC
+    LIMIT 1
+  ),
+  updated_at = CURRENT_TIMESTAMP
+WHERE al.album_id = sqlc.arg('album_id')
+  AND (al.cover_auto OR al.cover_asset_id IS NOT DISTINCT FROM sqlc.narg('replacing')::uuid);
+
 -- name: DeleteAlbum :exec
 DELETE FROM albums WHERE album_id = $1;
 
@@ -198,11 +286,35 @@ JOIN album_assets aa ON al.album_id = aa.album_id
 WHERE aa.asset_id = $1
 ORDER BY al.album_name ASC;
 
+-- name: IsAssetInAlbum :one
+SELECT EXISTS (
+  SELECT 1 FROM album_assets
+  WHERE album_id = $1 AND asset_id = $2
+) AS is_member;
+
 -- name: UpdateAssetPositionInAlbum :exec
 UPDATE album_assets
 SET position = $3
 WHERE album_id = $1 AND asset_id = $2;
 
+-- name: LockAlbumAssetIDs :many
+-- Live members of an album, locked until the transaction ends so a reorder
+-- is checked against the same membership it writes.
+SELECT aa.asset_id
+FROM album_assets aa
+JOIN assets a ON a.asset_id = aa.asset_id
+WHERE aa.album_id = $1 AND a.is_deleted = false
+ORDER BY aa.asset_id
+FOR UPDATE OF aa;
+
+-- name: ReorderAlbumAssets :many
+-- Sets each listed member's position to its 1-based index in asset_ids.
+UPDATE album_assets aa
+SET position = o.ord::integer
+FROM unnest(sqlc.arg('asset_ids')::uuid[]) WITH ORDINALITY AS o(asset_id, ord)
+WHERE aa.album_id = sqlc.arg('album_id') AND aa.asset_id = o.asset_id
+RETURNING aa.asset_id, aa.position;
+
 -- name: GetAlbumAssetCount :one
 SELECT COUNT(*) as count
 FROM album_assets aa
@@ -235,3 +347,87 @@ WHERE aa.album_id = sqlc.arg('album_id')
     WHERE sp.asset_id = a.asset_id
   )
 ORDER BY aa.position ASC NULLS LAST, aa.added_time ASC, aa.asset_id ASC;
+
+-- name: ListDuplicateAlbumsByUser :many
+-- Albums sharing their exact name and type with another album of the same
+-- user, grouped by name with the oldest album first.
+SELECT
+  al.album_id,
+  al.user_id,
+  al.album_name,
+  al.created_at,
+  al.updated_at,
+  al.description,
+  al.cover_asset_id,
+  al.album_type,
+  (
+    SELECT COUNT(*)
+    FROM album_assets aa
+    JOIN assets a ON a.asset_id = aa.asset_id
+    WHERE aa.album_id = al.album_id
+      AND a.is_deleted = false
+  ) AS asset_count
+FROM albums al
+WHERE al.user_id = sqlc.arg('user_id')
+  AND EXISTS (
+    SELECT 1
+    FROM albums other
+    WHERE other.user_id = al.user_id
+      AND other.album_name = al.album_name
+      AND other.album_type = al.album_type
+      AND other.album_id <> al.album_id
+  )
+ORDER BY al.album_name ASC, al.album_type ASC, al.created_at ASC, al.album_id ASC;
+
+-- name: MergeAlbumAssets :execrows
+-- Appends the source albums' members the target lacks after the target's last
+-- position. Each asset keeps its first membership in source order, and
+-- members stay in their album order, so the target reads like the sources
+-- laid end to end.
+INSERT INTO album_assets (album_id, asset_id, position, added_time)
+SELECT
+  sqlc.arg('target_album_id')::int,
+  src.asset_id,
+  COALESCE((
+    SELECT MAX(t.position)
+    FROM album_assets t
+    WHERE t.album_id = sqlc.arg('target_album_id')::int
+  ), 0) + ROW_NUMBER() OVER (
+    ORDER BY src.source_rank, src.position ASC NULLS LAST, src.added_time ASC, src.asset_id ASC
+  ),
+  src.added_time
+FROM (
+  SELECT DISTINCT ON (aa.asset_id)
+    aa.asset_id,
+    aa.position,
+    aa.added_time,
+    array_position(sqlc.arg('source_album_ids')::int[], aa.album_id) AS source_rank
+  FROM album_assets aa
+  WHERE aa.album_id = ANY(sqlc.arg('source_album_ids')::int[])
+    AND NOT EXISTS (
+      SELECT 1
+      FROM album_assets t
+      WHERE t.album_id = sqlc.arg('target_album_id')::int
+        AND t.asset_id = aa.asset_id
+    )
+  ORDER BY aa.asset_id, array_position(sqlc.arg('source_album_ids')::int[], aa.album_id)
+) src
+ON CONFLICT (album_id, asset_id) DO NOTHING;
+
+-- name: TouchMergedAlbum :one
+-- A target without a cover adopts the first source cover.
+UPDATE albums al
+SET cover_asset_id = COALESCE(al.cover_asset_id, (
+    SELECT s.cover_asset_id
+    FROM albums s
+    WHERE s.album_id = ANY(sqlc.arg('source_album_ids')::int[])
+      AND s.cover_asset_id IS NOT NULL
+    ORDER BY array_position(sqlc.arg('source_album_ids')::int[], s.album_id)
+    LIMIT 1
+  )),
+  updated_at = CURRENT_TIMESTAMP
+WHERE al.album_id = sqlc.arg('target_album_id')::int
+RETURNING *;
+
+-- name: DeleteAlbumsByIDs :execrows
+DELETE FROM albums WHERE album_id = ANY(sqlc.arg('album_ids')::int[]);
@@ -0,0 +1,23 @@
+-- name: RecordArchivedOriginal :exec
+INSERT INTO archived_originals (asset_id, archive_path)
+VALUES ($1, $2)
+ON CONFLICT (asset_id) DO UPDATE
+SET archive_path = EXCLUDED.archive_path,
+    archived_at = now();
+
+-- name: GetArchivedOriginal :one
+SELECT asset_id, archive_path, archived_at
+FROM archived_originals
+WHERE asset_id = $1;
+
+-- name: ListArchivedOriginalAssetIDsByRepository :many
+SELECT ao.asset_id
+FROM archived_originals ao
+JOIN assets a ON a.asset_id = ao.asset_id
+WHERE a.repository_id = $1;
+
+-- name: ListArchivedOriginalContentHashesByRepository :many
+SELECT a.content_hash
+FROM archived_originals ao
+JOIN assets a ON a.asset_id = ao.asset_id
+WHERE a.repository_id = $1;
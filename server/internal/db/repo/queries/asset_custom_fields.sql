@@ -0,0 +1,26 @@
+-- Asset custom fields: user-defined key/value pairs kept apart from extracted metadata.
+
+-- name: GetAssetCustomFields :one
+SELECT fields
+FROM asset_custom_fields
+WHERE asset_id = $1;
+
+-- name: UpsertAssetCustomFields :one
+INSERT INTO asset_custom_fields (asset_id, fields)
+VALUES ($1, $2)
+ON CONFLICT (asset_id)
+DO UPDATE SET
+    fields = EXCLUDED.fields,
+    updated_at = NOW()
+RETURNING fields;
+
+-- name: ListCustomFieldValues :many
+-- Every key/value pair in use on a live asset, for filter dropdowns. Values
+-- are rendered as text, so 3 and "3" share an entry.
+SELECT f.key::text AS field_key, (f.value #>> '{}')::text AS field_value
+FROM asset_custom_fields cf
+JOIN assets a ON a.asset_id = cf.asset_id
+CROSS JOIN LATERAL jsonb_each(cf.fields) AS f(key, value)
+WHERE a.is_deleted = false
+GROUP BY 1, 2
+ORDER BY 1, 2;
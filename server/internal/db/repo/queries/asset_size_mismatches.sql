@@ -0,0 +1,41 @@
+-- Asset size mismatches: results of the repository size quick-check.
+
+-- name: ListRepositoryAssetSizes :many
+-- The recorded size of every live asset of a repository with a stored path;
+-- all the size check needs, without loading full asset rows.
+SELECT asset_id, storage_path, file_size
+FROM assets
+WHERE repository_id = sqlc.arg('repository_id')
+  AND is_deleted = false
+  AND storage_path IS NOT NULL
+ORDER BY storage_path;
+
+-- name: UpsertAssetSizeMismatch :exec
+INSERT INTO asset_size_mismatches (asset_id, repository_id, recorded_size, actual_size, checked_at)
+VALUES (sqlc.arg('asset_id'), sqlc.arg('repository_id'), sqlc.arg('recorded_size'), sqlc.arg('actual_size'), sqlc.arg('checked_at'))
+ON CONFLICT (asset_id)
+DO UPDATE SET
+    repository_id = EXCLUDED.repository_id,
+    recorded_size = EXCLUDED.recorded_size,
+    actual_size = EXCLUDED.actual_size,
+    checked_at = EXCLUDED.checked_at;
+
+-- name: DeleteStaleAssetSizeMismatches :execrows
+-- Drops mismatches an earlier check of the repository recorded and the check
+-- that started at checked_before no longer found.
+DELETE FROM asset_size_mismatches
+WHERE repository_id = sqlc.arg('repository_id')
+  AND checked_at < sqlc.arg('checked_before');
+
+-- name: ListRepositorySizeMismatches :many
+-- Mismatches from the last size check of a repository. Assets re-imported
+-- since (their recorded size changed), moved elsewhere or deleted are left
+-- out.
+SELECT m.asset_id, a.storage_path, a.original_filename, m.recorded_size, m.actual_size, m.checked_at
+FROM asset_size_mismatches m
+JOIN assets a ON a.asset_id = m.asset_id
+WHERE m.repository_id = sqlc.arg('repository_id')
+  AND a.repository_id = m.repository_id
+  AND a.is_deleted = false
+  AND a.file_size = m.recorded_size
+ORDER BY a.storage_path, m.asset_id;
@@ -0,0 +1,41 @@
+-- Asset tagging retries: bounded re-queues of semantic tagging for photos without an embedding.
+
+-- name: ListPhotosAwaitingTagRetry :many
+-- Live photos uploaded before uploaded_before that still have no semantic
+-- embedding (and so no AI tags) and are not marked clip_failed, least-retried
+-- first.
+SELECT a.asset_id, COALESCE(r.attempts, 0)::int AS attempts
+FROM assets a
+LEFT JOIN asset_tagging_retries r ON r.asset_id = a.asset_id
+WHERE a.type = 'PHOTO'
+  AND a.is_deleted = false
+  AND a.upload_time < sqlc.arg('uploaded_before')
+  AND COALESCE(r.clip_failed, false) = false
+  AND NOT EXISTS (
+    SELECT 1
+    FROM search_embeddings se
+    WHERE se.asset_id = a.asset_id
+      AND se.frame_ts_ms IS NULL
+  )
+ORDER BY COALESCE(r.attempts, 0), a.upload_time, a.asset_id
+LIMIT sqlc.arg('limit');
+
+-- name: MarkTagRetrySkipped :exec
+-- Marks a photo left out of semantic indexing on purpose (see the
+-- repository's semantic_skip rule) as clip_failed, so it is not retried.
+INSERT INTO asset_tagging_retries (asset_id, attempts, last_attempt_at, clip_failed)
+VALUES (sqlc.arg('asset_id'), 0, NOW(), true)
+ON CONFLICT (asset_id)
+DO UPDATE SET clip_failed = true;
+
+-- name: RecordTagRetryAttempt :one
+-- Counts one more retry of the asset and marks it clip_failed once attempts
+-- reaches max_attempts. Returns the new attempt count.
+INSERT INTO asset_tagging_retries (asset_id, attempts, last_attempt_at, clip_failed)
+VALUES (sqlc.arg('asset_id'), 1, NOW(), 1 >= sqlc.arg('max_attempts')::int)
+ON CONFLICT (asset_id)
+DO UPDATE SET
+    attempts = asset_tagging_retries.attempts + 1,
+    last_attempt_at = NOW(),
+    clip_failed = asset_tagging_retries.attempts + 1 >= sqlc.arg('max_attempts')::int
+RETURNING attempts;
@@ -51,8 +51,18 @@ SET specific_metadata = $2
 WHERE asset_id = $1;
 
 -- name: UpdateAssetMetadataWithTakenTime :exec
+-- Replaces the metadata but keeps a blurhash the thumbnail task stored,
+-- which runs concurrently with metadata extraction, and the hash_verified
+-- flag recorded at ingest.
 UPDATE assets
-SET specific_metadata = sqlc.arg('specific_metadata'),
+SET specific_metadata = CASE
+        WHEN jsonb_typeof(sqlc.arg('specific_metadata')) = 'object'
+        THEN sqlc.arg('specific_metadata') || jsonb_strip_nulls(jsonb_build_object(
+            'blurhash', specific_metadata -> 'blurhash',
+            'hash_verified', specific_metadata -> 'hash_verified'
+        ))
+        ELSE sqlc.arg('specific_metadata')
+    END,
     exif_raw = COALESCE(sqlc.narg('exif_raw')::jsonb, exif_raw),
     taken_time = CASE
         WHEN sqlc.arg('taken_time')::timestamptz IS NOT NULL THEN sqlc.arg('taken_time')::timestamptz
@@ -93,10 +103,17 @@ UPDATE assets
 SET is_deleted = true, deleted_at = CURRENT_TIMESTAMP
 WHERE asset_id = $1;
 
--- name: RestoreAsset :exec
+-- name: PurgeAsset :execrows
+-- Removes the asset row itself. Thumbnails, tag links and album links do not
+-- cascade and must be deleted first; every other reference cascades or is
+-- set to NULL.
+DELETE FROM assets
+WHERE asset_id = $1;
+
+-- name: RestoreAsset :execrows
 UPDATE assets
 SET is_deleted = false, deleted_at = NULL
-WHERE asset_id = $1;
+WHERE asset_id = $1 AND is_deleted = true;
 
 -- name: SearchAssets :many
 SELECT * FROM assets
@@ -131,6 +148,19 @@ WHERE asset_id = sqlc.arg('asset_id')
   AND repository_id = sqlc.arg('repository_id')
 RETURNING *;
 
+-- name: DetachAssetStoragePath :execrows
+-- Frees a path for an asset moving onto it: whatever other asset is recorded
+-- there lost its file and goes to the Trash without a path, from where a
+-- later move (two files swapping names) brings it back.
+UPDATE assets
+SET
+    storage_path = NULL,
+    is_deleted = true,
+    deleted_at = COALESCE(deleted_at, CURRENT_TIMESTAMP)
+WHERE repository_id = sqlc.arg('repository_id')
+  AND storage_path = sqlc.arg('storage_path')
+  AND asset_id <> sqlc.arg('keep_asset_id');
+
 -- name: GetAssetsByStatus :many
 SELECT * FROM assets
 WHERE status->>'state' = $1 AND is_deleted = false
@@ -176,6 +206,15 @@ SELECT COUNT(*) as count
 FROM assets
 WHERE status->>'state' = $1 AND owner_id = $2 AND is_deleted = false;
 
+-- name: ListDeferredRepositoryAssets :many
+SELECT * FROM assets
+WHERE repository_id = sqlc.arg('repository_id')
+  AND status->>'state' = 'deferred'
+  AND is_deleted = false
+  AND asset_id > sqlc.arg('after_asset_id')
+ORDER BY asset_id
+LIMIT sqlc.arg('limit');
+
 -- name: ResetAssetStatusForRetry :one
 UPDATE assets
 SET status = jsonb_set(
@@ -207,11 +246,27 @@ SELECT * FROM assets
 WHERE content_hash = $1 AND repository_id = $2 AND is_deleted = false;
 
 -- name: GetAssetsByContentHashesAndRepository :many
-SELECT asset_id, content_hash, file_size, original_filename FROM assets
+SELECT asset_id, content_hash, file_size, original_filename, owner_id FROM assets
 WHERE content_hash = ANY(sqlc.arg('content_hashes')::text[])
   AND repository_id = sqlc.arg('repository_id')
   AND is_deleted = false;
 
+-- name: ClaimUnownedAsset :execrows
+-- Gives an asset discovered without an owner to the user who uploaded the
+-- same content again.
+UPDATE assets
+SET owner_id = sqlc.arg('owner_id'), updated_at = CURRENT_TIMESTAMP
+WHERE asset_id = sqlc.arg('asset_id')
+  AND owner_id IS NULL;
+
+-- name: GetAssetIDsByContentHashes :many
+SELECT asset_id, content_hash FROM assets
+WHERE content_hash = ANY(sqlc.arg('content_hashes')::text[])
+  AND is_deleted = false
+  AND (sqlc.narg('owner_id')::integer IS NULL OR owner_id = sqlc.narg('owner_id'))
+  AND (sqlc.narg('repository_id')::uuid IS NULL OR repository_id = sqlc.narg('repository_id'))
+ORDER BY upload_time ASC, asset_id ASC;
+
 -- name: GetAssetsByQuickFingerprintsAndRepository :many
 SELECT asset_id, quick_fingerprint, quick_fingerprint_version, file_size, original_filename FROM assets
 WHERE quick_fingerprint = ANY(sqlc.arg('quick_fingerprints')::text[])
@@ -230,11 +285,16 @@ WHERE repository_id = $1
 ORDER BY storage_path ASC;
 
 -- name: SoftDeleteAssetByRepositoryAndStoragePath :execrows
+-- Assets whose original was archived are kept: their file left the
+-- repository on purpose.
 UPDATE assets
 SET is_deleted = true, deleted_at = CURRENT_TIMESTAMP
 WHERE repository_id = $1
   AND storage_path = $2
-  AND is_deleted = false;
+  AND is_deleted = false
+  AND NOT EXISTS (
+      SELECT 1 FROM archived_originals ao WHERE ao.asset_id = assets.asset_id
+  );
 
 -- name: UpdateDiscoveredAssetByID :one
 UPDATE assets
@@ -252,14 +312,20 @@ WHERE asset_id = $1
 RETURNING *;
 
 -- name: CreateThumbnail :one
-INSERT INTO thumbnails (asset_id, size, storage_path, mime_type)
-VALUES ($1, $2, $3, $4)
+INSERT INTO thumbnails (asset_id, size, storage_path, mime_type, width, height)
+VALUES ($1, $2, $3, $4, $5, $6)
 ON CONFLICT (asset_id, size) DO UPDATE
 SET storage_path = EXCLUDED.storage_path,
     mime_type = EXCLUDED.mime_type,
+    width = EXCLUDED.width,
+    height = EXCLUDED.height,
     created_at = CURRENT_TIMESTAMP
 RETURNING *;
 
+-- name: DeleteThumbnailsByAsset :exec
+DELETE FROM thumbnails
+WHERE asset_id = $1;
+
 -- name: GetThumbnailByID :one
 SELECT * FROM thumbnails WHERE thumbnail_id = $1;
 
@@ -276,6 +342,10 @@ ORDER BY CASE size
     WHEN 'large' THEN 3
 END, thumbnail_id;
 
+-- name: CountThumbnailReferences :one
+SELECT COUNT(*) FROM thumbnails
+WHERE storage_path = $1;
+
 -- name: AddAssetToAlbum :exec
 INSERT INTO album_assets (asset_id, album_id, position)
 VALUES ($1, $2, $3)
@@ -291,10 +361,18 @@ VALUES ($1, $2, $3, $4)
 ON CONFLICT (asset_id, tag_id) DO UPDATE
 SET confidence = $3, source = $4;
 
+-- name: RemoveAssetFromAllAlbums :exec
+DELETE FROM album_assets
+WHERE asset_id = $1;
+
 -- name: RemoveTagFromAsset :exec
 DELETE FROM asset_tags
 WHERE asset_id = $1 AND tag_id = $2;
 
+-- name: DeleteAssetTagsByAsset :exec
+DELETE FROM asset_tags
+WHERE asset_id = $1;
+
 -- name: RemoveAssetTagsBySources :exec
 DELETE FROM asset_tags
 WHERE asset_id = $1
@@ -332,6 +410,15 @@ SET rating = sqlc.arg('rating')::integer,
     liked = sqlc.arg('liked')::boolean
 WHERE asset_id = sqlc.arg('asset_id');
 
+-- name: UpdateAssetBlurHash :exec
+UPDATE assets
+SET specific_metadata = jsonb_set(
+    COALESCE(specific_metadata, '{}'::jsonb),
+    '{blurhash}',
+    to_jsonb(sqlc.arg('blurhash')::text)
+)
+WHERE asset_id = sqlc.arg('asset_id');
+
 -- name: UpdateAssetDescription :exec
 UPDATE assets
 SET specific_metadata = jsonb_set(
@@ -341,6 +428,18 @@ SET specific_metadata = jsonb_set(
 )
 WHERE asset_id = sqlc.arg('asset_id');
 
+-- name: RestoreAssetDescription :exec
+-- Puts back a description that re-extracting metadata replaced, but only while
+-- the description is still the extracted one, so a newer edit is kept.
+UPDATE assets
+SET specific_metadata = jsonb_set(
+    COALESCE(specific_metadata, '{}'::jsonb),
+    '{description}',
+    to_jsonb(sqlc.arg('description')::text)
+)
+WHERE asset_id = sqlc.arg('asset_id')
+  AND COALESCE(specific_metadata->>'description', '') = sqlc.arg('expected')::text;
+
 -- name: GetAssetsByRating :many
 SELECT * FROM assets
 WHERE is_deleted = false
@@ -357,6 +456,60 @@ WHERE is_deleted = false
 ORDER BY upload_time DESC
 LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
 
+-- name: ListAssetsAroundTime :many
+-- Assets taken between from_time and to_time, closest to at first.
+SELECT a.* FROM assets a
+WHERE a.is_deleted = false
+  AND a.taken_time BETWEEN sqlc.arg('from_time')::timestamptz AND sqlc.arg('to_time')::timestamptz
+  AND (sqlc.narg('owner_id')::integer IS NULL OR a.owner_id = sqlc.narg('owner_id'))
+ORDER BY abs(extract(epoch FROM a.taken_time - sqlc.arg('at')::timestamptz)), a.taken_time, a.asset_id
+LIMIT sqlc.arg('limit');
+
+-- name: ListUnalbumedAssets :many
+-- Assets that belong to no album, newest upload first.
+SELECT a.* FROM assets a
+WHERE a.is_deleted = false
+  AND (sqlc.narg('owner_id')::integer IS NULL OR a.owner_id = sqlc.narg('owner_id'))
+  AND (sqlc.narg('repository_id')::uuid IS NULL OR a.repository_id = sqlc.narg('repository_id'))
+  AND NOT EXISTS (
+    SELECT 1 FROM album_assets aa WHERE aa.asset_id = a.asset_id
+  )
+ORDER BY a.upload_time DESC, a.asset_id DESC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: SearchAssetsByTags :many
+-- Assets carrying all (match_all) or any of tag_names, lower-cased, newest capture first.
+SELECT a.* FROM assets a
+WHERE a.is_deleted = false
+  AND (sqlc.narg('owner_id')::integer IS NULL OR a.owner_id = sqlc.narg('owner_id'))
+  AND (sqlc.narg('repository_id')::uuid IS NULL OR a.repository_id = sqlc.narg('repository_id'))
+  AND (
+    SELECT COUNT(DISTINCT lower(t.tag_name))
+    FROM asset_tags at
+    JOIN tags t ON t.tag_id = at.tag_id
+    WHERE at.asset_id = a.asset_id
+      AND lower(t.tag_name) = ANY(sqlc.arg('tag_names')::text[])
+      AND (cardinality(sqlc.arg('sources')::text[]) = 0 OR at.source = ANY(sqlc.arg('sources')::text[]))
+      AND at.confidence >= sqlc.arg('min_confidence')::float8
+  ) >= CASE WHEN sqlc.arg('match_all')::boolean THEN cardinality(sqlc.arg('tag_names')::text[]) ELSE 1 END
+ORDER BY COALESCE(a.taken_time, a.upload_time) DESC, a.asset_id DESC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: CountAssetsByTags :one
+SELECT COUNT(*) FROM assets a
+WHERE a.is_deleted = false
+  AND (sqlc.narg('owner_id')::integer IS NULL OR a.owner_id = sqlc.narg('owner_id'))
+  AND (sqlc.narg('repository_id')::uuid IS NULL OR a.repository_id = sqlc.narg('repository_id'))
+  AND (
+    SELECT COUNT(DISTINCT lower(t.tag_name))
+    FROM asset_tags at
+    JOIN tags t ON t.tag_id = at.tag_id
+    WHERE at.asset_id = a.asset_id
+      AND lower(t.tag_name) = ANY(sqlc.arg('tag_names')::text[])
+      AND (cardinality(sqlc.arg('sources')::text[]) = 0 OR at.source = ANY(sqlc.arg('sources')::text[]))
+      AND at.confidence >= sqlc.arg('min_confidence')::float8
+  ) >= CASE WHEN sqlc.arg('match_all')::boolean THEN cardinality(sqlc.arg('tag_names')::text[]) ELSE 1 END;
+
 -- name: GetAssetsByOwnerSorted :many
 SELECT * FROM assets
 WHERE owner_id = $1 AND is_deleted = false
@@ -391,6 +544,31 @@ UPDATE assets
 SET width = $2, height = $3
 WHERE asset_id = $1;
 
+-- name: ListAssetsWithoutRepository :many
+SELECT asset_id, storage_path, content_hash, file_size FROM assets
+WHERE repository_id IS NULL
+ORDER BY asset_id;
+
+-- name: AssignAssetRepository :execrows
+UPDATE assets
+SET repository_id = $2,
+    storage_path = $3,
+    updated_at = CURRENT_TIMESTAMP
+WHERE asset_id = $1
+  AND repository_id IS NULL;
+
+-- name: UpdateAssetContent :exec
+-- Records an original rewritten in place. updated_at is set here although the
+-- trigger would: the scanner treats files modified after it as changed.
+UPDATE assets
+SET file_size = $2,
+    content_hash = $3,
+    quick_fingerprint = $4,
+    quick_fingerprint_version = $5,
+    storage_path = $6,
+    updated_at = NOW()
+WHERE asset_id = $1;
+
 -- name: GetAssetsByRatingRange :many
 SELECT * FROM assets
 WHERE is_deleted = false
@@ -506,10 +684,10 @@ SELECT
   COUNT(CASE WHEN type = 'AUDIO' THEN 1 END) as audio_count,
   COUNT(CASE WHEN liked = true THEN 1 END) as liked_count,
   COUNT(CASE WHEN rating IS NOT NULL THEN 1 END) as rated_count,
-  AVG(rating) as avg_rating,
-  SUM(file_size) as total_size,
-  MIN(upload_time) as oldest_upload,
-  MAX(upload_time) as newest_upload
+  COALESCE(AVG(rating), 0)::float8 as avg_rating,
+  COALESCE(SUM(file_size), 0)::bigint as total_size,
+  MIN(upload_time)::timestamptz as oldest_upload,
+  MAX(upload_time)::timestamptz as newest_upload
 FROM assets
 WHERE is_deleted = false
   AND repository_id = sqlc.arg('repository_id')::uuid
@@ -621,6 +799,16 @@ WHERE a.is_deleted = COALESCE(sqlc.narg('is_deleted')::boolean, false)
   )
   AND (sqlc.narg('camera_model')::text IS NULL OR a.specific_metadata->>'camera_model' = sqlc.narg('camera_model'))
   AND (sqlc.narg('lens_model')::text IS NULL OR a.specific_metadata->>'lens_model' = sqlc.narg('lens_model'))
+  AND (
+    sqlc.narg('custom_fields')::jsonb IS NULL
+    OR EXISTS (
+      SELECT 1
+      FROM asset_custom_fields acf
+      WHERE acf.asset_id = a.asset_id
+        AND acf.fields @> sqlc.narg('custom_fields')::jsonb
+    )
+  )
+  AND (sqlc.narg('processing_status')::text IS NULL OR a.status->>'state' = sqlc.narg('processing_status'))
   AND (
     sqlc.narg('place')::text IS NULL
     OR EXISTS (
@@ -634,6 +822,172 @@ WHERE a.is_deleted = COALESCE(sqlc.narg('is_deleted')::boolean, false)
 ORDER BY COALESCE(a.taken_time, a.upload_time) DESC, a.asset_id DESC
 LIMIT sqlc.arg('limit');
 
+-- name: GetAssetNeighborsUnified :one
+-- Returns the assets either side of an anchor asset in GetAssetsUnified order
+-- Keyset lookups on (sort_time, asset_id), so the anchor need not be paged in
+WITH anchor AS (
+  SELECT
+    a.asset_id,
+    CASE
+      WHEN sqlc.narg('sort_by')::text = 'recently_added' THEN a.upload_time
+      ELSE COALESCE(a.taken_time, a.upload_time)
+    END AS sort_time
+  FROM assets a
+  WHERE a.asset_id = sqlc.arg('anchor_id')::uuid
+),
+filtered AS NOT MATERIALIZED (
+  SELECT
+    a.asset_id,
+    CASE
+      WHEN sqlc.narg('sort_by')::text = 'recently_added' THEN a.upload_time
+      ELSE COALESCE(a.taken_time, a.upload_time)
+    END AS sort_time
+  FROM assets a
+  WHERE a.is_deleted = COALESCE(sqlc.narg('is_deleted')::boolean, false)
+    AND (sqlc.narg('asset_ids')::uuid[] IS NULL OR a.asset_id = ANY(sqlc.narg('asset_ids')::uuid[]))
+    AND (sqlc.narg('query')::text IS NULL OR a.original_filename ILIKE '%' || sqlc.narg('query') || '%')
+    AND (sqlc.narg('asset_type')::text IS NULL OR a.type = sqlc.narg('asset_type'))
+    AND (sqlc.narg('asset_types')::text[] IS NULL OR a.type = ANY(sqlc.narg('asset_types')::text[]))
+    AND (sqlc.narg('owner_id')::integer IS NULL OR a.owner_id = sqlc.narg('owner_id'))
+    AND (sqlc.narg('repository_id')::uuid IS NULL OR a.repository_id = sqlc.narg('repository_id'))
+    AND (
+      sqlc.narg('folder_path')::text IS NULL
+      OR (
+        CASE
+          WHEN sqlc.narg('folder_path')::text = '' THEN
+            CASE WHEN COALESCE(sqlc.narg('folder_recursive')::boolean, true) THEN true
+              ELSE position('/' in a.storage_path) = 0
+            END
+          ELSE
+            CASE WHEN COALESCE(sqlc.narg('folder_recursive')::boolean, true) THEN
+              a.storage_path LIKE sqlc.narg('folder_path') || '/%'
+            ELSE
+              a.storage_path LIKE sqlc.narg('folder_path') || '/%'
+              AND a.storage_path NOT LIKE sqlc.narg('folder_path') || '/%/%'
+            END
+        END
+      )
+    )
+    AND (
+      sqlc.narg('person_id')::integer IS NULL
+      OR EXISTS (
+        SELECT 1
+        FROM face_cluster_members fcm
+        JOIN face_items fi_person ON fi_person.id = fcm.face_id
+        WHERE fcm.cluster_id = sqlc.narg('person_id')
+          AND fi_person.asset_id = a.asset_id
+      )
+    )
+    AND (
+      sqlc.narg('album_id')::integer IS NULL
+      OR EXISTS (
+        SELECT 1
+        FROM album_assets aa
+        WHERE aa.asset_id = a.asset_id
+          AND aa.album_id = sqlc.narg('album_id')
+      )
+    )
+    AND (
+      sqlc.narg('tag_name')::text IS NULL
+      OR EXISTS (
+        SELECT 1
+        FROM asset_tags at
+        JOIN tags t ON t.tag_id = at.tag_id
+        WHERE at.asset_id = a.asset_id
+          AND t.tag_name = sqlc.narg('tag_name')
+          AND (sqlc.narg('tag_source')::text IS NULL OR at.source = sqlc.narg('tag_source'))
+      )
+    )
+    AND (
+      sqlc.narg('tag_names')::text[] IS NULL
+      OR (
+        SELECT COUNT(DISTINCT t2.tag_name)
+        FROM asset_tags at2
+        JOIN tags t2 ON t2.tag_id = at2.tag_id
+        WHERE at2.asset_id = a.asset_id
+          AND t2.tag_name = ANY(sqlc.narg('tag_names')::text[])
+      ) = cardinality(sqlc.narg('tag_names')::text[])
+    )
+    AND (sqlc.narg('filename_val')::text IS NULL OR
+      CASE COALESCE(sqlc.narg('filename_operator')::text, 'contains')
+        WHEN 'matches' THEN a.original_filename ILIKE sqlc.narg('filename_val')
+        WHEN 'starts_with' THEN a.original_filename ILIKE sqlc.narg('filename_val') || '%'
+        WHEN 'ends_with' THEN a.original_filename ILIKE '%' || sqlc.narg('filename_val')
+        ELSE a.original_filename ILIKE '%' || sqlc.narg('filename_val') || '%'
+      END
+    )
+    AND (sqlc.narg('date_from')::timestamptz IS NULL OR COALESCE(a.taken_time, a.upload_time) >= sqlc.narg('date_from'))
+    AND (sqlc.narg('date_to')::timestamptz IS NULL OR COALESCE(a.taken_time, a.upload_time) <= sqlc.narg('date_to'))
+    AND (sqlc.narg('is_raw')::boolean IS NULL OR
+      CASE
+        WHEN sqlc.narg('is_raw') = true THEN a.specific_metadata->>'is_raw' = 'true'
+        ELSE a.specific_metadata->>'is_raw' = 'false' OR a.specific_metadata->>'is_raw' IS NULL
+      END
+    )
+    AND (sqlc.narg('rating')::integer IS NULL OR
+      CASE
+        WHEN sqlc.narg('rating') = 0 THEN a.rating IS NULL OR a.rating = 0
+        ELSE a.rating = sqlc.narg('rating')
+      END
+    )
+    AND (sqlc.narg('liked')::boolean IS NULL OR
+      CASE
+        WHEN sqlc.narg('liked') = false THEN a.liked IS NULL OR a.liked = false
+        ELSE a.liked = true
+      END
+    )
+    AND (sqlc.narg('camera_model')::text IS NULL OR a.specific_metadata->>'camera_model' = sqlc.narg('camera_model'))
+    AND (sqlc.narg('lens_model')::text IS NULL OR a.specific_metadata->>'lens_model' = sqlc.narg('lens_model'))
+    AND (
+      sqlc.narg('custom_fields')::jsonb IS NULL
+      OR EXISTS (
+        SELECT 1
+        FROM asset_custom_fields acf
+        WHERE acf.asset_id = a.asset_id
+          AND acf.fields @> sqlc.narg('custom_fields')::jsonb
+      )
+    )
+    AND (sqlc.narg('processing_status')::text IS NULL OR a.status->>'state' = sqlc.narg('processing_status'))
+    AND (
+      sqlc.narg('location_north')::float8 IS NULL
+      OR sqlc.narg('location_south')::float8 IS NULL
+      OR sqlc.narg('location_east')::float8 IS NULL
+      OR sqlc.narg('location_west')::float8 IS NULL
+      OR (
+        a.gps_latitude IS NOT NULL
+        AND a.gps_longitude IS NOT NULL
+        AND a.gps_latitude
+          BETWEEN LEAST(sqlc.narg('location_south')::float8, sqlc.narg('location_north')::float8)
+          AND GREATEST(sqlc.narg('location_south')::float8, sqlc.narg('location_north')::float8)
+        AND (
+          CASE
+            WHEN sqlc.narg('location_west')::float8 <= sqlc.narg('location_east')::float8 THEN
+              a.gps_longitude BETWEEN sqlc.narg('location_west')::float8 AND sqlc.narg('location_east')::float8
+            ELSE
+              a.gps_longitude >= sqlc.narg('location_west')::float8
+              OR a.gps_longitude <= sqlc.narg('location_east')::float8
+          END
+        )
+      )
+    )
+)
+SELECT
+  (
+    SELECT f.asset_id
+    FROM filtered f
+    WHERE (f.sort_time, f.asset_id) > (anchor.sort_time, anchor.asset_id)
+    ORDER BY f.sort_time ASC, f.asset_id ASC
+    LIMIT 1
+  )::uuid AS previous_id,
+  (
+    SELECT f.asset_id
+    FROM filtered f
+    WHERE (f.sort_time, f.asset_id) < (anchor.sort_time, anchor.asset_id)
+    ORDER BY f.sort_time DESC, f.asset_id DESC
+    LIMIT 1
+  )::uuid AS next_id
+FROM anchor;
+
 -- name: GetAssetsUnified :many
 -- Handles: listing, filename search, and all filtering
 -- Use this for most queries unless semantic search is needed
@@ -740,6 +1094,16 @@ WITH page_ids AS MATERIALIZED (
     )
     AND (sqlc.narg('camera_model')::text IS NULL OR a.specific_metadata->>'camera_model' = sqlc.narg('camera_model'))
     AND (sqlc.narg('lens_model')::text IS NULL OR a.specific_metadata->>'lens_model' = sqlc.narg('lens_model'))
+    AND (
+      sqlc.narg('custom_fields')::jsonb IS NULL
+      OR EXISTS (
+        SELECT 1
+        FROM asset_custom_fields acf
+        WHERE acf.asset_id = a.asset_id
+          AND acf.fields @> sqlc.narg('custom_fields')::jsonb
+      )
+    )
+    AND (sqlc.narg('processing_status')::text IS NULL OR a.status->>'state' = sqlc.narg('processing_status'))
     AND (
       sqlc.narg('location_north')::float8 IS NULL
       OR sqlc.narg('location_south')::float8 IS NULL
@@ -762,6 +1126,17 @@ WITH page_ids AS MATERIALIZED (
         )
       )
     )
+    -- Keyset continuation after the last row of a previous page
+    AND (
+      sqlc.narg('cursor_time')::timestamptz IS NULL
+      OR (
+        CASE
+          WHEN sqlc.narg('sort_by')::text = 'recently_added' THEN a.upload_time
+          ELSE COALESCE(a.taken_time, a.upload_time)
+        END,
+        a.asset_id
+      ) < (sqlc.narg('cursor_time')::timestamptz, sqlc.narg('cursor_id')::uuid)
+    )
   ORDER BY
     sort_time DESC,
     a.asset_id DESC
@@ -872,6 +1247,16 @@ WHERE a.is_deleted = COALESCE(sqlc.narg('is_deleted')::boolean, false)
   )
   AND (sqlc.narg('camera_model')::text IS NULL OR a.specific_metadata->>'camera_model' = sqlc.narg('camera_model'))
   AND (sqlc.narg('lens_model')::text IS NULL OR a.specific_metadata->>'lens_model' = sqlc.narg('lens_model'))
+  AND (
+    sqlc.narg('custom_fields')::jsonb IS NULL
+    OR EXISTS (
+      SELECT 1
+      FROM asset_custom_fields acf
+      WHERE acf.asset_id = a.asset_id
+        AND acf.fields @> sqlc.narg('custom_fields')::jsonb
+    )
+  )
+  AND (sqlc.narg('processing_status')::text IS NULL OR a.status->>'state' = sqlc.narg('processing_status'))
   AND (
     sqlc.narg('location_north')::float8 IS NULL
     OR sqlc.narg('location_south')::float8 IS NULL
@@ -1001,6 +1386,16 @@ WITH filtered AS MATERIALIZED (
     )
     AND (sqlc.narg('camera_model')::text IS NULL OR a.specific_metadata->>'camera_model' = sqlc.narg('camera_model'))
     AND (sqlc.narg('lens_model')::text IS NULL OR a.specific_metadata->>'lens_model' = sqlc.narg('lens_model'))
+    AND (
+      sqlc.narg('custom_fields')::jsonb IS NULL
+      OR EXISTS (
+        SELECT 1
+        FROM asset_custom_fields acf
+        WHERE acf.asset_id = a.asset_id
+          AND acf.fields @> sqlc.narg('custom_fields')::jsonb
+      )
+    )
+    AND (sqlc.narg('processing_status')::text IS NULL OR a.status->>'state' = sqlc.narg('processing_status'))
     AND (
       sqlc.narg('location_north')::float8 IS NULL
       OR sqlc.narg('location_south')::float8 IS NULL
@@ -1077,6 +1472,14 @@ paged AS (
     END AS sort_time
   FROM browse_items bi
   JOIN assets cover ON cover.asset_id = bi.cover_asset_id
+  WHERE sqlc.narg('cursor_time')::timestamptz IS NULL
+    OR (
+      CASE
+        WHEN sqlc.narg('sort_by')::text = 'recently_added' THEN cover.upload_time
+        ELSE COALESCE(cover.taken_time, cover.upload_time)
+      END,
+      cover.asset_id
+    ) < (sqlc.narg('cursor_time')::timestamptz, sqlc.narg('cursor_id')::uuid)
   ORDER BY sort_time DESC, cover.asset_id DESC
   LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset')
 )
@@ -1194,6 +1597,16 @@ WITH filtered AS MATERIALIZED (
     )
     AND (sqlc.narg('camera_model')::text IS NULL OR a.specific_metadata->>'camera_model' = sqlc.narg('camera_model'))
     AND (sqlc.narg('lens_model')::text IS NULL OR a.specific_metadata->>'lens_model' = sqlc.narg('lens_model'))
+    AND (
+      sqlc.narg('custom_fields')::jsonb IS NULL
+      OR EXISTS (
+        SELECT 1
+        FROM asset_custom_fields acf
+        WHERE acf.asset_id = a.asset_id
+          AND acf.fields @> sqlc.narg('custom_fields')::jsonb
+      )
+    )
+    AND (sqlc.narg('processing_status')::text IS NULL OR a.status->>'state' = sqlc.narg('processing_status'))
     AND (
       sqlc.narg('location_north')::float8 IS NULL
       OR sqlc.narg('location_south')::float8 IS NULL
@@ -67,6 +67,36 @@ WHERE a.type = 'PHOTO'
   )
   AND (sqlc.narg('repository_id')::uuid IS NULL OR a.repository_id = sqlc.narg('repository_id'));
 
+-- name: CountPhotoAssetsForReindex :one
+-- Photos a reindex would visit. With missing_only, only those lacking the
+-- result of at least one of the flagged tasks count.
+SELECT COUNT(*) AS count
+FROM assets a
+WHERE a.type = 'PHOTO'
+  AND a.is_deleted = false
+  AND (sqlc.narg('repository_id')::uuid IS NULL OR a.repository_id = sqlc.narg('repository_id'))
+  AND (sqlc.narg('date_from')::timestamptz IS NULL OR COALESCE(a.taken_time, a.upload_time) >= sqlc.narg('date_from'))
+  AND (sqlc.narg('date_to')::timestamptz IS NULL OR COALESCE(a.taken_time, a.upload_time) <= sqlc.narg('date_to'))
+  AND (
+    NOT sqlc.arg('missing_only')::boolean
+    OR (sqlc.arg('semantic')::boolean AND NOT EXISTS (
+      SELECT 1
+      FROM search_embeddings se
+      WHERE se.asset_id = a.asset_id
+        AND se.frame_ts_ms IS NULL
+    ))
+    OR (sqlc.arg('ocr')::boolean AND NOT EXISTS (
+      SELECT 1
+      FROM ocr_results o
+      WHERE o.asset_id = a.asset_id
+    ))
+    OR (sqlc.arg('face')::boolean AND NOT EXISTS (
+      SELECT 1
+      FROM face_results f
+      WHERE f.asset_id = a.asset_id
+    ))
+  );
+
 -- name: ListPhotoAssetsForIndexingBatch :many
 WITH page_ids AS MATERIALIZED (
   SELECT
@@ -76,6 +106,8 @@ WITH page_ids AS MATERIALIZED (
   WHERE a.type = 'PHOTO'
     AND a.is_deleted = false
     AND (sqlc.narg('repository_id')::uuid IS NULL OR a.repository_id = sqlc.narg('repository_id'))
+    AND (sqlc.narg('date_from')::timestamptz IS NULL OR COALESCE(a.taken_time, a.upload_time) >= sqlc.narg('date_from'))
+    AND (sqlc.narg('date_to')::timestamptz IS NULL OR COALESCE(a.taken_time, a.upload_time) <= sqlc.narg('date_to'))
   ORDER BY COALESCE(a.taken_time, a.upload_time) DESC, a.asset_id DESC
   LIMIT sqlc.arg('limit')
   OFFSET sqlc.arg('offset')
@@ -100,6 +132,8 @@ WITH page_ids AS MATERIALIZED (
         AND se.frame_ts_ms IS NULL
     )
     AND (sqlc.narg('repository_id')::uuid IS NULL OR a.repository_id = sqlc.narg('repository_id'))
+    AND (sqlc.narg('date_from')::timestamptz IS NULL OR COALESCE(a.taken_time, a.upload_time) >= sqlc.narg('date_from'))
+    AND (sqlc.narg('date_to')::timestamptz IS NULL OR COALESCE(a.taken_time, a.upload_time) <= sqlc.narg('date_to'))
   ORDER BY COALESCE(a.taken_time, a.upload_time) DESC, a.asset_id DESC
   LIMIT sqlc.arg('limit')
   OFFSET sqlc.arg('offset')
@@ -123,6 +157,8 @@ WITH page_ids AS MATERIALIZED (
       WHERE o.asset_id = a.asset_id
     )
     AND (sqlc.narg('repository_id')::uuid IS NULL OR a.repository_id = sqlc.narg('repository_id'))
+    AND (sqlc.narg('date_from')::timestamptz IS NULL OR COALESCE(a.taken_time, a.upload_time) >= sqlc.narg('date_from'))
+    AND (sqlc.narg('date_to')::timestamptz IS NULL OR COALESCE(a.taken_time, a.upload_time) <= sqlc.narg('date_to'))
   ORDER BY COALESCE(a.taken_time, a.upload_time) DESC, a.asset_id DESC
   LIMIT sqlc.arg('limit')
   OFFSET sqlc.arg('offset')
@@ -146,6 +182,8 @@ WITH page_ids AS MATERIALIZED (
       WHERE f.asset_id = a.asset_id
     )
     AND (sqlc.narg('repository_id')::uuid IS NULL OR a.repository_id = sqlc.narg('repository_id'))
+    AND (sqlc.narg('date_from')::timestamptz IS NULL OR COALESCE(a.taken_time, a.upload_time) >= sqlc.narg('date_from'))
+    AND (sqlc.narg('date_to')::timestamptz IS NULL OR COALESCE(a.taken_time, a.upload_time) <= sqlc.narg('date_to'))
   ORDER BY COALESCE(a.taken_time, a.upload_time) DESC, a.asset_id DESC
   LIMIT sqlc.arg('limit')
   OFFSET sqlc.arg('offset')
@@ -106,6 +106,39 @@ WHERE repo_id = $1;
 DELETE FROM repositories
 WHERE repo_id = ANY($1::uuid[]);
 
+-- name: CountRepositoryAssets :one
+-- Every asset row still pointing at the repository, Trash included.
+SELECT COUNT(*) FROM assets
+WHERE repository_id = $1;
+
+-- name: DetachRepositoryAssets :execrows
+-- Unlinks every asset from a repository that is about to be removed, records
+-- the repository it came from, and with soft_delete also moves it to Trash.
+WITH removed AS (
+    INSERT INTO asset_repository_removals (asset_id, repository_id, repository_name)
+    SELECT a.asset_id, r.repo_id, r.name
+    FROM assets a
+    JOIN repositories r ON r.repo_id = a.repository_id
+    WHERE a.repository_id = sqlc.arg('repository_id')
+    ON CONFLICT (asset_id) DO UPDATE
+    SET repository_id = EXCLUDED.repository_id,
+        repository_name = EXCLUDED.repository_name,
+        removed_at = CURRENT_TIMESTAMP
+)
+UPDATE assets
+SET repository_id = NULL,
+    deleted_at = CASE
+        WHEN sqlc.arg('soft_delete')::boolean AND NOT is_deleted THEN CURRENT_TIMESTAMP
+        ELSE deleted_at
+    END,
+    is_deleted = is_deleted OR sqlc.arg('soft_delete')::boolean,
+    updated_at = CURRENT_TIMESTAMP
+WHERE repository_id = sqlc.arg('repository_id');
+
+-- name: GetAssetRepositoryRemoval :one
+SELECT * FROM asset_repository_removals
+WHERE asset_id = $1;
+
 -- name: RepositoryExists :one
 SELECT EXISTS(
     SELECT 1 FROM repositories
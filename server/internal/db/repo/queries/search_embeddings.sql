@@ -20,3 +20,11 @@ WHERE asset_id = $1 AND frame_ts_ms IS NULL;
 -- name: CountAssetsWithSearchEmbedding :one
 SELECT COUNT(DISTINCT asset_id) AS count
 FROM search_embeddings;
+
+-- name: GetSearchEmbeddingColumnDimension :one
+-- pgvector stores a vector column's dimension as its type modifier.
+SELECT a.atttypmod::int AS dimensions
+FROM pg_attribute a
+WHERE a.attrelid = 'search_embeddings'::regclass
+  AND a.attname = 'vector'
+  AND NOT a.attisdropped;
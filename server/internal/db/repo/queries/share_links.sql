@@ -1,6 +1,6 @@
 -- name: CreateShareLink :one
-INSERT INTO share_links (owner_id, token_hash, title, description, source_kind, source_ref, asset_ids, asset_count, allow_download, include_originals, expires_at)
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+INSERT INTO share_links (owner_id, token_hash, title, description, source_kind, source_ref, asset_ids, asset_count, allow_download, include_originals, expires_at, password_hash)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
 RETURNING *;
 
 -- name: ListShareLinksByOwner :many
@@ -35,6 +35,10 @@ WHERE share_id = $1 AND owner_id = $2 AND (status = 'revoked' OR expires_at < CU
 SELECT * FROM share_links
 WHERE token_hash = $1 AND status = 'active' AND expires_at > CURRENT_TIMESTAMP;
 
+-- name: GetShareLinkByTokenHash :one
+-- Finds a link by token whatever its status, for owner-side revocation.
+SELECT * FROM share_links WHERE token_hash = $1 AND owner_id = $2;
+
 -- name: IncrementShareLinkView :exec
 UPDATE share_links
 SET view_count = view_count + 1, last_viewed_at = CURRENT_TIMESTAMP
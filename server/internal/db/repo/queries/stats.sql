@@ -84,3 +84,89 @@ WHERE
     AND (sqlc.narg('repository_id')::uuid IS NULL OR repository_id = sqlc.narg('repository_id'))
     AND (taken_time IS NOT NULL OR upload_time IS NOT NULL)
 ORDER BY year DESC;
+
+-- name: GetGearCameraCounts :many
+-- Shots per camera body for gear analytics, optionally scoped to a repository and owner.
+SELECT
+    (specific_metadata->>'camera_model')::text AS camera_model,
+    COUNT(*) AS count
+FROM assets
+WHERE
+    is_deleted = false
+    AND (sqlc.narg('repository_id')::uuid IS NULL OR repository_id = sqlc.narg('repository_id'))
+    AND (sqlc.narg('owner_id')::integer IS NULL OR owner_id = sqlc.narg('owner_id'))
+    AND COALESCE(specific_metadata->>'camera_model', '') != ''
+GROUP BY 1
+ORDER BY count DESC, camera_model
+LIMIT sqlc.arg('limit');
+
+-- name: GetGearLensCounts :many
+-- Shots per lens for gear analytics, optionally scoped to a repository and owner.
+SELECT
+    (specific_metadata->>'lens_model')::text AS lens_model,
+    COUNT(*) AS count
+FROM assets
+WHERE
+    is_deleted = false
+    AND (sqlc.narg('repository_id')::uuid IS NULL OR repository_id = sqlc.narg('repository_id'))
+    AND (sqlc.narg('owner_id')::integer IS NULL OR owner_id = sqlc.narg('owner_id'))
+    AND COALESCE(specific_metadata->>'lens_model', '') != ''
+GROUP BY 1
+ORDER BY count DESC, lens_model
+LIMIT sqlc.arg('limit');
+
+-- name: GetGearFocalLengthCounts :many
+-- Most-used focal lengths rounded to whole millimetres; the regex guards the numeric cast.
+SELECT
+    round((specific_metadata->>'focal_length')::numeric)::integer AS focal_length,
+    COUNT(*) AS count
+FROM assets
+WHERE
+    is_deleted = false
+    AND (sqlc.narg('repository_id')::uuid IS NULL OR repository_id = sqlc.narg('repository_id'))
+    AND (sqlc.narg('owner_id')::integer IS NULL OR owner_id = sqlc.narg('owner_id'))
+    AND specific_metadata->>'focal_length' ~ '^[0-9]+(\.[0-9]+)?$'
+    AND (specific_metadata->>'focal_length')::numeric >= 0.5
+GROUP BY 1
+ORDER BY count DESC, focal_length
+LIMIT sqlc.arg('limit');
+
+-- name: GetOverviewTypeCounts :many
+-- Live assets and their bytes per asset type for the dashboard overview.
+SELECT
+    type,
+    COUNT(*) AS count,
+    COALESCE(SUM(file_size), 0)::bigint AS total_size
+FROM assets
+WHERE
+    is_deleted = false
+    AND (sqlc.narg('repository_id')::uuid IS NULL OR repository_id = sqlc.narg('repository_id'))
+    AND (sqlc.narg('owner_id')::integer IS NULL OR owner_id = sqlc.narg('owner_id'))
+GROUP BY type
+ORDER BY type;
+
+-- name: GetOverviewAddedSince :one
+-- Live assets uploaded at or after since, for the dashboard overview.
+SELECT COUNT(*) AS count
+FROM assets
+WHERE
+    is_deleted = false
+    AND (sqlc.narg('repository_id')::uuid IS NULL OR repository_id = sqlc.narg('repository_id'))
+    AND (sqlc.narg('owner_id')::integer IS NULL OR owner_id = sqlc.narg('owner_id'))
+    AND upload_time >= sqlc.arg('since');
+
+-- name: GetOverviewRepositoryStorage :many
+-- Live assets and bytes per repository; repositories without assets report zero.
+SELECT
+    r.repo_id,
+    r.name,
+    COUNT(a.asset_id) AS asset_count,
+    COALESCE(SUM(a.file_size), 0)::bigint AS total_size
+FROM repositories r
+LEFT JOIN assets a
+    ON a.repository_id = r.repo_id
+    AND a.is_deleted = false
+    AND (sqlc.narg('owner_id')::integer IS NULL OR a.owner_id = sqlc.narg('owner_id'))
+WHERE sqlc.narg('repository_id')::uuid IS NULL OR r.repo_id = sqlc.narg('repository_id')
+GROUP BY r.repo_id, r.name
+ORDER BY total_size DESC, r.name;
@@ -9,3 +9,12 @@ SET
     updated_at = NOW()
 WHERE id = 1
 RETURNING *;
+
+-- name: SetProcessingPaused :one
+UPDATE system_state
+SET
+    processing_paused = $1,
+    processing_paused_at = CASE WHEN $1 THEN COALESCE(processing_paused_at, NOW()) END,
+    updated_at = NOW()
+WHERE id = 1
+RETURNING *;
@@ -35,6 +35,35 @@ RETURNING *;
 -- name: DeleteTag :exec
 DELETE FROM tags WHERE tag_id = $1;
 
+-- name: RenameTag :one
+UPDATE tags
+SET tag_name = sqlc.arg('tag_name')
+WHERE tag_id = sqlc.arg('tag_id')
+RETURNING *;
+
+-- name: MergeAssetTagsIntoTag :execrows
+-- Links every asset of the source tag to the target. An asset that already
+-- carries the target keeps one link with the higher confidence, preferring
+-- user-provided tags like MergeAssetTagsForDuplicate.
+INSERT INTO asset_tags (asset_id, tag_id, confidence, source)
+SELECT
+    t.asset_id,
+    sqlc.arg('target_tag_id'),
+    t.confidence,
+    t.source
+FROM asset_tags t
+WHERE t.tag_id = sqlc.arg('source_tag_id')
+ON CONFLICT (asset_id, tag_id) DO UPDATE
+SET confidence = GREATEST(asset_tags.confidence, EXCLUDED.confidence),
+    source = CASE
+        WHEN EXCLUDED.source = 'user' THEN 'user'
+        WHEN asset_tags.source = 'user' THEN asset_tags.source
+        ELSE EXCLUDED.source
+    END;
+
+-- name: DeleteAssetTagsByTag :exec
+DELETE FROM asset_tags WHERE tag_id = $1;
+
 -- name: GetTagSummaries :many
 -- Browsable tag vocabulary with counts/cover, distinct from
 -- SearchTagsByName (definition-only autocomplete). Groups by (tag_id,
@@ -43,6 +72,7 @@ DELETE FROM tags WHERE tag_id = $1;
 SELECT
   t.tag_id,
   t.tag_name,
+  t.category,
   at.source,
   COUNT(DISTINCT a.asset_id)::bigint AS asset_count,
   (ARRAY_AGG(a.asset_id ORDER BY COALESCE(a.taken_time, a.upload_time) DESC))[1]::uuid AS cover_asset_id,
@@ -54,7 +84,21 @@ WHERE a.is_deleted = false
   AND (sqlc.narg('owner_id')::integer IS NULL OR a.owner_id = sqlc.narg('owner_id'))
   AND (sqlc.narg('repository_id')::uuid IS NULL OR a.repository_id = sqlc.narg('repository_id'))
   AND (sqlc.narg('source')::text IS NULL OR at.source = sqlc.narg('source'))
+  AND (sqlc.narg('category')::text IS NULL OR t.category = sqlc.narg('category'))
   AND (sqlc.narg('query')::text IS NULL OR t.tag_name ILIKE '%' || sqlc.narg('query')::text || '%')
-GROUP BY t.tag_id, t.tag_name, at.source
+GROUP BY t.tag_id, t.tag_name, t.category, at.source
 ORDER BY asset_count DESC, t.tag_name ASC
 LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: DeleteAssetTagsBelowConfidence :execrows
+-- Maintenance sweep for AI tag noise: drops tag links from the given sources
+-- whose confidence falls below the threshold. Manual tags are never passed in.
+DELETE FROM asset_tags
+WHERE source = ANY(sqlc.arg('sources')::text[])
+  AND confidence < sqlc.arg('min_confidence')::numeric;
+
+-- name: DeleteOrphanedAITags :execrows
+-- Removes AI-generated tag definitions no asset references any more.
+DELETE FROM tags t
+WHERE t.is_ai_generated = true
+  AND NOT EXISTS (SELECT 1 FROM asset_tags at WHERE at.tag_id = t.tag_id);
@@ -0,0 +1,30 @@
+-- Upload batches: cancellable groups of batch uploads and their ingest jobs.
+
+-- name: EnsureUploadBatch :one
+-- Creates the batch on first use and returns it either way, so callers can
+-- check its owner and status.
+INSERT INTO upload_batches (batch_id, user_id)
+VALUES (sqlc.arg('batch_id'), sqlc.arg('user_id'))
+ON CONFLICT (batch_id) DO UPDATE SET batch_id = EXCLUDED.batch_id
+RETURNING *;
+
+-- name: GetUploadBatch :one
+SELECT * FROM upload_batches
+WHERE batch_id = $1;
+
+-- name: CancelUploadBatch :one
+-- Marks a batch cancelled; cancelling again keeps the first cancelled_at.
+UPDATE upload_batches
+SET status = 'cancelled', cancelled_at = COALESCE(cancelled_at, CURRENT_TIMESTAMP)
+WHERE batch_id = $1
+RETURNING *;
+
+-- name: AddUploadBatchJob :exec
+INSERT INTO upload_batch_jobs (batch_id, job_id)
+VALUES ($1, $2)
+ON CONFLICT DO NOTHING;
+
+-- name: ListUploadBatchJobIDs :many
+SELECT job_id FROM upload_batch_jobs
+WHERE batch_id = $1
+ORDER BY job_id;
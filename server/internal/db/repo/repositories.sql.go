@@ -48,6 +48,19 @@ func (q *Queries) CountRepositoriesByStatus(ctx context.Context, status dbtypes.
 	return count, err
 }
 
+const countRepositoryAssets = `-- name: CountRepositoryAssets :one
+SELECT COUNT(*) FROM assets
+WHERE repository_id = $1
+`
+
+// Every asset row still pointing at the repository, Trash included.
+func (q *Queries) CountRepositoryAssets(ctx context.Context, repositoryID pgtype.UUID) (int64, error) {
+	row := q.db.QueryRow(ctx, countRepositoryAssets, repositoryID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createRepository = `-- name: CreateRepository :one
 INSERT INTO repositories (
     repo_id,
@@ -128,6 +141,61 @@ func (q *Queries) DeleteRepository(ctx context.Context, repoID pgtype.UUID) erro
 	return err
 }
 
+const detachRepositoryAssets = `-- name: DetachRepositoryAssets :execrows
+WITH removed AS (
+    INSERT INTO asset_repository_removals (asset_id, repository_id, repository_name)
+    SELECT a.asset_id, r.repo_id, r.name
+    FROM assets a
+    JOIN repositories r ON r.repo_id = a.repository_id
+    WHERE a.repository_id = $1
+    ON CONFLICT (asset_id) DO UPDATE
+    SET repository_id = EXCLUDED.repository_id,
+        repository_name = EXCLUDED.repository_name,
+        removed_at = CURRENT_TIMESTAMP
+)
+UPDATE assets
+SET repository_id = NULL,
+    deleted_at = CASE
+        WHEN $2::boolean AND NOT is_deleted THEN CURRENT_TIMESTAMP
+        ELSE deleted_at
+    END,
+    is_deleted = is_deleted OR $2::boolean,
+    updated_at = CURRENT_TIMESTAMP
+WHERE repository_id = $1
+`
+
+type DetachRepositoryAssetsParams struct {
+	RepositoryID pgtype.UUID `db:"repository_id" json:"repository_id"`
+	SoftDelete   bool        `db:"soft_delete" json:"soft_delete"`
+}
+
+// Unlinks every asset from a repository that is about to be removed, records
+// the repository it came from, and with soft_delete also moves it to Trash.
+func (q *Queries) DetachRepositoryAssets(ctx context.Context, arg DetachRepositoryAssetsParams) (int64, error) {
+	result, err := q.db.Exec(ctx, detachRepositoryAssets, arg.RepositoryID, arg.SoftDelete)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const getAssetRepositoryRemoval = `-- name: GetAssetRepositoryRemoval :one
+SELECT asset_id, repository_id, repository_name, removed_at FROM asset_repository_removals
+WHERE asset_id = $1
+`
+
+func (q *Queries) GetAssetRepositoryRemoval(ctx context.Context, assetID pgtype.UUID) (AssetRepositoryRemoval, error) {
+	row := q.db.QueryRow(ctx, getAssetRepositoryRemoval, assetID)
+	var i AssetRepositoryRemoval
+	err := row.Scan(
+		&i.AssetID,
+		&i.RepositoryID,
+		&i.RepositoryName,
+		&i.RemovedAt,
+	)
+	return i, err
+}
+
 const getHostOwnerID = `-- name: GetHostOwnerID :one
 SELECT candidate.owner_id::integer AS host_owner_id
 FROM (
@@ -68,6 +68,22 @@ func (q *Queries) GetPrimarySearchEmbedding(ctx context.Context, assetID pgtype.
 	return i, err
 }
 
+const getSearchEmbeddingColumnDimension = `-- name: GetSearchEmbeddingColumnDimension :one
+SELECT a.atttypmod::int AS dimensions
+FROM pg_attribute a
+WHERE a.attrelid = 'search_embeddings'::regclass
+  AND a.attname = 'vector'
+  AND NOT a.attisdropped
+`
+
+// pgvector stores a vector column's dimension as its type modifier.
+func (q *Queries) GetSearchEmbeddingColumnDimension(ctx context.Context) (int32, error) {
+	row := q.db.QueryRow(ctx, getSearchEmbeddingColumnDimension)
+	var dimensions int32
+	err := row.Scan(&dimensions)
+	return dimensions, err
+}
+
 const insertSearchEmbedding = `-- name: InsertSearchEmbedding :exec
 
 INSERT INTO search_embeddings (asset_id, space_id, frame_ts_ms, vector, model_id)
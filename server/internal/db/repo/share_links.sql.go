@@ -12,9 +12,9 @@ import (
 )
 
 const createShareLink = `-- name: CreateShareLink :one
-INSERT INTO share_links (owner_id, token_hash, title, description, source_kind, source_ref, asset_ids, asset_count, allow_download, include_originals, expires_at)
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
-RETURNING share_id, owner_id, token_hash, title, description, source_kind, source_ref, asset_ids, asset_count, allow_download, include_originals, status, expires_at, created_at, updated_at, revoked_at, last_viewed_at, view_count
+INSERT INTO share_links (owner_id, token_hash, title, description, source_kind, source_ref, asset_ids, asset_count, allow_download, include_originals, expires_at, password_hash)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+RETURNING share_id, owner_id, token_hash, title, description, source_kind, source_ref, asset_ids, asset_count, allow_download, include_originals, status, expires_at, created_at, updated_at, revoked_at, last_viewed_at, view_count, password_hash
 `
 
 type CreateShareLinkParams struct {
@@ -29,6 +29,7 @@ type CreateShareLinkParams struct {
 	AllowDownload    bool               `db:"allow_download" json:"allow_download"`
 	IncludeOriginals bool               `db:"include_originals" json:"include_originals"`
 	ExpiresAt        pgtype.Timestamptz `db:"expires_at" json:"expires_at"`
+	PasswordHash     *string            `db:"password_hash" json:"password_hash"`
 }
 
 func (q *Queries) CreateShareLink(ctx context.Context, arg CreateShareLinkParams) (ShareLink, error) {
@@ -44,6 +45,7 @@ func (q *Queries) CreateShareLink(ctx context.Context, arg CreateShareLinkParams
 		arg.AllowDownload,
 		arg.IncludeOriginals,
 		arg.ExpiresAt,
+		arg.PasswordHash,
 	)
 	var i ShareLink
 	err := row.Scan(
@@ -65,6 +67,7 @@ func (q *Queries) CreateShareLink(ctx context.Context, arg CreateShareLinkParams
 		&i.RevokedAt,
 		&i.LastViewedAt,
 		&i.ViewCount,
+		&i.PasswordHash,
 	)
 	return i, err
 }
@@ -91,7 +94,7 @@ const extendShareLinkExpiry = `-- name: ExtendShareLinkExpiry :one
 UPDATE share_links
 SET expires_at = $3, updated_at = CURRENT_TIMESTAMP
 WHERE share_id = $1 AND owner_id = $2
-RETURNING share_id, owner_id, token_hash, title, description, source_kind, source_ref, asset_ids, asset_count, allow_download, include_originals, status, expires_at, created_at, updated_at, revoked_at, last_viewed_at, view_count
+RETURNING share_id, owner_id, token_hash, title, description, source_kind, source_ref, asset_ids, asset_count, allow_download, include_originals, status, expires_at, created_at, updated_at, revoked_at, last_viewed_at, view_count, password_hash
 `
 
 type ExtendShareLinkExpiryParams struct {
@@ -122,12 +125,13 @@ func (q *Queries) ExtendShareLinkExpiry(ctx context.Context, arg ExtendShareLink
 		&i.RevokedAt,
 		&i.LastViewedAt,
 		&i.ViewCount,
+		&i.PasswordHash,
 	)
 	return i, err
 }
 
 const getActiveShareLinkByTokenHash = `-- name: GetActiveShareLinkByTokenHash :one
-SELECT share_id, owner_id, token_hash, title, description, source_kind, source_ref, asset_ids, asset_count, allow_download, include_originals, status, expires_at, created_at, updated_at, revoked_at, last_viewed_at, view_count FROM share_links
+SELECT share_id, owner_id, token_hash, title, description, source_kind, source_ref, asset_ids, asset_count, allow_download, include_originals, status, expires_at, created_at, updated_at, revoked_at, last_viewed_at, view_count, password_hash FROM share_links
 WHERE token_hash = $1 AND status = 'active' AND expires_at > CURRENT_TIMESTAMP
 `
 
@@ -153,12 +157,13 @@ func (q *Queries) GetActiveShareLinkByTokenHash(ctx context.Context, tokenHash [
 		&i.RevokedAt,
 		&i.LastViewedAt,
 		&i.ViewCount,
+		&i.PasswordHash,
 	)
 	return i, err
 }
 
 const getShareLinkByID = `-- name: GetShareLinkByID :one
-SELECT share_id, owner_id, token_hash, title, description, source_kind, source_ref, asset_ids, asset_count, allow_download, include_originals, status, expires_at, created_at, updated_at, revoked_at, last_viewed_at, view_count FROM share_links WHERE share_id = $1 AND owner_id = $2
+SELECT share_id, owner_id, token_hash, title, description, source_kind, source_ref, asset_ids, asset_count, allow_download, include_originals, status, expires_at, created_at, updated_at, revoked_at, last_viewed_at, view_count, password_hash FROM share_links WHERE share_id = $1 AND owner_id = $2
 `
 
 type GetShareLinkByIDParams struct {
@@ -188,6 +193,44 @@ func (q *Queries) GetShareLinkByID(ctx context.Context, arg GetShareLinkByIDPara
 		&i.RevokedAt,
 		&i.LastViewedAt,
 		&i.ViewCount,
+		&i.PasswordHash,
+	)
+	return i, err
+}
+
+const getShareLinkByTokenHash = `-- name: GetShareLinkByTokenHash :one
+SELECT share_id, owner_id, token_hash, title, description, source_kind, source_ref, asset_ids, asset_count, allow_download, include_originals, status, expires_at, created_at, updated_at, revoked_at, last_viewed_at, view_count, password_hash FROM share_links WHERE token_hash = $1 AND owner_id = $2
+`
+
+type GetShareLinkByTokenHashParams struct {
+	TokenHash []byte `db:"token_hash" json:"token_hash"`
+	OwnerID   int32  `db:"owner_id" json:"owner_id"`
+}
+
+// Finds a link by token whatever its status, for owner-side revocation.
+func (q *Queries) GetShareLinkByTokenHash(ctx context.Context, arg GetShareLinkByTokenHashParams) (ShareLink, error) {
+	row := q.db.QueryRow(ctx, getShareLinkByTokenHash, arg.TokenHash, arg.OwnerID)
+	var i ShareLink
+	err := row.Scan(
+		&i.ShareID,
+		&i.OwnerID,
+		&i.TokenHash,
+		&i.Title,
+		&i.Description,
+		&i.SourceKind,
+		&i.SourceRef,
+		&i.AssetIds,
+		&i.AssetCount,
+		&i.AllowDownload,
+		&i.IncludeOriginals,
+		&i.Status,
+		&i.ExpiresAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.RevokedAt,
+		&i.LastViewedAt,
+		&i.ViewCount,
+		&i.PasswordHash,
 	)
 	return i, err
 }
@@ -204,7 +247,7 @@ func (q *Queries) IncrementShareLinkView(ctx context.Context, shareID pgtype.UUI
 }
 
 const listShareLinksByOwner = `-- name: ListShareLinksByOwner :many
-SELECT share_id, owner_id, token_hash, title, description, source_kind, source_ref, asset_ids, asset_count, allow_download, include_originals, status, expires_at, created_at, updated_at, revoked_at, last_viewed_at, view_count FROM share_links WHERE owner_id = $1 ORDER BY created_at DESC
+SELECT share_id, owner_id, token_hash, title, description, source_kind, source_ref, asset_ids, asset_count, allow_download, include_originals, status, expires_at, created_at, updated_at, revoked_at, last_viewed_at, view_count, password_hash FROM share_links WHERE owner_id = $1 ORDER BY created_at DESC
 `
 
 func (q *Queries) ListShareLinksByOwner(ctx context.Context, ownerID int32) ([]ShareLink, error) {
@@ -235,6 +278,7 @@ func (q *Queries) ListShareLinksByOwner(ctx context.Context, ownerID int32) ([]S
 			&i.RevokedAt,
 			&i.LastViewedAt,
 			&i.ViewCount,
+			&i.PasswordHash,
 		); err != nil {
 			return nil, err
 		}
@@ -250,7 +294,7 @@ const revokeShareLink = `-- name: RevokeShareLink :one
 UPDATE share_links
 SET status = 'revoked', revoked_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
 WHERE share_id = $1 AND owner_id = $2
-RETURNING share_id, owner_id, token_hash, title, description, source_kind, source_ref, asset_ids, asset_count, allow_download, include_originals, status, expires_at, created_at, updated_at, revoked_at, last_viewed_at, view_count
+RETURNING share_id, owner_id, token_hash, title, description, source_kind, source_ref, asset_ids, asset_count, allow_download, include_originals, status, expires_at, created_at, updated_at, revoked_at, last_viewed_at, view_count, password_hash
 `
 
 type RevokeShareLinkParams struct {
@@ -280,6 +324,7 @@ func (q *Queries) RevokeShareLink(ctx context.Context, arg RevokeShareLinkParams
 		&i.RevokedAt,
 		&i.LastViewedAt,
 		&i.ViewCount,
+		&i.PasswordHash,
 	)
 	return i, err
 }
@@ -288,7 +333,7 @@ const updateShareLinkSettings = `-- name: UpdateShareLinkSettings :one
 UPDATE share_links
 SET title = $3, description = $4, allow_download = $5, include_originals = $6, updated_at = CURRENT_TIMESTAMP
 WHERE share_id = $1 AND owner_id = $2
-RETURNING share_id, owner_id, token_hash, title, description, source_kind, source_ref, asset_ids, asset_count, allow_download, include_originals, status, expires_at, created_at, updated_at, revoked_at, last_viewed_at, view_count
+RETURNING share_id, owner_id, token_hash, title, description, source_kind, source_ref, asset_ids, asset_count, allow_download, include_originals, status, expires_at, created_at, updated_at, revoked_at, last_viewed_at, view_count, password_hash
 `
 
 type UpdateShareLinkSettingsParams struct {
@@ -329,6 +374,7 @@ func (q *Queries) UpdateShareLinkSettings(ctx context.Context, arg UpdateShareLi
 		&i.RevokedAt,
 		&i.LastViewedAt,
 		&i.ViewCount,
+		&i.PasswordHash,
 	)
 	return i, err
 }
@@ -182,6 +182,267 @@ func (q *Queries) GetFocalLengthDistribution(ctx context.Context, repositoryID p
 	return items, nil
 }
 
+const getGearCameraCounts = `-- name: GetGearCameraCounts :many
+SELECT
+    (specific_metadata->>'camera_model')::text AS camera_model,
+    COUNT(*) AS count
+FROM assets
+WHERE
+    is_deleted = false
+    AND ($1::uuid IS NULL OR repository_id = $1)
+    AND ($2::integer IS NULL OR owner_id = $2)
+    AND COALESCE(specific_metadata->>'camera_model', '') != ''
+GROUP BY 1
+ORDER BY count DESC, camera_model
+LIMIT $3
+`
+
+type GetGearCameraCountsParams struct {
+	RepositoryID pgtype.UUID `db:"repository_id" json:"repository_id"`
+	OwnerID      *int32      `db:"owner_id" json:"owner_id"`
+	Limit        int32       `db:"limit" json:"limit"`
+}
+
+type GetGearCameraCountsRow struct {
+	CameraModel string `db:"camera_model" json:"camera_model"`
+	Count       int64  `db:"count" json:"count"`
+}
+
+// Shots per camera body for gear analytics, optionally scoped to a repository and owner.
+func (q *Queries) GetGearCameraCounts(ctx context.Context, arg GetGearCameraCountsParams) ([]GetGearCameraCountsRow, error) {
+	rows, err := q.db.Query(ctx, getGearCameraCounts, arg.RepositoryID, arg.OwnerID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetGearCameraCountsRow
+	for rows.Next() {
+		var i GetGearCameraCountsRow
+		if err := rows.Scan(&i.CameraModel, &i.Count); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getGearFocalLengthCounts = `-- name: GetGearFocalLengthCounts :many
+SELECT
+    round((specific_metadata->>'focal_length')::numeric)::integer AS focal_length,
+    COUNT(*) AS count
+FROM assets
+WHERE
+    is_deleted = false
+    AND ($1::uuid IS NULL OR repository_id = $1)
+    AND ($2::integer IS NULL OR owner_id = $2)
+    AND specific_metadata->>'focal_length' ~ '^[0-9]+(\.[0-9]+)?$'
+    AND (specific_metadata->>'focal_length')::numeric >= 0.5
+GROUP BY 1
+ORDER BY count DESC, focal_length
+LIMIT $3
+`
+
+type GetGearFocalLengthCountsParams struct {
+	RepositoryID pgtype.UUID `db:"repository_id" json:"repository_id"`
+	OwnerID      *int32      `db:"owner_id" json:"owner_id"`
+	Limit        int32       `db:"limit" json:"limit"`
+}
+
+type GetGearFocalLengthCountsRow struct {
+	FocalLength int32 `db:"focal_length" json:"focal_length"`
+	Count       int64 `db:"count" json:"count"`
+}
+
+// Most-used focal lengths rounded to whole millimetres; the regex guards the numeric cast.
+func (q *Queries) GetGearFocalLengthCounts(ctx context.Context, arg GetGearFocalLengthCountsParams) ([]GetGearFocalLengthCountsRow, error) {
+	rows, err := q.db.Query(ctx, getGearFocalLengthCounts, arg.RepositoryID, arg.OwnerID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetGearFocalLengthCountsRow
+	for rows.Next() {
+		var i GetGearFocalLengthCountsRow
+		if err := rows.Scan(&i.FocalLength, &i.Count); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getGearLensCounts = `-- name: GetGearLensCounts :many
+SELECT
+    (specific_metadata->>'lens_model')::text AS lens_model,
+    COUNT(*) AS count
+FROM assets
+WHERE
+    is_deleted = false
+    AND ($1::uuid IS NULL OR repository_id = $1)
+    AND ($2::integer IS NULL OR owner_id = $2)
+    AND COALESCE(specific_metadata->>'lens_model', '') != ''
+GROUP BY 1
+ORDER BY count DESC, lens_model
+LIMIT $3
+`
+
+type GetGearLensCountsParams struct {
+	RepositoryID pgtype.UUID `db:"repository_id" json:"repository_id"`
+	OwnerID      *int32      `db:"owner_id" json:"owner_id"`
+	Limit        int32       `db:"limit" json:"limit"`
+}
+
+type GetGearLensCountsRow struct {
+	LensModel string `db:"lens_model" json:"lens_model"`
+	Count     int64  `db:"count" json:"count"`
+}
+
+// Shots per lens for gear analytics, optionally scoped to a repository and owner.
+func (q *Queries) GetGearLensCounts(ctx context.Context, arg GetGearLensCountsParams) ([]GetGearLensCountsRow, error) {
+	rows, err := q.db.Query(ctx, getGearLensCounts, arg.RepositoryID, arg.OwnerID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetGearLensCountsRow
+	for rows.Next() {
+		var i GetGearLensCountsRow
+		if err := rows.Scan(&i.LensModel, &i.Count); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getOverviewAddedSince = `-- name: GetOverviewAddedSince :one
+SELECT COUNT(*) AS count
+FROM assets
+WHERE
+    is_deleted = false
+    AND ($1::uuid IS NULL OR repository_id = $1)
+    AND ($2::integer IS NULL OR owner_id = $2)
+    AND upload_time >= $3
+`
+
+type GetOverviewAddedSinceParams struct {
+	RepositoryID pgtype.UUID        `db:"repository_id" json:"repository_id"`
+	OwnerID      *int32             `db:"owner_id" json:"owner_id"`
+	Since        pgtype.Timestamptz `db:"since" json:"since"`
+}
+
+// Live assets uploaded at or after since, for the dashboard overview.
+func (q *Queries) GetOverviewAddedSince(ctx context.Context, arg GetOverviewAddedSinceParams) (int64, error) {
+	row := q.db.QueryRow(ctx, getOverviewAddedSince, arg.RepositoryID, arg.OwnerID, arg.Since)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const getOverviewRepositoryStorage = `-- name: GetOverviewRepositoryStorage :many
+SELECT
+    r.repo_id,
+    r.name,
+    COUNT(a.asset_id) AS asset_count,
+    COALESCE(SUM(a.file_size), 0)::bigint AS total_size
+FROM repositories r
+LEFT JOIN assets a
+    ON a.repository_id = r.repo_id
+    AND a.is_deleted = false
+    AND ($1::integer IS NULL OR a.owner_id = $1)
+WHERE $2::uuid IS NULL OR r.repo_id = $2
+GROUP BY r.repo_id, r.name
+ORDER BY total_size DESC, r.name
+`
+
+type GetOverviewRepositoryStorageParams struct {
+	OwnerID      *int32      `db:"owner_id" json:"owner_id"`
+	RepositoryID pgtype.UUID `db:"repository_id" json:"repository_id"`
+}
+
+type GetOverviewRepositoryStorageRow struct {
+	RepoID     pgtype.UUID `db:"repo_id" json:"repo_id"`
+	Name       string      `db:"name" json:"name"`
+	AssetCount int64       `db:"asset_count" json:"asset_count"`
+	TotalSize  int64       `db:"total_size" json:"total_size"`
+}
+
+// Live assets and bytes per repository; repositories without assets report zero.
+func (q *Queries) GetOverviewRepositoryStorage(ctx context.Context, arg GetOverviewRepositoryStorageParams) ([]GetOverviewRepositoryStorageRow, error) {
+	rows, err := q.db.Query(ctx, getOverviewRepositoryStorage, arg.OwnerID, arg.RepositoryID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetOverviewRepositoryStorageRow
+	for rows.Next() {
+		var i GetOverviewRepositoryStorageRow
+		if err := rows.Scan(&i.RepoID, &i.Name, &i.AssetCount, &i.TotalSize); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getOverviewTypeCounts = `-- name: GetOverviewTypeCounts :many
+SELECT
+    type,
+    COUNT(*) AS count,
+    COALESCE(SUM(file_size), 0)::bigint AS total_size
+FROM assets
+WHERE
+    is_deleted = false
+    AND ($1::uuid IS NULL OR repository_id = $1)
+    AND ($2::integer IS NULL OR owner_id = $2)
+GROUP BY type
+ORDER BY type
+`
+
+type GetOverviewTypeCountsParams struct {
+	RepositoryID pgtype.UUID `db:"repository_id" json:"repository_id"`
+	OwnerID      *int32      `db:"owner_id" json:"owner_id"`
+}
+
+type GetOverviewTypeCountsRow struct {
+	Type      string `db:"type" json:"type"`
+	Count     int64  `db:"count" json:"count"`
+	TotalSize int64  `db:"total_size" json:"total_size"`
+}
+
+// Live assets and their bytes per asset type for the dashboard overview.
+func (q *Queries) GetOverviewTypeCounts(ctx context.Context, arg GetOverviewTypeCountsParams) ([]GetOverviewTypeCountsRow, error) {
+	rows, err := q.db.Query(ctx, getOverviewTypeCounts, arg.RepositoryID, arg.OwnerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetOverviewTypeCountsRow
+	for rows.Next() {
+		var i GetOverviewTypeCountsRow
+		if err := rows.Scan(&i.Type, &i.Count, &i.TotalSize); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getTimeDistributionHourly = `-- name: GetTimeDistributionHourly :many
 SELECT
     EXTRACT(HOUR FROM COALESCE(taken_time, upload_time))::integer AS hour,
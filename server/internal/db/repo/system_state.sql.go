@@ -10,14 +10,20 @@ import (
 )
 
 const getSystemState = `-- name: GetSystemState :one
-SELECT id, bootstrap_phase, updated_at FROM system_state
+SELECT id, bootstrap_phase, updated_at, processing_paused, processing_paused_at FROM system_state
 WHERE id = 1
 `
 
 func (q *Queries) GetSystemState(ctx context.Context) (SystemState, error) {
 	row := q.db.QueryRow(ctx, getSystemState)
 	var i SystemState
-	err := row.Scan(&i.ID, &i.BootstrapPhase, &i.UpdatedAt)
+	err := row.Scan(
+		&i.ID,
+		&i.BootstrapPhase,
+		&i.UpdatedAt,
+		&i.ProcessingPaused,
+		&i.ProcessingPausedAt,
+	)
 	return i, err
 }
 
@@ -27,12 +33,41 @@ SET
     bootstrap_phase = $1,
     updated_at = NOW()
 WHERE id = 1
-RETURNING id, bootstrap_phase, updated_at
+RETURNING id, bootstrap_phase, updated_at, processing_paused, processing_paused_at
 `
 
 func (q *Queries) SetBootstrapPhase(ctx context.Context, bootstrapPhase string) (SystemState, error) {
 	row := q.db.QueryRow(ctx, setBootstrapPhase, bootstrapPhase)
 	var i SystemState
-	err := row.Scan(&i.ID, &i.BootstrapPhase, &i.UpdatedAt)
+	err := row.Scan(
+		&i.ID,
+		&i.BootstrapPhase,
+		&i.UpdatedAt,
+		&i.ProcessingPaused,
+		&i.ProcessingPausedAt,
+	)
+	return i, err
+}
+
+const setProcessingPaused = `-- name: SetProcessingPaused :one
+UPDATE system_state
+SET
+    processing_paused = $1,
+    processing_paused_at = CASE WHEN $1 THEN COALESCE(processing_paused_at, NOW()) END,
+    updated_at = NOW()
+WHERE id = 1
+RETURNING id, bootstrap_phase, updated_at, processing_paused, processing_paused_at
+`
+
+func (q *Queries) SetProcessingPaused(ctx context.Context, processingPaused bool) (SystemState, error) {
+	row := q.db.QueryRow(ctx, setProcessingPaused, processingPaused)
+	var i SystemState
+	err := row.Scan(
+		&i.ID,
+		&i.BootstrapPhase,
+		&i.UpdatedAt,
+		&i.ProcessingPaused,
+		&i.ProcessingPausedAt,
+	)
 	return i, err
 }
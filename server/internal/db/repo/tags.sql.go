@@ -35,6 +35,51 @@ func (q *Queries) CreateTag(ctx context.Context, arg CreateTagParams) (Tag, erro
 	return i, err
 }
 
+const deleteAssetTagsBelowConfidence = `-- name: DeleteAssetTagsBelowConfidence :execrows
+DELETE FROM asset_tags
+WHERE source = ANY($1::text[])
+  AND confidence < $2::numeric
+`
+
+type DeleteAssetTagsBelowConfidenceParams struct {
+	Sources       []string       `db:"sources" json:"sources"`
+	MinConfidence pgtype.Numeric `db:"min_confidence" json:"min_confidence"`
+}
+
+// Maintenance sweep for AI tag noise: drops tag links from the given sources
+// whose confidence falls below the threshold. Manual tags are never passed in.
+func (q *Queries) DeleteAssetTagsBelowConfidence(ctx context.Context, arg DeleteAssetTagsBelowConfidenceParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteAssetTagsBelowConfidence, arg.Sources, arg.MinConfidence)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteAssetTagsByTag = `-- name: DeleteAssetTagsByTag :exec
+DELETE FROM asset_tags WHERE tag_id = $1
+`
+
+func (q *Queries) DeleteAssetTagsByTag(ctx context.Context, tagID int32) error {
+	_, err := q.db.Exec(ctx, deleteAssetTagsByTag, tagID)
+	return err
+}
+
+const deleteOrphanedAITags = `-- name: DeleteOrphanedAITags :execrows
+DELETE FROM tags t
+WHERE t.is_ai_generated = true
+  AND NOT EXISTS (SELECT 1 FROM asset_tags at WHERE at.tag_id = t.tag_id)
+`
+
+// Removes AI-generated tag definitions no asset references any more.
+func (q *Queries) DeleteOrphanedAITags(ctx context.Context) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteOrphanedAITags)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const deleteTag = `-- name: DeleteTag :exec
 DELETE FROM tags WHERE tag_id = $1
 `
@@ -80,6 +125,7 @@ const getTagSummaries = `-- name: GetTagSummaries :many
 SELECT
   t.tag_id,
   t.tag_name,
+  t.category,
   at.source,
   COUNT(DISTINCT a.asset_id)::bigint AS asset_count,
   (ARRAY_AGG(a.asset_id ORDER BY COALESCE(a.taken_time, a.upload_time) DESC))[1]::uuid AS cover_asset_id,
@@ -91,16 +137,18 @@ WHERE a.is_deleted = false
   AND ($1::integer IS NULL OR a.owner_id = $1)
   AND ($2::uuid IS NULL OR a.repository_id = $2)
   AND ($3::text IS NULL OR at.source = $3)
-  AND ($4::text IS NULL OR t.tag_name ILIKE '%' || $4::text || '%')
-GROUP BY t.tag_id, t.tag_name, at.source
+  AND ($4::text IS NULL OR t.category = $4)
+  AND ($5::text IS NULL OR t.tag_name ILIKE '%' || $5::text || '%')
+GROUP BY t.tag_id, t.tag_name, t.category, at.source
 ORDER BY asset_count DESC, t.tag_name ASC
-LIMIT $6 OFFSET $5
+LIMIT $7 OFFSET $6
 `
 
 type GetTagSummariesParams struct {
 	OwnerID      *int32      `db:"owner_id" json:"owner_id"`
 	RepositoryID pgtype.UUID `db:"repository_id" json:"repository_id"`
 	Source       *string     `db:"source" json:"source"`
+	Category     *string     `db:"category" json:"category"`
 	Query        *string     `db:"query" json:"query"`
 	Offset       int32       `db:"offset" json:"offset"`
 	Limit        int32       `db:"limit" json:"limit"`
@@ -109,6 +157,7 @@ type GetTagSummariesParams struct {
 type GetTagSummariesRow struct {
 	TagID        int32              `db:"tag_id" json:"tag_id"`
 	TagName      string             `db:"tag_name" json:"tag_name"`
+	Category     *string            `db:"category" json:"category"`
 	Source       string             `db:"source" json:"source"`
 	AssetCount   int64              `db:"asset_count" json:"asset_count"`
 	CoverAssetID pgtype.UUID        `db:"cover_asset_id" json:"cover_asset_id"`
@@ -124,6 +173,7 @@ func (q *Queries) GetTagSummaries(ctx context.Context, arg GetTagSummariesParams
 		arg.OwnerID,
 		arg.RepositoryID,
 		arg.Source,
+		arg.Category,
 		arg.Query,
 		arg.Offset,
 		arg.Limit,
@@ -138,6 +188,7 @@ func (q *Queries) GetTagSummaries(ctx context.Context, arg GetTagSummariesParams
 		if err := rows.Scan(
 			&i.TagID,
 			&i.TagName,
+			&i.Category,
 			&i.Source,
 			&i.AssetCount,
 			&i.CoverAssetID,
@@ -220,6 +271,64 @@ func (q *Queries) ListTags(ctx context.Context, arg ListTagsParams) ([]Tag, erro
 	return items, nil
 }
 
+const mergeAssetTagsIntoTag = `-- name: MergeAssetTagsIntoTag :execrows
+INSERT INTO asset_tags (asset_id, tag_id, confidence, source)
+SELECT
+    t.asset_id,
+    $1,
+    t.confidence,
+    t.source
+FROM asset_tags t
+WHERE t.tag_id = $2
+ON CONFLICT (asset_id, tag_id) DO UPDATE
+SET confidence = GREATEST(asset_tags.confidence, EXCLUDED.confidence),
+    source = CASE
+        WHEN EXCLUDED.source = 'user' THEN 'user'
+        WHEN asset_tags.source = 'user' THEN asset_tags.source
+        ELSE EXCLUDED.source
+    END
+`
+
+type MergeAssetTagsIntoTagParams struct {
+	TargetTagID int32 `db:"target_tag_id" json:"target_tag_id"`
+	SourceTagID int32 `db:"source_tag_id" json:"source_tag_id"`
+}
+
+// Links every asset of the source tag to the target. An asset that already
+// carries the target keeps one link with the higher confidence, preferring
+// user-provided tags like MergeAssetTagsForDuplicate.
+func (q *Queries) MergeAssetTagsIntoTag(ctx context.Context, arg MergeAssetTagsIntoTagParams) (int64, error) {
+	result, err := q.db.Exec(ctx, mergeAssetTagsIntoTag, arg.TargetTagID, arg.SourceTagID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const renameTag = `-- name: RenameTag :one
+UPDATE tags
+SET tag_name = $1
+WHERE tag_id = $2
+RETURNING tag_id, tag_name, category, is_ai_generated
+`
+
+type RenameTagParams struct {
+	TagName string `db:"tag_name" json:"tag_name"`
+	TagID   int32  `db:"tag_id" json:"tag_id"`
+}
+
+func (q *Queries) RenameTag(ctx context.Context, arg RenameTagParams) (Tag, error) {
+	row := q.db.QueryRow(ctx, renameTag, arg.TagName, arg.TagID)
+	var i Tag
+	err := row.Scan(
+		&i.TagID,
+		&i.TagName,
+		&i.Category,
+		&i.IsAiGenerated,
+	)
+	return i, err
+}
+
 const searchTagsByName = `-- name: SearchTagsByName :many
 SELECT tag_id, tag_name, category, is_ai_generated FROM tags
 WHERE $2::text IS NULL
@@ -0,0 +1,131 @@
+package processors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"server/internal/db/dbtypes"
+	"server/internal/db/repo"
+	"server/internal/queue/jobs"
+	"server/internal/storage"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/riverqueue/river"
+	"go.uber.org/zap"
+)
+
+// enqueueArchiveOriginal queues archiving of an asset whose pipeline just
+// completed, when its repository archives originals. Failures are logged:
+// the original then simply stays in the repository.
+func (ap *AssetProcessor) enqueueArchiveOriginal(ctx context.Context, assetID pgtype.UUID) {
+	_, repository, err := ap.loadAssetAndRepo(ctx, assetID)
+	if err != nil || !repository.Config.ArchivesOriginals() {
+		return
+	}
+	if _, err := ap.queueClient.Insert(ctx, jobs.ArchiveOriginalArgs{AssetID: assetID}, &river.InsertOpts{Queue: "archive_original"}); err != nil {
+		ap.logger.Warn("failed to queue original archiving",
+			zap.String("asset_id", assetID.String()),
+			zap.Error(err))
+	}
+}
+
+// ProcessArchiveOriginal moves an asset's original to its repository's
+// archive path once every pipeline task completed and the web derivatives
+// that are served in its place exist. The copy is verified and recorded
+// before the original is removed; the asset keeps its storage path.
+func (ap *AssetProcessor) ProcessArchiveOriginal(ctx context.Context, args jobs.ArchiveOriginalArgs) error {
+	asset, repository, err := ap.loadAssetAndRepo(ctx, args.AssetID)
+	if err != nil {
+		return err
+	}
+	if !repository.Config.ArchivesOriginals() || asset.StoragePath == nil {
+		return nil
+	}
+	source := filepath.Join(repository.Path, filepath.FromSlash(*asset.StoragePath))
+
+	if _, err := ap.queries.GetArchivedOriginal(ctx, asset.AssetID); err == nil {
+		// Recorded by an earlier attempt that failed to remove the original.
+		if err := os.Remove(source); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("remove archived original: %w", err)
+		}
+		return nil
+	} else if !errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("get archived original: %w", err)
+	}
+
+	for _, task := range trackedPipelineTasks(dbtypes.AssetType(asset.Type)) {
+		if !taskCompleted(asset.Status, task) {
+			return nil
+		}
+	}
+	if err := ap.verifyWebDerivatives(ctx, asset, repository); err != nil {
+		ap.logger.Warn("keeping original in repository",
+			zap.String("asset_id", asset.AssetID.String()),
+			zap.Error(err))
+		return nil
+	}
+
+	target := storage.ArchivedOriginalPath(repository.Config.LocalSettings.ArchivePath,
+		uuid.UUID(repository.RepoID.Bytes).String(), *asset.StoragePath)
+	if err := storage.ArchiveOriginal(source, target); err != nil {
+		return fmt.Errorf("archive original: %w", err)
+	}
+	if err := ap.queries.RecordArchivedOriginal(ctx, repo.RecordArchivedOriginalParams{
+		AssetID:     asset.AssetID,
+		ArchivePath: target,
+	}); err != nil {
+		return fmt.Errorf("record archived original: %w", err)
+	}
+	if err := os.Remove(source); err != nil {
+		return fmt.Errorf("remove archived original: %w", err)
+	}
+	ap.repoAudit(repository.Path).Operation("asset.original.archive",
+		zap.String("asset_id", asset.AssetID.String()),
+		zap.String("storage_path", *asset.StoragePath),
+		zap.String("archive_path", target),
+	)
+	return nil
+}
+
+// verifyWebDerivatives checks that the files served instead of the original
+// exist: thumbnails for photos and videos, web versions for videos and audio.
+func (ap *AssetProcessor) verifyWebDerivatives(ctx context.Context, asset *repo.Asset, repository repo.Repository) error {
+	assetType := dbtypes.AssetType(asset.Type)
+	var derivatives []string
+	if assetType == dbtypes.AssetTypePhoto || assetType == dbtypes.AssetTypeVideo {
+		thumbnails, err := ap.queries.GetThumbnailsByAsset(ctx, asset.AssetID)
+		if err != nil {
+			return fmt.Errorf("list thumbnails: %w", err)
+		}
+		if len(thumbnails) == 0 {
+			return fmt.Errorf("no thumbnails")
+		}
+		for _, thumbnail := range thumbnails {
+			derivatives = append(derivatives, filepath.Join(repository.Path, filepath.FromSlash(thumbnail.StoragePath)))
+		}
+	}
+	switch assetType {
+	case dbtypes.AssetTypePhoto:
+	case dbtypes.AssetTypeVideo:
+		derivatives = append(derivatives, filepath.Join(repository.Path, storage.DefaultStructure.VideosDir, "web", asset.ContentHash+"_web.mp4"))
+	case dbtypes.AssetTypeAudio:
+		derivatives = append(derivatives, filepath.Join(repository.Path, storage.DefaultStructure.AudiosDir, "web", asset.ContentHash+"_web.mp3"))
+	default:
+		return fmt.Errorf("no web derivatives for %s assets", asset.Type)
+	}
+	for _, path := range derivatives {
+		info, err := os.Stat(path)
+		if err != nil {
+			return fmt.Errorf("web derivative: %w", err)
+		}
+		if info.Size() == 0 {
+			return fmt.Errorf("web derivative %s is empty", path)
+		}
+	}
+	return nil
+}
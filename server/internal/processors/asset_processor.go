@@ -1,30 +1,37 @@
 package processors
 
 import (
+	"context"
 	"time"
 
 	"server/config"
 	"server/internal/db/repo"
 	"server/internal/logging"
+	"server/internal/queue/jobs"
 	"server/internal/service"
 	"server/internal/sourcing"
 	"server/internal/storage"
+	"server/internal/utils/keyedmutex"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/riverqueue/river"
 	"go.uber.org/zap"
 )
 
 // AssetPayload matches the ingest-stage payload fields (kept for compatibility with task workers).
 type AssetPayload struct {
-	ContentHash      string    `json:"contentHash" river:"unique"`
-	QuickFingerprint string    `json:"quickFingerprint,omitempty"`
-	StagedPath       string    `json:"stagedPath"`
-	UserID           string    `json:"userId" river:"unique"`
-	Timestamp        time.Time `json:"timestamp"`
-	ContentType      string    `json:"contentType,omitempty"`
-	FileName         string    `json:"fileName,omitempty"`
-	RepositoryID     string    `json:"repositoryId,omitempty"` // Repository UUID
+	ContentHash      string             `json:"contentHash" river:"unique"`
+	QuickFingerprint string             `json:"quickFingerprint,omitempty"`
+	StagedPath       string             `json:"stagedPath"`
+	UserID           string             `json:"userId" river:"unique"`
+	Timestamp        time.Time          `json:"timestamp"`
+	ContentType      string             `json:"contentType,omitempty"`
+	FileName         string             `json:"fileName,omitempty"`
+	RepositoryID     string             `json:"repositoryId,omitempty"` // Repository UUID
+	CaptureHints     *jobs.CaptureHints `json:"captureHints,omitempty"`
+	// ClientContentHash is the client's claimed BLAKE3 hash, verified at ingest.
+	ClientContentHash string `json:"clientContentHash,omitempty"`
 }
 
 // AssetProcessor holds shared dependencies for per-task processors.
@@ -40,8 +47,18 @@ type AssetProcessor struct {
 	lumenService     service.LumenService
 	transcodeConfig  config.TranscodeConfig
 	toolsConfig      config.ToolsConfig
+	scanConfig       config.RepositoryScanConfig
 	logger           *zap.Logger
 	auditProvider    logging.RepositoryAuditProvider
+	thumbnailLocks   keyedmutex.Mutex
+	assetLocks       AssetLocker
+}
+
+// AssetLocker serializes work on one asset across jobs and requests.
+// queue.AdvisoryAssetLocks implements it; the interface is repeated here
+// because the queue package imports this one.
+type AssetLocker interface {
+	WithAssetLock(ctx context.Context, assetID pgtype.UUID, fn func(context.Context) error) error
 }
 
 // NewAssetProcessor constructs the processor with required dependencies.
@@ -57,8 +74,10 @@ func NewAssetProcessor(
 	lumenService service.LumenService,
 	transcodeConfig config.TranscodeConfig,
 	toolsConfig config.ToolsConfig,
+	scanConfig config.RepositoryScanConfig,
 	logger *zap.Logger,
 	auditProvider logging.RepositoryAuditProvider,
+	assetLocks AssetLocker,
 ) *AssetProcessor {
 	if logger == nil {
 		logger = zap.NewNop()
@@ -78,9 +97,19 @@ func NewAssetProcessor(
 		lumenService:     lumenService,
 		transcodeConfig:  transcodeConfig,
 		toolsConfig:      toolsConfig,
+		scanConfig:       scanConfig,
 		logger:           logger.With(zap.String("component", "processor")),
 		auditProvider:    auditProvider,
+		assetLocks:       assetLocks,
+	}
+}
+
+// withAssetLock runs fn under assetID's lock, or directly without a locker.
+func (ap *AssetProcessor) withAssetLock(ctx context.Context, assetID pgtype.UUID, fn func(context.Context) error) error {
+	if ap.assetLocks == nil {
+		return fn(ctx)
 	}
+	return ap.assetLocks.WithAssetLock(ctx, assetID, fn)
 }
 
 func (ap *AssetProcessor) repoAudit(repoPath string) logging.RepositoryAuditLogger {
@@ -0,0 +1,72 @@
+package processors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/riverqueue/river"
+
+	"server/internal/db/dbtypes"
+	"server/internal/db/repo"
+	"server/internal/queue/jobs"
+	"server/internal/utils/exif"
+)
+
+// ErrRatingWriteUnsupported is returned when an asset's original cannot carry
+// a written-back rating (videos, audio, RAW and formats other than JPEG and
+// TIFF).
+var ErrRatingWriteUnsupported = errors.New("asset original cannot store a rating")
+
+// ratingRewriteReason names the temp files of rating write-backs.
+const ratingRewriteReason = "rating"
+
+// QueueRatingWrite queues writing an asset's stored rating into its original,
+// so the request setting the rating does not wait for exiftool.
+func (ap *AssetProcessor) QueueRatingWrite(ctx context.Context, assetID pgtype.UUID) error {
+	if _, err := ap.queueClient.Insert(ctx, jobs.WriteRatingArgs{AssetID: assetID}, &river.InsertOpts{Queue: "write_rating"}); err != nil {
+		return fmt.Errorf("enqueue write_rating: %w", err)
+	}
+	return nil
+}
+
+// ProcessWriteRating writes the asset's stored rating into the XMP Rating tag
+// of its JPEG or TIFF original so other tools see it. The worker holds the
+// asset's lock. The original is replaced in place without a trash copy: only
+// the XMP packet changes. Formats that cannot carry a rating are skipped.
+func (ap *AssetProcessor) ProcessWriteRating(ctx context.Context, args jobs.WriteRatingArgs) error {
+	asset, repository, err := ap.loadAssetAndRepo(ctx, args.AssetID)
+	if err != nil {
+		return err
+	}
+	rating := 0
+	if asset.Rating != nil {
+		rating = int(*asset.Rating)
+	}
+	err = ap.writeRatingToOriginal(ctx, asset, repository.Path, rating)
+	if errors.Is(err, ErrRatingWriteUnsupported) {
+		return nil
+	}
+	return err
+}
+
+func (ap *AssetProcessor) writeRatingToOriginal(ctx context.Context, asset *repo.Asset, repoPath string, rating int) error {
+	if dbtypes.AssetType(asset.Type) != dbtypes.AssetTypePhoto {
+		return fmt.Errorf("%w: %s assets", ErrRatingWriteUnsupported, strings.ToLower(asset.Type))
+	}
+	switch strings.ToLower(asset.MimeType) {
+	case "image/jpeg", "image/jpg", "image/tiff":
+	default:
+		return fmt.Errorf("%w: %s", ErrRatingWriteUnsupported, asset.MimeType)
+	}
+
+	exifTool := ap.toolsConfig.ExifToolCommand()
+	return ap.rewriteOriginal(ctx, asset, repoPath, ratingRewriteReason, false, nil, func(src, dst string) error {
+		if err := copyFile(src, dst); err != nil {
+			return err
+		}
+		return exif.WriteRating(ctx, exifTool, dst, rating)
+	})
+}
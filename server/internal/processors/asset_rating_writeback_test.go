@@ -0,0 +1,69 @@
+package processors
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/jpeg"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"server/config"
+	"server/internal/db/dbtypes"
+	"server/internal/storage"
+	"server/internal/utils/exif"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteRatingToOriginalRewritesInPlace(t *testing.T) {
+	exifToolPath, err := exec.LookPath("exiftool")
+	if err != nil {
+		t.Skip("exiftool not installed")
+	}
+
+	repoPath := t.TempDir()
+	var buf bytes.Buffer
+	require.NoError(t, jpeg.Encode(&buf, image.NewGray(image.Rect(0, 0, 2, 2)), nil))
+	require.NoError(t, os.WriteFile(filepath.Join(repoPath, "photo.jpg"), buf.Bytes(), 0o644))
+	asset := refreshTestAsset(t, "photo.jpg", `{}`)
+	asset.MimeType = "image/jpeg"
+
+	svc := &stubTransformAssetService{}
+	ap := &AssetProcessor{assetService: svc, toolsConfig: config.ToolsConfig{ExifToolPath: exifToolPath}}
+	require.NoError(t, ap.writeRatingToOriginal(context.Background(), asset, repoPath, 4))
+
+	tags, err := exif.ReadAllTags(context.Background(), exifToolPath, filepath.Join(repoPath, "photo.jpg"))
+	require.NoError(t, err)
+	require.EqualValues(t, 4, tags["XMP-xmp:Rating"])
+	require.NotNil(t, svc.content)
+	require.Nil(t, svc.dimensions)
+
+	require.Empty(t, trashBackups(t, repoPath), "ratings do not copy the original into the trash")
+	leftovers, err := os.ReadDir(filepath.Join(repoPath, storage.DefaultStructure.TempDir))
+	require.NoError(t, err)
+	require.Empty(t, leftovers)
+}
+
+func TestWriteRatingToOriginalSkipsUnsupportedFormats(t *testing.T) {
+	repoPath := t.TempDir()
+	svc := &stubTransformAssetService{}
+	ap := &AssetProcessor{assetService: svc}
+
+	cases := map[string]dbtypes.AssetType{
+		"image/x-canon-cr2": dbtypes.AssetTypePhoto,
+		"image/heic":        dbtypes.AssetTypePhoto,
+		"video/mp4":         dbtypes.AssetTypeVideo,
+	}
+	for mimeType, assetType := range cases {
+		asset := refreshTestAsset(t, "photo.jpg", `{}`)
+		asset.MimeType = mimeType
+		asset.Type = string(assetType)
+		err := ap.writeRatingToOriginal(context.Background(), asset, repoPath, 3)
+		require.ErrorIs(t, err, ErrRatingWriteUnsupported, mimeType)
+	}
+	require.Nil(t, svc.content)
+	require.Empty(t, trashBackups(t, repoPath))
+}
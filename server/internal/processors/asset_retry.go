@@ -15,6 +15,7 @@ import (
 	"server/internal/db/dbtypes/status"
 	"server/internal/db/repo"
 	"server/internal/queue/jobs"
+	"server/internal/sourcing"
 )
 
 // ProcessRetryTask is the entry point for the retry worker. It handles AssetRetryPayload.
@@ -68,8 +69,13 @@ func (ap *AssetProcessor) RetryAsset(ctx context.Context, assetIDStr string, ret
 	// Determine which tasks to retry
 	tasksToRetry := retryTasks
 	if len(tasksToRetry) == 0 {
-		// If no specific tasks requested, retry all failed tasks
-		tasksToRetry = currentStatus.GetFailedTasks()
+		// If no specific tasks requested, retry all failed tasks but a hash
+		// mismatch, which re-running cannot change.
+		for _, task := range currentStatus.GetFailedTasks() {
+			if task != sourcing.TaskVerifyHash {
+				tasksToRetry = append(tasksToRetry, task)
+			}
+		}
 	}
 
 	if len(tasksToRetry) == 0 {
@@ -117,18 +123,21 @@ func (ap *AssetProcessor) enqueueRetryTasks(
 		OriginalFilename: asset.OriginalFilename,
 		FileSize:         asset.FileSize,
 		MimeType:         asset.MimeType,
+		Force:            true,
 	}
 	commonThumb := jobs.ThumbnailArgs{
 		AssetID:     asset.AssetID,
 		RepoPath:    repository.Path,
 		StoragePath: *asset.StoragePath,
 		AssetType:   assetType,
+		Force:       true,
 	}
 	commonTranscode := jobs.TranscodeArgs{
 		AssetID:     asset.AssetID,
 		RepoPath:    repository.Path,
 		StoragePath: *asset.StoragePath,
 		AssetType:   assetType,
+		Force:       true,
 	}
 
 	// Enqueue tasks based on queue names (bijection: queue name = task name)
@@ -195,7 +204,7 @@ func (ap *AssetProcessor) enqueueRetryTasks(
 	if assetType == dbtypes.AssetTypePhoto {
 		// Check each ML task queue name
 		if queueSet["process_semantic"] || queueSet["process_bioclip"] || queueSet["process_ocr"] || queueSet["process_face"] {
-			err := ap.retryMLJobs(ctx, asset, queueSet)
+			err := ap.retryMLJobs(ctx, asset, repository, queueSet)
 			if err != nil {
 				return fmt.Errorf("enqueue ML retry: %w", err)
 			}
@@ -207,8 +216,8 @@ func (ap *AssetProcessor) enqueueRetryTasks(
 }
 
 // retryMLJobs re-enqueues specific ML pointer jobs that failed.
-func (ap *AssetProcessor) retryMLJobs(ctx context.Context, asset *repo.Asset, taskSet map[string]bool) error {
-	mlConfig, err := ap.settingsService.GetEffectiveMLConfig(ctx)
+func (ap *AssetProcessor) retryMLJobs(ctx context.Context, asset *repo.Asset, repository repo.Repository, taskSet map[string]bool) error {
+	mlConfig, err := ap.mlConfigFor(ctx, repository)
 	if err != nil {
 		return fmt.Errorf("load ML settings: %w", err)
 	}
@@ -7,8 +7,10 @@ import (
 
 	"server/internal/db/dbtypes"
 	statusdb "server/internal/db/dbtypes/status"
+	"server/internal/db/repo"
 
 	"github.com/jackc/pgx/v5/pgtype"
+	"go.uber.org/zap"
 )
 
 const (
@@ -35,6 +37,42 @@ func buildTrackedProcessingStatus(assetType dbtypes.AssetType, message string) (
 	return status.ToJSONB()
 }
 
+// skipCompletedTask reports whether taskName already completed for asset in
+// an earlier run. Duplicate jobs do happen (a River retry racing a manual
+// reprocess), and rerunning a finished task writes its derivatives twice.
+// force bypasses the check for intentional reprocessing.
+func (ap *AssetProcessor) skipCompletedTask(ctx context.Context, asset *repo.Asset, taskName string, force bool) bool {
+	if force || !taskCompleted(asset.Status, taskName) {
+		return false
+	}
+	// Thumbnails can be removed after the task finished (cleanup, rebuild);
+	// the status alone does not prove they still exist.
+	if taskName == taskThumbnail {
+		thumbnails, err := ap.queries.GetThumbnailsByAsset(ctx, asset.AssetID)
+		if err != nil || len(thumbnails) == 0 {
+			return false
+		}
+	}
+	ap.logger.Info("skipping completed asset task",
+		zap.String("asset_id", asset.AssetID.String()),
+		zap.String("task", taskName),
+	)
+	return true
+}
+
+// taskCompleted reports whether the stored asset status marks taskName
+// complete.
+func taskCompleted(rawStatus []byte, taskName string) bool {
+	if len(rawStatus) == 0 {
+		return false
+	}
+	current, err := statusdb.FromJSONB(rawStatus)
+	if err != nil {
+		return false
+	}
+	return current.Tasks[taskName].State == statusdb.TaskComplete
+}
+
 func (ap *AssetProcessor) runTrackedAssetTask(
 	ctx context.Context,
 	assetID pgtype.UUID,
@@ -55,9 +93,14 @@ func (ap *AssetProcessor) runTrackedAssetTask(
 		return err
 	}
 
+	pipelineComplete := false
 	ap.tryMutateAssetStatus(ctx, assetID, func(status *statusdb.AssetStatus) {
 		status.MarkTaskComplete(taskName, successMessage)
+		pipelineComplete = status.State == statusdb.StateComplete
 	})
+	if pipelineComplete {
+		ap.enqueueArchiveOriginal(ctx, assetID)
+	}
 	return nil
 }
 
@@ -0,0 +1,138 @@
+package processors
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	statusdb "server/internal/db/dbtypes/status"
+	"server/internal/db/repo"
+	"server/internal/queue/jobs"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// processedAssetDB answers the queries the task guards issue: GetAssetByID
+// returns an asset with the given status, GetRepository a repository at
+// /library, and GetThumbnailsByAsset the given number of rows. Every Exec is
+// recorded under its sqlc query name.
+type processedAssetDB struct {
+	repo.DBTX
+	status     []byte
+	thumbnails int
+	executed   []string
+}
+
+func (db *processedAssetDB) QueryRow(_ context.Context, sql string, _ ...interface{}) pgx.Row {
+	return processedAssetRow{db: db, name: strings.Fields(sql)[2]}
+}
+
+func (db *processedAssetDB) Query(context.Context, string, ...interface{}) (pgx.Rows, error) {
+	return &thumbnailRows{remaining: db.thumbnails}, nil
+}
+
+func (db *processedAssetDB) Exec(_ context.Context, sql string, _ ...interface{}) (pgconn.CommandTag, error) {
+	db.executed = append(db.executed, strings.Fields(sql)[2])
+	return pgconn.NewCommandTag("UPDATE 1"), nil
+}
+
+type processedAssetRow struct {
+	db   *processedAssetDB
+	name string
+}
+
+func (r processedAssetRow) Scan(dest ...any) error {
+	switch r.name {
+	case "GetAssetByID":
+		*dest[22].(*[]byte) = r.db.status
+		return nil
+	case "GetRepository":
+		*dest[2].(*string) = "/library"
+		return nil
+	}
+	return pgx.ErrNoRows
+}
+
+type thumbnailRows struct {
+	pgx.Rows
+	remaining int
+}
+
+func (r *thumbnailRows) Next() bool {
+	if r.remaining == 0 {
+		return false
+	}
+	r.remaining--
+	return true
+}
+
+func (r *thumbnailRows) Scan(...any) error { return nil }
+func (r *thumbnailRows) Close()            {}
+func (r *thumbnailRows) Err() error        { return nil }
+
+func taskStatus(t *testing.T, taskName string, state statusdb.TaskState) []byte {
+	t.Helper()
+	status := statusdb.NewTrackedProcessingStatus("processing", []string{taskName})
+	switch state {
+	case statusdb.TaskComplete:
+		status.MarkTaskComplete(taskName, "done")
+	case statusdb.TaskFailed:
+		status.MarkTaskFailed(taskName, "failed", "boom")
+	}
+	raw, err := status.ToJSONB()
+	require.NoError(t, err)
+	return raw
+}
+
+func guardTestProcessor(db *processedAssetDB) *AssetProcessor {
+	return &AssetProcessor{queries: repo.New(db), logger: zap.NewNop()}
+}
+
+func TestSkipCompletedTask(t *testing.T) {
+	tests := []struct {
+		name       string
+		task       string
+		state      statusdb.TaskState
+		thumbnails int
+		force      bool
+		want       bool
+	}{
+		{name: "fresh asset", task: taskMetadata, state: statusdb.TaskPending},
+		{name: "completed", task: taskMetadata, state: statusdb.TaskComplete, want: true},
+		{name: "completed but forced", task: taskMetadata, state: statusdb.TaskComplete, force: true},
+		{name: "failed", task: taskTranscode, state: statusdb.TaskFailed},
+		{name: "thumbnails present", task: taskThumbnail, state: statusdb.TaskComplete, thumbnails: 3, want: true},
+		{name: "thumbnails removed", task: taskThumbnail, state: statusdb.TaskComplete},
+	}
+	for _, tt := range tests {
+		db := &processedAssetDB{thumbnails: tt.thumbnails}
+		asset := &repo.Asset{
+			AssetID: pgtype.UUID{Bytes: uuid.New(), Valid: true},
+			Status:  taskStatus(t, tt.task, tt.state),
+		}
+		got := guardTestProcessor(db).skipCompletedTask(context.Background(), asset, tt.task, tt.force)
+		require.Equal(t, tt.want, got, tt.name)
+	}
+
+	require.False(t, taskCompleted(nil, taskMetadata))
+	require.False(t, taskCompleted([]byte("not json"), taskMetadata))
+}
+
+func TestCompletedTasksRerunAsNoOp(t *testing.T) {
+	assetID := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+
+	db := &processedAssetDB{status: taskStatus(t, taskMetadata, statusdb.TaskComplete)}
+	err := guardTestProcessor(db).ProcessMetadataTask(context.Background(), jobs.MetadataArgs{AssetID: assetID})
+	require.NoError(t, err)
+	require.Empty(t, db.executed)
+
+	db = &processedAssetDB{status: taskStatus(t, taskTranscode, statusdb.TaskComplete)}
+	err = guardTestProcessor(db).ProcessTranscodeTask(context.Background(), jobs.TranscodeArgs{AssetID: assetID})
+	require.NoError(t, err)
+	require.Empty(t, db.executed)
+}
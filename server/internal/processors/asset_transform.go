@@ -0,0 +1,275 @@
+package processors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/davidbyttow/govips/v2/vips"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/riverqueue/river"
+	"go.uber.org/zap"
+
+	"server/internal/db/dbtypes"
+	"server/internal/db/repo"
+	"server/internal/queue/jobs"
+	"server/internal/storage"
+	"server/internal/utils/exif"
+	"server/internal/utils/hash"
+	"server/internal/utils/imaging"
+)
+
+// ErrTransformUnsupported is returned when an asset's original cannot be
+// rotated or flipped in place (videos, audio, RAW and other formats we cannot
+// write back).
+var ErrTransformUnsupported = errors.New("asset original cannot be rotated or flipped")
+
+// transformTrashReason tags trash sidecars of originals replaced by a rotate
+// or flip, so an undo can find the pre-edit version.
+const transformTrashReason = "transform"
+
+// TransformAsset rotates or flips a photo's stored original and returns the
+// updated asset. JPEG and TIFF originals are reoriented losslessly by
+// rewriting their EXIF Orientation tag; PNG, WebP and AVIF are re-encoded.
+// The pre-edit original is moved to the repository trash so the edit can be
+// undone, and thumbnails are regenerated in the background. The asset's lock
+// is held throughout, so jobs and rating write-backs on it wait.
+func (ap *AssetProcessor) TransformAsset(ctx context.Context, assetID pgtype.UUID, transform exif.Transform) (*repo.Asset, error) {
+	if !transform.Valid() {
+		return nil, fmt.Errorf("%w: unknown transform %q", ErrTransformUnsupported, transform)
+	}
+	var updated *repo.Asset
+	err := ap.withAssetLock(ctx, assetID, func(ctx context.Context) error {
+		var err error
+		updated, err = ap.transformAsset(ctx, assetID, transform)
+		return err
+	})
+	return updated, err
+}
+
+func (ap *AssetProcessor) transformAsset(ctx context.Context, assetID pgtype.UUID, transform exif.Transform) (*repo.Asset, error) {
+	asset, repository, err := ap.loadAssetAndRepo(ctx, assetID)
+	if err != nil {
+		return nil, err
+	}
+	if dbtypes.AssetType(asset.Type) != dbtypes.AssetTypePhoto {
+		return nil, fmt.Errorf("%w: %s assets", ErrTransformUnsupported, strings.ToLower(asset.Type))
+	}
+	apply, err := ap.originalTransformer(ctx, asset.MimeType, transform)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ap.transformOriginal(ctx, asset, repository.Path, transform, apply); err != nil {
+		return nil, err
+	}
+
+	thumbnailArgs := jobs.ThumbnailArgs{
+		AssetID:     asset.AssetID,
+		RepoPath:    repository.Path,
+		StoragePath: *asset.StoragePath,
+		AssetType:   dbtypes.AssetTypePhoto,
+		// The original changed, so the existing thumbnails are stale.
+		Force: true,
+	}
+	if _, err := ap.queueClient.Insert(ctx, thumbnailArgs, &river.InsertOpts{Queue: "thumbnail_asset"}); err != nil {
+		return nil, fmt.Errorf("enqueue thumbnail_asset: %w", err)
+	}
+
+	updated, err := ap.queries.GetAssetByID(ctx, assetID)
+	if err != nil {
+		return nil, fmt.Errorf("reload asset: %w", err)
+	}
+	return &updated, nil
+}
+
+// originalTransformer picks how an original of mimeType is transformed. The
+// returned function writes the transformed copy of src to dst.
+func (ap *AssetProcessor) originalTransformer(ctx context.Context, mimeType string, transform exif.Transform) (func(src, dst string) error, error) {
+	switch strings.ToLower(mimeType) {
+	case "image/jpeg", "image/jpg", "image/tiff":
+		exifTool := ap.toolsConfig.ExifToolCommand()
+		return func(src, dst string) error {
+			if err := copyFile(src, dst); err != nil {
+				return err
+			}
+			current, err := exif.ReadOrientation(ctx, exifTool, dst)
+			if err != nil {
+				return err
+			}
+			next, err := exif.ApplyTransform(current, transform)
+			if err != nil {
+				return err
+			}
+			return exif.WriteOrientation(ctx, exifTool, dst, next)
+		}, nil
+	case "image/png", "image/webp", "image/avif":
+		return func(src, dst string) error {
+			buf, err := os.ReadFile(src)
+			if err != nil {
+				return fmt.Errorf("read original: %w", err)
+			}
+			out, err := imaging.TransformImageBytes(buf, pixelTransform(transform))
+			if err != nil {
+				return err
+			}
+			return os.WriteFile(dst, out, 0o644)
+		}, nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrTransformUnsupported, mimeType)
+	}
+}
+
+// transformOriginal replaces the original with its transformed copy, then
+// records the swapped dimensions for quarter turns.
+func (ap *AssetProcessor) transformOriginal(ctx context.Context, asset *repo.Asset, repoPath string, transform exif.Transform, apply func(src, dst string) error) error {
+	extra := map[string]interface{}{"transform": string(transform)}
+	if err := ap.rewriteOriginal(ctx, asset, repoPath, transformTrashReason, true, extra, apply); err != nil {
+		return err
+	}
+	if transform.SwapsDimensions() && asset.Width != nil && asset.Height != nil {
+		id := uuid.UUID(asset.AssetID.Bytes)
+		if err := ap.assetService.UpdateAssetDimensions(ctx, id, *asset.Height, *asset.Width); err != nil {
+			return fmt.Errorf("update asset dimensions: %w", err)
+		}
+	}
+	return nil
+}
+
+// rewriteOriginal writes an edited copy of the original to a temp file and
+// swaps it in, then records the new content. The current original is set aside
+// in the temp directory until both the swap and the database update succeed,
+// and put back if either fails; then it is moved to the trash under reason
+// with extra when backup is set, and removed otherwise. A content-addressed
+// original moves to the path of its new hash. On success asset carries the new
+// storage path and hash.
+func (ap *AssetProcessor) rewriteOriginal(ctx context.Context, asset *repo.Asset, repoPath, reason string, backup bool, extra map[string]interface{}, apply func(src, dst string) error) error {
+	fullPath, err := originalPath(asset, repoPath)
+	if err != nil {
+		return err
+	}
+
+	tempDir := filepath.Join(repoPath, storage.DefaultStructure.TempDir)
+	if err := os.MkdirAll(tempDir, 0o700); err != nil {
+		return fmt.Errorf("create temp directory: %w", err)
+	}
+	// Keep the original extension; exiftool picks the writer by file type.
+	tmp, err := os.CreateTemp(tempDir, reason+"_*"+filepath.Ext(fullPath))
+	if err != nil {
+		return fmt.Errorf("create temp file: %w", err)
+	}
+	tmpPath := tmp.Name()
+	_ = tmp.Close()
+	defer os.Remove(tmpPath)
+
+	if err := apply(fullPath, tmpPath); err != nil {
+		return fmt.Errorf("%s original: %w", reason, err)
+	}
+	content, err := hash.CalculateLayeredBLAKE3(tmpPath)
+	if err != nil {
+		return fmt.Errorf("hash rewritten original: %w", err)
+	}
+
+	previousStoragePath := *asset.StoragePath
+	storagePath := previousStoragePath
+	targetPath := fullPath
+	if rehashed, ok := storage.RehashedCASPath(storagePath, asset.ContentHash, content.ContentHash); ok {
+		// A file already at the new hash's path belongs to another asset with
+		// the same content; rather than share it, stay at the old path.
+		rehashedFull := filepath.Join(repoPath, rehashed)
+		if _, err := os.Lstat(rehashedFull); errors.Is(err, os.ErrNotExist) {
+			if err := os.MkdirAll(filepath.Dir(rehashedFull), 0o755); err != nil {
+				return fmt.Errorf("create content-addressed directory: %w", err)
+			}
+			storagePath, targetPath = rehashed, rehashedFull
+		}
+	}
+
+	previous := tmpPath + ".previous"
+	if err := os.Rename(fullPath, previous); err != nil {
+		return fmt.Errorf("set original aside: %w", err)
+	}
+	restore := func() {
+		if targetPath != fullPath {
+			_ = os.Remove(targetPath)
+		}
+		_ = os.Rename(previous, fullPath)
+	}
+	if err := os.Rename(tmpPath, targetPath); err != nil {
+		restore()
+		return fmt.Errorf("replace original: %w", err)
+	}
+
+	id := uuid.UUID(asset.AssetID.Bytes)
+	if err := ap.assetService.UpdateAssetContent(ctx, id, storagePath, content); err != nil {
+		restore()
+		return fmt.Errorf("update asset content: %w", err)
+	}
+	asset.StoragePath = &storagePath
+	asset.ContentHash = content.ContentHash
+	asset.FileSize = content.FileSize
+
+	if !backup {
+		_ = os.Remove(previous)
+		return nil
+	}
+	idString := id.String()
+	metadata := &storage.DeleteMetadata{
+		Reason:       reason,
+		AssetID:      &idString,
+		OriginalPath: previousStoragePath,
+		Extra:        extra,
+	}
+	previousRel, err := filepath.Rel(repoPath, previous)
+	if err == nil {
+		err = storage.NewDirectoryManager().MoveToTrash(repoPath, previousRel, metadata)
+	}
+	if err != nil && ap.logger != nil {
+		// The edit is in place; the pre-edit copy stays in the temp directory.
+		ap.logger.Warn("failed to move pre-edit original to trash",
+			zap.String("asset_id", idString),
+			zap.String("reason", reason),
+			zap.String("path", previous),
+			zap.Error(err))
+	}
+	return nil
+}
+
+// pixelTransform maps a transform onto the vips operations that apply it.
+func pixelTransform(transform exif.Transform) imaging.PixelTransform {
+	switch transform {
+	case exif.TransformRotate90:
+		return imaging.PixelTransform{Rotate: vips.Angle90}
+	case exif.TransformRotate180:
+		return imaging.PixelTransform{Rotate: vips.Angle180}
+	case exif.TransformRotate270:
+		return imaging.PixelTransform{Rotate: vips.Angle270}
+	case exif.TransformFlipHorizontal:
+		return imaging.PixelTransform{FlipHorizontal: true}
+	case exif.TransformFlipVertical:
+		return imaging.PixelTransform{FlipVertical: true}
+	}
+	return imaging.PixelTransform{}
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return fmt.Errorf("open original: %w", err)
+	}
+	defer in.Close()
+	out, err := os.Create(dst)
+	if err != nil {
+		return fmt.Errorf("create copy: %w", err)
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		_ = out.Close()
+		return fmt.Errorf("copy original: %w", err)
+	}
+	return out.Close()
+}
@@ -0,0 +1,210 @@
+package processors
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"server/internal/db/repo"
+	"server/internal/service"
+	"server/internal/storage"
+	"server/internal/utils/exif"
+	"server/internal/utils/hash"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+// stubTransformAssetService records content and dimension writes; any other
+// call panics through the nil embedded interface.
+type stubTransformAssetService struct {
+	service.AssetService
+	storagePath string
+	content     *hash.LayeredHashResult
+	dimensions  *[2]int32
+	contentErr  error
+}
+
+func (s *stubTransformAssetService) UpdateAssetContent(_ context.Context, _ uuid.UUID, storagePath string, content *hash.LayeredHashResult) error {
+	if s.contentErr != nil {
+		return s.contentErr
+	}
+	s.storagePath = storagePath
+	s.content = content
+	return nil
+}
+
+func (s *stubTransformAssetService) UpdateAssetDimensions(_ context.Context, _ uuid.UUID, width, height int32) error {
+	s.dimensions = &[2]int32{width, height}
+	return nil
+}
+
+func transformTestAsset(t *testing.T, repoPath string, width, height int32) *repo.Asset {
+	t.Helper()
+	require.NoError(t, os.WriteFile(filepath.Join(repoPath, "photo.png"), []byte("original"), 0o644))
+	asset := refreshTestAsset(t, "photo.png", `{}`)
+	asset.Width = &width
+	asset.Height = &height
+	return asset
+}
+
+// trashBackups returns the sidecars of files moved into the repository trash.
+func trashBackups(t *testing.T, repoPath string) []storage.DeleteMetadata {
+	t.Helper()
+	entries, err := os.ReadDir(filepath.Join(repoPath, storage.DefaultStructure.TrashDir))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	require.NoError(t, err)
+	var backups []storage.DeleteMetadata
+	for _, entry := range entries {
+		if !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+		raw, err := os.ReadFile(filepath.Join(repoPath, storage.DefaultStructure.TrashDir, entry.Name()))
+		require.NoError(t, err)
+		var meta storage.DeleteMetadata
+		require.NoError(t, json.Unmarshal(raw, &meta))
+		backup, err := os.ReadFile(filepath.Join(repoPath, storage.DefaultStructure.TrashDir, strings.TrimSuffix(entry.Name(), ".json")))
+		require.NoError(t, err)
+		require.Equal(t, "original", string(backup))
+		backups = append(backups, meta)
+	}
+	return backups
+}
+
+func TestTransformOriginalRotate90SwapsDimensionsAndKeepsBackup(t *testing.T) {
+	repoPath := t.TempDir()
+	svc := &stubTransformAssetService{}
+	ap := &AssetProcessor{assetService: svc}
+	asset := transformTestAsset(t, repoPath, 4000, 3000)
+
+	err := ap.transformOriginal(context.Background(), asset, repoPath, exif.TransformRotate90, func(src, dst string) error {
+		require.Equal(t, filepath.Join(repoPath, "photo.png"), src)
+		return os.WriteFile(dst, []byte("rotated"), 0o644)
+	})
+	require.NoError(t, err)
+
+	replaced, err := os.ReadFile(filepath.Join(repoPath, "photo.png"))
+	require.NoError(t, err)
+	require.Equal(t, "rotated", string(replaced))
+
+	require.NotNil(t, svc.dimensions)
+	require.Equal(t, [2]int32{3000, 4000}, *svc.dimensions)
+	require.NotNil(t, svc.content)
+	require.Equal(t, int64(len("rotated")), svc.content.FileSize)
+
+	backups := trashBackups(t, repoPath)
+	require.Len(t, backups, 1)
+	require.Equal(t, transformTrashReason, backups[0].Reason)
+	require.Equal(t, "photo.png", backups[0].OriginalPath)
+	require.Equal(t, string(exif.TransformRotate90), backups[0].Extra["transform"])
+
+	temps, err := os.ReadDir(filepath.Join(repoPath, storage.DefaultStructure.TempDir))
+	require.NoError(t, err)
+	require.Empty(t, temps)
+}
+
+func TestTransformOriginalFlipKeepsDimensions(t *testing.T) {
+	repoPath := t.TempDir()
+	svc := &stubTransformAssetService{}
+	ap := &AssetProcessor{assetService: svc}
+	asset := transformTestAsset(t, repoPath, 4000, 3000)
+
+	err := ap.transformOriginal(context.Background(), asset, repoPath, exif.TransformFlipHorizontal, func(_, dst string) error {
+		return os.WriteFile(dst, []byte("flipped"), 0o644)
+	})
+	require.NoError(t, err)
+	require.Nil(t, svc.dimensions)
+	require.NotNil(t, svc.content)
+	require.Len(t, trashBackups(t, repoPath), 1)
+}
+
+func TestTransformOriginalFailureLeavesOriginal(t *testing.T) {
+	repoPath := t.TempDir()
+	svc := &stubTransformAssetService{}
+	ap := &AssetProcessor{assetService: svc}
+	asset := transformTestAsset(t, repoPath, 4000, 3000)
+
+	err := ap.transformOriginal(context.Background(), asset, repoPath, exif.TransformRotate90, func(string, string) error {
+		return errors.New("encoder failed")
+	})
+	require.Error(t, err)
+
+	original, err := os.ReadFile(filepath.Join(repoPath, "photo.png"))
+	require.NoError(t, err)
+	require.Equal(t, "original", string(original))
+	require.Empty(t, trashBackups(t, repoPath))
+	require.Nil(t, svc.content)
+	require.Nil(t, svc.dimensions)
+}
+
+func TestTransformOriginalRestoresOriginalWhenUpdateFails(t *testing.T) {
+	repoPath := t.TempDir()
+	svc := &stubTransformAssetService{contentErr: errors.New("database unavailable")}
+	ap := &AssetProcessor{assetService: svc}
+	asset := transformTestAsset(t, repoPath, 4000, 3000)
+
+	err := ap.transformOriginal(context.Background(), asset, repoPath, exif.TransformRotate90, func(_, dst string) error {
+		return os.WriteFile(dst, []byte("rotated"), 0o644)
+	})
+	require.Error(t, err)
+
+	original, err := os.ReadFile(filepath.Join(repoPath, "photo.png"))
+	require.NoError(t, err)
+	require.Equal(t, "original", string(original))
+	require.Equal(t, "photo.png", *asset.StoragePath)
+	require.Empty(t, trashBackups(t, repoPath))
+	require.Nil(t, svc.dimensions)
+
+	temps, err := os.ReadDir(filepath.Join(repoPath, storage.DefaultStructure.TempDir))
+	require.NoError(t, err)
+	require.Empty(t, temps)
+}
+
+func TestTransformOriginalMovesContentAddressedOriginal(t *testing.T) {
+	repoPath := t.TempDir()
+	svc := &stubTransformAssetService{}
+	ap := &AssetProcessor{assetService: svc}
+
+	oldHash := "abcdef0123456789"
+	oldPath := filepath.Join("inbox", "ab", "cd", "ef", oldHash+".png")
+	require.NoError(t, os.MkdirAll(filepath.Join(repoPath, filepath.Dir(oldPath)), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(repoPath, oldPath), []byte("original"), 0o644))
+	asset := refreshTestAsset(t, oldPath, `{}`)
+	asset.ContentHash = oldHash
+
+	err := ap.transformOriginal(context.Background(), asset, repoPath, exif.TransformFlipVertical, func(_, dst string) error {
+		return os.WriteFile(dst, []byte("flipped"), 0o644)
+	})
+	require.NoError(t, err)
+
+	require.NotNil(t, svc.content)
+	newHash := svc.content.ContentHash
+	newPath := filepath.Join("inbox", newHash[0:2], newHash[2:4], newHash[4:6], newHash+".png")
+	require.Equal(t, newPath, svc.storagePath)
+	require.Equal(t, newPath, *asset.StoragePath)
+	require.Equal(t, newHash, asset.ContentHash)
+
+	moved, err := os.ReadFile(filepath.Join(repoPath, newPath))
+	require.NoError(t, err)
+	require.Equal(t, "flipped", string(moved))
+	_, err = os.Stat(filepath.Join(repoPath, oldPath))
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	backups := trashBackups(t, repoPath)
+	require.Len(t, backups, 1)
+	require.Equal(t, oldPath, backups[0].OriginalPath)
+}
+
+func TestOriginalTransformerRejectsUnsupportedFormats(t *testing.T) {
+	ap := &AssetProcessor{}
+	for _, mimeType := range []string{"image/heic", "image/x-canon-cr2", "video/mp4"} {
+		_, err := ap.originalTransformer(context.Background(), mimeType, exif.TransformRotate90)
+		require.ErrorIs(t, err, ErrTransformUnsupported, mimeType)
+	}
+}
@@ -17,6 +17,8 @@ import (
 	"server/internal/db/repo"
 	"server/internal/utils/exif"
 	"server/internal/utils/sysproc"
+
+	"go.uber.org/zap"
 )
 
 // AudioInfo holds audio metadata.
@@ -27,6 +29,9 @@ type AudioInfo struct {
 	Bitrate    int
 	Codec      string
 	Format     string
+	// Tags are the container's tags (ID3, Vorbis comments, iTunes atoms),
+	// keyed in lower case.
+	Tags map[string]string
 }
 
 // extractAudioMetadata updates the asset with ffprobe/EXIF-derived metadata.
@@ -71,6 +76,17 @@ func (ap *AssetProcessor) extractAudioMetadata(ctx context.Context, asset *repo.
 	if err := ap.assetService.UpdateAssetDuration(ctx, asset.AssetID.Bytes, audioInfo.Duration); err != nil {
 		return fmt.Errorf("update duration: %w", err)
 	}
+	fillAudioMetadataFromProbe(meta, audioInfo)
+
+	// The waveform is a nicety for the player; a file ffmpeg cannot decode
+	// still gets its metadata.
+	if peaks, err := ap.computeWaveformPeaks(ctx, audioPath); err != nil {
+		ap.logger.Warn("failed to compute audio waveform",
+			zap.String("asset_id", asset.AssetID.String()),
+			zap.Error(err))
+	} else {
+		meta.WaveformPeaks = peaks
+	}
 
 	sm, err := dbtypes.MarshalMeta(meta)
 	if err != nil {
@@ -80,10 +96,58 @@ func (ap *AssetProcessor) extractAudioMetadata(ctx context.Context, asset *repo.
 	if err := ap.assetService.UpdateAssetMetadataWithExifRaw(ctx, asset.AssetID.Bytes, sm, result.Raw); err != nil {
 		return fmt.Errorf("save metadata: %w", err)
 	}
+	asset.SpecificMetadata = sm
 
 	return nil
 }
 
+// fillAudioMetadataFromProbe completes what exiftool left empty with ffprobe's
+// view of the stream and its tags.
+func fillAudioMetadataFromProbe(meta *dbtypes.AudioSpecificMetadata, info *AudioInfo) {
+	meta.Duration = info.Duration
+	if meta.Codec == "" {
+		meta.Codec = info.Codec
+	}
+	if meta.Bitrate == 0 {
+		meta.Bitrate = info.Bitrate * 1000
+	}
+	if meta.SampleRate == 0 {
+		meta.SampleRate = info.SampleRate
+	}
+	if meta.Channels == 0 {
+		meta.Channels = info.Channels
+	}
+	if meta.Artist == "" {
+		meta.Artist = firstTag(info.Tags, "artist", "album_artist")
+	}
+	if meta.Album == "" {
+		meta.Album = firstTag(info.Tags, "album")
+	}
+	if meta.Title == "" {
+		meta.Title = firstTag(info.Tags, "title")
+	}
+	if meta.Genre == "" {
+		meta.Genre = firstTag(info.Tags, "genre")
+	}
+	if meta.Year == 0 {
+		// Dates come as "2023" or "2023-05-01".
+		if date := firstTag(info.Tags, "date", "year"); len(date) >= 4 {
+			if year, err := strconv.Atoi(date[:4]); err == nil {
+				meta.Year = year
+			}
+		}
+	}
+}
+
+func firstTag(tags map[string]string, keys ...string) string {
+	for _, key := range keys {
+		if v := strings.TrimSpace(tags[key]); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
 // transcodeAudioSmart applies a best-effort, resource-aware transcoding strategy.
 func (ap *AssetProcessor) transcodeAudioSmart(ctx context.Context, repoPath string, asset *repo.Asset, audioPath string, audioInfo *AudioInfo) error {
 	if strings.ToLower(audioInfo.Format) == "mp3" && audioInfo.Bitrate >= 128 && audioInfo.Bitrate <= 320 {
@@ -107,6 +171,10 @@ func (ap *AssetProcessor) transcodeAudioToMP3(ctx context.Context, inputPath str
 	if audioInfo.Bitrate > 0 && audioInfo.Bitrate < 192 {
 		targetBitrate = "128k"
 	}
+	channels := "2"
+	if audioInfo.Channels == 1 {
+		channels = "1" // keep mono if source is mono
+	}
 
 	cmd := exec.CommandContext(ctx, ap.toolsConfig.FFmpegCommand(),
 		"-i", inputPath,
@@ -114,17 +182,13 @@ func (ap *AssetProcessor) transcodeAudioToMP3(ctx context.Context, inputPath str
 		"-b:a", targetBitrate,
 		"-q:a", "2",
 		"-ar", "44100",
-		"-ac", "2",
+		"-ac", channels,
 		"-f", "mp3",
 		"-y",
 		outputPath,
 	)
 	sysproc.HideConsole(cmd)
 
-	if audioInfo.Channels == 1 {
-		cmd.Args[len(cmd.Args)-4] = "1" // keep mono if source is mono
-	}
-
 	if err := cmd.Run(); err != nil {
 		return "", fmt.Errorf("ffmpeg audio transcode failed: %w", err)
 	}
@@ -207,16 +271,18 @@ func (ap *AssetProcessor) getAudioInfo(audioPath string) (*AudioInfo, error) {
 
 	var probeData struct {
 		Streams []struct {
-			SampleRate string `json:"sample_rate"`
-			Channels   int    `json:"channels"`
-			CodecName  string `json:"codec_name"`
-			BitRate    string `json:"bit_rate"`
-			Duration   string `json:"duration"`
+			SampleRate string            `json:"sample_rate"`
+			Channels   int               `json:"channels"`
+			CodecName  string            `json:"codec_name"`
+			BitRate    string            `json:"bit_rate"`
+			Duration   string            `json:"duration"`
+			Tags       map[string]string `json:"tags"`
 		} `json:"streams"`
 		Format struct {
-			FormatName string `json:"format_name"`
-			Duration   string `json:"duration"`
-			BitRate    string `json:"bit_rate"`
+			FormatName string            `json:"format_name"`
+			Duration   string            `json:"duration"`
+			BitRate    string            `json:"bit_rate"`
+			Tags       map[string]string `json:"tags"`
 		} `json:"format"`
 	}
 
@@ -224,7 +290,17 @@ func (ap *AssetProcessor) getAudioInfo(audioPath string) (*AudioInfo, error) {
 		return nil, fmt.Errorf("parse ffprobe json: %w", err)
 	}
 
-	info := &AudioInfo{}
+	info := &AudioInfo{Tags: make(map[string]string)}
+	// Ogg and FLAC keep their comments on the stream, most other containers
+	// on the format; the format wins where both are set.
+	if len(probeData.Streams) > 0 {
+		for key, value := range probeData.Streams[0].Tags {
+			info.Tags[strings.ToLower(key)] = value
+		}
+	}
+	for key, value := range probeData.Format.Tags {
+		info.Tags[strings.ToLower(key)] = value
+	}
 
 	if len(probeData.Streams) > 0 {
 		stream := probeData.Streams[0]
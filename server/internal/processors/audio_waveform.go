@@ -0,0 +1,144 @@
+package processors
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os/exec"
+	"strconv"
+
+	"server/internal/utils/sysproc"
+)
+
+const (
+	// waveformPoints is how many peaks describe an audio waveform; enough
+	// for a full-width player scrubber.
+	waveformPoints = 1000
+	// waveformSampleRate is the rate audio is decoded at for its waveform.
+	// A thousand peaks need far less than playback quality.
+	waveformSampleRate = 8000
+)
+
+// computeWaveformPeaks decodes the audio at audioPath to mono PCM and reduces
+// it to waveformPoints peaks while it streams out of ffmpeg, so memory stays
+// bounded however long the recording is.
+func (ap *AssetProcessor) computeWaveformPeaks(ctx context.Context, audioPath string) ([]int, error) {
+	cmd := exec.CommandContext(ctx, ap.toolsConfig.FFmpegCommand(),
+		"-v", "error",
+		"-i", audioPath,
+		"-vn",
+		"-ac", "1",
+		"-ar", strconv.Itoa(waveformSampleRate),
+		"-f", "s16le",
+		"-acodec", "pcm_s16le",
+		"pipe:1",
+	)
+	sysproc.HideConsole(cmd)
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, fmt.Errorf("ffmpeg stdout: %w", err)
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("start ffmpeg: %w", err)
+	}
+
+	peaks, readErr := readWaveformPeaks(stdout, waveformPoints)
+	if readErr != nil {
+		// Unblock ffmpeg before waiting on it.
+		_, _ = io.Copy(io.Discard, stdout)
+	}
+	if err := cmd.Wait(); err != nil {
+		return nil, fmt.Errorf("ffmpeg waveform decode failed: %w", err)
+	}
+	if readErr != nil {
+		return nil, readErr
+	}
+	return peaks, nil
+}
+
+// readWaveformPeaks reads signed 16-bit little-endian mono PCM from r and
+// returns at most points peaks, each the loudest sample of its stretch of
+// the recording as a percentage of full scale.
+func readWaveformPeaks(r io.Reader, points int) ([]int, error) {
+	acc := newPeakAccumulator(points)
+	br := bufio.NewReaderSize(r, 64*1024)
+	var sample [2]byte
+	for {
+		if _, err := io.ReadFull(br, sample[:]); err != nil {
+			if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+				break
+			}
+			return nil, fmt.Errorf("read pcm: %w", err)
+		}
+		acc.add(float64(int16(binary.LittleEndian.Uint16(sample[:]))) / math.MaxInt16)
+	}
+	return acc.peaks(points), nil
+}
+
+// peakAccumulator keeps the peaks of a sample stream of unknown length in
+// bounded memory: each time the bucket count reaches twice the wanted number
+// of points, neighbouring buckets are merged and every later bucket covers
+// twice as many samples.
+type peakAccumulator struct {
+	limit   int
+	size    int
+	fill    int
+	current float64
+	buckets []float64
+}
+
+func newPeakAccumulator(points int) *peakAccumulator {
+	return &peakAccumulator{
+		limit:   2 * points,
+		size:    1,
+		buckets: make([]float64, 0, 2*points),
+	}
+}
+
+func (a *peakAccumulator) add(v float64) {
+	v = math.Min(math.Abs(v), 1)
+	if v > a.current {
+		a.current = v
+	}
+	a.fill++
+	if a.fill < a.size {
+		return
+	}
+	a.buckets = append(a.buckets, a.current)
+	a.current, a.fill = 0, 0
+	if len(a.buckets) < a.limit {
+		return
+	}
+	for i := 0; i < len(a.buckets)/2; i++ {
+		a.buckets[i] = math.Max(a.buckets[2*i], a.buckets[2*i+1])
+	}
+	a.buckets = a.buckets[:len(a.buckets)/2]
+	a.size *= 2
+}
+
+// peaks downsamples the buckets seen so far to at most points values.
+func (a *peakAccumulator) peaks(points int) []int {
+	buckets := a.buckets
+	if a.fill > 0 {
+		buckets = append(buckets, a.current)
+	}
+	n := min(points, len(buckets))
+	if n == 0 {
+		return nil
+	}
+	out := make([]int, n)
+	for i := range out {
+		lo, hi := i*len(buckets)/n, (i+1)*len(buckets)/n
+		peak := 0.0
+		for _, v := range buckets[lo:hi] {
+			peak = math.Max(peak, v)
+		}
+		out[i] = int(math.Round(peak * 100))
+	}
+	return out
+}
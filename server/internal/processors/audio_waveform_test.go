@@ -0,0 +1,46 @@
+package processors
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func pcm(samples ...int16) *bytes.Reader {
+	buf := &bytes.Buffer{}
+	_ = binary.Write(buf, binary.LittleEndian, samples)
+	return bytes.NewReader(buf.Bytes())
+}
+
+func TestReadWaveformPeaks_ShortRecordingKeepsEverySample(t *testing.T) {
+	peaks, err := readWaveformPeaks(pcm(0, 16384, -32767, 3277), 10)
+	require.NoError(t, err)
+	require.Equal(t, []int{0, 50, 100, 10}, peaks)
+}
+
+func TestReadWaveformPeaks_LongRecordingIsDownsampled(t *testing.T) {
+	// One loud sample in the second half of an otherwise silent stream.
+	samples := make([]int16, 100_000)
+	samples[75_000] = -32767
+	peaks, err := readWaveformPeaks(pcm(samples...), 1000)
+	require.NoError(t, err)
+	require.Len(t, peaks, 1000)
+
+	loud := -1
+	for i, p := range peaks {
+		if p > 0 {
+			require.Equal(t, -1, loud, "only one bucket holds the peak")
+			loud = i
+		}
+	}
+	require.InDelta(t, 750, loud, 2)
+	require.Equal(t, 100, peaks[loud])
+}
+
+func TestReadWaveformPeaks_Empty(t *testing.T) {
+	peaks, err := readWaveformPeaks(pcm(), 1000)
+	require.NoError(t, err)
+	require.Nil(t, peaks)
+}
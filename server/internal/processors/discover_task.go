@@ -2,11 +2,13 @@ package processors
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"path/filepath"
 	"strings"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 	"go.uber.org/zap"
 
@@ -17,7 +19,8 @@ import (
 )
 
 // ProcessDiscoveredAsset ingests files discovered by repository tree monitoring.
-// Delete operations are handled directly; upsert operations delegate to the SourceMaterializer.
+// Delete and move operations are handled directly; upsert operations delegate
+// to the SourceMaterializer. A move whose asset is gone imports the file anew.
 func (ap *AssetProcessor) ProcessDiscoveredAsset(ctx context.Context, args jobs.DiscoverAssetArgs) error {
 	repoUUID, err := uuid.Parse(strings.TrimSpace(args.RepositoryID))
 	if err != nil {
@@ -49,21 +52,77 @@ func (ap *AssetProcessor) ProcessDiscoveredAsset(ctx context.Context, args jobs.
 		return nil
 	}
 
-	// Upsert: delegate to the materializer (file validation, hash, create-or-update, pipeline)
-	filename := strings.TrimSpace(args.FileName)
-	if filename == "" {
-		filename = filepath.Base(storagePath)
+	var asset *repo.Asset
+	if operation == jobs.DiscoverOperationMove {
+		asset, err = ap.moveDiscoveredAsset(ctx, repoUUID, storagePath, args)
+		if err != nil {
+			return err
+		}
+	}
+
+	if asset == nil {
+		// Upsert: delegate to the materializer (file validation, hash, create-or-update, pipeline)
+		filename := strings.TrimSpace(args.FileName)
+		if filename == "" {
+			filename = filepath.Base(storagePath)
+		}
+
+		asset, err = ap.materializer.Materialize(ctx, sourcing.IngestSource{
+			RepositoryID:     repoUUID,
+			Kind:             sourcing.IngestSourceScan,
+			SourcePath:       storagePath, // repo-relative path
+			OriginalFilename: filename,
+			ContentType:      args.ContentType,
+			Timestamp:        args.DetectedAt,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	// A failed album update must not re-run ingestion; the next change to the
+	// file gets another chance.
+	if ap.scanConfig.AutoAlbumByFolder {
+		if err := addToFolderAlbum(ctx, ap.queries, asset, storagePath); err != nil {
+			ap.logger.Warn("Failed to add discovered asset to folder album",
+				zap.String("repository_id", args.RepositoryID),
+				zap.String("storage_path", storagePath),
+				zap.Error(err))
+		}
+	}
+	return nil
+}
+
+// moveDiscoveredAsset applies a rename the monitor detected. It returns nil
+// without error when the asset no longer exists, so the caller imports the
+// file at its new path instead.
+func (ap *AssetProcessor) moveDiscoveredAsset(ctx context.Context, repoUUID uuid.UUID, storagePath string, args jobs.DiscoverAssetArgs) (*repo.Asset, error) {
+	assetID, err := uuid.Parse(strings.TrimSpace(args.AssetID))
+	if err != nil {
+		return nil, fmt.Errorf("invalid moved asset id: %w", err)
+	}
+	previousPath, err := sanitizeDiscoveredPath(args.PreviousPath)
+	if err != nil {
+		return nil, err
 	}
 
-	_, err = ap.materializer.Materialize(ctx, sourcing.IngestSource{
-		RepositoryID:     repoUUID,
-		Kind:             sourcing.IngestSourceScan,
-		SourcePath:       storagePath, // repo-relative path
-		OriginalFilename: filename,
-		ContentType:      args.ContentType,
-		Timestamp:        args.DetectedAt,
-	})
-	return err
+	asset, err := ap.assetService.MoveRenamedAsset(ctx, repoUUID, assetID, storagePath)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("move discovered asset (%s -> %s): %w", previousPath, storagePath, err)
+	}
+	repository, repoErr := ap.queries.GetRepository(ctx, pgtype.UUID{Bytes: repoUUID, Valid: true})
+	if repoErr == nil {
+		ap.repoAudit(repository.Path).Operation("asset.discover.move",
+			zap.String("repository_id", args.RepositoryID),
+			zap.String("asset_id", assetID.String()),
+			zap.String("previous_path", previousPath),
+			zap.String("storage_path", storagePath),
+		)
+	}
+	return asset, nil
 }
 
 func normalizeDiscoverOperation(raw string) string {
@@ -73,6 +132,8 @@ func normalizeDiscoverOperation(raw string) string {
 		return jobs.DiscoverOperationUpsert
 	case jobs.DiscoverOperationDelete:
 		return jobs.DiscoverOperationDelete
+	case jobs.DiscoverOperationMove:
+		return jobs.DiscoverOperationMove
 	default:
 		return jobs.DiscoverOperationUpsert
 	}
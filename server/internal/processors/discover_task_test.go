@@ -29,5 +29,6 @@ func TestNormalizeDiscoverOperation(t *testing.T) {
 	require.Equal(t, jobs.DiscoverOperationUpsert, normalizeDiscoverOperation(" UPSERT "))
 	require.Equal(t, jobs.DiscoverOperationDelete, normalizeDiscoverOperation("delete"))
 	require.Equal(t, jobs.DiscoverOperationDelete, normalizeDiscoverOperation(" DELETE "))
+	require.Equal(t, jobs.DiscoverOperationMove, normalizeDiscoverOperation("move"))
 	require.Equal(t, jobs.DiscoverOperationUpsert, normalizeDiscoverOperation("unsupported"))
 }
@@ -0,0 +1,70 @@
+package processors
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"server/internal/db/dbtypes"
+	"server/internal/db/repo"
+	"server/internal/utils/exif"
+
+	"go.uber.org/zap"
+)
+
+// readEditorSidecar returns the darktable/RawTherapee/Lightroom sidecar kept
+// next to a RAW original, or nil when there is none. An unreadable sidecar is
+// logged and skipped rather than failing metadata extraction.
+func (ap *AssetProcessor) readEditorSidecar(asset *repo.Asset, fullPath string) *exif.EditorSidecar {
+	sidecar, err := exif.FindEditorSidecar(fullPath)
+	if err != nil {
+		if ap.logger != nil {
+			ap.logger.Warn("failed to read editor sidecar",
+				zap.String("asset_id", uuid.UUID(asset.AssetID.Bytes).String()),
+				zap.Error(err),
+			)
+		}
+		return nil
+	}
+	return sidecar
+}
+
+// applyEditorSidecarMetadata copies what the UI shows from the sidecar into
+// the photo metadata.
+func applyEditorSidecarMetadata(meta *dbtypes.PhotoSpecificMetadata, sidecar *exif.EditorSidecar) {
+	if sidecar == nil {
+		return
+	}
+	meta.HasEdits = sidecar.HasEdits
+	meta.EditedWith = ""
+	if sidecar.HasEdits {
+		meta.EditedWith = sidecar.Editor
+	}
+	if sidecar.ColorLabel != "" {
+		meta.ColorLabel = sidecar.ColorLabel
+	}
+}
+
+// importEditorSidecar carries the sidecar's rating and keywords over to the
+// asset. The rating only fills an unrated asset, so a rating given in Lumilio
+// is never overwritten; keywords become user tags.
+func (ap *AssetProcessor) importEditorSidecar(ctx context.Context, asset *repo.Asset, sidecar *exif.EditorSidecar) error {
+	if sidecar == nil {
+		return nil
+	}
+	assetID := uuid.UUID(asset.AssetID.Bytes)
+	if sidecar.Rating != nil && asset.Rating == nil {
+		if err := ap.assetService.UpdateAssetRating(ctx, assetID, *sidecar.Rating); err != nil {
+			return fmt.Errorf("import sidecar rating: %w", err)
+		}
+		rating := int32(*sidecar.Rating)
+		asset.Rating = &rating
+	}
+	for _, keyword := range sidecar.Keywords {
+		if _, err := ap.assetService.AddManualTagToAsset(ctx, assetID, keyword, ""); err != nil {
+			return fmt.Errorf("import sidecar keyword %q: %w", keyword, err)
+		}
+	}
+	return nil
+}
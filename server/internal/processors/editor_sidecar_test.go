@@ -0,0 +1,88 @@
+package processors
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"server/internal/db/dbtypes"
+	"server/internal/db/repo"
+	"server/internal/service"
+	"server/internal/utils/exif"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+// stubSidecarAssetService records rating and tag writes; any other call
+// panics through the nil embedded interface.
+type stubSidecarAssetService struct {
+	service.AssetService
+	ratings map[uuid.UUID]int
+	tags    []string
+}
+
+func (s *stubSidecarAssetService) UpdateAssetRating(_ context.Context, id uuid.UUID, rating int) error {
+	s.ratings[id] = rating
+	return nil
+}
+
+func (s *stubSidecarAssetService) AddManualTagToAsset(_ context.Context, _ uuid.UUID, tagName, _ string) (*repo.Tag, error) {
+	s.tags = append(s.tags, tagName)
+	return &repo.Tag{TagName: tagName}, nil
+}
+
+const darktableTestSidecar = `<x:xmpmeta xmlns:x="adobe:ns:meta/">
+ <rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#">
+  <rdf:Description rdf:about=""
+    xmlns:xmp="http://ns.adobe.com/xap/1.0/"
+    xmlns:dc="http://purl.org/dc/elements/1.1/"
+    xmlns:darktable="http://darktable.sf.net/"
+   xmp:Rating="5"
+   darktable:history_end="1">
+   <dc:subject><rdf:Bag><rdf:li>aurora</rdf:li></rdf:Bag></dc:subject>
+   <darktable:history>
+    <rdf:Seq>
+     <rdf:li darktable:num="0" darktable:operation="exposure" darktable:multi_name=""/>
+    </rdf:Seq>
+   </darktable:history>
+  </rdf:Description>
+ </rdf:RDF>
+</x:xmpmeta>`
+
+func TestImportEditorSidecarImportsDarktableRating(t *testing.T) {
+	dir := t.TempDir()
+	original := filepath.Join(dir, "IMG_0001.CR2")
+	require.NoError(t, os.WriteFile(original, []byte("raw"), 0o644))
+	require.NoError(t, os.WriteFile(original+".xmp", []byte(darktableTestSidecar), 0o644))
+
+	svc := &stubSidecarAssetService{ratings: map[uuid.UUID]int{}}
+	ap := &AssetProcessor{assetService: svc}
+	asset := &repo.Asset{AssetID: pgtype.UUID{Bytes: uuid.New(), Valid: true}}
+
+	sidecar := ap.readEditorSidecar(asset, original)
+	require.NotNil(t, sidecar)
+
+	var meta dbtypes.PhotoSpecificMetadata
+	applyEditorSidecarMetadata(&meta, sidecar)
+	require.True(t, meta.HasEdits)
+	require.Equal(t, "darktable", meta.EditedWith)
+
+	require.NoError(t, ap.importEditorSidecar(context.Background(), asset, sidecar))
+	require.Equal(t, 5, svc.ratings[uuid.UUID(asset.AssetID.Bytes)])
+	require.Equal(t, []string{"aurora"}, svc.tags)
+}
+
+func TestImportEditorSidecarKeepsExistingRating(t *testing.T) {
+	svc := &stubSidecarAssetService{ratings: map[uuid.UUID]int{}}
+	ap := &AssetProcessor{assetService: svc}
+	rating := int32(2)
+	asset := &repo.Asset{AssetID: pgtype.UUID{Bytes: uuid.New(), Valid: true}, Rating: &rating}
+
+	five := 5
+	require.NoError(t, ap.importEditorSidecar(context.Background(), asset, &exif.EditorSidecar{Rating: &five}))
+	require.Empty(t, svc.ratings)
+	require.Equal(t, int32(2), *asset.Rating)
+}
@@ -0,0 +1,75 @@
+package processors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+
+	"server/internal/db/repo"
+)
+
+// folderAlbumStore is the part of repo.Queries addToFolderAlbum uses.
+type folderAlbumStore interface {
+	GetAlbumByUserAndName(ctx context.Context, arg repo.GetAlbumByUserAndNameParams) (repo.Album, error)
+	CreateAlbum(ctx context.Context, arg repo.CreateAlbumParams) (repo.Album, error)
+	GetAlbumAssetCount(ctx context.Context, albumID int32) (int64, error)
+	AddAssetToAlbum(ctx context.Context, arg repo.AddAssetToAlbumParams) error
+}
+
+// folderAlbumName returns the album a discovered file belongs to: the folder
+// containing it, relative to the repository root. Albums are flat, so nested
+// folders keep their full path ("Trips/Japan") to stay distinct from a
+// top-level "Japan". Files at the root belong to no folder album.
+func folderAlbumName(storagePath string) (string, bool) {
+	dir := path.Dir(path.Clean(strings.ReplaceAll(storagePath, `\`, "/")))
+	if dir == "." || dir == "/" {
+		return "", false
+	}
+	return dir, true
+}
+
+// addToFolderAlbum adds asset to the owner's album named after the folder at
+// storagePath, creating the album on first use. Assets without an owner or
+// outside any folder are left alone.
+func addToFolderAlbum(ctx context.Context, store folderAlbumStore, asset *repo.Asset, storagePath string) error {
+	if asset == nil || asset.OwnerID == nil {
+		return nil
+	}
+	name, ok := folderAlbumName(storagePath)
+	if !ok {
+		return nil
+	}
+
+	album, err := store.GetAlbumByUserAndName(ctx, repo.GetAlbumByUserAndNameParams{
+		UserID:    *asset.OwnerID,
+		AlbumName: name,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		album, err = store.CreateAlbum(ctx, repo.CreateAlbumParams{
+			UserID:    *asset.OwnerID,
+			AlbumName: name,
+			AlbumType: repo.AlbumTypeDefault,
+		})
+	}
+	if err != nil {
+		return fmt.Errorf("resolve folder album %q: %w", name, err)
+	}
+
+	count, err := store.GetAlbumAssetCount(ctx, album.AlbumID)
+	if err != nil {
+		return fmt.Errorf("count folder album %q: %w", name, err)
+	}
+	position := int32(count)
+	if err := store.AddAssetToAlbum(ctx, repo.AddAssetToAlbumParams{
+		AssetID:  asset.AssetID,
+		AlbumID:  album.AlbumID,
+		Position: &position,
+	}); err != nil {
+		return fmt.Errorf("add asset to folder album %q: %w", name, err)
+	}
+	return nil
+}
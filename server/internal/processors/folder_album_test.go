@@ -0,0 +1,91 @@
+package processors
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+
+	"server/internal/db/repo"
+)
+
+type fakeFolderAlbumStore struct {
+	albums  []repo.Album
+	members map[int32][]pgtype.UUID
+}
+
+func (f *fakeFolderAlbumStore) GetAlbumByUserAndName(_ context.Context, arg repo.GetAlbumByUserAndNameParams) (repo.Album, error) {
+	for _, album := range f.albums {
+		if album.UserID == arg.UserID && album.AlbumName == arg.AlbumName {
+			return album, nil
+		}
+	}
+	return repo.Album{}, pgx.ErrNoRows
+}
+
+func (f *fakeFolderAlbumStore) CreateAlbum(_ context.Context, arg repo.CreateAlbumParams) (repo.Album, error) {
+	album := repo.Album{
+		AlbumID:   int32(len(f.albums) + 1),
+		UserID:    arg.UserID,
+		AlbumName: arg.AlbumName,
+		AlbumType: arg.AlbumType,
+	}
+	f.albums = append(f.albums, album)
+	return album, nil
+}
+
+func (f *fakeFolderAlbumStore) GetAlbumAssetCount(_ context.Context, albumID int32) (int64, error) {
+	return int64(len(f.members[albumID])), nil
+}
+
+func (f *fakeFolderAlbumStore) AddAssetToAlbum(_ context.Context, arg repo.AddAssetToAlbumParams) error {
+	if f.members == nil {
+		f.members = map[int32][]pgtype.UUID{}
+	}
+	f.members[arg.AlbumID] = append(f.members[arg.AlbumID], arg.AssetID)
+	return nil
+}
+
+func newFolderAlbumAsset(owner int32) *repo.Asset {
+	return &repo.Asset{
+		AssetID: pgtype.UUID{Bytes: uuid.New(), Valid: true},
+		OwnerID: &owner,
+	}
+}
+
+func TestFolderAlbumName(t *testing.T) {
+	name, ok := folderAlbumName("Vacation/a.jpg")
+	require.True(t, ok)
+	require.Equal(t, "Vacation", name)
+
+	name, ok = folderAlbumName("Trips/Japan/b.jpg")
+	require.True(t, ok)
+	require.Equal(t, "Trips/Japan", name)
+
+	_, ok = folderAlbumName("a.jpg")
+	require.False(t, ok)
+}
+
+func TestAddToFolderAlbum(t *testing.T) {
+	ctx := context.Background()
+	store := &fakeFolderAlbumStore{}
+
+	first := newFolderAlbumAsset(1)
+	second := newFolderAlbumAsset(1)
+	require.NoError(t, addToFolderAlbum(ctx, store, first, "Vacation/a.jpg"))
+	require.NoError(t, addToFolderAlbum(ctx, store, second, "Vacation/b.jpg"))
+
+	require.Len(t, store.albums, 1, "both files share one album")
+	album := store.albums[0]
+	require.Equal(t, "Vacation", album.AlbumName)
+	require.Equal(t, int32(1), album.UserID)
+	require.Equal(t, repo.AlbumTypeDefault, album.AlbumType)
+	require.Equal(t, []pgtype.UUID{first.AssetID, second.AssetID}, store.members[album.AlbumID])
+
+	require.NoError(t, addToFolderAlbum(ctx, store, newFolderAlbumAsset(1), "root.jpg"))
+	require.NoError(t, addToFolderAlbum(ctx, store, &repo.Asset{AssetID: first.AssetID}, "Other/c.jpg"))
+	require.Len(t, store.albums, 1, "root files and ownerless assets get no album")
+}
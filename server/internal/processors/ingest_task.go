@@ -57,6 +57,10 @@ func (ap *AssetProcessor) IngestAsset(ctx context.Context, task AssetPayload) (*
 		version := hash.QuickFingerprintVersion
 		quickFingerprintVersion = &version
 	}
+	var clientContentHash *string
+	if task.ClientContentHash != "" {
+		clientContentHash = &task.ClientContentHash
+	}
 
 	return ap.materializer.Materialize(ctx, sourcing.IngestSource{
 		RepositoryID:            repoUUID,
@@ -69,5 +73,7 @@ func (ap *AssetProcessor) IngestAsset(ctx context.Context, task AssetPayload) (*
 		QuickFingerprintVersion: quickFingerprintVersion,
 		Timestamp:               task.Timestamp,
 		ContentType:             task.ContentType,
+		CaptureHints:            task.CaptureHints,
+		ClientContentHash:       clientContentHash,
 	})
 }
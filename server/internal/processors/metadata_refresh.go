@@ -0,0 +1,123 @@
+package processors
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"server/internal/db/dbtypes"
+	"server/internal/db/repo"
+	"server/internal/utils/exif"
+)
+
+// ErrOriginalMissing is returned when an asset's original file is no longer
+// present in its repository, so it cannot be re-read or edited.
+var ErrOriginalMissing = errors.New("asset original file is missing")
+
+// ErrRawTagsUnsupported is returned when a raw tag dump is requested for an
+// asset that is not a photo.
+var ErrRawTagsUnsupported = errors.New("raw tag dump is only available for photos")
+
+// RefreshAssetMetadata re-extracts EXIF/ffprobe metadata for a single asset
+// from its stored original and returns the updated asset. Rating and liked
+// live in their own columns and are untouched, except that an unrated RAW
+// picks up the rating of its editor sidecar; a description the user set is
+// carried over onto the freshly extracted metadata.
+func (ap *AssetProcessor) RefreshAssetMetadata(ctx context.Context, assetID pgtype.UUID) (*repo.Asset, error) {
+	asset, repository, err := ap.loadAssetAndRepo(ctx, assetID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = ap.refreshAssetMetadata(ctx, asset, repository.Path, func(fullPath string) error {
+		return ap.extractMetadata(ctx, asset, fullPath, dbtypes.AssetType(asset.Type), nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	refreshed, err := ap.queries.GetAssetByID(ctx, assetID)
+	if err != nil {
+		return nil, fmt.Errorf("reload asset: %w", err)
+	}
+	return &refreshed, nil
+}
+
+// ReadAssetRawTags reads every EXIF/XMP/IPTC tag from a photo's stored
+// original, without touching what was extracted into the database.
+func (ap *AssetProcessor) ReadAssetRawTags(ctx context.Context, assetID pgtype.UUID) (map[string]any, error) {
+	asset, repository, err := ap.loadAssetAndRepo(ctx, assetID)
+	if err != nil {
+		return nil, err
+	}
+	if dbtypes.AssetType(asset.Type) != dbtypes.AssetTypePhoto {
+		return nil, ErrRawTagsUnsupported
+	}
+	fullPath, err := originalPath(asset, repository.Path)
+	if err != nil {
+		return nil, err
+	}
+	return exif.ReadAllTags(ctx, ap.toolsConfig.ExifToolCommand(), fullPath)
+}
+
+// refreshAssetMetadata checks the original is on disk, runs extract against it
+// and restores the description the asset carried before extraction. extract
+// leaves what it stored in asset.SpecificMetadata; the description is only put
+// back while it is still the extracted one, so an edit made meanwhile wins.
+func (ap *AssetProcessor) refreshAssetMetadata(ctx context.Context, asset *repo.Asset, repoPath string, extract func(fullPath string) error) error {
+	fullPath, err := originalPath(asset, repoPath)
+	if err != nil {
+		return err
+	}
+
+	description := metadataDescription(asset.SpecificMetadata)
+	if err := extract(fullPath); err != nil {
+		return fmt.Errorf("extract metadata: %w", err)
+	}
+	extracted := metadataDescription(asset.SpecificMetadata)
+	if description == "" || description == extracted {
+		return nil
+	}
+	if err := ap.assetService.RestoreAssetDescription(ctx, uuid.UUID(asset.AssetID.Bytes), description, extracted); err != nil {
+		return fmt.Errorf("restore description: %w", err)
+	}
+	return nil
+}
+
+// originalPath returns the absolute path of the asset's original, or
+// ErrOriginalMissing when it has none or the file is gone.
+func originalPath(asset *repo.Asset, repoPath string) (string, error) {
+	if asset.StoragePath == nil || strings.TrimSpace(*asset.StoragePath) == "" {
+		return "", ErrOriginalMissing
+	}
+	fullPath := filepath.Join(repoPath, *asset.StoragePath)
+	if _, err := os.Stat(fullPath); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return "", fmt.Errorf("%w: %s", ErrOriginalMissing, *asset.StoragePath)
+		}
+		return "", fmt.Errorf("stat original: %w", err)
+	}
+	return fullPath, nil
+}
+
+// metadataDescription returns the description stored in specific_metadata.
+// Photo, video and audio metadata all keep it under the same key.
+func metadataDescription(meta dbtypes.SpecificMetadata) string {
+	if len(meta) == 0 {
+		return ""
+	}
+	var fields struct {
+		Description string `json:"description"`
+	}
+	if err := json.Unmarshal(meta, &fields); err != nil {
+		return ""
+	}
+	return fields.Description
+}
@@ -0,0 +1,160 @@
+package processors
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"server/internal/db/dbtypes"
+	"server/internal/db/repo"
+	"server/internal/service"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+// stubRefreshAssetService records description restores and rating writes; any
+// other call panics through the nil embedded interface.
+type stubRefreshAssetService struct {
+	service.AssetService
+	descriptions map[uuid.UUID]string
+	expected     map[uuid.UUID]string
+	ratings      map[uuid.UUID]int
+	customFields map[uuid.UUID]map[string]any
+}
+
+func newStubRefreshAssetService() *stubRefreshAssetService {
+	return &stubRefreshAssetService{
+		descriptions: map[uuid.UUID]string{},
+		expected:     map[uuid.UUID]string{},
+		ratings:      map[uuid.UUID]int{},
+	}
+}
+
+func (s *stubRefreshAssetService) RestoreAssetDescription(_ context.Context, id uuid.UUID, description, expected string) error {
+	s.descriptions[id] = description
+	s.expected[id] = expected
+	return nil
+}
+
+func (s *stubRefreshAssetService) UpdateAssetRating(_ context.Context, id uuid.UUID, rating int) error {
+	s.ratings[id] = rating
+	return nil
+}
+
+func (s *stubRefreshAssetService) UpdateAssetRatingAndLike(_ context.Context, id uuid.UUID, rating int, _ bool) error {
+	s.ratings[id] = rating
+	return nil
+}
+
+func (s *stubRefreshAssetService) GetAssetCustomFields(_ context.Context, id uuid.UUID) (map[string]any, error) {
+	return s.customFields[id], nil
+}
+
+func refreshTestAsset(t *testing.T, storagePath string, meta string) *repo.Asset {
+	t.Helper()
+	rating := int32(4)
+	return &repo.Asset{
+		AssetID:          pgtype.UUID{Bytes: uuid.New(), Valid: true},
+		Type:             string(dbtypes.AssetTypePhoto),
+		StoragePath:      &storagePath,
+		SpecificMetadata: dbtypes.SpecificMetadata(meta),
+		Rating:           &rating,
+	}
+}
+
+func TestRefreshAssetMetadataRestoresDescription(t *testing.T) {
+	repoPath := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(repoPath, "photo.jpg"), []byte("jpeg"), 0o644))
+
+	svc := newStubRefreshAssetService()
+	ap := &AssetProcessor{assetService: svc}
+	asset := refreshTestAsset(t, "photo.jpg", `{"camera_model":"old","description":"Family picnic"}`)
+	id := uuid.UUID(asset.AssetID.Bytes)
+
+	var extractedFrom string
+	err := ap.refreshAssetMetadata(context.Background(), asset, repoPath, func(fullPath string) error {
+		extractedFrom = fullPath
+		asset.SpecificMetadata = dbtypes.SpecificMetadata(`{"camera_model":"X100V"}`)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(repoPath, "photo.jpg"), extractedFrom)
+	require.Equal(t, "Family picnic", svc.descriptions[id])
+	// Restored only while the description is still the extracted (empty) one.
+	require.Contains(t, svc.expected, id)
+	require.Empty(t, svc.expected[id])
+	require.Empty(t, svc.ratings)
+}
+
+func TestRefreshAssetMetadataSkipsUnchangedDescription(t *testing.T) {
+	repoPath := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(repoPath, "photo.jpg"), []byte("jpeg"), 0o644))
+
+	svc := newStubRefreshAssetService()
+	ap := &AssetProcessor{assetService: svc}
+	asset := refreshTestAsset(t, "photo.jpg", `{"camera_model":"old","description":"Family picnic"}`)
+
+	require.NoError(t, ap.refreshAssetMetadata(context.Background(), asset, repoPath, func(string) error {
+		asset.SpecificMetadata = dbtypes.SpecificMetadata(`{"camera_model":"X100V","description":"Family picnic"}`)
+		return nil
+	}))
+	require.Empty(t, svc.descriptions)
+	require.Empty(t, svc.ratings)
+}
+
+func TestRefreshAssetMetadataSkipsEmptyDescription(t *testing.T) {
+	repoPath := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(repoPath, "photo.jpg"), []byte("jpeg"), 0o644))
+
+	svc := newStubRefreshAssetService()
+	ap := &AssetProcessor{assetService: svc}
+	asset := refreshTestAsset(t, "photo.jpg", `{"camera_model":"old"}`)
+
+	require.NoError(t, ap.refreshAssetMetadata(context.Background(), asset, repoPath, func(string) error { return nil }))
+	require.Empty(t, svc.descriptions)
+}
+
+func TestRefreshAssetMetadataKeepsCustomFields(t *testing.T) {
+	repoPath := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(repoPath, "photo.jpg"), []byte("jpeg"), 0o644))
+
+	asset := refreshTestAsset(t, "photo.jpg", `{"camera_model":"old"}`)
+	id := uuid.UUID(asset.AssetID.Bytes)
+	svc := newStubRefreshAssetService()
+	svc.customFields = map[uuid.UUID]map[string]any{id: {"model_release": "signed", "client": "Acme"}}
+	ap := &AssetProcessor{assetService: svc}
+
+	// Extraction replaces specific_metadata wholesale; custom fields live apart
+	// from it and any write to them would panic through the nil embedded service.
+	require.NoError(t, ap.refreshAssetMetadata(context.Background(), asset, repoPath, func(string) error {
+		asset.SpecificMetadata = dbtypes.SpecificMetadata(`{"camera_model":"X100V"}`)
+		return nil
+	}))
+
+	fields, err := svc.GetAssetCustomFields(context.Background(), id)
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{"model_release": "signed", "client": "Acme"}, fields)
+}
+
+func TestRefreshAssetMetadataMissingOriginal(t *testing.T) {
+	ap := &AssetProcessor{}
+	extract := func(string) error {
+		t.Fatal("extract must not run without an original")
+		return nil
+	}
+
+	err := ap.refreshAssetMetadata(context.Background(), refreshTestAsset(t, "gone.jpg", `{}`), t.TempDir(), extract)
+	require.ErrorIs(t, err, ErrOriginalMissing)
+
+	err = ap.refreshAssetMetadata(context.Background(), refreshTestAsset(t, "", `{}`), t.TempDir(), extract)
+	require.ErrorIs(t, err, ErrOriginalMissing)
+}
+
+func TestMetadataDescription(t *testing.T) {
+	require.Equal(t, "Song notes", metadataDescription(dbtypes.SpecificMetadata(`{"title":"x","description":"Song notes"}`)))
+	require.Empty(t, metadataDescription(nil))
+	require.Empty(t, metadataDescription(dbtypes.SpecificMetadata(`not json`)))
+}
@@ -37,6 +37,9 @@ func (ap *AssetProcessor) ProcessMetadataTask(ctx context.Context, args jobs.Met
 	if err != nil {
 		return err
 	}
+	if ap.skipCompletedTask(ctx, asset, taskMetadata, args.Force) {
+		return nil
+	}
 
 	return ap.runTrackedAssetTask(
 		ctx,
@@ -46,30 +49,36 @@ func (ap *AssetProcessor) ProcessMetadataTask(ctx context.Context, args jobs.Met
 		"Metadata extracted",
 		func() error {
 			fullPath := filepath.Join(args.RepoPath, args.StoragePath)
-			switch args.AssetType {
-			case dbtypes.AssetTypePhoto:
-				return ap.extractPhotoMetadata(ctx, asset, fullPath)
-			case dbtypes.AssetTypeVideo:
-				info, err := ap.getVideoInfo(fullPath)
-				if err != nil {
-					return err
-				}
-				return ap.extractVideoMetadata(ctx, asset, fullPath, info)
-			case dbtypes.AssetTypeAudio:
-				info, err := ap.getAudioInfo(fullPath)
-				if err != nil {
-					return err
-				}
-				return ap.extractAudioMetadata(ctx, asset, fullPath, info)
-			default:
-				return fmt.Errorf("unsupported asset type for metadata: %s", args.AssetType)
-			}
+			return ap.extractMetadata(ctx, asset, fullPath, args.AssetType, args.CaptureHints)
 		},
 	)
 }
 
-// extractPhotoMetadata extracts EXIF metadata for photos.
-func (ap *AssetProcessor) extractPhotoMetadata(ctx context.Context, asset *repo.Asset, fullPath string) error {
+// extractMetadata runs the type-specific extractor for an asset's original.
+func (ap *AssetProcessor) extractMetadata(ctx context.Context, asset *repo.Asset, fullPath string, assetType dbtypes.AssetType, hints *jobs.CaptureHints) error {
+	switch assetType {
+	case dbtypes.AssetTypePhoto:
+		return ap.extractPhotoMetadata(ctx, asset, fullPath, hints)
+	case dbtypes.AssetTypeVideo:
+		info, err := ap.getVideoInfo(fullPath)
+		if err != nil {
+			return err
+		}
+		return ap.extractVideoMetadata(ctx, asset, fullPath, info, hints)
+	case dbtypes.AssetTypeAudio:
+		info, err := ap.getAudioInfo(fullPath)
+		if err != nil {
+			return err
+		}
+		return ap.extractAudioMetadata(ctx, asset, fullPath, info)
+	default:
+		return fmt.Errorf("unsupported asset type for metadata: %s", assetType)
+	}
+}
+
+// extractPhotoMetadata extracts EXIF metadata for photos. Client capture hints
+// only fill fields the EXIF data left empty.
+func (ap *AssetProcessor) extractPhotoMetadata(ctx context.Context, asset *repo.Asset, fullPath string, hints *jobs.CaptureHints) error {
 	// EXIF extraction
 	exifCfg := ap.createEXIFConfig()
 	extractor := exif.NewExtractor(exifCfg)
@@ -104,6 +113,12 @@ func (ap *AssetProcessor) extractPhotoMetadata(ctx context.Context, asset *repo.
 		return fmt.Errorf("unexpected metadata type for photo: %T", res.Metadata)
 	}
 	meta.IsRAW = file.IsRAWFile(asset.OriginalFilename)
+	var sidecar *exif.EditorSidecar
+	if meta.IsRAW {
+		sidecar = ap.readEditorSidecar(asset, fullPath)
+		applyEditorSidecarMetadata(meta, sidecar)
+	}
+	applyPhotoCaptureHints(meta, hints)
 
 	// Parse dimensions and update asset
 	// The dimensions in meta.Dimensions are already corrected by orientation
@@ -123,6 +138,10 @@ func (ap *AssetProcessor) extractPhotoMetadata(ctx context.Context, asset *repo.
 	if err := ap.assetService.UpdateAssetMetadataWithExifRaw(ctx, asset.AssetID.Bytes, sm, res.Raw); err != nil {
 		return fmt.Errorf("update asset metadata: %w", err)
 	}
+	asset.SpecificMetadata = sm
+	if err := ap.importEditorSidecar(ctx, asset, sidecar); err != nil {
+		return err
+	}
 
 	if hasValidLocationGPS(meta.GPSLatitude, meta.GPSLongitude) {
 		ap.enqueueLocationClusterRebuild(ctx, asset)
@@ -189,6 +208,43 @@ func (ap *AssetProcessor) enqueueLivePhotoMatcher(ctx context.Context, asset *re
 	}
 }
 
+// applyPhotoCaptureHints seeds photo metadata from client capture hints where
+// EXIF did not provide a value. Extracted values are never overwritten.
+func applyPhotoCaptureHints(meta *dbtypes.PhotoSpecificMetadata, hints *jobs.CaptureHints) {
+	if meta == nil || hints == nil {
+		return
+	}
+	if meta.TakenTime == nil && hints.TakenTime != nil {
+		takenTime := *hints.TakenTime
+		meta.TakenTime = &takenTime
+	}
+	if !hasValidLocationGPS(meta.GPSLatitude, meta.GPSLongitude) && hasValidLocationGPS(hints.Latitude, hints.Longitude) {
+		latitude, longitude := *hints.Latitude, *hints.Longitude
+		meta.GPSLatitude, meta.GPSLongitude = &latitude, &longitude
+	}
+	if strings.TrimSpace(meta.CameraModel) == "" && hints.Device != "" {
+		meta.CameraModel = hints.Device
+	}
+}
+
+// applyVideoCaptureHints is the video counterpart of applyPhotoCaptureHints.
+func applyVideoCaptureHints(meta *dbtypes.VideoSpecificMetadata, hints *jobs.CaptureHints) {
+	if meta == nil || hints == nil {
+		return
+	}
+	if meta.RecordedTime == nil && hints.TakenTime != nil {
+		recordedTime := *hints.TakenTime
+		meta.RecordedTime = &recordedTime
+	}
+	if !hasValidLocationGPS(meta.GPSLatitude, meta.GPSLongitude) && hasValidLocationGPS(hints.Latitude, hints.Longitude) {
+		latitude, longitude := *hints.Latitude, *hints.Longitude
+		meta.GPSLatitude, meta.GPSLongitude = &latitude, &longitude
+	}
+	if strings.TrimSpace(meta.CameraModel) == "" && hints.Device != "" {
+		meta.CameraModel = hints.Device
+	}
+}
+
 func hasValidLocationGPS(latitude, longitude *float64) bool {
 	if latitude == nil || longitude == nil {
 		return false
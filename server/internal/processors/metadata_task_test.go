@@ -0,0 +1,84 @@
+package processors
+
+import (
+	"testing"
+	"time"
+
+	"server/internal/db/dbtypes"
+	"server/internal/queue/jobs"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyPhotoCaptureHintsFillsMissingFields(t *testing.T) {
+	takenTime := time.Date(2025, 7, 14, 9, 30, 0, 0, time.UTC)
+	latitude, longitude := 48.8584, 2.2945
+	meta := &dbtypes.PhotoSpecificMetadata{}
+
+	applyPhotoCaptureHints(meta, &jobs.CaptureHints{
+		TakenTime: &takenTime,
+		Latitude:  &latitude,
+		Longitude: &longitude,
+		Device:    "iPhone 15 Pro",
+	})
+
+	require.NotNil(t, meta.TakenTime)
+	require.True(t, meta.TakenTime.Equal(takenTime))
+	require.NotNil(t, meta.GPSLatitude)
+	require.NotNil(t, meta.GPSLongitude)
+	require.Equal(t, latitude, *meta.GPSLatitude)
+	require.Equal(t, longitude, *meta.GPSLongitude)
+	require.Equal(t, "iPhone 15 Pro", meta.CameraModel)
+}
+
+func TestApplyPhotoCaptureHintsKeepsExifValues(t *testing.T) {
+	exifTime := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	exifLat, exifLng := 35.6586, 139.7454
+	meta := &dbtypes.PhotoSpecificMetadata{
+		TakenTime:    &exifTime,
+		GPSLatitude:  &exifLat,
+		GPSLongitude: &exifLng,
+		CameraModel:  "Canon EOS R5",
+	}
+
+	clientTime := time.Date(2025, 7, 14, 9, 30, 0, 0, time.UTC)
+	clientLat, clientLng := 48.8584, 2.2945
+	applyPhotoCaptureHints(meta, &jobs.CaptureHints{
+		TakenTime: &clientTime,
+		Latitude:  &clientLat,
+		Longitude: &clientLng,
+		Device:    "iPhone 15 Pro",
+	})
+
+	require.True(t, meta.TakenTime.Equal(exifTime))
+	require.Equal(t, exifLat, *meta.GPSLatitude)
+	require.Equal(t, exifLng, *meta.GPSLongitude)
+	require.Equal(t, "Canon EOS R5", meta.CameraModel)
+}
+
+func TestApplyPhotoCaptureHintsIgnoresNilHints(t *testing.T) {
+	meta := &dbtypes.PhotoSpecificMetadata{}
+	applyPhotoCaptureHints(meta, nil)
+	require.Nil(t, meta.TakenTime)
+	require.Nil(t, meta.GPSLatitude)
+	require.Empty(t, meta.CameraModel)
+}
+
+func TestApplyVideoCaptureHintsFillsOnlyMissingFields(t *testing.T) {
+	recorded := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	meta := &dbtypes.VideoSpecificMetadata{RecordedTime: &recorded}
+
+	clientTime := time.Date(2025, 7, 14, 9, 30, 0, 0, time.UTC)
+	latitude, longitude := 48.8584, 2.2945
+	applyVideoCaptureHints(meta, &jobs.CaptureHints{
+		TakenTime: &clientTime,
+		Latitude:  &latitude,
+		Longitude: &longitude,
+		Device:    "Pixel 9",
+	})
+
+	require.True(t, meta.RecordedTime.Equal(recorded))
+	require.NotNil(t, meta.GPSLatitude)
+	require.Equal(t, latitude, *meta.GPSLatitude)
+	require.Equal(t, "Pixel 9", meta.CameraModel)
+}
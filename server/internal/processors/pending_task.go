@@ -0,0 +1,30 @@
+package processors
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"server/internal/queue/jobs"
+)
+
+// ProcessPendingAssets is the entry point for the pending processing worker.
+// It queues the pipeline for every deferred upload of the repository.
+func (ap *AssetProcessor) ProcessPendingAssets(ctx context.Context, args jobs.ProcessPendingAssetsArgs) error {
+	repoUUID, err := uuid.Parse(args.RepositoryID)
+	if err != nil {
+		return fmt.Errorf("invalid repository ID: %w", err)
+	}
+	queued, err := ap.materializer.ProcessDeferredAssets(ctx, repoUUID)
+	if err != nil {
+		return err
+	}
+	ap.logger.Info("queued deferred assets for processing",
+		zap.String("operation", "repository.process_pending"),
+		zap.String("repository_id", args.RepositoryID),
+		zap.Int("assets", queued),
+	)
+	return nil
+}
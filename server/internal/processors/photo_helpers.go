@@ -14,6 +14,8 @@ import (
 	"server/internal/db/repo"
 	"server/internal/queue/jobs"
 	"server/internal/service"
+	"server/internal/settings"
+	"server/internal/utils/blurhash"
 	"server/internal/utils/exif"
 	"server/internal/utils/imaging"
 	"server/internal/utils/phash"
@@ -39,19 +41,50 @@ func (ap *AssetProcessor) createEXIFConfig() *exif.Config {
 }
 
 // generateThumbnails builds all configured thumbnail sizes from the provided
-// image stream and opportunistically stores pHash from the generated small WebP.
+// image stream and opportunistically stores the BlurHash and pHash of the
+// generated small WebP.
 func (ap *AssetProcessor) generateThumbnails(ctx context.Context, reader io.Reader, repository repo.Repository, asset *repo.Asset) (bool, error) {
-	outputs := make(map[string]io.Writer, len(thumbnailSizes))
-	buffers := make(map[string]*bytes.Buffer, len(thumbnailSizes))
+	smallBytes, err := ap.saveThumbnailSizes(ctx, reader, repository, asset, thumbnailSizes)
+	if err != nil {
+		return false, err
+	}
 
-	for name := range thumbnailSizes {
+	if len(smallBytes) == 0 {
+		return true, nil
+	}
+	if err := ap.saveBlurHashFromReader(ctx, asset.AssetID, bytes.NewReader(smallBytes)); err != nil && ap.logger != nil {
+		ap.logger.Warn("blurhash failed",
+			zap.String("asset_id", fmt.Sprintf("%x", asset.AssetID.Bytes)),
+			zap.Error(err),
+		)
+	}
+	if err := ap.savePHashEmbeddingFromReader(ctx, asset.AssetID, bytes.NewReader(smallBytes)); err != nil {
+		if ap.logger != nil {
+			ap.logger.Warn("inline pHash failed; falling back to process_phash",
+				zap.String("asset_id", fmt.Sprintf("%x", asset.AssetID.Bytes)),
+				zap.Error(err),
+			)
+		}
+		return true, nil
+	}
+
+	return false, nil
+}
+
+// saveThumbnailSizes renders the given sizes from the image stream, saves
+// each one that was produced and returns the small WebP bytes, if any.
+func (ap *AssetProcessor) saveThumbnailSizes(ctx context.Context, reader io.Reader, repository repo.Repository, asset *repo.Asset, sizes map[string][2]int) ([]byte, error) {
+	outputs := make(map[string]io.Writer, len(sizes))
+	buffers := make(map[string]*bytes.Buffer, len(sizes))
+
+	for name := range sizes {
 		buf := &bytes.Buffer{}
 		buffers[name] = buf
 		outputs[name] = buf
 	}
 
-	if err := imaging.StreamThumbnails(reader, thumbnailSizes, outputs); err != nil {
-		return false, fmt.Errorf("generate_thumbnails: %w", err)
+	if err := imaging.StreamThumbnails(reader, sizes, outputs); err != nil {
+		return nil, fmt.Errorf("generate_thumbnails: %w", err)
 	}
 
 	var smallBytes []byte
@@ -64,24 +97,10 @@ func (ap *AssetProcessor) generateThumbnails(ctx context.Context, reader io.Read
 			continue
 		}
 		if err := ap.assetService.SaveNewThumbnail(ctx, repository.Path, buf, asset, name); err != nil {
-			return false, fmt.Errorf("save_thumbnails: %w", err)
+			return nil, fmt.Errorf("save_thumbnails: %w", err)
 		}
 	}
-
-	if len(smallBytes) == 0 {
-		return true, nil
-	}
-	if err := ap.savePHashEmbeddingFromReader(ctx, asset.AssetID, bytes.NewReader(smallBytes)); err != nil {
-		if ap.logger != nil {
-			ap.logger.Warn("inline pHash failed; falling back to process_phash",
-				zap.String("asset_id", fmt.Sprintf("%x", asset.AssetID.Bytes)),
-				zap.Error(err),
-			)
-		}
-		return true, nil
-	}
-
-	return false, nil
+	return smallBytes, nil
 }
 
 func (ap *AssetProcessor) enqueuePHashJob(ctx context.Context, assetID pgtype.UUID) error {
@@ -110,10 +129,36 @@ func (ap *AssetProcessor) savePHashEmbeddingFromReader(ctx context.Context, asse
 	return nil
 }
 
+// saveBlurHashFromReader stores the BlurHash of a thumbnail in the asset's
+// metadata. A missing placeholder only costs the client a blank box, so
+// failures are logged rather than failing the thumbnail task.
+func (ap *AssetProcessor) saveBlurHashFromReader(ctx context.Context, assetID pgtype.UUID, reader io.Reader) error {
+	if ap.queries == nil {
+		return fmt.Errorf("queries are not configured")
+	}
+	hash, err := blurhash.ComputeFromReader(reader)
+	if err != nil {
+		return err
+	}
+	if err := ap.queries.UpdateAssetBlurHash(ctx, repo.UpdateAssetBlurHashParams{Blurhash: hash, AssetID: assetID}); err != nil {
+		return fmt.Errorf("save blurhash: %w", err)
+	}
+	return nil
+}
+
+// mlConfigFor returns the ML settings that apply to assets of repository: the
+// runtime settings, with every task off when the repository disables ML.
+func (ap *AssetProcessor) mlConfigFor(ctx context.Context, repository repo.Repository) (settings.ML, error) {
+	if repository.Config.DisablesML() {
+		return settings.ML{}, nil
+	}
+	return ap.settingsService.GetEffectiveMLConfig(ctx)
+}
+
 // enqueueMLJobs enqueues enabled ML jobs based on runtime settings.
 // This is called during ingestion/discovery for photos to enqueue ML processing tasks.
-func (ap *AssetProcessor) enqueueMLJobs(ctx context.Context, asset *repo.Asset) error {
-	mlConfig, err := ap.settingsService.GetEffectiveMLConfig(ctx)
+func (ap *AssetProcessor) enqueueMLJobs(ctx context.Context, asset *repo.Asset, repository repo.Repository) error {
+	mlConfig, err := ap.mlConfigFor(ctx, repository)
 	if err != nil {
 		return fmt.Errorf("load ML settings: %w", err)
 	}
@@ -123,7 +168,17 @@ func (ap *AssetProcessor) enqueueMLJobs(ctx context.Context, asset *repo.Asset)
 		return nil
 	}
 
-	if mlConfig.SemanticEnabled {
+	semantic := mlConfig.SemanticEnabled
+	if semantic && ap.skipsSemantic(ctx, asset, repository) {
+		semantic = false
+		// Keep the tag retry worker from queueing it anyway.
+		if ap.queries != nil {
+			if err := ap.queries.MarkTagRetrySkipped(ctx, asset.AssetID); err != nil {
+				return fmt.Errorf("mark semantic skipped: %w", err)
+			}
+		}
+	}
+	if semantic {
 		if ap.lumenService == nil || ap.lumenService.IsTaskAvailable("semantic_image_embed") {
 			_, err = ap.queueClient.Insert(ctx, jobs.ProcessSemanticArgs{
 				AssetID:           asset.AssetID,
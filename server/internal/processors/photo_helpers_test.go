@@ -14,6 +14,8 @@ import (
 
 	"server/internal/db/repo"
 	"server/internal/service"
+	"server/internal/settings"
+	"server/internal/utils/blurhash"
 	"server/internal/utils/imaging"
 	"server/internal/utils/phash"
 )
@@ -91,7 +93,7 @@ func TestGenerateThumbnailsStoresInlinePHashAndKeepsLarge(t *testing.T) {
 		embeddingService: embedding,
 	}
 
-	fallback, err := ap.generateThumbnails(context.Background(), bytes.NewReader(testJPEG(t)), repo.Repository{Path: t.TempDir()}, asset)
+	fallback, err := ap.generateThumbnails(context.Background(), bytes.NewReader(testJPEGSized(t, 2048, 1536)), repo.Repository{Path: t.TempDir()}, asset)
 	if err != nil {
 		t.Fatalf("generateThumbnails: %v", err)
 	}
@@ -163,6 +165,21 @@ func TestSavePHashEmbeddingFromReaderReturnsSaveError(t *testing.T) {
 	}
 }
 
+func TestBlurHashOfSmallWebPThumbnailDecodes(t *testing.T) {
+	imaging.StartVips()
+
+	hash, err := blurhash.ComputeFromReader(bytes.NewReader(testSmallWebP(t)))
+	if err != nil {
+		t.Fatalf("ComputeFromReader: %v", err)
+	}
+	if hash[0] != 'L' {
+		t.Fatalf("size flag %q, want 'L' for a landscape thumbnail", hash[0])
+	}
+	if _, err := blurhash.Decode(hash, 32, 24); err != nil {
+		t.Fatalf("Decode(%q): %v", hash, err)
+	}
+}
+
 func testSmallWebP(t *testing.T) []byte {
 	t.Helper()
 
@@ -177,10 +194,17 @@ func testSmallWebP(t *testing.T) []byte {
 
 func testJPEG(t *testing.T) []byte {
 	t.Helper()
+	return testJPEGSized(t, 640, 480)
+}
+
+// testJPEGSized renders a w*h gradient JPEG. Thumbnails are never upscaled,
+// so tests expecting every size need a source at least as large as "large".
+func testJPEGSized(t *testing.T, w, h int) []byte {
+	t.Helper()
 
-	img := image.NewRGBA(image.Rect(0, 0, 640, 480))
-	for y := 0; y < 480; y++ {
-		for x := 0; x < 640; x++ {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
 			img.Set(x, y, color.RGBA{
 				R: uint8(x % 256),
 				G: uint8(y % 256),
@@ -200,3 +224,50 @@ func testJPEG(t *testing.T) []byte {
 func stringPtr(s string) *string {
 	return &s
 }
+
+type mlSettingsStub struct {
+	service.SettingsService
+	ml settings.ML
+}
+
+func (s mlSettingsStub) GetEffectiveMLConfig(context.Context) (settings.ML, error) {
+	return s.ml, nil
+}
+
+func TestEnqueueMLJobsSkipsRepositoryWithMLDisabled(t *testing.T) {
+	global := settings.ML{SemanticEnabled: true, BioCLIPEnabled: true, OCREnabled: true, FaceEnabled: true}
+	// No queue client: enqueueing any ML job would panic.
+	ap := &AssetProcessor{settingsService: mlSettingsStub{ml: global}}
+
+	disabled := false
+	documents := repo.Repository{}
+	documents.Config.LocalSettings.MLEnabled = &disabled
+
+	cfg, err := ap.mlConfigFor(context.Background(), documents)
+	if err != nil {
+		t.Fatalf("mlConfigFor: %v", err)
+	}
+	if cfg.HasManualTasksEnabled() {
+		t.Fatalf("ML config for a repository with ml_enabled=false = %+v, want every task off", cfg)
+	}
+	asset := &repo.Asset{AssetID: pgtype.UUID{Valid: true}}
+	if err := ap.enqueueMLJobs(context.Background(), asset, documents); err != nil {
+		t.Fatalf("enqueueMLJobs: %v", err)
+	}
+	if err := ap.retryMLJobs(context.Background(), asset, documents, map[string]bool{"process_semantic": true, "process_face": true}); err != nil {
+		t.Fatalf("retryMLJobs: %v", err)
+	}
+
+	enabled := true
+	photos := repo.Repository{}
+	for _, override := range []*bool{nil, &enabled} {
+		photos.Config.LocalSettings.MLEnabled = override
+		cfg, err := ap.mlConfigFor(context.Background(), photos)
+		if err != nil {
+			t.Fatalf("mlConfigFor: %v", err)
+		}
+		if cfg != global {
+			t.Fatalf("ML config with ml_enabled=%v = %+v, want the global settings", override, cfg)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package processors
+
+import (
+	"context"
+	"strings"
+
+	"server/internal/db/repo"
+)
+
+// screenResolutions are the pixel sizes, short side first, of common phone,
+// tablet and desktop screens. Cameras do not produce these sizes, so a photo
+// that has one is almost always a screenshot.
+var screenResolutions = map[[2]int32]bool{
+	// iPhone
+	{640, 1136}: true, {750, 1334}: true, {1080, 1920}: true, {1242, 2208}: true,
+	{828, 1792}: true, {1125, 2436}: true, {1242, 2688}: true, {1080, 2340}: true,
+	{1170, 2532}: true, {1284, 2778}: true, {1179, 2556}: true, {1290, 2796}: true,
+	{1206, 2622}: true, {1320, 2868}: true,
+	// Android
+	{720, 1280}: true, {720, 1600}: true, {1080, 2400}: true, {1080, 2160}: true,
+	{1440, 2560}: true, {1440, 2960}: true, {1440, 3040}: true, {1440, 3120}: true,
+	{1440, 3200}: true,
+	// iPad
+	{1536, 2048}: true, {1620, 2160}: true, {1640, 2360}: true, {1668, 2224}: true,
+	{1668, 2388}: true, {2048, 2732}: true, {1488, 2266}: true,
+	// Desktop and laptop
+	{768, 1366}: true, {900, 1440}: true, {900, 1600}: true, {1050, 1680}: true,
+	{1200, 1920}: true, {1600, 2560}: true, {1800, 2880}: true,
+	{1964, 3024}: true, {2234, 3456}: true, {1080, 2560}: true, {1440, 3440}: true,
+	{2160, 3840}: true, {2880, 5120}: true, {800, 1280}: true,
+}
+
+// screenshotNamePrefixes are lowercase name prefixes that operating systems
+// give screenshots ("Screenshot_20240101-101010.png", "Screen Shot 2020-…").
+var screenshotNamePrefixes = []string{"screenshot", "screen shot"}
+
+// skipsSemantic reports whether the repository's semantic_skip rule keeps the
+// photo out of semantic indexing.
+func (ap *AssetProcessor) skipsSemantic(ctx context.Context, asset *repo.Asset, repository repo.Repository) bool {
+	rule := repository.Config.LocalSettings.SemanticSkip
+	if rule == nil {
+		return false
+	}
+	if rule.SkipsExtension(asset.OriginalFilename) {
+		return true
+	}
+	if !rule.Screenshots {
+		return false
+	}
+	if (asset.Width == nil || asset.Height == nil) && ap.queries != nil {
+		// Metadata extraction runs alongside thumbnails and may have stored
+		// the size since the asset was loaded.
+		if fresh, err := ap.queries.GetAssetByID(ctx, asset.AssetID); err == nil {
+			asset = &fresh
+		}
+	}
+	return looksLikeScreenshot(asset)
+}
+
+// looksLikeScreenshot judges by pixel size when it is known and by name
+// otherwise; a screenshot renamed on a device with an unusual screen is missed.
+func looksLikeScreenshot(asset *repo.Asset) bool {
+	name := strings.ToLower(asset.OriginalFilename)
+	for _, prefix := range screenshotNamePrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	if asset.Width == nil || asset.Height == nil {
+		return false
+	}
+	short, long := *asset.Width, *asset.Height
+	if short > long {
+		short, long = long, short
+	}
+	return screenResolutions[[2]int32{short, long}]
+}
@@ -0,0 +1,66 @@
+package processors
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"server/internal/db/repo"
+	"server/internal/settings"
+	"server/internal/storage/repocfg"
+)
+
+func sizedPhoto(name string, width, height int32) *repo.Asset {
+	return &repo.Asset{AssetID: pgtype.UUID{Valid: true}, OriginalFilename: name, Width: &width, Height: &height}
+}
+
+func TestEnqueueMLJobsSkipsSemanticForScreenshots(t *testing.T) {
+	// Only semantic indexing is on and there is no queue client: queueing
+	// the job would panic.
+	ap := &AssetProcessor{settingsService: mlSettingsStub{ml: settings.ML{SemanticEnabled: true}}}
+	screens := repo.Repository{}
+	screens.Config.LocalSettings.SemanticSkip = &repocfg.SemanticSkip{Screenshots: true}
+
+	for _, asset := range []*repo.Asset{
+		sizedPhoto("IMG_0042.PNG", 1179, 2556),
+		sizedPhoto("IMG_0043.PNG", 2556, 1179),
+		sizedPhoto("desktop.png", 2560, 1440),
+	} {
+		if err := ap.enqueueMLJobs(context.Background(), asset, screens); err != nil {
+			t.Fatalf("enqueueMLJobs(%s): %v", asset.OriginalFilename, err)
+		}
+	}
+}
+
+func TestSkipsSemantic(t *testing.T) {
+	ap := &AssetProcessor{}
+	screenshots := &repocfg.SemanticSkip{Screenshots: true}
+	scans := &repocfg.SemanticSkip{Extensions: []string{".tif", ".tiff"}}
+
+	tests := []struct {
+		name  string
+		rule  *repocfg.SemanticSkip
+		asset *repo.Asset
+		want  bool
+	}{
+		{"no rule", nil, sizedPhoto("IMG_0042.PNG", 1179, 2556), false},
+		{"phone screen", screenshots, sizedPhoto("IMG_0042.PNG", 1179, 2556), true},
+		{"camera photo", screenshots, sizedPhoto("IMG_0044.JPG", 3024, 4032), false},
+		{"screenshot name, size unknown", screenshots, &repo.Asset{OriginalFilename: "Screenshot_20240101-101010.png"}, true},
+		{"macOS screenshot name", screenshots, sizedPhoto("Screen Shot 2020-05-01 at 10.00.00.png", 1000, 700), true},
+		{"camera name, size unknown", screenshots, &repo.Asset{OriginalFilename: "IMG_0044.JPG"}, false},
+		{"screen size with screenshots off", scans, sizedPhoto("IMG_0042.PNG", 1179, 2556), false},
+		{"skipped extension", scans, sizedPhoto("page-1.TIFF", 2480, 3508), true},
+		{"other extension", scans, sizedPhoto("page-1.jpg", 2480, 3508), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repository := repo.Repository{}
+			repository.Config.LocalSettings.SemanticSkip = tt.rule
+			if got := ap.skipsSemantic(context.Background(), tt.asset, repository); got != tt.want {
+				t.Fatalf("skipsSemantic = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package processors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"go.uber.org/zap"
+
+	"server/internal/db/dbtypes"
+	"server/internal/db/repo"
+	"server/internal/utils/imagesource"
+)
+
+// ErrThumbnailUnsupported is returned when thumbnails cannot be generated on
+// demand for an asset: only photos are rendered inline, video frames and
+// audio waveforms are left to the thumbnail worker.
+var ErrThumbnailUnsupported = errors.New("on-demand thumbnails are only available for photos")
+
+// GenerateMissingThumbnail renders the requested thumbnail size, and any
+// smaller size, from a photo's original and saves them. It is the fallback
+// for thumbnails requested before the pipeline produced them, for example
+// for files the watcher imported moments ago. Requests for the same asset
+// and size share one render; a caller that waited finds the thumbnail saved
+// and returns without rendering again.
+func (ap *AssetProcessor) GenerateMissingThumbnail(ctx context.Context, assetID pgtype.UUID, size string) error {
+	sizes := thumbnailSizesUpTo(size)
+	if len(sizes) == 0 {
+		return fmt.Errorf("unknown thumbnail size %q", size)
+	}
+
+	unlock := ap.thumbnailLocks.Lock(assetID.String() + "/" + size)
+	defer unlock()
+
+	asset, repository, err := ap.loadAssetAndRepo(ctx, assetID)
+	if err != nil {
+		return err
+	}
+	if dbtypes.AssetType(asset.Type) != dbtypes.AssetTypePhoto {
+		return ErrThumbnailUnsupported
+	}
+	if ap.thumbnailOnDisk(ctx, assetID, repository.Path, sizes) {
+		return nil
+	}
+
+	fullPath, err := originalPath(asset, repository.Path)
+	if err != nil {
+		return err
+	}
+	reader, err := imagesource.OpenPhoto(ctx, fullPath, asset.OriginalFilename)
+	if err != nil {
+		return fmt.Errorf("open photo source: %w", err)
+	}
+	defer reader.Close()
+
+	if _, err := ap.saveThumbnailSizes(ctx, reader, repository, asset, sizes); err != nil {
+		return err
+	}
+	ap.logger.Info("generated missing thumbnail",
+		zap.String("asset_id", assetID.String()),
+		zap.String("size", size),
+	)
+	return nil
+}
+
+// thumbnailSizesUpTo returns size and every configured size smaller than it.
+func thumbnailSizesUpTo(size string) map[string][2]int {
+	limit, ok := thumbnailSizes[size]
+	if !ok {
+		return nil
+	}
+	sizes := make(map[string][2]int, len(thumbnailSizes))
+	for name, dim := range thumbnailSizes {
+		if dim[0] <= limit[0] && dim[1] <= limit[1] {
+			sizes[name] = dim
+		}
+	}
+	return sizes
+}
+
+// thumbnailOnDisk reports whether one of sizes already has a thumbnail
+// record whose file exists.
+func (ap *AssetProcessor) thumbnailOnDisk(ctx context.Context, assetID pgtype.UUID, repoPath string, sizes map[string][2]int) bool {
+	for name := range sizes {
+		thumbnail, err := ap.queries.GetThumbnailByAssetAndSize(ctx, repo.GetThumbnailByAssetAndSizeParams{
+			AssetID: assetID,
+			Size:    name,
+		})
+		if err != nil {
+			continue
+		}
+		path := thumbnail.StoragePath
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(repoPath, path)
+		}
+		if _, err := os.Stat(path); err == nil {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,24 @@
+package processors
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestThumbnailSizesUpTo(t *testing.T) {
+	require.Equal(t, []string{"small"}, sizeNames(thumbnailSizesUpTo("small")))
+	require.Equal(t, []string{"medium", "small"}, sizeNames(thumbnailSizesUpTo("medium")))
+	require.Equal(t, []string{"large", "medium", "small"}, sizeNames(thumbnailSizesUpTo("large")))
+	require.Empty(t, thumbnailSizesUpTo("huge"))
+}
+
+func sizeNames(sizes map[string][2]int) []string {
+	keys := make([]string, 0, len(sizes))
+	for _, name := range []string{"large", "medium", "small"} {
+		if _, ok := sizes[name]; ok {
+			keys = append(keys, name)
+		}
+	}
+	return keys
+}
@@ -28,6 +28,17 @@ func (ap *AssetProcessor) ProcessThumbnailTask(ctx context.Context, args jobs.Th
 	if err != nil {
 		return err
 	}
+	if ap.skipCompletedTask(ctx, asset, taskThumbnail, args.Force) {
+		// An earlier attempt may have generated thumbnails and then failed to
+		// queue the ML follow-ups. Queueing them again is safe: ML jobs are
+		// unique by args, so ones already queued or done are not duplicated.
+		if args.AssetType == dbtypes.AssetTypePhoto {
+			if err := ap.enqueueMLJobs(ctx, asset, repository); err != nil {
+				return fmt.Errorf("enqueue ML jobs: %w", err)
+			}
+		}
+		return nil
+	}
 
 	needsPHashFallback := false
 	if err := ap.runTrackedAssetTask(
@@ -48,7 +59,7 @@ func (ap *AssetProcessor) ProcessThumbnailTask(ctx context.Context, args jobs.Th
 				if err != nil {
 					return err
 				}
-				return ap.generateVideoThumbnail(ctx, repository.Path, asset, fullPath, info, ap.transcodeConfig)
+				return ap.generateVideoThumbnail(ctx, repository, asset, fullPath, info, ap.transcodeConfig)
 			case dbtypes.AssetTypeAudio:
 				// Optional waveform thumbnail for audio
 				return ap.generateWaveform(ctx, repository.Path, asset, fullPath)
@@ -67,7 +78,7 @@ func (ap *AssetProcessor) ProcessThumbnailTask(ctx context.Context, args jobs.Th
 			}
 		}
 
-		if err := ap.enqueueMLJobs(ctx, asset); err != nil {
+		if err := ap.enqueueMLJobs(ctx, asset, repository); err != nil {
 			return fmt.Errorf("enqueue ML jobs: %w", err)
 		}
 	}
@@ -7,6 +7,9 @@ import (
 
 	"server/internal/db/dbtypes"
 	"server/internal/queue/jobs"
+	"server/internal/storage"
+
+	"go.uber.org/zap"
 )
 
 // ProcessTranscodeTask handles video/audio transcoding.
@@ -15,6 +18,9 @@ func (ap *AssetProcessor) ProcessTranscodeTask(ctx context.Context, args jobs.Tr
 	if err != nil {
 		return err
 	}
+	if ap.skipCompletedTask(ctx, asset, taskTranscode, args.Force) {
+		return nil
+	}
 
 	return ap.runTrackedAssetTask(
 		ctx,
@@ -30,7 +36,19 @@ func (ap *AssetProcessor) ProcessTranscodeTask(ctx context.Context, args jobs.Tr
 				if err != nil {
 					return err
 				}
-				return ap.transcodeVideoSmart(ctx, repository.Path, asset, fullPath, info, ap.transcodeConfig)
+				if err := ap.transcodeVideoSmart(ctx, repository.Path, asset, fullPath, info, ap.transcodeConfig); err != nil {
+					return err
+				}
+				// HLS is an optional second rendition; the MP4 already plays.
+				if ap.transcodeConfig.HLSEnabled && info.Duration >= hlsMinDuration && asset.ContentHash != "" {
+					webPath := storage.WebVideoPath(repository.Path, asset.ContentHash)
+					if err := ap.segmentVideoHLS(ctx, repository.Path, asset, webPath); err != nil {
+						ap.logger.Warn("failed to segment video for hls",
+							zap.String("asset_id", asset.AssetID.String()),
+							zap.Error(err))
+					}
+				}
+				return nil
 			case dbtypes.AssetTypeAudio:
 				info, err := ap.getAudioInfo(fullPath)
 				if err != nil {
@@ -5,7 +5,6 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -17,9 +16,11 @@ import (
 	"server/config"
 	"server/internal/db/dbtypes"
 	"server/internal/db/repo"
+	"server/internal/queue/jobs"
 	"server/internal/utils/exif"
-	"server/internal/utils/imaging"
 	"server/internal/utils/sysproc"
+
+	"go.uber.org/zap"
 )
 
 // VideoInfo holds video metadata.
@@ -29,10 +30,14 @@ type VideoInfo struct {
 	Duration float64
 	Codec    string
 	Format   string
+	// AudioCodec is empty for videos without sound.
+	AudioCodec string
+	// BitRate is the overall bitrate in bits per second, 0 when unknown.
+	BitRate int64
 }
 
 // extractVideoMetadata updates the asset with ffprobe/EXIF-derived metadata.
-func (ap *AssetProcessor) extractVideoMetadata(ctx context.Context, asset *repo.Asset, videoPath string, videoInfo *VideoInfo) error {
+func (ap *AssetProcessor) extractVideoMetadata(ctx context.Context, asset *repo.Asset, videoPath string, videoInfo *VideoInfo, hints *jobs.CaptureHints) error {
 	file, err := os.Open(videoPath)
 	if err != nil {
 		return fmt.Errorf("open video file: %w", err)
@@ -69,6 +74,7 @@ func (ap *AssetProcessor) extractVideoMetadata(ctx context.Context, asset *repo.
 	if !ok {
 		return fmt.Errorf("unexpected metadata type for video: %T", result.Metadata)
 	}
+	applyVideoCaptureHints(meta, hints)
 
 	if err := ap.assetService.UpdateAssetDuration(ctx, asset.AssetID.Bytes, videoInfo.Duration); err != nil {
 		return fmt.Errorf("update duration: %w", err)
@@ -83,6 +89,7 @@ func (ap *AssetProcessor) extractVideoMetadata(ctx context.Context, asset *repo.
 	if err := ap.assetService.UpdateAssetMetadataWithExifRaw(ctx, asset.AssetID.Bytes, sm, result.Raw); err != nil {
 		return fmt.Errorf("save metadata: %w", err)
 	}
+	asset.SpecificMetadata = sm
 	ap.enqueueLivePhotoMatcher(ctx, asset, meta.ContentIdentifier)
 
 	return nil
@@ -100,9 +107,9 @@ func (ap *AssetProcessor) transcodeVideoSmart(ctx context.Context, repoPath stri
 
 	isLandscape := videoInfo.Width >= videoInfo.Height
 
-	// Already within bounds: copy if H.264 MP4, otherwise transcode at original size.
+	// Already within bounds: copy if web-friendly, otherwise transcode at original size.
 	if longSide <= maxDimension {
-		if isLandscape && strings.ToLower(videoInfo.Format) == "mp4" && strings.Contains(strings.ToLower(videoInfo.Codec), "h264") {
+		if isLandscape && webFriendlyVideo(videoPath, videoInfo, cfg.MaxBitrateKbps) {
 			return ap.copyVideoAsWebVersion(ctx, repoPath, asset, videoPath, "web")
 		}
 		scaleFilter := buildScaleFilter(videoInfo.Width, videoInfo.Height, videoInfo.Width, videoInfo.Height)
@@ -153,13 +160,39 @@ func buildScaleFilter(srcW, srcH, targetW, targetH int) string {
 	return fmt.Sprintf("scale=%d:-2", targetW)
 }
 
-// bitrateForResolution computes maxrate/bufsize based on pixel count.
-func bitrateForResolution(width, height int) (maxrate, bufsize string) {
+// webFriendlyVideo reports whether a video browsers play as is can be served
+// without transcoding: H.264 in an MP4 with AAC or no audio and, when
+// maxBitrateKbps is set, a known bitrate within it. ffprobe names the whole
+// MP4/MOV family "mov,mp4,...", so the extension tells the two apart.
+func webFriendlyVideo(videoPath string, info *VideoInfo, maxBitrateKbps int) bool {
+	switch strings.ToLower(filepath.Ext(videoPath)) {
+	case ".mp4", ".m4v":
+	default:
+		return false
+	}
+	if !strings.Contains(strings.ToLower(info.Format), "mp4") || !strings.Contains(strings.ToLower(info.Codec), "h264") {
+		return false
+	}
+	if info.AudioCodec != "" && info.AudioCodec != "aac" {
+		return false
+	}
+	if maxBitrateKbps > 0 && (info.BitRate <= 0 || info.BitRate > int64(maxBitrateKbps)*1000) {
+		return false
+	}
+	return true
+}
+
+// bitrateForResolution computes maxrate/bufsize based on pixel count, capped
+// at maxKbps when it is set.
+func bitrateForResolution(width, height, maxKbps int) (maxrate, bufsize string) {
 	pixels := width * height
 	rate := pixels / 300 // kbps, e.g. 1920×1080 → ~6912k
 	if rate < 2000 {
 		rate = 2000
 	}
+	if maxKbps > 0 && rate > maxKbps {
+		rate = maxKbps
+	}
 	return fmt.Sprintf("%dk", rate), fmt.Sprintf("%dk", rate*2)
 }
 
@@ -198,7 +231,7 @@ func resolveHardwareAccel(mode string) string {
 
 func buildTranscodeArgs(inputPath, outputPath, scaleFilter string, approxWidth, approxHeight int, cfg config.TranscodeConfig) []string {
 	scaleExpr := scaleFilter[len("scale="):] // w:h portion, reused for VAAPI
-	maxrate, bufsize := bitrateForResolution(approxWidth, approxHeight)
+	maxrate, bufsize := bitrateForResolution(approxWidth, approxHeight, cfg.MaxBitrateKbps)
 
 	accel := resolveHardwareAccel(cfg.HardwareAccel)
 
@@ -329,17 +362,66 @@ func (ap *AssetProcessor) saveTranscodedVideo(ctx context.Context, repoPath stri
 	return ap.assetService.SaveVideoVersion(ctx, repoPath, transcodedFile, asset, version)
 }
 
-// generateVideoThumbnail creates thumbnails from a representative video frame.
-func (ap *AssetProcessor) generateVideoThumbnail(ctx context.Context, repoPath string, asset *repo.Asset, videoPath string, info *VideoInfo, cfg config.TranscodeConfig) error {
+// videoPosterFraction is how far into a video its poster frame is taken:
+// late enough to skip fade-ins and black leaders, early enough to stay in
+// the opening scene.
+const videoPosterFraction = 0.1
+
+// videoPosterOffset returns where to grab the poster frame, in seconds. Zero
+// means the first frame, used when the duration is unknown.
+func videoPosterOffset(duration float64) float64 {
+	if duration <= 0 {
+		return 0
+	}
+	return duration * videoPosterFraction
+}
+
+// generateVideoThumbnail renders the thumbnail sizes from a representative
+// video frame and stores them like photo thumbnails. A video no frame can be
+// read from is left without thumbnails and logged rather than failing the
+// task, since retrying will not decode it either.
+func (ap *AssetProcessor) generateVideoThumbnail(ctx context.Context, repository repo.Repository, asset *repo.Asset, videoPath string, info *VideoInfo, cfg config.TranscodeConfig) error {
 	outputPath := filepath.Join(os.TempDir(), fmt.Sprintf("thumb_%s.jpg", asset.AssetID))
 	defer os.Remove(outputPath)
 
-	thumbnailTime := "00:00:01"
-	if info.Duration > 0 && info.Duration < 10 {
-		thumbnailSeconds := info.Duration * 0.1
-		thumbnailTime = fmt.Sprintf("00:00:%02d", int(thumbnailSeconds))
+	offset := videoPosterOffset(info.Duration)
+	err := ap.extractVideoFrame(ctx, videoPath, outputPath, offset, cfg)
+	if err != nil && offset > 0 && ctx.Err() == nil {
+		// The probed duration can overstate a truncated or oddly muxed
+		// file, leaving the seek past the last frame; the first keyframe
+		// always exists.
+		ap.logger.Debug("poster frame seek failed; using the first frame",
+			zap.String("asset_id", asset.AssetID.String()),
+			zap.Float64("offset_seconds", offset),
+			zap.Error(err),
+		)
+		err = ap.extractVideoFrame(ctx, videoPath, outputPath, 0, cfg)
+	}
+	if err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		ap.logger.Warn("no video frame could be extracted; leaving the video without thumbnails",
+			zap.String("asset_id", asset.AssetID.String()),
+			zap.String("path", videoPath),
+			zap.Error(err),
+		)
+		return nil
 	}
 
+	frame, err := os.Open(outputPath)
+	if err != nil {
+		return fmt.Errorf("open video frame: %w", err)
+	}
+	defer frame.Close()
+
+	_, err = ap.saveThumbnailSizes(ctx, frame, repository, asset, thumbnailSizes)
+	return err
+}
+
+// extractVideoFrame writes the frame at offset seconds, or the first frame
+// when offset is zero, to outputPath as a JPEG.
+func (ap *AssetProcessor) extractVideoFrame(ctx context.Context, videoPath, outputPath string, offset float64, cfg config.TranscodeConfig) error {
 	args := []string{}
 
 	accel := resolveHardwareAccel(cfg.HardwareAccel)
@@ -363,9 +445,14 @@ func (ap *AssetProcessor) generateVideoThumbnail(ctx context.Context, repoPath s
 		)
 	}
 
+	if offset > 0 {
+		// Input seeking lands on the keyframe before offset, which is
+		// fast and decodes cleanly.
+		args = append(args, "-ss", strconv.FormatFloat(offset, 'f', 3, 64))
+	}
 	args = append(args,
-		"-ss", thumbnailTime,
 		"-i", videoPath,
+		"-map", "0:v:0",
 		"-vframes", "1",
 		"-q:v", "2",
 		"-vf", "scale='min(1920,iw)':'min(1080,ih)':force_original_aspect_ratio=decrease",
@@ -382,47 +469,23 @@ func (ap *AssetProcessor) generateVideoThumbnail(ctx context.Context, repoPath s
 	cmd.Stderr = &stderr
 
 	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("generate thumbnail: %w\nstderr: %s", err, stderr.String())
-	}
-
-	thumbnailFile, err := os.Open(outputPath)
-	if err != nil {
-		return fmt.Errorf("open thumbnail: %w", err)
-	}
-	defer thumbnailFile.Close()
-
-	outputs := make(map[string]io.Writer, len(thumbnailSizes))
-	buffers := make(map[string]*bytes.Buffer, len(thumbnailSizes))
-	for name := range thumbnailSizes {
-		buf := &bytes.Buffer{}
-		buffers[name] = buf
-		outputs[name] = buf
+		return fmt.Errorf("extract frame: %w\nstderr: %s", err, stderr.String())
 	}
-
-	if err := imaging.StreamThumbnails(thumbnailFile, thumbnailSizes, outputs); err != nil {
-		return fmt.Errorf("generate thumbnails: %w", err)
+	// Seeking past the end is not an ffmpeg error; it just writes nothing.
+	if stat, err := os.Stat(outputPath); err != nil || stat.Size() == 0 {
+		return fmt.Errorf("extract frame: no frame at %.3fs", offset)
 	}
-
-	for name, buf := range buffers {
-		if buf.Len() == 0 {
-			continue
-		}
-		if err := ap.assetService.SaveNewThumbnail(ctx, repoPath, buf, asset, name); err != nil {
-			return fmt.Errorf("save thumbnail %s: %w", name, err)
-		}
-	}
-
 	return nil
 }
 
-// getVideoInfo probes the video using ffprobe to collect dimensions, codec, format, and duration.
+// getVideoInfo probes the video using ffprobe to collect dimensions, codecs,
+// format, bitrate and duration.
 func (ap *AssetProcessor) getVideoInfo(videoPath string) (*VideoInfo, error) {
 	cmd := exec.Command(ap.toolsConfig.FFprobeCommand(),
 		"-v", "quiet",
 		"-print_format", "json",
 		"-show_format",
 		"-show_streams",
-		"-select_streams", "v:0",
 		videoPath,
 	)
 	sysproc.HideConsole(cmd)
@@ -434,6 +497,7 @@ func (ap *AssetProcessor) getVideoInfo(videoPath string) (*VideoInfo, error) {
 
 	var probeData struct {
 		Streams []struct {
+			CodecType string `json:"codec_type"`
 			Width     int    `json:"width"`
 			Height    int    `json:"height"`
 			CodecName string `json:"codec_name"`
@@ -442,6 +506,7 @@ func (ap *AssetProcessor) getVideoInfo(videoPath string) (*VideoInfo, error) {
 		Format struct {
 			FormatName string `json:"format_name"`
 			Duration   string `json:"duration"`
+			BitRate    string `json:"bit_rate"`
 		} `json:"format"`
 	}
 
@@ -451,20 +516,29 @@ func (ap *AssetProcessor) getVideoInfo(videoPath string) (*VideoInfo, error) {
 
 	info := &VideoInfo{}
 
-	if len(probeData.Streams) > 0 {
-		stream := probeData.Streams[0]
-		info.Width = stream.Width
-		info.Height = stream.Height
-		info.Codec = stream.CodecName
-
-		if stream.Duration != "" {
-			if duration, err := strconv.ParseFloat(stream.Duration, 64); err == nil {
-				info.Duration = duration
+	videoSeen := false
+	for _, stream := range probeData.Streams {
+		switch {
+		case stream.CodecType == "video" && !videoSeen:
+			videoSeen = true
+			info.Width = stream.Width
+			info.Height = stream.Height
+			info.Codec = stream.CodecName
+
+			if stream.Duration != "" {
+				if duration, err := strconv.ParseFloat(stream.Duration, 64); err == nil {
+					info.Duration = duration
+				}
 			}
+		case stream.CodecType == "audio" && info.AudioCodec == "":
+			info.AudioCodec = stream.CodecName
 		}
 	}
 
 	info.Format = probeData.Format.FormatName
+	if bitRate, err := strconv.ParseInt(probeData.Format.BitRate, 10, 64); err == nil {
+		info.BitRate = bitRate
+	}
 
 	if info.Duration == 0 && probeData.Format.Duration != "" {
 		if duration, err := strconv.ParseFloat(probeData.Format.Duration, 64); err == nil {
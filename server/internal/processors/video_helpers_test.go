@@ -73,3 +73,76 @@ func TestBuildTranscodeArgs(t *testing.T) {
 		}
 	}
 }
+
+func TestBitrateForResolutionHonorsCap(t *testing.T) {
+	tests := []struct {
+		width, height, maxKbps int
+		maxrate, bufsize       string
+	}{
+		{1920, 1080, 0, "6912k", "13824k"},
+		{1920, 1080, 4000, "4000k", "8000k"},
+		{1920, 1080, 10000, "6912k", "13824k"},
+		{640, 360, 0, "2000k", "4000k"},
+		{640, 360, 1500, "1500k", "3000k"},
+	}
+
+	for _, tt := range tests {
+		maxrate, bufsize := bitrateForResolution(tt.width, tt.height, tt.maxKbps)
+		if maxrate != tt.maxrate || bufsize != tt.bufsize {
+			t.Errorf("bitrateForResolution(%d, %d, %d) = %s, %s; want %s, %s",
+				tt.width, tt.height, tt.maxKbps, maxrate, bufsize, tt.maxrate, tt.bufsize)
+		}
+	}
+}
+
+func TestWebFriendlyVideo(t *testing.T) {
+	mp4 := func(mutate func(*VideoInfo)) *VideoInfo {
+		info := &VideoInfo{Format: "mov,mp4,m4a,3gp,3g2,mj2", Codec: "h264", AudioCodec: "aac", BitRate: 5_000_000}
+		if mutate != nil {
+			mutate(info)
+		}
+		return info
+	}
+
+	tests := []struct {
+		name    string
+		path    string
+		info    *VideoInfo
+		maxKbps int
+		want    bool
+	}{
+		{"h264 aac mp4", "clip.mp4", mp4(nil), 0, true},
+		{"m4v", "clip.M4V", mp4(nil), 0, true},
+		{"silent", "clip.mp4", mp4(func(i *VideoInfo) { i.AudioCodec = "" }), 0, true},
+		{"within cap", "clip.mp4", mp4(nil), 6000, true},
+		{"mov container", "clip.mov", mp4(nil), 0, false},
+		{"hevc", "clip.mp4", mp4(func(i *VideoInfo) { i.Codec = "hevc" }), 0, false},
+		{"pcm audio", "clip.mp4", mp4(func(i *VideoInfo) { i.AudioCodec = "pcm_s16le" }), 0, false},
+		{"over cap", "clip.mp4", mp4(nil), 4000, false},
+		{"unknown bitrate under cap", "clip.mp4", mp4(func(i *VideoInfo) { i.BitRate = 0 }), 4000, false},
+	}
+
+	for _, tt := range tests {
+		if got := webFriendlyVideo(tt.path, tt.info, tt.maxKbps); got != tt.want {
+			t.Errorf("%s: webFriendlyVideo = %v; want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestVideoPosterOffset(t *testing.T) {
+	tests := []struct {
+		duration, want float64
+	}{
+		{0, 0},
+		{-1, 0},
+		{0.5, 0.05},
+		{8, 0.8},
+		{120, 12},
+	}
+
+	for _, tt := range tests {
+		if got := videoPosterOffset(tt.duration); got != tt.want {
+			t.Errorf("videoPosterOffset(%v) = %v; want %v", tt.duration, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package processors
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"server/internal/db/repo"
+	"server/internal/storage"
+	"server/internal/utils/sysproc"
+)
+
+const (
+	// hlsMinDuration is the shortest video, in seconds, segmented for HLS.
+	// Shorter web videos load whole about as fast as a first segment.
+	hlsMinDuration = 60.0
+	// hlsSegmentSeconds is the target segment length; segments are cut at
+	// the nearest keyframe.
+	hlsSegmentSeconds = 6
+)
+
+// segmentVideoHLS splits the web video at webPath into an HLS playlist and
+// MPEG-TS segments under the asset's HLS directory. The streams are copied,
+// not re-encoded: the web video is already H.264/AAC. The directory is
+// built aside and swapped in whole, so players never see half a playlist.
+func (ap *AssetProcessor) segmentVideoHLS(ctx context.Context, repoPath string, asset *repo.Asset, webPath string) error {
+	finalDir := storage.HLSDir(repoPath, asset.AssetID.String())
+	if err := os.MkdirAll(filepath.Dir(finalDir), 0o755); err != nil {
+		return fmt.Errorf("create hls directory: %w", err)
+	}
+	workDir, err := os.MkdirTemp(filepath.Dir(finalDir), ".tmp-"+asset.AssetID.String()+"-")
+	if err != nil {
+		return fmt.Errorf("create hls work directory: %w", err)
+	}
+	defer os.RemoveAll(workDir)
+
+	playlistPath := filepath.Join(workDir, storage.HLSPlaylistName)
+	cmd := exec.CommandContext(ctx, ap.toolsConfig.FFmpegCommand(),
+		"-v", "error",
+		"-i", webPath,
+		"-map", "0:v:0",
+		"-map", "0:a?",
+		"-c", "copy",
+		"-f", "hls",
+		"-hls_time", strconv.Itoa(hlsSegmentSeconds),
+		"-hls_playlist_type", "vod",
+		"-hls_segment_filename", filepath.Join(workDir, "segment_%05d.ts"),
+		"-y",
+		playlistPath,
+	)
+	sysproc.HideConsole(cmd)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("ffmpeg hls segmenting failed: %w: %s", err, strings.TrimSpace(string(output)))
+	}
+
+	playlist, err := os.ReadFile(playlistPath)
+	if err != nil {
+		return fmt.Errorf("read hls playlist: %w", err)
+	}
+	relative, err := relativeHLSPlaylist(playlist)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(playlistPath, relative, 0o644); err != nil {
+		return fmt.Errorf("write hls playlist: %w", err)
+	}
+	if err := os.Chmod(workDir, 0o755); err != nil {
+		return fmt.Errorf("chmod hls directory: %w", err)
+	}
+
+	if err := os.RemoveAll(finalDir); err != nil {
+		return fmt.Errorf("replace hls directory: %w", err)
+	}
+	if err := os.Rename(workDir, finalDir); err != nil {
+		return fmt.Errorf("install hls directory: %w", err)
+	}
+	return nil
+}
+
+// relativeHLSPlaylist rewrites every segment reference of an HLS playlist to
+// its bare file name, so the playlist resolves against whatever URL serves
+// it rather than the directory ffmpeg wrote it in.
+func relativeHLSPlaylist(playlist []byte) ([]byte, error) {
+	var out bytes.Buffer
+	scanner := bufio.NewScanner(bytes.NewReader(playlist))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line != "" && !strings.HasPrefix(line, "#") {
+			if strings.Contains(line, "://") {
+				return nil, fmt.Errorf("hls playlist references a remote segment: %s", line)
+			}
+			line = path.Base(filepath.ToSlash(line))
+		}
+		out.WriteString(line)
+		out.WriteByte('\n')
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read hls playlist: %w", err)
+	}
+	return out.Bytes(), nil
+}
@@ -0,0 +1,36 @@
+package processors
+
+import (
+	"testing"
+)
+
+func TestRelativeHLSPlaylist(t *testing.T) {
+	playlist := "#EXTM3U\n" +
+		"#EXT-X-VERSION:3\n" +
+		"#EXT-X-TARGETDURATION:6\n" +
+		"#EXTINF:6.006000,\n" +
+		"/srv/photos/.lumilio/assets/videos/hls/.tmp-abc-123/segment_00000.ts\n" +
+		"#EXTINF:2.5,\n" +
+		"segment_00001.ts\n" +
+		"#EXT-X-ENDLIST\n"
+
+	got, err := relativeHLSPlaylist([]byte(playlist))
+	if err != nil {
+		t.Fatalf("relativeHLSPlaylist returned error: %v", err)
+	}
+	want := "#EXTM3U\n" +
+		"#EXT-X-VERSION:3\n" +
+		"#EXT-X-TARGETDURATION:6\n" +
+		"#EXTINF:6.006000,\n" +
+		"segment_00000.ts\n" +
+		"#EXTINF:2.5,\n" +
+		"segment_00001.ts\n" +
+		"#EXT-X-ENDLIST\n"
+	if string(got) != want {
+		t.Fatalf("relativeHLSPlaylist =\n%s\nwant\n%s", got, want)
+	}
+
+	if _, err := relativeHLSPlaylist([]byte("#EXTM3U\nhttps://cdn.example.com/segment_00000.ts\n")); err == nil {
+		t.Fatal("relativeHLSPlaylist accepted a remote segment")
+	}
+}
@@ -0,0 +1,95 @@
+package processors
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"server/internal/db/dbtypes"
+	"server/internal/queue/jobs"
+	"server/internal/storage"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/riverqueue/river"
+	"go.uber.org/zap"
+)
+
+// webDerivativeRegenerationWindow collapses the regeneration requests of one
+// evicted derivative: a player issues many range requests while the first
+// transcode is still queued.
+const webDerivativeRegenerationWindow = 10 * time.Minute
+
+// ProcessEvictWebDerivatives removes the web videos and audio of every active
+// repository that were not served within transcode.web_derivative_retention.
+// Derivatives of archived originals are kept, since nothing could regenerate
+// them. A repository that cannot be swept is logged and skipped.
+func (ap *AssetProcessor) ProcessEvictWebDerivatives(ctx context.Context, _ jobs.EvictWebDerivativesArgs) error {
+	retention := ap.transcodeConfig.WebDerivativeRetention
+	if retention <= 0 {
+		return nil
+	}
+	cutoff := time.Now().Add(-retention)
+
+	repositories, err := ap.queries.ListActiveRepositories(ctx)
+	if err != nil {
+		return fmt.Errorf("list repositories: %w", err)
+	}
+	for _, repository := range repositories {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		hashes, err := ap.queries.ListArchivedOriginalContentHashesByRepository(ctx, repository.RepoID)
+		if err != nil {
+			return fmt.Errorf("list archived originals: %w", err)
+		}
+		archived := make(map[string]struct{}, len(hashes))
+		for _, hash := range hashes {
+			archived[hash] = struct{}{}
+		}
+
+		result, err := storage.EvictIdleWebDerivatives(repository.Path, cutoff, func(contentHash string) bool {
+			_, ok := archived[contentHash]
+			return ok
+		})
+		if err != nil {
+			ap.logger.Warn("failed to evict idle web derivatives",
+				zap.String("repository_id", repository.RepoID.String()),
+				zap.Error(err))
+		}
+		if result.Files > 0 {
+			ap.repoAudit(repository.Path).Operation("asset.web_derivative.evict",
+				zap.Int("files", result.Files),
+				zap.Int64("bytes", result.Bytes),
+				zap.Duration("retention", retention),
+			)
+		}
+	}
+	return nil
+}
+
+// RegenerateWebDerivative queues a transcode for an asset whose web
+// derivative was evicted. Repeated calls within a short window queue it
+// once.
+func (ap *AssetProcessor) RegenerateWebDerivative(ctx context.Context, assetID pgtype.UUID) error {
+	asset, repository, err := ap.loadAssetAndRepo(ctx, assetID)
+	if err != nil {
+		return err
+	}
+	assetType := dbtypes.AssetType(asset.Type)
+	if (assetType != dbtypes.AssetTypeVideo && assetType != dbtypes.AssetTypeAudio) || asset.StoragePath == nil {
+		return nil
+	}
+	if _, err := ap.queueClient.Insert(ctx, jobs.TranscodeArgs{
+		AssetID:     asset.AssetID,
+		RepoPath:    repository.Path,
+		StoragePath: *asset.StoragePath,
+		AssetType:   assetType,
+		Force:       true,
+	}, &river.InsertOpts{
+		Queue:      "transcode_asset",
+		UniqueOpts: river.UniqueOpts{ByArgs: true, ByPeriod: webDerivativeRegenerationWindow},
+	}); err != nil {
+		return fmt.Errorf("enqueue transcode: %w", err)
+	}
+	return nil
+}
@@ -35,5 +35,7 @@ func (w *ReindexAssetsWorker) Work(ctx context.Context, job *river.Job[ReindexAs
 		Offset:        args.Offset,
 		MissingOnly:   args.MissingOnly,
 		ResetSemantic: args.ResetSemantic,
+		DateFrom:      args.DateFrom,
+		DateTo:        args.DateTo,
 	})
 }
@@ -0,0 +1,32 @@
+package queue
+
+import (
+	"context"
+	"fmt"
+
+	"server/internal/queue/jobs"
+
+	"github.com/riverqueue/river"
+)
+
+// ArchiveOriginalArgs is the job payload alias to avoid import cycles.
+type ArchiveOriginalArgs = jobs.ArchiveOriginalArgs
+
+// ArchiveOriginalWorker moves processed originals to their repository's archive.
+type ArchiveOriginalWorker struct {
+	river.WorkerDefaults[ArchiveOriginalArgs]
+
+	Archive func(ctx context.Context, args ArchiveOriginalArgs) error
+
+	// Locks serializes this job with other jobs on the same asset.
+	Locks AssetLocker
+}
+
+func (w *ArchiveOriginalWorker) Work(ctx context.Context, job *river.Job[ArchiveOriginalArgs]) error {
+	if w.Archive == nil {
+		return fmt.Errorf("archive original worker missing processor")
+	}
+	return withAssetLock(ctx, w.Locks, job.Args.AssetID, func(ctx context.Context) error {
+		return w.Archive(ctx, job.Args)
+	})
+}
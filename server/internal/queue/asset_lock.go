@@ -0,0 +1,59 @@
+package queue
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// assetLockClass is the first key of every per-asset advisory lock. The
+// two-key form keeps these locks apart from the single-key locks taken
+// elsewhere.
+const assetLockClass int32 = 0x4c41 // "LA"
+
+// AssetLocker serializes jobs that touch the same asset.
+type AssetLocker interface {
+	// WithAssetLock runs fn while no other job holds assetID's lock.
+	WithAssetLock(ctx context.Context, assetID pgtype.UUID, fn func(context.Context) error) error
+}
+
+// AdvisoryAssetLocks holds each asset's lock as a PostgreSQL transaction
+// advisory lock on a hash of the asset ID, so it also serializes jobs run by
+// other server processes and is released with the transaction whatever
+// happens to the job. A job keeps its lock connection for as long as it runs,
+// so the pool's size caps how many asset jobs run at once; it should be
+// separate from the pool serving queries.
+type AdvisoryAssetLocks struct {
+	pool *pgxpool.Pool
+}
+
+func NewAdvisoryAssetLocks(pool *pgxpool.Pool) *AdvisoryAssetLocks {
+	return &AdvisoryAssetLocks{pool: pool}
+}
+
+func (l *AdvisoryAssetLocks) WithAssetLock(ctx context.Context, assetID pgtype.UUID, fn func(context.Context) error) error {
+	tx, err := l.pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("begin asset lock: %w", err)
+	}
+	// Ending the transaction releases the lock; do it even when the job's
+	// context was cancelled.
+	defer func() { _ = tx.Rollback(context.WithoutCancel(ctx)) }()
+
+	if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock($1, hashtext($2))`, assetLockClass, assetID.String()); err != nil {
+		return fmt.Errorf("acquire lock for asset %s: %w", assetID.String(), err)
+	}
+	return fn(ctx)
+}
+
+// withAssetLock runs fn under assetID's lock. A nil locker, or a job without
+// an asset, runs fn directly.
+func withAssetLock(ctx context.Context, locker AssetLocker, assetID pgtype.UUID, fn func(context.Context) error) error {
+	if locker == nil || !assetID.Valid {
+		return fn(ctx)
+	}
+	return locker.WithAssetLock(ctx, assetID, fn)
+}
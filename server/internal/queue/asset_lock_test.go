@@ -0,0 +1,141 @@
+package queue
+
+import (
+	"context"
+	"os"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"server/internal/db/dbtest"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/riverqueue/river"
+	"github.com/riverqueue/river/rivertype"
+)
+
+type recordingAssetLocker struct {
+	locked []pgtype.UUID
+}
+
+func (l *recordingAssetLocker) WithAssetLock(ctx context.Context, assetID pgtype.UUID, fn func(context.Context) error) error {
+	l.locked = append(l.locked, assetID)
+	return fn(ctx)
+}
+
+func TestMetadataWorkerRunsUnderAssetLock(t *testing.T) {
+	locker := &recordingAssetLocker{}
+	assetID := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+	var runs int
+	worker := &MetadataWorker{
+		Process: func(context.Context, MetadataArgs) error {
+			if len(locker.locked) != 1 {
+				t.Fatalf("Process ran with %d locks held, want 1", len(locker.locked))
+			}
+			runs++
+			return nil
+		},
+		Locks: locker,
+	}
+
+	job := &river.Job[MetadataArgs]{JobRow: &rivertype.JobRow{}, Args: MetadataArgs{AssetID: assetID}}
+	if err := worker.Work(context.Background(), job); err != nil {
+		t.Fatalf("Work returned %v", err)
+	}
+	if runs != 1 || len(locker.locked) != 1 || locker.locked[0] != assetID {
+		t.Fatalf("runs=%d locked=%v, want one run under %s", runs, locker.locked, assetID.String())
+	}
+
+	// Without a locker the job still runs.
+	worker.Locks = nil
+	if err := worker.Work(context.Background(), job); err != nil || runs != 2 {
+		t.Fatalf("unlocked Work returned %v after %d runs", err, runs)
+	}
+}
+
+// TestAdvisoryAssetLocksPostgresIntegration takes locks in the test database
+// but writes nothing to it.
+func TestAdvisoryAssetLocksPostgresIntegration(t *testing.T) {
+	ctx := context.Background()
+	pool := dbtest.Pool(t, os.Getenv(dbtest.DatabaseURLEnv))
+	locks := NewAdvisoryAssetLocks(pool)
+
+	t.Run("same asset serializes", func(t *testing.T) {
+		var active, maxActive atomic.Int32
+		worker := &MetadataWorker{
+			Process: func(context.Context, MetadataArgs) error {
+				n := active.Add(1)
+				for {
+					m := maxActive.Load()
+					if n <= m || maxActive.CompareAndSwap(m, n) {
+						break
+					}
+				}
+				time.Sleep(200 * time.Millisecond)
+				active.Add(-1)
+				return nil
+			},
+			Locks: locks,
+		}
+		assetID := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+
+		var wg sync.WaitGroup
+		errs := make(chan error, 2)
+		for i := 0; i < 2; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				job := &river.Job[MetadataArgs]{JobRow: &rivertype.JobRow{}, Args: MetadataArgs{AssetID: assetID}}
+				errs <- worker.Work(ctx, job)
+			}()
+		}
+		wg.Wait()
+		close(errs)
+		for err := range errs {
+			if err != nil {
+				t.Fatalf("Work returned %v", err)
+			}
+		}
+		if got := maxActive.Load(); got != 1 {
+			t.Fatalf("%d jobs on the same asset ran at once, want 1", got)
+		}
+	})
+
+	t.Run("different assets run in parallel", func(t *testing.T) {
+		var started sync.WaitGroup
+		started.Add(2)
+		bothStarted := make(chan struct{})
+		go func() {
+			started.Wait()
+			close(bothStarted)
+		}()
+		worker := &MetadataWorker{
+			Process: func(context.Context, MetadataArgs) error {
+				started.Done()
+				select {
+				case <-bothStarted:
+					return nil
+				case <-time.After(5 * time.Second):
+					t.Error("jobs on different assets did not overlap")
+					return nil
+				}
+			},
+			Locks: locks,
+		}
+
+		var wg sync.WaitGroup
+		for i := 0; i < 2; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				job := &river.Job[MetadataArgs]{JobRow: &rivertype.JobRow{}, Args: MetadataArgs{AssetID: pgtype.UUID{Bytes: uuid.New(), Valid: true}}}
+				if err := worker.Work(ctx, job); err != nil {
+					t.Errorf("Work returned %v", err)
+				}
+			}()
+		}
+		wg.Wait()
+	})
+}
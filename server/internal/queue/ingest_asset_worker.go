@@ -17,18 +17,25 @@ type IngestAssetWorker struct {
 	river.WorkerDefaults[IngestAssetArgs]
 
 	Processor *processors.AssetProcessor
+	// Gate leaves jobs queued while processing is paused.
+	Gate ProcessingGate
 }
 
 func (w *IngestAssetWorker) Work(ctx context.Context, job *river.Job[IngestAssetArgs]) error {
+	if err := holdIfProcessingPaused(ctx, w.Gate); err != nil {
+		return err
+	}
 	_, err := w.Processor.IngestAsset(ctx, processors.AssetPayload{
-		ContentHash:      job.Args.ContentHash,
-		QuickFingerprint: job.Args.QuickFingerprint,
-		StagedPath:       job.Args.StagedPath,
-		UserID:           job.Args.UserID,
-		Timestamp:        job.Args.Timestamp,
-		ContentType:      job.Args.ContentType,
-		FileName:         job.Args.FileName,
-		RepositoryID:     job.Args.RepositoryID,
+		ContentHash:       job.Args.ContentHash,
+		QuickFingerprint:  job.Args.QuickFingerprint,
+		StagedPath:        job.Args.StagedPath,
+		UserID:            job.Args.UserID,
+		Timestamp:         job.Args.Timestamp,
+		ContentType:       job.Args.ContentType,
+		FileName:          job.Args.FileName,
+		RepositoryID:      job.Args.RepositoryID,
+		CaptureHints:      job.Args.CaptureHints,
+		ClientContentHash: job.Args.ClientContentHash,
 	})
 	return err
 }
@@ -18,11 +18,16 @@ type DiscoverAssetWorker struct {
 
 	// ProcessDiscover ingests discovered repository files.
 	ProcessDiscover func(ctx context.Context, args DiscoverAssetArgs) error
+	// Gate leaves jobs queued while processing is paused.
+	Gate ProcessingGate
 }
 
 func (w *DiscoverAssetWorker) Work(ctx context.Context, job *river.Job[DiscoverAssetArgs]) error {
 	if w.ProcessDiscover == nil {
 		return fmt.Errorf("discover worker not configured")
 	}
+	if err := holdIfProcessingPaused(ctx, w.Gate); err != nil {
+		return err
+	}
 	return w.ProcessDiscover(ctx, job.Args)
 }
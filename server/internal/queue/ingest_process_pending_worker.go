@@ -0,0 +1,27 @@
+package queue
+
+import (
+	"context"
+	"fmt"
+
+	"server/internal/queue/jobs"
+
+	"github.com/riverqueue/river"
+)
+
+// ProcessPendingAssetsArgs is the job payload alias to avoid import cycles.
+type ProcessPendingAssetsArgs = jobs.ProcessPendingAssetsArgs
+
+// ProcessPendingAssetsWorker starts processing of a repository's deferred uploads.
+type ProcessPendingAssetsWorker struct {
+	river.WorkerDefaults[ProcessPendingAssetsArgs]
+
+	ProcessPending func(ctx context.Context, args ProcessPendingAssetsArgs) error
+}
+
+func (w *ProcessPendingAssetsWorker) Work(ctx context.Context, job *river.Job[ProcessPendingAssetsArgs]) error {
+	if w.ProcessPending == nil {
+		return fmt.Errorf("process pending assets worker missing processor")
+	}
+	return w.ProcessPending(ctx, job.Args)
+}
@@ -0,0 +1,27 @@
+package queue
+
+import (
+	"context"
+	"fmt"
+
+	"server/internal/queue/jobs"
+
+	"github.com/riverqueue/river"
+)
+
+// CheckRepositorySizesArgs is the job payload alias to avoid import cycles.
+type CheckRepositorySizesArgs = jobs.CheckRepositorySizesArgs
+
+// CheckRepositorySizesWorker runs repository size quick-checks.
+type CheckRepositorySizesWorker struct {
+	river.WorkerDefaults[CheckRepositorySizesArgs]
+
+	ProcessCheck func(ctx context.Context, args CheckRepositorySizesArgs) error
+}
+
+func (w *CheckRepositorySizesWorker) Work(ctx context.Context, job *river.Job[CheckRepositorySizesArgs]) error {
+	if w.ProcessCheck == nil {
+		return fmt.Errorf("check repository sizes worker missing processor")
+	}
+	return w.ProcessCheck(ctx, job.Args)
+}
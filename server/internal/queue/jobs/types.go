@@ -167,14 +167,17 @@ func (ProcessFaceArgs) InsertOpts() river.InsertOpts {
 
 // ReindexAssetsArgs queues a batch backfill for existing photo indexing tasks.
 // Offset advances across self-chained full-rebuild pages (MissingOnly=false);
-// it is ignored for missing-only backfills.
+// it is ignored for missing-only backfills. DateFrom and DateTo bound the
+// photos by capture time and carry over to every chained page.
 type ReindexAssetsArgs struct {
-	RepositoryID  *string  `json:"repositoryId,omitempty"`
-	Tasks         []string `json:"tasks,omitempty"`
-	Limit         int      `json:"limit,omitempty"`
-	Offset        int      `json:"offset,omitempty"`
-	MissingOnly   bool     `json:"missingOnly,omitempty"`
-	ResetSemantic bool     `json:"resetSemantic,omitempty"`
+	RepositoryID  *string    `json:"repositoryId,omitempty"`
+	Tasks         []string   `json:"tasks,omitempty"`
+	Limit         int        `json:"limit,omitempty"`
+	Offset        int        `json:"offset,omitempty"`
+	MissingOnly   bool       `json:"missingOnly,omitempty"`
+	ResetSemantic bool       `json:"resetSemantic,omitempty"`
+	DateFrom      *time.Time `json:"dateFrom,omitempty"`
+	DateTo        *time.Time `json:"dateTo,omitempty"`
 }
 
 func (ReindexAssetsArgs) Kind() string { return "reindex_assets" }
@@ -216,6 +219,59 @@ func (ScanRepositoryArgs) InsertOpts() river.InsertOpts {
 	}}
 }
 
+// CheckRepositorySizesArgs queues a size quick-check of a repository: every
+// asset's recorded file size is compared with its original on disk.
+type CheckRepositorySizesArgs struct {
+	RepositoryID string `json:"repositoryId" river:"unique"`
+}
+
+func (CheckRepositorySizesArgs) Kind() string { return "check_repository_sizes" }
+
+func (CheckRepositorySizesArgs) InsertOpts() river.InsertOpts {
+	return river.InsertOpts{UniqueOpts: river.UniqueOpts{
+		ByArgs:   true,
+		ByPeriod: 1 * time.Minute,
+	}}
+}
+
+// ProcessPendingAssetsArgs queues processing of every asset of a repository
+// whose upload was deferred by its manual processing mode.
+type ProcessPendingAssetsArgs struct {
+	RepositoryID string `json:"repositoryId" river:"unique"`
+}
+
+func (ProcessPendingAssetsArgs) Kind() string { return "process_pending_assets" }
+
+func (ProcessPendingAssetsArgs) InsertOpts() river.InsertOpts {
+	return river.InsertOpts{UniqueOpts: river.UniqueOpts{
+		ByArgs:   true,
+		ByPeriod: 1 * time.Minute,
+	}}
+}
+
+// ArchiveOriginalArgs moves an asset's original to its repository's archive
+// path once its web derivatives are verified.
+type ArchiveOriginalArgs struct {
+	AssetID pgtype.UUID `json:"assetId"`
+}
+
+func (ArchiveOriginalArgs) Kind() string { return "archive_original" }
+
+func (ArchiveOriginalArgs) InsertOpts() river.InsertOpts {
+	return river.InsertOpts{UniqueOpts: river.UniqueOpts{
+		ByArgs:   true,
+		ByPeriod: 10 * time.Minute,
+	}}
+}
+
+// WriteRatingArgs writes an asset's stored rating into its original. The
+// rating is read when the job runs, so the latest one is written.
+type WriteRatingArgs struct {
+	AssetID pgtype.UUID `json:"assetId"`
+}
+
+func (WriteRatingArgs) Kind() string { return "write_rating" }
+
 // DetectStacksArgs triggers logical-media merging and burst detection for a repository.
 type DetectStacksArgs struct {
 	RepositoryID string `json:"repositoryId" river:"unique"`
@@ -260,14 +316,28 @@ func (args ProcessPHashArgs) InsertOpts() river.InsertOpts {
 
 // IngestAssetArgs handles initial staging ingestion and asset creation.
 type IngestAssetArgs struct {
-	ContentHash      string    `json:"contentHash" river:"unique"`
-	QuickFingerprint string    `json:"quickFingerprint,omitempty"`
-	StagedPath       string    `json:"stagedPath"`
-	UserID           string    `json:"userId" river:"unique"`
-	Timestamp        time.Time `json:"timestamp"`
-	ContentType      string    `json:"contentType,omitempty"`
-	FileName         string    `json:"fileName,omitempty"`
-	RepositoryID     string    `json:"repositoryId,omitempty"`
+	ContentHash      string        `json:"contentHash" river:"unique"`
+	QuickFingerprint string        `json:"quickFingerprint,omitempty"`
+	StagedPath       string        `json:"stagedPath"`
+	UserID           string        `json:"userId" river:"unique"`
+	Timestamp        time.Time     `json:"timestamp"`
+	ContentType      string        `json:"contentType,omitempty"`
+	FileName         string        `json:"fileName,omitempty"`
+	RepositoryID     string        `json:"repositoryId,omitempty"`
+	CaptureHints     *CaptureHints `json:"captureHints,omitempty"`
+	// ClientContentHash is the BLAKE3 hash the client sent in X-Content-Hash,
+	// checked by the worker against the staged file.
+	ClientContentHash string `json:"clientContentHash,omitempty"`
+}
+
+// CaptureHints carries capture metadata supplied by the uploading client. Mobile
+// upload libraries often strip EXIF, so these values only fill fields the
+// extractor could not read from the file itself; extracted metadata always wins.
+type CaptureHints struct {
+	TakenTime *time.Time `json:"takenTime,omitempty"`
+	Latitude  *float64   `json:"latitude,omitempty"`
+	Longitude *float64   `json:"longitude,omitempty"`
+	Device    string     `json:"device,omitempty"`
 }
 
 func (IngestAssetArgs) Kind() string { return "ingest_asset" }
@@ -279,13 +349,18 @@ func (IngestAssetArgs) InsertOpts() river.InsertOpts {
 const (
 	DiscoverOperationUpsert = "upsert"
 	DiscoverOperationDelete = "delete"
+	// DiscoverOperationMove renames an existing asset from PreviousPath to
+	// RelativePath instead of importing the file again.
+	DiscoverOperationMove = "move"
 )
 
 // DiscoverAssetArgs handles repository file-tree discovery ingestion.
 type DiscoverAssetArgs struct {
 	RepositoryID string    `json:"repositoryId" river:"unique"`
-	RelativePath string    `json:"relativePath" river:"unique"`        // repository-relative user workspace path, e.g. albums/2026/02/a.jpg
-	Operation    string    `json:"operation,omitempty" river:"unique"` // upsert (default) or delete
+	RelativePath string    `json:"relativePath" river:"unique"`           // repository-relative user workspace path, e.g. albums/2026/02/a.jpg
+	Operation    string    `json:"operation,omitempty" river:"unique"`    // upsert (default), delete or move
+	PreviousPath string    `json:"previousPath,omitempty" river:"unique"` // move only: the path the file was renamed from
+	AssetID      string    `json:"assetId,omitempty"`                     // move only: the asset recorded at PreviousPath
 	FileName     string    `json:"fileName"`
 	ContentType  string    `json:"contentType,omitempty"`
 	FileSize     int64     `json:"fileSize,omitempty"`
@@ -314,6 +389,11 @@ type MetadataArgs struct {
 	OriginalFilename string            `json:"originalFilename,omitempty"`
 	FileSize         int64             `json:"fileSize,omitempty"`
 	MimeType         string            `json:"mimeType,omitempty"`
+	CaptureHints     *CaptureHints     `json:"captureHints,omitempty"`
+	// Force reruns the task even when the asset status says it already
+	// completed. Set it for intentional reprocessing; duplicate jobs leave it
+	// false and become no-ops.
+	Force bool `json:"force,omitempty"`
 }
 
 func (MetadataArgs) Kind() string { return "metadata_asset" }
@@ -328,6 +408,8 @@ type ThumbnailArgs struct {
 	RepoPath    string            `json:"repoPath"`
 	StoragePath string            `json:"storagePath"`
 	AssetType   dbtypes.AssetType `json:"assetType"`
+	// Force regenerates thumbnails that already exist, as MetadataArgs.Force.
+	Force bool `json:"force,omitempty"`
 }
 
 func (ThumbnailArgs) Kind() string { return "thumbnail_asset" }
@@ -342,6 +424,8 @@ type TranscodeArgs struct {
 	RepoPath    string            `json:"repoPath"`
 	StoragePath string            `json:"storagePath"`
 	AssetType   dbtypes.AssetType `json:"assetType"`
+	// Force transcodes again after a completed run, as MetadataArgs.Force.
+	Force bool `json:"force,omitempty"`
 }
 
 func (TranscodeArgs) Kind() string { return "transcode_asset" }
@@ -350,6 +434,19 @@ func (TranscodeArgs) InsertOpts() river.InsertOpts {
 	return river.InsertOpts{MaxAttempts: LocalToolMaxAttempts}
 }
 
+// EvictWebDerivativesArgs is the periodic sweep that removes web videos and
+// audio not served within transcode.web_derivative_retention.
+type EvictWebDerivativesArgs struct{}
+
+func (EvictWebDerivativesArgs) Kind() string { return "evict_web_derivatives" }
+
+func (EvictWebDerivativesArgs) InsertOpts() river.InsertOpts {
+	return river.InsertOpts{
+		Queue:      "evict_web_derivatives",
+		UniqueOpts: river.UniqueOpts{ByArgs: true, ByPeriod: time.Hour},
+	}
+}
+
 // DatabaseBackupArgs is the periodic database-backup tick. The worker decides
 // from runtime settings whether a dump is actually due, so ticks are cheap and
 // schedule changes need no periodic-job re-registration. Force marks an admin
@@ -381,3 +478,29 @@ func (ScheduleRepositoryScansArgs) InsertOpts() river.InsertOpts {
 		UniqueOpts: river.UniqueOpts{ByPeriod: 1 * time.Minute},
 	}
 }
+
+// RetryTaggingArgs is a periodic trigger that re-queues semantic tagging for
+// photos that still have no embedding once Lumen can serve it again.
+type RetryTaggingArgs struct{}
+
+func (RetryTaggingArgs) Kind() string { return "retry_tagging" }
+
+func (RetryTaggingArgs) InsertOpts() river.InsertOpts {
+	return river.InsertOpts{
+		Queue:      "retry_tagging",
+		UniqueOpts: river.UniqueOpts{ByPeriod: 1 * time.Minute},
+	}
+}
+
+// CleanupAITagsArgs is a periodic trigger that removes AI tag links below the
+// configured confidence threshold.
+type CleanupAITagsArgs struct{}
+
+func (CleanupAITagsArgs) Kind() string { return "cleanup_ai_tags" }
+
+func (CleanupAITagsArgs) InsertOpts() river.InsertOpts {
+	return river.InsertOpts{
+		Queue:      "cleanup_ai_tags",
+		UniqueOpts: river.UniqueOpts{ByPeriod: 1 * time.Minute},
+	}
+}
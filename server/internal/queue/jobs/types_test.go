@@ -87,6 +87,54 @@ func TestScanRepositoryArgsKindAndInsertOpts(t *testing.T) {
 	}
 }
 
+func TestCheckRepositorySizesArgsKindAndInsertOpts(t *testing.T) {
+	args := CheckRepositorySizesArgs{RepositoryID: "11111111-1111-1111-1111-111111111111"}
+
+	if args.Kind() != "check_repository_sizes" {
+		t.Fatalf("unexpected kind: %s", args.Kind())
+	}
+	opts := args.InsertOpts()
+	if !opts.UniqueOpts.ByArgs || opts.UniqueOpts.ByPeriod == 0 {
+		t.Fatalf("expected repository size checks to be unique by args within a period")
+	}
+}
+
+func TestArchiveOriginalArgsKindAndInsertOpts(t *testing.T) {
+	args := ArchiveOriginalArgs{}
+
+	if args.Kind() != "archive_original" {
+		t.Fatalf("unexpected kind: %s", args.Kind())
+	}
+	opts := args.InsertOpts()
+	if !opts.UniqueOpts.ByArgs || opts.UniqueOpts.ByPeriod == 0 {
+		t.Fatalf("expected archiving to be unique by args within a period")
+	}
+}
+
+func TestEvictWebDerivativesArgsKindAndInsertOpts(t *testing.T) {
+	args := EvictWebDerivativesArgs{}
+
+	if args.Kind() != "evict_web_derivatives" {
+		t.Fatalf("unexpected kind: %s", args.Kind())
+	}
+	opts := args.InsertOpts()
+	if opts.Queue != "evict_web_derivatives" || !opts.UniqueOpts.ByArgs || opts.UniqueOpts.ByPeriod == 0 {
+		t.Fatalf("expected one eviction sweep per period on its own queue")
+	}
+}
+
+func TestProcessPendingAssetsArgsKindAndInsertOpts(t *testing.T) {
+	args := ProcessPendingAssetsArgs{RepositoryID: "11111111-1111-1111-1111-111111111111"}
+
+	if args.Kind() != "process_pending_assets" {
+		t.Fatalf("unexpected kind: %s", args.Kind())
+	}
+	opts := args.InsertOpts()
+	if !opts.UniqueOpts.ByArgs || opts.UniqueOpts.ByPeriod == 0 {
+		t.Fatalf("expected pending processing to be unique by args within a period")
+	}
+}
+
 func TestProcessPHashArgsInsertOpts(t *testing.T) {
 	args := ProcessPHashArgs{}
 
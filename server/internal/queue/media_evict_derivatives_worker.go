@@ -0,0 +1,28 @@
+package queue
+
+import (
+	"context"
+	"fmt"
+
+	"server/internal/queue/jobs"
+
+	"github.com/riverqueue/river"
+)
+
+// EvictWebDerivativesArgs is the job payload alias to avoid import cycles.
+type EvictWebDerivativesArgs = jobs.EvictWebDerivativesArgs
+
+// EvictWebDerivativesWorker removes idle web derivatives from every active
+// repository.
+type EvictWebDerivativesWorker struct {
+	river.WorkerDefaults[EvictWebDerivativesArgs]
+
+	Evict func(ctx context.Context, args EvictWebDerivativesArgs) error
+}
+
+func (w *EvictWebDerivativesWorker) Work(ctx context.Context, job *river.Job[EvictWebDerivativesArgs]) error {
+	if w.Evict == nil {
+		return fmt.Errorf("evict web derivatives worker missing processor")
+	}
+	return w.Evict(ctx, job.Args)
+}
@@ -20,11 +20,16 @@ type MetadataWorker struct {
 	// Process performs metadata extraction for the given asset.
 	// It should be provided by the caller when registering the worker.
 	Process func(ctx context.Context, args MetadataArgs) error
+
+	// Locks serializes this job with other jobs on the same asset.
+	Locks AssetLocker
 }
 
 func (w *MetadataWorker) Work(ctx context.Context, job *river.Job[MetadataArgs]) error {
 	if w.Process == nil {
 		return fmt.Errorf("metadata worker not configured")
 	}
-	return w.Process(ctx, job.Args)
+	return withAssetLock(ctx, w.Locks, job.Args.AssetID, func(ctx context.Context) error {
+		return w.Process(ctx, job.Args)
+	})
 }
@@ -20,11 +20,16 @@ type ThumbnailWorker struct {
 	// Process performs thumbnail generation for the given asset.
 	// It should be provided by the caller when registering the worker.
 	Process func(ctx context.Context, args ThumbnailArgs) error
+
+	// Locks serializes this job with other jobs on the same asset.
+	Locks AssetLocker
 }
 
 func (w *ThumbnailWorker) Work(ctx context.Context, job *river.Job[ThumbnailArgs]) error {
 	if w.Process == nil {
 		return fmt.Errorf("thumbnail worker not configured")
 	}
-	return w.Process(ctx, job.Args)
+	return withAssetLock(ctx, w.Locks, job.Args.AssetID, func(ctx context.Context) error {
+		return w.Process(ctx, job.Args)
+	})
 }
@@ -20,11 +20,16 @@ type TranscodeWorker struct {
 	// Process performs transcoding for the given asset.
 	// It should be provided by the caller when registering the worker.
 	Process func(ctx context.Context, args TranscodeArgs) error
+
+	// Locks serializes this job with other jobs on the same asset.
+	Locks AssetLocker
 }
 
 func (w *TranscodeWorker) Work(ctx context.Context, job *river.Job[TranscodeArgs]) error {
 	if w.Process == nil {
 		return fmt.Errorf("transcode worker not configured")
 	}
-	return w.Process(ctx, job.Args)
+	return withAssetLock(ctx, w.Locks, job.Args.AssetID, func(ctx context.Context) error {
+		return w.Process(ctx, job.Args)
+	})
 }
@@ -25,6 +25,9 @@ type ProcessBioClipWorker struct {
 	SpeciesService speciesPredictionSaver
 	ConfigProvider MLConfigProvider
 	ImageLoader    MLImageLoader
+
+	// Locks serializes this job with other jobs on the same asset.
+	Locks AssetLocker
 }
 
 type speciesPredictionSaver interface {
@@ -36,6 +39,12 @@ func (w *ProcessBioClipWorker) Timeout(job *river.Job[ProcessBioClipArgs]) time.
 }
 
 func (w *ProcessBioClipWorker) Work(ctx context.Context, job *river.Job[ProcessBioClipArgs]) error {
+	return withAssetLock(ctx, w.Locks, job.Args.AssetID, func(ctx context.Context) error {
+		return w.work(ctx, job)
+	})
+}
+
+func (w *ProcessBioClipWorker) work(ctx context.Context, job *river.Job[ProcessBioClipArgs]) error {
 	args := job.Args
 	assetID := args.AssetID
 
@@ -23,6 +23,9 @@ type ProcessFaceWorker struct {
 	LumenService   service.LumenService
 	ConfigProvider MLConfigProvider
 	ImageLoader    MLImageLoader
+
+	// Locks serializes this job with other jobs on the same asset.
+	Locks AssetLocker
 }
 
 func (w *ProcessFaceWorker) Timeout(job *river.Job[ProcessFaceArgs]) time.Duration {
@@ -30,6 +33,12 @@ func (w *ProcessFaceWorker) Timeout(job *river.Job[ProcessFaceArgs]) time.Durati
 }
 
 func (w *ProcessFaceWorker) Work(ctx context.Context, job *river.Job[ProcessFaceArgs]) error {
+	return withAssetLock(ctx, w.Locks, job.Args.AssetID, func(ctx context.Context) error {
+		return w.work(ctx, job)
+	})
+}
+
+func (w *ProcessFaceWorker) work(ctx context.Context, job *river.Job[ProcessFaceArgs]) error {
 	args := job.Args
 	assetID := args.AssetID
 
@@ -23,6 +23,9 @@ type ProcessOcrWorker struct {
 	LumenService   service.LumenService
 	ConfigProvider MLConfigProvider
 	ImageLoader    MLImageLoader
+
+	// Locks serializes this job with other jobs on the same asset.
+	Locks AssetLocker
 }
 
 func (w *ProcessOcrWorker) Timeout(job *river.Job[ProcessOcrArgs]) time.Duration {
@@ -30,6 +33,12 @@ func (w *ProcessOcrWorker) Timeout(job *river.Job[ProcessOcrArgs]) time.Duration
 }
 
 func (w *ProcessOcrWorker) Work(ctx context.Context, job *river.Job[ProcessOcrArgs]) error {
+	return withAssetLock(ctx, w.Locks, job.Args.AssetID, func(ctx context.Context) error {
+		return w.work(ctx, job)
+	})
+}
+
+func (w *ProcessOcrWorker) work(ctx context.Context, job *river.Job[ProcessOcrArgs]) error {
 	args := job.Args
 	assetID := args.AssetID
 
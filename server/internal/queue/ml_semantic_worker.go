@@ -24,6 +24,9 @@ type ProcessSemanticWorker struct {
 	LumenService     service.LumenService
 	ConfigProvider   MLConfigProvider
 	ImageLoader      MLImageLoader
+
+	// Locks serializes this job with other jobs on the same asset.
+	Locks AssetLocker
 }
 
 func (w *ProcessSemanticWorker) Timeout(job *river.Job[ProcessSemanticArgs]) time.Duration {
@@ -31,6 +34,12 @@ func (w *ProcessSemanticWorker) Timeout(job *river.Job[ProcessSemanticArgs]) tim
 }
 
 func (w *ProcessSemanticWorker) Work(ctx context.Context, job *river.Job[ProcessSemanticArgs]) error {
+	return withAssetLock(ctx, w.Locks, job.Args.AssetID, func(ctx context.Context) error {
+		return w.work(ctx, job)
+	})
+}
+
+func (w *ProcessSemanticWorker) work(ctx context.Context, job *river.Job[ProcessSemanticArgs]) error {
 	args := job.Args
 	assetID := args.AssetID
 
@@ -0,0 +1,38 @@
+package queue
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/riverqueue/river"
+
+	"server/internal/queue/jobs"
+	"server/internal/service"
+)
+
+// CleanupAITagsArgs is the job payload alias to avoid import cycles.
+type CleanupAITagsArgs = jobs.CleanupAITagsArgs
+
+// CleanupAITagsWorker removes AI tag links below MinConfidence, the periodic
+// form of POST /admin/tags/cleanup. With RemoveOrphans it also deletes AI tags
+// no asset uses any more. A MinConfidence of 0 makes every tick a no-op.
+type CleanupAITagsWorker struct {
+	river.WorkerDefaults[CleanupAITagsArgs]
+
+	Cleanup       func(ctx context.Context, minConfidence float64, removeOrphans bool) (service.TagCleanupResult, error)
+	MinConfidence float64
+	RemoveOrphans bool
+}
+
+func (w *CleanupAITagsWorker) Work(ctx context.Context, job *river.Job[CleanupAITagsArgs]) error {
+	if w.MinConfidence <= 0 {
+		return nil
+	}
+	if w.Cleanup == nil {
+		return fmt.Errorf("cleanup ai tags worker missing service")
+	}
+	if _, err := w.Cleanup(ctx, w.MinConfidence, w.RemoveOrphans); err != nil {
+		return fmt.Errorf("cleanup ai tags: %w", err)
+	}
+	return nil
+}
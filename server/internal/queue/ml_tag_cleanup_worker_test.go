@@ -0,0 +1,39 @@
+package queue
+
+import (
+	"context"
+	"testing"
+
+	"server/internal/service"
+
+	"github.com/riverqueue/river"
+)
+
+type tagCleanupCall struct {
+	minConfidence float64
+	removeOrphans bool
+}
+
+func TestCleanupAITagsWorkerRunsConfiguredCleanup(t *testing.T) {
+	var calls []tagCleanupCall
+	cleanup := func(_ context.Context, minConfidence float64, removeOrphans bool) (service.TagCleanupResult, error) {
+		calls = append(calls, tagCleanupCall{minConfidence, removeOrphans})
+		return service.TagCleanupResult{RemovedAssetTags: 3}, nil
+	}
+
+	worker := &CleanupAITagsWorker{Cleanup: cleanup, MinConfidence: 0.3, RemoveOrphans: true}
+	if err := worker.Work(context.Background(), &river.Job[CleanupAITagsArgs]{}); err != nil {
+		t.Fatalf("work: %v", err)
+	}
+	if len(calls) != 1 || calls[0] != (tagCleanupCall{0.3, true}) {
+		t.Fatalf("expected one cleanup at 0.3 with orphans, got %+v", calls)
+	}
+
+	disabled := &CleanupAITagsWorker{Cleanup: cleanup}
+	if err := disabled.Work(context.Background(), &river.Job[CleanupAITagsArgs]{}); err != nil {
+		t.Fatalf("work: %v", err)
+	}
+	if len(calls) != 1 {
+		t.Fatalf("expected a zero threshold to skip cleanup, got %+v", calls)
+	}
+}
@@ -0,0 +1,108 @@
+package queue
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/riverqueue/river"
+	"github.com/riverqueue/river/rivertype"
+
+	"server/internal/db/repo"
+	"server/internal/queue/jobs"
+	"server/internal/service"
+)
+
+// RetryTaggingArgs is the job payload alias to avoid import cycles.
+type RetryTaggingArgs = jobs.RetryTaggingArgs
+
+// defaultTagRetryBatchSize caps how many photos one tick re-queues, so a long
+// outage drains over several ticks instead of flooding process_semantic.
+const defaultTagRetryBatchSize = 500
+
+// TagRetryStore is the part of repo.Queries RetryTaggingWorker uses.
+type TagRetryStore interface {
+	ListPhotosAwaitingTagRetry(ctx context.Context, arg repo.ListPhotosAwaitingTagRetryParams) ([]repo.ListPhotosAwaitingTagRetryRow, error)
+	RecordTagRetryAttempt(ctx context.Context, arg repo.RecordTagRetryAttemptParams) (int32, error)
+}
+
+// TagRetryInserter is the part of the River client RetryTaggingWorker uses.
+type TagRetryInserter interface {
+	Insert(ctx context.Context, args river.JobArgs, opts *river.InsertOpts) (*rivertype.JobInsertResult, error)
+}
+
+// RetryTaggingWorker re-queues semantic tagging for photos that never got an
+// embedding, typically because Lumen was down when they were ingested. Zero-shot
+// tags follow from the embedding, so one process_semantic job restores both.
+//
+// A tick does nothing while Lumen cannot serve semantic_image_embed, so an
+// outage does not use up a photo's attempts. Each re-queue counts as one
+// attempt; after MaxAttempts the photo is marked clip_failed and skipped.
+type RetryTaggingWorker struct {
+	river.WorkerDefaults[RetryTaggingArgs]
+
+	Store          TagRetryStore
+	Queue          TagRetryInserter
+	LumenService   service.LumenService
+	ConfigProvider MLConfigProvider
+	MaxAttempts    int
+	// Grace leaves out photos uploaded this recently; their first semantic
+	// job may still be waiting in the queue.
+	Grace     time.Duration
+	BatchSize int
+}
+
+func (w *RetryTaggingWorker) Work(ctx context.Context, job *river.Job[RetryTaggingArgs]) error {
+	if w.MaxAttempts <= 0 {
+		return nil
+	}
+	if w.Store == nil || w.Queue == nil {
+		return fmt.Errorf("retry tagging worker not configured")
+	}
+
+	enabled, err := isMLTaskEnabled(ctx, w.ConfigProvider, "process_semantic")
+	if err != nil {
+		return fmt.Errorf("load ml settings: %w", err)
+	}
+	if !enabled {
+		return nil
+	}
+	if w.LumenService == nil || !w.LumenService.IsTaskAvailable("semantic_image_embed") {
+		return nil
+	}
+
+	batchSize := w.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultTagRetryBatchSize
+	}
+	photos, err := w.Store.ListPhotosAwaitingTagRetry(ctx, repo.ListPhotosAwaitingTagRetryParams{
+		UploadedBefore: pgtype.Timestamptz{Time: time.Now().Add(-w.Grace), Valid: true},
+		Limit:          int32(batchSize),
+	})
+	if err != nil {
+		return fmt.Errorf("list photos awaiting tag retry: %w", err)
+	}
+
+	for _, photo := range photos {
+		result, err := w.Queue.Insert(ctx, jobs.ProcessSemanticArgs{
+			AssetID:           photo.AssetID,
+			PreprocessVersion: jobs.MLPreprocessVersionV1,
+		}, &river.InsertOpts{Queue: "process_semantic"})
+		if err != nil {
+			return fmt.Errorf("enqueue process_semantic for %s: %w", photo.AssetID.String(), err)
+		}
+		// A semantic job for the photo is already queued; let it run before
+		// counting another attempt.
+		if result != nil && result.UniqueSkippedAsDuplicate {
+			continue
+		}
+		if _, err := w.Store.RecordTagRetryAttempt(ctx, repo.RecordTagRetryAttemptParams{
+			AssetID:     photo.AssetID,
+			MaxAttempts: int32(w.MaxAttempts),
+		}); err != nil {
+			return fmt.Errorf("record tag retry for %s: %w", photo.AssetID.String(), err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,151 @@
+package queue
+
+import (
+	"context"
+	"testing"
+
+	"server/internal/db/repo"
+	"server/internal/queue/jobs"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/riverqueue/river"
+	"github.com/riverqueue/river/rivertype"
+)
+
+// tagRetryStoreStub stands in for assets without a semantic embedding plus the
+// asset_tagging_retries table.
+type tagRetryStoreStub struct {
+	untagged   []pgtype.UUID
+	attempts   map[pgtype.UUID]int32
+	clipFailed map[pgtype.UUID]bool
+}
+
+func newTagRetryStoreStub(untagged ...pgtype.UUID) *tagRetryStoreStub {
+	return &tagRetryStoreStub{
+		untagged:   untagged,
+		attempts:   map[pgtype.UUID]int32{},
+		clipFailed: map[pgtype.UUID]bool{},
+	}
+}
+
+func (s *tagRetryStoreStub) ListPhotosAwaitingTagRetry(_ context.Context, arg repo.ListPhotosAwaitingTagRetryParams) ([]repo.ListPhotosAwaitingTagRetryRow, error) {
+	var rows []repo.ListPhotosAwaitingTagRetryRow
+	for _, id := range s.untagged {
+		if s.clipFailed[id] || int32(len(rows)) >= arg.Limit {
+			continue
+		}
+		rows = append(rows, repo.ListPhotosAwaitingTagRetryRow{AssetID: id, Attempts: s.attempts[id]})
+	}
+	return rows, nil
+}
+
+func (s *tagRetryStoreStub) RecordTagRetryAttempt(_ context.Context, arg repo.RecordTagRetryAttemptParams) (int32, error) {
+	s.attempts[arg.AssetID]++
+	if s.attempts[arg.AssetID] >= arg.MaxAttempts {
+		s.clipFailed[arg.AssetID] = true
+	}
+	return s.attempts[arg.AssetID], nil
+}
+
+type tagRetryInserterStub struct {
+	inserted  []jobs.ProcessSemanticArgs
+	queues    []string
+	duplicate bool
+}
+
+func (s *tagRetryInserterStub) Insert(_ context.Context, args river.JobArgs, opts *river.InsertOpts) (*rivertype.JobInsertResult, error) {
+	s.inserted = append(s.inserted, args.(jobs.ProcessSemanticArgs))
+	s.queues = append(s.queues, opts.Queue)
+	return &rivertype.JobInsertResult{UniqueSkippedAsDuplicate: s.duplicate}, nil
+}
+
+func tagRetryAssetID(t *testing.T) pgtype.UUID {
+	t.Helper()
+	assetID := pgtype.UUID{}
+	if err := assetID.Scan("44444444-4444-4444-4444-444444444444"); err != nil {
+		t.Fatalf("scan asset id: %v", err)
+	}
+	return assetID
+}
+
+func runTagRetry(t *testing.T, worker *RetryTaggingWorker) {
+	t.Helper()
+	if err := worker.Work(context.Background(), &river.Job[RetryTaggingArgs]{}); err != nil {
+		t.Fatalf("worker returned error: %v", err)
+	}
+}
+
+func TestRetryTaggingWorkerRequeuesUntaggedPhotoWhenLumenRecovers(t *testing.T) {
+	t.Parallel()
+
+	assetID := tagRetryAssetID(t)
+	store := newTagRetryStoreStub(assetID)
+	inserter := &tagRetryInserterStub{}
+	lumen := &semanticWorkerLumenStub{available: map[string]bool{"semantic_image_embed": false}}
+	worker := &RetryTaggingWorker{Store: store, Queue: inserter, LumenService: lumen, MaxAttempts: 3}
+
+	runTagRetry(t, worker)
+	if len(inserter.inserted) != 0 {
+		t.Fatalf("expected no re-queue while Lumen is down, got %d", len(inserter.inserted))
+	}
+	if store.attempts[assetID] != 0 {
+		t.Fatalf("expected the outage not to use up attempts, got %d", store.attempts[assetID])
+	}
+
+	lumen.available["semantic_image_embed"] = true
+	runTagRetry(t, worker)
+	if len(inserter.inserted) != 1 {
+		t.Fatalf("expected one re-queue after Lumen recovered, got %d", len(inserter.inserted))
+	}
+	if inserter.inserted[0].AssetID != assetID || inserter.queues[0] != "process_semantic" {
+		t.Fatalf("unexpected job %+v on queue %q", inserter.inserted[0], inserter.queues[0])
+	}
+	if inserter.inserted[0].PreprocessVersion != jobs.MLPreprocessVersionV1 {
+		t.Fatalf("expected preprocess version %q, got %q", jobs.MLPreprocessVersionV1, inserter.inserted[0].PreprocessVersion)
+	}
+	if store.attempts[assetID] != 1 {
+		t.Fatalf("expected one recorded attempt, got %d", store.attempts[assetID])
+	}
+}
+
+func TestRetryTaggingWorkerStopsAtMaxAttempts(t *testing.T) {
+	t.Parallel()
+
+	assetID := tagRetryAssetID(t)
+	store := newTagRetryStoreStub(assetID)
+	inserter := &tagRetryInserterStub{}
+	worker := &RetryTaggingWorker{
+		Store:        store,
+		Queue:        inserter,
+		LumenService: &semanticWorkerLumenStub{available: map[string]bool{"semantic_image_embed": true}},
+		MaxAttempts:  2,
+	}
+
+	for range 4 {
+		runTagRetry(t, worker)
+	}
+	if len(inserter.inserted) != 2 {
+		t.Fatalf("expected 2 re-queues, got %d", len(inserter.inserted))
+	}
+	if !store.clipFailed[assetID] {
+		t.Fatal("expected the photo to be marked clip_failed")
+	}
+}
+
+func TestRetryTaggingWorkerSkipsAttemptWhenJobAlreadyQueued(t *testing.T) {
+	t.Parallel()
+
+	assetID := tagRetryAssetID(t)
+	store := newTagRetryStoreStub(assetID)
+	worker := &RetryTaggingWorker{
+		Store:        store,
+		Queue:        &tagRetryInserterStub{duplicate: true},
+		LumenService: &semanticWorkerLumenStub{available: map[string]bool{"semantic_image_embed": true}},
+		MaxAttempts:  1,
+	}
+
+	runTagRetry(t, worker)
+	if store.attempts[assetID] != 0 || store.clipFailed[assetID] {
+		t.Fatalf("expected no attempt for a duplicate job, got %d (clip_failed=%v)", store.attempts[assetID], store.clipFailed[assetID])
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
 	"time"
 
 	"server/internal/queue/jobs"
@@ -11,6 +12,7 @@ import (
 
 	"github.com/jackc/pgx/v5"
 	"github.com/riverqueue/river"
+	"go.uber.org/zap"
 )
 
 // ZeroshotClassifyArgs is the job payload.
@@ -26,9 +28,24 @@ type ZeroshotClassifyWorker struct {
 	ClassifierService service.ClassifierService
 	AITagService      service.AIGeneratedTagService
 	ConfigProvider    MLConfigProvider
+
+	// TopN caps the tags kept per asset, highest confidence first; zero keeps
+	// every match. Matches below MinConfidence are dropped, not stored.
+	TopN          int
+	MinConfidence float64
+	Logger        *zap.Logger
+
+	// Locks serializes this job with other jobs on the same asset.
+	Locks AssetLocker
 }
 
 func (w *ZeroshotClassifyWorker) Work(ctx context.Context, job *river.Job[ZeroshotClassifyArgs]) error {
+	return withAssetLock(ctx, w.Locks, job.Args.AssetID, func(ctx context.Context) error {
+		return w.work(ctx, job)
+	})
+}
+
+func (w *ZeroshotClassifyWorker) work(ctx context.Context, job *river.Job[ZeroshotClassifyArgs]) error {
 	assetID := job.Args.AssetID
 
 	enabled, err := isMLTaskEnabled(ctx, w.ConfigProvider, "classify_zeroshot")
@@ -53,8 +70,17 @@ func (w *ZeroshotClassifyWorker) Work(ctx context.Context, job *river.Job[Zerosh
 		return fmt.Errorf("classify asset: %w", err)
 	}
 
-	tags := make([]service.AIGeneratedTag, 0, len(hits))
-	for _, hit := range hits {
+	kept, filtered := selectZeroshotTags(hits, w.TopN, w.MinConfidence)
+	if filtered > 0 && w.Logger != nil {
+		w.Logger.Debug("zero-shot tags filtered",
+			zap.String("asset_id", assetID.String()),
+			zap.Int("matches", len(hits)),
+			zap.Int("filtered", filtered),
+		)
+	}
+
+	tags := make([]service.AIGeneratedTag, 0, len(kept))
+	for _, hit := range kept {
 		tags = append(tags, service.AIGeneratedTag{
 			Name:       hit.TagName,
 			Confidence: float32(hit.Confidence),
@@ -71,3 +97,19 @@ func (w *ZeroshotClassifyWorker) Work(ctx context.Context, job *river.Job[Zerosh
 
 	return nil
 }
+
+// selectZeroshotTags keeps the hits at or above minConfidence, at most topN of
+// them by descending confidence, and reports how many were dropped.
+func selectZeroshotTags(hits []service.ClassifierHit, topN int, minConfidence float64) ([]service.ClassifierHit, int) {
+	kept := make([]service.ClassifierHit, 0, len(hits))
+	for _, hit := range hits {
+		if hit.Confidence >= minConfidence {
+			kept = append(kept, hit)
+		}
+	}
+	sort.SliceStable(kept, func(i, j int) bool { return kept[i].Confidence > kept[j].Confidence })
+	if topN > 0 && len(kept) > topN {
+		kept = kept[:topN]
+	}
+	return kept, len(hits) - len(kept)
+}
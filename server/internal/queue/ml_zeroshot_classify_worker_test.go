@@ -0,0 +1,43 @@
+package queue
+
+import (
+	"reflect"
+	"testing"
+
+	"server/internal/service"
+)
+
+func TestSelectZeroshotTagsAppliesThresholdThenTopN(t *testing.T) {
+	hits := []service.ClassifierHit{
+		{TagName: "beach", Confidence: 0.62},
+		{TagName: "dog", Confidence: 0.91},
+		{TagName: "sunset", Confidence: 0.55},
+		{TagName: "food", Confidence: 0.74},
+	}
+
+	tests := []struct {
+		name          string
+		topN          int
+		minConfidence float64
+		want          []string
+		filtered      int
+	}{
+		{name: "keep everything", topN: 0, minConfidence: 0.5, want: []string{"dog", "food", "beach", "sunset"}},
+		{name: "top two", topN: 2, minConfidence: 0.5, want: []string{"dog", "food"}, filtered: 2},
+		{name: "threshold", topN: 0, minConfidence: 0.6, want: []string{"dog", "food", "beach"}, filtered: 1},
+		{name: "threshold before cap", topN: 5, minConfidence: 0.8, want: []string{"dog"}, filtered: 3},
+		{name: "nothing confident", topN: 3, minConfidence: 0.95, want: []string{}, filtered: 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kept, filtered := selectZeroshotTags(hits, tt.topN, tt.minConfidence)
+			names := make([]string, 0, len(kept))
+			for _, hit := range kept {
+				names = append(names, hit.TagName)
+			}
+			if !reflect.DeepEqual(names, tt.want) || filtered != tt.filtered {
+				t.Fatalf("kept %v, filtered %d; want %v, %d", names, filtered, tt.want, tt.filtered)
+			}
+		})
+	}
+}
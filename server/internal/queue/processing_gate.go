@@ -0,0 +1,36 @@
+package queue
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/riverqueue/river"
+)
+
+// processingPausedSnooze is how long a job waits before checking the pause
+// flag again. It bounds how quickly work picks up after a resume.
+const processingPausedSnooze = 30 * time.Second
+
+// ProcessingGate reports the global processing pause set by operators during
+// maintenance.
+type ProcessingGate interface {
+	ProcessingPaused(ctx context.Context) (bool, error)
+}
+
+// holdIfProcessingPaused returns a snooze when processing is paused, so the job
+// goes back to the queue without running and without using up an attempt. A
+// nil gate never pauses.
+func holdIfProcessingPaused(ctx context.Context, gate ProcessingGate) error {
+	if gate == nil {
+		return nil
+	}
+	paused, err := gate.ProcessingPaused(ctx)
+	if err != nil {
+		return fmt.Errorf("load processing pause: %w", err)
+	}
+	if paused {
+		return river.JobSnooze(processingPausedSnooze)
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/riverqueue/river"
+	"github.com/riverqueue/river/rivertype"
+)
+
+type processingGateStub struct {
+	paused bool
+	err    error
+}
+
+func (g *processingGateStub) ProcessingPaused(context.Context) (bool, error) {
+	return g.paused, g.err
+}
+
+func TestDiscoverAssetWorkerLeavesJobsQueuedWhilePaused(t *testing.T) {
+	gate := &processingGateStub{paused: true}
+	var runs int
+	worker := &DiscoverAssetWorker{
+		ProcessDiscover: func(context.Context, DiscoverAssetArgs) error {
+			runs++
+			return nil
+		},
+		Gate: gate,
+	}
+	job := &river.Job[DiscoverAssetArgs]{JobRow: &rivertype.JobRow{}, Args: DiscoverAssetArgs{RepositoryID: uuid.NewString(), RelativePath: "a.jpg"}}
+
+	for i := 0; i < 3; i++ {
+		err := worker.Work(context.Background(), job)
+		var snooze *rivertype.JobSnoozeError
+		if !errors.As(err, &snooze) {
+			t.Fatalf("paused Work returned %v, want a snooze", err)
+		}
+		if snooze.Duration != processingPausedSnooze {
+			t.Fatalf("snooze = %v, want %v", snooze.Duration, processingPausedSnooze)
+		}
+	}
+	if runs != 0 {
+		t.Fatalf("discovery ran %d times while paused", runs)
+	}
+
+	gate.paused = false
+	if err := worker.Work(context.Background(), job); err != nil {
+		t.Fatalf("resumed Work returned %v", err)
+	}
+	if runs != 1 {
+		t.Fatalf("discovery ran %d times after resume, want 1", runs)
+	}
+}
+
+func TestIngestAssetWorkerLeavesJobsQueuedWhilePaused(t *testing.T) {
+	// Processor is nil: a paused worker must return before touching it.
+	worker := &IngestAssetWorker{Gate: &processingGateStub{paused: true}}
+	job := &river.Job[IngestAssetArgs]{JobRow: &rivertype.JobRow{}, Args: IngestAssetArgs{ContentHash: "abc", StagedPath: "/staging/a.jpg"}}
+
+	var snooze *rivertype.JobSnoozeError
+	if err := worker.Work(context.Background(), job); !errors.As(err, &snooze) {
+		t.Fatalf("paused Work returned %v, want a snooze", err)
+	}
+}
+
+func TestHoldIfProcessingPaused(t *testing.T) {
+	if err := holdIfProcessingPaused(context.Background(), nil); err != nil {
+		t.Fatalf("nil gate returned %v", err)
+	}
+	if err := holdIfProcessingPaused(context.Background(), &processingGateStub{}); err != nil {
+		t.Fatalf("unpaused gate returned %v", err)
+	}
+	// An unreadable flag fails the attempt so River retries it, rather than
+	// guessing in either direction.
+	readErr := errors.New("db down")
+	err := holdIfProcessingPaused(context.Background(), &processingGateStub{err: readErr})
+	var snooze *rivertype.JobSnoozeError
+	if !errors.Is(err, readErr) || errors.As(err, &snooze) {
+		t.Fatalf("unreadable gate returned %v", err)
+	}
+}
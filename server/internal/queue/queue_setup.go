@@ -42,10 +42,12 @@ func queueWorkerCounts() (ingestWorkers int, thumbnailWorkers int, phashWorkers
 	return queueWorkerCountsForCPU(runtime.NumCPU())
 }
 
-func New(dbpool *pgxpool.Pool, workers *river.Workers, logger *slog.Logger) (*river.Client[pgx.Tx], error) {
+// queueConfigs returns the River queues the server works and their worker
+// concurrency.
+func queueConfigs() map[string]river.QueueConfig {
 	ingestWorkers, thumbnailWorkers, phashWorkers := queueWorkerCounts()
 
-	queues := map[string]river.QueueConfig{
+	return map[string]river.QueueConfig{
 		"ingest_asset":              {MaxWorkers: ingestWorkers},
 		"discover_asset":            {MaxWorkers: 20},
 		"metadata_asset":            {MaxWorkers: 20},
@@ -53,9 +55,16 @@ func New(dbpool *pgxpool.Pool, workers *river.Workers, logger *slog.Logger) (*ri
 		"transcode_asset":           {MaxWorkers: 1},
 		"retry_asset":               {MaxWorkers: 2},
 		"reindex_assets":            {MaxWorkers: 1},
+		"retry_tagging":             {MaxWorkers: 1},
+		"cleanup_ai_tags":           {MaxWorkers: 1},
 		"rebuild_location_clusters": {MaxWorkers: 1},
 		"scan_repository":           {MaxWorkers: 1},
+		"check_repository_sizes":    {MaxWorkers: 1},
+		"process_pending_assets":    {MaxWorkers: 1},
+		"archive_original":          {MaxWorkers: 2},
+		"write_rating":              {MaxWorkers: 2},
 		"db_backup":                 {MaxWorkers: 1},
+		"evict_web_derivatives":     {MaxWorkers: 1},
 		"detect_stacks":             {MaxWorkers: 1},
 		"match_live_photo":          {MaxWorkers: 2},
 		"process_semantic":          {MaxWorkers: 2},
@@ -65,6 +74,21 @@ func New(dbpool *pgxpool.Pool, workers *river.Workers, logger *slog.Logger) (*ri
 		"classify_zeroshot":         {MaxWorkers: 2},
 		"process_phash":             {MaxWorkers: phashWorkers},
 	}
+}
+
+// QueueConcurrency returns the configured MaxWorkers of every queue, keyed by
+// queue name.
+func QueueConcurrency() map[string]int {
+	configs := queueConfigs()
+	concurrency := make(map[string]int, len(configs))
+	for name, cfg := range configs {
+		concurrency[name] = cfg.MaxWorkers
+	}
+	return concurrency
+}
+
+func New(dbpool *pgxpool.Pool, workers *river.Workers, logger *slog.Logger) (*river.Client[pgx.Tx], error) {
+	queues := queueConfigs()
 
 	client, err := river.NewClient(riverpgxv5.New(dbpool), &river.Config{
 		Schema:  "public",
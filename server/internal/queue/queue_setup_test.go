@@ -66,3 +66,21 @@ func TestQueueWorkerCountsForCPU(t *testing.T) {
 		})
 	}
 }
+
+func TestQueueConcurrencyMatchesConfigs(t *testing.T) {
+	t.Parallel()
+
+	concurrency := QueueConcurrency()
+	configs := queueConfigs()
+	if len(concurrency) != len(configs) {
+		t.Fatalf("QueueConcurrency has %d queues, want %d", len(concurrency), len(configs))
+	}
+	for name, cfg := range configs {
+		if concurrency[name] != cfg.MaxWorkers {
+			t.Fatalf("QueueConcurrency[%q] = %d, want %d", name, concurrency[name], cfg.MaxWorkers)
+		}
+	}
+	if concurrency["transcode_asset"] != 1 {
+		t.Fatalf("transcode_asset concurrency = %d, want 1", concurrency["transcode_asset"])
+	}
+}
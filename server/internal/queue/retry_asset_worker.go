@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/riverqueue/river"
 
 	"server/internal/queue/jobs"
@@ -20,11 +21,20 @@ type AssetRetryWorker struct {
 	// ProcessRetry performs retry for the given asset.
 	// It should be provided by the caller when registering the worker.
 	ProcessRetry func(ctx context.Context, args AssetRetryArgs) error
+
+	// Locks serializes this job with other jobs on the same asset.
+	Locks AssetLocker
 }
 
 func (w *AssetRetryWorker) Work(ctx context.Context, job *river.Job[AssetRetryArgs]) error {
 	if w.ProcessRetry == nil {
 		return fmt.Errorf("asset retry worker not configured")
 	}
-	return w.ProcessRetry(ctx, job.Args)
+	// An unparseable ID leaves assetID invalid, which runs unlocked and lets
+	// ProcessRetry report the bad ID.
+	var assetID pgtype.UUID
+	_ = assetID.Scan(job.Args.AssetID)
+	return withAssetLock(ctx, w.Locks, assetID, func(ctx context.Context) error {
+		return w.ProcessRetry(ctx, job.Args)
+	})
 }
@@ -0,0 +1,32 @@
+package queue
+
+import (
+	"context"
+	"fmt"
+
+	"server/internal/queue/jobs"
+
+	"github.com/riverqueue/river"
+)
+
+// WriteRatingArgs is the job payload alias to avoid import cycles.
+type WriteRatingArgs = jobs.WriteRatingArgs
+
+// WriteRatingWorker writes stored ratings back into asset originals.
+type WriteRatingWorker struct {
+	river.WorkerDefaults[WriteRatingArgs]
+
+	Write func(ctx context.Context, args WriteRatingArgs) error
+
+	// Locks serializes this job with other jobs on the same asset.
+	Locks AssetLocker
+}
+
+func (w *WriteRatingWorker) Work(ctx context.Context, job *river.Job[WriteRatingArgs]) error {
+	if w.Write == nil {
+		return fmt.Errorf("write rating worker missing processor")
+	}
+	return withAssetLock(ctx, w.Locks, job.Args.AssetID, func(ctx context.Context) error {
+		return w.Write(ctx, job.Args)
+	})
+}
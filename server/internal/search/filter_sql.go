@@ -128,6 +128,17 @@ func buildAssetFilterConditions(builder *sqlBuilder, filter Filter, assetAlias s
 	if filter.LensModel != nil {
 		conditions = append(conditions, fmt.Sprintf("%s.specific_metadata->>'lens_model' = %s", a, builder.addArg(*filter.LensModel)))
 	}
+	if len(filter.CustomFields) > 0 {
+		conditions = append(conditions, fmt.Sprintf(`EXISTS (
+			SELECT 1
+			FROM asset_custom_fields acf
+			WHERE acf.asset_id = %s.asset_id
+			  AND acf.fields @> %s::jsonb
+		)`, a, builder.addArg(filter.CustomFields)))
+	}
+	if filter.ProcessingStatus != nil {
+		conditions = append(conditions, fmt.Sprintf("%s.status->>'state' = %s", a, builder.addArg(*filter.ProcessingStatus)))
+	}
 	if filter.LocationNorth != nil && filter.LocationSouth != nil && filter.LocationEast != nil && filter.LocationWest != nil {
 		northPlaceholder := builder.addArg(*filter.LocationNorth)
 		southPlaceholder := builder.addArg(*filter.LocationSouth)
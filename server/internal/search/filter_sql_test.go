@@ -0,0 +1,22 @@
+package search
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildAssetFilterConditionsMatchesProcessingStatus(t *testing.T) {
+	failed := "failed"
+	builder := &sqlBuilder{}
+	conditions, err := buildAssetFilterConditions(builder, Filter{ProcessingStatus: &failed}, "a")
+	require.NoError(t, err)
+	require.Contains(t, joinConditions(conditions), "a.status->>'state' = $2")
+	require.Equal(t, []any{false, "failed"}, builder.args)
+
+	builder = &sqlBuilder{}
+	conditions, err = buildAssetFilterConditions(builder, Filter{}, "a")
+	require.NoError(t, err)
+	require.False(t, strings.Contains(joinConditions(conditions), "status"))
+}
@@ -36,6 +36,8 @@ type Filter struct {
 	Liked            *bool
 	CameraModel      *string
 	LensModel        *string
+	CustomFields     []byte // JSON object the asset's custom fields must contain
+	ProcessingStatus *string
 	TagName          *string
 	TagSource        *string
 	TagNames         []string
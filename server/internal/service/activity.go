@@ -0,0 +1,60 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"server/internal/db/repo"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Activity event types, as stored in activity_events.event_type.
+const (
+	ActivityAssetAdded         = "asset.added"
+	ActivityAssetDeleted       = "asset.deleted"
+	ActivityAssetRatingUpdated = "asset.rating_updated"
+	ActivityAlbumCreated       = "album.created"
+	ActivityAlbumUpdated       = "album.updated"
+	ActivityAlbumDeleted       = "album.deleted"
+	ActivityAlbumAssetAdded    = "album.asset_added"
+	ActivityAlbumAssetRemoved  = "album.asset_removed"
+)
+
+// ActivityRecorder appends activity events; repo.Querier satisfies it.
+type ActivityRecorder interface {
+	RecordActivityEvent(ctx context.Context, arg repo.RecordActivityEventParams) error
+}
+
+// ActivityEvent is one library change for the activity feed. OwnerID may be
+// left nil while the asset or album still exists; it is then looked up.
+type ActivityEvent struct {
+	Type    string
+	AssetID pgtype.UUID
+	AlbumID *int32
+	OwnerID *int32
+	Payload map[string]any
+}
+
+// RecordActivity appends event to the activity feed.
+func RecordActivity(ctx context.Context, recorder ActivityRecorder, event ActivityEvent) error {
+	payload := []byte("{}")
+	if len(event.Payload) > 0 {
+		encoded, err := json.Marshal(event.Payload)
+		if err != nil {
+			return fmt.Errorf("encode %s activity payload: %w", event.Type, err)
+		}
+		payload = encoded
+	}
+	if err := recorder.RecordActivityEvent(ctx, repo.RecordActivityEventParams{
+		EventType: event.Type,
+		AssetID:   event.AssetID,
+		AlbumID:   event.AlbumID,
+		OwnerID:   event.OwnerID,
+		Payload:   payload,
+	}); err != nil {
+		return fmt.Errorf("record %s activity: %w", event.Type, err)
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"server/internal/db/dbtest"
+	"server/internal/db/dbtypes"
+	statusdb "server/internal/db/dbtypes/status"
+	"server/internal/db/repo"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAssetMutationsRecordActivityPostgresIntegration(t *testing.T) {
+	ctx := context.Background()
+	pool := dbtest.Pool(t, os.Getenv(dbtest.DatabaseURLEnv))
+
+	suffix := strings.ReplaceAll(uuid.NewString(), "-", "")[:12]
+	username := "activity_" + suffix
+	var userID int32
+	require.NoError(t, pool.QueryRow(ctx, `
+		INSERT INTO users (username, password, webauthn_user_handle)
+		VALUES ($1, 'unused', $2)
+		RETURNING user_id`, username, []byte(username)).Scan(&userID))
+	t.Cleanup(func() {
+		_, _ = pool.Exec(ctx, `DELETE FROM activity_events WHERE owner_id = $1`, userID)
+		_, _ = pool.Exec(ctx, `DELETE FROM assets WHERE owner_id = $1`, userID)
+		_, _ = pool.Exec(ctx, `DELETE FROM users WHERE user_id = $1`, userID)
+	})
+
+	queries := repo.New(pool)
+	svc := &assetService{queries: queries, pool: pool}
+	since := time.Now().Add(-time.Second)
+
+	assetStatus, err := statusdb.NewCompleteStatus().ToJSONB()
+	require.NoError(t, err)
+	asset, err := svc.CreateAssetRecord(ctx, repo.CreateAssetParams{
+		OwnerID:          &userID,
+		Type:             string(dbtypes.AssetTypePhoto),
+		OriginalFilename: username + ".jpg",
+		MimeType:         "image/jpeg",
+		FileSize:         1024,
+		ContentHash:      suffix,
+		SpecificMetadata: dbtypes.SpecificMetadata(`{}`),
+		Status:           assetStatus,
+	})
+	require.NoError(t, err)
+	assetID := uuid.UUID(asset.AssetID.Bytes)
+	require.NoError(t, svc.UpdateAssetRating(ctx, assetID, 4))
+	require.NoError(t, svc.DeleteAsset(ctx, assetID))
+
+	events, err := queries.ListActivityEvents(ctx, repo.ListActivityEventsParams{
+		Since:   pgtype.Timestamptz{Time: since, Valid: true},
+		OwnerID: &userID,
+		Limit:   10,
+	})
+	require.NoError(t, err)
+	require.Len(t, events, 3)
+	for i, want := range []string{ActivityAssetAdded, ActivityAssetRatingUpdated, ActivityAssetDeleted} {
+		require.Equal(t, want, events[i].EventType)
+		require.Equal(t, asset.AssetID, events[i].AssetID)
+		require.Equal(t, &userID, events[i].OwnerID, "owner is looked up from the asset")
+	}
+	var payload map[string]any
+	require.NoError(t, json.Unmarshal(events[1].Payload, &payload))
+	require.Equal(t, float64(4), payload["rating"])
+
+	// The cursor continues after the last event seen.
+	last := events[len(events)-1]
+	more, err := queries.ListActivityEvents(ctx, repo.ListActivityEventsParams{
+		Since:   last.OccurredAt,
+		AfterID: last.EventID,
+		OwnerID: &userID,
+		Limit:   10,
+	})
+	require.NoError(t, err)
+	require.Empty(t, more)
+
+	otherOwner := userID + 1_000_000
+	scoped, err := queries.ListActivityEvents(ctx, repo.ListActivityEventsParams{
+		Since:   pgtype.Timestamptz{Time: since, Valid: true},
+		OwnerID: &otherOwner,
+		Limit:   10,
+	})
+	require.NoError(t, err)
+	require.Empty(t, scoped, "other users do not see the events")
+}
@@ -0,0 +1,112 @@
+package service
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"server/internal/db/dbtest"
+	"server/internal/db/repo"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAlbumCoverAutoSelectionPostgresIntegration(t *testing.T) {
+	ctx := context.Background()
+	pool := dbtest.Pool(t, os.Getenv(dbtest.DatabaseURLEnv))
+
+	suffix := strings.ReplaceAll(uuid.NewString(), "-", "")[:12]
+	username := "autocover_" + suffix
+	var userID int32
+	require.NoError(t, pool.QueryRow(ctx, `
+		INSERT INTO users (username, password, webauthn_user_handle)
+		VALUES ($1, 'unused', $2)
+		RETURNING user_id`, username, []byte(username)).Scan(&userID))
+	t.Cleanup(func() {
+		_, _ = pool.Exec(ctx, `DELETE FROM albums WHERE user_id = $1`, userID)
+		_, _ = pool.Exec(ctx, `DELETE FROM assets WHERE original_filename LIKE $1`, username+"%")
+		_, _ = pool.Exec(ctx, `DELETE FROM users WHERE user_id = $1`, userID)
+	})
+
+	insertAsset := func(name string, rating *int32) pgtype.UUID {
+		var assetID pgtype.UUID
+		require.NoError(t, pool.QueryRow(ctx, `
+			INSERT INTO assets (type, original_filename, mime_type, file_size, content_hash, owner_id, rating)
+			VALUES ('PHOTO', $1, 'image/jpeg', 1024, $2, $3, $4)
+			RETURNING asset_id`, username+"_"+name+".jpg", suffix+name, userID, rating).Scan(&assetID))
+		return assetID
+	}
+	rated := func(r int32) *int32 { return &r }
+
+	queries := repo.New(pool)
+	svc := NewAlbumService(queries, pool)
+	newAlbum := func(name string) repo.Album {
+		album, err := svc.CreateNewAlbum(ctx, repo.CreateAlbumParams{
+			UserID:    userID,
+			AlbumName: username + "_" + name,
+			AlbumType: repo.AlbumTypeDefault,
+		})
+		require.NoError(t, err)
+		require.True(t, album.CoverAuto, "albums created without a cover choose one")
+		return album
+	}
+	add := func(albumID int32, assetID pgtype.UUID) {
+		require.NoError(t, svc.AddAssetToAlbum(ctx, repo.AddAssetToAlbumParams{AlbumID: albumID, AssetID: assetID}))
+	}
+	remove := func(albumID int32, assetID pgtype.UUID) {
+		require.NoError(t, svc.RemoveAssetFromAlbum(ctx, repo.RemoveAssetFromAlbumParams{AlbumID: albumID, AssetID: assetID}))
+	}
+	coverOf := func(albumID int32) pgtype.UUID {
+		album, err := svc.GetAlbumByID(ctx, albumID)
+		require.NoError(t, err)
+		return album.CoverAssetID
+	}
+
+	t.Run("follows the highest rating", func(t *testing.T) {
+		unrated, best := insertAsset("f_unrated", nil), insertAsset("f_best", rated(5))
+		album := newAlbum("follows")
+
+		add(album.AlbumID, unrated)
+		require.Equal(t, unrated, coverOf(album.AlbumID))
+		add(album.AlbumID, best)
+		require.Equal(t, best, coverOf(album.AlbumID))
+
+		remove(album.AlbumID, best)
+		require.Equal(t, unrated, coverOf(album.AlbumID))
+		remove(album.AlbumID, unrated)
+		require.False(t, coverOf(album.AlbumID).Valid)
+	})
+
+	t.Run("manual cover stays until removed", func(t *testing.T) {
+		low, high := insertAsset("m_low", rated(1)), insertAsset("m_high", rated(4))
+		album := newAlbum("manual")
+		add(album.AlbumID, low)
+
+		manual, err := queries.SetAlbumCoverAsset(ctx, repo.SetAlbumCoverAssetParams{AlbumID: album.AlbumID, CoverAssetID: low})
+		require.NoError(t, err)
+		require.False(t, manual.CoverAuto)
+
+		add(album.AlbumID, high)
+		require.Equal(t, low, coverOf(album.AlbumID), "a cover picked by hand is kept")
+
+		remove(album.AlbumID, low)
+		require.Equal(t, high, coverOf(album.AlbumID))
+	})
+
+	t.Run("auto re-selects a manual cover", func(t *testing.T) {
+		low, high := insertAsset("a_low", rated(2)), insertAsset("a_high", rated(3))
+		album := newAlbum("auto")
+		add(album.AlbumID, low)
+		add(album.AlbumID, high)
+		_, err := queries.SetAlbumCoverAsset(ctx, repo.SetAlbumCoverAssetParams{AlbumID: album.AlbumID, CoverAssetID: low})
+		require.NoError(t, err)
+
+		auto, err := svc.AutoSelectAlbumCover(ctx, album.AlbumID)
+		require.NoError(t, err)
+		require.True(t, auto.CoverAuto)
+		require.Equal(t, high, auto.CoverAssetID)
+	})
+}
@@ -0,0 +1,89 @@
+package service
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"server/internal/db/dbtest"
+	"server/internal/db/repo"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeleteAssetRefreshesAlbumCoverPostgresIntegration(t *testing.T) {
+	ctx := context.Background()
+	pool := dbtest.Pool(t, os.Getenv(dbtest.DatabaseURLEnv))
+
+	suffix := strings.ReplaceAll(uuid.NewString(), "-", "")[:12]
+	username := "cover_" + suffix
+	var userID int32
+	require.NoError(t, pool.QueryRow(ctx, `
+		INSERT INTO users (username, password, webauthn_user_handle)
+		VALUES ($1, 'unused', $2)
+		RETURNING user_id`, username, []byte(username)).Scan(&userID))
+	t.Cleanup(func() {
+		_, _ = pool.Exec(ctx, `DELETE FROM albums WHERE user_id = $1`, userID)
+		_, _ = pool.Exec(ctx, `DELETE FROM assets WHERE original_filename LIKE $1`, username+"%")
+		_, _ = pool.Exec(ctx, `DELETE FROM users WHERE user_id = $1`, userID)
+	})
+
+	insertAsset := func(name string) uuid.UUID {
+		var assetID uuid.UUID
+		require.NoError(t, pool.QueryRow(ctx, `
+			INSERT INTO assets (type, original_filename, mime_type, file_size, content_hash, owner_id)
+			VALUES ('PHOTO', $1, 'image/jpeg', 1024, $2, $3)
+			RETURNING asset_id`, username+"_"+name+".jpg", suffix+name, userID).Scan(&assetID))
+		return assetID
+	}
+	// newAlbum creates an album whose cover is its first member; later
+	// members are added progressively more recently.
+	newAlbum := func(members ...uuid.UUID) int32 {
+		var albumID int32
+		require.NoError(t, pool.QueryRow(ctx, `
+			INSERT INTO albums (user_id, album_name, cover_asset_id)
+			VALUES ($1, $2, $3)
+			RETURNING album_id`, userID, username, members[0]).Scan(&albumID))
+		addedAt := time.Now().Add(-time.Hour)
+		for _, member := range members {
+			_, err := pool.Exec(ctx, `
+				INSERT INTO album_assets (album_id, asset_id, added_time)
+				VALUES ($1, $2, $3)`, albumID, member, addedAt)
+			require.NoError(t, err)
+			addedAt = addedAt.Add(time.Minute)
+		}
+		return albumID
+	}
+	coverOf := func(albumID int32) *uuid.UUID {
+		var cover *uuid.UUID
+		require.NoError(t, pool.QueryRow(ctx, `SELECT cover_asset_id FROM albums WHERE album_id = $1`, albumID).Scan(&cover))
+		return cover
+	}
+
+	t.Run("reassign", func(t *testing.T) {
+		cover, older, newest := insertAsset("r_cover"), insertAsset("r_older"), insertAsset("r_newest")
+		albumID := newAlbum(cover, older, newest)
+
+		svc := &assetService{queries: repo.New(pool), pool: pool, reassignAlbumCovers: true}
+		require.NoError(t, svc.DeleteAsset(ctx, cover))
+		require.Equal(t, &newest, coverOf(albumID), "cover moves to the most recently added member")
+
+		require.NoError(t, svc.DeleteAsset(ctx, newest))
+		require.Equal(t, &older, coverOf(albumID))
+
+		require.NoError(t, svc.DeleteAsset(ctx, older))
+		require.Nil(t, coverOf(albumID), "no live member left to reassign to")
+	})
+
+	t.Run("clear", func(t *testing.T) {
+		cover, other := insertAsset("c_cover"), insertAsset("c_other")
+		albumID := newAlbum(cover, other)
+
+		svc := &assetService{queries: repo.New(pool), pool: pool}
+		require.NoError(t, svc.DeleteAsset(ctx, cover))
+		require.Nil(t, coverOf(albumID))
+	})
+}
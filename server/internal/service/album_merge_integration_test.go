@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"server/internal/db/dbtest"
+	"server/internal/db/repo"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeAlbumsPostgresIntegration(t *testing.T) {
+	ctx := context.Background()
+	pool := dbtest.Pool(t, os.Getenv(dbtest.DatabaseURLEnv))
+
+	suffix := strings.ReplaceAll(uuid.NewString(), "-", "")[:12]
+	username := "merge_" + suffix
+	var userID int32
+	require.NoError(t, pool.QueryRow(ctx, `
+		INSERT INTO users (username, password, webauthn_user_handle)
+		VALUES ($1, 'unused', $2)
+		RETURNING user_id`, username, []byte(username)).Scan(&userID))
+	t.Cleanup(func() {
+		_, _ = pool.Exec(ctx, `DELETE FROM albums WHERE user_id = $1`, userID)
+		_, _ = pool.Exec(ctx, `DELETE FROM assets WHERE original_filename LIKE $1`, username+"%")
+		_, _ = pool.Exec(ctx, `DELETE FROM users WHERE user_id = $1`, userID)
+	})
+
+	insertAsset := func(name string) uuid.UUID {
+		var assetID uuid.UUID
+		require.NoError(t, pool.QueryRow(ctx, `
+			INSERT INTO assets (type, original_filename, mime_type, file_size, content_hash, owner_id)
+			VALUES ('PHOTO', $1, 'image/jpeg', 1024, $2, $3)
+			RETURNING asset_id`, username+"_"+name+".jpg", suffix+name, userID).Scan(&assetID))
+		return assetID
+	}
+	// newAlbum creates an album named like every other album of the test
+	// user, with members at positions 1, 2, 3...
+	newAlbum := func(cover *uuid.UUID, members ...uuid.UUID) int32 {
+		var albumID int32
+		require.NoError(t, pool.QueryRow(ctx, `
+			INSERT INTO albums (user_id, album_name, cover_asset_id)
+			VALUES ($1, 'Trip', $2)
+			RETURNING album_id`, userID, cover).Scan(&albumID))
+		for i, member := range members {
+			_, err := pool.Exec(ctx, `
+				INSERT INTO album_assets (album_id, asset_id, position)
+				VALUES ($1, $2, $3)`, albumID, member, i+1)
+			require.NoError(t, err)
+		}
+		return albumID
+	}
+
+	a, b, c, d, e := insertAsset("a"), insertAsset("b"), insertAsset("c"), insertAsset("d"), insertAsset("e")
+	target := newAlbum(nil, a, b)
+	first := newAlbum(&d, d, b, c)
+	second := newAlbum(nil, e, c)
+
+	svc := NewAlbumService(repo.New(pool), pool)
+	duplicates, err := svc.ListDuplicateAlbums(ctx, userID)
+	require.NoError(t, err)
+	require.Len(t, duplicates, 3)
+	require.Equal(t, target, duplicates[0].AlbumID, "oldest album comes first")
+	require.Equal(t, int64(3), duplicates[1].AssetCount)
+
+	result, err := svc.MergeAlbums(ctx, target, []int32{first, second})
+	require.NoError(t, err)
+	require.Equal(t, int64(3), result.AssetsAdded, "b is already in the target and c is added once")
+	require.Equal(t, int64(2), result.AlbumsRemoved)
+	require.True(t, result.Album.CoverAssetID.Valid)
+	require.Equal(t, d, uuid.UUID(result.Album.CoverAssetID.Bytes), "target adopts the first source cover")
+
+	rows, err := pool.Query(ctx, `
+		SELECT asset_id FROM album_assets
+		WHERE album_id = $1
+		ORDER BY position, asset_id`, target)
+	require.NoError(t, err)
+	var members []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		require.NoError(t, rows.Scan(&id))
+		members = append(members, id)
+	}
+	require.NoError(t, rows.Err())
+	require.Equal(t, []uuid.UUID{a, b, d, c, e}, members, "sources are appended in order after the target's own assets")
+
+	var remaining int
+	require.NoError(t, pool.QueryRow(ctx, `
+		SELECT COUNT(*) FROM albums WHERE album_id = ANY($1::int[])`, []int32{first, second}).Scan(&remaining))
+	require.Zero(t, remaining, "source albums are deleted")
+
+	duplicates, err = svc.ListDuplicateAlbums(ctx, userID)
+	require.NoError(t, err)
+	require.Empty(t, duplicates)
+}
@@ -0,0 +1,26 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckAlbumOrder(t *testing.T) {
+	a, b, c, outsider := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	members := []pgtype.UUID{{Bytes: a, Valid: true}, {Bytes: b, Valid: true}, {Bytes: c, Valid: true}}
+
+	require.NoError(t, checkAlbumOrder(members, []uuid.UUID{c, a, b}))
+	require.NoError(t, checkAlbumOrder(nil, nil), "an empty album has an empty order")
+
+	var orderErr *AlbumOrderError
+	err := checkAlbumOrder(members, []uuid.UUID{c, outsider, a, a})
+	require.True(t, errors.As(err, &orderErr))
+	require.Equal(t, []uuid.UUID{outsider}, orderErr.NotInAlbum)
+	require.Equal(t, []uuid.UUID{b}, orderErr.Missing)
+	require.Equal(t, []uuid.UUID{a}, orderErr.Repeated)
+	require.EqualError(t, err, "order must list every asset of the album once: 1 not in album, 1 missing, 1 repeated")
+}
@@ -2,10 +2,12 @@ package service
 
 import (
 	"context"
+	"fmt"
 	"server/internal/db/repo"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 type AlbumService interface {
@@ -19,11 +21,68 @@ type AlbumService interface {
 	AddAssetToAlbum(ctx context.Context, params repo.AddAssetToAlbumParams) error
 	RemoveAssetFromAlbum(ctx context.Context, params repo.RemoveAssetFromAlbumParams) error
 	UpdateAssetPositionInAlbum(ctx context.Context, params repo.UpdateAssetPositionInAlbumParams) error
+	ReorderAlbumAssets(ctx context.Context, albumID int32, order []uuid.UUID) ([]uuid.UUID, error)
 	GetAssetAlbums(ctx context.Context, assetID pgtype.UUID) ([]repo.GetAssetAlbumsRow, error)
+	ListDuplicateAlbums(ctx context.Context, userID int32) ([]repo.ListDuplicateAlbumsByUserRow, error)
+	MergeAlbums(ctx context.Context, targetID int32, sourceIDs []int32) (MergeAlbumsResult, error)
+	AutoSelectAlbumCover(ctx context.Context, albumID int32) (repo.Album, error)
 }
 
 type albumService struct {
 	queries *repo.Queries
+	pool    *pgxpool.Pool
+}
+
+// MergeAlbumsResult reports what a merge changed.
+type MergeAlbumsResult struct {
+	Album         repo.Album
+	AssetsAdded   int64
+	AlbumsRemoved int64
+}
+
+// AlbumOrderError rejects a reorder whose list is not exactly the album's
+// live members, each once.
+type AlbumOrderError struct {
+	NotInAlbum []uuid.UUID
+	Missing    []uuid.UUID
+	Repeated   []uuid.UUID
+}
+
+func (e *AlbumOrderError) Error() string {
+	return fmt.Sprintf("order must list every asset of the album once: %d not in album, %d missing, %d repeated",
+		len(e.NotInAlbum), len(e.Missing), len(e.Repeated))
+}
+
+// checkAlbumOrder compares a requested order with the album's members.
+func checkAlbumOrder(members []pgtype.UUID, order []uuid.UUID) error {
+	remaining := make(map[uuid.UUID]bool, len(members))
+	for _, member := range members {
+		remaining[uuid.UUID(member.Bytes)] = true
+	}
+
+	var orderErr AlbumOrderError
+	seen := make(map[uuid.UUID]bool, len(order))
+	for _, id := range order {
+		switch {
+		case seen[id]:
+			orderErr.Repeated = append(orderErr.Repeated, id)
+		case !remaining[id]:
+			orderErr.NotInAlbum = append(orderErr.NotInAlbum, id)
+		default:
+			delete(remaining, id)
+		}
+		seen[id] = true
+	}
+	for _, member := range members {
+		if id := uuid.UUID(member.Bytes); remaining[id] {
+			orderErr.Missing = append(orderErr.Missing, id)
+		}
+	}
+
+	if len(orderErr.NotInAlbum) > 0 || len(orderErr.Missing) > 0 || len(orderErr.Repeated) > 0 {
+		return &orderErr
+	}
+	return nil
 }
 
 // Request/Response types
@@ -31,24 +90,49 @@ type NewAlbumRequest struct {
 	UserID      int32   `json:"user_id" binding:"required"`
 	AlbumName   string  `json:"album_name" binding:"required"`
 	Description *string `json:"description,omitempty"`
-	// Accept UUID as string and validate format.
-	CoverAssetID string `json:"cover_asset_id" binding:"required,uuid4"`
+	// Accept UUID as string and validate format. Without one the cover is
+	// chosen automatically once the album has assets.
+	CoverAssetID *string `json:"cover_asset_id,omitempty" binding:"omitempty,uuid4"`
 }
 
 func (r NewAlbumRequest) CoverAssetAsPG() (pgtype.UUID, error) {
-	u, err := uuid.Parse(r.CoverAssetID)
+	if r.CoverAssetID == nil || *r.CoverAssetID == "" {
+		return pgtype.UUID{}, nil
+	}
+	u, err := uuid.Parse(*r.CoverAssetID)
 	if err != nil {
 		return pgtype.UUID{}, err
 	}
 	return pgtype.UUID{Bytes: u, Valid: true}, nil
 }
 
-func NewAlbumService(q *repo.Queries) AlbumService {
+func NewAlbumService(q *repo.Queries, pool *pgxpool.Pool) AlbumService {
 	return &albumService{
 		queries: q,
+		pool:    pool,
 	}
 }
 
+func (s *albumService) withTx(ctx context.Context, fn func(*repo.Queries) error) error {
+	if s.pool == nil {
+		return fn(s.queries)
+	}
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin album transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := fn(s.queries.WithTx(tx)); err != nil {
+		return err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit album transaction: %w", err)
+	}
+	return nil
+}
+
 // CreateNewAlbum creates a new album, userID, name not null
 func (s *albumService) CreateNewAlbum(ctx context.Context, params repo.CreateAlbumParams) (repo.Album, error) {
 	album, err := s.queries.CreateAlbum(ctx, params)
@@ -84,14 +168,44 @@ func (s *albumService) GetAlbumAssetCount(ctx context.Context, albumID int32) (i
 	return s.queries.GetAlbumAssetCount(ctx, albumID)
 }
 
-// AddAssetToAlbum adds an asset to an album
+// AddAssetToAlbum adds an asset to an album, choosing the cover again if it
+// is automatic or missing
 func (s *albumService) AddAssetToAlbum(ctx context.Context, params repo.AddAssetToAlbumParams) error {
-	return s.queries.AddAssetToAlbum(ctx, params)
+	return s.withTx(ctx, func(q *repo.Queries) error {
+		if err := q.AddAssetToAlbum(ctx, params); err != nil {
+			return err
+		}
+		return refreshAlbumCover(ctx, q, params.AlbumID, pgtype.UUID{})
+	})
 }
 
-// RemoveAssetFromAlbum removes an asset from an album
+// RemoveAssetFromAlbum removes an asset from an album, choosing another
+// cover if it was the cover
 func (s *albumService) RemoveAssetFromAlbum(ctx context.Context, params repo.RemoveAssetFromAlbumParams) error {
-	return s.queries.RemoveAssetFromAlbum(ctx, params)
+	return s.withTx(ctx, func(q *repo.Queries) error {
+		if err := q.RemoveAssetFromAlbum(ctx, params); err != nil {
+			return err
+		}
+		return refreshAlbumCover(ctx, q, params.AlbumID, params.AssetID)
+	})
+}
+
+// AutoSelectAlbumCover replaces the album's cover with the automatic choice,
+// its highest-rated asset, the most recently taken among equals, and keeps
+// choosing it as assets come and go until a cover is picked by hand.
+func (s *albumService) AutoSelectAlbumCover(ctx context.Context, albumID int32) (repo.Album, error) {
+	return s.queries.AutoSelectAlbumCover(ctx, albumID)
+}
+
+// refreshAlbumCover keeps an automatic cover current after a membership
+// change. replacing is the cover that needs a successor even if it was picked
+// by hand: the removed asset, or invalid (NULL) to fill an album without a
+// cover. Either way the album's cover is automatic afterwards.
+func refreshAlbumCover(ctx context.Context, q *repo.Queries, albumID int32, replacing pgtype.UUID) error {
+	if _, err := q.RefreshAlbumCover(ctx, repo.RefreshAlbumCoverParams{AlbumID: albumID, Replacing: replacing}); err != nil {
+		return fmt.Errorf("refresh album cover: %w", err)
+	}
+	return nil
 }
 
 // UpdateAssetPositionInAlbum updates the position of an asset within an album
@@ -100,6 +214,82 @@ func (s *albumService) UpdateAssetPositionInAlbum(ctx context.Context, params re
 }
 
 // GetAssetAlbums retrieves all albums that contain a specific asset
+// ReorderAlbumAssets sets the positions of an album's live members to their
+// order in the list, which must hold each of them exactly once, in one
+// transaction. It returns the members in their new order.
+func (s *albumService) ReorderAlbumAssets(ctx context.Context, albumID int32, order []uuid.UUID) ([]uuid.UUID, error) {
+	var ordered []uuid.UUID
+	err := s.withTx(ctx, func(q *repo.Queries) error {
+		members, err := q.LockAlbumAssetIDs(ctx, albumID)
+		if err != nil {
+			return fmt.Errorf("lock album members: %w", err)
+		}
+		if err := checkAlbumOrder(members, order); err != nil {
+			return err
+		}
+
+		ids := make([]pgtype.UUID, len(order))
+		for i, id := range order {
+			ids[i] = pgtype.UUID{Bytes: id, Valid: true}
+		}
+		rows, err := q.ReorderAlbumAssets(ctx, repo.ReorderAlbumAssetsParams{AssetIds: ids, AlbumID: albumID})
+		if err != nil {
+			return fmt.Errorf("reorder album assets: %w", err)
+		}
+		if len(rows) != len(order) {
+			return fmt.Errorf("reorder album assets: updated %d of %d members", len(rows), len(order))
+		}
+
+		ordered = make([]uuid.UUID, len(rows))
+		for _, row := range rows {
+			ordered[*row.Position-1] = uuid.UUID(row.AssetID.Bytes)
+		}
+		return nil
+	})
+	return ordered, err
+}
+
 func (s *albumService) GetAssetAlbums(ctx context.Context, assetID pgtype.UUID) ([]repo.GetAssetAlbumsRow, error) {
 	return s.queries.GetAssetAlbums(ctx, assetID)
 }
+
+// ListDuplicateAlbums returns a user's albums that share their name and type
+// with another of the user's albums, oldest first within each name.
+func (s *albumService) ListDuplicateAlbums(ctx context.Context, userID int32) ([]repo.ListDuplicateAlbumsByUserRow, error) {
+	return s.queries.ListDuplicateAlbumsByUser(ctx, userID)
+}
+
+// MergeAlbums moves the members of the source albums into the target and
+// deletes the sources, in one transaction. Members the target already has
+// keep their place; the rest are appended in source order.
+func (s *albumService) MergeAlbums(ctx context.Context, targetID int32, sourceIDs []int32) (MergeAlbumsResult, error) {
+	var result MergeAlbumsResult
+	err := s.withTx(ctx, func(q *repo.Queries) error {
+		added, err := q.MergeAlbumAssets(ctx, repo.MergeAlbumAssetsParams{
+			TargetAlbumID:  targetID,
+			SourceAlbumIds: sourceIDs,
+		})
+		if err != nil {
+			return fmt.Errorf("merge album assets: %w", err)
+		}
+		album, err := q.TouchMergedAlbum(ctx, repo.TouchMergedAlbumParams{
+			SourceAlbumIds: sourceIDs,
+			TargetAlbumID:  targetID,
+		})
+		if err != nil {
+			return fmt.Errorf("update merge target: %w", err)
+		}
+		if album.CoverAuto || !album.CoverAssetID.Valid {
+			if album, err = q.AutoSelectAlbumCover(ctx, targetID); err != nil {
+				return fmt.Errorf("choose merge target cover: %w", err)
+			}
+		}
+		removed, err := q.DeleteAlbumsByIDs(ctx, sourceIDs)
+		if err != nil {
+			return fmt.Errorf("delete merged albums: %w", err)
+		}
+		result = MergeAlbumsResult{Album: album, AssetsAdded: added, AlbumsRemoved: removed}
+		return nil
+	})
+	return result, err
+}
@@ -0,0 +1,70 @@
+package service
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"server/internal/db/dbtest"
+	"server/internal/db/repo"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListAssetsAroundTimePostgresIntegration(t *testing.T) {
+	ctx := context.Background()
+	pool := dbtest.Pool(t, os.Getenv(dbtest.DatabaseURLEnv))
+
+	suffix := strings.ReplaceAll(uuid.NewString(), "-", "")[:12]
+	username := "aroundtime_" + suffix
+	var userID int32
+	require.NoError(t, pool.QueryRow(ctx, `
+		INSERT INTO users (username, password, webauthn_user_handle)
+		VALUES ($1, 'unused', $2)
+		RETURNING user_id`, username, []byte(username)).Scan(&userID))
+	t.Cleanup(func() {
+		_, _ = pool.Exec(ctx, `DELETE FROM assets WHERE original_filename LIKE $1`, username+"%")
+		_, _ = pool.Exec(ctx, `DELETE FROM users WHERE user_id = $1`, userID)
+	})
+
+	at := time.Date(2024, 6, 15, 15, 0, 0, 0, time.UTC)
+	insertAsset := func(name string, offset time.Duration, deleted bool) uuid.UUID {
+		var assetID uuid.UUID
+		require.NoError(t, pool.QueryRow(ctx, `
+			INSERT INTO assets (type, original_filename, mime_type, file_size, content_hash, owner_id, taken_time, is_deleted)
+			VALUES ('PHOTO', $1, 'image/jpeg', 1024, $2, $3, $4, $5)
+			RETURNING asset_id`, username+"_"+name+".jpg", suffix+name, userID, at.Add(offset), deleted).Scan(&assetID))
+		return assetID
+	}
+	later := insertAsset("later", 40*time.Minute, false)
+	closest := insertAsset("closest", -5*time.Minute, false)
+	earlier := insertAsset("earlier", -50*time.Minute, false)
+	insertAsset("too_early", -2*time.Hour, false)
+	insertAsset("too_late", 61*time.Minute, false)
+	insertAsset("trashed", time.Minute, true)
+
+	svc := &assetService{queries: repo.New(pool)}
+	assets, err := svc.ListAssetsAroundTime(ctx, at, time.Hour, &userID, 50)
+	require.NoError(t, err)
+	var ids []uuid.UUID
+	for _, asset := range assets {
+		ids = append(ids, uuid.UUID(asset.AssetID.Bytes))
+	}
+	require.Equal(t, []uuid.UUID{closest, later, earlier}, ids, "only in-window assets, closest first")
+
+	top, err := svc.ListAssetsAroundTime(ctx, at, time.Hour, &userID, 1)
+	require.NoError(t, err)
+	require.Len(t, top, 1)
+	require.Equal(t, closest, uuid.UUID(top[0].AssetID.Bytes))
+
+	other := userID + 1_000_000
+	none, err := svc.ListAssetsAroundTime(ctx, at, time.Hour, &other, 50)
+	require.NoError(t, err)
+	require.Empty(t, none)
+
+	_, err = svc.ListAssetsAroundTime(ctx, at, 0, &userID, 50)
+	require.Error(t, err)
+}
@@ -60,12 +60,14 @@ type BrowseItem struct {
 }
 
 // BrowseQueryResult is the unified browse/list response: Items matches pagination, TotalVisible counts rows
-// after stack collapse, TotalAssets counts underlying assets, StackMode echoes the resolved mode.
+// after stack collapse, TotalAssets counts underlying assets, StackMode echoes the resolved mode. NextCursor
+// continues after the last item of a full page; it is nil on the last page and for semantic search.
 type BrowseQueryResult struct {
 	Items        []BrowseItem
 	TotalVisible int64
 	TotalAssets  int64
 	StackMode    string
+	NextCursor   *AssetCursor
 }
 
 // SearchBrowseResult combines optional semantic "top results" with the main filename-based browse listing.
@@ -94,23 +96,36 @@ func normalizeStackMode(mode string) string {
 // QueryBrowseItems returns paginated browse rows for the gallery. Expanded mode forwards to QueryAssets
 // (one row per asset). Collapsed mode uses SQL unified collapsed queries, except semantic search with a non-empty
 // query, where vector-ranked assets are collapsed in application code via collapseAssetsToBrowseItems.
+// A cursor replaces the offset; semantic search has no stable sort key and rejects it with ErrAssetCursorUnsupported.
 func (s *assetService) QueryBrowseItems(ctx context.Context, params QueryAssetsParams) (BrowseQueryResult, error) {
 	params.StackMode = normalizeStackMode(params.StackMode)
+	semantic := params.SearchType == "semantic" && strings.TrimSpace(params.Query) != ""
+	if params.Cursor != nil {
+		if semantic {
+			return BrowseQueryResult{}, ErrAssetCursorUnsupported
+		}
+		params.Offset = 0
+	}
 
 	if params.StackMode == StackModeExpanded {
 		assets, total, err := s.QueryAssets(ctx, params)
 		if err != nil {
 			return BrowseQueryResult{}, err
 		}
-		return BrowseQueryResult{
-			Items:        assetsToBrowseItems(assets),
+		items := assetsToBrowseItems(assets)
+		result := BrowseQueryResult{
+			Items:        items,
 			TotalVisible: total,
 			TotalAssets:  total,
 			StackMode:    params.StackMode,
-		}, nil
+		}
+		if !semantic {
+			result.NextCursor = nextAssetCursor(items, params.SortBy, params.Limit)
+		}
+		return result, nil
 	}
 
-	if params.SearchType == "semantic" && strings.TrimSpace(params.Query) != "" {
+	if semantic {
 		return s.queryCollapsedAggregateBrowseItems(ctx, params)
 	}
 
@@ -138,6 +153,7 @@ func (s *assetService) QueryBrowseItems(ctx context.Context, params QueryAssetsP
 		TotalVisible: totalVisible,
 		TotalAssets:  totalAssets,
 		StackMode:    params.StackMode,
+		NextCursor:   nextAssetCursor(items, params.SortBy, params.Limit),
 	}, nil
 }
 
@@ -291,6 +307,8 @@ func (s *assetService) queryCollapsedAggregateBrowseItems(ctx context.Context, p
 		Liked:            params.Liked,
 		CameraModel:      params.CameraModel,
 		LensModel:        params.LensModel,
+		CustomFields:     params.CustomFields,
+		ProcessingStatus: params.ProcessingStatus,
 		TagName:          params.TagName,
 		TagSource:        params.TagSource,
 		TagNames:         params.TagNames,
@@ -710,6 +728,8 @@ func (s *assetService) countAssetsUnified(ctx context.Context, params QueryAsset
 		Liked:            params.Liked,
 		CameraModel:      params.CameraModel,
 		LensModel:        params.LensModel,
+		CustomFields:     params.CustomFields,
+		ProcessingStatus: params.ProcessingStatus,
 		TagName:          params.TagName,
 		TagSource:        params.TagSource,
 		TagNames:         params.TagNames,
@@ -749,6 +769,8 @@ func (s *assetService) countCollapsedBrowseItemsUnified(ctx context.Context, par
 		Liked:            params.Liked,
 		CameraModel:      params.CameraModel,
 		LensModel:        params.LensModel,
+		CustomFields:     params.CustomFields,
+		ProcessingStatus: params.ProcessingStatus,
 		TagName:          params.TagName,
 		TagSource:        params.TagSource,
 		TagNames:         params.TagNames,
@@ -762,13 +784,15 @@ func (s *assetService) countCollapsedBrowseItemsUnified(ctx context.Context, par
 	})
 }
 
-// getCollapsedBrowseItemsUnified loads one page of collapsed browse rows (assets and stacks) with sort/offset/limit.
+// getCollapsedBrowseItemsUnified loads one page of collapsed browse rows (assets and stacks) with sort/offset/limit,
+// or sort/cursor/limit when params.Cursor is set.
 func (s *assetService) getCollapsedBrowseItemsUnified(ctx context.Context, params QueryAssetsParams) ([]repo.GetCollapsedBrowseItemsUnifiedRow, error) {
 	repoUUID, ratingPtr, fromTime, toTime, queryPtr, err := normalizeBrowseRepoParams(params)
 	if err != nil {
 		return nil, err
 	}
 	sortByPtr := normalizeSortByPointer(params.SortBy)
+	cursorTime, cursorID := assetCursorArgs(params.Cursor)
 	return s.queries.GetCollapsedBrowseItemsUnified(ctx, repo.GetCollapsedBrowseItemsUnifiedParams{
 		AssetIds:         assetSetSourcePgUUIDs(params.Source),
 		Query:            queryPtr,
@@ -792,12 +816,16 @@ func (s *assetService) getCollapsedBrowseItemsUnified(ctx context.Context, param
 		Liked:            params.Liked,
 		CameraModel:      params.CameraModel,
 		LensModel:        params.LensModel,
+		CustomFields:     params.CustomFields,
+		ProcessingStatus: params.ProcessingStatus,
 		LocationNorth:    params.LocationNorth,
 		LocationSouth:    params.LocationSouth,
 		LocationEast:     params.LocationEast,
 		LocationWest:     params.LocationWest,
 		SortBy:           sortByPtr,
 		IsDeleted:        params.IsDeleted,
+		CursorTime:       cursorTime,
+		CursorID:         cursorID,
 		Offset:           int32(params.Offset),
 		Limit:            int32(params.Limit),
 	})
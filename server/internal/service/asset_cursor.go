@@ -0,0 +1,95 @@
+package service
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"server/internal/db/repo"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// ErrInvalidAssetCursor is returned for a cursor that was not issued by
+// EncodeAssetCursor.
+var ErrInvalidAssetCursor = errors.New("invalid asset cursor")
+
+// ErrAssetCursorUnsupported is returned for result sets without a stable sort
+// key, where a page cannot be continued by keyset.
+var ErrAssetCursorUnsupported = errors.New("cursor pagination is not available for semantic search")
+
+// AssetCursor is the sort key of the last row of a page: its sort time under
+// the query's sort order and its asset ID, which breaks ties. The next page
+// starts strictly after it, so rows added or removed above the cursor do not
+// shift later pages the way an offset does.
+type AssetCursor struct {
+	SortTime time.Time
+	AssetID  uuid.UUID
+}
+
+// EncodeAssetCursor returns the opaque token clients send back to continue a
+// listing.
+func EncodeAssetCursor(cursor AssetCursor) string {
+	raw := strconv.FormatInt(cursor.SortTime.UnixMicro(), 10) + ":" + cursor.AssetID.String()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// DecodeAssetCursor parses a token produced by EncodeAssetCursor.
+func DecodeAssetCursor(token string) (AssetCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return AssetCursor{}, fmt.Errorf("%w: %v", ErrInvalidAssetCursor, err)
+	}
+	micros, id, ok := strings.Cut(string(raw), ":")
+	if !ok {
+		return AssetCursor{}, ErrInvalidAssetCursor
+	}
+	sortMicros, err := strconv.ParseInt(micros, 10, 64)
+	if err != nil {
+		return AssetCursor{}, fmt.Errorf("%w: %v", ErrInvalidAssetCursor, err)
+	}
+	assetID, err := uuid.Parse(id)
+	if err != nil {
+		return AssetCursor{}, fmt.Errorf("%w: %v", ErrInvalidAssetCursor, err)
+	}
+	return AssetCursor{SortTime: time.UnixMicro(sortMicros).UTC(), AssetID: assetID}, nil
+}
+
+// assetCursorArgs returns the keyset arguments of the unified list queries;
+// both are NULL when the query is not continued from a cursor.
+func assetCursorArgs(cursor *AssetCursor) (pgtype.Timestamptz, pgtype.UUID) {
+	if cursor == nil {
+		return pgtype.Timestamptz{}, pgtype.UUID{}
+	}
+	return pgtype.Timestamptz{Time: cursor.SortTime, Valid: true},
+		pgtype.UUID{Bytes: cursor.AssetID, Valid: true}
+}
+
+// assetSortTime mirrors the sort_time expression of the unified list queries.
+func assetSortTime(asset repo.Asset, sortBy string) time.Time {
+	if sortBy != "recently_added" && asset.TakenTime.Valid {
+		return asset.TakenTime.Time
+	}
+	return asset.UploadTime.Time
+}
+
+// nextAssetCursor returns the cursor after the last of items, or nil when the
+// page came back short and there is nothing left to continue. Stacks sort by
+// their cover asset, which BrowseItem.Asset carries.
+func nextAssetCursor(items []BrowseItem, sortBy string, limit int) *AssetCursor {
+	if limit <= 0 || len(items) < limit {
+		return nil
+	}
+	last := items[len(items)-1].Asset
+	if !last.AssetID.Valid {
+		return nil
+	}
+	return &AssetCursor{
+		SortTime: assetSortTime(last, sortBy),
+		AssetID:  uuid.UUID(last.AssetID.Bytes),
+	}
+}
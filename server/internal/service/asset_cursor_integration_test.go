@@ -0,0 +1,69 @@
+package service
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"server/internal/db/dbtest"
+	"server/internal/db/repo"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryBrowseItemsCursorPostgresIntegration(t *testing.T) {
+	ctx := context.Background()
+	pool := dbtest.Pool(t, os.Getenv(dbtest.DatabaseURLEnv))
+
+	suffix := strings.ReplaceAll(uuid.NewString(), "-", "")[:12]
+	prefix := "cursor_" + suffix
+	insert := func(name string, takenTime time.Time) uuid.UUID {
+		var assetID uuid.UUID
+		require.NoError(t, pool.QueryRow(ctx, `
+			INSERT INTO assets (type, original_filename, mime_type, file_size, content_hash, taken_time)
+			VALUES ('PHOTO', $1, 'image/jpeg', 1024, $2, $3)
+			RETURNING asset_id`, prefix+"_"+name+".jpg", suffix+name, takenTime).Scan(&assetID))
+		return assetID
+	}
+	t.Cleanup(func() {
+		_, _ = pool.Exec(ctx, `DELETE FROM assets WHERE original_filename LIKE $1`, prefix+"%")
+	})
+
+	march := insert("march", time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC))
+	february := insert("february", time.Date(2024, 2, 15, 0, 0, 0, 0, time.UTC))
+	january := insert("january", time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC))
+
+	svc := &assetService{queries: repo.New(pool)}
+	params := QueryAssetsParams{
+		Query:     prefix,
+		SortBy:    "date_captured",
+		StackMode: StackModeExpanded,
+		Limit:     2,
+	}
+
+	first, err := svc.QueryBrowseItems(ctx, params)
+	require.NoError(t, err)
+	require.Equal(t, []uuid.UUID{march, february}, browseItemAssetIDs(first.Items))
+	require.NotNil(t, first.NextCursor)
+
+	// An asset newer than the cursor does not shift the next page.
+	insert("april", time.Date(2024, 4, 15, 0, 0, 0, 0, time.UTC))
+
+	params.Cursor = first.NextCursor
+	second, err := svc.QueryBrowseItems(ctx, params)
+	require.NoError(t, err)
+	require.Equal(t, []uuid.UUID{january}, browseItemAssetIDs(second.Items))
+	require.Nil(t, second.NextCursor, "a short page is the last one")
+	require.EqualValues(t, 4, second.TotalAssets, "totals still count the whole result set")
+}
+
+func browseItemAssetIDs(items []BrowseItem) []uuid.UUID {
+	ids := make([]uuid.UUID, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, uuid.UUID(item.Asset.AssetID.Bytes))
+	}
+	return ids
+}
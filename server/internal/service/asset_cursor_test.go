@@ -0,0 +1,60 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"server/internal/db/repo"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAssetCursorRoundTrip(t *testing.T) {
+	cursor := AssetCursor{
+		SortTime: time.Date(2024, 1, 15, 10, 30, 0, 123456000, time.UTC),
+		AssetID:  uuid.MustParse("550e8400-e29b-41d4-a716-446655440000"),
+	}
+
+	decoded, err := DecodeAssetCursor(EncodeAssetCursor(cursor))
+	require.NoError(t, err)
+	require.Equal(t, cursor, decoded)
+}
+
+func TestDecodeAssetCursorRejectsGarbage(t *testing.T) {
+	for _, token := range []string{"", "not base64!", "bm8tY29sb24", "MTIzOm5vdC1hLXV1aWQ"} {
+		_, err := DecodeAssetCursor(token)
+		require.ErrorIs(t, err, ErrInvalidAssetCursor, token)
+	}
+}
+
+func TestNextAssetCursorFollowsSortOrder(t *testing.T) {
+	taken := time.Date(2023, 6, 1, 8, 0, 0, 0, time.UTC)
+	uploaded := time.Date(2024, 2, 1, 8, 0, 0, 0, time.UTC)
+	lastID := uuid.New()
+	items := []BrowseItem{
+		{Type: "asset", Asset: repo.Asset{AssetID: pgtype.UUID{Bytes: uuid.New(), Valid: true}}},
+		{Type: "stack", Asset: repo.Asset{
+			AssetID:    pgtype.UUID{Bytes: lastID, Valid: true},
+			TakenTime:  pgtype.Timestamptz{Time: taken, Valid: true},
+			UploadTime: pgtype.Timestamptz{Time: uploaded, Valid: true},
+		}},
+	}
+
+	require.Equal(t, &AssetCursor{SortTime: taken, AssetID: lastID}, nextAssetCursor(items, "date_captured", 2))
+	require.Equal(t, &AssetCursor{SortTime: uploaded, AssetID: lastID}, nextAssetCursor(items, "recently_added", 2))
+	require.Nil(t, nextAssetCursor(items, "date_captured", 3), "a short page is the last one")
+}
+
+func TestQueryBrowseItemsRejectsCursorForSemanticSearch(t *testing.T) {
+	svc := &assetService{}
+	_, err := svc.QueryBrowseItems(context.Background(), QueryAssetsParams{
+		Query:      "sunset",
+		SearchType: "semantic",
+		Limit:      20,
+		Cursor:     &AssetCursor{SortTime: time.Now(), AssetID: uuid.New()},
+	})
+	require.ErrorIs(t, err, ErrAssetCursorUnsupported)
+}
@@ -0,0 +1,117 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"server/internal/db/repo"
+)
+
+// ErrInvalidCustomFields is returned when custom fields fail validation.
+var ErrInvalidCustomFields = errors.New("invalid custom fields")
+
+const (
+	maxCustomFields           = 50
+	maxCustomFieldValueLength = 1024
+)
+
+// customFieldKeyPattern keeps keys usable as filter names and JSON paths:
+// lower snake case, starting with a letter, at most 64 characters.
+var customFieldKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,63}$`)
+
+// ValidateCustomFields checks a custom field object before it is stored or
+// used as a filter. Keys must be lower snake case ("model_release"); values
+// must be strings, numbers or booleans so every field can be listed and
+// matched as a single value.
+func ValidateCustomFields(fields map[string]any) error {
+	if len(fields) > maxCustomFields {
+		return fmt.Errorf("%w: at most %d fields are allowed", ErrInvalidCustomFields, maxCustomFields)
+	}
+	for key, value := range fields {
+		if !customFieldKeyPattern.MatchString(key) {
+			return fmt.Errorf("%w: key %q must be lower snake case, start with a letter and be at most 64 characters", ErrInvalidCustomFields, key)
+		}
+		switch v := value.(type) {
+		case string:
+			if len(v) > maxCustomFieldValueLength {
+				return fmt.Errorf("%w: value of %q is longer than %d bytes", ErrInvalidCustomFields, key, maxCustomFieldValueLength)
+			}
+		case float64, bool, json.Number:
+		default:
+			return fmt.Errorf("%w: value of %q must be a string, number or boolean", ErrInvalidCustomFields, key)
+		}
+	}
+	return nil
+}
+
+// GetAssetCustomFields returns the asset's custom fields, empty when none
+// were ever set.
+func (s *assetService) GetAssetCustomFields(ctx context.Context, id uuid.UUID) (map[string]any, error) {
+	raw, err := s.queries.GetAssetCustomFields(ctx, pgtype.UUID{Bytes: id, Valid: true})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return map[string]any{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get custom fields: %w", err)
+	}
+	return decodeCustomFields(raw)
+}
+
+// SetAssetCustomFields replaces the asset's custom fields with fields and
+// returns what was stored. An empty object clears them.
+func (s *assetService) SetAssetCustomFields(ctx context.Context, id uuid.UUID, fields map[string]any) (map[string]any, error) {
+	if err := ValidateCustomFields(fields); err != nil {
+		return nil, err
+	}
+	if fields == nil {
+		fields = map[string]any{}
+	}
+	encoded, err := json.Marshal(fields)
+	if err != nil {
+		return nil, fmt.Errorf("encode custom fields: %w", err)
+	}
+	raw, err := s.queries.UpsertAssetCustomFields(ctx, repo.UpsertAssetCustomFieldsParams{
+		AssetID: pgtype.UUID{Bytes: id, Valid: true},
+		Fields:  encoded,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("save custom fields: %w", err)
+	}
+	return decodeCustomFields(raw)
+}
+
+// GetCustomFieldValues lists the values in use for each custom field key,
+// sorted, for filter dropdowns.
+func (s *assetService) GetCustomFieldValues(ctx context.Context) (map[string][]string, error) {
+	rows, err := s.queries.ListCustomFieldValues(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list custom field values: %w", err)
+	}
+	values := make(map[string][]string)
+	for _, row := range rows {
+		values[row.FieldKey] = append(values[row.FieldKey], row.FieldValue)
+	}
+	for key := range values {
+		sort.Strings(values[key])
+	}
+	return values, nil
+}
+
+func decodeCustomFields(raw []byte) (map[string]any, error) {
+	fields := map[string]any{}
+	if len(raw) == 0 {
+		return fields, nil
+	}
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return nil, fmt.Errorf("decode custom fields: %w", err)
+	}
+	return fields, nil
+}
@@ -0,0 +1,45 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestValidateCustomFields(t *testing.T) {
+	valid := map[string]any{
+		"model_release": "signed",
+		"client":        "Acme",
+		"shoot_day":     float64(2),
+		"delivered":     true,
+		"v2":            "",
+	}
+	if err := ValidateCustomFields(valid); err != nil {
+		t.Fatalf("ValidateCustomFields(valid) = %v", err)
+	}
+	if err := ValidateCustomFields(nil); err != nil {
+		t.Fatalf("ValidateCustomFields(nil) = %v", err)
+	}
+
+	tooMany := map[string]any{}
+	for i := 0; i <= maxCustomFields; i++ {
+		tooMany[fmt.Sprintf("field_%d", i)] = "v"
+	}
+	for name, fields := range map[string]map[string]any{
+		"uppercase key":  {"Client": "Acme"},
+		"dash in key":    {"model-release": "signed"},
+		"leading digit":  {"1st": "yes"},
+		"empty key":      {"": "x"},
+		"key too long":   {"a" + strings.Repeat("b", 64): "x"},
+		"object value":   {"client": map[string]any{"name": "Acme"}},
+		"array value":    {"clients": []any{"Acme"}},
+		"null value":     {"client": nil},
+		"value too long": {"notes": strings.Repeat("x", maxCustomFieldValueLength+1)},
+		"too many":       tooMany,
+	} {
+		if err := ValidateCustomFields(fields); !errors.Is(err, ErrInvalidCustomFields) {
+			t.Errorf("%s: ValidateCustomFields = %v, want ErrInvalidCustomFields", name, err)
+		}
+	}
+}
@@ -35,6 +35,7 @@ type FolderSummary struct {
 type TagSummary struct {
 	TagID        int32
 	TagName      string
+	Category     *string
 	Source       string
 	AssetCount   int64
 	CoverAssetID *string
@@ -177,7 +178,7 @@ func (s *assetService) GetFolderSummary(ctx context.Context, ownerID *int32, rep
 // ListTagSummaries lists the tag vocabulary (manual and AI/system) visible to
 // the owner, with usage counts and covers, optionally filtered by source or
 // name substring.
-func (s *assetService) ListTagSummaries(ctx context.Context, ownerID *int32, repositoryID *string, source *string, query *string, limit, offset int) ([]TagSummary, error) {
+func (s *assetService) ListTagSummaries(ctx context.Context, ownerID *int32, repositoryID *string, source, category, query *string, limit, offset int) ([]TagSummary, error) {
 	var repoUUID pgtype.UUID
 	if repositoryID != nil && strings.TrimSpace(*repositoryID) != "" {
 		parsed, err := uuid.Parse(strings.TrimSpace(*repositoryID))
@@ -191,6 +192,7 @@ func (s *assetService) ListTagSummaries(ctx context.Context, ownerID *int32, rep
 		OwnerID:      ownerID,
 		RepositoryID: repoUUID,
 		Source:       source,
+		Category:     category,
 		Query:        query,
 		Offset:       int32(offset),
 		Limit:        int32(limit),
@@ -204,6 +206,7 @@ func (s *assetService) ListTagSummaries(ctx context.Context, ownerID *int32, rep
 		summaries = append(summaries, TagSummary{
 			TagID:        row.TagID,
 			TagName:      row.TagName,
+			Category:     row.Category,
 			Source:       row.Source,
 			AssetCount:   row.AssetCount,
 			CoverAssetID: optionalStringFromPgUUID(row.CoverAssetID),
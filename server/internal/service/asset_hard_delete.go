@@ -0,0 +1,168 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"server/internal/db/repo"
+	"server/internal/storage"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// hardDeleteTrashReason is the DeleteMetadata.Reason for files an asset hard
+// delete moved into the repository trash.
+const hardDeleteTrashReason = "asset_hard_delete"
+
+// HardDeleteAsset marks an asset deleted, moves its original and derived files
+// (thumbnails and web video/audio versions) into the repository trash, then
+// removes the asset and its thumbnail rows. Should removing the rows fail, the
+// asset is left soft-deleted with its files in the trash, where RestoreAsset
+// can bring it back. Files that are already gone are skipped, so a call that
+// failed part way through can simply be repeated. A thumbnail file shared with
+// another asset stays where it is.
+func (s *assetService) HardDeleteAsset(ctx context.Context, id uuid.UUID, repoPath string, deletedBy *string) error {
+	pgUUID := pgtype.UUID{Bytes: id, Valid: true}
+	asset, err := s.queries.GetAssetByIDAny(ctx, pgUUID)
+	if err != nil {
+		return fmt.Errorf("get asset: %w", err)
+	}
+	thumbnails, err := s.queries.GetThumbnailsByAsset(ctx, pgUUID)
+	if err != nil {
+		return fmt.Errorf("get thumbnails: %w", err)
+	}
+	if asset.IsDeleted == nil || !*asset.IsDeleted {
+		if err := s.queries.DeleteAsset(ctx, pgUUID); err != nil {
+			return fmt.Errorf("mark asset deleted: %w", err)
+		}
+	}
+
+	if err := s.trashAssetFiles(ctx, &asset, thumbnails, repoPath, deletedBy); err != nil {
+		return err
+	}
+
+	return s.withTx(ctx, func(q *repo.Queries) error {
+		if _, err := q.RefreshAlbumCoversForAsset(ctx, repo.RefreshAlbumCoversForAssetParams{
+			Reassign: s.reassignAlbumCovers,
+			AssetID:  pgUUID,
+		}); err != nil {
+			return fmt.Errorf("failed to refresh album covers: %w", err)
+		}
+		if err := q.RemoveAssetFromAllAlbums(ctx, pgUUID); err != nil {
+			return fmt.Errorf("failed to remove album links: %w", err)
+		}
+		if err := q.DeleteAssetTagsByAsset(ctx, pgUUID); err != nil {
+			return fmt.Errorf("failed to remove tag links: %w", err)
+		}
+		if err := q.DeleteThumbnailsByAsset(ctx, pgUUID); err != nil {
+			return fmt.Errorf("failed to delete thumbnail records: %w", err)
+		}
+		if _, err := q.PurgeAsset(ctx, pgUUID); err != nil {
+			return fmt.Errorf("failed to delete asset record: %w", err)
+		}
+		return RecordActivity(ctx, q, ActivityEvent{
+			Type:    ActivityAssetDeleted,
+			AssetID: pgUUID,
+			OwnerID: asset.OwnerID,
+			Payload: map[string]any{"permanent": true},
+		})
+	})
+}
+
+// trashAssetFiles moves the files owned by asset into the trash. Its thumbnail
+// files stay locked from counting their references until they are moved, so
+// no other asset starts sharing one that is about to go.
+func (s *assetService) trashAssetFiles(ctx context.Context, asset *repo.Asset, thumbnails []repo.Thumbnail, repoPath string, deletedBy *string) error {
+	paths := make([]string, 0, len(thumbnails))
+	for _, thumbnail := range thumbnails {
+		paths = append(paths, filepath.Join(repoPath, thumbnail.StoragePath))
+	}
+	// Lock in a fixed order so two hard deletes sharing files cannot deadlock.
+	slices.Sort(paths)
+	paths = slices.Compact(paths)
+	for _, path := range paths {
+		unlock := s.thumbnailFiles.Lock(path)
+		defer unlock()
+	}
+
+	files, err := s.hardDeleteFiles(ctx, asset, thumbnails)
+	if err != nil {
+		return err
+	}
+	assetID := uuid.UUID(asset.AssetID.Bytes).String()
+	dm := storage.NewDirectoryManager()
+	for _, file := range files {
+		metadata := &storage.DeleteMetadata{
+			Reason:  hardDeleteTrashReason,
+			AssetID: &assetID,
+			UserID:  deletedBy,
+			Extra:   map[string]interface{}{"kind": file.kind},
+		}
+		if err := dm.MoveToTrash(repoPath, file.path, metadata); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("move %s to trash: %w", file.kind, err)
+		}
+	}
+	return nil
+}
+
+// hardDeleteFile is a repository-relative file to move into the trash.
+type hardDeleteFile struct {
+	path string
+	kind string
+}
+
+// hardDeleteFiles lists the files owned by an asset. A deduplicated thumbnail
+// file is only included when no other asset's thumbnail points at it.
+func (s *assetService) hardDeleteFiles(ctx context.Context, asset *repo.Asset, thumbnails []repo.Thumbnail) ([]hardDeleteFile, error) {
+	var files []hardDeleteFile
+	if asset.StoragePath != nil && *asset.StoragePath != "" {
+		files = append(files, hardDeleteFile{path: *asset.StoragePath, kind: "original"})
+	}
+
+	own := make(map[string]int64, len(thumbnails))
+	for _, thumbnail := range thumbnails {
+		own[thumbnail.StoragePath]++
+	}
+	for _, thumbnail := range thumbnails {
+		count, ok := own[thumbnail.StoragePath]
+		if !ok {
+			continue // already listed under another size
+		}
+		delete(own, thumbnail.StoragePath)
+		references, err := s.queries.CountThumbnailReferences(ctx, thumbnail.StoragePath)
+		if err != nil {
+			return nil, fmt.Errorf("count references to thumbnail %s: %w", thumbnail.StoragePath, err)
+		}
+		if references > count {
+			continue
+		}
+		files = append(files, hardDeleteFile{path: thumbnail.StoragePath, kind: "thumbnail:" + thumbnail.Size})
+	}
+
+	if asset.Type == AssetTypeVideo {
+		files = append(files, hardDeleteFile{
+			path: filepath.Join(storage.DefaultStructure.VideosDir, "hls", asset.AssetID.String()),
+			kind: "hls",
+		})
+	}
+	if asset.ContentHash != "" {
+		switch asset.Type {
+		case AssetTypeVideo:
+			files = append(files, hardDeleteFile{
+				path: filepath.Join(storage.DefaultStructure.VideosDir, "web", asset.ContentHash+"_web.mp4"),
+				kind: "web_video",
+			})
+		case AssetTypeAudio:
+			files = append(files, hardDeleteFile{
+				path: filepath.Join(storage.DefaultStructure.AudiosDir, "web", asset.ContentHash+"_web.mp3"),
+				kind: "web_audio",
+			})
+		}
+	}
+	return files, nil
+}
@@ -0,0 +1,125 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"server/internal/db/dbtest"
+	"server/internal/db/repo"
+	"server/internal/storage"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHardDeleteAssetPostgresIntegration(t *testing.T) {
+	ctx := context.Background()
+	pool := dbtest.Pool(t, os.Getenv(dbtest.DatabaseURLEnv))
+
+	suffix := strings.ReplaceAll(uuid.NewString(), "-", "")[:12]
+	prefix := "harddelete_" + suffix
+	t.Cleanup(func() {
+		_, _ = pool.Exec(ctx, `DELETE FROM asset_tags WHERE asset_id IN (SELECT asset_id FROM assets WHERE original_filename LIKE $1)`, prefix+"%")
+		_, _ = pool.Exec(ctx, `DELETE FROM thumbnails WHERE asset_id IN (SELECT asset_id FROM assets WHERE original_filename LIKE $1)`, prefix+"%")
+		_, _ = pool.Exec(ctx, `DELETE FROM assets WHERE original_filename LIKE $1`, prefix+"%")
+		_, _ = pool.Exec(ctx, `DELETE FROM tags WHERE tag_name = $1`, prefix)
+	})
+
+	repoPath := t.TempDir()
+	storagePath := filepath.Join("inbox", prefix+".mp4")
+	require.NoError(t, os.MkdirAll(filepath.Join(repoPath, "inbox"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(repoPath, storagePath), []byte("original"), 0o644))
+	webPath := filepath.Join(storage.DefaultStructure.VideosDir, "web", suffix+"_web.mp4")
+	require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(repoPath, webPath)), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(repoPath, webPath), []byte("web"), 0o644))
+
+	var assetID uuid.UUID
+	require.NoError(t, pool.QueryRow(ctx, `
+		INSERT INTO assets (type, original_filename, storage_path, mime_type, file_size, content_hash)
+		VALUES ('VIDEO', $1, $2, 'video/mp4', 1024, $3)
+		RETURNING asset_id`, prefix+".mp4", storagePath, suffix).Scan(&assetID))
+	asset, err := repo.New(pool).GetAssetByID(ctx, pgtype.UUID{Bytes: assetID, Valid: true})
+	require.NoError(t, err)
+
+	svc, err := NewAssetService(repo.New(pool), pool, nil, nil, true, false, SearchFusionWeights{})
+	require.NoError(t, err)
+	require.NoError(t, svc.SaveNewThumbnail(ctx, repoPath, bytes.NewReader([]byte("small thumbnail")), &asset, "small"))
+	require.NoError(t, svc.SaveNewThumbnail(ctx, repoPath, bytes.NewReader([]byte("large thumbnail")), &asset, "large"))
+	_, err = svc.AddManualTagToAsset(ctx, assetID, prefix, "")
+	require.NoError(t, err)
+
+	// A derived file that already went missing must not block the delete.
+	large, err := svc.GetThumbnailByAssetIDAndSize(ctx, assetID, "large")
+	require.NoError(t, err)
+	require.NoError(t, os.Remove(filepath.Join(repoPath, large.StoragePath)))
+
+	// Hard delete is also how Trash is emptied, so start from a soft-deleted asset.
+	require.NoError(t, svc.DeleteAsset(ctx, assetID))
+	userID := "7"
+	require.NoError(t, svc.HardDeleteAsset(ctx, assetID, repoPath, &userID))
+
+	_, err = repo.New(pool).GetAssetByIDAny(ctx, pgtype.UUID{Bytes: assetID, Valid: true})
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+	thumbnails, err := repo.New(pool).GetThumbnailsByAsset(ctx, pgtype.UUID{Bytes: assetID, Valid: true})
+	require.NoError(t, err)
+	require.Empty(t, thumbnails)
+	require.NoFileExists(t, filepath.Join(repoPath, storagePath))
+	require.NoFileExists(t, filepath.Join(repoPath, webPath))
+
+	trashed, err := storage.NewDirectoryManager().ListTrashFiles(repoPath)
+	require.NoError(t, err)
+	kinds := map[string]bool{}
+	for _, file := range trashed {
+		require.NotNil(t, file.Metadata)
+		require.Equal(t, hardDeleteTrashReason, file.Metadata.Reason)
+		require.Equal(t, assetID.String(), *file.Metadata.AssetID)
+		require.Equal(t, userID, *file.Metadata.UserID)
+		kinds[file.Metadata.Extra["kind"].(string)] = true
+	}
+	require.Equal(t, map[string]bool{"original": true, "thumbnail:small": true, "web_video": true}, kinds)
+}
+
+func TestHardDeleteAssetLeavesRestorableAssetWhenPurgeFails(t *testing.T) {
+	ctx := context.Background()
+	pool := dbtest.Pool(t, os.Getenv(dbtest.DatabaseURLEnv))
+
+	suffix := strings.ReplaceAll(uuid.NewString(), "-", "")[:12]
+	prefix := "harddeletefail_" + suffix
+	t.Cleanup(func() {
+		_, _ = pool.Exec(ctx, `DELETE FROM assets WHERE original_filename LIKE $1`, prefix+"%")
+	})
+
+	repoPath := t.TempDir()
+	storagePath := filepath.Join("inbox", prefix+".jpg")
+	require.NoError(t, os.MkdirAll(filepath.Join(repoPath, "inbox"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(repoPath, storagePath), []byte("original"), 0o644))
+
+	var assetID uuid.UUID
+	require.NoError(t, pool.QueryRow(ctx, `
+		INSERT INTO assets (type, original_filename, storage_path, mime_type, file_size, content_hash)
+		VALUES ('PHOTO', $1, $2, 'image/jpeg', 8, $3)
+		RETURNING asset_id`, prefix+".jpg", storagePath, suffix).Scan(&assetID))
+
+	// The purge transaction cannot begin on a closed pool; everything before
+	// it goes through the live one.
+	closed := dbtest.Pool(t, os.Getenv(dbtest.DatabaseURLEnv))
+	closed.Close()
+	failing := &assetService{queries: repo.New(pool), pool: closed}
+	require.Error(t, failing.HardDeleteAsset(ctx, assetID, repoPath, nil))
+
+	asset, err := repo.New(pool).GetAssetByIDAny(ctx, pgtype.UUID{Bytes: assetID, Valid: true})
+	require.NoError(t, err)
+	require.NotNil(t, asset.IsDeleted)
+	require.True(t, *asset.IsDeleted)
+	require.NoFileExists(t, filepath.Join(repoPath, storagePath))
+
+	svc := &assetService{queries: repo.New(pool), pool: pool}
+	require.NoError(t, svc.RestoreAsset(ctx, assetID, repoPath))
+	require.FileExists(t, filepath.Join(repoPath, storagePath))
+}
@@ -0,0 +1,126 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"server/internal/db/dbtypes"
+)
+
+// ErrInvalidMetadata is returned when specific metadata does not fit the
+// schema of the asset's type.
+var ErrInvalidMetadata = errors.New("invalid metadata")
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// metadataFieldTypes maps each asset type to the JSON fields its specific
+// metadata struct declares.
+var metadataFieldTypes = map[dbtypes.AssetType]map[string]reflect.Type{
+	dbtypes.AssetTypePhoto: jsonFieldTypes(reflect.TypeOf(dbtypes.PhotoSpecificMetadata{})),
+	dbtypes.AssetTypeVideo: jsonFieldTypes(reflect.TypeOf(dbtypes.VideoSpecificMetadata{})),
+	dbtypes.AssetTypeAudio: jsonFieldTypes(reflect.TypeOf(dbtypes.AudioSpecificMetadata{})),
+}
+
+func jsonFieldTypes(t reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			fields[name] = t.Field(i).Type
+		}
+	}
+	return fields
+}
+
+// ValidateSpecificMetadata checks metadata sent for an asset of assetType.
+// It must be a JSON object, and every field the type's metadata struct
+// declares ("taken_time", "f_number", ...) must decode into it; GPS
+// coordinates must also lie in range. Fields the struct does not declare are
+// custom additions and are kept as sent. The error wraps ErrInvalidMetadata
+// and lists each offending field.
+func ValidateSpecificMetadata(assetType dbtypes.AssetType, metadata dbtypes.SpecificMetadata) error {
+	known, ok := metadataFieldTypes[assetType]
+	if !ok {
+		return fmt.Errorf("%w: unsupported asset type %q", ErrInvalidMetadata, assetType)
+	}
+
+	var fields map[string]json.RawMessage
+	trimmed := bytes.TrimSpace(metadata)
+	if len(trimmed) == 0 || trimmed[0] != '{' || json.Unmarshal(trimmed, &fields) != nil {
+		return fmt.Errorf("%w: specific_metadata must be a JSON object", ErrInvalidMetadata)
+	}
+
+	var problems []string
+	for name, raw := range fields {
+		fieldType, ok := known[name]
+		if !ok {
+			continue
+		}
+		value := reflect.New(fieldType)
+		if err := json.Unmarshal(raw, value.Interface()); err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %s", name, describeMetadataFieldType(fieldType)))
+			continue
+		}
+		if name == "gps_latitude" || name == "gps_longitude" {
+			if problem := checkCoordinate(name, value.Elem()); problem != "" {
+				problems = append(problems, problem)
+			}
+		}
+	}
+	if len(problems) == 0 {
+		return nil
+	}
+	sort.Strings(problems)
+	return fmt.Errorf("%w: %s", ErrInvalidMetadata, strings.Join(problems, "; "))
+}
+
+// describeMetadataFieldType names what a field of type t accepts.
+func describeMetadataFieldType(t reflect.Type) string {
+	nullable := t.Kind() == reflect.Pointer
+	if nullable {
+		t = t.Elem()
+	}
+	var expected string
+	switch {
+	case t == timeType:
+		expected = "an RFC 3339 timestamp"
+	case t.Kind() == reflect.String:
+		expected = "a string"
+	case t.Kind() == reflect.Bool:
+		expected = "a boolean"
+	case t.Kind() >= reflect.Int && t.Kind() <= reflect.Uint64:
+		expected = "an integer"
+	case t.Kind() == reflect.Float32 || t.Kind() == reflect.Float64:
+		expected = "a number"
+	case t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Int:
+		expected = "an array of integers"
+	default:
+		expected = "a " + t.String()
+	}
+	if nullable {
+		expected += " or null"
+	}
+	return "must be " + expected
+}
+
+func checkCoordinate(name string, value reflect.Value) string {
+	if value.IsNil() {
+		return ""
+	}
+	limit := 90.0
+	if name == "gps_longitude" {
+		limit = 180
+	}
+	coordinate := value.Elem().Float()
+	if math.Abs(coordinate) > limit {
+		return fmt.Sprintf("%s: must be between %g and %g", name, -limit, limit)
+	}
+	return ""
+}
@@ -0,0 +1,76 @@
+package service
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"server/internal/db/dbtypes"
+)
+
+func TestValidateSpecificMetadata_AcceptsConformingAndCustomFields(t *testing.T) {
+	for name, tc := range map[string]struct {
+		assetType dbtypes.AssetType
+		metadata  string
+	}{
+		"photo":          {dbtypes.AssetTypePhoto, `{"taken_time":"2024-06-15T10:30:00+02:00","f_number":2.8,"iso_speed":200,"gps_latitude":48.85,"gps_longitude":2.35}`},
+		"photo nulls":    {dbtypes.AssetTypePhoto, `{"taken_time":null,"gps_latitude":null}`},
+		"photo custom":   {dbtypes.AssetTypePhoto, `{"camera_model":"X100V","film_simulation":"Classic Chrome","roll":{"number":3}}`},
+		"empty object":   {dbtypes.AssetTypePhoto, `{}`},
+		"video":          {dbtypes.AssetTypeVideo, `{"recorded_time":"2023-01-01T00:00:00Z","frame_rate":29.97}`},
+		"audio":          {dbtypes.AssetTypeAudio, `{"artist":"John Doe","year":2023,"waveform_peaks":[10,80]}`},
+		"leading spaces": {dbtypes.AssetTypeAudio, ` {"title":"Song"}`},
+	} {
+		if err := ValidateSpecificMetadata(tc.assetType, dbtypes.SpecificMetadata(tc.metadata)); err != nil {
+			t.Errorf("%s: ValidateSpecificMetadata() = %v", name, err)
+		}
+	}
+}
+
+func TestValidateSpecificMetadata_RejectsMalformedPhotoMetadata(t *testing.T) {
+	for name, tc := range map[string]struct {
+		metadata string
+		want     []string
+	}{
+		"not an object":       {`"taken yesterday"`, []string{"must be a JSON object"}},
+		"array":               {`[{"taken_time":"2024-06-15T10:30:00Z"}]`, []string{"must be a JSON object"}},
+		"null":                {`null`, []string{"must be a JSON object"}},
+		"empty":               {``, []string{"must be a JSON object"}},
+		"taken_time not time": {`{"taken_time":"yesterday"}`, []string{"taken_time: must be an RFC 3339 timestamp or null"}},
+		"f_number as text":    {`{"f_number":"wide"}`, []string{"f_number: must be a number"}},
+		"iso as float":        {`{"iso_speed":100.5}`, []string{"iso_speed: must be an integer"}},
+		"is_raw as string":    {`{"is_raw":"yes"}`, []string{"is_raw: must be a boolean"}},
+		"camera as number":    {`{"camera_model":5}`, []string{"camera_model: must be a string"}},
+		"latitude range":      {`{"gps_latitude":91,"gps_longitude":2.35}`, []string{"gps_latitude: must be between -90 and 90"}},
+		"longitude range":     {`{"gps_latitude":48.85,"gps_longitude":-180.5}`, []string{"gps_longitude: must be between -180 and 180"}},
+		"every problem": {
+			`{"taken_time":1718440200,"f_number":"wide","custom":"kept"}`,
+			[]string{"f_number: must be a number", "taken_time: must be an RFC 3339 timestamp or null"},
+		},
+	} {
+		err := ValidateSpecificMetadata(dbtypes.AssetTypePhoto, dbtypes.SpecificMetadata(tc.metadata))
+		if !errors.Is(err, ErrInvalidMetadata) {
+			t.Errorf("%s: ValidateSpecificMetadata() = %v, want ErrInvalidMetadata", name, err)
+			continue
+		}
+		for _, want := range tc.want {
+			if !strings.Contains(err.Error(), want) {
+				t.Errorf("%s: error %q does not mention %q", name, err, want)
+			}
+		}
+	}
+}
+
+func TestValidateSpecificMetadata_UsesTheAssetTypeSchema(t *testing.T) {
+	// recorded_time is only declared for videos; on a photo it is a custom field.
+	if err := ValidateSpecificMetadata(dbtypes.AssetTypePhoto, dbtypes.SpecificMetadata(`{"recorded_time":"soon"}`)); err != nil {
+		t.Fatalf("photo with custom recorded_time: %v", err)
+	}
+	err := ValidateSpecificMetadata(dbtypes.AssetTypeVideo, dbtypes.SpecificMetadata(`{"recorded_time":"soon"}`))
+	if !errors.Is(err, ErrInvalidMetadata) || !strings.Contains(err.Error(), "recorded_time") {
+		t.Fatalf("video with bad recorded_time: %v", err)
+	}
+	if err := ValidateSpecificMetadata(dbtypes.AssetType("DOCUMENT"), dbtypes.SpecificMetadata(`{}`)); !errors.Is(err, ErrInvalidMetadata) {
+		t.Fatalf("unknown asset type: %v", err)
+	}
+}
@@ -0,0 +1,48 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"server/internal/db/repo"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// MoveRenamedAsset points an asset at the path its file was renamed to. An
+// asset still recorded at that path lost its file to the rename and is put in
+// the Trash without a path; when two files swap names, the move of that
+// asset brings it back. Both updates share a transaction so the path stays
+// unique throughout.
+func (s *assetService) MoveRenamedAsset(ctx context.Context, repositoryID, id uuid.UUID, storagePath string) (*repo.Asset, error) {
+	repoID := pgtype.UUID{Bytes: repositoryID, Valid: true}
+	assetID := pgtype.UUID{Bytes: id, Valid: true}
+
+	var moved repo.Asset
+	err := s.withTx(ctx, func(q *repo.Queries) error {
+		if _, err := q.DetachAssetStoragePath(ctx, repo.DetachAssetStoragePathParams{
+			RepositoryID: repoID,
+			StoragePath:  &storagePath,
+			KeepAssetID:  assetID,
+		}); err != nil {
+			return fmt.Errorf("free %s: %w", storagePath, err)
+		}
+		asset, err := q.MoveAssetWithinRepository(ctx, repo.MoveAssetWithinRepositoryParams{
+			AssetID:          assetID,
+			RepositoryID:     repoID,
+			StoragePath:      &storagePath,
+			OriginalFilename: filepath.Base(storagePath),
+		})
+		if err != nil {
+			return fmt.Errorf("move asset to %s: %w", storagePath, err)
+		}
+		moved = asset
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &moved, nil
+}
@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"server/internal/db/dbtest"
+	"server/internal/db/dbtypes"
+	statusdb "server/internal/db/dbtypes/status"
+	"server/internal/db/repo"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMoveRenamedAssetSwapPostgresIntegration(t *testing.T) {
+	ctx := context.Background()
+	pool := dbtest.Pool(t, os.Getenv(dbtest.DatabaseURLEnv))
+
+	suffix := strings.ReplaceAll(uuid.NewString(), "-", "")[:12]
+	var repositoryID pgtype.UUID
+	require.NoError(t, pool.QueryRow(ctx, `
+		INSERT INTO repositories (name, path)
+		VALUES ($1, $2)
+		RETURNING repo_id`, "move_"+suffix, "/tmp/move_"+suffix).Scan(&repositoryID))
+	t.Cleanup(func() {
+		_, _ = pool.Exec(ctx, `DELETE FROM assets WHERE repository_id = $1`, repositoryID)
+		_, _ = pool.Exec(ctx, `DELETE FROM repositories WHERE repo_id = $1`, repositoryID)
+	})
+
+	queries := repo.New(pool)
+	svc := &assetService{queries: queries, pool: pool}
+	assetStatus, err := statusdb.NewCompleteStatus().ToJSONB()
+	require.NoError(t, err)
+	create := func(storagePath string) uuid.UUID {
+		asset, err := svc.CreateAssetRecord(ctx, repo.CreateAssetParams{
+			Type:             string(dbtypes.AssetTypePhoto),
+			OriginalFilename: storagePath,
+			StoragePath:      &storagePath,
+			RepositoryID:     repositoryID,
+			MimeType:         "image/jpeg",
+			FileSize:         1024,
+			ContentHash:      storagePath + suffix,
+			SpecificMetadata: dbtypes.SpecificMetadata(`{}`),
+			Status:           assetStatus,
+		})
+		require.NoError(t, err)
+		return uuid.UUID(asset.AssetID.Bytes)
+	}
+	x, y := create("x.jpg"), create("y.jpg")
+	repoUUID := uuid.UUID(repositoryID.Bytes)
+
+	// x.jpg and y.jpg swapped names: the first move parks x's asset, the
+	// second brings it back at x's old path.
+	movedY, err := svc.MoveRenamedAsset(ctx, repoUUID, y, "x.jpg")
+	require.NoError(t, err)
+	require.Equal(t, "x.jpg", *movedY.StoragePath)
+	parked, err := queries.GetAssetByIDAny(ctx, pgtype.UUID{Bytes: x, Valid: true})
+	require.NoError(t, err)
+	require.Nil(t, parked.StoragePath)
+	require.True(t, *parked.IsDeleted)
+
+	movedX, err := svc.MoveRenamedAsset(ctx, repoUUID, x, "y.jpg")
+	require.NoError(t, err)
+	require.Equal(t, "y.jpg", *movedX.StoragePath)
+	require.False(t, *movedX.IsDeleted)
+
+	for id, want := range map[uuid.UUID]string{x: "y.jpg", y: "x.jpg"} {
+		asset, err := queries.GetAssetByID(ctx, pgtype.UUID{Bytes: id, Valid: true})
+		require.NoError(t, err)
+		require.Equal(t, want, *asset.StoragePath)
+		require.Equal(t, want, asset.OriginalFilename)
+		require.False(t, *asset.IsDeleted)
+	}
+}
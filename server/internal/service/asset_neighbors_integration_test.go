@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"server/internal/db/dbtest"
+	"server/internal/db/repo"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetAssetNeighborsPostgresIntegration(t *testing.T) {
+	ctx := context.Background()
+	pool := dbtest.Pool(t, os.Getenv(dbtest.DatabaseURLEnv))
+
+	suffix := strings.ReplaceAll(uuid.NewString(), "-", "")[:12]
+	prefix := "neighbors_" + suffix
+	insert := func(name string, takenTime *time.Time) uuid.UUID {
+		var assetID uuid.UUID
+		require.NoError(t, pool.QueryRow(ctx, `
+			INSERT INTO assets (type, original_filename, mime_type, file_size, content_hash, taken_time)
+			VALUES ('PHOTO', $1, 'image/jpeg', 1024, $2, $3)
+			RETURNING asset_id`, prefix+"_"+name+".jpg", suffix+name, takenTime).Scan(&assetID))
+		return assetID
+	}
+	at := func(value string) *time.Time {
+		parsed, err := time.Parse(time.DateOnly, value)
+		require.NoError(t, err)
+		return &parsed
+	}
+
+	january := insert("january", at("2024-01-15"))
+	february := insert("february", at("2024-02-15"))
+	march := insert("march", at("2024-03-15"))
+	// No capture time: falls back to upload_time (now), outside the date filter.
+	undated := insert("undated", nil)
+	t.Cleanup(func() {
+		_, _ = pool.Exec(ctx, `DELETE FROM assets WHERE original_filename LIKE $1`, prefix+"%")
+	})
+
+	svc := &assetService{queries: repo.New(pool)}
+	params := QueryAssetsParams{
+		Query:    prefix,
+		SortBy:   "date_captured",
+		DateFrom: at("2024-01-01"),
+		DateTo:   at("2024-12-31"),
+	}
+
+	middle, err := svc.GetAssetNeighbors(ctx, february, params)
+	require.NoError(t, err)
+	require.Equal(t, &march, middle.PreviousID, "newer asset comes before in date_captured order")
+	require.Equal(t, &january, middle.NextID)
+
+	newest, err := svc.GetAssetNeighbors(ctx, march, params)
+	require.NoError(t, err)
+	require.Nil(t, newest.PreviousID, "undated asset is excluded by the date filter")
+	require.Equal(t, &february, newest.NextID)
+
+	oldest, err := svc.GetAssetNeighbors(ctx, january, params)
+	require.NoError(t, err)
+	require.Equal(t, &february, oldest.PreviousID)
+	require.Nil(t, oldest.NextID)
+
+	params.DateFrom, params.DateTo = nil, nil
+	unfiltered, err := svc.GetAssetNeighbors(ctx, march, params)
+	require.NoError(t, err)
+	require.Equal(t, &undated, unfiltered.PreviousID)
+
+	_, err = svc.GetAssetNeighbors(ctx, uuid.New(), params)
+	require.True(t, errors.Is(err, ErrAssetNotFound))
+}
+
+func TestGetAssetNeighborsRejectsSemanticSearch(t *testing.T) {
+	svc := &assetService{}
+	_, err := svc.GetAssetNeighbors(context.Background(), uuid.New(), QueryAssetsParams{
+		Query:      "sunset",
+		SearchType: "semantic",
+	})
+	require.ErrorIs(t, err, ErrNeighborsUnsupported)
+}
@@ -0,0 +1,121 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"server/internal/db/repo"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// ErrNeighborsUnsupported is returned for result sets without a stable sort
+// key, where adjacency cannot be resolved by keyset.
+var ErrNeighborsUnsupported = errors.New("asset neighbors are not available for semantic search")
+
+// AssetNeighbors holds the assets adjacent to an anchor in a query's sort
+// order. Previous sorts before the anchor (newer), Next after it (older); nil
+// marks either end of the result set.
+type AssetNeighbors struct {
+	PreviousID *uuid.UUID
+	NextID     *uuid.UUID
+}
+
+// GetAssetNeighbors resolves the previous and next asset around assetID under
+// the same filters and sort as queryAssetsUnified. Stacks are not collapsed,
+// so a swipe steps through every member. Limit and offset are ignored.
+func (s *assetService) GetAssetNeighbors(ctx context.Context, assetID uuid.UUID, params QueryAssetsParams) (AssetNeighbors, error) {
+	if params.SearchType == "semantic" && params.Query != "" {
+		return AssetNeighbors{}, ErrNeighborsUnsupported
+	}
+
+	var repoUUID pgtype.UUID
+	if params.RepositoryID != nil && *params.RepositoryID != "" {
+		parsedUUID, err := uuid.Parse(*params.RepositoryID)
+		if err != nil {
+			return AssetNeighbors{}, fmt.Errorf("invalid repository ID: %w", err)
+		}
+		repoUUID = pgtype.UUID{Bytes: parsedUUID, Valid: true}
+	}
+
+	var ratingPtr *int32
+	if params.Rating != nil {
+		r := int32(*params.Rating)
+		ratingPtr = &r
+	}
+
+	var fromTime, toTime pgtype.Timestamptz
+	if params.DateFrom != nil {
+		fromTime = pgtype.Timestamptz{Time: *params.DateFrom, Valid: true}
+	}
+	if params.DateTo != nil {
+		toTime = pgtype.Timestamptz{Time: *params.DateTo, Valid: true}
+	}
+
+	var queryPtr *string
+	if params.Query != "" {
+		queryPtr = &params.Query
+	}
+
+	var sortByPtr *string
+	if params.SortBy == "recently_added" {
+		s := "recently_added"
+		sortByPtr = &s
+	}
+
+	row, err := s.queries.GetAssetNeighborsUnified(ctx, repo.GetAssetNeighborsUnifiedParams{
+		SortBy:           sortByPtr,
+		AnchorID:         pgtype.UUID{Bytes: assetID, Valid: true},
+		AssetIds:         assetSetSourcePgUUIDs(params.Source),
+		AssetType:        params.AssetType,
+		AssetTypes:       params.AssetTypes,
+		RepositoryID:     repoUUID,
+		PersonID:         params.PersonID,
+		OwnerID:          params.OwnerID,
+		AlbumID:          params.AlbumID,
+		Query:            queryPtr,
+		FilenameVal:      params.FilenameValue,
+		FilenameOperator: params.FilenameOperator,
+		IsRaw:            params.IsRaw,
+		Rating:           ratingPtr,
+		Liked:            params.Liked,
+		CameraModel:      params.CameraModel,
+		LensModel:        params.LensModel,
+		CustomFields:     params.CustomFields,
+		ProcessingStatus: params.ProcessingStatus,
+		TagName:          params.TagName,
+		TagSource:        params.TagSource,
+		TagNames:         params.TagNames,
+		FolderPath:       params.FolderPath,
+		FolderRecursive:  params.FolderRecursive,
+		LocationNorth:    params.LocationNorth,
+		LocationSouth:    params.LocationSouth,
+		LocationEast:     params.LocationEast,
+		LocationWest:     params.LocationWest,
+		DateFrom:         fromTime,
+		DateTo:           toTime,
+		IsDeleted:        params.IsDeleted,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return AssetNeighbors{}, ErrAssetNotFound
+	}
+	if err != nil {
+		return AssetNeighbors{}, fmt.Errorf("failed to get asset neighbors: %w", err)
+	}
+
+	return AssetNeighbors{
+		PreviousID: neighborID(row.PreviousID),
+		NextID:     neighborID(row.NextID),
+	}, nil
+}
+
+func neighborID(id pgtype.UUID) *uuid.UUID {
+	if !id.Valid {
+		return nil
+	}
+	value := uuid.UUID(id.Bytes)
+	return &value
+}
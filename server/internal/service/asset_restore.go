@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"server/internal/storage"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var (
+	// ErrAssetNotDeleted reports a restore of an asset that is not in Trash.
+	ErrAssetNotDeleted = errors.New("asset is not deleted")
+	// ErrOriginalUnrecoverable reports that a deleted asset's original is
+	// neither at its storage path nor in the repository trash.
+	ErrOriginalUnrecoverable = errors.New("original file can no longer be recovered")
+)
+
+// RestoreAsset takes an asset out of the app Trash. A soft delete leaves the
+// files alone, but a hard delete that failed after moving them keeps the row;
+// with repoPath set, those files are brought back from the repository trash
+// before the row is restored. An original that cannot be found either way
+// fails with ErrOriginalUnrecoverable and leaves the asset deleted.
+func (s *assetService) RestoreAsset(ctx context.Context, id uuid.UUID, repoPath string) error {
+	pgUUID := pgtype.UUID{Bytes: id, Valid: true}
+	asset, err := s.queries.GetAssetByIDAny(ctx, pgUUID)
+	if err != nil {
+		return fmt.Errorf("get asset: %w", err)
+	}
+	if asset.IsDeleted == nil || !*asset.IsDeleted {
+		return ErrAssetNotDeleted
+	}
+
+	if repoPath != "" && asset.StoragePath != nil && *asset.StoragePath != "" {
+		if err := recoverAssetFromTrash(repoPath, id.String(), *asset.StoragePath); err != nil {
+			return err
+		}
+	}
+
+	restored, err := s.queries.RestoreAsset(ctx, pgUUID)
+	if err != nil {
+		return err
+	}
+	if restored == 0 {
+		return ErrAssetNotDeleted
+	}
+	return nil
+}
+
+// recoverAssetFromTrash moves an asset's hard-deleted files back into place
+// when its original is missing. Derived files are recovered on a best-effort
+// basis; they can be regenerated.
+func recoverAssetFromTrash(repoPath, assetID, storagePath string) error {
+	originalPath, err := resolveRepositoryFile(repoPath, storagePath)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(originalPath); err == nil {
+		return nil
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("check original: %w", err)
+	}
+
+	dm := storage.NewDirectoryManager()
+	trashed, err := dm.ListTrashFiles(repoPath)
+	if err != nil {
+		return fmt.Errorf("list repository trash: %w", err)
+	}
+	var original string
+	var derived []string
+	for _, file := range trashed {
+		metadata := file.Metadata
+		if metadata == nil || metadata.Reason != hardDeleteTrashReason || metadata.AssetID == nil || *metadata.AssetID != assetID {
+			continue
+		}
+		if kind, _ := metadata.Extra["kind"].(string); kind == "original" {
+			original = file.ID
+		} else {
+			derived = append(derived, file.ID)
+		}
+	}
+	if original == "" {
+		return ErrOriginalUnrecoverable
+	}
+	if err := dm.RecoverFromTrash(repoPath, original); err != nil {
+		return fmt.Errorf("%w: %v", ErrOriginalUnrecoverable, err)
+	}
+	for _, trashID := range derived {
+		_ = dm.RecoverFromTrash(repoPath, trashID)
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package service
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"server/internal/storage"
+)
+
+func writeRestoreTestFile(t *testing.T, repoPath, rel, content string) {
+	t.Helper()
+	full := filepath.Join(repoPath, rel)
+	if err := os.MkdirAll(filepath.Dir(full), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(full, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func trashForAsset(t *testing.T, repoPath, rel, assetID, reason, kind string) {
+	t.Helper()
+	metadata := &storage.DeleteMetadata{Reason: reason, AssetID: &assetID, Extra: map[string]interface{}{"kind": kind}}
+	if err := storage.NewDirectoryManager().MoveToTrash(repoPath, rel, metadata); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRecoverAssetFromTrashBringsBackHardDeletedFiles(t *testing.T) {
+	repoPath := t.TempDir()
+	assetID := "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa"
+	original := filepath.Join("2024", "photo.jpg")
+	thumbnail := filepath.Join(".lumilio", "assets", "thumbnails", "small", "photo.webp")
+	writeRestoreTestFile(t, repoPath, original, "original")
+	writeRestoreTestFile(t, repoPath, thumbnail, "thumbnail")
+	writeRestoreTestFile(t, repoPath, "other.jpg", "other")
+	trashForAsset(t, repoPath, original, assetID, hardDeleteTrashReason, "original")
+	trashForAsset(t, repoPath, thumbnail, assetID, hardDeleteTrashReason, "thumbnail:small")
+	trashForAsset(t, repoPath, "other.jpg", "bbbbbbbb-bbbb-bbbb-bbbb-bbbbbbbbbbbb", hardDeleteTrashReason, "original")
+
+	if err := recoverAssetFromTrash(repoPath, assetID, original); err != nil {
+		t.Fatalf("recover: %v", err)
+	}
+	for _, rel := range []string{original, thumbnail} {
+		if _, err := os.Stat(filepath.Join(repoPath, rel)); err != nil {
+			t.Fatalf("%s not recovered: %v", rel, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(repoPath, "other.jpg")); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("another asset's file was recovered: %v", err)
+	}
+}
+
+func TestRecoverAssetFromTrashLeavesPresentOriginal(t *testing.T) {
+	repoPath := t.TempDir()
+	writeRestoreTestFile(t, repoPath, "photo.jpg", "original")
+
+	if err := recoverAssetFromTrash(repoPath, "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa", "photo.jpg"); err != nil {
+		t.Fatalf("recover: %v", err)
+	}
+}
+
+func TestRecoverAssetFromTrashReportsUnrecoverableOriginal(t *testing.T) {
+	repoPath := t.TempDir()
+	assetID := "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa"
+	// A transform backup is an older version of the file, not the deleted original.
+	writeRestoreTestFile(t, repoPath, "photo.jpg", "before rotation")
+	trashForAsset(t, repoPath, "photo.jpg", assetID, "transform", "original")
+
+	err := recoverAssetFromTrash(repoPath, assetID, "photo.jpg")
+	if !errors.Is(err, ErrOriginalUnrecoverable) {
+		t.Fatalf("err = %v, want ErrOriginalUnrecoverable", err)
+	}
+}
@@ -28,13 +28,24 @@ const (
 	semanticUnavailableReason = "semantic_unavailable"
 )
 
-// fusedChannelWeights mirror the aggregate RRF weights, extended with the
-// filename channel.
-var fusedChannelWeights = map[string]float64{
-	aggregatesearch.SourceEmbedding: 1.0,
-	aggregatesearch.SourcePlace:     0.8,
-	aggregatesearch.SourceOCR:       0.7,
-	SourceFilename:                  0.6,
+// SearchFusionWeights are the weighted-RRF weights of the search channels
+// (search.*_weight in the runtime manifest). The semantic, OCR and place
+// weights also drive the aggregate semantic search.
+type SearchFusionWeights struct {
+	Semantic float64
+	OCR      float64
+	Place    float64
+	Filename float64
+}
+
+// channelWeights keys the weights by RRF source name.
+func (w SearchFusionWeights) channelWeights() map[string]float64 {
+	return map[string]float64{
+		aggregatesearch.SourceEmbedding: w.Semantic,
+		aggregatesearch.SourcePlace:     w.Place,
+		aggregatesearch.SourceOCR:       w.OCR,
+		SourceFilename:                  w.Filename,
+	}
 }
 
 // fusedSearchSet is the pipeline output: the complete relevance set in
@@ -143,7 +154,7 @@ func (s *assetService) searchAssetsFusedSet(ctx context.Context, params SearchAs
 	if ran == 0 {
 		return set, false
 	}
-	set.Members = aggregatesearch.FuseSet(all, fusedChannelWeights)
+	set.Members = aggregatesearch.FuseSet(all, s.fusionWeights.channelWeights())
 	return set, true
 }
 
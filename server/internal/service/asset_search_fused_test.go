@@ -0,0 +1,36 @@
+package service
+
+import (
+	"testing"
+
+	aggregatesearch "server/internal/search"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchFusionWeightsReorderChannels(t *testing.T) {
+	semanticOnly, filenameOnly, both := uuid.New(), uuid.New(), uuid.New()
+	candidates := []aggregatesearch.Candidate{
+		{AssetID: semanticOnly, Source: aggregatesearch.SourceEmbedding, Rank: 1},
+		{AssetID: both, Source: aggregatesearch.SourceEmbedding, Rank: 2},
+		{AssetID: filenameOnly, Source: SourceFilename, Rank: 1},
+		{AssetID: both, Source: SourceFilename, Rank: 2},
+	}
+	order := func(weights SearchFusionWeights) []uuid.UUID {
+		fused := aggregatesearch.FuseSet(candidates, weights.channelWeights())
+		ids := make([]uuid.UUID, len(fused))
+		for i, member := range fused {
+			ids[i] = member.AssetID
+		}
+		return ids
+	}
+
+	defaults := SearchFusionWeights{Semantic: 1.0, OCR: 0.7, Place: 0.8, Filename: 0.6}
+	require.Equal(t, []uuid.UUID{both, semanticOnly, filenameOnly}, order(defaults),
+		"an asset both channels found outranks either channel's top hit")
+
+	filenameFirst := defaults
+	filenameFirst.Filename = 2.0
+	require.Equal(t, []uuid.UUID{both, filenameOnly, semanticOnly}, order(filenameFirst))
+}
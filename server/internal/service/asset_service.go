@@ -1,6 +1,7 @@
 package service
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
@@ -13,7 +14,10 @@ import (
 	"server/internal/db/dbtypes"
 	"server/internal/db/repo"
 	aggregatesearch "server/internal/search"
+	"server/internal/storage"
 	"server/internal/utils/geohash"
+	"server/internal/utils/hash"
+	"server/internal/utils/keyedmutex"
 	"strings"
 	"time"
 
@@ -56,7 +60,16 @@ type AssetService interface {
 	GetAssetsByTypesSorted(ctx context.Context, assetTypes []string, sortOrder string, limit, offset int) ([]repo.Asset, error)
 	GetAssetsByOwnerAndTypes(ctx context.Context, ownerID int, assetTypes []string, sortOrder string, limit, offset int) ([]repo.Asset, error)
 	DeleteAsset(ctx context.Context, id uuid.UUID) error
-	RestoreAsset(ctx context.Context, id uuid.UUID) error
+	// HardDeleteAsset moves the asset's files into the repository trash and
+	// removes its database rows for good.
+	HardDeleteAsset(ctx context.Context, id uuid.UUID, repoPath string, deletedBy *string) error
+	// RestoreAsset takes the asset out of Trash. With repoPath set, an
+	// original that is no longer on disk is first recovered from the
+	// repository trash.
+	RestoreAsset(ctx context.Context, id uuid.UUID, repoPath string) error
+	// MoveRenamedAsset records that the asset's file was renamed to storagePath
+	// within its repository, keeping its ratings, tags and albums.
+	MoveRenamedAsset(ctx context.Context, repositoryID, id uuid.UUID, storagePath string) (*repo.Asset, error)
 
 	UpdateAssetMetadata(ctx context.Context, id uuid.UUID, metadata dbtypes.SpecificMetadata) error
 	UpdateAssetMetadataWithExifRaw(ctx context.Context, id uuid.UUID, metadata dbtypes.SpecificMetadata, exifRaw json.RawMessage) error
@@ -66,8 +79,13 @@ type AssetService interface {
 	UpdateAssetLike(ctx context.Context, id uuid.UUID, liked bool) error
 	UpdateAssetRatingAndLike(ctx context.Context, id uuid.UUID, rating int, liked bool) error
 	UpdateAssetDescription(ctx context.Context, id uuid.UUID, description string) error
+	RestoreAssetDescription(ctx context.Context, id uuid.UUID, description, expected string) error
+	GetAssetCustomFields(ctx context.Context, id uuid.UUID) (map[string]any, error)
+	SetAssetCustomFields(ctx context.Context, id uuid.UUID, fields map[string]any) (map[string]any, error)
 	GetAssetsByRating(ctx context.Context, rating int, ownerID *int32, limit, offset int) ([]repo.Asset, error)
 	GetLikedAssets(ctx context.Context, ownerID *int32, limit, offset int) ([]repo.Asset, error)
+	ListUnalbumedAssets(ctx context.Context, ownerID *int32, repositoryID *string, limit, offset int) ([]repo.Asset, error)
+	ListAssetsAroundTime(ctx context.Context, at time.Time, window time.Duration, ownerID *int32, limit int) ([]repo.Asset, error)
 
 	AddAssetToAlbum(ctx context.Context, assetID uuid.UUID, albumID int) error
 	RemoveAssetFromAlbum(ctx context.Context, assetID uuid.UUID, albumID int) error
@@ -76,14 +94,23 @@ type AssetService interface {
 	RemoveTagFromAsset(ctx context.Context, assetID uuid.UUID, tagID int) error
 	// AddManualTagToAsset resolves (creating if needed) a tag by name and links
 	// it to the asset with the "manual" source. Returns the resolved tag.
-	AddManualTagToAsset(ctx context.Context, assetID uuid.UUID, tagName string) (*repo.Tag, error)
+	AddManualTagToAsset(ctx context.Context, assetID uuid.UUID, tagName, category string) (*repo.Tag, error)
 	// GetAssetTags returns all tags linked to an asset (any source) as the raw
 	// JSON aggregate (tag_id, tag_name, category, confidence, source).
 	GetAssetTags(ctx context.Context, assetID uuid.UUID) (json.RawMessage, error)
 	// SearchTags returns tag definitions for autocomplete; empty query lists all.
 	SearchTags(ctx context.Context, query string, limit int) ([]repo.Tag, error)
-
-	CreateThumbnail(ctx context.Context, assetID pgtype.UUID, size string, thumbnailPath string) (*repo.Thumbnail, error)
+	// CleanupAITags removes AI-sourced tag links below minConfidence and,
+	// when removeOrphans is set, AI tag definitions left without any asset.
+	CleanupAITags(ctx context.Context, minConfidence float64, removeOrphans bool) (TagCleanupResult, error)
+	// RenameTag renames a tag definition; a name used by another tag fails
+	// with ErrTagNameTaken.
+	RenameTag(ctx context.Context, tagID int32, name string) (*repo.Tag, error)
+	// MergeTags moves the source tag's asset links onto the target and
+	// deletes the source, in one transaction.
+	MergeTags(ctx context.Context, sourceTagID, targetTagID int32) (TagMergeResult, error)
+
+	CreateThumbnail(ctx context.Context, assetID pgtype.UUID, size string, thumbnailPath string, width, height *int32) (*repo.Thumbnail, error)
 	DetectDuplicates(ctx context.Context, hash string) ([]repo.Asset, error)
 	SaveAssetIndex(ctx context.Context, taskID string, hash string) error
 	CreateAssetRecord(ctx context.Context, params repo.CreateAssetParams) (*repo.Asset, error)
@@ -91,23 +118,28 @@ type AssetService interface {
 	GetOrCreateTagByName(ctx context.Context, name, category string, isAIGenerated bool) (*repo.Tag, error)
 	GetThumbnailByID(ctx context.Context, thumbnailID int) (*repo.Thumbnail, error)
 	GetThumbnailByAssetIDAndSize(ctx context.Context, assetID uuid.UUID, size string) (*repo.Thumbnail, error)
+	GetThumbnailsByAssetID(ctx context.Context, assetID uuid.UUID) ([]repo.Thumbnail, error)
 
 	SaveNewAsset(ctx context.Context, fileReader io.Reader, filename string, hash string) (string, error)
 	SaveNewThumbnail(ctx context.Context, repoPath string, buffers io.Reader, asset *repo.Asset, size string) error
 	GetDistinctCameraModels(ctx context.Context) ([]string, error)
 	GetDistinctLenses(ctx context.Context) ([]string, error)
+	GetCustomFieldValues(ctx context.Context) (map[string][]string, error)
 
 	// Video and Audio processing methods
 	SaveVideoVersion(ctx context.Context, repoPath string, videoReader io.Reader, asset *repo.Asset, version string) error
 	SaveAudioVersion(ctx context.Context, repoPath string, audioReader io.Reader, asset *repo.Asset, version string) error
 	UpdateAssetDuration(ctx context.Context, id uuid.UUID, duration float64) error
 	UpdateAssetDimensions(ctx context.Context, id uuid.UUID, width, height int32) error
+	UpdateAssetContent(ctx context.Context, id uuid.UUID, storagePath string, content *hash.LayeredHashResult) error
 
 	// Unified query API
 	QueryAssets(ctx context.Context, params QueryAssetsParams) ([]repo.Asset, int64, error)
 	QueryBrowseItems(ctx context.Context, params QueryAssetsParams) (BrowseQueryResult, error)
+	GetAssetNeighbors(ctx context.Context, assetID uuid.UUID, params QueryAssetsParams) (AssetNeighbors, error)
 	SearchAssets(ctx context.Context, params SearchAssetsParams) (SearchAssetsResult, error)
 	SearchBrowseItems(ctx context.Context, params SearchAssetsParams) (SearchBrowseResult, error)
+	SearchAssetsByTag(ctx context.Context, params TagSearchParams) (TagSearchResult, error)
 	QueryPhotoMapPoints(ctx context.Context, params QueryPhotoMapPointsParams) ([]PhotoMapPoint, int64, error)
 
 	// Single-retriever set search (agent producer path and the search Results
@@ -121,7 +153,7 @@ type AssetService interface {
 	// entity exists; "folders" come from assets.storage_path prefixes).
 	ListFolderSummaries(ctx context.Context, ownerID *int32, repositoryID *string, parentPath string) ([]FolderSummary, error)
 	GetFolderSummary(ctx context.Context, ownerID *int32, repositoryID string, folderPath string) (FolderSummary, error)
-	ListTagSummaries(ctx context.Context, ownerID *int32, repositoryID *string, source *string, query *string, limit, offset int) ([]TagSummary, error)
+	ListTagSummaries(ctx context.Context, ownerID *int32, repositoryID *string, source, category, query *string, limit, offset int) ([]TagSummary, error)
 }
 
 // QueryAssetsParams contains all parameters for the unified asset query
@@ -145,6 +177,8 @@ type QueryAssetsParams struct {
 	Liked            *bool
 	CameraModel      *string
 	LensModel        *string
+	CustomFields     json.RawMessage // JSON object the asset's custom fields must contain
+	ProcessingStatus *string         // status state, e.g. "failed"
 	TagName          *string
 	TagSource        *string
 	TagNames         []string
@@ -159,6 +193,7 @@ type QueryAssetsParams struct {
 	Source           *AssetSetSource
 	Limit            int
 	Offset           int
+	Cursor           *AssetCursor // Continue after this row instead of skipping Offset rows
 }
 
 type AssetSetSourceKind string
@@ -266,18 +301,32 @@ type assetService struct {
 	searchAssetsFusedSetFn func(ctx context.Context, params SearchAssetsParams) (fusedSearchSet, bool)
 	hydrateAssetsInOrderFn func(ctx context.Context, ids []uuid.UUID, isDeleted *bool) ([]repo.Asset, error)
 	pageAssetsBySortFn     func(ctx context.Context, ids []uuid.UUID, sortBy string, limit, offset int, isDeleted *bool) ([]repo.Asset, error)
-}
-
-func NewAssetService(q *repo.Queries, pool *pgxpool.Pool, l LumenService, e EmbeddingService, loggers ...*zap.Logger) (AssetService, error) {
+	// reassignAlbumCovers moves covers off a deleted asset to another album
+	// member instead of clearing them.
+	reassignAlbumCovers bool
+	// dedupeThumbnails names thumbnail files by their own content hash so
+	// identical thumbnails of different assets share one file.
+	dedupeThumbnails bool
+	// thumbnailFiles locks thumbnail files by full path while a reference to
+	// them is added or one is counted before deleting the file.
+	thumbnailFiles keyedmutex.Mutex
+	// fusionWeights weigh the search channels when their rankings are fused.
+	fusionWeights SearchFusionWeights
+}
+
+func NewAssetService(q *repo.Queries, pool *pgxpool.Pool, l LumenService, e EmbeddingService, reassignAlbumCovers bool, dedupeThumbnails bool, fusionWeights SearchFusionWeights, loggers ...*zap.Logger) (AssetService, error) {
 	logger := zap.NewNop()
 	if len(loggers) > 0 && loggers[0] != nil {
 		logger = loggers[0]
 	}
 	svc := &assetService{
-		queries:          q,
-		pool:             pool,
-		lumen:            l,
-		embeddingService: e,
+		queries:             q,
+		pool:                pool,
+		lumen:               l,
+		embeddingService:    e,
+		reassignAlbumCovers: reassignAlbumCovers,
+		dedupeThumbnails:    dedupeThumbnails,
+		fusionWeights:       fusionWeights,
 	}
 	svc.semanticRetriever = aggregatesearch.NewEmbeddingRetriever(
 		pool,
@@ -297,10 +346,10 @@ func NewAssetService(q *repo.Queries, pool *pgxpool.Pool, l LumenService, e Embe
 			}
 			return svc.embeddingService.ResolveDefaultSearchSpace(ctx, EmbeddingTypeSemantic, model, dimensions)
 		},
-		1.0,
+		fusionWeights.Semantic,
 	)
-	svc.ocrRetriever = aggregatesearch.NewOCRRetriever(pool, 0.7)
-	svc.placeRetriever = aggregatesearch.NewPlaceRetriever(pool, 0.8)
+	svc.ocrRetriever = aggregatesearch.NewOCRRetriever(pool, fusionWeights.OCR)
+	svc.placeRetriever = aggregatesearch.NewPlaceRetriever(pool, fusionWeights.Place)
 	svc.aggregateSearch = aggregatesearch.NewAggregateService(pool, []aggregatesearch.Retriever{
 		svc.semanticRetriever,
 		svc.ocrRetriever,
@@ -317,9 +366,22 @@ func NewAssetService(q *repo.Queries, pool *pgxpool.Pool, l LumenService, e Embe
 func (s *assetService) CreateAssetRecord(ctx context.Context, params repo.CreateAssetParams) (*repo.Asset, error) {
 	// Note: taken_time will be set to NULL initially and updated later when EXIF is processed
 	// This is because we need to extract the time from the actual file content, not just the parameters
-	asset, err := s.queries.CreateAsset(ctx, params)
+	var asset repo.Asset
+	err := s.withTx(ctx, func(q *repo.Queries) error {
+		created, err := q.CreateAsset(ctx, params)
+		if err != nil {
+			return fmt.Errorf("failed to create asset: %w", err)
+		}
+		asset = created
+		return RecordActivity(ctx, q, ActivityEvent{
+			Type:    ActivityAssetAdded,
+			AssetID: created.AssetID,
+			OwnerID: created.OwnerID,
+			Payload: map[string]any{"type": created.Type, "original_filename": created.OriginalFilename},
+		})
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to create asset: %w", err)
+		return nil, err
 	}
 
 	return &asset, nil
@@ -451,23 +513,43 @@ func (s *assetService) DetectDuplicates(ctx context.Context, hash string) ([]rep
 	return s.queries.GetAssetsByContentHash(ctx, hash)
 }
 
-// UpdateAssetMetadata updates the specific metadata of an asset and extracts taken_time
+// UpdateAssetMetadata updates the specific metadata of an asset and extracts taken_time.
+// The metadata comes from clients, so it is first checked against the asset
+// type with ValidateSpecificMetadata; a mismatch returns ErrInvalidMetadata.
 func (s *assetService) UpdateAssetMetadata(ctx context.Context, id uuid.UUID, metadata dbtypes.SpecificMetadata) error {
-	return s.UpdateAssetMetadataWithExifRaw(ctx, id, metadata, nil)
+	asset, err := s.assetForMetadataUpdate(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := ValidateSpecificMetadata(dbtypes.AssetType(asset.Type), metadata); err != nil {
+		return err
+	}
+	return s.writeAssetMetadata(ctx, asset, metadata, nil)
 }
 
 func (s *assetService) UpdateAssetMetadataWithExifRaw(ctx context.Context, id uuid.UUID, metadata dbtypes.SpecificMetadata, exifRaw json.RawMessage) error {
+	asset, err := s.assetForMetadataUpdate(ctx, id)
+	if err != nil {
+		return err
+	}
+	return s.writeAssetMetadata(ctx, asset, metadata, exifRaw)
+}
+
+// assetForMetadataUpdate loads the asset whose type decides how metadata is read.
+func (s *assetService) assetForMetadataUpdate(ctx context.Context, id uuid.UUID) (repo.Asset, error) {
 	pgUUID := pgtype.UUID{}
 	if err := pgUUID.Scan(id.String()); err != nil {
-		return fmt.Errorf("invalid UUID: %w", err)
+		return repo.Asset{}, fmt.Errorf("invalid UUID: %w", err)
 	}
 
-	// Get the asset to determine its type for taken_time extraction
 	asset, err := s.queries.GetAssetByID(ctx, pgUUID)
 	if err != nil {
-		return fmt.Errorf("failed to get asset for metadata update: %w", err)
+		return repo.Asset{}, fmt.Errorf("failed to get asset for metadata update: %w", err)
 	}
+	return asset, nil
+}
 
+func (s *assetService) writeAssetMetadata(ctx context.Context, asset repo.Asset, metadata dbtypes.SpecificMetadata, exifRaw json.RawMessage) error {
 	// Extract taken_time from metadata based on asset type
 	var takenTime *time.Time
 	var captureOffsetMinutes *int16
@@ -506,7 +588,7 @@ func (s *assetService) UpdateAssetMetadataWithExifRaw(ctx context.Context, id uu
 	}
 
 	params := repo.UpdateAssetMetadataWithTakenTimeParams{
-		AssetID:              pgUUID,
+		AssetID:              asset.AssetID,
 		SpecificMetadata:     metadata,
 		ExifRaw:              []byte(exifRaw),
 		TakenTime:            takenTimeParam,
@@ -548,23 +630,46 @@ func geohashesForGPS(latitude, longitude *float64) (*string, *string) {
 }
 
 // DeleteAsset moves an asset into the app Trash via a database soft-delete.
+// Albums using the asset as their cover are repointed in the same
+// transaction so they never show a trashed cover.
 func (s *assetService) DeleteAsset(ctx context.Context, id uuid.UUID) error {
 	pgUUID := pgtype.UUID{}
 	if err := pgUUID.Scan(id.String()); err != nil {
 		return fmt.Errorf("invalid UUID: %w", err)
 	}
 
-	return s.queries.DeleteAsset(ctx, pgUUID)
+	return s.withTx(ctx, func(q *repo.Queries) error {
+		if err := q.DeleteAsset(ctx, pgUUID); err != nil {
+			return err
+		}
+		if _, err := q.RefreshAlbumCoversForAsset(ctx, repo.RefreshAlbumCoversForAssetParams{
+			Reassign: s.reassignAlbumCovers,
+			AssetID:  pgUUID,
+		}); err != nil {
+			return fmt.Errorf("failed to refresh album covers: %w", err)
+		}
+		return RecordActivity(ctx, q, ActivityEvent{Type: ActivityAssetDeleted, AssetID: pgUUID})
+	})
 }
 
-// RestoreAsset restores an asset from the app Trash.
-func (s *assetService) RestoreAsset(ctx context.Context, id uuid.UUID) error {
-	pgUUID := pgtype.UUID{}
-	if err := pgUUID.Scan(id.String()); err != nil {
-		return fmt.Errorf("invalid UUID: %w", err)
+func (s *assetService) withTx(ctx context.Context, fn func(*repo.Queries) error) error {
+	if s.pool == nil {
+		return fn(s.queries)
+	}
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin asset transaction: %w", err)
 	}
+	defer tx.Rollback(ctx)
 
-	return s.queries.RestoreAsset(ctx, pgUUID)
+	if err := fn(s.queries.WithTx(tx)); err != nil {
+		return err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit asset transaction: %w", err)
+	}
+	return nil
 }
 
 // AddAssetToAlbum adds an asset to an album
@@ -579,7 +684,12 @@ func (s *assetService) AddAssetToAlbum(ctx context.Context, assetID uuid.UUID, a
 		AlbumID: int32(albumID),
 	}
 
-	return s.queries.AddAssetToAlbum(ctx, params)
+	return s.withTx(ctx, func(q *repo.Queries) error {
+		if err := q.AddAssetToAlbum(ctx, params); err != nil {
+			return err
+		}
+		return refreshAlbumCover(ctx, q, params.AlbumID, pgtype.UUID{})
+	})
 }
 
 // RemoveAssetFromAlbum removes an asset from an album
@@ -594,7 +704,12 @@ func (s *assetService) RemoveAssetFromAlbum(ctx context.Context, assetID uuid.UU
 		AlbumID: int32(albumID),
 	}
 
-	return s.queries.RemoveAssetFromAlbum(ctx, params)
+	return s.withTx(ctx, func(q *repo.Queries) error {
+		if err := q.RemoveAssetFromAlbum(ctx, params); err != nil {
+			return err
+		}
+		return refreshAlbumCover(ctx, q, params.AlbumID, params.AssetID)
+	})
 }
 
 // AddTagToAsset adds a tag to an asset
@@ -634,15 +749,59 @@ func (s *assetService) RemoveTagFromAsset(ctx context.Context, assetID uuid.UUID
 	return s.queries.RemoveTagFromAsset(ctx, params)
 }
 
+// AIAssetTagSources lists the asset_tags.source values written by AI
+// pipelines. Tag cleanup only ever touches these sources.
+var AIAssetTagSources = []string{"ai", "bioclip_classify", AssetTagSourceZeroshot}
+
+// TagCleanupResult reports what a CleanupAITags run removed.
+type TagCleanupResult struct {
+	RemovedAssetTags int64
+	RemovedTags      int64
+}
+
+// CleanupAITags drops low-confidence AI tag links. User and system tags are
+// left alone regardless of their confidence.
+func (s *assetService) CleanupAITags(ctx context.Context, minConfidence float64, removeOrphans bool) (TagCleanupResult, error) {
+	if minConfidence <= 0 || minConfidence > 1 {
+		return TagCleanupResult{}, fmt.Errorf("min confidence must be in (0, 1], got %v", minConfidence)
+	}
+
+	threshold := pgtype.Numeric{}
+	if err := threshold.Scan(fmt.Sprintf("%.3f", minConfidence)); err != nil {
+		return TagCleanupResult{}, fmt.Errorf("failed to convert confidence: %w", err)
+	}
+
+	var result TagCleanupResult
+	removed, err := s.queries.DeleteAssetTagsBelowConfidence(ctx, repo.DeleteAssetTagsBelowConfidenceParams{
+		Sources:       AIAssetTagSources,
+		MinConfidence: threshold,
+	})
+	if err != nil {
+		return result, fmt.Errorf("delete low-confidence ai tags: %w", err)
+	}
+	result.RemovedAssetTags = removed
+
+	if removeOrphans {
+		removed, err := s.queries.DeleteOrphanedAITags(ctx)
+		if err != nil {
+			return result, fmt.Errorf("delete orphaned ai tags: %w", err)
+		}
+		result.RemovedTags = removed
+	}
+
+	return result, nil
+}
+
 // AddManualTagToAsset resolves a tag by name (creating it if absent) and links
-// it to the asset with the manual source and full confidence.
-func (s *assetService) AddManualTagToAsset(ctx context.Context, assetID uuid.UUID, tagName string) (*repo.Tag, error) {
+// it to the asset with the manual source and full confidence. category is only
+// applied when the tag is created; an existing tag keeps its own.
+func (s *assetService) AddManualTagToAsset(ctx context.Context, assetID uuid.UUID, tagName, category string) (*repo.Tag, error) {
 	name := strings.TrimSpace(tagName)
 	if name == "" {
 		return nil, fmt.Errorf("tag name must not be empty")
 	}
 
-	tag, err := s.GetOrCreateTagByName(ctx, name, "", false)
+	tag, err := s.GetOrCreateTagByName(ctx, name, strings.TrimSpace(category), false)
 	if err != nil {
 		return nil, err
 	}
@@ -756,13 +915,16 @@ func (s *assetService) SaveNewAsset(ctx context.Context, fileReader io.Reader, f
 // Thumbnail CRUD Operations
 // ================================
 
-// CreateThumbnail creates or updates a thumbnail record for an asset
-func (s *assetService) CreateThumbnail(ctx context.Context, assetID pgtype.UUID, size string, thumbnailPath string) (*repo.Thumbnail, error) {
+// CreateThumbnail creates or updates a thumbnail record for an asset.
+// width and height are the generated image's pixel size, nil when unknown.
+func (s *assetService) CreateThumbnail(ctx context.Context, assetID pgtype.UUID, size string, thumbnailPath string, width, height *int32) (*repo.Thumbnail, error) {
 	params := repo.CreateThumbnailParams{
 		AssetID:     assetID,
 		Size:        size,
 		StoragePath: thumbnailPath,
 		MimeType:    "image/webp",
+		Width:       width,
+		Height:      height,
 	}
 
 	dbThumbnail, err := s.queries.CreateThumbnail(ctx, params)
@@ -803,8 +965,27 @@ func (s *assetService) GetThumbnailByAssetIDAndSize(ctx context.Context, assetID
 	return &dbThumbnail, nil
 }
 
+// GetThumbnailsByAssetID lists an asset's thumbnails, smallest size first.
+func (s *assetService) GetThumbnailsByAssetID(ctx context.Context, assetID uuid.UUID) ([]repo.Thumbnail, error) {
+	pgUUID := pgtype.UUID{}
+	if err := pgUUID.Scan(assetID.String()); err != nil {
+		return nil, fmt.Errorf("invalid UUID: %w", err)
+	}
+
+	thumbnails, err := s.queries.GetThumbnailsByAsset(ctx, pgUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get thumbnails: %w", err)
+	}
+	return thumbnails, nil
+}
+
 // SaveNewThumbnail saves thumbnail file to repository and creates database record
 //
+// With thumbnail deduplication enabled the file is named by the hash of its
+// own bytes and may be shared with other assets; a file the asset no longer
+// points at is deleted once nothing else references it. The pixel size read
+// from the encoded image is stored alongside for srcset.
+//
 // asset repo.Asset must be valid in following cases:
 //   - asset ID is not empty
 //   - asset hash is not empty
@@ -827,22 +1008,95 @@ func (s *assetService) SaveNewThumbnail(ctx context.Context, repoPath string, bu
 		return fmt.Errorf("repository path is required")
 	}
 
-	// Generate thumbnail filename using hash and size
+	// Remember the file this asset/size pointed at so it can be released once
+	// the record moves to the new one.
+	var previousPath string
+	if previous, err := s.queries.GetThumbnailByAssetAndSize(ctx, repo.GetThumbnailByAssetAndSizeParams{
+		AssetID: asset.AssetID,
+		Size:    size,
+	}); err == nil {
+		previousPath = previous.StoragePath
+	} else if !errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("failed to look up existing thumbnail: %w", err)
+	}
+
+	// Thumbnails are small; hold the bytes so their dimensions can be read
+	// from the header before writing.
+	data, err := io.ReadAll(buffers)
+	if err != nil {
+		return fmt.Errorf("failed to read thumbnail: %w", err)
+	}
+	width, height := thumbnailDimensions(data)
+
+	// Thumbnails live under .lumilio/assets/thumbnails/{size}/ as
+	// {asset hash}_{size}.webp, or as {thumbnail hash}.webp so that identical
+	// thumbnails share one file.
 	filename := fmt.Sprintf("%s_%s.webp", asset.ContentHash, size)
+	if s.dedupeThumbnails {
+		filename = storage.ContentAddressedName(data, ".webp")
+	}
+	relPath := filepath.Join(".lumilio/assets/thumbnails", size, filename)
+	if err := s.storeThumbnail(ctx, repoPath, relPath, data, asset, size, width, height); err != nil {
+		return err
+	}
 
-	// Construct full path: .lumilio/assets/thumbnails/{size}/{hash}_{size}.webp
-	thumbnailDir := filepath.Join(repoPath, ".lumilio/assets/thumbnails", size)
-	thumbnailPath := filepath.Join(thumbnailDir, filename)
+	if previousPath != "" && previousPath != relPath {
+		s.releaseThumbnailFile(ctx, repoPath, previousPath)
+	}
+	return nil
+}
 
-	// Ensure directory exists
-	if err := os.MkdirAll(thumbnailDir, 0755); err != nil {
-		return fmt.Errorf("failed to create thumbnail directory: %w", err)
+// storeThumbnail writes data to relPath and records it as the asset's
+// thumbnail of size. The file stays locked until the record exists, so a
+// release of another reference to a shared file cannot delete it in between.
+func (s *assetService) storeThumbnail(ctx context.Context, repoPath, relPath string, data []byte, asset *repo.Asset, size string, width, height *int32) error {
+	thumbnailPath := filepath.Join(repoPath, relPath)
+	unlock := s.thumbnailFiles.Lock(thumbnailPath)
+	defer unlock()
+
+	var written int64
+	// created is false when the file was already there for another asset and
+	// must survive a failed insert below.
+	created := true
+	if s.dedupeThumbnails {
+		file, err := storage.WriteContentAddressed(filepath.Dir(thumbnailPath), bytes.NewReader(data), ".webp")
+		if err != nil {
+			return fmt.Errorf("failed to write thumbnail: %w", err)
+		}
+		written, created = file.Size, !file.Shared
+	} else {
+		n, err := writeThumbnailFile(filepath.Dir(thumbnailPath), filepath.Base(thumbnailPath), bytes.NewReader(data))
+		if err != nil {
+			return err
+		}
+		written = n
 	}
 
-	// Write the thumbnail file
+	assetUUID, _ := uuid.FromBytes(asset.AssetID.Bytes[:])
+	log.Printf("Saved thumbnail for asset %s: size=%s, path=%s, bytes=%d", assetUUID.String(), size, thumbnailPath, written)
+
+	// Create database record with relative path
+	if _, err := s.CreateThumbnail(ctx, asset.AssetID, size, relPath, width, height); err != nil {
+		// Clean up file if database insertion fails
+		if created {
+			os.Remove(thumbnailPath)
+		}
+		return fmt.Errorf("failed to create thumbnail database record: %w", err)
+	}
+	return nil
+}
+
+// writeThumbnailFile writes buffers to dir/filename, replacing any existing
+// file, and removes partial or empty output on failure.
+func writeThumbnailFile(dir, filename string, buffers io.Reader) (int64, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return 0, fmt.Errorf("failed to create thumbnail directory: %w", err)
+	}
+	thumbnailPath := filepath.Join(dir, filename)
+
 	file, err := os.Create(thumbnailPath)
 	if err != nil {
-		return fmt.Errorf("failed to create thumbnail file: %w", err)
+		return 0, fmt.Errorf("failed to create thumbnail file: %w", err)
 	}
 	defer file.Close()
 
@@ -850,28 +1104,37 @@ func (s *assetService) SaveNewThumbnail(ctx context.Context, repoPath string, bu
 	if err != nil {
 		// Clean up partial file on error
 		os.Remove(thumbnailPath)
-		return fmt.Errorf("failed to write thumbnail: %w", err)
+		return 0, fmt.Errorf("failed to write thumbnail: %w", err)
 	}
 
 	// Ensure: file was written
 	if written == 0 {
 		os.Remove(thumbnailPath)
-		return fmt.Errorf("no data written for thumbnail")
+		return 0, fmt.Errorf("no data written for thumbnail")
 	}
+	return written, nil
+}
 
-	assetUUID, _ := uuid.FromBytes(asset.AssetID.Bytes[:])
-	log.Printf("Saved thumbnail for asset %s: size=%s, path=%s, bytes=%d", assetUUID.String(), size, thumbnailPath, written)
+// releaseThumbnailFile deletes a thumbnail file once no thumbnail record
+// references it any more. Shared files stay until their last reference goes.
+// The count and the removal hold the file's lock, which storeThumbnail holds
+// until a new reference is recorded. Failures only leak a file, so they are
+// logged rather than returned.
+func (s *assetService) releaseThumbnailFile(ctx context.Context, repoPath, relPath string) {
+	unlock := s.thumbnailFiles.Lock(filepath.Join(repoPath, relPath))
+	defer unlock()
 
-	// Create database record with relative path
-	relPath := filepath.Join(".lumilio/assets/thumbnails", size, filename)
-	_, err = s.CreateThumbnail(ctx, asset.AssetID, size, relPath)
+	references, err := s.queries.CountThumbnailReferences(ctx, relPath)
 	if err != nil {
-		// Clean up file if database insertion fails
-		os.Remove(thumbnailPath)
-		return fmt.Errorf("failed to create thumbnail database record: %w", err)
+		log.Printf("Failed to count references to thumbnail %s: %v", relPath, err)
+		return
+	}
+	if references > 0 {
+		return
+	}
+	if err := os.Remove(filepath.Join(repoPath, relPath)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		log.Printf("Failed to remove unreferenced thumbnail %s: %v", relPath, err)
 	}
-
-	return nil
 }
 
 // ================================
@@ -998,7 +1261,16 @@ func (s *assetService) UpdateAssetRating(ctx context.Context, id uuid.UUID, rati
 		Rating:  int32(rating),
 	}
 
-	return s.queries.UpdateAssetRating(ctx, params)
+	return s.withTx(ctx, func(q *repo.Queries) error {
+		if err := q.UpdateAssetRating(ctx, params); err != nil {
+			return err
+		}
+		return RecordActivity(ctx, q, ActivityEvent{
+			Type:    ActivityAssetRatingUpdated,
+			AssetID: pgUUID,
+			Payload: map[string]any{"rating": rating},
+		})
+	})
 }
 
 func (s *assetService) UpdateAssetLike(ctx context.Context, id uuid.UUID, liked bool) error {
@@ -1044,6 +1316,16 @@ func (s *assetService) UpdateAssetDescription(ctx context.Context, id uuid.UUID,
 	return s.queries.UpdateAssetDescription(ctx, params)
 }
 
+// RestoreAssetDescription sets the description back to description unless it
+// changed from expected in the meantime. Both happen in one statement.
+func (s *assetService) RestoreAssetDescription(ctx context.Context, id uuid.UUID, description, expected string) error {
+	return s.queries.RestoreAssetDescription(ctx, repo.RestoreAssetDescriptionParams{
+		Description: description,
+		AssetID:     pgtype.UUID{Bytes: id, Valid: true},
+		Expected:    expected,
+	})
+}
+
 func (s *assetService) GetAssetsByRating(ctx context.Context, rating int, ownerID *int32, limit, offset int) ([]repo.Asset, error) {
 	params := repo.GetAssetsByRatingParams{
 		Rating:  int32(rating),
@@ -1065,6 +1347,36 @@ func (s *assetService) GetLikedAssets(ctx context.Context, ownerID *int32, limit
 	return s.queries.GetLikedAssets(ctx, params)
 }
 
+// ListUnalbumedAssets lists assets that are in no album, optionally scoped to
+// one owner and one repository.
+func (s *assetService) ListUnalbumedAssets(ctx context.Context, ownerID *int32, repositoryID *string, limit, offset int) ([]repo.Asset, error) {
+	repoUUID, err := parseRepositoryUUID(repositoryID)
+	if err != nil {
+		return nil, err
+	}
+	return s.queries.ListUnalbumedAssets(ctx, repo.ListUnalbumedAssetsParams{
+		OwnerID:      ownerID,
+		RepositoryID: repoUUID,
+		Limit:        int32(limit),
+		Offset:       int32(offset),
+	})
+}
+
+// ListAssetsAroundTime lists assets taken within window of at, before or
+// after it, closest first, optionally scoped to one owner.
+func (s *assetService) ListAssetsAroundTime(ctx context.Context, at time.Time, window time.Duration, ownerID *int32, limit int) ([]repo.Asset, error) {
+	if window <= 0 {
+		return nil, fmt.Errorf("window must be positive, got %s", window)
+	}
+	return s.queries.ListAssetsAroundTime(ctx, repo.ListAssetsAroundTimeParams{
+		FromTime: pgtype.Timestamptz{Time: at.Add(-window), Valid: true},
+		ToTime:   pgtype.Timestamptz{Time: at.Add(window), Valid: true},
+		OwnerID:  ownerID,
+		At:       pgtype.Timestamptz{Time: at, Valid: true},
+		Limit:    int32(limit),
+	})
+}
+
 // SaveVideoVersion Video and Audio processing methods implementation
 //
 // asset repo.Asset must be valid in following cases:
@@ -1216,6 +1528,23 @@ func (s *assetService) UpdateAssetDimensions(ctx context.Context, id uuid.UUID,
 	return s.queries.UpdateAssetDimensions(ctx, params)
 }
 
+// UpdateAssetContent records the path, hash and size of an original that was
+// rewritten, e.g. after a rotate or flip. The path changes when a
+// content-addressed original moves to the location of its new hash.
+func (s *assetService) UpdateAssetContent(ctx context.Context, id uuid.UUID, storagePath string, content *hash.LayeredHashResult) error {
+	if content == nil {
+		return fmt.Errorf("content hash is required")
+	}
+	return s.queries.UpdateAssetContent(ctx, repo.UpdateAssetContentParams{
+		AssetID:                 pgtype.UUID{Bytes: id, Valid: true},
+		FileSize:                content.FileSize,
+		ContentHash:             content.ContentHash,
+		QuickFingerprint:        content.QuickFingerprint,
+		QuickFingerprintVersion: content.QuickFingerprintVersion,
+		StoragePath:             &storagePath,
+	})
+}
+
 // ================================
 // Unified Query API
 // ================================
@@ -1423,6 +1752,8 @@ func buildAggregateSearchFilter(params QueryAssetsParams) (aggregatesearch.Filte
 		Liked:            params.Liked,
 		CameraModel:      params.CameraModel,
 		LensModel:        params.LensModel,
+		CustomFields:     params.CustomFields,
+		ProcessingStatus: params.ProcessingStatus,
 		TagName:          params.TagName,
 		TagSource:        params.TagSource,
 		TagNames:         params.TagNames,
@@ -1483,6 +1814,7 @@ func (s *assetService) queryAssetsUnified(ctx context.Context, params QueryAsset
 		sortByPtr = &s
 	}
 	sourceAssetIDs := assetSetSourcePgUUIDs(params.Source)
+	cursorTime, cursorID := assetCursorArgs(params.Cursor)
 
 	// Get total count
 	countResult, err := s.queries.CountAssetsUnified(ctx, repo.CountAssetsUnifiedParams{
@@ -1501,6 +1833,8 @@ func (s *assetService) queryAssetsUnified(ctx context.Context, params QueryAsset
 		Liked:            params.Liked,
 		CameraModel:      params.CameraModel,
 		LensModel:        params.LensModel,
+		CustomFields:     params.CustomFields,
+		ProcessingStatus: params.ProcessingStatus,
 		TagName:          params.TagName,
 		TagSource:        params.TagSource,
 		TagNames:         params.TagNames,
@@ -1535,6 +1869,8 @@ func (s *assetService) queryAssetsUnified(ctx context.Context, params QueryAsset
 		Liked:            params.Liked,
 		CameraModel:      params.CameraModel,
 		LensModel:        params.LensModel,
+		CustomFields:     params.CustomFields,
+		ProcessingStatus: params.ProcessingStatus,
 		TagName:          params.TagName,
 		TagSource:        params.TagSource,
 		TagNames:         params.TagNames,
@@ -1548,6 +1884,8 @@ func (s *assetService) queryAssetsUnified(ctx context.Context, params QueryAsset
 		DateFrom:         fromTime,
 		DateTo:           toTime,
 		IsDeleted:        params.IsDeleted,
+		CursorTime:       cursorTime,
+		CursorID:         cursorID,
 		Limit:            int32(params.Limit),
 		Offset:           int32(params.Offset),
 	})
@@ -1983,5 +2321,6 @@ func filenameMembershipParams(params QueryAssetsParams) repo.GetAssetIDsUnifiedP
 	out.Liked = params.Liked
 	out.CameraModel = params.CameraModel
 	out.LensModel = params.LensModel
+	out.ProcessingStatus = params.ProcessingStatus
 	return out
 }
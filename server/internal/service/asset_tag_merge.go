@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"server/internal/db/repo"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// maxTagNameLength matches tags.tag_name varchar(50).
+const maxTagNameLength = 50
+
+var (
+	// ErrTagNotFound reports a tag ID with no tag definition.
+	ErrTagNotFound = errors.New("tag not found")
+	// ErrTagNameTaken reports a rename onto the name of another tag; merging
+	// the two is the way to combine them.
+	ErrTagNameTaken = errors.New("tag name is already in use")
+	// ErrInvalidTagName reports an empty or over-long tag name.
+	ErrInvalidTagName = errors.New("invalid tag name")
+	// ErrTagMergeIntoItself reports a merge whose source and target match.
+	ErrTagMergeIntoItself = errors.New("tag cannot be merged into itself")
+)
+
+// TagMergeResult reports what a MergeTags run changed.
+type TagMergeResult struct {
+	Target repo.Tag
+	// MergedAssetTags counts the source's asset links written onto the
+	// target, including links folded into one the asset already had.
+	MergedAssetTags int64
+}
+
+// RenameTag changes a tag's name for every asset that carries it.
+func (s *assetService) RenameTag(ctx context.Context, tagID int32, name string) (*repo.Tag, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || utf8.RuneCountInString(name) > maxTagNameLength {
+		return nil, fmt.Errorf("%w: must be 1-%d characters", ErrInvalidTagName, maxTagNameLength)
+	}
+
+	tag, err := s.queries.RenameTag(ctx, repo.RenameTagParams{TagName: name, TagID: tagID})
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		return nil, ErrTagNotFound
+	case isUniqueTagNameViolation(err):
+		return nil, ErrTagNameTaken
+	case err != nil:
+		return nil, fmt.Errorf("rename tag %d: %w", tagID, err)
+	}
+	return &tag, nil
+}
+
+// MergeTags moves every asset link of the source tag onto the target and
+// deletes the source. An asset carrying both keeps a single link. The whole
+// merge runs in one transaction, so a failure leaves both tags untouched.
+func (s *assetService) MergeTags(ctx context.Context, sourceTagID, targetTagID int32) (TagMergeResult, error) {
+	if sourceTagID == targetTagID {
+		return TagMergeResult{}, ErrTagMergeIntoItself
+	}
+
+	var result TagMergeResult
+	err := s.withTx(ctx, func(q *repo.Queries) error {
+		if _, err := q.GetTagByID(ctx, sourceTagID); err != nil {
+			return tagLookupError(sourceTagID, err)
+		}
+		target, err := q.GetTagByID(ctx, targetTagID)
+		if err != nil {
+			return tagLookupError(targetTagID, err)
+		}
+		result.Target = target
+
+		merged, err := q.MergeAssetTagsIntoTag(ctx, repo.MergeAssetTagsIntoTagParams{
+			TargetTagID: targetTagID,
+			SourceTagID: sourceTagID,
+		})
+		if err != nil {
+			return fmt.Errorf("merge asset tags: %w", err)
+		}
+		result.MergedAssetTags = merged
+
+		if err := q.DeleteAssetTagsByTag(ctx, sourceTagID); err != nil {
+			return fmt.Errorf("delete source asset tags: %w", err)
+		}
+		if err := q.DeleteTag(ctx, sourceTagID); err != nil {
+			return fmt.Errorf("delete source tag: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return TagMergeResult{}, err
+	}
+	return result, nil
+}
+
+func tagLookupError(tagID int32, err error) error {
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrTagNotFound
+	}
+	return fmt.Errorf("get tag %d: %w", tagID, err)
+}
+
+func isUniqueTagNameViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return false
+	}
+	return pgErr.Code == "23505" && pgErr.ConstraintName == "tags_tag_name_key"
+}
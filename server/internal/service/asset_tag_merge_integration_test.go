@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"server/internal/db/dbtest"
+	"server/internal/db/repo"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeAndRenameTagsPostgresIntegration(t *testing.T) {
+	ctx := context.Background()
+	pool := dbtest.Pool(t, os.Getenv(dbtest.DatabaseURLEnv))
+
+	suffix := strings.ReplaceAll(uuid.NewString(), "-", "")[:12]
+	prefix := "tagmerge_" + suffix
+	t.Cleanup(func() {
+		_, _ = pool.Exec(ctx, `DELETE FROM asset_tags WHERE asset_id IN (SELECT asset_id FROM assets WHERE original_filename LIKE $1)`, prefix+"%")
+		_, _ = pool.Exec(ctx, `DELETE FROM assets WHERE original_filename LIKE $1`, prefix+"%")
+		_, _ = pool.Exec(ctx, `DELETE FROM tags WHERE tag_name LIKE $1`, prefix+"%")
+	})
+
+	insertAsset := func(name string) uuid.UUID {
+		var assetID uuid.UUID
+		require.NoError(t, pool.QueryRow(ctx, `
+			INSERT INTO assets (type, original_filename, mime_type, file_size, content_hash)
+			VALUES ('PHOTO', $1, 'image/jpeg', 1024, $2)
+			RETURNING asset_id`, prefix+"_"+name+".jpg", suffix+name).Scan(&assetID))
+		return assetID
+	}
+	insertTag := func(name string) int32 {
+		var tagID int32
+		require.NoError(t, pool.QueryRow(ctx, `
+			INSERT INTO tags (tag_name) VALUES ($1) RETURNING tag_id`, prefix+name).Scan(&tagID))
+		return tagID
+	}
+	link := func(assetID uuid.UUID, tagID int32, confidence, source string) {
+		_, err := pool.Exec(ctx, `
+			INSERT INTO asset_tags (asset_id, tag_id, confidence, source)
+			VALUES ($1, $2, $3::numeric, $4)`, assetID, tagID, confidence, source)
+		require.NoError(t, err)
+	}
+
+	a, b, c := insertAsset("a"), insertAsset("b"), insertAsset("c")
+	dog, dogs := insertTag("dog"), insertTag("dogs")
+	link(a, dog, "0.600", "ai")
+	link(b, dog, "0.900", "ai")
+	link(b, dogs, "0.700", "user")
+	link(c, dogs, "0.800", "ai")
+
+	svc := &assetService{queries: repo.New(pool), pool: pool}
+
+	_, err := svc.RenameTag(ctx, dogs, prefix+"dog")
+	require.ErrorIs(t, err, ErrTagNameTaken)
+	renamed, err := svc.RenameTag(ctx, dog, "  "+prefix+"canine  ")
+	require.NoError(t, err)
+	require.Equal(t, prefix+"canine", renamed.TagName)
+
+	_, err = svc.MergeTags(ctx, dogs, dogs)
+	require.ErrorIs(t, err, ErrTagMergeIntoItself)
+	_, err = svc.MergeTags(ctx, dogs, -1)
+	require.ErrorIs(t, err, ErrTagNotFound)
+
+	result, err := svc.MergeTags(ctx, dogs, dog)
+	require.NoError(t, err)
+	require.Equal(t, dog, result.Target.TagID)
+	require.Equal(t, int64(2), result.MergedAssetTags)
+
+	rows, err := pool.Query(ctx, `
+		SELECT asset_id, tag_id, confidence::text, source FROM asset_tags
+		WHERE tag_id IN ($1, $2)
+		ORDER BY confidence`, dog, dogs)
+	require.NoError(t, err)
+	type assetTag struct {
+		assetID    uuid.UUID
+		tagID      int32
+		confidence string
+		source     string
+	}
+	var links []assetTag
+	for rows.Next() {
+		var l assetTag
+		require.NoError(t, rows.Scan(&l.assetID, &l.tagID, &l.confidence, &l.source))
+		links = append(links, l)
+	}
+	require.NoError(t, rows.Err())
+	require.Equal(t, []assetTag{
+		{a, dog, "0.600", "ai"},
+		{c, dog, "0.800", "ai"},
+		{b, dog, "0.900", "user"},
+	}, links, "b keeps one link with the higher confidence and the user source")
+
+	var remaining int
+	require.NoError(t, pool.QueryRow(ctx, `SELECT COUNT(*) FROM tags WHERE tag_id = $1`, dogs).Scan(&remaining))
+	require.Zero(t, remaining, "source tag is deleted")
+}
@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"server/internal/db/repo"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// ErrNoSearchTags is returned by SearchAssetsByTag when no tag name is given.
+var ErrNoSearchTags = errors.New("at least one tag name is required")
+
+// Tag source groups accepted by SearchAssetsByTag alongside the raw
+// asset_tags.source values.
+const (
+	// TagSourceGroupAI matches every AIAssetTagSources value.
+	TagSourceGroupAI = "ai"
+	// TagSourceGroupManual matches tags added by hand.
+	TagSourceGroupManual = "manual"
+)
+
+// TagSearchParams selects assets by their tags.
+type TagSearchParams struct {
+	// TagNames are matched case-insensitively.
+	TagNames []string
+	// MatchAny returns assets carrying any of TagNames instead of all of them.
+	MatchAny bool
+	// Source restricts matching tag links to one source: a raw
+	// asset_tags.source value, TagSourceGroupAI or TagSourceGroupManual.
+	Source *string
+	// MinConfidence drops tag links below it; user tags carry 1.0.
+	MinConfidence *float64
+	OwnerID       *int32
+	RepositoryID  *string
+	Limit         int
+	Offset        int
+}
+
+// TagSearchResult is a page of tag search results, newest capture first, with
+// the total number of matching assets. Stacks are not collapsed.
+type TagSearchResult struct {
+	Items []BrowseItem
+	Total int64
+}
+
+// SearchAssetsByTag returns the assets tagged with the given tag names, sorted
+// like the date_captured listing.
+func (s *assetService) SearchAssetsByTag(ctx context.Context, params TagSearchParams) (TagSearchResult, error) {
+	tagNames := normalizeSearchTagNames(params.TagNames)
+	if len(tagNames) == 0 {
+		return TagSearchResult{}, ErrNoSearchTags
+	}
+	var repoUUID pgtype.UUID
+	if params.RepositoryID != nil && *params.RepositoryID != "" {
+		parsed, err := uuid.Parse(*params.RepositoryID)
+		if err != nil {
+			return TagSearchResult{}, fmt.Errorf("invalid repository ID: %w", err)
+		}
+		repoUUID = pgtype.UUID{Bytes: parsed, Valid: true}
+	}
+	minConfidence := 0.0
+	if params.MinConfidence != nil {
+		minConfidence = *params.MinConfidence
+	}
+
+	countArgs := repo.CountAssetsByTagsParams{
+		OwnerID:       params.OwnerID,
+		RepositoryID:  repoUUID,
+		TagNames:      tagNames,
+		Sources:       tagSearchSources(params.Source),
+		MinConfidence: minConfidence,
+		MatchAll:      !params.MatchAny,
+	}
+	total, err := s.queries.CountAssetsByTags(ctx, countArgs)
+	if err != nil {
+		return TagSearchResult{}, fmt.Errorf("count assets by tags: %w", err)
+	}
+	assets, err := s.queries.SearchAssetsByTags(ctx, repo.SearchAssetsByTagsParams{
+		OwnerID:       countArgs.OwnerID,
+		RepositoryID:  countArgs.RepositoryID,
+		TagNames:      countArgs.TagNames,
+		Sources:       countArgs.Sources,
+		MinConfidence: countArgs.MinConfidence,
+		MatchAll:      countArgs.MatchAll,
+		Offset:        int32(params.Offset),
+		Limit:         int32(params.Limit),
+	})
+	if err != nil {
+		return TagSearchResult{}, fmt.Errorf("search assets by tags: %w", err)
+	}
+	return TagSearchResult{Items: assetsToBrowseItems(assets), Total: total}, nil
+}
+
+// normalizeSearchTagNames trims, lower-cases and de-duplicates tag names.
+func normalizeSearchTagNames(names []string) []string {
+	normalized := make([]string, 0, len(names))
+	seen := make(map[string]struct{}, len(names))
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		if _, ok := seen[name]; ok {
+			continue
+		}
+		seen[name] = struct{}{}
+		normalized = append(normalized, name)
+	}
+	return normalized
+}
+
+// tagSearchSources expands a source filter into asset_tags.source values. The
+// result is never nil: the query reads an empty array as "any source".
+func tagSearchSources(source *string) []string {
+	if source == nil || strings.TrimSpace(*source) == "" {
+		return []string{}
+	}
+	switch value := strings.ToLower(strings.TrimSpace(*source)); value {
+	case TagSourceGroupAI:
+		return append([]string{}, AIAssetTagSources...)
+	case TagSourceGroupManual:
+		return []string{AssetTagSourceUser}
+	default:
+		return []string{value}
+	}
+}
@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"server/internal/db/dbtest"
+	"server/internal/db/repo"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeSearchTagNames(t *testing.T) {
+	require.Equal(t, []string{"bird", "sunset"}, normalizeSearchTagNames([]string{" Bird", "", "sunset", "BIRD "}))
+	require.Empty(t, normalizeSearchTagNames([]string{" ", ""}))
+}
+
+func TestTagSearchSources(t *testing.T) {
+	source := func(s string) *string { return &s }
+	require.Equal(t, []string{}, tagSearchSources(nil))
+	require.Equal(t, []string{}, tagSearchSources(source(" ")))
+	require.Equal(t, AIAssetTagSources, tagSearchSources(source("AI")))
+	require.Equal(t, []string{AssetTagSourceUser}, tagSearchSources(source("manual")))
+	require.Equal(t, []string{"zeroshot"}, tagSearchSources(source("zeroshot")))
+}
+
+func TestSearchAssetsByTagPostgresIntegration(t *testing.T) {
+	ctx := context.Background()
+	pool := dbtest.Pool(t, os.Getenv(dbtest.DatabaseURLEnv))
+
+	suffix := strings.ReplaceAll(uuid.NewString(), "-", "")[:12]
+	prefix := "tagsearch_" + suffix
+	bird, branch := "bird_"+suffix, "branch_"+suffix
+	svc := &assetService{queries: repo.New(pool)}
+
+	insert := func(name string, takenTime time.Time) uuid.UUID {
+		var assetID uuid.UUID
+		require.NoError(t, pool.QueryRow(ctx, `
+			INSERT INTO assets (type, original_filename, mime_type, file_size, content_hash, taken_time)
+			VALUES ('PHOTO', $1, 'image/jpeg', 1024, $2, $3)
+			RETURNING asset_id`, prefix+"_"+name+".jpg", suffix+name, takenTime).Scan(&assetID))
+		return assetID
+	}
+	tag := func(assetID uuid.UUID, name string, confidence float32, source string) {
+		tagRow, err := svc.GetOrCreateTagByName(ctx, name, "", source != AssetTagSourceUser)
+		require.NoError(t, err)
+		require.NoError(t, svc.AddTagToAsset(ctx, assetID, int(tagRow.TagID), confidence, source))
+	}
+	t.Cleanup(func() {
+		_, _ = pool.Exec(ctx, `DELETE FROM asset_tags WHERE asset_id IN (SELECT asset_id FROM assets WHERE original_filename LIKE $1)`, prefix+"%")
+		_, _ = pool.Exec(ctx, `DELETE FROM assets WHERE original_filename LIKE $1`, prefix+"%")
+		_, _ = pool.Exec(ctx, `DELETE FROM tags WHERE tag_name = ANY($1::text[])`, []string{bird, branch})
+	})
+
+	both := insert("both", time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC))
+	birdOnly := insert("bird", time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC))
+	branchOnly := insert("branch", time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC))
+	tag(both, bird, 0.9, AssetTagSourceZeroshot)
+	tag(both, branch, 1.0, AssetTagSourceUser)
+	tag(birdOnly, bird, 0.3, AssetTagSourceZeroshot)
+	tag(branchOnly, branch, 0.8, AssetTagSourceZeroshot)
+
+	search := func(params TagSearchParams) []uuid.UUID {
+		t.Helper()
+		params.Limit = 10
+		result, err := svc.SearchAssetsByTag(ctx, params)
+		require.NoError(t, err)
+		require.EqualValues(t, len(result.Items), result.Total)
+		return browseItemAssetIDs(result.Items)
+	}
+
+	require.Equal(t, []uuid.UUID{both}, search(TagSearchParams{TagNames: []string{bird, strings.ToUpper(branch)}}))
+	require.Equal(t, []uuid.UUID{branchOnly, both, birdOnly}, search(TagSearchParams{TagNames: []string{bird, branch}, MatchAny: true}))
+
+	minConfidence := 0.5
+	require.Equal(t, []uuid.UUID{both}, search(TagSearchParams{TagNames: []string{bird}, MinConfidence: &minConfidence}))
+
+	manual := TagSourceGroupManual
+	require.Equal(t, []uuid.UUID{both}, search(TagSearchParams{TagNames: []string{branch}, Source: &manual}))
+	ai := TagSourceGroupAI
+	require.Equal(t, []uuid.UUID{branchOnly}, search(TagSearchParams{TagNames: []string{branch}, Source: &ai}))
+}
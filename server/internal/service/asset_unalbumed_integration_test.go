@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"server/internal/db/dbtest"
+	"server/internal/db/repo"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListUnalbumedAssetsPostgresIntegration(t *testing.T) {
+	ctx := context.Background()
+	pool := dbtest.Pool(t, os.Getenv(dbtest.DatabaseURLEnv))
+
+	suffix := strings.ReplaceAll(uuid.NewString(), "-", "")[:12]
+	username := "unalbumed_" + suffix
+	var userID int32
+	require.NoError(t, pool.QueryRow(ctx, `
+		INSERT INTO users (username, password, webauthn_user_handle)
+		VALUES ($1, 'unused', $2)
+		RETURNING user_id`, username, []byte(username)).Scan(&userID))
+	t.Cleanup(func() {
+		_, _ = pool.Exec(ctx, `DELETE FROM albums WHERE user_id = $1`, userID)
+		_, _ = pool.Exec(ctx, `DELETE FROM assets WHERE original_filename LIKE $1`, username+"%")
+		_, _ = pool.Exec(ctx, `DELETE FROM users WHERE user_id = $1`, userID)
+	})
+
+	insertAsset := func(name string, deleted bool) uuid.UUID {
+		var assetID uuid.UUID
+		require.NoError(t, pool.QueryRow(ctx, `
+			INSERT INTO assets (type, original_filename, mime_type, file_size, content_hash, owner_id, is_deleted)
+			VALUES ('PHOTO', $1, 'image/jpeg', 1024, $2, $3, $4)
+			RETURNING asset_id`, username+"_"+name+".jpg", suffix+name, userID, deleted).Scan(&assetID))
+		return assetID
+	}
+	inAlbum := insertAsset("in_album", false)
+	orphan := insertAsset("orphan", false)
+	otherOrphan := insertAsset("other_orphan", false)
+	insertAsset("trashed", true)
+
+	var albumID int32
+	require.NoError(t, pool.QueryRow(ctx, `
+		INSERT INTO albums (user_id, album_name) VALUES ($1, 'Trip')
+		RETURNING album_id`, userID).Scan(&albumID))
+	_, err := pool.Exec(ctx, `INSERT INTO album_assets (album_id, asset_id, position) VALUES ($1, $2, 1)`, albumID, inAlbum)
+	require.NoError(t, err)
+
+	svc := &assetService{queries: repo.New(pool)}
+	assets, err := svc.ListUnalbumedAssets(ctx, &userID, nil, 50, 0)
+	require.NoError(t, err)
+	var ids []uuid.UUID
+	for _, asset := range assets {
+		ids = append(ids, uuid.UUID(asset.AssetID.Bytes))
+	}
+	require.ElementsMatch(t, []uuid.UUID{orphan, otherOrphan}, ids, "album members and trashed assets are excluded")
+
+	page, err := svc.ListUnalbumedAssets(ctx, &userID, nil, 1, 1)
+	require.NoError(t, err)
+	require.Len(t, page, 1)
+
+	otherRepository := uuid.NewString()
+	scoped, err := svc.ListUnalbumedAssets(ctx, &userID, &otherRepository, 50, 0)
+	require.NoError(t, err)
+	require.Empty(t, scoped)
+
+	invalid := "not-a-uuid"
+	_, err = svc.ListUnalbumedAssets(ctx, &userID, &invalid, 50, 0)
+	require.Error(t, err)
+}
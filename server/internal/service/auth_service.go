@@ -32,6 +32,7 @@ var (
 	ErrUserAlreadyExists          = errors.New("user already exists")
 	ErrPasswordChangeRequired     = errors.New("password change required")
 	ErrInvalidPasswordChangeToken = errors.New("invalid password change token")
+	ErrInvalidAssetDownloadToken  = errors.New("invalid asset download token")
 )
 
 // Request/Response types
@@ -106,6 +107,7 @@ type AuthService struct {
 	mfaTokenSecret            []byte
 	passkeyTokenSecret        []byte
 	mediaTokenSecret          []byte
+	assetDownloadTokenSecret  []byte
 	passwordChangeTokenSecret []byte
 	mfaEncryptKey             []byte
 	accessTokenTTL            time.Duration
@@ -158,6 +160,7 @@ func NewAuthService(queries *repo.Queries, db *pgxpool.Pool, cfg config.AuthConf
 	mfaTokenSecret := secretbox.DeriveScopedSecret(rootSecret, "mfa.signing.v1")
 	passkeyTokenSecret := secretbox.DeriveScopedSecret(rootSecret, "passkey.signing.v1")
 	mediaTokenSecret := secretbox.DeriveScopedSecret(rootSecret, "media.url.signing.v1")
+	assetDownloadTokenSecret := secretbox.DeriveScopedSecret(rootSecret, "asset.download.signing.v1")
 	passwordChangeTokenSecret := secretbox.DeriveScopedSecret(rootSecret, "password.change.signing.v1")
 	mfaEncryptKey := secretbox.DeriveScopedSecret(rootSecret, "mfa.encryption.v1")
 
@@ -168,6 +171,7 @@ func NewAuthService(queries *repo.Queries, db *pgxpool.Pool, cfg config.AuthConf
 		mfaTokenSecret:            mfaTokenSecret,
 		passkeyTokenSecret:        passkeyTokenSecret,
 		mediaTokenSecret:          mediaTokenSecret,
+		assetDownloadTokenSecret:  assetDownloadTokenSecret,
 		passwordChangeTokenSecret: passwordChangeTokenSecret,
 		mfaEncryptKey:             mfaEncryptKey,
 		accessTokenTTL:            cfg.AccessTokenTTL,
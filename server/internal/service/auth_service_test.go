@@ -10,6 +10,7 @@ import (
 	"server/internal/db/repo"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 )
 
@@ -97,3 +98,44 @@ func TestRequiredPasswordChangeTokenRejectsWrongPurposeAndSigningMethod(t *testi
 	_, err = svc.parsePasswordChangeToken(token)
 	require.ErrorIs(t, err, ErrInvalidPasswordChangeToken)
 }
+
+func TestAssetDownloadTokenCarriesAssetAndRejectsTamperingAndExpiry(t *testing.T) {
+	svc, err := NewAuthService(nil, nil, config.AuthConfig{SecretKeyFile: filepath.Join(t.TempDir(), "secret")})
+	require.NoError(t, err)
+
+	assetID := uuid.New()
+	token, expiresAt, err := svc.GenerateAssetDownloadToken(assetID, time.Hour)
+	require.NoError(t, err)
+	require.WithinDuration(t, time.Now().Add(time.Hour), expiresAt, 2*time.Second)
+
+	got, gotExpiresAt, err := svc.ValidateAssetDownloadToken(token)
+	require.NoError(t, err)
+	require.Equal(t, assetID, got)
+	require.WithinDuration(t, expiresAt, gotExpiresAt, time.Second)
+
+	// Flipping a signature character breaks the HMAC.
+	tampered := []byte(token)
+	last := len(tampered) - 2
+	if tampered[last] == 'A' {
+		tampered[last] = 'B'
+	} else {
+		tampered[last] = 'A'
+	}
+	_, _, err = svc.ValidateAssetDownloadToken(string(tampered))
+	require.ErrorIs(t, err, ErrInvalidAssetDownloadToken)
+
+	expired, _, err := svc.GenerateAssetDownloadToken(assetID, -time.Minute)
+	require.NoError(t, err)
+	_, _, err = svc.ValidateAssetDownloadToken(expired)
+	require.ErrorIs(t, err, ErrInvalidAssetDownloadToken)
+
+	// A media token is signed with a different key and never grants a download.
+	now := time.Now()
+	media, err := jwt.NewWithClaims(jwt.SigningMethodHS256, assetDownloadClaims{
+		AssetID: assetID.String(), Purpose: assetDownloadTokenPurpose,
+		RegisteredClaims: jwt.RegisteredClaims{ExpiresAt: jwt.NewNumericDate(now.Add(time.Minute))},
+	}).SignedString(svc.mediaTokenSecret)
+	require.NoError(t, err)
+	_, _, err = svc.ValidateAssetDownloadToken(media)
+	require.ErrorIs(t, err, ErrInvalidAssetDownloadToken)
+}
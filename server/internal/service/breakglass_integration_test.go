@@ -10,10 +10,10 @@ import (
 	"time"
 
 	"server/config"
+	"server/internal/db/dbtest"
 	"server/internal/db/repo"
 
 	"github.com/google/uuid"
-	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/stretchr/testify/require"
 	"golang.org/x/crypto/bcrypt"
 )
@@ -22,16 +22,8 @@ import (
 // committed transactions against a real, already-migrated PostgreSQL database.
 // Point the environment variable at an isolated disposable database.
 func TestBreakGlassPostgresIntegration(t *testing.T) {
-	databaseURL := strings.TrimSpace(os.Getenv("LUMILIO_BREAK_GLASS_TEST_DATABASE_URL"))
-	if databaseURL == "" {
-		t.Skip("set LUMILIO_BREAK_GLASS_TEST_DATABASE_URL to an isolated migrated PostgreSQL database")
-	}
-
 	ctx := context.Background()
-	pool, err := pgxpool.New(ctx, databaseURL)
-	require.NoError(t, err)
-	t.Cleanup(pool.Close)
-	require.NoError(t, pool.Ping(ctx))
+	pool := dbtest.Pool(t, os.Getenv(dbtest.DatabaseURLEnv))
 
 	suffix := strings.ReplaceAll(uuid.NewString(), "-", "")[:12]
 	oldAdmin := "bg_old_" + suffix
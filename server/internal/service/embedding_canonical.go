@@ -1,6 +1,10 @@
 package service
 
-import "math"
+import (
+	"errors"
+	"fmt"
+	"math"
+)
 
 // CanonicalEmbeddingDim is the fixed storage/query dimension for semantic
 // (SigLIP2) vectors. SigLIP2 is Matryoshka-trained, so a model's output can be
@@ -10,6 +14,22 @@ import "math"
 // column migration). base is natively 768; so400m (1152) truncates to it.
 const CanonicalEmbeddingDim = 768
 
+// ErrEmbeddingDimensionMismatch is returned when a semantic vector cannot be
+// stored in the fixed-dimension search_embeddings column.
+var ErrEmbeddingDimensionMismatch = errors.New("embedding dimension does not match the semantic search index")
+
+// validateSemanticDimension rejects a canonicalized semantic vector whose
+// length is not the index dimension. Longer model outputs are already
+// truncated by canonicalizeSemanticVector, so this only trips for a model
+// whose native dimension is below CanonicalEmbeddingDim; storing its vectors
+// would fail in pgvector and leave them incomparable with the rest anyway.
+func validateSemanticDimension(vec []float32) error {
+	if len(vec) != CanonicalEmbeddingDim {
+		return fmt.Errorf("%w: got %d dimensions, index expects %d", ErrEmbeddingDimensionMismatch, len(vec), CanonicalEmbeddingDim)
+	}
+	return nil
+}
+
 // canonicalizeSemanticVector maps a raw model embedding into the canonical
 // semantic space: truncate to the leading CanonicalEmbeddingDim components, then
 // L2-normalize. Every semantic embed site — stored image vectors, text queries,
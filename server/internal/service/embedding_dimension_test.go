@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"server/internal/db/repo"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestSaveEmbeddingRejectsSemanticDimensionMismatch(t *testing.T) {
+	// A model below the canonical dimension cannot be truncated up to it. The
+	// save must fail before touching the database (pool is nil here).
+	svc := &embeddingService{}
+	short := make([]float32, CanonicalEmbeddingDim/2)
+	short[0] = 1
+
+	err := svc.SaveEmbedding(context.Background(), pgtype.UUID{Valid: true}, EmbeddingTypeSemantic, "small-model", short, true)
+	if !errors.Is(err, ErrEmbeddingDimensionMismatch) {
+		t.Fatalf("expected ErrEmbeddingDimensionMismatch, got %v", err)
+	}
+}
+
+func TestValidateSemanticDimension(t *testing.T) {
+	if err := validateSemanticDimension(canonicalizeSemanticVector(make([]float32, CanonicalEmbeddingDim+384))); err != nil {
+		t.Fatalf("truncated vector rejected: %v", err)
+	}
+	if err := validateSemanticDimension(make([]float32, CanonicalEmbeddingDim)); err != nil {
+		t.Fatalf("canonical vector rejected: %v", err)
+	}
+	if err := validateSemanticDimension(make([]float32, 512)); !errors.Is(err, ErrEmbeddingDimensionMismatch) {
+		t.Fatalf("expected ErrEmbeddingDimensionMismatch for 512 dims, got %v", err)
+	}
+}
+
+type semanticDimensionQuerierStub struct {
+	columnDim int32
+	space     *repo.EmbeddingSpace
+}
+
+func (s semanticDimensionQuerierStub) GetSearchEmbeddingColumnDimension(context.Context) (int32, error) {
+	return s.columnDim, nil
+}
+
+func (s semanticDimensionQuerierStub) GetDefaultEmbeddingSpaceByType(context.Context, string) (repo.EmbeddingSpace, error) {
+	if s.space == nil {
+		return repo.EmbeddingSpace{}, pgx.ErrNoRows
+	}
+	return *s.space, nil
+}
+
+func TestLogSemanticDimensionCheck(t *testing.T) {
+	tests := []struct {
+		name  string
+		stub  semanticDimensionQuerierStub
+		level zapcore.Level
+	}{
+		{name: "matching column", stub: semanticDimensionQuerierStub{columnDim: CanonicalEmbeddingDim}, level: zapcore.InfoLevel},
+		{name: "mismatched column", stub: semanticDimensionQuerierStub{columnDim: 512}, level: zapcore.ErrorLevel},
+		{
+			name:  "stale model space",
+			stub:  semanticDimensionQuerierStub{columnDim: CanonicalEmbeddingDim, space: &repo.EmbeddingSpace{ModelID: "old", Dimensions: 512}},
+			level: zapcore.WarnLevel,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			core, logs := observer.New(zapcore.DebugLevel)
+			LogSemanticDimensionCheck(context.Background(), tt.stub, zap.New(core))
+			entries := logs.All()
+			if len(entries) != 1 || entries[0].Level != tt.level {
+				t.Fatalf("expected one %s entry, got %+v", tt.level, entries)
+			}
+			if got := entries[0].ContextMap()["column_dimensions"]; got != tt.stub.columnDim {
+				t.Fatalf("column_dimensions = %v, want %d", got, tt.stub.columnDim)
+			}
+		})
+	}
+}
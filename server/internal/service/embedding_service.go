@@ -12,6 +12,7 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/pgvector/pgvector-go"
+	"go.uber.org/zap"
 )
 
 const embeddingDistanceMetricL2 = "l2"
@@ -64,6 +65,52 @@ func NewEmbeddingService(queries *repo.Queries, pool *pgxpool.Pool) EmbeddingSer
 	}
 }
 
+// semanticDimensionQuerier is the part of repo.Queries the startup dimension
+// check reads.
+type semanticDimensionQuerier interface {
+	GetSearchEmbeddingColumnDimension(ctx context.Context) (int32, error)
+	GetDefaultEmbeddingSpaceByType(ctx context.Context, embeddingType string) (repo.EmbeddingSpace, error)
+}
+
+// LogSemanticDimensionCheck compares the search_embeddings column dimension
+// with the dimension semantic vectors are canonicalized to, and logs the
+// active model's recorded space next to them. A column that disagrees with
+// CanonicalEmbeddingDim means every semantic save and ANN query will fail
+// until the schema is migrated, so it is logged as an error; the server still
+// starts so the rest of the library stays usable.
+func LogSemanticDimensionCheck(ctx context.Context, queries semanticDimensionQuerier, logger *zap.Logger) {
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+	columnDim, err := queries.GetSearchEmbeddingColumnDimension(ctx)
+	if err != nil {
+		logger.Warn("could not read semantic search column dimension", zap.Error(err))
+		return
+	}
+
+	fields := []zap.Field{
+		zap.Int32("column_dimensions", columnDim),
+		zap.Int("expected_dimensions", CanonicalEmbeddingDim),
+	}
+	space, err := queries.GetDefaultEmbeddingSpaceByType(ctx, string(EmbeddingTypeSemantic))
+	switch {
+	case err == nil:
+		fields = append(fields, zap.String("model", space.ModelID), zap.Int32("model_dimensions", space.Dimensions))
+	case !errors.Is(err, pgx.ErrNoRows):
+		logger.Warn("could not read default semantic embedding space", zap.Error(err))
+	}
+
+	if int(columnDim) != CanonicalEmbeddingDim {
+		logger.Error("semantic search column dimension does not match the canonical embedding dimension; semantic indexing and search will fail", fields...)
+		return
+	}
+	if space.ModelID != "" && int(space.Dimensions) != CanonicalEmbeddingDim {
+		logger.Warn("default semantic embedding space was recorded with a different dimension; re-index semantic embeddings", fields...)
+		return
+	}
+	logger.Info("semantic search dimension check passed", fields...)
+}
+
 // SaveEmbedding saves any type of embedding with specified primary status.
 func (e *embeddingService) SaveEmbedding(ctx context.Context, assetID pgtype.UUID, embeddingType EmbeddingType, model string, vector []float32, isPrimary bool) error {
 	if len(vector) == 0 {
@@ -76,6 +123,9 @@ func (e *embeddingService) SaveEmbedding(ctx context.Context, assetID pgtype.UUI
 	// (e.g. pHash) carry their own semantics and are stored verbatim.
 	if embeddingType == EmbeddingTypeSemantic {
 		vector = canonicalizeSemanticVector(vector)
+		if err := validateSemanticDimension(vector); err != nil {
+			return fmt.Errorf("model %s: %w", model, err)
+		}
 	}
 
 	tx, err := e.pool.Begin(ctx)
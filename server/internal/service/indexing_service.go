@@ -8,6 +8,7 @@ import (
 	"path/filepath"
 	"sort"
 	"strings"
+	"time"
 
 	"server/internal/db/repo"
 	"server/internal/logging"
@@ -56,6 +57,12 @@ type ReindexAssetsInput struct {
 	Limit        int
 	Offset       int
 	MissingOnly  bool
+	// DateFrom and DateTo bound the rebuild by capture time, falling back to
+	// upload time, inclusive on both ends.
+	DateFrom *time.Time
+	DateTo   *time.Time
+	// DryRun counts the matching photos without queueing anything.
+	DryRun bool
 	// ResetSemantic wipes all semantic vectors and demotes the default embedding
 	// space before rebuilding. Used for a model swap (drop+refill). Honored only
 	// on the first page (Offset == 0) when the semantic task is enabled.
@@ -78,6 +85,10 @@ type ReindexAssetsJobResult struct {
 	Limit        int
 	MissingOnly  bool
 	RepositoryID *string
+	// Matching is how many photos the rebuild visits. Missing-only runs queue
+	// at most Limit of them per request.
+	Matching int64
+	DryRun   bool
 }
 
 type AssetIndexingService interface {
@@ -261,28 +272,71 @@ func (s *assetIndexingService) EnqueueReindexAssets(ctx context.Context, input R
 			Limit:        input.Limit,
 			MissingOnly:  input.MissingOnly,
 			RepositoryID: input.RepositoryID,
+			DryRun:       input.DryRun,
 		}, nil
 	}
 
+	repositoryUUID, err := parseRepositoryUUID(input.RepositoryID)
+	if err != nil {
+		return ReindexAssetsJobResult{}, err
+	}
+	matching, err := s.queries.CountPhotoAssetsForReindex(ctx, reindexCountParams(repositoryUUID, enabledTasks, input))
+	if err != nil {
+		return ReindexAssetsJobResult{}, fmt.Errorf("count photos for reindex: %w", err)
+	}
+
+	result := ReindexAssetsJobResult{
+		Requested:    enabledTasks,
+		Disabled:     disabledTasks,
+		Limit:        input.Limit,
+		MissingOnly:  input.MissingOnly,
+		RepositoryID: input.RepositoryID,
+		Matching:     matching,
+		DryRun:       input.DryRun,
+	}
+	if input.DryRun {
+		return result, nil
+	}
+
 	jobResult, err := s.queueClient.Insert(ctx, jobs.ReindexAssetsArgs{
 		RepositoryID:  input.RepositoryID,
 		Tasks:         indexingTasksToStrings(enabledTasks),
 		Limit:         input.Limit,
 		MissingOnly:   input.MissingOnly,
 		ResetSemantic: input.ResetSemantic,
+		DateFrom:      input.DateFrom,
+		DateTo:        input.DateTo,
 	}, &river.InsertOpts{Queue: "reindex_assets"})
 	if err != nil {
 		return ReindexAssetsJobResult{}, fmt.Errorf("enqueue reindex job: %w", err)
 	}
+	result.JobID = jobResult.Job.ID
+	return result, nil
+}
 
-	return ReindexAssetsJobResult{
-		JobID:        jobResult.Job.ID,
-		Requested:    enabledTasks,
-		Disabled:     disabledTasks,
-		Limit:        input.Limit,
+// reindexCountParams mirrors the candidate selection of
+// collectReindexCandidates as a single count.
+func reindexCountParams(repositoryUUID pgtype.UUID, tasks []AssetIndexingTask, input ReindexAssetsInput) repo.CountPhotoAssetsForReindexParams {
+	dateFrom, dateTo := reindexDateBounds(input)
+	return repo.CountPhotoAssetsForReindexParams{
+		RepositoryID: repositoryUUID,
+		DateFrom:     dateFrom,
+		DateTo:       dateTo,
 		MissingOnly:  input.MissingOnly,
-		RepositoryID: input.RepositoryID,
-	}, nil
+		Semantic:     containsIndexingTask(tasks, AssetIndexingTaskSemanticImage),
+		Ocr:          containsIndexingTask(tasks, AssetIndexingTaskOCR),
+		Face:         containsIndexingTask(tasks, AssetIndexingTaskFaceRecognition),
+	}
+}
+
+func reindexDateBounds(input ReindexAssetsInput) (from, to pgtype.Timestamptz) {
+	if input.DateFrom != nil {
+		from = pgtype.Timestamptz{Time: *input.DateFrom, Valid: true}
+	}
+	if input.DateTo != nil {
+		to = pgtype.Timestamptz{Time: *input.DateTo, Valid: true}
+	}
+	return from, to
 }
 
 func (s *assetIndexingService) ProcessReindexAssets(ctx context.Context, input ReindexAssetsInput) error {
@@ -393,6 +447,8 @@ func (s *assetIndexingService) ProcessReindexAssets(ctx context.Context, input R
 			Limit:        input.Limit,
 			Offset:       nextOffset,
 			MissingOnly:  false,
+			DateFrom:     input.DateFrom,
+			DateTo:       input.DateTo,
 		}, &river.InsertOpts{Queue: "reindex_assets"}); err != nil {
 			s.logger.Warn("reindex failed to enqueue next page",
 				zap.String("operation", "reindex.process"),
@@ -449,11 +505,14 @@ func (s *assetIndexingService) collectReindexCandidates(
 		candidate.tasks[task] = true
 	}
 
+	dateFrom, dateTo := reindexDateBounds(input)
 	if !input.MissingOnly {
 		assets, err := s.queries.ListPhotoAssetsForIndexingBatch(ctx, repo.ListPhotoAssetsForIndexingBatchParams{
 			RepositoryID: repositoryUUID,
 			Limit:        int32(input.Limit),
 			Offset:       int32(input.Offset),
+			DateFrom:     dateFrom,
+			DateTo:       dateTo,
 		})
 		if err != nil {
 			return nil, fmt.Errorf("list photo assets for indexing: %w", err)
@@ -465,7 +524,7 @@ func (s *assetIndexingService) collectReindexCandidates(
 		}
 	} else {
 		for _, task := range tasks {
-			assets, err := s.listMissingAssetsForTask(ctx, repositoryUUID, task, input.Limit)
+			assets, err := s.listMissingAssetsForTask(ctx, repositoryUUID, task, input.Limit, dateFrom, dateTo)
 			if err != nil {
 				return nil, err
 			}
@@ -491,6 +550,7 @@ func (s *assetIndexingService) listMissingAssetsForTask(
 	repositoryUUID pgtype.UUID,
 	task AssetIndexingTask,
 	limit int,
+	dateFrom, dateTo pgtype.Timestamptz,
 ) ([]repo.Asset, error) {
 	switch task {
 	case AssetIndexingTaskSemanticImage:
@@ -498,18 +558,24 @@ func (s *assetIndexingService) listMissingAssetsForTask(
 			RepositoryID: repositoryUUID,
 			Limit:        int32(limit),
 			Offset:       0,
+			DateFrom:     dateFrom,
+			DateTo:       dateTo,
 		})
 	case AssetIndexingTaskOCR:
 		return s.queries.ListPhotoAssetsMissingOCRResults(ctx, repo.ListPhotoAssetsMissingOCRResultsParams{
 			RepositoryID: repositoryUUID,
 			Limit:        int32(limit),
 			Offset:       0,
+			DateFrom:     dateFrom,
+			DateTo:       dateTo,
 		})
 	case AssetIndexingTaskFaceRecognition:
 		return s.queries.ListPhotoAssetsMissingFaceResults(ctx, repo.ListPhotoAssetsMissingFaceResultsParams{
 			RepositoryID: repositoryUUID,
 			Limit:        int32(limit),
 			Offset:       0,
+			DateFrom:     dateFrom,
+			DateTo:       dateTo,
 		})
 	default:
 		return nil, fmt.Errorf("unsupported indexing task: %s", task)
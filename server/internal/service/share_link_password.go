@@ -0,0 +1,63 @@
+package service
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+
+	"server/internal/db/repo"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Share passwords gate viewing on top of the token and are stored as bcrypt
+// hashes. A viewer presents the password once and gets back an unlock proof,
+// an HMAC over the token and the stored hash, which later requests (thumbnail
+// loads, mostly) present instead, so bcrypt runs once per visit rather than
+// once per image. Changing the password or the token invalidates old proofs.
+
+// shareLinkPasswordMaxBytes is bcrypt's input limit; longer passwords would
+// be silently truncated.
+const shareLinkPasswordMaxBytes = 72
+
+func hashShareLinkPassword(password string) (*string, error) {
+	if password == "" {
+		return nil, nil
+	}
+	if len(password) > shareLinkPasswordMaxBytes {
+		return nil, fmt.Errorf("%w: must be at most %d bytes", ErrShareLinkInvalidPassword, shareLinkPasswordMaxBytes)
+	}
+	hashed, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, fmt.Errorf("hash share password: %w", err)
+	}
+	encoded := string(hashed)
+	return &encoded, nil
+}
+
+func (s *shareLinkService) unlockProof(link repo.ShareLink, rawToken string) string {
+	mac := hmac.New(sha256.New, s.hmacKey)
+	mac.Write([]byte("unlock\x00"))
+	mac.Write([]byte(rawToken))
+	mac.Write([]byte{0})
+	mac.Write([]byte(*link.PasswordHash))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// UnlockPublic lets a viewer into a resolved share. Open shares need nothing
+// and return an empty proof. Protected shares accept a proof from an earlier
+// unlock, else the password, and return the proof to hand back to the viewer.
+func (s *shareLinkService) UnlockPublic(link repo.ShareLink, rawToken, password, proof string) (string, error) {
+	if link.PasswordHash == nil {
+		return "", nil
+	}
+	want := s.unlockProof(link, rawToken)
+	if proof != "" && hmac.Equal([]byte(proof), []byte(want)) {
+		return want, nil
+	}
+	if password == "" || bcrypt.CompareHashAndPassword([]byte(*link.PasswordHash), []byte(password)) != nil {
+		return "", ErrShareLinkLocked
+	}
+	return want, nil
+}
@@ -0,0 +1,51 @@
+package service
+
+import (
+	"testing"
+
+	"server/internal/db/repo"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestShareLinkUnlockPublic(t *testing.T) {
+	s := &shareLinkService{hmacKey: []byte("test-key")}
+
+	open := repo.ShareLink{}
+	proof, err := s.UnlockPublic(open, "tok", "", "")
+	require.NoError(t, err)
+	require.Empty(t, proof, "open shares need no proof")
+
+	hash, err := hashShareLinkPassword("correct horse")
+	require.NoError(t, err)
+	locked := repo.ShareLink{PasswordHash: hash}
+
+	_, err = s.UnlockPublic(locked, "tok", "", "")
+	require.ErrorIs(t, err, ErrShareLinkLocked)
+	_, err = s.UnlockPublic(locked, "tok", "wrong", "")
+	require.ErrorIs(t, err, ErrShareLinkLocked)
+
+	proof, err = s.UnlockPublic(locked, "tok", "correct horse", "")
+	require.NoError(t, err)
+	require.NotEmpty(t, proof)
+
+	again, err := s.UnlockPublic(locked, "tok", "", proof)
+	require.NoError(t, err)
+	require.Equal(t, proof, again)
+	_, err = s.UnlockPublic(locked, "other-token", "", proof)
+	require.ErrorIs(t, err, ErrShareLinkLocked, "a proof only unlocks the share it was issued for")
+
+	rehashed, err := hashShareLinkPassword("correct horse")
+	require.NoError(t, err)
+	_, err = s.UnlockPublic(repo.ShareLink{PasswordHash: rehashed}, "tok", "", proof)
+	require.ErrorIs(t, err, ErrShareLinkLocked, "resetting the password invalidates old proofs")
+}
+
+func TestHashShareLinkPassword(t *testing.T) {
+	hash, err := hashShareLinkPassword("")
+	require.NoError(t, err)
+	require.Nil(t, hash)
+
+	_, err = hashShareLinkPassword(string(make([]byte, 73)))
+	require.ErrorIs(t, err, ErrShareLinkInvalidPassword)
+}
@@ -42,6 +42,11 @@ var (
 	// ErrShareLinkInvalidSource wraps source_kind/source_ref validation
 	// failures so handlers can map them to 400 instead of 500.
 	ErrShareLinkInvalidSource = errors.New("invalid share source")
+	// ErrShareLinkInvalidPassword rejects a password that can't be stored.
+	ErrShareLinkInvalidPassword = errors.New("invalid share password")
+	// ErrShareLinkLocked is returned by UnlockPublic for a password-protected
+	// share when no valid password or unlock proof was presented.
+	ErrShareLinkLocked = errors.New("share link is password protected")
 )
 
 // ShareLinkCreateParams collects the inputs needed to resolve a source and
@@ -61,6 +66,8 @@ type ShareLinkCreateParams struct {
 	ExpiresInDays    int
 	AllowDownload    bool
 	IncludeOriginals bool
+	// Password, when non-empty, is required before the share can be viewed.
+	Password string
 }
 
 // ShareLinkUpdateParams is a partial patch to a share link's settings.
@@ -83,10 +90,15 @@ type ShareLinkService interface {
 	UpdateSettings(ctx context.Context, ownerID int32, shareID uuid.UUID, params ShareLinkUpdateParams) (repo.ShareLink, error)
 	Revoke(ctx context.Context, ownerID int32, shareID uuid.UUID) (repo.ShareLink, error)
 	Delete(ctx context.Context, ownerID int32, shareID uuid.UUID) error
+	// GetByToken finds one of the owner's links by its raw token, whatever its
+	// status, so the owner can revoke a link they only hold the token of.
+	GetByToken(ctx context.Context, ownerID int32, rawToken string) (repo.ShareLink, error)
 
 	// ResolvePublic authorizes a raw share token: active status and
 	// non-expired only. Every public handler must call this first.
 	ResolvePublic(ctx context.Context, rawToken string) (repo.ShareLink, error)
+	// UnlockPublic checks a resolved share's password. See share_link_password.go.
+	UnlockPublic(link repo.ShareLink, rawToken, password, proof string) (string, error)
 	RecordView(ctx context.Context, shareID uuid.UUID) error
 	// PublicAssetSource wraps a resolved share's asset snapshot for reuse with
 	// AssetService.QueryBrowseItems, the same source-scoping mechanism pins use.
@@ -162,6 +174,11 @@ func (s *shareLinkService) resolveSourceAssetIDs(ctx context.Context, ownerID in
 		if err != nil {
 			return nil, err
 		}
+		// Smart albums have no membership rows; the caller evaluates their
+		// rules and passes the matches in.
+		if len(explicitIDs) > 0 {
+			return s.resolveExplicitAssetIDs(ctx, ownerScope, explicitIDs)
+		}
 		return s.resolveByQuery(ctx, QueryAssetsParams{OwnerID: ownerScope, AlbumID: &albumID, SortBy: "date_captured", Limit: ShareLinkMaxAssets})
 	case "person":
 		personID, err := parseInt32Ref(ref)
@@ -269,6 +286,11 @@ func clampExpiryDays(days int) int {
 }
 
 func (s *shareLinkService) Create(ctx context.Context, params ShareLinkCreateParams) (repo.ShareLink, string, error) {
+	passwordHash, err := hashShareLinkPassword(params.Password)
+	if err != nil {
+		return repo.ShareLink{}, "", err
+	}
+
 	assetIDs, err := s.resolveSourceAssetIDs(ctx, params.OwnerID, params.OwnerScope, params.SourceKind, params.SourceRef, params.ExplicitAssetIDs)
 	if err != nil {
 		return repo.ShareLink{}, "", err
@@ -298,6 +320,7 @@ func (s *shareLinkService) Create(ctx context.Context, params ShareLinkCreatePar
 		AllowDownload:    params.AllowDownload,
 		IncludeOriginals: params.IncludeOriginals,
 		ExpiresAt:        pgtype.Timestamptz{Time: expiresAt, Valid: true},
+		PasswordHash:     passwordHash,
 	})
 	if err != nil {
 		return repo.ShareLink{}, "", err
@@ -424,6 +447,20 @@ func (s *shareLinkService) Delete(ctx context.Context, ownerID int32, shareID uu
 	return nil
 }
 
+func (s *shareLinkService) GetByToken(ctx context.Context, ownerID int32, rawToken string) (repo.ShareLink, error) {
+	link, err := s.queries.GetShareLinkByTokenHash(ctx, repo.GetShareLinkByTokenHashParams{
+		TokenHash: s.hashToken(rawToken),
+		OwnerID:   ownerID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return repo.ShareLink{}, ErrShareLinkNotFound
+		}
+		return repo.ShareLink{}, err
+	}
+	return link, nil
+}
+
 func (s *shareLinkService) ResolvePublic(ctx context.Context, rawToken string) (repo.ShareLink, error) {
 	link, err := s.queries.GetActiveShareLinkByTokenHash(ctx, s.hashToken(rawToken))
 	if err != nil {
@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"server/internal/db/dbtest"
+	"server/internal/db/repo"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCleanupAITagsPostgresIntegration(t *testing.T) {
+	ctx := context.Background()
+	pool := dbtest.Pool(t, os.Getenv(dbtest.DatabaseURLEnv))
+
+	suffix := strings.ReplaceAll(uuid.NewString(), "-", "")[:12]
+	var assetID uuid.UUID
+	require.NoError(t, pool.QueryRow(ctx, `
+		INSERT INTO assets (type, original_filename, mime_type, file_size, content_hash)
+		VALUES ('PHOTO', $1, 'image/jpeg', 1024, $2)
+		RETURNING asset_id`, "cleanup_"+suffix+".jpg", suffix).Scan(&assetID))
+
+	tagNames := map[string]string{
+		"weak_ai":   "weak_ai_" + suffix,
+		"strong_ai": "strong_ai_" + suffix,
+		"weak_user": "weak_user_" + suffix,
+	}
+	names := make([]string, 0, len(tagNames))
+	for _, name := range tagNames {
+		names = append(names, name)
+	}
+	t.Cleanup(func() {
+		_, _ = pool.Exec(ctx, `DELETE FROM asset_tags WHERE asset_id = $1`, assetID)
+		_, _ = pool.Exec(ctx, `DELETE FROM tags WHERE tag_name = ANY($1)`, names)
+		_, _ = pool.Exec(ctx, `DELETE FROM assets WHERE asset_id = $1`, assetID)
+	})
+
+	attach := func(name string, aiGenerated bool, confidence float64, source string) {
+		var tagID int32
+		require.NoError(t, pool.QueryRow(ctx, `
+			INSERT INTO tags (tag_name, is_ai_generated) VALUES ($1, $2)
+			RETURNING tag_id`, name, aiGenerated).Scan(&tagID))
+		_, err := pool.Exec(ctx, `
+			INSERT INTO asset_tags (asset_id, tag_id, confidence, source)
+			VALUES ($1, $2, $3, $4)`, assetID, tagID, confidence, source)
+		require.NoError(t, err)
+	}
+	attach(tagNames["weak_ai"], true, 0.12, AssetTagSourceZeroshot)
+	attach(tagNames["strong_ai"], true, 0.91, AssetTagSourceZeroshot)
+	attach(tagNames["weak_user"], false, 0.10, AssetTagSourceUser)
+
+	svc := &assetService{queries: repo.New(pool)}
+	result, err := svc.CleanupAITags(ctx, 0.5, true)
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, result.RemovedAssetTags, int64(1))
+	require.GreaterOrEqual(t, result.RemovedTags, int64(1))
+
+	rows, err := pool.Query(ctx, `
+		SELECT t.tag_name FROM asset_tags at
+		JOIN tags t ON t.tag_id = at.tag_id
+		WHERE at.asset_id = $1
+		ORDER BY t.tag_name`, assetID)
+	require.NoError(t, err)
+	var remaining []string
+	for rows.Next() {
+		var name string
+		require.NoError(t, rows.Scan(&name))
+		remaining = append(remaining, name)
+	}
+	require.NoError(t, rows.Err())
+	require.ElementsMatch(t, []string{tagNames["strong_ai"], tagNames["weak_user"]}, remaining)
+
+	var orphanCount int
+	require.NoError(t, pool.QueryRow(ctx, `SELECT COUNT(*) FROM tags WHERE tag_name = $1`, tagNames["weak_ai"]).Scan(&orphanCount))
+	require.Zero(t, orphanCount, "orphaned AI tag definition should be removed")
+}
+
+func TestCleanupAITagsRejectsOutOfRangeThreshold(t *testing.T) {
+	svc := &assetService{}
+	for _, threshold := range []float64{0, -0.1, 1.01} {
+		_, err := svc.CleanupAITags(context.Background(), threshold, false)
+		require.Error(t, err)
+	}
+}
@@ -0,0 +1,74 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"server/internal/db/dbtest"
+	"server/internal/db/repo"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSaveNewThumbnailSharesIdenticalThumbnailsPostgresIntegration(t *testing.T) {
+	ctx := context.Background()
+	pool := dbtest.Pool(t, os.Getenv(dbtest.DatabaseURLEnv))
+
+	suffix := strings.ReplaceAll(uuid.NewString(), "-", "")[:12]
+	prefix := "thumbdedupe_" + suffix
+	t.Cleanup(func() {
+		_, _ = pool.Exec(ctx, `DELETE FROM thumbnails WHERE asset_id IN (SELECT asset_id FROM assets WHERE original_filename LIKE $1)`, prefix+"%")
+		_, _ = pool.Exec(ctx, `DELETE FROM assets WHERE original_filename LIKE $1`, prefix+"%")
+	})
+
+	insertAsset := func(name string) repo.Asset {
+		var assetID uuid.UUID
+		require.NoError(t, pool.QueryRow(ctx, `
+			INSERT INTO assets (type, original_filename, mime_type, file_size, content_hash)
+			VALUES ('PHOTO', $1, 'image/jpeg', 1024, $2)
+			RETURNING asset_id`, prefix+"_"+name+".jpg", suffix+name).Scan(&assetID))
+		asset, err := repo.New(pool).GetAssetByID(ctx, pgtype.UUID{Bytes: assetID, Valid: true})
+		require.NoError(t, err)
+		return asset
+	}
+	burstA := insertAsset("a")
+	burstB := insertAsset("b")
+
+	svc, err := NewAssetService(repo.New(pool), pool, nil, nil, true, true, SearchFusionWeights{})
+	require.NoError(t, err)
+	repoPath := t.TempDir()
+	thumbnailDir := filepath.Join(repoPath, ".lumilio/assets/thumbnails", "small")
+
+	// Different originals whose small thumbnails come out byte-identical.
+	require.NoError(t, svc.SaveNewThumbnail(ctx, repoPath, bytes.NewReader([]byte("same thumbnail")), &burstA, "small"))
+	require.NoError(t, svc.SaveNewThumbnail(ctx, repoPath, bytes.NewReader([]byte("same thumbnail")), &burstB, "small"))
+
+	thumbA, err := svc.GetThumbnailByAssetIDAndSize(ctx, uuid.UUID(burstA.AssetID.Bytes), "small")
+	require.NoError(t, err)
+	thumbB, err := svc.GetThumbnailByAssetIDAndSize(ctx, uuid.UUID(burstB.AssetID.Bytes), "small")
+	require.NoError(t, err)
+	require.NotEqual(t, thumbA.ThumbnailID, thumbB.ThumbnailID, "each asset keeps its own thumbnail row")
+	require.Equal(t, thumbA.StoragePath, thumbB.StoragePath, "identical thumbnails share one file")
+
+	entries, err := os.ReadDir(thumbnailDir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+
+	// Regenerating A moves it off the shared file, which B still uses.
+	shared := filepath.Join(repoPath, thumbA.StoragePath)
+	require.NoError(t, svc.SaveNewThumbnail(ctx, repoPath, bytes.NewReader([]byte("rotated thumbnail")), &burstA, "small"))
+	require.FileExists(t, shared)
+
+	// Once B moves too, nothing references the shared file and it is removed.
+	require.NoError(t, svc.SaveNewThumbnail(ctx, repoPath, bytes.NewReader([]byte("rotated thumbnail")), &burstB, "small"))
+	require.NoFileExists(t, shared)
+	entries, err = os.ReadDir(thumbnailDir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+}
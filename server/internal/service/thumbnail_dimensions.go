@@ -0,0 +1,21 @@
+package service
+
+import (
+	"bytes"
+	"image"
+	_ "image/png"
+
+	_ "golang.org/x/image/webp"
+)
+
+// thumbnailDimensions reads the pixel size from an encoded thumbnail's header.
+// Both are nil when the format is not recognised; the thumbnail is still
+// stored, it is just left out of srcset.
+func thumbnailDimensions(data []byte) (width, height *int32) {
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil || cfg.Width <= 0 || cfg.Height <= 0 {
+		return nil, nil
+	}
+	w, h := int32(cfg.Width), int32(cfg.Height)
+	return &w, &h
+}
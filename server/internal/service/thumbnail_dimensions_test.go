@@ -0,0 +1,45 @@
+package service
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/png"
+	"testing"
+)
+
+// losslessWebPHeader builds the header of a VP8L WebP of the given size,
+// which is all DecodeConfig reads.
+func losslessWebPHeader(width, height int) []byte {
+	bits := uint32(width-1) | uint32(height-1)<<14
+	chunk := []byte{0x2f, 0, 0, 0, 0}
+	binary.LittleEndian.PutUint32(chunk[1:], bits)
+
+	var buf bytes.Buffer
+	buf.WriteString("RIFF")
+	_ = binary.Write(&buf, binary.LittleEndian, uint32(4+8+len(chunk)))
+	buf.WriteString("WEBPVP8L")
+	_ = binary.Write(&buf, binary.LittleEndian, uint32(len(chunk)))
+	buf.Write(chunk)
+	return buf.Bytes()
+}
+
+func TestThumbnailDimensions(t *testing.T) {
+	width, height := thumbnailDimensions(losslessWebPHeader(400, 267))
+	if width == nil || height == nil || *width != 400 || *height != 267 {
+		t.Fatalf("webp dimensions = %v x %v, want 400 x 267", width, height)
+	}
+
+	var waveform bytes.Buffer
+	if err := png.Encode(&waveform, image.NewGray(image.Rect(0, 0, 1800, 140))); err != nil {
+		t.Fatal(err)
+	}
+	width, height = thumbnailDimensions(waveform.Bytes())
+	if width == nil || height == nil || *width != 1800 || *height != 140 {
+		t.Fatalf("png dimensions = %v x %v, want 1800 x 140", width, height)
+	}
+
+	if width, height = thumbnailDimensions([]byte("not an image")); width != nil || height != nil {
+		t.Fatalf("unrecognised data yielded %v x %v", width, height)
+	}
+}
@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"server/internal/db/repo"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/require"
+)
+
+// thumbnailReferencesDB answers CountThumbnailReferences with references; any
+// other query panics through the nil embedded DBTX.
+type thumbnailReferencesDB struct {
+	repo.DBTX
+	references *atomic.Int64
+}
+
+func (db thumbnailReferencesDB) QueryRow(context.Context, string, ...interface{}) pgx.Row {
+	return thumbnailReferencesRow{count: db.references.Load()}
+}
+
+type thumbnailReferencesRow struct{ count int64 }
+
+func (r thumbnailReferencesRow) Scan(dest ...any) error {
+	*dest[0].(*int64) = r.count
+	return nil
+}
+
+func TestReleaseThumbnailFileWaitsForNewReference(t *testing.T) {
+	repoPath := t.TempDir()
+	relPath := filepath.Join(".lumilio/assets/thumbnails", "small", "shared.webp")
+	fullPath := filepath.Join(repoPath, relPath)
+	require.NoError(t, os.MkdirAll(filepath.Dir(fullPath), 0o755))
+	require.NoError(t, os.WriteFile(fullPath, []byte("thumbnail"), 0o644))
+
+	var references atomic.Int64
+	svc := &assetService{queries: repo.New(thumbnailReferencesDB{references: &references})}
+
+	// Another asset is between reusing the shared file and recording it, as
+	// storeThumbnail would be; the last old reference is released meanwhile.
+	unlock := svc.thumbnailFiles.Lock(fullPath)
+	released := make(chan struct{})
+	go func() {
+		svc.releaseThumbnailFile(context.Background(), repoPath, relPath)
+		close(released)
+	}()
+
+	select {
+	case <-released:
+		t.Fatal("release counted references while a new one was being recorded")
+	case <-time.After(50 * time.Millisecond):
+	}
+	references.Store(1)
+	unlock()
+	<-released
+
+	require.FileExists(t, fullPath)
+}
@@ -0,0 +1,112 @@
+package sourcing
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"server/internal/db/dbtest"
+	"server/internal/db/dbtypes"
+	statusdb "server/internal/db/dbtypes/status"
+	"server/internal/db/repo"
+	"server/internal/service"
+	"server/internal/storage"
+	"server/internal/storage/repocfg"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/riverqueue/river"
+	"github.com/riverqueue/river/riverdriver/riverpgxv5"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeferredUploadsWaitForProcessPendingPostgresIntegration(t *testing.T) {
+	ctx := context.Background()
+	pool := dbtest.Pool(t, os.Getenv(dbtest.DatabaseURLEnv))
+
+	queries := repo.New(pool)
+	queueClient, err := river.NewClient(riverpgxv5.New(pool), &river.Config{})
+	require.NoError(t, err)
+	assetService, err := service.NewAssetService(queries, pool, nil, nil, true, true, service.SearchFusionWeights{})
+	require.NoError(t, err)
+
+	repoPath := t.TempDir()
+	require.NoError(t, storage.NewDirectoryManager().CreateStructure(repoPath))
+	config := repocfg.NewRepositoryConfig("Deferred " + uuid.NewString()[:8])
+	config.LocalSettings.ProcessingMode = repocfg.ProcessingModeManual
+	require.NoError(t, config.SaveConfigToFile(repoPath))
+
+	now := pgtype.Timestamptz{Time: time.Now(), Valid: true}
+	repository, err := queries.CreateRepository(ctx, repo.CreateRepositoryParams{
+		RepoID:    pgtype.UUID{Bytes: uuid.MustParse(config.ID), Valid: true},
+		Name:      config.Name,
+		Path:      repoPath,
+		Config:    *config,
+		Role:      dbtypes.RepoRoleRegular,
+		Status:    dbtypes.RepoStatusActive,
+		CreatedAt: now,
+		UpdatedAt: now,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_, _ = pool.Exec(ctx, `DELETE FROM river_job WHERE args->>'repoPath' = $1`, repoPath)
+		_, _ = pool.Exec(ctx, `DELETE FROM assets WHERE repository_id = $1`, repository.RepoID)
+		_, _ = pool.Exec(ctx, `DELETE FROM repositories WHERE repo_id = $1`, repository.RepoID)
+	})
+
+	stagingManager := storage.NewStagingManager()
+	materializer := NewSourceMaterializer(queries, stagingManager, queueClient, assetService, nil, nil)
+	upload := func(name, content string) *repo.Asset {
+		t.Helper()
+		staging, err := stagingManager.CreateStagingFile(repoPath, name)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(staging.Path, []byte(content), 0644))
+		asset, err := materializer.Materialize(ctx, IngestSource{
+			RepositoryID:     uuid.UUID(repository.RepoID.Bytes),
+			Kind:             IngestSourceUpload,
+			SourcePath:       staging.Path,
+			OriginalFilename: name,
+			Timestamp:        time.Now(),
+			ContentType:      "image/jpeg",
+		})
+		require.NoError(t, err)
+		require.NotNil(t, asset)
+		return asset
+	}
+	pipelineJobs := func() int {
+		t.Helper()
+		var count int
+		require.NoError(t, pool.QueryRow(ctx, `SELECT COUNT(*) FROM river_job WHERE args->>'repoPath' = $1`, repoPath).Scan(&count))
+		return count
+	}
+	assetState := func(assetID pgtype.UUID) statusdb.AssetState {
+		t.Helper()
+		asset, err := queries.GetAssetByID(ctx, assetID)
+		require.NoError(t, err)
+		current, err := statusdb.FromJSONB(asset.Status)
+		require.NoError(t, err)
+		return current.State
+	}
+
+	first := upload("deferred_a.jpg", "first photo")
+	second := upload("deferred_b.jpg", "second photo")
+	require.Zero(t, pipelineJobs(), "deferred uploads queue no processing")
+	require.Equal(t, statusdb.StateDeferred, assetState(first.AssetID))
+	require.Equal(t, statusdb.StateDeferred, assetState(second.AssetID))
+
+	queued, err := materializer.ProcessDeferredAssets(ctx, uuid.UUID(repository.RepoID.Bytes))
+	require.NoError(t, err)
+	require.Equal(t, 2, queued)
+	// Metadata and thumbnail jobs for each photo.
+	require.Equal(t, 4, pipelineJobs())
+	require.Equal(t, statusdb.StateProcessing, assetState(first.AssetID))
+
+	queued, err = materializer.ProcessDeferredAssets(ctx, uuid.UUID(repository.RepoID.Bytes))
+	require.NoError(t, err)
+	require.Zero(t, queued, "processed assets are no longer pending")
+
+	_, err = materializer.ProcessDeferredAssets(ctx, uuid.New())
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+}
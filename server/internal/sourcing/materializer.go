@@ -34,6 +34,9 @@ const (
 	TaskMetadata  = "metadata_asset"
 	TaskThumbnail = "thumbnail_asset"
 	TaskTranscode = "transcode_asset"
+	// TaskVerifyHash is recorded failed, never queued, when an upload's bytes
+	// do not hash to what the client claimed.
+	TaskVerifyHash = "verify_hash"
 )
 
 // SourceMaterializer validates an IngestSource, materializes the file into the
@@ -77,8 +80,9 @@ func NewSourceMaterializer(
 // Materialize processes an IngestSource through validation, file materialization,
 // DB record creation/update, and pipeline enqueuing.
 //
-// Returns nil asset with nil error when the asset is unchanged (scan skip) or
-// the source file has disappeared.
+// Returns nil asset with nil error when the asset is unchanged (scan skip), the
+// source file has disappeared, or the repository skips an upload whose file
+// name is already in use.
 func (m *SourceMaterializer) Materialize(ctx context.Context, source IngestSource) (*repo.Asset, error) {
 	// 1. Validate file type
 	validation := file.ValidateFile(source.OriginalFilename, source.ContentType)
@@ -126,12 +130,38 @@ func (m *SourceMaterializer) materializeFromStaging(
 	}
 	fileSize := info.Size()
 
-	// Upload handlers pass a server-verified full hash; non-HTTP staging sources
-	// are hashed here. Client fingerprints never populate ContentHash.
-	hashes, err := m.resolveLayeredHash(source)
+	// Build staging file handle
+	stagingFile := &storage.StagingFile{
+		ID:        filepath.Base(source.SourcePath),
+		RepoPath:  repository.Path,
+		Path:      source.SourcePath,
+		Filename:  source.OriginalFilename,
+		CreatedAt: source.Timestamp,
+	}
+	if source.CaptureHints != nil {
+		stagingFile.TakenTime = source.CaptureHints.TakenTime
+	}
+
+	// Every staged file is hashed here; the hash an upload handler took while
+	// staging and the one a client claimed are only checked against it.
+	hashes, err := hashStagedFile(source)
+	if errors.Is(err, errStagedFileChanged) {
+		if moveErr := m.stagingManager.MoveStagingToFailed(stagingFile); moveErr != nil {
+			m.logger.Warn("failed to move changed staging file to failed dir",
+				zap.String("operation", "source.materialize"),
+				zap.String("staging_path", stagingFile.Path),
+				zap.Error(moveErr),
+			)
+		}
+		m.audit(repository.Path).Error("asset.materialize.verify_hash", err,
+			zap.String("original_filename", source.OriginalFilename),
+		)
+		return nil, err
+	}
 	if err != nil {
 		return nil, fmt.Errorf("calculate layered hash: %w", err)
 	}
+	hashVerified := clientHashVerified(source.ClientContentHash, hashes.ContentHash)
 	lockIndex, _ := strconv.ParseUint(hashes.ContentHash[:2], 16, 8)
 	m.contentLocks[lockIndex].Lock()
 	defer m.contentLocks[lockIndex].Unlock()
@@ -147,20 +177,40 @@ func (m *SourceMaterializer) materializeFromStaging(
 		return existing, nil
 	}
 
-	// Build staging file handle
-	stagingFile := &storage.StagingFile{
-		ID:        filepath.Base(source.SourcePath),
-		RepoPath:  repository.Path,
-		Path:      source.SourcePath,
-		Filename:  source.OriginalFilename,
-		CreatedAt: source.Timestamp,
+	// A repository that skips duplicate file names keeps the file already
+	// filed under this name and drops the upload before any record exists.
+	plan, err := m.stagingManager.PlanInboxCommit(stagingFile, hashes.ContentHash)
+	if err != nil {
+		return nil, fmt.Errorf("plan inbox commit: %w", err)
+	}
+	if plan.Action == storage.DuplicateActionSkipped {
+		if removeErr := os.Remove(source.SourcePath); removeErr != nil && !os.IsNotExist(removeErr) {
+			return nil, fmt.Errorf("remove skipped staging file: %w", removeErr)
+		}
+		m.audit(repository.Path).Operation("asset.materialize.skip_duplicate_name",
+			zap.String("repository_id", uuid.UUID(repository.RepoID.Bytes).String()),
+			zap.String("original_filename", source.OriginalFilename),
+		)
+		return nil, nil
 	}
 
-	// Initial tracked status
-	statusJSON, err := buildTrackedProcessingStatus(validation.AssetType, "Asset ingestion started")
+	// Initial tracked status; a wrong client hash leaves the asset with a
+	// warning once the pipeline finishes.
+	initialStatus := statusdb.NewTrackedProcessingStatus("Asset ingestion started", pipelineTaskNames(validation.AssetType))
+	if hashVerified != nil && !*hashVerified {
+		initialStatus.MarkTaskFailed(TaskVerifyHash, "Content hash mismatch",
+			fmt.Sprintf("client sent content hash %s but the file hashes to %s", *source.ClientContentHash, hashes.ContentHash))
+	}
+	statusJSON, err := initialStatus.ToJSONB()
 	if err != nil {
 		return nil, fmt.Errorf("marshal status: %w", err)
 	}
+	var specificMetadata dbtypes.SpecificMetadata
+	if hashVerified != nil {
+		if specificMetadata, err = dbtypes.MarshalMeta(map[string]bool{"hash_verified": *hashVerified}); err != nil {
+			return nil, fmt.Errorf("marshal metadata: %w", err)
+		}
+	}
 
 	// Resolve owner: explicit OwnerID, otherwise repository default
 	ownerID := source.OwnerID
@@ -168,6 +218,13 @@ func (m *SourceMaterializer) materializeFromStaging(
 		ownerID = repository.DefaultOwnerID
 	}
 
+	// Seed taken_time from the client hint so the asset sorts correctly before
+	// metadata extraction runs; extraction replaces it when EXIF has a date.
+	takenTime := time.Now()
+	if source.CaptureHints != nil && source.CaptureHints.TakenTime != nil {
+		takenTime = *source.CaptureHints.TakenTime
+	}
+
 	// Create asset record (storage path not yet known)
 	asset, err := m.assetService.CreateAssetRecord(ctx, repo.CreateAssetParams{
 		OwnerID:                 ownerID,
@@ -179,7 +236,8 @@ func (m *SourceMaterializer) materializeFromStaging(
 		ContentHash:             hashes.ContentHash,
 		QuickFingerprint:        hashes.QuickFingerprint,
 		QuickFingerprintVersion: hashes.QuickFingerprintVersion,
-		TakenTime:               pgtype.Timestamptz{Time: time.Now(), Valid: true},
+		TakenTime:               pgtype.Timestamptz{Time: takenTime, Valid: true},
+		SpecificMetadata:        specificMetadata,
 		Rating:                  int32Ptr(0),
 		RepositoryID:            repository.RepoID,
 		Status:                  statusJSON,
@@ -189,12 +247,51 @@ func (m *SourceMaterializer) materializeFromStaging(
 	}
 
 	// Commit staging → inbox; the storage path is determined by the repo's storage strategy
+	stagingFile.AssetID = asset.AssetID.String()
 	storageRelPath, err := m.stagingManager.CommitStagingFileToInbox(stagingFile, hashes.ContentHash)
+	if errors.Is(err, storage.ErrFlatManifest) {
+		// The file is in place; only the flat manifest missed this upload.
+		m.logger.Warn("failed to record upload in flat manifest",
+			zap.String("asset_id", asset.AssetID.String()),
+			zap.String("storage_path", storageRelPath),
+			zap.Error(err))
+		err = nil
+	}
 	if err != nil {
 		m.handleStagingFailure(ctx, stagingFile, repository.Path, asset.AssetID, err)
 		return asset, nil // asset record exists but is in failed state
 	}
 
+	// Under the overwrite policy the file replaced whatever was filed at this
+	// path; the asset recorded there lost its file and goes to the Trash.
+	replaced, err := m.queries.DetachAssetStoragePath(ctx, repo.DetachAssetStoragePathParams{
+		RepositoryID: repository.RepoID,
+		StoragePath:  &storageRelPath,
+		KeepAssetID:  asset.AssetID,
+	})
+	if err != nil {
+		m.logger.Warn("failed to detach asset replaced by upload",
+			zap.String("asset_id", asset.AssetID.String()),
+			zap.String("storage_path", storageRelPath),
+			zap.Error(err))
+	} else if replaced > 0 {
+		m.audit(repository.Path).Operation("asset.materialize.overwrite",
+			zap.String("asset_id", asset.AssetID.String()),
+			zap.String("storage_path", storageRelPath),
+			zap.Int64("replaced_assets", replaced),
+		)
+	}
+
+	// Uploads to a repository in manual processing mode are stored and
+	// recorded, then wait for ProcessDeferredAssets.
+	deferred := source.Kind == IngestSourceUpload && repository.Config.DefersProcessing()
+	if deferred {
+		statusJSON, err = statusdb.NewDeferredStatus().ToJSONB()
+		if err != nil {
+			return nil, fmt.Errorf("marshal status: %w", err)
+		}
+	}
+
 	// Update asset with the resolved storage path
 	_, err = m.queries.UpdateAssetStoragePathAndStatus(ctx, repo.UpdateAssetStoragePathAndStatusParams{
 		AssetID:     asset.AssetID,
@@ -208,8 +305,10 @@ func (m *SourceMaterializer) materializeFromStaging(
 
 	// Enqueue downstream pipeline
 	assetType := dbtypes.AssetType(asset.Type)
-	if err := m.enqueuePipeline(ctx, repository, asset, storageRelPath, assetType); err != nil {
-		return nil, err
+	if !deferred {
+		if err := m.enqueuePipeline(ctx, repository, asset, storageRelPath, assetType, source.CaptureHints); err != nil {
+			return nil, err
+		}
 	}
 
 	m.audit(repository.Path).Operation("asset.materialize.staging",
@@ -218,6 +317,7 @@ func (m *SourceMaterializer) materializeFromStaging(
 		zap.String("storage_path", storageRelPath),
 		zap.String("asset_type", string(assetType)),
 		zap.String("source_kind", string(source.Kind)),
+		zap.Bool("processing_deferred", deferred),
 	)
 
 	return asset, nil
@@ -298,7 +398,7 @@ func (m *SourceMaterializer) materializeInPlace(
 		}
 		asset := updated
 
-		if err := m.enqueuePipeline(ctx, repository, &asset, storagePath, assetType); err != nil {
+		if err := m.enqueuePipeline(ctx, repository, &asset, storagePath, assetType, source.CaptureHints); err != nil {
 			return nil, err
 		}
 
@@ -351,7 +451,7 @@ func (m *SourceMaterializer) materializeInPlace(
 	}
 	asset := created
 
-	if err := m.enqueuePipeline(ctx, repository, asset, storagePath, assetType); err != nil {
+	if err := m.enqueuePipeline(ctx, repository, asset, storagePath, assetType, source.CaptureHints); err != nil {
 		return nil, err
 	}
 
@@ -391,29 +491,36 @@ func (m *SourceMaterializer) findExistingContent(ctx context.Context, repository
 	return nil, nil
 }
 
-func (m *SourceMaterializer) resolveLayeredHash(source IngestSource) (*hash.LayeredHashResult, error) {
-	info, err := os.Stat(source.SourcePath)
+// errStagedFileChanged reports a staged file that no longer hashes to what
+// the upload handler computed while writing it.
+var errStagedFileChanged = errors.New("staged file changed after upload")
+
+// hashStagedFile computes the layered BLAKE3 hash of a staged file and checks
+// it against the full hash recorded at upload, if any.
+func hashStagedFile(source IngestSource) (*hash.LayeredHashResult, error) {
+	result, err := hash.CalculateLayeredBLAKE3(source.SourcePath)
 	if err != nil {
-		return nil, fmt.Errorf("stat source for hashing: %w", err)
-	}
-	if source.ContentHash == nil || !hash.ValidateHash(strings.TrimSpace(*source.ContentHash), hash.AlgorithmBLAKE3) {
-		return hash.CalculateLayeredBLAKE3(source.SourcePath)
-	}
-	result := &hash.LayeredHashResult{
-		ContentHash: strings.ToLower(strings.TrimSpace(*source.ContentHash)),
-		FileSize:    info.Size(),
+		return nil, err
 	}
-	if info.Size() > hash.QuickHashThreshold && source.QuickFingerprint != nil && source.QuickFingerprintVersion != nil &&
-		*source.QuickFingerprintVersion == hash.QuickFingerprintVersion &&
-		hash.ValidateHash(strings.TrimSpace(*source.QuickFingerprint), hash.AlgorithmBLAKE3) {
-		quick := strings.ToLower(strings.TrimSpace(*source.QuickFingerprint))
-		version := hash.QuickFingerprintVersion
-		result.QuickFingerprint = &quick
-		result.QuickFingerprintVersion = &version
+	if source.ContentHash != nil {
+		recorded := strings.ToLower(strings.TrimSpace(*source.ContentHash))
+		if recorded != "" && recorded != result.ContentHash {
+			return nil, fmt.Errorf("%w: recorded %s, now %s", errStagedFileChanged, recorded, result.ContentHash)
+		}
 	}
 	return result, nil
 }
 
+// clientHashVerified reports whether the hash a client claimed for its upload
+// matches the computed one, or nil when it claimed none.
+func clientHashVerified(claimed *string, computed string) *bool {
+	if claimed == nil || strings.TrimSpace(*claimed) == "" {
+		return nil
+	}
+	verified := strings.EqualFold(strings.TrimSpace(*claimed), computed)
+	return &verified
+}
+
 // handleStagingFailure attempts to move a failed staging file to the failed
 // directory and marks the asset record as failed.
 func (m *SourceMaterializer) handleStagingFailure(
@@ -472,6 +579,62 @@ func (m *SourceMaterializer) resolveRepository(ctx context.Context, repoUUID uui
 	return repository, nil
 }
 
+// deferredAssetBatch is how many deferred assets ProcessDeferredAssets loads at a time.
+const deferredAssetBatch = 200
+
+// ProcessDeferredAssets starts the pipeline for every asset of a repository
+// that was uploaded in manual processing mode and not processed yet, and
+// returns how many were queued. Client capture hints other than the taken
+// time, which seeds the asset record, are not kept for deferred uploads.
+func (m *SourceMaterializer) ProcessDeferredAssets(ctx context.Context, repoUUID uuid.UUID) (int, error) {
+	repository, err := m.queries.GetRepository(ctx, pgtype.UUID{Bytes: repoUUID, Valid: true})
+	if err != nil {
+		return 0, fmt.Errorf("get repository: %w", err)
+	}
+
+	queued := 0
+	after := pgtype.UUID{Valid: true}
+	for {
+		assets, err := m.queries.ListDeferredRepositoryAssets(ctx, repo.ListDeferredRepositoryAssetsParams{
+			RepositoryID: repository.RepoID,
+			AfterAssetID: after,
+			Limit:        deferredAssetBatch,
+		})
+		if err != nil {
+			return queued, fmt.Errorf("list deferred assets: %w", err)
+		}
+		for i := range assets {
+			asset := &assets[i]
+			if err := m.processDeferredAsset(ctx, repository, asset); err != nil {
+				return queued, fmt.Errorf("process deferred asset %s: %w", asset.AssetID.String(), err)
+			}
+			queued++
+		}
+		if len(assets) < deferredAssetBatch {
+			return queued, nil
+		}
+		after = assets[len(assets)-1].AssetID
+	}
+}
+
+func (m *SourceMaterializer) processDeferredAsset(ctx context.Context, repository repo.Repository, asset *repo.Asset) error {
+	if asset.StoragePath == nil || *asset.StoragePath == "" {
+		return fmt.Errorf("asset has no storage path")
+	}
+	assetType := dbtypes.AssetType(asset.Type)
+	statusJSON, err := buildTrackedProcessingStatus(assetType, "Deferred processing started")
+	if err != nil {
+		return fmt.Errorf("marshal status: %w", err)
+	}
+	if _, err := m.queries.UpdateAssetStatusWithErrors(ctx, repo.UpdateAssetStatusWithErrorsParams{
+		AssetID: asset.AssetID,
+		Status:  statusJSON,
+	}); err != nil {
+		return fmt.Errorf("update asset status: %w", err)
+	}
+	return m.enqueuePipeline(ctx, repository, asset, *asset.StoragePath, assetType, nil)
+}
+
 // enqueuePipeline inserts downstream River jobs for the asset pipeline.
 func (m *SourceMaterializer) enqueuePipeline(
 	ctx context.Context,
@@ -479,6 +642,7 @@ func (m *SourceMaterializer) enqueuePipeline(
 	asset *repo.Asset,
 	storagePath string,
 	assetType dbtypes.AssetType,
+	captureHints *jobs.CaptureHints,
 ) error {
 	pgID := asset.AssetID
 	commonMeta := jobs.MetadataArgs{
@@ -489,6 +653,7 @@ func (m *SourceMaterializer) enqueuePipeline(
 		OriginalFilename: asset.OriginalFilename,
 		FileSize:         asset.FileSize,
 		MimeType:         asset.MimeType,
+		CaptureHints:     captureHints,
 	}
 	commonThumb := jobs.ThumbnailArgs{
 		AssetID:     pgID,
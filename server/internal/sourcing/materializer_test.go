@@ -0,0 +1,34 @@
+package sourcing
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"server/internal/utils/hash"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHashStagedFileVerifiesUploadAndClientHashes(t *testing.T) {
+	staged := filepath.Join(t.TempDir(), "IMG_0001.jpg")
+	require.NoError(t, os.WriteFile(staged, []byte("staged upload bytes"), 0o644))
+	want, err := hash.CalculateLayeredBLAKE3(staged)
+	require.NoError(t, err)
+
+	recorded := strings.ToUpper(want.ContentHash)
+	got, err := hashStagedFile(IngestSource{SourcePath: staged, ContentHash: &recorded})
+	require.NoError(t, err)
+	require.Equal(t, want.ContentHash, got.ContentHash)
+
+	stale := strings.Repeat("0", 64)
+	_, err = hashStagedFile(IngestSource{SourcePath: staged, ContentHash: &stale})
+	require.True(t, errors.Is(err, errStagedFileChanged))
+
+	require.Nil(t, clientHashVerified(nil, got.ContentHash))
+	claimed := " " + recorded + " "
+	require.True(t, *clientHashVerified(&claimed, got.ContentHash))
+	require.False(t, *clientHashVerified(&stale, got.ContentHash))
+}
@@ -8,6 +8,8 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+
+	"server/internal/queue/jobs"
 )
 
 // IngestSourceKind identifies the origin of an asset being ingested.
@@ -34,11 +36,13 @@ type IngestSource struct {
 	OriginalFilename        string
 	Size                    int64   // optional hint; the materializer always stats the file for the authoritative size
 	ContentHash             *string // authoritative full hash for trusted in-place sources
+	ClientContentHash       *string // hash the uploading client claimed; verified, never trusted
 	QuickFingerprint        *string // non-authoritative large-file precheck hint
 	QuickFingerprintVersion *string
 	Timestamp               time.Time
 	ContentType             string
-	Metadata                map[string]any // source-specific metadata (e.g. cloud object key, upload session ID)
+	Metadata                map[string]any     // source-specific metadata (e.g. cloud object key, upload session ID)
+	CaptureHints            *jobs.CaptureHints // client-supplied capture metadata; only fills gaps left by extraction
 }
 
 // AssetSource produces IngestSource candidates from a specific origin.
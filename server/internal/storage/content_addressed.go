@@ -0,0 +1,75 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"server/internal/utils/hash"
+)
+
+// ContentAddressedFile describes a file written by WriteContentAddressed.
+type ContentAddressedFile struct {
+	// Name is the file name inside the directory: the BLAKE3 hash of the
+	// content followed by the requested extension.
+	Name string
+	// Size is the number of bytes read from the source.
+	Size int64
+	// Shared is true when a file with the same content already existed and
+	// was reused instead of being written again.
+	Shared bool
+}
+
+// ContentAddressedName returns the name WriteContentAddressed gives data, so a
+// caller can lock or look up the file before writing it.
+func ContentAddressedName(data []byte, ext string) string {
+	hasher := hash.NewLayeredWriter()
+	_, _ = hasher.Write(data)
+	return hasher.Result().ContentHash + ext
+}
+
+// WriteContentAddressed stores the bytes of r in dir under the hash of those
+// bytes, so identical content is only ever kept once. The content is staged in
+// a temporary file beside the target and renamed into place; when the target
+// already exists the staged copy is discarded. Empty content is rejected.
+func WriteContentAddressed(dir string, r io.Reader, ext string) (ContentAddressedFile, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return ContentAddressedFile{}, fmt.Errorf("create directory: %w", err)
+	}
+	tmp, err := os.CreateTemp(dir, ".staging-*")
+	if err != nil {
+		return ContentAddressedFile{}, fmt.Errorf("create staging file: %w", err)
+	}
+	tmpPath := tmp.Name()
+	// Removing after a successful rename is a harmless no-op.
+	defer os.Remove(tmpPath)
+
+	hasher := hash.NewLayeredWriter()
+	written, err := io.Copy(io.MultiWriter(tmp, hasher), r)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return ContentAddressedFile{}, fmt.Errorf("write content: %w", err)
+	}
+	if written == 0 {
+		return ContentAddressedFile{}, errors.New("no data written")
+	}
+
+	file := ContentAddressedFile{Name: hasher.Result().ContentHash + ext, Size: written}
+	target := filepath.Join(dir, file.Name)
+	if _, err := os.Stat(target); err == nil {
+		file.Shared = true
+		return file, nil
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return ContentAddressedFile{}, fmt.Errorf("stat %s: %w", file.Name, err)
+	}
+	// Two writers racing on the same content rename identical bytes, so the
+	// loser simply replaces the winner's file with an equal one.
+	if err := os.Rename(tmpPath, target); err != nil {
+		return ContentAddressedFile{}, fmt.Errorf("commit %s: %w", file.Name, err)
+	}
+	return file, nil
+}
@@ -0,0 +1,47 @@
+package storage
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteContentAddressedSharesIdenticalContent(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "thumbnails", "small")
+
+	first, err := WriteContentAddressed(dir, bytes.NewReader([]byte("webp bytes")), ".webp")
+	require.NoError(t, err)
+	require.False(t, first.Shared)
+	require.Equal(t, int64(len("webp bytes")), first.Size)
+	require.Equal(t, ".webp", filepath.Ext(first.Name))
+	require.Equal(t, ContentAddressedName([]byte("webp bytes"), ".webp"), first.Name)
+
+	second, err := WriteContentAddressed(dir, bytes.NewReader([]byte("webp bytes")), ".webp")
+	require.NoError(t, err)
+	require.True(t, second.Shared)
+	require.Equal(t, first.Name, second.Name)
+
+	other, err := WriteContentAddressed(dir, bytes.NewReader([]byte("other bytes")), ".webp")
+	require.NoError(t, err)
+	require.NotEqual(t, first.Name, other.Name)
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 2, "identical content must be stored once and no staging files left")
+	content, err := os.ReadFile(filepath.Join(dir, first.Name))
+	require.NoError(t, err)
+	require.Equal(t, "webp bytes", string(content))
+}
+
+func TestWriteContentAddressedRejectsEmptyContent(t *testing.T) {
+	dir := t.TempDir()
+	_, err := WriteContentAddressed(dir, bytes.NewReader(nil), ".webp")
+	require.Error(t, err)
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Empty(t, entries)
+}
@@ -31,6 +31,10 @@ type DirectoryStructure struct {
 	// Staging subdirectories
 	IncomingDir string // .lumilio/staging/incoming
 	FailedDir   string // .lumilio/staging/failed
+
+	// FlatManifestFile maps uploads to their inbox names under the flat
+	// storage strategy.
+	FlatManifestFile string // .lumilio/flat-manifest.jsonl
 }
 
 // DefaultStructure provides the default directory structure configuration
@@ -49,6 +53,8 @@ var DefaultStructure = DirectoryStructure{
 	TrashDir:      ".lumilio/trash",
 	IncomingDir:   ".lumilio/staging/incoming",
 	FailedDir:     ".lumilio/staging/failed",
+
+	FlatManifestFile: ".lumilio/flat-manifest.jsonl",
 }
 
 // dirSpec is one directory in a repository's layout: its repo-relative path and
@@ -71,6 +77,7 @@ var repoDirs = []dirSpec{
 	{".lumilio/assets/thumbnails/large", 0o755},
 	{".lumilio/assets/videos", 0o755},
 	{".lumilio/assets/videos/web", 0o755},
+	{".lumilio/assets/videos/hls", 0o755},
 	{".lumilio/assets/audios", 0o755},
 	{".lumilio/assets/audios/web", 0o755},
 	{".lumilio/assets/faces", 0o755},
@@ -143,6 +150,12 @@ type StagingFile struct {
 	Path      string    `json:"path"`
 	Filename  string    `json:"filename"`
 	CreatedAt time.Time `json:"created_at"`
+	// TakenTime is when the photo was taken, if known before metadata
+	// extraction. The date_prefix duplicate strategy names collisions after it.
+	TakenTime *time.Time `json:"taken_time,omitempty"`
+	// AssetID is the asset the file is committed for, recorded in the flat
+	// storage manifest.
+	AssetID string `json:"asset_id,omitempty"`
 }
 
 // TempFile represents a temporary processing file
@@ -0,0 +1,92 @@
+package storage
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// ErrFlatManifest marks a failed flat manifest update. The file it describes
+// has already been committed, so callers log it rather than fail the upload.
+var ErrFlatManifest = errors.New("update flat storage manifest")
+
+// FlatManifestEntry records where the flat storage strategy put one upload.
+// The manifest is append-only: an asset committed twice has two entries and
+// the last one is current.
+type FlatManifestEntry struct {
+	AssetID          string `json:"asset_id,omitempty"`
+	OriginalFilename string `json:"original_filename"`
+	// StoragePath is repository-relative, e.g. inbox/IMG_0001 (1).jpg.
+	StoragePath string `json:"storage_path"`
+	// Collision is set when a file of the original name was already in the
+	// inbox, so the duplicate handling policy renamed or overwrote it.
+	Collision   bool      `json:"collision,omitempty"`
+	CommittedAt time.Time `json:"committed_at"`
+}
+
+// flatManifestMu serialises appends from concurrent uploads in this process.
+var flatManifestMu sync.Mutex
+
+// appendFlatManifestEntry adds entry as one JSON line to the repository's
+// flat manifest, creating it on first use.
+func appendFlatManifestEntry(repoPath string, entry FlatManifestEntry) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrFlatManifest, err)
+	}
+
+	flatManifestMu.Lock()
+	defer flatManifestMu.Unlock()
+
+	manifestPath := filepath.Join(repoPath, DefaultStructure.FlatManifestFile)
+	if err := os.MkdirAll(filepath.Dir(manifestPath), 0755); err != nil {
+		return fmt.Errorf("%w: %v", ErrFlatManifest, err)
+	}
+	f, err := os.OpenFile(manifestPath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrFlatManifest, err)
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("%w: %v", ErrFlatManifest, err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("%w: %v", ErrFlatManifest, err)
+	}
+	return nil
+}
+
+// ReadFlatManifest returns the manifest entries of a repository in commit
+// order. A repository that never used the flat strategy has none.
+func ReadFlatManifest(repoPath string) ([]FlatManifestEntry, error) {
+	f, err := os.Open(filepath.Join(repoPath, DefaultStructure.FlatManifestFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("open flat manifest: %w", err)
+	}
+	defer f.Close()
+
+	var entries []FlatManifestEntry
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var entry FlatManifestEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, fmt.Errorf("parse flat manifest line %d: %w", len(entries)+1, err)
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read flat manifest: %w", err)
+	}
+	return entries, nil
+}
@@ -0,0 +1,166 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"server/internal/db/dbtypes"
+	"server/internal/db/repo"
+	"server/internal/utils/hash"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// LegacyAssetStore is the part of repo.Queries AssignLegacyAssets uses.
+type LegacyAssetStore interface {
+	ListAssetsWithoutRepository(ctx context.Context) ([]repo.ListAssetsWithoutRepositoryRow, error)
+	AssignAssetRepository(ctx context.Context, arg repo.AssignAssetRepositoryParams) (int64, error)
+}
+
+// LegacyAssignResult reports how many repository-less assets were attached to
+// a repository and which could not be located in it.
+type LegacyAssignResult struct {
+	Resolved           int
+	UnresolvedAssetIDs []string
+}
+
+// AssignLegacyAssets attaches every asset without a repository to repository,
+// rewriting its storage path to be relative to the repository root. Assets
+// whose file cannot be found under the root with the recorded size and
+// content hash, or whose path is already taken
+// by another asset in the repository, are left untouched and reported.
+func AssignLegacyAssets(ctx context.Context, store LegacyAssetStore, repository repo.Repository) (LegacyAssignResult, error) {
+	assets, err := store.ListAssetsWithoutRepository(ctx)
+	if err != nil {
+		return LegacyAssignResult{}, fmt.Errorf("list assets without repository: %w", err)
+	}
+
+	var result LegacyAssignResult
+	for _, asset := range assets {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+		unresolved := func() {
+			result.UnresolvedAssetIDs = append(result.UnresolvedAssetIDs, asset.AssetID.String())
+		}
+		if asset.StoragePath == nil {
+			unresolved()
+			continue
+		}
+		relPath, ok := ResolveLegacyStoragePath(repository.Path, *asset.StoragePath, asset.FileSize, asset.ContentHash)
+		if !ok {
+			unresolved()
+			continue
+		}
+
+		updated, err := store.AssignAssetRepository(ctx, repo.AssignAssetRepositoryParams{
+			AssetID:      asset.AssetID,
+			RepositoryID: repository.RepoID,
+			StoragePath:  &relPath,
+		})
+		var pgErr *pgconn.PgError
+		switch {
+		case errors.As(err, &pgErr) && pgErr.Code == "23505":
+			// Another asset already owns this file in the repository.
+			unresolved()
+		case err != nil:
+			return result, fmt.Errorf("assign asset %s: %w", asset.AssetID.String(), err)
+		case updated == 0:
+			// Assigned concurrently by someone else; nothing left to do.
+		default:
+			result.Resolved++
+		}
+	}
+	return result, nil
+}
+
+// ResolveLegacyStoragePath finds the file a legacy storage path refers to
+// under repoPath and returns its repository-relative, slash-separated path.
+// A file only matches when it has fileSize bytes and, when contentHash is
+// set, that BLAKE3 content hash.
+//
+// An absolute path inside repoPath is made relative directly. Otherwise the
+// path is assumed to have moved along with some of its parent folders, so ever
+// shorter tails of it are tried against the repository root: the old
+// /mnt/photos/2019/trip/a.jpg is found as 2019/trip/a.jpg or trip/a.jpg. The
+// tails keep at least one folder, since a bare filename like IMG_0001.jpg says
+// too little about which file was meant. Files inside the repository's system
+// directory never match.
+func ResolveLegacyStoragePath(repoPath, storagePath string, fileSize int64, contentHash string) (string, bool) {
+	trimmed := strings.TrimSpace(storagePath)
+	if trimmed == "" {
+		return "", false
+	}
+	root := filepath.Clean(repoPath)
+
+	if filepath.IsAbs(trimmed) {
+		if rel, err := filepath.Rel(root, filepath.Clean(trimmed)); err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			if candidate, ok := legacyCandidate(root, filepath.ToSlash(rel), fileSize, contentHash); ok {
+				return candidate, true
+			}
+		}
+	}
+
+	// Legacy rows may come from another OS: accept either separator and drop
+	// a drive letter before splitting.
+	normalized := strings.ReplaceAll(trimmed, `\`, "/")
+	if len(normalized) >= 2 && normalized[1] == ':' && isASCIILetter(normalized[0]) {
+		normalized = normalized[2:]
+	}
+	parts := strings.FieldsFunc(normalized, func(r rune) bool { return r == '/' })
+	tails := len(parts) - 1
+	if len(parts) == 1 {
+		// Stored as a bare filename: only the repository root can hold it.
+		tails = 1
+	}
+	for i := 0; i < tails; i++ {
+		if candidate, ok := legacyCandidate(root, strings.Join(parts[i:], "/"), fileSize, contentHash); ok {
+			return candidate, true
+		}
+	}
+	return "", false
+}
+
+// legacyCandidate reports whether rel names a regular file under root that an
+// asset with fileSize and contentHash may point at.
+func legacyCandidate(root, rel string, fileSize int64, contentHash string) (string, bool) {
+	clean := path.Clean(rel)
+	if clean == "." || clean == ".." || strings.HasPrefix(clean, "../") || strings.HasPrefix(clean, "/") {
+		return "", false
+	}
+	if clean == DefaultStructure.SystemDir || strings.HasPrefix(clean, DefaultStructure.SystemDir+"/") {
+		return "", false
+	}
+	full := filepath.Join(root, filepath.FromSlash(clean))
+	info, err := os.Stat(full)
+	if err != nil || !info.Mode().IsRegular() || info.Size() != fileSize {
+		return "", false
+	}
+	if contentHash = strings.TrimSpace(contentHash); contentHash != "" {
+		fileHash, err := hash.CalculateBLAKE3(full)
+		if err != nil || !strings.EqualFold(fileHash, contentHash) {
+			return "", false
+		}
+	}
+	return clean, true
+}
+
+// AssignLegacyAssets attaches assets that have no repository to the repository
+// with the given id. See the package-level AssignLegacyAssets.
+func (rm *DefaultRepositoryManager) AssignLegacyAssets(ctx context.Context, id string) (LegacyAssignResult, error) {
+	repository, err := rm.GetRepository(id)
+	if err != nil {
+		return LegacyAssignResult{}, err
+	}
+	// An unreachable root would report every asset as unresolved, which reads
+	// as "these files are gone" rather than "plug the drive back in".
+	if repository.Status == dbtypes.RepoStatusOffline || repository.Status == dbtypes.RepoStatusError {
+		return LegacyAssignResult{}, fmt.Errorf("%w: %s", ErrRepositoryOffline, repository.Name)
+	}
+	return AssignLegacyAssets(ctx, rm.queries, *repository)
+}
@@ -0,0 +1,168 @@
+package storage
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"server/internal/db/repo"
+	"server/internal/utils/hash"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+// legacyFileContent is what writeLegacyFile puts in every file.
+const legacyFileContent = "photo"
+
+func writeLegacyFile(t *testing.T, root, rel string) {
+	t.Helper()
+	writeLegacyFileContent(t, root, rel, legacyFileContent)
+}
+
+func writeLegacyFileContent(t *testing.T, root, rel, content string) {
+	t.Helper()
+	full := filepath.Join(root, filepath.FromSlash(rel))
+	require.NoError(t, os.MkdirAll(filepath.Dir(full), 0o755))
+	require.NoError(t, os.WriteFile(full, []byte(content), 0o644))
+}
+
+func TestResolveLegacyStoragePath(t *testing.T) {
+	root := t.TempDir()
+	writeLegacyFile(t, root, "2019/trip/a.jpg")
+	writeLegacyFile(t, root, "inbox/aa/bb/cc/hash.jpg")
+	writeLegacyFile(t, root, ".lumilio/assets/thumbnails/small/x.webp")
+
+	tests := map[string]struct {
+		want string
+		ok   bool
+	}{
+		filepath.Join(root, "2019", "trip", "a.jpg"):               {"2019/trip/a.jpg", true},
+		"/mnt/old-library/photos/2019/trip/a.jpg":                  {"2019/trip/a.jpg", true},
+		`D:\Photos\2019\trip\a.jpg`:                                {"2019/trip/a.jpg", true},
+		"inbox/aa/bb/cc/hash.jpg":                                  {"inbox/aa/bb/cc/hash.jpg", true},
+		"/mnt/old-library/.lumilio/assets/thumbnails/small/x.webp": {"", false},
+		"/mnt/old-library/photos/missing.jpg":                      {"", false},
+		"/mnt/old-library/2019":                                    {"", false},
+		"../2019/trip/a.jpg":                                       {"2019/trip/a.jpg", true},
+		"  ":                                                       {"", false},
+	}
+	for storagePath, tt := range tests {
+		t.Run(storagePath, func(t *testing.T) {
+			got, ok := ResolveLegacyStoragePath(root, storagePath, int64(len(legacyFileContent)), "")
+			require.Equal(t, tt.ok, ok)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestResolveLegacyStoragePathVerifiesSameNamedFiles(t *testing.T) {
+	root := t.TempDir()
+	writeLegacyFileContent(t, root, "IMG_0001.jpg", "beach")
+	writeLegacyFileContent(t, root, "2019/trip/IMG_0001.jpg", "hills")
+	beachHash, err := hash.CalculateBLAKE3(filepath.Join(root, "IMG_0001.jpg"))
+	require.NoError(t, err)
+	hillsHash, err := hash.CalculateBLAKE3(filepath.Join(root, "2019", "trip", "IMG_0001.jpg"))
+	require.NoError(t, err)
+
+	got, ok := ResolveLegacyStoragePath(root, "/mnt/old/2019/trip/IMG_0001.jpg", 5, hillsHash)
+	require.True(t, ok)
+	require.Equal(t, "2019/trip/IMG_0001.jpg", got)
+
+	// Same name and size, different content.
+	_, ok = ResolveLegacyStoragePath(root, "/mnt/old/2019/trip/IMG_0001.jpg", 5, beachHash)
+	require.False(t, ok)
+	// Different size.
+	_, ok = ResolveLegacyStoragePath(root, "/mnt/old/2019/trip/IMG_0001.jpg", 6, "")
+	require.False(t, ok)
+	// Only the filename is shared with the file at the root.
+	_, ok = ResolveLegacyStoragePath(root, "/mnt/old/2020/IMG_0001.jpg", 5, beachHash)
+	require.False(t, ok)
+	// A path that was a bare filename still matches at the root.
+	got, ok = ResolveLegacyStoragePath(root, "IMG_0001.jpg", 5, beachHash)
+	require.True(t, ok)
+	require.Equal(t, "IMG_0001.jpg", got)
+}
+
+// fakeLegacyAssetStore mimics the assets table for AssignLegacyAssets: rows
+// with a repository are not listed, and (repository, path) pairs are unique.
+type fakeLegacyAssetStore struct {
+	repositoryOf map[pgtype.UUID]pgtype.UUID
+	pathOf       map[pgtype.UUID]*string
+	order        []pgtype.UUID
+}
+
+func (s *fakeLegacyAssetStore) add(repositoryID pgtype.UUID, storagePath *string) pgtype.UUID {
+	id := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+	s.repositoryOf[id] = repositoryID
+	s.pathOf[id] = storagePath
+	s.order = append(s.order, id)
+	return id
+}
+
+func (s *fakeLegacyAssetStore) ListAssetsWithoutRepository(context.Context) ([]repo.ListAssetsWithoutRepositoryRow, error) {
+	var rows []repo.ListAssetsWithoutRepositoryRow
+	for _, id := range s.order {
+		if !s.repositoryOf[id].Valid {
+			rows = append(rows, repo.ListAssetsWithoutRepositoryRow{AssetID: id, StoragePath: s.pathOf[id], FileSize: int64(len(legacyFileContent))})
+		}
+	}
+	return rows, nil
+}
+
+func (s *fakeLegacyAssetStore) AssignAssetRepository(_ context.Context, arg repo.AssignAssetRepositoryParams) (int64, error) {
+	for id, repositoryID := range s.repositoryOf {
+		if repositoryID == arg.RepositoryID && s.pathOf[id] != nil && *s.pathOf[id] == *arg.StoragePath {
+			return 0, &pgconn.PgError{Code: "23505"}
+		}
+	}
+	if s.repositoryOf[arg.AssetID].Valid {
+		return 0, nil
+	}
+	s.repositoryOf[arg.AssetID] = arg.RepositoryID
+	s.pathOf[arg.AssetID] = arg.StoragePath
+	return 1, nil
+}
+
+func TestAssignLegacyAssetsMixedAssets(t *testing.T) {
+	root := t.TempDir()
+	writeLegacyFile(t, root, "2019/trip/a.jpg")
+	writeLegacyFile(t, root, "2020/b.jpg")
+	writeLegacyFile(t, root, "2021/c.jpg")
+
+	repository := repo.Repository{RepoID: pgtype.UUID{Bytes: uuid.New(), Valid: true}, Path: root}
+	store := &fakeLegacyAssetStore{repositoryOf: map[pgtype.UUID]pgtype.UUID{}, pathOf: map[pgtype.UUID]*string{}}
+	path := func(p string) *string { return &p }
+
+	// Already migrated: repository set and a relative path. Must be left alone.
+	current := store.add(repository.RepoID, path("2021/c.jpg"))
+	legacyAbsolute := store.add(pgtype.UUID{}, path(filepath.Join(root, "2019", "trip", "a.jpg")))
+	legacyMoved := store.add(pgtype.UUID{}, path("/old/library/2020/b.jpg"))
+	missing := store.add(pgtype.UUID{}, path("/old/library/gone.jpg"))
+	noPath := store.add(pgtype.UUID{}, nil)
+	// Points at the file the migrated asset already owns.
+	taken := store.add(pgtype.UUID{}, path("/old/library/2021/c.jpg"))
+
+	result, err := AssignLegacyAssets(context.Background(), store, repository)
+	require.NoError(t, err)
+	require.Equal(t, 2, result.Resolved)
+	require.ElementsMatch(t, []string{missing.String(), noPath.String(), taken.String()}, result.UnresolvedAssetIDs)
+
+	require.Equal(t, repository.RepoID, store.repositoryOf[legacyAbsolute])
+	require.Equal(t, "2019/trip/a.jpg", *store.pathOf[legacyAbsolute])
+	require.Equal(t, repository.RepoID, store.repositoryOf[legacyMoved])
+	require.Equal(t, "2020/b.jpg", *store.pathOf[legacyMoved])
+	require.Equal(t, "2021/c.jpg", *store.pathOf[current])
+	require.False(t, store.repositoryOf[missing].Valid)
+	require.False(t, store.repositoryOf[taken].Valid)
+	require.Equal(t, "/old/library/2021/c.jpg", *store.pathOf[taken])
+
+	// Running again only retries the leftovers.
+	again, err := AssignLegacyAssets(context.Background(), store, repository)
+	require.NoError(t, err)
+	require.Zero(t, again.Resolved)
+	require.Len(t, again.UnresolvedAssetIDs, 3)
+}
@@ -0,0 +1,437 @@
+// Package monitor watches repository workspaces and queues discovery of files
+// as they change, so new photos show up without waiting for the next periodic
+// scan. It only shortens the delay: the periodic scan still reconciles
+// everything a watch can miss, such as the files of a deleted directory or
+// changes made while the server was down.
+package monitor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"server/internal/db/repo"
+	"server/internal/queue/jobs"
+	"server/internal/storage/scanner"
+	"server/internal/utils/hash"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/riverqueue/river"
+	"github.com/riverqueue/river/rivertype"
+	"go.uber.org/zap"
+)
+
+// Backends of config.RepositoryScanConfig.Monitor.
+const (
+	BackendOff      = "off"
+	BackendFsnotify = "fsnotify"
+)
+
+// Enqueuer inserts discovery jobs; *river.Client[pgx.Tx] satisfies it.
+type Enqueuer interface {
+	InsertMany(ctx context.Context, params []river.InsertManyParams) ([]*rivertype.JobInsertResult, error)
+}
+
+// AssetLookup finds the asset recorded at a workspace path, to recognise
+// renames; *repo.Queries satisfies it.
+type AssetLookup interface {
+	GetAssetByRepositoryAndStoragePathAny(ctx context.Context, arg repo.GetAssetByRepositoryAndStoragePathAnyParams) (repo.Asset, error)
+}
+
+type pendingKey struct {
+	repositoryID string
+	storagePath  string
+}
+
+// FsnotifyMonitor watches every directory of its repositories with
+// github.com/fsnotify/fsnotify, skipping .lumilio and inbox like the scanner.
+// A changed file is queued once it has had no events for the settle time, as
+// a discover_asset upsert or, when it is gone, a delete. A file that settles
+// at a new path with the content of an asset whose path changed in the same
+// window is queued as a move of that asset instead.
+type FsnotifyMonitor struct {
+	watcher     *fsnotify.Watcher
+	queue       Enqueuer
+	assets      AssetLookup
+	settle      time.Duration
+	minFileSize int64
+	logger      *zap.Logger
+
+	mu      sync.Mutex
+	roots   map[string]string // repository ID -> absolute root
+	pending map[pendingKey]time.Time
+}
+
+// NewFsnotifyMonitor creates a monitor with no repositories. Files smaller
+// than minFileSize bytes are ignored; zero disables the filter. Without
+// assets, renames are queued as a delete and an upsert.
+func NewFsnotifyMonitor(queue Enqueuer, assets AssetLookup, settle time.Duration, minFileSize int64, logger *zap.Logger) (*FsnotifyMonitor, error) {
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("create fsnotify watcher: %w", err)
+	}
+	return &FsnotifyMonitor{
+		watcher:     watcher,
+		queue:       queue,
+		assets:      assets,
+		settle:      settle,
+		minFileSize: minFileSize,
+		logger:      logger.With(zap.String("component", "repository_monitor")),
+		roots:       make(map[string]string),
+		pending:     make(map[pendingKey]time.Time),
+	}, nil
+}
+
+// AddRepository starts watching the workspace of a repository. Files already
+// there are left to the periodic scan.
+func (m *FsnotifyMonitor) AddRepository(repositoryID, root string) error {
+	root, err := filepath.Abs(root)
+	if err != nil {
+		return fmt.Errorf("resolve repository root: %w", err)
+	}
+	m.mu.Lock()
+	m.roots[repositoryID] = root
+	m.mu.Unlock()
+	if _, err := m.watchTree(root, root); err != nil {
+		m.RemoveRepository(repositoryID)
+		return err
+	}
+	return nil
+}
+
+// RemoveRepository stops watching a repository and drops its pending changes.
+func (m *FsnotifyMonitor) RemoveRepository(repositoryID string) {
+	m.mu.Lock()
+	root, ok := m.roots[repositoryID]
+	delete(m.roots, repositoryID)
+	for key := range m.pending {
+		if key.repositoryID == repositoryID {
+			delete(m.pending, key)
+		}
+	}
+	m.mu.Unlock()
+	if !ok {
+		return
+	}
+	for _, path := range m.watcher.WatchList() {
+		if path == root || strings.HasPrefix(path, root+string(filepath.Separator)) {
+			_ = m.watcher.Remove(path)
+		}
+	}
+}
+
+// Run handles events and queues settled changes until ctx is done, then
+// closes the watcher.
+func (m *FsnotifyMonitor) Run(ctx context.Context) error {
+	defer m.watcher.Close()
+	ticker := time.NewTicker(max(m.settle/2, 100*time.Millisecond))
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-m.watcher.Events:
+			if !ok {
+				return nil
+			}
+			m.handleEvent(event, time.Now())
+		case err, ok := <-m.watcher.Errors:
+			if !ok {
+				return nil
+			}
+			// An overflow loses events; the periodic scan picks them up.
+			m.logger.Warn("repository monitor error",
+				zap.String("operation", "repository_monitor.watch"),
+				zap.Error(err),
+			)
+		case now := <-ticker.C:
+			m.flushPending(ctx, now)
+		}
+	}
+}
+
+func (m *FsnotifyMonitor) handleEvent(event fsnotify.Event, now time.Time) {
+	repositoryID, root, rel, ok := m.resolve(event.Name)
+	if !ok || scanner.IsExcludedWorkspacePath(rel) {
+		return
+	}
+	if event.Has(fsnotify.Create) {
+		if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+			// Files can land in a new directory before its watch is added,
+			// so queue whatever it already holds.
+			files, err := m.watchTree(root, event.Name)
+			if err != nil {
+				m.logger.Warn("failed to watch new repository directory",
+					zap.String("operation", "repository_monitor.watch"),
+					zap.String("repository_id", repositoryID),
+					zap.String("path", rel),
+					zap.Error(err),
+				)
+			}
+			m.mu.Lock()
+			for _, file := range files {
+				m.pending[pendingKey{repositoryID: repositoryID, storagePath: file}] = now
+			}
+			m.mu.Unlock()
+			return
+		}
+	}
+	if event.Has(fsnotify.Chmod) && !event.Has(fsnotify.Write) {
+		return
+	}
+	m.mu.Lock()
+	m.pending[pendingKey{repositoryID: repositoryID, storagePath: rel}] = now
+	m.mu.Unlock()
+}
+
+// resolve maps an absolute path to its repository and workspace-relative path.
+func (m *FsnotifyMonitor) resolve(path string) (repositoryID, root, rel string, ok bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for id, candidate := range m.roots {
+		if !strings.HasPrefix(path, candidate+string(filepath.Separator)) || len(candidate) <= len(root) {
+			continue
+		}
+		relPath, err := filepath.Rel(candidate, path)
+		if err != nil {
+			continue
+		}
+		repositoryID, root, rel, ok = id, candidate, filepath.ToSlash(relPath), true
+	}
+	return repositoryID, root, rel, ok
+}
+
+// watchTree watches dir and every directory below it outside the excluded
+// workspace paths, and returns the relative paths of the files it holds.
+func (m *FsnotifyMonitor) watchTree(root, dir string) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if path == dir {
+				return err
+			}
+			return nil
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return nil
+		}
+		rel = filepath.ToSlash(rel)
+		if !d.IsDir() {
+			files = append(files, rel)
+			return nil
+		}
+		if rel != "." && scanner.IsExcludedWorkspacePath(rel) {
+			return filepath.SkipDir
+		}
+		if err := m.watcher.Add(path); err != nil {
+			return fmt.Errorf("watch %s: %w", path, err)
+		}
+		return nil
+	})
+	return files, err
+}
+
+// change is a settled workspace path: a supported file that exists with
+// size bytes, or one that is gone.
+type change struct {
+	repositoryID string
+	root         string
+	storagePath  string
+	exists       bool
+	size         int64
+}
+
+// flushPending queues the changes that have settled by now.
+func (m *FsnotifyMonitor) flushPending(ctx context.Context, now time.Time) {
+	m.mu.Lock()
+	var settled []pendingKey
+	for key, last := range m.pending {
+		if now.Sub(last) >= m.settle {
+			settled = append(settled, key)
+			delete(m.pending, key)
+		}
+	}
+	roots := make(map[string]string, len(m.roots))
+	for id, root := range m.roots {
+		roots[id] = root
+	}
+	m.mu.Unlock()
+
+	var changes []change
+	for _, key := range settled {
+		root, ok := roots[key.repositoryID]
+		if !ok {
+			continue
+		}
+		if c, ok := m.settledChange(root, key); ok {
+			changes = append(changes, c)
+		}
+	}
+	if len(changes) == 0 {
+		return
+	}
+
+	params, handled := m.detectMoves(ctx, changes)
+	for i, c := range changes {
+		if handled[i] {
+			continue
+		}
+		operation := jobs.DiscoverOperationUpsert
+		if !c.exists {
+			operation = jobs.DiscoverOperationDelete
+		}
+		params = append(params, scanner.DiscoverInsertParams(c.repositoryID, c.storagePath, c.size, operation))
+	}
+	if _, err := m.queue.InsertMany(ctx, params); err != nil {
+		// Dropped; the periodic scan queues these files again.
+		m.logger.Warn("failed to queue discovered repository changes",
+			zap.String("operation", "repository_monitor.enqueue"),
+			zap.Int("count", len(params)),
+			zap.Error(err),
+		)
+	}
+}
+
+// settledChange resolves a settled path, or false when it is not a supported
+// workspace file.
+func (m *FsnotifyMonitor) settledChange(root string, key pendingKey) (change, bool) {
+	storagePath, ok := scanner.ShouldScanPath(key.storagePath)
+	if !ok {
+		return change{}, false
+	}
+	c := change{repositoryID: key.repositoryID, root: root, storagePath: storagePath}
+	info, err := os.Stat(c.fullPath())
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		return c, true
+	case err != nil, !info.Mode().IsRegular():
+		return change{}, false
+	case m.minFileSize > 0 && info.Size() < m.minFileSize:
+		return change{}, false
+	}
+	c.exists, c.size = true, info.Size()
+	return c, true
+}
+
+func (c change) fullPath() string {
+	return filepath.Join(c.root, filepath.FromSlash(c.storagePath))
+}
+
+// moveSource is the asset recorded at a settled path.
+type moveSource struct {
+	change int
+	asset  repo.Asset
+}
+
+// detectMoves pairs settled files with assets that left another settled path
+// with the same size and content hash, like the scanner's move
+// reconciliation. Each pair becomes a move job; handled marks the changes the
+// moves account for. The path an asset left is handled even when a new file
+// already took its place, since an upsert there could run before the move
+// and overwrite the asset; the periodic scan imports that file instead.
+func (m *FsnotifyMonitor) detectMoves(ctx context.Context, changes []change) ([]river.InsertManyParams, map[int]bool) {
+	handled := make(map[int]bool)
+	if m.assets == nil {
+		return nil, handled
+	}
+
+	own := make(map[int]repo.Asset)
+	bySize := make(map[string]map[int64][]moveSource)
+	for i, c := range changes {
+		repoUUID, err := uuid.Parse(c.repositoryID)
+		if err != nil {
+			continue
+		}
+		storagePath := c.storagePath
+		asset, err := m.assets.GetAssetByRepositoryAndStoragePathAny(ctx, repo.GetAssetByRepositoryAndStoragePathAnyParams{
+			RepositoryID: pgtype.UUID{Bytes: repoUUID, Valid: true},
+			StoragePath:  &storagePath,
+		})
+		if err != nil {
+			continue
+		}
+		own[i] = asset
+		if bySize[c.repositoryID] == nil {
+			bySize[c.repositoryID] = make(map[int64][]moveSource)
+		}
+		bySize[c.repositoryID][asset.FileSize] = append(bySize[c.repositoryID][asset.FileSize], moveSource{change: i, asset: asset})
+	}
+	if len(own) == 0 {
+		return nil, handled
+	}
+
+	hashes := make(map[int]string)
+	hashOf := func(i int) string {
+		if h, ok := hashes[i]; ok {
+			return h
+		}
+		var h string
+		if changes[i].exists {
+			if result, err := hash.CalculateFileHash(changes[i].fullPath(), hash.AlgorithmBLAKE3, false); err == nil {
+				h = result.Hash
+			}
+		}
+		hashes[i] = h
+		return h
+	}
+	// left reports whether the asset's file is no longer at its path.
+	left := func(source moveSource) bool {
+		return !changes[source.change].exists || hashOf(source.change) != source.asset.ContentHash
+	}
+
+	var params []river.InsertManyParams
+	claimed := make(map[int]bool)
+	for i, c := range changes {
+		candidates := bySize[c.repositoryID][c.size]
+		if !c.exists || len(candidates) == 0 {
+			continue
+		}
+		contentHash := hashOf(i)
+		if contentHash == "" {
+			continue
+		}
+		if asset, ok := own[i]; ok && asset.ContentHash == contentHash {
+			continue // unchanged in place
+		}
+		var matches []moveSource
+		for _, source := range candidates {
+			if source.change != i && !claimed[source.change] && source.asset.ContentHash == contentHash && left(source) {
+				matches = append(matches, source)
+			}
+		}
+		// Identical copies leave the pairing ambiguous; keep the plain upsert.
+		if len(matches) != 1 {
+			continue
+		}
+		source := matches[0]
+		claimed[source.change] = true
+		handled[i] = true
+		handled[source.change] = true
+		params = append(params, moveParams(c, changes[source.change].storagePath, source.asset))
+	}
+	return params, handled
+}
+
+// moveParams builds the discover_asset job renaming asset from previousPath
+// to the path of c.
+func moveParams(c change, previousPath string, asset repo.Asset) river.InsertManyParams {
+	insert := scanner.DiscoverInsertParams(c.repositoryID, c.storagePath, c.size, jobs.DiscoverOperationUpsert)
+	args := insert.Args.(jobs.DiscoverAssetArgs)
+	args.Operation = jobs.DiscoverOperationMove
+	args.PreviousPath = previousPath
+	args.AssetID = uuid.UUID(asset.AssetID.Bytes).String()
+	insert.Args = args
+	return insert
+}
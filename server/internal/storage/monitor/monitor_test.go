@@ -0,0 +1,234 @@
+package monitor
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"server/internal/db/repo"
+	"server/internal/queue/jobs"
+	"server/internal/utils/hash"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/riverqueue/river"
+	"github.com/riverqueue/river/rivertype"
+	"github.com/stretchr/testify/require"
+)
+
+const testRepositoryID = "8a4c5c8e-5b0f-4d6a-9a57-2f4b0f6c1d3e"
+
+type fakeEnqueuer struct {
+	mu   sync.Mutex
+	args []jobs.DiscoverAssetArgs
+}
+
+func (f *fakeEnqueuer) InsertMany(_ context.Context, params []river.InsertManyParams) ([]*rivertype.JobInsertResult, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, p := range params {
+		f.args = append(f.args, p.Args.(jobs.DiscoverAssetArgs))
+	}
+	return nil, nil
+}
+
+func (f *fakeEnqueuer) take() []jobs.DiscoverAssetArgs {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	args := f.args
+	f.args = nil
+	return args
+}
+
+// fakeAssets maps storage paths to the assets recorded there.
+type fakeAssets map[string]repo.Asset
+
+func (f fakeAssets) GetAssetByRepositoryAndStoragePathAny(_ context.Context, arg repo.GetAssetByRepositoryAndStoragePathAnyParams) (repo.Asset, error) {
+	asset, ok := f[*arg.StoragePath]
+	if !ok {
+		return repo.Asset{}, pgx.ErrNoRows
+	}
+	return asset, nil
+}
+
+func newTestMonitor(t *testing.T, minFileSize int64) (*FsnotifyMonitor, *fakeEnqueuer, string) {
+	t.Helper()
+	root := t.TempDir()
+	for _, dir := range []string{".lumilio/staging", "inbox/2026", "albums"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(root, dir), 0755))
+	}
+	queue := &fakeEnqueuer{}
+	m, err := NewFsnotifyMonitor(queue, fakeAssets{}, time.Second, minFileSize, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = m.watcher.Close() })
+	require.NoError(t, m.AddRepository(testRepositoryID, root))
+	return m, queue, root
+}
+
+func writeFile(t *testing.T, path string, size int) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, make([]byte, size), 0644))
+}
+
+// recordAsset writes content to the workspace path and records an asset for it.
+func recordAsset(t *testing.T, m *FsnotifyMonitor, root, storagePath, content string) repo.Asset {
+	t.Helper()
+	path := filepath.Join(root, filepath.FromSlash(storagePath))
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	result, err := hash.CalculateFileHash(path, hash.AlgorithmBLAKE3, false)
+	require.NoError(t, err)
+	asset := repo.Asset{
+		AssetID:     pgtype.UUID{Bytes: uuid.New(), Valid: true},
+		FileSize:    int64(len(content)),
+		ContentHash: result.Hash,
+	}
+	m.assets.(fakeAssets)[storagePath] = asset
+	return asset
+}
+
+func assetIDString(asset repo.Asset) string {
+	return uuid.UUID(asset.AssetID.Bytes).String()
+}
+
+func TestAddRepositorySkipsSystemDirectories(t *testing.T) {
+	m, _, root := newTestMonitor(t, 0)
+
+	watched := m.watcher.WatchList()
+	require.Contains(t, watched, root)
+	require.Contains(t, watched, filepath.Join(root, "albums"))
+	for _, path := range watched {
+		require.NotContains(t, path, ".lumilio")
+		require.NotContains(t, path, "inbox")
+	}
+
+	m.RemoveRepository(testRepositoryID)
+	require.Empty(t, m.watcher.WatchList())
+}
+
+func TestFlushPendingQueuesSettledChanges(t *testing.T) {
+	m, queue, root := newTestMonitor(t, 0)
+	ctx := context.Background()
+	now := time.Now()
+
+	photo := filepath.Join(root, "albums", "a.jpg")
+	writeFile(t, photo, 10)
+	m.handleEvent(fsnotify.Event{Name: photo, Op: fsnotify.Create}, now)
+	m.handleEvent(fsnotify.Event{Name: filepath.Join(root, "albums", "notes.txt"), Op: fsnotify.Create}, now)
+	m.handleEvent(fsnotify.Event{Name: filepath.Join(root, "inbox", "2026", "b.jpg"), Op: fsnotify.Create}, now)
+
+	m.flushPending(ctx, now.Add(500*time.Millisecond))
+	require.Empty(t, queue.take(), "changes wait for the settle time")
+
+	m.handleEvent(fsnotify.Event{Name: photo, Op: fsnotify.Write}, now.Add(800*time.Millisecond))
+	m.flushPending(ctx, now.Add(1500*time.Millisecond))
+	require.Empty(t, queue.take(), "a new event restarts the settle timer")
+
+	m.flushPending(ctx, now.Add(2*time.Second))
+	args := queue.take()
+	require.Len(t, args, 1)
+	require.Equal(t, testRepositoryID, args[0].RepositoryID)
+	require.Equal(t, "albums/a.jpg", args[0].RelativePath)
+	require.Equal(t, jobs.DiscoverOperationUpsert, args[0].Operation)
+	require.Equal(t, "a.jpg", args[0].FileName)
+	require.Equal(t, int64(10), args[0].FileSize)
+
+	require.NoError(t, os.Remove(photo))
+	m.handleEvent(fsnotify.Event{Name: photo, Op: fsnotify.Remove}, now.Add(3*time.Second))
+	m.flushPending(ctx, now.Add(5*time.Second))
+	args = queue.take()
+	require.Len(t, args, 1)
+	require.Equal(t, jobs.DiscoverOperationDelete, args[0].Operation)
+	require.Equal(t, "albums/a.jpg", args[0].RelativePath)
+}
+
+func TestNewDirectoryIsWatchedAndItsFilesQueued(t *testing.T) {
+	m, queue, root := newTestMonitor(t, 5)
+	now := time.Now()
+
+	dir := filepath.Join(root, "trips", "2026")
+	writeFile(t, filepath.Join(dir, "big.jpg"), 10)
+	writeFile(t, filepath.Join(dir, "tiny.jpg"), 1)
+	m.handleEvent(fsnotify.Event{Name: filepath.Join(root, "trips"), Op: fsnotify.Create}, now)
+
+	require.True(t, slices.Contains(m.watcher.WatchList(), dir))
+
+	m.flushPending(context.Background(), now.Add(time.Second))
+	args := queue.take()
+	require.Len(t, args, 1, "files below the minimum size are skipped")
+	require.Equal(t, "trips/2026/big.jpg", args[0].RelativePath)
+}
+
+func TestRenameIsQueuedAsMove(t *testing.T) {
+	m, queue, root := newTestMonitor(t, 0)
+	now := time.Now()
+
+	asset := recordAsset(t, m, root, "albums/a.jpg", "photo a")
+	from, to := filepath.Join(root, "albums", "a.jpg"), filepath.Join(root, "albums", "b.jpg")
+	require.NoError(t, os.Rename(from, to))
+	m.handleEvent(fsnotify.Event{Name: from, Op: fsnotify.Rename}, now)
+	m.handleEvent(fsnotify.Event{Name: to, Op: fsnotify.Create}, now)
+
+	m.flushPending(context.Background(), now.Add(time.Second))
+	args := queue.take()
+	require.Len(t, args, 1, "a rename is one move, not a delete and an upsert")
+	require.Equal(t, jobs.DiscoverOperationMove, args[0].Operation)
+	require.Equal(t, "albums/b.jpg", args[0].RelativePath)
+	require.Equal(t, "albums/a.jpg", args[0].PreviousPath)
+	require.Equal(t, assetIDString(asset), args[0].AssetID)
+	require.Equal(t, "b.jpg", args[0].FileName)
+}
+
+func TestSwappedFilesAreQueuedAsTwoMoves(t *testing.T) {
+	m, queue, root := newTestMonitor(t, 0)
+	now := time.Now()
+
+	x := recordAsset(t, m, root, "albums/x.jpg", "photo x")
+	y := recordAsset(t, m, root, "albums/y.jpg", "photo y")
+	xPath, yPath := filepath.Join(root, "albums", "x.jpg"), filepath.Join(root, "albums", "y.jpg")
+	tmp := filepath.Join(root, "albums", "swap.tmp")
+	require.NoError(t, os.Rename(xPath, tmp))
+	require.NoError(t, os.Rename(yPath, xPath))
+	require.NoError(t, os.Rename(tmp, yPath))
+	for _, path := range []string{xPath, yPath, tmp} {
+		m.handleEvent(fsnotify.Event{Name: path, Op: fsnotify.Create}, now)
+	}
+
+	m.flushPending(context.Background(), now.Add(time.Second))
+	moves := make(map[string]jobs.DiscoverAssetArgs)
+	for _, args := range queue.take() {
+		require.Equal(t, jobs.DiscoverOperationMove, args.Operation)
+		moves[args.RelativePath] = args
+	}
+	require.Len(t, moves, 2)
+	require.Equal(t, assetIDString(y), moves["albums/x.jpg"].AssetID)
+	require.Equal(t, "albums/y.jpg", moves["albums/x.jpg"].PreviousPath)
+	require.Equal(t, assetIDString(x), moves["albums/y.jpg"].AssetID)
+	require.Equal(t, "albums/x.jpg", moves["albums/y.jpg"].PreviousPath)
+}
+
+func TestUnchangedAndCopiedFilesAreNotMoves(t *testing.T) {
+	m, queue, root := newTestMonitor(t, 0)
+	now := time.Now()
+
+	recordAsset(t, m, root, "albums/a.jpg", "photo a")
+	copyPath := filepath.Join(root, "albums", "copy.jpg")
+	writeFile(t, copyPath, 0)
+	require.NoError(t, os.WriteFile(copyPath, []byte("photo a"), 0644))
+	m.handleEvent(fsnotify.Event{Name: filepath.Join(root, "albums", "a.jpg"), Op: fsnotify.Write}, now)
+	m.handleEvent(fsnotify.Event{Name: copyPath, Op: fsnotify.Create}, now)
+
+	m.flushPending(context.Background(), now.Add(time.Second))
+	args := queue.take()
+	require.Len(t, args, 2)
+	for _, a := range args {
+		require.Equal(t, jobs.DiscoverOperationUpsert, a.Operation, a.RelativePath)
+	}
+}
@@ -0,0 +1,67 @@
+package storage
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"server/internal/utils/hash"
+)
+
+// ArchivedOriginalPath returns where the original at storagePath of a
+// repository is kept under archiveRoot. Repositories sharing an archive root
+// get a directory each, so equal storage paths do not collide.
+func ArchivedOriginalPath(archiveRoot, repositoryID, storagePath string) string {
+	return filepath.Join(archiveRoot, repositoryID, filepath.FromSlash(storagePath))
+}
+
+// ArchiveOriginal copies the original at source to target and verifies the
+// copy by content hash; removing source is left to the caller. The copy is
+// written beside target and renamed into place, so target never holds a
+// partial file.
+func ArchiveOriginal(source, target string) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return fmt.Errorf("create archive directory: %w", err)
+	}
+	in, err := os.Open(source)
+	if err != nil {
+		return fmt.Errorf("open original: %w", err)
+	}
+	defer in.Close()
+
+	tmp, err := os.CreateTemp(filepath.Dir(target), ".archive-*")
+	if err != nil {
+		return fmt.Errorf("create archive copy: %w", err)
+	}
+	tmpPath := tmp.Name()
+	defer os.Remove(tmpPath)
+
+	if _, err := io.Copy(tmp, in); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("copy original: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("sync archive copy: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("close archive copy: %w", err)
+	}
+
+	want, err := hash.CalculateFileHash(source, hash.AlgorithmBLAKE3, false)
+	if err != nil {
+		return fmt.Errorf("hash original: %w", err)
+	}
+	got, err := hash.CalculateFileHash(tmpPath, hash.AlgorithmBLAKE3, false)
+	if err != nil {
+		return fmt.Errorf("hash archive copy: %w", err)
+	}
+	if got.Hash != want.Hash || got.FileSize != want.FileSize {
+		return fmt.Errorf("archive copy of %s does not match the original", source)
+	}
+	if err := os.Rename(tmpPath, target); err != nil {
+		return fmt.Errorf("move archive copy into place: %w", err)
+	}
+	return nil
+}
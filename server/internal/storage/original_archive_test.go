@@ -0,0 +1,39 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestArchiveOriginalCopiesAndVerifies(t *testing.T) {
+	repoRoot, archiveRoot := t.TempDir(), t.TempDir()
+	source := filepath.Join(repoRoot, "2024", "photo.jpg")
+	require.NoError(t, os.MkdirAll(filepath.Dir(source), 0o755))
+	require.NoError(t, os.WriteFile(source, []byte("original bytes"), 0o644))
+
+	target := ArchivedOriginalPath(archiveRoot, "repo-1", "2024/photo.jpg")
+	require.Equal(t, filepath.Join(archiveRoot, "repo-1", "2024", "photo.jpg"), target)
+	require.NoError(t, ArchiveOriginal(source, target))
+
+	archived, err := os.ReadFile(target)
+	require.NoError(t, err)
+	require.Equal(t, "original bytes", string(archived))
+	_, err = os.Stat(source)
+	require.NoError(t, err, "removing the original is left to the caller")
+
+	entries, err := os.ReadDir(filepath.Dir(target))
+	require.NoError(t, err)
+	require.Len(t, entries, 1, "no temporary copy is left behind")
+}
+
+func TestArchiveOriginalMissingSourceLeavesNoTarget(t *testing.T) {
+	archiveRoot := t.TempDir()
+	target := ArchivedOriginalPath(archiveRoot, "repo-1", "gone.jpg")
+
+	require.Error(t, ArchiveOriginal(filepath.Join(t.TempDir(), "gone.jpg"), target))
+	_, err := os.Stat(target)
+	require.True(t, os.IsNotExist(err))
+}
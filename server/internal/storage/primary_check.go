@@ -0,0 +1,39 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"server/internal/db/dbtypes"
+	"server/internal/db/repo"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// ErrPrimaryRepositoryUnavailable reports that uploads without an explicit
+// repository have nowhere to go: the primary repository is missing or its
+// location is not reachable.
+var ErrPrimaryRepositoryUnavailable = errors.New("primary repository unavailable")
+
+// PrimaryRepositoryLookup is the query CheckPrimaryRepository depends on.
+type PrimaryRepositoryLookup interface {
+	GetPrimaryRepository(ctx context.Context) (repo.Repository, error)
+}
+
+// CheckPrimaryRepository reports whether the primary repository is registered
+// and reachable. It reads the status left by ReconcileAll rather than touching
+// the disk itself.
+func CheckPrimaryRepository(ctx context.Context, lookup PrimaryRepositoryLookup) error {
+	repository, err := lookup.GetPrimaryRepository(ctx)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("%w: no primary repository is registered", ErrPrimaryRepositoryUnavailable)
+	}
+	if err != nil {
+		return fmt.Errorf("get primary repository: %w", err)
+	}
+	if repository.Status == dbtypes.RepoStatusOffline || repository.Status == dbtypes.RepoStatusError {
+		return fmt.Errorf("%w: %s is %s", ErrPrimaryRepositoryUnavailable, repository.Path, repository.Status)
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"server/internal/db/dbtypes"
+	"server/internal/db/repo"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type stubPrimaryLookup struct {
+	repository repo.Repository
+	err        error
+}
+
+func (s stubPrimaryLookup) GetPrimaryRepository(context.Context) (repo.Repository, error) {
+	return s.repository, s.err
+}
+
+func TestCheckPrimaryRepository(t *testing.T) {
+	cases := map[string]struct {
+		lookup      stubPrimaryLookup
+		unavailable bool
+		wantErr     bool
+	}{
+		"active":     {lookup: stubPrimaryLookup{repository: repo.Repository{Status: dbtypes.RepoStatusActive}}},
+		"scanning":   {lookup: stubPrimaryLookup{repository: repo.Repository{Status: dbtypes.RepoStatusScanning}}},
+		"missing":    {lookup: stubPrimaryLookup{err: pgx.ErrNoRows}, unavailable: true, wantErr: true},
+		"offline":    {lookup: stubPrimaryLookup{repository: repo.Repository{Path: "/mnt/photos", Status: dbtypes.RepoStatusOffline}}, unavailable: true, wantErr: true},
+		"error":      {lookup: stubPrimaryLookup{repository: repo.Repository{Status: dbtypes.RepoStatusError}}, unavailable: true, wantErr: true},
+		"query fail": {lookup: stubPrimaryLookup{err: errors.New("connection refused")}, wantErr: true},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := CheckPrimaryRepository(context.Background(), tc.lookup)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("CheckPrimaryRepository() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if got := errors.Is(err, ErrPrimaryRepositoryUnavailable); got != tc.unavailable {
+				t.Fatalf("errors.Is(err, ErrPrimaryRepositoryUnavailable) = %v, want %v (err %v)", got, tc.unavailable, err)
+			}
+		})
+	}
+}
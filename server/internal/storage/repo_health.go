@@ -0,0 +1,116 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"server/internal/db/dbtypes"
+	"server/internal/db/repo"
+)
+
+// RepositoryLister is the query CheckRepositoryHealth depends on.
+type RepositoryLister interface {
+	ListRepositories() ([]*repo.Repository, error)
+}
+
+// StructureValidator checks a repository's on-disk layout.
+type StructureValidator interface {
+	ValidateStructure(repoPath string) (*StructureValidation, error)
+}
+
+// RepositoryHealthOptions tunes CheckRepositoryHealth.
+type RepositoryHealthOptions struct {
+	// SyncStaleAfter flags a repository whose last completed scan is older than
+	// this. Zero disables the check, e.g. when periodic scans are off.
+	SyncStaleAfter time.Duration
+	// Now is the reference time for staleness; zero means time.Now().
+	Now time.Time
+}
+
+// RepositoryHealth is one repository's structure and sync state.
+type RepositoryHealth struct {
+	RepositoryID string
+	Name         string
+	Status       dbtypes.RepoStatus
+	Healthy      bool
+	// Structure is nil when the repository is offline and was not inspected.
+	Structure *StructureValidation
+	LastSync  *time.Time
+	SyncStale bool
+	Problems  []string
+}
+
+// CheckRepositoryHealth validates every registered repository's directory
+// structure and sync recency. Unlike ValidateStructure, a missing directory
+// counts as unhealthy here: it is repairable, but until then uploads or
+// thumbnails into it fail. Offline repositories are reported without touching
+// their path.
+func CheckRepositoryHealth(ctx context.Context, repositories RepositoryLister, validator StructureValidator, opts RepositoryHealthOptions) ([]RepositoryHealth, error) {
+	listed, err := repositories.ListRepositories()
+	if err != nil {
+		return nil, fmt.Errorf("list repositories: %w", err)
+	}
+	now := opts.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+
+	results := make([]RepositoryHealth, 0, len(listed))
+	for _, repository := range listed {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		results = append(results, checkOneRepository(repository, validator, opts.SyncStaleAfter, now))
+	}
+	return results, nil
+}
+
+func checkOneRepository(repository *repo.Repository, validator StructureValidator, staleAfter time.Duration, now time.Time) RepositoryHealth {
+	health := RepositoryHealth{
+		RepositoryID: repository.RepoID.String(),
+		Name:         repository.Name,
+		Status:       repository.Status,
+	}
+	if repository.LastSync.Valid {
+		lastSync := repository.LastSync.Time
+		health.LastSync = &lastSync
+	}
+
+	switch repository.Status {
+	case dbtypes.RepoStatusOffline, dbtypes.RepoStatusError:
+		health.Problems = append(health.Problems, fmt.Sprintf("repository is %s", repository.Status))
+	default:
+		validation, err := validator.ValidateStructure(repository.Path)
+		if err != nil {
+			health.Problems = append(health.Problems, fmt.Sprintf("validate structure: %v", err))
+			break
+		}
+		health.Structure = validation
+		for _, dir := range validation.MissingDirectories {
+			health.Problems = append(health.Problems, "missing directory: "+dir)
+		}
+		health.Problems = append(health.Problems, validation.InvalidPaths...)
+		health.Problems = append(health.Problems, validation.PermissionIssues...)
+	}
+
+	if staleAfter > 0 {
+		// A repository that has never finished a scan is only stale once it has
+		// existed for a full window; a fresh registration is still scanning.
+		since := repository.CreatedAt
+		if repository.LastSync.Valid {
+			since = repository.LastSync
+		}
+		if since.Valid && now.Sub(since.Time) > staleAfter {
+			health.SyncStale = true
+			if repository.LastSync.Valid {
+				health.Problems = append(health.Problems, fmt.Sprintf("last sync %s ago", now.Sub(since.Time).Round(time.Minute)))
+			} else {
+				health.Problems = append(health.Problems, "never synced")
+			}
+		}
+	}
+
+	health.Healthy = len(health.Problems) == 0
+	return health
+}
@@ -0,0 +1,102 @@
+package storage
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"server/internal/db/dbtypes"
+	"server/internal/db/repo"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type stubRepositoryLister []*repo.Repository
+
+func (s stubRepositoryLister) ListRepositories() ([]*repo.Repository, error) {
+	return s, nil
+}
+
+func healthTestRepository(t *testing.T, name string, status dbtypes.RepoStatus, lastSync time.Time) *repo.Repository {
+	t.Helper()
+	path := t.TempDir()
+	if err := NewDirectoryManager().CreateStructure(path); err != nil {
+		t.Fatalf("CreateStructure: %v", err)
+	}
+	repository := &repo.Repository{
+		RepoID:    pgtype.UUID{Bytes: uuid.New(), Valid: true},
+		Name:      name,
+		Path:      path,
+		Status:    status,
+		CreatedAt: pgtype.Timestamptz{Time: lastSync.Add(-time.Hour), Valid: true},
+	}
+	if !lastSync.IsZero() {
+		repository.LastSync = pgtype.Timestamptz{Time: lastSync, Valid: true}
+	}
+	return repository
+}
+
+func TestCheckRepositoryHealth(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	healthy := healthTestRepository(t, "healthy", dbtypes.RepoStatusActive, now.Add(-10*time.Minute))
+	missingDir := healthTestRepository(t, "missing-dir", dbtypes.RepoStatusActive, now.Add(-10*time.Minute))
+	if err := os.RemoveAll(filepath.Join(missingDir.Path, ".lumilio", "assets", "faces")); err != nil {
+		t.Fatal(err)
+	}
+	stale := healthTestRepository(t, "stale", dbtypes.RepoStatusScanning, now.Add(-48*time.Hour))
+	offline := &repo.Repository{
+		RepoID: pgtype.UUID{Bytes: uuid.New(), Valid: true},
+		Name:   "offline",
+		Path:   filepath.Join(t.TempDir(), "unplugged"),
+		Status: dbtypes.RepoStatusOffline,
+	}
+
+	results, err := CheckRepositoryHealth(context.Background(),
+		stubRepositoryLister{healthy, missingDir, stale, offline},
+		NewDirectoryManager(),
+		RepositoryHealthOptions{SyncStaleAfter: 6 * time.Hour, Now: now})
+	if err != nil {
+		t.Fatalf("CheckRepositoryHealth: %v", err)
+	}
+	byName := map[string]RepositoryHealth{}
+	for _, result := range results {
+		byName[result.Name] = result
+	}
+
+	if got := byName["healthy"]; !got.Healthy || got.Structure == nil || len(got.Problems) != 0 {
+		t.Fatalf("healthy repository reported %+v", got)
+	}
+
+	got := byName["missing-dir"]
+	if got.Healthy {
+		t.Fatal("repository with a missing directory reported healthy")
+	}
+	if len(got.Problems) != 1 || !strings.Contains(got.Problems[0], ".lumilio/assets/faces") {
+		t.Fatalf("missing-dir problems = %v", got.Problems)
+	}
+
+	if got := byName["stale"]; got.Healthy || !got.SyncStale {
+		t.Fatalf("stale repository reported %+v", got)
+	}
+
+	got = byName["offline"]
+	if got.Healthy || got.Structure != nil {
+		t.Fatalf("offline repository reported %+v", got)
+	}
+}
+
+func TestCheckRepositoryHealthStalenessDisabled(t *testing.T) {
+	old := healthTestRepository(t, "old", dbtypes.RepoStatusActive, time.Now().Add(-30*24*time.Hour))
+
+	results, err := CheckRepositoryHealth(context.Background(), stubRepositoryLister{old}, NewDirectoryManager(), RepositoryHealthOptions{})
+	if err != nil {
+		t.Fatalf("CheckRepositoryHealth: %v", err)
+	}
+	if len(results) != 1 || !results[0].Healthy || results[0].SyncStale {
+		t.Fatalf("results = %+v, want healthy with staleness disabled", results)
+	}
+}
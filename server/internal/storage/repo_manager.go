@@ -62,6 +62,10 @@ type RepositoryManager interface {
 	// repository-relative.
 	RelocateRepository(ctx context.Context, id string, newPath string) (*repo.Repository, error)
 
+	// AssignLegacyAssets attaches assets that have no repository to the
+	// repository with the given id, making their storage paths relative to it.
+	AssignLegacyAssets(ctx context.Context, id string) (LegacyAssignResult, error)
+
 	// RegisterRepositoryCopy registers a duplicated repository directory as an
 	// independent repository by minting a fresh UUID into its .lumiliorepo.
 	RegisterRepositoryCopy(ctx context.Context, path string, defaultOwnerID *int32, role dbtypes.RepoRole) (*repo.Repository, error)
@@ -82,6 +86,10 @@ type RepositoryManager interface {
 	// ListRepositories returns all registered repositories.
 	ListRepositories() ([]*repo.Repository, error)
 
+	// GetRepositoryAssetStats summarises the assets of a repository, optionally
+	// only those of one owner.
+	GetRepositoryAssetStats(ctx context.Context, id string, ownerID *int32) (*RepositoryAssetStats, error)
+
 	// HostOwnerID returns the initial administrator that represents ownership
 	// of this host. The primary repository pins the identity after bootstrap;
 	// before then, the first account is used. Nil means setup has no user yet.
@@ -93,7 +101,9 @@ type RepositoryManager interface {
 
 	// RemoveRepository deletes only the database record; the on-disk repository
 	// and its media are left untouched so the data can be re-registered later.
-	RemoveRepository(id string) error
+	// A repository that still has assets is refused with ErrRepositoryHasAssets
+	// unless policy says what to do with them.
+	RemoveRepository(id string, policy RemovedAssetPolicy) error
 
 	// GetRepositoryPath returns the absolute on-disk path of a repository.
 	// face/people depend on this via their own narrow interfaces.
@@ -440,7 +450,7 @@ func (rm *DefaultRepositoryManager) InitializeRepository(path string, config rep
 
 	// Validate configuration
 	if err := config.Validate(); err != nil {
-		return nil, fmt.Errorf("invalid configuration: %w", err)
+		return nil, err
 	}
 
 	// Create directory structure using directory manager
@@ -547,28 +557,53 @@ func (rm *DefaultRepositoryManager) ListRepositories() ([]*repo.Repository, erro
 	return result, nil
 }
 
-func (rm *DefaultRepositoryManager) RemoveRepository(id string) error {
+func (rm *DefaultRepositoryManager) RemoveRepository(id string, policy RemovedAssetPolicy) error {
 	repoUUID, err := uuid.Parse(id)
 	if err != nil {
 		rm.logger.Warn("repository remove failed: invalid id", zap.String("operation", "repository.remove"), zap.String("repository_id", id), zap.Error(err))
 		return fmt.Errorf("invalid repository ID: %w", err)
 	}
+	repoID := pgtype.UUID{Bytes: repoUUID, Valid: true}
 
 	var repoPath string
 	if rm.queries != nil {
-		existing, getErr := rm.queries.GetRepository(context.Background(), pgtype.UUID{Bytes: repoUUID, Valid: true})
+		existing, getErr := rm.queries.GetRepository(context.Background(), repoID)
 		if getErr == nil {
 			repoPath = existing.Path
 		}
 	}
 
-	err = rm.queries.DeleteRepository(context.Background(), pgtype.UUID{Bytes: repoUUID, Valid: true})
+	assetCount, err := rm.queries.CountRepositoryAssets(context.Background(), repoID)
+	if err != nil {
+		rm.repoAudit(repoPath).Error("repository.remove", err, zap.String("repository_id", id))
+		return fmt.Errorf("failed to count repository assets: %w", err)
+	}
+
+	var detached int64
+	if assetCount > 0 {
+		if policy == RemovedAssetsRefuse {
+			return fmt.Errorf("%w: %d assets", ErrRepositoryHasAssets, assetCount)
+		}
+		detached, err = rm.queries.DetachRepositoryAssets(context.Background(), repo.DetachRepositoryAssetsParams{
+			RepositoryID: repoID,
+			SoftDelete:   policy == RemovedAssetsSoftDelete,
+		})
+		if err != nil {
+			rm.repoAudit(repoPath).Error("repository.remove", err, zap.String("repository_id", id), zap.String("asset_policy", string(policy)))
+			return fmt.Errorf("failed to detach repository assets: %w", err)
+		}
+	}
+
+	err = rm.queries.DeleteRepository(context.Background(), repoID)
 	if err != nil {
 		rm.repoAudit(repoPath).Error("repository.remove", err, zap.String("repository_id", id))
 		return fmt.Errorf("failed to remove repository: %w", err)
 	}
 
-	rm.repoAudit(repoPath).Operation("repository.remove", zap.String("repository_id", id))
+	rm.repoAudit(repoPath).Operation("repository.remove",
+		zap.String("repository_id", id),
+		zap.String("asset_policy", string(policy)),
+		zap.Int64("detached_assets", detached))
 
 	return nil
 }
@@ -592,7 +627,7 @@ func (rm *DefaultRepositoryManager) UpdateRepository(id string, config repocfg.R
 
 	// Validate configuration
 	if err := config.Validate(); err != nil {
-		return nil, fmt.Errorf("invalid configuration: %w", err)
+		return nil, err
 	}
 
 	// The on-disk .lumiliorepo is authoritative and the DB config column is a
@@ -33,6 +33,10 @@ type CreateRepositorySpec struct {
 	OwnerID           *int32
 	StorageStrategy   string
 	DuplicateHandling string
+	// Imported, when set, supplies the whole configuration of the new
+	// repository instead of the defaults. An imported configuration never
+	// registers an existing repository in its place.
+	Imported *repocfg.PortableConfig
 }
 
 // CreateRepositoryResult carries the created repository plus any non-fatal
@@ -83,6 +87,9 @@ func (rm *DefaultRepositoryManager) CreateRepository(ctx context.Context, spec C
 	}
 
 	if repocfg.IsRepositoryRoot(repoPath) {
+		if spec.Imported != nil {
+			return nil, fmt.Errorf("%w: %s", ErrRepositoryExistsAtPath, repoPath)
+		}
 		dbRepo, err := rm.AddRepository(repoPath, spec.OwnerID, role, rootIDs...)
 		if err != nil {
 			return nil, err
@@ -90,15 +97,20 @@ func (rm *DefaultRepositoryManager) CreateRepository(ctx context.Context, spec C
 		return &CreateRepositoryResult{Repository: dbRepo, Warnings: warnings}, nil
 	}
 
-	defaults, err := rm.GetRepositoryDefaults(ctx)
-	if err != nil {
-		return nil, err
+	var cfg *repocfg.RepositoryConfig
+	if spec.Imported != nil {
+		cfg = spec.Imported.NewRepositoryConfig(spec.Name)
+	} else {
+		defaults, err := rm.GetRepositoryDefaults(ctx)
+		if err != nil {
+			return nil, err
+		}
+		cfg = repocfg.NewRepositoryConfig(
+			spec.Name,
+			repocfg.WithStorageStrategy(firstNonEmpty(spec.StorageStrategy, defaults.Strategy, "date")),
+			repocfg.WithLocalSettings(firstNonEmpty(spec.DuplicateHandling, defaults.DuplicateHandling, "rename")),
+		)
 	}
-	cfg := repocfg.NewRepositoryConfig(
-		spec.Name,
-		repocfg.WithStorageStrategy(firstNonEmpty(spec.StorageStrategy, defaults.Strategy, "date")),
-		repocfg.WithLocalSettings(firstNonEmpty(spec.DuplicateHandling, defaults.DuplicateHandling, "rename")),
-	)
 	dbRepo, err := rm.InitializeRepository(repoPath, *cfg, spec.OwnerID, role, rootIDs...)
 	if err != nil {
 		return nil, err
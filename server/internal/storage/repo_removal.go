@@ -0,0 +1,41 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	// ErrRepositoryHasAssets reports that a repository still owns assets and
+	// the caller did not choose what should happen to them on removal.
+	ErrRepositoryHasAssets = errors.New("repository still has assets")
+
+	// ErrRepositoryRemoved reports that an asset's repository was removed, so
+	// its files are no longer reachable through the registry.
+	ErrRepositoryRemoved = errors.New("repository was removed")
+)
+
+// RemovedAssetPolicy selects what RemoveRepository does with the assets of the
+// repository being removed.
+type RemovedAssetPolicy string
+
+const (
+	// RemovedAssetsRefuse keeps the repository when it still has assets.
+	RemovedAssetsRefuse RemovedAssetPolicy = ""
+	// RemovedAssetsDetach keeps the assets in the library, unlinked from any
+	// repository and marked as coming from a removed one.
+	RemovedAssetsDetach RemovedAssetPolicy = "detach"
+	// RemovedAssetsSoftDelete detaches the assets and also moves them to Trash.
+	RemovedAssetsSoftDelete RemovedAssetPolicy = "soft_delete"
+)
+
+// ParseRemovedAssetPolicy accepts "", "detach" and "soft_delete".
+func ParseRemovedAssetPolicy(raw string) (RemovedAssetPolicy, error) {
+	switch policy := RemovedAssetPolicy(strings.ToLower(strings.TrimSpace(raw))); policy {
+	case RemovedAssetsRefuse, RemovedAssetsDetach, RemovedAssetsSoftDelete:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("unknown removed asset policy %q", raw)
+	}
+}
@@ -0,0 +1,95 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"server/internal/db/repo"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestParseRemovedAssetPolicy(t *testing.T) {
+	tests := map[string]RemovedAssetPolicy{
+		"":             RemovedAssetsRefuse,
+		"detach":       RemovedAssetsDetach,
+		" Soft_Delete": RemovedAssetsSoftDelete,
+	}
+	for raw, want := range tests {
+		got, err := ParseRemovedAssetPolicy(raw)
+		require.NoError(t, err, raw)
+		require.Equal(t, want, got)
+	}
+
+	_, err := ParseRemovedAssetPolicy("purge")
+	require.Error(t, err)
+}
+
+// removalDB answers the queries RemoveRepository issues: GetRepository finds
+// nothing, CountRepositoryAssets reports assets, and every Exec is recorded
+// under its sqlc query name.
+type removalDB struct {
+	repo.DBTX
+	assets   int64
+	executed []string
+}
+
+func (db *removalDB) QueryRow(_ context.Context, sql string, _ ...interface{}) pgx.Row {
+	if strings.HasPrefix(sql, "-- name: CountRepositoryAssets ") {
+		return countRow(db.assets)
+	}
+	return countRow(-1)
+}
+
+func (db *removalDB) Exec(_ context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
+	name := strings.Fields(sql)[2]
+	if name == "DetachRepositoryAssets" {
+		db.executed = append(db.executed, fmt.Sprintf("%s soft_delete=%v", name, args[1]))
+		return pgconn.NewCommandTag(fmt.Sprintf("UPDATE %d", db.assets)), nil
+	}
+	db.executed = append(db.executed, name)
+	return pgconn.NewCommandTag("DELETE 1"), nil
+}
+
+type countRow int64
+
+func (r countRow) Scan(dest ...any) error {
+	if r < 0 {
+		return pgx.ErrNoRows
+	}
+	*dest[0].(*int64) = int64(r)
+	return nil
+}
+
+func TestRemoveRepositoryAssetPolicy(t *testing.T) {
+	id := uuid.NewString()
+	tests := []struct {
+		policy RemovedAssetPolicy
+		assets int64
+		want   []string
+	}{
+		{RemovedAssetsRefuse, 0, []string{"DeleteRepository"}},
+		{RemovedAssetsRefuse, 3, nil},
+		{RemovedAssetsDetach, 3, []string{"DetachRepositoryAssets soft_delete=false", "DeleteRepository"}},
+		{RemovedAssetsSoftDelete, 3, []string{"DetachRepositoryAssets soft_delete=true", "DeleteRepository"}},
+	}
+	for _, tt := range tests {
+		db := &removalDB{assets: tt.assets}
+		manager, err := NewRepositoryManager(repo.New(db), zap.NewNop(), nil)
+		require.NoError(t, err)
+
+		err = manager.RemoveRepository(id, tt.policy)
+		if tt.want == nil {
+			require.ErrorIs(t, err, ErrRepositoryHasAssets)
+		} else {
+			require.NoError(t, err)
+		}
+		require.Equal(t, tt.want, db.executed, "policy %q with %d assets", tt.policy, tt.assets)
+	}
+}
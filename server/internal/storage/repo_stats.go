@@ -0,0 +1,75 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"server/internal/db/repo"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// RepositoryAssetStats summarises the live (not trashed) assets of a
+// repository.
+type RepositoryAssetStats struct {
+	TotalAssets int64
+	PhotoCount  int64
+	VideoCount  int64
+	AudioCount  int64
+	LikedCount  int64
+	RatedCount  int64
+	// AverageRating is over rated assets only; nil when none is rated.
+	AverageRating *float64
+	// TotalSize is the sum of the recorded original sizes, in bytes.
+	TotalSize    int64
+	OldestUpload *time.Time
+	NewestUpload *time.Time
+}
+
+// GetRepositoryAssetStats counts the assets of the repository with the given
+// UUID, restricted to ownerID when it is set. An unregistered repository
+// yields an error wrapping pgx.ErrNoRows.
+func (rm *DefaultRepositoryManager) GetRepositoryAssetStats(ctx context.Context, id string, ownerID *int32) (*RepositoryAssetStats, error) {
+	repoUUID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid repository ID: %w", err)
+	}
+	repositoryID := pgtype.UUID{Bytes: repoUUID, Valid: true}
+	if _, err := rm.queries.GetRepository(ctx, repositoryID); err != nil {
+		return nil, fmt.Errorf("repository not found: %w", err)
+	}
+
+	row, err := rm.queries.GetRepositoryAssetStats(ctx, repo.GetRepositoryAssetStatsParams{
+		RepositoryID: repositoryID,
+		OwnerID:      ownerID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get repository asset stats: %w", err)
+	}
+	return repositoryAssetStatsFromRow(row), nil
+}
+
+func repositoryAssetStatsFromRow(row repo.GetRepositoryAssetStatsRow) *RepositoryAssetStats {
+	stats := &RepositoryAssetStats{
+		TotalAssets: row.TotalAssets,
+		PhotoCount:  row.PhotoCount,
+		VideoCount:  row.VideoCount,
+		AudioCount:  row.AudioCount,
+		LikedCount:  row.LikedCount,
+		RatedCount:  row.RatedCount,
+		TotalSize:   row.TotalSize,
+	}
+	if row.RatedCount > 0 {
+		average := row.AvgRating
+		stats.AverageRating = &average
+	}
+	if row.OldestUpload.Valid {
+		stats.OldestUpload = &row.OldestUpload.Time
+	}
+	if row.NewestUpload.Valid {
+		stats.NewestUpload = &row.NewestUpload.Time
+	}
+	return stats
+}
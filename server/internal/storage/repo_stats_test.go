@@ -0,0 +1,36 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"server/internal/db/repo"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepositoryAssetStatsFromRow(t *testing.T) {
+	empty := repositoryAssetStatsFromRow(repo.GetRepositoryAssetStatsRow{})
+	require.Zero(t, empty.TotalAssets)
+	require.Nil(t, empty.AverageRating, "no rated assets means no average, not 0")
+	require.Nil(t, empty.OldestUpload)
+	require.Nil(t, empty.NewestUpload)
+
+	oldest := time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC)
+	newest := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	stats := repositoryAssetStatsFromRow(repo.GetRepositoryAssetStatsRow{
+		TotalAssets:  3,
+		PhotoCount:   2,
+		VideoCount:   1,
+		RatedCount:   2,
+		AvgRating:    3.5,
+		TotalSize:    4096,
+		OldestUpload: pgtype.Timestamptz{Time: oldest, Valid: true},
+		NewestUpload: pgtype.Timestamptz{Time: newest, Valid: true},
+	})
+	require.Equal(t, 3.5, *stats.AverageRating)
+	require.EqualValues(t, 4096, stats.TotalSize)
+	require.Equal(t, oldest, *stats.OldestUpload)
+	require.Equal(t, newest, *stats.NewestUpload)
+}
@@ -0,0 +1,80 @@
+package repocfg
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+// Identification of a portable configuration bundle. PortableConfigVersion
+// changes whenever a field is removed or changes meaning; importers refuse
+// versions they do not know.
+const (
+	PortableConfigKind    = "lumilio.repository-config"
+	PortableConfigVersion = 1
+)
+
+// PortableConfig is the part of a repository's configuration that can be
+// carried to another instance: how files are laid out and processed. The
+// identity, creation time and location stay behind; an import creates a new
+// repository with its own. ArchivePath is carried verbatim and must make
+// sense on the importing host.
+type PortableConfig struct {
+	Kind            string        `json:"kind" example:"lumilio.repository-config"`
+	Version         int           `json:"version" example:"1"`
+	Name            string        `json:"name" example:"Family Photos"`
+	ExportedAt      time.Time     `json:"exported_at"`
+	StorageStrategy string        `json:"storage_strategy" example:"date"`
+	LocalSettings   LocalSettings `json:"local_settings"`
+}
+
+// Portable returns the portable part of the configuration, stamped with
+// exportedAt.
+func (rc *RepositoryConfig) Portable(exportedAt time.Time) PortableConfig {
+	return PortableConfig{
+		Kind:            PortableConfigKind,
+		Version:         PortableConfigVersion,
+		Name:            rc.Name,
+		ExportedAt:      exportedAt.UTC(),
+		StorageStrategy: rc.StorageStrategy,
+		LocalSettings:   rc.LocalSettings.clone(),
+	}
+}
+
+// Validate checks that the bundle is one this version understands and that
+// it describes a valid configuration. Every error wraps ErrInvalidConfig.
+func (p PortableConfig) Validate() error {
+	if p.Kind != PortableConfigKind {
+		return fmt.Errorf("%w: unsupported bundle kind '%s', expected %s", ErrInvalidConfig, p.Kind, PortableConfigKind)
+	}
+	if p.Version != PortableConfigVersion {
+		return fmt.Errorf("%w: unsupported bundle version %d, expected %d", ErrInvalidConfig, p.Version, PortableConfigVersion)
+	}
+	name := p.Name
+	if name == "" {
+		// The importer may name the repository; only the settings matter here.
+		name = "imported"
+	}
+	return p.NewRepositoryConfig(name).Validate()
+}
+
+// NewRepositoryConfig creates the configuration of a new repository called
+// name with the bundle's settings.
+func (p PortableConfig) NewRepositoryConfig(name string) *RepositoryConfig {
+	cfg := NewRepositoryConfig(name, WithStorageStrategy(p.StorageStrategy))
+	cfg.LocalSettings = p.LocalSettings.clone()
+	return cfg
+}
+
+func (s LocalSettings) clone() LocalSettings {
+	if s.MLEnabled != nil {
+		enabled := *s.MLEnabled
+		s.MLEnabled = &enabled
+	}
+	if s.SemanticSkip != nil {
+		skip := *s.SemanticSkip
+		skip.Extensions = slices.Clone(skip.Extensions)
+		s.SemanticSkip = &skip
+	}
+	return s
+}
@@ -0,0 +1,58 @@
+package repocfg
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPortableConfig_RoundTrip(t *testing.T) {
+	disabled := false
+	source := NewRepositoryConfig("Scans", WithStorageStrategy("flat"), WithLocalSettings("date_prefix"))
+	source.LocalSettings.ProcessingMode = ProcessingModeManual
+	source.LocalSettings.MLEnabled = &disabled
+
+	data, err := json.Marshal(source.Portable(time.Now()))
+	require.NoError(t, err)
+	var bundle PortableConfig
+	require.NoError(t, json.Unmarshal(data, &bundle))
+	require.NoError(t, bundle.Validate())
+
+	imported := bundle.NewRepositoryConfig("Scans copy")
+	require.NoError(t, imported.Validate())
+	assert.Equal(t, source.StorageStrategy, imported.StorageStrategy)
+	assert.Equal(t, source.LocalSettings, imported.LocalSettings)
+	assert.Equal(t, "Scans copy", imported.Name)
+	assert.NotEqual(t, source.ID, imported.ID, "an import is a new repository")
+
+	*imported.LocalSettings.MLEnabled = true
+	assert.False(t, *source.LocalSettings.MLEnabled, "settings are copied, not shared")
+}
+
+func TestPortableConfig_ValidateFailures(t *testing.T) {
+	valid := NewRepositoryConfig("Archive").Portable(time.Now())
+	require.NoError(t, valid.Validate())
+
+	unknownKind := valid
+	unknownKind.Kind = "something-else"
+	require.ErrorIs(t, unknownKind.Validate(), ErrInvalidConfig)
+
+	newerVersion := valid
+	newerVersion.Version = PortableConfigVersion + 1
+	err := newerVersion.Validate()
+	require.ErrorIs(t, err, ErrInvalidConfig)
+	assert.Contains(t, err.Error(), "unsupported bundle version")
+
+	badStrategy := valid
+	badStrategy.StorageStrategy = "by-camera"
+	err = badStrategy.Validate()
+	require.ErrorIs(t, err, ErrInvalidConfig)
+	assert.Contains(t, err.Error(), "invalid storage strategy")
+
+	unnamed := valid
+	unnamed.Name = ""
+	require.NoError(t, unnamed.Validate(), "the importer names the repository")
+}
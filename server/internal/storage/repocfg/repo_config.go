@@ -1,15 +1,38 @@
 package repocfg
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"gopkg.in/yaml.v3"
 )
 
+// ErrInvalidConfig is wrapped by every RepositoryConfig.Validate error.
+var ErrInvalidConfig = errors.New("invalid configuration")
+
+// Processing modes of LocalSettings.ProcessingMode.
+const (
+	// ProcessingModeOnUpload runs the ingest pipeline as soon as an upload is stored.
+	ProcessingModeOnUpload = "on_upload"
+	// ProcessingModeManual stores and records uploads but leaves them deferred
+	// until processing is requested for the repository.
+	ProcessingModeManual = "manual"
+)
+
+// Policies of LocalSettings.OriginalPolicy.
+const (
+	// OriginalPolicyKeep leaves originals in the repository.
+	OriginalPolicyKeep = "keep"
+	// OriginalPolicyArchive moves an original to ArchivePath once its web
+	// derivatives are generated and verified; they are served in its place.
+	OriginalPolicyArchive = "archive"
+)
+
 // RepositoryConfig represents the complete .lumiliorepo configuration file structure
 type RepositoryConfig struct {
 	Version   string    `yaml:"version" json:"version"`
@@ -18,15 +41,62 @@ type RepositoryConfig struct {
 	CreatedAt time.Time `yaml:"created_at" json:"created_at"`
 
 	// Storage configuration
-	StorageStrategy string        `yaml:"storage_strategy" json:"storage_strategy"` // "date", "cas", "flat" date -> yyyy/mm/IMG_001.jpg (month based)
+	// date -> inbox/yyyy/mm/IMG_001.jpg (month based), cas -> inbox/aa/bb/cc/<hash>.jpg,
+	// flat -> inbox/IMG_001.jpg under the original name, with collisions resolved by
+	// HandleDuplicateFilenames and recorded in .lumilio/flat-manifest.jsonl.
+	StorageStrategy string        `yaml:"storage_strategy" json:"storage_strategy"` // "date", "cas", "flat"
 	LocalSettings   LocalSettings `yaml:"local_settings" json:"local_settings"`
 }
 
 // LocalSettings configures repository-specific behavior
 type LocalSettings struct {
 	// HandleDuplicateFilenames how to handle files with same name
-	// "rename" = add (1), (2) suffix, "uuid" = add UUID, "overwrite" = replace existing
+	// "rename" = add (1), (2) suffix, "uuid" = add UUID, "overwrite" = replace existing,
+	// "skip" = keep the existing file and drop the new one,
+	// "date_prefix" = prefix the taken date, e.g. 20240615_IMG_0001.jpg
 	HandleDuplicateFilenames string `yaml:"handle_duplicate_filenames" json:"handle_duplicate_filenames"`
+	// ProcessingMode when uploads are processed: "on_upload" (the default, also
+	// when empty) or "manual", which defers them until
+	// POST /repositories/{id}/process-pending.
+	ProcessingMode string `yaml:"processing_mode,omitempty" json:"processing_mode,omitempty"`
+	// MLEnabled overrides the global ML settings for this repository: false
+	// queues no ML jobs for its assets; nil or true follows the global task
+	// flags.
+	MLEnabled *bool `yaml:"ml_enabled,omitempty" json:"ml_enabled,omitempty"`
+	// OriginalPolicy is "keep" (the default, also when empty) or "archive",
+	// which moves processed originals under ArchivePath, an absolute
+	// directory such as a cold-storage mount.
+	OriginalPolicy string `yaml:"original_policy,omitempty" json:"original_policy,omitempty"`
+	ArchivePath    string `yaml:"archive_path,omitempty" json:"archive_path,omitempty"`
+	// SemanticSkip names photos that are not sent for semantic (CLIP)
+	// indexing. Nil indexes every photo.
+	SemanticSkip *SemanticSkip `yaml:"semantic_skip,omitempty" json:"semantic_skip,omitempty"`
+}
+
+// SemanticSkip selects photos that get no semantic embedding, and so no
+// zero-shot tags or search hits by content. Screenshots and scanned documents
+// mostly earn junk tags. OCR and face detection still run on them.
+type SemanticSkip struct {
+	// Screenshots skips photos whose pixel size is a common screen
+	// resolution, or whose name marks them as a screenshot.
+	Screenshots bool `yaml:"screenshots,omitempty" json:"screenshots,omitempty"`
+	// Extensions skips photos by file extension, e.g. [".png", ".tiff"].
+	// Matching ignores case.
+	Extensions []string `yaml:"extensions,omitempty" json:"extensions,omitempty"`
+}
+
+// SkipsExtension reports whether photos named filename are skipped by extension.
+func (s *SemanticSkip) SkipsExtension(filename string) bool {
+	if s == nil {
+		return false
+	}
+	ext := filepath.Ext(filename)
+	for _, skipped := range s.Extensions {
+		if ext != "" && strings.EqualFold(ext, skipped) {
+			return true
+		}
+	}
+	return false
 }
 
 // DefaultRepositoryConfig returns a sensible default configuration template
@@ -37,6 +107,7 @@ func DefaultRepositoryConfig() *RepositoryConfig {
 		StorageStrategy: "date",
 		LocalSettings: LocalSettings{
 			HandleDuplicateFilenames: "uuid",
+			ProcessingMode:           ProcessingModeOnUpload,
 		},
 	}
 }
@@ -115,7 +186,7 @@ func (rc *RepositoryConfig) SaveConfigToFile(repoPath string) error {
 
 	// Validate before saving
 	if err := rc.Validate(); err != nil {
-		return fmt.Errorf("invalid configuration: %w", err)
+		return err
 	}
 
 	data, err := yaml.Marshal(rc)
@@ -131,18 +202,19 @@ func (rc *RepositoryConfig) SaveConfigToFile(repoPath string) error {
 	return nil
 }
 
-// Validate checks if the repository configuration is valid
+// Validate checks if the repository configuration is valid. Every error wraps
+// ErrInvalidConfig and names the offending field.
 func (rc *RepositoryConfig) Validate() error {
 	if rc.Version == "" {
-		return fmt.Errorf("version is required")
+		return fmt.Errorf("%w: version is required", ErrInvalidConfig)
 	}
 
 	if rc.ID == "" {
-		return fmt.Errorf("repository ID is required")
+		return fmt.Errorf("%w: repository ID is required", ErrInvalidConfig)
 	}
 
 	if rc.Name == "" {
-		return fmt.Errorf("repository name is required")
+		return fmt.Errorf("%w: repository name is required", ErrInvalidConfig)
 	}
 
 	// Validate storage strategy
@@ -152,22 +224,64 @@ func (rc *RepositoryConfig) Validate() error {
 		"flat": true,
 	}
 	if !validStrategies[rc.StorageStrategy] {
-		return fmt.Errorf("invalid storage strategy '%s', must be one of: date, cas, flat", rc.StorageStrategy)
+		return fmt.Errorf("%w: invalid storage strategy '%s', must be one of: date, cas, flat", ErrInvalidConfig, rc.StorageStrategy)
 	}
 
 	// Validate duplicate handling strategy
 	validDuplicateStrategies := map[string]bool{
-		"rename":    true,
-		"uuid":      true,
-		"overwrite": true,
+		"rename":      true,
+		"uuid":        true,
+		"overwrite":   true,
+		"skip":        true,
+		"date_prefix": true,
 	}
 	if !validDuplicateStrategies[rc.LocalSettings.HandleDuplicateFilenames] {
-		return fmt.Errorf("invalid handle_duplicate_filenames '%s', must be one of: rename, uuid, overwrite", rc.LocalSettings.HandleDuplicateFilenames)
+		return fmt.Errorf("%w: invalid handle_duplicate_filenames '%s', must be one of: rename, uuid, overwrite, skip, date_prefix", ErrInvalidConfig, rc.LocalSettings.HandleDuplicateFilenames)
+	}
+
+	switch rc.LocalSettings.ProcessingMode {
+	case "", ProcessingModeOnUpload, ProcessingModeManual:
+	default:
+		return fmt.Errorf("%w: invalid processing_mode '%s', must be one of: on_upload, manual", ErrInvalidConfig, rc.LocalSettings.ProcessingMode)
+	}
+
+	switch rc.LocalSettings.OriginalPolicy {
+	case "", OriginalPolicyKeep:
+	case OriginalPolicyArchive:
+		if !filepath.IsAbs(rc.LocalSettings.ArchivePath) {
+			return fmt.Errorf("%w: original_policy 'archive' needs an absolute archive_path", ErrInvalidConfig)
+		}
+	default:
+		return fmt.Errorf("%w: invalid original_policy '%s', must be one of: keep, archive", ErrInvalidConfig, rc.LocalSettings.OriginalPolicy)
+	}
+
+	if skip := rc.LocalSettings.SemanticSkip; skip != nil {
+		for _, ext := range skip.Extensions {
+			if len(ext) < 2 || ext[0] != '.' || strings.ContainsAny(ext[1:], `./\`) {
+				return fmt.Errorf("%w: invalid semantic_skip extension '%s', must look like .png", ErrInvalidConfig, ext)
+			}
+		}
 	}
 
 	return nil
 }
 
+// DefersProcessing reports whether uploads wait for an explicit processing request.
+func (rc *RepositoryConfig) DefersProcessing() bool {
+	return rc.LocalSettings.ProcessingMode == ProcessingModeManual
+}
+
+// DisablesML reports whether ML processing is turned off for the repository
+// regardless of the global settings.
+func (rc *RepositoryConfig) DisablesML() bool {
+	return rc.LocalSettings.MLEnabled != nil && !*rc.LocalSettings.MLEnabled
+}
+
+// ArchivesOriginals reports whether processed originals move to the archive path.
+func (rc *RepositoryConfig) ArchivesOriginals() bool {
+	return rc.LocalSettings.OriginalPolicy == OriginalPolicyArchive
+}
+
 // IsRepositoryRoot checks if a directory contains a .lumiliorepo file
 func IsRepositoryRoot(path string) bool {
 	configPath := filepath.Join(path, ".lumiliorepo")
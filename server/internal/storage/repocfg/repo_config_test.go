@@ -81,7 +81,7 @@ func TestRepositoryConfig_ValidateFailures(t *testing.T) {
 	t.Run("invalid storage strategy", func(t *testing.T) {
 		cfg := NewRepositoryConfig("Invalid", WithStorageStrategy("unknown"))
 		err := cfg.Validate()
-		require.Error(t, err)
+		require.ErrorIs(t, err, ErrInvalidConfig)
 		assert.Contains(t, err.Error(), "invalid storage strategy")
 	})
 
@@ -89,9 +89,72 @@ func TestRepositoryConfig_ValidateFailures(t *testing.T) {
 		cfg := NewRepositoryConfig("Invalid")
 		cfg.LocalSettings.HandleDuplicateFilenames = "bad-mode"
 		err := cfg.Validate()
-		require.Error(t, err)
+		require.ErrorIs(t, err, ErrInvalidConfig)
 		assert.Contains(t, err.Error(), "invalid handle_duplicate_filenames")
 	})
+
+	t.Run("invalid processing mode", func(t *testing.T) {
+		cfg := NewRepositoryConfig("Invalid")
+		cfg.LocalSettings.ProcessingMode = "later"
+		err := cfg.Validate()
+		require.ErrorIs(t, err, ErrInvalidConfig)
+		assert.Contains(t, err.Error(), "invalid processing_mode")
+	})
+}
+
+func TestRepositoryConfig_ProcessingMode(t *testing.T) {
+	cfg := NewRepositoryConfig("Archive")
+	assert.Equal(t, ProcessingModeOnUpload, cfg.LocalSettings.ProcessingMode)
+	assert.False(t, cfg.DefersProcessing())
+
+	// Configs written before the setting existed process on upload.
+	cfg.LocalSettings.ProcessingMode = ""
+	require.NoError(t, cfg.Validate())
+	assert.False(t, cfg.DefersProcessing())
+
+	cfg.LocalSettings.ProcessingMode = ProcessingModeManual
+	require.NoError(t, cfg.Validate())
+	assert.True(t, cfg.DefersProcessing())
+}
+
+func TestRepositoryConfig_MLEnabled(t *testing.T) {
+	cfg := NewRepositoryConfig("Documents")
+	assert.Nil(t, cfg.LocalSettings.MLEnabled)
+	assert.False(t, cfg.DisablesML(), "unset follows the global settings")
+
+	enabled, disabled := true, false
+	cfg.LocalSettings.MLEnabled = &enabled
+	assert.False(t, cfg.DisablesML())
+	cfg.LocalSettings.MLEnabled = &disabled
+	assert.True(t, cfg.DisablesML())
+
+	dir := t.TempDir()
+	require.NoError(t, cfg.SaveConfigToFile(dir))
+	loaded, err := LoadConfigFromFile(dir)
+	require.NoError(t, err)
+	assert.True(t, loaded.DisablesML(), "ml_enabled survives a round trip through .lumiliorepo")
+}
+
+func TestRepositoryConfig_OriginalPolicy(t *testing.T) {
+	cfg := NewRepositoryConfig("Cold")
+	assert.False(t, cfg.ArchivesOriginals(), "originals stay in the repository by default")
+
+	cfg.LocalSettings.OriginalPolicy = OriginalPolicyArchive
+	err := cfg.Validate()
+	require.ErrorIs(t, err, ErrInvalidConfig)
+	assert.Contains(t, err.Error(), "archive_path")
+
+	cfg.LocalSettings.ArchivePath = "relative/archive"
+	require.ErrorIs(t, cfg.Validate(), ErrInvalidConfig)
+
+	cfg.LocalSettings.ArchivePath = t.TempDir()
+	require.NoError(t, cfg.Validate())
+	assert.True(t, cfg.ArchivesOriginals())
+
+	cfg.LocalSettings.OriginalPolicy = "delete"
+	err = cfg.Validate()
+	require.ErrorIs(t, err, ErrInvalidConfig)
+	assert.Contains(t, err.Error(), "invalid original_policy")
 }
 
 func TestIsRepositoryRoot(t *testing.T) {
@@ -102,3 +165,27 @@ func TestIsRepositoryRoot(t *testing.T) {
 	require.NoError(t, cfg.SaveConfigToFile(dir))
 	assert.True(t, IsRepositoryRoot(dir))
 }
+
+func TestRepositoryConfig_SemanticSkip(t *testing.T) {
+	cfg := NewRepositoryConfig("Screens")
+	var unset *SemanticSkip
+	assert.False(t, unset.SkipsExtension("scan.png"), "no rule skips nothing")
+
+	cfg.LocalSettings.SemanticSkip = &SemanticSkip{Screenshots: true, Extensions: []string{".png", ".TIFF"}}
+	require.NoError(t, cfg.Validate())
+	assert.True(t, cfg.LocalSettings.SemanticSkip.SkipsExtension("Scan 1.PNG"))
+	assert.True(t, cfg.LocalSettings.SemanticSkip.SkipsExtension("page.tiff"))
+	assert.False(t, cfg.LocalSettings.SemanticSkip.SkipsExtension("IMG_0001.jpg"))
+	assert.False(t, cfg.LocalSettings.SemanticSkip.SkipsExtension("png"))
+
+	dir := t.TempDir()
+	require.NoError(t, cfg.SaveConfigToFile(dir))
+	loaded, err := LoadConfigFromFile(dir)
+	require.NoError(t, err)
+	assert.Equal(t, cfg.LocalSettings.SemanticSkip, loaded.LocalSettings.SemanticSkip, "semantic_skip survives a round trip through .lumiliorepo")
+
+	for _, ext := range []string{"png", ".", ".tar.gz", "../x"} {
+		cfg.LocalSettings.SemanticSkip.Extensions = []string{ext}
+		assert.ErrorIs(t, cfg.Validate(), ErrInvalidConfig, ext)
+	}
+}
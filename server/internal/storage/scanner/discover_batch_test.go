@@ -0,0 +1,60 @@
+package scanner
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"server/internal/queue/jobs"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/riverqueue/river"
+	"github.com/riverqueue/river/rivertype"
+)
+
+// recordingInserter counts InsertMany calls and the jobs in each.
+type recordingInserter struct {
+	batches []int
+	paths   []string
+}
+
+func (r *recordingInserter) InsertMany(_ context.Context, params []river.InsertManyParams) ([]*rivertype.JobInsertResult, error) {
+	r.batches = append(r.batches, len(params))
+	for _, p := range params {
+		r.paths = append(r.paths, p.Args.(jobs.DiscoverAssetArgs).RelativePath)
+	}
+	return nil, nil
+}
+
+func TestDiscoverBatcherGroupsInserts(t *testing.T) {
+	inserter := &recordingInserter{}
+	batch := &discoverBatcher{queue: inserter, ctx: context.Background(), batchSize: 20}
+	repositoryID := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+
+	for i := range 50 {
+		name := fmt.Sprintf("photo-%02d.jpg", i)
+		entry := diskEntry{StoragePath: "album/" + name, Filename: name, Size: 1024}
+		if err := batch.add(repositoryID, entry, jobs.DiscoverOperationUpsert); err != nil {
+			t.Fatalf("add: %v", err)
+		}
+	}
+	if err := batch.flush(); err != nil {
+		t.Fatalf("flush: %v", err)
+	}
+
+	if want := []int{20, 20, 10}; fmt.Sprint(inserter.batches) != fmt.Sprint(want) {
+		t.Fatalf("InsertMany batches = %v, want %v", inserter.batches, want)
+	}
+	if len(inserter.paths) != 50 || inserter.paths[0] != "album/photo-00.jpg" || inserter.paths[49] != "album/photo-49.jpg" {
+		t.Fatalf("unexpected discovered paths: %v", inserter.paths)
+	}
+
+	// Nothing left pending: another flush must not insert an empty batch.
+	if err := batch.flush(); err != nil {
+		t.Fatalf("flush: %v", err)
+	}
+	if len(inserter.batches) != 3 {
+		t.Fatalf("empty flush inserted a batch: %v", inserter.batches)
+	}
+}
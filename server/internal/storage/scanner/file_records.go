@@ -0,0 +1,265 @@
+package scanner
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"server/internal/db/repo"
+	"server/internal/storage"
+	"server/internal/utils/hash"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"go.uber.org/zap"
+)
+
+// FileRecordStore is the part of repo.Queries RebuildFileRecords uses.
+type FileRecordStore interface {
+	ListAssetsByRepositoryAny(ctx context.Context, repositoryID pgtype.UUID) ([]repo.Asset, error)
+	ListAssetsWithoutRepository(ctx context.Context) ([]repo.ListAssetsWithoutRepositoryRow, error)
+	MoveAssetWithinRepository(ctx context.Context, arg repo.MoveAssetWithinRepositoryParams) (repo.Asset, error)
+	AssignAssetRepository(ctx context.Context, arg repo.AssignAssetRepositoryParams) (int64, error)
+}
+
+// FileRecordRebuildResult reports how the files of a repository line up with
+// asset rows after a rebuild.
+type FileRecordRebuildResult struct {
+	// Matched counts files an asset of the repository already pointed at.
+	Matched int64
+	// Relinked counts assets of the repository whose file had moved and was
+	// found again by content hash.
+	Relinked int64
+	// Assigned counts assets without a repository that were attached to one of
+	// its files, by former path or by content hash.
+	Assigned int64
+	// UnmatchedFiles are repository-relative paths no asset points at. A scan
+	// imports them as new assets.
+	UnmatchedFiles []string
+	// MissingAssetIDs are live assets of the repository whose file was not
+	// found anywhere in it.
+	MissingAssetIDs []string
+	// Partial is set when some of the repository could not be read, so
+	// MissingAssetIDs was not computed.
+	Partial bool
+}
+
+// RebuildFileRecords walks a repository and repairs which file every asset
+// row points at: assets whose file moved are relinked by content hash, and
+// assets that lost their repository are attached to it by former path or
+// content hash. With dryRun the result is computed but nothing is written.
+// New files are only reported; importing them is the scanner's job.
+func (s *Scanner) RebuildFileRecords(ctx context.Context, repositoryID string, dryRun bool) (FileRecordRebuildResult, error) {
+	if s == nil || s.queries == nil {
+		return FileRecordRebuildResult{}, fmt.Errorf("repository scanner unavailable")
+	}
+	repoID, err := parseRepositoryID(repositoryID)
+	if err != nil {
+		return FileRecordRebuildResult{}, err
+	}
+	repository, err := s.queries.GetRepository(ctx, repoID)
+	if err != nil {
+		return FileRecordRebuildResult{}, fmt.Errorf("get repository: %w", err)
+	}
+	// Walking an unplugged drive would report every asset as missing.
+	if !isScannableRepositoryRoot(repository.Path) {
+		return FileRecordRebuildResult{}, fmt.Errorf("%w: %s", storage.ErrRepositoryOffline, repository.Name)
+	}
+
+	walk, err := walkRepository(repository.Path, 0, s.minFileSize)
+	if err != nil {
+		return FileRecordRebuildResult{}, fmt.Errorf("walk repository: %w", err)
+	}
+	result, err := rebuildFileRecords(ctx, s.queries, repository, walk, dryRun)
+	if err != nil {
+		return result, err
+	}
+
+	s.logger.Info("repository file records rebuilt",
+		zap.String("operation", "repository_scan.rebuild_file_records"),
+		zap.String("repository_id", repository.RepoID.String()),
+		zap.Bool("dry_run", dryRun),
+		zap.Int64("matched", result.Matched),
+		zap.Int64("relinked", result.Relinked),
+		zap.Int64("assigned", result.Assigned),
+		zap.Int("unmatched_files", len(result.UnmatchedFiles)),
+		zap.Int("missing_assets", len(result.MissingAssetIDs)),
+	)
+	return result, nil
+}
+
+// fileRecordCandidate is an asset that may own one of the unclaimed files:
+// either an asset of the repository whose path no longer exists, or an asset
+// without any repository.
+type fileRecordCandidate struct {
+	asset      repo.Asset
+	unassigned bool
+}
+
+func rebuildFileRecords(ctx context.Context, store FileRecordStore, repository repo.Repository, walk walkResult, dryRun bool) (FileRecordRebuildResult, error) {
+	var result FileRecordRebuildResult
+
+	assets, err := store.ListAssetsByRepositoryAny(ctx, repository.RepoID)
+	if err != nil {
+		return result, fmt.Errorf("list repository assets: %w", err)
+	}
+	unclaimed := make(map[string]diskEntry, len(walk.entries))
+	for storagePath, entry := range walk.entries {
+		unclaimed[storagePath] = entry
+	}
+	missing := make(map[string]repo.Asset)
+	for _, asset := range assets {
+		if asset.StoragePath == nil {
+			continue
+		}
+		cleaned, ok := CleanWorkspacePath(*asset.StoragePath)
+		if !ok || IsExcludedWorkspacePath(cleaned) {
+			continue
+		}
+		if _, onDisk := unclaimed[cleaned]; onDisk {
+			delete(unclaimed, cleaned)
+			result.Matched++
+			continue
+		}
+		if _, deferred := walk.deferredPaths[cleaned]; deferred || isSoftDeleted(asset) {
+			continue
+		}
+		missing[asset.AssetID.String()] = asset
+	}
+
+	unassigned, err := store.ListAssetsWithoutRepository(ctx)
+	if err != nil {
+		return result, fmt.Errorf("list assets without repository: %w", err)
+	}
+
+	// Former paths first: they are cheap and unambiguous.
+	var byHash []fileRecordCandidate
+	for _, row := range unassigned {
+		asset := repo.Asset{AssetID: row.AssetID, StoragePath: row.StoragePath, ContentHash: row.ContentHash, FileSize: row.FileSize}
+		if row.StoragePath != nil {
+			if rel, ok := storage.ResolveLegacyStoragePath(repository.Path, *row.StoragePath, row.FileSize, row.ContentHash); ok {
+				if _, free := unclaimed[rel]; free {
+					assigned, err := assignFileRecord(ctx, store, repository, asset, rel, dryRun)
+					if err != nil {
+						return result, err
+					}
+					if assigned {
+						delete(unclaimed, rel)
+						result.Assigned++
+						continue
+					}
+				}
+			}
+		}
+		byHash = append(byHash, fileRecordCandidate{asset: asset, unassigned: true})
+	}
+	for _, asset := range missing {
+		byHash = append(byHash, fileRecordCandidate{asset: asset})
+	}
+
+	// Then content hashes, hashing only files whose size some candidate has.
+	// A fingerprint shared by several candidates is ambiguous and left alone.
+	candidates := make(map[assetFingerprint][]fileRecordCandidate)
+	candidateSizes := make(map[int64]struct{})
+	for _, candidate := range byHash {
+		if strings.TrimSpace(candidate.asset.ContentHash) == "" {
+			continue
+		}
+		key := assetFingerprint{hash: candidate.asset.ContentHash, size: candidate.asset.FileSize}
+		candidates[key] = append(candidates[key], candidate)
+		candidateSizes[candidate.asset.FileSize] = struct{}{}
+	}
+	for _, storagePath := range sortedEntryPaths(unclaimed) {
+		if len(candidates) == 0 {
+			break
+		}
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+		entry := unclaimed[storagePath]
+		if _, ok := candidateSizes[entry.Size]; !ok {
+			continue
+		}
+		hashResult, err := hash.CalculateFileHash(filepath.Join(repository.Path, filepath.FromSlash(storagePath)), hash.AlgorithmBLAKE3, false)
+		if err != nil {
+			continue
+		}
+		key := assetFingerprint{hash: hashResult.Hash, size: entry.Size}
+		matches := candidates[key]
+		if len(matches) != 1 {
+			continue
+		}
+		candidate := matches[0]
+		delete(candidates, key)
+
+		if candidate.unassigned {
+			assigned, err := assignFileRecord(ctx, store, repository, candidate.asset, storagePath, dryRun)
+			if err != nil {
+				return result, err
+			}
+			if !assigned {
+				continue
+			}
+			result.Assigned++
+		} else {
+			if !dryRun {
+				if _, err := store.MoveAssetWithinRepository(ctx, repo.MoveAssetWithinRepositoryParams{
+					AssetID:          candidate.asset.AssetID,
+					RepositoryID:     repository.RepoID,
+					StoragePath:      &entry.StoragePath,
+					OriginalFilename: entry.Filename,
+				}); err != nil {
+					return result, fmt.Errorf("relink asset %s to %s: %w", candidate.asset.AssetID.String(), storagePath, err)
+				}
+			}
+			delete(missing, candidate.asset.AssetID.String())
+			result.Relinked++
+		}
+		delete(unclaimed, storagePath)
+	}
+
+	result.UnmatchedFiles = sortedEntryPaths(unclaimed)
+	if !walk.deleteSafe {
+		result.Partial = true
+		return result, nil
+	}
+	result.MissingAssetIDs = make([]string, 0, len(missing))
+	for id := range missing {
+		result.MissingAssetIDs = append(result.MissingAssetIDs, id)
+	}
+	sort.Strings(result.MissingAssetIDs)
+	return result, nil
+}
+
+// assignFileRecord attaches an asset without a repository to storagePath. It
+// reports false when the asset was attached elsewhere meanwhile or the path
+// already belongs to another asset.
+func assignFileRecord(ctx context.Context, store FileRecordStore, repository repo.Repository, asset repo.Asset, storagePath string, dryRun bool) (bool, error) {
+	if dryRun {
+		return true, nil
+	}
+	updated, err := store.AssignAssetRepository(ctx, repo.AssignAssetRepositoryParams{
+		AssetID:      asset.AssetID,
+		RepositoryID: repository.RepoID,
+		StoragePath:  &storagePath,
+	})
+	switch {
+	case isUniqueConstraintViolation(err):
+		return false, nil
+	case err != nil:
+		return false, fmt.Errorf("assign asset %s to %s: %w", asset.AssetID.String(), storagePath, err)
+	}
+	return updated > 0, nil
+}
+
+func sortedEntryPaths(entries map[string]diskEntry) []string {
+	paths := make([]string, 0, len(entries))
+	for storagePath := range entries {
+		paths = append(paths, storagePath)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+var _ FileRecordStore = (*repo.Queries)(nil)
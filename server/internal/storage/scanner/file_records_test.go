@@ -0,0 +1,194 @@
+package scanner
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"server/internal/db/repo"
+	"server/internal/utils/hash"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// fakeFileRecordStore keeps asset rows in memory: repositoryOf is empty for
+// assets without a repository.
+type fakeFileRecordStore struct {
+	assets       map[pgtype.UUID]*repo.Asset
+	order        []pgtype.UUID
+	repositoryOf map[pgtype.UUID]pgtype.UUID
+}
+
+func newFakeFileRecordStore() *fakeFileRecordStore {
+	return &fakeFileRecordStore{assets: map[pgtype.UUID]*repo.Asset{}, repositoryOf: map[pgtype.UUID]pgtype.UUID{}}
+}
+
+func (s *fakeFileRecordStore) add(repositoryID pgtype.UUID, storagePath *string, contentHash string, size int64) pgtype.UUID {
+	id := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+	s.assets[id] = &repo.Asset{AssetID: id, RepositoryID: repositoryID, StoragePath: storagePath, ContentHash: contentHash, FileSize: size}
+	s.repositoryOf[id] = repositoryID
+	s.order = append(s.order, id)
+	return id
+}
+
+func (s *fakeFileRecordStore) ListAssetsByRepositoryAny(_ context.Context, repositoryID pgtype.UUID) ([]repo.Asset, error) {
+	var assets []repo.Asset
+	for _, id := range s.order {
+		if s.repositoryOf[id] == repositoryID && s.assets[id].StoragePath != nil {
+			assets = append(assets, *s.assets[id])
+		}
+	}
+	return assets, nil
+}
+
+func (s *fakeFileRecordStore) ListAssetsWithoutRepository(context.Context) ([]repo.ListAssetsWithoutRepositoryRow, error) {
+	var rows []repo.ListAssetsWithoutRepositoryRow
+	for _, id := range s.order {
+		if !s.repositoryOf[id].Valid {
+			asset := s.assets[id]
+			rows = append(rows, repo.ListAssetsWithoutRepositoryRow{AssetID: id, StoragePath: asset.StoragePath, ContentHash: asset.ContentHash, FileSize: asset.FileSize})
+		}
+	}
+	return rows, nil
+}
+
+func (s *fakeFileRecordStore) MoveAssetWithinRepository(_ context.Context, arg repo.MoveAssetWithinRepositoryParams) (repo.Asset, error) {
+	asset := s.assets[arg.AssetID]
+	asset.StoragePath = arg.StoragePath
+	asset.OriginalFilename = arg.OriginalFilename
+	return *asset, nil
+}
+
+func (s *fakeFileRecordStore) AssignAssetRepository(_ context.Context, arg repo.AssignAssetRepositoryParams) (int64, error) {
+	if s.repositoryOf[arg.AssetID].Valid {
+		return 0, nil
+	}
+	s.repositoryOf[arg.AssetID] = arg.RepositoryID
+	s.assets[arg.AssetID].StoragePath = arg.StoragePath
+	return 1, nil
+}
+
+func (s *fakeFileRecordStore) pathOf(id pgtype.UUID) string {
+	if s.assets[id].StoragePath == nil {
+		return ""
+	}
+	return *s.assets[id].StoragePath
+}
+
+// writeRepositoryFile writes a file under root and returns its BLAKE3 hash and size.
+func writeRepositoryFile(t *testing.T, root, rel, content string) (string, int64) {
+	t.Helper()
+	path := filepath.Join(root, filepath.FromSlash(rel))
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+	result, err := hash.CalculateFileHash(path, hash.AlgorithmBLAKE3, false)
+	if err != nil {
+		t.Fatalf("hash file: %v", err)
+	}
+	return result.Hash, result.FileSize
+}
+
+func TestRebuildFileRecordsCreatesRecordsForKnownAssets(t *testing.T) {
+	root := t.TempDir()
+	repository := repo.Repository{RepoID: pgtype.UUID{Bytes: uuid.New(), Valid: true}, Path: root}
+	path := func(p string) *string { return &p }
+
+	keptHash, keptSize := writeRepositoryFile(t, root, "2021/kept.jpg", "kept")
+	movedHash, movedSize := writeRepositoryFile(t, root, "sorted/moved.jpg", "moved")
+	legacyHash, legacySize := writeRepositoryFile(t, root, "2019/trip/legacy.jpg", "legacy")
+	orphanHash, orphanSize := writeRepositoryFile(t, root, "misc/renamed.jpg", "orphan")
+	writeRepositoryFile(t, root, "new/unknown.jpg", "nobody")
+	writeRepositoryFile(t, root, ".lumilio/assets/thumbnails/small/x.jpg", "thumbnail")
+
+	run := func(dryRun bool) (*fakeFileRecordStore, FileRecordRebuildResult, map[string]pgtype.UUID) {
+		store := newFakeFileRecordStore()
+		ids := map[string]pgtype.UUID{
+			"kept":    store.add(repository.RepoID, path("2021/kept.jpg"), keptHash, keptSize),
+			"moved":   store.add(repository.RepoID, path("inbox-old/moved.jpg"), movedHash, movedSize),
+			"gone":    store.add(repository.RepoID, path("2020/gone.jpg"), "feed", 4),
+			"legacy":  store.add(pgtype.UUID{}, path("/mnt/old-library/2019/trip/legacy.jpg"), legacyHash, legacySize),
+			"orphan":  store.add(pgtype.UUID{}, nil, orphanHash, orphanSize),
+			"foreign": store.add(pgtype.UUID{}, path("/elsewhere/other.jpg"), "cafe", 9),
+		}
+		walk, err := walkRepository(root, 0, 0)
+		if err != nil {
+			t.Fatalf("walk repository: %v", err)
+		}
+		result, err := rebuildFileRecords(context.Background(), store, repository, walk, dryRun)
+		if err != nil {
+			t.Fatalf("rebuild file records: %v", err)
+		}
+		return store, result, ids
+	}
+
+	store, result, ids := run(false)
+	if result.Matched != 1 || result.Relinked != 1 || result.Assigned != 2 {
+		t.Fatalf("matched=%d relinked=%d assigned=%d, want 1, 1 and 2", result.Matched, result.Relinked, result.Assigned)
+	}
+	if want := []string{"new/unknown.jpg"}; !reflect.DeepEqual(result.UnmatchedFiles, want) {
+		t.Fatalf("unmatched files = %v, want %v", result.UnmatchedFiles, want)
+	}
+	if want := []string{ids["gone"].String()}; !reflect.DeepEqual(result.MissingAssetIDs, want) {
+		t.Fatalf("missing assets = %v, want %v", result.MissingAssetIDs, want)
+	}
+
+	for name, wantPath := range map[string]string{
+		"kept":   "2021/kept.jpg",
+		"moved":  "sorted/moved.jpg",
+		"legacy": "2019/trip/legacy.jpg",
+		"orphan": "misc/renamed.jpg",
+	} {
+		if got := store.pathOf(ids[name]); got != wantPath {
+			t.Fatalf("%s asset points at %q, want %q", name, got, wantPath)
+		}
+		if store.repositoryOf[ids[name]] != repository.RepoID {
+			t.Fatalf("%s asset was not attached to the repository", name)
+		}
+	}
+	if store.repositoryOf[ids["foreign"]].Valid {
+		t.Fatal("asset from another library was attached to the repository")
+	}
+
+	dryStore, dryResult, dryIDs := run(true)
+	if dryResult.Matched != 1 || dryResult.Relinked != 1 || dryResult.Assigned != 2 {
+		t.Fatalf("dry run matched=%d relinked=%d assigned=%d, want 1, 1 and 2", dryResult.Matched, dryResult.Relinked, dryResult.Assigned)
+	}
+	if got := dryStore.pathOf(dryIDs["moved"]); got != "inbox-old/moved.jpg" {
+		t.Fatalf("dry run moved the asset to %q", got)
+	}
+	if dryStore.repositoryOf[dryIDs["orphan"]].Valid {
+		t.Fatal("dry run attached an asset")
+	}
+}
+
+func TestRebuildFileRecordsSkipsAmbiguousHashes(t *testing.T) {
+	root := t.TempDir()
+	repository := repo.Repository{RepoID: pgtype.UUID{Bytes: uuid.New(), Valid: true}, Path: root}
+	contentHash, size := writeRepositoryFile(t, root, "copy.jpg", "same bytes")
+
+	store := newFakeFileRecordStore()
+	first := store.add(pgtype.UUID{}, nil, contentHash, size)
+	second := store.add(pgtype.UUID{}, nil, contentHash, size)
+
+	walk, err := walkRepository(root, 0, 0)
+	if err != nil {
+		t.Fatalf("walk repository: %v", err)
+	}
+	result, err := rebuildFileRecords(context.Background(), store, repository, walk, false)
+	if err != nil {
+		t.Fatalf("rebuild file records: %v", err)
+	}
+	if result.Assigned != 0 || store.repositoryOf[first].Valid || store.repositoryOf[second].Valid {
+		t.Fatalf("ambiguous file was assigned: %+v", result)
+	}
+	if want := []string{"copy.jpg"}; !reflect.DeepEqual(result.UnmatchedFiles, want) {
+		t.Fatalf("unmatched files = %v, want %v", result.UnmatchedFiles, want)
+	}
+}
@@ -0,0 +1,218 @@
+package scanner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"server/internal/db/repo"
+	"server/internal/storage"
+	"server/internal/storage/repocfg"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"go.uber.org/zap"
+)
+
+// OrganizeStore is the part of repo.Queries OrganizeInbox uses.
+type OrganizeStore interface {
+	ListAssetsByRepositoryAny(ctx context.Context, repositoryID pgtype.UUID) ([]repo.Asset, error)
+	MoveAssetWithinRepository(ctx context.Context, arg repo.MoveAssetWithinRepositoryParams) (repo.Asset, error)
+}
+
+// OrganizeMove is one inbox file the organizer relocates. Paths are
+// repository-relative.
+type OrganizeMove struct {
+	AssetID string
+	From    string
+	To      string
+}
+
+// OrganizeResult reports how a storage strategy lays out a repository's inbox.
+type OrganizeResult struct {
+	Strategy string
+	// Moves are the files whose path changes, ordered by current path.
+	Moves []OrganizeMove
+	// Unchanged counts files already where the strategy puts them.
+	Unchanged int
+	// Skipped counts inbox assets left alone: their file is not on disk, as
+	// for archived originals, or CAS already has another file of that content.
+	Skipped int
+	// Applied counts the moves made; it stays 0 for a preview.
+	Applied int
+}
+
+// OrganizeInbox lays out a repository's inbox by strategy, or by the
+// repository's own strategy when it is empty. Date folders follow each
+// asset's capture time rather than its upload time. With dryRun nothing is
+// moved; otherwise each file is renamed and its asset row updated in turn.
+// Files outside the inbox belong to the user and are never touched.
+func (s *Scanner) OrganizeInbox(ctx context.Context, repositoryID, strategy string, dryRun bool) (OrganizeResult, error) {
+	if s == nil || s.queries == nil {
+		return OrganizeResult{}, fmt.Errorf("repository scanner unavailable")
+	}
+	repoID, err := parseRepositoryID(repositoryID)
+	if err != nil {
+		return OrganizeResult{}, err
+	}
+	repository, err := s.queries.GetRepository(ctx, repoID)
+	if err != nil {
+		return OrganizeResult{}, fmt.Errorf("get repository: %w", err)
+	}
+	if !isScannableRepositoryRoot(repository.Path) {
+		return OrganizeResult{}, fmt.Errorf("%w: %s", storage.ErrRepositoryOffline, repository.Name)
+	}
+	cfg, err := repocfg.LoadConfigFromFile(repository.Path)
+	if err != nil {
+		return OrganizeResult{}, fmt.Errorf("load repository config: %w", err)
+	}
+
+	result, err := organizeInbox(ctx, s.queries, repository, cfg, strategy, dryRun)
+	if err != nil {
+		return result, err
+	}
+
+	s.logger.Info("repository inbox organized",
+		zap.String("operation", "repository_scan.organize_inbox"),
+		zap.String("repository_id", repository.RepoID.String()),
+		zap.String("strategy", result.Strategy),
+		zap.Bool("dry_run", dryRun),
+		zap.Int("moves", len(result.Moves)),
+		zap.Int("applied", result.Applied),
+		zap.Int("unchanged", result.Unchanged),
+		zap.Int("skipped", result.Skipped),
+	)
+	return result, nil
+}
+
+func organizeInbox(ctx context.Context, store OrganizeStore, repository repo.Repository, cfg *repocfg.RepositoryConfig, strategy string, dryRun bool) (OrganizeResult, error) {
+	strategy = strings.ToLower(strings.TrimSpace(strategy))
+	if strategy == "" {
+		strategy = strings.ToLower(cfg.StorageStrategy)
+	}
+	switch strategy {
+	case "date", "cas", "flat":
+	default:
+		return OrganizeResult{}, fmt.Errorf("%w: invalid storage strategy '%s', must be one of: date, cas, flat", repocfg.ErrInvalidConfig, strategy)
+	}
+	result := OrganizeResult{Strategy: strategy}
+
+	assets, err := store.ListAssetsByRepositoryAny(ctx, repository.RepoID)
+	if err != nil {
+		return result, fmt.Errorf("list repository assets: %w", err)
+	}
+
+	// Every recorded path is claimed, trashed assets' included, so a move
+	// never lands on a path another row still points at.
+	claimed := make(map[string]struct{}, len(assets))
+	var inbox []repo.Asset
+	for _, asset := range assets {
+		if asset.StoragePath == nil {
+			continue
+		}
+		cleaned, ok := CleanWorkspacePath(*asset.StoragePath)
+		if !ok {
+			continue
+		}
+		claimed[cleaned] = struct{}{}
+		if !isSoftDeleted(asset) && strings.HasPrefix(filepath.ToSlash(cleaned), storage.DefaultStructure.InboxDir+"/") {
+			inbox = append(inbox, asset)
+		}
+	}
+	sort.Slice(inbox, func(i, j int) bool { return *inbox[i].StoragePath < *inbox[j].StoragePath })
+
+	for _, asset := range inbox {
+		from, _ := CleanWorkspacePath(*asset.StoragePath)
+		if _, err := os.Stat(filepath.Join(repository.Path, from)); err != nil {
+			result.Skipped++
+			continue
+		}
+		taken := func(rel string) bool {
+			if rel == from {
+				return false
+			}
+			if _, ok := claimed[rel]; ok {
+				return true
+			}
+			_, err := os.Lstat(filepath.Join(repository.Path, rel))
+			return !errors.Is(err, os.ErrNotExist)
+		}
+		to := storage.PlanInboxPath(strategy, cfg.LocalSettings.HandleDuplicateFilenames, filepath.Base(from), asset.ContentHash, organizeTime(asset), taken)
+		if to == from {
+			result.Unchanged++
+			continue
+		}
+		// CAS paths are not renamed on a clash: another file already holds
+		// this content.
+		if taken(to) {
+			result.Skipped++
+			continue
+		}
+		claimed[to] = struct{}{}
+		result.Moves = append(result.Moves, OrganizeMove{
+			AssetID: asset.AssetID.String(),
+			From:    filepath.ToSlash(from),
+			To:      filepath.ToSlash(to),
+		})
+		if dryRun {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+		if err := applyOrganizeMove(ctx, store, repository, asset, from, to); err != nil {
+			return result, err
+		}
+		result.Applied++
+	}
+	return result, nil
+}
+
+// applyOrganizeMove renames one file and points its asset at the new path,
+// putting the file back when the row cannot be updated.
+func applyOrganizeMove(ctx context.Context, store OrganizeStore, repository repo.Repository, asset repo.Asset, from, to string) error {
+	fromFull := filepath.Join(repository.Path, from)
+	toFull := filepath.Join(repository.Path, to)
+	if _, err := os.Lstat(toFull); !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("move asset %s: %s is already taken", asset.AssetID.String(), to)
+	}
+	if err := os.MkdirAll(filepath.Dir(toFull), 0755); err != nil {
+		return fmt.Errorf("create %s: %w", filepath.Dir(to), err)
+	}
+	if err := os.Rename(fromFull, toFull); err != nil {
+		return fmt.Errorf("move %s to %s: %w", from, to, err)
+	}
+
+	storagePath := filepath.ToSlash(to)
+	if _, err := store.MoveAssetWithinRepository(ctx, repo.MoveAssetWithinRepositoryParams{
+		AssetID:          asset.AssetID,
+		RepositoryID:     repository.RepoID,
+		StoragePath:      &storagePath,
+		OriginalFilename: asset.OriginalFilename,
+	}); err != nil {
+		if restoreErr := os.Rename(toFull, fromFull); restoreErr != nil {
+			return fmt.Errorf("record move of asset %s: %w (file left at %s: %v)", asset.AssetID.String(), err, to, restoreErr)
+		}
+		return fmt.Errorf("record move of asset %s: %w", asset.AssetID.String(), err)
+	}
+	return nil
+}
+
+// organizeTime is the moment date folders are picked by: the capture time in
+// the camera's own offset when known, else the upload time.
+func organizeTime(asset repo.Asset) time.Time {
+	if !asset.TakenTime.Valid {
+		return asset.UploadTime.Time.UTC()
+	}
+	taken := asset.TakenTime.Time.UTC()
+	if asset.CaptureOffsetMinutes != nil {
+		taken = taken.Add(time.Duration(*asset.CaptureOffsetMinutes) * time.Minute)
+	}
+	return taken
+}
+
+var _ OrganizeStore = (*repo.Queries)(nil)
@@ -0,0 +1,154 @@
+package scanner
+
+import (
+	"context"
+	"io/fs"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"server/internal/db/repo"
+	"server/internal/storage/repocfg"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// repositoryFiles lists every file under root, repository-relative.
+func repositoryFiles(t *testing.T, root string) []string {
+	t.Helper()
+	var files []string
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(root, path)
+		files = append(files, filepath.ToSlash(rel))
+		return err
+	})
+	if err != nil {
+		t.Fatalf("walk repository: %v", err)
+	}
+	sort.Strings(files)
+	return files
+}
+
+func TestOrganizeInboxPreviewsThenAppliesDateLayout(t *testing.T) {
+	root := t.TempDir()
+	repository := repo.Repository{RepoID: pgtype.UUID{Bytes: uuid.New(), Valid: true}, Path: root}
+	path := func(p string) *string { return &p }
+	at := func(s string) pgtype.Timestamptz {
+		parsed, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			t.Fatalf("parse time: %v", err)
+		}
+		return pgtype.Timestamptz{Time: parsed, Valid: true}
+	}
+
+	writeRepositoryFile(t, root, "inbox/2026/10/beach.jpg", "beach")
+	writeRepositoryFile(t, root, "inbox/2026/10/IMG_1.jpg", "first")
+	writeRepositoryFile(t, root, "inbox/2026/09/IMG_1.jpg", "second")
+	writeRepositoryFile(t, root, "inbox/2021/05/sorted.jpg", "sorted")
+	writeRepositoryFile(t, root, "inbox/2026/10/trashed.jpg", "trashed")
+	writeRepositoryFile(t, root, "Camera/own.jpg", "own")
+
+	store := newFakeFileRecordStore()
+	add := func(storagePath string, taken pgtype.Timestamptz) pgtype.UUID {
+		id := store.add(repository.RepoID, path(storagePath), "", 0)
+		store.assets[id].TakenTime = taken
+		store.assets[id].UploadTime = at("2026-10-01T12:00:00Z")
+		store.assets[id].OriginalFilename = filepath.Base(storagePath)
+		return id
+	}
+	// 23:30 UTC on the 31st is already June in UTC+2.
+	beach := add("inbox/2026/10/beach.jpg", at("2021-05-31T23:30:00Z"))
+	offset := int16(120)
+	store.assets[beach].CaptureOffsetMinutes = &offset
+	first := add("inbox/2026/10/IMG_1.jpg", at("2020-01-05T10:00:00Z"))
+	second := add("inbox/2026/09/IMG_1.jpg", at("2020-01-20T10:00:00Z"))
+	sorted := add("inbox/2021/05/sorted.jpg", at("2021-05-10T10:00:00Z"))
+	uploaded := add("inbox/2026/10/missing.jpg", pgtype.Timestamptz{})
+	trashed := add("inbox/2026/10/trashed.jpg", at("2019-01-01T00:00:00Z"))
+	deleted := true
+	store.assets[trashed].IsDeleted = &deleted
+	own := add("Camera/own.jpg", at("2018-01-01T00:00:00Z"))
+
+	before := repositoryFiles(t, root)
+	cfg := repocfg.DefaultRepositoryConfig()
+	cfg.LocalSettings.HandleDuplicateFilenames = "rename"
+
+	preview, err := organizeInbox(context.Background(), store, repository, cfg, "", true)
+	if err != nil {
+		t.Fatalf("preview: %v", err)
+	}
+	wantMoves := []OrganizeMove{
+		{AssetID: second.String(), From: "inbox/2026/09/IMG_1.jpg", To: "inbox/2020/01/IMG_1.jpg"},
+		{AssetID: first.String(), From: "inbox/2026/10/IMG_1.jpg", To: "inbox/2020/01/IMG_1 (1).jpg"},
+		{AssetID: beach.String(), From: "inbox/2026/10/beach.jpg", To: "inbox/2021/06/beach.jpg"},
+	}
+	if preview.Strategy != "date" || !reflect.DeepEqual(preview.Moves, wantMoves) {
+		t.Fatalf("preview = %+v, want date moves %+v", preview, wantMoves)
+	}
+	if preview.Unchanged != 1 || preview.Skipped != 1 || preview.Applied != 0 {
+		t.Fatalf("preview unchanged=%d skipped=%d applied=%d, want 1, 1 and 0", preview.Unchanged, preview.Skipped, preview.Applied)
+	}
+	if after := repositoryFiles(t, root); !reflect.DeepEqual(after, before) {
+		t.Fatalf("preview changed the repository: %v, was %v", after, before)
+	}
+	if got := store.pathOf(beach); got != "inbox/2026/10/beach.jpg" {
+		t.Fatalf("preview moved an asset to %q", got)
+	}
+
+	applied, err := organizeInbox(context.Background(), store, repository, cfg, "", false)
+	if err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+	if applied.Applied != len(wantMoves) || !reflect.DeepEqual(applied.Moves, wantMoves) {
+		t.Fatalf("apply = %+v, want the previewed moves", applied)
+	}
+	wantFiles := []string{
+		"Camera/own.jpg",
+		"inbox/2020/01/IMG_1 (1).jpg",
+		"inbox/2020/01/IMG_1.jpg",
+		"inbox/2021/05/sorted.jpg",
+		"inbox/2021/06/beach.jpg",
+		"inbox/2026/10/trashed.jpg",
+	}
+	if got := repositoryFiles(t, root); !reflect.DeepEqual(got, wantFiles) {
+		t.Fatalf("files after apply = %v, want %v", got, wantFiles)
+	}
+	for id, want := range map[pgtype.UUID]string{
+		beach:    "inbox/2021/06/beach.jpg",
+		first:    "inbox/2020/01/IMG_1 (1).jpg",
+		second:   "inbox/2020/01/IMG_1.jpg",
+		sorted:   "inbox/2021/05/sorted.jpg",
+		uploaded: "inbox/2026/10/missing.jpg",
+		trashed:  "inbox/2026/10/trashed.jpg",
+		own:      "Camera/own.jpg",
+	} {
+		if got := store.pathOf(id); got != want {
+			t.Fatalf("asset points at %q, want %q", got, want)
+		}
+	}
+	if got := store.assets[first].OriginalFilename; got != "IMG_1.jpg" {
+		t.Fatalf("original filename changed to %q", got)
+	}
+
+	again, err := organizeInbox(context.Background(), store, repository, cfg, "date", true)
+	if err != nil {
+		t.Fatalf("second preview: %v", err)
+	}
+	if len(again.Moves) != 0 || again.Unchanged != 4 {
+		t.Fatalf("organized inbox still has moves: %+v", again)
+	}
+}
+
+func TestOrganizeInboxRejectsUnknownStrategy(t *testing.T) {
+	repository := repo.Repository{RepoID: pgtype.UUID{Bytes: uuid.New(), Valid: true}, Path: t.TempDir()}
+	_, err := organizeInbox(context.Background(), newFakeFileRecordStore(), repository, repocfg.DefaultRepositoryConfig(), "by-camera", true)
+	if err == nil {
+		t.Fatal("unknown strategy was accepted")
+	}
+}
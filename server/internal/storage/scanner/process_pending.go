@@ -0,0 +1,36 @@
+package scanner
+
+import (
+	"context"
+	"fmt"
+
+	"server/internal/queue/jobs"
+
+	"github.com/riverqueue/river"
+)
+
+// EnqueueProcessPending queues processing of a repository's deferred uploads,
+// those stored while its processing mode was manual.
+func (s *Scanner) EnqueueProcessPending(ctx context.Context, repositoryID string) (EnqueueResult, error) {
+	if s == nil || s.queue == nil {
+		return EnqueueResult{}, fmt.Errorf("repository scanner queue unavailable")
+	}
+	repoID, err := parseRepositoryID(repositoryID)
+	if err != nil {
+		return EnqueueResult{}, err
+	}
+	if _, err := s.queries.GetRepository(ctx, repoID); err != nil {
+		return EnqueueResult{}, fmt.Errorf("get repository: %w", err)
+	}
+	job, err := s.queue.Insert(ctx, jobs.ProcessPendingAssetsArgs{
+		RepositoryID: repositoryID,
+	}, &river.InsertOpts{Queue: "process_pending_assets"})
+	if err != nil {
+		return EnqueueResult{}, fmt.Errorf("enqueue pending asset processing: %w", err)
+	}
+	return EnqueueResult{
+		JobID:        job.Job.ID,
+		RepositoryID: repositoryID,
+		Status:       ScanStatusQueued,
+	}, nil
+}
@@ -0,0 +1,119 @@
+package scanner
+
+import (
+	"errors"
+	"sync"
+
+	"server/internal/db/repo"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// ErrScanEventsClosed is returned when subscribing after CloseScanEvents.
+var ErrScanEventsClosed = errors.New("repository scan events closed")
+
+// scanEvents fans scan run snapshots out to subscribers, so any number of
+// status streams follow a repository without each polling the database.
+type scanEvents struct {
+	mu     sync.Mutex
+	subs   map[pgtype.UUID]map[chan repo.RepositoryScanRun]struct{}
+	closed bool
+}
+
+func newScanEvents() *scanEvents {
+	return &scanEvents{subs: make(map[pgtype.UUID]map[chan repo.RepositoryScanRun]struct{})}
+}
+
+// subscribe registers a channel for runs of repoID. The channel holds only the
+// newest snapshot: a slow reader skips intermediate states rather than
+// stalling the scan. It is closed by unsubscribe, closeRepository or close.
+func (e *scanEvents) subscribe(repoID pgtype.UUID) (<-chan repo.RepositoryScanRun, func(), error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.closed {
+		return nil, nil, ErrScanEventsClosed
+	}
+	ch := make(chan repo.RepositoryScanRun, 1)
+	if e.subs[repoID] == nil {
+		e.subs[repoID] = make(map[chan repo.RepositoryScanRun]struct{})
+	}
+	e.subs[repoID][ch] = struct{}{}
+
+	unsubscribe := func() {
+		e.mu.Lock()
+		defer e.mu.Unlock()
+		if _, ok := e.subs[repoID][ch]; !ok {
+			return
+		}
+		delete(e.subs[repoID], ch)
+		if len(e.subs[repoID]) == 0 {
+			delete(e.subs, repoID)
+		}
+		close(ch)
+	}
+	return ch, unsubscribe, nil
+}
+
+// publish hands run to every subscriber of its repository, replacing any
+// snapshot the subscriber has not read yet.
+func (e *scanEvents) publish(run repo.RepositoryScanRun) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for ch := range e.subs[run.RepositoryID] {
+		select {
+		case <-ch:
+		default:
+		}
+		ch <- run
+	}
+}
+
+// closeRepository ends every subscription to repoID.
+func (e *scanEvents) closeRepository(repoID pgtype.UUID) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for ch := range e.subs[repoID] {
+		close(ch)
+	}
+	delete(e.subs, repoID)
+}
+
+// close ends every subscription and refuses new ones.
+func (e *scanEvents) close() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for repoID, chans := range e.subs {
+		for ch := range chans {
+			close(ch)
+		}
+		delete(e.subs, repoID)
+	}
+	e.closed = true
+}
+
+// SubscribeScanRuns follows the scan runs of a repository: every run this
+// process starts, fails or completes is sent on the returned channel. Call the
+// returned function to stop; the channel is also closed when the repository
+// is removed (CloseRepositoryScanEvents) or on shutdown (CloseScanEvents).
+func (s *Scanner) SubscribeScanRuns(repositoryID string) (<-chan repo.RepositoryScanRun, func(), error) {
+	repoID, err := parseRepositoryID(repositoryID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return s.events.subscribe(repoID)
+}
+
+// CloseRepositoryScanEvents ends the subscriptions to a removed repository.
+func (s *Scanner) CloseRepositoryScanEvents(repositoryID string) {
+	repoID, err := parseRepositoryID(repositoryID)
+	if err != nil {
+		return
+	}
+	s.events.closeRepository(repoID)
+}
+
+// CloseScanEvents ends all subscriptions, letting status streams return
+// before the HTTP server drains its connections.
+func (s *Scanner) CloseScanEvents() {
+	s.events.close()
+}
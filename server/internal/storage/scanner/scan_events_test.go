@@ -0,0 +1,81 @@
+package scanner
+
+import (
+	"errors"
+	"testing"
+
+	"server/internal/db/repo"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func TestScanEventsDeliversNewestRunPerRepository(t *testing.T) {
+	events := newScanEvents()
+	repoA := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+	repoB := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+
+	first, unsubscribeFirst, err := events.subscribe(repoA)
+	if err != nil {
+		t.Fatalf("subscribe: %v", err)
+	}
+	defer unsubscribeFirst()
+	second, unsubscribeSecond, err := events.subscribe(repoA)
+	if err != nil {
+		t.Fatalf("subscribe: %v", err)
+	}
+	other, unsubscribeOther, err := events.subscribe(repoB)
+	if err != nil {
+		t.Fatalf("subscribe: %v", err)
+	}
+	defer unsubscribeOther()
+
+	events.publish(repo.RepositoryScanRun{RepositoryID: repoA, Status: ScanStatusRunning})
+	events.publish(repo.RepositoryScanRun{RepositoryID: repoA, Status: ScanStatusCompleted})
+
+	for name, ch := range map[string]<-chan repo.RepositoryScanRun{"first": first, "second": second} {
+		select {
+		case run := <-ch:
+			if run.Status != ScanStatusCompleted {
+				t.Fatalf("%s subscriber got %q, want the newest run", name, run.Status)
+			}
+		default:
+			t.Fatalf("%s subscriber got no run", name)
+		}
+	}
+	select {
+	case run := <-other:
+		t.Fatalf("subscriber of another repository got %+v", run)
+	default:
+	}
+
+	unsubscribeSecond()
+	if _, ok := <-second; ok {
+		t.Fatal("channel open after unsubscribe")
+	}
+	unsubscribeSecond()
+}
+
+func TestScanEventsCloseEndsSubscriptions(t *testing.T) {
+	events := newScanEvents()
+	repoA := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+	repoB := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+
+	removed, unsubscribeRemoved, _ := events.subscribe(repoA)
+	kept, _, _ := events.subscribe(repoB)
+
+	events.closeRepository(repoA)
+	if _, ok := <-removed; ok {
+		t.Fatal("subscription to removed repository still open")
+	}
+	unsubscribeRemoved()
+
+	events.close()
+	if _, ok := <-kept; ok {
+		t.Fatal("subscription still open after close")
+	}
+	if _, _, err := events.subscribe(repoB); !errors.Is(err, ErrScanEventsClosed) {
+		t.Fatalf("subscribe after close = %v, want ErrScanEventsClosed", err)
+	}
+	events.publish(repo.RepositoryScanRun{RepositoryID: repoB})
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/riverqueue/river"
+	"github.com/riverqueue/river/rivertype"
 	"go.uber.org/zap"
 )
 
@@ -43,10 +44,12 @@ type EnqueueResult struct {
 }
 
 type Scanner struct {
-	queries *repo.Queries
-	queue   *river.Client[pgx.Tx]
-	cfg     config.RepositoryScanConfig
-	logger  *zap.Logger
+	queries     *repo.Queries
+	queue       *river.Client[pgx.Tx]
+	cfg         config.RepositoryScanConfig
+	minFileSize int64
+	logger      *zap.Logger
+	events      *scanEvents
 }
 
 type diskEntry struct {
@@ -56,10 +59,14 @@ type diskEntry struct {
 	MTime       time.Time
 }
 
+// walkResult is what one repository walk found. deferredPaths are files on
+// disk the walk passed over, either not yet settled or below the minimum size;
+// their assets are not missing.
 type walkResult struct {
 	entries       map[string]diskEntry
 	deferredPaths map[string]struct{}
 	skipped       int64
+	undersized    int64
 	deleteSafe    bool
 	partialReason string
 }
@@ -76,15 +83,19 @@ type assetFingerprint struct {
 	size int64
 }
 
-func NewScanner(queries *repo.Queries, queue *river.Client[pgx.Tx], cfg config.RepositoryScanConfig, logger *zap.Logger) *Scanner {
+// NewScanner creates a repository scanner. Files smaller than minFileSize bytes
+// are skipped during the walk; zero disables the filter.
+func NewScanner(queries *repo.Queries, queue *river.Client[pgx.Tx], cfg config.RepositoryScanConfig, minFileSize int64, logger *zap.Logger) *Scanner {
 	if logger == nil {
 		logger = zap.NewNop()
 	}
 	return &Scanner{
-		queries: queries,
-		queue:   queue,
-		cfg:     cfg,
-		logger:  logger.With(zap.String("component", "repository_scanner")),
+		queries:     queries,
+		queue:       queue,
+		cfg:         cfg,
+		minFileSize: minFileSize,
+		logger:      logger.With(zap.String("component", "repository_scanner")),
+		events:      newScanEvents(),
 	}
 }
 
@@ -230,11 +241,12 @@ func (s *Scanner) ProcessScanRepository(ctx context.Context, args jobs.ScanRepos
 		}
 		return fmt.Errorf("create scan run: %w", err)
 	}
+	s.events.publish(scanRun)
 
 	counters, scanErr := s.scanRepository(ctx, repository, normalizeMode(args.Mode), args.Force)
 	finishedAt := pgtype.Timestamptz{Time: time.Now().UTC(), Valid: true}
 	if scanErr != nil {
-		_, failErr := s.queries.FailRepositoryScanRun(ctx, repo.FailRepositoryScanRunParams{
+		failed, failErr := s.queries.FailRepositoryScanRun(ctx, repo.FailRepositoryScanRunParams{
 			ScanID:          scanID,
 			FinishedAt:      finishedAt,
 			DiscoveredCount: counters.discovered,
@@ -246,6 +258,7 @@ func (s *Scanner) ProcessScanRepository(ctx context.Context, args jobs.ScanRepos
 		if failErr != nil {
 			return fmt.Errorf("scan failed: %w; additionally failed to mark scan failed: %v", scanErr, failErr)
 		}
+		s.events.publish(failed)
 		return scanErr
 	}
 
@@ -261,6 +274,7 @@ func (s *Scanner) ProcessScanRepository(ctx context.Context, args jobs.ScanRepos
 		return fmt.Errorf("complete scan run: %w", err)
 	}
 	scanRun = completed
+	s.events.publish(scanRun)
 
 	if _, err := s.queries.UpdateRepositoryLastSync(ctx, repo.UpdateRepositoryLastSyncParams{
 		RepoID:    repository.RepoID,
@@ -327,11 +341,19 @@ func (s *Scanner) scanRepository(ctx context.Context, repository repo.Repository
 		settle = 0
 	}
 
-	walk, err := walkRepository(repository.Path, settle)
+	walk, err := walkRepository(repository.Path, settle, s.minFileSize)
 	counters := scanCounters{skipped: walk.skipped}
 	if err != nil {
 		return counters, err
 	}
+	if walk.undersized > 0 {
+		s.logger.Info("repository scan skipped files below minimum size",
+			zap.String("operation", "repository_scan.skip_undersized"),
+			zap.String("repository_id", repository.RepoID.String()),
+			zap.Int64("count", walk.undersized),
+			zap.Int64("min_file_size_bytes", s.minFileSize),
+		)
+	}
 
 	dbAssets, err := s.queries.ListAssetsByRepositoryAny(ctx, repository.RepoID)
 	if err != nil {
@@ -399,13 +421,44 @@ func (s *Scanner) scanRepository(ctx context.Context, repository repo.Repository
 		return counters, nil
 	}
 
-	for storagePath, asset := range dbByPath {
+	// An archived original left the repository on purpose.
+	archived := make(map[pgtype.UUID]struct{})
+	if len(dbByPath) > 0 {
+		ids, err := s.queries.ListArchivedOriginalAssetIDsByRepository(ctx, repository.RepoID)
+		if err != nil {
+			return counters, fmt.Errorf("list archived originals: %w", err)
+		}
+		for _, id := range ids {
+			archived[id] = struct{}{}
+		}
+	}
+	deleted, err := queueMissingDeletes(ctx, batch, repository.RepoID, walk, dbByPath, archived)
+	counters.deleted = deleted
+	if err != nil {
+		return counters, err
+	}
+
+	if err := batch.flush(); err != nil {
+		return counters, err
+	}
+	return counters, nil
+}
+
+// queueMissingDeletes queues a delete for every asset in missing whose file
+// the walk did not find. Files the walk saw but passed over (unsettled or
+// undersized), archived originals and soft-deleted assets are left alone.
+func queueMissingDeletes(ctx context.Context, batch *discoverBatcher, repositoryID pgtype.UUID, walk walkResult, missing map[string]repo.Asset, archived map[pgtype.UUID]struct{}) (int64, error) {
+	var deleted int64
+	for storagePath, asset := range missing {
 		if ctx.Err() != nil {
-			return counters, ctx.Err()
+			return deleted, ctx.Err()
 		}
 		if _, deferred := walk.deferredPaths[storagePath]; deferred {
 			continue
 		}
+		if _, ok := archived[asset.AssetID]; ok {
+			continue
+		}
 		if isSoftDeleted(asset) {
 			continue
 		}
@@ -413,16 +466,12 @@ func (s *Scanner) scanRepository(ctx context.Context, repository repo.Repository
 			StoragePath: storagePath,
 			Filename:    filepath.Base(storagePath),
 		}
-		if err := batch.add(repository.RepoID, entry, jobs.DiscoverOperationDelete); err != nil {
-			return counters, err
+		if err := batch.add(repositoryID, entry, jobs.DiscoverOperationDelete); err != nil {
+			return deleted, err
 		}
-		counters.deleted++
-	}
-
-	if err := batch.flush(); err != nil {
-		return counters, err
+		deleted++
 	}
-	return counters, nil
+	return deleted, nil
 }
 
 func (s *Scanner) reconcileMovedEntries(
@@ -506,38 +555,49 @@ func (s *Scanner) reconcileMovedEntries(
 	return moved, nil
 }
 
+// discoverInserter is the part of the River client discoverBatcher uses.
+type discoverInserter interface {
+	InsertMany(ctx context.Context, params []river.InsertManyParams) ([]*rivertype.JobInsertResult, error)
+}
+
 // discoverBatcher accumulates discover_asset jobs and inserts them in batches of
 // cfg.BatchSize via River's InsertMany, instead of one insert per file.
 type discoverBatcher struct {
-	queue     *river.Client[pgx.Tx]
+	queue     discoverInserter
 	ctx       context.Context
 	batchSize int
 	pending   []river.InsertManyParams
 }
 
-func (s *Scanner) newDiscoverBatcher(ctx context.Context) *discoverBatcher {
-	return &discoverBatcher{queue: s.queue, ctx: ctx, batchSize: s.cfg.BatchSize}
-}
-
-func (b *discoverBatcher) add(repositoryID pgtype.UUID, entry diskEntry, operation string) error {
+// DiscoverInsertParams builds the discover_asset job for a workspace file, as
+// queued by scans and the repository monitor. size is ignored for deletes.
+func DiscoverInsertParams(repositoryID, storagePath string, size int64, operation string) river.InsertManyParams {
+	filename := filepath.Base(storagePath)
 	args := jobs.DiscoverAssetArgs{
-		RepositoryID: repositoryID.String(),
-		RelativePath: filepath.ToSlash(entry.StoragePath),
+		RepositoryID: repositoryID,
+		RelativePath: filepath.ToSlash(storagePath),
 		Operation:    operation,
-		FileName:     entry.Filename,
+		FileName:     filename,
 		DetectedAt:   time.Now().UTC(),
 	}
 	if operation == jobs.DiscoverOperationUpsert {
-		if mediaInfo, err := file.ResolveMedia(entry.Filename); err == nil {
+		if mediaInfo, err := file.ResolveMedia(filename); err == nil {
 			args.ContentType = mediaInfo.MimeType
 		}
-		args.FileSize = entry.Size
+		args.FileSize = size
 	}
-
-	b.pending = append(b.pending, river.InsertManyParams{
+	return river.InsertManyParams{
 		Args:       args,
 		InsertOpts: &river.InsertOpts{Queue: "discover_asset"},
-	})
+	}
+}
+
+func (s *Scanner) newDiscoverBatcher(ctx context.Context) *discoverBatcher {
+	return &discoverBatcher{queue: s.queue, ctx: ctx, batchSize: s.cfg.BatchSize}
+}
+
+func (b *discoverBatcher) add(repositoryID pgtype.UUID, entry diskEntry, operation string) error {
+	b.pending = append(b.pending, DiscoverInsertParams(repositoryID.String(), entry.StoragePath, entry.Size, operation))
 	if len(b.pending) >= b.batchSize {
 		return b.flush()
 	}
@@ -563,7 +623,7 @@ func isUniqueConstraintViolation(err error) bool {
 	return false
 }
 
-func walkRepository(repoPath string, settle time.Duration) (walkResult, error) {
+func walkRepository(repoPath string, settle time.Duration, minFileSize int64) (walkResult, error) {
 	result := walkResult{
 		entries:       make(map[string]diskEntry),
 		deferredPaths: make(map[string]struct{}),
@@ -619,6 +679,12 @@ func walkRepository(repoPath string, settle time.Duration) (walkResult, error) {
 			}
 			return nil
 		}
+		if minFileSize > 0 && info.Size() < minFileSize {
+			result.skipped++
+			result.undersized++
+			result.deferredPaths[cleaned] = struct{}{}
+			return nil
+		}
 		if settle > 0 && now.Sub(info.ModTime()) < settle {
 			result.skipped++
 			result.deferredPaths[cleaned] = struct{}{}
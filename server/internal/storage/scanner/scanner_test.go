@@ -1,10 +1,16 @@
 package scanner
 
 import (
+	"context"
 	"os"
 	"path/filepath"
 	"testing"
 	"time"
+
+	"server/internal/db/repo"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 func TestShouldScanPathFiltersWorkspace(t *testing.T) {
@@ -54,7 +60,7 @@ func TestWalkRepositorySkipsExcludedAndUnsettledFiles(t *testing.T) {
 	writeFile("album/recent.jpg", time.Now())
 	writeFile("album/readme.txt", old)
 
-	result, err := walkRepository(root, 5*time.Second)
+	result, err := walkRepository(root, 5*time.Second, 0)
 	if err != nil {
 		t.Fatalf("walk repository: %v", err)
 	}
@@ -87,7 +93,7 @@ func TestWalkRepositoryCanIncludeUnsettledFiles(t *testing.T) {
 		}
 	}
 
-	result, err := walkRepository(root, 0)
+	result, err := walkRepository(root, 0, 0)
 	if err != nil {
 		t.Fatalf("walk repository: %v", err)
 	}
@@ -98,3 +104,63 @@ func TestWalkRepositoryCanIncludeUnsettledFiles(t *testing.T) {
 		t.Fatalf("expected two scanned entries, got %#v", result.entries)
 	}
 }
+
+func TestWalkRepositorySkipsFilesBelowMinimumSize(t *testing.T) {
+	root := t.TempDir()
+	writeFile := func(rel string, size int) {
+		path := filepath.Join(root, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		if err := os.WriteFile(path, make([]byte, size), 0644); err != nil {
+			t.Fatalf("write file: %v", err)
+		}
+	}
+	writeFile("album/._photo.jpg", 200)
+	writeFile("album/photo.jpg", 20*1024)
+
+	result, err := walkRepository(root, 0, 10*1024)
+	if err != nil {
+		t.Fatalf("walk repository: %v", err)
+	}
+	if _, ok := result.entries["album/._photo.jpg"]; ok {
+		t.Fatalf("expected 200-byte file to be skipped, got %#v", result.entries)
+	}
+	if _, ok := result.entries["album/photo.jpg"]; !ok {
+		t.Fatalf("expected album/photo.jpg to be scanned, got %#v", result.entries)
+	}
+	if result.undersized != 1 || result.skipped != 1 {
+		t.Fatalf("expected one undersized skip, got undersized=%d skipped=%d", result.undersized, result.skipped)
+	}
+}
+
+func TestQueueMissingDeletesKeepsUndersizedAssets(t *testing.T) {
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "small.jpg"), make([]byte, 200), 0644); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+	walk, err := walkRepository(root, 0, 10*1024)
+	if err != nil {
+		t.Fatalf("walk repository: %v", err)
+	}
+
+	// small.jpg was indexed before the threshold was set; gone.jpg left disk.
+	missing := map[string]repo.Asset{
+		"small.jpg": {AssetID: pgtype.UUID{Bytes: uuid.New(), Valid: true}},
+		"gone.jpg":  {AssetID: pgtype.UUID{Bytes: uuid.New(), Valid: true}},
+	}
+	inserter := &recordingInserter{}
+	batch := &discoverBatcher{queue: inserter, ctx: context.Background(), batchSize: 20}
+	repositoryID := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+
+	deleted, err := queueMissingDeletes(context.Background(), batch, repositoryID, walk, missing, nil)
+	if err != nil {
+		t.Fatalf("queue deletes: %v", err)
+	}
+	if err := batch.flush(); err != nil {
+		t.Fatalf("flush: %v", err)
+	}
+	if deleted != 1 || len(inserter.paths) != 1 || inserter.paths[0] != "gone.jpg" {
+		t.Fatalf("expected only gone.jpg to be deleted, got deleted=%d paths=%v", deleted, inserter.paths)
+	}
+}
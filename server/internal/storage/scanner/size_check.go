@@ -0,0 +1,164 @@
+package scanner
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"server/internal/db/repo"
+	"server/internal/queue/jobs"
+	"server/internal/storage"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/riverqueue/river"
+	"go.uber.org/zap"
+)
+
+// SizeCheckStore is the part of repo.Queries the size check uses.
+type SizeCheckStore interface {
+	ListRepositoryAssetSizes(ctx context.Context, repositoryID pgtype.UUID) ([]repo.ListRepositoryAssetSizesRow, error)
+	UpsertAssetSizeMismatch(ctx context.Context, arg repo.UpsertAssetSizeMismatchParams) error
+	DeleteStaleAssetSizeMismatches(ctx context.Context, arg repo.DeleteStaleAssetSizeMismatchesParams) (int64, error)
+}
+
+// SizeCheckResult summarises one size check of a repository.
+type SizeCheckResult struct {
+	// Checked counts assets whose original was found and compared.
+	Checked int64
+	// Mismatched counts assets whose original no longer has the recorded size.
+	Mismatched int64
+	// Missing counts assets whose original could not be found or read. A scan
+	// or a file record rebuild deals with those.
+	Missing int64
+}
+
+// EnqueueSizeCheck queues a size quick-check of a repository.
+func (s *Scanner) EnqueueSizeCheck(ctx context.Context, repositoryID string) (EnqueueResult, error) {
+	if s == nil || s.queue == nil {
+		return EnqueueResult{}, fmt.Errorf("repository scanner queue unavailable")
+	}
+	repoID, err := parseRepositoryID(repositoryID)
+	if err != nil {
+		return EnqueueResult{}, err
+	}
+	if _, err := s.queries.GetRepository(ctx, repoID); err != nil {
+		return EnqueueResult{}, fmt.Errorf("get repository: %w", err)
+	}
+	job, err := s.queue.Insert(ctx, jobs.CheckRepositorySizesArgs{
+		RepositoryID: repositoryID,
+	}, &river.InsertOpts{Queue: "check_repository_sizes"})
+	if err != nil {
+		return EnqueueResult{}, fmt.Errorf("enqueue repository size check: %w", err)
+	}
+	return EnqueueResult{
+		JobID:        job.Job.ID,
+		RepositoryID: repositoryID,
+		Status:       ScanStatusQueued,
+	}, nil
+}
+
+// ProcessCheckRepositorySizes compares the recorded file size of every asset
+// of a repository with its original on disk and records the assets that
+// differ, a sign of truncation or outside modification. Nothing is hashed,
+// so it is far cheaper than verifying content hashes.
+func (s *Scanner) ProcessCheckRepositorySizes(ctx context.Context, args jobs.CheckRepositorySizesArgs) error {
+	if s == nil || s.queries == nil {
+		return fmt.Errorf("repository scanner unavailable")
+	}
+	repoID, err := parseRepositoryID(args.RepositoryID)
+	if err != nil {
+		return err
+	}
+	repository, err := s.queries.GetRepository(ctx, repoID)
+	if err != nil {
+		return fmt.Errorf("get repository: %w", err)
+	}
+	// Checking an unplugged drive would report every asset as missing.
+	if !isScannableRepositoryRoot(repository.Path) {
+		return fmt.Errorf("%w: %s", storage.ErrRepositoryOffline, repository.Name)
+	}
+
+	result, err := checkFileSizes(ctx, s.queries, repository, time.Now().UTC())
+	if err != nil {
+		return err
+	}
+	s.logger.Info("repository size check completed",
+		zap.String("operation", "repository_scan.check_sizes"),
+		zap.String("repository_id", args.RepositoryID),
+		zap.Int64("checked", result.Checked),
+		zap.Int64("mismatched", result.Mismatched),
+		zap.Int64("missing", result.Missing),
+	)
+	return nil
+}
+
+// ListSizeMismatches returns the assets the last size check of a repository
+// found with a different size on disk.
+func (s *Scanner) ListSizeMismatches(ctx context.Context, repositoryID string) ([]repo.ListRepositorySizeMismatchesRow, error) {
+	if s == nil || s.queries == nil {
+		return nil, fmt.Errorf("repository scanner unavailable")
+	}
+	repoID, err := parseRepositoryID(repositoryID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.queries.GetRepository(ctx, repoID); err != nil {
+		return nil, fmt.Errorf("get repository: %w", err)
+	}
+	return s.queries.ListRepositorySizeMismatches(ctx, repoID)
+}
+
+func checkFileSizes(ctx context.Context, store SizeCheckStore, repository repo.Repository, checkedAt time.Time) (SizeCheckResult, error) {
+	var result SizeCheckResult
+	// Postgres keeps microseconds; rows written below must not compare as
+	// older than checkedAt once stored.
+	checkedAtTS := pgtype.Timestamptz{Time: checkedAt.Truncate(time.Microsecond), Valid: true}
+
+	assets, err := store.ListRepositoryAssetSizes(ctx, repository.RepoID)
+	if err != nil {
+		return result, fmt.Errorf("list repository asset sizes: %w", err)
+	}
+	for _, asset := range assets {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+		if asset.StoragePath == nil {
+			continue
+		}
+		cleaned, ok := CleanWorkspacePath(*asset.StoragePath)
+		if !ok {
+			continue
+		}
+		info, err := os.Stat(filepath.Join(repository.Path, filepath.FromSlash(cleaned)))
+		if err != nil || !info.Mode().IsRegular() {
+			result.Missing++
+			continue
+		}
+		result.Checked++
+		if info.Size() == asset.FileSize {
+			continue
+		}
+		if err := store.UpsertAssetSizeMismatch(ctx, repo.UpsertAssetSizeMismatchParams{
+			AssetID:      asset.AssetID,
+			RepositoryID: repository.RepoID,
+			RecordedSize: asset.FileSize,
+			ActualSize:   info.Size(),
+			CheckedAt:    checkedAtTS,
+		}); err != nil {
+			return result, fmt.Errorf("record size mismatch of asset %s: %w", asset.AssetID.String(), err)
+		}
+		result.Mismatched++
+	}
+
+	if _, err := store.DeleteStaleAssetSizeMismatches(ctx, repo.DeleteStaleAssetSizeMismatchesParams{
+		RepositoryID:  repository.RepoID,
+		CheckedBefore: checkedAtTS,
+	}); err != nil {
+		return result, fmt.Errorf("delete stale size mismatches: %w", err)
+	}
+	return result, nil
+}
+
+var _ SizeCheckStore = (*repo.Queries)(nil)
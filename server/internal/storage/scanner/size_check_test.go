@@ -0,0 +1,97 @@
+package scanner
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"server/internal/db/repo"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// fakeSizeCheckStore keeps recorded sizes and mismatch rows in memory.
+type fakeSizeCheckStore struct {
+	assets     []repo.ListRepositoryAssetSizesRow
+	mismatches map[pgtype.UUID]repo.UpsertAssetSizeMismatchParams
+}
+
+func (s *fakeSizeCheckStore) add(storagePath string, size int64) pgtype.UUID {
+	id := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+	s.assets = append(s.assets, repo.ListRepositoryAssetSizesRow{AssetID: id, StoragePath: &storagePath, FileSize: size})
+	return id
+}
+
+func (s *fakeSizeCheckStore) ListRepositoryAssetSizes(context.Context, pgtype.UUID) ([]repo.ListRepositoryAssetSizesRow, error) {
+	return s.assets, nil
+}
+
+func (s *fakeSizeCheckStore) UpsertAssetSizeMismatch(_ context.Context, arg repo.UpsertAssetSizeMismatchParams) error {
+	s.mismatches[arg.AssetID] = arg
+	return nil
+}
+
+func (s *fakeSizeCheckStore) DeleteStaleAssetSizeMismatches(_ context.Context, arg repo.DeleteStaleAssetSizeMismatchesParams) (int64, error) {
+	var deleted int64
+	for id, row := range s.mismatches {
+		if row.RepositoryID == arg.RepositoryID && row.CheckedAt.Time.Before(arg.CheckedBefore.Time) {
+			delete(s.mismatches, id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+func TestCheckFileSizesFlagsTruncatedFile(t *testing.T) {
+	root := t.TempDir()
+	repository := repo.Repository{RepoID: pgtype.UUID{Bytes: uuid.New(), Valid: true}, Path: root}
+
+	_, intactSize := writeRepositoryFile(t, root, "2024/intact.jpg", "intact photo bytes")
+	_, truncatedSize := writeRepositoryFile(t, root, "2024/truncated.jpg", "a photo that will lose its tail")
+
+	store := &fakeSizeCheckStore{mismatches: map[pgtype.UUID]repo.UpsertAssetSizeMismatchParams{}}
+	store.add("2024/intact.jpg", intactSize)
+	truncated := store.add("2024/truncated.jpg", truncatedSize)
+	store.add("2024/gone.jpg", 42)
+
+	first := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	result, err := checkFileSizes(context.Background(), store, repository, first)
+	if err != nil {
+		t.Fatalf("check file sizes: %v", err)
+	}
+	if result.Checked != 2 || result.Mismatched != 0 || result.Missing != 1 {
+		t.Fatalf("before truncation: %+v, want 2 checked, 0 mismatched and 1 missing", result)
+	}
+
+	// Truncated after import, outside of Lumilio.
+	if err := os.Truncate(filepath.Join(root, "2024", "truncated.jpg"), 7); err != nil {
+		t.Fatalf("truncate: %v", err)
+	}
+	second := first.Add(time.Hour)
+	result, err = checkFileSizes(context.Background(), store, repository, second)
+	if err != nil {
+		t.Fatalf("check file sizes: %v", err)
+	}
+	if result.Checked != 2 || result.Mismatched != 1 {
+		t.Fatalf("after truncation: %+v, want 2 checked and 1 mismatched", result)
+	}
+	row, ok := store.mismatches[truncated]
+	if !ok || len(store.mismatches) != 1 {
+		t.Fatalf("mismatches = %+v, want only the truncated asset", store.mismatches)
+	}
+	if row.RecordedSize != truncatedSize || row.ActualSize != 7 || !row.CheckedAt.Time.Equal(second) {
+		t.Fatalf("mismatch = %+v, want recorded %d and actual 7 at %s", row, truncatedSize, second)
+	}
+
+	// Once the file is restored, the next check clears the mismatch.
+	writeRepositoryFile(t, root, "2024/truncated.jpg", "a photo that will lose its tail")
+	if _, err := checkFileSizes(context.Background(), store, repository, second.Add(time.Hour)); err != nil {
+		t.Fatalf("check file sizes: %v", err)
+	}
+	if len(store.mismatches) != 0 {
+		t.Fatalf("mismatches after restore = %+v, want none", store.mismatches)
+	}
+}
@@ -1,6 +1,7 @@
 package storage
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -27,9 +28,16 @@ type StagingManager interface {
 
 	// CommitStagingFileToInbox commits a staged file to the inbox location
 	// derived from the repository's storage strategy, returning the inbox-
-	// relative path it was written to.
+	// relative path it was written to. Under the flat strategy it also appends
+	// to the flat manifest; if only that fails, the path is returned with an
+	// error wrapping ErrFlatManifest.
 	CommitStagingFileToInbox(stagingFile *StagingFile, hash string) (string, error)
 
+	// PlanInboxCommit resolves where CommitStagingFileToInbox would put a
+	// staged file right now and what it would do about a file already using
+	// that name, without touching the staged file.
+	PlanInboxCommit(stagingFile *StagingFile, hash string) (InboxCommit, error)
+
 	// MoveStagingToFailed moves a staged file into .lumilio/staging/failed.
 	MoveStagingToFailed(stagingFile *StagingFile) error
 
@@ -37,6 +45,34 @@ type StagingManager interface {
 	CleanupStaging(repoPath string, maxAge time.Duration) error
 }
 
+// DuplicateAction is what committing a staged file does about another file
+// already using its name in the inbox, as the repository's
+// handle_duplicate_filenames setting decides.
+type DuplicateAction string
+
+const (
+	// DuplicateActionNone means the name was free.
+	DuplicateActionNone DuplicateAction = ""
+	// DuplicateActionRenamed files the upload under a new name (rename, uuid
+	// and date_prefix).
+	DuplicateActionRenamed DuplicateAction = "renamed"
+	// DuplicateActionOverwritten replaces the existing file.
+	DuplicateActionOverwritten DuplicateAction = "overwritten"
+	// DuplicateActionSkipped drops the upload and keeps the existing file.
+	DuplicateActionSkipped DuplicateAction = "skipped"
+)
+
+// ErrDuplicateSkipped is returned by CommitStagingFileToInbox when the name is
+// taken and the repository skips duplicate file names.
+var ErrDuplicateSkipped = errors.New("file name already in use and duplicate handling is skip")
+
+// InboxCommit is the outcome PlanInboxCommit predicts for a staged file.
+type InboxCommit struct {
+	// Path is the inbox-relative destination; empty when Action is skipped.
+	Path   string
+	Action DuplicateAction
+}
+
 // DefaultStagingManager implements the StagingManager interface.
 type DefaultStagingManager struct{}
 
@@ -121,18 +157,54 @@ func (sm *DefaultStagingManager) CommitStagingFileToInbox(stagingFile *StagingFi
 	}
 
 	// Resolve inbox path based on repository configuration
-	inboxPath, err := sm.resolveInboxRelativePath(stagingFile.RepoPath, cfg, stagingFile.Filename, hash)
+	inboxPath, action, err := sm.resolveInboxRelativePath(stagingFile.RepoPath, cfg, stagingFile.Filename, hash, stagingFile.collisionTime())
 	if err != nil {
 		return "", fmt.Errorf("failed to resolve inbox path: %w", err)
 	}
+	if action == DuplicateActionSkipped {
+		return "", fmt.Errorf("%w: %s", ErrDuplicateSkipped, stagingFile.Filename)
+	}
+	flat := strings.EqualFold(cfg.StorageStrategy, "flat")
 
 	// Commit to the resolved inbox path
 	if err := sm.CommitStagingFile(stagingFile, inboxPath); err != nil {
 		return "", err
 	}
+
+	// Flat inboxes keep original names, so record which upload got which name
+	// once the duplicate handling policy has resolved a collision.
+	if flat {
+		if err := appendFlatManifestEntry(stagingFile.RepoPath, FlatManifestEntry{
+			AssetID:          stagingFile.AssetID,
+			OriginalFilename: stagingFile.Filename,
+			StoragePath:      filepath.ToSlash(inboxPath),
+			Collision:        action != DuplicateActionNone,
+			CommittedAt:      time.Now().UTC(),
+		}); err != nil {
+			return inboxPath, err
+		}
+	}
 	return inboxPath, nil
 }
 
+// PlanInboxCommit resolves the inbox destination of a staged file and the
+// duplicate file name action without moving it. Another commit may still take
+// the name before this file is committed.
+func (sm *DefaultStagingManager) PlanInboxCommit(stagingFile *StagingFile, hash string) (InboxCommit, error) {
+	if stagingFile == nil {
+		return InboxCommit{}, fmt.Errorf("staging file is nil")
+	}
+	cfg, err := repocfg.LoadConfigFromFile(stagingFile.RepoPath)
+	if err != nil {
+		return InboxCommit{}, fmt.Errorf("failed to load repository config: %w", err)
+	}
+	inboxPath, action, err := sm.resolveInboxRelativePath(stagingFile.RepoPath, cfg, stagingFile.Filename, hash, stagingFile.collisionTime())
+	if err != nil {
+		return InboxCommit{}, fmt.Errorf("failed to resolve inbox path: %w", err)
+	}
+	return InboxCommit{Path: inboxPath, Action: action}, nil
+}
+
 // MoveStagingToFailed moves a staging file to the failed directory
 func (sm *DefaultStagingManager) MoveStagingToFailed(stagingFile *StagingFile) error {
 	if stagingFile == nil {
@@ -152,6 +224,18 @@ func (sm *DefaultStagingManager) MoveStagingToFailed(stagingFile *StagingFile) e
 	return nil
 }
 
+// collisionTime is the time the date_prefix duplicate strategy names a
+// colliding file after: the taken time when known, else when it was staged.
+func (sf *StagingFile) collisionTime() time.Time {
+	if sf.TakenTime != nil && !sf.TakenTime.IsZero() {
+		return *sf.TakenTime
+	}
+	if !sf.CreatedAt.IsZero() {
+		return sf.CreatedAt
+	}
+	return time.Now()
+}
+
 // resolveFailedPath resolves a timestamped target path under the failed area.
 func (sm *DefaultStagingManager) resolveFailedPath(repoPath string, originalFilename string) (string, error) {
 	failedDir := filepath.Join(repoPath, DefaultStructure.FailedDir)
@@ -178,7 +262,8 @@ func (sm *DefaultStagingManager) ResolveInboxPath(repoPath string, originalFilen
 	if err != nil {
 		return "", fmt.Errorf("failed to load repository config: %w", err)
 	}
-	return sm.resolveInboxRelativePath(repoPath, cfg, originalFilename, hash)
+	path, _, err := sm.resolveInboxRelativePath(repoPath, cfg, originalFilename, hash, time.Now())
+	return path, err
 }
 
 // CleanupStaging removes staged files (incoming and failed) older than maxAge.
@@ -216,12 +301,14 @@ func (sm *DefaultStagingManager) CleanupStaging(repoPath string, maxAge time.Dur
 	return nil
 }
 
-// resolveInboxRelativePath decides the inbox-relative final path based on repository storage strategy.
+// resolveInboxRelativePath decides the inbox-relative final path based on
+// repository storage strategy, and what that does about a name in use; the
+// path is empty when the file is to be skipped.
 // Strategies:
 //   - date: inbox/YYYY/MM/<filename-with-duplicate-handling>
-//   - flat: inbox/<filename-with-duplicate-handling>
+//   - flat: inbox/<filename-with-duplicate-handling>, recorded in the flat manifest
 //   - cas:  inbox/aa/bb/cc/<hash><ext> (falls back to date if hash is empty)
-func (sm *DefaultStagingManager) resolveInboxRelativePath(repoPath string, cfg *repocfg.RepositoryConfig, originalFilename string, hash string) (string, error) {
+func (sm *DefaultStagingManager) resolveInboxRelativePath(repoPath string, cfg *repocfg.RepositoryConfig, originalFilename string, hash string, takenAt time.Time) (string, DuplicateAction, error) {
 	inboxRoot := filepath.Join(repoPath, DefaultStructure.InboxDir)
 	strategy := strings.ToLower(cfg.StorageStrategy)
 	duplicateMode := cfg.LocalSettings.HandleDuplicateFilenames
@@ -229,8 +316,11 @@ func (sm *DefaultStagingManager) resolveInboxRelativePath(repoPath string, cfg *
 	switch strategy {
 	case "flat":
 		// inbox/<filename>
-		filename := sm.uniqueInboxFilename(inboxRoot, originalFilename, duplicateMode)
-		return filepath.Join(DefaultStructure.InboxDir, filename), nil
+		filename, action := sm.uniqueInboxFilename(inboxRoot, originalFilename, duplicateMode, takenAt)
+		if action == DuplicateActionSkipped {
+			return "", action, nil
+		}
+		return filepath.Join(DefaultStructure.InboxDir, filename), action, nil
 
 	case "cas":
 		// inbox/aa/bb/cc/<hash><ext>
@@ -239,44 +329,114 @@ func (sm *DefaultStagingManager) resolveInboxRelativePath(repoPath string, cfg *
 			return sm.resolveInboxRelativePath(repoPath, &repocfg.RepositoryConfig{
 				StorageStrategy: "date",
 				LocalSettings:   cfg.LocalSettings,
-			}, originalFilename, hash)
+			}, originalFilename, hash, takenAt)
 		}
 
-		ext := filepath.Ext(originalFilename)
-		seg1 := hash[0:2]
-		seg2 := hash[2:4]
-		seg3 := hash[4:6]
-		dirRel := filepath.Join(DefaultStructure.InboxDir, seg1, seg2, seg3)
+		dirRel := inboxCASDir(hash)
 		fullDir := filepath.Join(repoPath, dirRel)
 		if err := os.MkdirAll(fullDir, 0755); err != nil {
-			return "", fmt.Errorf("failed to create CAS inbox directories: %w", err)
+			return "", DuplicateActionNone, fmt.Errorf("failed to create CAS inbox directories: %w", err)
 		}
-		filename := hash + ext
-		return filepath.Join(dirRel, filename), nil
+		return filepath.Join(dirRel, hash+filepath.Ext(originalFilename)), DuplicateActionNone, nil
 
 	case "date":
 		fallthrough
 	default:
 		// inbox/YYYY/MM/<filename>
-		now := time.Now()
-		dirRel := filepath.Join(DefaultStructure.InboxDir, fmt.Sprintf("%d", now.Year()), fmt.Sprintf("%02d", now.Month()))
+		dirRel := inboxDateDir(time.Now())
 		fullDir := filepath.Join(repoPath, dirRel)
 		if err := os.MkdirAll(fullDir, 0755); err != nil {
-			return "", fmt.Errorf("failed to create date-based inbox directories: %w", err)
+			return "", DuplicateActionNone, fmt.Errorf("failed to create date-based inbox directories: %w", err)
 		}
 		// Apply duplicate handling in the target directory
-		filename := sm.uniqueInboxFilename(fullDir, originalFilename, duplicateMode)
-		return filepath.Join(dirRel, filename), nil
+		filename, action := sm.uniqueInboxFilename(fullDir, originalFilename, duplicateMode, takenAt)
+		if action == DuplicateActionSkipped {
+			return "", action, nil
+		}
+		return filepath.Join(dirRel, filename), action, nil
 	}
 }
 
+// inboxDateDir is the date strategy's inbox/YYYY/MM directory for t.
+func inboxDateDir(t time.Time) string {
+	return filepath.Join(DefaultStructure.InboxDir, fmt.Sprintf("%d", t.Year()), fmt.Sprintf("%02d", t.Month()))
+}
+
+// inboxCASDir is the CAS strategy's inbox/aa/bb/cc directory for a hash of at
+// least six characters.
+func inboxCASDir(hash string) string {
+	return filepath.Join(DefaultStructure.InboxDir, hash[0:2], hash[2:4], hash[4:6])
+}
+
+// RehashedCASPath returns where a content-addressed inbox file belongs once its
+// content hash changed from oldHash to newHash. ok is false when relPath is not
+// the CAS location of oldHash, e.g. for files filed by date or name.
+func RehashedCASPath(relPath, oldHash, newHash string) (string, bool) {
+	if len(oldHash) < 6 || len(newHash) < 6 {
+		return "", false
+	}
+	ext := filepath.Ext(relPath)
+	if filepath.Clean(relPath) != filepath.Join(inboxCASDir(oldHash), oldHash+ext) {
+		return "", false
+	}
+	return filepath.Join(inboxCASDir(newHash), newHash+ext), true
+}
+
+// PlanInboxPath returns the inbox-relative path the storage strategy gives a
+// file named filename, without touching the disk. Unlike uploads, which file
+// by the time they arrive, the date strategy uses at, normally the capture
+// time. taken reports whether a repository-relative path is already in use;
+// a clash is resolved by duplicateMode, except that overwrite and skip rename
+// instead, since nothing may be replaced or left behind. CAS paths are unique
+// by content and returned as they are.
+func PlanInboxPath(strategy, duplicateMode, filename, hash string, at time.Time, taken func(string) bool) string {
+	var dirRel string
+	switch strings.ToLower(strategy) {
+	case "flat":
+		dirRel = DefaultStructure.InboxDir
+	case "cas":
+		if len(hash) >= 6 {
+			return filepath.Join(inboxCASDir(hash), hash+filepath.Ext(filename))
+		}
+		dirRel = inboxDateDir(at)
+	default:
+		dirRel = inboxDateDir(at)
+	}
+	if strings.EqualFold(duplicateMode, "overwrite") || strings.EqualFold(duplicateMode, "skip") {
+		duplicateMode = "rename"
+	}
+	name := uniqueFilename(func(name string) bool {
+		return taken(filepath.Join(dirRel, name))
+	}, filename, duplicateMode, at)
+	return filepath.Join(dirRel, name)
+}
+
 // uniqueInboxFilename applies duplicate handling within a specific directory.
-// duplicateMode can be: "overwrite", "uuid", "rename" (default)
-func (sm *DefaultStagingManager) uniqueInboxFilename(dirFullPath string, filename string, duplicateMode string) string {
-	originalPath := filepath.Join(dirFullPath, filename)
+// duplicateMode can be: "overwrite", "skip", "uuid", "date_prefix", "rename"
+// (default). takenAt is only used by date_prefix.
+func (sm *DefaultStagingManager) uniqueInboxFilename(dirFullPath string, filename string, duplicateMode string, takenAt time.Time) (string, DuplicateAction) {
+	exists := func(name string) bool {
+		_, err := os.Stat(filepath.Join(dirFullPath, name))
+		return !os.IsNotExist(err)
+	}
+	if !exists(filename) {
+		return filename, DuplicateActionNone
+	}
+	switch strings.ToLower(duplicateMode) {
+	case "skip":
+		return "", DuplicateActionSkipped
+	case "overwrite":
+		return filename, DuplicateActionOverwritten
+	default:
+		return uniqueFilename(exists, filename, duplicateMode, takenAt), DuplicateActionRenamed
+	}
+}
 
+// uniqueFilename resolves a clash on filename by duplicateMode, where exists
+// reports whether a name in the target directory is in use.
+func uniqueFilename(exists func(string) bool, filename string, duplicateMode string, takenAt time.Time) string {
 	// If file doesn't exist, use the provided name
-	if _, err := os.Stat(originalPath); os.IsNotExist(err) {
+	if !exists(filename) {
 		return filename
 	}
 
@@ -288,6 +448,19 @@ func (sm *DefaultStagingManager) uniqueInboxFilename(dirFullPath string, filenam
 		ext := filepath.Ext(filename)
 		base := strings.TrimSuffix(filename, ext)
 		return fmt.Sprintf("%s_%s%s", base, uuid.New().String()[:8], ext)
+	case "date_prefix":
+		// 20240615_IMG_0001.jpg; two shots of the same day fall back to the
+		// full time, and only then to a counter.
+		candidates := []string{
+			takenAt.Format("20060102") + "_" + filename,
+			takenAt.Format("20060102_150405") + "_" + filename,
+		}
+		for _, candidate := range candidates {
+			if !exists(candidate) {
+				return candidate
+			}
+		}
+		return uniqueFilename(exists, candidates[1], "rename", takenAt)
 	case "rename":
 		fallthrough
 	default:
@@ -296,7 +469,7 @@ func (sm *DefaultStagingManager) uniqueInboxFilename(dirFullPath string, filenam
 		base := strings.TrimSuffix(filename, ext)
 		for i := 1; i <= 999; i++ {
 			newFilename := fmt.Sprintf("%s (%d)%s", base, i, ext)
-			if _, err := os.Stat(filepath.Join(dirFullPath, newFilename)); os.IsNotExist(err) {
+			if !exists(newFilename) {
 				return newFilename
 			}
 		}
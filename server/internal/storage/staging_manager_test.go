@@ -1,6 +1,8 @@
 package storage
 
 import (
+	"bytes"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
@@ -8,6 +10,7 @@ import (
 	"time"
 
 	"server/internal/storage/repocfg"
+	"server/internal/utils/hash"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -263,6 +266,35 @@ func TestStagingManager_InboxIntegration(t *testing.T) {
 	})
 }
 
+func TestStagingManager_StreamedHashCommitsToCASPath(t *testing.T) {
+	sm := NewStagingManager()
+	testDir := t.TempDir()
+	require.NoError(t, NewDirectoryManager().CreateStructure(testDir))
+	require.NoError(t, repocfg.NewRepositoryConfig("Streamed CAS",
+		repocfg.WithStorageStrategy("cas")).SaveConfigToFile(testDir))
+
+	stagingFile, err := sm.CreateStagingFile(testDir, "streamed.jpg")
+	require.NoError(t, err)
+	dst, err := os.Create(stagingFile.Path)
+	require.NoError(t, err)
+	hasher := hash.NewLayeredWriter()
+	content := []byte("bytes streamed straight into staging")
+	_, err = io.Copy(io.MultiWriter(dst, hasher), bytes.NewReader(content))
+	require.NoError(t, err)
+	require.NoError(t, dst.Close())
+	streamed := hasher.Result()
+
+	storagePath, err := sm.CommitStagingFileToInbox(stagingFile, streamed.ContentHash)
+	require.NoError(t, err)
+
+	h := streamed.ContentHash
+	assert.Equal(t, filepath.Join("inbox", h[0:2], h[2:4], h[4:6], h+".jpg"), storagePath)
+	committed, err := hash.CalculateLayeredBLAKE3(filepath.Join(testDir, storagePath))
+	require.NoError(t, err)
+	assert.Equal(t, streamed.ContentHash, committed.ContentHash, "commit path must name the committed bytes")
+	assert.Equal(t, int64(len(content)), committed.FileSize)
+}
+
 func TestStagingManager_DuplicateHandling(t *testing.T) {
 	sm := NewStagingManager()
 	testDir := t.TempDir()
@@ -349,6 +381,110 @@ func TestStagingManager_DuplicateHandling(t *testing.T) {
 		}
 		assert.Equal(t, 2, uuidFiles, "Should have two UUID files")
 	})
+
+	t.Run("duplicate handling with date prefix strategy", func(t *testing.T) {
+		config := repocfg.NewRepositoryConfig("Test Date Prefix",
+			repocfg.WithStorageStrategy("flat"),
+			repocfg.WithLocalSettings("date_prefix"))
+		err := config.SaveConfigToFile(testDir)
+		require.NoError(t, err)
+
+		commit := func(content string, taken time.Time) string {
+			staging, err := sm.CreateStagingFile(testDir, "IMG_0001.jpg")
+			require.NoError(t, err)
+			require.NoError(t, os.WriteFile(staging.Path, []byte(content), 0644))
+			staging.TakenTime = &taken
+			path, err := sm.CommitStagingFileToInbox(staging, "")
+			require.NoError(t, err)
+			return path
+		}
+
+		first := commit("camera a", time.Date(2024, 6, 15, 10, 0, 0, 0, time.UTC))
+		second := commit("camera b", time.Date(2024, 6, 15, 10, 30, 0, 0, time.UTC))
+		third := commit("camera c", time.Date(2023, 1, 2, 8, 0, 0, 0, time.UTC))
+		sameDay := commit("camera d", time.Date(2024, 6, 15, 18, 45, 12, 0, time.UTC))
+
+		assert.Equal(t, filepath.Join("inbox", "IMG_0001.jpg"), first)
+		assert.Equal(t, filepath.Join("inbox", "20240615_IMG_0001.jpg"), second)
+		assert.Equal(t, filepath.Join("inbox", "20230102_IMG_0001.jpg"), third)
+		assert.Equal(t, filepath.Join("inbox", "20240615_184512_IMG_0001.jpg"), sameDay)
+
+		for path, content := range map[string]string{first: "camera a", second: "camera b", third: "camera c", sameDay: "camera d"} {
+			data, err := os.ReadFile(filepath.Join(testDir, path))
+			require.NoError(t, err)
+			assert.Equal(t, content, string(data))
+		}
+	})
+
+	stage := func(name, content string) *StagingFile {
+		staging, err := sm.CreateStagingFile(testDir, name)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(staging.Path, []byte(content), 0644))
+		return staging
+	}
+
+	t.Run("duplicate handling with skip strategy", func(t *testing.T) {
+		config := repocfg.NewRepositoryConfig("Test Skip",
+			repocfg.WithStorageStrategy("flat"),
+			repocfg.WithLocalSettings("skip"))
+		require.NoError(t, config.SaveConfigToFile(testDir))
+
+		first := stage("skip-test.jpg", "kept")
+		plan, err := sm.PlanInboxCommit(first, "")
+		require.NoError(t, err)
+		assert.Equal(t, InboxCommit{Path: filepath.Join("inbox", "skip-test.jpg")}, plan)
+		_, err = sm.CommitStagingFileToInbox(first, "")
+		require.NoError(t, err)
+
+		second := stage("skip-test.jpg", "dropped")
+		plan, err = sm.PlanInboxCommit(second, "")
+		require.NoError(t, err)
+		assert.Equal(t, DuplicateActionSkipped, plan.Action)
+		assert.Empty(t, plan.Path)
+		_, err = sm.CommitStagingFileToInbox(second, "")
+		require.ErrorIs(t, err, ErrDuplicateSkipped)
+
+		data, err := os.ReadFile(filepath.Join(testDir, "inbox", "skip-test.jpg"))
+		require.NoError(t, err)
+		assert.Equal(t, "kept", string(data))
+		_, err = os.Stat(second.Path)
+		assert.NoError(t, err, "a skipped file stays staged for the caller to drop")
+	})
+
+	t.Run("duplicate handling with overwrite strategy", func(t *testing.T) {
+		config := repocfg.NewRepositoryConfig("Test Overwrite",
+			repocfg.WithStorageStrategy("flat"),
+			repocfg.WithLocalSettings("overwrite"))
+		require.NoError(t, config.SaveConfigToFile(testDir))
+
+		_, err := sm.CommitStagingFileToInbox(stage("overwrite-test.jpg", "old"), "")
+		require.NoError(t, err)
+
+		replacement := stage("overwrite-test.jpg", "new")
+		plan, err := sm.PlanInboxCommit(replacement, "")
+		require.NoError(t, err)
+		assert.Equal(t, InboxCommit{Path: filepath.Join("inbox", "overwrite-test.jpg"), Action: DuplicateActionOverwritten}, plan)
+		path, err := sm.CommitStagingFileToInbox(replacement, "")
+		require.NoError(t, err)
+		assert.Equal(t, plan.Path, path)
+
+		data, err := os.ReadFile(filepath.Join(testDir, path))
+		require.NoError(t, err)
+		assert.Equal(t, "new", string(data))
+	})
+
+	t.Run("rename reports the new name", func(t *testing.T) {
+		config := repocfg.NewRepositoryConfig("Test Rename Plan",
+			repocfg.WithStorageStrategy("flat"),
+			repocfg.WithLocalSettings("rename"))
+		require.NoError(t, config.SaveConfigToFile(testDir))
+
+		_, err := sm.CommitStagingFileToInbox(stage("rename-plan.jpg", "first"), "")
+		require.NoError(t, err)
+		plan, err := sm.PlanInboxCommit(stage("rename-plan.jpg", "second"), "")
+		require.NoError(t, err)
+		assert.Equal(t, InboxCommit{Path: filepath.Join("inbox", "rename-plan (1).jpg"), Action: DuplicateActionRenamed}, plan)
+	})
 }
 
 func TestStagingManager_ResolveInboxPath(t *testing.T) {
@@ -444,3 +580,81 @@ func TestStagingManager_ErrorHandling(t *testing.T) {
 		assert.Contains(t, err.Error(), "failed to load repository config")
 	})
 }
+
+func TestStagingManager_FlatManifest(t *testing.T) {
+	sm := NewStagingManager()
+	testDir := t.TempDir()
+	require.NoError(t, NewDirectoryManager().CreateStructure(testDir))
+	config := repocfg.NewRepositoryConfig("Test Flat Manifest",
+		repocfg.WithStorageStrategy("flat"),
+		repocfg.WithLocalSettings("rename"))
+	require.NoError(t, config.SaveConfigToFile(testDir))
+
+	commit := func(assetID, filename, content string) string {
+		t.Helper()
+		staging, err := sm.CreateStagingFile(testDir, filename)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(staging.Path, []byte(content), 0644))
+		staging.AssetID = assetID
+		storagePath, err := sm.CommitStagingFileToInbox(staging, "")
+		require.NoError(t, err)
+		return storagePath
+	}
+
+	first := commit("asset-1", "IMG_0001.jpg", "first")
+	second := commit("asset-2", "IMG_0001.jpg", "second")
+	other := commit("asset-3", "IMG_0002.jpg", "other")
+	assert.Equal(t, filepath.Join("inbox", "IMG_0001.jpg"), first)
+	assert.Equal(t, filepath.Join("inbox", "IMG_0001 (1).jpg"), second)
+	assert.Equal(t, filepath.Join("inbox", "IMG_0002.jpg"), other)
+
+	entries, err := ReadFlatManifest(testDir)
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+	assert.Equal(t, "asset-1", entries[0].AssetID)
+	assert.Equal(t, "inbox/IMG_0001.jpg", entries[0].StoragePath)
+	assert.False(t, entries[0].Collision)
+	assert.Equal(t, "asset-2", entries[1].AssetID)
+	assert.Equal(t, "IMG_0001.jpg", entries[1].OriginalFilename)
+	assert.Equal(t, "inbox/IMG_0001 (1).jpg", entries[1].StoragePath)
+	assert.True(t, entries[1].Collision)
+	assert.False(t, entries[2].Collision)
+	assert.False(t, entries[2].CommittedAt.IsZero())
+
+	// Other strategies do not keep a manifest.
+	dateConfig := repocfg.NewRepositoryConfig("Test Date",
+		repocfg.WithStorageStrategy("date"),
+		repocfg.WithLocalSettings("rename"))
+	require.NoError(t, dateConfig.SaveConfigToFile(testDir))
+	commit("asset-4", "IMG_0003.jpg", "dated")
+	entries, err = ReadFlatManifest(testDir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 3)
+}
+
+func TestReadFlatManifest_Missing(t *testing.T) {
+	entries, err := ReadFlatManifest(t.TempDir())
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestRehashedCASPath(t *testing.T) {
+	oldHash := "abcdef0123456789"
+	newHash := "123456fedcba9876"
+	casPath := filepath.Join("inbox", "ab", "cd", "ef", oldHash+".jpg")
+
+	moved, ok := RehashedCASPath(casPath, oldHash, newHash)
+	require.True(t, ok)
+	assert.Equal(t, filepath.Join("inbox", "12", "34", "56", newHash+".jpg"), moved)
+
+	for name, relPath := range map[string]string{
+		"date strategy": filepath.Join("inbox", "2024", "06", "IMG_0001.jpg"),
+		"flat strategy": filepath.Join("inbox", "IMG_0001.jpg"),
+		"other hash":    filepath.Join("inbox", "ab", "cd", "ef", "abcdef9999999999.jpg"),
+	} {
+		_, ok := RehashedCASPath(relPath, oldHash, newHash)
+		assert.False(t, ok, name)
+	}
+	_, ok = RehashedCASPath(casPath, oldHash, "12")
+	assert.False(t, ok, "short hashes have no CAS path")
+}
@@ -0,0 +1,101 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Web derivatives are the browser-playable versions of videos and audio,
+// named after the original's content hash. They can be regenerated from the
+// original, so idle ones may be evicted to reclaim space.
+const (
+	webVideoSuffix = "_web.mp4"
+	webAudioSuffix = "_web.mp3"
+)
+
+// WebDerivativeTouchInterval is how stale a derivative's modification time
+// may get before serving it refreshes it. The modification time records the
+// last access, so it only needs to be as precise as the retention window.
+const WebDerivativeTouchInterval = time.Hour
+
+// WebVideoPath returns where the web video for contentHash lives in the
+// repository at repoPath.
+func WebVideoPath(repoPath, contentHash string) string {
+	return filepath.Join(repoPath, DefaultStructure.VideosDir, "web", contentHash+webVideoSuffix)
+}
+
+// WebAudioPath returns where the web audio for contentHash lives in the
+// repository at repoPath.
+func WebAudioPath(repoPath, contentHash string) string {
+	return filepath.Join(repoPath, DefaultStructure.AudiosDir, "web", contentHash+webAudioSuffix)
+}
+
+// HLSPlaylistName is the playlist in an HLS directory; its segments sit
+// beside it and are referenced by bare file name.
+const HLSPlaylistName = "index.m3u8"
+
+// HLSDir returns the directory holding the HLS playlist and segments of the
+// video assetID in the repository at repoPath.
+func HLSDir(repoPath, assetID string) string {
+	return filepath.Join(repoPath, DefaultStructure.VideosDir, "hls", assetID)
+}
+
+// TouchWebDerivative records that the derivative at path, last modified at
+// modTime, was served at now. Failures are ignored: a read-only repository
+// only loses eviction precision.
+func TouchWebDerivative(path string, modTime, now time.Time) {
+	if now.Sub(modTime) < WebDerivativeTouchInterval {
+		return
+	}
+	_ = os.Chtimes(path, now, now)
+}
+
+// EvictionResult counts the web derivatives one eviction pass removed.
+type EvictionResult struct {
+	Files int
+	Bytes int64
+}
+
+// EvictIdleWebDerivatives removes the web videos and audio of the repository
+// at repoPath that were neither written nor served since cutoff. keep is
+// asked about each candidate's content hash and spares those it accepts,
+// such as derivatives whose original was archived and cannot regenerate
+// them.
+func EvictIdleWebDerivatives(repoPath string, cutoff time.Time, keep func(contentHash string) bool) (EvictionResult, error) {
+	var result EvictionResult
+	for _, dir := range []struct{ path, suffix string }{
+		{filepath.Join(repoPath, DefaultStructure.VideosDir, "web"), webVideoSuffix},
+		{filepath.Join(repoPath, DefaultStructure.AudiosDir, "web"), webAudioSuffix},
+	} {
+		entries, err := os.ReadDir(dir.path)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return result, fmt.Errorf("list web derivatives: %w", err)
+		}
+		for _, entry := range entries {
+			contentHash, ok := strings.CutSuffix(entry.Name(), dir.suffix)
+			if !ok || contentHash == "" || !entry.Type().IsRegular() {
+				continue
+			}
+			info, err := entry.Info()
+			if err != nil || !info.ModTime().Before(cutoff) || keep(contentHash) {
+				continue
+			}
+			if err := os.Remove(filepath.Join(dir.path, entry.Name())); err != nil {
+				if errors.Is(err, os.ErrNotExist) {
+					continue
+				}
+				return result, fmt.Errorf("remove web derivative: %w", err)
+			}
+			result.Files++
+			result.Bytes += info.Size()
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,84 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func writeWebDerivative(t *testing.T, path string, modTime time.Time) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte("derivative"), 0o644))
+	require.NoError(t, os.Chtimes(path, modTime, modTime))
+}
+
+func TestEvictIdleWebDerivatives(t *testing.T) {
+	repoPath := t.TempDir()
+	now := time.Now()
+	cutoff := now.Add(-30 * 24 * time.Hour)
+	old := cutoff.Add(-time.Hour)
+
+	idleVideo := WebVideoPath(repoPath, "idle")
+	idleAudio := WebAudioPath(repoPath, "idlesong")
+	recentVideo := WebVideoPath(repoPath, "recent")
+	archivedVideo := WebVideoPath(repoPath, "archived")
+	unrelated := filepath.Join(filepath.Dir(idleVideo), "notes.txt")
+	writeWebDerivative(t, idleVideo, old)
+	writeWebDerivative(t, idleAudio, old)
+	writeWebDerivative(t, recentVideo, now)
+	writeWebDerivative(t, archivedVideo, old)
+	writeWebDerivative(t, unrelated, old)
+
+	result, err := EvictIdleWebDerivatives(repoPath, cutoff, func(contentHash string) bool {
+		return contentHash == "archived"
+	})
+	require.NoError(t, err)
+	require.Equal(t, EvictionResult{Files: 2, Bytes: int64(2 * len("derivative"))}, result)
+
+	for path, kept := range map[string]bool{
+		idleVideo:     false,
+		idleAudio:     false,
+		recentVideo:   true,
+		archivedVideo: true,
+		unrelated:     true,
+	} {
+		_, err := os.Stat(path)
+		require.Equal(t, kept, err == nil, path)
+	}
+}
+
+func TestEvictIdleWebDerivativesWithoutDerivatives(t *testing.T) {
+	result, err := EvictIdleWebDerivatives(t.TempDir(), time.Now(), func(string) bool { return false })
+	require.NoError(t, err)
+	require.Zero(t, result)
+}
+
+// Serving a derivative pushes its eviction back; it is only written once per
+// touch interval.
+func TestTouchWebDerivative(t *testing.T) {
+	repoPath := t.TempDir()
+	path := WebVideoPath(repoPath, "hash")
+	now := time.Now().Truncate(time.Second)
+
+	fresh := now.Add(-time.Minute)
+	writeWebDerivative(t, path, fresh)
+	TouchWebDerivative(path, fresh, now)
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	require.True(t, info.ModTime().Equal(fresh), "a recently touched derivative is not rewritten")
+
+	stale := now.Add(-48 * time.Hour)
+	require.NoError(t, os.Chtimes(path, stale, stale))
+	TouchWebDerivative(path, stale, now)
+	info, err = os.Stat(path)
+	require.NoError(t, err)
+	require.True(t, info.ModTime().Equal(now))
+
+	result, err := EvictIdleWebDerivatives(repoPath, now.Add(-24*time.Hour), func(string) bool { return false })
+	require.NoError(t, err)
+	require.Zero(t, result.Files, "a served derivative is not idle")
+}
@@ -0,0 +1,206 @@
+// Package blurhash encodes images into BlurHash strings, the compact
+// placeholders clients paint while the real thumbnail loads. See
+// https://blurha.sh for the format.
+package blurhash
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"math"
+	"strings"
+
+	_ "golang.org/x/image/webp"
+)
+
+const base83Chars = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz#$%*+,-.:;=?@[]^_{|}~"
+
+// ComputeFromReader decodes an image stream and encodes it with four
+// components along its long edge and three along its short edge.
+func ComputeFromReader(r io.Reader) (string, error) {
+	img, _, err := image.Decode(r)
+	if err != nil {
+		return "", fmt.Errorf("decode image: %w", err)
+	}
+	xComponents, yComponents := 4, 3
+	if bounds := img.Bounds(); bounds.Dy() > bounds.Dx() {
+		xComponents, yComponents = 3, 4
+	}
+	return Encode(img, xComponents, yComponents)
+}
+
+// Encode returns the BlurHash of img using xComponents by yComponents
+// cosine components, each between 1 and 9.
+func Encode(img image.Image, xComponents, yComponents int) (string, error) {
+	if xComponents < 1 || xComponents > 9 || yComponents < 1 || yComponents > 9 {
+		return "", fmt.Errorf("blurhash components must be 1-9, got %dx%d", xComponents, yComponents)
+	}
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width == 0 || height == 0 {
+		return "", fmt.Errorf("empty image")
+	}
+
+	// Convert every pixel to linear RGB once; each component sums over all.
+	pixels := make([][3]float64, width*height)
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			r, g, b, _ := img.At(bounds.Min.X+x, bounds.Min.Y+y).RGBA()
+			pixels[y*width+x] = [3]float64{srgbToLinear(int(r >> 8)), srgbToLinear(int(g >> 8)), srgbToLinear(int(b >> 8))}
+		}
+	}
+
+	factors := make([][3]float64, 0, xComponents*yComponents)
+	for j := 0; j < yComponents; j++ {
+		for i := 0; i < xComponents; i++ {
+			normalisation := 2.0
+			if i == 0 && j == 0 {
+				normalisation = 1
+			}
+			var factor [3]float64
+			for y := 0; y < height; y++ {
+				basisY := math.Cos(math.Pi * float64(j) * float64(y) / float64(height))
+				for x := 0; x < width; x++ {
+					basis := normalisation * basisY * math.Cos(math.Pi*float64(i)*float64(x)/float64(width))
+					pixel := pixels[y*width+x]
+					factor[0] += basis * pixel[0]
+					factor[1] += basis * pixel[1]
+					factor[2] += basis * pixel[2]
+				}
+			}
+			scale := 1 / float64(width*height)
+			factors = append(factors, [3]float64{factor[0] * scale, factor[1] * scale, factor[2] * scale})
+		}
+	}
+
+	var hash strings.Builder
+	hash.WriteString(encode83((xComponents-1)+(yComponents-1)*9, 1))
+
+	dc, ac := factors[0], factors[1:]
+	maximumValue := 1.0
+	if len(ac) > 0 {
+		actualMax := 0.0
+		for _, factor := range ac {
+			actualMax = math.Max(actualMax, math.Max(math.Abs(factor[0]), math.Max(math.Abs(factor[1]), math.Abs(factor[2]))))
+		}
+		quantisedMax := clampInt(int(math.Floor(actualMax*166-0.5)), 0, 82)
+		maximumValue = float64(quantisedMax+1) / 166
+		hash.WriteString(encode83(quantisedMax, 1))
+	} else {
+		hash.WriteString(encode83(0, 1))
+	}
+
+	hash.WriteString(encode83(linearToSRGB(dc[0])<<16+linearToSRGB(dc[1])<<8+linearToSRGB(dc[2]), 4))
+	for _, factor := range ac {
+		quantise := func(v float64) int {
+			return clampInt(int(math.Floor(signPow(v/maximumValue, 0.5)*9+9.5)), 0, 18)
+		}
+		hash.WriteString(encode83(quantise(factor[0])*19*19+quantise(factor[1])*19+quantise(factor[2]), 2))
+	}
+	return hash.String(), nil
+}
+
+// Decode renders hash into a width by height image.
+func Decode(hash string, width, height int) (image.Image, error) {
+	if len(hash) < 6 {
+		return nil, fmt.Errorf("blurhash %q is too short", hash)
+	}
+	sizeFlag, err := decode83(hash[:1])
+	if err != nil {
+		return nil, err
+	}
+	xComponents, yComponents := sizeFlag%9+1, sizeFlag/9+1
+	if len(hash) != 4+2*xComponents*yComponents {
+		return nil, fmt.Errorf("blurhash %q has length %d, want %d", hash, len(hash), 4+2*xComponents*yComponents)
+	}
+
+	quantisedMax, err := decode83(hash[1:2])
+	if err != nil {
+		return nil, err
+	}
+	maximumValue := float64(quantisedMax+1) / 166
+
+	colors := make([][3]float64, xComponents*yComponents)
+	dc, err := decode83(hash[2:6])
+	if err != nil {
+		return nil, err
+	}
+	colors[0] = [3]float64{srgbToLinear(dc >> 16), srgbToLinear(dc >> 8 & 255), srgbToLinear(dc & 255)}
+	for i := 1; i < len(colors); i++ {
+		value, err := decode83(hash[4+i*2 : 6+i*2])
+		if err != nil {
+			return nil, err
+		}
+		unquantise := func(q int) float64 {
+			return signPow(float64(q-9)/9, 2) * maximumValue
+		}
+		colors[i] = [3]float64{unquantise(value / (19 * 19)), unquantise(value / 19 % 19), unquantise(value % 19)}
+	}
+
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			var r, g, b float64
+			for j := 0; j < yComponents; j++ {
+				for i := 0; i < xComponents; i++ {
+					basis := math.Cos(math.Pi*float64(x)*float64(i)/float64(width)) *
+						math.Cos(math.Pi*float64(y)*float64(j)/float64(height))
+					c := colors[j*xComponents+i]
+					r += c[0] * basis
+					g += c[1] * basis
+					b += c[2] * basis
+				}
+			}
+			img.SetNRGBA(x, y, color.NRGBA{R: uint8(linearToSRGB(r)), G: uint8(linearToSRGB(g)), B: uint8(linearToSRGB(b)), A: 255})
+		}
+	}
+	return img, nil
+}
+
+func encode83(value, length int) string {
+	out := make([]byte, length)
+	for i := length - 1; i >= 0; i-- {
+		out[i] = base83Chars[value%83]
+		value /= 83
+	}
+	return string(out)
+}
+
+func decode83(s string) (int, error) {
+	value := 0
+	for _, c := range s {
+		digit := strings.IndexRune(base83Chars, c)
+		if digit < 0 {
+			return 0, fmt.Errorf("invalid blurhash character %q", c)
+		}
+		value = value*83 + digit
+	}
+	return value, nil
+}
+
+func srgbToLinear(value int) float64 {
+	v := float64(value) / 255
+	if v <= 0.04045 {
+		return v / 12.92
+	}
+	return math.Pow((v+0.055)/1.055, 2.4)
+}
+
+func linearToSRGB(value float64) int {
+	v := math.Max(0, math.Min(1, value))
+	if v <= 0.0031308 {
+		return int(v*12.92*255 + 0.5)
+	}
+	return int((1.055*math.Pow(v, 1/2.4)-0.055)*255 + 0.5)
+}
+
+func signPow(value, exp float64) float64 {
+	return math.Copysign(math.Pow(math.Abs(value), exp), value)
+}
+
+func clampInt(v, lo, hi int) int {
+	return max(lo, min(hi, v))
+}
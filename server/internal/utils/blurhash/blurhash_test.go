@@ -0,0 +1,109 @@
+package blurhash
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+)
+
+func solidImage(width, height int, c color.NRGBA) *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.SetNRGBA(x, y, c)
+		}
+	}
+	return img
+}
+
+func nearColor(t *testing.T, got color.Color, want color.NRGBA, tolerance int) {
+	t.Helper()
+	r, g, b, _ := got.RGBA()
+	for i, pair := range [][2]int{{int(r >> 8), int(want.R)}, {int(g >> 8), int(want.G)}, {int(b >> 8), int(want.B)}} {
+		if diff := pair[0] - pair[1]; diff > tolerance || diff < -tolerance {
+			t.Fatalf("channel %d = %d, want %d±%d", i, pair[0], pair[1], tolerance)
+		}
+	}
+}
+
+func TestEncodeSolidColorDecodesToThatColor(t *testing.T) {
+	want := color.NRGBA{R: 200, G: 80, B: 30, A: 255}
+	hash, err := Encode(solidImage(32, 24, want), 4, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hash) != 4+2*4*3 {
+		t.Fatalf("hash %q has length %d", hash, len(hash))
+	}
+	if hash[0] != 'L' {
+		t.Fatalf("size flag %q, want 'L' for 4x3 components", hash[0])
+	}
+
+	decoded, err := Decode(hash, 8, 6)
+	if err != nil {
+		t.Fatal(err)
+	}
+	nearColor(t, decoded.At(4, 3), want, 2)
+}
+
+func TestEncodeKeepsLeftRightGradient(t *testing.T) {
+	left := color.NRGBA{R: 20, G: 20, B: 220, A: 255}
+	right := color.NRGBA{R: 230, G: 220, B: 20, A: 255}
+	img := solidImage(40, 20, left)
+	for y := 0; y < 20; y++ {
+		for x := 20; x < 40; x++ {
+			img.SetNRGBA(x, y, right)
+		}
+	}
+
+	hash, err := Encode(img, 4, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := Decode(hash, 40, 20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The blur softens the edge, so only the two far ends are compared.
+	nearColor(t, decoded.At(0, 10), left, 60)
+	nearColor(t, decoded.At(39, 10), right, 60)
+}
+
+func TestComputeFromReaderUsesMoreComponentsOnLongEdge(t *testing.T) {
+	for _, tc := range []struct {
+		width, height int
+		flag          byte
+	}{
+		{width: 40, height: 30, flag: 'L'}, // 4x3
+		{width: 30, height: 40, flag: 'T'}, // 3x4
+	} {
+		var buf bytes.Buffer
+		if err := png.Encode(&buf, solidImage(tc.width, tc.height, color.NRGBA{R: 90, G: 140, B: 60, A: 255})); err != nil {
+			t.Fatal(err)
+		}
+		hash, err := ComputeFromReader(&buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hash[0] != tc.flag {
+			t.Fatalf("%dx%d: size flag %q, want %q", tc.width, tc.height, hash[0], tc.flag)
+		}
+		if _, err := Decode(hash, 4, 4); err != nil {
+			t.Fatalf("%dx%d: %v", tc.width, tc.height, err)
+		}
+	}
+}
+
+func TestDecodeRejectsMalformedHashes(t *testing.T) {
+	// The sample hash from blurha.sh decodes; truncated or altered copies do not.
+	if _, err := Decode("LEHV6nWB2yk8pyo0adR*.7kCMdnj", 4, 4); err != nil {
+		t.Fatal(err)
+	}
+	for _, hash := range []string{"", "LEHV6", "LEHV6nWB2yk8pyo0adR*.7kCMdn", "LEHV6nWB2yk8pyo0adR*.7kCMd!j"} {
+		if _, err := Decode(hash, 4, 4); err == nil {
+			t.Fatalf("Decode(%q) succeeded", hash)
+		}
+	}
+}
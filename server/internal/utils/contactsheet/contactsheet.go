@@ -0,0 +1,181 @@
+// Package contactsheet lays thumbnails out on a grid in a single image, for
+// printing or a quick review of an album. It works from already generated
+// thumbnails in pure Go, so a sheet costs a decode and a downscale per tile
+// rather than a full render of every original.
+package contactsheet
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/jpeg"
+	_ "image/png"
+
+	"golang.org/x/image/draw"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+	_ "golang.org/x/image/webp"
+)
+
+// Bounds of a layout. MaxEdge keeps the longest sheet at about 80 MB of RGBA.
+const (
+	MaxColumns  = 20
+	MaxRows     = 20
+	MinTileSize = 64
+	MaxTileSize = 800
+	MaxEdge     = 8000
+
+	// Gutter is the space around and between cells.
+	Gutter = 8
+	// LabelHeight is the strip under each tile that holds its label.
+	LabelHeight = 18
+)
+
+// ErrInvalidLayout is returned for a layout outside the bounds above.
+var ErrInvalidLayout = errors.New("invalid contact sheet layout")
+
+var (
+	background  = color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}
+	placeholder = color.RGBA{R: 0xe0, G: 0xe0, B: 0xe0, A: 0xff}
+	labelColor  = color.RGBA{R: 0x33, G: 0x33, B: 0x33, A: 0xff}
+)
+
+// Layout is the grid of a sheet. Each tile is fitted into a TileSize square,
+// with a label strip underneath when Labels is set.
+type Layout struct {
+	Columns  int
+	Rows     int
+	TileSize int
+	Labels   bool
+}
+
+// Tile is one cell of the sheet. A nil Image leaves a grey placeholder, for
+// assets without a usable thumbnail.
+type Tile struct {
+	Image image.Image
+	Label string
+}
+
+// Validate checks the layout against the bounds of this package.
+func (l Layout) Validate() error {
+	switch {
+	case l.Columns < 1 || l.Columns > MaxColumns:
+		return fmt.Errorf("%w: columns must be between 1 and %d", ErrInvalidLayout, MaxColumns)
+	case l.Rows < 1 || l.Rows > MaxRows:
+		return fmt.Errorf("%w: rows must be between 1 and %d", ErrInvalidLayout, MaxRows)
+	case l.TileSize < MinTileSize || l.TileSize > MaxTileSize:
+		return fmt.Errorf("%w: tile size must be between %d and %d", ErrInvalidLayout, MinTileSize, MaxTileSize)
+	}
+	if width, height := l.Size(); width > MaxEdge || height > MaxEdge {
+		return fmt.Errorf("%w: a %dx%d sheet exceeds %d px per edge", ErrInvalidLayout, width, height, MaxEdge)
+	}
+	return nil
+}
+
+// Tiles is the number of cells in the grid.
+func (l Layout) Tiles() int {
+	return l.Columns * l.Rows
+}
+
+// Size returns the pixel size of the sheet.
+func (l Layout) Size() (width, height int) {
+	return l.Columns*l.TileSize + (l.Columns+1)*Gutter,
+		l.Rows*l.cellHeight() + (l.Rows+1)*Gutter
+}
+
+func (l Layout) cellHeight() int {
+	if l.Labels {
+		return l.TileSize + LabelHeight
+	}
+	return l.TileSize
+}
+
+// Compose draws tiles row by row onto a sheet of l.Size(). Cells beyond the
+// last tile stay empty; tiles beyond the grid are an error.
+func Compose(l Layout, tiles []Tile) (*image.RGBA, error) {
+	if err := l.Validate(); err != nil {
+		return nil, err
+	}
+	if len(tiles) > l.Tiles() {
+		return nil, fmt.Errorf("%w: %d tiles do not fit a %dx%d grid", ErrInvalidLayout, len(tiles), l.Columns, l.Rows)
+	}
+
+	width, height := l.Size()
+	sheet := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(sheet, sheet.Bounds(), image.NewUniform(background), image.Point{}, draw.Src)
+
+	for index, tile := range tiles {
+		x := Gutter + (index%l.Columns)*(l.TileSize+Gutter)
+		y := Gutter + (index/l.Columns)*(l.cellHeight()+Gutter)
+		cell := image.Rect(x, y, x+l.TileSize, y+l.TileSize)
+
+		if tile.Image == nil {
+			draw.Draw(sheet, cell, image.NewUniform(placeholder), image.Point{}, draw.Src)
+		} else {
+			draw.ApproxBiLinear.Scale(sheet, fitRect(cell, tile.Image.Bounds()), tile.Image, tile.Image.Bounds(), draw.Over, nil)
+		}
+		if l.Labels && tile.Label != "" {
+			drawLabel(sheet, tile.Label, x, cell.Max.Y, l.TileSize)
+		}
+	}
+	return sheet, nil
+}
+
+// DecodeTile decodes a thumbnail file (WebP, JPEG or PNG).
+func DecodeTile(data []byte) (image.Image, error) {
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("decode tile: %w", err)
+	}
+	return img, nil
+}
+
+// fitRect centres a src-shaped rectangle in the cell, as large as fits.
+func fitRect(cell, src image.Rectangle) image.Rectangle {
+	sw, sh := src.Dx(), src.Dy()
+	if sw <= 0 || sh <= 0 {
+		return image.Rectangle{}
+	}
+	size := cell.Dx()
+	w, h := size, size
+	if sw >= sh {
+		h = max(1, sh*size/sw)
+	} else {
+		w = max(1, sw*size/sh)
+	}
+	x := cell.Min.X + (size-w)/2
+	y := cell.Min.Y + (size-h)/2
+	return image.Rect(x, y, x+w, y+h)
+}
+
+// drawLabel writes label in the strip below a tile, cut to the tile's width.
+func drawLabel(sheet *image.RGBA, label string, x, top, width int) {
+	face := basicfont.Face7x13
+	label = fitLabel(face, label, width)
+	d := font.Drawer{
+		Dst:  sheet,
+		Src:  image.NewUniform(labelColor),
+		Face: face,
+		Dot:  fixed.P(x, top+face.Ascent+(LabelHeight-face.Height)/2),
+	}
+	d.DrawString(label)
+}
+
+// fitLabel shortens label with "..." until it fits width pixels.
+func fitLabel(face font.Face, label string, width int) string {
+	limit := fixed.I(width)
+	if font.MeasureString(face, label) <= limit {
+		return label
+	}
+	runes := []rune(label)
+	for len(runes) > 0 {
+		runes = runes[:len(runes)-1]
+		if candidate := string(runes) + "..."; font.MeasureString(face, candidate) <= limit {
+			return candidate
+		}
+	}
+	return ""
+}
@@ -0,0 +1,96 @@
+package contactsheet
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/image/font/basicfont"
+)
+
+func solid(w, h int, c color.Color) image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.Set(x, y, c)
+		}
+	}
+	return img
+}
+
+func TestComposeMatchesRequestedGrid(t *testing.T) {
+	for _, layout := range []Layout{
+		{Columns: 1, Rows: 1, TileSize: 64},
+		{Columns: 4, Rows: 3, TileSize: 128},
+		{Columns: 3, Rows: 5, TileSize: 100, Labels: true},
+	} {
+		tiles := make([]Tile, layout.Tiles()-1)
+		for i := range tiles {
+			tiles[i] = Tile{Image: solid(300, 200, color.Black), Label: "IMG_0001.jpg"}
+		}
+
+		sheet, err := Compose(layout, tiles)
+		require.NoError(t, err)
+		width, height := layout.Size()
+		require.Equal(t, image.Rect(0, 0, width, height), sheet.Bounds())
+
+		cellHeight := layout.TileSize
+		if layout.Labels {
+			cellHeight += LabelHeight
+		}
+		require.Equal(t, layout.Columns*layout.TileSize+(layout.Columns+1)*Gutter, width)
+		require.Equal(t, layout.Rows*cellHeight+(layout.Rows+1)*Gutter, height)
+	}
+}
+
+func TestComposePlacesTilesRowByRow(t *testing.T) {
+	layout := Layout{Columns: 2, Rows: 2, TileSize: 64}
+	red := color.RGBA{R: 0xff, A: 0xff}
+	sheet, err := Compose(layout, []Tile{{}, {}, {Image: solid(64, 32, red)}})
+	require.NoError(t, err)
+
+	// Placeholder in the first cell, a letterboxed red tile in the third
+	// (second row, first column), and the fourth cell left blank.
+	require.Equal(t, placeholder, sheet.RGBAAt(Gutter+1, Gutter+1))
+	thirdTop := Gutter + 64 + Gutter
+	require.Equal(t, red, sheet.RGBAAt(Gutter+32, thirdTop+32))
+	require.Equal(t, background, sheet.RGBAAt(Gutter+32, thirdTop+2))
+	require.Equal(t, background, sheet.RGBAAt(2*Gutter+64+32, thirdTop+32))
+}
+
+func TestComposeRejectsBadLayouts(t *testing.T) {
+	for name, layout := range map[string]Layout{
+		"no columns":     {Columns: 0, Rows: 1, TileSize: 128},
+		"too many rows":  {Columns: 1, Rows: MaxRows + 1, TileSize: 128},
+		"tiny tiles":     {Columns: 1, Rows: 1, TileSize: MinTileSize - 1},
+		"oversize sheet": {Columns: MaxColumns, Rows: 1, TileSize: MaxTileSize},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := Compose(layout, nil)
+			require.ErrorIs(t, err, ErrInvalidLayout)
+		})
+	}
+
+	_, err := Compose(Layout{Columns: 1, Rows: 1, TileSize: 64}, make([]Tile, 2))
+	require.ErrorIs(t, err, ErrInvalidLayout)
+}
+
+func TestFitLabelTruncates(t *testing.T) {
+	face := basicfont.Face7x13
+	require.Equal(t, "short.jpg", fitLabel(face, "short.jpg", 64*7))
+	require.Equal(t, "a_very...", fitLabel(face, "a_very_long_filename.jpg", 9*7))
+}
+
+func TestDecodeTile(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, solid(3, 2, color.White)))
+	img, err := DecodeTile(buf.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, image.Rect(0, 0, 3, 2), img.Bounds())
+
+	_, err = DecodeTile([]byte("not an image"))
+	require.Error(t, err)
+}
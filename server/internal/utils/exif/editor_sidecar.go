@@ -0,0 +1,304 @@
+package exif
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Editor names reported in EditorSidecar.Editor.
+const (
+	EditorDarktable   = "darktable"
+	EditorRawTherapee = "rawtherapee"
+	// EditorXMP is reported for an XMP sidecar no known raw editor claimed,
+	// such as one written by a DAM or Lightroom.
+	EditorXMP = "xmp"
+)
+
+const (
+	xmpNamespace       = "http://ns.adobe.com/xap/1.0/"
+	dcNamespace        = "http://purl.org/dc/elements/1.1/"
+	rdfNamespace       = "http://www.w3.org/1999/02/22-rdf-syntax-ns#"
+	darktableNamespace = "http://darktable.sf.net/"
+)
+
+// colorLabelNames maps darktable colour label and RawTherapee ColorLabel
+// numbers to the names Lightroom writes into xmp:Label.
+var colorLabelNames = []string{"Red", "Yellow", "Green", "Blue", "Purple"}
+
+// EditorSidecar is what a raw editor recorded about a photo in the sidecar it
+// keeps next to the original.
+type EditorSidecar struct {
+	// Path is the sidecar file the fields were read from.
+	Path string
+	// Editor is EditorDarktable, EditorRawTherapee or EditorXMP.
+	Editor string
+	// Rating is the star rating, 1 to 5; nil when unrated or rejected.
+	Rating *int
+	// ColorLabel is the colour label name, e.g. "Red".
+	ColorLabel string
+	// Keywords are the flat keywords (dc:subject, or the IPTC keywords of a
+	// RawTherapee profile).
+	Keywords []string
+	// HasEdits reports a processing history, meaning the editor can render a
+	// developed version that differs from the camera default.
+	HasEdits bool
+}
+
+// FindEditorSidecar looks for the sidecars raw editors keep next to the
+// original at path: an XMP named after the full file name (darktable,
+// "IMG_0001.CR2.xmp") or after its stem (Lightroom, "IMG_0001.xmp"), and a
+// RawTherapee processing profile ("IMG_0001.CR2.pp3"). Fields from the XMP
+// win; the profile fills what it left empty. It returns nil when there is no
+// sidecar.
+func FindEditorSidecar(path string) (*EditorSidecar, error) {
+	stem := strings.TrimSuffix(path, filepath.Ext(path))
+	var sidecar *EditorSidecar
+	for _, candidate := range []string{path + ".xmp", path + ".XMP", stem + ".xmp", stem + ".XMP"} {
+		data, err := readSidecarFile(candidate)
+		if err != nil {
+			return nil, err
+		}
+		if data == nil {
+			continue
+		}
+		if sidecar, err = ParseXMPSidecar(data); err != nil {
+			return nil, fmt.Errorf("parse %s: %w", filepath.Base(candidate), err)
+		}
+		sidecar.Path = candidate
+		break
+	}
+
+	data, err := readSidecarFile(path + ".pp3")
+	if err != nil {
+		return nil, err
+	}
+	if data == nil {
+		return sidecar, nil
+	}
+	profile := ParseRawTherapeeProfile(data)
+	profile.Path = path + ".pp3"
+	if sidecar == nil {
+		return profile, nil
+	}
+	sidecar.HasEdits = sidecar.HasEdits || profile.HasEdits
+	if sidecar.Rating == nil {
+		sidecar.Rating = profile.Rating
+	}
+	if sidecar.ColorLabel == "" {
+		sidecar.ColorLabel = profile.ColorLabel
+	}
+	if len(sidecar.Keywords) == 0 {
+		sidecar.Keywords = profile.Keywords
+	}
+	return sidecar, nil
+}
+
+// readSidecarFile returns the file's contents, or nil when it does not exist.
+func readSidecarFile(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read sidecar: %w", err)
+	}
+	return data, nil
+}
+
+// ParseXMPSidecar reads rating, colour label and keywords from an XMP sidecar.
+// Properties may be written as attributes of rdf:Description (darktable,
+// exiv2) or as elements (Adobe). A darktable history marks the photo as
+// edited when it applies anything beyond darktable's built-in defaults.
+func ParseXMPSidecar(data []byte) (*EditorSidecar, error) {
+	sidecar := &EditorSidecar{Editor: EditorXMP}
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	var stack []xml.Name
+	var history []darktableHistoryEntry
+	historyEnd := -1
+
+	inside := func(space, local string) bool {
+		for _, name := range stack {
+			if name.Space == space && name.Local == local {
+				return true
+			}
+		}
+		return false
+	}
+
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			stack = append(stack, t.Name)
+			if t.Name.Space == darktableNamespace {
+				sidecar.Editor = EditorDarktable
+			}
+			if t.Name.Space == rdfNamespace && t.Name.Local == "li" && inside(darktableNamespace, "history") {
+				history = append(history, parseDarktableHistoryEntry(t.Attr, len(history)))
+				continue
+			}
+			for _, attr := range t.Attr {
+				if attr.Name.Space == darktableNamespace && attr.Name.Local == "history_end" {
+					historyEnd = nonNegativeInt(attr.Value)
+					sidecar.Editor = EditorDarktable
+					continue
+				}
+				sidecar.setXMPProperty(attr.Name, attr.Value)
+			}
+		case xml.EndElement:
+			if len(stack) > 0 {
+				stack = stack[:len(stack)-1]
+			}
+		case xml.CharData:
+			if len(stack) == 0 {
+				continue
+			}
+			value := strings.TrimSpace(string(t))
+			if value == "" {
+				continue
+			}
+			top := stack[len(stack)-1]
+			switch {
+			case top.Space == rdfNamespace && top.Local == "li" && inside(dcNamespace, "subject"):
+				sidecar.Keywords = append(sidecar.Keywords, value)
+			case top.Space == rdfNamespace && top.Local == "li" && inside(darktableNamespace, "colorlabels"):
+				if sidecar.ColorLabel == "" {
+					sidecar.ColorLabel = colorLabelName(value, 0)
+				}
+			case top.Space == darktableNamespace && top.Local == "history_end":
+				historyEnd = nonNegativeInt(value)
+			default:
+				sidecar.setXMPProperty(top, value)
+			}
+		}
+	}
+
+	for _, entry := range history {
+		// Entries from history_end on were undone in the darkroom.
+		if historyEnd >= 0 && entry.num >= historyEnd {
+			continue
+		}
+		if !entry.builtin {
+			sidecar.HasEdits = true
+			break
+		}
+	}
+	return sidecar, nil
+}
+
+// darktableHistoryEntry is one operation of a darktable:history sequence.
+type darktableHistoryEntry struct {
+	num int
+	// builtin marks the presets darktable applies to every raw on first
+	// open (multi_name "_builtin_..."), which are not the user's edits.
+	builtin bool
+}
+
+func parseDarktableHistoryEntry(attrs []xml.Attr, index int) darktableHistoryEntry {
+	entry := darktableHistoryEntry{num: index}
+	for _, attr := range attrs {
+		if attr.Name.Space != darktableNamespace {
+			continue
+		}
+		switch attr.Name.Local {
+		case "num":
+			if num := nonNegativeInt(attr.Value); num >= 0 {
+				entry.num = num
+			}
+		case "multi_name":
+			entry.builtin = strings.HasPrefix(attr.Value, "_builtin_")
+		}
+	}
+	return entry
+}
+
+// nonNegativeInt parses a count or index, or returns -1.
+func nonNegativeInt(value string) int {
+	n, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || n < 0 {
+		return -1
+	}
+	return n
+}
+
+// setXMPProperty applies one simple property, in attribute or element form.
+func (s *EditorSidecar) setXMPProperty(name xml.Name, value string) {
+	switch {
+	case name.Space == xmpNamespace && name.Local == "Rating":
+		s.Rating = sidecarRating(value)
+	case name.Space == xmpNamespace && name.Local == "Label":
+		s.ColorLabel = strings.TrimSpace(value)
+	}
+}
+
+// ParseRawTherapeeProfile reads rating, colour label and keywords from a
+// RawTherapee .pp3 processing profile. RawTherapee writes one for every
+// photo it has opened, so its presence alone marks the photo as edited.
+func ParseRawTherapeeProfile(data []byte) *EditorSidecar {
+	sidecar := &EditorSidecar{Editor: EditorRawTherapee, HasEdits: true}
+	section := ""
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			section = line[1 : len(line)-1]
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		switch section + "." + strings.TrimSpace(key) {
+		case "General.Rank":
+			sidecar.Rating = sidecarRating(value)
+		case "General.ColorLabel":
+			// RawTherapee numbers labels from 1; 0 is none.
+			sidecar.ColorLabel = colorLabelName(value, 1)
+		case "IPTC.Keywords":
+			for _, keyword := range strings.Split(value, ";") {
+				if keyword = strings.TrimSpace(keyword); keyword != "" {
+					sidecar.Keywords = append(sidecar.Keywords, keyword)
+				}
+			}
+		}
+	}
+	return sidecar
+}
+
+// sidecarRating parses a star rating. Zero (unrated) and -1 (rejected) carry
+// no rating.
+func sidecarRating(value string) *int {
+	rating, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || rating < 1 || rating > 5 {
+		return nil
+	}
+	return &rating
+}
+
+// colorLabelName maps a numbered colour label to its name, with first being
+// the number of the first label.
+func colorLabelName(value string, first int) string {
+	index, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil {
+		return ""
+	}
+	index -= first
+	if index < 0 || index >= len(colorLabelNames) {
+		return ""
+	}
+	return colorLabelNames[index]
+}
@@ -0,0 +1,118 @@
+package exif
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// darktableSidecar is trimmed from a darktable 4.6 sidecar: the two
+// "_builtin_" entries are applied to every raw, the exposure one is the
+// user's edit.
+const darktableSidecar = `<?xml version="1.0" encoding="UTF-8"?>
+<x:xmpmeta xmlns:x="adobe:ns:meta/" x:xmptk="XMP Core 4.4.0-Exiv2">
+ <rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#">
+  <rdf:Description rdf:about=""
+    xmlns:xmp="http://ns.adobe.com/xap/1.0/"
+    xmlns:xmpMM="http://ns.adobe.com/xap/1.0/mm/"
+    xmlns:dc="http://purl.org/dc/elements/1.1/"
+    xmlns:darktable="http://darktable.sf.net/"
+   xmp:Rating="4"
+   xmpMM:DerivedFrom="IMG_0001.CR2"
+   darktable:xmp_version="5"
+   darktable:history_end="3">
+   <darktable:colorlabels>
+    <rdf:Seq>
+     <rdf:li>2</rdf:li>
+    </rdf:Seq>
+   </darktable:colorlabels>
+   <dc:subject>
+    <rdf:Bag>
+     <rdf:li>norway</rdf:li>
+     <rdf:li>fjord</rdf:li>
+    </rdf:Bag>
+   </dc:subject>
+   <darktable:history>
+    <rdf:Seq>
+     <rdf:li darktable:num="0" darktable:operation="filmicrgb" darktable:enabled="1" darktable:multi_name="_builtin_scene-referred default"/>
+     <rdf:li darktable:num="1" darktable:operation="exposure" darktable:enabled="1" darktable:multi_name="_builtin_scene-referred default"/>
+     <rdf:li darktable:num="2" darktable:operation="exposure" darktable:enabled="1" darktable:multi_name=""/>
+    </rdf:Seq>
+   </darktable:history>
+  </rdf:Description>
+ </rdf:RDF>
+</x:xmpmeta>
+`
+
+func TestParseXMPSidecarReadsDarktableSidecar(t *testing.T) {
+	sidecar, err := ParseXMPSidecar([]byte(darktableSidecar))
+	require.NoError(t, err)
+	require.Equal(t, EditorDarktable, sidecar.Editor)
+	require.NotNil(t, sidecar.Rating)
+	require.Equal(t, 4, *sidecar.Rating)
+	require.Equal(t, "Green", sidecar.ColorLabel)
+	require.Equal(t, []string{"norway", "fjord"}, sidecar.Keywords)
+	require.True(t, sidecar.HasEdits)
+}
+
+func TestParseXMPSidecarIgnoresBuiltinAndUndoneHistory(t *testing.T) {
+	// history_end 2 leaves only the built-in defaults applied.
+	undone := []byte(strings.Replace(darktableSidecar, `darktable:history_end="3"`, `darktable:history_end="2"`, 1))
+	sidecar, err := ParseXMPSidecar(undone)
+	require.NoError(t, err)
+	require.False(t, sidecar.HasEdits)
+}
+
+func TestParseXMPSidecarReadsElementForm(t *testing.T) {
+	sidecar, err := ParseXMPSidecar([]byte(`<x:xmpmeta xmlns:x="adobe:ns:meta/">
+ <rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#">
+  <rdf:Description rdf:about="" xmlns:xmp="http://ns.adobe.com/xap/1.0/">
+   <xmp:Rating>-1</xmp:Rating>
+   <xmp:Label>Red</xmp:Label>
+  </rdf:Description>
+ </rdf:RDF>
+</x:xmpmeta>`))
+	require.NoError(t, err)
+	require.Equal(t, EditorXMP, sidecar.Editor)
+	require.Nil(t, sidecar.Rating, "rejected is not a rating")
+	require.Equal(t, "Red", sidecar.ColorLabel)
+	require.False(t, sidecar.HasEdits)
+}
+
+func TestParseRawTherapeeProfile(t *testing.T) {
+	sidecar := ParseRawTherapeeProfile([]byte("[Version]\nAppVersion=5.10\n\n[General]\nRank=3\nColorLabel=1\nInTrash=false\n\n[IPTC]\nKeywords=harbour;night;\n"))
+	require.Equal(t, EditorRawTherapee, sidecar.Editor)
+	require.NotNil(t, sidecar.Rating)
+	require.Equal(t, 3, *sidecar.Rating)
+	require.Equal(t, "Red", sidecar.ColorLabel)
+	require.Equal(t, []string{"harbour", "night"}, sidecar.Keywords)
+	require.True(t, sidecar.HasEdits)
+}
+
+func TestFindEditorSidecar(t *testing.T) {
+	dir := t.TempDir()
+	original := filepath.Join(dir, "IMG_0001.CR2")
+	require.NoError(t, os.WriteFile(original, []byte("raw"), 0o644))
+
+	sidecar, err := FindEditorSidecar(original)
+	require.NoError(t, err)
+	require.Nil(t, sidecar)
+
+	require.NoError(t, os.WriteFile(original+".xmp", []byte(darktableSidecar), 0o644))
+	require.NoError(t, os.WriteFile(original+".pp3", []byte("[General]\nRank=1\n\n[IPTC]\nKeywords=ignored;\n"), 0o644))
+	sidecar, err = FindEditorSidecar(original)
+	require.NoError(t, err)
+	require.Equal(t, original+".xmp", sidecar.Path)
+	require.Equal(t, 4, *sidecar.Rating, "the XMP wins over the profile")
+	require.Equal(t, []string{"norway", "fjord"}, sidecar.Keywords)
+
+	// A Lightroom-style sidecar named after the stem is found as well.
+	other := filepath.Join(dir, "IMG_0002.NEF")
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "IMG_0002.xmp"), []byte(`<x:xmpmeta xmlns:x="adobe:ns:meta/"><rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#"><rdf:Description xmlns:xmp="http://ns.adobe.com/xap/1.0/" xmp:Rating="2"/></rdf:RDF></x:xmpmeta>`), 0o644))
+	sidecar, err = FindEditorSidecar(other)
+	require.NoError(t, err)
+	require.Equal(t, 2, *sidecar.Rating)
+}
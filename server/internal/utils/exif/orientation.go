@@ -0,0 +1,115 @@
+package exif
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"server/internal/utils/sysproc"
+	"strconv"
+	"strings"
+)
+
+// Transform is a user-requested rotation or flip of a photo as displayed.
+type Transform string
+
+const (
+	TransformRotate90       Transform = "rotate_90"
+	TransformRotate180      Transform = "rotate_180"
+	TransformRotate270      Transform = "rotate_270"
+	TransformFlipHorizontal Transform = "flip_horizontal"
+	TransformFlipVertical   Transform = "flip_vertical"
+)
+
+// orientationMatrix maps each EXIF Orientation value (1-8) to the transform
+// that turns stored pixels into the displayed image, as a 2x2 matrix acting on
+// (x, y) with y pointing down: (x, y) -> (m[0]x + m[1]y, m[2]x + m[3]y).
+var orientationMatrix = map[int][4]int{
+	1: {1, 0, 0, 1},   // Horizontal (normal)
+	2: {-1, 0, 0, 1},  // Mirror horizontal
+	3: {-1, 0, 0, -1}, // Rotate 180
+	4: {1, 0, 0, -1},  // Mirror vertical
+	5: {0, 1, 1, 0},   // Mirror horizontal and rotate 270 CW
+	6: {0, -1, 1, 0},  // Rotate 90 CW
+	7: {0, -1, -1, 0}, // Mirror horizontal and rotate 90 CW
+	8: {0, 1, -1, 0},  // Rotate 270 CW
+}
+
+var transformMatrix = map[Transform][4]int{
+	TransformRotate90:       orientationMatrix[6],
+	TransformRotate180:      orientationMatrix[3],
+	TransformRotate270:      orientationMatrix[8],
+	TransformFlipHorizontal: orientationMatrix[2],
+	TransformFlipVertical:   orientationMatrix[4],
+}
+
+// Valid reports whether t is a known transform.
+func (t Transform) Valid() bool {
+	_, ok := transformMatrix[t]
+	return ok
+}
+
+// SwapsDimensions reports whether applying t exchanges width and height.
+func (t Transform) SwapsDimensions() bool {
+	return t == TransformRotate90 || t == TransformRotate270
+}
+
+// ApplyTransform returns the EXIF Orientation that displays the same stored
+// pixels with t applied on top of orientation. Unknown orientations are
+// treated as 1 (normal).
+func ApplyTransform(orientation int, t Transform) (int, error) {
+	m, ok := transformMatrix[t]
+	if !ok {
+		return 0, fmt.Errorf("unknown transform %q", t)
+	}
+	current, ok := orientationMatrix[orientation]
+	if !ok {
+		current = orientationMatrix[1]
+	}
+	composed := [4]int{
+		m[0]*current[0] + m[1]*current[2],
+		m[0]*current[1] + m[1]*current[3],
+		m[2]*current[0] + m[3]*current[2],
+		m[2]*current[1] + m[3]*current[3],
+	}
+	for value, matrix := range orientationMatrix {
+		if matrix == composed {
+			return value, nil
+		}
+	}
+	return 0, fmt.Errorf("no orientation for %v", composed)
+}
+
+// ReadOrientation returns the numeric EXIF Orientation of the file at path,
+// or 1 when the tag is absent.
+func ReadOrientation(ctx context.Context, exifToolPath, path string) (int, error) {
+	cmd := exec.CommandContext(ctx, exifToolPath, "-n", "-s3", "-Orientation", path)
+	sysproc.HideConsole(cmd)
+	output, err := cmd.Output()
+	if err != nil {
+		return 0, fmt.Errorf("read orientation: %w", err)
+	}
+	value := strings.TrimSpace(string(output))
+	if value == "" {
+		return 1, nil
+	}
+	orientation, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("parse orientation %q: %w", value, err)
+	}
+	return orientation, nil
+}
+
+// WriteOrientation sets the EXIF Orientation of the file at path in place.
+// Only the tag changes; the image data is left byte-for-byte intact.
+func WriteOrientation(ctx context.Context, exifToolPath, path string, orientation int) error {
+	if _, ok := orientationMatrix[orientation]; !ok {
+		return fmt.Errorf("invalid orientation %d", orientation)
+	}
+	cmd := exec.CommandContext(ctx, exifToolPath, "-n", "-overwrite_original",
+		fmt.Sprintf("-Orientation=%d", orientation), path)
+	sysproc.HideConsole(cmd)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("write orientation: %w: %s", err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
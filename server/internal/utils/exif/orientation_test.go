@@ -0,0 +1,87 @@
+package exif
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyTransform(t *testing.T) {
+	cases := []struct {
+		orientation int
+		transform   Transform
+		want        int
+	}{
+		{1, TransformRotate90, 6},
+		{1, TransformRotate180, 3},
+		{1, TransformRotate270, 8},
+		{1, TransformFlipHorizontal, 2},
+		{1, TransformFlipVertical, 4},
+		{6, TransformRotate90, 3},
+		{8, TransformRotate90, 1},
+		{6, TransformFlipHorizontal, 5},
+		{6, TransformFlipVertical, 7},
+		{0, TransformRotate90, 6},
+	}
+	for _, tc := range cases {
+		got, err := ApplyTransform(tc.orientation, tc.transform)
+		require.NoError(t, err)
+		require.Equal(t, tc.want, got, "orientation %d + %s", tc.orientation, tc.transform)
+	}
+}
+
+func TestApplyTransformRoundTrips(t *testing.T) {
+	for orientation := 1; orientation <= 8; orientation++ {
+		current := orientation
+		for range 4 {
+			var err error
+			current, err = ApplyTransform(current, TransformRotate90)
+			require.NoError(t, err)
+		}
+		require.Equal(t, orientation, current, "four quarter turns from %d", orientation)
+
+		for _, flip := range []Transform{TransformFlipHorizontal, TransformFlipVertical} {
+			once, err := ApplyTransform(orientation, flip)
+			require.NoError(t, err)
+			twice, err := ApplyTransform(once, flip)
+			require.NoError(t, err)
+			require.Equal(t, orientation, twice, "double %s from %d", flip, orientation)
+		}
+	}
+}
+
+func TestApplyTransformRejectsUnknown(t *testing.T) {
+	_, err := ApplyTransform(1, Transform("rotate_45"))
+	require.Error(t, err)
+	require.False(t, Transform("rotate_45").Valid())
+	require.True(t, TransformRotate90.SwapsDimensions())
+	require.False(t, TransformFlipHorizontal.SwapsDimensions())
+}
+
+func TestCorrectDimensionsByOrientationCoversAllEight(t *testing.T) {
+	// exiftool prints the description unless -n is set; both forms occur.
+	cases := []struct {
+		numeric     string
+		description string
+		swapped     bool
+	}{
+		{"1", "Horizontal (normal)", false},
+		{"2", "Mirror horizontal", false},
+		{"3", "Rotate 180", false},
+		{"4", "Mirror vertical", false},
+		{"5", "Mirror horizontal and rotate 270 CW", true},
+		{"6", "Rotate 90 CW", true},
+		{"7", "Mirror horizontal and rotate 90 CW", true},
+		{"8", "Rotate 270 CW", true},
+	}
+	for _, tc := range cases {
+		want := [2]int{4032, 3024}
+		if tc.swapped {
+			want = [2]int{3024, 4032}
+		}
+		for _, orientation := range []string{tc.numeric, tc.description} {
+			w, h := correctDimensionsByOrientation(4032, 3024, orientation)
+			require.Equal(t, want, [2]int{w, h}, "orientation %q", orientation)
+		}
+	}
+}
@@ -0,0 +1,25 @@
+package exif
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"server/internal/utils/sysproc"
+	"strings"
+)
+
+// WriteRating sets the XMP Rating (0-5) of the file at path in place. Zero
+// means unrated, as in Lightroom and digiKam. Only the XMP packet changes;
+// the image data is left intact.
+func WriteRating(ctx context.Context, exifToolPath, path string, rating int) error {
+	if rating < 0 || rating > 5 {
+		return fmt.Errorf("invalid rating %d", rating)
+	}
+	cmd := exec.CommandContext(ctx, exifToolPath, "-n", "-overwrite_original",
+		fmt.Sprintf("-XMP-xmp:Rating=%d", rating), path)
+	sysproc.HideConsole(cmd)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("write rating: %w: %s", err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
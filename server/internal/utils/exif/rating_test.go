@@ -0,0 +1,37 @@
+package exif
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/jpeg"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteRatingRejectsOutOfRange(t *testing.T) {
+	require.Error(t, WriteRating(context.Background(), "exiftool", "photo.jpg", -1))
+	require.Error(t, WriteRating(context.Background(), "exiftool", "photo.jpg", 6))
+}
+
+func TestWriteRatingWithExifTool(t *testing.T) {
+	exifToolPath, err := exec.LookPath("exiftool")
+	if err != nil {
+		t.Skip("exiftool not installed")
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, jpeg.Encode(&buf, image.NewGray(image.Rect(0, 0, 2, 2)), nil))
+	path := filepath.Join(t.TempDir(), "rated.jpg")
+	require.NoError(t, os.WriteFile(path, buf.Bytes(), 0o600))
+
+	require.NoError(t, WriteRating(context.Background(), exifToolPath, path, 4))
+
+	tags, err := ReadAllTags(context.Background(), exifToolPath, path)
+	require.NoError(t, err)
+	require.EqualValues(t, 4, tags["XMP-xmp:Rating"])
+}
@@ -0,0 +1,51 @@
+package exif
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"server/internal/utils/sysproc"
+	"strings"
+)
+
+// ReadAllTags returns every tag exiftool can read from the file at path:
+// EXIF, MakerNotes, XMP, IPTC, ICC and the rest, including unknown tags and
+// the same tag repeated in several groups. Keys are "Group:Tag" with the
+// specific group (e.g. "IFD0:Make", "XMP-dc:Creator", "IPTC:Keywords").
+// Binary values are reported by exiftool as a size placeholder, not the data.
+// The System group (file name, directory, permissions) describes the server's
+// filesystem rather than the file and is left out.
+func ReadAllTags(ctx context.Context, exifToolPath, path string) (map[string]any, error) {
+	cmd := exec.CommandContext(ctx, exifToolPath,
+		"-j", "-a", "-u", "-G1", "-s", "-charset", "utf8", "-ignoreMinorErrors", path)
+	sysproc.HideConsole(cmd)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("read tags: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return parseAllTags(output)
+}
+
+// parseAllTags decodes exiftool -j output for a single file.
+func parseAllTags(output []byte) (map[string]any, error) {
+	var files []map[string]any
+	if err := json.Unmarshal(output, &files); err != nil {
+		return nil, fmt.Errorf("parse exiftool output: %w", err)
+	}
+	if len(files) == 0 {
+		return map[string]any{}, nil
+	}
+
+	tags := files[0]
+	delete(tags, "SourceFile")
+	for key := range tags {
+		if strings.HasPrefix(key, "System:") {
+			delete(tags, key)
+		}
+	}
+	return tags, nil
+}
@@ -0,0 +1,70 @@
+package exif
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/jpeg"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAllTagsKeepsGroupedTags(t *testing.T) {
+	output := []byte(`[{
+		"SourceFile": "/repo/photo.jpg",
+		"System:FileName": "photo.jpg",
+		"System:Directory": "/repo",
+		"File:MIMEType": "image/jpeg",
+		"IFD0:Make": "FUJIFILM",
+		"ExifIFD:ISO": 400,
+		"XMP-dc:Creator": "Edwin",
+		"IPTC:Keywords": ["street", "night"],
+		"ExifIFD:OffsetSchema": "(Binary data 4 bytes, use -b option to extract)"
+	}]`)
+
+	tags, err := parseAllTags(output)
+	require.NoError(t, err)
+	require.Equal(t, "FUJIFILM", tags["IFD0:Make"])
+	require.Equal(t, float64(400), tags["ExifIFD:ISO"])
+	require.Equal(t, "Edwin", tags["XMP-dc:Creator"])
+	require.Equal(t, []any{"street", "night"}, tags["IPTC:Keywords"])
+	require.Contains(t, tags, "File:MIMEType")
+	require.NotContains(t, tags, "SourceFile")
+	require.NotContains(t, tags, "System:FileName")
+	require.NotContains(t, tags, "System:Directory")
+}
+
+func TestParseAllTagsRejectsInvalidOutput(t *testing.T) {
+	_, err := parseAllTags([]byte("Error: File not found"))
+	require.Error(t, err)
+
+	tags, err := parseAllTags([]byte("[]"))
+	require.NoError(t, err)
+	require.Empty(t, tags)
+}
+
+func TestReadAllTagsWithExifTool(t *testing.T) {
+	exifToolPath, err := exec.LookPath("exiftool")
+	if err != nil {
+		t.Skip("exiftool not installed")
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, jpeg.Encode(&buf, image.NewGray(image.Rect(0, 0, 2, 2)), nil))
+	path := filepath.Join(t.TempDir(), "tagged.jpg")
+	require.NoError(t, os.WriteFile(path, buf.Bytes(), 0o600))
+	write := exec.Command(exifToolPath, "-overwrite_original",
+		"-IFD0:Make=FUJIFILM", "-XMP-dc:Creator=Edwin", "-IPTC:Keywords=street", path)
+	out, err := write.CombinedOutput()
+	require.NoError(t, err, string(out))
+
+	tags, err := ReadAllTags(context.Background(), exifToolPath, path)
+	require.NoError(t, err)
+	require.Equal(t, "FUJIFILM", tags["IFD0:Make"])
+	require.Equal(t, "Edwin", tags["XMP-dc:Creator"])
+	require.Equal(t, "street", tags["IPTC:Keywords"])
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"sync/atomic"
 
 	"github.com/zeebo/blake3"
 )
@@ -18,8 +19,36 @@ const (
 	QuickHashThreshold = 100 * 1024 * 1024
 
 	QuickFingerprintVersion = "blake3-size-first-last-1m-v1"
+
+	// DefaultReadBufferSize is how much of a file full hashes read at a time.
+	DefaultReadBufferSize = 1 * 1024 * 1024 // 1 MB
 )
 
+// ReadOptions tunes how full hashes read their input. Neither setting changes
+// the resulting hash.
+type ReadOptions struct {
+	// BufferSize is the size of each read; zero or less uses
+	// DefaultReadBufferSize.
+	BufferSize int
+	// ReadAhead reads the next buffer on a separate goroutine while the
+	// current one is hashed, so disk I/O and hashing overlap.
+	ReadAhead bool
+}
+
+var readOptions atomic.Pointer[ReadOptions]
+
+// SetReadOptions replaces the read options used by every later full hash.
+func SetReadOptions(opts ReadOptions) {
+	readOptions.Store(&opts)
+}
+
+func currentReadOptions() ReadOptions {
+	if opts := readOptions.Load(); opts != nil {
+		return *opts
+	}
+	return ReadOptions{}
+}
+
 // HashAlgorithm defines the hashing algorithm to use
 type HashAlgorithm string
 
@@ -141,17 +170,21 @@ func CalculateQuickSHA256(filePath string) (string, error) {
 
 // calculateFullHash calculates the full hash of a file
 func calculateFullHash(reader io.Reader, algorithm HashAlgorithm) (string, error) {
+	return calculateFullHashWith(reader, algorithm, currentReadOptions())
+}
+
+func calculateFullHashWith(reader io.Reader, algorithm HashAlgorithm, opts ReadOptions) (string, error) {
 	switch algorithm {
 	case AlgorithmBLAKE3:
 		hasher := blake3.New()
-		if _, err := io.Copy(hasher, reader); err != nil {
+		if err := copyForHash(hasher, reader, opts); err != nil {
 			return "", fmt.Errorf("failed to calculate BLAKE3 hash: %w", err)
 		}
 		return hex.EncodeToString(hasher.Sum(nil)), nil
 
 	case AlgorithmSHA256:
 		hasher := sha256.New()
-		if _, err := io.Copy(hasher, reader); err != nil {
+		if err := copyForHash(hasher, reader, opts); err != nil {
 			return "", fmt.Errorf("failed to calculate SHA256 hash: %w", err)
 		}
 		return hex.EncodeToString(hasher.Sum(nil)), nil
@@ -161,6 +194,79 @@ func calculateFullHash(reader io.Reader, algorithm HashAlgorithm) (string, error
 	}
 }
 
+// copyForHash feeds src into dst in reads of opts.BufferSize.
+func copyForHash(dst io.Writer, src io.Reader, opts ReadOptions) error {
+	size := opts.BufferSize
+	if size <= 0 {
+		size = DefaultReadBufferSize
+	}
+	if opts.ReadAhead {
+		return copyReadAhead(dst, src, size)
+	}
+	// Hide WriterTo/ReaderFrom so io.CopyBuffer really uses buf: *os.File's
+	// WriteTo falls back to io.Copy's 32 KB buffer.
+	_, err := io.CopyBuffer(struct{ io.Writer }{dst}, struct{ io.Reader }{src}, make([]byte, size))
+	return err
+}
+
+// copyReadAhead double-buffers the copy: one buffer is filled from src while
+// the other is written to dst. It returns only after the reader goroutine
+// has stopped, so src is never read after the hash finishes.
+func copyReadAhead(dst io.Writer, src io.Reader, size int) error {
+	type chunk struct {
+		buf []byte
+		n   int
+		err error
+	}
+	free := make(chan []byte, 2)
+	free <- make([]byte, size)
+	free <- make([]byte, size)
+	filled := make(chan chunk, 2)
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		for {
+			var buf []byte
+			select {
+			case buf = <-free:
+			case <-done:
+				return
+			}
+			n, err := io.ReadFull(src, buf)
+			select {
+			case filled <- chunk{buf: buf, n: n, err: err}:
+			case <-done:
+				return
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+	defer func() {
+		close(done)
+		<-stopped
+	}()
+
+	for {
+		c := <-filled
+		if c.n > 0 {
+			if _, err := dst.Write(c.buf[:c.n]); err != nil {
+				return err
+			}
+		}
+		switch c.err {
+		case nil:
+			free <- c.buf
+		case io.EOF, io.ErrUnexpectedEOF:
+			return nil
+		default:
+			return c.err
+		}
+	}
+}
+
 // calculateQuickHash calculates a quick hash for large files
 // Strategy: hash(first_chunk + last_chunk + file_size)
 // This provides fast hashing for large video/audio files while maintaining
@@ -181,11 +287,7 @@ func calculateQuickHash(file *os.File, fileSize int64, algorithm HashAlgorithm)
 	}
 
 	// Write file size as part of hash input
-	fileSizeBytes := make([]byte, 8)
-	for i := 0; i < 8; i++ {
-		fileSizeBytes[i] = byte(fileSize >> (i * 8))
-	}
-	hasher.Write(fileSizeBytes)
+	hasher.Write(fileSizeBytes(fileSize))
 
 	// Read first chunk
 	firstChunk := make([]byte, QuickHashChunkSize)
@@ -214,6 +316,15 @@ func calculateQuickHash(file *os.File, fileSize int64, algorithm HashAlgorithm)
 	return hex.EncodeToString(hasher.Sum(nil)), nil
 }
 
+// fileSizeBytes encodes the file size the way quick hashes consume it.
+func fileSizeBytes(fileSize int64) []byte {
+	encoded := make([]byte, 8)
+	for i := 0; i < 8; i++ {
+		encoded[i] = byte(fileSize >> (i * 8))
+	}
+	return encoded
+}
+
 // CalculateReaderHash calculates hash from an io.Reader
 func CalculateReaderHash(reader io.Reader, algorithm HashAlgorithm) (string, error) {
 	return calculateFullHash(reader, algorithm)
@@ -2,6 +2,7 @@ package hash
 
 import (
 	"encoding/hex"
+	"fmt"
 	"os"
 	"path/filepath"
 	"testing"
@@ -66,3 +67,66 @@ func TestCalculateLayeredBLAKE3LargeFileKeepsSeparateFingerprint(t *testing.T) {
 		t.Fatal("authoritative content hash must be distinct from the sampled fingerprint")
 	}
 }
+
+// referenceContent is 3 MB and 17 bytes of a repeating pattern, so reads of
+// every tested size end on a partial buffer.
+func referenceContent() []byte {
+	content := make([]byte, 3*1024*1024+17)
+	for i := range content {
+		content[i] = byte(i % 251)
+	}
+	return content
+}
+
+func TestFullHashMatchesReferenceAcrossReadOptions(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "reference.bin")
+	if err := os.WriteFile(path, referenceContent(), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	want := map[HashAlgorithm]string{
+		AlgorithmBLAKE3: "26003c63117013de5d02be76e5e32a2f75bfbc075f17180fd5f9f0b4752d2bfe",
+		AlgorithmSHA256: "fe2aaf82bfa2ffec207a0c6fa7ce7d4af268d67e2672fdaec675f3f9b65d0854",
+	}
+	t.Cleanup(func() { SetReadOptions(ReadOptions{}) })
+
+	for _, bufferSize := range []int{0, 4095, 32 * 1024, DefaultReadBufferSize, 8 * 1024 * 1024} {
+		for _, readAhead := range []bool{false, true} {
+			SetReadOptions(ReadOptions{BufferSize: bufferSize, ReadAhead: readAhead})
+			for algorithm, wantHash := range want {
+				result, err := CalculateFileHash(path, algorithm, false)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if result.Hash != wantHash {
+					t.Fatalf("%s with buffer %d, read-ahead %v = %s, want %s", algorithm, bufferSize, readAhead, result.Hash, wantHash)
+				}
+			}
+		}
+	}
+}
+
+func BenchmarkCalculateFileHash(b *testing.B) {
+	path := filepath.Join(b.TempDir(), "bench.bin")
+	content := make([]byte, 64*1024*1024)
+	for i := range content {
+		content[i] = byte(i % 251)
+	}
+	if err := os.WriteFile(path, content, 0o600); err != nil {
+		b.Fatal(err)
+	}
+	b.Cleanup(func() { SetReadOptions(ReadOptions{}) })
+
+	for _, bufferSize := range []int{32 * 1024, DefaultReadBufferSize, 8 * 1024 * 1024} {
+		for _, readAhead := range []bool{false, true} {
+			b.Run(fmt.Sprintf("buffer=%d/read_ahead=%v", bufferSize, readAhead), func(b *testing.B) {
+				SetReadOptions(ReadOptions{BufferSize: bufferSize, ReadAhead: readAhead})
+				b.SetBytes(int64(len(content)))
+				for i := 0; i < b.N; i++ {
+					if _, err := CalculateFileHash(path, AlgorithmBLAKE3, false); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		}
+	}
+}
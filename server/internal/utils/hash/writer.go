@@ -0,0 +1,78 @@
+package hash
+
+import (
+	"encoding/hex"
+
+	"github.com/zeebo/blake3"
+)
+
+// LayeredWriter hashes the bytes written through it and yields the same result
+// CalculateLayeredBLAKE3 would for a file with that content. Tee an upload
+// through it while staging so the hash is known without reading the file back.
+type LayeredWriter struct {
+	full *blake3.Hasher
+	size int64
+	// first holds the leading QuickHashChunkSize bytes and tail is a ring of
+	// the most recent QuickHashChunkSize bytes after them; together they feed
+	// the quick fingerprint once the size passes QuickHashThreshold.
+	first   []byte
+	tail    []byte
+	tailPos int
+}
+
+// NewLayeredWriter returns an empty LayeredWriter.
+func NewLayeredWriter() *LayeredWriter {
+	return &LayeredWriter{full: blake3.New()}
+}
+
+// Write implements io.Writer. It never returns an error.
+func (w *LayeredWriter) Write(p []byte) (int, error) {
+	n := len(p)
+	_, _ = w.full.Write(p)
+	w.size += int64(n)
+
+	if room := QuickHashChunkSize - len(w.first); room > 0 {
+		take := min(room, len(p))
+		w.first = append(w.first, p[:take]...)
+		p = p[take:]
+	}
+	if len(p) == 0 {
+		return n, nil
+	}
+	if w.tail == nil {
+		w.tail = make([]byte, QuickHashChunkSize)
+	}
+	if len(p) > len(w.tail) {
+		p = p[len(p)-len(w.tail):]
+	}
+	for len(p) > 0 {
+		copied := copy(w.tail[w.tailPos:], p)
+		w.tailPos = (w.tailPos + copied) % len(w.tail)
+		p = p[copied:]
+	}
+	return n, nil
+}
+
+// Result returns the layered hash of everything written so far.
+func (w *LayeredWriter) Result() *LayeredHashResult {
+	result := &LayeredHashResult{
+		ContentHash: hex.EncodeToString(w.full.Sum(nil)),
+		FileSize:    w.size,
+	}
+	if w.size <= QuickHashThreshold {
+		return result
+	}
+
+	// Mirrors calculateQuickHash: above the threshold the last chunk is always
+	// a full QuickHashChunkSize bytes that does not overlap the first.
+	quick := blake3.New()
+	_, _ = quick.Write(fileSizeBytes(w.size))
+	_, _ = quick.Write(w.first)
+	_, _ = quick.Write(w.tail[w.tailPos:])
+	_, _ = quick.Write(w.tail[:w.tailPos])
+	fingerprint := hex.EncodeToString(quick.Sum(nil))
+	version := QuickFingerprintVersion
+	result.QuickFingerprint = &fingerprint
+	result.QuickFingerprintVersion = &version
+	return result
+}
@@ -0,0 +1,57 @@
+package hash
+
+import (
+	"bytes"
+	"io"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// streamAndCompare writes content through a LayeredWriter in uneven pieces
+// and checks it agrees with hashing the same bytes from disk.
+func streamAndCompare(t *testing.T, content []byte) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "content.bin")
+	if err := os.WriteFile(path, content, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	want, err := CalculateLayeredBLAKE3(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	writer := NewLayeredWriter()
+	if _, err := io.CopyBuffer(writer, bytes.NewReader(content), make([]byte, 1<<20+7)); err != nil {
+		t.Fatal(err)
+	}
+	got := writer.Result()
+
+	if got.ContentHash != want.ContentHash {
+		t.Fatalf("content hash = %q, want %q", got.ContentHash, want.ContentHash)
+	}
+	if got.FileSize != want.FileSize {
+		t.Fatalf("file size = %d, want %d", got.FileSize, want.FileSize)
+	}
+	if (got.QuickFingerprint == nil) != (want.QuickFingerprint == nil) {
+		t.Fatalf("quick fingerprint presence = %v, want %v", got.QuickFingerprint != nil, want.QuickFingerprint != nil)
+	}
+	if want.QuickFingerprint != nil && *got.QuickFingerprint != *want.QuickFingerprint {
+		t.Fatalf("quick fingerprint = %q, want %q", *got.QuickFingerprint, *want.QuickFingerprint)
+	}
+}
+
+func TestLayeredWriterMatchesFileHashSmall(t *testing.T) {
+	streamAndCompare(t, []byte("streamed upload content"))
+	streamAndCompare(t, nil)
+}
+
+func TestLayeredWriterMatchesFileHashLarge(t *testing.T) {
+	content := make([]byte, QuickHashThreshold+3*QuickHashChunkSize/2)
+	// Distinct bytes at both ends so a misplaced chunk changes the fingerprint.
+	rng := rand.New(rand.NewSource(1))
+	rng.Read(content[:2*QuickHashChunkSize])
+	rng.Read(content[len(content)-2*QuickHashChunkSize:])
+	streamAndCompare(t, content)
+}
@@ -0,0 +1,96 @@
+// Package imageguard bounds image operations that run while a request waits,
+// such as exports and on-demand thumbnails. A request-time decode of a huge or
+// hostile source (a decode bomb) would otherwise tie up a handler and its
+// memory for as long as the decoder takes.
+package imageguard
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"time"
+
+	_ "golang.org/x/image/webp"
+)
+
+// ErrSourceTooLarge is returned for sources above the dimension or megapixel
+// cap. Handlers map it to 422.
+var ErrSourceTooLarge = errors.New("source image is too large to process on the fly")
+
+// ErrTimeout is returned when an operation runs past the timeout. Handlers map
+// it to 504.
+var ErrTimeout = errors.New("image operation timed out")
+
+// Limits is the guard for request-time image operations. Zero fields disable
+// their check, so the zero value lets everything through.
+type Limits struct {
+	// MaxDimension caps the longer edge of the source in pixels.
+	MaxDimension int
+	// MaxMegapixels caps the source's width times height, in millions.
+	MaxMegapixels int
+	// Timeout bounds one operation.
+	Timeout time.Duration
+}
+
+// Check rejects a source of the given size with ErrSourceTooLarge. Unknown
+// dimensions (zero or negative) pass; callers resolve them with Dimensions
+// first where they can.
+func (l Limits) Check(width, height int) error {
+	if width <= 0 || height <= 0 {
+		return nil
+	}
+	if l.MaxDimension > 0 && max(width, height) > l.MaxDimension {
+		return fmt.Errorf("%w: %dx%d exceeds %d px per edge", ErrSourceTooLarge, width, height, l.MaxDimension)
+	}
+	if l.MaxMegapixels > 0 && int64(width)*int64(height) > int64(l.MaxMegapixels)*1_000_000 {
+		return fmt.Errorf("%w: %dx%d exceeds %d megapixels", ErrSourceTooLarge, width, height, l.MaxMegapixels)
+	}
+	return nil
+}
+
+// Run checks the source size and runs op under the timeout. op receives a
+// context that is cancelled at the deadline, but decoders such as libvips do
+// not observe it: on timeout Run returns ErrTimeout without waiting, and op
+// finishes in the background with its result discarded.
+func (l Limits) Run(ctx context.Context, width, height int, op func(ctx context.Context) error) error {
+	if err := l.Check(width, height); err != nil {
+		return err
+	}
+	if l.Timeout <= 0 {
+		return op(ctx)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, l.Timeout)
+	defer cancel()
+	done := make(chan error, 1)
+	go func() { done <- op(ctx) }()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return fmt.Errorf("%w after %s", ErrTimeout, l.Timeout)
+		}
+		return ctx.Err()
+	}
+}
+
+// Dimensions reads the pixel size from the header of an encoded image without
+// decoding it. JPEG, PNG, GIF and WebP are recognised; for other formats
+// (HEIC, TIFF, AVIF) the recorded size of the asset is returned, or zeros
+// when that is unknown too.
+func Dimensions(data []byte, recordedWidth, recordedHeight *int32) (width, height int) {
+	if cfg, _, err := image.DecodeConfig(bytes.NewReader(data)); err == nil && cfg.Width > 0 && cfg.Height > 0 {
+		return cfg.Width, cfg.Height
+	}
+	if recordedWidth != nil && recordedHeight != nil {
+		return int(*recordedWidth), int(*recordedHeight)
+	}
+	return 0, 0
+}
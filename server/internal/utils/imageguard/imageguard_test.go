@@ -0,0 +1,85 @@
+package imageguard
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/png"
+	"testing"
+	"time"
+)
+
+func TestCheckRejectsOversizedSources(t *testing.T) {
+	limits := Limits{MaxDimension: 10000, MaxMegapixels: 50}
+
+	for _, tc := range []struct {
+		width, height int
+		wantErr       bool
+	}{
+		{6000, 4000, false},  // 24 MP
+		{10000, 5000, false}, // at both caps
+		{10001, 100, true},   // long edge
+		{9000, 9000, true},   // 81 MP
+		{0, 0, false},        // unknown
+	} {
+		err := limits.Check(tc.width, tc.height)
+		if got := errors.Is(err, ErrSourceTooLarge); got != tc.wantErr {
+			t.Errorf("Check(%d, %d) = %v, want too large %v", tc.width, tc.height, err, tc.wantErr)
+		}
+	}
+
+	if err := (Limits{}).Check(100000, 100000); err != nil {
+		t.Fatalf("zero limits rejected a source: %v", err)
+	}
+}
+
+func TestRunTimesOut(t *testing.T) {
+	limits := Limits{Timeout: 20 * time.Millisecond}
+	release := make(chan struct{})
+	defer close(release)
+
+	err := limits.Run(context.Background(), 100, 100, func(context.Context) error {
+		<-release // a decoder that ignores its context
+		return nil
+	})
+	if !errors.Is(err, ErrTimeout) {
+		t.Fatalf("Run = %v, want ErrTimeout", err)
+	}
+}
+
+func TestRunReturnsOperationResult(t *testing.T) {
+	limits := Limits{MaxDimension: 1000, Timeout: time.Second}
+	boom := errors.New("boom")
+
+	if err := limits.Run(context.Background(), 100, 100, func(context.Context) error { return boom }); !errors.Is(err, boom) {
+		t.Fatalf("Run = %v, want the operation's error", err)
+	}
+
+	called := false
+	err := limits.Run(context.Background(), 2000, 100, func(context.Context) error {
+		called = true
+		return nil
+	})
+	if !errors.Is(err, ErrSourceTooLarge) || called {
+		t.Fatalf("Run = %v (called %v), want ErrSourceTooLarge before the operation", err, called)
+	}
+}
+
+func TestDimensionsReadsHeaderOrFallsBack(t *testing.T) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewGray(image.Rect(0, 0, 40, 30))); err != nil {
+		t.Fatalf("encode png: %v", err)
+	}
+	recordedWidth, recordedHeight := int32(4000), int32(3000)
+
+	if w, h := Dimensions(buf.Bytes(), &recordedWidth, &recordedHeight); w != 40 || h != 30 {
+		t.Fatalf("Dimensions(png) = %dx%d, want the header's 40x30", w, h)
+	}
+	if w, h := Dimensions([]byte("not an image"), &recordedWidth, &recordedHeight); w != 4000 || h != 3000 {
+		t.Fatalf("Dimensions(unknown) = %dx%d, want the recorded 4000x3000", w, h)
+	}
+	if w, h := Dimensions([]byte("not an image"), nil, nil); w != 0 || h != 0 {
+		t.Fatalf("Dimensions(unknown, unrecorded) = %dx%d, want 0x0", w, h)
+	}
+}
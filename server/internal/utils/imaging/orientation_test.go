@@ -0,0 +1,100 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"testing"
+
+	"github.com/davidbyttow/govips/v2/vips"
+)
+
+// markedJPEG renders a 64x32 blue JPEG whose stored top-left 16x16 block is
+// red, tagged with the given EXIF Orientation.
+func markedJPEG(t *testing.T, orientation uint16) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, 64, 32))
+	for y := 0; y < 32; y++ {
+		for x := 0; x < 64; x++ {
+			c := color.RGBA{B: 255, A: 255}
+			if x < 16 && y < 16 {
+				c = color.RGBA{R: 255, A: 255}
+			}
+			img.Set(x, y, c)
+		}
+	}
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: 95}); err != nil {
+		t.Fatalf("encode marked jpeg: %v", err)
+	}
+
+	// APP1 "Exif" segment: big-endian TIFF header and an IFD0 holding only
+	// Orientation (0x0112, SHORT).
+	var tiff bytes.Buffer
+	tiff.WriteString("MM\x00\x2a")
+	_ = binary.Write(&tiff, binary.BigEndian, uint32(8))
+	_ = binary.Write(&tiff, binary.BigEndian, uint16(1))
+	_ = binary.Write(&tiff, binary.BigEndian, []uint16{0x0112, 3})
+	_ = binary.Write(&tiff, binary.BigEndian, uint32(1))
+	_ = binary.Write(&tiff, binary.BigEndian, []uint16{orientation, 0})
+	_ = binary.Write(&tiff, binary.BigEndian, uint32(0))
+	payload := append([]byte("Exif\x00\x00"), tiff.Bytes()...)
+
+	src := buf.Bytes()
+	out := append([]byte{}, src[:2]...) // SOI
+	out = append(out, 0xff, 0xe1)
+	out = binary.BigEndian.AppendUint16(out, uint16(len(payload)+2))
+	out = append(out, payload...)
+	return append(out, src[2:]...)
+}
+
+func isRed(t *testing.T, img *vips.ImageRef, x, y int) bool {
+	t.Helper()
+	p, err := img.GetPoint(x, y)
+	if err != nil {
+		t.Fatalf("GetPoint(%d, %d): %v", x, y, err)
+	}
+	return p[0] > 180 && p[2] < 90
+}
+
+// TestStreamThumbnails_AppliesAllEightOrientations checks that the stored
+// top-left marker lands where each EXIF Orientation displays it, and that
+// the quarter-turn orientations swap the thumbnail dimensions.
+func TestStreamThumbnails_AppliesAllEightOrientations(t *testing.T) {
+	StartVips()
+
+	// Displayed corner of the stored top-left pixel: 0 top-left, 1 top-right,
+	// 2 bottom-right, 3 bottom-left.
+	wantCorner := map[uint16]int{1: 0, 2: 1, 3: 2, 4: 3, 5: 0, 6: 1, 7: 2, 8: 3}
+	for orientation := uint16(1); orientation <= 8; orientation++ {
+		out, err := runStreamThumbnails(markedJPEG(t, orientation), map[string][2]int{"small": {400, 400}})
+		if err != nil {
+			t.Fatalf("orientation %d: StreamThumbnails: %v", orientation, err)
+		}
+		thumb, err := vips.NewImageFromBuffer(out["small"])
+		if err != nil {
+			t.Fatalf("orientation %d: decode thumbnail: %v", orientation, err)
+		}
+
+		w, h := thumb.Width(), thumb.Height()
+		wantW, wantH := 64, 32
+		if orientation >= 5 {
+			wantW, wantH = 32, 64
+		}
+		if w != wantW || h != wantH {
+			thumb.Close()
+			t.Fatalf("orientation %d: thumbnail is %dx%d, want %dx%d", orientation, w, h, wantW, wantH)
+		}
+
+		corners := [4][2]int{{4, 4}, {w - 5, 4}, {w - 5, h - 5}, {4, h - 5}}
+		for i, corner := range corners {
+			if got, want := isRed(t, thumb, corner[0], corner[1]), i == wantCorner[orientation]; got != want {
+				thumb.Close()
+				t.Fatalf("orientation %d: corner %d red = %v, want %v", orientation, i, got, want)
+			}
+		}
+		thumb.Close()
+	}
+}
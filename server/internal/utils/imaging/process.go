@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"image"
 	"io"
+	"sort"
 	"strings"
 
 	"github.com/davidbyttow/govips/v2/vips"
@@ -209,6 +210,11 @@ func processImageRefFromBytes(buf []byte, opts ProcessOptions) (*vips.ImageRef,
 // scale.
 //
 // EXIF orientation is auto-applied only for JPEG and TIFF sources.
+//
+// Sizes are never upscaled: the smallest box is always produced, but a larger
+// box the source cannot fill is skipped along with every box above it, and
+// its writer is left untouched. Callers detect skipped sizes by their empty
+// output.
 func StreamThumbnails(
 	r io.Reader,
 	sizes map[string][2]int,
@@ -224,7 +230,8 @@ func StreamThumbnails(
 
 	params := thumbnailImportParams(shouldAutoRotate(srcBuf))
 
-	for name, dim := range sizes {
+	for i, name := range thumbnailSizeOrder(sizes) {
+		dim := sizes[name]
 		out, ok := outputs[name]
 		if !ok {
 			return fmt.Errorf("missing writer for size %q", name)
@@ -240,6 +247,12 @@ func StreamThumbnails(
 		if err != nil {
 			return fmt.Errorf("[%s] thumbnail load: %w", name, err)
 		}
+		// SizeDown returns the source unscaled when it fits inside the box,
+		// so an undersized result means the source is smaller than this box.
+		if i > 0 && !fillsThumbnailBox(thumb.Width(), thumb.Height(), dim) {
+			thumb.Close()
+			return nil
+		}
 
 		encoded, encErr := encode(thumb, ProcessOptions{
 			Format:        vips.ImageTypeWEBP,
@@ -258,6 +271,29 @@ func StreamThumbnails(
 	return nil
 }
 
+// thumbnailSizeOrder returns size names from the smallest bounding box to the
+// largest, breaking ties by name so output order is deterministic.
+func thumbnailSizeOrder(sizes map[string][2]int) []string {
+	names := make([]string, 0, len(sizes))
+	for name := range sizes {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		a, b := sizes[names[i]], sizes[names[j]]
+		if areaA, areaB := a[0]*a[1], b[0]*b[1]; areaA != areaB {
+			return areaA < areaB
+		}
+		return names[i] < names[j]
+	})
+	return names
+}
+
+// fillsThumbnailBox reports whether an image of width x height reaches the
+// box on at least one axis, i.e. fitting it to the box would not enlarge it.
+func fillsThumbnailBox(width, height int, box [2]int) bool {
+	return width >= box[0] || height >= box[1]
+}
+
 // encode writes the in-memory ImageRef to bytes in the requested format. Metadata
 // and ICC profiles are stripped according to opts to keep thumbnail output
 // browser-friendly and small.
@@ -54,7 +54,7 @@ func runStreamThumbnails(src []byte, sizes map[string][2]int) (map[string][]byte
 func TestStreamThumbnails_BasicOutput(t *testing.T) {
 	StartVips()
 
-	src := synthJPEG(t, 1024, 768)
+	src := synthJPEG(t, 2048, 1536)
 	sizes := map[string][2]int{
 		"small":  {400, 400},
 		"medium": {800, 800},
@@ -128,3 +128,63 @@ func TestStreamThumbnails_Concurrent(t *testing.T) {
 		t.Fatalf("concurrent run produced divergent output: %v", err)
 	}
 }
+
+func TestStreamThumbnails_NeverUpscales(t *testing.T) {
+	StartVips()
+
+	sizes := map[string][2]int{
+		"small":  {400, 400},
+		"medium": {800, 800},
+		"large":  {1920, 1920},
+	}
+
+	out, err := runStreamThumbnails(synthJPEG(t, 300, 200), sizes)
+	if err != nil {
+		t.Fatalf("StreamThumbnails: %v", err)
+	}
+	if len(out["small"]) == 0 {
+		t.Fatal("small: the smallest size must always be produced")
+	}
+	for _, name := range []string{"medium", "large"} {
+		if len(out[name]) != 0 {
+			t.Fatalf("%s: produced %d bytes for a 300px source", name, len(out[name]))
+		}
+	}
+
+	out, err = runStreamThumbnails(synthJPEG(t, 1024, 768), sizes)
+	if err != nil {
+		t.Fatalf("StreamThumbnails: %v", err)
+	}
+	if len(out["small"]) == 0 || len(out["medium"]) == 0 || len(out["large"]) != 0 {
+		t.Fatalf("1024px source: got small=%d medium=%d large=%d bytes", len(out["small"]), len(out["medium"]), len(out["large"]))
+	}
+}
+
+func TestThumbnailSizeOrder(t *testing.T) {
+	got := thumbnailSizeOrder(map[string][2]int{
+		"large":  {1920, 1920},
+		"small":  {400, 400},
+		"medium": {800, 800},
+	})
+	want := []string{"small", "medium", "large"}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Fatalf("order = %v, want %v", got, want)
+	}
+}
+
+func TestFillsThumbnailBox(t *testing.T) {
+	box := [2]int{800, 800}
+	for _, tc := range []struct {
+		width, height int
+		want          bool
+	}{
+		{300, 200, false},
+		{799, 799, false},
+		{800, 600, true},
+		{600, 1200, true},
+	} {
+		if got := fillsThumbnailBox(tc.width, tc.height, box); got != tc.want {
+			t.Fatalf("fillsThumbnailBox(%d, %d) = %v, want %v", tc.width, tc.height, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package imaging
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/davidbyttow/govips/v2/vips"
+)
+
+// ErrTransformUnsupported is returned by TransformImageBytes for source
+// formats it cannot write back in their own format.
+var ErrTransformUnsupported = errors.New("image format cannot be transformed in place")
+
+// transformQuality is the lossy encoder quality used when a transformed image
+// has to be re-encoded; high enough that a single edit is not visibly lossy.
+const transformQuality = 95
+
+// PixelTransform rotates an image clockwise and then mirrors it.
+type PixelTransform struct {
+	Rotate         vips.Angle
+	FlipHorizontal bool
+	FlipVertical   bool
+}
+
+// TransformImageBytes applies t to the pixels of buf and re-encodes the result
+// in the source format, keeping metadata and the ICC profile. Only PNG, WebP
+// and AVIF sources are accepted; JPEG and TIFF should be reoriented through
+// their EXIF Orientation tag instead, which needs no re-encode.
+func TransformImageBytes(buf []byte, t PixelTransform) ([]byte, error) {
+	if len(buf) == 0 {
+		return nil, fmt.Errorf("empty image buffer")
+	}
+
+	img, err := vips.NewImageFromBuffer(buf)
+	if err != nil {
+		return nil, fmt.Errorf("load image: %w", err)
+	}
+	defer img.Close()
+
+	format := img.Format()
+	switch format {
+	case vips.ImageTypePNG, vips.ImageTypeWEBP, vips.ImageTypeAVIF:
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrTransformUnsupported, vips.ImageTypes[format])
+	}
+
+	if t.Rotate != vips.Angle0 {
+		if err := img.Rotate(t.Rotate); err != nil {
+			return nil, fmt.Errorf("rotate: %w", err)
+		}
+	}
+	if t.FlipHorizontal {
+		if err := img.Flip(vips.DirectionHorizontal); err != nil {
+			return nil, fmt.Errorf("flip horizontal: %w", err)
+		}
+	}
+	if t.FlipVertical {
+		if err := img.Flip(vips.DirectionVertical); err != nil {
+			return nil, fmt.Errorf("flip vertical: %w", err)
+		}
+	}
+
+	return encode(img, ProcessOptions{Format: format, Quality: transformQuality})
+}
@@ -0,0 +1,43 @@
+// Package keyedmutex serialises work per key within one process.
+package keyedmutex
+
+import "sync"
+
+// Mutex serialises work per key. Entries are dropped once no caller holds or
+// waits for them, so it does not grow with every key seen. The zero value is
+// ready to use.
+type Mutex struct {
+	mu    sync.Mutex
+	locks map[string]*entry
+}
+
+type entry struct {
+	sync.Mutex
+	refs int
+}
+
+// Lock blocks until key is free and returns the function that releases it.
+func (k *Mutex) Lock(key string) func() {
+	k.mu.Lock()
+	if k.locks == nil {
+		k.locks = make(map[string]*entry)
+	}
+	e, ok := k.locks[key]
+	if !ok {
+		e = &entry{}
+		k.locks[key] = e
+	}
+	e.refs++
+	k.mu.Unlock()
+
+	e.Lock()
+	return func() {
+		e.Unlock()
+		k.mu.Lock()
+		e.refs--
+		if e.refs == 0 {
+			delete(k.locks, key)
+		}
+		k.mu.Unlock()
+	}
+}
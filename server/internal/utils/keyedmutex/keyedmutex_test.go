@@ -0,0 +1,43 @@
+package keyedmutex
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMutexSerialisesPerKey(t *testing.T) {
+	var locks Mutex
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	active, maxActive := 0, 0
+
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			unlock := locks.Lock("asset/medium")
+			defer unlock()
+
+			mu.Lock()
+			active++
+			maxActive = max(maxActive, active)
+			mu.Unlock()
+
+			mu.Lock()
+			active--
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	require.Equal(t, 1, maxActive)
+	require.Empty(t, locks.locks, "released keys are dropped")
+
+	// Different keys do not wait for each other.
+	unlockA := locks.Lock("a/small")
+	unlockB := locks.Lock("b/small")
+	unlockB()
+	unlockA()
+}
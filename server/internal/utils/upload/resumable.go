@@ -0,0 +1,279 @@
+package upload
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"server/internal/storage"
+)
+
+var (
+	// ErrResumableNotFound is returned for an unknown upload, or one whose
+	// staging file has been cleaned up.
+	ErrResumableNotFound = errors.New("resumable upload not found")
+	// ErrOffsetMismatch is returned when a chunk does not start at the end of
+	// what has been received so far.
+	ErrOffsetMismatch = errors.New("upload offset does not match received bytes")
+	// ErrUploadTooLarge is returned when a chunk would grow the file past its
+	// declared size.
+	ErrUploadTooLarge = errors.New("chunk exceeds declared upload size")
+	// ErrUploadBusy is returned when another chunk for the same upload is
+	// still being written.
+	ErrUploadBusy = errors.New("another chunk is being written to this upload")
+	// ErrUploadIncomplete is returned when completing an upload that has not
+	// received all of its bytes.
+	ErrUploadIncomplete = errors.New("upload has not received all bytes")
+	// ErrUploadCompleted is returned when appending to an upload that was
+	// already completed.
+	ErrUploadCompleted = errors.New("upload already completed")
+	// ErrUploadCompleting is returned when another request is already
+	// completing the upload.
+	ErrUploadCompleting = errors.New("upload is already being completed")
+)
+
+// ResumableUpload is an offset-based (tus-style) upload appended chunk by
+// chunk into a single staging file. The staging file's size is the offset;
+// the manifest only records what the file is for, so a restart loses nothing
+// but the bytes of a chunk that was cut off mid-write.
+type ResumableUpload struct {
+	ID             string    `json:"id"`
+	Filename       string    `json:"filename"`
+	ContentType    string    `json:"content_type"`
+	Size           int64     `json:"size"`
+	StagingPath    string    `json:"staging_path"`
+	RepositoryPath string    `json:"repository_path"`
+	UserID         string    `json:"user_id"`
+	CreatedAt      time.Time `json:"created_at"`
+	TaskID         *int64    `json:"task_id,omitempty"`
+}
+
+// Offset returns how many bytes the upload has received.
+func (u *ResumableUpload) Offset() (int64, error) {
+	info, err := os.Stat(u.StagingPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, ErrResumableNotFound
+	}
+	if err != nil {
+		return 0, fmt.Errorf("stat staging file: %w", err)
+	}
+	return info.Size(), nil
+}
+
+// ResumableManager keeps resumable uploads, persisting each one as a JSON
+// manifest next to the repository's incoming staging files.
+type ResumableManager struct {
+	mu      sync.Mutex
+	uploads map[string]*ResumableUpload
+	writing map[string]*sync.Mutex
+}
+
+// NewResumableManager creates an empty manager. Uploads from before a restart
+// are reloaded from their manifests on first access.
+func NewResumableManager() *ResumableManager {
+	return &ResumableManager{
+		uploads: make(map[string]*ResumableUpload),
+		writing: make(map[string]*sync.Mutex),
+	}
+}
+
+// Create registers an upload of size bytes into the already created, empty
+// staging file at stagingPath.
+func (rm *ResumableManager) Create(repoPath, stagingPath, filename, contentType string, size int64, userID string) (*ResumableUpload, error) {
+	u := &ResumableUpload{
+		ID:             uuid.NewString(),
+		Filename:       filepath.Base(filename),
+		ContentType:    contentType,
+		Size:           size,
+		StagingPath:    stagingPath,
+		RepositoryPath: repoPath,
+		UserID:         userID,
+		CreatedAt:      time.Now(),
+	}
+	if err := persistResumable(u); err != nil {
+		return nil, fmt.Errorf("persist upload: %w", err)
+	}
+
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+	rm.uploads[u.ID] = u
+	clone := *u
+	return &clone, nil
+}
+
+// Get returns the upload with id, loading its manifest from one of repoPaths
+// when it is not in memory.
+func (rm *ResumableManager) Get(id string, repoPaths []string) (*ResumableUpload, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, ErrResumableNotFound
+	}
+
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+	u, ok := rm.uploads[id]
+	if !ok {
+		for _, repoPath := range repoPaths {
+			if loaded, err := loadResumable(repoPath, id); err == nil {
+				u = loaded
+				break
+			}
+		}
+		if u == nil {
+			return nil, ErrResumableNotFound
+		}
+		rm.uploads[id] = u
+	}
+	clone := *u
+	return &clone, nil
+}
+
+// Append writes r to the end of the upload, provided offset equals the bytes
+// received so far, and returns the new offset. Bytes written before a read
+// error are kept so the client can resume from the returned offset.
+func (rm *ResumableManager) Append(u *ResumableUpload, offset int64, r io.Reader) (int64, error) {
+	lock := rm.writeLock(u.ID)
+	if !lock.TryLock() {
+		return 0, ErrUploadBusy
+	}
+	defer lock.Unlock()
+
+	if u.TaskID != nil {
+		return 0, ErrUploadCompleted
+	}
+	current, err := u.Offset()
+	if err != nil {
+		return 0, err
+	}
+	if offset != current {
+		return current, ErrOffsetMismatch
+	}
+
+	f, err := os.OpenFile(u.StagingPath, os.O_WRONLY|os.O_APPEND, 0)
+	if err != nil {
+		return current, fmt.Errorf("open staging file: %w", err)
+	}
+	remaining := u.Size - current
+	written, copyErr := io.Copy(f, io.LimitReader(r, remaining+1))
+	if written > remaining {
+		// Leave the file as it was; a partial overflow would be accepted
+		// silently on the next chunk.
+		truncErr := f.Truncate(current)
+		_ = f.Close()
+		if truncErr != nil {
+			return current, fmt.Errorf("discard oversized chunk: %w", truncErr)
+		}
+		return current, ErrUploadTooLarge
+	}
+	if err := f.Close(); err != nil && copyErr == nil {
+		copyErr = err
+	}
+	if copyErr != nil {
+		return current + written, fmt.Errorf("write chunk: %w", copyErr)
+	}
+	return current + written, nil
+}
+
+// MarkCompleted records the ingest task the finished upload was queued as, so
+// a repeated complete call returns the same task.
+func (rm *ResumableManager) MarkCompleted(id string, taskID int64) error {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+	u, ok := rm.uploads[id]
+	if !ok {
+		return ErrResumableNotFound
+	}
+	u.TaskID = &taskID
+	return persistResumable(u)
+}
+
+// Claim reserves completing the upload for the caller. The claim is a marker
+// file next to the manifest created with O_EXCL, so of several concurrent
+// complete calls, even from different processes, only one queues the upload;
+// the others get ErrUploadCompleting. release drops the claim and is meant for
+// a completion that failed before the upload was queued.
+func (rm *ResumableManager) Claim(u *ResumableUpload) (release func(), err error) {
+	path := resumableClaimPath(u.RepositoryPath, u.ID)
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if errors.Is(err, os.ErrExist) {
+		return nil, ErrUploadCompleting
+	}
+	if err != nil {
+		return nil, fmt.Errorf("claim upload: %w", err)
+	}
+	_ = f.Close()
+	return func() { _ = os.Remove(path) }, nil
+}
+
+// Delete forgets the upload and removes its manifest and claim. The staging file is
+// left to the caller.
+func (rm *ResumableManager) Delete(id string) {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+	if u, ok := rm.uploads[id]; ok {
+		_ = os.Remove(resumableManifestPath(u.RepositoryPath, id))
+		_ = os.Remove(resumableClaimPath(u.RepositoryPath, id))
+		delete(rm.uploads, id)
+		delete(rm.writing, id)
+	}
+}
+
+func (rm *ResumableManager) writeLock(id string) *sync.Mutex {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+	lock, ok := rm.writing[id]
+	if !ok {
+		lock = &sync.Mutex{}
+		rm.writing[id] = lock
+	}
+	return lock
+}
+
+func resumableManifestPath(repoPath, id string) string {
+	return filepath.Join(repoPath, storage.DefaultStructure.IncomingDir, "resumable_uploads", id+".json")
+}
+
+func resumableClaimPath(repoPath, id string) string {
+	return filepath.Join(repoPath, storage.DefaultStructure.IncomingDir, "resumable_uploads", id+".completing")
+}
+
+func persistResumable(u *ResumableUpload) error {
+	path := resumableManifestPath(u.RepositoryPath, u.ID)
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	data, err := json.Marshal(u)
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+func loadResumable(repoPath, id string) (*ResumableUpload, error) {
+	data, err := os.ReadFile(resumableManifestPath(repoPath, id))
+	if err != nil {
+		return nil, err
+	}
+	var u ResumableUpload
+	if err := json.Unmarshal(data, &u); err != nil {
+		return nil, err
+	}
+	// Only trust a staging path inside the repository the manifest lives in.
+	incomingRoot := filepath.Join(repoPath, storage.DefaultStructure.IncomingDir)
+	rel, err := filepath.Rel(incomingRoot, u.StagingPath)
+	if err != nil || rel == ".." || filepath.IsAbs(rel) || len(rel) >= 3 && rel[:3] == ".."+string(filepath.Separator) {
+		return nil, fmt.Errorf("staging path outside repository")
+	}
+	u.RepositoryPath = repoPath
+	return &u, nil
+}
@@ -0,0 +1,162 @@
+package upload
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"testing/iotest"
+)
+
+func newResumableForTest(t *testing.T, size int64) (*ResumableManager, *ResumableUpload, string) {
+	t.Helper()
+	repoPath := t.TempDir()
+	stagingPath := filepath.Join(repoPath, ".lumilio", "staging", "incoming", "staged_video.mp4")
+	if err := os.MkdirAll(filepath.Dir(stagingPath), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(stagingPath, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	manager := NewResumableManager()
+	u, err := manager.Create(repoPath, stagingPath, "video.mp4", "video/mp4", size, "7")
+	if err != nil {
+		t.Fatalf("create upload: %v", err)
+	}
+	return manager, u, repoPath
+}
+
+func TestResumableAppendValidatesOffset(t *testing.T) {
+	manager, u, _ := newResumableForTest(t, 10)
+
+	offset, err := manager.Append(u, 0, strings.NewReader("hello"))
+	if err != nil || offset != 5 {
+		t.Fatalf("first chunk: offset=%d err=%v", offset, err)
+	}
+
+	offset, err = manager.Append(u, 0, strings.NewReader("again"))
+	if !errors.Is(err, ErrOffsetMismatch) || offset != 5 {
+		t.Fatalf("stale offset: offset=%d err=%v, want 5 and ErrOffsetMismatch", offset, err)
+	}
+
+	offset, err = manager.Append(u, 5, strings.NewReader("world"))
+	if err != nil || offset != 10 {
+		t.Fatalf("second chunk: offset=%d err=%v", offset, err)
+	}
+	data, err := os.ReadFile(u.StagingPath)
+	if err != nil || string(data) != "helloworld" {
+		t.Fatalf("staging file = %q (%v)", data, err)
+	}
+}
+
+func TestResumableAppendRejectsOversizedChunk(t *testing.T) {
+	manager, u, _ := newResumableForTest(t, 4)
+
+	offset, err := manager.Append(u, 0, strings.NewReader("too long"))
+	if !errors.Is(err, ErrUploadTooLarge) || offset != 0 {
+		t.Fatalf("oversized chunk: offset=%d err=%v", offset, err)
+	}
+	if current, _ := u.Offset(); current != 0 {
+		t.Fatalf("oversized chunk left %d bytes behind", current)
+	}
+}
+
+func TestResumableAppendKeepsBytesBeforeDisconnect(t *testing.T) {
+	manager, u, _ := newResumableForTest(t, 10)
+
+	cut := iotest.TimeoutReader(iotest.OneByteReader(strings.NewReader("abc")))
+	offset, err := manager.Append(u, 0, cut)
+	if err == nil || offset != 1 {
+		t.Fatalf("interrupted chunk: offset=%d err=%v, want 1 and an error", offset, err)
+	}
+
+	offset, err = manager.Append(u, 1, strings.NewReader("bcdefghij"))
+	if err != nil || offset != 10 {
+		t.Fatalf("resumed chunk: offset=%d err=%v", offset, err)
+	}
+}
+
+func TestResumableUploadSurvivesRestart(t *testing.T) {
+	manager, u, repoPath := newResumableForTest(t, 6)
+	if _, err := manager.Append(u, 0, strings.NewReader("abc")); err != nil {
+		t.Fatal(err)
+	}
+
+	restarted := NewResumableManager()
+	if _, err := restarted.Get(u.ID, nil); !errors.Is(err, ErrResumableNotFound) {
+		t.Fatalf("expected not found without repository paths, got %v", err)
+	}
+	restored, err := restarted.Get(u.ID, []string{t.TempDir(), repoPath})
+	if err != nil {
+		t.Fatalf("restore upload: %v", err)
+	}
+	if restored.Filename != "video.mp4" || restored.Size != 6 || restored.UserID != "7" {
+		t.Fatalf("unexpected restored upload: %#v", restored)
+	}
+	if offset, err := restored.Offset(); err != nil || offset != 3 {
+		t.Fatalf("restored offset=%d err=%v, want 3", offset, err)
+	}
+
+	if err := restarted.MarkCompleted(u.ID, 42); err != nil {
+		t.Fatal(err)
+	}
+	completed, err := NewResumableManager().Get(u.ID, []string{repoPath})
+	if err != nil || completed.TaskID == nil || *completed.TaskID != 42 {
+		t.Fatalf("completed upload not persisted: %#v (%v)", completed, err)
+	}
+	if _, err := restarted.Append(completed, 3, strings.NewReader("def")); !errors.Is(err, ErrUploadCompleted) {
+		t.Fatalf("append after complete: %v", err)
+	}
+}
+
+func TestResumableClaimAdmitsOneCompletion(t *testing.T) {
+	manager, u, repoPath := newResumableForTest(t, 6)
+	// A second manager stands in for another server process.
+	otherManager := NewResumableManager()
+	other, err := otherManager.Get(u.ID, []string{repoPath})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		releases []func()
+		busy     int
+	)
+	for i := 0; i < 8; i++ {
+		claimer, target := manager, u
+		if i%2 == 1 {
+			claimer, target = otherManager, other
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			release, err := claimer.Claim(target)
+			mu.Lock()
+			defer mu.Unlock()
+			switch {
+			case err == nil:
+				releases = append(releases, release)
+			case errors.Is(err, ErrUploadCompleting):
+				busy++
+			default:
+				t.Errorf("claim: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+	if len(releases) != 1 || busy != 7 {
+		t.Fatalf("claims granted=%d refused=%d, want 1 and 7", len(releases), busy)
+	}
+
+	// A completion that failed before queueing lets the upload be retried.
+	releases[0]()
+	release, err := manager.Claim(u)
+	if err != nil {
+		t.Fatalf("claim after release: %v", err)
+	}
+	release()
+}
@@ -0,0 +1,259 @@
+// Package urlfetch downloads remote files on a client's behalf without letting
+// the client reach the server's own network (SSRF).
+//
+// Destinations are checked twice: the URL (scheme, host allowlist) before any
+// request and on every redirect, and the resolved IP address when the
+// connection is made. Checking at dial time is what defeats DNS rebinding and
+// redirects to internal hosts: whatever name the client supplies, the server
+// never connects to a loopback, private, link-local or otherwise reserved
+// address.
+package urlfetch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"path"
+	"strings"
+	"syscall"
+	"time"
+)
+
+var (
+	// ErrInvalidURL reports a URL that is not an absolute http(s) URL.
+	ErrInvalidURL = errors.New("url must be an absolute http or https url")
+	// ErrHostNotAllowed reports a host missing from the configured allowlist.
+	ErrHostNotAllowed = errors.New("host is not in the url import allowlist")
+	// ErrForbiddenAddress reports a destination resolving to an internal or
+	// reserved address.
+	ErrForbiddenAddress = errors.New("destination address is not publicly routable")
+	// ErrTooLarge reports a remote file over the size limit.
+	ErrTooLarge = errors.New("remote file exceeds the size limit")
+)
+
+// maxRedirects bounds how many redirects one fetch follows.
+const maxRedirects = 5
+
+// blockedPrefixes are special-purpose ranges that netip's predicates do not
+// cover but that must never be dialed.
+var blockedPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),       // "this network"
+	netip.MustParsePrefix("100.64.0.0/10"),   // carrier-grade NAT
+	netip.MustParsePrefix("192.0.0.0/24"),    // IETF protocol assignments
+	netip.MustParsePrefix("192.0.2.0/24"),    // documentation
+	netip.MustParsePrefix("198.18.0.0/15"),   // benchmarking
+	netip.MustParsePrefix("198.51.100.0/24"), // documentation
+	netip.MustParsePrefix("203.0.113.0/24"),  // documentation
+	netip.MustParsePrefix("240.0.0.0/4"),     // reserved, incl. broadcast
+	netip.MustParsePrefix("64:ff9b::/96"),    // NAT64, embeds IPv4
+	netip.MustParsePrefix("64:ff9b:1::/48"),  // local-use NAT64
+	netip.MustParsePrefix("100::/64"),        // discard-only
+	netip.MustParsePrefix("2001::/32"),       // Teredo, embeds IPv4
+	netip.MustParsePrefix("2001:db8::/32"),   // documentation
+	netip.MustParsePrefix("2002::/16"),       // 6to4, embeds IPv4
+	netip.MustParsePrefix("fec0::/10"),       // deprecated site-local
+}
+
+// PublicAddress reports whether addr may be dialed for a URL import: a
+// globally routable unicast address.
+func PublicAddress(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if !addr.IsValid() || !addr.IsGlobalUnicast() || addr.IsPrivate() ||
+		addr.IsLoopback() || addr.IsLinkLocalUnicast() || addr.IsUnspecified() {
+		return false
+	}
+	for _, prefix := range blockedPrefixes {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
+
+// Fetcher downloads remote files for URL imports.
+type Fetcher struct {
+	maxBytes     int64
+	allowedHosts []string
+	client       *http.Client
+}
+
+// New returns a Fetcher reading at most maxBytes per file. allowedHosts
+// restricts the hosts that may be fetched from, either exactly ("example.com")
+// or with their subdomains ("*.example.com"); empty allows any public host.
+func New(maxBytes int64, allowedHosts []string) *Fetcher {
+	return newFetcher(maxBytes, allowedHosts, PublicAddress)
+}
+
+func newFetcher(maxBytes int64, allowedHosts []string, allowAddr func(netip.Addr) bool) *Fetcher {
+	f := &Fetcher{maxBytes: maxBytes}
+	for _, host := range allowedHosts {
+		if host = strings.ToLower(strings.TrimSpace(host)); host != "" {
+			f.allowedHosts = append(f.allowedHosts, host)
+		}
+	}
+
+	dialer := &net.Dialer{
+		Timeout: 10 * time.Second,
+		// Control runs after DNS resolution, once per address actually
+		// dialed, so a name cannot be re-pointed at an internal host
+		// between the check and the connection.
+		Control: func(_, address string, _ syscall.RawConn) error {
+			addrPort, err := netip.ParseAddrPort(address)
+			if err != nil {
+				return fmt.Errorf("%w: %s", ErrForbiddenAddress, address)
+			}
+			if !allowAddr(addrPort.Addr()) {
+				return fmt.Errorf("%w: %s", ErrForbiddenAddress, addrPort.Addr())
+			}
+			return nil
+		},
+	}
+	f.client = &http.Client{
+		Transport: &http.Transport{
+			// Never use an environment proxy: the dial check would then
+			// vet the proxy instead of the destination.
+			Proxy:                 nil,
+			DialContext:           dialer.DialContext,
+			TLSHandshakeTimeout:   10 * time.Second,
+			ResponseHeaderTimeout: 30 * time.Second,
+			MaxIdleConns:          4,
+			IdleConnTimeout:       30 * time.Second,
+		},
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= maxRedirects {
+				return fmt.Errorf("stopped after %d redirects", maxRedirects)
+			}
+			return f.checkURL(req.URL)
+		},
+	}
+	return f
+}
+
+// Enabled reports whether URL imports are allowed at all.
+func (f *Fetcher) Enabled() bool { return f != nil && f.maxBytes > 0 }
+
+// MaxBytes is the largest file the fetcher reads.
+func (f *Fetcher) MaxBytes() int64 { return f.maxBytes }
+
+// ParseURL validates a client-supplied URL before anything is fetched.
+func (f *Fetcher) ParseURL(raw string) (*url.URL, error) {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidURL, err)
+	}
+	if err := f.checkURL(u); err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+func (f *Fetcher) checkURL(u *url.URL) error {
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+		return ErrInvalidURL
+	}
+	if u.User != nil {
+		return fmt.Errorf("%w: credentials in the url are not supported", ErrInvalidURL)
+	}
+	host := strings.ToLower(u.Hostname())
+	// Literal addresses are rejected up front for a clear error; names are
+	// vetted when dialed.
+	if addr, err := netip.ParseAddr(host); err == nil && !PublicAddress(addr) {
+		return fmt.Errorf("%w: %s", ErrForbiddenAddress, addr)
+	}
+	if len(f.allowedHosts) == 0 {
+		return nil
+	}
+	for _, allowed := range f.allowedHosts {
+		if suffix, ok := strings.CutPrefix(allowed, "*."); ok {
+			if strings.HasSuffix(host, "."+suffix) {
+				return nil
+			}
+		} else if host == allowed {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s", ErrHostNotAllowed, host)
+}
+
+// Download is an open remote file. Body fails with ErrTooLarge once more than
+// the fetcher's size limit has been read; the caller must close it.
+type Download struct {
+	Body io.ReadCloser
+	// ContentType is the media type the server declared, without parameters.
+	ContentType string
+	// Filename comes from Content-Disposition, else the last path segment
+	// of the final URL; it may be empty.
+	Filename string
+	// Size is the declared length, -1 when unknown.
+	Size int64
+}
+
+// Open starts downloading u, which must have come from ParseURL.
+func (f *Fetcher) Open(ctx context.Context, u *url.URL) (*Download, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidURL, err)
+	}
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("remote server answered %s", resp.Status)
+	}
+	if resp.ContentLength > f.maxBytes {
+		resp.Body.Close()
+		return nil, fmt.Errorf("%w: %d bytes", ErrTooLarge, resp.ContentLength)
+	}
+
+	contentType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	filename := ""
+	if _, params, err := mime.ParseMediaType(resp.Header.Get("Content-Disposition")); err == nil {
+		filename = path.Base(strings.ReplaceAll(params["filename"], "\\", "/"))
+	}
+	if filename == "" || filename == "." || filename == "/" {
+		filename = path.Base(resp.Request.URL.Path)
+	}
+	if filename == "." || filename == "/" {
+		filename = ""
+	}
+	return &Download{
+		Body:        &limitedBody{body: resp.Body, remaining: f.maxBytes},
+		ContentType: contentType,
+		Filename:    filename,
+		Size:        resp.ContentLength,
+	}, nil
+}
+
+// limitedBody reads at most remaining bytes and reports ErrTooLarge, rather
+// than a silent EOF, when the body holds more.
+type limitedBody struct {
+	body      io.ReadCloser
+	remaining int64
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	if b.remaining <= 0 {
+		// Tell "exactly at the limit" from "over it" by reading one more byte.
+		var probe [1]byte
+		if n, _ := io.ReadFull(b.body, probe[:]); n > 0 {
+			return 0, ErrTooLarge
+		}
+		return 0, io.EOF
+	}
+	if int64(len(p)) > b.remaining {
+		p = p[:b.remaining]
+	}
+	n, err := b.body.Read(p)
+	b.remaining -= int64(n)
+	return n, err
+}
+
+func (b *limitedBody) Close() error { return b.body.Close() }
@@ -0,0 +1,3 @@
+ALTER TABLE public.system_state
+    DROP COLUMN IF EXISTS processing_paused_at,
+    DROP COLUMN IF EXISTS processing_paused;
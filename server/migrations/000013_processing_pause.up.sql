@@ -0,0 +1,7 @@
+-- Global processing pause for maintenance windows. While set, the ingest and
+-- discovery workers snooze their jobs instead of running them, so work stays
+-- queued until an operator resumes processing. Lives on system_state because
+-- it is server operational state, not a user-facing setting.
+ALTER TABLE public.system_state
+    ADD COLUMN processing_paused boolean DEFAULT false NOT NULL,
+    ADD COLUMN processing_paused_at timestamp with time zone;
@@ -0,0 +1,14 @@
+-- User-defined key/value fields per asset ("client": "Acme"). Kept out of
+-- assets.specific_metadata, which metadata extraction rewrites wholesale, so
+-- they survive a metadata refresh.
+CREATE TABLE public.asset_custom_fields (
+    asset_id uuid NOT NULL,
+    fields jsonb DEFAULT '{}'::jsonb NOT NULL,
+    updated_at timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT asset_custom_fields_pkey PRIMARY KEY (asset_id),
+    CONSTRAINT asset_custom_fields_asset_id_fkey FOREIGN KEY (asset_id) REFERENCES public.assets(asset_id) ON DELETE CASCADE,
+    CONSTRAINT asset_custom_fields_object_check CHECK ((jsonb_typeof(fields) = 'object'::text))
+);
+
+-- Serves the containment (@>) filter on custom fields.
+CREATE INDEX idx_asset_custom_fields_fields ON public.asset_custom_fields USING gin (fields jsonb_path_ops);
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS public.asset_tagging_retries;
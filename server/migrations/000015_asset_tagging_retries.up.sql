@@ -0,0 +1,12 @@
+-- Automatic re-queues of semantic (CLIP) tagging for photos that never got an
+-- embedding, e.g. because Lumen was down when they were ingested. clip_failed
+-- is set once attempts reaches the configured bound; such photos are left
+-- alone until someone reindexes them by hand.
+CREATE TABLE public.asset_tagging_retries (
+    asset_id uuid NOT NULL,
+    attempts integer DEFAULT 0 NOT NULL,
+    last_attempt_at timestamp with time zone DEFAULT now() NOT NULL,
+    clip_failed boolean DEFAULT false NOT NULL,
+    CONSTRAINT asset_tagging_retries_pkey PRIMARY KEY (asset_id),
+    CONSTRAINT asset_tagging_retries_asset_id_fkey FOREIGN KEY (asset_id) REFERENCES public.assets(asset_id) ON DELETE CASCADE
+);
@@ -0,0 +1,3 @@
+ALTER TABLE public.thumbnails
+    DROP COLUMN IF EXISTS width,
+    DROP COLUMN IF EXISTS height;
@@ -0,0 +1,5 @@
+-- Pixel size of each generated thumbnail, for responsive srcset. Rows written
+-- before this migration stay NULL until their thumbnails are regenerated.
+ALTER TABLE public.thumbnails
+    ADD COLUMN width integer,
+    ADD COLUMN height integer;
@@ -0,0 +1,13 @@
+-- Assets left behind when their repository was removed. Their repository_id
+-- is cleared so the repository row can go; this records where they came from,
+-- so serving them answers 410 Gone instead of failing to resolve a path. Only
+-- consulted while the asset has no repository, so assigning it to one again
+-- (e.g. after re-registering the folder) makes the row moot.
+CREATE TABLE public.asset_repository_removals (
+    asset_id uuid NOT NULL,
+    repository_id uuid NOT NULL,
+    repository_name text NOT NULL,
+    removed_at timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT asset_repository_removals_pkey PRIMARY KEY (asset_id),
+    CONSTRAINT asset_repository_removals_asset_id_fkey FOREIGN KEY (asset_id) REFERENCES public.assets(asset_id) ON DELETE CASCADE
+);
@@ -0,0 +1,16 @@
+-- Assets whose original on disk no longer has the size recorded at import,
+-- as found by the last size check of their repository: a cheap sign of a
+-- truncated or externally modified file that needs no re-hashing. Each check
+-- replaces the repository's rows.
+CREATE TABLE public.asset_size_mismatches (
+    asset_id uuid NOT NULL,
+    repository_id uuid NOT NULL,
+    recorded_size bigint NOT NULL,
+    actual_size bigint NOT NULL,
+    checked_at timestamp with time zone NOT NULL,
+    CONSTRAINT asset_size_mismatches_pkey PRIMARY KEY (asset_id),
+    CONSTRAINT asset_size_mismatches_asset_id_fkey FOREIGN KEY (asset_id) REFERENCES public.assets(asset_id) ON DELETE CASCADE,
+    CONSTRAINT asset_size_mismatches_repository_id_fkey FOREIGN KEY (repository_id) REFERENCES public.repositories(repo_id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_asset_size_mismatches_repository ON public.asset_size_mismatches USING btree (repository_id);
@@ -0,0 +1,16 @@
+-- Library changes in the order they happened, for the activity feed clients
+-- poll to sync incrementally. Rows outlive the assets and albums they name,
+-- so deletions stay visible; owner_id is the user whose library changed.
+CREATE TABLE public.activity_events (
+    event_id bigserial NOT NULL,
+    event_type text NOT NULL,
+    asset_id uuid,
+    album_id integer,
+    owner_id integer,
+    payload jsonb DEFAULT '{}'::jsonb NOT NULL,
+    occurred_at timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT activity_events_pkey PRIMARY KEY (event_id)
+);
+
+CREATE INDEX idx_activity_events_occurred ON public.activity_events USING btree (occurred_at, event_id);
+CREATE INDEX idx_activity_events_owner_occurred ON public.activity_events USING btree (owner_id, occurred_at, event_id);
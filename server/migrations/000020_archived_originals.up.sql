@@ -0,0 +1,11 @@
+-- Originals moved to a repository's archive location once their web
+-- derivatives were verified. The asset keeps its storage_path in the
+-- repository; archive_path is the absolute path of the archived copy, read
+-- when the original is requested and no longer in the repository.
+CREATE TABLE public.archived_originals (
+    asset_id uuid NOT NULL,
+    archive_path text NOT NULL,
+    archived_at timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT archived_originals_pkey PRIMARY KEY (asset_id),
+    CONSTRAINT archived_originals_asset_id_fkey FOREIGN KEY (asset_id) REFERENCES public.assets(asset_id) ON DELETE CASCADE
+);
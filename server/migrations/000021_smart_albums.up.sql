@@ -0,0 +1,5 @@
+-- A smart album stores an asset filter instead of members: its assets are
+-- whatever the filter matches when the album is read. NULL for ordinary
+-- albums, which keep using album_assets.
+ALTER TABLE public.albums
+    ADD COLUMN rules jsonb;
@@ -0,0 +1,2 @@
+ALTER TABLE public.share_links
+    DROP COLUMN IF EXISTS password_hash;
@@ -0,0 +1,4 @@
+-- Optional bcrypt hash of a password public viewers must present. NULL
+-- leaves the share open to anyone holding its token.
+ALTER TABLE public.share_links
+    ADD COLUMN password_hash text;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS public.upload_batch_jobs;
+DROP TABLE IF EXISTS public.upload_batches;
@@ -0,0 +1,20 @@
+-- Client-named groups of batch uploads, so an import spread over many
+-- /assets/batch requests can be cancelled as a whole. user_id is the upload
+-- caller ID as stored in ingest job args ("anonymous" without a session).
+CREATE TABLE public.upload_batches (
+    batch_id uuid NOT NULL,
+    user_id text NOT NULL,
+    status text DEFAULT 'active'::text NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    cancelled_at timestamp with time zone,
+    CONSTRAINT upload_batches_pkey PRIMARY KEY (batch_id),
+    CONSTRAINT upload_batches_status_check CHECK ((status = ANY (ARRAY['active'::text, 'cancelled'::text])))
+);
+
+-- The River ingest job each file accepted into a batch was queued as.
+CREATE TABLE public.upload_batch_jobs (
+    batch_id uuid NOT NULL,
+    job_id bigint NOT NULL,
+    CONSTRAINT upload_batch_jobs_pkey PRIMARY KEY (batch_id, job_id),
+    CONSTRAINT upload_batch_jobs_batch_id_fkey FOREIGN KEY (batch_id) REFERENCES public.upload_batches(batch_id) ON DELETE CASCADE
+);
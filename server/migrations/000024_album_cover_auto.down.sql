@@ -0,0 +1,2 @@
+ALTER TABLE public.albums
+    DROP COLUMN IF EXISTS cover_auto;
@@ -0,0 +1,22 @@
+-- Albums whose cover the server chooses: the highest-rated live member, the
+-- most recently taken among equals, re-chosen whenever membership changes.
+-- Set for albums created without a cover and by PUT /albums/{id}/cover/auto;
+-- picking a cover by hand clears it.
+ALTER TABLE public.albums
+    ADD COLUMN cover_auto boolean DEFAULT false NOT NULL;
+
+-- Manual albums without a cover showed their first asset; they now get the
+-- automatic choice.
+UPDATE public.albums al
+SET cover_auto = true,
+    cover_asset_id = (
+      SELECT aa.asset_id
+      FROM public.album_assets aa
+      JOIN public.assets a ON a.asset_id = aa.asset_id
+      WHERE aa.album_id = al.album_id
+        AND a.is_deleted = false
+      ORDER BY a.rating DESC NULLS LAST, COALESCE(a.taken_time, a.upload_time) DESC, a.asset_id DESC
+      LIMIT 1
+    )
+WHERE al.cover_asset_id IS NULL
+  AND al.rules IS NULL;